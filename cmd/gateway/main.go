@@ -40,6 +40,9 @@ import (
 var (
 	serviceAuthFlag              = "service-auth-conf"
 	confFile                     = flag.String("conf", "configs/config.yaml", "path to config file")
+	skipSparkManagerCheck        = flag.Bool("skip-spark-manager-check", false, "skip checking at startup that the SparkManager endpoint of every cluster, rendered from the hostname template, resolves and accepts connections")
+	printConfig                  = flag.Bool("print-config", false, "print the effective config, with defaults applied, and exit")
+	printConfigSchema            = flag.Bool("print-config-schema", false, "print a reference of all config keys and exit")
 	sparkManagerHostnameFlag     = "spark-manager-hostname-template"
 	sparkManagerHostnameTemplate = flag.String(sparkManagerHostnameFlag, "localhost",
 		"Defines the template for the SparkManager service name. The Gateway server uses this template to route "+
@@ -56,6 +59,14 @@ func init() {
 	}
 	flag.Parse()
 
+	if *printConfigSchema {
+		if err := cfg.PrintSchema(os.Stdout); err != nil {
+			klog.Errorf("unable to print config schema. Error: %v", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Require and validate Hostname Template
 	if *sparkManagerHostnameTemplate == "" {
 		klog.Errorf("--%s is a required flag.", sparkManagerHostnameFlag)
@@ -77,6 +88,15 @@ func init() {
 		os.Exit(1)
 	}
 
+	if *printConfig {
+		sgConfig.ConfigDefaulter()
+		if err := cfg.PrintConfig(os.Stdout, &sgConfig); err != nil {
+			klog.Errorf("unable to print config. Error: %v", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	errors := sgConfig.Validate()
 	if len(errors) > 0 {
 		klog.Errorf("'spark-gateway' config has invalid values:\n%s", strings.Join(errors, "\n"))
@@ -96,7 +116,7 @@ func main() {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	server, err := server.NewGateway(ctx, &sgConfig, *sparkManagerHostnameTemplate)
+	server, err := server.NewGateway(ctx, &sgConfig, *sparkManagerHostnameTemplate, !*skipSparkManagerCheck)
 	if err != nil {
 		klog.Errorf("unable to create gateway server. Error: %v", err)
 		os.Exit(1)
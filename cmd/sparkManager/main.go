@@ -30,8 +30,10 @@ import (
 )
 
 var (
-	confFile = flag.String("conf", "configs/config.yaml", "path to config file")
-	cluster  = flag.String("cluster", "", "Kubernetes Cluster Endpoint")
+	confFile          = flag.String("conf", "configs/config.yaml", "path to config file")
+	cluster           = flag.String("cluster", "", "Kubernetes Cluster Endpoint")
+	printConfig       = flag.Bool("print-config", false, "print the effective config, with defaults applied, and exit")
+	printConfigSchema = flag.Bool("print-config-schema", false, "print a reference of all config keys and exit")
 )
 var sgConfig cfg.SparkGatewayConfig
 
@@ -42,6 +44,14 @@ func init() {
 	}
 	flag.Parse()
 
+	if *printConfigSchema {
+		if err := cfg.PrintSchema(os.Stdout); err != nil {
+			klog.Errorf("unable to print config schema. Error: %v", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Read gateway config file and validate
 	err := cfg.ConfigUnmarshal(*confFile, &sgConfig)
 	if err != nil {
@@ -49,6 +59,15 @@ func init() {
 		os.Exit(1)
 	}
 
+	if *printConfig {
+		sgConfig.ConfigDefaulter()
+		if err := cfg.PrintConfig(os.Stdout, &sgConfig); err != nil {
+			klog.Errorf("unable to print config. Error: %v", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	errors := sgConfig.Validate()
 	if len(errors) > 0 {
 		klog.Errorf("'spark-gateway' config has invalid values:\n%s", strings.Join(errors, "\n"))
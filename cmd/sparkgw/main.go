@@ -0,0 +1,83 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/slackhq/spark-gateway/internal/cli"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+)
+
+const usage = `Usage: sparkgw <command> [flags]
+
+Commands:
+  status <gatewayId>    Print the status of a GatewayApplication
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(cli.ExitCodeError)
+	}
+
+	switch os.Args[1] {
+	case "status":
+		os.Exit(runStatus(os.Args[2:]))
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(cli.ExitCodeError)
+	}
+}
+
+func runStatus(args []string) int {
+	flags := flag.NewFlagSet("status", flag.ContinueOnError)
+	gatewayUrl := flags.String("gateway-url", os.Getenv("SPARKGW_URL"), "Gateway base URL, defaults to $SPARKGW_URL")
+	user := flags.String("user", os.Getenv("SPARKGW_USER"), "Basic auth user, defaults to $SPARKGW_USER. The password is read from $SPARKGW_PASSWORD")
+	watch := flags.BoolP("watch", "w", false, "Poll until the application terminates, exiting 0 on COMPLETED and 1 on FAILED")
+	interval := flags.Duration("interval", 5*time.Second, "Poll interval in watch mode")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: sparkgw status <gatewayId> [flags]\n\nFlags:\n%s", flags.FlagUsages())
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return cli.ExitCodeError
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return cli.ExitCodeError
+	}
+
+	if *gatewayUrl == "" {
+		fmt.Fprintln(os.Stderr, "--gateway-url or $SPARKGW_URL must be set")
+		return cli.ExitCodeError
+	}
+
+	if *interval <= 0 {
+		fmt.Fprintln(os.Stderr, "--interval must be positive")
+		return cli.ExitCodeError
+	}
+
+	ctx := util.SetupSignalHandler()
+	client := cli.NewClient(*gatewayUrl, *user, os.Getenv("SPARKGW_PASSWORD"))
+
+	return cli.RunStatus(ctx, client, flags.Arg(0), cli.StatusOptions{Watch: *watch, Interval: *interval}, os.Stdout, os.Stderr)
+}
@@ -16,8 +16,12 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	flag "github.com/spf13/pflag"
 	"k8s.io/klog/v2"
@@ -29,6 +33,22 @@ import (
 var (
 	gatewayUrlFlag     = "gateway-url"
 	helmTestGatewayUrl = flag.String(gatewayUrlFlag, "", "Service name to use for Helm tests")
+	mode               = flag.String("mode", "helm", "Tests to run: helm runs the Helm tests, load generates load against the Gateway, fixtures exercises representative SparkApplications")
+
+	loadUser      = flag.String("user", "admin", "User load and fixtures requests are sent as")
+	loadCluster   = flag.String("cluster", "", "Cluster load list requests ask for")
+	loadNamespace = flag.String("namespace", "", "Namespace load and fixtures requests submit to and list, the test application's namespace when unset")
+	submitRPS     = flag.Float64("submit-rps", 1, "Submits per second in load mode")
+	statusRPS     = flag.Float64("status-rps", 0, "Status requests per second for submitted applications in load mode")
+	listRPS       = flag.Float64("list-rps", 0, "List requests per second in load mode, requires --cluster")
+	loadDuration  = flag.Duration("duration", time.Minute, "How long load is generated for")
+	loadTimeout   = flag.Duration("timeout", 30*time.Second, "Timeout of each load and fixtures request")
+	loadCleanup   = flag.Bool("cleanup", true, "Delete the applications submitted in load mode once it ends")
+
+	fixtureNames = flag.StringSlice("fixtures", nil, "Fixtures run in fixtures mode, all of them when unset: java, python, dynamic-allocation, gpu, large-conf")
+
+	fakeSparkManagers = flag.StringSlice("fake-spark-managers", nil, "cluster=address pairs of in-memory SparkManagers to serve during the load run, for Gateways configured to use them")
+	fakeLatency       = flag.Duration("fake-latency", 0, "Latency each fake SparkManager adds to its Kubernetes calls")
 )
 
 func init() {
@@ -47,10 +67,77 @@ func main() {
 	ctx := util.SetupSignalHandler()
 
 	if *helmTestGatewayUrl == "" {
-		klog.Errorf("%s flag must be set for helm tests", *helmTestGatewayUrl)
+		klog.Errorf("%s flag must be set for tests", gatewayUrlFlag)
 		os.Exit(1)
 	}
 
-	tests.Run(ctx, *helmTestGatewayUrl)
+	switch *mode {
+	case "helm":
+		tests.Run(ctx, *helmTestGatewayUrl)
+	case "load":
+		for _, fake := range *fakeSparkManagers {
+			cluster, addr, ok := strings.Cut(fake, "=")
+			if !ok {
+				klog.Errorf("invalid --fake-spark-managers value '%s', expected cluster=address", fake)
+				os.Exit(1)
+			}
+			server, err := tests.NewFakeSparkManager(ctx, addr, cluster, *fakeLatency)
+			if err != nil {
+				klog.Errorf("error creating fake SparkManager for cluster '%s': %v", cluster, err)
+				os.Exit(1)
+			}
+			go func() {
+				if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					klog.Errorf("fake SparkManager for cluster '%s' failed: %v", cluster, err)
+					os.Exit(1)
+				}
+			}()
+			defer server.Close()
+			klog.Infof("Fake SparkManager for cluster '%s' listening on %s", cluster, addr)
+		}
+
+		report, err := tests.RunLoad(ctx, tests.LoadConfig{
+			GatewayURL: *helmTestGatewayUrl,
+			User:       *loadUser,
+			Cluster:    *loadCluster,
+			Namespace:  *loadNamespace,
+			SubmitRPS:  *submitRPS,
+			StatusRPS:  *statusRPS,
+			ListRPS:    *listRPS,
+			Duration:   *loadDuration,
+			Timeout:    *loadTimeout,
+			Cleanup:    *loadCleanup,
+		})
+		if err != nil {
+			klog.Error(err)
+			os.Exit(1)
+		}
+		if err := report.Write(os.Stdout); err != nil {
+			klog.Error(err)
+			os.Exit(1)
+		}
+	case "fixtures":
+		report, err := tests.RunFixtures(ctx, tests.FixturesConfig{
+			GatewayURL: *helmTestGatewayUrl,
+			User:       *loadUser,
+			Namespace:  *loadNamespace,
+			Fixtures:   *fixtureNames,
+			Timeout:    *loadTimeout,
+		})
+		if err != nil {
+			klog.Error(err)
+			os.Exit(1)
+		}
+		if err := report.Write(os.Stdout); err != nil {
+			klog.Error(err)
+			os.Exit(1)
+		}
+		if !report.Passed() {
+			os.Exit(1)
+		}
+	default:
+		klog.Errorf("invalid --mode '%s', valid modes: helm, load, fixtures", *mode)
+		os.Exit(1)
+	}
 
 }
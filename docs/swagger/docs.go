@@ -19,14 +19,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/batches": {
-            "get": {
+        "/admin/applications/{gatewayId}/migrate": {
+            "post": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Lists Livy batches with optional pagination.",
+                "description": "Deletes the GatewayApplication from its cluster and resubmits its spec to the requested cluster under a new GatewayId. The resubmitted application is annotated with 'spark-gateway/migrated-from' and 'spark-gateway/original-gateway-id'. The application is only deleted once its pods render in the requested cluster. If the resubmission still fails and the database is enabled, its spec is recorded as a failed asynchronous submission named in the error.",
                 "consumes": [
                     "application/json"
                 ],
@@ -34,39 +34,72 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Livy"
+                    "Admin"
                 ],
-                "summary": "List Livy batches",
+                "summary": "Migrate a GatewayApplication to another cluster",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Starting index for pagination (default: 0)",
-                        "name": "from",
-                        "in": "query"
+                        "type": "string",
+                        "description": "GatewayId of the application to migrate",
+                        "name": "gatewayId",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Number of batches to return (default: 0 for all)",
-                        "name": "size",
-                        "in": "query"
+                        "description": "Cluster to migrate to",
+                        "name": "migration",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.MigrationRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "List of Livy batches",
+                    "201": {
+                        "description": "Resubmitted GatewayApplication",
                         "schema": {
-                            "$ref": "#/definitions/domain.LivyListBatchesResponse"
+                            "$ref": "#/definitions/domain.GatewayApplication"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid target cluster",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User is not an admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "GatewayApplication not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/clusters/{cluster}/namespaces": {
             "post": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Submits a new Livy batch request. Proxy user can be specified via request body proxyUser field or defaults to authenticated user.",
+                "description": "Registers a namespace with a cluster at runtime so GatewayApplications can be submitted to it. With provision, the cluster's SparkManager creates the namespace, a ServiceAccount (default 'spark') and the Role Spark drivers need before it becomes routable. With the database enabled the registration is persisted and picked up by every Gateway instance within gateway.routingWeights.refreshInterval, otherwise it is held in memory by the receiving instance.",
                 "consumes": [
                     "application/json"
                 ],
@@ -74,44 +107,72 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Livy"
+                    "Admin"
                 ],
-                "summary": "Create a new Livy batch",
+                "summary": "Register a namespace",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Kubernetes namespace for the batch",
-                        "name": "X-Spark-Gateway-Livy-Namespace",
-                        "in": "header"
+                        "description": "Cluster name",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "description": "Livy batch request",
-                        "name": "LivyCreateBatchRequest",
+                        "description": "Namespace to register",
+                        "name": "registration",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.LivyCreateBatchRequest"
+                            "$ref": "#/definitions/domain.NamespaceRegistration"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created Livy batch",
+                        "description": "Registered namespace",
                         "schema": {
-                            "$ref": "#/definitions/domain.LivyBatch"
+                            "$ref": "#/definitions/domain.RegisteredNamespace"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not an admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Namespace already registered",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Namespace would make the cluster configuration invalid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/batches/{batchId}": {
-            "get": {
+        "/admin/clusters/{cluster}/namespaces/{namespace}/migrate": {
+            "post": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Retrieves a Livy batch by ID.",
+                "description": "Migrates every GatewayApplication in the namespace on the cluster that hasn't completed or failed to the requested cluster, for evacuating a cluster. A failed migration doesn't stop the others; the outcome of each is returned.",
                 "consumes": [
                     "application/json"
                 ],
@@ -119,34 +180,82 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Livy"
+                    "Admin"
                 ],
-                "summary": "Get a Livy batch",
+                "summary": "Migrate a namespace's GatewayApplications to another cluster",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Batch ID",
-                        "name": "batchId",
+                        "type": "string",
+                        "description": "Cluster to migrate from",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace to migrate",
+                        "name": "namespace",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Cluster to migrate to",
+                        "name": "migration",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.MigrationRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Livy batch details",
+                        "description": "Outcome of each migration",
                         "schema": {
-                            "$ref": "#/definitions/domain.LivyBatch"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.MigrationResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid target cluster",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User is not an admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Cluster or namespace not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/clusters/{cluster}/namespaces/{namespace}/weight": {
+            "put": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Deletes the specified Livy batch.",
+                "description": "Overrides the routing weight of a namespace in a cluster at runtime, shifting how much new traffic the weight based routers send to it. A weight of 0 drains the namespace of new GatewayApplications. With the database enabled the weight is persisted and picked up by every Gateway instance within gateway.routingWeights.refreshInterval, otherwise it is held in memory by the receiving instance.",
                 "consumes": [
                     "application/json"
                 ],
@@ -154,21 +263,61 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Livy"
+                    "Admin"
                 ],
-                "summary": "Delete a Livy batch",
+                "summary": "Set a namespace's routing weight",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Batch ID",
-                        "name": "batchId",
+                        "type": "string",
+                        "description": "Cluster name",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace name",
+                        "name": "namespace",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Routing weight",
+                        "name": "weight",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.NamespaceWeightRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Batch deleted: {'msg': 'deleted'}",
+                        "description": "Routing weight set",
+                        "schema": {
+                            "$ref": "#/definitions/domain.NamespaceWeight"
+                        }
+                    },
+                    "400": {
+                        "description": "Routing weight missing or negative",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User is not an admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Cluster or namespace not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -179,94 +328,109 @@ const docTemplate = `{
                 }
             }
         },
-        "/batches/{batchId}/log": {
+        "/admin/failed-submissions": {
             "get": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Retrieves the driver logs for the specified Livy batch.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lists the dead-lettered asynchronous submissions, which SparkManager rejected or which ran out of attempts, most recently failed first. Only served when gateway.asyncSubmission is enabled.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Livy"
+                    "Admin"
                 ],
-                "summary": "Get logs for a Livy batch",
+                "summary": "List failed asynchronous submissions",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Batch ID",
-                        "name": "batchId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of log lines to retrieve (default: 0 for all)",
-                        "name": "size",
+                        "type": "string",
+                        "description": "Only list the submissions to this namespace",
+                        "name": "namespace",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Livy batch logs",
+                        "description": "Failed submissions",
                         "schema": {
-                            "$ref": "#/definitions/domain.LivyLogBatchResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.FailedSubmission"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User is not an admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/batches/{batchId}/state": {
+        "/admin/failed-submissions/{gatewayId}": {
             "get": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Retrieves only the state field of the specified Livy batch.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns the dead-lettered asynchronous submission with the SparkApplication that failed to be submitted.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Livy"
+                    "Admin"
                 ],
-                "summary": "Get state of a Livy batch",
+                "summary": "Get a failed asynchronous submission",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Batch ID",
-                        "name": "batchId",
+                        "type": "string",
+                        "description": "GatewayId of the submission",
+                        "name": "gatewayId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Livy batch state",
+                        "description": "Failed submission",
                         "schema": {
-                            "$ref": "#/definitions/domain.LivyGetBatchStateResponse"
+                            "$ref": "#/definitions/domain.FailedSubmission"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not an admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "No failed submission with the GatewayId",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/v1/applications": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Lists summaries of applications in specified cluster. Optionally filter by namespace.",
+                "description": "Replaces the SparkApplication of the dead-lettered submission, e.g. the one returned by getting the submission with its spec fixed, and moves it to another cluster if 'cluster' is set. The application is validated and built for the submitting user the same way as a new submission. The submission stays failed until it is retried, under a new GatewayId if its cluster changed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -274,84 +438,208 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Applications"
+                    "Admin"
                 ],
-                "summary": "List GatewayApplicationSummary",
+                "summary": "Edit a failed asynchronous submission",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster name",
-                        "name": "cluster",
-                        "in": "query",
+                        "description": "GatewayId of the submission",
+                        "name": "gatewayId",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Namespace (optional)",
-                        "name": "namespace",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
+                        "description": "Replacement SparkApplication and cluster",
+                        "name": "edit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.FailedSubmissionEdit"
+                        }
+                    }
+                ],
+                "responses": {
                     "200": {
-                        "description": "List of GatewayApplicationSummary objects",
+                        "description": "Edited submission",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.GatewayApplicationSummary"
+                            "$ref": "#/definitions/domain.FailedSubmission"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid SparkApplication or cluster",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User is not an admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "No failed submission with the GatewayId",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid SparkApplication names",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Rejects the dead-lettered submission for good, removing it from the submission queue before its retention runs out.",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete a failed asynchronous submission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GatewayId of the submission",
+                        "name": "gatewayId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Submission deleted"
+                    },
+                    "403": {
+                        "description": "User is not an admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "No failed submission with the GatewayId",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
                 }
             }
         },
-        "/v1/applications/": {
+        "/admin/failed-submissions/{gatewayId}/retry": {
             "post": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Submits the provided GatewayApplication to the given namespace.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Queues the dead-lettered submission again with its attempts reset. It is submitted to the same cluster by the next drain of the submission queue and can be followed by its GatewayId.",
                 "tags": [
-                    "Applications"
+                    "Admin"
                 ],
-                "summary": "Submit a new GatewayApplication",
+                "summary": "Retry a failed asynchronous submission",
                 "parameters": [
                     {
-                        "description": "v1beta2.SparkApplication resource",
-                        "name": "SparkApplication",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "GatewayId of the submission",
+                        "name": "gatewayId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Submission queued again"
+                    },
+                    "403": {
+                        "description": "User is not an admin",
                         "schema": {
-                            "$ref": "#/definitions/v1beta2.SparkApplication"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "No failed submission with the GatewayId",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
+                }
+            }
+        },
+        "/admin/faults": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Lists the fault rules applied to this Gateway instance's calls to SparkManagers, by cluster. The rule of cluster ` + "`" + `*` + "`" + ` applies to clusters without a rule of their own.",
+                "produces": [
+                    "application/json"
                 ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List injected faults",
                 "responses": {
-                    "201": {
-                        "description": "GatewayApplication Created",
+                    "200": {
+                        "description": "Fault rules by cluster",
                         "schema": {
-                            "$ref": "#/definitions/domain.GatewayApplication"
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/domain.FaultRule"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User is not an admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/applications/{gatewayId}": {
-            "get": {
+        "/admin/faults/{target}": {
+            "put": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Retrieves the full GatewayApplication resource by ID.",
+                "description": "Delays this Gateway instance's calls to the cluster's SparkManager, and fails a fraction of them with an error status or a connection reset, to check retries, timeouts and circuit breakers. Only available when gateway.faultInjection is enabled.",
                 "consumes": [
                     "application/json"
                 ],
@@ -359,23 +647,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Applications"
+                    "Admin"
                 ],
-                "summary": "Get a GatewayApplication",
+                "summary": "Inject faults into calls to a cluster's SparkManager",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "GatewayApplication Name",
-                        "name": "gatewayId",
+                        "description": "Cluster whose SparkManager calls to inject faults into, or * for all clusters",
+                        "name": "target",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Faults to inject",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.FaultRule"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "GatewayApplication resource",
+                        "description": "Applied fault rule",
                         "schema": {
-                            "$ref": "#/definitions/domain.GatewayApplication"
+                            "$ref": "#/definitions/domain.FaultRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fault rule",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User is not an admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
@@ -386,29 +701,37 @@ const docTemplate = `{
                         "BasicAuth": []
                     }
                 ],
-                "description": "Deletes the specified GatewayApplication",
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Applications"
+                    "Admin"
                 ],
-                "summary": "Delete a GatewayApplication",
+                "summary": "Stop injecting faults into calls to a cluster's SparkManager",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "GatewayApplication Name",
-                        "name": "gatewayId",
+                        "description": "Cluster whose SparkManager calls to stop injecting faults into, or * for all clusters",
+                        "name": "target",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Application deleted: {'status': 'success'}",
+                    "204": {
+                        "description": "Fault rule removed"
+                    },
+                    "403": {
+                        "description": "User is not an admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "No fault rule for the target",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -419,344 +742,2948 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/applications/{gatewayId}/logs": {
+        "/admin/killswitches": {
             "get": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Retrieves the last N lines of driver logs for the specified GatewayApplication. Defaults to the last 100 lines.",
+                "description": "Lists the namespaces whose new submissions are rejected by this Gateway instance.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List engaged namespace kill switches",
+                "responses": {
+                    "200": {
+                        "description": "Engaged kill switches",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.NamespaceKillSwitch"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User is not an admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/killswitches/{namespace}": {
+            "put": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Immediately rejects new GatewayApplications submitted to the namespace in every cluster with a 403 carrying the message, for incident response. With suspendRunning, the executors of the namespace's running GatewayApplications are also scaled to zero and the outcome of each is returned; they are not scaled back up on release. With the database enabled the kill switch is persisted and picked up by every Gateway instance within gateway.killSwitches.refreshInterval, otherwise it is held in memory by the receiving instance.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Applications"
+                    "Admin"
                 ],
-                "summary": "Get driver logs of a GatewayApplication",
+                "summary": "Engage a namespace kill switch",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "GatewayApplication Name",
-                        "name": "gatewayId",
+                        "description": "Namespace to reject submissions to",
+                        "name": "namespace",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Number of log lines to retrieve (default: 100)",
-                        "name": "lines",
-                        "in": "query"
+                        "description": "Message and whether to suspend running applications",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/domain.KillSwitchRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Driver logs",
+                        "description": "Engaged kill switch",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/domain.NamespaceKillSwitch"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not an admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Namespace not configured in any cluster",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/v1/applications/{gatewayId}/status": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Retrieves only the status field of a GatewayApplication.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Accepts new GatewayApplications submitted to the namespace again.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Applications"
+                    "Admin"
                 ],
-                "summary": "Get GatewayApplication status",
+                "summary": "Release a namespace kill switch",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "GatewayApplication Name",
-                        "name": "gatewayId",
+                        "description": "Namespace to accept submissions to",
+                        "name": "namespace",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "GatewayApplication status",
+                    "204": {
+                        "description": "Kill switch released"
+                    },
+                    "403": {
+                        "description": "User is not an admin",
                         "schema": {
-                            "$ref": "#/definitions/v1beta2.SparkApplicationStatus"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
+                    },
+                    "404": {
+                        "description": "No kill switch engaged for the namespace",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/routing/simulate": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Returns the distribution of clusters the configured cluster routers would choose over count submissions to the namespace, without submitting anything, to validate routing weight changes before applying them. Simulated submissions are not queued when every cluster is at its concurrency cap; they are counted as unroutable.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Simulate cluster routing",
+                "parameters": [
+                    {
+                        "description": "Namespace and number of submissions to simulate, at most 1000",
+                        "name": "simulation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.RoutingSimulationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Clusters chosen",
+                        "schema": {
+                            "$ref": "#/definitions/domain.RoutingSimulation"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing namespace or count out of range",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User is not an admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Namespace not registered in any cluster",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/stuck-applications": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Lists the GatewayApplications found SUBMITTED or PENDING_RERUN for longer than gateway.stuckApplications.threshold by the latest detection, longest stuck first. Only served when the detector is enabled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List stuck applications",
+                "responses": {
+                    "200": {
+                        "description": "Stuck applications",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.StuckApplication"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User is not an admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/batches": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Lists Livy batches with optional pagination.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Livy"
+                ],
+                "summary": "List Livy batches",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Starting index for pagination (default: 0)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of batches to return (default: 0 for all)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of Livy batches",
+                        "schema": {
+                            "$ref": "#/definitions/domain.LivyListBatchesResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Submits a new Livy batch request. The batch is submitted as the authenticated user, a proxyUser in the request body is subject to the namespace's proxyUser policy.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Livy"
+                ],
+                "summary": "Create a new Livy batch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kubernetes namespace for the batch",
+                        "name": "X-Spark-Gateway-Livy-Namespace",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Livy batch request",
+                        "name": "LivyCreateBatchRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.LivyCreateBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created Livy batch",
+                        "schema": {
+                            "$ref": "#/definitions/domain.LivyBatch"
+                        }
+                    }
+                }
+            }
+        },
+        "/batches/{batchId}": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieves a Livy batch by ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Livy"
+                ],
+                "summary": "Get a Livy batch",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Batch ID",
+                        "name": "batchId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Livy batch details",
+                        "schema": {
+                            "$ref": "#/definitions/domain.LivyBatch"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Deletes the specified Livy batch.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Livy"
+                ],
+                "summary": "Delete a Livy batch",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Batch ID",
+                        "name": "batchId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Batch deleted: {'msg': 'deleted'}",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/batches/{batchId}/log": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieves the driver logs for the specified Livy batch.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Livy"
+                ],
+                "summary": "Get logs for a Livy batch",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Batch ID",
+                        "name": "batchId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of log lines to retrieve (default: the namespace's defaultLogLines, capped at its maxLogLines)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Livy batch logs",
+                        "schema": {
+                            "$ref": "#/definitions/domain.LivyLogBatchResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/batches/{batchId}/state": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieves only the state field of the specified Livy batch.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Livy"
+                ],
+                "summary": "Get state of a Livy batch",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Batch ID",
+                        "name": "batchId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Livy batch state",
+                        "schema": {
+                            "$ref": "#/definitions/domain.LivyGetBatchStateResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/applications": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Lists summaries of applications in specified cluster. Optionally filter by namespace.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "List GatewayApplicationSummary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster name",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace (optional)",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status projection: 'full' (default) or 'summary' for only state, timestamps and submissionID",
+                        "name": "view",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort field: 'creationTime', 'state' or 'name'",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: 'asc' (default) or 'desc'. Requires sortBy",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of GatewayApplicationSummary objects",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.GatewayApplicationSummary"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/applications/": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Submits the provided GatewayApplication to the given namespace. The body may instead be a v1 List of the SparkApplication and up to 10 small v1 ConfigMaps in its namespace, which are created with the SparkApplication as \"\u003cgatewayId\u003e-\u003cname\u003e\" and deleted with it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Submit a new GatewayApplication",
+                "parameters": [
+                    {
+                        "description": "v1beta2.SparkApplication resource",
+                        "name": "SparkApplication",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1beta2.SparkApplication"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "Ignore",
+                            "Warn",
+                            "Strict"
+                        ],
+                        "type": "string",
+                        "description": "How unknown and duplicate fields are handled: Ignore drops them, Warn returns a Warning header for each, Strict rejects the submission. Defaults to gateway.fieldValidation",
+                        "name": "fieldValidation",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue the submission and return its GatewayId before it is created in its cluster, requires gateway.asyncSubmission",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "GatewayApplication Created, with warnings if gateway.softQuota is enabled and the namespace is nearly out of ResourceQuota",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GatewayApplication"
+                        }
+                    },
+                    "202": {
+                        "description": "GatewayApplication queued with async=true, its status reports the submission",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GatewayApplication"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid SparkApplication or bundled ConfigMaps, or unknown fields with fieldValidation=Strict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "SparkApplication failed validation, with the error message and the results of each failed check",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ValidationError"
+                        }
+                    },
+                    "501": {
+                        "description": "async=true without gateway.asyncSubmission enabled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/applications/render": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Returns the driver and first executor pods the submitted GatewayApplication would run, as created by spark-submit and mutated by the Spark Operator webhook in the cluster it is routed to, without submitting it. Accepts the same body as a submission. Cluster admission, e.g. LimitRange defaults or policy webhooks, isn't applied.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Render the pods of a GatewayApplication",
+                "parameters": [
+                    {
+                        "description": "v1beta2.SparkApplication resource",
+                        "name": "SparkApplication",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1beta2.SparkApplication"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "Ignore",
+                            "Warn",
+                            "Strict"
+                        ],
+                        "type": "string",
+                        "description": "How unknown and duplicate fields are handled, as for submissions",
+                        "name": "fieldValidation",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rendered driver and executor pods",
+                        "schema": {
+                            "$ref": "#/definitions/domain.RenderedPods"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid SparkApplication",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "SparkApplication failed validation, with the error message and the results of each failed check",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ValidationError"
+                        }
+                    },
+                    "501": {
+                        "description": "The cluster's backend doesn't run SparkApplications through the Spark Operator",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/applications/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Returns the number of applications, optionally grouped by cluster, namespace and/or state. Counts are computed by each SparkManager so no application objects are returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Count GatewayApplications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster name (optional, defaults to all clusters)",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace (optional, defaults to all namespaces)",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields to group by: 'cluster', 'namespace', 'state'",
+                        "name": "groupBy",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Application counts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ApplicationCount"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/applications/watch": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Streams application changes across all clusters as newline delimited JSON, multiplexing the SparkManager watch of each cluster and namespace. Every event carries a bookmark; pass the last one received as resourceVersion to resume the stream. A stream whose resourceVersion has expired reports an ERROR event with code 410 and is dropped from later bookmarks.",
+                "produces": [
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Watch GatewayApplications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Namespace (optional, defaults to all namespaces)",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kubernetes label selector",
+                        "name": "labelSelector",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bookmark from a previous event to resume from",
+                        "name": "resourceVersion",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of watch events",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GatewayWatchEvent"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/applications/{gatewayId}": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieves the full GatewayApplication resource by ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Get a GatewayApplication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GatewayApplication Name",
+                        "name": "gatewayId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "GatewayApplication resource",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GatewayApplication"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Deletes the specified GatewayApplication",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Delete a GatewayApplication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GatewayApplication Name",
+                        "name": "gatewayId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete the application even if it wasn't created by Spark Gateway. Admins only",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Application deleted: {'status': 'success'}",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "force=true from a non-admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "GatewayApplication not found, or not created by Spark Gateway and force=true isn't set",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Merges labels into, and sets spec.timeToLiveSeconds or spec.dynamicAllocation.maxExecutors of, a GatewayApplication whose driver hasn't started running yet. Applications that are already running are rejected with 409, and maxExecutors can only be set on applications with dynamic allocation enabled. The Gateway's own spark-gateway/ labels can't be changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Update a GatewayApplication that hasn't started running",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GatewayApplication Name",
+                        "name": "gatewayId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "ApplicationUpdate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ApplicationUpdate"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Update the application even if it wasn't created by Spark Gateway. Admins only",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated GatewayApplication",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GatewayApplication"
+                        }
+                    },
+                    "403": {
+                        "description": "force=true from a non-admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "GatewayApplication not found, or not created by Spark Gateway and force=true isn't set",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/applications/{gatewayId}/logs": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieves the last N lines of driver logs for the specified GatewayApplication. Defaults to the last 100 lines. Set pod=executor and executorId to read the logs of an executor instead, the ids of its executors are listed by its pods.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Get driver or executor logs of a GatewayApplication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GatewayApplication Name",
+                        "name": "gatewayId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of log lines to retrieve (default: the namespace's defaultLogLines, capped at its maxLogLines)",
+                        "name": "lines",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Tail the logs live as plain text, starting from the last lines, until the driver exits",
+                        "name": "follow",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod to read logs from, driver or executor (default: driver)",
+                        "name": "pod",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id of the executor to read logs from, required with pod=executor",
+                        "name": "executorId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Driver or executor logs",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pod or executorId, or executor logs requested with follow=true",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "The GatewayApplication has no executor with executorId",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "501": {
+                        "description": "The cluster's backend cannot follow driver logs or read executor logs",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/applications/{gatewayId}/logs/download": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Streams the complete driver logs for the specified GatewayApplication as an attachment. Set gzip=true to compress the download.",
+                "produces": [
+                    "text/plain",
+                    "application/gzip"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Download complete driver logs of a GatewayApplication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GatewayApplication Name",
+                        "name": "gatewayId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Compress the logs with gzip (default: false)",
+                        "name": "gzip",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Driver logs",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/applications/{gatewayId}/metrics": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Returns the Prometheus metrics served by the driver of a running GatewayApplication, read through its cluster's SparkManager. The driver must expose them with spec.monitoring.exposeDriverMetrics and spec.monitoring.prometheus, or a Spark PrometheusServlet sink.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Get the Prometheus metrics of a GatewayApplication driver",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GatewayApplication Name",
+                        "name": "gatewayId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Driver metrics in the Prometheus text format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "GatewayApplication not found, or its driver doesn't expose metrics",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "GatewayApplication is not running",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "501": {
+                        "description": "The cluster's backend cannot reach driver Pods",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/applications/{gatewayId}/pods": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Lists the driver and executor pods of a GatewayApplication with their phases, the driver first and executors in order of their id. Pass an executor's id as executorId to the logs endpoint to read its logs.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "List the pods of a GatewayApplication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GatewayApplication Name",
+                        "name": "gatewayId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Driver and executor pods of the GatewayApplication",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ApplicationPods"
+                        }
+                    },
+                    "501": {
+                        "description": "The cluster's backend cannot list the pods of applications",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/applications/{gatewayId}/resubmit": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Submits the spec of an existing GatewayApplication again under a new GatewayId, as a new submission by the requesting user, e.g. to retry a failed batch job without keeping its spec. The status and the labels and annotations set by the Gateway are dropped and the resubmission is annotated with spark-gateway/resubmitted-from. The original GatewayApplication is left as is.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Resubmit a GatewayApplication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GatewayApplication Name",
+                        "name": "gatewayId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Resubmitted GatewayApplication",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GatewayApplication"
+                        }
+                    },
+                    "404": {
+                        "description": "GatewayApplication not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "SparkApplication failed validation, with the error message and the results of each failed check",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ValidationError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/applications/{gatewayId}/scale": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Sets spec.executor.instances, or spec.dynamicAllocation.maxExecutors for applications with dynamic allocation enabled, of a running GatewayApplication. Scaling up is rejected with 403 if the additional executors don't fit in the namespace's ResourceQuotas, and with 409 if the application isn't running.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Scale the executors of a running GatewayApplication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GatewayApplication Name",
+                        "name": "gatewayId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Executor count to scale to",
+                        "name": "ExecutorScale",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ExecutorScale"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Scale the application even if it wasn't created by Spark Gateway. Admins only",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Scaled GatewayApplication",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GatewayApplication"
+                        }
+                    },
+                    "403": {
+                        "description": "force=true from a non-admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "GatewayApplication not found, or not created by Spark Gateway and force=true isn't set",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/applications/{gatewayId}/status": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieves only the status field of a GatewayApplication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Get GatewayApplication status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GatewayApplication Name",
+                        "name": "gatewayId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "GatewayApplication status with derived timings",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ApplicationStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/applications/{gatewayId}/status/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Streams the status of a GatewayApplication as Server-Sent Events instead of polling. A ` + "`" + `status` + "`" + ` event carrying the same document as the status endpoint is sent with the current status and then each time the state changes. The stream ends after a terminal state is sent, or if the application is deleted.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Stream GatewayApplication status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GatewayApplication Name",
+                        "name": "gatewayId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of status events",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ApplicationStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/applications/{gatewayId}/timeline": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Returns the state transitions recorded for a GatewayApplication, such as it being submitted, running or failing with its error message, oldest first. Events are kept in the database for the eventRetention of the namespace, so they are available after the application's pods and Kubernetes events are gone.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Get the lifecycle events of a GatewayApplication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GatewayApplication Name",
+                        "name": "gatewayId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lifecycle events of the GatewayApplication",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ApplicationTimeline"
+                        }
+                    },
+                    "404": {
+                        "description": "No events recorded for the GatewayApplication",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "501": {
+                        "description": "The database is disabled, so no events are recorded",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/applications/{gatewayId}/wait": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Long-polls a compact status document for clients such as Airflow deferrable operators. Returns as soon as the state differs from the state query parameter or is terminal, otherwise returns the current status once timeout elapses. Omitting state returns immediately.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Wait for a GatewayApplication state change",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GatewayApplication Name",
+                        "name": "gatewayId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last state seen by the client",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum time to wait, e.g. 30s (default and cap: gateway.waitStatus.maxTimeout)",
+                        "name": "timeout",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "GatewayApplication wait status",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GatewayApplicationWaitStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/schedules": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Lists schedules, oldest first. Optionally filter by namespace.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "List schedules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Namespace (optional)",
+                        "name": "namespace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of schedules",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ScheduledApplication"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Creates a schedule submitting the SparkApplication as a new GatewayApplication, on behalf of the requesting user, every time the standard 5 field cron expression matches in UTC. Each run goes through routing, kill switches and validation like any submission and is annotated with spark-gateway/schedule.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Schedule a SparkApplication",
+                "parameters": [
+                    {
+                        "description": "Cron expression and SparkApplication to submit",
+                        "name": "ScheduleRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created schedule",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScheduledApplication"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/schedules/{scheduleId}": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Returns the schedule with its cron expression, SparkApplication and next run time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Get a schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule id",
+                        "name": "scheduleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedule",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScheduledApplication"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Deletes the schedule and the history of its runs. GatewayApplications it submitted are not deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Delete a schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule id",
+                        "name": "scheduleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedule deleted: {'status': 'success'}",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/schedules/{scheduleId}/pause": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Stops the schedule from submitting runs until it is resumed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Pause a schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule id",
+                        "name": "scheduleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Paused schedule",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScheduledApplication"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/schedules/{scheduleId}/resume": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Resumes the schedule from the next time its cron expression matches. Runs missed while it was paused are not submitted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Resume a paused schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule id",
+                        "name": "scheduleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Resumed schedule",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScheduledApplication"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/schedules/{scheduleId}/runs": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Returns the latest runs of the schedule, latest first, with the GatewayId submitted for each or the error that prevented it from being submitted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Get the runs of a schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule id",
+                        "name": "scheduleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Runs of the schedule",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ScheduledRun"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.ApplicationCost": {
+            "type": "object",
+            "properties": {
+                "coreHours": {
+                    "type": "number",
+                    "example": 20
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "estimate": {
+                    "type": "number",
+                    "example": 1.25
+                },
+                "final": {
+                    "type": "boolean"
+                },
+                "gbHours": {
+                    "type": "number",
+                    "example": 80
+                }
+            }
+        },
+        "domain.ApplicationCount": {
+            "type": "object",
+            "properties": {
+                "cluster": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ApplicationPods": {
+            "type": "object",
+            "properties": {
+                "gatewayId": {
+                    "type": "string"
+                },
+                "pods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SparkApplicationPod"
+                    }
+                }
+            }
+        },
+        "domain.ApplicationStatus": {
+            "type": "object",
+            "properties": {
+                "applicationState": {
+                    "description": "AppState tells the overall application state.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1beta2.ApplicationState"
+                        }
+                    ]
+                },
+                "creationTimestamp": {
+                    "type": "string"
+                },
+                "driverInfo": {
+                    "description": "DriverInfo has information about the driver.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1beta2.DriverInfo"
+                        }
+                    ]
+                },
+                "executionAttempts": {
+                    "description": "ExecutionAttempts is the total number of attempts to run a submitted application to completion.\nIncremented upon each attempted run of the application and reset upon invalidation.",
+                    "type": "integer"
+                },
+                "executorState": {
+                    "description": "ExecutorState records the state of executors by executor Pod names.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/v1beta2.ExecutorState"
+                    }
+                },
+                "gatewayState": {
+                    "description": "GatewayState is applicationState.state normalized to a GatewayState",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.GatewayState"
+                        }
+                    ]
+                },
+                "lastSubmissionAttemptTime": {
+                    "description": "LastSubmissionAttemptTime is the time for the last application submission attempt.\n+nullable",
+                    "type": "string"
+                },
+                "sparkApplicationId": {
+                    "description": "SparkApplicationID is set by the spark-distribution(via spark.app.id config) on the driver and executor pods",
+                    "type": "string"
+                },
+                "submission": {
+                    "description": "Submission is set while an asynchronous submission hasn't been created in its cluster",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.QueuedSubmission"
+                        }
+                    ]
+                },
+                "submissionAttempts": {
+                    "description": "SubmissionAttempts is the total number of attempts to submit an application to run.\nIncremented upon each attempted submission of the application and reset upon invalidation and rerun.",
+                    "type": "integer"
+                },
+                "submissionID": {
+                    "description": "SubmissionID is a unique ID of the current submission of the application.",
+                    "type": "string"
+                },
+                "terminationTime": {
+                    "description": "CompletionTime is the time when the application runs to completion if it does.\n+nullable",
+                    "type": "string"
+                },
+                "timings": {
+                    "$ref": "#/definitions/domain.ApplicationTimings"
+                }
+            }
+        },
+        "domain.ApplicationTimeline": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TimelineEvent"
+                    }
+                },
+                "gatewayId": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ApplicationTimings": {
+            "type": "object",
+            "properties": {
+                "queuedSeconds": {
+                    "description": "QueuedSeconds is the time from creation until the last submission attempt",
+                    "type": "integer"
+                },
+                "runSeconds": {
+                    "description": "RunSeconds is the time from the last submission attempt until termination",
+                    "type": "integer"
+                },
+                "totalSeconds": {
+                    "description": "TotalSeconds is the time from creation until termination",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ApplicationUpdate": {
+            "type": "object",
+            "properties": {
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "maxExecutors": {
+                    "type": "integer"
+                },
+                "timeToLiveSeconds": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ExecutorScale": {
+            "type": "object",
+            "properties": {
+                "instances": {
+                    "type": "integer"
+                },
+                "maxExecutors": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.FailedSubmission": {
+            "type": "object",
+            "properties": {
+                "application": {
+                    "description": "Application is the SparkApplication that failed to be submitted, only returned for a single submission",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1beta2.SparkApplication"
+                        }
+                    ]
+                },
+                "attempts": {
+                    "description": "Attempts is how many times submitting the application was attempted",
+                    "type": "integer"
+                },
+                "cluster": {
+                    "type": "string"
+                },
+                "failedTime": {
+                    "type": "string"
+                },
+                "gatewayId": {
+                    "type": "string"
+                },
+                "lastError": {
+                    "description": "LastError is the error of the attempt that failed the submission",
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "queuedTime": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FailedSubmissionEdit": {
+            "type": "object",
+            "properties": {
+                "application": {
+                    "$ref": "#/definitions/v1beta2.SparkApplication"
+                },
+                "cluster": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FaultRule": {
+            "type": "object",
+            "properties": {
+                "errorRate": {
+                    "description": "ErrorRate is the fraction of calls answered with ErrorStatus without being sent",
+                    "type": "number",
+                    "example": 0.1
+                },
+                "errorStatus": {
+                    "description": "ErrorStatus is the HTTP status of injected errors, 503 when unset",
+                    "type": "integer",
+                    "example": 503
+                },
+                "latency": {
+                    "description": "Latency is a Go duration added before each call",
+                    "type": "string",
+                    "example": "250ms"
+                },
+                "resetRate": {
+                    "description": "ResetRate is the fraction of calls failed with a connection reset without being sent",
+                    "type": "number",
+                    "example": 0.05
+                }
+            }
+        },
+        "domain.GatewayApplication": {
+            "type": "object",
+            "properties": {
+                "cluster": {
+                    "type": "string"
+                },
+                "cost": {
+                    "description": "Cost is only set when the cluster has cost rates and the application has been submitted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ApplicationCost"
+                        }
+                    ]
+                },
+                "gatewayId": {
+                    "type": "string"
+                },
+                "gatewayState": {
+                    "$ref": "#/definitions/domain.GatewayState"
+                },
+                "historyServer": {
+                    "description": "HistoryServer is only set on terminal GatewayApplications when gateway.historyServer is enabled",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.HistoryServerSummary"
+                        }
+                    ]
+                },
+                "sparkApplication": {
+                    "$ref": "#/definitions/domain.GatewaySparkApplication"
+                },
+                "sparkLogURLs": {
+                    "$ref": "#/definitions/domain.SparkLogURLs"
+                },
+                "user": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "description": "Warnings are only set on responses with a condition the caller should act on, like a submission admitted to a\nnamespace nearly out of quota or a status URL that failed to render",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SubmissionWarning"
+                    }
+                }
+            }
+        },
+        "domain.GatewayApplicationMeta": {
+            "type": "object",
+            "properties": {
+                "annotations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "creationTimestamp": {
+                    "description": "CreationTimestamp is copied from the SparkApplication ObjectMeta and is read-only",
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.GatewayApplicationSummary": {
+            "type": "object",
+            "properties": {
+                "apiVersion": {
+                    "description": "APIVersion defines the versioned schema of this representation of an object.\nServers should convert recognized schemas to the latest internal value, and\nmay reject unrecognized values.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources\n+optional",
+                    "type": "string"
+                },
+                "cluster": {
+                    "type": "string"
+                },
+                "cost": {
+                    "description": "Cost is only set when the cluster has cost rates and the application has been submitted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ApplicationCost"
+                        }
+                    ]
+                },
+                "gatewayId": {
+                    "type": "string"
+                },
+                "gatewayState": {
+                    "$ref": "#/definitions/domain.GatewayState"
+                },
+                "kind": {
+                    "description": "Kind is a string value representing the REST resource this object represents.\nServers may infer this from the endpoint the client submits requests to.\nCannot be updated.\nIn CamelCase.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds\n+optional",
+                    "type": "string"
+                },
+                "metadata": {
+                    "$ref": "#/definitions/domain.GatewayApplicationMeta"
+                },
+                "status": {
+                    "$ref": "#/definitions/v1beta2.SparkApplicationStatus"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.GatewayApplicationWaitStatus": {
+            "type": "object",
+            "properties": {
+                "failureMessage": {
+                    "type": "string"
+                },
+                "gatewayState": {
+                    "$ref": "#/definitions/domain.GatewayState"
+                },
+                "state": {
+                    "$ref": "#/definitions/v1beta2.ApplicationStateType"
+                },
+                "terminal": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.GatewaySparkApplication": {
+            "type": "object",
+            "properties": {
+                "apiVersion": {
+                    "description": "APIVersion defines the versioned schema of this representation of an object.\nServers should convert recognized schemas to the latest internal value, and\nmay reject unrecognized values.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources\n+optional",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "Kind is a string value representing the REST resource this object represents.\nServers may infer this from the endpoint the client submits requests to.\nCannot be updated.\nIn CamelCase.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds\n+optional",
+                    "type": "string"
+                },
+                "metadata": {
+                    "$ref": "#/definitions/domain.GatewayApplicationMeta"
+                },
+                "spec": {
+                    "$ref": "#/definitions/v1beta2.SparkApplicationSpec"
+                },
+                "status": {
+                    "$ref": "#/definitions/v1beta2.SparkApplicationStatus"
+                }
+            }
+        },
+        "domain.GatewayState": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "RUNNING",
+                "SUCCEEDED",
+                "FAILED",
+                "UNKNOWN"
+            ],
+            "x-enum-varnames": [
+                "GatewayStatePending",
+                "GatewayStateRunning",
+                "GatewayStateSucceeded",
+                "GatewayStateFailed",
+                "GatewayStateUnknown"
+            ]
+        },
+        "domain.GatewayWatchEvent": {
+            "type": "object",
+            "properties": {
+                "bookmark": {
+                    "description": "Bookmark resumes the whole stream after this event when passed back as the resourceVersion query parameter",
+                    "type": "string"
+                },
+                "cluster": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "object": {
+                    "$ref": "#/definitions/domain.GatewayApplicationSummary"
+                },
+                "type": {
+                    "$ref": "#/definitions/domain.WatchEventType"
+                }
+            }
+        },
+        "domain.HistoryServerJobCounts": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer"
+                },
+                "other": {
+                    "type": "integer"
+                },
+                "succeeded": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.HistoryServerStage": {
+            "type": "object",
+            "properties": {
+                "attemptId": {
+                    "type": "integer"
+                },
+                "durationSeconds": {
+                    "type": "number"
+                },
+                "failureReason": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "stageId": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.HistoryServerStageCounts": {
+            "type": "object",
+            "properties": {
+                "complete": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "other": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.HistoryServerSummary": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "durationSeconds": {
+                    "type": "number"
+                },
+                "endTime": {
+                    "type": "string"
+                },
+                "failedStages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.HistoryServerStage"
+                    }
+                },
+                "jobs": {
+                    "$ref": "#/definitions/domain.HistoryServerJobCounts"
+                },
+                "longestStages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.HistoryServerStage"
+                    }
+                },
+                "sparkApplicationId": {
+                    "type": "string"
+                },
+                "stages": {
+                    "$ref": "#/definitions/domain.HistoryServerStageCounts"
+                },
+                "startTime": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.KillSwitchRequest": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "suspendRunning": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.LivyBatch": {
+            "type": "object",
+            "properties": {
+                "appId": {
+                    "type": "string"
+                },
+                "appInfo": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "log": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "state": {
+                    "type": "string"
+                },
+                "ttl": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.LivyConf": {
+            "type": "object",
+            "additionalProperties": {}
+        },
+        "domain.LivyCreateBatchRequest": {
+            "type": "object",
+            "properties": {
+                "archives": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "args": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "className": {
+                    "type": "string"
+                },
+                "conf": {
+                    "$ref": "#/definitions/domain.LivyConf"
+                },
+                "driverCores": {
+                    "type": "integer"
+                },
+                "driverMemory": {
+                    "type": "string"
+                },
+                "executorCores": {
+                    "type": "integer"
+                },
+                "executorMemory": {
+                    "type": "string"
+                },
+                "file": {
+                    "type": "string"
+                },
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "jars": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "numExecutors": {
+                    "type": "integer"
+                },
+                "proxyUser": {
+                    "type": "string"
+                },
+                "pyFiles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "queue": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.LivyGetBatchStateResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.LivyListBatchesResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "integer"
+                },
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.LivyBatch"
                     }
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.GatewayApplication": {
+        },
+        "domain.LivyLogBatchResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "log": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.MigrationRequest": {
             "type": "object",
             "properties": {
                 "cluster": {
                     "type": "string"
-                },
-                "gatewayId": {
+                }
+            }
+        },
+        "domain.MigrationResult": {
+            "type": "object",
+            "properties": {
+                "cluster": {
                     "type": "string"
                 },
-                "sparkApplication": {
-                    "$ref": "#/definitions/domain.GatewaySparkApplication"
+                "error": {
+                    "type": "string"
                 },
-                "sparkLogURLs": {
-                    "$ref": "#/definitions/domain.SparkLogURLs"
+                "gatewayId": {
+                    "type": "string"
                 },
-                "user": {
+                "newGatewayId": {
                     "type": "string"
                 }
             }
         },
-        "domain.GatewayApplicationMeta": {
+        "domain.NamespaceKillSwitch": {
             "type": "object",
             "properties": {
-                "annotations": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
+                "engagedAt": {
+                    "type": "string"
                 },
-                "labels": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
+                "engagedBy": {
+                    "type": "string"
                 },
-                "name": {
+                "message": {
                     "type": "string"
                 },
                 "namespace": {
                     "type": "string"
+                },
+                "suspended": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SuspendResult"
+                    }
                 }
             }
         },
-        "domain.GatewayApplicationSummary": {
+        "domain.NamespaceRegistration": {
             "type": "object",
             "properties": {
-                "apiVersion": {
-                    "description": "APIVersion defines the versioned schema of this representation of an object.\nServers should convert recognized schemas to the latest internal value, and\nmay reject unrecognized values.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources\n+optional",
+                "id": {
+                    "type": "string"
+                },
+                "name": {
                     "type": "string"
                 },
+                "provision": {
+                    "type": "boolean"
+                },
+                "routingWeight": {
+                    "type": "number"
+                },
+                "serviceAccount": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.NamespaceWeight": {
+            "type": "object",
+            "properties": {
                 "cluster": {
                     "type": "string"
                 },
-                "gatewayId": {
+                "namespace": {
                     "type": "string"
                 },
-                "kind": {
-                    "description": "Kind is a string value representing the REST resource this object represents.\nServers may infer this from the endpoint the client submits requests to.\nCannot be updated.\nIn CamelCase.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds\n+optional",
+                "persisted": {
+                    "type": "boolean"
+                },
+                "routingWeight": {
+                    "type": "number"
+                },
+                "updatedAt": {
                     "type": "string"
                 },
-                "metadata": {
-                    "$ref": "#/definitions/domain.GatewayApplicationMeta"
+                "updatedBy": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.NamespaceWeightRequest": {
+            "type": "object",
+            "properties": {
+                "routingWeight": {
+                    "type": "number",
+                    "example": 2
+                }
+            }
+        },
+        "domain.QueuedSubmission": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "Attempts is how many times submitting the application was attempted",
+                    "type": "integer"
                 },
-                "status": {
-                    "$ref": "#/definitions/v1beta2.SparkApplicationStatus"
+                "lastError": {
+                    "description": "LastError is the error of the last failed attempt",
+                    "type": "string"
                 },
-                "user": {
+                "nextAttemptTime": {
+                    "description": "NextAttemptTime is when submitting a QUEUED application is attempted next",
+                    "type": "string"
+                },
+                "queuedTime": {
                     "type": "string"
+                },
+                "state": {
+                    "$ref": "#/definitions/domain.SubmissionState"
                 }
             }
         },
-        "domain.GatewaySparkApplication": {
+        "domain.RegisteredNamespace": {
             "type": "object",
             "properties": {
-                "apiVersion": {
-                    "description": "APIVersion defines the versioned schema of this representation of an object.\nServers should convert recognized schemas to the latest internal value, and\nmay reject unrecognized values.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources\n+optional",
+                "cluster": {
                     "type": "string"
                 },
-                "kind": {
-                    "description": "Kind is a string value representing the REST resource this object represents.\nServers may infer this from the endpoint the client submits requests to.\nCannot be updated.\nIn CamelCase.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds\n+optional",
+                "id": {
                     "type": "string"
                 },
-                "metadata": {
-                    "$ref": "#/definitions/domain.GatewayApplicationMeta"
+                "name": {
+                    "type": "string"
                 },
-                "spec": {
-                    "$ref": "#/definitions/v1beta2.SparkApplicationSpec"
+                "provisioned": {
+                    "type": "boolean"
                 },
-                "status": {
-                    "$ref": "#/definitions/v1beta2.SparkApplicationStatus"
+                "routingWeight": {
+                    "type": "number"
                 }
             }
         },
-        "domain.LivyBatch": {
+        "domain.RenderedPods": {
             "type": "object",
             "properties": {
-                "appId": {
+                "cluster": {
                     "type": "string"
                 },
-                "appInfo": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
-                },
-                "id": {
-                    "type": "integer"
+                "driver": {
+                    "$ref": "#/definitions/v1.Pod"
                 },
-                "log": {
+                "executor": {
+                    "$ref": "#/definitions/v1.Pod"
+                }
+            }
+        },
+        "domain.RoutingSimulation": {
+            "type": "object",
+            "properties": {
+                "clusters": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/domain.SimulatedClusterPick"
                     }
                 },
-                "state": {
+                "count": {
+                    "type": "integer"
+                },
+                "error": {
                     "type": "string"
                 },
-                "ttl": {
+                "namespace": {
                     "type": "string"
+                },
+                "unroutable": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.LivyConf": {
+        "domain.RoutingSimulationRequest": {
             "type": "object",
-            "additionalProperties": {}
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "namespace": {
+                    "type": "string",
+                    "example": "default"
+                }
+            }
         },
-        "domain.LivyCreateBatchRequest": {
+        "domain.ScheduleRequest": {
             "type": "object",
             "properties": {
-                "archives": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "application": {
+                    "$ref": "#/definitions/v1beta2.SparkApplication"
                 },
-                "args": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "cron": {
+                    "type": "string",
+                    "example": "0 2 * * *"
+                }
+            }
+        },
+        "domain.ScheduledApplication": {
+            "type": "object",
+            "properties": {
+                "application": {
+                    "$ref": "#/definitions/v1beta2.SparkApplication"
                 },
-                "className": {
+                "creationTime": {
+                    "type": "string"
+                },
+                "cron": {
+                    "type": "string"
+                },
+                "lastRunTime": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "nextRunTime": {
+                    "type": "string"
+                },
+                "paused": {
+                    "type": "boolean"
+                },
+                "scheduleId": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ScheduledRun": {
+            "type": "object",
+            "properties": {
+                "creationTime": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "gatewayId": {
+                    "type": "string"
+                },
+                "scheduledTime": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SimulatedClusterPick": {
+            "type": "object",
+            "properties": {
+                "cluster": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "share": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.SparkApplicationPod": {
+            "type": "object",
+            "properties": {
+                "executorId": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nodeName": {
+                    "type": "string"
+                },
+                "phase": {
+                    "$ref": "#/definitions/v1.PodPhase"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "startTime": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SparkLogURLs": {
+            "type": "object",
+            "properties": {
+                "logsUI": {
+                    "type": "string"
+                },
+                "sparkHistoryUI": {
+                    "type": "string"
+                },
+                "sparkUI": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.StuckApplication": {
+            "type": "object",
+            "properties": {
+                "cluster": {
                     "type": "string"
                 },
-                "conf": {
-                    "$ref": "#/definitions/domain.LivyConf"
+                "gatewayId": {
+                    "type": "string"
                 },
-                "driverCores": {
-                    "type": "integer"
+                "namespace": {
+                    "type": "string"
                 },
-                "driverMemory": {
+                "since": {
                     "type": "string"
                 },
-                "executorCores": {
-                    "type": "integer"
+                "state": {
+                    "$ref": "#/definitions/v1beta2.ApplicationStateType"
                 },
-                "executorMemory": {
+                "user": {
                     "type": "string"
-                },
-                "file": {
+                }
+            }
+        },
+        "domain.SubmissionState": {
+            "type": "string",
+            "enum": [
+                "QUEUED",
+                "SUBMITTED",
+                "FAILED"
+            ],
+            "x-enum-varnames": [
+                "SubmissionStateQueued",
+                "SubmissionStateSubmitted",
+                "SubmissionStateFailed"
+            ]
+        },
+        "domain.SubmissionWarning": {
+            "type": "object",
+            "properties": {
+                "cluster": {
                     "type": "string"
                 },
-                "files": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "jars": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "code": {
+                    "type": "string",
+                    "example": "SoftQuotaExceeded"
                 },
-                "name": {
+                "message": {
                     "type": "string"
                 },
-                "numExecutors": {
-                    "type": "integer"
-                },
-                "proxyUser": {
+                "namespace": {
                     "type": "string"
                 },
-                "pyFiles": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "threshold": {
+                    "type": "number",
+                    "example": 0.8
                 },
-                "queue": {
-                    "type": "string"
+                "utilization": {
+                    "type": "number",
+                    "example": 0.85
                 }
             }
         },
-        "domain.LivyGetBatchStateResponse": {
+        "domain.SuspendResult": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer"
+                "cluster": {
+                    "type": "string"
                 },
-                "state": {
+                "error": {
+                    "type": "string"
+                },
+                "gatewayId": {
                     "type": "string"
                 }
             }
         },
-        "domain.LivyListBatchesResponse": {
+        "domain.TimelineEvent": {
             "type": "object",
             "properties": {
-                "from": {
-                    "type": "integer"
+                "message": {
+                    "description": "Message is the error message of the state, if any",
+                    "type": "string"
                 },
-                "sessions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.LivyBatch"
-                    }
+                "state": {
+                    "description": "State is the state the SparkApplication transitioned to",
+                    "type": "string"
                 },
-                "total": {
-                    "type": "integer"
+                "time": {
+                    "type": "string"
                 }
             }
         },
-        "domain.LivyLogBatchResponse": {
+        "domain.ValidationError": {
             "type": "object",
             "properties": {
-                "from": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "log": {
+                "validation": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/domain.ValidationResult"
                     }
-                },
-                "size": {
-                    "type": "integer"
                 }
             }
         },
-        "domain.SparkLogURLs": {
+        "domain.ValidationResult": {
             "type": "object",
             "properties": {
-                "logsUI": {
-                    "type": "string"
+                "field": {
+                    "type": "string",
+                    "example": "spec.driver.podName"
                 },
-                "sparkHistoryUI": {
-                    "type": "string"
+                "message": {
+                    "type": "string",
+                    "example": "spec.driver.podName 'My_Driver' must consist of lowercase alphanumeric characters or '-', and start and end with an alphanumeric character, use 'my-driver' instead"
                 },
-                "sparkUI": {
-                    "type": "string"
+                "rule": {
+                    "type": "string",
+                    "example": "dnsLabel"
+                },
+                "severity": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ValidationSeverity"
+                        }
+                    ],
+                    "example": "error"
                 }
             }
         },
+        "domain.ValidationSeverity": {
+            "type": "string",
+            "enum": [
+                "error",
+                "warning"
+            ],
+            "x-enum-varnames": [
+                "ValidationSeverityError",
+                "ValidationSeverityWarning"
+            ]
+        },
+        "domain.WatchEventType": {
+            "type": "string",
+            "enum": [
+                "ADDED",
+                "MODIFIED",
+                "DELETED",
+                "BOOKMARK",
+                "ERROR"
+            ],
+            "x-enum-varnames": [
+                "WatchEventAdded",
+                "WatchEventModified",
+                "WatchEventDeleted",
+                "WatchEventBookmark",
+                "WatchEventError"
+            ]
+        },
         "intstr.IntOrString": {
             "type": "object",
             "properties": {
@@ -791,6 +3718,19 @@ const docTemplate = `{
                 "String"
             ]
         },
+        "k8s_io_api_core_v1.ConditionStatus": {
+            "type": "string",
+            "enum": [
+                "True",
+                "False",
+                "Unknown"
+            ],
+            "x-enum-varnames": [
+                "ConditionTrue",
+                "ConditionFalse",
+                "ConditionUnknown"
+            ]
+        },
         "resource.Quantity": {
             "type": "object",
             "properties": {
@@ -1391,29 +4331,221 @@ const docTemplate = `{
                     "description": "Name of the resource to which this resource resize policy applies.\nSupported values: cpu, memory.",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/v1.ResourceName"
+                            "$ref": "#/definitions/v1.ResourceName"
+                        }
+                    ]
+                },
+                "restartPolicy": {
+                    "description": "Restart policy to apply when specified resource is resized.\nIf not specified, it defaults to NotRequired.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ResourceResizeRestartPolicy"
+                        }
+                    ]
+                }
+            }
+        },
+        "v1.ContainerRestartPolicy": {
+            "type": "string",
+            "enum": [
+                "Always"
+            ],
+            "x-enum-varnames": [
+                "ContainerRestartPolicyAlways"
+            ]
+        },
+        "v1.ContainerState": {
+            "type": "object",
+            "properties": {
+                "running": {
+                    "description": "Details about a running container\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ContainerStateRunning"
+                        }
+                    ]
+                },
+                "terminated": {
+                    "description": "Details about a terminated container\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ContainerStateTerminated"
+                        }
+                    ]
+                },
+                "waiting": {
+                    "description": "Details about a waiting container\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ContainerStateWaiting"
+                        }
+                    ]
+                }
+            }
+        },
+        "v1.ContainerStateRunning": {
+            "type": "object",
+            "properties": {
+                "startedAt": {
+                    "description": "Time at which the container was last (re-)started\n+optional",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ContainerStateTerminated": {
+            "type": "object",
+            "properties": {
+                "containerID": {
+                    "description": "Container's ID in the format '\u003ctype\u003e://\u003ccontainer_id\u003e'\n+optional",
+                    "type": "string"
+                },
+                "exitCode": {
+                    "description": "Exit status from the last termination of the container",
+                    "type": "integer"
+                },
+                "finishedAt": {
+                    "description": "Time at which the container last terminated\n+optional",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Message regarding the last termination of the container\n+optional",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "(brief) reason from the last termination of the container\n+optional",
+                    "type": "string"
+                },
+                "signal": {
+                    "description": "Signal from the last termination of the container\n+optional",
+                    "type": "integer"
+                },
+                "startedAt": {
+                    "description": "Time at which previous execution of the container started\n+optional",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ContainerStateWaiting": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "Message regarding why the container is not yet running.\n+optional",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "(brief) reason the container is not yet running.\n+optional",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ContainerStatus": {
+            "type": "object",
+            "properties": {
+                "allocatedResources": {
+                    "description": "AllocatedResources represents the compute resources allocated for this container by the\nnode. Kubelet sets this value to Container.Resources.Requests upon successful pod admission\nand after successfully admitting desired pod resize.\n+featureGate=InPlacePodVerticalScalingAllocatedStatus\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ResourceList"
+                        }
+                    ]
+                },
+                "allocatedResourcesStatus": {
+                    "description": "AllocatedResourcesStatus represents the status of various resources\nallocated for this Pod.\n+featureGate=ResourceHealthStatus\n+optional\n+patchMergeKey=name\n+patchStrategy=merge\n+listType=map\n+listMapKey=name",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ResourceStatus"
+                    }
+                },
+                "containerID": {
+                    "description": "ContainerID is the ID of the container in the format '\u003ctype\u003e://\u003ccontainer_id\u003e'.\nWhere type is a container runtime identifier, returned from Version call of CRI API\n(for example \"containerd\").\n+optional",
+                    "type": "string"
+                },
+                "image": {
+                    "description": "Image is the name of container image that the container is running.\nThe container image may not match the image used in the PodSpec,\nas it may have been resolved by the runtime.\nMore info: https://kubernetes.io/docs/concepts/containers/images.",
+                    "type": "string"
+                },
+                "imageID": {
+                    "description": "ImageID is the image ID of the container's image. The image ID may not\nmatch the image ID of the image used in the PodSpec, as it may have been\nresolved by the runtime.",
+                    "type": "string"
+                },
+                "lastState": {
+                    "description": "LastTerminationState holds the last termination state of the container to\nhelp debug container crashes and restarts. This field is not\npopulated if the container is still running and RestartCount is 0.\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ContainerState"
+                        }
+                    ]
+                },
+                "name": {
+                    "description": "Name is a DNS_LABEL representing the unique name of the container.\nEach container in a pod must have a unique name across all container types.\nCannot be updated.",
+                    "type": "string"
+                },
+                "ready": {
+                    "description": "Ready specifies whether the container is currently passing its readiness check.\nThe value will change as readiness probes keep executing. If no readiness\nprobes are specified, this field defaults to true once the container is\nfully started (see Started field).\n\nThe value is typically used to determine whether a container is ready to\naccept traffic.",
+                    "type": "boolean"
+                },
+                "resources": {
+                    "description": "Resources represents the compute resource requests and limits that have been successfully\nenacted on the running container after it has been started or has been successfully resized.\n+featureGate=InPlacePodVerticalScaling\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ResourceRequirements"
+                        }
+                    ]
+                },
+                "restartCount": {
+                    "description": "RestartCount holds the number of times the container has been restarted.\nKubelet makes an effort to always increment the value, but there\nare cases when the state may be lost due to node restarts and then the value\nmay be reset to 0. The value is never negative.",
+                    "type": "integer"
+                },
+                "started": {
+                    "description": "Started indicates whether the container has finished its postStart lifecycle hook\nand passed its startup probe.\nInitialized as false, becomes true after startupProbe is considered\nsuccessful. Resets to false when the container is restarted, or if kubelet\nloses state temporarily. In both cases, startup probes will run again.\nIs always true when no startupProbe is defined and container is running and\nhas passed the postStart lifecycle hook. The null value must be treated the\nsame as false.\n+optional",
+                    "type": "boolean"
+                },
+                "state": {
+                    "description": "State holds details about the container's current condition.\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ContainerState"
+                        }
+                    ]
+                },
+                "stopSignal": {
+                    "description": "StopSignal reports the effective stop signal for this container\n+featureGate=ContainerStopSignals\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.Signal"
+                        }
+                    ]
+                },
+                "user": {
+                    "description": "User represents user identity information initially attached to the first process of the container\n+featureGate=SupplementalGroupsPolicy\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ContainerUser"
                         }
                     ]
                 },
-                "restartPolicy": {
-                    "description": "Restart policy to apply when specified resource is resized.\nIf not specified, it defaults to NotRequired.",
+                "volumeMounts": {
+                    "description": "Status of volume mounts.\n+optional\n+patchMergeKey=mountPath\n+patchStrategy=merge\n+listType=map\n+listMapKey=mountPath\n+featureGate=RecursiveReadOnlyMounts",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.VolumeMountStatus"
+                    }
+                }
+            }
+        },
+        "v1.ContainerUser": {
+            "type": "object",
+            "properties": {
+                "linux": {
+                    "description": "Linux holds user identity information initially attached to the first process of the containers in Linux.\nNote that the actual running identity can be changed if the process has enough privilege to do so.\n+optional",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/v1.ResourceResizeRestartPolicy"
+                            "$ref": "#/definitions/v1.LinuxContainerUser"
                         }
                     ]
                 }
             }
         },
-        "v1.ContainerRestartPolicy": {
-            "type": "string",
-            "enum": [
-                "Always"
-            ],
-            "x-enum-varnames": [
-                "ContainerRestartPolicyAlways"
-            ]
-        },
         "v1.DNSPolicy": {
             "type": "string",
             "enum": [
@@ -1992,6 +5124,15 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.HostIP": {
+            "type": "object",
+            "properties": {
+                "ip": {
+                    "description": "IP is the IP address assigned to the host\n+required",
+                    "type": "string"
+                }
+            }
+        },
         "v1.HostPathType": {
             "type": "string",
             "enum": [
@@ -2262,6 +5403,26 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.LinuxContainerUser": {
+            "type": "object",
+            "properties": {
+                "gid": {
+                    "description": "GID is the primary gid initially attached to the first process in the container",
+                    "type": "integer"
+                },
+                "supplementalGroups": {
+                    "description": "SupplementalGroups are the supplemental groups initially attached to the first process in the container\n+optional\n+listType=atomic",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "uid": {
+                    "description": "UID is the primary uid initially attached to the first process in the container",
+                    "type": "integer"
+                }
+            }
+        },
         "v1.LocalObjectReference": {
             "type": "object",
             "properties": {
@@ -2728,6 +5889,43 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.Pod": {
+            "type": "object",
+            "properties": {
+                "apiVersion": {
+                    "description": "APIVersion defines the versioned schema of this representation of an object.\nServers should convert recognized schemas to the latest internal value, and\nmay reject unrecognized values.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources\n+optional",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "Kind is a string value representing the REST resource this object represents.\nServers may infer this from the endpoint the client submits requests to.\nCannot be updated.\nIn CamelCase.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds\n+optional",
+                    "type": "string"
+                },
+                "metadata": {
+                    "description": "Standard object's metadata.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ObjectMeta"
+                        }
+                    ]
+                },
+                "spec": {
+                    "description": "Specification of the desired behavior of the pod.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.PodSpec"
+                        }
+                    ]
+                },
+                "status": {
+                    "description": "Most recently observed status of the pod.\nThis data may not be up to date.\nPopulated by the system.\nRead-only.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.PodStatus"
+                        }
+                    ]
+                }
+            }
+        },
         "v1.PodAffinity": {
             "type": "object",
             "properties": {
@@ -2812,6 +6010,47 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.PodCondition": {
+            "type": "object",
+            "properties": {
+                "lastProbeTime": {
+                    "description": "Last time we probed the condition.\n+optional",
+                    "type": "string"
+                },
+                "lastTransitionTime": {
+                    "description": "Last time the condition transitioned from one status to another.\n+optional",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Human-readable message indicating details about last transition.\n+optional",
+                    "type": "string"
+                },
+                "observedGeneration": {
+                    "description": "If set, this represents the .metadata.generation that the pod condition was set based upon.\nThis is an alpha field. Enable PodObservedGenerationTracking to be able to use this field.\n+featureGate=PodObservedGenerationTracking\n+optional",
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "Unique, one-word, CamelCase reason for the condition's last transition.\n+optional",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is the status of the condition.\nCan be True, False, Unknown.\nMore info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#pod-conditions",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/k8s_io_api_core_v1.ConditionStatus"
+                        }
+                    ]
+                },
+                "type": {
+                    "description": "Type is the type of the condition.\nMore info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#pod-conditions",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.PodConditionType"
+                        }
+                    ]
+                }
+            }
+        },
         "v1.PodConditionType": {
             "type": "string",
             "enum": [
@@ -2885,6 +6124,15 @@ const docTemplate = `{
                 "FSGroupChangeAlways"
             ]
         },
+        "v1.PodIP": {
+            "type": "object",
+            "properties": {
+                "ip": {
+                    "description": "IP is the IP address assigned to the pod\n+required",
+                    "type": "string"
+                }
+            }
+        },
         "v1.PodOS": {
             "type": "object",
             "properties": {
@@ -2898,6 +6146,36 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.PodPhase": {
+            "type": "string",
+            "enum": [
+                "Pending",
+                "Running",
+                "Succeeded",
+                "Failed",
+                "Unknown"
+            ],
+            "x-enum-varnames": [
+                "PodPending",
+                "PodRunning",
+                "PodSucceeded",
+                "PodFailed",
+                "PodUnknown"
+            ]
+        },
+        "v1.PodQOSClass": {
+            "type": "string",
+            "enum": [
+                "Guaranteed",
+                "Burstable",
+                "BestEffort"
+            ],
+            "x-enum-varnames": [
+                "PodQOSGuaranteed",
+                "PodQOSBurstable",
+                "PodQOSBestEffort"
+            ]
+        },
         "v1.PodReadinessGate": {
             "type": "object",
             "properties": {
@@ -2911,6 +6189,19 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.PodResizeStatus": {
+            "type": "string",
+            "enum": [
+                "InProgress",
+                "Deferred",
+                "Infeasible"
+            ],
+            "x-enum-varnames": [
+                "PodResizeStatusInProgress",
+                "PodResizeStatusDeferred",
+                "PodResizeStatusInfeasible"
+            ]
+        },
         "v1.PodResourceClaim": {
             "type": "object",
             "properties": {
@@ -2928,6 +6219,19 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.PodResourceClaimStatus": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Name uniquely identifies this resource claim inside the pod.\nThis must match the name of an entry in pod.spec.resourceClaims,\nwhich implies that the string must be a DNS_LABEL.",
+                    "type": "string"
+                },
+                "resourceClaimName": {
+                    "description": "ResourceClaimName is the name of the ResourceClaim that was\ngenerated for the Pod in the namespace of the Pod. If this is\nunset, then generating a ResourceClaim was not necessary. The\npod.spec.resourceClaims entry can be ignored in this case.\n\n+optional",
+                    "type": "string"
+                }
+            }
+        },
         "v1.PodSELinuxChangePolicy": {
             "type": "string",
             "enum": [
@@ -3276,6 +6580,112 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.PodStatus": {
+            "type": "object",
+            "properties": {
+                "conditions": {
+                    "description": "Current service state of pod.\nMore info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#pod-conditions\n+optional\n+patchMergeKey=type\n+patchStrategy=merge\n+listType=map\n+listMapKey=type",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.PodCondition"
+                    }
+                },
+                "containerStatuses": {
+                    "description": "Statuses of containers in this pod.\nEach container in the pod should have at most one status in this list,\nand all statuses should be for containers in the pod.\nHowever this is not enforced.\nIf a status for a non-existent container is present in the list, or the list has duplicate names,\nthe behavior of various Kubernetes components is not defined and those statuses might be\nignored.\nMore info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#pod-and-container-status\n+optional\n+listType=atomic",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ContainerStatus"
+                    }
+                },
+                "ephemeralContainerStatuses": {
+                    "description": "Statuses for any ephemeral containers that have run in this pod.\nEach ephemeral container in the pod should have at most one status in this list,\nand all statuses should be for containers in the pod.\nHowever this is not enforced.\nIf a status for a non-existent container is present in the list, or the list has duplicate names,\nthe behavior of various Kubernetes components is not defined and those statuses might be\nignored.\nMore info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#pod-and-container-status\n+optional\n+listType=atomic",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ContainerStatus"
+                    }
+                },
+                "hostIP": {
+                    "description": "hostIP holds the IP address of the host to which the pod is assigned. Empty if the pod has not started yet.\nA pod can be assigned to a node that has a problem in kubelet which in turns mean that HostIP will\nnot be updated even if there is a node is assigned to pod\n+optional",
+                    "type": "string"
+                },
+                "hostIPs": {
+                    "description": "hostIPs holds the IP addresses allocated to the host. If this field is specified, the first entry must\nmatch the hostIP field. This list is empty if the pod has not started yet.\nA pod can be assigned to a node that has a problem in kubelet which in turns means that HostIPs will\nnot be updated even if there is a node is assigned to this pod.\n+optional\n+patchStrategy=merge\n+patchMergeKey=ip\n+listType=atomic",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.HostIP"
+                    }
+                },
+                "initContainerStatuses": {
+                    "description": "Statuses of init containers in this pod. The most recent successful non-restartable\ninit container will have ready = true, the most recently started container will have\nstartTime set.\nEach init container in the pod should have at most one status in this list,\nand all statuses should be for containers in the pod.\nHowever this is not enforced.\nIf a status for a non-existent container is present in the list, or the list has duplicate names,\nthe behavior of various Kubernetes components is not defined and those statuses might be\nignored.\nMore info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle/#pod-and-container-status\n+listType=atomic",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ContainerStatus"
+                    }
+                },
+                "message": {
+                    "description": "A human readable message indicating details about why the pod is in this condition.\n+optional",
+                    "type": "string"
+                },
+                "nominatedNodeName": {
+                    "description": "nominatedNodeName is set only when this pod preempts other pods on the node, but it cannot be\nscheduled right away as preemption victims receive their graceful termination periods.\nThis field does not guarantee that the pod will be scheduled on this node. Scheduler may decide\nto place the pod elsewhere if other nodes become available sooner. Scheduler may also decide to\ngive the resources on this node to a higher priority pod that is created after preemption.\nAs a result, this field may be different than PodSpec.nodeName when the pod is\nscheduled.\n+optional",
+                    "type": "string"
+                },
+                "observedGeneration": {
+                    "description": "If set, this represents the .metadata.generation that the pod status was set based upon.\nThis is an alpha field. Enable PodObservedGenerationTracking to be able to use this field.\n+featureGate=PodObservedGenerationTracking\n+optional",
+                    "type": "integer"
+                },
+                "phase": {
+                    "description": "The phase of a Pod is a simple, high-level summary of where the Pod is in its lifecycle.\nThe conditions array, the reason and message fields, and the individual container status\narrays contain more detail about the pod's status.\nThere are five possible phase values:\n\nPending: The pod has been accepted by the Kubernetes system, but one or more of the\ncontainer images has not been created. This includes time before being scheduled as\nwell as time spent downloading images over the network, which could take a while.\nRunning: The pod has been bound to a node, and all of the containers have been created.\nAt least one container is still running, or is in the process of starting or restarting.\nSucceeded: All containers in the pod have terminated in success, and will not be restarted.\nFailed: All containers in the pod have terminated, and at least one container has\nterminated in failure. The container either exited with non-zero status or was terminated\nby the system.\nUnknown: For some reason the state of the pod could not be obtained, typically due to an\nerror in communicating with the host of the pod.\n\nMore info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#pod-phase\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.PodPhase"
+                        }
+                    ]
+                },
+                "podIP": {
+                    "description": "podIP address allocated to the pod. Routable at least within the cluster.\nEmpty if not yet allocated.\n+optional",
+                    "type": "string"
+                },
+                "podIPs": {
+                    "description": "podIPs holds the IP addresses allocated to the pod. If this field is specified, the 0th entry must\nmatch the podIP field. Pods may be allocated at most 1 value for each of IPv4 and IPv6. This list\nis empty if no IPs have been allocated yet.\n+optional\n+patchStrategy=merge\n+patchMergeKey=ip\n+listType=map\n+listMapKey=ip",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.PodIP"
+                    }
+                },
+                "qosClass": {
+                    "description": "The Quality of Service (QOS) classification assigned to the pod based on resource requirements\nSee PodQOSClass type for available QOS classes\nMore info: https://kubernetes.io/docs/concepts/workloads/pods/pod-qos/#quality-of-service-classes\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.PodQOSClass"
+                        }
+                    ]
+                },
+                "reason": {
+                    "description": "A brief CamelCase message indicating details about why the pod is in this state.\ne.g. 'Evicted'\n+optional",
+                    "type": "string"
+                },
+                "resize": {
+                    "description": "Status of resources resize desired for pod's containers.\nIt is empty if no resources resize is pending.\nAny changes to container resources will automatically set this to \"Proposed\"\nDeprecated: Resize status is moved to two pod conditions PodResizePending and PodResizeInProgress.\nPodResizePending will track states where the spec has been resized, but the Kubelet has not yet allocated the resources.\nPodResizeInProgress will track in-progress resizes, and should be present whenever allocated resources != acknowledged resources.\n+featureGate=InPlacePodVerticalScaling\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.PodResizeStatus"
+                        }
+                    ]
+                },
+                "resourceClaimStatuses": {
+                    "description": "Status of resource claims.\n+patchMergeKey=name\n+patchStrategy=merge,retainKeys\n+listType=map\n+listMapKey=name\n+featureGate=DynamicResourceAllocation\n+optional",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.PodResourceClaimStatus"
+                    }
+                },
+                "startTime": {
+                    "description": "RFC 3339 date and time at which the object was acknowledged by the Kubelet.\nThis is before the Kubelet pulled the container image(s) for the pod.\n+optional",
+                    "type": "string"
+                }
+            }
+        },
         "v1.PodTemplateSpec": {
             "type": "object",
             "properties": {
@@ -3576,6 +6986,36 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.ResourceHealth": {
+            "type": "object",
+            "properties": {
+                "health": {
+                    "description": "Health of the resource.\ncan be one of:\n - Healthy: operates as normal\n - Unhealthy: reported unhealthy. We consider this a temporary health issue\n              since we do not have a mechanism today to distinguish\n              temporary and permanent issues.\n - Unknown: The status cannot be determined.\n            For example, Device Plugin got unregistered and hasn't been re-registered since.\n\nIn future we may want to introduce the PermanentlyUnhealthy Status.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ResourceHealthStatus"
+                        }
+                    ]
+                },
+                "resourceID": {
+                    "description": "ResourceID is the unique identifier of the resource. See the ResourceID type for more information.",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ResourceHealthStatus": {
+            "type": "string",
+            "enum": [
+                "Healthy",
+                "Unhealthy",
+                "Unknown"
+            ],
+            "x-enum-varnames": [
+                "ResourceHealthStatusHealthy",
+                "ResourceHealthStatusUnhealthy",
+                "ResourceHealthStatusUnknown"
+            ]
+        },
         "v1.ResourceList": {
             "type": "object",
             "additionalProperties": {
@@ -3668,6 +7108,26 @@ const docTemplate = `{
                 "RestartContainer"
             ]
         },
+        "v1.ResourceStatus": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Name of the resource. Must be unique within the pod and in case of non-DRA resource, match one of the resources from the pod spec.\nFor DRA resources, the value must be \"claim:\u003cclaim_name\u003e/\u003crequest\u003e\".\nWhen this status is reported about a container, the \"claim_name\" and \"request\" must match one of the claims of this container.\n+required",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ResourceName"
+                        }
+                    ]
+                },
+                "resources": {
+                    "description": "List of unique resources health. Each element in the list contains an unique resource ID and its health.\nAt a minimum, for the lifetime of a Pod, resource ID must uniquely identify the resource allocated to the Pod on the Node.\nIf other Pod on the same Node reports the status with the same resource ID, it must be the same resource they share.\nSee ResourceID type definition for a specific format it has in various use cases.\n+listType=map\n+listMapKey=resourceID",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ResourceHealth"
+                    }
+                }
+            }
+        },
         "v1.RestartPolicy": {
             "type": "string",
             "enum": [
@@ -4694,6 +8154,31 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.VolumeMountStatus": {
+            "type": "object",
+            "properties": {
+                "mountPath": {
+                    "description": "MountPath corresponds to the original VolumeMount.",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name corresponds to the name of the original VolumeMount.",
+                    "type": "string"
+                },
+                "readOnly": {
+                    "description": "ReadOnly corresponds to the original VolumeMount.\n+optional",
+                    "type": "boolean"
+                },
+                "recursiveReadOnly": {
+                    "description": "RecursiveReadOnly must be set to Disabled, Enabled, or unspecified (for non-readonly mounts).\nAn IfPossible value in the original VolumeMount must be translated to Disabled or Enabled,\ndepending on the mount result.\n+featureGate=RecursiveReadOnlyMounts\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.RecursiveReadOnlyMode"
+                        }
+                    ]
+                }
+            }
+        },
         "v1.VolumeProjection": {
             "type": "object",
             "properties": {
@@ -0,0 +1,83 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+
+	sgHttp "github.com/slackhq/spark-gateway/internal/shared/http"
+)
+
+// Client is a minimal client for the Gateway REST API used by the sparkgw CLI.
+type Client struct {
+	BaseURL    string
+	Username   string
+	Password   string
+	HTTPClient *http.Client
+}
+
+// NewClient returns a Client for the Gateway at baseURL, e.g. http://spark-gateway:8080.
+func NewClient(baseURL string, username string, password string) *Client {
+	return &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		Username:   username,
+		Password:   password,
+		HTTPClient: sgHttp.DefaultClient,
+	}
+}
+
+// Status fetches the SparkApplicationStatus of the GatewayApplication with the given gatewayId.
+func (c *Client) Status(ctx context.Context, gatewayId string) (*v1beta2.SparkApplicationStatus, error) {
+	statusUrl := fmt.Sprintf("%s/api/v1/applications/%s/status", c.BaseURL, url.PathEscape(gatewayId))
+
+	req, err := http.NewRequest(http.MethodGet, statusUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create status request: %w", err)
+	}
+	c.addAuth(req)
+
+	resp, respBody, err := sgHttp.HttpRequest(ctx, c.HTTPClient, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sgHttp.CheckJsonResponse(resp, respBody); err != nil {
+		return nil, err
+	}
+
+	var status v1beta2.SparkApplicationStatus
+	if err := json.Unmarshal(*respBody, &status); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal status response: %w", err)
+	}
+
+	return &status, nil
+}
+
+func (c *Client) addAuth(req *http.Request) {
+	if c.Username == "" {
+		return
+	}
+	auth := c.Username + ":" + c.Password
+	req.Header.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(auth)))
+}
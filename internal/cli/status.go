@@ -0,0 +1,159 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+// Exit codes returned by RunStatus so shell scripts can branch on the outcome of a GatewayApplication.
+const (
+	ExitCodeCompleted   = 0
+	ExitCodeFailed      = 1
+	ExitCodeError       = 2
+	ExitCodeInterrupted = 3
+)
+
+// StatusOptions configures RunStatus.
+type StatusOptions struct {
+	// Watch keeps polling until the application reaches a terminal state.
+	Watch bool
+	// Interval is how often the status is polled in watch mode.
+	Interval time.Duration
+}
+
+// RunStatus prints the status of gatewayId to out. Without Watch it prints the current status once and returns
+// ExitCodeCompleted unless the request fails. With Watch it prints a line every time the state or executor counts
+// change, prints the failure message when the application terminates, and returns an exit code reflecting the
+// final state. Transient request errors are reported to errOut and retried; client errors such as an unknown
+// gatewayId end the watch.
+func RunStatus(ctx context.Context, client *Client, gatewayId string, opts StatusOptions, out io.Writer, errOut io.Writer) int {
+
+	if !opts.Watch {
+		status, err := client.Status(ctx, gatewayId)
+		if err != nil {
+			fmt.Fprintf(errOut, "error getting status of %s: %s\n", gatewayId, err)
+			return ExitCodeError
+		}
+		fmt.Fprintln(out, FormatStatus(status))
+		if msg := status.AppState.ErrorMessage; msg != "" {
+			fmt.Fprintf(out, "error: %s\n", msg)
+		}
+		return ExitCodeCompleted
+	}
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	var last string
+	for {
+		status, err := client.Status(ctx, gatewayId)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ExitCodeInterrupted
+			}
+			if !isRetryable(err) {
+				fmt.Fprintf(errOut, "error getting status of %s: %s\n", gatewayId, err)
+				return ExitCodeError
+			}
+			fmt.Fprintf(errOut, "error getting status of %s, retrying: %s\n", gatewayId, err)
+		} else {
+			if line := FormatStatus(status); line != last {
+				fmt.Fprintf(out, "%s %s\n", time.Now().UTC().Format(time.RFC3339), line)
+				last = line
+			}
+
+			if code, done := exitCodeForState(status.AppState.State); done {
+				if msg := status.AppState.ErrorMessage; msg != "" {
+					fmt.Fprintf(out, "failure: %s\n", msg)
+				}
+				return code
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ExitCodeInterrupted
+		case <-ticker.C:
+		}
+	}
+}
+
+// FormatStatus renders the application state and executor counts as a single line.
+func FormatStatus(status *v1beta2.SparkApplicationStatus) string {
+	state := string(status.AppState.State)
+	if state == "" {
+		state = "UNKNOWN"
+	}
+
+	counts := map[v1beta2.ExecutorState]int{}
+	for _, executorState := range status.ExecutorState {
+		counts[executorState]++
+	}
+
+	var parts []string
+	for _, executorState := range []v1beta2.ExecutorState{
+		v1beta2.ExecutorStateRunning,
+		v1beta2.ExecutorStatePending,
+		v1beta2.ExecutorStateCompleted,
+		v1beta2.ExecutorStateFailed,
+		v1beta2.ExecutorStateUnknown,
+	} {
+		if counts[executorState] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[executorState], strings.ToLower(string(executorState))))
+		}
+	}
+
+	executors := "none"
+	if len(parts) > 0 {
+		executors = strings.Join(parts, ", ")
+	}
+
+	return fmt.Sprintf("%s executors: %s", state, executors)
+}
+
+// exitCodeForState returns the exit code for a terminal state, and false if state is not terminal.
+func exitCodeForState(state v1beta2.ApplicationStateType) (int, bool) {
+	switch state {
+	case v1beta2.ApplicationStateCompleted:
+		return ExitCodeCompleted, true
+	case v1beta2.ApplicationStateFailed, v1beta2.ApplicationStateFailedSubmission:
+		return ExitCodeFailed, true
+	default:
+		return 0, false
+	}
+}
+
+func isRetryable(err error) bool {
+	var gatewayErr gatewayerrors.GatewayError
+	if !errors.As(err, &gatewayErr) {
+		return true
+	}
+	if gatewayErr.Status == http.StatusTooManyRequests {
+		return true
+	}
+	return gatewayErr.Status >= http.StatusInternalServerError
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+)
+
+func statusServer(t *testing.T, statuses ...v1beta2.SparkApplicationStatus) *httptest.Server {
+	var calls atomic.Int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/applications/clusterid-testid/status", r.URL.Path, "status path should be requested")
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok, "basic auth should be set")
+		assert.Equal(t, "user", user, "basic auth user should match")
+		assert.Equal(t, "pass", pass, "basic auth password should match")
+
+		i := int(calls.Add(1)) - 1
+		if i >= len(statuses) {
+			i = len(statuses) - 1
+		}
+		json.NewEncoder(w).Encode(statuses[i])
+	}))
+}
+
+func TestRunStatus(t *testing.T) {
+	running := v1beta2.SparkApplicationStatus{
+		AppState: v1beta2.ApplicationState{State: v1beta2.ApplicationStateRunning},
+		ExecutorState: map[string]v1beta2.ExecutorState{
+			"exec-1": v1beta2.ExecutorStateRunning,
+			"exec-2": v1beta2.ExecutorStateRunning,
+			"exec-3": v1beta2.ExecutorStatePending,
+		},
+	}
+	failed := v1beta2.SparkApplicationStatus{
+		AppState: v1beta2.ApplicationState{State: v1beta2.ApplicationStateFailed, ErrorMessage: "driver OOMKilled"},
+		ExecutorState: map[string]v1beta2.ExecutorState{
+			"exec-1": v1beta2.ExecutorStateFailed,
+		},
+	}
+	completed := v1beta2.SparkApplicationStatus{
+		AppState: v1beta2.ApplicationState{State: v1beta2.ApplicationStateCompleted},
+	}
+
+	tests := []struct {
+		name         string
+		statuses     []v1beta2.SparkApplicationStatus
+		watch        bool
+		expectedCode int
+		expectedOut  []string
+	}{
+		{
+			name:         "single status",
+			statuses:     []v1beta2.SparkApplicationStatus{running},
+			expectedCode: ExitCodeCompleted,
+			expectedOut:  []string{"RUNNING executors: 2 running, 1 pending\n"},
+		},
+		{
+			name:         "watch until failed",
+			statuses:     []v1beta2.SparkApplicationStatus{running, running, failed},
+			watch:        true,
+			expectedCode: ExitCodeFailed,
+			expectedOut:  []string{"RUNNING executors: 2 running, 1 pending\n", "FAILED executors: 1 failed\n", "failure: driver OOMKilled\n"},
+		},
+		{
+			name:         "watch until completed",
+			statuses:     []v1beta2.SparkApplicationStatus{running, completed},
+			watch:        true,
+			expectedCode: ExitCodeCompleted,
+			expectedOut:  []string{"RUNNING executors: 2 running, 1 pending\n", "COMPLETED executors: none\n"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := statusServer(t, test.statuses...)
+			defer server.Close()
+
+			var out, errOut bytes.Buffer
+			code := RunStatus(context.Background(), NewClient(server.URL, "user", "pass"), "clusterid-testid", StatusOptions{Watch: test.watch, Interval: time.Millisecond}, &out, &errOut)
+
+			assert.Equal(t, test.expectedCode, code, "exit code should reflect the final state")
+			assert.Empty(t, errOut.String(), "no errors should be reported")
+			lines := bytes.SplitAfter(out.Bytes(), []byte("\n"))
+			assert.Len(t, lines, len(test.expectedOut)+1, "one line per state change should be printed")
+			for i, expected := range test.expectedOut {
+				assert.Contains(t, string(lines[i]), expected, "output line should match")
+			}
+		})
+	}
+}
+
+func TestRunStatusNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"not found"}`))
+	}))
+	defer server.Close()
+
+	var out, errOut bytes.Buffer
+	code := RunStatus(context.Background(), NewClient(server.URL, "", ""), "clusterid-testid", StatusOptions{Watch: true, Interval: time.Millisecond}, &out, &errOut)
+
+	assert.Equal(t, ExitCodeError, code, "non-retryable errors should end the watch")
+	assert.Contains(t, errOut.String(), "not found", "error should be reported")
+}
+
+func TestRunStatusInterrupted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var out, errOut bytes.Buffer
+	code := RunStatus(ctx, NewClient(server.URL, "", ""), "clusterid-testid", StatusOptions{Watch: true, Interval: 5 * time.Millisecond}, &out, &errOut)
+
+	assert.Equal(t, ExitCodeInterrupted, code, "cancelled watch should return the interrupted exit code")
+	assert.Contains(t, errOut.String(), "retrying", "retryable errors should be reported")
+}
@@ -16,11 +16,15 @@
 package domain
 
 import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
-	"github.com/google/uuid"
 	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
 	"github.com/slackhq/spark-gateway/internal/shared/util"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,15 +33,23 @@ import (
 const GATEWAY_USER_LABEL = "spark-gateway/user"
 const GATEWAY_CLUSTER_LABEL = "spark-gateway/cluster"
 const GATEWAY_APPLICATION_NAME_ANNOTATION = "applicationName"
+const GATEWAY_ACTING_USER_ANNOTATION = "spark-gateway/acting-user"
+const GATEWAY_TEAM_LABEL = "spark-gateway/team"
+const GATEWAY_SPEC_HASH_ANNOTATION = "spark-gateway/spec-hash"
+
+// GATEWAY_SPECULATIVE_ANNOTATION set to "true" on a submission opts it in to speculative submission. Both copies of a
+// speculative submission carry the same GATEWAY_SPECULATIVE_GROUP_LABEL.
+const GATEWAY_SPECULATIVE_ANNOTATION = "spark-gateway/speculative"
+const GATEWAY_SPECULATIVE_GROUP_LABEL = "spark-gateway/speculative-group"
 
 // Most models here are simply wrappers for corresponding v1beta2 types with some fields removed or defaulted. These will most likely need
 // to be expanded into individual models like what Batch Processing Gateway did to fully decouple everything, but since we're
 // focusing on Kubeflow Spark Operator for now, we will target their models
 
 type StatusUrlTemplates struct {
-	SparkUITemplate        string `koanf:"sparkUI"`
-	SparkHistoryUITemplate string `koanf:"sparkHistoryUI"`
-	LogsUITemplate         string `koanf:"logsUI"`
+	SparkUITemplate        string `koanf:"sparkUI" desc:"Template of the Spark UI link"`
+	SparkHistoryUITemplate string `koanf:"sparkHistoryUI" desc:"Template of the Spark History Server link"`
+	LogsUITemplate         string `koanf:"logsUI" desc:"Template of the logs link"`
 }
 
 type SparkLogURLs struct {
@@ -56,12 +68,131 @@ func NewGatewayApplicationStatus(status v1beta2.SparkApplicationStatus) *v1beta2
 	return &gatewayStatus
 }
 
+// ApplicationStatus is the SparkApplicationStatus of an application along with its creation time, and the Timings
+// derived from both.
+type ApplicationStatus struct {
+	v1beta2.SparkApplicationStatus `json:",inline"`
+	// GatewayState is applicationState.state normalized to a GatewayState
+	GatewayState      GatewayState        `json:"gatewayState"`
+	CreationTimestamp metav1.Time         `json:"creationTimestamp"`
+	Timings           *ApplicationTimings `json:"timings,omitempty"`
+	// Submission is set while an asynchronous submission hasn't been created in its cluster
+	Submission *QueuedSubmission `json:"submission,omitempty"`
+}
+
+func (a *ApplicationStatus) DeepCopy() *ApplicationStatus {
+	return &ApplicationStatus{
+		SparkApplicationStatus: *a.SparkApplicationStatus.DeepCopy(),
+		GatewayState:           a.GatewayState,
+		CreationTimestamp:      *a.CreationTimestamp.DeepCopy(),
+		Timings:                a.Timings.DeepCopy(),
+		Submission:             a.Submission.DeepCopy(),
+	}
+}
+
+// ApplicationTimings are durations, in seconds, derived from the timestamps of a SparkApplication. The
+// SparkApplication CRD does not record when the driver started running, so QueuedSeconds ends when the Spark Operator
+// last submitted the application. Durations of applications that have not been submitted or terminated yet are
+// measured until now, and a duration is nil when its start is unknown.
+type ApplicationTimings struct {
+	// QueuedSeconds is the time from creation until the last submission attempt
+	QueuedSeconds *int64 `json:"queuedSeconds,omitempty"`
+	// RunSeconds is the time from the last submission attempt until termination
+	RunSeconds *int64 `json:"runSeconds,omitempty"`
+	// TotalSeconds is the time from creation until termination
+	TotalSeconds *int64 `json:"totalSeconds,omitempty"`
+}
+
+// NewApplicationTimings derives the ApplicationTimings of an application created at creationTimestamp with status,
+// measuring durations that have not ended yet until now.
+func NewApplicationTimings(creationTimestamp metav1.Time, status v1beta2.SparkApplicationStatus, now time.Time) *ApplicationTimings {
+	submitted := status.LastSubmissionAttemptTime
+	terminated := status.TerminationTime
+
+	until := func(end metav1.Time) time.Time {
+		if end.IsZero() {
+			return now
+		}
+		return end.Time
+	}
+
+	seconds := func(start metav1.Time, end time.Time) *int64 {
+		if start.IsZero() || end.Before(start.Time) {
+			return nil
+		}
+		duration := int64(end.Sub(start.Time).Seconds())
+		return &duration
+	}
+
+	timings := &ApplicationTimings{
+		QueuedSeconds: seconds(creationTimestamp, until(submitted)),
+		TotalSeconds:  seconds(creationTimestamp, until(terminated)),
+	}
+	if !submitted.IsZero() {
+		timings.RunSeconds = seconds(submitted, until(terminated))
+	}
+
+	return timings
+}
+
+func (a *ApplicationTimings) DeepCopy() *ApplicationTimings {
+	if a == nil {
+		return nil
+	}
+
+	copyValue := func(value *int64) *int64 {
+		if value == nil {
+			return nil
+		}
+		copied := *value
+		return &copied
+	}
+
+	return &ApplicationTimings{
+		QueuedSeconds: copyValue(a.QueuedSeconds),
+		RunSeconds:    copyValue(a.RunSeconds),
+		TotalSeconds:  copyValue(a.TotalSeconds),
+	}
+}
+
+// IsTerminalApplicationState reports whether a SparkApplication in state will not change state again.
+func IsTerminalApplicationState(state v1beta2.ApplicationStateType) bool {
+	return state == v1beta2.ApplicationStateCompleted || state == v1beta2.ApplicationStateFailed
+}
+
+// GatewayApplicationWaitStatus is a compact status document for clients, such as Airflow deferrable operators, that
+// wait on a GatewayApplication to terminate.
+type GatewayApplicationWaitStatus struct {
+	State          v1beta2.ApplicationStateType `json:"state"`
+	GatewayState   GatewayState                 `json:"gatewayState"`
+	Terminal       bool                         `json:"terminal"`
+	FailureMessage string                       `json:"failureMessage,omitempty"`
+}
+
+// NewGatewayApplicationWaitStatus takes in a v1beta2.SparkApplicationStatus and returns its GatewayApplicationWaitStatus.
+// FailureMessage is only set for applications that failed.
+func NewGatewayApplicationWaitStatus(status v1beta2.SparkApplicationStatus) *GatewayApplicationWaitStatus {
+	waitStatus := &GatewayApplicationWaitStatus{
+		State:        status.AppState.State,
+		GatewayState: NewGatewayState(status.AppState.State),
+		Terminal:     IsTerminalApplicationState(status.AppState.State),
+	}
+
+	if status.AppState.State == v1beta2.ApplicationStateFailed || status.AppState.State == v1beta2.ApplicationStateFailedSubmission {
+		waitStatus.FailureMessage = status.AppState.ErrorMessage
+	}
+
+	return waitStatus
+}
+
 // GatewayApplicationMeta is essentially a metav1.ObjectMeta with only fields we deem necessary for GatewayApplications
 type GatewayApplicationMeta struct {
 	Name        string            `json:"name"`
 	Namespace   string            `json:"namespace"`
 	Labels      map[string]string `json:"labels"`
 	Annotations map[string]string `json:"annotations"`
+	// CreationTimestamp is copied from the SparkApplication ObjectMeta and is read-only
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
 }
 
 // NewGatewayApplicationMeta takes metav1.ObjectMeta and returns a GatewayApplicationMeta with
@@ -80,10 +211,11 @@ func NewGatewayApplicationMeta(appMeta metav1.ObjectMeta) *GatewayApplicationMet
 	}
 
 	return &GatewayApplicationMeta{
-		Name:        appMeta.Name,
-		Namespace:   appMeta.Namespace,
-		Annotations: annotations,
-		Labels:      labels,
+		Name:              appMeta.Name,
+		Namespace:         appMeta.Namespace,
+		Annotations:       annotations,
+		Labels:            labels,
+		CreationTimestamp: appMeta.CreationTimestamp,
 	}
 }
 
@@ -93,6 +225,8 @@ type SparkManagerSparkApplicationSummary struct {
 	metav1.TypeMeta        `json:",inline"`
 	GatewayApplicationMeta `json:"metadata"`
 	Status                 v1beta2.SparkApplicationStatus `json:"status"`
+	// Cost is only set when the cluster has cost rates and the application has been submitted
+	Cost *ApplicationCost `json:"cost,omitempty"`
 }
 
 func NewSparkManagerSparkApplicationSummary(sparkApp *v1beta2.SparkApplication) *SparkManagerSparkApplicationSummary {
@@ -103,19 +237,235 @@ func NewSparkManagerSparkApplicationSummary(sparkApp *v1beta2.SparkApplication)
 	}
 }
 
+// SummaryView controls how much of the SparkApplicationStatus is included in list responses
+type SummaryView string
+
+const (
+	// SummaryViewFull includes the full SparkApplicationStatus
+	SummaryViewFull SummaryView = "full"
+	// SummaryViewSlim includes only the application state, timestamps and submissionID
+	SummaryViewSlim SummaryView = "summary"
+)
+
+func ParseSummaryView(view string) (SummaryView, error) {
+	switch SummaryView(view) {
+	case "", SummaryViewFull:
+		return SummaryViewFull, nil
+	case SummaryViewSlim:
+		return SummaryViewSlim, nil
+	default:
+		return "", fmt.Errorf("invalid view '%s', valid values: [%s %s]", view, SummaryViewFull, SummaryViewSlim)
+	}
+}
+
+// ListSortField is the field list responses are ordered by
+type ListSortField string
+
+const (
+	ListSortByCreationTime ListSortField = "creationTime"
+	ListSortByState        ListSortField = "state"
+	ListSortByName         ListSortField = "name"
+)
+
+// ListSortOrder is the direction list responses are ordered in
+type ListSortOrder string
+
+const (
+	ListSortOrderAsc  ListSortOrder = "asc"
+	ListSortOrderDesc ListSortOrder = "desc"
+)
+
+// ListSort describes how list responses should be ordered. An empty By leaves the
+// order as returned by the upstream clusters.
+type ListSort struct {
+	By    ListSortField
+	Order ListSortOrder
+}
+
+// ParseListSort validates the `sortBy` and `order` query values. `order` defaults to asc and
+// may only be set alongside `sortBy`.
+func ParseListSort(sortBy string, order string) (ListSort, error) {
+	listSort := ListSort{}
+
+	switch ListSortField(sortBy) {
+	case "":
+		if order != "" {
+			return ListSort{}, fmt.Errorf("'order' requires 'sortBy' to be set")
+		}
+		return listSort, nil
+	case ListSortByCreationTime, ListSortByState, ListSortByName:
+		listSort.By = ListSortField(sortBy)
+	default:
+		return ListSort{}, fmt.Errorf("invalid sortBy '%s', valid values: [%s %s %s]", sortBy, ListSortByCreationTime, ListSortByState, ListSortByName)
+	}
+
+	switch ListSortOrder(order) {
+	case "", ListSortOrderAsc:
+		listSort.Order = ListSortOrderAsc
+	case ListSortOrderDesc:
+		listSort.Order = ListSortOrderDesc
+	default:
+		return ListSort{}, fmt.Errorf("invalid order '%s', valid values: [%s %s]", order, ListSortOrderAsc, ListSortOrderDesc)
+	}
+
+	return listSort, nil
+}
+
+// SortGatewayApplicationSummaries orders summaries in place according to listSort. Ties are broken
+// by GatewayId so results are stable across requests.
+func SortGatewayApplicationSummaries(summaries []*GatewayApplicationSummary, listSort ListSort) {
+	if listSort.By == "" {
+		return
+	}
+
+	slices.SortStableFunc(summaries, func(a, b *GatewayApplicationSummary) int {
+		var c int
+		switch listSort.By {
+		case ListSortByCreationTime:
+			c = a.CreationTimestamp.Time.Compare(b.CreationTimestamp.Time)
+		case ListSortByState:
+			c = cmp.Compare(a.Status.AppState.State, b.Status.AppState.State)
+		case ListSortByName:
+			c = cmp.Compare(a.Name, b.Name)
+		}
+
+		if c == 0 {
+			c = cmp.Compare(a.GatewayId, b.GatewayId)
+		}
+
+		if listSort.Order == ListSortOrderDesc {
+			return -c
+		}
+		return c
+	})
+}
+
+// NewSparkManagerSparkApplicationSummaryView returns a SparkManagerSparkApplicationSummary with the Status projected
+// according to view
+func NewSparkManagerSparkApplicationSummaryView(sparkApp *v1beta2.SparkApplication, view SummaryView) *SparkManagerSparkApplicationSummary {
+	summary := NewSparkManagerSparkApplicationSummary(sparkApp)
+
+	if view == SummaryViewSlim {
+		summary.Status = v1beta2.SparkApplicationStatus{
+			AppState:                  sparkApp.Status.AppState,
+			LastSubmissionAttemptTime: sparkApp.Status.LastSubmissionAttemptTime,
+			TerminationTime:           sparkApp.Status.TerminationTime,
+			SubmissionID:              sparkApp.Status.SubmissionID,
+		}
+	}
+
+	return summary
+}
+
+// SparkManagerApplicationCounts holds the number of SparkApplications in each state for a namespace
+type SparkManagerApplicationCounts struct {
+	Namespace string         `json:"namespace"`
+	States    map[string]int `json:"states"`
+}
+
+// CountState returns the state name used when counting applications. SparkApplications that have not yet been
+// picked up by the operator have an empty state, which is counted as NEW.
+func CountState(state v1beta2.ApplicationStateType) string {
+	if state == v1beta2.ApplicationStateNew {
+		return "NEW"
+	}
+	return string(state)
+}
+
+// CountGroupBy is a field application counts can be grouped by
+type CountGroupBy string
+
+const (
+	CountGroupByCluster   CountGroupBy = "cluster"
+	CountGroupByNamespace CountGroupBy = "namespace"
+	CountGroupByState     CountGroupBy = "state"
+)
+
+// ParseCountGroupBy parses a comma separated `groupBy` query value. An empty value returns no fields, meaning a single
+// total count.
+func ParseCountGroupBy(groupBy string) ([]CountGroupBy, error) {
+	fields := []CountGroupBy{}
+	if groupBy == "" {
+		return fields, nil
+	}
+
+	for _, rawField := range strings.Split(groupBy, ",") {
+		field := CountGroupBy(strings.TrimSpace(rawField))
+		switch field {
+		case CountGroupByCluster, CountGroupByNamespace, CountGroupByState:
+			if !slices.Contains(fields, field) {
+				fields = append(fields, field)
+			}
+		default:
+			return nil, fmt.Errorf("invalid groupBy '%s', valid values: [%s %s %s]", field, CountGroupByCluster, CountGroupByNamespace, CountGroupByState)
+		}
+	}
+
+	return fields, nil
+}
+
+// ApplicationCount is the number of applications matching a group. Fields that were not grouped by are left empty.
+type ApplicationCount struct {
+	Cluster   string `json:"cluster,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	State     string `json:"state,omitempty"`
+	Count     int    `json:"count"`
+}
+
+// AggregateApplicationCounts rolls up per namespace SparkManager counts from a cluster into `counts`, keyed by the
+// fields in groupBy
+func AggregateApplicationCounts(counts map[ApplicationCount]int, cluster string, nsCounts SparkManagerApplicationCounts, groupBy []CountGroupBy) {
+	for state, count := range nsCounts.States {
+		key := ApplicationCount{}
+		for _, field := range groupBy {
+			switch field {
+			case CountGroupByCluster:
+				key.Cluster = cluster
+			case CountGroupByNamespace:
+				key.Namespace = nsCounts.Namespace
+			case CountGroupByState:
+				key.State = state
+			}
+		}
+		counts[key] += count
+	}
+}
+
+// ApplicationCountList flattens aggregated counts into a list ordered by cluster, namespace and state
+func ApplicationCountList(counts map[ApplicationCount]int) []*ApplicationCount {
+	countList := []*ApplicationCount{}
+	for key, count := range counts {
+		appCount := key
+		appCount.Count = count
+		countList = append(countList, &appCount)
+	}
+
+	slices.SortFunc(countList, func(a, b *ApplicationCount) int {
+		return cmp.Or(
+			cmp.Compare(a.Cluster, b.Cluster),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.State, b.State),
+		)
+	})
+
+	return countList
+}
+
 // GatewayApplicationSummary is a SparkManagerApplicationSummary with additional Spark Gateway
 // specific fields for extra context
 type GatewayApplicationSummary struct {
 	SparkManagerSparkApplicationSummary `json:",inline"`
-	GatewayId                           string `json:"gatewayId"`
-	Cluster                             string `json:"cluster"`
-	User                                string `json:"user"`
+	GatewayId                           string       `json:"gatewayId"`
+	GatewayState                        GatewayState `json:"gatewayState"`
+	Cluster                             string       `json:"cluster"`
+	User                                string       `json:"user"`
 }
 
 func NewGatewayApplicationSummary(sparkManagerSummary SparkManagerSparkApplicationSummary) *GatewayApplicationSummary {
 	return &GatewayApplicationSummary{
 		SparkManagerSparkApplicationSummary: sparkManagerSummary,
 		GatewayId:                           sparkManagerSummary.Name,
+		GatewayState:                        NewGatewayState(sparkManagerSummary.Status.AppState.State),
 		Cluster:                             sparkManagerSummary.Labels[GATEWAY_CLUSTER_LABEL],
 		User:                                sparkManagerSummary.Labels[GATEWAY_USER_LABEL],
 	}
@@ -165,16 +515,49 @@ func NewGatewaySparkApplication(sparkApp *v1beta2.SparkApplication, opts ...func
 func WithUser(user string) func(*GatewaySparkApplication) {
 	return func(gsa *GatewaySparkApplication) {
 		gsa.Labels[GATEWAY_USER_LABEL] = user
+		gsa.Labels[GATEWAY_OWNER_LABEL] = user
 		gsa.Spec.ProxyUser = &user
 	}
 }
 
+// WithProxyUser overrides spec.proxyUser. Should be applied after WithUser so the resolved
+// proxyUser takes precedence over the authenticated user.
+func WithProxyUser(proxyUser string) func(*GatewaySparkApplication) {
+	return func(gsa *GatewaySparkApplication) {
+		gsa.Spec.ProxyUser = &proxyUser
+	}
+}
+
+// WithRestartPolicy overrides spec.restartPolicy with the policy resolved from the namespace's restartPolicy limits.
+func WithRestartPolicy(restartPolicy v1beta2.RestartPolicy) func(*GatewaySparkApplication) {
+	return func(gsa *GatewaySparkApplication) {
+		gsa.Spec.RestartPolicy = restartPolicy
+	}
+}
+
+// WithDefaultTimeToLive sets spec.timeToLiveSeconds to ttlSeconds if the submission didn't set it. A ttlSeconds of 0
+// leaves the spec unchanged.
+func WithDefaultTimeToLive(ttlSeconds int64) func(*GatewaySparkApplication) {
+	return func(gsa *GatewaySparkApplication) {
+		if gsa.Spec.TimeToLiveSeconds == nil && ttlSeconds > 0 {
+			gsa.Spec.TimeToLiveSeconds = &ttlSeconds
+		}
+	}
+}
+
 func WithCluster(cluster string) func(*GatewaySparkApplication) {
 	return func(gsa *GatewaySparkApplication) {
 		gsa.Labels[GATEWAY_CLUSTER_LABEL] = cluster
 	}
 }
 
+// WithSpeculativeGroup labels a copy of a speculative submission with the group shared by all its copies
+func WithSpeculativeGroup(group string) func(*GatewaySparkApplication) {
+	return func(gsa *GatewaySparkApplication) {
+		gsa.Labels[GATEWAY_SPECULATIVE_GROUP_LABEL] = group
+	}
+}
+
 func WithSelector(selectorMap map[string]string) func(*GatewaySparkApplication) {
 	return func(gsa *GatewaySparkApplication) {
 		// Add selector values if they exist
@@ -196,12 +579,102 @@ func WithId(gatewayId string) func(*GatewaySparkApplication) {
 	}
 }
 
+// SparkApplicationSpecHash returns a hash of the SparkApplication spec and submitting user, used to recognise repeated
+// submissions of the same application
+func SparkApplicationSpecHash(sparkApp *v1beta2.SparkApplication) (string, error) {
+	specBytes, err := json.Marshal(struct {
+		User string                       `json:"user"`
+		Spec v1beta2.SparkApplicationSpec `json:"spec"`
+	}{
+		User: sparkApp.Labels[GATEWAY_USER_LABEL],
+		Spec: sparkApp.Spec,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error marshalling SparkApplication spec: %w", err)
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(specBytes)), nil
+}
+
+// WithSpecHash sets the GATEWAY_SPEC_HASH_ANNOTATION from the application's spec and user. Should be applied after
+// any options modifying the spec or user.
+func WithSpecHash() func(*GatewaySparkApplication) {
+	return func(gsa *GatewaySparkApplication) {
+		specHash, err := SparkApplicationSpecHash(gsa.ToV1Beta2SparkApplication())
+		if err != nil {
+			// SparkApplicationSpecs always marshal, but skip the annotation rather than fail the submission
+			return
+		}
+		gsa.Annotations[GATEWAY_SPEC_HASH_ANNOTATION] = specHash
+	}
+}
+
+// IsSameSubmission returns true if existing was created from the same submission as submitted, determined by
+// comparing the GATEWAY_SPEC_HASH_ANNOTATION of existing with the hash of submitted. The annotation is compared rather
+// than the specs directly because existing may have had defaults applied by the cluster.
+func IsSameSubmission(existing *v1beta2.SparkApplication, submitted *v1beta2.SparkApplication) bool {
+	existingHash, ok := existing.Annotations[GATEWAY_SPEC_HASH_ANNOTATION]
+	if !ok {
+		return false
+	}
+
+	submittedHash, err := SparkApplicationSpecHash(submitted)
+	if err != nil {
+		return false
+	}
+
+	return existingHash == submittedHash
+}
+
 type GatewayApplication struct {
 	SparkApplication GatewaySparkApplication `json:"sparkApplication"`
 	GatewayId        string                  `json:"gatewayId"`
+	GatewayState     GatewayState            `json:"gatewayState"`
 	Cluster          string                  `json:"cluster"`
 	User             string                  `json:"user"`
 	SparkLogURLs     SparkLogURLs            `json:"sparkLogURLs"`
+	// HistoryServer is only set on terminal GatewayApplications when gateway.historyServer is enabled
+	HistoryServer *HistoryServerSummary `json:"historyServer,omitempty"`
+	// Cost is only set when the cluster has cost rates and the application has been submitted
+	Cost *ApplicationCost `json:"cost,omitempty"`
+	// Warnings are only set on responses with a condition the caller should act on, like a submission admitted to a
+	// namespace nearly out of quota or a status URL that failed to render
+	Warnings []SubmissionWarning `json:"warnings,omitempty"`
+}
+
+// RedactedValue replaces spec values that may hold secrets in Redacted GatewayApplications
+const RedactedValue = "REDACTED"
+
+// Redacted returns a copy of the GatewayApplication with spec values that may hold secrets replaced with RedactedValue:
+// arguments, sparkConf, hadoopConf and the driver and executor envs. Keys are kept so the configuration remains
+// readable.
+func (ga *GatewayApplication) Redacted() *GatewayApplication {
+	redacted := *ga
+	redacted.SparkApplication.Spec = *ga.SparkApplication.Spec.DeepCopy()
+
+	spec := &redacted.SparkApplication.Spec
+	for i := range spec.Arguments {
+		spec.Arguments[i] = RedactedValue
+	}
+	redactValues(spec.SparkConf)
+	redactValues(spec.HadoopConf)
+
+	for _, podSpec := range []*v1beta2.SparkPodSpec{&spec.Driver.SparkPodSpec, &spec.Executor.SparkPodSpec} {
+		redactValues(podSpec.EnvVars)
+		for i := range podSpec.Env {
+			if podSpec.Env[i].ValueFrom == nil {
+				podSpec.Env[i].Value = RedactedValue
+			}
+		}
+	}
+
+	return &redacted
+}
+
+func redactValues(values map[string]string) {
+	for key := range values {
+		values[key] = RedactedValue
+	}
 }
 
 // ToLivyBatch maps a GatewayApplication to a LivyBatch object.
@@ -240,36 +713,8 @@ func GatewayApplicationFromV1Beta2SparkApplication(sparkApp *v1beta2.SparkApplic
 	return &GatewayApplication{
 		SparkApplication: *NewGatewaySparkApplication(sparkApp),
 		GatewayId:        gatewayId,
+		GatewayState:     NewGatewayState(sparkApp.Status.AppState.State),
 		Cluster:          cluster,
 		User:             appUser,
 	}
 }
-
-func NewId(cluster KubeCluster, namespace string) (string, error) {
-	// Generate name from clusterId, namespaceId, and UUID
-	uuid, err := uuid.NewV7()
-	if err != nil {
-		return "", fmt.Errorf("error generating application UUID: %w", err)
-	}
-
-	kubeNamespace, err := cluster.GetNamespaceByName(namespace)
-	if err != nil {
-		return "", fmt.Errorf("error generating GatewayId: %w", err)
-	}
-
-	appName := fmt.Sprintf("%s-%s-%s", cluster.ClusterId, kubeNamespace.NamespaceId, uuid)
-
-	return appName, nil
-}
-
-func ParseGatewayIdUUID(gatewayId string) (*uuid.UUID, error) {
-	parts := strings.Split(gatewayId, "-")
-	if len(parts) == 7 {
-		uid, err := uuid.Parse(strings.Join(parts[2:], "-"))
-		if err != nil {
-			return nil, fmt.Errorf("error parsing gateway UUID (%s): %v", gatewayId, err)
-		}
-		return &uid, nil
-	}
-	return nil, fmt.Errorf("error parsing gatewayId (%s). Format must be 'cluster-namespace-uuid'", gatewayId)
-}
@@ -2,8 +2,10 @@ package domain
 
 import (
 	"testing"
+	"time"
 
 	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -75,7 +77,8 @@ func TestNewGatewaySparkApplicationWithUser(t *testing.T) {
 		GatewayApplicationMeta: GatewayApplicationMeta{
 			Namespace: "test",
 			Labels: map[string]string{
-				GATEWAY_USER_LABEL: "user",
+				GATEWAY_USER_LABEL:  "user",
+				GATEWAY_OWNER_LABEL: "user",
 			},
 			Annotations: map[string]string{},
 		},
@@ -203,6 +206,7 @@ func TestNewGatewaySparkApplicationWithAll(t *testing.T) {
 			Namespace: "test",
 			Labels: map[string]string{
 				GATEWAY_USER_LABEL:    "user",
+				GATEWAY_OWNER_LABEL:   "user",
 				GATEWAY_CLUSTER_LABEL: "cluster",
 				"key":                 "value",
 			},
@@ -223,3 +227,236 @@ func TestNewGatewaySparkApplicationWithAll(t *testing.T) {
 
 	assert.Equal(t, &expected, gotApp, "applications should be the same")
 }
+
+func TestParseSummaryView(t *testing.T) {
+	view, err := ParseSummaryView("")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, SummaryViewFull, view, "empty view should default to full")
+
+	view, err = ParseSummaryView("summary")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, SummaryViewSlim, view, "views should match")
+
+	_, err = ParseSummaryView("bad")
+	assert.EqualError(t, err, "invalid view 'bad', valid values: [full summary]", "errors should match")
+}
+
+func TestNewSparkManagerSparkApplicationSummaryView(t *testing.T) {
+	now := v1.Now()
+	sparkApp := v1beta2.SparkApplication{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "test",
+		},
+		Status: v1beta2.SparkApplicationStatus{
+			SparkApplicationID:        "spark-123",
+			SubmissionID:              "submission",
+			LastSubmissionAttemptTime: now,
+			TerminationTime:           now,
+			AppState:                  v1beta2.ApplicationState{State: v1beta2.ApplicationStateRunning},
+			DriverInfo:                v1beta2.DriverInfo{PodName: "driver"},
+			ExecutorState:             map[string]v1beta2.ExecutorState{"exec-1": v1beta2.ExecutorStateRunning},
+		},
+	}
+
+	full := NewSparkManagerSparkApplicationSummaryView(&sparkApp, SummaryViewFull)
+	assert.Equal(t, sparkApp.Status, full.Status, "full view should include full status")
+
+	slim := NewSparkManagerSparkApplicationSummaryView(&sparkApp, SummaryViewSlim)
+	assert.Equal(t, v1beta2.SparkApplicationStatus{
+		SubmissionID:              "submission",
+		LastSubmissionAttemptTime: now,
+		TerminationTime:           now,
+		AppState:                  v1beta2.ApplicationState{State: v1beta2.ApplicationStateRunning},
+	}, slim.Status, "summary view should only include state, timestamps and submissionID")
+	assert.Equal(t, "name", slim.Name, "metadata should be preserved")
+}
+
+func TestParseListSort(t *testing.T) {
+	listSort, err := ParseListSort("", "")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, ListSort{}, listSort, "empty sortBy should not sort")
+
+	listSort, err = ParseListSort("creationTime", "")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, ListSort{By: ListSortByCreationTime, Order: ListSortOrderAsc}, listSort, "order should default to asc")
+
+	listSort, err = ParseListSort("state", "desc")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, ListSort{By: ListSortByState, Order: ListSortOrderDesc}, listSort, "sorts should match")
+
+	_, err = ParseListSort("bad", "")
+	assert.EqualError(t, err, "invalid sortBy 'bad', valid values: [creationTime state name]", "errors should match")
+
+	_, err = ParseListSort("name", "sideways")
+	assert.EqualError(t, err, "invalid order 'sideways', valid values: [asc desc]", "errors should match")
+
+	_, err = ParseListSort("", "desc")
+	assert.EqualError(t, err, "'order' requires 'sortBy' to be set", "errors should match")
+}
+
+func TestSortGatewayApplicationSummaries(t *testing.T) {
+	now := time.Now()
+	newSummary := func(gatewayId string, name string, state v1beta2.ApplicationStateType, created time.Time) *GatewayApplicationSummary {
+		return &GatewayApplicationSummary{
+			SparkManagerSparkApplicationSummary: SparkManagerSparkApplicationSummary{
+				GatewayApplicationMeta: GatewayApplicationMeta{Name: name, CreationTimestamp: v1.NewTime(created)},
+				Status:                 v1beta2.SparkApplicationStatus{AppState: v1beta2.ApplicationState{State: state}},
+			},
+			GatewayId: gatewayId,
+		}
+	}
+
+	a := newSummary("a", "charlie", v1beta2.ApplicationStateRunning, now.Add(-time.Hour))
+	b := newSummary("b", "alpha", v1beta2.ApplicationStateCompleted, now)
+	c := newSummary("c", "bravo", v1beta2.ApplicationStateRunning, now.Add(-2*time.Hour))
+
+	tests := []struct {
+		name     string
+		listSort ListSort
+		expected []*GatewayApplicationSummary
+	}{
+		{"unsorted", ListSort{}, []*GatewayApplicationSummary{a, b, c}},
+		{"creationTime asc", ListSort{By: ListSortByCreationTime, Order: ListSortOrderAsc}, []*GatewayApplicationSummary{c, a, b}},
+		{"creationTime desc", ListSort{By: ListSortByCreationTime, Order: ListSortOrderDesc}, []*GatewayApplicationSummary{b, a, c}},
+		{"name asc", ListSort{By: ListSortByName, Order: ListSortOrderAsc}, []*GatewayApplicationSummary{b, c, a}},
+		{"state asc ties by gatewayId", ListSort{By: ListSortByState, Order: ListSortOrderAsc}, []*GatewayApplicationSummary{b, a, c}},
+		{"state desc ties by gatewayId", ListSort{By: ListSortByState, Order: ListSortOrderDesc}, []*GatewayApplicationSummary{c, a, b}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			summaries := []*GatewayApplicationSummary{a, b, c}
+			SortGatewayApplicationSummaries(summaries, test.listSort)
+			assert.Equal(t, test.expected, summaries, "order should match")
+		})
+	}
+}
+
+func TestParseCountGroupBy(t *testing.T) {
+	groupBy, err := ParseCountGroupBy("")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []CountGroupBy{}, groupBy, "empty groupBy should return no fields")
+
+	groupBy, err = ParseCountGroupBy("namespace, state,namespace")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []CountGroupBy{CountGroupByNamespace, CountGroupByState}, groupBy, "fields should be trimmed and deduplicated")
+
+	_, err = ParseCountGroupBy("namespace,user")
+	assert.EqualError(t, err, "invalid groupBy 'user', valid values: [cluster namespace state]", "errors should match")
+}
+
+func TestAggregateApplicationCounts(t *testing.T) {
+	counts := map[ApplicationCount]int{}
+	AggregateApplicationCounts(counts, "a", SparkManagerApplicationCounts{Namespace: "ns", States: map[string]int{"RUNNING": 1, "NEW": 2}}, []CountGroupBy{CountGroupByCluster})
+	AggregateApplicationCounts(counts, "b", SparkManagerApplicationCounts{Namespace: "ns", States: map[string]int{"RUNNING": 3}}, []CountGroupBy{CountGroupByCluster})
+
+	assert.Equal(t, []*ApplicationCount{
+		{Cluster: "a", Count: 3},
+		{Cluster: "b", Count: 3},
+	}, ApplicationCountList(counts), "counts should be grouped by cluster")
+}
+
+func TestIsSameSubmission(t *testing.T) {
+	mainClass := "Main"
+	submitted := &v1beta2.SparkApplication{
+		ObjectMeta: v1.ObjectMeta{Labels: map[string]string{GATEWAY_USER_LABEL: "user"}},
+		Spec:       v1beta2.SparkApplicationSpec{MainClass: &mainClass},
+	}
+	specHash, err := SparkApplicationSpecHash(submitted)
+	assert.Nil(t, err, "err should be nil")
+
+	existing := submitted.DeepCopy()
+	existing.Annotations = map[string]string{GATEWAY_SPEC_HASH_ANNOTATION: specHash}
+	assert.True(t, IsSameSubmission(existing, submitted), "matching hashes should be the same submission")
+
+	otherUser := submitted.DeepCopy()
+	otherUser.Labels[GATEWAY_USER_LABEL] = "other"
+	assert.False(t, IsSameSubmission(existing, otherUser), "different users should not be the same submission")
+
+	otherSpec := submitted.DeepCopy()
+	otherSpec.Spec.Arguments = []string{"arg"}
+	assert.False(t, IsSameSubmission(existing, otherSpec), "different specs should not be the same submission")
+
+	assert.False(t, IsSameSubmission(submitted, submitted), "existing without a hash should not be the same submission")
+}
+
+func TestNewGatewaySparkApplicationWithSpecHash(t *testing.T) {
+	gotApp := NewGatewaySparkApplication(&v1beta2.SparkApplication{}, WithUser("user"), WithSpecHash())
+
+	specHash, _ := SparkApplicationSpecHash(gotApp.ToV1Beta2SparkApplication())
+	assert.Equal(t, specHash, gotApp.Annotations[GATEWAY_SPEC_HASH_ANNOTATION], "spec hash annotation should be set")
+}
+
+func TestGatewayApplicationRedacted(t *testing.T) {
+	app := &GatewayApplication{
+		GatewayId: "gatewayId",
+		SparkApplication: GatewaySparkApplication{
+			Spec: v1beta2.SparkApplicationSpec{
+				Arguments:  []string{"--token", "secret"},
+				SparkConf:  map[string]string{"spark.key": "secret"},
+				HadoopConf: map[string]string{"fs.s3a.secret.key": "secret"},
+				Driver: v1beta2.DriverSpec{SparkPodSpec: v1beta2.SparkPodSpec{
+					EnvVars: map[string]string{"TOKEN": "secret"},
+				}},
+			},
+		},
+	}
+
+	redacted := app.Redacted()
+
+	assert.Equal(t, "gatewayId", redacted.GatewayId, "metadata should be kept")
+	assert.Equal(t, []string{RedactedValue, RedactedValue}, redacted.SparkApplication.Spec.Arguments, "arguments should be redacted")
+	assert.Equal(t, map[string]string{"spark.key": RedactedValue}, redacted.SparkApplication.Spec.SparkConf, "sparkConf values should be redacted")
+	assert.Equal(t, map[string]string{"fs.s3a.secret.key": RedactedValue}, redacted.SparkApplication.Spec.HadoopConf, "hadoopConf values should be redacted")
+	assert.Equal(t, map[string]string{"TOKEN": RedactedValue}, redacted.SparkApplication.Spec.Driver.EnvVars, "driver envVars should be redacted")
+	assert.Equal(t, "secret", app.SparkApplication.Spec.SparkConf["spark.key"], "original should not be modified")
+}
+
+func TestNewApplicationTimings(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := created.Add(10 * time.Minute)
+
+	tests := []struct {
+		test     string
+		created  time.Time
+		status   v1beta2.SparkApplicationStatus
+		expected *ApplicationTimings
+	}{
+		{
+			test:     "not submitted",
+			created:  created,
+			expected: &ApplicationTimings{QueuedSeconds: util.Ptr(int64(600)), TotalSeconds: util.Ptr(int64(600))},
+		},
+		{
+			test:     "running",
+			created:  created,
+			status:   v1beta2.SparkApplicationStatus{LastSubmissionAttemptTime: v1.NewTime(created.Add(time.Minute))},
+			expected: &ApplicationTimings{QueuedSeconds: util.Ptr(int64(60)), RunSeconds: util.Ptr(int64(540)), TotalSeconds: util.Ptr(int64(600))},
+		},
+		{
+			test:    "terminated",
+			created: created,
+			status: v1beta2.SparkApplicationStatus{
+				LastSubmissionAttemptTime: v1.NewTime(created.Add(time.Minute)),
+				TerminationTime:           v1.NewTime(created.Add(5 * time.Minute)),
+			},
+			expected: &ApplicationTimings{QueuedSeconds: util.Ptr(int64(60)), RunSeconds: util.Ptr(int64(240)), TotalSeconds: util.Ptr(int64(300))},
+		},
+		{
+			test:     "unknown creation",
+			status:   v1beta2.SparkApplicationStatus{LastSubmissionAttemptTime: v1.NewTime(created.Add(time.Minute))},
+			expected: &ApplicationTimings{RunSeconds: util.Ptr(int64(540))},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.test, func(t *testing.T) {
+			var creationTimestamp v1.Time
+			if !test.created.IsZero() {
+				creationTimestamp = v1.NewTime(test.created)
+			}
+			assert.Equal(t, test.expected, NewApplicationTimings(creationTimestamp, test.status, now), "timings should match")
+		})
+	}
+}
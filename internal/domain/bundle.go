@@ -0,0 +1,237 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+)
+
+// GATEWAY_CONFIGMAPS_ANNOTATION carries the ConfigMaps bundled with a submission from the Gateway to SparkManager as
+// JSON. SparkManager removes it before creating the SparkApplication.
+const GATEWAY_CONFIGMAPS_ANNOTATION = "spark-gateway/configmaps"
+
+const (
+	// MaxAuxiliaryConfigMaps is the most ConfigMaps a submission can bundle
+	MaxAuxiliaryConfigMaps = 10
+	// MaxAuxiliaryConfigMapsBytes bounds the encoded size of a submission's ConfigMaps, well within the 256KiB
+	// Kubernetes allows for all annotations of an object
+	MaxAuxiliaryConfigMapsBytes = 128 * 1024
+)
+
+// AuxiliaryConfigMap is a small ConfigMap, e.g. log4j properties, bundled with a submission. It is created in the
+// SparkApplication's namespace as AuxiliaryConfigMapName and deleted with the SparkApplication.
+type AuxiliaryConfigMap struct {
+	Name string            `json:"name"`
+	Data map[string]string `json:"data"`
+}
+
+// submissionItem holds the fields of a List item needed to tell SparkApplications and ConfigMaps apart
+type submissionItem struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+	Data       map[string]string `json:"data"`
+	BinaryData map[string][]byte `json:"binaryData"`
+}
+
+// ParseSubmission splits a submission into the SparkApplication and the ConfigMaps bundled with it. A submission is
+// either a SparkApplication, or a List of one SparkApplication and ConfigMaps in its namespace.
+func ParseSubmission(body []byte) (json.RawMessage, []AuxiliaryConfigMap, error) {
+	var list struct {
+		Kind  string            `json:"kind"`
+		Items []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil || list.Kind != "List" {
+		// Not a List, so the body is bound as a SparkApplication
+		return body, nil, nil
+	}
+
+	var application json.RawMessage
+	var namespace string
+	var items []submissionItem
+	for i, raw := range list.Items {
+		var item submissionItem
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, nil, fmt.Errorf("items[%d] is invalid: %w", i, err)
+		}
+
+		switch {
+		case item.Kind == "SparkApplication":
+			if application != nil {
+				return nil, nil, errors.New("a submission may only have one SparkApplication")
+			}
+			application = raw
+			namespace = item.Metadata.Namespace
+		case item.Kind == "ConfigMap" && (item.APIVersion == "" || item.APIVersion == "v1"):
+			items = append(items, item)
+		default:
+			return nil, nil, fmt.Errorf("items[%d] has unsupported kind '%s', a submission may only have a SparkApplication and v1 ConfigMaps", i, item.Kind)
+		}
+	}
+	if application == nil {
+		return nil, nil, errors.New("a submission must have a SparkApplication")
+	}
+
+	var configMaps []AuxiliaryConfigMap
+	for _, item := range items {
+		if item.Metadata.Namespace != "" && item.Metadata.Namespace != namespace {
+			return nil, nil, fmt.Errorf("ConfigMap '%s' must be in the SparkApplication's namespace '%s'", item.Metadata.Name, namespace)
+		}
+		if len(item.BinaryData) > 0 {
+			return nil, nil, fmt.Errorf("ConfigMap '%s' must not set binaryData", item.Metadata.Name)
+		}
+		configMaps = append(configMaps, AuxiliaryConfigMap{Name: item.Metadata.Name, Data: item.Data})
+	}
+
+	return application, configMaps, nil
+}
+
+// SetAuxiliaryConfigMaps bundles configMaps with application, replacing any it had. Names must be DNS-1123 labels
+// and unique, and the ConfigMaps must fit MaxAuxiliaryConfigMaps and MaxAuxiliaryConfigMapsBytes.
+func SetAuxiliaryConfigMaps(application *v1beta2.SparkApplication, configMaps []AuxiliaryConfigMap) error {
+	delete(application.Annotations, GATEWAY_CONFIGMAPS_ANNOTATION)
+	if len(configMaps) == 0 {
+		return nil
+	}
+
+	if len(configMaps) > MaxAuxiliaryConfigMaps {
+		return fmt.Errorf("a submission may bundle at most %d ConfigMaps, got %d", MaxAuxiliaryConfigMaps, len(configMaps))
+	}
+
+	var errs []error
+	var names []string
+	for _, configMap := range configMaps {
+		if problems := validation.IsDNS1123Label(configMap.Name); len(problems) > 0 {
+			errs = append(errs, fmt.Errorf("ConfigMap name '%s' is invalid: %s", configMap.Name, strings.Join(problems, ", ")))
+		}
+		if slices.Contains(names, configMap.Name) {
+			errs = append(errs, fmt.Errorf("ConfigMap name '%s' is bundled more than once", configMap.Name))
+		}
+		names = append(names, configMap.Name)
+		for key := range configMap.Data {
+			if problems := validation.IsConfigMapKey(key); len(problems) > 0 {
+				errs = append(errs, fmt.Errorf("ConfigMap '%s' key '%s' is invalid: %s", configMap.Name, key, strings.Join(problems, ", ")))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	encoded, err := json.Marshal(configMaps)
+	if err != nil {
+		return fmt.Errorf("error encoding ConfigMaps: %w", err)
+	}
+	if len(encoded) > MaxAuxiliaryConfigMapsBytes {
+		return fmt.Errorf("bundled ConfigMaps may total at most %d bytes, got %d", MaxAuxiliaryConfigMapsBytes, len(encoded))
+	}
+
+	if application.Annotations == nil {
+		application.Annotations = map[string]string{}
+	}
+	application.Annotations[GATEWAY_CONFIGMAPS_ANNOTATION] = string(encoded)
+	return nil
+}
+
+// AuxiliaryConfigMaps returns the ConfigMaps bundled with the SparkApplication annotated with annotations
+func AuxiliaryConfigMaps(annotations map[string]string) ([]AuxiliaryConfigMap, error) {
+	encoded, ok := annotations[GATEWAY_CONFIGMAPS_ANNOTATION]
+	if !ok {
+		return nil, nil
+	}
+
+	var configMaps []AuxiliaryConfigMap
+	decoder := json.NewDecoder(bytes.NewBufferString(encoded))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&configMaps); err != nil {
+		return nil, fmt.Errorf("error decoding %s annotation: %w", GATEWAY_CONFIGMAPS_ANNOTATION, err)
+	}
+	return configMaps, nil
+}
+
+// AuxiliaryConfigMapName returns the name the ConfigMap bundled as name is created with for the SparkApplication
+// appName, so the ConfigMaps of different submissions of the same bundle don't collide
+func AuxiliaryConfigMapName(appName string, name string) string {
+	return fmt.Sprintf("%s-%s", appName, name)
+}
+
+// RenameAuxiliaryConfigMapReferences points the references in spec to the bundled ConfigMaps named names at the
+// names they are created with: spec.sparkConfigMap, spec.hadoopConfigMap, ConfigMap volumes and the configMaps of the
+// driver and executor. References to other ConfigMaps are left alone.
+func RenameAuxiliaryConfigMapReferences(spec *v1beta2.SparkApplicationSpec, appName string, names []string) {
+	rename := func(name string) string {
+		if slices.Contains(names, name) {
+			return AuxiliaryConfigMapName(appName, name)
+		}
+		return name
+	}
+
+	if spec.SparkConfigMap != nil {
+		spec.SparkConfigMap = util.Ptr(rename(*spec.SparkConfigMap))
+	}
+	if spec.HadoopConfigMap != nil {
+		spec.HadoopConfigMap = util.Ptr(rename(*spec.HadoopConfigMap))
+	}
+
+	spec.Volumes = slices.Clone(spec.Volumes)
+	for i := range spec.Volumes {
+		if configMap := spec.Volumes[i].ConfigMap; configMap != nil {
+			renamed := configMap.DeepCopy()
+			renamed.Name = rename(renamed.Name)
+			spec.Volumes[i].ConfigMap = renamed
+		}
+	}
+
+	spec.Driver.ConfigMaps = slices.Clone(spec.Driver.ConfigMaps)
+	for i := range spec.Driver.ConfigMaps {
+		spec.Driver.ConfigMaps[i].Name = rename(spec.Driver.ConfigMaps[i].Name)
+	}
+	spec.Executor.ConfigMaps = slices.Clone(spec.Executor.ConfigMaps)
+	for i := range spec.Executor.ConfigMaps {
+		spec.Executor.ConfigMaps[i].Name = rename(spec.Executor.ConfigMaps[i].Name)
+	}
+}
+
+// WithAuxiliaryConfigMapReferences points the references to bundled ConfigMaps in the spec at the names they are
+// created with. Should be applied after WithId and before WithSpecHash.
+func WithAuxiliaryConfigMapReferences() func(*GatewaySparkApplication) {
+	return func(gsa *GatewaySparkApplication) {
+		configMaps, err := AuxiliaryConfigMaps(gsa.Annotations)
+		if err != nil || len(configMaps) == 0 {
+			// The annotation is set by the Gateway, so it is always valid
+			return
+		}
+
+		var names []string
+		for _, configMap := range configMaps {
+			names = append(names, configMap.Name)
+		}
+		RenameAuxiliaryConfigMapReferences(&gsa.Spec, gsa.Name, names)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+)
+
+func TestParseSubmission(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               string
+		expectedApp        string
+		expectedConfigMaps []AuxiliaryConfigMap
+		expectedErr        string
+	}{
+		{
+			name:        "SparkApplication",
+			body:        `{"kind":"SparkApplication","metadata":{"namespace":"ns"}}`,
+			expectedApp: `{"kind":"SparkApplication","metadata":{"namespace":"ns"}}`,
+		},
+		{
+			name:        "not an object is bound as a SparkApplication",
+			body:        `[]`,
+			expectedApp: `[]`,
+		},
+		{
+			name:               "List with ConfigMaps",
+			body:               `{"kind":"List","items":[{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"log4j","namespace":"ns"},"data":{"log4j.properties":"rootLogger.level=info"}},{"kind":"SparkApplication","metadata":{"namespace":"ns"}}]}`,
+			expectedApp:        `{"kind":"SparkApplication","metadata":{"namespace":"ns"}}`,
+			expectedConfigMaps: []AuxiliaryConfigMap{{Name: "log4j", Data: map[string]string{"log4j.properties": "rootLogger.level=info"}}},
+		},
+		{
+			name:        "List without SparkApplication",
+			body:        `{"kind":"List","items":[{"kind":"ConfigMap","metadata":{"name":"log4j"}}]}`,
+			expectedErr: "a submission must have a SparkApplication",
+		},
+		{
+			name:        "List with two SparkApplications",
+			body:        `{"kind":"List","items":[{"kind":"SparkApplication"},{"kind":"SparkApplication"}]}`,
+			expectedErr: "a submission may only have one SparkApplication",
+		},
+		{
+			name:        "List with a Secret",
+			body:        `{"kind":"List","items":[{"kind":"SparkApplication"},{"apiVersion":"v1","kind":"Secret"}]}`,
+			expectedErr: "items[1] has unsupported kind 'Secret', a submission may only have a SparkApplication and v1 ConfigMaps",
+		},
+		{
+			name:        "ConfigMap in another namespace",
+			body:        `{"kind":"List","items":[{"kind":"SparkApplication","metadata":{"namespace":"ns"}},{"kind":"ConfigMap","metadata":{"name":"log4j","namespace":"other"}}]}`,
+			expectedErr: "ConfigMap 'log4j' must be in the SparkApplication's namespace 'ns'",
+		},
+		{
+			name:        "ConfigMap with binaryData",
+			body:        `{"kind":"List","items":[{"kind":"SparkApplication"},{"kind":"ConfigMap","metadata":{"name":"log4j"},"binaryData":{"key":"AA=="}}]}`,
+			expectedErr: "ConfigMap 'log4j' must not set binaryData",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			app, configMaps, err := ParseSubmission([]byte(test.body))
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr, "error should match")
+				return
+			}
+			assert.NoError(t, err, "submission should parse")
+			assert.Equal(t, test.expectedApp, string(app), "SparkApplication should match")
+			assert.Equal(t, test.expectedConfigMaps, configMaps, "ConfigMaps should match")
+		})
+	}
+}
+
+func TestSetAuxiliaryConfigMaps(t *testing.T) {
+	tests := []struct {
+		name        string
+		configMaps  []AuxiliaryConfigMap
+		expectedErr string
+	}{
+		{
+			name:       "valid ConfigMaps",
+			configMaps: []AuxiliaryConfigMap{{Name: "log4j", Data: map[string]string{"log4j.properties": "rootLogger.level=info"}}},
+		},
+		{
+			name:        "invalid name",
+			configMaps:  []AuxiliaryConfigMap{{Name: "Log4j"}},
+			expectedErr: "ConfigMap name 'Log4j' is invalid",
+		},
+		{
+			name:        "duplicate name",
+			configMaps:  []AuxiliaryConfigMap{{Name: "log4j"}, {Name: "log4j"}},
+			expectedErr: "ConfigMap name 'log4j' is bundled more than once",
+		},
+		{
+			name:        "invalid key",
+			configMaps:  []AuxiliaryConfigMap{{Name: "log4j", Data: map[string]string{"log4j/properties": ""}}},
+			expectedErr: "ConfigMap 'log4j' key 'log4j/properties' is invalid",
+		},
+		{
+			name:        "too large",
+			configMaps:  []AuxiliaryConfigMap{{Name: "log4j", Data: map[string]string{"log4j.properties": strings.Repeat("a", MaxAuxiliaryConfigMapsBytes)}}},
+			expectedErr: "bundled ConfigMaps may total at most",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			application := &v1beta2.SparkApplication{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{GATEWAY_CONFIGMAPS_ANNOTATION: `[{"name":"spoofed"}]`},
+			}}
+
+			err := SetAuxiliaryConfigMaps(application, test.configMaps)
+			if test.expectedErr != "" {
+				assert.ErrorContains(t, err, test.expectedErr, "error should match")
+				return
+			}
+			assert.NoError(t, err, "ConfigMaps should be valid")
+
+			configMaps, err := AuxiliaryConfigMaps(application.Annotations)
+			assert.NoError(t, err, "annotation should decode")
+			assert.Equal(t, test.configMaps, configMaps, "ConfigMaps should round trip through the annotation")
+		})
+	}
+}
+
+func TestWithAuxiliaryConfigMapReferences(t *testing.T) {
+	submitted := &v1beta2.SparkApplication{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns"},
+		Spec: v1beta2.SparkApplicationSpec{
+			SparkConfigMap:  util.Ptr("log4j"),
+			HadoopConfigMap: util.Ptr("shared-hadoop"),
+			Volumes: []corev1.Volume{
+				{Name: "templates", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "templates"}}}},
+				{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			},
+			Driver: v1beta2.DriverSpec{SparkPodSpec: v1beta2.SparkPodSpec{ConfigMaps: []v1beta2.NamePath{{Name: "templates", Path: "/templates"}}}},
+		},
+	}
+	assert.NoError(t, SetAuxiliaryConfigMaps(submitted, []AuxiliaryConfigMap{{Name: "log4j"}, {Name: "templates"}}), "ConfigMaps should be valid")
+
+	gaSparkApp := NewGatewaySparkApplication(submitted, WithId("c1-ns-uuid"), WithAuxiliaryConfigMapReferences())
+
+	assert.Equal(t, "c1-ns-uuid-log4j", *gaSparkApp.Spec.SparkConfigMap, "sparkConfigMap should reference the created ConfigMap")
+	assert.Equal(t, "shared-hadoop", *gaSparkApp.Spec.HadoopConfigMap, "references to other ConfigMaps should be kept")
+	assert.Equal(t, "c1-ns-uuid-templates", gaSparkApp.Spec.Volumes[0].ConfigMap.Name, "ConfigMap volumes should reference the created ConfigMap")
+	assert.Equal(t, "c1-ns-uuid-templates", gaSparkApp.Spec.Driver.ConfigMaps[0].Name, "driver configMaps should reference the created ConfigMap")
+	assert.Equal(t, "log4j", *submitted.Spec.SparkConfigMap, "submitted spec should not be modified")
+	assert.Equal(t, "templates", submitted.Spec.Volumes[0].ConfigMap.Name, "submitted volumes should not be modified")
+}
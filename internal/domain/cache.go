@@ -0,0 +1,33 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import "time"
+
+// CacheStatus describes how fresh the informer cache SparkManager serves SparkApplication lists from is
+type CacheStatus struct {
+	// Synced is false until the informer has completed its initial list
+	Synced bool
+	// ResourceVersion is the resource version of the latest list, watch event or bookmark the informer observed
+	ResourceVersion string
+	// StaleSince is when the informer's watch started failing, zero while it is receiving updates
+	StaleSince time.Time
+}
+
+// Stale returns whether the cache may be missing recent changes
+func (s CacheStatus) Stale() bool {
+	return !s.Synced || !s.StaleSince.IsZero()
+}
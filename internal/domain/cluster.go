@@ -18,21 +18,249 @@ package domain
 import (
 	"fmt"
 	"regexp"
+	"slices"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+)
+
+const (
+	// ProxyUserModeUser always sets spec.proxyUser to the authenticated user
+	ProxyUserModeUser = "user"
+	// ProxyUserModePreserve keeps a submitted spec.proxyUser if the authenticated user is allowed to override it
+	ProxyUserModePreserve = "preserve"
+)
+
+var validProxyUserModes = []string{ProxyUserModeUser, ProxyUserModePreserve}
+
+const (
+	// maxDriverPodNameLength is the Kubernetes DNS label limit that driver pod names, '<gatewayId>-driver', must fit in
+	maxDriverPodNameLength = 63
+	driverPodNameSuffix    = "-driver"
+	// gatewayIdUUIDLength is the length of the UUID part of a GatewayId
+	gatewayIdUUIDLength = 36
+	// maxGatewayIdIdsLength is the combined length budget of a cluster id and namespace id in a GatewayId
+	// 'clusterId-namespaceId-uuid'
+	maxGatewayIdIdsLength = maxDriverPodNameLength - len(driverPodNameSuffix) - gatewayIdUUIDLength - 2
 )
 
+// ProxyUserPolicy controls how spec.proxyUser is set for SparkApplications submitted to a namespace.
+// AllowOverride is a list of regexes matched against the authenticated user to determine who may submit
+// a proxyUser different from themselves when Mode is ProxyUserModePreserve.
+type ProxyUserPolicy struct {
+	Mode          string   `koanf:"mode" default:"user" desc:"user always sets the authenticated user, preserve keeps a submitted proxyUser if allowed"`
+	AllowOverride []string `koanf:"allowOverride" desc:"Regexes of users allowed to submit a different proxyUser in preserve mode"`
+}
+
+// ResolveProxyUser returns the proxyUser that should be set on a SparkApplication submitted by user. If the
+// submitted proxyUser is not allowed by the policy, an error is returned.
+func (p ProxyUserPolicy) ResolveProxyUser(user string, submitted *string) (string, error) {
+	if p.Mode != ProxyUserModePreserve || submitted == nil || *submitted == "" || *submitted == user {
+		return user, nil
+	}
+
+	for _, allow := range p.AllowOverride {
+		allowRegex, err := regexp.Compile(allow)
+		if err != nil {
+			return "", fmt.Errorf("invalid proxyUser allowOverride regex '%s': %w", allow, err)
+		}
+		if allowRegex.MatchString(user) {
+			return *submitted, nil
+		}
+	}
+
+	return "", fmt.Errorf("user '%s' is not allowed to set proxyUser '%s'", user, *submitted)
+}
+
+var validRestartPolicyTypes = []string{string(v1beta2.RestartPolicyNever), string(v1beta2.RestartPolicyOnFailure), string(v1beta2.RestartPolicyAlways)}
+
+// RestartPolicyLimits constrains spec.restartPolicy for SparkApplications submitted to a namespace. AllowedTypes lists
+// the restartPolicy types that may be submitted, with an unset type counting as Never. When set, MaxOnFailureRetries
+// and MaxOnSubmissionFailureRetries cap the respective retries, and are applied to retrying submissions that don't
+// set them so a restart loop is always bounded.
+type RestartPolicyLimits struct {
+	AllowedTypes                  []string `koanf:"allowedTypes" desc:"restartPolicy types that may be submitted: Never, OnFailure or Always"`
+	MaxOnFailureRetries           int32    `koanf:"maxOnFailureRetries" desc:"Cap on restartPolicy.onFailureRetries"`
+	MaxOnSubmissionFailureRetries int32    `koanf:"maxOnSubmissionFailureRetries" desc:"Cap on restartPolicy.onSubmissionFailureRetries"`
+}
+
+// ResolveRestartPolicy returns the restartPolicy that should be set on a SparkApplication submitted with submitted,
+// with retries capped. If the submitted type is not allowed, an error is returned.
+func (l RestartPolicyLimits) ResolveRestartPolicy(submitted v1beta2.RestartPolicy) (v1beta2.RestartPolicy, error) {
+	restartType := submitted.Type
+	if restartType == "" {
+		restartType = v1beta2.RestartPolicyNever
+	}
+
+	if len(l.AllowedTypes) > 0 && !slices.Contains(l.AllowedTypes, string(restartType)) {
+		return v1beta2.RestartPolicy{}, &ValidationError{Results: []ValidationResult{{
+			Field:    "spec.restartPolicy.type",
+			Rule:     "allowedTypes",
+			Message:  fmt.Sprintf("restartPolicy type '%s' is not allowed, allowed types: %v", restartType, l.AllowedTypes),
+			Severity: ValidationSeverityError,
+		}}}
+	}
+
+	resolved := *submitted.DeepCopy()
+	if restartType == v1beta2.RestartPolicyNever {
+		return resolved, nil
+	}
+
+	resolved.OnFailureRetries = capRetries(resolved.OnFailureRetries, l.MaxOnFailureRetries)
+	resolved.OnSubmissionFailureRetries = capRetries(resolved.OnSubmissionFailureRetries, l.MaxOnSubmissionFailureRetries)
+
+	return resolved, nil
+}
+
+func capRetries(retries *int32, limit int32) *int32 {
+	if limit <= 0 || (retries != nil && *retries <= limit) {
+		return retries
+	}
+	return &limit
+}
+
+// KubeNamespace is a namespace SparkApplications can be submitted to. TimeToLiveSeconds is set as
+// spec.timeToLiveSeconds on SparkApplications submitted without one, DefaultLogLines is the number of driver log lines
+// returned when a request doesn't specify it, MaxLogLines caps the lines a request can ask for, EventRetention is
+// how long lifecycle events of its SparkApplications are kept and ApplicationRetention is how long terminal
+// SparkApplications are kept before SparkManager garbage collects them. A value of 0 disables the setting. The global
+// settings of the same name, database.events.retention and sparkManager.garbageCollector.retention, are applied to
+// namespaces that don't set them.
 type KubeNamespace struct {
-	Name          string  `koanf:"name"`
-	NamespaceId   string  `koanf:"id"`
-	RoutingWeight float64 `koanf:"routingWeight"`
+	Name                 string              `koanf:"name" required:"true" desc:"Kubernetes namespace name"`
+	NamespaceId          string              `koanf:"id" required:"true" desc:"Lowercase alphanumeric id used in GatewayIds"`
+	RoutingWeight        float64             `koanf:"routingWeight" default:"1" desc:"Routing weight of the namespace"`
+	ProxyUser            ProxyUserPolicy     `koanf:"proxyUser" desc:"How spec.proxyUser is set"`
+	RestartPolicy        RestartPolicyLimits `koanf:"restartPolicy" desc:"Limits on spec.restartPolicy"`
+	TimeToLiveSeconds    int64               `koanf:"timeToLiveSeconds" desc:"Overrides the global timeToLiveSeconds"`
+	DefaultLogLines      int                 `koanf:"defaultLogLines" desc:"Overrides the global defaultLogLines"`
+	MaxLogLines          int                 `koanf:"maxLogLines" desc:"Overrides the global maxLogLines"`
+	EventRetention       time.Duration       `koanf:"eventRetention" desc:"Overrides database.events.retention"`
+	ApplicationRetention time.Duration       `koanf:"applicationRetention" desc:"Overrides sparkManager.garbageCollector.retention"`
+}
+
+// ResolveLogLines returns the number of driver log lines to fetch for a request asking for tailLines, using
+// DefaultLogLines if tailLines is not positive and capping the result at MaxLogLines.
+func (n KubeNamespace) ResolveLogLines(tailLines int) int {
+	if tailLines <= 0 {
+		tailLines = n.DefaultLogLines
+	}
+	if n.MaxLogLines > 0 && tailLines > n.MaxLogLines {
+		tailLines = n.MaxLogLines
+	}
+	return tailLines
+}
+
+const (
+	// BackendSparkOperator is the default cluster backend, which runs SparkApplications through the Kubeflow Spark Operator
+	BackendSparkOperator = "sparkOperator"
+	// BackendEMROnEKS runs SparkApplications as EMR on EKS job runs in the namespaces' virtual clusters
+	BackendEMROnEKS = "emrOnEks"
+)
+
+// EMROnEKSConfig configures the emrOnEks backend. Driver logs are read from CloudWatch Logs if LogGroupName is set,
+// otherwise from S3 if S3LogUri is set.
+type EMROnEKSConfig struct {
+	Region           string            `koanf:"region" desc:"AWS region of the virtual clusters"`
+	ExecutionRoleArn string            `koanf:"executionRoleArn" desc:"IAM role job runs execute as"`
+	ReleaseLabel     string            `koanf:"releaseLabel" desc:"EMR release label of job runs"`
+	VirtualClusters  map[string]string `koanf:"virtualClusters" desc:"Virtual cluster id per namespace name"`
+	ListWindow       time.Duration     `koanf:"listWindow" desc:"How far back job runs are listed, 24h for emrOnEks clusters"`
+	LogGroupName     string            `koanf:"logGroupName" desc:"CloudWatch Logs group driver logs are read from"`
+	LogStreamPrefix  string            `koanf:"logStreamPrefix" desc:"CloudWatch Logs stream prefix of driver logs"`
+	S3LogUri         string            `koanf:"s3LogUri" desc:"S3 URI driver logs are read from when logGroupName is unset"`
+}
+
+// MetricsScrapeConfig overrides how the cluster router scrapes the cluster's SparkManager metrics, for SparkManagers
+// behind a service mesh or ingress. Unset Port and Path fall back to sparkManager.metricsServer, and TLS only applies
+// with the https Scheme.
+type MetricsScrapeConfig struct {
+	Scheme string           `koanf:"scheme" default:"http" desc:"Scheme metrics are scraped with: http or https"`
+	Port   string           `koanf:"port" desc:"Overrides sparkManager.metricsServer.port"`
+	Path   string           `koanf:"path" desc:"Overrides sparkManager.metricsServer.endpoint"`
+	TLS    MetricsTLSConfig `koanf:"tls" desc:"TLS settings of https scrapes"`
+}
+
+// MetricsTLSConfig configures the TLS client scraping SparkManager metrics over https. CAFile replaces the system roots
+// when set, and CertFile and KeyFile present a client certificate.
+type MetricsTLSConfig struct {
+	CAFile             string `koanf:"caFile" desc:"PEM file of the CAs trusted to sign the server certificate"`
+	CertFile           string `koanf:"certFile" desc:"PEM file of the client certificate"`
+	KeyFile            string `koanf:"keyFile" desc:"PEM file of the client certificate key"`
+	ServerName         string `koanf:"serverName" desc:"Server name verified against the server certificate"`
+	InsecureSkipVerify bool   `koanf:"insecureSkipVerify" desc:"Skips verifying the server certificate"`
+}
+
+const (
+	MetricsScrapeSchemeHTTP  = "http"
+	MetricsScrapeSchemeHTTPS = "https"
+)
+
+var validMetricsScrapeSchemes = []string{MetricsScrapeSchemeHTTP, MetricsScrapeSchemeHTTPS}
+
+// OperatorHealthConfig configures SparkManager checking that the cluster's Spark Operator is running. Every Interval
+// the Deployments in Namespace are read and the operator is down while any has no available replicas. Submissions to a
+// cluster whose operator is down are rejected rather than left in the New state.
+type OperatorHealthConfig struct {
+	Enable      bool          `koanf:"enable" desc:"Enables checking the Spark Operator is running"`
+	Namespace   string        `koanf:"namespace" default:"spark-operator" desc:"Namespace of the Spark Operator Deployments"`
+	Deployments []string      `koanf:"deployments" desc:"Spark Operator Deployments, spark-operator-controller and spark-operator-webhook if unset"`
+	Interval    time.Duration `koanf:"interval" default:"30s" desc:"How often the Deployments are checked"`
+}
+
+// DefaultOperatorDeployments are the Deployments installed by the Kubeflow Spark Operator Helm chart
+var DefaultOperatorDeployments = []string{"spark-operator-controller", "spark-operator-webhook"}
+
+// SparkApplicationCRD identifies the SparkApplication custom resource served by a cluster's API server, for clusters
+// running operator forks that serve the CRD under a different API group, version, kind or resource name.
+type SparkApplicationCRD struct {
+	Group    string `koanf:"group" desc:"API group, sparkoperator.k8s.io if unset"`
+	Version  string `koanf:"version" desc:"API version, v1beta2 if unset"`
+	Kind     string `koanf:"kind" desc:"Kind, SparkApplication if unset"`
+	Resource string `koanf:"resource" desc:"Resource name, sparkapplications if unset"`
+}
+
+// DefaultSparkApplicationCRD is the SparkApplication CRD installed by the Kubeflow Spark Operator
+var DefaultSparkApplicationCRD = SparkApplicationCRD{
+	Group:    v1beta2.SchemeGroupVersion.Group,
+	Version:  v1beta2.SchemeGroupVersion.Version,
+	Kind:     "SparkApplication",
+	Resource: "sparkapplications",
+}
+
+// WithDefaults returns c with unset fields taken from DefaultSparkApplicationCRD.
+func (c SparkApplicationCRD) WithDefaults() SparkApplicationCRD {
+	if c.Group == "" {
+		c.Group = DefaultSparkApplicationCRD.Group
+	}
+	if c.Version == "" {
+		c.Version = DefaultSparkApplicationCRD.Version
+	}
+	if c.Kind == "" {
+		c.Kind = DefaultSparkApplicationCRD.Kind
+	}
+	if c.Resource == "" {
+		c.Resource = DefaultSparkApplicationCRD.Resource
+	}
+	return c
 }
 
 type KubeCluster struct {
-	Name                        string          `koanf:"name"`
-	ClusterId                   string          `koanf:"id"`
-	MasterURL                   string          `koanf:"masterURL"`
-	RoutingWeight               float64         `koanf:"routingWeight"`
-	Namespaces                  []KubeNamespace `koanf:"namespaces"`
-	CertificateAuthorityB64File string          `koanf:"certificateAuthorityB64File"`
+	Name                        string               `koanf:"name" required:"true" desc:"Cluster name, also used to render the SparkManager hostname"`
+	ClusterId                   string               `koanf:"id" required:"true" desc:"Lowercase alphanumeric id used in GatewayIds"`
+	MasterURL                   string               `koanf:"masterURL" required:"true" desc:"Kubernetes API server URL"`
+	RoutingWeight               float64              `koanf:"routingWeight" default:"1" desc:"Routing weight of the cluster"`
+	Namespaces                  []KubeNamespace      `koanf:"namespaces" required:"true" desc:"Namespaces SparkApplications can be submitted to"`
+	CertificateAuthorityB64File string               `koanf:"certificateAuthorityB64File" desc:"File holding the base64 encoded API server CA certificate"`
+	SparkApplicationCRD         SparkApplicationCRD  `koanf:"sparkApplicationCRD" desc:"SparkApplication CRD served by the cluster"`
+	Backend                     string               `koanf:"backend" default:"sparkOperator" desc:"Backend running SparkApplications: sparkOperator or emrOnEks"`
+	EMROnEKS                    EMROnEKSConfig       `koanf:"emrOnEks" desc:"emrOnEks backend"`
+	MetricsScrape               MetricsScrapeConfig  `koanf:"metricsScrape" desc:"How the cluster router scrapes SparkManager metrics"`
+	OperatorHealth              OperatorHealthConfig `koanf:"operatorHealth" desc:"Spark Operator health checks"`
+	Metadata                    MetadataPolicy       `koanf:"metadata" desc:"Labels and annotations added to or stripped from SparkApplications submitted to the cluster"`
+	MaxActiveApplications       int                  `koanf:"maxActiveApplications" desc:"Non-terminal applications at which clusterRouter.concurrencyCap stops routing to the cluster, 0 for no cap"`
+	Cost                        CostRates            `koanf:"cost" desc:"Rates the cost of applications run in the cluster is estimated with"`
+	Labels                      map[string]string    `koanf:"labels" desc:"Labels of the cluster, e.g. topology.kubernetes.io/region, matched against the region and zone submissions prefer"`
 }
 
 func (k *KubeCluster) GetNamespaceById(namespaceId string) (KubeNamespace, error) {
@@ -73,9 +301,60 @@ func ValidateCluster(cluster KubeCluster) (errMessages []string) {
 		errMessages = append(errMessages, "`clusters[].id` can only contain lowercase alphanumeric characters")
 	}
 
+	if cluster.MetricsScrape.Scheme != "" && !slices.Contains(validMetricsScrapeSchemes, cluster.MetricsScrape.Scheme) {
+		errMessages = append(errMessages, fmt.Sprintf("cluster '%s' has invalid `metricsScrape.scheme` '%s', valid values: %v", cluster.Name, cluster.MetricsScrape.Scheme, validMetricsScrapeSchemes))
+	}
+
+	if cluster.MetricsScrape.TLS != (MetricsTLSConfig{}) && cluster.MetricsScrape.Scheme != MetricsScrapeSchemeHTTPS {
+		errMessages = append(errMessages, fmt.Sprintf("cluster '%s' sets `metricsScrape.tls`, which requires `metricsScrape.scheme` https", cluster.Name))
+	}
+
+	if (cluster.MetricsScrape.TLS.CertFile == "") != (cluster.MetricsScrape.TLS.KeyFile == "") {
+		errMessages = append(errMessages, fmt.Sprintf("cluster '%s' must set both or neither of `metricsScrape.tls.certFile` and `metricsScrape.tls.keyFile`", cluster.Name))
+	}
+
+	if cluster.OperatorHealth.Enable && cluster.Backend != "" && cluster.Backend != BackendSparkOperator {
+		errMessages = append(errMessages, fmt.Sprintf("cluster '%s' enables `operatorHealth`, which only applies to the %s backend", cluster.Name, BackendSparkOperator))
+	}
+
+	if cluster.OperatorHealth.Interval < 0 {
+		errMessages = append(errMessages, fmt.Sprintf("cluster '%s' `operatorHealth.interval` must not be negative", cluster.Name))
+	}
+
+	if cluster.MaxActiveApplications < 0 {
+		errMessages = append(errMessages, fmt.Sprintf("cluster '%s' `maxActiveApplications` must not be negative", cluster.Name))
+	}
+
+	if cluster.Cost.CoreHour < 0 || cluster.Cost.GBHour < 0 {
+		errMessages = append(errMessages, fmt.Sprintf("cluster '%s' `cost` rates must not be negative", cluster.Name))
+	}
+
+	for _, problem := range cluster.Metadata.Validate() {
+		errMessages = append(errMessages, fmt.Sprintf("cluster '%s' `metadata` %s", cluster.Name, problem))
+	}
+
 	seenNamespaceIds := map[string]bool{}
+	seenNamespaceNames := map[string]bool{}
 	for _, kubeNamespace := range cluster.Namespaces {
 
+		if kubeNamespace.NamespaceId == "" {
+			errMessages = append(errMessages, fmt.Sprintf("namespace '%s' in cluster '%s' must have an `id`", kubeNamespace.Name, cluster.Name))
+		}
+
+		if seenNamespaceNames[kubeNamespace.Name] {
+			errMessages = append(errMessages, fmt.Sprintf("duplicate namespace name found in cluster '%s' namespaces configuration: '%s'", cluster.Name, kubeNamespace.Name))
+		}
+		seenNamespaceNames[kubeNamespace.Name] = true
+
+		// GatewayIds are used as driver pod names, so they must fit the DNS label limit with the driver suffix
+		if idsLength := len(cluster.ClusterId) + len(kubeNamespace.NamespaceId); idsLength > maxGatewayIdIdsLength {
+			podNameLength := idsLength + 2 + gatewayIdUUIDLength + len(driverPodNameSuffix)
+			errMessages = append(errMessages, fmt.Sprintf(
+				"namespace '%s' in cluster '%s' would produce %d character driver pod names '%s-%s-<uuid>%s', the limit is %d. `clusters[].id` '%s' and namespace `id` '%s' must total at most %d characters",
+				kubeNamespace.Name, cluster.Name, podNameLength, cluster.ClusterId, kubeNamespace.NamespaceId, driverPodNameSuffix, maxDriverPodNameLength, cluster.ClusterId, kubeNamespace.NamespaceId, maxGatewayIdIdsLength,
+			))
+		}
+
 		// Check if dupe id exists
 		_, ok := seenNamespaceIds[kubeNamespace.NamespaceId]
 		if ok {
@@ -92,6 +371,38 @@ func ValidateCluster(cluster KubeCluster) (errMessages []string) {
 		if namespaceMatch {
 			errMessages = append(errMessages, "namespace `id`s can only contain lowercase alphanumeric characters")
 		}
+
+		if kubeNamespace.ProxyUser.Mode != "" && !slices.Contains(validProxyUserModes, kubeNamespace.ProxyUser.Mode) {
+			errMessages = append(errMessages, fmt.Sprintf("namespace '%s' has invalid `proxyUser.mode` '%s', valid values: %v", kubeNamespace.Name, kubeNamespace.ProxyUser.Mode, validProxyUserModes))
+		}
+
+		for _, allow := range kubeNamespace.ProxyUser.AllowOverride {
+			if _, err := regexp.Compile(allow); err != nil {
+				errMessages = append(errMessages, fmt.Sprintf("namespace '%s' has invalid `proxyUser.allowOverride` regex '%s': %v", kubeNamespace.Name, allow, err))
+			}
+		}
+
+		for _, restartType := range kubeNamespace.RestartPolicy.AllowedTypes {
+			if !slices.Contains(validRestartPolicyTypes, restartType) {
+				errMessages = append(errMessages, fmt.Sprintf("namespace '%s' has invalid `restartPolicy.allowedTypes` value '%s', valid values: %v", kubeNamespace.Name, restartType, validRestartPolicyTypes))
+			}
+		}
+
+		if kubeNamespace.RestartPolicy.MaxOnFailureRetries < 0 || kubeNamespace.RestartPolicy.MaxOnSubmissionFailureRetries < 0 {
+			errMessages = append(errMessages, fmt.Sprintf("namespace '%s' `restartPolicy` retry caps must not be negative", kubeNamespace.Name))
+		}
+
+		if kubeNamespace.TimeToLiveSeconds < 0 || kubeNamespace.DefaultLogLines < 0 || kubeNamespace.MaxLogLines < 0 || kubeNamespace.EventRetention < 0 || kubeNamespace.ApplicationRetention < 0 {
+			errMessages = append(errMessages, fmt.Sprintf("namespace '%s' `timeToLiveSeconds`, `defaultLogLines`, `maxLogLines`, `eventRetention` and `applicationRetention` must not be negative", kubeNamespace.Name))
+		}
+
+		if cluster.Backend == BackendEMROnEKS && cluster.EMROnEKS.VirtualClusters[kubeNamespace.Name] == "" {
+			errMessages = append(errMessages, fmt.Sprintf("namespace '%s' in cluster '%s' must have an `emrOnEks.virtualClusters` entry", kubeNamespace.Name, cluster.Name))
+		}
+	}
+
+	if cluster.Backend == BackendEMROnEKS && (cluster.EMROnEKS.Region == "" || cluster.EMROnEKS.ExecutionRoleArn == "" || cluster.EMROnEKS.ReleaseLabel == "") {
+		errMessages = append(errMessages, fmt.Sprintf("cluster '%s' must set `emrOnEks.region`, `emrOnEks.executionRoleArn` and `emrOnEks.releaseLabel` to use the '%s' backend", cluster.Name, BackendEMROnEKS))
 	}
 
 	return errMessages
@@ -17,7 +17,10 @@ package domain
 
 import (
 	"testing"
+	"time"
 
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -118,7 +121,58 @@ var clusterValidateTests = []struct {
 				},
 			},
 		},
-		errs: []string{"namespace `id`s must be less than 13 characters"},
+		errs: []string{
+			"namespace 'namespace' in cluster 'valid-cluster' would produce 65 character driver pod names 'id-namespaceidtoolong-<uuid>-driver', the limit is 63. `clusters[].id` 'id' and namespace `id` 'namespaceidtoolong' must total at most 18 characters",
+			"namespace `id`s must be less than 13 characters",
+		},
+	},
+	{
+		test: "cluster and namespace ids exceed driver pod name budget",
+		cluster: KubeCluster{
+			Name:      "valid-cluster",
+			ClusterId: "clusterid123",
+			MasterURL: "masterURL",
+			Namespaces: []KubeNamespace{
+				{
+					Name:        "namespace",
+					NamespaceId: "namespace1",
+				},
+			},
+		},
+		errs: []string{"namespace 'namespace' in cluster 'valid-cluster' would produce 67 character driver pod names 'clusterid123-namespace1-<uuid>-driver', the limit is 63. `clusters[].id` 'clusterid123' and namespace `id` 'namespace1' must total at most 18 characters"},
+	},
+	{
+		test: "missing namespace id",
+		cluster: KubeCluster{
+			Name:      "valid-cluster",
+			ClusterId: "id",
+			MasterURL: "masterURL",
+			Namespaces: []KubeNamespace{
+				{
+					Name: "namespace",
+				},
+			},
+		},
+		errs: []string{"namespace 'namespace' in cluster 'valid-cluster' must have an `id`"},
+	},
+	{
+		test: "duplicate namespace names",
+		cluster: KubeCluster{
+			Name:      "valid-cluster",
+			ClusterId: "id",
+			MasterURL: "masterURL",
+			Namespaces: []KubeNamespace{
+				{
+					Name:        "namespace",
+					NamespaceId: "id1",
+				},
+				{
+					Name:        "namespace",
+					NamespaceId: "id2",
+				},
+			},
+		},
+		errs: []string{"duplicate namespace name found in cluster 'valid-cluster' namespaces configuration: 'namespace'"},
 	},
 	{
 		test: "invalid namespace id",
@@ -135,6 +189,165 @@ var clusterValidateTests = []struct {
 		},
 		errs: []string{"namespace `id`s can only contain lowercase alphanumeric characters"},
 	},
+	{
+		test: "invalid proxyUser mode",
+		cluster: KubeCluster{
+			Name:      "valid-cluster",
+			ClusterId: "id",
+			MasterURL: "masterURL",
+			Namespaces: []KubeNamespace{
+				{
+					Name:        "namespace",
+					NamespaceId: "id",
+					ProxyUser: ProxyUserPolicy{
+						Mode: "bad",
+					},
+				},
+			},
+		},
+		errs: []string{"namespace 'namespace' has invalid `proxyUser.mode` 'bad', valid values: [user preserve]"},
+	},
+	{
+		test: "invalid proxyUser allowOverride regex",
+		cluster: KubeCluster{
+			Name:      "valid-cluster",
+			ClusterId: "id",
+			MasterURL: "masterURL",
+			Namespaces: []KubeNamespace{
+				{
+					Name:        "namespace",
+					NamespaceId: "id",
+					ProxyUser: ProxyUserPolicy{
+						Mode:          ProxyUserModePreserve,
+						AllowOverride: []string{"*"},
+					},
+				},
+			},
+		},
+		errs: []string{"namespace 'namespace' has invalid `proxyUser.allowOverride` regex '*': error parsing regexp: missing argument to repetition operator: `*`"},
+	},
+	{
+		test: "invalid restartPolicy allowedTypes",
+		cluster: KubeCluster{
+			Name:      "valid-cluster",
+			ClusterId: "id",
+			MasterURL: "masterURL",
+			Namespaces: []KubeNamespace{
+				{
+					Name:        "namespace",
+					NamespaceId: "id",
+					RestartPolicy: RestartPolicyLimits{
+						AllowedTypes: []string{"Sometimes"},
+					},
+				},
+			},
+		},
+		errs: []string{"namespace 'namespace' has invalid `restartPolicy.allowedTypes` value 'Sometimes', valid values: [Never OnFailure Always]"},
+	},
+	{
+		test: "emrOnEks backend missing settings",
+		cluster: KubeCluster{
+			Name:      "valid-cluster",
+			ClusterId: "id",
+			MasterURL: "masterURL",
+			Backend:   BackendEMROnEKS,
+			Namespaces: []KubeNamespace{
+				{
+					Name:        "namespace",
+					NamespaceId: "id",
+				},
+			},
+		},
+		errs: []string{
+			"namespace 'namespace' in cluster 'valid-cluster' must have an `emrOnEks.virtualClusters` entry",
+			"cluster 'valid-cluster' must set `emrOnEks.region`, `emrOnEks.executionRoleArn` and `emrOnEks.releaseLabel` to use the 'emrOnEks' backend",
+		},
+	},
+	{
+		test: "valid emrOnEks backend",
+		cluster: KubeCluster{
+			Name:      "valid-cluster",
+			ClusterId: "id",
+			MasterURL: "masterURL",
+			Backend:   BackendEMROnEKS,
+			EMROnEKS: EMROnEKSConfig{
+				Region:           "us-east-1",
+				ExecutionRoleArn: "arn:aws:iam::123456789012:role/emr",
+				ReleaseLabel:     "emr-7.1.0-latest",
+				VirtualClusters:  map[string]string{"namespace": "vc1"},
+			},
+			Namespaces: []KubeNamespace{
+				{
+					Name:        "namespace",
+					NamespaceId: "id",
+				},
+			},
+		},
+	},
+	{
+		test: "invalid metrics scrape config",
+		cluster: KubeCluster{
+			Name:      "valid-cluster",
+			ClusterId: "id",
+			MasterURL: "masterURL",
+			MetricsScrape: MetricsScrapeConfig{
+				Scheme: "tcp",
+				TLS:    MetricsTLSConfig{CertFile: "cert.pem"},
+			},
+			Namespaces: []KubeNamespace{
+				{
+					Name:        "namespace",
+					NamespaceId: "id",
+				},
+			},
+		},
+		errs: []string{
+			"cluster 'valid-cluster' has invalid `metricsScrape.scheme` 'tcp', valid values: [http https]",
+			"cluster 'valid-cluster' sets `metricsScrape.tls`, which requires `metricsScrape.scheme` https",
+			"cluster 'valid-cluster' must set both or neither of `metricsScrape.tls.certFile` and `metricsScrape.tls.keyFile`",
+		},
+	},
+	{
+		test: "https metrics scrape config",
+		cluster: KubeCluster{
+			Name:      "valid-cluster",
+			ClusterId: "id",
+			MasterURL: "masterURL",
+			MetricsScrape: MetricsScrapeConfig{
+				Scheme: MetricsScrapeSchemeHTTPS,
+				Port:   "443",
+				TLS:    MetricsTLSConfig{CAFile: "ca.pem", CertFile: "cert.pem", KeyFile: "key.pem"},
+			},
+			Namespaces: []KubeNamespace{
+				{
+					Name:        "namespace",
+					NamespaceId: "id",
+				},
+			},
+		},
+	},
+	{
+		test: "operator health on non sparkOperator backend",
+		cluster: KubeCluster{
+			Name:           "valid-cluster",
+			ClusterId:      "id",
+			MasterURL:      "masterURL",
+			Backend:        BackendEMROnEKS,
+			OperatorHealth: OperatorHealthConfig{Enable: true, Interval: -time.Second},
+			Namespaces: []KubeNamespace{
+				{
+					Name:        "namespace",
+					NamespaceId: "id",
+				},
+			},
+		},
+		errs: []string{
+			"cluster 'valid-cluster' enables `operatorHealth`, which only applies to the sparkOperator backend",
+			"cluster 'valid-cluster' `operatorHealth.interval` must not be negative",
+			"namespace 'namespace' in cluster 'valid-cluster' must have an `emrOnEks.virtualClusters` entry",
+			"cluster 'valid-cluster' must set `emrOnEks.region`, `emrOnEks.executionRoleArn` and `emrOnEks.releaseLabel` to use the 'emrOnEks' backend",
+		},
+	},
 }
 
 func TestClusterValidation(t *testing.T) {
@@ -145,3 +358,123 @@ func TestClusterValidation(t *testing.T) {
 		})
 	}
 }
+
+var resolveProxyUserTests = []struct {
+	test      string
+	policy    ProxyUserPolicy
+	user      string
+	submitted *string
+	expected  string
+	err       string
+}{
+	{
+		test:      "default mode uses authenticated user",
+		policy:    ProxyUserPolicy{},
+		user:      "alice",
+		submitted: util.Ptr("hdfs-svc"),
+		expected:  "alice",
+	},
+	{
+		test:      "user mode uses authenticated user",
+		policy:    ProxyUserPolicy{Mode: ProxyUserModeUser, AllowOverride: []string{".*"}},
+		user:      "alice",
+		submitted: util.Ptr("hdfs-svc"),
+		expected:  "alice",
+	},
+	{
+		test:     "preserve mode with no submitted proxyUser",
+		policy:   ProxyUserPolicy{Mode: ProxyUserModePreserve},
+		user:     "alice",
+		expected: "alice",
+	},
+	{
+		test:      "preserve mode with submitted proxyUser matching user",
+		policy:    ProxyUserPolicy{Mode: ProxyUserModePreserve},
+		user:      "alice",
+		submitted: util.Ptr("alice"),
+		expected:  "alice",
+	},
+	{
+		test:      "preserve mode with allowed override",
+		policy:    ProxyUserPolicy{Mode: ProxyUserModePreserve, AllowOverride: []string{"^airflow-.*"}},
+		user:      "airflow-prod",
+		submitted: util.Ptr("bob"),
+		expected:  "bob",
+	},
+	{
+		test:      "preserve mode with disallowed override",
+		policy:    ProxyUserPolicy{Mode: ProxyUserModePreserve, AllowOverride: []string{"^airflow-.*"}},
+		user:      "alice",
+		submitted: util.Ptr("bob"),
+		err:       "user 'alice' is not allowed to set proxyUser 'bob'",
+	},
+}
+
+func TestResolveProxyUser(t *testing.T) {
+	for _, test := range resolveProxyUserTests {
+		t.Run(test.test, func(t *testing.T) {
+			proxyUser, err := test.policy.ResolveProxyUser(test.user, test.submitted)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err, "errors should match")
+				return
+			}
+
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, test.expected, proxyUser, "proxyUser should match")
+		})
+	}
+}
+
+var resolveRestartPolicyTests = []struct {
+	test      string
+	limits    RestartPolicyLimits
+	submitted v1beta2.RestartPolicy
+	expected  v1beta2.RestartPolicy
+	err       string
+}{
+	{
+		test:      "no limits keeps submitted policy",
+		limits:    RestartPolicyLimits{},
+		submitted: v1beta2.RestartPolicy{Type: v1beta2.RestartPolicyAlways},
+		expected:  v1beta2.RestartPolicy{Type: v1beta2.RestartPolicyAlways},
+	},
+	{
+		test:      "unset type counts as Never",
+		limits:    RestartPolicyLimits{AllowedTypes: []string{"Never"}, MaxOnFailureRetries: 3},
+		submitted: v1beta2.RestartPolicy{},
+		expected:  v1beta2.RestartPolicy{},
+	},
+	{
+		test:      "disallowed type is rejected",
+		limits:    RestartPolicyLimits{AllowedTypes: []string{"Never", "OnFailure"}},
+		submitted: v1beta2.RestartPolicy{Type: v1beta2.RestartPolicyAlways},
+		err:       "restartPolicy type 'Always' is not allowed, allowed types: [Never OnFailure]",
+	},
+	{
+		test:      "retries above the cap are capped",
+		limits:    RestartPolicyLimits{MaxOnFailureRetries: 3, MaxOnSubmissionFailureRetries: 2},
+		submitted: v1beta2.RestartPolicy{Type: v1beta2.RestartPolicyOnFailure, OnFailureRetries: util.Ptr(int32(100)), OnSubmissionFailureRetries: util.Ptr(int32(1))},
+		expected:  v1beta2.RestartPolicy{Type: v1beta2.RestartPolicyOnFailure, OnFailureRetries: util.Ptr(int32(3)), OnSubmissionFailureRetries: util.Ptr(int32(1))},
+	},
+	{
+		test:      "unset retries get the cap",
+		limits:    RestartPolicyLimits{MaxOnFailureRetries: 3},
+		submitted: v1beta2.RestartPolicy{Type: v1beta2.RestartPolicyOnFailure},
+		expected:  v1beta2.RestartPolicy{Type: v1beta2.RestartPolicyOnFailure, OnFailureRetries: util.Ptr(int32(3))},
+	},
+}
+
+func TestResolveRestartPolicy(t *testing.T) {
+	for _, test := range resolveRestartPolicyTests {
+		t.Run(test.test, func(t *testing.T) {
+			restartPolicy, err := test.limits.ResolveRestartPolicy(test.submitted)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err, "errors should match")
+				return
+			}
+
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, test.expected, restartPolicy, "restartPolicy should match")
+		})
+	}
+}
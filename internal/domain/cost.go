@@ -0,0 +1,78 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+)
+
+// CostRates price the resources SparkApplications request in a cluster, so their cost can be estimated. Costs are only
+// estimated when either rate is set.
+type CostRates struct {
+	CoreHour float64 `koanf:"coreHour" desc:"Price of a CPU core requested for an hour, costs aren't estimated if neither rate is set"`
+	GBHour   float64 `koanf:"gbHour" desc:"Price of a GiB of memory requested for an hour"`
+	Currency string  `koanf:"currency" default:"USD" desc:"Currency of the rates, returned with cost estimates"`
+}
+
+// Enabled returns whether costs are estimated with the rates
+func (r CostRates) Enabled() bool {
+	return r.CoreHour > 0 || r.GBHour > 0
+}
+
+// ApplicationCost is the estimated cost of the CPU and memory a SparkApplication requests for its driver and
+// executors, from its last submission attempt until it terminated, or until now while it runs. Final is set once the
+// application terminated, so the estimate no longer grows.
+type ApplicationCost struct {
+	Currency  string  `json:"currency" example:"USD"`
+	Estimate  float64 `json:"estimate" example:"1.25"`
+	CoreHours float64 `json:"coreHours" example:"20"`
+	GBHours   float64 `json:"gbHours" example:"80"`
+	Final     bool    `json:"final"`
+}
+
+// NewApplicationCost estimates the cost at rates of an application with status requesting cores and memoryBytes. It
+// returns nil if the rates aren't enabled or the application hasn't been submitted yet.
+func NewApplicationCost(rates CostRates, status v1beta2.SparkApplicationStatus, cores float64, memoryBytes float64, now time.Time) *ApplicationCost {
+	if !rates.Enabled() || status.LastSubmissionAttemptTime.IsZero() {
+		return nil
+	}
+
+	final := IsTerminalApplicationState(status.AppState.State)
+	end := now
+	if final && !status.TerminationTime.IsZero() {
+		end = status.TerminationTime.Time
+	}
+	hours := max(end.Sub(status.LastSubmissionAttemptTime.Time).Hours(), 0)
+
+	coreHours := cores * hours
+	gbHours := memoryBytes / (1 << 30) * hours
+
+	return &ApplicationCost{
+		Currency:  rates.Currency,
+		Estimate:  roundCost(coreHours*rates.CoreHour + gbHours*rates.GBHour),
+		CoreHours: roundCost(coreHours),
+		GBHours:   roundCost(gbHours),
+		Final:     final,
+	}
+}
+
+// roundCost rounds v to 4 decimal places, so estimates don't carry floating point noise
+func roundCost(v float64) float64 {
+	return math.Round(v*1e4) / 1e4
+}
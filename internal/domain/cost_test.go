@@ -0,0 +1,81 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewApplicationCost(t *testing.T) {
+	rates := CostRates{CoreHour: 0.05, GBHour: 0.01, Currency: "USD"}
+	submitted := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := submitted.Add(2 * time.Hour)
+
+	running := v1beta2.SparkApplicationStatus{
+		AppState:                  v1beta2.ApplicationState{State: v1beta2.ApplicationStateRunning},
+		LastSubmissionAttemptTime: metav1.NewTime(submitted),
+	}
+	completed := v1beta2.SparkApplicationStatus{
+		AppState:                  v1beta2.ApplicationState{State: v1beta2.ApplicationStateCompleted},
+		LastSubmissionAttemptTime: metav1.NewTime(submitted),
+		TerminationTime:           metav1.NewTime(submitted.Add(30 * time.Minute)),
+	}
+
+	tests := []struct {
+		name   string
+		rates  CostRates
+		status v1beta2.SparkApplicationStatus
+		want   *ApplicationCost
+	}{
+		{
+			name:   "rates not set",
+			rates:  CostRates{Currency: "USD"},
+			status: running,
+			want:   nil,
+		},
+		{
+			name:   "not submitted",
+			rates:  rates,
+			status: v1beta2.SparkApplicationStatus{},
+			want:   nil,
+		},
+		{
+			name:   "running until now",
+			rates:  rates,
+			status: running,
+			want:   &ApplicationCost{Currency: "USD", Estimate: 0.56, CoreHours: 8, GBHours: 16, Final: false},
+		},
+		{
+			name:   "completed at termination",
+			rates:  rates,
+			status: completed,
+			want:   &ApplicationCost{Currency: "USD", Estimate: 0.14, CoreHours: 2, GBHours: 4, Final: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// 4 cores and 8GiB requested
+			got := NewApplicationCost(test.rates, test.status, 4, 8*(1<<30), now)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
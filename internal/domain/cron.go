@@ -0,0 +1,210 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronHorizon is how far ahead CronSchedule.Next looks for a matching time, so expressions that never match, e.g. the
+// 30th of February, don't loop forever
+const cronHorizon = 5 * 366 * 24 * time.Hour
+
+// cronMacros are the shorthands accepted in place of the 5 fields
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var cronDayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// cronField is a set of the values a field matches, bit i set if value i matches
+type cronField uint64
+
+func (f cronField) has(value int) bool {
+	return f&(1<<uint(value)) != 0
+}
+
+// CronSchedule is a parsed standard 5 field cron expression: minute, hour, day of month, month and day of week, in UTC.
+// Fields accept *, values, ranges, steps and lists, e.g. `*/15 9-17 * * MON-FRI`, and months and days of the week their
+// 3 letter names. As with cron, a time matches if both the day of month and day of week match, or either of them when
+// both are restricted. The @yearly, @monthly, @weekly, @daily and @hourly shorthands are also accepted.
+type CronSchedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek cronField
+	// restrictedDays is set if neither the day of month nor the day of week is *
+	restrictedDays bool
+}
+
+// ParseCronSchedule parses a cron expression, returning an error if it is invalid or never matches
+func ParseCronSchedule(expression string) (*CronSchedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) == 1 {
+		macro, ok := cronMacros[strings.ToLower(fields[0])]
+		if !ok {
+			return nil, fmt.Errorf("invalid cron expression '%s': unknown shorthand", expression)
+		}
+		fields = strings.Fields(macro)
+	}
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression '%s': expected 5 fields, got %d", expression, len(fields))
+	}
+
+	var schedule CronSchedule
+	var err error
+	if schedule.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron expression '%s': minute: %w", expression, err)
+	}
+	if schedule.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron expression '%s': hour: %w", expression, err)
+	}
+	if schedule.dayOfMonth, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron expression '%s': day of month: %w", expression, err)
+	}
+	if schedule.month, err = parseCronField(fields[3], 1, 12, cronMonthNames); err != nil {
+		return nil, fmt.Errorf("invalid cron expression '%s': month: %w", expression, err)
+	}
+	// Sunday is both 0 and 7
+	if schedule.dayOfWeek, err = parseCronField(fields[4], 0, 7, cronDayNames); err != nil {
+		return nil, fmt.Errorf("invalid cron expression '%s': day of week: %w", expression, err)
+	}
+	if schedule.dayOfWeek.has(7) {
+		schedule.dayOfWeek |= 1
+	}
+	schedule.restrictedDays = fields[2] != "*" && fields[4] != "*"
+
+	if schedule.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("invalid cron expression '%s': never matches", expression)
+	}
+
+	return &schedule, nil
+}
+
+// parseCronField parses a comma separated list of *, values, ranges and steps between minValue and maxValue. names
+// maps lower case names to values.
+func parseCronField(field string, minValue int, maxValue int, names map[string]int) (cronField, error) {
+	var set cronField
+	for part := range strings.SplitSeq(field, ",") {
+		valueRange, stepText, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepText); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step '%s'", stepText)
+			}
+		}
+
+		var low, high int
+		switch {
+		case valueRange == "*":
+			low, high = minValue, maxValue
+		case strings.Contains(valueRange, "-"):
+			lowText, highText, _ := strings.Cut(valueRange, "-")
+			var err error
+			if low, err = parseCronValue(lowText, minValue, maxValue, names); err != nil {
+				return 0, err
+			}
+			if high, err = parseCronValue(highText, minValue, maxValue, names); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid range '%s'", valueRange)
+			}
+		default:
+			var err error
+			if low, err = parseCronValue(valueRange, minValue, maxValue, names); err != nil {
+				return 0, err
+			}
+			// A step from a single value runs to the end of the field, e.g. 5/15
+			high = low
+			if hasStep {
+				high = maxValue
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			set |= 1 << uint(value)
+		}
+	}
+
+	return set, nil
+}
+
+func parseCronValue(text string, minValue int, maxValue int, names map[string]int) (int, error) {
+	if value, ok := names[strings.ToLower(text)]; ok {
+		return value, nil
+	}
+
+	value, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value '%s'", text)
+	}
+	if value < minValue || value > maxValue {
+		return 0, fmt.Errorf("value %d is outside %d-%d", value, minValue, maxValue)
+	}
+	return value, nil
+}
+
+// Next returns the first time after after matching the schedule, in UTC, or the zero time if none does within 5 years
+func (s CronSchedule) Next(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	horizon := t.Add(cronHorizon)
+
+	for t.Before(horizon) {
+		if !s.month.has(int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.hour.has(t.Hour()) {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if !s.minute.has(t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s CronSchedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.dayOfMonth.has(t.Day())
+	dayOfWeek := s.dayOfWeek.has(int(t.Weekday()))
+	if s.restrictedDays {
+		return dayOfMonth || dayOfWeek
+	}
+	return dayOfMonth && dayOfWeek
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCronScheduleErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@every 5m",
+		"0 0 30 feb *",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := ParseCronSchedule(expr)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// Wednesday
+	after := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{expr: "* * * * *", want: time.Date(2025, 1, 1, 10, 31, 0, 0, time.UTC)},
+		{expr: "*/15 * * * *", want: time.Date(2025, 1, 1, 10, 45, 0, 0, time.UTC)},
+		{expr: "0 2 * * *", want: time.Date(2025, 1, 2, 2, 0, 0, 0, time.UTC)},
+		{expr: "0 9-17/4 * * *", want: time.Date(2025, 1, 1, 13, 0, 0, 0, time.UTC)},
+		{expr: "0,45 10 * * *", want: time.Date(2025, 1, 1, 10, 45, 0, 0, time.UTC)},
+		{expr: "0 0 * * mon", want: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * 0", want: time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * 7", want: time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 1 mar *", want: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Day of month or day of week when both are restricted
+		{expr: "0 0 15 * fri", want: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{expr: "@hourly", want: time.Date(2025, 1, 1, 11, 0, 0, 0, time.UTC)},
+		{expr: "@daily", want: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{expr: "@weekly", want: time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{expr: "@monthly", want: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "@yearly", want: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			schedule, err := ParseCronSchedule(test.expr)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, schedule.Next(after))
+		})
+	}
+}
+
+func TestCronScheduleNextIsAfter(t *testing.T) {
+	schedule, err := ParseCronSchedule("30 10 * * *")
+	assert.NoError(t, err)
+
+	after := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 1, 2, 10, 30, 0, 0, time.UTC), schedule.Next(after), "Next should be strictly after the time passed")
+
+	local := time.Date(2025, 1, 1, 11, 30, 0, 0, time.FixedZone("UTC+1", 3600))
+	assert.Equal(t, time.Date(2025, 1, 2, 10, 30, 0, 0, time.UTC), schedule.Next(local), "cron expressions should be evaluated in UTC")
+}
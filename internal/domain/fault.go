@@ -0,0 +1,74 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AllFaultTargets is the fault injection target matching every call without a rule of its own
+const AllFaultTargets = "*"
+
+// FaultRule describes the faults injected into calls to a target: the SparkManager of a cluster for the Gateway, or
+// a Kubernetes API verb for SparkManager. Each call is delayed by Latency, then either failed with a connection reset
+// with probability ResetRate, answered with ErrorStatus with probability ErrorRate, or sent.
+type FaultRule struct {
+	// Latency is a Go duration added before each call
+	Latency string `json:"latency,omitempty" example:"250ms"`
+	// ErrorRate is the fraction of calls answered with ErrorStatus without being sent
+	ErrorRate float64 `json:"errorRate,omitempty" example:"0.1"`
+	// ErrorStatus is the HTTP status of injected errors, 503 when unset
+	ErrorStatus int `json:"errorStatus,omitempty" example:"503"`
+	// ResetRate is the fraction of calls failed with a connection reset without being sent
+	ResetRate float64 `json:"resetRate,omitempty" example:"0.05"`
+}
+
+// Validate checks the rule can be applied
+func (r FaultRule) Validate() error {
+	var errs []error
+	if r.Latency != "" {
+		latency, err := time.ParseDuration(r.Latency)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("latency is invalid: %w", err))
+		} else if latency < 0 {
+			errs = append(errs, errors.New("latency must not be negative"))
+		}
+	}
+	if r.ErrorRate < 0 || r.ErrorRate > 1 {
+		errs = append(errs, errors.New("errorRate must be between 0 and 1"))
+	}
+	if r.ResetRate < 0 || r.ResetRate > 1 {
+		errs = append(errs, errors.New("resetRate must be between 0 and 1"))
+	}
+	if r.ErrorRate+r.ResetRate > 1 {
+		errs = append(errs, errors.New("errorRate and resetRate must not add up to more than 1"))
+	}
+	if r.ErrorStatus != 0 && (r.ErrorStatus < 400 || r.ErrorStatus > 599) {
+		errs = append(errs, errors.New("errorStatus must be a 4xx or 5xx status"))
+	}
+	return errors.Join(errs...)
+}
+
+// LatencyDuration returns the rule's Latency, or 0 when it is unset or invalid
+func (r FaultRule) LatencyDuration() time.Duration {
+	latency, err := time.ParseDuration(r.Latency)
+	if err != nil {
+		return 0
+	}
+	return latency
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// gatewayIdUUIDNamespace is the namespace of the UUIDs derived from GatewayIds not ending with a UUID
+var gatewayIdUUIDNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com/slackhq/spark-gateway/gateway-id"))
+
+// NewId generates a GatewayId ending with a UUIDv7, so GatewayIds sort in the order they were generated
+func NewId(cluster KubeCluster, namespace string) (string, error) {
+	uuid, err := uuid.NewV7()
+	if err != nil {
+		return "", fmt.Errorf("error generating application UUID: %w", err)
+	}
+
+	return newGatewayId(cluster, namespace, uuid.String())
+}
+
+// NewUUIDv4Id generates a GatewayId ending with a random UUIDv4
+func NewUUIDv4Id(cluster KubeCluster, namespace string) (string, error) {
+	uuid, err := uuid.NewRandom()
+	if err != nil {
+		return "", fmt.Errorf("error generating application UUID: %w", err)
+	}
+
+	return newGatewayId(cluster, namespace, uuid.String())
+}
+
+// NewSnowflakeId generates a GatewayId ending with a snowflake id, a decimal 63 bit integer of the milliseconds since
+// 2025-01-01, a node and a sequence number, which is shorter than a UUID and sorts in the order it was generated
+func NewSnowflakeId(cluster KubeCluster, namespace string) (string, error) {
+	return newGatewayId(cluster, namespace, fmt.Sprint(defaultSnowflakes.next(time.Now())))
+}
+
+// newGatewayId returns the GatewayId of cluster and namespace ending with id
+func newGatewayId(cluster KubeCluster, namespace string, id string) (string, error) {
+	kubeNamespace, err := cluster.GetNamespaceByName(namespace)
+	if err != nil {
+		return "", fmt.Errorf("error generating GatewayId: %w", err)
+	}
+
+	return fmt.Sprintf("%s-%s-%s", cluster.ClusterId, kubeNamespace.NamespaceId, id), nil
+}
+
+// ParseGatewayId returns the cluster and namespace ids a GatewayId starts with, in the 'cluster-namespace-id' format
+func ParseGatewayId(gatewayId string) (clusterId string, namespaceId string, err error) {
+	parts := strings.SplitN(gatewayId, "-", 3)
+	if len(parts) < 3 {
+		return "", "", fmt.Errorf("invalid gatewayId '%s', format must be 'cluster-namespace-uuid'", gatewayId)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// ValidateGatewayId checks that gatewayId can name a SparkApplication and is parsed back into cluster and namespace,
+// so a GatewayId generator can't create applications the Gateway can't find again
+func ValidateGatewayId(gatewayId string, cluster KubeCluster, namespace string) error {
+	if problems := validation.IsDNS1123Label(gatewayId); len(problems) > 0 {
+		return fmt.Errorf("GatewayId '%s' can't name a SparkApplication: %s", gatewayId, strings.Join(problems, ", "))
+	}
+
+	clusterId, namespaceId, err := ParseGatewayId(gatewayId)
+	if err != nil {
+		return err
+	}
+	if clusterId != cluster.ClusterId {
+		return fmt.Errorf("GatewayId '%s' is parsed as cluster id '%s' instead of '%s'", gatewayId, clusterId, cluster.ClusterId)
+	}
+	kubeNamespace, err := cluster.GetNamespaceById(namespaceId)
+	if err != nil || kubeNamespace.Name != namespace {
+		return fmt.Errorf("GatewayId '%s' is not parsed as namespace '%s' of cluster '%s'", gatewayId, namespace, cluster.Name)
+	}
+
+	return nil
+}
+
+// ParseGatewayIdUUID returns the UUID identifying gatewayId in the database: the UUID it ends with, or a UUID derived
+// from the whole GatewayId for GatewayIds generated with other schemes
+func ParseGatewayIdUUID(gatewayId string) (*uuid.UUID, error) {
+	parts := strings.SplitN(gatewayId, "-", 3)
+	if len(parts) < 3 || parts[2] == "" {
+		return nil, fmt.Errorf("error parsing gatewayId (%s). Format must be 'cluster-namespace-uuid'", gatewayId)
+	}
+
+	if uid, err := uuid.Parse(parts[2]); err == nil {
+		return &uid, nil
+	}
+
+	uid := uuid.NewSHA1(gatewayIdUUIDNamespace, []byte(gatewayId))
+	return &uid, nil
+}
+
+// snowflakeEpoch is the start of the timestamps of snowflake ids
+var snowflakeEpoch = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// defaultSnowflakes generates the snowflake ids of this process. Its node is picked at random, distinct Gateway
+// instances may rarely share one and generate the same id in the same millisecond, which Create regenerates.
+var defaultSnowflakes = &snowflakes{node: rand.Int64N(1 << 10)}
+
+// snowflakes generates snowflake ids: 41 bits of milliseconds since snowflakeEpoch, 10 bits of node and 12 bits of
+// sequence number within the millisecond
+type snowflakes struct {
+	lock       sync.Mutex
+	node       int64
+	lastMillis int64
+	sequence   int64
+}
+
+func (s *snowflakes) next(now time.Time) int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	millis := now.Sub(snowflakeEpoch).Milliseconds()
+	if millis <= s.lastMillis {
+		// Within the same millisecond, or the clock went back: count on from the last id
+		millis = s.lastMillis
+		s.sequence = (s.sequence + 1) & (1<<12 - 1)
+		if s.sequence == 0 {
+			millis++
+		}
+	} else {
+		s.sequence = 0
+	}
+	s.lastMillis = millis
+
+	return millis<<22 | s.node<<12 | s.sequence
+}
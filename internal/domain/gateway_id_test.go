@@ -0,0 +1,95 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var gatewayIdTestCluster = KubeCluster{
+	Name:       "cluster",
+	ClusterId:  "clusterid",
+	Namespaces: []KubeNamespace{{Name: "testNamespace", NamespaceId: "nsid"}},
+}
+
+func TestGatewayIdGenerators(t *testing.T) {
+	for name, generate := range map[string]func(KubeCluster, string) (string, error){
+		"uuidv7":    NewId,
+		"uuidv4":    NewUUIDv4Id,
+		"snowflake": NewSnowflakeId,
+	} {
+		gatewayId, err := generate(gatewayIdTestCluster, "testNamespace")
+		assert.NoError(t, err, "%s: generating should not error", name)
+		assert.NoError(t, ValidateGatewayId(gatewayId, gatewayIdTestCluster, "testNamespace"), "%s: generated GatewayIds should be valid", name)
+
+		_, err = generate(gatewayIdTestCluster, "missing")
+		assert.Error(t, err, "%s: namespaces missing from the cluster should error", name)
+	}
+}
+
+func TestValidateGatewayId(t *testing.T) {
+	tests := []struct {
+		name      string
+		gatewayId string
+		valid     bool
+	}{
+		{name: "uuid", gatewayId: "clusterid-nsid-01982d11-c2c1-7c3d-8b2f-944ae7248434", valid: true},
+		{name: "snowflake", gatewayId: "clusterid-nsid-8131948860329984", valid: true},
+		{name: "uppercase", gatewayId: "clusterid-nsid-2Luv5ZkQ", valid: false},
+		{name: "no id", gatewayId: "clusterid-nsid", valid: false},
+		{name: "other cluster", gatewayId: "otherid-nsid-1", valid: false},
+		{name: "other namespace", gatewayId: "clusterid-otherns-1", valid: false},
+	}
+
+	for _, test := range tests {
+		err := ValidateGatewayId(test.gatewayId, gatewayIdTestCluster, "testNamespace")
+		assert.Equal(t, test.valid, err == nil, "%s: validity should match, got %v", test.name, err)
+	}
+}
+
+func TestParseGatewayIdUUID(t *testing.T) {
+	uid, err := ParseGatewayIdUUID("clusterid-nsid-01982d11-c2c1-7c3d-8b2f-944ae7248434")
+	assert.NoError(t, err)
+	assert.Equal(t, uuid.MustParse("01982d11-c2c1-7c3d-8b2f-944ae7248434"), *uid, "the UUID a GatewayId ends with should be used")
+
+	uid, err = ParseGatewayIdUUID("clusterid-nsid-8131948860329984")
+	assert.NoError(t, err)
+	again, _ := ParseGatewayIdUUID("clusterid-nsid-8131948860329984")
+	other, _ := ParseGatewayIdUUID("clusterid-nsid-8131948860329985")
+	assert.Equal(t, uid, again, "UUIDs derived from a GatewayId should be stable")
+	assert.NotEqual(t, uid, other, "distinct GatewayIds should derive distinct UUIDs")
+
+	_, err = ParseGatewayIdUUID("spark-pi")
+	assert.Error(t, err, "names that aren't GatewayIds should error")
+}
+
+func TestSnowflakes(t *testing.T) {
+	snowflakes := &snowflakes{node: 5}
+	now := snowflakeEpoch.Add(time.Second)
+
+	first := snowflakes.next(now)
+	second := snowflakes.next(now)
+	assert.Equal(t, int64(1000)<<22|5<<12, first, "ids should hold the milliseconds since the epoch and the node")
+	assert.Equal(t, first+1, second, "ids in the same millisecond should count the sequence up")
+	assert.Greater(t, snowflakes.next(now.Add(-time.Minute)), second, "ids should keep increasing when the clock goes back")
+
+	snowflakes.sequence = 1<<12 - 1
+	assert.Equal(t, int64(1001)<<22|5<<12, snowflakes.next(now), "an exhausted sequence should move on to the next millisecond")
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"time"
+)
+
+// HistoryServerSummary summarizes a completed Spark application as recorded by the Spark History Server, returned
+// with terminal GatewayApplications when gateway.historyServer is enabled
+type HistoryServerSummary struct {
+	SparkApplicationID string                   `json:"sparkApplicationId"`
+	StartTime          *time.Time               `json:"startTime,omitempty"`
+	EndTime            *time.Time               `json:"endTime,omitempty"`
+	DurationSeconds    float64                  `json:"durationSeconds"`
+	Attempts           int                      `json:"attempts"`
+	Jobs               HistoryServerJobCounts   `json:"jobs"`
+	Stages             HistoryServerStageCounts `json:"stages"`
+	FailedStages       []HistoryServerStage     `json:"failedStages,omitempty"`
+	LongestStages      []HistoryServerStage     `json:"longestStages,omitempty"`
+}
+
+// HistoryServerJobCounts counts the jobs of a Spark application by status
+type HistoryServerJobCounts struct {
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	Other     int `json:"other,omitempty"`
+}
+
+// HistoryServerStageCounts counts the stage attempts of a Spark application by status
+type HistoryServerStageCounts struct {
+	Complete int `json:"complete"`
+	Failed   int `json:"failed"`
+	Skipped  int `json:"skipped"`
+	Other    int `json:"other,omitempty"`
+}
+
+// HistoryServerStage is a stage attempt of a Spark application. FailureReason is only set on failed stages.
+type HistoryServerStage struct {
+	StageID         int     `json:"stageId"`
+	AttemptID       int     `json:"attemptId"`
+	Name            string  `json:"name"`
+	Status          string  `json:"status"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	FailureReason   string  `json:"failureReason,omitempty"`
+}
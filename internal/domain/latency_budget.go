@@ -0,0 +1,157 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Phases of a request a LatencyBudget is divided among
+const (
+	// LatencyBudgetRouting is choosing the cluster a submission goes to
+	LatencyBudgetRouting = "routing"
+	// LatencyBudgetRoutingRetry is retrying routing with the fallback cluster router
+	LatencyBudgetRoutingRetry = "routingRetry"
+	// LatencyBudgetSparkManager is a call to a SparkManager
+	LatencyBudgetSparkManager = "sparkManager"
+)
+
+// LatencyBudget bounds how long the Gateway takes to serve a request. Phases with a share may use at most that share of
+// Total from when they start, the others may use whatever is left, so a slow router leaves time for the SparkManager
+// call. It is safe to record to from concurrent calls.
+type LatencyBudget struct {
+	Total  time.Duration
+	Shares map[string]float64
+
+	start  time.Time
+	mu     sync.Mutex
+	phases []LatencyBudgetPhase
+	// exhausted is set once a phase ran out of its share
+	exhausted bool
+}
+
+// LatencyBudgetPhase is how long a phase of a request took out of its LatencyBudget. Error is set when the phase
+// failed, including when it ran out of budget.
+type LatencyBudgetPhase struct {
+	Phase           string  `json:"phase"`
+	Cluster         string  `json:"cluster,omitempty"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	Error           string  `json:"error,omitempty"`
+}
+
+// LatencyBudgetError is returned for requests that ran out of their LatencyBudget, with the phases that completed or
+// were cut short as partial diagnostics
+type LatencyBudgetError struct {
+	BudgetSeconds  float64              `json:"budgetSeconds"`
+	ElapsedSeconds float64              `json:"elapsedSeconds"`
+	Phases         []LatencyBudgetPhase `json:"phases"`
+}
+
+func (e *LatencyBudgetError) Error() string {
+	return fmt.Sprintf("latency budget of %v exceeded after %v", time.Duration(e.BudgetSeconds*float64(time.Second)), time.Duration(e.ElapsedSeconds*float64(time.Second)).Round(time.Millisecond))
+}
+
+// NewLatencyBudget returns a LatencyBudget of total starting now
+func NewLatencyBudget(total time.Duration, shares map[string]float64) *LatencyBudget {
+	return &LatencyBudget{
+		Total:  total,
+		Shares: shares,
+		start:  time.Now(),
+		phases: []LatencyBudgetPhase{},
+	}
+}
+
+type latencyBudgetKey struct{}
+
+// WithLatencyBudget returns a copy of ctx whose phases are bounded by budget
+func WithLatencyBudget(ctx context.Context, budget *LatencyBudget) context.Context {
+	return context.WithValue(ctx, latencyBudgetKey{}, budget)
+}
+
+// LatencyBudgetFrom returns the LatencyBudget of ctx, or nil if the request has none. Phases of a nil LatencyBudget are
+// unbounded.
+func LatencyBudgetFrom(ctx context.Context) *LatencyBudget {
+	budget, _ := ctx.Value(latencyBudgetKey{}).(*LatencyBudget)
+	return budget
+}
+
+// Deadline is when the budget runs out
+func (b *LatencyBudget) Deadline() time.Time {
+	return b.start.Add(b.Total)
+}
+
+// Phase returns a copy of ctx bounded by what phase may use of the budget, and a function recording the phase's outcome
+// that must be called once it ends
+func (b *LatencyBudget) Phase(ctx context.Context, phase string, cluster string) (context.Context, func(err error)) {
+	if b == nil {
+		return ctx, func(error) {}
+	}
+
+	start := time.Now()
+	deadline := b.Deadline()
+	if share, ok := b.Shares[phase]; ok && share > 0 {
+		if shareDeadline := start.Add(time.Duration(share * float64(b.Total))); shareDeadline.Before(deadline) {
+			deadline = shareDeadline
+		}
+	}
+	phaseCtx, cancel := context.WithDeadline(ctx, deadline)
+
+	return phaseCtx, func(err error) {
+		exhausted := errors.Is(phaseCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil
+		cancel()
+		b.record(phase, cluster, time.Since(start), err, exhausted)
+	}
+}
+
+func (b *LatencyBudget) record(phase string, cluster string, duration time.Duration, err error, exhausted bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.exhausted = b.exhausted || exhausted
+
+	recorded := LatencyBudgetPhase{Phase: phase, Cluster: cluster, DurationSeconds: duration.Seconds()}
+	if err != nil {
+		recorded.Error = err.Error()
+	}
+	b.phases = append(b.phases, recorded)
+}
+
+// Exhausted is true once the budget or the share of one of its phases has run out
+func (b *LatencyBudget) Exhausted() bool {
+	if b == nil {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.exhausted || !time.Now().Before(b.Deadline())
+}
+
+// Err returns the LatencyBudgetError describing how the budget was spent so far
+func (b *LatencyBudget) Err() *LatencyBudgetError {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return &LatencyBudgetError{
+		BudgetSeconds:  b.Total.Seconds(),
+		ElapsedSeconds: time.Since(b.start).Seconds(),
+		Phases:         append([]LatencyBudgetPhase{}, b.phases...),
+	}
+}
@@ -25,9 +25,10 @@ import (
 )
 
 const (
-	LIVY_BATCH_ID_LABEL   string             = "spark-gateway/livy-batch-id"
-	DEFAULT_SPARK_VERSION string             = "3"
-	DEFAULT_SPARK_MODE    v1beta2.DeployMode = v1beta2.DeployModeCluster
+	LIVY_BATCH_ID_LABEL    string             = "spark-gateway/livy-batch-id"
+	LIVY_APPLICATION_LABEL string             = "spark-gateway/livy"
+	DEFAULT_SPARK_VERSION  string             = "3"
+	DEFAULT_SPARK_MODE     v1beta2.DeployMode = v1beta2.DeployModeCluster
 )
 
 type LivySessionState int
@@ -55,17 +56,20 @@ var sessionStateName = map[LivySessionState]string{
 	LivySessionStateError:        "error",
 	LivySessionStateDead:         "dead",
 	LivySessionStateKilled:       "killed",
-	LivySessionStateSuccess:      "finished",
+	LivySessionStateSuccess:      "success",
 }
 
+// applicationTypeToSessionStateName maps SparkApplication states to the states Apache Livy reports for batches. Livy
+// batches that fail are dead, error is only reported by interactive sessions. PendingRerun applications are starting
+// again, clients such as Airflow treat dead batches as terminal.
 var applicationTypeToSessionStateName = map[v1beta2.ApplicationStateType]LivySessionState{
 	v1beta2.ApplicationStateNew:              LivySessionStateNotStarted,
 	v1beta2.ApplicationStateSubmitted:        LivySessionStateStarting,
 	v1beta2.ApplicationStateRunning:          LivySessionStateRunning,
 	v1beta2.ApplicationStateCompleted:        LivySessionStateSuccess,
-	v1beta2.ApplicationStateFailed:           LivySessionStateError,
+	v1beta2.ApplicationStateFailed:           LivySessionStateDead,
 	v1beta2.ApplicationStateFailedSubmission: LivySessionStateDead,
-	v1beta2.ApplicationStatePendingRerun:     LivySessionStateDead,
+	v1beta2.ApplicationStatePendingRerun:     LivySessionStateStarting,
 	v1beta2.ApplicationStateInvalidating:     LivySessionStateShuttingDown,
 	v1beta2.ApplicationStateSucceeding:       LivySessionStateShuttingDown,
 	v1beta2.ApplicationStateFailing:          LivySessionStateShuttingDown,
@@ -148,6 +152,9 @@ func (c *LivyCreateBatchRequest) ToV1Beta2SparkApplication(namespace string) *v1
 		ObjectMeta: v1.ObjectMeta{
 			Name:      c.Name,
 			Namespace: namespace,
+			Labels: map[string]string{
+				LIVY_APPLICATION_LABEL: "true",
+			},
 		},
 		Spec: v1beta2.SparkApplicationSpec{
 			Type:                appType,
@@ -189,11 +196,13 @@ type LivyListBatchesResponse struct {
 	Sessions []*LivyBatch `json:"sessions"`
 }
 
+// LivyLogBatchResponse is a page of batch logs. Logs are tailed, so the page returned is the whole log as far as clients
+// paginating it with From and Total are concerned.
 type LivyLogBatchResponse struct {
-	Id   int      `json:"id"`
-	From int      `json:"from"`
-	Size int      `json:"size"`
-	Log  []string `json:"log"`
+	Id    int      `json:"id"`
+	From  int      `json:"from"`
+	Total int      `json:"total"`
+	Log   []string `json:"log"`
 }
 
 type LivyGetBatchStateResponse struct {
@@ -49,6 +49,9 @@ func TestLivyBatchToV1Beta2Application(t *testing.T) {
 		},
 		ObjectMeta: v1.ObjectMeta{
 			Name: "name",
+			Labels: map[string]string{
+				LIVY_APPLICATION_LABEL: "true",
+			},
 		},
 		Spec: v1beta2.SparkApplicationSpec{
 			Type:                "Java",
@@ -139,6 +142,9 @@ func TestLivyBatchToV1Beta2ApplicationNamespace(t *testing.T) {
 		ObjectMeta: v1.ObjectMeta{
 			Name:      "name",
 			Namespace: "namespace",
+			Labels: map[string]string{
+				LIVY_APPLICATION_LABEL: "true",
+			},
 		},
 		Spec: v1beta2.SparkApplicationSpec{
 			Type:                "Java",
@@ -203,7 +209,7 @@ func TestLivySessionState_String(t *testing.T) {
 		{LivySessionStateError, "error"},
 		{LivySessionStateDead, "dead"},
 		{LivySessionStateKilled, "killed"},
-		{LivySessionStateSuccess, "finished"}, // Apache Livy uses "finished" for successful completion
+		{LivySessionStateSuccess, "success"},
 	}
 
 	for _, tt := range tests {
@@ -222,9 +228,9 @@ func TestFromV1Beta2ApplicationState(t *testing.T) {
 		{v1beta2.ApplicationStateSubmitted, LivySessionStateStarting},
 		{v1beta2.ApplicationStateRunning, LivySessionStateRunning},
 		{v1beta2.ApplicationStateCompleted, LivySessionStateSuccess},
-		{v1beta2.ApplicationStateFailed, LivySessionStateError},
+		{v1beta2.ApplicationStateFailed, LivySessionStateDead},
 		{v1beta2.ApplicationStateFailedSubmission, LivySessionStateDead},
-		{v1beta2.ApplicationStatePendingRerun, LivySessionStateDead},
+		{v1beta2.ApplicationStatePendingRerun, LivySessionStateStarting},
 		{v1beta2.ApplicationStateInvalidating, LivySessionStateShuttingDown},
 		{v1beta2.ApplicationStateSucceeding, LivySessionStateShuttingDown},
 		{v1beta2.ApplicationStateFailing, LivySessionStateShuttingDown},
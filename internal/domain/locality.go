@@ -0,0 +1,69 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GATEWAY_PREFERRED_REGION_ANNOTATION and GATEWAY_PREFERRED_ZONE_ANNOTATION are the region and zone a submission
+// prefers to run in, e.g. the region of the S3 buckets it reads. They are matched against the
+// topology.kubernetes.io/region and topology.kubernetes.io/zone labels of the clusters.
+const GATEWAY_PREFERRED_REGION_ANNOTATION = "spark-gateway/preferred-region"
+const GATEWAY_PREFERRED_ZONE_ANNOTATION = "spark-gateway/preferred-zone"
+
+// LocalityPreference is the region and zone a submission prefers to be routed to. Unset fields match any cluster.
+type LocalityPreference struct {
+	Region string
+	Zone   string
+}
+
+// NewLocalityPreference returns the LocalityPreference set in the annotations of a submission
+func NewLocalityPreference(annotations map[string]string) LocalityPreference {
+	return LocalityPreference{
+		Region: annotations[GATEWAY_PREFERRED_REGION_ANNOTATION],
+		Zone:   annotations[GATEWAY_PREFERRED_ZONE_ANNOTATION],
+	}
+}
+
+// IsZero returns whether no locality is preferred
+func (l LocalityPreference) IsZero() bool {
+	return l.Region == "" && l.Zone == ""
+}
+
+// Matches returns whether the labels of cluster match the preferred region and zone
+func (l LocalityPreference) Matches(cluster KubeCluster) bool {
+	if l.Region != "" && cluster.Labels[corev1.LabelTopologyRegion] != l.Region {
+		return false
+	}
+	if l.Zone != "" && cluster.Labels[corev1.LabelTopologyZone] != l.Zone {
+		return false
+	}
+	return true
+}
+
+func (l LocalityPreference) String() string {
+	var parts []string
+	if l.Region != "" {
+		parts = append(parts, "region "+l.Region)
+	}
+	if l.Zone != "" {
+		parts = append(parts, "zone "+l.Zone)
+	}
+	return strings.Join(parts, " and ")
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalityPreference(t *testing.T) {
+	locality := NewLocalityPreference(map[string]string{
+		GATEWAY_PREFERRED_REGION_ANNOTATION: "us-east-1",
+		GATEWAY_PREFERRED_ZONE_ANNOTATION:   "us-east-1a",
+	})
+	assert.Equal(t, LocalityPreference{Region: "us-east-1", Zone: "us-east-1a"}, locality)
+	assert.Equal(t, "region us-east-1 and zone us-east-1a", locality.String())
+
+	assert.True(t, locality.Matches(KubeCluster{Labels: map[string]string{"topology.kubernetes.io/region": "us-east-1", "topology.kubernetes.io/zone": "us-east-1a"}}))
+	assert.False(t, locality.Matches(KubeCluster{Labels: map[string]string{"topology.kubernetes.io/region": "us-east-1", "topology.kubernetes.io/zone": "us-east-1b"}}), "the zone should match too")
+	assert.False(t, locality.Matches(KubeCluster{}), "unlabeled clusters should not match")
+
+	assert.True(t, NewLocalityPreference(nil).IsZero(), "submissions without annotations prefer no locality")
+	assert.True(t, NewLocalityPreference(nil).Matches(KubeCluster{}), "no preference matches every cluster")
+}
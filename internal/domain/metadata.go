@@ -0,0 +1,125 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// gatewayMetadataPrefix prefixes the labels and annotations owned by the Gateway, which MetadataPolicies can't change
+const gatewayMetadataPrefix = "spark-gateway/"
+
+// MetadataPolicy adjusts the labels and annotations of SparkApplications submitted to a cluster, and of their driver
+// and executor pods, e.g. to add cost center labels or turn off sidecar injection. Submitted keys matching
+// StripLabels or StripAnnotations are removed first, where a trailing * matches any suffix. Labels and Annotations are
+// then set, overriding submitted values. The Gateway's own spark-gateway/ labels and annotations are never changed.
+type MetadataPolicy struct {
+	Labels           map[string]string `koanf:"labels" desc:"Labels set on SparkApplications and their pods, overriding submitted values"`
+	Annotations      map[string]string `koanf:"annotations" desc:"Annotations set on SparkApplications and their pods, overriding submitted values"`
+	StripLabels      []string          `koanf:"stripLabels" desc:"Submitted label keys removed, a trailing * matches any suffix"`
+	StripAnnotations []string          `koanf:"stripAnnotations" desc:"Submitted annotation keys removed, a trailing * matches any suffix"`
+}
+
+// Validate returns a message for each key or value of the policy that Kubernetes would reject or that belongs to the
+// Gateway
+func (p MetadataPolicy) Validate() (errMessages []string) {
+	for key, value := range p.Labels {
+		errMessages = append(errMessages, validateMetadataKey("labels", key)...)
+		for _, problem := range validation.IsValidLabelValue(value) {
+			errMessages = append(errMessages, fmt.Sprintf("`labels` value '%s' of '%s' is invalid: %s", value, key, problem))
+		}
+	}
+	for key := range p.Annotations {
+		errMessages = append(errMessages, validateMetadataKey("annotations", key)...)
+	}
+	for _, pattern := range p.StripLabels {
+		errMessages = append(errMessages, validateMetadataKey("stripLabels", strings.TrimSuffix(pattern, "*"))...)
+	}
+	for _, pattern := range p.StripAnnotations {
+		errMessages = append(errMessages, validateMetadataKey("stripAnnotations", strings.TrimSuffix(pattern, "*"))...)
+	}
+	return errMessages
+}
+
+func validateMetadataKey(field string, key string) []string {
+	if strings.HasPrefix(key, gatewayMetadataPrefix) {
+		return []string{fmt.Sprintf("`%s` key '%s' is owned by the Gateway", field, key)}
+	}
+	// Patterns may be cut anywhere, so only check whole keys
+	if key == "" || strings.HasSuffix(key, "/") || strings.HasSuffix(key, ".") || strings.HasSuffix(key, "-") {
+		return nil
+	}
+
+	var errMessages []string
+	for _, problem := range validation.IsQualifiedName(key) {
+		errMessages = append(errMessages, fmt.Sprintf("`%s` key '%s' is invalid: %s", field, key, problem))
+	}
+	return errMessages
+}
+
+// Apply returns labels and annotations adjusted by the policy. The passed maps aren't modified.
+func (p MetadataPolicy) Apply(labels map[string]string, annotations map[string]string) (map[string]string, map[string]string) {
+	return applyMetadata(labels, p.StripLabels, p.Labels), applyMetadata(annotations, p.StripAnnotations, p.Annotations)
+}
+
+func applyMetadata(submitted map[string]string, strip []string, set map[string]string) map[string]string {
+	if len(strip) == 0 && len(set) == 0 {
+		return submitted
+	}
+
+	adjusted := maps.Clone(submitted)
+	if adjusted == nil {
+		adjusted = map[string]string{}
+	}
+	maps.DeleteFunc(adjusted, func(key string, _ string) bool {
+		return !strings.HasPrefix(key, gatewayMetadataPrefix) && matchesAny(key, strip)
+	})
+	maps.Copy(adjusted, set)
+	return adjusted
+}
+
+func matchesAny(key string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// WithMetadataPolicy applies the target cluster's MetadataPolicy to the application and its driver and executor pods.
+// Should be applied before the options setting the Gateway's own labels and annotations.
+func WithMetadataPolicy(policy MetadataPolicy) func(*GatewaySparkApplication) {
+	return func(gsa *GatewaySparkApplication) {
+		gsa.Labels, gsa.Annotations = policy.Apply(gsa.Labels, gsa.Annotations)
+		gsa.Spec.Driver.SparkPodSpec = applyPodMetadata(policy, gsa.Spec.Driver.SparkPodSpec)
+		gsa.Spec.Executor.SparkPodSpec = applyPodMetadata(policy, gsa.Spec.Executor.SparkPodSpec)
+	}
+}
+
+func applyPodMetadata(policy MetadataPolicy, podSpec v1beta2.SparkPodSpec) v1beta2.SparkPodSpec {
+	podSpec.Labels, podSpec.Annotations = policy.Apply(podSpec.Labels, podSpec.Annotations)
+	return podSpec
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWithMetadataPolicy(t *testing.T) {
+	policy := MetadataPolicy{
+		Labels:           map[string]string{"cost-center": "data"},
+		Annotations:      map[string]string{"sidecar.istio.io/inject": "false"},
+		StripLabels:      []string{"team.example.com/*", "environment"},
+		StripAnnotations: []string{"*"},
+	}
+
+	submitted := &v1beta2.SparkApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: "ns",
+			Labels: map[string]string{
+				"cost-center":           "spoofed",
+				"team.example.com/name": "eng",
+				"environment":           "dev",
+				"version":               "1",
+				GATEWAY_TEAM_LABEL:      "data",
+			},
+			Annotations: map[string]string{
+				"sidecar.istio.io/inject":      "true",
+				"example.com/owner":            "alice",
+				GATEWAY_ACTING_USER_ANNOTATION: "airflow",
+			},
+		},
+		Spec: v1beta2.SparkApplicationSpec{
+			Driver: v1beta2.DriverSpec{SparkPodSpec: v1beta2.SparkPodSpec{
+				Labels: map[string]string{"environment": "dev"},
+			}},
+		},
+	}
+
+	gaSparkApp := NewGatewaySparkApplication(submitted, WithMetadataPolicy(policy), WithCluster("cluster"))
+
+	assert.Equal(t, map[string]string{
+		"cost-center":         "data",
+		"version":             "1",
+		GATEWAY_TEAM_LABEL:    "data",
+		GATEWAY_CLUSTER_LABEL: "cluster",
+	}, gaSparkApp.Labels, "labels should be stripped, then set, keeping Gateway labels")
+	assert.Equal(t, map[string]string{
+		"sidecar.istio.io/inject":      "false",
+		GATEWAY_ACTING_USER_ANNOTATION: "airflow",
+	}, gaSparkApp.Annotations, "annotations should be stripped, then set, keeping Gateway annotations")
+	assert.Equal(t, map[string]string{"cost-center": "data"}, gaSparkApp.Spec.Driver.Labels, "driver labels should follow the policy")
+	assert.Equal(t, map[string]string{"sidecar.istio.io/inject": "false"}, gaSparkApp.Spec.Executor.Annotations, "executor annotations should follow the policy")
+	assert.Equal(t, "spoofed", submitted.Labels["cost-center"], "submitted labels should not be modified")
+	assert.Equal(t, "dev", submitted.Spec.Driver.Labels["environment"], "submitted driver labels should not be modified")
+}
+
+func TestMetadataPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy MetadataPolicy
+		errs   int
+	}{
+		{
+			name: "valid policy",
+			policy: MetadataPolicy{
+				Labels:           map[string]string{"cost-center": "data"},
+				Annotations:      map[string]string{"sidecar.istio.io/inject": "false"},
+				StripLabels:      []string{"team.example.com/*", "*"},
+				StripAnnotations: []string{"example.com/owner"},
+			},
+		},
+		{
+			name:   "gateway owned keys",
+			policy: MetadataPolicy{Labels: map[string]string{GATEWAY_USER_LABEL: "alice"}, StripAnnotations: []string{"spark-gateway/*"}},
+			errs:   2,
+		},
+		{
+			name:   "invalid label key and value",
+			policy: MetadataPolicy{Labels: map[string]string{"cost center": "data science"}},
+			errs:   2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Len(t, test.policy.Validate(), test.errs, "errors should match")
+		})
+	}
+}
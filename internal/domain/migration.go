@@ -0,0 +1,83 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"maps"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GATEWAY_MIGRATED_FROM_ANNOTATION is the GatewayId an application was migrated from, and
+// GATEWAY_ORIGINAL_GATEWAY_ID_ANNOTATION the GatewayId it was first submitted with, shared by all its migrations.
+const GATEWAY_MIGRATED_FROM_ANNOTATION = "spark-gateway/migrated-from"
+const GATEWAY_ORIGINAL_GATEWAY_ID_ANNOTATION = "spark-gateway/original-gateway-id"
+
+// MigrationRequest names the cluster GatewayApplications are migrated to
+type MigrationRequest struct {
+	Cluster string `json:"cluster"`
+}
+
+// MigrationResult is the outcome of migrating one GatewayApplication. Error is set when the migration failed, in which
+// case NewGatewayId is only set if the application was resubmitted.
+type MigrationResult struct {
+	GatewayId    string `json:"gatewayId"`
+	NewGatewayId string `json:"newGatewayId,omitempty"`
+	Cluster      string `json:"cluster"`
+	Error        string `json:"error,omitempty"`
+}
+
+// NewMigrationSparkApplication returns the SparkApplication to resubmit when migrating sparkApp to another cluster: the
+// submitted name, labels, annotations and spec, without the status or the labels and annotations the Gateway sets.
+func NewMigrationSparkApplication(sparkApp *v1beta2.SparkApplication) *v1beta2.SparkApplication {
+	labels := maps.Clone(sparkApp.Labels)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	delete(labels, GATEWAY_CLUSTER_LABEL)
+	delete(labels, GATEWAY_SPECULATIVE_GROUP_LABEL)
+
+	annotations := maps.Clone(sparkApp.Annotations)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	name := annotations[GATEWAY_APPLICATION_NAME_ANNOTATION]
+	delete(annotations, GATEWAY_APPLICATION_NAME_ANNOTATION)
+	delete(annotations, GATEWAY_SPEC_HASH_ANNOTATION)
+
+	return &v1beta2.SparkApplication{
+		TypeMeta: sparkApp.TypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   sparkApp.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: *sparkApp.Spec.DeepCopy(),
+	}
+}
+
+// WithMigratedFrom records the GatewayId an application is migrated from, keeping the GatewayId it was originally
+// submitted with across repeated migrations.
+func WithMigratedFrom(gatewayId string) func(*GatewaySparkApplication) {
+	return func(gsa *GatewaySparkApplication) {
+		gsa.Annotations[GATEWAY_MIGRATED_FROM_ANNOTATION] = gatewayId
+		if _, ok := gsa.Annotations[GATEWAY_ORIGINAL_GATEWAY_ID_ANNOTATION]; !ok {
+			gsa.Annotations[GATEWAY_ORIGINAL_GATEWAY_ID_ANNOTATION] = gatewayId
+		}
+	}
+}
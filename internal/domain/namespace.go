@@ -0,0 +1,101 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// DefaultDriverServiceAccount is the ServiceAccount provisioned for Spark drivers when a registration does not name one
+const DefaultDriverServiceAccount = "spark"
+
+// NamespaceRegistration registers a namespace with a cluster at runtime. When Provision is set, the cluster's
+// SparkManager first creates the Kubernetes namespace and the RBAC Spark drivers run with.
+type NamespaceRegistration struct {
+	Name           string  `json:"name"`
+	NamespaceId    string  `json:"id"`
+	RoutingWeight  float64 `json:"routingWeight,omitempty"`
+	Provision      bool    `json:"provision,omitempty"`
+	ServiceAccount string  `json:"serviceAccount,omitempty"`
+}
+
+// RegisteredNamespace is the namespace configuration a cluster routes with after a NamespaceRegistration
+type RegisteredNamespace struct {
+	Cluster       string  `json:"cluster"`
+	Name          string  `json:"name"`
+	NamespaceId   string  `json:"id"`
+	RoutingWeight float64 `json:"routingWeight"`
+	Provisioned   bool    `json:"provisioned"`
+}
+
+// NamespaceWeightRequest sets the routing weight of a namespace in a cluster at runtime. RoutingWeight is required, 0
+// stops routing new GatewayApplications to the namespace in the cluster.
+type NamespaceWeightRequest struct {
+	RoutingWeight *float64 `json:"routingWeight" example:"2"`
+}
+
+// NamespaceWeight is a routing weight set on a namespace at runtime, overriding its configured routingWeight.
+// Persisted is false when the weight is only held in memory by the Gateway instance that set it.
+type NamespaceWeight struct {
+	Cluster       string    `json:"cluster"`
+	Namespace     string    `json:"namespace"`
+	RoutingWeight float64   `json:"routingWeight"`
+	UpdatedBy     string    `json:"updatedBy"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+	Persisted     bool      `json:"persisted"`
+}
+
+// NamespaceProvisioning is sent to SparkManager to create a namespace with a ServiceAccount and the Role Spark drivers
+// need to manage their executors
+type NamespaceProvisioning struct {
+	ServiceAccount string `json:"serviceAccount"`
+}
+
+// DriverRoleRules are the permissions of the Role provisioned namespaces grant Spark drivers, to create and clean up
+// their executors. SparkManager must hold them itself to grant them.
+var DriverRoleRules = []rbacv1.PolicyRule{
+	{
+		APIGroups: []string{""},
+		Resources: []string{"pods", "services", "configmaps", "persistentvolumeclaims"},
+		Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete", "deletecollection"},
+	},
+}
+
+// KillSwitchRequest engages a namespace kill switch. Message is returned to clients whose submissions are rejected.
+// SuspendRunning also scales the executors of the namespace's running GatewayApplications to zero.
+type KillSwitchRequest struct {
+	Message        string `json:"message,omitempty"`
+	SuspendRunning bool   `json:"suspendRunning,omitempty"`
+}
+
+// NamespaceKillSwitch rejects new GatewayApplications submitted to Namespace in every cluster while it is engaged.
+// Suspended is only set in the response to engaging the kill switch.
+type NamespaceKillSwitch struct {
+	Namespace string          `json:"namespace"`
+	Message   string          `json:"message"`
+	EngagedBy string          `json:"engagedBy"`
+	EngagedAt time.Time       `json:"engagedAt"`
+	Suspended []SuspendResult `json:"suspended,omitempty"`
+}
+
+// SuspendResult is the outcome of suspending one running GatewayApplication when a kill switch is engaged
+type SuspendResult struct {
+	GatewayId string `json:"gatewayId"`
+	Cluster   string `json:"cluster"`
+	Error     string `json:"error,omitempty"`
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// maxApplicationNameLength is the Kubernetes object name limit, applied to submitted names which are preserved in
+	// the GATEWAY_APPLICATION_NAME_ANNOTATION
+	maxApplicationNameLength = validation.DNS1123SubdomainMaxLength
+	// maxExecutorPodNamePrefixLength leaves room in the 63 character pod name limit for the '-exec-<id>' suffix Spark
+	// appends to spark.kubernetes.executor.podNamePrefix
+	maxExecutorPodNamePrefixLength = 47
+
+	executorPodNamePrefixConf = "spark.kubernetes.executor.podNamePrefix"
+)
+
+var (
+	dnsLabelFormat       = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	invalidDNSLabelChars = regexp.MustCompile(`[^a-z0-9-]+`)
+)
+
+// SanitizeDNSLabel returns name adjusted to a valid DNS-1123 label of at most maxLength characters: lowercased,
+// with runs of invalid characters replaced by '-', truncated and trimmed of leading and trailing '-'.
+func SanitizeDNSLabel(name string, maxLength int) string {
+	sanitized := invalidDNSLabelChars.ReplaceAllString(strings.ToLower(name), "-")
+	sanitized = strings.Trim(sanitized, "-")
+	if len(sanitized) > maxLength {
+		sanitized = strings.TrimRight(sanitized[:maxLength], "-")
+	}
+	return sanitized
+}
+
+// ValidateApplicationNames checks the user supplied names of a submitted SparkApplication that end up in Kubernetes
+// object names or annotations against Kubernetes naming limits. The returned results explain each violation and,
+// where possible, the adjusted name that would be accepted.
+func ValidateApplicationNames(application *v1beta2.SparkApplication) (results []ValidationResult) {
+	if len(application.Name) > maxApplicationNameLength {
+		results = append(results, ValidationResult{
+			Field:    "metadata.name",
+			Rule:     "maxLength",
+			Message:  fmt.Sprintf("metadata.name must be at most %d characters, got %d", maxApplicationNameLength, len(application.Name)),
+			Severity: ValidationSeverityError,
+		})
+	}
+
+	if podName := application.Spec.Driver.PodName; podName != nil {
+		results = append(results, validateDNSLabel("spec.driver.podName", *podName, validation.DNS1123LabelMaxLength)...)
+	}
+
+	if prefix, ok := application.Spec.SparkConf[executorPodNamePrefixConf]; ok {
+		results = append(results, validateDNSLabel(fmt.Sprintf("spec.sparkConf[%s]", executorPodNamePrefixConf), prefix, maxExecutorPodNamePrefixLength)...)
+	}
+
+	return results
+}
+
+func validateDNSLabel(field string, name string, maxLength int) []ValidationResult {
+	var problems []string
+	if len(name) > maxLength {
+		problems = append(problems, fmt.Sprintf("must be at most %d characters", maxLength))
+	}
+	if !dnsLabelFormat.MatchString(name) {
+		problems = append(problems, "must consist of lowercase alphanumeric characters or '-', and start and end with an alphanumeric character")
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("%s '%s' %s", field, name, strings.Join(problems, " and "))
+	if sanitized := SanitizeDNSLabel(name, maxLength); sanitized != "" {
+		message += fmt.Sprintf(", use '%s' instead", sanitized)
+	}
+
+	return []ValidationResult{{Field: field, Rule: "dnsLabel", Message: message, Severity: ValidationSeverityError}}
+}
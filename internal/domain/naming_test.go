@@ -0,0 +1,112 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSanitizeDNSLabel(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		maxLength int
+		expected  string
+	}{
+		{name: "valid name is unchanged", input: "my-driver", maxLength: 63, expected: "my-driver"},
+		{name: "uppercase and invalid characters", input: "My_Driver.Pod", maxLength: 63, expected: "my-driver-pod"},
+		{name: "leading and trailing invalid characters", input: "_driver_", maxLength: 63, expected: "driver"},
+		{name: "truncated without trailing dash", input: "abcde-fghij", maxLength: 6, expected: "abcde"},
+		{name: "nothing valid left", input: "___", maxLength: 63, expected: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, SanitizeDNSLabel(test.input, test.maxLength), "sanitized name should match")
+		})
+	}
+}
+
+func TestValidateApplicationNames(t *testing.T) {
+	tests := []struct {
+		name        string
+		application v1beta2.SparkApplication
+		results     []ValidationResult
+	}{
+		{
+			name: "valid names",
+			application: v1beta2.SparkApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "My Job"},
+				Spec: v1beta2.SparkApplicationSpec{
+					Driver:    v1beta2.DriverSpec{PodName: util.Ptr("my-driver")},
+					SparkConf: map[string]string{"spark.kubernetes.executor.podNamePrefix": "my-job"},
+				},
+			},
+		},
+		{
+			name: "name too long",
+			application: v1beta2.SparkApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a", 254)},
+			},
+			results: []ValidationResult{{
+				Field:    "metadata.name",
+				Rule:     "maxLength",
+				Message:  "metadata.name must be at most 253 characters, got 254",
+				Severity: ValidationSeverityError,
+			}},
+		},
+		{
+			name: "invalid driver pod name",
+			application: v1beta2.SparkApplication{
+				Spec: v1beta2.SparkApplicationSpec{
+					Driver: v1beta2.DriverSpec{PodName: util.Ptr("My_Driver")},
+				},
+			},
+			results: []ValidationResult{{
+				Field:    "spec.driver.podName",
+				Rule:     "dnsLabel",
+				Message:  "spec.driver.podName 'My_Driver' must consist of lowercase alphanumeric characters or '-', and start and end with an alphanumeric character, use 'my-driver' instead",
+				Severity: ValidationSeverityError,
+			}},
+		},
+		{
+			name: "executor pod name prefix too long",
+			application: v1beta2.SparkApplication{
+				Spec: v1beta2.SparkApplicationSpec{
+					SparkConf: map[string]string{"spark.kubernetes.executor.podNamePrefix": strings.Repeat("a", 48)},
+				},
+			},
+			results: []ValidationResult{{
+				Field:    "spec.sparkConf[spark.kubernetes.executor.podNamePrefix]",
+				Rule:     "dnsLabel",
+				Message:  "spec.sparkConf[spark.kubernetes.executor.podNamePrefix] '" + strings.Repeat("a", 48) + "' must be at most 47 characters, use '" + strings.Repeat("a", 47) + "' instead",
+				Severity: ValidationSeverityError,
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.results, ValidateApplicationNames(&test.application), "results should match")
+		})
+	}
+}
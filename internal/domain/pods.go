@@ -0,0 +1,111 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/kubeflow/spark-operator/v2/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SparkApplicationPod is a driver or executor pod of a SparkApplication. ExecutorId is only set on executors, and is the
+// id their logs are read with.
+type SparkApplicationPod struct {
+	Name       string          `json:"name"`
+	Role       string          `json:"role"`
+	ExecutorId string          `json:"executorId,omitempty"`
+	Phase      corev1.PodPhase `json:"phase"`
+	NodeName   string          `json:"nodeName,omitempty"`
+	StartTime  *metav1.Time    `json:"startTime,omitempty"`
+}
+
+// NewSparkApplicationPods returns the SparkApplicationPods of pods, the driver first and executors in order of their id.
+// Pods without the spark-role label Spark sets are skipped.
+func NewSparkApplicationPods(pods []corev1.Pod) []*SparkApplicationPod {
+	appPods := []*SparkApplicationPod{}
+	for _, pod := range pods {
+		role := pod.Labels[common.LabelSparkRole]
+		if role != common.SparkRoleDriver && role != common.SparkRoleExecutor {
+			continue
+		}
+
+		appPod := &SparkApplicationPod{
+			Name:      pod.Name,
+			Role:      role,
+			Phase:     pod.Status.Phase,
+			NodeName:  pod.Spec.NodeName,
+			StartTime: pod.Status.StartTime,
+		}
+		if role == common.SparkRoleExecutor {
+			appPod.ExecutorId = pod.Labels[common.LabelSparkExecutorID]
+		}
+		appPods = append(appPods, appPod)
+	}
+
+	sort.SliceStable(appPods, func(i, j int) bool {
+		if appPods[i].Role != appPods[j].Role {
+			return appPods[i].Role == common.SparkRoleDriver
+		}
+		idI, _ := strconv.Atoi(appPods[i].ExecutorId)
+		idJ, _ := strconv.Atoi(appPods[j].ExecutorId)
+		return idI < idJ
+	})
+
+	return appPods
+}
+
+// ApplicationPods is the driver and executor pods of a GatewayApplication
+type ApplicationPods struct {
+	GatewayId string                 `json:"gatewayId"`
+	Pods      []*SparkApplicationPod `json:"pods"`
+}
+
+// LogPod is the pod of a SparkApplication logs are read from. ExecutorId is empty for the driver.
+type LogPod struct {
+	Role       string
+	ExecutorId string
+}
+
+// IsExecutor returns whether logs are read from an executor rather than the driver
+func (p LogPod) IsExecutor() bool {
+	return p.Role == common.SparkRoleExecutor
+}
+
+// ParseLogPod parses the pod and executorId query parameters of a logs request. pod defaults to the driver, executorId
+// is required for, and only allowed with, pod=executor.
+func ParseLogPod(pod string, executorId string) (LogPod, error) {
+	switch pod {
+	case "", common.SparkRoleDriver:
+		if executorId != "" {
+			return LogPod{}, fmt.Errorf("'executorId' is only allowed with pod=%s", common.SparkRoleExecutor)
+		}
+		return LogPod{Role: common.SparkRoleDriver}, nil
+	case common.SparkRoleExecutor:
+		if executorId == "" {
+			return LogPod{}, fmt.Errorf("'executorId' is required with pod=%s, list the executors of the application with its pods", common.SparkRoleExecutor)
+		}
+		if id, err := strconv.Atoi(executorId); err != nil || id < 1 {
+			return LogPod{}, fmt.Errorf("'executorId' must be a positive integer, got '%s'", executorId)
+		}
+		return LogPod{Role: common.SparkRoleExecutor, ExecutorId: executorId}, nil
+	default:
+		return LogPod{}, fmt.Errorf("'pod' must be '%s' or '%s', got '%s'", common.SparkRoleDriver, common.SparkRoleExecutor, pod)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func sparkPod(name string, role string, executorId string, phase corev1.PodPhase) corev1.Pod {
+	labels := map[string]string{"spark-role": role}
+	if executorId != "" {
+		labels["spark-exec-id"] = executorId
+	}
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestNewSparkApplicationPods(t *testing.T) {
+	pods := []corev1.Pod{
+		sparkPod("app-exec-10", "executor", "10", corev1.PodRunning),
+		sparkPod("app-exec-2", "executor", "2", corev1.PodFailed),
+		{ObjectMeta: metav1.ObjectMeta{Name: "unrelated"}},
+		sparkPod("app-driver", "driver", "", corev1.PodRunning),
+	}
+
+	appPods := NewSparkApplicationPods(pods)
+
+	assert.Equal(t, []*SparkApplicationPod{
+		{Name: "app-driver", Role: "driver", Phase: corev1.PodRunning},
+		{Name: "app-exec-2", Role: "executor", ExecutorId: "2", Phase: corev1.PodFailed},
+		{Name: "app-exec-10", Role: "executor", ExecutorId: "10", Phase: corev1.PodRunning},
+	}, appPods, "the driver should come first and executors should be ordered by id")
+}
+
+func TestParseLogPod(t *testing.T) {
+	tests := []struct {
+		name       string
+		pod        string
+		executorId string
+		want       LogPod
+		wantErr    bool
+	}{
+		{name: "default", want: LogPod{Role: "driver"}},
+		{name: "driver", pod: "driver", want: LogPod{Role: "driver"}},
+		{name: "executor", pod: "executor", executorId: "3", want: LogPod{Role: "executor", ExecutorId: "3"}},
+		{name: "executor without id", pod: "executor", wantErr: true},
+		{name: "executor with invalid id", pod: "executor", executorId: "abc", wantErr: true},
+		{name: "executor with zero id", pod: "executor", executorId: "0", wantErr: true},
+		{name: "driver with executor id", pod: "driver", executorId: "3", wantErr: true},
+		{name: "unknown pod", pod: "shuffle", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logPod, err := ParseLogPod(test.pod, test.executorId)
+			if test.wantErr {
+				assert.Error(t, err, "log pod should be invalid")
+				return
+			}
+			assert.NoError(t, err, "log pod should be valid")
+			assert.Equal(t, test.want, logPod)
+		})
+	}
+}
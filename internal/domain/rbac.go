@@ -0,0 +1,78 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"slices"
+)
+
+// GATEWAY_OWNER_LABEL is the user owning a GatewayApplication, who can change and delete it when roles are enforced.
+// It is set from the submitting user, never from the submission.
+const GATEWAY_OWNER_LABEL = "spark-gateway/owner"
+
+// Role is what a user is allowed to do with GatewayApplications when roles are enforced
+type Role string
+
+const (
+	// RoleAdmin can do anything with any GatewayApplication
+	RoleAdmin Role = "admin"
+	// RoleSubmitter can read GatewayApplications, submit them and change or delete the ones they own
+	RoleSubmitter Role = "submitter"
+	// RoleViewer can only get and list GatewayApplications
+	RoleViewer Role = "viewer"
+)
+
+// Roles are the valid Roles, from the most to the least privileged
+var Roles = []Role{RoleAdmin, RoleSubmitter, RoleViewer}
+
+// ValidRole returns whether role is one of Roles
+func ValidRole(role Role) bool {
+	return slices.Contains(Roles, role)
+}
+
+// HighestRole returns the most privileged of roles, or fallback if roles is empty
+func HighestRole(roles []Role, fallback Role) Role {
+	for _, role := range Roles {
+		if slices.Contains(roles, role) {
+			return role
+		}
+	}
+	return fallback
+}
+
+// ApplicationOwner returns the owner of ga, the submitting user for applications submitted before GATEWAY_OWNER_LABEL
+// was set
+func ApplicationOwner(ga *GatewayApplication) string {
+	if owner := ga.SparkApplication.Labels[GATEWAY_OWNER_LABEL]; owner != "" {
+		return owner
+	}
+	return ga.User
+}
+
+type selectorOverrideKey struct{}
+
+// WithSelectorOverride returns a copy of ctx whose deletions and changes also apply to SparkApplications without the
+// Gateway's selector labels, i.e. that weren't submitted through Spark Gateway. It is only set for admins.
+func WithSelectorOverride(ctx context.Context) context.Context {
+	return context.WithValue(ctx, selectorOverrideKey{}, true)
+}
+
+// IsSelectorOverride returns whether deletions and changes made with ctx skip the selector label check
+func IsSelectorOverride(ctx context.Context) bool {
+	override, _ := ctx.Value(selectorOverrideKey{}).(bool)
+	return override
+}
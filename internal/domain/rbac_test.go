@@ -0,0 +1,43 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighestRole(t *testing.T) {
+	assert.Equal(t, RoleAdmin, HighestRole([]Role{RoleViewer, RoleAdmin, RoleSubmitter}, RoleViewer), "the most privileged role should be returned")
+	assert.Equal(t, RoleSubmitter, HighestRole(nil, RoleSubmitter), "the fallback should be returned without roles")
+}
+
+func TestApplicationOwner(t *testing.T) {
+	owned := &GatewayApplication{User: "service", SparkApplication: GatewaySparkApplication{
+		GatewayApplicationMeta: GatewayApplicationMeta{Labels: map[string]string{GATEWAY_OWNER_LABEL: "alice"}},
+	}}
+	assert.Equal(t, "alice", ApplicationOwner(owned), "the owner label should be the owner")
+
+	legacy := &GatewayApplication{User: "alice"}
+	assert.Equal(t, "alice", ApplicationOwner(legacy), "the user should own applications without an owner label")
+}
+
+func TestSelectorOverride(t *testing.T) {
+	assert.False(t, IsSelectorOverride(context.Background()), "contexts should not override by default")
+	assert.True(t, IsSelectorOverride(WithSelectorOverride(context.Background())), "override should be set")
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// RenderedPods are the driver and executor pods Spark and the Spark Operator would create for a SparkApplication in
+// Cluster, so users can check tolerations, affinity, env and resources before submitting it. Executor is the first
+// executor, every executor of an application shares its spec.
+type RenderedPods struct {
+	Cluster  string     `json:"cluster,omitempty"`
+	Driver   corev1.Pod `json:"driver"`
+	Executor corev1.Pod `json:"executor"`
+}
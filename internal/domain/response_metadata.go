@@ -0,0 +1,127 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ResponseMetadata describes how the Gateway served a request, so clients can debug slow requests without access to
+// Gateway logs. It is only collected for requests asking for it, and is safe to record to from concurrent calls.
+type ResponseMetadata struct {
+	RequestId string `json:"requestId"`
+	// Clusters are the clusters whose SparkManager was called to serve the request
+	Clusters []string `json:"clusters"`
+	// DurationSeconds is how long the Gateway took to serve the request
+	DurationSeconds float64 `json:"durationSeconds"`
+	// SparkManagerSeconds is how long calls to SparkManagers took in total
+	SparkManagerSeconds float64                `json:"sparkManagerSeconds"`
+	SparkManagerCalls   []SparkManagerCallTime `json:"sparkManagerCalls"`
+	// Cached is true when any of the request's SparkManager responses were served from the Gateway's response cache
+	Cached bool `json:"cached"`
+	// Deduplicated is true when any of the request's SparkManager responses were shared with an identical request in
+	// flight
+	Deduplicated bool `json:"deduplicated"`
+
+	mu sync.Mutex
+}
+
+// SparkManagerCallTime is how long a call to the SparkManager of Cluster took
+type SparkManagerCallTime struct {
+	Cluster         string  `json:"cluster"`
+	Method          string  `json:"method"`
+	Path            string  `json:"path"`
+	DurationSeconds float64 `json:"durationSeconds"`
+}
+
+type responseMetadataKey struct{}
+
+// WithResponseMetadata returns a copy of ctx that calls record how a request was served to metadata
+func WithResponseMetadata(ctx context.Context, metadata *ResponseMetadata) context.Context {
+	return context.WithValue(ctx, responseMetadataKey{}, metadata)
+}
+
+// ResponseMetadataFrom returns the ResponseMetadata of ctx, or nil if the request didn't ask for it. Recording to a
+// nil ResponseMetadata does nothing.
+func ResponseMetadataFrom(ctx context.Context) *ResponseMetadata {
+	metadata, _ := ctx.Value(responseMetadataKey{}).(*ResponseMetadata)
+	return metadata
+}
+
+// RecordSparkManagerCall records a call to the SparkManager of cluster that took duration
+func (m *ResponseMetadata) RecordSparkManagerCall(cluster string, method string, path string, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.SparkManagerCalls = append(m.SparkManagerCalls, SparkManagerCallTime{
+		Cluster:         cluster,
+		Method:          method,
+		Path:            path,
+		DurationSeconds: duration.Seconds(),
+	})
+	m.SparkManagerSeconds += duration.Seconds()
+	if !slices.Contains(m.Clusters, cluster) {
+		m.Clusters = append(m.Clusters, cluster)
+		slices.Sort(m.Clusters)
+	}
+}
+
+// RecordCacheHit records that a SparkManager response was served from the response cache
+func (m *ResponseMetadata) RecordCacheHit(cluster string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Cached = true
+	if !slices.Contains(m.Clusters, cluster) {
+		m.Clusters = append(m.Clusters, cluster)
+		slices.Sort(m.Clusters)
+	}
+}
+
+// RecordDeduplicated records that a SparkManager response was shared with an identical request in flight
+func (m *ResponseMetadata) RecordDeduplicated(cluster string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Deduplicated = true
+	if !slices.Contains(m.Clusters, cluster) {
+		m.Clusters = append(m.Clusters, cluster)
+		slices.Sort(m.Clusters)
+	}
+}
+
+// Finish records that serving the request took duration
+func (m *ResponseMetadata) Finish(duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.DurationSeconds = duration.Seconds()
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+)
+
+// GATEWAY_RESUBMITTED_FROM_ANNOTATION is the GatewayId an application was resubmitted from
+const GATEWAY_RESUBMITTED_FROM_ANNOTATION = "spark-gateway/resubmitted-from"
+
+// NewResubmissionSparkApplication returns the SparkApplication to submit to rerun sparkApp under a new GatewayId: its
+// submitted name, labels, annotations and spec, as for migrations, annotated with the gatewayId it is resubmitted from.
+// The user, owner, team and acting user of the original submission are dropped, so they are set from the resubmitting request.
+func NewResubmissionSparkApplication(sparkApp *v1beta2.SparkApplication, gatewayId string) *v1beta2.SparkApplication {
+	resubmission := NewMigrationSparkApplication(sparkApp)
+
+	delete(resubmission.Labels, GATEWAY_USER_LABEL)
+	delete(resubmission.Labels, GATEWAY_OWNER_LABEL)
+	delete(resubmission.Labels, GATEWAY_TEAM_LABEL)
+	delete(resubmission.Annotations, GATEWAY_ACTING_USER_ANNOTATION)
+	delete(resubmission.Annotations, GATEWAY_MIGRATED_FROM_ANNOTATION)
+	delete(resubmission.Annotations, GATEWAY_ORIGINAL_GATEWAY_ID_ANNOTATION)
+	resubmission.Annotations[GATEWAY_RESUBMITTED_FROM_ANNOTATION] = gatewayId
+
+	return resubmission
+}
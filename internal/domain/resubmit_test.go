@@ -0,0 +1,62 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewResubmissionSparkApplication(t *testing.T) {
+	sparkApp := &v1beta2.SparkApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "clusterid-nsid-uuid",
+			Namespace: "testNamespace",
+			Labels: map[string]string{
+				GATEWAY_USER_LABEL:    "user",
+				GATEWAY_TEAM_LABEL:    "team",
+				GATEWAY_CLUSTER_LABEL: "cluster",
+				"app":                 "etl",
+			},
+			Annotations: map[string]string{
+				GATEWAY_APPLICATION_NAME_ANNOTATION:    "etl-job",
+				GATEWAY_ACTING_USER_ANNOTATION:         "service",
+				GATEWAY_MIGRATED_FROM_ANNOTATION:       "otherid-nsid-uuid",
+				GATEWAY_ORIGINAL_GATEWAY_ID_ANNOTATION: "otherid-nsid-uuid",
+				"owner":                                "data",
+			},
+			ResourceVersion: "42",
+		},
+		Spec:   v1beta2.SparkApplicationSpec{MainClass: &[]string{"Main"}[0]},
+		Status: v1beta2.SparkApplicationStatus{AppState: v1beta2.ApplicationState{State: v1beta2.ApplicationStateFailed}},
+	}
+
+	resubmission := NewResubmissionSparkApplication(sparkApp, "clusterid-nsid-uuid")
+
+	assert.Equal(t, &v1beta2.SparkApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "etl-job",
+			Namespace:   "testNamespace",
+			Labels:      map[string]string{"app": "etl"},
+			Annotations: map[string]string{"owner": "data", GATEWAY_RESUBMITTED_FROM_ANNOTATION: "clusterid-nsid-uuid"},
+		},
+		Spec: sparkApp.Spec,
+	}, resubmission, "only the submitted name, labels, annotations and spec should be resubmitted")
+	assert.Equal(t, "user", sparkApp.Labels[GATEWAY_USER_LABEL], "the original application should not be modified")
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+// MaxRoutingSimulationCount caps the submissions simulated by one RoutingSimulationRequest
+const MaxRoutingSimulationCount = 1000
+
+// RoutingSimulationRequest asks for the clusters the cluster routers would choose for Count submissions to Namespace
+type RoutingSimulationRequest struct {
+	Namespace string `json:"namespace" example:"default"`
+	Count     int    `json:"count" example:"100"`
+}
+
+// RoutingSimulation is the distribution of clusters chosen over Count simulated submissions. Unroutable counts the
+// submissions no cluster could be chosen for, with Error the last routing error.
+type RoutingSimulation struct {
+	Namespace  string                 `json:"namespace"`
+	Count      int                    `json:"count"`
+	Clusters   []SimulatedClusterPick `json:"clusters"`
+	Unroutable int                    `json:"unroutable"`
+	Error      string                 `json:"error,omitempty"`
+}
+
+// SimulatedClusterPick is how many simulated submissions were routed to Cluster, and their Share of the total
+type SimulatedClusterPick struct {
+	Cluster string  `json:"cluster"`
+	Count   int     `json:"count"`
+	Share   float64 `json:"share"`
+}
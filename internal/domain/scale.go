@@ -0,0 +1,71 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+)
+
+// ExecutorScale changes the executors of a running SparkApplication. Instances sets `spec.executor.instances` of
+// applications with a static executor count, MaxExecutors sets `spec.dynamicAllocation.maxExecutors` of applications
+// with dynamic allocation enabled. Exactly one must be set.
+type ExecutorScale struct {
+	Instances    *int32 `json:"instances,omitempty"`
+	MaxExecutors *int32 `json:"maxExecutors,omitempty"`
+}
+
+// Validate checks that exactly one of Instances and MaxExecutors is set and that it isn't negative
+func (s ExecutorScale) Validate() error {
+	if (s.Instances == nil) == (s.MaxExecutors == nil) {
+		return fmt.Errorf("exactly one of 'instances' and 'maxExecutors' must be set")
+	}
+	if s.Instances != nil && *s.Instances < 0 {
+		return fmt.Errorf("'instances' must not be negative, got %d", *s.Instances)
+	}
+	if s.MaxExecutors != nil && *s.MaxExecutors < 0 {
+		return fmt.Errorf("'maxExecutors' must not be negative, got %d", *s.MaxExecutors)
+	}
+
+	return nil
+}
+
+// Count returns the executor count requested by whichever field is set
+func (s ExecutorScale) Count() int32 {
+	if s.Instances != nil {
+		return *s.Instances
+	}
+	if s.MaxExecutors != nil {
+		return *s.MaxExecutors
+	}
+	return 0
+}
+
+// NewExecutorScale returns the ExecutorScale setting the executors of an application with spec to count, setting
+// MaxExecutors if dynamic allocation is enabled in the spec or its sparkConf and Instances otherwise
+func NewExecutorScale(spec v1beta2.SparkApplicationSpec, count int32) ExecutorScale {
+	dynamicAllocation := spec.DynamicAllocation != nil && spec.DynamicAllocation.Enabled
+	if enabled, err := strconv.ParseBool(spec.SparkConf["spark.dynamicAllocation.enabled"]); err == nil && enabled {
+		dynamicAllocation = true
+	}
+
+	if dynamicAllocation {
+		return ExecutorScale{MaxExecutors: &count}
+	}
+	return ExecutorScale{Instances: &count}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+)
+
+func TestExecutorScaleValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		scale   ExecutorScale
+		wantErr bool
+	}{
+		{name: "instances", scale: ExecutorScale{Instances: util.Ptr(int32(2))}},
+		{name: "maxExecutors", scale: ExecutorScale{MaxExecutors: util.Ptr(int32(0))}},
+		{name: "neither set", scale: ExecutorScale{}, wantErr: true},
+		{name: "both set", scale: ExecutorScale{Instances: util.Ptr(int32(2)), MaxExecutors: util.Ptr(int32(2))}, wantErr: true},
+		{name: "negative instances", scale: ExecutorScale{Instances: util.Ptr(int32(-1))}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.scale.Validate()
+			if test.wantErr {
+				assert.Error(t, err, "scale should be invalid")
+			} else {
+				assert.NoError(t, err, "scale should be valid")
+			}
+		})
+	}
+}
+
+func TestNewExecutorScale(t *testing.T) {
+	static := NewExecutorScale(v1beta2.SparkApplicationSpec{}, 0)
+	assert.Equal(t, ExecutorScale{Instances: util.Ptr(int32(0))}, static, "static allocation should scale instances")
+
+	dynamicSpec := NewExecutorScale(v1beta2.SparkApplicationSpec{DynamicAllocation: &v1beta2.DynamicAllocation{Enabled: true}}, 2)
+	assert.Equal(t, ExecutorScale{MaxExecutors: util.Ptr(int32(2))}, dynamicSpec, "dynamic allocation in the spec should scale maxExecutors")
+
+	dynamicConf := NewExecutorScale(v1beta2.SparkApplicationSpec{SparkConf: map[string]string{"spark.dynamicAllocation.enabled": "true"}}, 2)
+	assert.Equal(t, ExecutorScale{MaxExecutors: util.Ptr(int32(2))}, dynamicConf, "dynamic allocation in sparkConf should scale maxExecutors")
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+)
+
+// GATEWAY_SCHEDULE_ANNOTATION is set on GatewayApplications created by a ScheduledApplication to its ScheduleId
+const GATEWAY_SCHEDULE_ANNOTATION = "spark-gateway/schedule"
+
+// ScheduleRequest creates a ScheduledApplication submitting Application every time Cron matches
+type ScheduleRequest struct {
+	Cron        string                   `json:"cron" example:"0 2 * * *"`
+	Application v1beta2.SparkApplication `json:"application"`
+}
+
+// ScheduledApplication submits its Application as a new GatewayApplication every time its Cron expression matches, in
+// UTC, on behalf of the User who created it. NextRunTime is when it will next be submitted unless it is Paused.
+type ScheduledApplication struct {
+	ScheduleId   string                    `json:"scheduleId"`
+	Cron         string                    `json:"cron"`
+	Namespace    string                    `json:"namespace"`
+	User         string                    `json:"user"`
+	Paused       bool                      `json:"paused"`
+	NextRunTime  time.Time                 `json:"nextRunTime"`
+	LastRunTime  *time.Time                `json:"lastRunTime,omitempty"`
+	CreationTime time.Time                 `json:"creationTime"`
+	Application  *v1beta2.SparkApplication `json:"application"`
+}
+
+// ScheduledRun is a GatewayApplication created by a ScheduledApplication for the run at ScheduledTime, or the Error
+// that prevented it from being created
+type ScheduledRun struct {
+	ScheduledTime time.Time `json:"scheduledTime"`
+	GatewayId     string    `json:"gatewayId,omitempty"`
+	Error         string    `json:"error,omitempty"`
+	CreationTime  time.Time `json:"creationTime"`
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import "github.com/kubeflow/spark-operator/v2/api/v1beta2"
+
+// GatewayState is a small, stable set of states SparkApplication states are normalized to, so clients don't each need
+// to know every operator state. It is returned as gatewayState alongside the operator's state.
+type GatewayState string
+
+const (
+	// GatewayStatePending is an application that hasn't started running, or is being resubmitted
+	GatewayStatePending GatewayState = "PENDING"
+	// GatewayStateRunning is an application whose driver is running or shutting down
+	GatewayStateRunning GatewayState = "RUNNING"
+	// GatewayStateSucceeded is an application that completed
+	GatewayStateSucceeded GatewayState = "SUCCEEDED"
+	// GatewayStateFailed is an application that failed to run or to be submitted
+	GatewayStateFailed GatewayState = "FAILED"
+	// GatewayStateUnknown is an application whose state the operator doesn't know, or that the Gateway doesn't map
+	GatewayStateUnknown GatewayState = "UNKNOWN"
+)
+
+var applicationStateToGatewayState = map[v1beta2.ApplicationStateType]GatewayState{
+	v1beta2.ApplicationStateNew:              GatewayStatePending,
+	v1beta2.ApplicationStateSubmitted:        GatewayStatePending,
+	v1beta2.ApplicationStatePendingRerun:     GatewayStatePending,
+	v1beta2.ApplicationStateInvalidating:     GatewayStatePending,
+	v1beta2.ApplicationStateRunning:          GatewayStateRunning,
+	v1beta2.ApplicationStateSucceeding:       GatewayStateRunning,
+	v1beta2.ApplicationStateFailing:          GatewayStateRunning,
+	v1beta2.ApplicationStateCompleted:        GatewayStateSucceeded,
+	v1beta2.ApplicationStateFailed:           GatewayStateFailed,
+	v1beta2.ApplicationStateFailedSubmission: GatewayStateFailed,
+	v1beta2.ApplicationStateUnknown:          GatewayStateUnknown,
+}
+
+// NewGatewayState returns the GatewayState state is normalized to. States added by newer operators are UNKNOWN until
+// they are mapped.
+func NewGatewayState(state v1beta2.ApplicationStateType) GatewayState {
+	if gatewayState, ok := applicationStateToGatewayState[state]; ok {
+		return gatewayState
+	}
+	return GatewayStateUnknown
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGatewayState(t *testing.T) {
+	tests := []struct {
+		state v1beta2.ApplicationStateType
+		want  GatewayState
+	}{
+		{state: v1beta2.ApplicationStateNew, want: GatewayStatePending},
+		{state: v1beta2.ApplicationStateSubmitted, want: GatewayStatePending},
+		{state: v1beta2.ApplicationStatePendingRerun, want: GatewayStatePending},
+		{state: v1beta2.ApplicationStateInvalidating, want: GatewayStatePending},
+		{state: v1beta2.ApplicationStateRunning, want: GatewayStateRunning},
+		{state: v1beta2.ApplicationStateSucceeding, want: GatewayStateRunning},
+		{state: v1beta2.ApplicationStateFailing, want: GatewayStateRunning},
+		{state: v1beta2.ApplicationStateCompleted, want: GatewayStateSucceeded},
+		{state: v1beta2.ApplicationStateFailed, want: GatewayStateFailed},
+		{state: v1beta2.ApplicationStateFailedSubmission, want: GatewayStateFailed},
+		{state: v1beta2.ApplicationStateUnknown, want: GatewayStateUnknown},
+		{state: "SOME_NEW_STATE", want: GatewayStateUnknown},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.state), func(t *testing.T) {
+			assert.Equal(t, test.want, NewGatewayState(test.state), "state should be normalized")
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"fmt"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+)
+
+// URLTemplateWarning is the code of warnings for responses with a status URL whose template failed to render
+const URLTemplateWarning = "URLTemplateRenderFailed"
+
+// Render renders the status URL templates for gaSparkApp. A URL whose template fails to render is left empty, and a
+// warning with the reason is returned for it.
+func (t StatusUrlTemplates) Render(gaSparkApp *GatewaySparkApplication) (SparkLogURLs, []SubmissionWarning) {
+	return t.render(gaSparkApp.ToV1Beta2SparkApplication())
+}
+
+// render renders the templates against the v1beta2.SparkApplication, which is the object the templates are documented
+// to reference, so both {{.Name}} and {{.ObjectMeta.Name}} render
+func (t StatusUrlTemplates) render(sparkApp *v1beta2.SparkApplication) (SparkLogURLs, []SubmissionWarning) {
+	var urls SparkLogURLs
+	var warnings []SubmissionWarning
+
+	for _, statusUrl := range []struct {
+		name     string
+		template string
+		url      *string
+	}{
+		{name: "sparkUI", template: t.SparkUITemplate, url: &urls.SparkUI},
+		{name: "logsUI", template: t.LogsUITemplate, url: &urls.LogsUI},
+		{name: "sparkHistoryUI", template: t.SparkHistoryUITemplate, url: &urls.SparkHistoryUI},
+	} {
+		rendered, err := util.RenderTemplate(statusUrl.template, sparkApp)
+		if err != nil {
+			warnings = append(warnings, SubmissionWarning{
+				Code:    URLTemplateWarning,
+				Message: fmt.Sprintf("the %s URL could not be rendered: %v", statusUrl.name, err),
+			})
+			continue
+		}
+		*statusUrl.url = *rendered
+	}
+
+	return urls, warnings
+}
+
+// Validate renders the status URL templates against a sample SparkApplication, so templates that can't render
+// for any application, like ones that don't parse or reference fields that don't exist, are caught at startup
+func (t StatusUrlTemplates) Validate() (errorMessages []string) {
+	_, warnings := t.render(sampleSparkApplication())
+	for _, warning := range warnings {
+		errorMessages = append(errorMessages, fmt.Sprintf("config error: 'gateway.statusUrlTemplates': %s", warning.Message))
+	}
+
+	return errorMessages
+}
+
+// sampleSparkApplication is a running SparkApplication with the fields status URL templates commonly reference set
+func sampleSparkApplication() *v1beta2.SparkApplication {
+	return &v1beta2.SparkApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "clusterid-nsid-01982d11-c2c1-7c3d-8b2f-944ae7248434",
+			Namespace:         "sample-namespace",
+			CreationTimestamp: metav1.Now(),
+		},
+		Status: v1beta2.SparkApplicationStatus{
+			SparkApplicationID: "spark-0123456789abcdef",
+			SubmissionID:       "01982d11-c2c1-7c3d-8b2f-944ae7248434",
+			DriverInfo: v1beta2.DriverInfo{
+				PodName: "clusterid-nsid-01982d11-c2c1-7c3d-8b2f-944ae7248434-driver",
+			},
+			AppState: v1beta2.ApplicationState{State: v1beta2.ApplicationStateRunning},
+		},
+	}
+}
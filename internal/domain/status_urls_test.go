@@ -0,0 +1,61 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusUrlTemplatesRender(t *testing.T) {
+	templates := StatusUrlTemplates{
+		SparkUITemplate:        "host.com/ui/{{.Namespace}}/{{.Name}}",
+		SparkHistoryUITemplate: "host.com/history/{{.Status.SparkApplicationID}}",
+		LogsUITemplate:         "host.com/logs/{{.ObjectMeta.Name}}-driver",
+	}
+
+	urls, warnings := templates.Render(NewGatewaySparkApplication(sampleSparkApplication()))
+	assert.Empty(t, warnings, "templates referencing SparkApplication fields should render")
+	assert.Equal(t, SparkLogURLs{
+		SparkUI:        "host.com/ui/sample-namespace/clusterid-nsid-01982d11-c2c1-7c3d-8b2f-944ae7248434",
+		SparkHistoryUI: "host.com/history/spark-0123456789abcdef",
+		LogsUI:         "host.com/logs/clusterid-nsid-01982d11-c2c1-7c3d-8b2f-944ae7248434-driver",
+	}, urls)
+
+	templates.SparkHistoryUITemplate = "host.com/history/{{.GatewayId}}"
+	urls, warnings = templates.Render(NewGatewaySparkApplication(sampleSparkApplication()))
+	assert.Equal(t, "", urls.SparkHistoryUI, "the URL that failed to render should be empty")
+	if assert.Len(t, warnings, 1, "a warning should be returned for the URL that failed to render") {
+		assert.Equal(t, URLTemplateWarning, warnings[0].Code)
+		assert.Contains(t, warnings[0].Message, "sparkHistoryUI", "the warning should name the URL")
+	}
+}
+
+func TestStatusUrlTemplatesValidate(t *testing.T) {
+	assert.Empty(t, StatusUrlTemplates{}.Validate(), "empty templates should be valid")
+
+	assert.Empty(t, StatusUrlTemplates{
+		SparkUITemplate:        "{{.Status.DriverInfo.WebUIIngressAddress}}",
+		SparkHistoryUITemplate: "sparkhistory.domain.com/history/{{.Status.SparkApplicationID}}/jobs",
+		LogsUITemplate:         "logs.domain.com/'host:{{.ObjectMeta.Name}}-driver'",
+	}.Validate(), "the example templates should be valid")
+
+	assert.Len(t, StatusUrlTemplates{
+		SparkUITemplate: "{{.Status.DriverInfo.WebUIIngressAddress",
+		LogsUITemplate:  "logs.domain.com/{{.Missing}}",
+	}.Validate(), 2, "templates that don't parse or reference missing fields should be invalid")
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+)
+
+// StuckStates are the application states in which the Spark Operator hasn't got a driver running, e.g. because the
+// driver pod can't be scheduled or the operator dropped the application. Applications staying in them are stuck.
+var StuckStates = []v1beta2.ApplicationStateType{
+	v1beta2.ApplicationStateSubmitted,
+	v1beta2.ApplicationStatePendingRerun,
+}
+
+// StuckApplication is a GatewayApplication that has been in one of the StuckStates for longer than the configured
+// threshold. Since is its last submission attempt, or its creation if it hasn't been submitted yet.
+type StuckApplication struct {
+	GatewayId string                       `json:"gatewayId"`
+	Cluster   string                       `json:"cluster"`
+	Namespace string                       `json:"namespace"`
+	User      string                       `json:"user"`
+	State     v1beta2.ApplicationStateType `json:"state"`
+	Since     time.Time                    `json:"since"`
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+)
+
+// SubmissionState is the state of an asynchronous submission in the submission queue
+type SubmissionState string
+
+const (
+	// SubmissionStateQueued is a submission waiting to be, or being, submitted to its cluster's SparkManager
+	SubmissionStateQueued SubmissionState = "QUEUED"
+	// SubmissionStateSubmitted is a submission created in its cluster
+	SubmissionStateSubmitted SubmissionState = "SUBMITTED"
+	// SubmissionStateFailed is a submission that was rejected by SparkManager or ran out of attempts
+	SubmissionStateFailed SubmissionState = "FAILED"
+)
+
+// QueuedSubmission describes an asynchronous submission that hasn't been created in its cluster yet
+type QueuedSubmission struct {
+	State SubmissionState `json:"state"`
+	// Attempts is how many times submitting the application was attempted
+	Attempts int `json:"attempts"`
+	// LastError is the error of the last failed attempt
+	LastError string `json:"lastError,omitempty"`
+	// NextAttemptTime is when submitting a QUEUED application is attempted next
+	NextAttemptTime *time.Time `json:"nextAttemptTime,omitempty"`
+	QueuedTime      time.Time  `json:"queuedTime"`
+}
+
+func (q *QueuedSubmission) DeepCopy() *QueuedSubmission {
+	if q == nil {
+		return nil
+	}
+
+	out := *q
+	if q.NextAttemptTime != nil {
+		nextAttemptTime := *q.NextAttemptTime
+		out.NextAttemptTime = &nextAttemptTime
+	}
+	return &out
+}
+
+// FailedSubmission is a dead-lettered asynchronous submission, one that SparkManager rejected or that ran out of
+// attempts. It stays in the submission queue until it is retried, deleted, or pruned after the queue's retention.
+type FailedSubmission struct {
+	GatewayId string `json:"gatewayId"`
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+	User      string `json:"user"`
+	// Attempts is how many times submitting the application was attempted
+	Attempts int `json:"attempts"`
+	// LastError is the error of the attempt that failed the submission
+	LastError  string    `json:"lastError,omitempty"`
+	QueuedTime time.Time `json:"queuedTime"`
+	FailedTime time.Time `json:"failedTime"`
+	// Application is the SparkApplication that failed to be submitted, only returned for a single submission
+	Application *v1beta2.SparkApplication `json:"application,omitempty"`
+}
+
+// FailedSubmissionEdit replaces the SparkApplication of a FailedSubmission before it is retried, and the cluster it is
+// submitted to if Cluster is set
+type FailedSubmissionEdit struct {
+	Application *v1beta2.SparkApplication `json:"application"`
+	Cluster     string                    `json:"cluster,omitempty"`
+}
+
+type asyncSubmissionKey struct{}
+
+// WithAsyncSubmission returns a copy of ctx whose submissions are queued rather than created in the cluster
+func WithAsyncSubmission(ctx context.Context) context.Context {
+	return context.WithValue(ctx, asyncSubmissionKey{}, true)
+}
+
+// IsAsyncSubmission returns whether submissions made with ctx are queued
+func IsAsyncSubmission(ctx context.Context) bool {
+	async, _ := ctx.Value(asyncSubmissionKey{}).(bool)
+	return async
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import "time"
+
+// TimelineEvent is a lifecycle event of a SparkApplication, such as it being submitted, running or failing. Events are
+// kept after the SparkApplication, its pods and its Kubernetes events have been garbage collected.
+type TimelineEvent struct {
+	Time time.Time `json:"time"`
+	// State is the state the SparkApplication transitioned to
+	State string `json:"state"`
+	// Message is the error message of the state, if any
+	Message string `json:"message,omitempty"`
+}
+
+// ApplicationTimeline is the lifecycle events of a GatewayApplication, oldest first
+type ApplicationTimeline struct {
+	GatewayId string          `json:"gatewayId"`
+	Events    []TimelineEvent `json:"events"`
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ApplicationUpdate changes the mutable fields of a SparkApplication that hasn't started running yet. Labels are
+// merged into the application's labels, TimeToLiveSeconds sets `spec.timeToLiveSeconds` and MaxExecutors sets
+// `spec.dynamicAllocation.maxExecutors` of applications with dynamic allocation enabled. At least one must be set.
+type ApplicationUpdate struct {
+	Labels            map[string]string `json:"labels,omitempty"`
+	TimeToLiveSeconds *int64            `json:"timeToLiveSeconds,omitempty"`
+	MaxExecutors      *int32            `json:"maxExecutors,omitempty"`
+}
+
+// Validate checks that at least one field is set, that Labels are valid Kubernetes labels not owned by the Gateway and
+// that TimeToLiveSeconds and MaxExecutors aren't negative
+func (u ApplicationUpdate) Validate() error {
+	if len(u.Labels) == 0 && u.TimeToLiveSeconds == nil && u.MaxExecutors == nil {
+		return fmt.Errorf("at least one of 'labels', 'timeToLiveSeconds' and 'maxExecutors' must be set")
+	}
+
+	var errMessages []string
+	for key, value := range u.Labels {
+		errMessages = append(errMessages, validateMetadataKey("labels", key)...)
+		for _, problem := range validation.IsValidLabelValue(value) {
+			errMessages = append(errMessages, fmt.Sprintf("`labels` value '%s' of '%s' is invalid: %s", value, key, problem))
+		}
+	}
+	if u.TimeToLiveSeconds != nil && *u.TimeToLiveSeconds < 0 {
+		errMessages = append(errMessages, fmt.Sprintf("'timeToLiveSeconds' must not be negative, got %d", *u.TimeToLiveSeconds))
+	}
+	if u.MaxExecutors != nil && *u.MaxExecutors < 0 {
+		errMessages = append(errMessages, fmt.Sprintf("'maxExecutors' must not be negative, got %d", *u.MaxExecutors))
+	}
+
+	if len(errMessages) > 0 {
+		return errors.New(strings.Join(errMessages, ", "))
+	}
+	return nil
+}
+
+// Apply sets the fields of the update on sparkApp
+func (u ApplicationUpdate) Apply(sparkApp *v1beta2.SparkApplication) {
+	if len(u.Labels) > 0 {
+		if sparkApp.Labels == nil {
+			sparkApp.Labels = map[string]string{}
+		}
+		maps.Copy(sparkApp.Labels, u.Labels)
+	}
+	if u.TimeToLiveSeconds != nil {
+		sparkApp.Spec.TimeToLiveSeconds = u.TimeToLiveSeconds
+	}
+	if u.MaxExecutors != nil {
+		if sparkApp.Spec.DynamicAllocation == nil {
+			sparkApp.Spec.DynamicAllocation = &v1beta2.DynamicAllocation{}
+		}
+		sparkApp.Spec.DynamicAllocation.MaxExecutors = u.MaxExecutors
+	}
+}
+
+// IsUpdatable returns whether a SparkApplication in state can still be updated, which is until its driver starts
+// running
+func IsUpdatable(state v1beta2.ApplicationStateType) bool {
+	return state == "" || NewGatewayState(state) == GatewayStatePending
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+)
+
+func TestApplicationUpdateValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  ApplicationUpdate
+		wantErr bool
+	}{
+		{name: "labels", update: ApplicationUpdate{Labels: map[string]string{"team": "data"}}},
+		{name: "ttl", update: ApplicationUpdate{TimeToLiveSeconds: util.Ptr(int64(3600))}},
+		{name: "maxExecutors", update: ApplicationUpdate{MaxExecutors: util.Ptr(int32(0))}},
+		{name: "nothing set", update: ApplicationUpdate{}, wantErr: true},
+		{name: "gateway label", update: ApplicationUpdate{Labels: map[string]string{GATEWAY_USER_LABEL: "mallory"}}, wantErr: true},
+		{name: "invalid label value", update: ApplicationUpdate{Labels: map[string]string{"team": "not a value"}}, wantErr: true},
+		{name: "negative ttl", update: ApplicationUpdate{TimeToLiveSeconds: util.Ptr(int64(-1))}, wantErr: true},
+		{name: "negative maxExecutors", update: ApplicationUpdate{MaxExecutors: util.Ptr(int32(-1))}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.update.Validate()
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestApplicationUpdateApply(t *testing.T) {
+	sparkApp := &v1beta2.SparkApplication{}
+	sparkApp.Labels = map[string]string{GATEWAY_USER_LABEL: "alice", "team": "web"}
+
+	ApplicationUpdate{
+		Labels:            map[string]string{"team": "data", "tier": "batch"},
+		TimeToLiveSeconds: util.Ptr(int64(60)),
+		MaxExecutors:      util.Ptr(int32(8)),
+	}.Apply(sparkApp)
+
+	assert.Equal(t, map[string]string{GATEWAY_USER_LABEL: "alice", "team": "data", "tier": "batch"}, sparkApp.Labels, "labels should be merged")
+	assert.Equal(t, int64(60), *sparkApp.Spec.TimeToLiveSeconds)
+	assert.Equal(t, int32(8), *sparkApp.Spec.DynamicAllocation.MaxExecutors)
+}
+
+func TestIsUpdatable(t *testing.T) {
+	assert.True(t, IsUpdatable(""), "applications the operator hasn't seen should be updatable")
+	assert.True(t, IsUpdatable(v1beta2.ApplicationStateSubmitted))
+	assert.True(t, IsUpdatable(v1beta2.ApplicationStatePendingRerun))
+	assert.False(t, IsUpdatable(v1beta2.ApplicationStateRunning))
+	assert.False(t, IsUpdatable(v1beta2.ApplicationStateCompleted))
+	assert.False(t, IsUpdatable(v1beta2.ApplicationStateUnknown))
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"strings"
+)
+
+type ValidationSeverity string
+
+const (
+	// ValidationSeverityError results reject the submission
+	ValidationSeverityError ValidationSeverity = "error"
+	// ValidationSeverityWarning results are reported without rejecting the submission
+	ValidationSeverityWarning ValidationSeverity = "warning"
+)
+
+// ValidationResult is a problem found validating a submitted SparkApplication. Field is the path of the offending
+// field, e.g. spec.driver.podName, so UIs and CLIs can point at it, and Rule names the check it failed.
+type ValidationResult struct {
+	Field    string             `json:"field" example:"spec.driver.podName"`
+	Rule     string             `json:"rule" example:"dnsLabel"`
+	Message  string             `json:"message" example:"spec.driver.podName 'My_Driver' must consist of lowercase alphanumeric characters or '-', and start and end with an alphanumeric character, use 'my-driver' instead"`
+	Severity ValidationSeverity `json:"severity" example:"error"`
+}
+
+// ValidationError rejects a submitted SparkApplication with the results that failed it. Its results are returned with
+// the error message under validation in the response body.
+type ValidationError struct {
+	Results []ValidationResult `json:"validation"`
+}
+
+// NewValidationError returns a ValidationError with results if any of them has ValidationSeverityError, otherwise nil
+func NewValidationError(results []ValidationResult) error {
+	for _, result := range results {
+		if result.Severity == ValidationSeverityError {
+			return &ValidationError{Results: results}
+		}
+	}
+	return nil
+}
+
+func (e *ValidationError) Error() string {
+	var messages []string
+	for _, result := range e.Results {
+		if result.Severity == ValidationSeverityError {
+			messages = append(messages, result.Message)
+		}
+	}
+	return strings.Join(messages, "; ")
+}
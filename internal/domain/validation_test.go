@@ -0,0 +1,60 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewValidationError(t *testing.T) {
+	podName := ValidationResult{Field: "spec.driver.podName", Rule: "dnsLabel", Message: "spec.driver.podName is invalid", Severity: ValidationSeverityError}
+	name := ValidationResult{Field: "metadata.name", Rule: "maxLength", Message: "metadata.name is too long", Severity: ValidationSeverityError}
+	warning := ValidationResult{Field: "spec.sparkVersion", Rule: "deprecated", Message: "spec.sparkVersion is deprecated", Severity: ValidationSeverityWarning}
+
+	tests := []struct {
+		name            string
+		results         []ValidationResult
+		expectedMessage string
+	}{
+		{
+			name: "no results",
+		},
+		{
+			name:    "only warnings",
+			results: []ValidationResult{warning},
+		},
+		{
+			name:            "errors",
+			results:         []ValidationResult{name, warning, podName},
+			expectedMessage: "metadata.name is too long; spec.driver.podName is invalid",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := NewValidationError(test.results)
+			if test.expectedMessage == "" {
+				assert.NoError(t, err, "results without errors should not fail validation")
+				return
+			}
+
+			assert.EqualError(t, err, test.expectedMessage, "message should only join errors")
+			assert.Equal(t, test.results, err.(*ValidationError).Results, "every result should be returned")
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+// SoftQuotaWarning is the code of warnings for submissions admitted to a namespace whose ResourceQuota utilization is
+// above the soft quota threshold
+const SoftQuotaWarning = "SoftQuotaExceeded"
+
+// LocalityWarning is the code of warnings for submissions routed outside the region or zone they prefer, because none
+// of the clusters of their namespace in it could be routed to
+const LocalityWarning = "LocalityPreferenceUnmet"
+
+// SubmissionWarning is a condition found while admitting a submission or building a response, returned so the caller
+// can act on it before it turns into rejections or broken links. Utilization and Threshold are ratios between 0 and 1.
+type SubmissionWarning struct {
+	Code        string  `json:"code" example:"SoftQuotaExceeded"`
+	Message     string  `json:"message"`
+	Cluster     string  `json:"cluster,omitempty"`
+	Namespace   string  `json:"namespace,omitempty"`
+	Utilization float64 `json:"utilization,omitempty" example:"0.85"`
+	Threshold   float64 `json:"threshold,omitempty" example:"0.8"`
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// WatchEventType mirrors the Kubernetes watch event types
+type WatchEventType string
+
+const (
+	WatchEventAdded    WatchEventType = WatchEventType(watch.Added)
+	WatchEventModified WatchEventType = WatchEventType(watch.Modified)
+	WatchEventDeleted  WatchEventType = WatchEventType(watch.Deleted)
+	WatchEventBookmark WatchEventType = WatchEventType(watch.Bookmark)
+	WatchEventError    WatchEventType = WatchEventType(watch.Error)
+)
+
+// SparkManagerWatchEvent is a single SparkApplication watch event streamed by SparkManager as newline delimited JSON.
+// Bookmark events carry only a ResourceVersion and Error events carry Code and Error instead of an Object.
+type SparkManagerWatchEvent struct {
+	Type            WatchEventType                       `json:"type"`
+	ResourceVersion string                               `json:"resourceVersion,omitempty"`
+	Object          *SparkManagerSparkApplicationSummary `json:"object,omitempty"`
+	Code            int32                                `json:"code,omitempty"`
+	Error           string                               `json:"error,omitempty"`
+}
+
+// NewSparkManagerWatchEvent converts a Kubernetes watch event for a SparkApplication
+func NewSparkManagerWatchEvent(event watch.Event) SparkManagerWatchEvent {
+	switch obj := event.Object.(type) {
+	case *v1beta2.SparkApplication:
+		watchEvent := SparkManagerWatchEvent{
+			Type:            WatchEventType(event.Type),
+			ResourceVersion: obj.ResourceVersion,
+		}
+		if event.Type != watch.Bookmark {
+			watchEvent.Object = NewSparkManagerSparkApplicationSummary(obj)
+		}
+		return watchEvent
+	case *metav1.Status:
+		return SparkManagerWatchEvent{Type: WatchEventError, Code: obj.Code, Error: obj.Message}
+	default:
+		return SparkManagerWatchEvent{Type: WatchEventError, Error: fmt.Sprintf("unexpected watch object type %T", event.Object)}
+	}
+}
+
+// GatewayWatchEvent is a single event in the Gateway applications watch stream, which multiplexes the SparkManager
+// watch streams of every cluster and namespace being watched
+type GatewayWatchEvent struct {
+	Type      WatchEventType             `json:"type"`
+	Cluster   string                     `json:"cluster"`
+	Namespace string                     `json:"namespace"`
+	Object    *GatewayApplicationSummary `json:"object,omitempty"`
+	Code      int32                      `json:"code,omitempty"`
+	Error     string                     `json:"error,omitempty"`
+	// Bookmark resumes the whole stream after this event when passed back as the resourceVersion query parameter
+	Bookmark string `json:"bookmark,omitempty"`
+}
+
+// WatchBookmark holds the last resourceVersion seen on each SparkManager watch stream, keyed by WatchStreamKey
+type WatchBookmark map[string]string
+
+// WatchStreamKey identifies the SparkManager watch stream of namespace in cluster within a WatchBookmark
+func WatchStreamKey(cluster string, namespace string) string {
+	return fmt.Sprintf("%s/%s", cluster, namespace)
+}
+
+// ParseWatchBookmark decodes a bookmark token returned in GatewayWatchEvent.Bookmark. An empty token starts every
+// stream from the current state.
+func ParseWatchBookmark(token string) (WatchBookmark, error) {
+	bookmark := WatchBookmark{}
+	if token == "" {
+		return bookmark, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resourceVersion bookmark: %w", err)
+	}
+
+	if err := json.Unmarshal(decoded, &bookmark); err != nil {
+		return nil, fmt.Errorf("invalid resourceVersion bookmark: %w", err)
+	}
+
+	return bookmark, nil
+}
+
+// Encode returns the opaque token form of the bookmark
+func (b WatchBookmark) Encode() string {
+	// Marshaling a map[string]string cannot fail
+	encoded, _ := json.Marshal(b)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
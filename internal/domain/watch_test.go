@@ -0,0 +1,84 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func TestNewSparkManagerWatchEvent(t *testing.T) {
+	sparkApp := &v1beta2.SparkApplication{
+		ObjectMeta: v1.ObjectMeta{Name: "app", Namespace: "ns", ResourceVersion: "42"},
+	}
+
+	testCases := []struct {
+		name     string
+		event    watch.Event
+		expected SparkManagerWatchEvent
+	}{
+		{
+			name:  "modified",
+			event: watch.Event{Type: watch.Modified, Object: sparkApp},
+			expected: SparkManagerWatchEvent{
+				Type:            WatchEventModified,
+				ResourceVersion: "42",
+				Object:          NewSparkManagerSparkApplicationSummary(sparkApp),
+			},
+		},
+		{
+			name:     "bookmark",
+			event:    watch.Event{Type: watch.Bookmark, Object: sparkApp},
+			expected: SparkManagerWatchEvent{Type: WatchEventBookmark, ResourceVersion: "42"},
+		},
+		{
+			name:     "error",
+			event:    watch.Event{Type: watch.Error, Object: &v1.Status{Code: 410, Message: "too old resource version"}},
+			expected: SparkManagerWatchEvent{Type: WatchEventError, Code: 410, Error: "too old resource version"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, NewSparkManagerWatchEvent(tc.event), "watch events should be the same")
+		})
+	}
+}
+
+func TestWatchBookmarkRoundTrip(t *testing.T) {
+	bookmark := WatchBookmark{
+		WatchStreamKey("cluster-a", "ns"): "100",
+		WatchStreamKey("cluster-b", "ns"): "7",
+	}
+
+	parsed, err := ParseWatchBookmark(bookmark.Encode())
+
+	assert.NoError(t, err, "encoded bookmark should parse")
+	assert.Equal(t, bookmark, parsed, "bookmarks should be the same")
+}
+
+func TestParseWatchBookmark(t *testing.T) {
+	empty, err := ParseWatchBookmark("")
+	assert.NoError(t, err, "empty bookmark should parse")
+	assert.Empty(t, empty, "empty bookmark should have no streams")
+
+	_, err = ParseWatchBookmark("not-a-bookmark")
+	assert.ErrorContains(t, err, "invalid resourceVersion bookmark", "invalid bookmark should error")
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/service"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+type FailedSubmissionHandler struct {
+	service service.FailedSubmissionService
+}
+
+func NewFailedSubmissionHandler(service service.FailedSubmissionService) *FailedSubmissionHandler {
+	return &FailedSubmissionHandler{service: service}
+}
+
+// ListFailedSubmissions godoc
+// @Summary List failed asynchronous submissions
+// @Description Lists the dead-lettered asynchronous submissions, which SparkManager rejected or which ran out of attempts, most recently failed first. Only served when gateway.asyncSubmission is enabled.
+// @Tags Admin
+// @Produce json
+// @Security BasicAuth
+// @Param namespace query string false "Only list the submissions to this namespace"
+// @Success 200 {array} domain.FailedSubmission "Failed submissions"
+// @Failure 403 {object} map[string]string "User is not an admin"
+// @Router /admin/failed-submissions [get]
+func (h *FailedSubmissionHandler) List(c *gin.Context) {
+
+	failed, err := h.service.List(c.Request.Context(), c.Query("namespace"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, failed)
+}
+
+// GetFailedSubmission godoc
+// @Summary Get a failed asynchronous submission
+// @Description Returns the dead-lettered asynchronous submission with the SparkApplication that failed to be submitted.
+// @Tags Admin
+// @Produce json
+// @Security BasicAuth
+// @Param gatewayId path string true "GatewayId of the submission"
+// @Success 200 {object} domain.FailedSubmission "Failed submission"
+// @Failure 403 {object} map[string]string "User is not an admin"
+// @Failure 404 {object} map[string]string "No failed submission with the GatewayId"
+// @Router /admin/failed-submissions/{gatewayId} [get]
+func (h *FailedSubmissionHandler) Get(c *gin.Context) {
+
+	failed, err := h.service.Get(c.Request.Context(), c.Param("gatewayId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, failed)
+}
+
+// EditFailedSubmission godoc
+// @Summary Edit a failed asynchronous submission
+// @Description Replaces the SparkApplication of the dead-lettered submission, e.g. the one returned by getting the submission with its spec fixed, and moves it to another cluster if 'cluster' is set. The application is validated and built for the submitting user the same way as a new submission. The submission stays failed until it is retried, under a new GatewayId if its cluster changed.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param gatewayId path string true "GatewayId of the submission"
+// @Param edit body domain.FailedSubmissionEdit true "Replacement SparkApplication and cluster"
+// @Success 200 {object} domain.FailedSubmission "Edited submission"
+// @Failure 400 {object} map[string]string "Invalid SparkApplication or cluster"
+// @Failure 403 {object} map[string]string "User is not an admin"
+// @Failure 404 {object} map[string]string "No failed submission with the GatewayId"
+// @Failure 422 {object} map[string]string "Invalid SparkApplication names"
+// @Router /admin/failed-submissions/{gatewayId} [put]
+func (h *FailedSubmissionHandler) Edit(c *gin.Context) {
+
+	var edit domain.FailedSubmissionEdit
+	if err := c.ShouldBindJSON(&edit); err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	failed, err := h.service.Edit(c.Request.Context(), c.Param("gatewayId"), edit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, failed)
+}
+
+// RetryFailedSubmission godoc
+// @Summary Retry a failed asynchronous submission
+// @Description Queues the dead-lettered submission again with its attempts reset. It is submitted to the same cluster by the next drain of the submission queue and can be followed by its GatewayId.
+// @Tags Admin
+// @Security BasicAuth
+// @Param gatewayId path string true "GatewayId of the submission"
+// @Success 202 "Submission queued again"
+// @Failure 403 {object} map[string]string "User is not an admin"
+// @Failure 404 {object} map[string]string "No failed submission with the GatewayId"
+// @Router /admin/failed-submissions/{gatewayId}/retry [post]
+func (h *FailedSubmissionHandler) Retry(c *gin.Context) {
+
+	if err := h.service.Retry(c.Request.Context(), c.Param("gatewayId")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// DeleteFailedSubmission godoc
+// @Summary Delete a failed asynchronous submission
+// @Description Rejects the dead-lettered submission for good, removing it from the submission queue before its retention runs out.
+// @Tags Admin
+// @Security BasicAuth
+// @Param gatewayId path string true "GatewayId of the submission"
+// @Success 204 "Submission deleted"
+// @Failure 403 {object} map[string]string "User is not an admin"
+// @Failure 404 {object} map[string]string "No failed submission with the GatewayId"
+// @Router /admin/failed-submissions/{gatewayId} [delete]
+func (h *FailedSubmissionHandler) Delete(c *gin.Context) {
+
+	if err := h.service.Delete(c.Request.Context(), c.Param("gatewayId")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/service"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	sgMiddleware "github.com/slackhq/spark-gateway/internal/shared/middleware"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+)
+
+func TestFailedSubmissionHandler(t *testing.T) {
+	notFound := gatewayerrors.NewNotFound(errors.New("no failed submission with GatewayId 'c1-missing'"))
+
+	testCases := []struct {
+		name           string
+		user           string
+		method         string
+		path           string
+		body           string
+		disabled       bool
+		expectedStatus int
+	}{
+		{
+			name:           "admin lists failed submissions",
+			user:           "admin",
+			method:         http.MethodGet,
+			path:           "/api/admin/failed-submissions?namespace=ns",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "admin gets a failed submission",
+			user:           "admin",
+			method:         http.MethodGet,
+			path:           "/api/admin/failed-submissions/c1-app",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "admin edits a failed submission",
+			user:           "admin",
+			method:         http.MethodPut,
+			path:           "/api/admin/failed-submissions/c1-app",
+			body:           `{"application": {"metadata": {"namespace": "ns"}}, "cluster": "c2"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "editing with an invalid body",
+			user:           "admin",
+			method:         http.MethodPut,
+			path:           "/api/admin/failed-submissions/c1-app",
+			body:           `{"application": [`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "admin retries a failed submission",
+			user:           "admin",
+			method:         http.MethodPost,
+			path:           "/api/admin/failed-submissions/c1-app/retry",
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "admin deletes a failed submission",
+			user:           "admin",
+			method:         http.MethodDelete,
+			path:           "/api/admin/failed-submissions/c1-app",
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "retrying a missing submission",
+			user:           "admin",
+			method:         http.MethodPost,
+			path:           "/api/admin/failed-submissions/c1-missing/retry",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "non admin is forbidden",
+			user:           "alice",
+			method:         http.MethodPost,
+			path:           "/api/admin/failed-submissions/c1-app/retry",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "not served when asynchronous submissions are disabled",
+			user:           "admin",
+			method:         http.MethodGet,
+			path:           "/api/admin/failed-submissions",
+			disabled:       true,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			failedService := &service.FailedSubmissionServiceMock{
+				ListFunc: func(ctx context.Context, namespace string) ([]domain.FailedSubmission, error) {
+					return []domain.FailedSubmission{{GatewayId: "c1-app", Namespace: namespace}}, nil
+				},
+				GetFunc: func(ctx context.Context, gatewayId string) (*domain.FailedSubmission, error) {
+					return &domain.FailedSubmission{GatewayId: gatewayId}, nil
+				},
+				EditFunc: func(ctx context.Context, gatewayId string, edit domain.FailedSubmissionEdit) (*domain.FailedSubmission, error) {
+					return &domain.FailedSubmission{GatewayId: gatewayId, Cluster: edit.Cluster, Application: edit.Application}, nil
+				},
+				RetryFunc: func(ctx context.Context, gatewayId string) error {
+					if gatewayId == "c1-missing" {
+						return notFound
+					}
+					return nil
+				},
+				DeleteFunc: func(ctx context.Context, gatewayId string) error {
+					return nil
+				},
+			}
+			var failedSubmissions service.FailedSubmissionService = failedService
+			if tc.disabled {
+				failedSubmissions = nil
+			}
+
+			router := gin.New()
+			adminGroup := router.Group("/api/admin")
+			adminGroup.Use(sgMiddleware.ApplicationErrorHandler)
+			adminGroup.Use(func(c *gin.Context) { c.Set("user", tc.user) })
+			adminGroup.Use(RequireAdmin([]string{"admin"}))
+			routes.Register(adminGroup, Routes(&service.NamespaceServiceMock{}, &service.MigrationServiceMock{}, &service.KillSwitchServiceMock{}, &service.RoutingSimulatorMock{}, nil, failedSubmissions, nil))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code, "status should match")
+			if tc.expectedStatus == http.StatusOK && tc.method == http.MethodGet && len(failedService.ListCalls()) > 0 {
+				assert.Equal(t, "ns", failedService.ListCalls()[0].Namespace, "namespace should be passed to the service")
+			}
+			if tc.expectedStatus == http.StatusOK && tc.method == http.MethodPut {
+				edit := failedService.EditCalls()[0].Edit
+				assert.Equal(t, "c2", edit.Cluster, "cluster should be passed to the service")
+				assert.Equal(t, "ns", edit.Application.Namespace, "application should be passed to the service")
+			}
+		})
+	}
+}
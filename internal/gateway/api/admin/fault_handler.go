@@ -0,0 +1,95 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/faults"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+type FaultHandler struct {
+	injector *faults.Injector
+}
+
+func NewFaultHandler(injector *faults.Injector) *FaultHandler {
+	return &FaultHandler{injector: injector}
+}
+
+// ListFaults godoc
+// @Summary List injected faults
+// @Description Lists the fault rules applied to this Gateway instance's calls to SparkManagers, by cluster. The rule of cluster `*` applies to clusters without a rule of their own.
+// @Tags Admin
+// @Produce json
+// @Security BasicAuth
+// @Success 200 {object} map[string]domain.FaultRule "Fault rules by cluster"
+// @Failure 403 {object} map[string]string "User is not an admin"
+// @Router /admin/faults [get]
+func (h *FaultHandler) List(c *gin.Context) {
+	c.JSON(http.StatusOK, h.injector.Rules())
+}
+
+// SetFault godoc
+// @Summary Inject faults into calls to a cluster's SparkManager
+// @Description Delays this Gateway instance's calls to the cluster's SparkManager, and fails a fraction of them with an error status or a connection reset, to check retries, timeouts and circuit breakers. Only available when gateway.faultInjection is enabled.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param target path string true "Cluster whose SparkManager calls to inject faults into, or * for all clusters"
+// @Param request body domain.FaultRule true "Faults to inject"
+// @Success 200 {object} domain.FaultRule "Applied fault rule"
+// @Failure 400 {object} map[string]string "Invalid fault rule"
+// @Failure 403 {object} map[string]string "User is not an admin"
+// @Router /admin/faults/{target} [put]
+func (h *FaultHandler) Set(c *gin.Context) {
+
+	var rule domain.FaultRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	if err := h.injector.Set(c.Param("target"), rule); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// ClearFault godoc
+// @Summary Stop injecting faults into calls to a cluster's SparkManager
+// @Tags Admin
+// @Produce json
+// @Security BasicAuth
+// @Param target path string true "Cluster whose SparkManager calls to stop injecting faults into, or * for all clusters"
+// @Success 204 "Fault rule removed"
+// @Failure 403 {object} map[string]string "User is not an admin"
+// @Failure 404 {object} map[string]string "No fault rule for the target"
+// @Router /admin/faults/{target} [delete]
+func (h *FaultHandler) Clear(c *gin.Context) {
+
+	if err := h.injector.Clear(c.Param("target")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/service"
+	"github.com/slackhq/spark-gateway/internal/shared/faults"
+	sgMiddleware "github.com/slackhq/spark-gateway/internal/shared/middleware"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+)
+
+func TestFaultHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		user           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedRules  map[string]domain.FaultRule
+	}{
+		{
+			name:           "admin sets fault rule",
+			user:           "admin",
+			method:         http.MethodPut,
+			path:           "/api/admin/faults/cluster",
+			body:           `{"latency":"250ms","errorRate":0.1}`,
+			expectedStatus: http.StatusOK,
+			expectedRules:  map[string]domain.FaultRule{"existing": {ErrorRate: 1}, "cluster": {Latency: "250ms", ErrorRate: 0.1}},
+		},
+		{
+			name:           "invalid fault rule",
+			user:           "admin",
+			method:         http.MethodPut,
+			path:           "/api/admin/faults/cluster",
+			body:           `{"errorRate":2}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedRules:  map[string]domain.FaultRule{"existing": {ErrorRate: 1}},
+		},
+		{
+			name:           "admin clears fault rule",
+			user:           "admin",
+			method:         http.MethodDelete,
+			path:           "/api/admin/faults/existing",
+			expectedStatus: http.StatusNoContent,
+			expectedRules:  map[string]domain.FaultRule{},
+		},
+		{
+			name:           "clearing missing fault rule",
+			user:           "admin",
+			method:         http.MethodDelete,
+			path:           "/api/admin/faults/cluster",
+			expectedStatus: http.StatusNotFound,
+			expectedRules:  map[string]domain.FaultRule{"existing": {ErrorRate: 1}},
+		},
+		{
+			name:           "admin lists fault rules",
+			user:           "admin",
+			method:         http.MethodGet,
+			path:           "/api/admin/faults",
+			expectedStatus: http.StatusOK,
+			expectedRules:  map[string]domain.FaultRule{"existing": {ErrorRate: 1}},
+		},
+		{
+			name:           "non admin is forbidden",
+			user:           "alice",
+			method:         http.MethodPut,
+			path:           "/api/admin/faults/cluster",
+			body:           `{"errorRate":1}`,
+			expectedStatus: http.StatusForbidden,
+			expectedRules:  map[string]domain.FaultRule{"existing": {ErrorRate: 1}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			injector := faults.NewInjector()
+			assert.NoError(t, injector.Set("existing", domain.FaultRule{ErrorRate: 1}), "rule should be valid")
+
+			router := gin.New()
+			adminGroup := router.Group("/api/admin")
+			adminGroup.Use(sgMiddleware.ApplicationErrorHandler)
+			adminGroup.Use(func(c *gin.Context) { c.Set("user", tc.user) })
+			adminGroup.Use(RequireAdmin([]string{"admin"}))
+			routes.Register(adminGroup, Routes(&service.NamespaceServiceMock{}, &service.MigrationServiceMock{}, &service.KillSwitchServiceMock{}, &service.RoutingSimulatorMock{}, nil, nil, injector))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.body))
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code, "status should match")
+			assert.Equal(t, tc.expectedRules, injector.Rules(), "rules should match")
+		})
+	}
+}
+
+func TestFaultRoutesDisabled(t *testing.T) {
+	router := gin.New()
+	adminGroup := router.Group("/api/admin")
+	adminGroup.Use(func(c *gin.Context) { c.Set("user", "admin") })
+	adminGroup.Use(RequireAdmin([]string{"admin"}))
+	routes.Register(adminGroup, Routes(&service.NamespaceServiceMock{}, &service.MigrationServiceMock{}, &service.KillSwitchServiceMock{}, &service.RoutingSimulatorMock{}, nil, nil, nil))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/admin/faults", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code, "fault routes should not be registered without an injector")
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/service"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+type KillSwitchHandler struct {
+	service service.KillSwitchService
+}
+
+func NewKillSwitchHandler(service service.KillSwitchService) *KillSwitchHandler {
+	return &KillSwitchHandler{service: service}
+}
+
+// ListKillSwitches godoc
+// @Summary List engaged namespace kill switches
+// @Description Lists the namespaces whose new submissions are rejected by this Gateway instance.
+// @Tags Admin
+// @Produce json
+// @Security BasicAuth
+// @Success 200 {array} domain.NamespaceKillSwitch "Engaged kill switches"
+// @Failure 403 {object} map[string]string "User is not an admin"
+// @Router /admin/killswitches [get]
+func (h *KillSwitchHandler) List(c *gin.Context) {
+	c.JSON(http.StatusOK, h.service.List())
+}
+
+// EngageKillSwitch godoc
+// @Summary Engage a namespace kill switch
+// @Description Immediately rejects new GatewayApplications submitted to the namespace in every cluster with a 403 carrying the message, for incident response. With suspendRunning, the executors of the namespace's running GatewayApplications are also scaled to zero and the outcome of each is returned; they are not scaled back up on release. With the database enabled the kill switch is persisted and picked up by every Gateway instance within gateway.killSwitches.refreshInterval, otherwise it is held in memory by the receiving instance.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param namespace path string true "Namespace to reject submissions to"
+// @Param request body domain.KillSwitchRequest false "Message and whether to suspend running applications"
+// @Success 200 {object} domain.NamespaceKillSwitch "Engaged kill switch"
+// @Failure 403 {object} map[string]string "User is not an admin"
+// @Failure 404 {object} map[string]string "Namespace not configured in any cluster"
+// @Router /admin/killswitches/{namespace} [put]
+func (h *KillSwitchHandler) Engage(c *gin.Context) {
+
+	var request domain.KillSwitchRequest
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	killSwitch, err := h.service.Engage(c.Request.Context(), c.Param("namespace"), c.GetString("user"), request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, killSwitch)
+}
+
+// ReleaseKillSwitch godoc
+// @Summary Release a namespace kill switch
+// @Description Accepts new GatewayApplications submitted to the namespace again.
+// @Tags Admin
+// @Produce json
+// @Security BasicAuth
+// @Param namespace path string true "Namespace to accept submissions to"
+// @Success 204 "Kill switch released"
+// @Failure 403 {object} map[string]string "User is not an admin"
+// @Failure 404 {object} map[string]string "No kill switch engaged for the namespace"
+// @Router /admin/killswitches/{namespace} [delete]
+func (h *KillSwitchHandler) Release(c *gin.Context) {
+
+	if err := h.service.Release(c.Request.Context(), c.Param("namespace")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/service"
+	sgMiddleware "github.com/slackhq/spark-gateway/internal/shared/middleware"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+)
+
+func TestKillSwitchHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		user           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedCalls  int
+	}{
+		{
+			name:           "admin engages kill switch",
+			user:           "admin",
+			method:         http.MethodPut,
+			path:           "/api/admin/killswitches/ns",
+			body:           `{"message":"incident 123","suspendRunning":true}`,
+			expectedStatus: http.StatusOK,
+			expectedCalls:  1,
+		},
+		{
+			name:           "admin engages kill switch without body",
+			user:           "admin",
+			method:         http.MethodPut,
+			path:           "/api/admin/killswitches/ns",
+			expectedStatus: http.StatusOK,
+			expectedCalls:  1,
+		},
+		{
+			name:           "invalid body",
+			user:           "admin",
+			method:         http.MethodPut,
+			path:           "/api/admin/killswitches/ns",
+			body:           `{"suspendRunning":"yes"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "admin releases kill switch",
+			user:           "admin",
+			method:         http.MethodDelete,
+			path:           "/api/admin/killswitches/ns",
+			expectedStatus: http.StatusNoContent,
+			expectedCalls:  1,
+		},
+		{
+			name:           "admin lists kill switches",
+			user:           "admin",
+			method:         http.MethodGet,
+			path:           "/api/admin/killswitches",
+			expectedStatus: http.StatusOK,
+			expectedCalls:  1,
+		},
+		{
+			name:           "non admin is forbidden",
+			user:           "alice",
+			method:         http.MethodPut,
+			path:           "/api/admin/killswitches/ns",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			killSwitchService := &service.KillSwitchServiceMock{
+				EngageFunc: func(ctx context.Context, namespace string, user string, request domain.KillSwitchRequest) (*domain.NamespaceKillSwitch, error) {
+					return &domain.NamespaceKillSwitch{Namespace: namespace, Message: request.Message, EngagedBy: user}, nil
+				},
+				ReleaseFunc: func(ctx context.Context, namespace string) error {
+					return nil
+				},
+				ListFunc: func() []domain.NamespaceKillSwitch {
+					return []domain.NamespaceKillSwitch{}
+				},
+			}
+
+			router := gin.New()
+			adminGroup := router.Group("/api/admin")
+			adminGroup.Use(sgMiddleware.ApplicationErrorHandler)
+			adminGroup.Use(func(c *gin.Context) { c.Set("user", tc.user) })
+			adminGroup.Use(RequireAdmin([]string{"admin"}))
+			routes.Register(adminGroup, Routes(&service.NamespaceServiceMock{}, &service.MigrationServiceMock{}, killSwitchService, &service.RoutingSimulatorMock{}, nil, nil, nil))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.body))
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code, "status should match")
+			calls := len(killSwitchService.EngageCalls()) + len(killSwitchService.ReleaseCalls()) + len(killSwitchService.ListCalls())
+			assert.Equal(t, tc.expectedCalls, calls, "service calls should match")
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/service"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+type MigrationHandler struct {
+	service service.MigrationService
+}
+
+func NewMigrationHandler(service service.MigrationService) *MigrationHandler {
+	return &MigrationHandler{service: service}
+}
+
+// MigrateApplication godoc
+// @Summary Migrate a GatewayApplication to another cluster
+// @Description Deletes the GatewayApplication from its cluster and resubmits its spec to the requested cluster under a new GatewayId. The resubmitted application is annotated with 'spark-gateway/migrated-from' and 'spark-gateway/original-gateway-id'. The application is only deleted once its pods render in the requested cluster. If the resubmission still fails and the database is enabled, its spec is recorded as a failed asynchronous submission named in the error.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param gatewayId path string true "GatewayId of the application to migrate"
+// @Param migration body domain.MigrationRequest true "Cluster to migrate to"
+// @Success 201 {object} domain.GatewayApplication "Resubmitted GatewayApplication"
+// @Failure 400 {object} map[string]string "Invalid target cluster"
+// @Failure 403 {object} map[string]string "User is not an admin"
+// @Failure 404 {object} map[string]string "GatewayApplication not found"
+// @Router /admin/applications/{gatewayId}/migrate [post]
+func (h *MigrationHandler) Migrate(c *gin.Context) {
+
+	migration, ok := bindMigrationRequest(c)
+	if !ok {
+		return
+	}
+
+	migrated, err := h.service.Migrate(c.Request.Context(), c.Param("gatewayId"), migration.Cluster)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, migrated)
+}
+
+// MigrateNamespace godoc
+// @Summary Migrate a namespace's GatewayApplications to another cluster
+// @Description Migrates every GatewayApplication in the namespace on the cluster that hasn't completed or failed to the requested cluster, for evacuating a cluster. A failed migration doesn't stop the others; the outcome of each is returned.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param cluster path string true "Cluster to migrate from"
+// @Param namespace path string true "Namespace to migrate"
+// @Param migration body domain.MigrationRequest true "Cluster to migrate to"
+// @Success 200 {array} domain.MigrationResult "Outcome of each migration"
+// @Failure 400 {object} map[string]string "Invalid target cluster"
+// @Failure 403 {object} map[string]string "User is not an admin"
+// @Failure 404 {object} map[string]string "Cluster or namespace not found"
+// @Router /admin/clusters/{cluster}/namespaces/{namespace}/migrate [post]
+func (h *MigrationHandler) MigrateNamespace(c *gin.Context) {
+
+	migration, ok := bindMigrationRequest(c)
+	if !ok {
+		return
+	}
+
+	results, err := h.service.MigrateNamespace(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), migration.Cluster)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+func bindMigrationRequest(c *gin.Context) (*domain.MigrationRequest, bool) {
+	var migration domain.MigrationRequest
+	if err := c.ShouldBindJSON(&migration); err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return nil, false
+	}
+
+	if migration.Cluster == "" {
+		c.Error(gatewayerrors.NewBadRequest(errors.New("migration request must have a 'cluster'")))
+		return nil, false
+	}
+
+	return &migration, true
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/service"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	sgMiddleware "github.com/slackhq/spark-gateway/internal/shared/middleware"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+)
+
+func TestMigrationHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		user           string
+		path           string
+		body           string
+		serviceErr     error
+		expectedStatus int
+		expectedCalls  int
+	}{
+		{
+			name:           "admin migrates application",
+			user:           "admin",
+			path:           "/api/admin/applications/a-ns-uuid/migrate",
+			body:           `{"cluster":"cluster-b"}`,
+			expectedStatus: http.StatusCreated,
+			expectedCalls:  1,
+		},
+		{
+			name:           "admin migrates namespace",
+			user:           "admin",
+			path:           "/api/admin/clusters/cluster-a/namespaces/ns/migrate",
+			body:           `{"cluster":"cluster-b"}`,
+			expectedStatus: http.StatusOK,
+			expectedCalls:  1,
+		},
+		{
+			name:           "non admin is forbidden",
+			user:           "alice",
+			path:           "/api/admin/applications/a-ns-uuid/migrate",
+			body:           `{"cluster":"cluster-b"}`,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "missing cluster",
+			user:           "admin",
+			path:           "/api/admin/clusters/cluster-a/namespaces/ns/migrate",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			user:           "admin",
+			path:           "/api/admin/applications/a-ns-uuid/migrate",
+			body:           `{"cluster":"cluster-a"}`,
+			serviceErr:     gatewayerrors.NewBadRequest(errors.New("same cluster")),
+			expectedStatus: http.StatusBadRequest,
+			expectedCalls:  1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			migrationService := &service.MigrationServiceMock{
+				MigrateFunc: func(ctx context.Context, gatewayId string, targetCluster string) (*domain.GatewayApplication, error) {
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return &domain.GatewayApplication{GatewayId: "b-ns-uuid", Cluster: targetCluster}, nil
+				},
+				MigrateNamespaceFunc: func(ctx context.Context, sourceCluster string, namespace string, targetCluster string) ([]domain.MigrationResult, error) {
+					return []domain.MigrationResult{{GatewayId: "a-ns-uuid", NewGatewayId: "b-ns-uuid", Cluster: targetCluster}}, nil
+				},
+			}
+
+			router := gin.New()
+			adminGroup := router.Group("/api/admin")
+			adminGroup.Use(sgMiddleware.ApplicationErrorHandler)
+			adminGroup.Use(func(c *gin.Context) { c.Set("user", tc.user) })
+			adminGroup.Use(RequireAdmin([]string{"admin"}))
+			routes.Register(adminGroup, Routes(&service.NamespaceServiceMock{}, migrationService, &service.KillSwitchServiceMock{}, &service.RoutingSimulatorMock{}, nil, nil, nil))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, tc.path, bytes.NewBufferString(tc.body))
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code, "status should match")
+			calls := len(migrationService.MigrateCalls()) + len(migrationService.MigrateNamespaceCalls())
+			assert.Equal(t, tc.expectedCalls, calls, "service calls should match")
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/service"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+// RequireAdmin rejects requests whose authenticated user is not one of adminUsers with a 403. It must run after the
+// middleware that sets the `user` context variable.
+func RequireAdmin(adminUsers []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.GetString("user")
+		if !slices.Contains(adminUsers, user) {
+			c.Error(gatewayerrors.NewForbidden(fmt.Errorf("user '%s' is not a Spark Gateway admin", user)))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+type NamespaceHandler struct {
+	service service.NamespaceService
+}
+
+func NewNamespaceHandler(service service.NamespaceService) *NamespaceHandler {
+	return &NamespaceHandler{service: service}
+}
+
+// RegisterNamespace godoc
+// @Summary Register a namespace
+// @Description Registers a namespace with a cluster at runtime so GatewayApplications can be submitted to it. With provision, the cluster's SparkManager creates the namespace, a ServiceAccount (default 'spark') and the Role Spark drivers need before it becomes routable. With the database enabled the registration is persisted and picked up by every Gateway instance within gateway.routingWeights.refreshInterval, otherwise it is held in memory by the receiving instance.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param cluster path string true "Cluster name"
+// @Param registration body domain.NamespaceRegistration true "Namespace to register"
+// @Success 201 {object} domain.RegisteredNamespace "Registered namespace"
+// @Failure 403 {object} map[string]string "User is not an admin"
+// @Failure 409 {object} map[string]string "Namespace already registered"
+// @Failure 422 {object} map[string]string "Namespace would make the cluster configuration invalid"
+// @Router /admin/clusters/{cluster}/namespaces [post]
+func (h *NamespaceHandler) Register(c *gin.Context) {
+
+	var registration domain.NamespaceRegistration
+	if err := c.ShouldBindJSON(&registration); err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	if registration.Name == "" || registration.NamespaceId == "" {
+		c.Error(gatewayerrors.NewBadRequest(errors.New("namespace registration must have a 'name' and 'id'")))
+		return
+	}
+
+	registered, err := h.service.Register(c.Request.Context(), c.Param("cluster"), registration)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, registered)
+}
+
+// SetNamespaceWeight godoc
+// @Summary Set a namespace's routing weight
+// @Description Overrides the routing weight of a namespace in a cluster at runtime, shifting how much new traffic the weight based routers send to it. A weight of 0 drains the namespace of new GatewayApplications. With the database enabled the weight is persisted and picked up by every Gateway instance within gateway.routingWeights.refreshInterval, otherwise it is held in memory by the receiving instance.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param cluster path string true "Cluster name"
+// @Param namespace path string true "Namespace name"
+// @Param weight body domain.NamespaceWeightRequest true "Routing weight"
+// @Success 200 {object} domain.NamespaceWeight "Routing weight set"
+// @Failure 400 {object} map[string]string "Routing weight missing or negative"
+// @Failure 403 {object} map[string]string "User is not an admin"
+// @Failure 404 {object} map[string]string "Cluster or namespace not found"
+// @Router /admin/clusters/{cluster}/namespaces/{namespace}/weight [put]
+func (h *NamespaceHandler) SetWeight(c *gin.Context) {
+
+	var request domain.NamespaceWeightRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	weight, err := h.service.SetWeight(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.GetString("user"), request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, weight)
+}
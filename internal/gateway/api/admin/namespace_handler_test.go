@@ -0,0 +1,111 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/service"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	sgMiddleware "github.com/slackhq/spark-gateway/internal/shared/middleware"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func TestNamespaceHandlerRegister(t *testing.T) {
+	testCases := []struct {
+		name           string
+		user           string
+		body           string
+		serviceErr     error
+		expectedStatus int
+		expectedCalls  int
+	}{
+		{
+			name:           "admin registers namespace",
+			user:           "admin",
+			body:           `{"name":"team-a","id":"teama","provision":true}`,
+			expectedStatus: http.StatusCreated,
+			expectedCalls:  1,
+		},
+		{
+			name:           "non admin is forbidden",
+			user:           "alice",
+			body:           `{"name":"team-a","id":"teama"}`,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "missing id",
+			user:           "admin",
+			body:           `{"name":"team-a"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			user:           "admin",
+			body:           `{"name":"team-a","id":"teama"}`,
+			serviceErr:     gatewayerrors.NewConflict(errors.New("already registered")),
+			expectedStatus: http.StatusConflict,
+			expectedCalls:  1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			namespaceService := &service.NamespaceServiceMock{
+				RegisterFunc: func(ctx context.Context, cluster string, registration domain.NamespaceRegistration) (*domain.RegisteredNamespace, error) {
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return &domain.RegisteredNamespace{Cluster: cluster, Name: registration.Name, NamespaceId: registration.NamespaceId, RoutingWeight: 1, Provisioned: registration.Provision}, nil
+				},
+			}
+
+			router := gin.New()
+			adminGroup := router.Group("/api/admin")
+			adminGroup.Use(sgMiddleware.ApplicationErrorHandler)
+			adminGroup.Use(func(c *gin.Context) { c.Set("user", tc.user) })
+			adminGroup.Use(RequireAdmin([]string{"admin"}))
+			routes.Register(adminGroup, Routes(namespaceService, &service.MigrationServiceMock{}, &service.KillSwitchServiceMock{}, &service.RoutingSimulatorMock{}, nil, nil, nil))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/api/admin/clusters/cluster/namespaces", bytes.NewBufferString(tc.body))
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code, "status should match")
+			assert.Len(t, namespaceService.RegisterCalls(), tc.expectedCalls, "service calls should match")
+
+			if tc.expectedStatus == http.StatusCreated {
+				var registered domain.RegisteredNamespace
+				json.Unmarshal(w.Body.Bytes(), &registered)
+				assert.Equal(t, domain.RegisteredNamespace{Cluster: "cluster", Name: "team-a", NamespaceId: "teama", RoutingWeight: 1, Provisioned: true}, registered, "response should match")
+			}
+		})
+	}
+}
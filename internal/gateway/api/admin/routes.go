@@ -0,0 +1,76 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slackhq/spark-gateway/internal/gateway/service"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/faults"
+	sgMiddleware "github.com/slackhq/spark-gateway/internal/shared/middleware"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+)
+
+// Group declares the admin API for operating Spark Gateway at runtime, authenticated by the middleware configured for
+// admin routes and restricted to adminUsers
+func Group(adminUsers []string, namespaceService service.NamespaceService, migrationService service.MigrationService, killSwitchService service.KillSwitchService, routingSimulator service.RoutingSimulator, stuckDetector service.StuckApplicationDetector, failedSubmissions service.FailedSubmissionService, faultInjector *faults.Injector) routes.Group {
+	return routes.Group{
+		Prefix:     "/api/admin",
+		Auth:       config.AdminRouteGroup,
+		Middleware: []gin.HandlerFunc{sgMiddleware.ApplicationErrorHandler},
+		Authorize:  []gin.HandlerFunc{RequireAdmin(adminUsers)},
+		Routes:     Routes(namespaceService, migrationService, killSwitchService, routingSimulator, stuckDetector, failedSubmissions, faultInjector),
+	}
+}
+
+// Routes declares routes for operating Spark Gateway at runtime
+func Routes(namespaceService service.NamespaceService, migrationService service.MigrationService, killSwitchService service.KillSwitchService, routingSimulator service.RoutingSimulator, stuckDetector service.StuckApplicationDetector, failedSubmissions service.FailedSubmissionService, faultInjector *faults.Injector) []routes.Route {
+
+	h := NewNamespaceHandler(namespaceService)
+	mh := NewMigrationHandler(migrationService)
+	kh := NewKillSwitchHandler(killSwitchService)
+	rh := NewRoutingHandler(routingSimulator)
+
+	adminRoutes := []routes.Route{
+		{Method: http.MethodPost, Path: "/clusters/:cluster/namespaces", Handler: h.Register, Writes: true},
+		{Method: http.MethodPut, Path: "/clusters/:cluster/namespaces/:namespace/weight", Handler: h.SetWeight, Writes: true},
+		{Method: http.MethodPost, Path: "/clusters/:cluster/namespaces/:namespace/migrate", Handler: mh.MigrateNamespace, Writes: true},
+		{Method: http.MethodPost, Path: "/applications/:gatewayId/migrate", Handler: mh.Migrate, Writes: true},
+
+		{Method: http.MethodGet, Path: "/killswitches", Handler: kh.List},
+		{Method: http.MethodPut, Path: "/killswitches/:namespace", Handler: kh.Engage, Writes: true},
+		{Method: http.MethodDelete, Path: "/killswitches/:namespace", Handler: kh.Release, Writes: true},
+
+		{Method: http.MethodPost, Path: "/routing/simulate", Handler: rh.Simulate},
+	}
+
+	// stuckDetector is nil unless gateway.stuckApplications is enabled
+	if stuckDetector != nil {
+		sh := NewStuckApplicationHandler(stuckDetector)
+		adminRoutes = append(adminRoutes, routes.Route{Method: http.MethodGet, Path: "/stuck-applications", Handler: sh.List})
+	}
+
+	// failedSubmissions is nil unless gateway.asyncSubmission is enabled
+	if failedSubmissions != nil {
+		fsh := NewFailedSubmissionHandler(failedSubmissions)
+		adminRoutes = append(adminRoutes,
+			routes.Route{Method: http.MethodGet, Path: "/failed-submissions", Handler: fsh.List},
+			routes.Route{Method: http.MethodGet, Path: "/failed-submissions/:gatewayId", Handler: fsh.Get},
+			routes.Route{Method: http.MethodPut, Path: "/failed-submissions/:gatewayId", Handler: fsh.Edit, Writes: true},
+			routes.Route{Method: http.MethodPost, Path: "/failed-submissions/:gatewayId/retry", Handler: fsh.Retry, Writes: true},
+			routes.Route{Method: http.MethodDelete, Path: "/failed-submissions/:gatewayId", Handler: fsh.Delete, Writes: true},
+		)
+	}
+
+	// faultInjector is nil unless gateway.faultInjection is enabled
+	if faultInjector != nil {
+		fh := NewFaultHandler(faultInjector)
+		adminRoutes = append(adminRoutes,
+			routes.Route{Method: http.MethodGet, Path: "/faults", Handler: fh.List},
+			routes.Route{Method: http.MethodPut, Path: "/faults/:target", Handler: fh.Set},
+			routes.Route{Method: http.MethodDelete, Path: "/faults/:target", Handler: fh.Clear},
+		)
+	}
+
+	return adminRoutes
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/service"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+type RoutingHandler struct {
+	simulator service.RoutingSimulator
+}
+
+func NewRoutingHandler(simulator service.RoutingSimulator) *RoutingHandler {
+	return &RoutingHandler{simulator: simulator}
+}
+
+// SimulateRouting godoc
+// @Summary Simulate cluster routing
+// @Description Returns the distribution of clusters the configured cluster routers would choose over count submissions to the namespace, without submitting anything, to validate routing weight changes before applying them. Simulated submissions are not queued when every cluster is at its concurrency cap; they are counted as unroutable.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param simulation body domain.RoutingSimulationRequest true "Namespace and number of submissions to simulate, at most 1000"
+// @Success 200 {object} domain.RoutingSimulation "Clusters chosen"
+// @Failure 400 {object} map[string]string "Missing namespace or count out of range"
+// @Failure 403 {object} map[string]string "User is not an admin"
+// @Failure 404 {object} map[string]string "Namespace not registered in any cluster"
+// @Router /admin/routing/simulate [post]
+func (h *RoutingHandler) Simulate(c *gin.Context) {
+
+	var request domain.RoutingSimulationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	simulation, err := h.simulator.Simulate(c.Request.Context(), request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, simulation)
+}
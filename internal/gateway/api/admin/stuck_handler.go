@@ -0,0 +1,44 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slackhq/spark-gateway/internal/gateway/service"
+)
+
+type StuckApplicationHandler struct {
+	detector service.StuckApplicationDetector
+}
+
+func NewStuckApplicationHandler(detector service.StuckApplicationDetector) *StuckApplicationHandler {
+	return &StuckApplicationHandler{detector: detector}
+}
+
+// ListStuckApplications godoc
+// @Summary List stuck applications
+// @Description Lists the GatewayApplications found SUBMITTED or PENDING_RERUN for longer than gateway.stuckApplications.threshold by the latest detection, longest stuck first. Only served when the detector is enabled.
+// @Tags Admin
+// @Produce json
+// @Security BasicAuth
+// @Success 200 {array} domain.StuckApplication "Stuck applications"
+// @Failure 403 {object} map[string]string "User is not an admin"
+// @Router /admin/stuck-applications [get]
+func (h *StuckApplicationHandler) List(c *gin.Context) {
+	c.JSON(http.StatusOK, h.detector.List())
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/service"
+	sgMiddleware "github.com/slackhq/spark-gateway/internal/shared/middleware"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+)
+
+func TestStuckApplicationHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		user           string
+		disabled       bool
+		expectedStatus int
+		expectedCalls  int
+	}{
+		{
+			name:           "admin lists stuck applications",
+			user:           "admin",
+			expectedStatus: http.StatusOK,
+			expectedCalls:  1,
+		},
+		{
+			name:           "non admin is forbidden",
+			user:           "alice",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "not served when the detector is disabled",
+			user:           "admin",
+			disabled:       true,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			detector := &service.StuckApplicationDetectorMock{
+				ListFunc: func() []domain.StuckApplication {
+					return []domain.StuckApplication{{GatewayId: "c1-app", Cluster: "c1"}}
+				},
+			}
+			var stuckDetector service.StuckApplicationDetector = detector
+			if tc.disabled {
+				stuckDetector = nil
+			}
+
+			router := gin.New()
+			adminGroup := router.Group("/api/admin")
+			adminGroup.Use(sgMiddleware.ApplicationErrorHandler)
+			adminGroup.Use(func(c *gin.Context) { c.Set("user", tc.user) })
+			adminGroup.Use(RequireAdmin([]string{"admin"}))
+			routes.Register(adminGroup, Routes(&service.NamespaceServiceMock{}, &service.MigrationServiceMock{}, &service.KillSwitchServiceMock{}, &service.RoutingSimulatorMock{}, stuckDetector, nil, nil))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/api/admin/stuck-applications", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code, "status should match")
+			assert.Equal(t, tc.expectedCalls, len(detector.ListCalls()), "detector calls should match")
+		})
+	}
+}
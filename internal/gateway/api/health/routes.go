@@ -1,11 +1,17 @@
 package health
 
-import "github.com/gin-gonic/gin"
+import (
+	"net/http"
 
-func RegisterHealthRoutes(rg *gin.RouterGroup) {
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+)
 
-	h := &HealthHandler{}
+// Routes declares the unauthenticated health route
+func Routes() []routes.Route {
 
-	rg.GET("/health", h.Health)
+	h := &HealthHandler{}
 
+	return []routes.Route{
+		{Method: http.MethodGet, Path: "/health", Handler: h.Health},
+	}
 }
@@ -0,0 +1,215 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/service"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+)
+
+// The tests in this file check the Livy API against the responses of Apache Livy that clients such as Sparkmagic and
+// Airflow's Livy operators parse, so they keep working without patches.
+
+const compatGatewayId = "clusterid-nsid-uuid"
+
+// newCompatRouter serves the Livy API of a LivyApplicationService backed by appService and a database holding batch 7
+func newCompatRouter(t *testing.T, appService *service.GatewayApplicationServiceMock) *gin.Engine {
+	db := &database.LivyApplicationDatabaseMock{
+		GetByBatchIdFunc: func(ctx context.Context, batchId int) (database.LivyApplication, error) {
+			if batchId != 7 {
+				return database.LivyApplication{}, gatewayerrors.NewNotFound(fmt.Errorf("batch %d not found", batchId))
+			}
+			return database.LivyApplication{BatchID: 7, GatewayID: compatGatewayId}, nil
+		},
+		ListFromFunc: func(ctx context.Context, fromId int, size int) ([]database.LivyApplication, error) {
+			return nil, nil
+		},
+		InsertLivyApplicationFunc: func(ctx context.Context, gatewayId string) (database.LivyApplication, error) {
+			return database.LivyApplication{BatchID: 7, GatewayID: gatewayId}, nil
+		},
+		SetLivyApplicationTerminalBatchFunc: func(ctx context.Context, batchId int, batch *domain.LivyBatch) error {
+			return nil
+		},
+	}
+	livyService := service.NewLivyService(appService, db, "default", domain.StatusUrlTemplates{})
+
+	router := gin.New()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("user", "user")
+		ctx.Next()
+	})
+	registry := routes.NewRegistry()
+	registry.Add(Group(testConfig, livyService, appService))
+	err := registry.Build(router, func(rg *gin.RouterGroup, auth config.MiddlewareRouteGroup, allowAnonymous bool) error {
+		return nil
+	})
+	assert.NoError(t, err, "routes should build")
+
+	return router
+}
+
+func serveCompat(router *gin.Engine, method string, path string, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func applicationInState(state v1beta2.ApplicationStateType) *domain.GatewayApplication {
+	return &domain.GatewayApplication{
+		GatewayId: compatGatewayId,
+		SparkApplication: domain.GatewaySparkApplication{
+			Status: v1beta2.SparkApplicationStatus{AppState: v1beta2.ApplicationState{State: state}},
+		},
+	}
+}
+
+func TestLivyCompatBatchState(t *testing.T) {
+	tests := []struct {
+		appState  v1beta2.ApplicationStateType
+		livyState string
+	}{
+		{appState: "", livyState: "not_started"},
+		{appState: v1beta2.ApplicationStateSubmitted, livyState: "starting"},
+		{appState: v1beta2.ApplicationStatePendingRerun, livyState: "starting"},
+		{appState: v1beta2.ApplicationStateRunning, livyState: "running"},
+		{appState: v1beta2.ApplicationStateSucceeding, livyState: "shutting_down"},
+		{appState: v1beta2.ApplicationStateCompleted, livyState: "success"},
+		{appState: v1beta2.ApplicationStateFailed, livyState: "dead"},
+		{appState: v1beta2.ApplicationStateFailedSubmission, livyState: "dead"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.livyState+"/"+string(tc.appState), func(t *testing.T) {
+			router := newCompatRouter(t, &service.GatewayApplicationServiceMock{
+				GetFunc: func(ctx context.Context, gatewayId string) (*domain.GatewayApplication, error) {
+					return applicationInState(tc.appState), nil
+				},
+			})
+
+			w := serveCompat(router, http.MethodGet, "/api/livy/batches/7/state", "")
+
+			assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+			assert.JSONEq(t, fmt.Sprintf(`{"id": 7, "state": %q}`, tc.livyState), w.Body.String(), "bodies should match")
+		})
+	}
+}
+
+func TestLivyCompatCreateBatch(t *testing.T) {
+	router := newCompatRouter(t, &service.GatewayApplicationServiceMock{
+		CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error) {
+			return applicationInState(v1beta2.ApplicationStateSubmitted), nil
+		},
+	})
+
+	w := serveCompat(router, http.MethodPost, "/api/livy/batches", `{"file": "local:///app.jar", "proxyUser": "user"}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code, "codes should match")
+	assert.Equal(t, "/api/livy/batches/7", w.Header().Get("Location"), "the batch should be located")
+	assert.JSONEq(t, `{
+		"id": 7,
+		"appId": "",
+		"appInfo": {"driverLogUrl": "", "sparkUiUrl": "", "sparkHistoryUrl": "", "GatewayId": "clusterid-nsid-uuid", "Cluster": ""},
+		"ttl": "-1",
+		"log": [],
+		"state": "starting"
+	}`, w.Body.String(), "bodies should match")
+}
+
+func TestLivyCompatListBatches(t *testing.T) {
+	router := newCompatRouter(t, &service.GatewayApplicationServiceMock{})
+
+	w := serveCompat(router, http.MethodGet, "/api/livy/batches", "")
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.JSONEq(t, `{"from": 0, "total": 0, "sessions": []}`, w.Body.String(), "no batches should be an empty array")
+}
+
+func TestLivyCompatDeleteBatch(t *testing.T) {
+	router := newCompatRouter(t, &service.GatewayApplicationServiceMock{
+		DeleteFunc: func(ctx context.Context, gatewayId string) error {
+			return nil
+		},
+	})
+
+	w := serveCompat(router, http.MethodDelete, "/api/livy/batches/7", "")
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.JSONEq(t, `{"msg": "deleted"}`, w.Body.String(), "bodies should match")
+}
+
+func TestLivyCompatBatchLogs(t *testing.T) {
+	router := newCompatRouter(t, &service.GatewayApplicationServiceMock{
+		LogsFunc: func(ctx context.Context, gatewayId string, tailLines int) (*string, error) {
+			logs := "line 1\nline 2"
+			return &logs, nil
+		},
+	})
+
+	w := serveCompat(router, http.MethodGet, "/api/livy/batches/7/log?size=100", "")
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.JSONEq(t, `{"id": 7, "from": 0, "total": 2, "log": ["line 1", "line 2"]}`, w.Body.String(), "bodies should match")
+}
+
+func TestLivyCompatErrors(t *testing.T) {
+	router := newCompatRouter(t, &service.GatewayApplicationServiceMock{
+		DeleteFunc: func(ctx context.Context, gatewayId string) error {
+			return errors.New("SparkManager unavailable")
+		},
+	})
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+	}{
+		{name: "unknown batch", method: http.MethodGet, path: "/api/livy/batches/8", status: http.StatusNotFound},
+		{name: "invalid batch id", method: http.MethodGet, path: "/api/livy/batches/abc", status: http.StatusBadRequest},
+		{name: "failed delete", method: http.MethodDelete, path: "/api/livy/batches/7", status: http.StatusInternalServerError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveCompat(router, tc.method, tc.path, "")
+
+			var body map[string]any
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body), "errors should be JSON")
+			assert.Equal(t, tc.status, w.Code, "codes should match")
+			assert.Len(t, body, 1, "errors should only have the msg envelope")
+			assert.NotEmpty(t, body["msg"], "errors should have a msg")
+		})
+	}
+}
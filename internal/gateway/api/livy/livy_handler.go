@@ -51,22 +51,19 @@ func validateIntParam(c *gin.Context, paramName string, isPathParam bool, requir
 	return value, true
 }
 
-// resolveProxyUser determines the proxy user for a Livy batch request
-// Priority order: request proxyUser field > authenticated user
-func resolveProxyUser(c *gin.Context, req *domain.LivyCreateBatchRequest) error {
-	// If proxyUser is already set in the request, use it
-	if req.ProxyUser != "" {
-		return nil
-	}
-
-	// Fall back to the authenticated user from the context
+// livySubmitter returns the authenticated identity submitting a Livy batch. The proxyUser of the request is left for
+// the namespace's proxyUser policy to accept or reject.
+func livySubmitter(c *gin.Context) (service.LivySubmitter, error) {
 	gotUser, exists := c.Get("user")
 	if !exists {
-		return errors.New("no user set, congratulations you've encountered a bug that should never happen")
+		return service.LivySubmitter{}, errors.New("no user set, congratulations you've encountered a bug that should never happen")
 	}
 
-	req.ProxyUser = gotUser.(string)
-	return nil
+	return service.LivySubmitter{
+		User:       gotUser.(string),
+		ActingUser: c.GetString("actingUser"),
+		Team:       c.GetString("team"),
+	}, nil
 }
 
 type LivyHandler struct {
@@ -133,6 +130,11 @@ func (l *LivyHandler) List(c *gin.Context) {
 		return
 	}
 
+	// Livy lists no batches as an empty array rather than null
+	if listBatches == nil {
+		listBatches = []*domain.LivyBatch{}
+	}
+
 	c.JSON(http.StatusOK, domain.LivyListBatchesResponse{
 		From:     from,
 		Total:    len(listBatches),
@@ -143,7 +145,7 @@ func (l *LivyHandler) List(c *gin.Context) {
 
 // CreateLivyBatch godoc
 // @Summary Create a new Livy batch
-// @Description Submits a new Livy batch request. Proxy user can be specified via request body proxyUser field or defaults to authenticated user.
+// @Description Submits a new Livy batch request. The batch is submitted as the authenticated user, a proxyUser in the request body is subject to the namespace's proxyUser policy.
 // @Tags Livy
 // @Accept json
 // @Produce json
@@ -159,8 +161,8 @@ func (l *LivyHandler) Create(c *gin.Context) {
 		return
 	}
 
-	// Resolve the proxy user for this request
-	if err := resolveProxyUser(c, &createReq); err != nil {
+	submitter, err := livySubmitter(c)
+	if err != nil {
 		c.Error(err)
 		return
 	}
@@ -168,12 +170,13 @@ func (l *LivyHandler) Create(c *gin.Context) {
 	// Get namespace from headers if supplied
 	namespace := c.GetHeader("X-Spark-Gateway-Livy-Namespace")
 
-	createdBatch, err := l.livyService.Create(c, createReq, namespace)
+	createdBatch, err := l.livyService.Create(c, createReq, namespace, submitter)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
+	c.Header("Location", fmt.Sprintf("%s/%d", c.Request.URL.Path, createdBatch.Id))
 	c.JSON(http.StatusCreated, createdBatch)
 }
 
@@ -210,7 +213,7 @@ func (l *LivyHandler) Delete(c *gin.Context) {
 // @Produce json
 // @Security BasicAuth
 // @Param batchId path int true "Batch ID"
-// @Param size query int false "Number of log lines to retrieve (default: 0 for all)"
+// @Param size query int false "Number of log lines to retrieve (default: the namespace's defaultLogLines, capped at its maxLogLines)"
 // @Success 200 {object} domain.LivyLogBatchResponse "Livy batch logs"
 // @Router /batches/{batchId}/log [get]
 func (l *LivyHandler) Logs(c *gin.Context) {
@@ -231,10 +234,10 @@ func (l *LivyHandler) Logs(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, domain.LivyLogBatchResponse{
-		Id:   logsId,
-		From: -1,
-		Size: size,
-		Log:  logs,
+		Id:    logsId,
+		From:  0,
+		Total: len(logs),
+		Log:   logs,
 	})
 }
 
@@ -11,11 +11,15 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
 	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/clusterrouter"
 	"github.com/slackhq/spark-gateway/internal/gateway/service"
 	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
 	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
 	"github.com/slackhq/spark-gateway/internal/shared/middleware"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -102,7 +106,7 @@ func TestLivyApplicationhandlerGet(t *testing.T) {
 	}
 
 	router, livyGroup := NewLivyRouter()
-	RegisterLivyBatchRoutes(livyGroup, service)
+	routes.Register(livyGroup, BatchRoutes(service))
 
 	req, _ := http.NewRequest("GET", "/api/livy/batches/0", nil)
 	w := httptest.NewRecorder()
@@ -119,7 +123,7 @@ func TestLivyApplicationhandlerGetBadIdInt(t *testing.T) {
 	service := &service.LivyApplicationServiceMock{}
 
 	router, livyGroup := NewLivyRouter()
-	RegisterLivyBatchRoutes(livyGroup, service)
+	routes.Register(livyGroup, BatchRoutes(service))
 
 	req, _ := http.NewRequest("GET", "/api/livy/batches/badId", nil)
 	w := httptest.NewRecorder()
@@ -135,7 +139,7 @@ func TestLivyApplicationhandlerGetBadIdNegativeNumber(t *testing.T) {
 	service := &service.LivyApplicationServiceMock{}
 
 	router, livyGroup := NewLivyRouter()
-	RegisterLivyBatchRoutes(livyGroup, service)
+	routes.Register(livyGroup, BatchRoutes(service))
 
 	req, _ := http.NewRequest("GET", "/api/livy/batches/-1", nil)
 	w := httptest.NewRecorder()
@@ -169,12 +173,12 @@ func TestLivyApplicationHandlerCreate(t *testing.T) {
 	}
 
 	service := &service.LivyApplicationServiceMock{
-		CreateFunc: func(ctx context.Context, createReq domain.LivyCreateBatchRequest, namespace string) (*domain.LivyBatch, error) {
+		CreateFunc: func(ctx context.Context, createReq domain.LivyCreateBatchRequest, namespace string, submitter service.LivySubmitter) (*domain.LivyBatch, error) {
 			return retApp, nil
 		},
 	}
 
-	RegisterLivyBatchRoutes(livyGroup, service)
+	routes.Register(livyGroup, BatchRoutes(service))
 
 	createReq := domain.LivyCreateBatchRequest{
 		File:      "testFile",
@@ -219,68 +223,102 @@ func TestLivyApplicationHandlerCreate(t *testing.T) {
 	assert.Equal(t, gotApp, *retApp, "returned JSON should match")
 }
 
-func TestResolveProxyUser_ExistingProxyUser(t *testing.T) {
-	gin.SetMode(gin.TestMode)
-
-	// Setup
-	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	req := httptest.NewRequest("POST", "/test", nil)
-	c.Request = req
+func TestLivyApplicationHandlerCreateProxyUserPolicy(t *testing.T) {
+	cluster := domain.KubeCluster{
+		Name:      "cluster",
+		ClusterId: "clusterid",
+		Namespaces: []domain.KubeNamespace{{
+			Name:        "default",
+			NamespaceId: "nsid",
+			ProxyUser:   domain.ProxyUserPolicy{Mode: domain.ProxyUserModePreserve, AllowOverride: []string{"^admin$"}},
+		}},
+	}
+	clusterRouter := &clusterrouter.ClusterRouterMock{
+		GetClusterFunc: func(ctx context.Context, namespace string) (*domain.KubeCluster, error) {
+			return &cluster, nil
+		},
+	}
+	gatewayIdGen := func(cluster domain.KubeCluster, namespace string) (string, error) {
+		return "clusterid-nsid-uuid", nil
+	}
 
-	createReq := &domain.LivyCreateBatchRequest{
-		ProxyUser: "requestUser",
+	tests := []struct {
+		name          string
+		user          string
+		proxyUser     string
+		statusCode    int
+		wantProxyUser string
+	}{
+		{name: "no proxyUser", user: "user", proxyUser: "", statusCode: http.StatusCreated, wantProxyUser: "user"},
+		{name: "own proxyUser", user: "user", proxyUser: "user", statusCode: http.StatusCreated, wantProxyUser: "user"},
+		{name: "allowed override", user: "admin", proxyUser: "other", statusCode: http.StatusCreated, wantProxyUser: "other"},
+		{name: "forbidden override", user: "user", proxyUser: "other", statusCode: http.StatusForbidden},
 	}
 
-	// Test
-	err := resolveProxyUser(c, createReq)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			appRepo := &service.GatewayApplicationRepositoryMock{
+				CreateFunc: func(ctx context.Context, cluster domain.KubeCluster, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
+					return application, nil
+				},
+			}
+			appService := service.NewApplicationService(appRepo, nil, clusterRouter, clusterRouter, config.GatewayConfig{}, "", "", gatewayIdGen)
+			livyDatabase := &database.LivyApplicationDatabaseMock{
+				InsertLivyApplicationFunc: func(ctx context.Context, gatewayId string) (database.LivyApplication, error) {
+					return database.LivyApplication{BatchID: 7, GatewayID: gatewayId}, nil
+				},
+			}
+			livyService := service.NewLivyService(appService, livyDatabase, "default", domain.StatusUrlTemplates{})
 
-	// Assertions
-	assert.NoError(t, err)
-	assert.Equal(t, "requestUser", createReq.ProxyUser, "existing proxyUser should be preserved")
-}
+			router := gin.New()
+			livyGroup := router.Group("/api/livy")
+			livyGroup.Use(LivyErrorHandler)
+			livyGroup.Use(func(ctx *gin.Context) {
+				ctx.Set("user", tc.user)
+				ctx.Next()
+			})
+			routes.Register(livyGroup, BatchRoutes(livyService))
 
-func TestResolveProxyUser_AuthenticatedUser(t *testing.T) {
-	gin.SetMode(gin.TestMode)
+			jsonReq, _ := json.Marshal(domain.LivyCreateBatchRequest{File: "local:///app.jar", ProxyUser: tc.proxyUser})
+			req, _ := http.NewRequest("POST", "/api/livy/batches", bytes.NewBuffer(jsonReq))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
 
-	// Setup
+			assert.Equal(t, tc.statusCode, w.Code, "codes should match")
+			if tc.statusCode != http.StatusCreated {
+				assert.Empty(t, appRepo.CreateCalls(), "the SparkApplication should not be created")
+				return
+			}
+			if assert.Len(t, appRepo.CreateCalls(), 1, "the SparkApplication should be created") {
+				created := appRepo.CreateCalls()[0].Application
+				assert.Equal(t, tc.wantProxyUser, *created.Spec.ProxyUser, "proxyUser should be resolved by the policy")
+				assert.Equal(t, tc.user, created.Labels[domain.GATEWAY_USER_LABEL], "the owner should be the authenticated user")
+			}
+		})
+	}
+}
+
+func TestLivySubmitter(t *testing.T) {
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
-	req := httptest.NewRequest("POST", "/test", nil)
-	c.Request = req
-	c.Set("user", "authenticatedUser")
+	c.Request = httptest.NewRequest("POST", "/test", nil)
+	c.Set("user", "user")
+	c.Set("actingUser", "principal")
+	c.Set("team", "team")
 
-	createReq := &domain.LivyCreateBatchRequest{
-		ProxyUser: "", // Empty proxy user
-	}
+	submitter, err := livySubmitter(c)
 
-	// Test
-	err := resolveProxyUser(c, createReq)
-
-	// Assertions
 	assert.NoError(t, err)
-	assert.Equal(t, "authenticatedUser", createReq.ProxyUser, "should fall back to authenticated user")
+	assert.Equal(t, service.LivySubmitter{User: "user", ActingUser: "principal", Team: "team"}, submitter, "submitter should match the authenticated identity")
 }
 
-func TestResolveProxyUser_NoUserContext(t *testing.T) {
-	gin.SetMode(gin.TestMode)
-
-	// Setup
+func TestLivySubmitter_NoUserContext(t *testing.T) {
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
-	req := httptest.NewRequest("POST", "/test", nil)
-	c.Request = req
-	// Don't set user context
+	c.Request = httptest.NewRequest("POST", "/test", nil)
 
-	createReq := &domain.LivyCreateBatchRequest{
-		ProxyUser: "", // Empty proxy user
-	}
-
-	// Test
-	err := resolveProxyUser(c, createReq)
+	_, err := livySubmitter(c)
 
-	// Assertions
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "no user set")
 }
-
@@ -0,0 +1,66 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livy
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/slackhq/spark-gateway/internal/gateway/metrics"
+)
+
+// batchOperations maps Livy routes, relative to the Livy group, to the operation used in Livy request metrics
+var batchOperations = map[string]string{
+	http.MethodGet + " /batches":                "list",
+	http.MethodPost + " /batches":               "create",
+	http.MethodGet + " /batches/:batchId":       "get",
+	http.MethodGet + " /batches/:batchId/state": "state",
+	http.MethodDelete + " /batches/:batchId":    "delete",
+	http.MethodGet + " /batches/:batchId/log":   "logs",
+}
+
+// requestOperation returns the operation for a request method and its matched route relative to groupPath. Routes that
+// are not known are reported as "other" so unmatched paths cannot grow label cardinality.
+func requestOperation(method string, groupPath string, fullPath string) string {
+	if operation, ok := batchOperations[method+" "+strings.TrimPrefix(fullPath, groupPath)]; ok {
+		return operation
+	}
+	return "other"
+}
+
+// RequestMetrics returns a middleware recording Livy request counts and latencies labeled by operation, separately from
+// the native API since Livy clients poll batches rather than watching them. groupPath is the path of the group the Livy
+// routes are served under.
+func RequestMetrics(groupPath string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		// Skip requests which didn't match a route, such as 404s
+		if c.FullPath() == "" {
+			return
+		}
+
+		operation := requestOperation(c.Request.Method, groupPath, c.FullPath())
+		metrics.LivyRequestsTotal.WithLabelValues(operation, strconv.Itoa(c.Writer.Status())).Inc()
+		metrics.LivyRequestDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/metrics"
+	"github.com/slackhq/spark-gateway/internal/gateway/service"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+)
+
+func TestRequestOperation(t *testing.T) {
+	assert.Equal(t, "state", requestOperation(http.MethodGet, "/api/livy", "/api/livy/batches/:batchId/state"), "operations should match")
+	assert.Equal(t, "delete", requestOperation(http.MethodDelete, "/api/livy", "/api/livy/batches/:batchId"), "operations should match")
+	assert.Equal(t, "other", requestOperation(http.MethodPut, "/api/livy", "/api/livy/batches/:batchId"), "unknown routes should be reported as other")
+}
+
+func TestRequestMetrics(t *testing.T) {
+	livyService := &service.LivyApplicationServiceMock{
+		GetFunc: func(ctx context.Context, batchId int) (*domain.LivyBatch, error) {
+			if batchId == 404 {
+				return nil, gatewayerrors.NewNotFound(errors.New("no batch"))
+			}
+			return &domain.LivyBatch{Id: int32(batchId)}, nil
+		},
+	}
+
+	router := gin.New()
+	registry := routes.NewRegistry()
+	registry.Add(Group(testConfig, livyService, &service.GatewayApplicationServiceMock{}))
+	err := registry.Build(router, func(rg *gin.RouterGroup, auth config.MiddlewareRouteGroup, allowAnonymous bool) error {
+		return nil
+	})
+	assert.NoError(t, err, "routes should build")
+
+	okBefore := testutil.ToFloat64(metrics.LivyRequestsTotal.WithLabelValues("get", "200"))
+	notFoundBefore := testutil.ToFloat64(metrics.LivyRequestsTotal.WithLabelValues("get", "404"))
+
+	for _, path := range []string{"/api/livy/batches/1", "/api/livy/batches/404", "/api/livy/unknown"} {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, okBefore+1, testutil.ToFloat64(metrics.LivyRequestsTotal.WithLabelValues("get", "200")), "successful requests should be counted")
+	assert.Equal(t, notFoundBefore+1, testutil.ToFloat64(metrics.LivyRequestsTotal.WithLabelValues("get", "404")), "requests should be counted with the status code of the rendered error")
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.LivyRequestsTotal.WithLabelValues("other", "404")), "unmatched requests shouldn't be counted")
+}
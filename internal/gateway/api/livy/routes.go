@@ -1,22 +1,68 @@
 package livy
 
 import (
+	"net/http"
+	"strconv"
+
 	"github.com/gin-gonic/gin"
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/api/middleware"
 	"github.com/slackhq/spark-gateway/internal/gateway/service"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
 )
 
-// RegisterLivyBatchRoutes registers routes handling GatewayApplication submissions
-func RegisterLivyBatchRoutes(rg *gin.RouterGroup, livyService service.LivyApplicationService) {
+// Group declares the Livy compatible API, authenticated by the middleware configured for livy routes
+func Group(sgConf *config.SparkGatewayConfig, livyService service.LivyApplicationService, appService service.GatewayApplicationReader) routes.Group {
+	group := routes.Group{
+		Prefix: "/api/livy",
+		Auth:   config.LivyRouteGroup,
+		Routes: BatchRoutes(livyService),
+	}
+	// Metrics are recorded once errors are rendered so they are labeled with the status code returned
+	group.Middleware = []gin.HandlerFunc{RequestMetrics(group.BasePath()), LivyErrorHandler}
 
-	h := NewLivyBatchApplicationHandler(livyService)
+	if sgConf.GatewayConfig.RBAC.Enable {
+		group.Authorize = []gin.HandlerFunc{middleware.AuthorizeRoles(sgConf.GatewayConfig.RBAC, sgConf.GatewayConfig.AdminUsers, Owner(livyService, appService))}
+	}
+
+	return group
+}
+
+// Owner returns a middleware.OwnerFunc returning the owner of the application of requests to routes with a batchId
+func Owner(livyService service.LivyApplicationService, appService service.GatewayApplicationReader) middleware.OwnerFunc {
+	return func(c *gin.Context) (string, bool, error) {
+		batchId, err := strconv.Atoi(c.Param("batchId"))
+		if err != nil {
+			// Requests without a valid batchId are rejected by the handlers
+			return "", false, nil
+		}
 
-	rg.GET("/batches", h.List)
-	rg.POST("/batches", h.Create)
+		batch, err := livyService.Get(c, batchId)
+		if err != nil {
+			return "", false, err
+		}
+		app, err := appService.Get(c, batch.AppInfo["GatewayId"])
+		if err != nil {
+			return "", false, err
+		}
+		return domain.ApplicationOwner(app), true, nil
+	}
+}
+
+// BatchRoutes declares routes handling GatewayApplication submissions as Livy batches
+func BatchRoutes(livyService service.LivyApplicationService) []routes.Route {
+
+	h := NewLivyBatchApplicationHandler(livyService)
 
-	rg.GET("/batches/:batchId", h.Get)
-	rg.GET("/batches/:batchId/state", h.State)
-	rg.DELETE("/batches/:batchId", h.Delete)
+	return []routes.Route{
+		{Method: http.MethodGet, Path: "/batches", Handler: h.List},
+		{Method: http.MethodPost, Path: "/batches", Handler: h.Create, Writes: true},
 
-	rg.GET("/batches/:batchId/log", h.Logs)
+		{Method: http.MethodGet, Path: "/batches/:batchId", Handler: h.Get},
+		{Method: http.MethodGet, Path: "/batches/:batchId/state", Handler: h.State},
+		{Method: http.MethodDelete, Path: "/batches/:batchId", Handler: h.Delete, Writes: true},
 
+		{Method: http.MethodGet, Path: "/batches/:batchId/log", Handler: h.Logs},
+	}
 }
@@ -16,12 +16,15 @@
 package middleware
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 
 	"github.com/gin-gonic/gin"
 	"github.com/knadh/koanf/providers/confmap"
 	"github.com/knadh/koanf/v2"
 	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
 	"k8s.io/klog/v2"
 )
 
@@ -33,6 +36,11 @@ var BuiltinMiddleware map[string]NewMiddleware = map[string]NewMiddleware{
 	"RegexBasicAuthDenyMiddleware":  NewRegexBasicAuthDenyMiddleware,
 	"HeaderAuthMiddleware":          NewHeaderAuthMiddleware,
 	"ServiceTokenAuthMiddleware":    NewServiceTokenAuthMiddleware,
+	"ImpersonationMiddleware":       NewImpersonationMiddleware,
+	"LDAPGroupMiddleware":           NewLDAPGroupMiddleware,
+	"HtpasswdAuthMiddleware":        NewHtpasswdAuthMiddleware,
+	"HMACAuthMiddleware":            NewHMACAuthMiddleware,
+	"IdentityExtractorMiddleware":   NewIdentityExtractorMiddleware,
 }
 
 //go:generate moq -out mockmiddleware.go . GatewayMiddleware
@@ -67,17 +75,29 @@ func LoadMiddlewareConf(mw GatewayMiddlewareConf, conf MiddlewareConfMap) error
 	return nil
 }
 
-func AddMiddleware(mwDefs []config.MiddlewareDefinition, rg *gin.RouterGroup) error {
+// AddMiddleware adds the middleware in mwDefs that apply to routeGroup to rg, ordered by their Order. If allowAnonymous
+// is set, requests none of them authenticate continue as the anonymous user instead of being rejected.
+func AddMiddleware(mwDefs []config.MiddlewareDefinition, rg *gin.RouterGroup, routeGroup config.MiddlewareRouteGroup, allowAnonymous bool) error {
 
-	// If no definitions are passed, we return the AnonymousUserMiddleware to ensure
+	var groupDefs []config.MiddlewareDefinition
+	for _, mwDef := range mwDefs {
+		if mwDef.AppliesTo(routeGroup) {
+			groupDefs = append(groupDefs, mwDef)
+		}
+	}
+	slices.SortStableFunc(groupDefs, func(a, b config.MiddlewareDefinition) int {
+		return cmp.Compare(a.Order, b.Order)
+	})
+
+	// If no definitions apply, we return the AnonymousUserMiddleware to ensure
 	// a user exists
-	if len(mwDefs) == 0 {
-		klog.Info("No middleware configured, setting AnonymousUserMiddleware")
+	if len(groupDefs) == 0 {
+		klog.Infof("No middleware configured for %s routes, setting AnonymousUserMiddleware", routeGroup)
 		rg.Use(AnonymousUserMiddleware)
 		return nil
 	}
 
-	for _, mwDef := range mwDefs {
+	for _, mwDef := range groupDefs {
 
 		// Get from available middleware
 		// TODO: Make these plugins
@@ -86,7 +106,7 @@ func AddMiddleware(mwDefs []config.MiddlewareDefinition, rg *gin.RouterGroup) er
 			return fmt.Errorf("no builtin middleware with type [%s]", mwDef.Type)
 		}
 
-		klog.Infof("Initializing middleware [%s]", mwDef.Type)
+		klog.Infof("Initializing middleware [%s] for %s routes", mwDef.Type, routeGroup)
 		mwImpl, err := mwNew(mwDef.Conf)
 
 		if err != nil {
@@ -96,8 +116,30 @@ func AddMiddleware(mwDefs []config.MiddlewareDefinition, rg *gin.RouterGroup) er
 		rg.Use(mwImpl.Handler)
 	}
 
+	if allowAnonymous {
+		rg.Use(AnonymousFallbackMiddleware)
+		return nil
+	}
+
 	// IsAuthed goes last to ensure a User exists for
 	rg.Use(IsAuthed)
 
 	return nil
 }
+
+// Authenticator returns a routes.Authenticator adding the middleware in mwDefs that apply to each Group's route group
+func Authenticator(mwDefs []config.MiddlewareDefinition) routes.Authenticator {
+	return func(rg *gin.RouterGroup, routeGroup config.MiddlewareRouteGroup, allowAnonymous bool) error {
+		return AddMiddleware(mwDefs, rg, routeGroup, allowAnonymous)
+	}
+}
+
+// ScopedTo returns whether any middleware in mwDefs lists routeGroup in its routes
+func ScopedTo(mwDefs []config.MiddlewareDefinition, routeGroup config.MiddlewareRouteGroup) bool {
+	for _, mwDef := range mwDefs {
+		if slices.Contains(mwDef.Routes, routeGroup) {
+			return true
+		}
+	}
+	return false
+}
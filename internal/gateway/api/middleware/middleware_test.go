@@ -78,7 +78,7 @@ func TestAddMiddleware(t *testing.T) {
 			router := gin.New()
 			root := router.Group("/test")
 
-			err := AddMiddleware(test.mwDefs, root)
+			err := AddMiddleware(test.mwDefs, root, config.APIRouteGroup, false)
 			if err != nil {
 				assert.Equal(t, test.err, err.Error(), "errors should match")
 				return
@@ -108,3 +108,90 @@ func TestAddMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestAddMiddlewareOrderAndRoutes(t *testing.T) {
+	var ran []string
+	for _, name := range []string{"first", "second", "livyOnly"} {
+		BuiltinMiddleware[name] = func(conf MiddlewareConfMap) (GatewayMiddleware, error) {
+			return &GatewayMiddlewareMock{HandlerFunc: func(c *gin.Context) {
+				ran = append(ran, name)
+				c.Set("user", name)
+			}}, nil
+		}
+		defer delete(BuiltinMiddleware, name)
+	}
+
+	mwDefs := []config.MiddlewareDefinition{
+		{Type: "second", Order: 2},
+		{Type: "livyOnly", Order: 1, Routes: []config.MiddlewareRouteGroup{config.LivyRouteGroup, config.MetricsRouteGroup}},
+		{Type: "first", Order: 1},
+	}
+
+	tests := []struct {
+		routeGroup config.MiddlewareRouteGroup
+		expected   []string
+	}{
+		{routeGroup: config.APIRouteGroup, expected: []string{"first", "second"}},
+		{routeGroup: config.LivyRouteGroup, expected: []string{"livyOnly", "first", "second"}},
+		{routeGroup: config.MetricsRouteGroup, expected: []string{"livyOnly"}},
+	}
+
+	for _, test := range tests {
+		ran = nil
+		router := gin.New()
+		root := router.Group("/test")
+		assert.NoError(t, AddMiddleware(mwDefs, root, test.routeGroup, false), "%s: adding middleware should not error", test.routeGroup)
+		root.GET("")
+
+		req, _ := http.NewRequest("GET", "/test", nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.Equal(t, test.expected, ran, "%s: middleware should run in order", test.routeGroup)
+	}
+
+	assert.True(t, ScopedTo(mwDefs, config.MetricsRouteGroup), "metrics should be scoped")
+	assert.False(t, ScopedTo(mwDefs, config.AdminRouteGroup), "admin should only get unscoped middleware")
+}
+
+func TestAddMiddlewareAllowAnonymous(t *testing.T) {
+	mwDefs := []config.MiddlewareDefinition{
+		{Type: "RegexBasicAuthAllowMiddleware", Conf: map[string]any{"allow": []string{"test"}}},
+	}
+
+	tests := []struct {
+		test              string
+		authHeader        string
+		expectedStatus    int
+		expectedUser      string
+		expectedAnonymous bool
+	}{
+		{test: "no credentials", expectedStatus: http.StatusOK, expectedUser: AnonymousUser, expectedAnonymous: true},
+		{test: "authenticated", authHeader: "Basic dGVzdDp0ZXN0", expectedStatus: http.StatusOK, expectedUser: "test"},
+		{test: "rejected credentials", authHeader: "Basic b3RoZXI6dGVzdA==", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.test, func(t *testing.T) {
+			var gotUser string
+			var gotAnonymous bool
+			router := gin.New()
+			root := router.Group("/test")
+			assert.NoError(t, AddMiddleware(mwDefs, root, config.APIRouteGroup, true), "adding middleware should not error")
+			root.GET("", func(c *gin.Context) {
+				gotUser = c.GetString("user")
+				gotAnonymous = c.GetBool("anonymous")
+			})
+
+			req, _ := http.NewRequest("GET", "/test", nil)
+			if test.authHeader != "" {
+				req.Header.Add("Authorization", test.authHeader)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatus, w.Code, "codes should match")
+			assert.Equal(t, test.expectedUser, gotUser, "user should match")
+			assert.Equal(t, test.expectedAnonymous, gotAnonymous, "anonymous should match")
+		})
+	}
+}
@@ -19,9 +19,22 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+const AnonymousUser = "anonymous"
+
 // AnonymousUserMiddleware ensures that a User is set in the context. This is only used when no Middleware
 // are configured
 func AnonymousUserMiddleware(c *gin.Context) {
-	c.Set("user", "anonymous")
+	c.Set("user", AnonymousUser)
+	c.Next()
+}
+
+// AnonymousFallbackMiddleware sets the anonymous user for requests that no configured Middleware authenticated, and
+// sets the context `anonymous` key so authorization can restrict what they are allowed to do. It runs after the
+// configured Middleware in place of IsAuthed.
+func AnonymousFallbackMiddleware(c *gin.Context) {
+	if user := c.GetString("user"); user == "" {
+		c.Set("user", AnonymousUser)
+		c.Set("anonymous", true)
+	}
 	c.Next()
 }
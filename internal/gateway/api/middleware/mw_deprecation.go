@@ -0,0 +1,62 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/slackhq/spark-gateway/internal/gateway/metrics"
+)
+
+// Deprecation declares when a route was deprecated and when it will be removed, see RFC 9745 and RFC 8594
+type Deprecation struct {
+	// Since is when the route was deprecated
+	Since time.Time
+	// Sunset is when the route will stop being served, unset if it hasn't been decided
+	Sunset time.Time
+	// Successor is the path or URL of the route replacing it, if any
+	Successor string
+	// Link is the URL documenting the deprecation, if any
+	Link string
+}
+
+// Deprecated sets the Deprecation, Sunset and Link headers of deprecation on every response of the route it is
+// registered on, and counts its requests in metrics.DeprecatedRequestsTotal. It is meant to be registered per route,
+// before the route's handler, e.g. rg.GET("/old", middleware.Deprecated(deprecation), h.Old). Handlers should also be
+// marked @Deprecated in their godoc so the Swagger docs show it.
+func Deprecated(deprecation Deprecation) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		metrics.DeprecatedRequestsTotal.WithLabelValues(c.Request.Method, c.FullPath()).Inc()
+
+		// Deprecation is a structured field date, the Unix time prefixed with @
+		c.Header("Deprecation", fmt.Sprintf("@%d", deprecation.Since.Unix()))
+		if !deprecation.Sunset.IsZero() {
+			c.Header("Sunset", deprecation.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if deprecation.Successor != "" {
+			c.Writer.Header().Add("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, deprecation.Successor))
+		}
+		if deprecation.Link != "" {
+			c.Writer.Header().Add("Link", fmt.Sprintf(`<%s>; rel="deprecation"; type="text/html"`, deprecation.Link))
+		}
+
+		c.Next()
+	}
+}
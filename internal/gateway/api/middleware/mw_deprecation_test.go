@@ -0,0 +1,65 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/gateway/metrics"
+)
+
+func TestDeprecated(t *testing.T) {
+	router := gin.New()
+	router.GET("/api/v1/old/:id", Deprecated(Deprecation{
+		Since:     time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		Sunset:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Successor: "/api/v2/new",
+		Link:      "https://example.com/deprecations",
+	}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.GET("/api/v1/undecided", Deprecated(Deprecation{Since: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	before := testutil.ToFloat64(metrics.DeprecatedRequestsTotal.WithLabelValues(http.MethodGet, "/api/v1/old/:id"))
+
+	for _, id := range []string{"a", "b"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/old/"+id, nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code, "deprecated route should still be served")
+		assert.Equal(t, "@1751328000", w.Header().Get("Deprecation"), "deprecation should be the structured date of Since")
+		assert.Equal(t, "Thu, 01 Jan 2026 00:00:00 GMT", w.Header().Get("Sunset"), "sunset should be the HTTP date of Sunset")
+		assert.Equal(t, []string{`</api/v2/new>; rel="successor-version"`, `<https://example.com/deprecations>; rel="deprecation"; type="text/html"`}, w.Header().Values("Link"), "links should match")
+	}
+	assert.Equal(t, before+2, testutil.ToFloat64(metrics.DeprecatedRequestsTotal.WithLabelValues(http.MethodGet, "/api/v1/old/:id")), "requests should be counted by route")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/undecided", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, "@1751328000", w.Header().Get("Deprecation"), "deprecation should be set")
+	assert.Empty(t, w.Header().Get("Sunset"), "sunset should be omitted until decided")
+	assert.Empty(t, w.Header().Values("Link"), "links should be omitted when unset")
+}
@@ -0,0 +1,337 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/file"
+	"github.com/knadh/koanf/v2"
+	"k8s.io/klog/v2"
+)
+
+const (
+	HMACKeysPathDefault     = "/conf/hmac-keys.yaml"
+	HMACMaxClockSkewDefault = 5 * time.Minute
+	HMACMaxBodyBytesDefault = 10 << 20
+	HMACReloadDefault       = 30 * time.Second
+
+	HMACClientHeader    = "X-Spark-Gateway-Client"
+	HMACTimestampHeader = "X-Spark-Gateway-Timestamp"
+	HMACSignatureHeader = "X-Spark-Gateway-Signature"
+)
+
+// HMACKeyMap maps client names to their accepted signing keys. Multiple keys per client allow rotating
+// keys without downtime.
+type HMACKeyMap map[string][]string
+
+// HMACAuthMiddleware authenticates machine clients that sign requests with a shared key. The signature is
+// the hex encoded HMAC-SHA256 of StringToSign. Requests with a timestamp outside MaxClockSkew, or reusing a
+// signature already seen within that window, are rejected. Seen signatures are only kept in memory, so each Gateway
+// replica rejects replays on its own. Will set the context `user` key to the client name if the signature is valid.
+type HMACAuthMiddleware struct {
+	Keys         HMACKeyMap
+	MaxClockSkew time.Duration
+	// MaxBodyBytes is the largest body read to check its signature, larger requests are rejected before being
+	// authenticated
+	MaxBodyBytes int64
+	now          func() time.Time
+
+	// keysPath is checked for changes every reloadInterval so keys can be rotated without restarting
+	keysPath       string
+	reloadInterval time.Duration
+	keysMu         sync.RWMutex
+	keysModTime    time.Time
+	nextReload     time.Time
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+	// expiries holds the seen signatures in the order they expire, so expired ones are pruned from the front
+	expiries []seenSignature
+}
+
+type seenSignature struct {
+	signature string
+	expires   time.Time
+}
+
+type HMACAuthMiddlewareConf struct {
+	KeysPath       string        `koanf:"keysFile"`
+	MaxClockSkew   time.Duration `koanf:"maxClockSkew"`
+	MaxBodyBytes   int64         `koanf:"maxBodyBytes"`
+	ReloadInterval time.Duration `koanf:"reloadInterval"`
+}
+
+func (h *HMACAuthMiddlewareConf) Name() string {
+	return "HMACAuthMiddlewareConf"
+}
+
+func (h *HMACAuthMiddlewareConf) Validate() error {
+	if h.MaxClockSkew < 0 {
+		return fmt.Errorf("maxClockSkew cannot be negative")
+	}
+	if h.MaxBodyBytes < 0 {
+		return fmt.Errorf("maxBodyBytes cannot be negative")
+	}
+	if h.ReloadInterval < 0 {
+		return fmt.Errorf("reloadInterval cannot be negative")
+	}
+	return nil
+}
+
+func NewHMACAuthMiddleware(confMap MiddlewareConfMap) (GatewayMiddleware, error) {
+	var mwConf HMACAuthMiddlewareConf
+
+	if err := LoadMiddlewareConf(&mwConf, confMap); err != nil {
+		return nil, fmt.Errorf("error creating HMACAuthMiddleware: %w", err)
+	}
+
+	filePath := mwConf.KeysPath
+	// If no path was provided in the Config, use a default
+	if filePath == "" {
+		filePath = HMACKeysPathDefault
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error creating HMACAuthMiddleware: %w", err)
+	}
+	keys, err := loadHMACKeys(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error creating HMACAuthMiddleware: %w", err)
+	}
+
+	maxClockSkew := mwConf.MaxClockSkew
+	if maxClockSkew == 0 {
+		maxClockSkew = HMACMaxClockSkewDefault
+	}
+	maxBodyBytes := mwConf.MaxBodyBytes
+	if maxBodyBytes == 0 {
+		maxBodyBytes = HMACMaxBodyBytesDefault
+	}
+	reloadInterval := mwConf.ReloadInterval
+	if reloadInterval == 0 {
+		reloadInterval = HMACReloadDefault
+	}
+
+	return &HMACAuthMiddleware{
+		Keys:           keys,
+		MaxClockSkew:   maxClockSkew,
+		MaxBodyBytes:   maxBodyBytes,
+		now:            time.Now,
+		keysPath:       filePath,
+		reloadInterval: reloadInterval,
+		keysModTime:    info.ModTime(),
+		nextReload:     time.Now().Add(reloadInterval),
+		seen:           map[string]time.Time{},
+	}, nil
+}
+
+func loadHMACKeys(filePath string) (HMACKeyMap, error) {
+	keysK := koanf.New(".")
+	if err := keysK.Load(file.Provider(filePath), yaml.Parser()); err != nil {
+		return nil, fmt.Errorf("error parsing hmac keys file: %w", err)
+	}
+
+	var keys HMACKeyMap
+	if err := keysK.Unmarshal("", &keys); err != nil {
+		return nil, fmt.Errorf("error unmarshaling hmac keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// StringToSign returns the canonical request that clients must sign.
+func StringToSign(timestamp string, method string, requestURI string, body []byte) []byte {
+	return fmt.Appendf(nil, "%s\n%s\n%s\n%s", timestamp, method, requestURI, body)
+}
+
+// SignRequest returns the hex encoded HMAC-SHA256 signature of the request with key.
+func SignRequest(key string, timestamp string, method string, requestURI string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(StringToSign(timestamp, method, requestURI, body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (h *HMACAuthMiddleware) Handler(c *gin.Context) {
+
+	client := c.GetHeader(HMACClientHeader)
+
+	// No header, not using this middleware so we continue
+	if client == "" {
+		c.Next()
+		return
+	}
+
+	timestamp := c.GetHeader(HMACTimestampHeader)
+	signature := c.GetHeader(HMACSignatureHeader)
+	if timestamp == "" || signature == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": fmt.Sprintf("%s and %s must be set", HMACTimestampHeader, HMACSignatureHeader)})
+		return
+	}
+
+	unixTime, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": fmt.Sprintf("invalid %s", HMACTimestampHeader)})
+		return
+	}
+
+	now := h.now()
+	requestTime := time.Unix(unixTime, 0)
+	if requestTime.Before(now.Add(-h.MaxClockSkew)) || requestTime.After(now.Add(h.MaxClockSkew)) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "request timestamp outside allowed window"})
+		return
+	}
+
+	keys, found := h.clientKeys(client, now)
+	if !found {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("client %s not authorized", client)})
+		return
+	}
+
+	var body []byte
+	if c.Request.Body != nil {
+		reader := c.Request.Body
+		if h.MaxBodyBytes > 0 {
+			reader = http.MaxBytesReader(c.Writer, c.Request.Body, h.MaxBodyBytes)
+		}
+		body, err = io.ReadAll(reader)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("request body larger than %d bytes", h.MaxBodyBytes)})
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "unable to read request body"})
+			return
+		}
+		// Restore body for handlers
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	valid := false
+	for _, key := range keys {
+		expected := SignRequest(key, timestamp, c.Request.Method, c.Request.URL.RequestURI(), body)
+		if hmac.Equal([]byte(expected), []byte(signature)) {
+			valid = true
+			break
+		}
+	}
+
+	if !valid {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid request signature"})
+		return
+	}
+
+	if h.isReplay(signature, now) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "request signature already used"})
+		return
+	}
+
+	c.Set("user", client)
+	c.Next()
+}
+
+// clientKeys returns the keys of client, first reloading the keys file if it changed since it was last checked
+func (h *HMACAuthMiddleware) clientKeys(client string, now time.Time) ([]string, bool) {
+	h.reloadKeys(now)
+
+	h.keysMu.RLock()
+	defer h.keysMu.RUnlock()
+
+	keys, found := h.Keys[client]
+	return keys, found
+}
+
+// reloadKeys reloads the keys file if reloadInterval passed since it was last checked and it was modified since.
+// The current keys are kept if the file can't be read. Whether a reload is due is checked under the read lock so
+// requests only serialize on the write lock once per reloadInterval.
+func (h *HMACAuthMiddleware) reloadKeys(now time.Time) {
+	if h.keysPath == "" {
+		return
+	}
+
+	h.keysMu.RLock()
+	due := !now.Before(h.nextReload)
+	h.keysMu.RUnlock()
+	if !due {
+		return
+	}
+
+	h.keysMu.Lock()
+	defer h.keysMu.Unlock()
+
+	// Another request may have reloaded the keys while waiting for the write lock
+	if now.Before(h.nextReload) {
+		return
+	}
+	h.nextReload = now.Add(h.reloadInterval)
+
+	info, err := os.Stat(h.keysPath)
+	if err != nil {
+		klog.Warningf("HMACAuthMiddleware: keeping current keys, unable to stat hmac keys file: %v", err)
+		return
+	}
+	if info.ModTime().Equal(h.keysModTime) {
+		return
+	}
+
+	keys, err := loadHMACKeys(h.keysPath)
+	if err != nil {
+		klog.Warningf("HMACAuthMiddleware: keeping current keys, unable to reload hmac keys: %v", err)
+		return
+	}
+	h.Keys = keys
+	h.keysModTime = info.ModTime()
+	klog.Infof("HMACAuthMiddleware: reloaded hmac keys from %s", h.keysPath)
+}
+
+// isReplay records signature as seen and returns true if it was already seen within the clock skew window.
+// Expired signatures are pruned from the front of expiries on each call.
+func (h *HMACAuthMiddleware) isReplay(signature string, now time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	expired := 0
+	for expired < len(h.expiries) && now.After(h.expiries[expired].expires) {
+		delete(h.seen, h.expiries[expired].signature)
+		expired++
+	}
+	h.expiries = h.expiries[expired:]
+
+	if _, found := h.seen[signature]; found {
+		return true
+	}
+
+	// Timestamps are accepted up to MaxClockSkew in either direction
+	expires := now.Add(2 * h.MaxClockSkew)
+	h.seen[signature] = expires
+	h.expiries = append(h.expiries, seenSignature{signature: signature, expires: expires})
+	return false
+}
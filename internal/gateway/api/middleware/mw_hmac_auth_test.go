@@ -0,0 +1,267 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHMACAuthMiddleware(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "hmac-keys.yaml")
+	if err != nil {
+		t.Fatalf("unable to create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write([]byte("airflow:\n  - old-key\n  - new-key\n")); err != nil {
+		t.Fatalf("unable to write to temp file: %v", err)
+	}
+	tmpFile.Close()
+
+	mw, err := NewHMACAuthMiddleware(MiddlewareConfMap{
+		"keysFile":     tmpFile.Name(),
+		"maxClockSkew": "1m",
+	})
+
+	h := mw.(*HMACAuthMiddleware)
+
+	assert.Nil(t, err, "should be no error")
+	assert.Equal(t, HMACKeyMap{"airflow": {"old-key", "new-key"}}, h.Keys, "keys should match")
+	assert.Equal(t, time.Minute, h.MaxClockSkew, "maxClockSkew should match")
+	assert.Equal(t, int64(HMACMaxBodyBytesDefault), h.MaxBodyBytes, "maxBodyBytes should default")
+	assert.Equal(t, HMACReloadDefault, h.reloadInterval, "reloadInterval should default")
+}
+
+func TestHMACAuthMiddleware(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	ts := strconv.FormatInt(now.Unix(), 10)
+	oldTs := strconv.FormatInt(now.Add(-10*time.Minute).Unix(), 10)
+	body := []byte(`{"metadata":{"namespace":"test"}}`)
+
+	tests := []struct {
+		test           string
+		client         string
+		timestamp      string
+		signature      string
+		expectedUser   string
+		expectedStatus int
+	}{
+		{
+			test:           "no client header",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			test:           "missing signature",
+			client:         "airflow",
+			timestamp:      ts,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			test:           "valid signature",
+			client:         "airflow",
+			timestamp:      ts,
+			signature:      SignRequest("new-key", ts, "POST", "/?a=b", body),
+			expectedUser:   "airflow",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			test:           "replayed signature",
+			client:         "airflow",
+			timestamp:      ts,
+			signature:      SignRequest("new-key", ts, "POST", "/?a=b", body),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			test:           "valid signature with rotated key",
+			client:         "airflow",
+			timestamp:      ts,
+			signature:      SignRequest("old-key", ts, "POST", "/?a=b", body),
+			expectedUser:   "airflow",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			test:           "wrong key",
+			client:         "airflow",
+			timestamp:      ts,
+			signature:      SignRequest("bad-key", ts, "POST", "/?a=b", body),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			test:           "signature over different path",
+			client:         "airflow",
+			timestamp:      ts,
+			signature:      SignRequest("new-key", ts, "POST", "/other", body),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			test:           "expired timestamp",
+			client:         "airflow",
+			timestamp:      oldTs,
+			signature:      SignRequest("new-key", oldTs, "POST", "/?a=b", body),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			test:           "unknown client",
+			client:         "unknown",
+			timestamp:      ts,
+			signature:      SignRequest("new-key", ts, "POST", "/?a=b", body),
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	mw := &HMACAuthMiddleware{
+		Keys:         HMACKeyMap{"airflow": {"old-key", "new-key"}},
+		MaxClockSkew: 5 * time.Minute,
+		now:          func() time.Time { return now },
+		seen:         map[string]time.Time{},
+	}
+
+	for _, test := range tests {
+		t.Run(test.test, func(t *testing.T) {
+
+			var gotUser string
+			var gotBody []byte
+			router := gin.New()
+			router.Use(mw.Handler)
+			router.POST("/", func(c *gin.Context) {
+				gotUser = c.GetString("user")
+				gotBody, _ = io.ReadAll(c.Request.Body)
+			})
+
+			req, _ := http.NewRequest("POST", "/?a=b", bytes.NewReader(body))
+			if test.client != "" {
+				req.Header.Add(HMACClientHeader, test.client)
+			}
+			if test.timestamp != "" {
+				req.Header.Add(HMACTimestampHeader, test.timestamp)
+			}
+			if test.signature != "" {
+				req.Header.Add(HMACSignatureHeader, test.signature)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatus, w.Code, "codes should match")
+			assert.Equal(t, test.expectedUser, gotUser, "user should match")
+			if w.Code == http.StatusOK {
+				assert.Equal(t, body, gotBody, "body should be readable by handlers")
+			}
+		})
+	}
+}
+
+// signedRequest returns a request to path signed by client with key at now
+func signedRequest(client string, key string, now time.Time, path string, body []byte) *http.Request {
+	ts := strconv.FormatInt(now.Unix(), 10)
+	req, _ := http.NewRequest("POST", path, bytes.NewReader(body))
+	req.Header.Add(HMACClientHeader, client)
+	req.Header.Add(HMACTimestampHeader, ts)
+	req.Header.Add(HMACSignatureHeader, SignRequest(key, ts, "POST", path, body))
+	return req
+}
+
+func TestHMACAuthMiddlewareMaxBodyBytes(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	mw := &HMACAuthMiddleware{
+		Keys:         HMACKeyMap{"airflow": {"key"}},
+		MaxClockSkew: 5 * time.Minute,
+		MaxBodyBytes: 8,
+		now:          func() time.Time { return now },
+		seen:         map[string]time.Time{},
+	}
+	router := gin.New()
+	router.Use(mw.Handler)
+	router.POST("/", func(c *gin.Context) {})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, signedRequest("airflow", "key", now, "/", []byte("0123456789")))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code, "bodies over maxBodyBytes should be rejected")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, signedRequest("airflow", "key", now, "/", []byte("01234567")))
+	assert.Equal(t, http.StatusOK, w.Code, "bodies up to maxBodyBytes should be accepted")
+}
+
+func TestHMACAuthMiddlewareReplayExpiry(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	mw := &HMACAuthMiddleware{
+		MaxClockSkew: time.Minute,
+		seen:         map[string]time.Time{},
+	}
+
+	assert.False(t, mw.isReplay("a", now), "first use should not be a replay")
+	assert.False(t, mw.isReplay("b", now.Add(time.Minute)), "first use should not be a replay")
+	assert.True(t, mw.isReplay("a", now.Add(time.Minute)), "reuse within the window should be a replay")
+
+	mw.isReplay("c", now.Add(2*time.Minute+time.Second))
+	assert.NotContains(t, mw.seen, "a", "expired signatures should be pruned")
+	assert.Contains(t, mw.seen, "b", "signatures within the window should be kept")
+	assert.Len(t, mw.expiries, 2, "expiries should only hold seen signatures")
+}
+
+func TestHMACAuthMiddlewareReloadKeys(t *testing.T) {
+	keysFile := filepath.Join(t.TempDir(), "hmac-keys.yaml")
+	if err := os.WriteFile(keysFile, []byte("airflow:\n  - old-key\n"), 0o600); err != nil {
+		t.Fatalf("unable to write keys file: %v", err)
+	}
+
+	mw, err := NewHMACAuthMiddleware(MiddlewareConfMap{
+		"keysFile":       keysFile,
+		"reloadInterval": "1m",
+	})
+	assert.Nil(t, err, "should be no error")
+	h := mw.(*HMACAuthMiddleware)
+	now := time.Now()
+	h.now = func() time.Time { return now }
+
+	router := gin.New()
+	router.Use(h.Handler)
+	router.POST("/", func(c *gin.Context) {})
+
+	if err := os.WriteFile(keysFile, []byte("airflow:\n  - new-key\n"), 0o600); err != nil {
+		t.Fatalf("unable to write keys file: %v", err)
+	}
+	// Make sure the change is seen even on filesystems with coarse modification times
+	if err := os.Chtimes(keysFile, now.Add(time.Second), now.Add(time.Second)); err != nil {
+		t.Fatalf("unable to touch keys file: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, signedRequest("airflow", "new-key", now, "/", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "keys should not be reloaded before reloadInterval")
+
+	now = now.Add(time.Minute)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, signedRequest("airflow", "new-key", now, "/", nil))
+	assert.Equal(t, http.StatusOK, w.Code, "rotated keys should be accepted once reloaded")
+
+	now = now.Add(time.Second)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, signedRequest("airflow", "old-key", now, "/", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "removed keys should be rejected once reloaded")
+}
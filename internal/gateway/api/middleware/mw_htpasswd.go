@@ -0,0 +1,165 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const HtpasswdFilePathDefault = "/conf/htpasswd"
+
+// htpasswdDummyHash is compared against when the user isn't in the htpasswd file, so unknown users take as long to
+// reject as a wrong password and response times don't reveal which usernames exist
+var htpasswdDummyHash = []byte("$2a$10$fWI.2SvNRvZVJr6Mxsiof.2BsJFNeX6t3KY/TQweWLgpSjrGZeT6K")
+
+// HtpasswdMap maps usernames to bcrypt password hashes
+type HtpasswdMap map[string][]byte
+
+// HtpasswdAuthMiddleware verifies Basic Authorization credentials against an htpasswd style file of
+// bcrypt hashes. Will set the context `user` key if the credentials are valid. If the auth header is
+// missing, the request continues so other middleware can authenticate it.
+type HtpasswdAuthMiddleware struct {
+	Users HtpasswdMap
+}
+
+type HtpasswdAuthMiddlewareConf struct {
+	HtpasswdFilePath string `koanf:"htpasswdFile"`
+}
+
+func (h *HtpasswdAuthMiddlewareConf) Name() string {
+	return "HtpasswdAuthMiddlewareConf"
+}
+
+func (h *HtpasswdAuthMiddlewareConf) Validate() error {
+	return nil
+}
+
+func NewHtpasswdAuthMiddleware(confMap MiddlewareConfMap) (GatewayMiddleware, error) {
+	var mwConf HtpasswdAuthMiddlewareConf
+
+	if err := LoadMiddlewareConf(&mwConf, confMap); err != nil {
+		return nil, fmt.Errorf("error creating HtpasswdAuthMiddleware: %w", err)
+	}
+
+	filePath := mwConf.HtpasswdFilePath
+	// If no path was provided in the Config, use a default
+	if filePath == "" {
+		filePath = HtpasswdFilePathDefault
+	}
+
+	users, err := LoadHtpasswdFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error creating HtpasswdAuthMiddleware: %w", err)
+	}
+
+	return &HtpasswdAuthMiddleware{Users: users}, nil
+}
+
+// LoadHtpasswdFile parses `user:hash` lines from the file at filePath. Empty lines and lines starting
+// with `#` are ignored. Only bcrypt hashes are supported.
+func LoadHtpasswdFile(filePath string) (HtpasswdMap, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening htpasswd file: %w", err)
+	}
+	defer file.Close()
+
+	users := HtpasswdMap{}
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		user, hash, found := strings.Cut(line, ":")
+		if !found || user == "" {
+			return nil, fmt.Errorf("invalid htpasswd entry on line %d", lineNum)
+		}
+
+		if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+			return nil, fmt.Errorf("htpasswd entry for user %s on line %d is not a bcrypt hash: %w", user, lineNum, err)
+		}
+
+		users[user] = []byte(hash)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading htpasswd file: %w", err)
+	}
+
+	return users, nil
+}
+
+// GetCredentialsFromAuthHeader ensures a valid Basic authorization header and returns the decoded username and password.
+func GetCredentialsFromAuthHeader(authHeader string) (string, string, error) {
+	token, found := strings.CutPrefix(authHeader, "Basic ")
+	if !found {
+		return "", "", errors.New("invalid Authorization format, must be like `Basic <token>`")
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return "", "", fmt.Errorf("could not decode auth token: %w", err)
+	}
+
+	user, password, found := strings.Cut(string(decoded), ":")
+	if !found {
+		return "", "", fmt.Errorf("could not parse decoded auth token")
+	}
+
+	return user, password, nil
+}
+
+func (h *HtpasswdAuthMiddleware) Handler(c *gin.Context) {
+
+	authHeader := c.GetHeader("Authorization")
+
+	// No header, not using this middleware so we continue
+	if authHeader == "" {
+		c.Next()
+		return
+	}
+
+	user, password, err := GetCredentialsFromAuthHeader(authHeader)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid Authorization header"})
+		return
+	}
+
+	hash, found := h.Users[user]
+	if !found {
+		hash = htpasswdDummyHash
+	}
+	if bcrypt.CompareHashAndPassword(hash, []byte(password)) != nil || !found {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid username or password"})
+		return
+	}
+
+	c.Set("user", user)
+	c.Next()
+}
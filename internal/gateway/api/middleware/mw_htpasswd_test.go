@@ -0,0 +1,153 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func writeHtpasswdFile(t *testing.T, contents string) string {
+	tmpFile, err := os.CreateTemp("", "htpasswd")
+	if err != nil {
+		t.Fatalf("unable to create temp file: %v", err)
+	}
+	t.Cleanup(func() { os.Remove(tmpFile.Name()) })
+
+	if _, err := tmpFile.Write([]byte(contents)); err != nil {
+		t.Fatalf("unable to write to temp file: %v", err)
+	}
+	tmpFile.Close()
+
+	return tmpFile.Name()
+}
+
+func TestLoadHtpasswdFile(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+
+	path := writeHtpasswdFile(t, "# comment\n\nalice:"+string(hash)+"\n")
+	users, err := LoadHtpasswdFile(path)
+
+	assert.Nil(t, err, "should be no error")
+	assert.Equal(t, HtpasswdMap{"alice": hash}, users, "users should match")
+
+	path = writeHtpasswdFile(t, "alice:{SHA}abc\n")
+	_, err = LoadHtpasswdFile(path)
+	assert.ErrorContains(t, err, "htpasswd entry for user alice on line 1 is not a bcrypt hash", "non bcrypt hashes should error")
+
+	path = writeHtpasswdFile(t, "alice\n")
+	_, err = LoadHtpasswdFile(path)
+	assert.EqualError(t, err, "invalid htpasswd entry on line 1", "malformed lines should error")
+}
+
+func TestNewHtpasswdAuthMiddlewareDifferentPath(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	path := writeHtpasswdFile(t, "alice:"+string(hash))
+
+	mw, err := NewHtpasswdAuthMiddleware(MiddlewareConfMap{
+		"htpasswdFile": path,
+	})
+
+	assert.Nil(t, err, "should be no error")
+	assert.Contains(t, mw.(*HtpasswdAuthMiddleware).Users, "alice", "user should be loaded")
+}
+
+func basicAuth(user, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+password))
+}
+
+var htpasswdHandlerTests = []struct {
+	test           string
+	authHeader     string
+	expectedUser   string
+	expectedStatus int
+}{
+	{
+		test:           "no auth header",
+		expectedStatus: http.StatusOK,
+	},
+	{
+		test:           "valid credentials",
+		authHeader:     basicAuth("alice", "secret"),
+		expectedUser:   "alice",
+		expectedStatus: http.StatusOK,
+	},
+	{
+		test:           "password containing colon",
+		authHeader:     basicAuth("bob", "sec:ret"),
+		expectedUser:   "bob",
+		expectedStatus: http.StatusOK,
+	},
+	{
+		test:           "wrong password",
+		authHeader:     basicAuth("alice", "wrong"),
+		expectedStatus: http.StatusUnauthorized,
+	},
+	{
+		test:           "unknown user",
+		authHeader:     basicAuth("eve", "secret"),
+		expectedStatus: http.StatusUnauthorized,
+	},
+	{
+		test:           "invalid header",
+		authHeader:     "Bearer token",
+		expectedStatus: http.StatusUnauthorized,
+	},
+}
+
+func TestHtpasswdAuthMiddleware(t *testing.T) {
+	aliceHash, _ := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	bobHash, _ := bcrypt.GenerateFromPassword([]byte("sec:ret"), bcrypt.MinCost)
+
+	mw := HtpasswdAuthMiddleware{Users: HtpasswdMap{"alice": aliceHash, "bob": bobHash}}
+
+	for _, test := range htpasswdHandlerTests {
+		t.Run(test.test, func(t *testing.T) {
+
+			var gotUser string
+			router := gin.New()
+			router.Use(mw.Handler)
+			router.GET("/", func(c *gin.Context) {
+				gotUser = c.GetString("user")
+			})
+
+			req, _ := http.NewRequest("GET", "/", nil)
+			if test.authHeader != "" {
+				req.Header.Add("Authorization", test.authHeader)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatus, w.Code, "codes should match")
+			assert.Equal(t, test.expectedUser, gotUser, "user should match")
+		})
+	}
+}
+
+func TestHtpasswdDummyHash(t *testing.T) {
+	cost, err := bcrypt.Cost(htpasswdDummyHash)
+
+	assert.NoError(t, err, "dummy hash should be a valid bcrypt hash")
+	assert.Equal(t, bcrypt.DefaultCost, cost, "dummy hash should cost as much as htpasswd generated hashes")
+}
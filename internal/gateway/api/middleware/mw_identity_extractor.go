@@ -0,0 +1,193 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/klog/v2"
+)
+
+const (
+	IdentityExtractorHeader   = "header"
+	IdentityExtractorBasic    = "basicAuth"
+	IdentityExtractorJWTClaim = "jwtClaim"
+)
+
+// IdentityExtractorMiddleware sets the `user` context variable from the first of its Extractors that finds an
+// identity in the request, so the same Gateway can run behind proxies that pass the user differently. Identities are
+// trusted as found: basic auth passwords and JWT signatures are not verified, which must be done by the proxy in front
+// of the Gateway or by middleware configured before this one.
+type IdentityExtractorMiddleware struct {
+	Extractors    []IdentityExtractor
+	RejectInvalid bool
+}
+
+// IdentityExtractor reads an identity from one source in the request. Identities not matching Validation are
+// skipped, and TrimPrefix and TrimSuffix are removed before validating, e.g. the domain of an email claim.
+type IdentityExtractor struct {
+	Type       string `koanf:"type"`
+	Header     string `koanf:"header"`
+	Claim      string `koanf:"claim"`
+	TrimPrefix string `koanf:"trimPrefix"`
+	TrimSuffix string `koanf:"trimSuffix"`
+	Validation string `koanf:"validation"`
+
+	validation *regexp.Regexp
+}
+
+type IdentityExtractorMiddlewareConf struct {
+	Extractors    []IdentityExtractor `koanf:"extractors"`
+	RejectInvalid bool                `koanf:"rejectInvalid"`
+}
+
+func (i *IdentityExtractorMiddlewareConf) Name() string {
+	return "IdentityExtractorMiddlewareConf"
+}
+
+func (i *IdentityExtractorMiddlewareConf) Validate() error {
+	if len(i.Extractors) == 0 {
+		return fmt.Errorf("at least one extractor must be configured")
+	}
+
+	for idx, extractor := range i.Extractors {
+		switch extractor.Type {
+		case IdentityExtractorHeader:
+			if extractor.Header == "" {
+				return fmt.Errorf("extractor %d: header is required for type %s", idx, extractor.Type)
+			}
+		case IdentityExtractorJWTClaim:
+			if extractor.Claim == "" {
+				return fmt.Errorf("extractor %d: claim is required for type %s", idx, extractor.Type)
+			}
+		case IdentityExtractorBasic:
+		default:
+			return fmt.Errorf("extractor %d: unknown type [%s], must be one of %s, %s or %s", idx, extractor.Type, IdentityExtractorHeader, IdentityExtractorBasic, IdentityExtractorJWTClaim)
+		}
+
+		if extractor.Validation != "" {
+			if _, err := regexp.Compile(extractor.Validation); err != nil {
+				return fmt.Errorf("extractor %d: invalid Validation regex [%s]: %w", idx, extractor.Validation, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func NewIdentityExtractorMiddleware(confMap MiddlewareConfMap) (GatewayMiddleware, error) {
+	var mwConf IdentityExtractorMiddlewareConf
+
+	if err := LoadMiddlewareConf(&mwConf, confMap); err != nil {
+		return nil, fmt.Errorf("error creating IdentityExtractorMiddleware: %w", err)
+	}
+
+	extractors := make([]IdentityExtractor, 0, len(mwConf.Extractors))
+	for _, extractor := range mwConf.Extractors {
+		if extractor.Validation != "" {
+			extractor.validation = regexp.MustCompile(extractor.Validation)
+		}
+		extractors = append(extractors, extractor)
+	}
+
+	return &IdentityExtractorMiddleware{Extractors: extractors, RejectInvalid: mwConf.RejectInvalid}, nil
+}
+
+func (i *IdentityExtractorMiddleware) Handler(c *gin.Context) {
+
+	for _, extractor := range i.Extractors {
+		identity := extractor.Extract(c.Request)
+		if identity == "" {
+			continue
+		}
+
+		if extractor.validation != nil && !extractor.validation.MatchString(identity) {
+			klog.V(1).Infof("IdentityExtractorMiddleware: %s identity [%s] does not match validation", extractor.Type, identity)
+			if i.RejectInvalid {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid user identity"})
+				return
+			}
+			continue
+		}
+
+		c.Set("user", identity)
+		c.Next()
+		return
+	}
+
+	c.Next()
+}
+
+// Extract returns the identity found in r, or an empty string if the extractor's source is missing or malformed.
+func (e IdentityExtractor) Extract(r *http.Request) string {
+	var identity string
+
+	switch e.Type {
+	case IdentityExtractorHeader:
+		identity = r.Header.Get(e.Header)
+	case IdentityExtractorBasic:
+		identity, _, _ = r.BasicAuth()
+	case IdentityExtractorJWTClaim:
+		identity = jwtClaim(e.token(r), e.Claim)
+	}
+
+	identity = strings.TrimSpace(identity)
+	identity = strings.TrimPrefix(identity, e.TrimPrefix)
+	identity = strings.TrimSuffix(identity, e.TrimSuffix)
+
+	return identity
+}
+
+// token returns the JWT in the extractor's Header, or the bearer token of the Authorization header if unset
+func (e IdentityExtractor) token(r *http.Request) string {
+	if e.Header != "" {
+		return r.Header.Get(e.Header)
+	}
+
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return token
+}
+
+// jwtClaim returns the string claim of the unverified JWT token, or an empty string if token isn't a JWT or the claim
+// isn't a string
+func jwtClaim(token string, claim string) string {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return ""
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return ""
+	}
+
+	var claims map[string]any
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+
+	value, _ := claims[claim].(string)
+	return value
+}
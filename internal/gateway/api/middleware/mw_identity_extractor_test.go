@@ -0,0 +1,163 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var identityExtractorConfTests = []struct {
+	test string
+	conf MiddlewareConfMap
+	err  string
+}{
+	{
+		test: "valid conf",
+		conf: MiddlewareConfMap{"extractors": []map[string]any{
+			{"type": "header", "header": "X-Forwarded-User"},
+			{"type": "jwtClaim", "claim": "email"},
+			{"type": "basicAuth"},
+		}},
+	},
+	{
+		test: "no extractors",
+		conf: MiddlewareConfMap{},
+		err:  "at least one extractor must be configured",
+	},
+	{
+		test: "unknown type",
+		conf: MiddlewareConfMap{"extractors": []map[string]any{{"type": "cookie"}}},
+		err:  "extractor 0: unknown type [cookie]",
+	},
+	{
+		test: "header without header",
+		conf: MiddlewareConfMap{"extractors": []map[string]any{{"type": "header"}}},
+		err:  "extractor 0: header is required for type header",
+	},
+	{
+		test: "jwtClaim without claim",
+		conf: MiddlewareConfMap{"extractors": []map[string]any{{"type": "jwtClaim"}}},
+		err:  "extractor 0: claim is required for type jwtClaim",
+	},
+	{
+		test: "bad validation regex",
+		conf: MiddlewareConfMap{"extractors": []map[string]any{{"type": "basicAuth", "validation": "*"}}},
+		err:  "extractor 0: invalid Validation regex [*]",
+	},
+}
+
+func TestNewIdentityExtractorMiddleware(t *testing.T) {
+	for _, test := range identityExtractorConfTests {
+		t.Run(test.test, func(t *testing.T) {
+			mw, err := NewIdentityExtractorMiddleware(test.conf)
+
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err, "errors should match")
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotNil(t, mw)
+		})
+	}
+}
+
+func testJWT(payload string) string {
+	return "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+}
+
+func TestIdentityExtractorMiddlewareHandler(t *testing.T) {
+	conf := MiddlewareConfMap{"extractors": []map[string]any{
+		{"type": "header", "header": "X-Forwarded-User", "validation": "^[a-z]+$"},
+		{"type": "jwtClaim", "claim": "email", "trimSuffix": "@example.com"},
+		{"type": "basicAuth"},
+	}}
+
+	tests := []struct {
+		test          string
+		rejectInvalid bool
+		setup         func(r *http.Request)
+		code          int
+		user          string
+	}{
+		{
+			test:  "header has precedence",
+			setup: func(r *http.Request) { r.Header.Set("X-Forwarded-User", "alice"); r.SetBasicAuth("bob", "pass") },
+			code:  http.StatusOK,
+			user:  "alice",
+		},
+		{
+			test:  "invalid header falls through",
+			setup: func(r *http.Request) { r.Header.Set("X-Forwarded-User", "Alice!"); r.SetBasicAuth("bob", "pass") },
+			code:  http.StatusOK,
+			user:  "bob",
+		},
+		{
+			test:          "invalid header rejected",
+			rejectInvalid: true,
+			setup:         func(r *http.Request) { r.Header.Set("X-Forwarded-User", "Alice!"); r.SetBasicAuth("bob", "pass") },
+			code:          http.StatusUnauthorized,
+		},
+		{
+			test: "jwt claim",
+			setup: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer "+testJWT(`{"email":"carol@example.com"}`))
+			},
+			code: http.StatusOK,
+			user: "carol",
+		},
+		{
+			test:  "malformed jwt",
+			setup: func(r *http.Request) { r.Header.Set("Authorization", "Bearer not-a-jwt") },
+			code:  http.StatusUnauthorized,
+		},
+		{
+			test:  "no identity",
+			setup: func(r *http.Request) {},
+			code:  http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.test, func(t *testing.T) {
+			conf["rejectInvalid"] = test.rejectInvalid
+			mw, err := NewIdentityExtractorMiddleware(conf)
+			assert.NoError(t, err)
+
+			router := gin.New()
+			router.Use(mw.Handler, IsAuthed)
+			var gotUser string
+			router.GET("/", func(c *gin.Context) {
+				gotUser = c.GetString("user")
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			test.setup(req)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.code, w.Code)
+			assert.Equal(t, test.user, gotUser)
+		})
+	}
+}
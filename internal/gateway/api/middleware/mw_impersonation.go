@@ -0,0 +1,135 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/klog/v2"
+)
+
+const ImpersonationHeaderDefault = "X-On-Behalf-Of"
+
+// ImpersonationMiddleware allows trusted principals to act on behalf of another user by passing the
+// impersonation header. The `user` context variable is replaced with the header value, and the original
+// principal is kept in the `actingUser` context variable so both identities can be audited. This must be
+// configured after the middleware that authenticates the trusted principal.
+type ImpersonationMiddleware struct {
+	Header            string
+	TrustedPrincipals []*regexp.Regexp
+	Validation        *regexp.Regexp
+}
+
+type ImpersonationMiddlewareConf struct {
+	Header            string   `koanf:"header"`
+	TrustedPrincipals []string `koanf:"trustedPrincipals"`
+	Validation        string   `koanf:"validation"`
+}
+
+func (i *ImpersonationMiddlewareConf) Name() string {
+	return "ImpersonationMiddlewareConf"
+}
+
+func (i *ImpersonationMiddlewareConf) Validate() error {
+	if len(i.TrustedPrincipals) == 0 {
+		return fmt.Errorf("at least one trustedPrincipal must be configured")
+	}
+
+	for _, principal := range i.TrustedPrincipals {
+		if _, err := regexp.Compile(principal); err != nil {
+			return fmt.Errorf("invalid trustedPrincipals regex [%s]: %w", principal, err)
+		}
+	}
+
+	if i.Validation != "" {
+		if _, err := regexp.Compile(i.Validation); err != nil {
+			return fmt.Errorf("invalid Validation regex [%s]: %w", i.Validation, err)
+		}
+	}
+
+	return nil
+}
+
+func NewImpersonationMiddleware(confMap MiddlewareConfMap) (GatewayMiddleware, error) {
+	var mwConf ImpersonationMiddlewareConf
+
+	if err := LoadMiddlewareConf(&mwConf, confMap); err != nil {
+		return nil, fmt.Errorf("error creating ImpersonationMiddleware: %w", err)
+	}
+
+	header := mwConf.Header
+	if header == "" {
+		header = ImpersonationHeaderDefault
+	}
+
+	var trusted []*regexp.Regexp
+	for _, principal := range mwConf.TrustedPrincipals {
+		trusted = append(trusted, regexp.MustCompile(principal))
+	}
+
+	var validation *regexp.Regexp
+	if mwConf.Validation != "" {
+		validation = regexp.MustCompile(mwConf.Validation)
+	}
+
+	return &ImpersonationMiddleware{Header: header, TrustedPrincipals: trusted, Validation: validation}, nil
+}
+
+func (i *ImpersonationMiddleware) isTrusted(principal string) bool {
+	for _, trusted := range i.TrustedPrincipals {
+		if trusted.MatchString(principal) {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *ImpersonationMiddleware) Handler(c *gin.Context) {
+
+	onBehalfOf := c.GetHeader(i.Header)
+
+	// No header, not impersonating so we continue
+	if onBehalfOf == "" {
+		c.Next()
+		return
+	}
+
+	principal := c.GetString("user")
+	if principal == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": fmt.Sprintf("%s requires an authenticated principal", i.Header)})
+		return
+	}
+
+	if !i.isTrusted(principal) {
+		klog.Warningf("Impersonation denied: principal: %s, onBehalfOf: %s, clientIP: %s, method: %s, path: %s", principal, onBehalfOf, c.ClientIP(), c.Request.Method, c.Request.URL.Path)
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("user %s is not allowed to impersonate other users", principal)})
+		return
+	}
+
+	if i.Validation != nil && !i.Validation.MatchString(onBehalfOf) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid %s value: %s", i.Header, onBehalfOf)})
+		return
+	}
+
+	klog.Infof("Impersonation: principal: %s, onBehalfOf: %s, clientIP: %s, method: %s, path: %s", principal, onBehalfOf, c.ClientIP(), c.Request.Method, c.Request.URL.Path)
+
+	c.Set("actingUser", principal)
+	c.Set("user", onBehalfOf)
+	c.Next()
+}
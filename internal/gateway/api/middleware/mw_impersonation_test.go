@@ -0,0 +1,151 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var impersonationConfTests = []struct {
+	test string
+	conf MiddlewareConfMap
+	err  string
+}{
+	{
+		test: "valid conf",
+		conf: MiddlewareConfMap{"trustedPrincipals": []string{"^airflow$"}},
+	},
+	{
+		test: "no trusted principals",
+		conf: MiddlewareConfMap{},
+		err:  "at least one trustedPrincipal must be configured",
+	},
+	{
+		test: "bad trusted principal regex",
+		conf: MiddlewareConfMap{"trustedPrincipals": []string{"*"}},
+		err:  "invalid trustedPrincipals regex [*]",
+	},
+	{
+		test: "bad validation regex",
+		conf: MiddlewareConfMap{"trustedPrincipals": []string{"^airflow$"}, "validation": "*"},
+		err:  "invalid Validation regex [*]",
+	},
+}
+
+func TestNewImpersonationMiddleware(t *testing.T) {
+	for _, test := range impersonationConfTests {
+		t.Run(test.test, func(t *testing.T) {
+			mw, err := NewImpersonationMiddleware(test.conf)
+
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err, "errors should match")
+				return
+			}
+
+			assert.Nil(t, err, "should be no error")
+			assert.Equal(t, ImpersonationHeaderDefault, mw.(*ImpersonationMiddleware).Header, "header should be defaulted")
+		})
+	}
+}
+
+var impersonationHandlerTests = []struct {
+	test               string
+	user               string
+	onBehalfOf         string
+	expectedUser       string
+	expectedActingUser string
+	expectedCode       int
+}{
+	{
+		test:         "no header",
+		user:         "alice",
+		expectedUser: "alice",
+		expectedCode: http.StatusOK,
+	},
+	{
+		test:               "trusted principal",
+		user:               "airflow",
+		onBehalfOf:         "alice",
+		expectedUser:       "alice",
+		expectedActingUser: "airflow",
+		expectedCode:       http.StatusOK,
+	},
+	{
+		test:         "untrusted principal",
+		user:         "bob",
+		onBehalfOf:   "alice",
+		expectedCode: http.StatusForbidden,
+	},
+	{
+		test:         "no authenticated principal",
+		onBehalfOf:   "alice",
+		expectedCode: http.StatusUnauthorized,
+	},
+	{
+		test:         "invalid onBehalfOf value",
+		user:         "airflow",
+		onBehalfOf:   "Alice!",
+		expectedCode: http.StatusBadRequest,
+	},
+}
+
+func TestImpersonationMiddleware(t *testing.T) {
+
+	mw, err := NewImpersonationMiddleware(MiddlewareConfMap{
+		"trustedPrincipals": []string{"^airflow$"},
+		"validation":        "^[a-z]+$",
+	})
+	assert.Nil(t, err, "should be no error")
+
+	for _, test := range impersonationHandlerTests {
+		t.Run(test.test, func(t *testing.T) {
+
+			var gotUser, gotActingUser string
+			router := gin.New()
+
+			router.Use(func(c *gin.Context) {
+				if test.user != "" {
+					c.Set("user", test.user)
+				}
+				c.Next()
+			})
+
+			router.Use(mw.Handler)
+
+			router.GET("/", func(c *gin.Context) {
+				gotUser = c.GetString("user")
+				gotActingUser = c.GetString("actingUser")
+			})
+
+			req, _ := http.NewRequest("GET", "/", nil)
+			if test.onBehalfOf != "" {
+				req.Header.Add(ImpersonationHeaderDefault, test.onBehalfOf)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedCode, w.Code, "codes should match")
+			assert.Equal(t, test.expectedUser, gotUser, "user should match")
+			assert.Equal(t, test.expectedActingUser, gotActingUser, "actingUser should match")
+		})
+	}
+}
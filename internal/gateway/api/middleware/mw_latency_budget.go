@@ -0,0 +1,86 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+)
+
+// LatencyBudgetHeader asks for a request to be served within a budget, as a Go duration like 5s
+const LatencyBudgetHeader = "X-Spark-Gateway-Latency-Budget"
+
+// LatencyBudget bounds requests by the budget in their LatencyBudgetHeader, capped at the configured max, or by the
+// configured default, dividing it among routing and SparkManager calls. Requests that run out of budget before a
+// response is written fail with a 504 carrying the domain.LatencyBudgetError. It must run after ApplicationErrorHandler
+// so the error is rendered. Requests to streamingRoutes, as returned by routes.Group.StreamingPaths, are not bounded.
+func LatencyBudget(budgetConfig config.LatencyBudgetConfig, streamingRoutes ...string) gin.HandlerFunc {
+	shares := map[string]float64{
+		domain.LatencyBudgetRouting:      budgetConfig.RoutingShare,
+		domain.LatencyBudgetRoutingRetry: budgetConfig.RoutingRetryShare,
+	}
+
+	return func(c *gin.Context) {
+		if routes.IsStreaming(c, streamingRoutes) {
+			c.Next()
+			return
+		}
+
+		total := budgetConfig.Default
+		if header := c.GetHeader(LatencyBudgetHeader); header != "" {
+			requested, err := time.ParseDuration(header)
+			if err != nil || requested <= 0 {
+				c.Error(gatewayerrors.NewBadRequest(fmt.Errorf("invalid %s header '%s', expected a positive duration like 5s", LatencyBudgetHeader, header)))
+				c.Abort()
+				return
+			}
+			total = requested
+			if budgetConfig.Max > 0 {
+				total = min(total, budgetConfig.Max)
+			}
+		}
+		if total <= 0 {
+			c.Next()
+			return
+		}
+
+		budget := domain.NewLatencyBudget(total, shares)
+		ctx, cancel := context.WithDeadline(domain.WithLatencyBudget(c.Request.Context(), budget), budget.Deadline())
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		// Replace the error of requests cut short by running out of budget with the budget's diagnostics
+		if len(c.Errors) == 0 || c.Writer.Written() || !budget.Exhausted() {
+			return
+		}
+		if lastErr := c.Errors.Last().Err; errors.Is(lastErr, context.DeadlineExceeded) || gatewayerrors.NewFrom(lastErr).Status == http.StatusGatewayTimeout {
+			c.Errors = c.Errors[:0]
+			c.Error(gatewayerrors.NewTimeout(budget.Err()))
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	sgMiddleware "github.com/slackhq/spark-gateway/internal/shared/middleware"
+)
+
+func TestLatencyBudget(t *testing.T) {
+	budgetConfig := config.LatencyBudgetConfig{Enable: true, Max: 10 * time.Second, RoutingShare: 0.5, RoutingRetryShare: 0.5}
+
+	router := gin.New()
+	router.ContextWithFallback = true
+	router.Use(sgMiddleware.ApplicationErrorHandler, LatencyBudget(budgetConfig, "/applications/watch"))
+	// Routing blocks until its share of the budget runs out, then the SparkManager call is made with what's left
+	router.POST("/applications", func(c *gin.Context) {
+		budget := domain.LatencyBudgetFrom(c)
+		routingCtx, done := budget.Phase(c, domain.LatencyBudgetRouting, "")
+		<-routingCtx.Done()
+		done(routingCtx.Err())
+
+		_, done = budget.Phase(c, domain.LatencyBudgetSparkManager, "cluster-a")
+		done(nil)
+		c.Error(fmt.Errorf("error getting routing cluster: %w", routingCtx.Err()))
+	})
+	router.GET("/applications/:gatewayId", func(c *gin.Context) {
+		deadline, ok := c.Request.Context().Deadline()
+		if !ok {
+			c.JSON(http.StatusOK, gin.H{"budget": ""})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"budget": time.Until(deadline).Round(time.Second).String()})
+	})
+	router.DELETE("/applications/:gatewayId", func(c *gin.Context) {
+		c.Error(gatewayerrors.NewNotFound(errors.New("not found")))
+	})
+
+	t.Run("exhausted budget returns diagnostics", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/applications", nil)
+		req.Header.Set(LatencyBudgetHeader, "100ms")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code, "codes should match")
+		var body struct {
+			Error         string                    `json:"error"`
+			LatencyBudget domain.LatencyBudgetError `json:"latencyBudget"`
+		}
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &body), "body should be JSON")
+		assert.Contains(t, body.Error, "latency budget of 100ms exceeded", "error should name the budget")
+		assert.Equal(t, 0.1, body.LatencyBudget.BudgetSeconds, "budget should match")
+		assert.Len(t, body.LatencyBudget.Phases, 2, "phases should be reported")
+		assert.Equal(t, domain.LatencyBudgetRouting, body.LatencyBudget.Phases[0].Phase, "routing should be reported first")
+		assert.InDelta(t, 0.05, body.LatencyBudget.Phases[0].DurationSeconds, 0.04, "routing should be cut at its share")
+		assert.Equal(t, "cluster-a", body.LatencyBudget.Phases[1].Cluster, "SparkManager calls should name the cluster")
+	})
+
+	testCases := []struct {
+		name           string
+		method         string
+		header         string
+		expectedStatus int
+		expectedBudget string
+	}{
+		{name: "no budget", method: http.MethodGet, expectedStatus: http.StatusOK, expectedBudget: ""},
+		{name: "budget from header", method: http.MethodGet, header: "3s", expectedStatus: http.StatusOK, expectedBudget: "3s"},
+		{name: "budget capped at max", method: http.MethodGet, header: "1h", expectedStatus: http.StatusOK, expectedBudget: "10s"},
+		{name: "invalid budget", method: http.MethodGet, header: "soon", expectedStatus: http.StatusBadRequest},
+		{name: "errors within budget are kept", method: http.MethodDelete, header: "1s", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tc.method, "/applications/app", nil)
+			if tc.header != "" {
+				req.Header.Set(LatencyBudgetHeader, tc.header)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code, "codes should match")
+			if tc.expectedStatus == http.StatusOK {
+				assert.JSONEq(t, fmt.Sprintf(`{"budget": "%s"}`, tc.expectedBudget), w.Body.String(), "deadline should match the budget")
+			}
+		})
+	}
+}
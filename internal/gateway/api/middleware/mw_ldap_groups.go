@@ -0,0 +1,378 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-ldap/ldap/v3"
+	"k8s.io/klog/v2"
+)
+
+const (
+	LDAPUserFilterDefault     = "(&(objectClass=user)(sAMAccountName=%s))"
+	LDAPGroupAttributeDefault = "memberOf"
+	LDAPCacheTTLDefault       = 5 * time.Minute
+	LDAPNegativeCacheDefault  = 30 * time.Second
+	LDAPTimeoutDefault        = 5 * time.Second
+)
+
+//go:generate moq -rm -out mockgroupresolver.go . GroupResolver
+
+// GroupResolver returns the directory groups a user belongs to. Users missing from the directory are reported with an
+// LDAPUserNotFoundError, so they can be told apart from the directory being unavailable.
+type GroupResolver interface {
+	ResolveGroups(user string) ([]string, error)
+}
+
+// LDAPUserNotFoundError is returned when the user filter doesn't match exactly one directory entry
+type LDAPUserNotFoundError struct {
+	User    string
+	Entries int
+}
+
+func (e *LDAPUserNotFoundError) Error() string {
+	return fmt.Sprintf("expected 1 LDAP entry for user %s, found %d", e.User, e.Entries)
+}
+
+// LDAPGroupMiddleware looks up the groups of the authenticated `user` from LDAP/AD and sets them in the
+// `groups` context variable so authorization can be expressed in terms of directory groups. If AllowGroups
+// is set, users who are not a member of at least one of them are denied, as are users missing from the directory.
+// This must be configured after the middleware that authenticates the user.
+type LDAPGroupMiddleware struct {
+	Resolver    GroupResolver
+	AllowGroups []string
+}
+
+type LDAPGroupMiddlewareConf struct {
+	URL              string        `koanf:"url"`
+	StartTLS         bool          `koanf:"startTLS"`
+	BindDN           string        `koanf:"bindDN"`
+	BindPasswordFile string        `koanf:"bindPasswordFile"`
+	BaseDN           string        `koanf:"baseDN"`
+	UserFilter       string        `koanf:"userFilter"`
+	GroupAttribute   string        `koanf:"groupAttribute"`
+	CacheTTL         time.Duration `koanf:"cacheTTL"`
+	NegativeCacheTTL time.Duration `koanf:"negativeCacheTTL"`
+	Timeout          time.Duration `koanf:"timeout"`
+	AllowGroups      []string      `koanf:"allowGroups"`
+}
+
+func (l *LDAPGroupMiddlewareConf) Name() string {
+	return "LDAPGroupMiddlewareConf"
+}
+
+func (l *LDAPGroupMiddlewareConf) Validate() error {
+	if l.URL == "" {
+		return fmt.Errorf("url must be set")
+	}
+
+	if l.BaseDN == "" {
+		return fmt.Errorf("baseDN must be set")
+	}
+
+	if l.UserFilter != "" && strings.Count(l.UserFilter, "%s") != 1 {
+		return fmt.Errorf("userFilter must contain exactly one '%%s' for the username: %s", l.UserFilter)
+	}
+
+	if l.CacheTTL < 0 {
+		return fmt.Errorf("cacheTTL cannot be negative")
+	}
+
+	if l.NegativeCacheTTL < 0 {
+		return fmt.Errorf("negativeCacheTTL cannot be negative")
+	}
+
+	if l.Timeout < 0 {
+		return fmt.Errorf("timeout cannot be negative")
+	}
+
+	return nil
+}
+
+func NewLDAPGroupMiddleware(confMap MiddlewareConfMap) (GatewayMiddleware, error) {
+	var mwConf LDAPGroupMiddlewareConf
+
+	if err := LoadMiddlewareConf(&mwConf, confMap); err != nil {
+		return nil, fmt.Errorf("error creating LDAPGroupMiddleware: %w", err)
+	}
+
+	resolver, err := NewLDAPGroupResolver(mwConf)
+	if err != nil {
+		return nil, fmt.Errorf("error creating LDAPGroupMiddleware: %w", err)
+	}
+
+	ttl := mwConf.CacheTTL
+	if ttl == 0 {
+		ttl = LDAPCacheTTLDefault
+	}
+	negativeTTL := mwConf.NegativeCacheTTL
+	if negativeTTL == 0 {
+		negativeTTL = LDAPNegativeCacheDefault
+	}
+
+	return &LDAPGroupMiddleware{
+		Resolver:    NewCachingGroupResolver(resolver, ttl, negativeTTL),
+		AllowGroups: mwConf.AllowGroups,
+	}, nil
+}
+
+func (l *LDAPGroupMiddleware) Handler(c *gin.Context) {
+
+	user := c.GetString("user")
+
+	// No user to resolve, let IsAuthed handle it
+	if user == "" {
+		c.Next()
+		return
+	}
+
+	groups, err := l.Resolver.ResolveGroups(user)
+	var notFound *LDAPUserNotFoundError
+	if errors.As(err, &notFound) {
+		klog.Warningf("denying user %s: %v", user, err)
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("user %s not found in the directory", user)})
+		return
+	}
+	if err != nil {
+		klog.Errorf("error resolving LDAP groups for user %s: %v", user, err)
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "unable to resolve user groups"})
+		return
+	}
+
+	if len(l.AllowGroups) > 0 && !slices.ContainsFunc(groups, func(group string) bool {
+		return slices.Contains(l.AllowGroups, group)
+	}) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("user %s is not a member of an allowed group", user)})
+		return
+	}
+
+	c.Set("groups", groups)
+	c.Next()
+}
+
+// LDAPGroupResolver resolves groups by searching for the user entry under BaseDN and reading GroupAttribute.
+// Values that are DNs are reduced to their CN. Lookups run on the request path, so dialing, each LDAP operation and the
+// search itself are bounded by timeout.
+type LDAPGroupResolver struct {
+	url            string
+	startTLS       bool
+	bindDN         string
+	bindPassword   string
+	baseDN         string
+	userFilter     string
+	groupAttribute string
+	timeout        time.Duration
+}
+
+func NewLDAPGroupResolver(conf LDAPGroupMiddlewareConf) (*LDAPGroupResolver, error) {
+	var bindPassword string
+	if conf.BindPasswordFile != "" {
+		password, err := os.ReadFile(conf.BindPasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading bindPasswordFile: %w", err)
+		}
+		bindPassword = strings.TrimSpace(string(password))
+	}
+
+	userFilter := conf.UserFilter
+	if userFilter == "" {
+		userFilter = LDAPUserFilterDefault
+	}
+
+	groupAttribute := conf.GroupAttribute
+	if groupAttribute == "" {
+		groupAttribute = LDAPGroupAttributeDefault
+	}
+
+	timeout := conf.Timeout
+	if timeout == 0 {
+		timeout = LDAPTimeoutDefault
+	}
+
+	return &LDAPGroupResolver{
+		url:            conf.URL,
+		startTLS:       conf.StartTLS,
+		bindDN:         conf.BindDN,
+		bindPassword:   bindPassword,
+		baseDN:         conf.BaseDN,
+		userFilter:     userFilter,
+		groupAttribute: groupAttribute,
+		timeout:        timeout,
+	}, nil
+}
+
+func (l *LDAPGroupResolver) ResolveGroups(user string) ([]string, error) {
+	conn, err := ldap.DialURL(l.url, ldap.DialWithDialer(&net.Dialer{Timeout: l.timeout}))
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to LDAP: %w", err)
+	}
+	defer conn.Close()
+	conn.SetTimeout(l.timeout)
+
+	if l.startTLS {
+		if err := conn.StartTLS(nil); err != nil {
+			return nil, fmt.Errorf("error starting TLS: %w", err)
+		}
+	}
+
+	if l.bindDN != "" {
+		if err := conn.Bind(l.bindDN, l.bindPassword); err != nil {
+			return nil, fmt.Errorf("error binding to LDAP: %w", err)
+		}
+	}
+
+	searchReq := ldap.NewSearchRequest(
+		l.baseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		2,
+		searchTimeLimit(l.timeout),
+		false,
+		fmt.Sprintf(l.userFilter, ldap.EscapeFilter(user)),
+		[]string{l.groupAttribute},
+		nil,
+	)
+
+	result, err := conn.Search(searchReq)
+	if err != nil {
+		return nil, fmt.Errorf("error searching LDAP for user %s: %w", user, err)
+	}
+
+	if len(result.Entries) != 1 {
+		return nil, &LDAPUserNotFoundError{User: user, Entries: len(result.Entries)}
+	}
+
+	var groups []string
+	for _, value := range result.Entries[0].GetAttributeValues(l.groupAttribute) {
+		groups = append(groups, groupName(value))
+	}
+
+	return groups, nil
+}
+
+// searchTimeLimit returns the server side time limit in seconds of searches bounded by timeout, which is at least 1s
+// since 0 means no limit
+func searchTimeLimit(timeout time.Duration) int {
+	return max(1, int(timeout/time.Second))
+}
+
+// groupName returns the CN of a group DN, or the value unchanged if it is not a DN.
+func groupName(value string) string {
+	dn, err := ldap.ParseDN(value)
+	if err != nil || len(dn.RDNs) == 0 {
+		return value
+	}
+
+	for _, attr := range dn.RDNs[0].Attributes {
+		if strings.EqualFold(attr.Type, "cn") {
+			return attr.Value
+		}
+	}
+
+	return value
+}
+
+type cachedGroups struct {
+	groups []string
+	// err is the LDAPUserNotFoundError of users missing from the directory
+	err     error
+	expires time.Time
+}
+
+// CachingGroupResolver caches successful lookups of the wrapped GroupResolver for ttl, and users missing from the
+// directory for negativeTTL so they don't query LDAP on every request. Other errors are not cached. Expired entries are
+// evicted when read, and swept at most once per ttl so users who never return don't stay cached.
+type CachingGroupResolver struct {
+	resolver    GroupResolver
+	ttl         time.Duration
+	negativeTTL time.Duration
+	now         func() time.Time
+
+	mu        sync.Mutex
+	cache     map[string]cachedGroups
+	nextSweep time.Time
+}
+
+func NewCachingGroupResolver(resolver GroupResolver, ttl time.Duration, negativeTTL time.Duration) *CachingGroupResolver {
+	return &CachingGroupResolver{
+		resolver:    resolver,
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		now:         time.Now,
+		cache:       map[string]cachedGroups{},
+	}
+}
+
+func (c *CachingGroupResolver) ResolveGroups(user string) ([]string, error) {
+	if cached, ok := c.get(user); ok {
+		return cached.groups, cached.err
+	}
+
+	groups, err := c.resolver.ResolveGroups(user)
+	var notFound *LDAPUserNotFoundError
+	if errors.As(err, &notFound) {
+		c.set(user, cachedGroups{err: err}, c.negativeTTL)
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.set(user, cachedGroups{groups: groups}, c.ttl)
+
+	return groups, nil
+}
+
+func (c *CachingGroupResolver) get(user string) (cachedGroups, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.cache[user]
+	if ok && !c.now().Before(cached.expires) {
+		delete(c.cache, user)
+		return cachedGroups{}, false
+	}
+
+	return cached, ok
+}
+
+func (c *CachingGroupResolver) set(user string, cached cachedGroups, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if !now.Before(c.nextSweep) {
+		for u, entry := range c.cache {
+			if !now.Before(entry.expires) {
+				delete(c.cache, u)
+			}
+		}
+		c.nextSweep = now.Add(c.ttl)
+	}
+
+	cached.expires = now.Add(ttl)
+	c.cache[user] = cached
+}
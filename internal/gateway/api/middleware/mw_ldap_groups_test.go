@@ -0,0 +1,265 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var ldapConfTests = []struct {
+	test string
+	conf LDAPGroupMiddlewareConf
+	err  string
+}{
+	{
+		test: "valid conf",
+		conf: LDAPGroupMiddlewareConf{URL: "ldaps://ldap.example.com", BaseDN: "dc=example,dc=com"},
+	},
+	{
+		test: "no url",
+		conf: LDAPGroupMiddlewareConf{BaseDN: "dc=example,dc=com"},
+		err:  "url must be set",
+	},
+	{
+		test: "no baseDN",
+		conf: LDAPGroupMiddlewareConf{URL: "ldaps://ldap.example.com"},
+		err:  "baseDN must be set",
+	},
+	{
+		test: "bad userFilter",
+		conf: LDAPGroupMiddlewareConf{URL: "ldaps://ldap.example.com", BaseDN: "dc=example,dc=com", UserFilter: "(uid=bob)"},
+		err:  "userFilter must contain exactly one '%s' for the username: (uid=bob)",
+	},
+	{
+		test: "negative timeout",
+		conf: LDAPGroupMiddlewareConf{URL: "ldaps://ldap.example.com", BaseDN: "dc=example,dc=com", Timeout: -time.Second},
+		err:  "timeout cannot be negative",
+	},
+}
+
+func TestLDAPGroupMiddlewareConfValidate(t *testing.T) {
+	for _, test := range ldapConfTests {
+		t.Run(test.test, func(t *testing.T) {
+			err := test.conf.Validate()
+
+			if test.err != "" {
+				assert.EqualError(t, err, test.err, "errors should match")
+			} else {
+				assert.Nil(t, err, "should be no error")
+			}
+		})
+	}
+}
+
+func TestNewLDAPGroupMiddlewareCacheTTL(t *testing.T) {
+	mw, err := NewLDAPGroupMiddleware(MiddlewareConfMap{
+		"url":      "ldaps://ldap.example.com",
+		"baseDN":   "dc=example,dc=com",
+		"cacheTTL": "30s",
+	})
+
+	assert.Nil(t, err, "should be no error")
+	assert.Equal(t, 30*time.Second, mw.(*LDAPGroupMiddleware).Resolver.(*CachingGroupResolver).ttl, "cacheTTL should be parsed")
+	assert.Equal(t, LDAPNegativeCacheDefault, mw.(*LDAPGroupMiddleware).Resolver.(*CachingGroupResolver).negativeTTL, "negativeCacheTTL should default")
+	assert.Equal(t, LDAPTimeoutDefault, mw.(*LDAPGroupMiddleware).Resolver.(*CachingGroupResolver).resolver.(*LDAPGroupResolver).timeout, "timeout should default")
+}
+
+func TestLDAPGroupResolverTimeout(t *testing.T) {
+	// Nothing listens on a closed listener's port, so the lookup fails instead of hanging
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err, "should be no error")
+	listener.Close()
+
+	resolver, err := NewLDAPGroupResolver(LDAPGroupMiddlewareConf{URL: "ldap://" + listener.Addr().String(), BaseDN: "dc=example,dc=com", Timeout: time.Second})
+	assert.Nil(t, err, "should be no error")
+
+	_, err = resolver.ResolveGroups("alice")
+	assert.ErrorContains(t, err, "error connecting to LDAP", "unreachable LDAP should fail")
+
+	assert.Equal(t, 1, searchTimeLimit(500*time.Millisecond), "search time limit should be at least 1s")
+	assert.Equal(t, 5, searchTimeLimit(5*time.Second), "search time limit should match the timeout")
+}
+
+func TestGroupName(t *testing.T) {
+	assert.Equal(t, "spark-users", groupName("CN=spark-users,OU=Groups,DC=example,DC=com"), "should return CN")
+	assert.Equal(t, "spark-users", groupName("spark-users"), "should return plain value")
+	assert.Equal(t, "ou=Groups,dc=example,dc=com", groupName("ou=Groups,dc=example,dc=com"), "should return DN without CN")
+}
+
+func TestCachingGroupResolver(t *testing.T) {
+	calls := 0
+	resolver := &GroupResolverMock{
+		ResolveGroupsFunc: func(user string) ([]string, error) {
+			calls++
+			if user == "error" {
+				return nil, errors.New("ldap down")
+			}
+			if user == "missing" {
+				return nil, &LDAPUserNotFoundError{User: user}
+			}
+			return []string{"spark-users"}, nil
+		},
+	}
+
+	now := time.Now()
+	caching := NewCachingGroupResolver(resolver, time.Minute, 10*time.Second)
+	caching.now = func() time.Time { return now }
+
+	groups, err := caching.ResolveGroups("alice")
+	assert.Nil(t, err, "should be no error")
+	assert.Equal(t, []string{"spark-users"}, groups, "groups should match")
+
+	caching.ResolveGroups("alice")
+	assert.Equal(t, 1, calls, "second lookup should be cached")
+
+	now = now.Add(2 * time.Minute)
+	caching.ResolveGroups("alice")
+	assert.Equal(t, 2, calls, "expired entry should be resolved again")
+
+	_, err = caching.ResolveGroups("error")
+	assert.EqualError(t, err, "ldap down", "errors should be returned")
+	caching.ResolveGroups("error")
+	assert.Equal(t, 4, calls, "errors should not be cached")
+
+	var notFound *LDAPUserNotFoundError
+	_, err = caching.ResolveGroups("missing")
+	assert.ErrorAs(t, err, &notFound, "users missing from the directory should be reported")
+	_, err = caching.ResolveGroups("missing")
+	assert.ErrorAs(t, err, &notFound, "cached missing users should be reported")
+	assert.Equal(t, 5, calls, "missing users should be cached")
+
+	now = now.Add(11 * time.Second)
+	caching.ResolveGroups("missing")
+	assert.Equal(t, 6, calls, "missing users should be resolved again after negativeTTL")
+}
+
+func TestCachingGroupResolverEviction(t *testing.T) {
+	resolver := &GroupResolverMock{
+		ResolveGroupsFunc: func(user string) ([]string, error) {
+			return []string{"spark-users"}, nil
+		},
+	}
+
+	now := time.Now()
+	caching := NewCachingGroupResolver(resolver, time.Minute, 10*time.Second)
+	caching.now = func() time.Time { return now }
+
+	caching.ResolveGroups("alice")
+	caching.ResolveGroups("bob")
+
+	now = now.Add(2 * time.Minute)
+	caching.ResolveGroups("carol")
+	assert.Len(t, caching.cache, 1, "expired users should be swept")
+	assert.Contains(t, caching.cache, "carol", "the new user should be cached")
+
+	now = now.Add(2 * time.Minute)
+	caching.get("carol")
+	assert.Empty(t, caching.cache, "expired users should be evicted when read")
+}
+
+var ldapHandlerTests = []struct {
+	test           string
+	user           string
+	allowGroups    []string
+	expectedGroups []string
+	expectedCode   int
+}{
+	{
+		test:         "no user",
+		expectedCode: http.StatusOK,
+	},
+	{
+		test:           "groups resolved",
+		user:           "alice",
+		expectedGroups: []string{"spark-users", "data-eng"},
+		expectedCode:   http.StatusOK,
+	},
+	{
+		test:           "allowed group",
+		user:           "alice",
+		allowGroups:    []string{"data-eng"},
+		expectedGroups: []string{"spark-users", "data-eng"},
+		expectedCode:   http.StatusOK,
+	},
+	{
+		test:         "not in allowed group",
+		user:         "alice",
+		allowGroups:  []string{"admins"},
+		expectedCode: http.StatusForbidden,
+	},
+	{
+		test:         "resolver error",
+		user:         "error",
+		expectedCode: http.StatusServiceUnavailable,
+	},
+	{
+		test:         "user not in directory",
+		user:         "missing",
+		expectedCode: http.StatusForbidden,
+	},
+}
+
+func TestLDAPGroupMiddleware(t *testing.T) {
+	resolver := &GroupResolverMock{
+		ResolveGroupsFunc: func(user string) ([]string, error) {
+			if user == "error" {
+				return nil, errors.New("ldap down")
+			}
+			if user == "missing" {
+				return nil, &LDAPUserNotFoundError{User: user}
+			}
+			return []string{"spark-users", "data-eng"}, nil
+		},
+	}
+
+	for _, test := range ldapHandlerTests {
+		t.Run(test.test, func(t *testing.T) {
+			mw := LDAPGroupMiddleware{Resolver: resolver, AllowGroups: test.allowGroups}
+
+			var gotGroups []string
+			router := gin.New()
+
+			router.Use(func(c *gin.Context) {
+				if test.user != "" {
+					c.Set("user", test.user)
+				}
+				c.Next()
+			})
+
+			router.Use(mw.Handler)
+
+			router.GET("/", func(c *gin.Context) {
+				gotGroups = c.GetStringSlice("groups")
+			})
+
+			req, _ := http.NewRequest("GET", "/", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedCode, w.Code, "codes should match")
+			assert.Equal(t, test.expectedGroups, gotGroups, "groups should match")
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+// OwnerFunc returns the owner of the application or schedule a request targets. ok is false for requests that don't
+// target one, e.g. submissions.
+type OwnerFunc func(c *gin.Context) (owner string, ok bool, err error)
+
+// AuthorizeRoles enforces the role of the authenticated `user` of requests, resolved from rbac with their `groups` and
+// set in the `role` context key. adminUsers are always admins. Viewers can only make GET requests and submitters can
+// only make other requests to applications and schedules owner returns them as the owner of. It must be added after
+// the middleware authenticating users, and leaves requests of anonymous users to AuthorizeAnonymous.
+func AuthorizeRoles(rbac config.RBACConfig, adminUsers []string, owner OwnerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetBool("anonymous") {
+			c.Next()
+			return
+		}
+
+		user := c.GetString("user")
+		role := domain.RoleAdmin
+		if !slices.Contains(adminUsers, user) {
+			role = rbac.Role(user, c.GetStringSlice("groups"))
+		}
+		c.Set("role", string(role))
+
+		if role == domain.RoleAdmin || c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		if role == domain.RoleViewer {
+			c.Error(gatewayerrors.NewForbidden(fmt.Errorf("user %s is a viewer and can only get and list applications", user)))
+			c.Abort()
+			return
+		}
+
+		targetOwner, ok, err := owner(c)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		if ok && targetOwner != user {
+			c.Error(gatewayerrors.NewForbidden(fmt.Errorf("user %s can only change applications and schedules they own, this one is owned by %s", user, targetOwner)))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
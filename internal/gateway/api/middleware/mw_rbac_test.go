@@ -0,0 +1,106 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	sgMiddleware "github.com/slackhq/spark-gateway/internal/shared/middleware"
+)
+
+var authorizeRolesTests = []struct {
+	test           string
+	user           string
+	groups         []string
+	anonymous      bool
+	method         string
+	path           string
+	expectedStatus int
+	expectedRole   string
+}{
+	{test: "viewer get", user: "victor", method: http.MethodGet, path: "/applications/alices-app", expectedStatus: http.StatusOK, expectedRole: "viewer"},
+	{test: "viewer submit", user: "victor", method: http.MethodPost, path: "/applications", expectedStatus: http.StatusForbidden, expectedRole: "viewer"},
+	{test: "viewer delete", user: "victor", method: http.MethodDelete, path: "/applications/victors-app", expectedStatus: http.StatusForbidden, expectedRole: "viewer"},
+	{test: "submitter submit", user: "sam", groups: []string{"submitters"}, method: http.MethodPost, path: "/applications", expectedStatus: http.StatusOK, expectedRole: "submitter"},
+	{test: "submitter delete own", user: "sam", groups: []string{"submitters"}, method: http.MethodDelete, path: "/applications/sams-app", expectedStatus: http.StatusOK, expectedRole: "submitter"},
+	{test: "submitter delete other", user: "sam", groups: []string{"submitters"}, method: http.MethodDelete, path: "/applications/alices-app", expectedStatus: http.StatusForbidden, expectedRole: "submitter"},
+	{test: "submitter delete missing", user: "sam", groups: []string{"submitters"}, method: http.MethodDelete, path: "/applications/missing", expectedStatus: http.StatusNotFound, expectedRole: "submitter"},
+	{test: "admin delete other", user: "ada", method: http.MethodDelete, path: "/applications/alices-app", expectedStatus: http.StatusOK, expectedRole: "admin"},
+	{test: "admin user delete other", user: "root", method: http.MethodDelete, path: "/applications/alices-app", expectedStatus: http.StatusOK, expectedRole: "admin"},
+	{test: "anonymous", user: "anonymous", anonymous: true, method: http.MethodDelete, path: "/applications/alices-app", expectedStatus: http.StatusOK},
+}
+
+func TestAuthorizeRoles(t *testing.T) {
+	rbac := config.RBACConfig{
+		Enable:      true,
+		DefaultRole: domain.RoleViewer,
+		Bindings: []config.RoleBinding{
+			{Role: domain.RoleSubmitter, Groups: []string{"submitters"}},
+			{Role: domain.RoleAdmin, Users: []string{"ada"}},
+		},
+	}
+	owners := map[string]string{"alices-app": "alice", "sams-app": "sam", "victors-app": "victor"}
+	owner := func(c *gin.Context) (string, bool, error) {
+		gatewayId := c.Param("gatewayId")
+		if gatewayId == "" {
+			return "", false, nil
+		}
+		appOwner, ok := owners[gatewayId]
+		if !ok {
+			return "", false, gatewayerrors.NewNotFound(errors.New("application not found"))
+		}
+		return appOwner, true, nil
+	}
+
+	for _, tc := range authorizeRolesTests {
+		t.Run(tc.test, func(t *testing.T) {
+			var gotRole string
+			router := gin.New()
+			router.Use(sgMiddleware.ApplicationErrorHandler, func(c *gin.Context) {
+				c.Set("user", tc.user)
+				c.Set("anonymous", tc.anonymous)
+				if tc.groups != nil {
+					c.Set("groups", tc.groups)
+				}
+			}, AuthorizeRoles(rbac, []string{"root"}, owner))
+			handler := func(c *gin.Context) {
+				gotRole = c.GetString("role")
+				c.Status(http.StatusOK)
+			}
+			router.GET("/applications/:gatewayId", handler)
+			router.POST("/applications", handler)
+			router.DELETE("/applications/:gatewayId", handler)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tc.method, tc.path, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code, "codes should match")
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, tc.expectedRole, gotRole, "roles should match")
+			}
+		})
+	}
+}
@@ -16,10 +16,13 @@
 package middleware
 
 import (
+	"crypto/subtle"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -40,7 +43,8 @@ func GetUserFromAuthHeader(authHeader string) (string, error) {
 		return "", fmt.Errorf("could not decode auth token: %w", err)
 	}
 
-	userPass := strings.Split(string(decoded), ":")
+	// Passwords may contain colons, usernames cannot
+	userPass := strings.SplitN(string(decoded), ":", 2)
 
 	if len(userPass) != 2 {
 		return "", fmt.Errorf("could not parse decoded auth token")
@@ -49,8 +53,20 @@ func GetUserFromAuthHeader(authHeader string) (string, error) {
 	return userPass[0], nil
 }
 
+// principalSecretKeyRegex matches valid Kubernetes Secret keys, which are the file names of a mounted Secret
+var principalSecretKeyRegex = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+
+// PrincipalMapping is the effective identity of a service principal. User replaces the principal as the `user` and
+// Team is set as the `team` context variable, which labels submitted SparkApplications.
+type PrincipalMapping struct {
+	User string `koanf:"user"`
+	Team string `koanf:"team"`
+}
+
 type RegexBasicAuthAllowMiddlewareConf struct {
-	Allow []string `koanf:"allow"`
+	Allow      []string                    `koanf:"allow"`
+	SecretsDir string                      `koanf:"secretsDir"`
+	Principals map[string]PrincipalMapping `koanf:"principals"`
 }
 
 func (r *RegexBasicAuthAllowMiddlewareConf) Name() string {
@@ -65,6 +81,10 @@ func (r *RegexBasicAuthAllowMiddlewareConf) Validate() error {
 		}
 	}
 
+	if len(r.Principals) > 0 && r.SecretsDir == "" {
+		return errors.New("principals can only be mapped when secretsDir is set")
+	}
+
 	return nil
 }
 
@@ -72,8 +92,15 @@ func (r *RegexBasicAuthAllowMiddlewareConf) Validate() error {
 // allow list of regexes. Will set the context `user` key if the passed
 // basic auth satisfies the allow/deny list criteria. If auth header is missing,
 // the request is denied.
+//
+// If SecretsDir is set, the password must also match the contents of the file named
+// after the user in SecretsDir, e.g. a mounted Kubernetes Secret. Files are read on
+// every request so rotated Secrets take effect without a restart. Authenticated users
+// found in Principals are then replaced with their mapped user and team.
 type RegexBasicAuthAllowMiddleware struct {
 	AllowRegexes []*regexp.Regexp
+	SecretsDir   string
+	Principals   map[string]PrincipalMapping
 }
 
 func NewRegexBasicAuthAllowMiddleware(confMap MiddlewareConfMap) (GatewayMiddleware, error) {
@@ -90,7 +117,11 @@ func NewRegexBasicAuthAllowMiddleware(confMap MiddlewareConfMap) (GatewayMiddlew
 		allowRegexes = append(allowRegexes, allowRegex)
 	}
 
-	return &RegexBasicAuthAllowMiddleware{AllowRegexes: allowRegexes}, nil
+	return &RegexBasicAuthAllowMiddleware{
+		AllowRegexes: allowRegexes,
+		SecretsDir:   mwConf.SecretsDir,
+		Principals:   mwConf.Principals,
+	}, nil
 }
 
 func (r *RegexBasicAuthAllowMiddleware) Handler(c *gin.Context) {
@@ -103,17 +134,61 @@ func (r *RegexBasicAuthAllowMiddleware) Handler(c *gin.Context) {
 			return
 		}
 
-		if allowUser := r.AllowUsername(authUser); allowUser {
-			c.Set("user", authUser)
-		} else {
+		if allowUser := r.AllowUsername(authUser); !allowUser {
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user is unauthorized"})
 			return
 		}
+
+		if r.SecretsDir == "" {
+			c.Set("user", authUser)
+			c.Next()
+			return
+		}
+
+		_, password, err := GetCredentialsFromAuthHeader(authHeader)
+		if err != nil || !r.VerifyPassword(authUser, password) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid username or password"})
+			return
+		}
+
+		mapping := r.Principals[authUser]
+		if mapping.User == "" {
+			c.Set("user", authUser)
+		} else {
+			// Keep the authenticated principal for auditing, as with impersonation
+			c.Set("user", mapping.User)
+			c.Set("actingUser", authUser)
+		}
+
+		if mapping.Team != "" {
+			c.Set("team", mapping.Team)
+		}
 	}
 
 	c.Next()
 }
 
+// VerifyPassword compares password in constant time with the secret stored for username in SecretsDir. Trailing
+// newlines of the secret are ignored. Usernames that are not valid Secret keys never match.
+func (r *RegexBasicAuthAllowMiddleware) VerifyPassword(username string, password string) bool {
+
+	if !principalSecretKeyRegex.MatchString(username) || username == "." || username == ".." {
+		return false
+	}
+
+	secret, err := os.ReadFile(filepath.Join(r.SecretsDir, username))
+	if err != nil {
+		return false
+	}
+
+	secret = []byte(strings.TrimRight(string(secret), "\r\n"))
+	if len(secret) == 0 {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(password), secret) == 1
+}
+
 // AllowUsername will use the regexes defined in RegexBasicAuthAllowMiddlewareConf to determine
 // whether a user is authorized or not.
 func (r *RegexBasicAuthAllowMiddleware) AllowUsername(username string) bool {
@@ -18,6 +18,8 @@ package middleware
 import (
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 
@@ -71,6 +73,30 @@ func TestAllowRegexBasicAuthConfValidate(t *testing.T) {
 	}
 }
 
+func TestAllowRegexBasicAuthConfValidatePrincipals(t *testing.T) {
+	conf := RegexBasicAuthAllowMiddlewareConf{
+		Principals: map[string]PrincipalMapping{"airflow": {User: "data-eng"}},
+	}
+
+	assert.EqualError(t, conf.Validate(), "principals can only be mapped when secretsDir is set", "principals without secretsDir should error")
+
+	conf.SecretsDir = "/secrets"
+	assert.Nil(t, conf.Validate(), "principals with secretsDir should be valid")
+}
+
+func TestNewRegexBasicAuthAllowMiddlewarePrincipals(t *testing.T) {
+	mw, err := NewRegexBasicAuthAllowMiddleware(MiddlewareConfMap{
+		"allow":      []string{".*"},
+		"secretsDir": "/secrets",
+		"principals": map[string]interface{}{
+			"airflow.prod": map[string]interface{}{"user": "data-eng", "team": "data"},
+		},
+	})
+
+	assert.Nil(t, err, "should be no error")
+	assert.Equal(t, map[string]PrincipalMapping{"airflow.prod": {User: "data-eng", Team: "data"}}, mw.(*RegexBasicAuthAllowMiddleware).Principals, "principals should be loaded")
+}
+
 var allowAuthHandlerTests = []struct {
 	test           string
 	allowRes       []string
@@ -242,3 +268,114 @@ func TestRegexDenyBasicAuthMiddleware(t *testing.T) {
 		})
 	}
 }
+
+var allowAuthSecretsHandlerTests = []struct {
+	test           string
+	authHeader     string
+	expectedStatus int
+	expectedUser   string
+	expectedActing string
+	expectedTeam   string
+}{
+	{
+		test:           "Missing token",
+		expectedStatus: http.StatusOK,
+	},
+	{
+		test:           "Valid password",
+		authHeader:     basicAuth("alice", "secret"),
+		expectedStatus: http.StatusOK,
+		expectedUser:   "alice",
+	},
+	{
+		test:           "Valid password mapped principal",
+		authHeader:     basicAuth("airflow", "rotated:token"),
+		expectedStatus: http.StatusOK,
+		expectedUser:   "data-eng",
+		expectedActing: "airflow",
+		expectedTeam:   "data",
+	},
+	{
+		test:           "Team only mapping keeps principal",
+		authHeader:     basicAuth("etl", "etl-secret"),
+		expectedStatus: http.StatusOK,
+		expectedUser:   "etl",
+		expectedTeam:   "ingest",
+	},
+	{
+		test:           "Wrong password",
+		authHeader:     basicAuth("airflow", "wrong"),
+		expectedStatus: http.StatusUnauthorized,
+	},
+	{
+		test:           "No secret",
+		authHeader:     basicAuth("bob", "secret"),
+		expectedStatus: http.StatusUnauthorized,
+	},
+	{
+		test:           "Empty secret",
+		authHeader:     basicAuth("empty", ""),
+		expectedStatus: http.StatusUnauthorized,
+	},
+	{
+		test:           "Path traversal",
+		authHeader:     basicAuth("../alice", "secret"),
+		expectedStatus: http.StatusUnauthorized,
+	},
+	{
+		test:           "Not allowed",
+		authHeader:     basicAuth("mallory", "secret"),
+		expectedStatus: http.StatusForbidden,
+	},
+}
+
+func TestRegexAllowBasicAuthMiddlewareSecrets(t *testing.T) {
+	secretsDir := t.TempDir()
+	for user, secret := range map[string]string{
+		"alice":   "secret\n",
+		"airflow": "rotated:token",
+		"etl":     "etl-secret",
+		"empty":   "",
+		"mallory": "secret",
+	} {
+		if err := os.WriteFile(filepath.Join(secretsDir, user), []byte(secret), 0600); err != nil {
+			t.Fatalf("unable to write secret: %v", err)
+		}
+	}
+
+	mw := RegexBasicAuthAllowMiddleware{
+		AllowRegexes: []*regexp.Regexp{regexp.MustCompile(`^(alice|airflow|etl|bob|empty|\.\./alice)$`)},
+		SecretsDir:   secretsDir,
+		Principals: map[string]PrincipalMapping{
+			"airflow": {User: "data-eng", Team: "data"},
+			"etl":     {Team: "ingest"},
+		},
+	}
+
+	for _, test := range allowAuthSecretsHandlerTests {
+		t.Run(test.test, func(t *testing.T) {
+
+			var gotUser, gotActing, gotTeam string
+			router := gin.New()
+			router.Use(mw.Handler)
+			router.GET("/", func(c *gin.Context) {
+				gotUser = c.GetString("user")
+				gotActing = c.GetString("actingUser")
+				gotTeam = c.GetString("team")
+			})
+
+			req, _ := http.NewRequest("GET", "/", nil)
+			if test.authHeader != "" {
+				req.Header.Add("Authorization", test.authHeader)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatus, w.Code, "codes should match")
+			assert.Equal(t, test.expectedUser, gotUser, "user should match")
+			assert.Equal(t, test.expectedActing, gotActing, "acting user should match")
+			assert.Equal(t, test.expectedTeam, gotTeam, "team should match")
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+)
+
+const (
+	// ResponseMetadataHeader asks for the response metadata block when set to true, like the metadata query parameter
+	ResponseMetadataHeader = "X-Spark-Gateway-Metadata"
+	// ResponseMetadataQuery asks for the response metadata block when set to true
+	ResponseMetadataQuery = "metadata"
+	// RequestIdHeader carries the ID of a request. A client set ID is kept so it can be correlated with client logs.
+	RequestIdHeader = "X-Request-Id"
+)
+
+// ResponseMetadataEnvelope wraps JSON responses of requests asking for their domain.ResponseMetadata
+type ResponseMetadataEnvelope struct {
+	Data     json.RawMessage          `json:"data"`
+	Metadata *domain.ResponseMetadata `json:"metadata"`
+}
+
+// ResponseMetadata collects the domain.ResponseMetadata of requests setting ResponseMetadataHeader or the
+// ResponseMetadataQuery parameter to true, and wraps their JSON responses in a ResponseMetadataEnvelope. Other
+// responses are returned as they are with their RequestIdHeader set. Requests to streamingRoutes, as returned by
+// routes.Group.StreamingPaths, are never wrapped since their responses are not buffered.
+func ResponseMetadata(streamingRoutes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !wantsResponseMetadata(c) || routes.IsStreaming(c, streamingRoutes) {
+			c.Next()
+			return
+		}
+
+		requestId := c.GetHeader(RequestIdHeader)
+		if requestId == "" {
+			requestId = uuid.NewString()
+		}
+		c.Header(RequestIdHeader, requestId)
+
+		metadata := &domain.ResponseMetadata{
+			RequestId:         requestId,
+			Clusters:          []string{},
+			SparkManagerCalls: []domain.SparkManagerCallTime{},
+		}
+		c.Request = c.Request.WithContext(domain.WithResponseMetadata(c.Request.Context(), metadata))
+
+		writer := &bufferedResponseWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = writer
+
+		start := time.Now()
+		c.Next()
+		metadata.Finish(time.Since(start))
+
+		c.Writer = writer.ResponseWriter
+		body := writer.body.Bytes()
+		if strings.HasPrefix(c.Writer.Header().Get("Content-Type"), gin.MIMEJSON) && json.Valid(body) {
+			envelope, err := json.Marshal(ResponseMetadataEnvelope{Data: body, Metadata: metadata})
+			if err != nil {
+				klog.Errorf("could not marshal response metadata of request %s: %s", requestId, err)
+			} else {
+				body = envelope
+			}
+		}
+
+		c.Writer.Header().Del("Content-Length")
+		if len(body) > 0 {
+			c.Writer.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		}
+		c.Writer.WriteHeader(writer.status)
+		if len(body) > 0 {
+			if _, err := c.Writer.Write(body); err != nil {
+				klog.Errorf("could not write response of request %s: %s", requestId, err)
+			}
+		}
+	}
+}
+
+func wantsResponseMetadata(c *gin.Context) bool {
+	if wants, err := strconv.ParseBool(c.GetHeader(ResponseMetadataHeader)); err == nil && wants {
+		return true
+	}
+	wants, err := strconv.ParseBool(c.Query(ResponseMetadataQuery))
+	return err == nil && wants
+}
+
+// bufferedResponseWriter holds a response until the handlers are done with it, so it can be wrapped
+type bufferedResponseWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+	}
+}
+
+func (w *bufferedResponseWriter) WriteHeaderNow() {}
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedResponseWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedResponseWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedResponseWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *bufferedResponseWriter) Written() bool {
+	return w.body.Len() > 0
+}
+
+func (w *bufferedResponseWriter) Flush() {}
@@ -0,0 +1,106 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+)
+
+func TestResponseMetadata(t *testing.T) {
+	router := gin.New()
+	// Handlers pass the gin.Context to services, as on the Gateway router
+	router.ContextWithFallback = true
+	router.Use(ResponseMetadata("/applications/watch"))
+	router.GET("/applications/:gatewayId", func(c *gin.Context) {
+		domain.ResponseMetadataFrom(c).RecordSparkManagerCall("cluster-a", http.MethodGet, "/api/v1/ns/app", 2*time.Second)
+		domain.ResponseMetadataFrom(c).RecordCacheHit("cluster-b")
+		c.JSON(http.StatusCreated, gin.H{"name": "app"})
+	})
+	router.GET("/applications/watch", func(c *gin.Context) {
+		assert.Nil(t, domain.ResponseMetadataFrom(c), "streaming routes should not collect metadata")
+		c.String(http.StatusOK, "event")
+	})
+	router.GET("/logs", func(c *gin.Context) {
+		c.String(http.StatusOK, "driver logs")
+	})
+
+	t.Run("not asked for", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/applications/app", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code, "codes should match")
+		assert.JSONEq(t, `{"name": "app"}`, w.Body.String(), "responses should not be wrapped")
+		assert.Empty(t, w.Header().Get(RequestIdHeader), "request id should not be set")
+	})
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/applications/app?metadata=true", nil),
+		func() *http.Request {
+			req := httptest.NewRequest(http.MethodGet, "/applications/app", nil)
+			req.Header.Set(ResponseMetadataHeader, "true")
+			req.Header.Set(RequestIdHeader, "client-id")
+			return req
+		}(),
+	} {
+		t.Run(req.URL.String()+" "+req.Header.Get(ResponseMetadataHeader), func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusCreated, w.Code, "codes should be kept")
+
+			var envelope struct {
+				Data     map[string]string       `json:"data"`
+				Metadata domain.ResponseMetadata `json:"metadata"`
+			}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &envelope), "response should decode")
+			assert.Equal(t, map[string]string{"name": "app"}, envelope.Data, "data should be the original response")
+			assert.Equal(t, w.Header().Get(RequestIdHeader), envelope.Metadata.RequestId, "request ids should match")
+			if id := req.Header.Get(RequestIdHeader); id != "" {
+				assert.Equal(t, id, envelope.Metadata.RequestId, "client request ids should be kept")
+			}
+			assert.Equal(t, []string{"cluster-a", "cluster-b"}, envelope.Metadata.Clusters, "clusters should be recorded")
+			assert.Equal(t, 2.0, envelope.Metadata.SparkManagerSeconds, "SparkManager latency should be recorded")
+			assert.True(t, envelope.Metadata.Cached, "cache hits should be recorded")
+		})
+	}
+
+	t.Run("streaming", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/applications/watch?metadata=true", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, "event", w.Body.String(), "streams should not be wrapped")
+	})
+
+	t.Run("not JSON", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/logs?metadata=true", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, "driver logs", w.Body.String(), "responses other than JSON should not be wrapped")
+		assert.NotEmpty(t, w.Header().Get(RequestIdHeader), "request id should be set")
+	})
+}
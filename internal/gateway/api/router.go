@@ -2,49 +2,136 @@ package api
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/pprof"
 
 	"github.com/gin-gonic/gin"
+	"github.com/slackhq/spark-gateway/internal/gateway/api/admin"
 	"github.com/slackhq/spark-gateway/internal/gateway/api/health"
 	"github.com/slackhq/spark-gateway/internal/gateway/api/livy"
 	"github.com/slackhq/spark-gateway/internal/gateway/api/middleware"
 	"github.com/slackhq/spark-gateway/internal/gateway/api/swagger"
+	"github.com/slackhq/spark-gateway/internal/gateway/api/ui"
 	v1 "github.com/slackhq/spark-gateway/internal/gateway/api/v1"
+	"github.com/slackhq/spark-gateway/internal/gateway/metrics"
 	"github.com/slackhq/spark-gateway/internal/gateway/service"
 	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/faults"
 	sgMiddleware "github.com/slackhq/spark-gateway/internal/shared/middleware"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
 )
 
-func NewRouter(sgConf *config.SparkGatewayConfig, appService service.GatewayApplicationService, livyService service.LivyApplicationService) (*gin.Engine, error) {
+func NewRouter(sgConf *config.SparkGatewayConfig, appService service.GatewayApplicationService, livyService service.LivyApplicationService, scheduleService service.ScheduleService, namespaceService service.NamespaceService, migrationService service.MigrationService, killSwitchService service.KillSwitchService, routingSimulator service.RoutingSimulator, stuckDetector service.StuckApplicationDetector, failedSubmissions service.FailedSubmissionService, faultInjector *faults.Injector) (*gin.Engine, error) {
 
-	router := gin.Default()
+	router, err := newEngine(sgConf.GatewayConfig.ClientIP)
+	if err != nil {
+		return nil, err
+	}
 
-	// Root group for unversioned routes
-	rootGroup := router.Group("")
+	registry := routes.NewRegistry()
 
-	health.RegisterHealthRoutes(rootGroup)
+	// Unversioned routes
+	registry.Add(routes.Group{Routes: health.Routes()})
+
+	// The metrics route is only authenticated when middleware are scoped to it
+	metricsGroup := routes.Group{Routes: metrics.Routes()}
+	if middleware.ScopedTo(sgConf.GatewayConfig.Middleware, config.MetricsRouteGroup) {
+		metricsGroup.Auth = config.MetricsRouteGroup
+		metricsGroup.Middleware = []gin.HandlerFunc{sgMiddleware.ApplicationErrorHandler}
+	}
+	registry.Add(metricsGroup)
 
 	if sgConf.GatewayConfig.EnableSwaggerUI {
-		swagger.RegisterSwaggerRoutes(rootGroup)
+		registry.Add(routes.Group{Routes: swagger.Routes()})
 	}
 
 	// Versioned routes
-	v1Group := router.Group("/api/v1")
-	v1Group.Use(sgMiddleware.ApplicationErrorHandler)
-	if err := middleware.AddMiddleware(sgConf.GatewayConfig.Middleware, v1Group); err != nil {
-		return nil, fmt.Errorf("error adding middlewares to routes: %w", err)
+	registry.Add(v1.Group(sgConf, appService, scheduleService))
+
+	// Admin routes move to the admin listener when one is configured
+	if sgConf.GatewayConfig.AdminPort == "" {
+		addAdminGroup(registry, sgConf, namespaceService, migrationService, killSwitchService, routingSimulator, stuckDetector, failedSubmissions, faultInjector)
 	}
 
-	v1.RegisterGatewayApplicationRoutes(v1Group, sgConf, appService)
+	if sgConf.GatewayConfig.WebUI.Enable {
+		uiGroup, err := ui.Group(sgConf.GatewayConfig.WebUI)
+		if err != nil {
+			return nil, err
+		}
+		registry.Add(uiGroup)
+	}
 
 	if sgConf.LivyConfig.Enable {
-		livyGroup := router.Group("/api/livy")
-		livyGroup.Use(livy.LivyErrorHandler)
-		if err := middleware.AddMiddleware(sgConf.GatewayConfig.Middleware, livyGroup); err != nil {
-			return nil, fmt.Errorf("error adding middlewares to routes: %w", err)
-		}
-		livy.RegisterLivyBatchRoutes(livyGroup, livyService)
+		registry.Add(livy.Group(sgConf, livyService, appService))
+	}
+
+	if err := registry.Build(router, middleware.Authenticator(sgConf.GatewayConfig.Middleware)); err != nil {
+		return nil, err
+	}
+
+	return router, nil
+
+}
+
+// NewAdminRouter returns the router served on the admin listener: the admin API and the Go profiler under
+// /debug/pprof. The profiler is not authenticated, so access to the admin port must be restricted by network policy.
+func NewAdminRouter(sgConf *config.SparkGatewayConfig, namespaceService service.NamespaceService, migrationService service.MigrationService, killSwitchService service.KillSwitchService, routingSimulator service.RoutingSimulator, stuckDetector service.StuckApplicationDetector, failedSubmissions service.FailedSubmissionService, faultInjector *faults.Injector) (*gin.Engine, error) {
+
+	router, err := newEngine(sgConf.GatewayConfig.ClientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := routes.NewRegistry()
+	registry.Add(routes.Group{Routes: health.Routes()})
+
+	addAdminGroup(registry, sgConf, namespaceService, migrationService, killSwitchService, routingSimulator, stuckDetector, failedSubmissions, faultInjector)
+
+	registry.Add(routes.Group{Prefix: "/debug/pprof", Routes: []routes.Route{
+		{Method: http.MethodGet, Path: "/", Handler: gin.WrapF(pprof.Index)},
+		{Method: http.MethodGet, Path: "/cmdline", Handler: gin.WrapF(pprof.Cmdline)},
+		{Method: http.MethodGet, Path: "/profile", Handler: gin.WrapF(pprof.Profile)},
+		{Method: http.MethodPost, Path: "/symbol", Handler: gin.WrapF(pprof.Symbol)},
+		{Method: http.MethodGet, Path: "/symbol", Handler: gin.WrapF(pprof.Symbol)},
+		{Method: http.MethodGet, Path: "/trace", Handler: gin.WrapF(pprof.Trace)},
+		{Method: http.MethodGet, Path: "/:profile", Handler: func(c *gin.Context) {
+			pprof.Handler(c.Param("profile")).ServeHTTP(c.Writer, c.Request)
+		}},
+	}})
+
+	if err := registry.Build(router, middleware.Authenticator(sgConf.GatewayConfig.Middleware)); err != nil {
+		return nil, err
 	}
 
 	return router, nil
+}
+
+// newEngine returns a gin.Engine finding client IPs as configured by clientIP, so access logs and c.ClientIP() only
+// honor forwarded headers set by trusted proxies
+func newEngine(clientIP config.ClientIPConfig) (*gin.Engine, error) {
+	router := gin.Default()
+	// Handlers pass the gin.Context to services, so it must carry the request's cancellation through to SparkManager
+	router.ContextWithFallback = true
 
+	if err := router.SetTrustedProxies(clientIP.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+	if len(clientIP.Headers) > 0 {
+		router.RemoteIPHeaders = clientIP.Headers
+	}
+
+	return router, nil
+}
+
+// addAdminGroup adds the admin API to registry. Admin routes are only served when admins are configured
+func addAdminGroup(registry *routes.Registry, sgConf *config.SparkGatewayConfig, namespaceService service.NamespaceService, migrationService service.MigrationService, killSwitchService service.KillSwitchService, routingSimulator service.RoutingSimulator, stuckDetector service.StuckApplicationDetector, failedSubmissions service.FailedSubmissionService, faultInjector *faults.Injector) {
+	if len(sgConf.GatewayConfig.AdminUsers) == 0 {
+		return
+	}
+
+	group := admin.Group(sgConf.GatewayConfig.AdminUsers, namespaceService, migrationService, killSwitchService, routingSimulator, stuckDetector, failedSubmissions, faultInjector)
+	if sgConf.GatewayConfig.ReadOnly {
+		group.Routes = routes.ReadRoutes(group.Routes)
+	}
+	registry.Add(group)
 }
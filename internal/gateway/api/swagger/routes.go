@@ -5,18 +5,20 @@ import (
 
 	"github.com/gin-gonic/gin"
 	swaggerDocs "github.com/slackhq/spark-gateway/docs/swagger"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func RegisterSwaggerRoutes(rg *gin.RouterGroup) {
+func Routes() []routes.Route {
 	swaggerDocs.SwaggerInfo.BasePath = "/api"
 
-	// Swagger UI on /swagger/index.html
-	rg.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.DefaultModelsExpandDepth(-1)))
-	// Redirect /doc and /docs/ to /swagger/index.html
-	rg.GET("/docs", func(ctx *gin.Context) {
-		ctx.Redirect(http.StatusMovedPermanently, "/swagger/index.html")
-	})
-
+	return []routes.Route{
+		// Swagger UI on /swagger/index.html
+		{Method: http.MethodGet, Path: "/swagger/*any", Handler: ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.DefaultModelsExpandDepth(-1))},
+		// Redirect /doc and /docs/ to /swagger/index.html
+		{Method: http.MethodGet, Path: "/docs", Handler: func(ctx *gin.Context) {
+			ctx.Redirect(http.StatusMovedPermanently, "/swagger/index.html")
+		}},
+	}
 }
@@ -0,0 +1,94 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	sgMiddleware "github.com/slackhq/spark-gateway/internal/shared/middleware"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+)
+
+//go:embed static
+var staticFiles embed.FS
+
+// Group declares the dashboard, authenticated by the middleware configured for ui routes. If webUI sets a
+// basicAuthRealm, responses challenge browsers for Basic auth credentials.
+func Group(webUI config.WebUIConfig) (routes.Group, error) {
+	uiRoutes, err := Routes()
+	if err != nil {
+		return routes.Group{}, err
+	}
+
+	group := routes.Group{
+		Prefix:     "/ui",
+		Auth:       config.UIRouteGroup,
+		Middleware: []gin.HandlerFunc{sgMiddleware.ApplicationErrorHandler},
+		Routes:     uiRoutes,
+	}
+	if webUI.BasicAuthRealm != "" {
+		group.Middleware = append(group.Middleware, BasicAuthChallenge(webUI.BasicAuthRealm))
+	}
+
+	return group, nil
+}
+
+// Routes declares the dashboard page on the group path and its assets under /assets. The page is served without a
+// trailing slash so browsers reuse credentials entered for it on /api/v1 requests made by the dashboard.
+func Routes() ([]routes.Route, error) {
+	static, err := fs.Sub(staticFiles, "static")
+	if err != nil {
+		return nil, fmt.Errorf("error loading web UI files: %w", err)
+	}
+
+	index, err := fs.ReadFile(static, "index.html")
+	if err != nil {
+		return nil, fmt.Errorf("error loading web UI index: %w", err)
+	}
+
+	assets, err := fs.Sub(static, "assets")
+	if err != nil {
+		return nil, fmt.Errorf("error loading web UI assets: %w", err)
+	}
+	assetsFS := http.FS(assets)
+	serveAsset := func(c *gin.Context) {
+		c.FileFromFS(c.Param("filepath"), assetsFS)
+	}
+
+	return []routes.Route{
+		{Method: http.MethodGet, Path: "", Handler: func(c *gin.Context) {
+			c.Data(http.StatusOK, "text/html; charset=utf-8", index)
+		}},
+		{Method: http.MethodGet, Path: "/assets/*filepath", Handler: serveAsset},
+		{Method: http.MethodHead, Path: "/assets/*filepath", Handler: serveAsset},
+	}, nil
+}
+
+// BasicAuthChallenge returns a middleware adding a WWW-Authenticate header for realm, so a browser prompts for
+// credentials if a later auth middleware responds with 401. Browsers ignore the header on other responses.
+func BasicAuthChallenge(realm string) gin.HandlerFunc {
+	challenge := fmt.Sprintf("Basic realm=%q, charset=\"UTF-8\"", realm)
+	return func(c *gin.Context) {
+		c.Header("WWW-Authenticate", challenge)
+		c.Next()
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func TestRoutes(t *testing.T) {
+	router := gin.New()
+	uiGroup := router.Group("/ui")
+	uiGroup.Use(BasicAuthChallenge("Spark Gateway"))
+	uiRoutes, err := Routes()
+	assert.NoError(t, err, "routes should build")
+	routes.Register(uiGroup, uiRoutes)
+
+	testCases := []struct {
+		path        string
+		contentType string
+	}{
+		{path: "/ui", contentType: "text/html; charset=utf-8"},
+		// Asset content types come from the system mime table
+		{path: "/ui/assets/app.js"},
+		{path: "/ui/assets/style.css"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, tc.path, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+			if tc.contentType != "" {
+				assert.Equal(t, tc.contentType, w.Header().Get("Content-Type"), "content types should match")
+			}
+			assert.Equal(t, `Basic realm="Spark Gateway", charset="UTF-8"`, w.Header().Get("WWW-Authenticate"), "challenge should be set")
+		})
+	}
+}
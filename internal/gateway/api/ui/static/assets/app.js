@@ -0,0 +1,130 @@
+// Spark Gateway dashboard. Reads everything from the /api/v1 routes with the browser's credentials.
+(function () {
+  "use strict";
+
+  const api = "api/v1";
+  let applications = [];
+
+  function escapeHtml(value) {
+    return String(value ?? "").replace(/[&<>"']/g, (c) => ({
+      "&": "&amp;", "<": "&lt;", ">": "&gt;", '"': "&quot;", "'": "&#39;",
+    })[c]);
+  }
+
+  async function getJson(path) {
+    const resp = await fetch(path, { headers: { Accept: "application/json" } });
+    const body = await resp.json().catch(() => ({}));
+    if (!resp.ok) {
+      throw new Error(body.error || `${resp.status} ${resp.statusText}`);
+    }
+    return body;
+  }
+
+  function stateBadge(status) {
+    const state = (status && status.applicationState && status.applicationState.state) || "NEW";
+    return `<span class="badge ${escapeHtml(state.toLowerCase())}">${escapeHtml(state)}</span>`;
+  }
+
+  // Clusters are discovered from the counts summary so no extra configuration is needed
+  async function loadApplications() {
+    const message = document.getElementById("list-message");
+    message.textContent = "";
+    try {
+      const counts = await getJson(`${api}/applications/summary?groupBy=cluster`);
+      const clusters = counts.map((count) => count.cluster);
+      updateClusterFilter(clusters);
+
+      const lists = await Promise.all(clusters.map((cluster) =>
+        getJson(`${api}/applications?cluster=${encodeURIComponent(cluster)}&view=summary&sortBy=creationTime&order=desc`)));
+      applications = lists.flat();
+      renderApplications();
+    } catch (err) {
+      message.textContent = `Error loading applications: ${err.message}`;
+    }
+  }
+
+  function updateClusterFilter(clusters) {
+    const select = document.getElementById("cluster-filter");
+    const selected = select.value;
+    select.innerHTML = `<option value="">All clusters</option>` +
+      clusters.map((cluster) => `<option>${escapeHtml(cluster)}</option>`).join("");
+    select.value = clusters.includes(selected) ? selected : "";
+  }
+
+  function renderApplications() {
+    const cluster = document.getElementById("cluster-filter").value;
+    const namespace = document.getElementById("namespace-filter").value.trim();
+    const user = document.getElementById("user-filter").value.trim();
+
+    const rows = applications
+      .filter((app) => !cluster || app.cluster === cluster)
+      .filter((app) => !namespace || app.metadata.namespace === namespace)
+      .filter((app) => !user || app.user === user)
+      .map((app) => `<tr>
+        <td><a href="#/applications/${encodeURIComponent(app.gatewayId)}">${escapeHtml(app.gatewayId)}</a></td>
+        <td>${escapeHtml(app.metadata.annotations && app.metadata.annotations.applicationName)}</td>
+        <td>${escapeHtml(app.cluster)}</td>
+        <td>${escapeHtml(app.metadata.namespace)}</td>
+        <td>${escapeHtml(app.user)}</td>
+        <td>${stateBadge(app.status)}</td>
+        <td>${escapeHtml(app.metadata.creationTimestamp)}</td>
+      </tr>`);
+
+    document.querySelector("#applications tbody").innerHTML =
+      rows.join("") || `<tr><td colspan="7">No applications found</td></tr>`;
+  }
+
+  async function loadApplication(gatewayId) {
+    const message = document.getElementById("detail-message");
+    message.textContent = "";
+    document.getElementById("detail-title").textContent = gatewayId;
+    document.getElementById("detail-fields").innerHTML = "";
+    document.getElementById("detail-links").innerHTML = "";
+    document.getElementById("detail-status").textContent = "";
+
+    try {
+      const app = await getJson(`${api}/applications/${encodeURIComponent(gatewayId)}`);
+      const meta = app.sparkApplication.metadata;
+      document.getElementById("detail-fields").innerHTML = [
+        ["State", stateBadge(app.sparkApplication.status)],
+        ["Cluster", escapeHtml(app.cluster)],
+        ["Namespace", escapeHtml(meta.namespace)],
+        ["User", escapeHtml(app.user)],
+        ["Name", escapeHtml(meta.annotations && meta.annotations.applicationName)],
+      ].map(([key, value]) => `<dt>${key}</dt><dd>${value}</dd>`).join("");
+
+      const urls = app.sparkLogURLs || {};
+      const links = [
+        ["Spark UI", urls.sparkUI],
+        ["Spark History Server", urls.sparkHistoryUI],
+        ["Logs", urls.logsUI],
+        ["Download driver logs", `${api}/applications/${encodeURIComponent(gatewayId)}/logs/download`],
+      ].filter(([, url]) => url);
+      document.getElementById("detail-links").innerHTML = links
+        .map(([label, url]) => `<li><a href="${escapeHtml(url)}" target="_blank" rel="noopener">${label}</a></li>`)
+        .join("");
+
+      document.getElementById("detail-status").textContent = JSON.stringify(app.sparkApplication.status, null, 2);
+    } catch (err) {
+      message.textContent = `Error loading application: ${err.message}`;
+    }
+  }
+
+  function route() {
+    const match = window.location.hash.match(/^#\/applications\/(.+)$/);
+    document.getElementById("list-view").hidden = Boolean(match);
+    document.getElementById("detail-view").hidden = !match;
+    if (match) {
+      loadApplication(decodeURIComponent(match[1]));
+    } else {
+      loadApplications();
+    }
+  }
+
+  document.getElementById("refresh").addEventListener("click", loadApplications);
+  document.getElementById("cluster-filter").addEventListener("change", renderApplications);
+  document.getElementById("namespace-filter").addEventListener("input", renderApplications);
+  document.getElementById("user-filter").addEventListener("input", renderApplications);
+  window.addEventListener("hashchange", route);
+  route();
+})();
@@ -16,23 +16,41 @@
 package v1
 
 import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/slackhq/spark-gateway/internal/domain"
 	"github.com/slackhq/spark-gateway/internal/gateway/service"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
 	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+	"k8s.io/klog/v2"
+	sigsjson "sigs.k8s.io/json"
 )
 
 type GatewayApplicationHandler struct {
-	service         service.GatewayApplicationService
-	defaultLogLines int
+	service service.GatewayApplicationService
+	// fieldValidation is how unknown fields of submissions are handled unless the request sets its own
+	fieldValidation string
+	// asyncSubmission is whether submissions may be queued with async=true
+	asyncSubmission bool
+	// adminUsers can delete and change GatewayApplications without the selector labels with force=true
+	adminUsers []string
 }
 
-func NewGatewayApplicationHandler(service service.GatewayApplicationService, defaultLogLines int) *GatewayApplicationHandler {
-	return &GatewayApplicationHandler{service: service, defaultLogLines: defaultLogLines}
+func NewGatewayApplicationHandler(service service.GatewayApplicationService, fieldValidation string, asyncSubmission bool, adminUsers []string) *GatewayApplicationHandler {
+	return &GatewayApplicationHandler{service: service, fieldValidation: fieldValidation, asyncSubmission: asyncSubmission, adminUsers: adminUsers}
 }
 
 // ListGatewayApplicationSummaries godoc
@@ -44,6 +62,9 @@ func NewGatewayApplicationHandler(service service.GatewayApplicationService, def
 // @Security BasicAuth
 // @Param cluster query string true "Cluster name"
 // @Param namespace query string false "Namespace (optional)"
+// @Param view query string false "Status projection: 'full' (default) or 'summary' for only state, timestamps and submissionID"
+// @Param sortBy query string false "Sort field: 'creationTime', 'state' or 'name'"
+// @Param order query string false "Sort order: 'asc' (default) or 'desc'. Requires sortBy"
 // @Success 200 {array} domain.GatewayApplicationSummary "List of GatewayApplicationSummary objects"
 // @Router /v1/applications [get]
 func (h *GatewayApplicationHandler) List(c *gin.Context) {
@@ -56,7 +77,19 @@ func (h *GatewayApplicationHandler) List(c *gin.Context) {
 
 	namespace := c.Query("namespace")
 
-	appMetaList, err := h.service.List(c, cluster, namespace)
+	view, err := domain.ParseSummaryView(c.Query("view"))
+	if err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	listSort, err := domain.ParseListSort(c.Query("sortBy"), c.Query("order"))
+	if err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	appMetaList, err := h.service.List(c, cluster, namespace, view, listSort)
 
 	if err != nil {
 		c.Error(err)
@@ -66,6 +99,80 @@ func (h *GatewayApplicationHandler) List(c *gin.Context) {
 	c.JSON(http.StatusOK, appMetaList)
 }
 
+// GetGatewayApplicationCounts godoc
+// @Summary Count GatewayApplications
+// @Description Returns the number of applications, optionally grouped by cluster, namespace and/or state. Counts are computed by each SparkManager so no application objects are returned.
+// @Tags Applications
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param cluster query string false "Cluster name (optional, defaults to all clusters)"
+// @Param namespace query string false "Namespace (optional, defaults to all namespaces)"
+// @Param groupBy query string false "Comma separated fields to group by: 'cluster', 'namespace', 'state'"
+// @Success 200 {array} domain.ApplicationCount "Application counts"
+// @Router /v1/applications/summary [get]
+func (h *GatewayApplicationHandler) Summary(c *gin.Context) {
+
+	groupBy, err := domain.ParseCountGroupBy(c.Query("groupBy"))
+	if err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	counts, err := h.service.Counts(c, c.Query("cluster"), c.Query("namespace"), groupBy)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, counts)
+}
+
+// WatchGatewayApplications godoc
+// @Summary Watch GatewayApplications
+// @Description Streams application changes across all clusters as newline delimited JSON, multiplexing the SparkManager watch of each cluster and namespace. Every event carries a bookmark; pass the last one received as resourceVersion to resume the stream. A stream whose resourceVersion has expired reports an ERROR event with code 410 and is dropped from later bookmarks.
+// @Tags Applications
+// @Produce application/x-ndjson
+// @Security BasicAuth
+// @Param namespace query string false "Namespace (optional, defaults to all namespaces)"
+// @Param labelSelector query string false "Kubernetes label selector"
+// @Param resourceVersion query string false "Bookmark from a previous event to resume from"
+// @Success 200 {object} domain.GatewayWatchEvent "Stream of watch events"
+// @Router /v1/applications/watch [get]
+func (h *GatewayApplicationHandler) Watch(c *gin.Context) {
+
+	bookmark, err := domain.ParseWatchBookmark(c.Query("resourceVersion"))
+	if err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	// Cancelled when the client disconnects or writing fails, which stops every SparkManager stream
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	events, err := h.service.Watch(ctx, c.Query("namespace"), c.Query("labelSelector"), bookmark)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	encoder := json.NewEncoder(c.Writer)
+	for event := range events {
+		if err := encoder.Encode(event); err != nil {
+			// Headers have already been sent, so we can only log the error
+			klog.Errorf("error streaming watch events: %v", err)
+			return
+		}
+		c.Writer.Flush()
+	}
+}
+
 // GetGatewayApplication godoc
 // @Summary Get a GatewayApplication
 // @Description Retrieves the full GatewayApplication resource by ID.
@@ -85,6 +192,11 @@ func (h *GatewayApplicationHandler) Get(c *gin.Context) {
 		return
 	}
 
+	// Anonymous users must not see values that may hold secrets
+	if c.GetBool("anonymous") {
+		application = application.Redacted()
+	}
+
 	c.JSON(http.StatusOK, application)
 }
 
@@ -96,7 +208,7 @@ func (h *GatewayApplicationHandler) Get(c *gin.Context) {
 // @Produce json
 // @Security BasicAuth
 // @Param gatewayId path string true "GatewayApplication Name"
-// @Success 200 {object} v1beta2.SparkApplicationStatus "GatewayApplication status"
+// @Success 200 {object} domain.ApplicationStatus "GatewayApplication status with derived timings"
 // @Router /v1/applications/{gatewayId}/status [get]
 func (h *GatewayApplicationHandler) Status(c *gin.Context) {
 
@@ -110,20 +222,102 @@ func (h *GatewayApplicationHandler) Status(c *gin.Context) {
 	c.JSON(http.StatusOK, appStatus)
 }
 
+// StreamGatewayApplicationStatus godoc
+// @Summary Stream GatewayApplication status
+// @Description Streams the status of a GatewayApplication as Server-Sent Events instead of polling. A `status` event carrying the same document as the status endpoint is sent with the current status and then each time the state changes. The stream ends after a terminal state is sent, or if the application is deleted.
+// @Tags Applications
+// @Produce text/event-stream
+// @Security BasicAuth
+// @Param gatewayId path string true "GatewayApplication Name"
+// @Success 200 {object} domain.ApplicationStatus "Stream of status events"
+// @Router /v1/applications/{gatewayId}/status/stream [get]
+func (h *GatewayApplicationHandler) StreamStatus(c *gin.Context) {
+
+	// Cancelled when the client disconnects or writing fails, which stops the SparkManager stream
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	statuses, err := h.service.StreamStatus(ctx, c.Param("gatewayId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	for status := range statuses {
+		data, err := json.Marshal(status)
+		if err == nil {
+			_, err = fmt.Fprintf(c.Writer, "event: status\ndata: %s\n\n", data)
+		}
+		if err != nil {
+			// Headers have already been sent, so we can only log the error
+			klog.Errorf("error streaming status for GatewayApplication '%s': %v", c.Param("gatewayId"), err)
+			return
+		}
+		c.Writer.Flush()
+	}
+}
+
+// WaitGatewayApplicationStatus godoc
+// @Summary Wait for a GatewayApplication state change
+// @Description Long-polls a compact status document for clients such as Airflow deferrable operators. Returns as soon as the state differs from the state query parameter or is terminal, otherwise returns the current status once timeout elapses. Omitting state returns immediately.
+// @Tags Applications
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param gatewayId path string true "GatewayApplication Name"
+// @Param state query string false "Last state seen by the client"
+// @Param timeout query string false "Maximum time to wait, e.g. 30s (default and cap: gateway.waitStatus.maxTimeout)"
+// @Success 200 {object} domain.GatewayApplicationWaitStatus "GatewayApplication wait status"
+// @Router /v1/applications/{gatewayId}/wait [get]
+func (h *GatewayApplicationHandler) WaitStatus(c *gin.Context) {
+
+	var timeout time.Duration
+	if timeoutQuery := c.Query("timeout"); timeoutQuery != "" {
+		var err error
+		timeout, err = time.ParseDuration(timeoutQuery)
+		if err != nil {
+			c.Error(gatewayerrors.NewBadRequest(fmt.Errorf("invalid 'timeout' query parameter: %w", err)))
+			return
+		}
+	}
+
+	// Use the request context so waiting stops when the client disconnects
+	waitStatus, err := h.service.WaitStatus(c.Request.Context(), c.Param("gatewayId"), v1beta2.ApplicationStateType(c.Query("state")), timeout)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, waitStatus)
+}
+
 // GetGatewayApplicationLogs godoc
-// @Summary Get driver logs of a GatewayApplication
-// @Description Retrieves the last N lines of driver logs for the specified GatewayApplication. Defaults to the last 100 lines.
+// @Summary Get driver or executor logs of a GatewayApplication
+// @Description Retrieves the last N lines of driver logs for the specified GatewayApplication. Defaults to the last 100 lines. Set pod=executor and executorId to read the logs of an executor instead, the ids of its executors are listed by its pods.
 // @Tags Applications
 // @Accept json
 // @Produce plain
 // @Security BasicAuth
 // @Param gatewayId path string true "GatewayApplication Name"
-// @Param lines query int false "Number of log lines to retrieve (default: 100)"
-// @Success 200 {string} string "Driver logs"
+// @Param lines query int false "Number of log lines to retrieve (default: the namespace's defaultLogLines, capped at its maxLogLines)"
+// @Param follow query bool false "Tail the logs live as plain text, starting from the last lines, until the driver exits"
+// @Param pod query string false "Pod to read logs from, driver or executor (default: driver)"
+// @Param executorId query string false "Id of the executor to read logs from, required with pod=executor"
+// @Success 200 {string} string "Driver or executor logs"
+// @Failure 400 {object} map[string]string "Invalid pod or executorId, or executor logs requested with follow=true"
+// @Failure 404 {object} map[string]string "The GatewayApplication has no executor with executorId"
+// @Failure 501 {object} map[string]string "The cluster's backend cannot follow driver logs or read executor logs"
 // @Router /v1/applications/{gatewayId}/logs [get]
 func (h *GatewayApplicationHandler) Logs(c *gin.Context) {
 
-	tailLines := h.defaultLogLines
+	// 0 lets the service apply the namespace's defaultLogLines
+	tailLines := 0
 	var err error
 	tailLinesQuery := c.Query("lines")
 	if tailLinesQuery != "" {
@@ -133,7 +327,35 @@ func (h *GatewayApplicationHandler) Logs(c *gin.Context) {
 			return
 		}
 	}
-	logString, err := h.service.Logs(c, c.Param("gatewayId"), tailLines)
+
+	logPod, err := domain.ParseLogPod(c.Query("pod"), c.Query("executorId"))
+	if err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	if followQuery := c.Query("follow"); followQuery != "" {
+		follow, err := strconv.ParseBool(followQuery)
+		if err != nil {
+			c.Error(gatewayerrors.NewBadRequest(fmt.Errorf("invalid follow query parameter '%s': %w", followQuery, err)))
+			return
+		}
+		if follow {
+			if logPod.IsExecutor() {
+				c.Error(gatewayerrors.NewBadRequest(fmt.Errorf("only driver logs can be followed")))
+				return
+			}
+			h.followLogs(c, tailLines)
+			return
+		}
+	}
+
+	var logString *string
+	if logPod.IsExecutor() {
+		logString, err = h.service.ExecutorLogs(c, c.Param("gatewayId"), logPod.ExecutorId, tailLines)
+	} else {
+		logString, err = h.service.Logs(c, c.Param("gatewayId"), tailLines)
+	}
 
 	if err != nil {
 		c.Error(err)
@@ -143,39 +365,382 @@ func (h *GatewayApplicationHandler) Logs(c *gin.Context) {
 	c.JSON(http.StatusOK, logString)
 }
 
+// followLogs streams the driver logs as plain text as the driver writes them. No Content-Length is set so the response
+// is chunked.
+func (h *GatewayApplicationHandler) followLogs(c *gin.Context, tailLines int) {
+
+	gatewayId := c.Param("gatewayId")
+
+	logStream, err := h.service.FollowLogs(c, gatewayId, tailLines)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer logStream.Close()
+
+	c.Header("Content-Type", "text/plain; charset=utf-8")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	if _, err := io.Copy(util.NewFlushWriter(c.Writer), logStream); err != nil {
+		// Headers have already been sent, so we can only log the error
+		klog.Errorf("error following logs for GatewayApplication '%s': %v", gatewayId, err)
+	}
+}
+
+// DownloadGatewayApplicationLogs godoc
+// @Summary Download complete driver logs of a GatewayApplication
+// @Description Streams the complete driver logs for the specified GatewayApplication as an attachment. Set gzip=true to compress the download.
+// @Tags Applications
+// @Produce plain
+// @Produce application/gzip
+// @Security BasicAuth
+// @Param gatewayId path string true "GatewayApplication Name"
+// @Param gzip query bool false "Compress the logs with gzip (default: false)"
+// @Success 200 {file} file "Driver logs"
+// @Router /v1/applications/{gatewayId}/logs/download [get]
+func (h *GatewayApplicationHandler) DownloadLogs(c *gin.Context) {
+
+	gatewayId := c.Param("gatewayId")
+
+	useGzip := false
+	if gzipQuery := c.Query("gzip"); gzipQuery != "" {
+		var err error
+		useGzip, err = strconv.ParseBool(gzipQuery)
+		if err != nil {
+			c.Error(gatewayerrors.NewBadRequest(fmt.Errorf("invalid gzip query parameter '%s': %w", gzipQuery, err)))
+			return
+		}
+	}
+
+	logStream, err := h.service.StreamLogs(c, gatewayId)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer logStream.Close()
+
+	filename := fmt.Sprintf("%s-driver.log", gatewayId)
+	var out io.Writer = c.Writer
+	if useGzip {
+		filename += ".gz"
+		c.Header("Content-Type", "application/gzip")
+		gzipWriter := gzip.NewWriter(c.Writer)
+		defer gzipWriter.Close()
+		out = gzipWriter
+	} else {
+		c.Header("Content-Type", "text/plain; charset=utf-8")
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	// No Content-Length is set so the response is chunked
+	if _, err := io.Copy(out, logStream); err != nil {
+		// Headers have already been sent, so we can only log the error
+		klog.Errorf("error streaming logs for GatewayApplication '%s': %v", gatewayId, err)
+	}
+}
+
+// GetGatewayApplicationDriverMetrics godoc
+// @Summary Get the Prometheus metrics of a GatewayApplication driver
+// @Description Returns the Prometheus metrics served by the driver of a running GatewayApplication, read through its cluster's SparkManager. The driver must expose them with spec.monitoring.exposeDriverMetrics and spec.monitoring.prometheus, or a Spark PrometheusServlet sink.
+// @Tags Applications
+// @Produce plain
+// @Security BasicAuth
+// @Param gatewayId path string true "GatewayApplication Name"
+// @Success 200 {string} string "Driver metrics in the Prometheus text format"
+// @Failure 404 {object} map[string]string "GatewayApplication not found, or its driver doesn't expose metrics"
+// @Failure 409 {object} map[string]string "GatewayApplication is not running"
+// @Failure 501 {object} map[string]string "The cluster's backend cannot reach driver Pods"
+// @Router /v1/applications/{gatewayId}/metrics [get]
+func (h *GatewayApplicationHandler) DriverMetrics(c *gin.Context) {
+
+	gatewayId := c.Param("gatewayId")
+
+	metrics, err := h.service.DriverMetrics(c, gatewayId)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer metrics.Close()
+
+	c.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	if _, err := io.Copy(c.Writer, metrics); err != nil {
+		// Headers have already been sent, so we can only log the error
+		klog.Errorf("error streaming driver metrics for GatewayApplication '%s': %v", gatewayId, err)
+	}
+}
+
+// GetGatewayApplicationPods godoc
+// @Summary List the pods of a GatewayApplication
+// @Description Lists the driver and executor pods of a GatewayApplication with their phases, the driver first and executors in order of their id. Pass an executor's id as executorId to the logs endpoint to read its logs.
+// @Tags Applications
+// @Produce json
+// @Security BasicAuth
+// @Param gatewayId path string true "GatewayApplication Name"
+// @Success 200 {object} domain.ApplicationPods "Driver and executor pods of the GatewayApplication"
+// @Failure 501 {object} map[string]string "The cluster's backend cannot list the pods of applications"
+// @Router /v1/applications/{gatewayId}/pods [get]
+func (h *GatewayApplicationHandler) Pods(c *gin.Context) {
+
+	pods, err := h.service.Pods(c, c.Param("gatewayId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pods)
+}
+
+// GetGatewayApplicationTimeline godoc
+// @Summary Get the lifecycle events of a GatewayApplication
+// @Description Returns the state transitions recorded for a GatewayApplication, such as it being submitted, running or failing with its error message, oldest first. Events are kept in the database for the eventRetention of the namespace, so they are available after the application's pods and Kubernetes events are gone.
+// @Tags Applications
+// @Produce json
+// @Security BasicAuth
+// @Param gatewayId path string true "GatewayApplication Name"
+// @Success 200 {object} domain.ApplicationTimeline "Lifecycle events of the GatewayApplication"
+// @Failure 404 {object} map[string]string "No events recorded for the GatewayApplication"
+// @Failure 501 {object} map[string]string "The database is disabled, so no events are recorded"
+// @Router /v1/applications/{gatewayId}/timeline [get]
+func (h *GatewayApplicationHandler) Timeline(c *gin.Context) {
+
+	timeline, err := h.service.Timeline(c, c.Param("gatewayId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, timeline)
+}
+
 // CreateGatewayApplication godoc
 // @Summary Submit a new GatewayApplication
-// @Description Submits the provided GatewayApplication to the given namespace.
+// @Description Submits the provided GatewayApplication to the given namespace. The body may instead be a v1 List of the SparkApplication and up to 10 small v1 ConfigMaps in its namespace, which are created with the SparkApplication as "<gatewayId>-<name>" and deleted with it.
 // @Tags Applications
 // @Accept json
 // @Produce json
 // @Security BasicAuth
 // @Param SparkApplication body v1beta2.SparkApplication true "v1beta2.SparkApplication resource"
-// @Success 201 {object} domain.GatewayApplication "GatewayApplication Created"
+// @Param fieldValidation query string false "How unknown and duplicate fields are handled: Ignore drops them, Warn returns a Warning header for each, Strict rejects the submission. Defaults to gateway.fieldValidation" Enums(Ignore, Warn, Strict)
+// @Param async query bool false "Queue the submission and return its GatewayId before it is created in its cluster, requires gateway.asyncSubmission"
+// @Success 201 {object} domain.GatewayApplication "GatewayApplication Created, with warnings if gateway.softQuota is enabled and the namespace is nearly out of ResourceQuota"
+// @Success 202 {object} domain.GatewayApplication "GatewayApplication queued with async=true, its status reports the submission"
+// @Failure 400 {object} map[string]string "Invalid SparkApplication or bundled ConfigMaps, or unknown fields with fieldValidation=Strict"
+// @Failure 422 {object} domain.ValidationError "SparkApplication failed validation, with the error message and the results of each failed check"
+// @Failure 501 {object} map[string]string "async=true without gateway.asyncSubmission enabled"
 // @Router /v1/applications/ [post]
 func (h *GatewayApplicationHandler) Create(c *gin.Context) {
 
+	async, err := strconv.ParseBool(c.DefaultQuery("async", "false"))
+	if err != nil {
+		c.Error(gatewayerrors.NewBadRequest(fmt.Errorf("invalid 'async' query parameter: %w", err)))
+		return
+	}
+	if async && !h.asyncSubmission {
+		c.Error(gatewayerrors.New(http.StatusNotImplemented, errors.New("asynchronous submissions are only accepted when gateway.asyncSubmission is enabled")))
+		return
+	}
+
+	app, user, ok := h.bindSubmission(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Context(c)
+	status := http.StatusCreated
+	if async {
+		ctx = domain.WithAsyncSubmission(ctx)
+		status = http.StatusAccepted
+	}
+
+	createdApp, err := h.service.Create(ctx, app, user)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(status, createdApp)
+}
+
+// RenderGatewayApplicationPods godoc
+// @Summary Render the pods of a GatewayApplication
+// @Description Returns the driver and first executor pods the submitted GatewayApplication would run, as created by spark-submit and mutated by the Spark Operator webhook in the cluster it is routed to, without submitting it. Accepts the same body as a submission. Cluster admission, e.g. LimitRange defaults or policy webhooks, isn't applied.
+// @Tags Applications
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param SparkApplication body v1beta2.SparkApplication true "v1beta2.SparkApplication resource"
+// @Param fieldValidation query string false "How unknown and duplicate fields are handled, as for submissions" Enums(Ignore, Warn, Strict)
+// @Success 200 {object} domain.RenderedPods "Rendered driver and executor pods"
+// @Failure 400 {object} map[string]string "Invalid SparkApplication"
+// @Failure 422 {object} domain.ValidationError "SparkApplication failed validation, with the error message and the results of each failed check"
+// @Failure 501 {object} map[string]string "The cluster's backend doesn't run SparkApplications through the Spark Operator"
+// @Router /v1/applications/render [post]
+func (h *GatewayApplicationHandler) RenderPods(c *gin.Context) {
+
+	app, user, ok := h.bindSubmission(c)
+	if !ok {
+		return
+	}
+
+	pods, err := h.service.RenderPods(c, app, user)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pods)
+}
+
+// bindSubmission binds the SparkApplication submitted in the request body, with the ConfigMaps bundled with it and
+// the acting user annotation and team label of the request, and returns it with the submitting user. The response has
+// been written if ok is false.
+func (h *GatewayApplicationHandler) bindSubmission(c *gin.Context) (*v1beta2.SparkApplication, string, bool) {
+
+	fieldValidation := c.DefaultQuery("fieldValidation", h.fieldValidation)
+	if fieldValidation != "" && !util.ValueExists(fieldValidation, config.ValidFieldValidations) {
+		c.Error(gatewayerrors.NewBadRequest(fmt.Errorf("invalid fieldValidation '%s', valid values: %s", fieldValidation, strings.Join(config.ValidFieldValidations, ", "))))
+		return nil, "", false
+	}
+
+	if c.Request.Body == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return nil, "", false
+	}
+	body, err := c.GetRawData()
+	if err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return nil, "", false
+	}
+
+	// Submissions may bundle ConfigMaps with the SparkApplication in a List
+	appJSON, configMaps, err := domain.ParseSubmission(body)
+	if err != nil {
+		c.Error(gatewayerrors.NewBadRequest(fmt.Errorf("invalid submission: %w", err)))
+		return nil, "", false
+	}
+
 	var app v1beta2.SparkApplication
 
-	if err := c.ShouldBindJSON(&app); err != nil {
+	if err := json.Unmarshal(appJSON, &app); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
+		return nil, "", false
+	}
+
+	if fieldValidation == config.FieldValidationWarn || fieldValidation == config.FieldValidationStrict {
+		if unknown := unknownFields(appJSON); len(unknown) > 0 {
+			if fieldValidation == config.FieldValidationStrict {
+				c.Error(gatewayerrors.NewBadRequest(fmt.Errorf("submitted SparkApplication has unknown or duplicate fields: %s", strings.Join(unknown, ", "))))
+				return nil, "", false
+			}
+			for _, field := range unknown {
+				c.Writer.Header().Add("Warning", fmt.Sprintf("299 - %q", field))
+			}
+		}
+	}
+
+	// Bundled ConfigMaps are only passed to SparkManager from a List, never a submitted annotation
+	if err := domain.SetAuxiliaryConfigMaps(&app, configMaps); err != nil {
+		c.Error(gatewayerrors.NewBadRequest(fmt.Errorf("invalid submission: %w", err)))
+		return nil, "", false
 	}
 
 	if app.Namespace == "" {
 		c.Error(gatewayerrors.NewBadRequest(errors.New("submitted SparkApplication must have a Namespace")))
-		return
+		return nil, "", false
+	}
+
+	user, ok := bindSubmitter(c, &app)
+	if !ok {
+		return nil, "", false
 	}
 
+	return &app, user, true
+}
+
+// bindSubmitter sets the acting user annotation and team label of the request on app and returns the submitting user.
+// The response has been written if ok is false.
+func bindSubmitter(c *gin.Context, app *v1beta2.SparkApplication) (string, bool) {
+
 	gotUser, exists := c.Get("user")
 	if !exists {
 		c.Error(errors.New("no user set, congratulations you've encountered a bug that should never happen"))
-		return
+		return "", false
 	}
 	user := gotUser.(string)
 
-	createdApp, err := h.service.Create(c, &app, user)
+	// The acting user annotation is only set from the trusted principal impersonating the user, never the submission
+	delete(app.Annotations, domain.GATEWAY_ACTING_USER_ANNOTATION)
+	if actingUser := c.GetString("actingUser"); actingUser != "" {
+		if app.Annotations == nil {
+			app.Annotations = map[string]string{}
+		}
+		app.Annotations[domain.GATEWAY_ACTING_USER_ANNOTATION] = actingUser
+	}
+
+	// The team label is only set from the team mapped to the authenticated principal, never the submission
+	delete(app.Labels, domain.GATEWAY_TEAM_LABEL)
+	if team := c.GetString("team"); team != "" {
+		if app.Labels == nil {
+			app.Labels = map[string]string{}
+		}
+		app.Labels[domain.GATEWAY_TEAM_LABEL] = team
+	}
 
+	return user, true
+}
+
+// unknownFields returns a message for each field of the SparkApplication in body that isn't in the v1beta2 schema or
+// is set more than once, e.g. `unknown field "spec.exectuor"`
+func unknownFields(body []byte) []string {
+	if len(body) == 0 {
+		return nil
+	}
+
+	var app v1beta2.SparkApplication
+	strictErrs, err := sigsjson.UnmarshalStrict(body, &app)
+	if err != nil {
+		// Malformed bodies have already been rejected by binding
+		return nil
+	}
+
+	var fields []string
+	for _, strictErr := range strictErrs {
+		fields = append(fields, strictErr.Error())
+	}
+	return fields
+}
+
+// ResubmitGatewayApplication godoc
+// @Summary Resubmit a GatewayApplication
+// @Description Submits the spec of an existing GatewayApplication again under a new GatewayId, as a new submission by the requesting user, e.g. to retry a failed batch job without keeping its spec. The status and the labels and annotations set by the Gateway are dropped and the resubmission is annotated with spark-gateway/resubmitted-from. The original GatewayApplication is left as is.
+// @Tags Applications
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param gatewayId path string true "GatewayApplication Name"
+// @Success 201 {object} domain.GatewayApplication "Resubmitted GatewayApplication"
+// @Failure 404 {object} map[string]string "GatewayApplication not found"
+// @Failure 422 {object} domain.ValidationError "SparkApplication failed validation, with the error message and the results of each failed check"
+// @Router /v1/applications/{gatewayId}/resubmit [post]
+func (h *GatewayApplicationHandler) Resubmit(c *gin.Context) {
+
+	app, err := h.service.Resubmission(c, c.Param("gatewayId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	user, ok := bindSubmitter(c, app)
+	if !ok {
+		return
+	}
+
+	createdApp, err := h.service.Create(c, app, user)
 	if err != nil {
 		c.Error(err)
 		return
@@ -192,11 +757,19 @@ func (h *GatewayApplicationHandler) Create(c *gin.Context) {
 // @Produce json
 // @Security BasicAuth
 // @Param gatewayId path string true "GatewayApplication Name"
+// @Param force query bool false "Delete the application even if it wasn't created by Spark Gateway. Admins only"
 // @Success 200 {object} map[string]string "Application deleted: {'status': 'success'}"
+// @Failure 403 {object} map[string]string "force=true from a non-admin"
+// @Failure 404 {object} map[string]string "GatewayApplication not found, or not created by Spark Gateway and force=true isn't set"
 // @Router /v1/applications/{gatewayId} [delete]
 func (h *GatewayApplicationHandler) Delete(c *gin.Context) {
 
-	err := h.service.Delete(c, c.Param("gatewayId"))
+	ctx, ok := h.selectorOverride(c)
+	if !ok {
+		return
+	}
+
+	err := h.service.Delete(ctx, c.Param("gatewayId"))
 
 	if err != nil {
 		c.Error(err)
@@ -205,3 +778,101 @@ func (h *GatewayApplicationHandler) Delete(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"status": "success"})
 }
+
+// UpdateGatewayApplication godoc
+// @Summary Update a GatewayApplication that hasn't started running
+// @Description Merges labels into, and sets spec.timeToLiveSeconds or spec.dynamicAllocation.maxExecutors of, a GatewayApplication whose driver hasn't started running yet. Applications that are already running are rejected with 409, and maxExecutors can only be set on applications with dynamic allocation enabled. The Gateway's own spark-gateway/ labels can't be changed.
+// @Tags Applications
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param gatewayId path string true "GatewayApplication Name"
+// @Param ApplicationUpdate body domain.ApplicationUpdate true "Fields to update"
+// @Param force query bool false "Update the application even if it wasn't created by Spark Gateway. Admins only"
+// @Success 200 {object} domain.GatewayApplication "Updated GatewayApplication"
+// @Failure 403 {object} map[string]string "force=true from a non-admin"
+// @Failure 404 {object} map[string]string "GatewayApplication not found, or not created by Spark Gateway and force=true isn't set"
+// @Router /v1/applications/{gatewayId} [patch]
+func (h *GatewayApplicationHandler) Update(c *gin.Context) {
+
+	ctx, ok := h.selectorOverride(c)
+	if !ok {
+		return
+	}
+
+	var update domain.ApplicationUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	updatedApp, err := h.service.Update(ctx, c.Param("gatewayId"), update)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedApp)
+}
+
+// ScaleGatewayApplication godoc
+// @Summary Scale the executors of a running GatewayApplication
+// @Description Sets spec.executor.instances, or spec.dynamicAllocation.maxExecutors for applications with dynamic allocation enabled, of a running GatewayApplication. Scaling up is rejected with 403 if the additional executors don't fit in the namespace's ResourceQuotas, and with 409 if the application isn't running.
+// @Tags Applications
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param gatewayId path string true "GatewayApplication Name"
+// @Param ExecutorScale body domain.ExecutorScale true "Executor count to scale to"
+// @Param force query bool false "Scale the application even if it wasn't created by Spark Gateway. Admins only"
+// @Success 200 {object} domain.GatewayApplication "Scaled GatewayApplication"
+// @Failure 403 {object} map[string]string "force=true from a non-admin"
+// @Failure 404 {object} map[string]string "GatewayApplication not found, or not created by Spark Gateway and force=true isn't set"
+// @Router /v1/applications/{gatewayId}/scale [post]
+func (h *GatewayApplicationHandler) Scale(c *gin.Context) {
+
+	ctx, ok := h.selectorOverride(c)
+	if !ok {
+		return
+	}
+
+	var scale domain.ExecutorScale
+	if err := c.ShouldBindJSON(&scale); err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	scaledApp, err := h.service.Scale(ctx, c.Param("gatewayId"), scale)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, scaledApp)
+}
+
+// selectorOverride returns the context to delete or change a GatewayApplication with. If the request sets force=true it
+// carries the selector override to SparkManager, which then also deletes and changes SparkApplications that weren't
+// created by Spark Gateway. Only adminUsers, and admins when roles are enforced, can set it. ok is false if the request
+// was rejected.
+func (h *GatewayApplicationHandler) selectorOverride(c *gin.Context) (ctx context.Context, ok bool) {
+	force, err := strconv.ParseBool(c.DefaultQuery("force", "false"))
+	if err != nil {
+		c.Error(gatewayerrors.NewBadRequest(fmt.Errorf("invalid 'force' query parameter: %w", err)))
+		return nil, false
+	}
+	if !force {
+		return c, true
+	}
+
+	user := c.GetString("user")
+	if !slices.Contains(h.adminUsers, user) && c.GetString("role") != string(domain.RoleAdmin) {
+		c.Error(gatewayerrors.NewForbidden(fmt.Errorf("user %s is not an admin and can't set force=true", user)))
+		return nil, false
+	}
+
+	klog.Infof("User %s is overriding the selector label check of %s %s", user, c.Request.Method, c.Request.URL.Path)
+	return domain.WithSelectorOverride(c), true
+}
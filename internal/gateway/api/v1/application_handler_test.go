@@ -17,13 +17,17 @@ package v1
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
@@ -32,7 +36,9 @@ import (
 	"github.com/slackhq/spark-gateway/internal/shared/config"
 	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
 	sgMiddleware "github.com/slackhq/spark-gateway/internal/shared/middleware"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var testConfig = &config.SparkGatewayConfig{DefaultLogLines: 100}
@@ -118,7 +124,7 @@ func TestApplicationHandlerGet(t *testing.T) {
 	}
 
 	router, v1Group := NewV1Router()
-	RegisterGatewayApplicationRoutes(v1Group, testConfig, service)
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
 
 	req, _ := http.NewRequest("GET", "/api/v1/applications/clusterid-testid", nil)
 	w := httptest.NewRecorder()
@@ -131,6 +137,87 @@ func TestApplicationHandlerGet(t *testing.T) {
 	assert.Equal(t, gotApp, *retApp, "returned JSON should match")
 }
 
+func TestApplicationHandlerSummary(t *testing.T) {
+
+	retCounts := []*domain.ApplicationCount{{Namespace: "test", State: "RUNNING", Count: 2}}
+
+	service := &service.GatewayApplicationServiceMock{
+		CountsFunc: func(ctx context.Context, cluster string, namespace string, groupBy []domain.CountGroupBy) ([]*domain.ApplicationCount, error) {
+			return retCounts, nil
+		},
+	}
+
+	router, v1Group := NewV1Router()
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	req, _ := http.NewRequest("GET", "/api/v1/applications/summary?groupBy=namespace,state", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var gotCounts []*domain.ApplicationCount
+	json.Unmarshal(w.Body.Bytes(), &gotCounts)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, retCounts, gotCounts, "returned JSON should match")
+	assert.Equal(t, []domain.CountGroupBy{domain.CountGroupByNamespace, domain.CountGroupByState}, service.CountsCalls()[0].GroupBy, "groupBy should be passed to service")
+
+	req, _ = http.NewRequest("GET", "/api/v1/applications/summary?groupBy=user", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	responseData, _ := io.ReadAll(w.Body)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "codes should match")
+	assert.Equal(t, `{"error":"invalid groupBy 'user', valid values: [cluster namespace state]"}`, string(responseData), "errors should match")
+}
+
+func TestApplicationHandlerWatch(t *testing.T) {
+
+	bookmark := domain.WatchBookmark{domain.WatchStreamKey("cluster", "test"): "42"}
+	retEvents := []*domain.GatewayWatchEvent{
+		{Type: domain.WatchEventAdded, Cluster: "cluster", Namespace: "test", Bookmark: "a"},
+		{Type: domain.WatchEventBookmark, Cluster: "cluster", Namespace: "test", Bookmark: "b"},
+	}
+
+	service := &service.GatewayApplicationServiceMock{
+		WatchFunc: func(ctx context.Context, namespace string, labelSelector string, bookmark domain.WatchBookmark) (<-chan *domain.GatewayWatchEvent, error) {
+			events := make(chan *domain.GatewayWatchEvent, len(retEvents))
+			for _, event := range retEvents {
+				events <- event
+			}
+			close(events)
+			return events, nil
+		},
+	}
+
+	router, v1Group := NewV1Router()
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	req, _ := http.NewRequest("GET", "/api/v1/applications/watch?namespace=test&labelSelector=app%3Dtest&resourceVersion="+bookmark.Encode(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"), "content type should match")
+
+	decoder := json.NewDecoder(w.Body)
+	for _, expected := range retEvents {
+		var got domain.GatewayWatchEvent
+		assert.NoError(t, decoder.Decode(&got), "event should be json")
+		assert.Equal(t, *expected, got, "events should match")
+	}
+
+	watchCall := service.WatchCalls()[0]
+	assert.Equal(t, "test", watchCall.Namespace, "namespace should be passed to service")
+	assert.Equal(t, "app=test", watchCall.LabelSelector, "labelSelector should be passed to service")
+	assert.Equal(t, bookmark, watchCall.Bookmark, "bookmark should be passed to service")
+
+	req, _ = http.NewRequest("GET", "/api/v1/applications/watch?resourceVersion=not-a-bookmark", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "codes should match")
+}
+
 func TestApplicationHandlerGetError(t *testing.T) {
 
 	service := &service.GatewayApplicationServiceMock{
@@ -140,7 +227,7 @@ func TestApplicationHandlerGetError(t *testing.T) {
 	}
 
 	router, v1Group := NewV1Router()
-	RegisterGatewayApplicationRoutes(v1Group, testConfig, service)
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
 
 	req, _ := http.NewRequest("GET", "/api/v1/applications/clusterid-testid", nil)
 	w := httptest.NewRecorder()
@@ -157,24 +244,28 @@ func TestApplicationHandlerGetError(t *testing.T) {
 }
 func TestApplicationHandlerStatus(t *testing.T) {
 
-	retResp := &v1beta2.SparkApplicationStatus{
-		SubmissionID: "submissionId",
+	queued := int64(5)
+	retResp := &domain.ApplicationStatus{
+		SparkApplicationStatus: v1beta2.SparkApplicationStatus{
+			SubmissionID: "submissionId",
+		},
+		Timings: &domain.ApplicationTimings{QueuedSeconds: &queued},
 	}
 
 	service := &service.GatewayApplicationServiceMock{
-		StatusFunc: func(ctx context.Context, gatewayId string) (*v1beta2.SparkApplicationStatus, error) {
+		StatusFunc: func(ctx context.Context, gatewayId string) (*domain.ApplicationStatus, error) {
 			return retResp, nil
 		},
 	}
 
 	router, v1Group := NewV1Router()
-	RegisterGatewayApplicationRoutes(v1Group, testConfig, service)
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
 
 	req, _ := http.NewRequest("GET", "/api/v1/applications/clusterid-testid/status", nil)
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
-	var gotStatus v1beta2.SparkApplicationStatus
+	var gotStatus domain.ApplicationStatus
 	json.Unmarshal(w.Body.Bytes(), &gotStatus)
 
 	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
@@ -183,13 +274,13 @@ func TestApplicationHandlerStatus(t *testing.T) {
 func TestApplicationHandlerStatusError(t *testing.T) {
 
 	service := &service.GatewayApplicationServiceMock{
-		StatusFunc: func(ctx context.Context, gatewayId string) (*v1beta2.SparkApplicationStatus, error) {
-			return &v1beta2.SparkApplicationStatus{}, gatewayerrors.NewNotFound(errors.New("error getting SparkApplication 'clusterid-testid'"))
+		StatusFunc: func(ctx context.Context, gatewayId string) (*domain.ApplicationStatus, error) {
+			return &domain.ApplicationStatus{}, gatewayerrors.NewNotFound(errors.New("error getting SparkApplication 'clusterid-testid'"))
 		},
 	}
 
 	router, v1Group := NewV1Router()
-	RegisterGatewayApplicationRoutes(v1Group, testConfig, service)
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
 
 	req, _ := http.NewRequest("GET", "/api/v1/applications/clusterid-testid/status", nil)
 	w := httptest.NewRecorder()
@@ -202,6 +293,97 @@ func TestApplicationHandlerStatusError(t *testing.T) {
 	assert.Equal(t, resp, string(responseData), "errors should match")
 }
 
+func TestApplicationHandlerWaitStatus(t *testing.T) {
+
+	retResp := &domain.GatewayApplicationWaitStatus{
+		State:          v1beta2.ApplicationStateFailed,
+		GatewayState:   domain.GatewayStateFailed,
+		Terminal:       true,
+		FailureMessage: "driver OOMKilled",
+	}
+
+	var gotLastState v1beta2.ApplicationStateType
+	var gotTimeout time.Duration
+	service := &service.GatewayApplicationServiceMock{
+		WaitStatusFunc: func(ctx context.Context, gatewayId string, lastState v1beta2.ApplicationStateType, timeout time.Duration) (*domain.GatewayApplicationWaitStatus, error) {
+			gotLastState = lastState
+			gotTimeout = timeout
+			return retResp, nil
+		},
+	}
+
+	router, v1Group := NewV1Router()
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	req, _ := http.NewRequest("GET", "/api/v1/applications/clusterid-testid/wait?state=RUNNING&timeout=30s", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.JSONEq(t, `{"state":"FAILED","gatewayState":"FAILED","terminal":true,"failureMessage":"driver OOMKilled"}`, w.Body.String(), "returned JSON should match")
+	assert.Equal(t, v1beta2.ApplicationStateRunning, gotLastState, "state query parameter should be passed to the service")
+	assert.Equal(t, 30*time.Second, gotTimeout, "timeout query parameter should be passed to the service")
+}
+
+func TestApplicationHandlerWaitStatusBadTimeout(t *testing.T) {
+
+	router, v1Group := NewV1Router()
+	routes.Register(v1Group, ApplicationRoutes(testConfig, &service.GatewayApplicationServiceMock{}))
+
+	req, _ := http.NewRequest("GET", "/api/v1/applications/clusterid-testid/wait?timeout=soon", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "codes should match")
+	assert.Contains(t, w.Body.String(), "invalid 'timeout' query parameter", "error should match")
+}
+
+func TestApplicationHandlerStreamStatus(t *testing.T) {
+
+	service := &service.GatewayApplicationServiceMock{
+		StreamStatusFunc: func(ctx context.Context, gatewayId string) (<-chan *domain.ApplicationStatus, error) {
+			statuses := make(chan *domain.ApplicationStatus, 2)
+			statuses <- &domain.ApplicationStatus{GatewayState: domain.GatewayStateRunning}
+			statuses <- &domain.ApplicationStatus{GatewayState: domain.GatewayStateSucceeded}
+			close(statuses)
+			return statuses, nil
+		},
+	}
+
+	router, v1Group := NewV1Router()
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	req, _ := http.NewRequest("GET", "/api/v1/applications/clusterid-testid/status/stream", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"), "content type should match")
+
+	events := strings.Split(strings.TrimSpace(w.Body.String()), "\n\n")
+	assert.Len(t, events, 2, "each status should be an event")
+	assert.True(t, strings.HasPrefix(events[1], "event: status\ndata: {"), "events should be named status and carry JSON")
+	assert.Contains(t, events[1], `"gatewayState":"SUCCEEDED"`, "statuses should be streamed in order")
+}
+
+func TestApplicationHandlerStreamStatusError(t *testing.T) {
+
+	service := &service.GatewayApplicationServiceMock{
+		StreamStatusFunc: func(ctx context.Context, gatewayId string) (<-chan *domain.ApplicationStatus, error) {
+			return nil, gatewayerrors.NewNotFound(errors.New("error getting SparkApplication 'clusterid-testid'"))
+		},
+	}
+
+	router, v1Group := NewV1Router()
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	req, _ := http.NewRequest("GET", "/api/v1/applications/clusterid-testid/status/stream", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code, "errors before the stream starts should be returned as usual")
+}
+
 func TestApplicationHandlerCreate(t *testing.T) {
 	router, v1Group := NewV1Router()
 
@@ -227,7 +409,7 @@ func TestApplicationHandlerCreate(t *testing.T) {
 		},
 	}
 
-	RegisterGatewayApplicationRoutes(v1Group, testConfig, service)
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
 
 	createReq := domain.GatewaySparkApplication{
 		GatewayApplicationMeta: domain.GatewayApplicationMeta{
@@ -247,6 +429,302 @@ func TestApplicationHandlerCreate(t *testing.T) {
 	assert.Equal(t, http.StatusCreated, w.Code, "codes should match")
 	assert.Equal(t, gotApp, *retApp, "returned JSON should match")
 }
+
+func TestApplicationHandlerCreateAsync(t *testing.T) {
+	tests := []struct {
+		name      string
+		enabled   bool
+		query     string
+		wantCode  int
+		wantAsync bool
+	}{
+		{name: "synchronous", enabled: true, query: "", wantCode: http.StatusCreated},
+		{name: "async", enabled: true, query: "?async=true", wantCode: http.StatusAccepted, wantAsync: true},
+		{name: "async disabled", query: "?async=true", wantCode: http.StatusNotImplemented},
+		{name: "invalid async", enabled: true, query: "?async=maybe", wantCode: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, v1Group := NewV1Router()
+
+			v1Group.Use(func(ctx *gin.Context) {
+				ctx.Set("user", "user")
+				ctx.Next()
+			})
+
+			var gotAsync bool
+			service := &service.GatewayApplicationServiceMock{
+				CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error) {
+					gotAsync = domain.IsAsyncSubmission(ctx)
+					return &domain.GatewayApplication{GatewayState: domain.GatewayStatePending}, nil
+				},
+			}
+
+			sgConf := &config.SparkGatewayConfig{GatewayConfig: config.GatewayConfig{AsyncSubmission: config.AsyncSubmissionConfig{Enable: test.enabled}}}
+			routes.Register(v1Group, ApplicationRoutes(sgConf, service))
+
+			jsonReq, _ := json.Marshal(domain.GatewaySparkApplication{GatewayApplicationMeta: domain.GatewayApplicationMeta{Name: "app", Namespace: "test"}})
+			req, _ := http.NewRequest("POST", "/api/v1/applications"+test.query, bytes.NewBuffer(jsonReq))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.wantCode, w.Code, "codes should match")
+			assert.Equal(t, test.wantAsync, gotAsync, "async submissions should be passed in the context")
+		})
+	}
+}
+
+func TestApplicationHandlerCreateActingUser(t *testing.T) {
+	router, v1Group := NewV1Router()
+
+	v1Group.Use(func(ctx *gin.Context) {
+		ctx.Set("user", "alice")
+		ctx.Set("actingUser", "airflow")
+		ctx.Next()
+	})
+
+	var gotAnnotations map[string]string
+	var gotUser string
+	service := &service.GatewayApplicationServiceMock{
+		CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error) {
+			gotAnnotations = application.Annotations
+			gotUser = user
+			return &domain.GatewayApplication{}, nil
+		},
+	}
+
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	createReq := domain.GatewaySparkApplication{
+		GatewayApplicationMeta: domain.GatewayApplicationMeta{
+			Name:      "clusterid-testid",
+			Namespace: "test",
+		},
+	}
+
+	jsonReq, _ := json.Marshal(createReq)
+	req, _ := http.NewRequest("POST", "/api/v1/applications", bytes.NewBuffer(jsonReq))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code, "codes should match")
+	assert.Equal(t, "alice", gotUser, "effective user should be passed to service")
+	assert.Equal(t, "airflow", gotAnnotations[domain.GATEWAY_ACTING_USER_ANNOTATION], "acting user annotation should be set")
+}
+
+func TestApplicationHandlerCreateSpoofedActingUser(t *testing.T) {
+	router, v1Group := NewV1Router()
+
+	v1Group.Use(func(ctx *gin.Context) {
+		ctx.Set("user", "alice")
+		ctx.Next()
+	})
+
+	var gotAnnotations map[string]string
+	service := &service.GatewayApplicationServiceMock{
+		CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error) {
+			gotAnnotations = application.Annotations
+			return &domain.GatewayApplication{}, nil
+		},
+	}
+
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	createReq := domain.GatewaySparkApplication{
+		GatewayApplicationMeta: domain.GatewayApplicationMeta{
+			Name:        "clusterid-testid",
+			Namespace:   "test",
+			Annotations: map[string]string{domain.GATEWAY_ACTING_USER_ANNOTATION: "airflow"},
+		},
+	}
+
+	jsonReq, _ := json.Marshal(createReq)
+	req, _ := http.NewRequest("POST", "/api/v1/applications", bytes.NewBuffer(jsonReq))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code, "codes should match")
+	assert.NotContains(t, gotAnnotations, domain.GATEWAY_ACTING_USER_ANNOTATION, "acting user annotation should not be taken from the submission")
+}
+
+func TestApplicationHandlerCreateTeam(t *testing.T) {
+	router, v1Group := NewV1Router()
+
+	v1Group.Use(func(ctx *gin.Context) {
+		ctx.Set("user", "data-eng")
+		ctx.Set("team", "data")
+		ctx.Next()
+	})
+
+	var gotLabels map[string]string
+	service := &service.GatewayApplicationServiceMock{
+		CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error) {
+			gotLabels = application.Labels
+			return &domain.GatewayApplication{}, nil
+		},
+	}
+
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	createReq := domain.GatewaySparkApplication{
+		GatewayApplicationMeta: domain.GatewayApplicationMeta{
+			Name:      "clusterid-testid",
+			Namespace: "test",
+			Labels:    map[string]string{domain.GATEWAY_TEAM_LABEL: "spoofed"},
+		},
+	}
+
+	jsonReq, _ := json.Marshal(createReq)
+	req, _ := http.NewRequest("POST", "/api/v1/applications", bytes.NewBuffer(jsonReq))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code, "codes should match")
+	assert.Equal(t, "data", gotLabels[domain.GATEWAY_TEAM_LABEL], "team label should be set from the context")
+}
+
+func TestApplicationHandlerCreateFieldValidation(t *testing.T) {
+	testCases := []struct {
+		name             string
+		fieldValidation  string
+		query            string
+		body             string
+		expectedStatus   int
+		expectedWarnings []string
+	}{
+		{
+			name:            "unknown fields are ignored by default",
+			fieldValidation: config.FieldValidationIgnore,
+			body:            `{"metadata":{"namespace":"test"},"spec":{"exectuor":{"instances":10}}}`,
+			expectedStatus:  http.StatusCreated,
+		},
+		{
+			name:             "unknown fields are warned about",
+			fieldValidation:  config.FieldValidationWarn,
+			body:             `{"metadata":{"namespace":"test"},"spec":{"exectuor":{"instances":10},"driver":{"cors":1}}}`,
+			expectedStatus:   http.StatusCreated,
+			expectedWarnings: []string{`299 - "unknown field \"spec.driver.cors\""`, `299 - "unknown field \"spec.exectuor\""`},
+		},
+		{
+			name:            "unknown fields are rejected in strict mode",
+			fieldValidation: config.FieldValidationStrict,
+			body:            `{"metadata":{"namespace":"test"},"spec":{"exectuor":{"instances":10}}}`,
+			expectedStatus:  http.StatusBadRequest,
+		},
+		{
+			name:            "duplicate fields are rejected in strict mode",
+			fieldValidation: config.FieldValidationStrict,
+			body:            `{"metadata":{"namespace":"test"},"spec":{"mode":"cluster","mode":"client"}}`,
+			expectedStatus:  http.StatusBadRequest,
+		},
+		{
+			name:            "known fields are accepted in strict mode",
+			fieldValidation: config.FieldValidationStrict,
+			body:            `{"metadata":{"namespace":"test"},"spec":{"executor":{"instances":10}}}`,
+			expectedStatus:  http.StatusCreated,
+		},
+		{
+			name:            "request overrides configured mode",
+			fieldValidation: config.FieldValidationIgnore,
+			query:           "?fieldValidation=Strict",
+			body:            `{"metadata":{"namespace":"test"},"spec":{"exectuor":{"instances":10}}}`,
+			expectedStatus:  http.StatusBadRequest,
+		},
+		{
+			name:            "invalid requested mode",
+			fieldValidation: config.FieldValidationIgnore,
+			query:           "?fieldValidation=strict",
+			body:            `{"metadata":{"namespace":"test"}}`,
+			expectedStatus:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router, v1Group := NewV1Router()
+			v1Group.Use(func(ctx *gin.Context) {
+				ctx.Set("user", "user")
+				ctx.Next()
+			})
+
+			service := &service.GatewayApplicationServiceMock{
+				CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error) {
+					return &domain.GatewayApplication{}, nil
+				},
+			}
+			sgConf := &config.SparkGatewayConfig{GatewayConfig: config.GatewayConfig{FieldValidation: tc.fieldValidation}}
+			routes.Register(v1Group, ApplicationRoutes(sgConf, service))
+
+			req, _ := http.NewRequest("POST", "/api/v1/applications"+tc.query, bytes.NewBufferString(tc.body))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code, "codes should match")
+			assert.ElementsMatch(t, tc.expectedWarnings, w.Header().Values("Warning"), "warnings should match")
+			if tc.expectedStatus == http.StatusBadRequest {
+				assert.Empty(t, service.CreateCalls(), "rejected submissions should not be created")
+			}
+		})
+	}
+}
+
+func TestApplicationHandlerCreateBundle(t *testing.T) {
+	testCases := []struct {
+		name                string
+		body                string
+		expectedStatus      int
+		expectedAnnotations map[string]string
+	}{
+		{
+			name:                "List bundles ConfigMaps",
+			body:                `{"kind":"List","items":[{"kind":"SparkApplication","metadata":{"namespace":"test"}},{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"log4j"},"data":{"log4j.properties":"rootLogger.level=info"}}]}`,
+			expectedStatus:      http.StatusCreated,
+			expectedAnnotations: map[string]string{domain.GATEWAY_CONFIGMAPS_ANNOTATION: `[{"name":"log4j","data":{"log4j.properties":"rootLogger.level=info"}}]`},
+		},
+		{
+			name:                "annotation set by the user is dropped",
+			body:                `{"metadata":{"namespace":"test","annotations":{"spark-gateway/configmaps":"[{\"name\":\"log4j\"}]"}}}`,
+			expectedStatus:      http.StatusCreated,
+			expectedAnnotations: map[string]string{},
+		},
+		{
+			name:           "invalid ConfigMap is rejected",
+			body:           `{"kind":"List","items":[{"kind":"SparkApplication","metadata":{"namespace":"test"}},{"kind":"ConfigMap","metadata":{"name":"Log4j"}}]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router, v1Group := NewV1Router()
+			v1Group.Use(func(ctx *gin.Context) {
+				ctx.Set("user", "user")
+				ctx.Next()
+			})
+
+			service := &service.GatewayApplicationServiceMock{
+				CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error) {
+					return &domain.GatewayApplication{}, nil
+				},
+			}
+			routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+			req, _ := http.NewRequest("POST", "/api/v1/applications", bytes.NewBufferString(tc.body))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code, "codes should match")
+			if tc.expectedStatus != http.StatusCreated {
+				assert.Empty(t, service.CreateCalls(), "rejected submissions should not be created")
+				return
+			}
+			assert.Len(t, service.CreateCalls(), 1, "submission should be created")
+			assert.Equal(t, tc.expectedAnnotations, service.CreateCalls()[0].Application.Annotations, "annotations should match")
+		})
+	}
+}
+
 func TestApplicationHandlerCreateBadRequest(t *testing.T) {
 
 	service := &service.GatewayApplicationServiceMock{
@@ -256,7 +734,7 @@ func TestApplicationHandlerCreateBadRequest(t *testing.T) {
 	}
 
 	router, v1Group := NewV1Router()
-	RegisterGatewayApplicationRoutes(v1Group, testConfig, service)
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
 
 	req, _ := http.NewRequest("POST", "/api/v1/applications", nil)
 	w := httptest.NewRecorder()
@@ -283,7 +761,7 @@ func TestApplicationHandlerCreateAlreadyExists(t *testing.T) {
 		},
 	}
 
-	RegisterGatewayApplicationRoutes(v1Group, testConfig, service)
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
 
 	createReq := domain.GatewaySparkApplication{
 		GatewayApplicationMeta: domain.GatewayApplicationMeta{
@@ -304,6 +782,250 @@ func TestApplicationHandlerCreateAlreadyExists(t *testing.T) {
 	assert.Equal(t, resp, string(responseData), "errors should match")
 }
 
+func TestApplicationHandlerCreateInvalid(t *testing.T) {
+	router, v1Group := NewV1Router()
+
+	v1Group.Use(func(ctx *gin.Context) {
+		ctx.Set("user", "user")
+		ctx.Next()
+	})
+
+	service := &service.GatewayApplicationServiceMock{
+		CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error) {
+			return nil, gatewayerrors.NewInvalid(fmt.Errorf("invalid GatewayApplication names: %w", domain.NewValidationError(domain.ValidateApplicationNames(application))))
+		},
+	}
+
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	req, _ := http.NewRequest("POST", "/api/v1/applications", bytes.NewBufferString(`{"metadata":{"namespace":"test"},"spec":{"driver":{"podName":"My_Driver"}}}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	resp := `{"error":"invalid GatewayApplication names: spec.driver.podName 'My_Driver' must consist of lowercase alphanumeric characters or '-', and start and end with an alphanumeric character, use 'my-driver' instead","validation":[{"field":"spec.driver.podName","rule":"dnsLabel","message":"spec.driver.podName 'My_Driver' must consist of lowercase alphanumeric characters or '-', and start and end with an alphanumeric character, use 'my-driver' instead","severity":"error"}]}`
+
+	responseData, _ := io.ReadAll(w.Body)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code, "codes should match")
+	assert.Equal(t, resp, string(responseData), "validation results should be returned")
+}
+
+func TestApplicationHandlerDownloadLogs(t *testing.T) {
+
+	logs := "line 1\nline 2\n"
+	service := &service.GatewayApplicationServiceMock{
+		StreamLogsFunc: func(ctx context.Context, gatewayId string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(logs)), nil
+		},
+	}
+
+	router, v1Group := NewV1Router()
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	req, _ := http.NewRequest("GET", "/api/v1/applications/clusterid-testid/logs/download", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"), "content type should match")
+	assert.Equal(t, `attachment; filename="clusterid-testid-driver.log"`, w.Header().Get("Content-Disposition"), "content disposition should match")
+	assert.Equal(t, logs, w.Body.String(), "logs should match")
+
+	req, _ = http.NewRequest("GET", "/api/v1/applications/clusterid-testid/logs/download?gzip=true", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, "application/gzip", w.Header().Get("Content-Type"), "content type should match")
+	assert.Equal(t, `attachment; filename="clusterid-testid-driver.log.gz"`, w.Header().Get("Content-Disposition"), "content disposition should match")
+
+	gzipReader, err := gzip.NewReader(w.Body)
+	assert.Nil(t, err, "body should be gzipped")
+	gotLogs, _ := io.ReadAll(gzipReader)
+	assert.Equal(t, logs, string(gotLogs), "decompressed logs should match")
+}
+
+func TestApplicationHandlerFollowLogs(t *testing.T) {
+
+	logs := "line 1\nline 2\n"
+	var gotTailLines int
+	service := &service.GatewayApplicationServiceMock{
+		FollowLogsFunc: func(ctx context.Context, gatewayId string, tailLines int) (io.ReadCloser, error) {
+			gotTailLines = tailLines
+			return io.NopCloser(strings.NewReader(logs)), nil
+		},
+	}
+
+	router, v1Group := NewV1Router()
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	req, _ := http.NewRequest("GET", "/api/v1/applications/clusterid-testid/logs?follow=true&lines=10", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"), "followed logs should be plain text")
+	assert.Equal(t, logs, w.Body.String(), "logs should match")
+	assert.Equal(t, 10, gotTailLines, "lines should be passed to the service")
+
+	req, _ = http.NewRequest("GET", "/api/v1/applications/clusterid-testid/logs?follow=maybe", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "invalid follow values should be rejected")
+}
+
+func TestApplicationHandlerExecutorLogs(t *testing.T) {
+
+	logs := "executor line"
+	service := &service.GatewayApplicationServiceMock{
+		ExecutorLogsFunc: func(ctx context.Context, gatewayId string, executorId string, tailLines int) (*string, error) {
+			return &logs, nil
+		},
+	}
+
+	router, v1Group := NewV1Router()
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	req, _ := http.NewRequest("GET", "/api/v1/applications/clusterid-testid/logs?pod=executor&executorId=3&lines=10", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, `"executor line"`, w.Body.String(), "logs should match")
+	assert.Equal(t, "3", service.ExecutorLogsCalls()[0].ExecutorId, "executorId should be passed to the service")
+	assert.Equal(t, 10, service.ExecutorLogsCalls()[0].TailLines, "lines should be passed to the service")
+
+	for _, query := range []string{"pod=executor", "pod=executor&executorId=-1", "pod=driver&executorId=3", "pod=executor&executorId=3&follow=true"} {
+		req, _ = http.NewRequest("GET", "/api/v1/applications/clusterid-testid/logs?"+query, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, "'%s' should be rejected", query)
+	}
+	assert.Len(t, service.ExecutorLogsCalls(), 1, "service should not be called for bad requests")
+}
+
+func TestApplicationHandlerPods(t *testing.T) {
+
+	pods := &domain.ApplicationPods{
+		GatewayId: "clusterid-testid",
+		Pods: []*domain.SparkApplicationPod{
+			{Name: "clusterid-testid-driver", Role: "driver", Phase: "Running"},
+			{Name: "clusterid-testid-exec-1", Role: "executor", ExecutorId: "1", Phase: "Running"},
+		},
+	}
+	service := &service.GatewayApplicationServiceMock{
+		PodsFunc: func(ctx context.Context, gatewayId string) (*domain.ApplicationPods, error) {
+			return pods, nil
+		},
+	}
+
+	router, v1Group := NewV1Router()
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	req, _ := http.NewRequest("GET", "/api/v1/applications/clusterid-testid/pods", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var gotPods domain.ApplicationPods
+	json.Unmarshal(w.Body.Bytes(), &gotPods)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, pods, &gotPods, "pods should match")
+}
+
+func TestApplicationHandlerDownloadLogsError(t *testing.T) {
+
+	service := &service.GatewayApplicationServiceMock{
+		StreamLogsFunc: func(ctx context.Context, gatewayId string) (io.ReadCloser, error) {
+			return nil, gatewayerrors.NewNotFound(errors.New("error getting SparkApplication 'clusterid-testid'"))
+		},
+	}
+
+	router, v1Group := NewV1Router()
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	req, _ := http.NewRequest("GET", "/api/v1/applications/clusterid-testid/logs/download", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code, "codes should match")
+	assert.Equal(t, `{"error":"error getting SparkApplication 'clusterid-testid'"}`, w.Body.String(), "errors should match")
+
+	req, _ = http.NewRequest("GET", "/api/v1/applications/clusterid-testid/logs/download?gzip=maybe", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "invalid gzip param should be a bad request")
+	assert.Len(t, service.StreamLogsCalls(), 1, "service should not be called for bad requests")
+}
+
+func TestApplicationHandlerDriverMetrics(t *testing.T) {
+
+	metrics := "# TYPE jvm_memory_bytes_used gauge\njvm_memory_bytes_used{area=\"heap\"} 1.2e+08\n"
+	service := &service.GatewayApplicationServiceMock{
+		DriverMetricsFunc: func(ctx context.Context, gatewayId string) (io.ReadCloser, error) {
+			if gatewayId != "clusterid-testid" {
+				return nil, gatewayerrors.NewConflict(errors.New("SparkApplication is in state 'COMPLETED'"))
+			}
+			return io.NopCloser(strings.NewReader(metrics)), nil
+		},
+	}
+
+	router, v1Group := NewV1Router()
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	req, _ := http.NewRequest("GET", "/api/v1/applications/clusterid-testid/metrics", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", w.Header().Get("Content-Type"), "content type should be the Prometheus text format")
+	assert.Equal(t, metrics, w.Body.String(), "metrics should match")
+
+	req, _ = http.NewRequest("GET", "/api/v1/applications/clusterid-completed/metrics", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code, "errors should be mapped to their status")
+	assert.Equal(t, `{"error":"SparkApplication is in state 'COMPLETED'"}`, w.Body.String(), "errors should match")
+}
+
+func TestApplicationHandlerTimeline(t *testing.T) {
+
+	submitted := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	service := &service.GatewayApplicationServiceMock{
+		TimelineFunc: func(ctx context.Context, gatewayId string) (*domain.ApplicationTimeline, error) {
+			if gatewayId != "clusterid-testid" {
+				return nil, gatewayerrors.NewNotFound(errors.New("no events recorded"))
+			}
+			return &domain.ApplicationTimeline{GatewayId: gatewayId, Events: []domain.TimelineEvent{
+				{Time: submitted, State: "SUBMITTED"},
+				{Time: submitted.Add(time.Minute), State: "FAILED", Message: "driver OOMKilled"},
+			}}, nil
+		},
+	}
+
+	router, v1Group := NewV1Router()
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	req, _ := http.NewRequest("GET", "/api/v1/applications/clusterid-testid/timeline", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.JSONEq(t, `{"gatewayId": "clusterid-testid", "events": [
+		{"time": "2025-01-01T10:00:00Z", "state": "SUBMITTED"},
+		{"time": "2025-01-01T10:01:00Z", "state": "FAILED", "message": "driver OOMKilled"}
+	]}`, w.Body.String(), "timelines should match")
+
+	req, _ = http.NewRequest("GET", "/api/v1/applications/clusterid-unknown/timeline", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code, "errors should be mapped to their status")
+}
+
 func TestApplicationHandlerDelete(t *testing.T) {
 
 	service := &service.GatewayApplicationServiceMock{
@@ -313,7 +1035,7 @@ func TestApplicationHandlerDelete(t *testing.T) {
 	}
 
 	router, v1Group := NewV1Router()
-	RegisterGatewayApplicationRoutes(v1Group, testConfig, service)
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
 
 	req, _ := http.NewRequest("DELETE", "/api/v1/applications/clusterid-testid", nil)
 	w := httptest.NewRecorder()
@@ -337,7 +1059,7 @@ func TestApplicationHandlerDeleteError(t *testing.T) {
 	}
 
 	router, v1Group := NewV1Router()
-	RegisterGatewayApplicationRoutes(v1Group, testConfig, service)
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
 
 	req, _ := http.NewRequest("DELETE", "/api/v1/applications/clusterid-testid", nil)
 	w := httptest.NewRecorder()
@@ -352,3 +1074,206 @@ func TestApplicationHandlerDeleteError(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, w.Code, "codes should match")
 	assert.Equal(t, resp, string(responseData), "returned JSON should match")
 }
+
+func TestApplicationHandlerScale(t *testing.T) {
+
+	var gotScale domain.ExecutorScale
+	service := &service.GatewayApplicationServiceMock{
+		ScaleFunc: func(ctx context.Context, gatewayId string, scale domain.ExecutorScale) (*domain.GatewayApplication, error) {
+			gotScale = scale
+			return &domain.GatewayApplication{GatewayId: gatewayId}, nil
+		},
+	}
+
+	router, v1Group := NewV1Router()
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	req, _ := http.NewRequest("POST", "/api/v1/applications/clusterid-testid/scale", bytes.NewBufferString(`{"instances":2}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var gotApp domain.GatewayApplication
+	json.Unmarshal(w.Body.Bytes(), &gotApp)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, int32(2), *gotScale.Instances, "instances should be passed to the service")
+	assert.Equal(t, "clusterid-testid", gotApp.GatewayId, "scaled application should be returned")
+}
+
+func TestApplicationHandlerScaleError(t *testing.T) {
+
+	service := &service.GatewayApplicationServiceMock{
+		ScaleFunc: func(ctx context.Context, gatewayId string, scale domain.ExecutorScale) (*domain.GatewayApplication, error) {
+			return nil, gatewayerrors.NewConflict(errors.New("SparkApplication 'clusterid-testid' is not running"))
+		},
+	}
+
+	router, v1Group := NewV1Router()
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	req, _ := http.NewRequest("POST", "/api/v1/applications/clusterid-testid/scale", bytes.NewBufferString(`{"maxExecutors":2}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code, "codes should match")
+}
+
+func TestApplicationHandlerUpdate(t *testing.T) {
+
+	var gotUpdate domain.ApplicationUpdate
+	service := &service.GatewayApplicationServiceMock{
+		UpdateFunc: func(ctx context.Context, gatewayId string, update domain.ApplicationUpdate) (*domain.GatewayApplication, error) {
+			gotUpdate = update
+			return &domain.GatewayApplication{GatewayId: gatewayId}, nil
+		},
+	}
+
+	router, v1Group := NewV1Router()
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	req, _ := http.NewRequest(http.MethodPatch, "/api/v1/applications/clusterid-testid", bytes.NewBufferString(`{"labels":{"team":"data"},"maxExecutors":4}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var gotApp domain.GatewayApplication
+	json.Unmarshal(w.Body.Bytes(), &gotApp)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, map[string]string{"team": "data"}, gotUpdate.Labels, "labels should be passed to the service")
+	assert.Equal(t, int32(4), *gotUpdate.MaxExecutors, "maxExecutors should be passed to the service")
+	assert.Equal(t, "clusterid-testid", gotApp.GatewayId, "updated application should be returned")
+}
+
+func TestApplicationHandlerResubmit(t *testing.T) {
+	router, v1Group := NewV1Router()
+
+	v1Group.Use(func(ctx *gin.Context) {
+		ctx.Set("user", "alice")
+		ctx.Set("team", "data")
+		ctx.Next()
+	})
+
+	var gotApp *v1beta2.SparkApplication
+	var gotUser string
+	service := &service.GatewayApplicationServiceMock{
+		ResubmissionFunc: func(ctx context.Context, gatewayId string) (*v1beta2.SparkApplication, error) {
+			return &v1beta2.SparkApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "etl-job",
+					Namespace:   "test",
+					Annotations: map[string]string{domain.GATEWAY_RESUBMITTED_FROM_ANNOTATION: gatewayId},
+				},
+			}, nil
+		},
+		CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error) {
+			gotApp = application
+			gotUser = user
+			return &domain.GatewayApplication{GatewayId: "clusterid-newid"}, nil
+		},
+	}
+
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	req, _ := http.NewRequest("POST", "/api/v1/applications/clusterid-testid/resubmit", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var createdApp domain.GatewayApplication
+	json.Unmarshal(w.Body.Bytes(), &createdApp)
+
+	assert.Equal(t, http.StatusCreated, w.Code, "codes should match")
+	assert.Equal(t, "clusterid-newid", createdApp.GatewayId, "resubmitted application should be returned")
+	assert.Equal(t, "alice", gotUser, "the requesting user should resubmit the application")
+	assert.Equal(t, "data", gotApp.Labels[domain.GATEWAY_TEAM_LABEL], "team label should be set from the request")
+	assert.Equal(t, "clusterid-testid", gotApp.Annotations[domain.GATEWAY_RESUBMITTED_FROM_ANNOTATION], "the resubmission should be created")
+}
+
+func TestApplicationHandlerResubmitNotFound(t *testing.T) {
+	router, v1Group := NewV1Router()
+
+	v1Group.Use(func(ctx *gin.Context) {
+		ctx.Set("user", "alice")
+		ctx.Next()
+	})
+
+	service := &service.GatewayApplicationServiceMock{
+		ResubmissionFunc: func(ctx context.Context, gatewayId string) (*v1beta2.SparkApplication, error) {
+			return nil, gatewayerrors.NewNotFound(errors.New("error getting SparkApplication 'clusterid-testid'"))
+		},
+	}
+
+	routes.Register(v1Group, ApplicationRoutes(testConfig, service))
+
+	req, _ := http.NewRequest("POST", "/api/v1/applications/clusterid-testid/resubmit", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code, "codes should match")
+	assert.Len(t, service.CreateCalls(), 0, "nothing should be created")
+}
+
+func TestGroupReadOnly(t *testing.T) {
+	conf := &config.SparkGatewayConfig{GatewayConfig: config.GatewayConfig{ReadOnly: true}}
+	group := Group(conf, &service.GatewayApplicationServiceMock{}, nil)
+
+	var paths []string
+	for _, route := range group.Routes {
+		paths = append(paths, route.Method+" "+route.Path)
+	}
+	assert.Contains(t, paths, "GET /applications", "reads should be served")
+	assert.Contains(t, paths, "POST /applications/render", "dry runs should be served")
+	assert.NotContains(t, paths, "POST /applications", "submissions shouldn't be served")
+	assert.NotContains(t, paths, "DELETE /applications/:gatewayId", "deletions shouldn't be served")
+	assert.NotContains(t, paths, "POST /applications/:gatewayId/scale", "scaling shouldn't be served")
+}
+
+func TestApplicationHandlerDeleteForce(t *testing.T) {
+	conf := &config.SparkGatewayConfig{GatewayConfig: config.GatewayConfig{AdminUsers: []string{"admin"}}}
+
+	testCases := []struct {
+		name             string
+		user             string
+		role             domain.Role
+		query            string
+		expectedStatus   int
+		expectedOverride bool
+	}{
+		{name: "without force", user: "alice", expectedStatus: http.StatusOK},
+		{name: "force by admin user", user: "admin", query: "?force=true", expectedStatus: http.StatusOK, expectedOverride: true},
+		{name: "force by admin role", user: "bob", role: domain.RoleAdmin, query: "?force=true", expectedStatus: http.StatusOK, expectedOverride: true},
+		{name: "force by non-admin", user: "alice", role: domain.RoleSubmitter, query: "?force=true", expectedStatus: http.StatusForbidden},
+		{name: "invalid force", user: "admin", query: "?force=yes", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotOverride bool
+			service := &service.GatewayApplicationServiceMock{
+				DeleteFunc: func(ctx context.Context, gatewayId string) error {
+					gotOverride = domain.IsSelectorOverride(ctx)
+					return nil
+				},
+			}
+
+			router, v1Group := NewV1Router()
+			v1Group.Use(func(c *gin.Context) {
+				c.Set("user", tc.user)
+				if tc.role != "" {
+					c.Set("role", string(tc.role))
+				}
+				c.Next()
+			})
+			routes.Register(v1Group, ApplicationRoutes(conf, service))
+
+			req, _ := http.NewRequest(http.MethodDelete, "/api/v1/applications/clusterid-testid"+tc.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code, "codes should match")
+			assert.Equal(t, tc.expectedOverride, gotOverride, "selector override should match")
+			if tc.expectedStatus != http.StatusOK {
+				assert.Len(t, service.DeleteCalls(), 0, "rejected requests should not delete")
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/api/middleware"
+	"github.com/slackhq/spark-gateway/internal/gateway/service"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+// anonymousReadOnlyRoutes are the routes, relative to the group, anonymous users may GET
+var anonymousReadOnlyRoutes = []string{
+	"/applications",
+	"/applications/:gatewayId",
+	"/applications/:gatewayId/status",
+}
+
+// AuthorizeAnonymous restricts requests with the context `anonymous` key set to getting, listing and reading the status
+// of applications in namespaces. List requests must set the namespace query parameter. All other requests of anonymous
+// users are rejected with a 401 so clients know to authenticate, regardless of the middleware configured. basePath is
+// the path of the group the routes are served under.
+func AuthorizeAnonymous(basePath string, namespaces []string, appService service.GatewayApplicationReader) gin.HandlerFunc {
+
+	var routes []string
+	for _, route := range anonymousReadOnlyRoutes {
+		routes = append(routes, basePath+route)
+	}
+
+	return func(c *gin.Context) {
+		if !c.GetBool("anonymous") {
+			c.Next()
+			return
+		}
+
+		if c.Request.Method != http.MethodGet || !slices.Contains(routes, c.FullPath()) {
+			c.Error(gatewayerrors.NewUnauthorized(errors.New("authentication required")))
+			c.Abort()
+			return
+		}
+
+		namespace := c.Query("namespace")
+		if gatewayId := c.Param("gatewayId"); gatewayId != "" {
+			_, appNamespace, err := appService.GetClusterNamespaceFromGatewayId(gatewayId)
+			if err != nil {
+				c.Error(err)
+				c.Abort()
+				return
+			}
+			namespace = appNamespace
+		}
+
+		if !slices.Contains(namespaces, namespace) {
+			c.Error(gatewayerrors.NewForbidden(fmt.Errorf("anonymous users can only read applications in namespaces %v", namespaces)))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// Owner returns a middleware.OwnerFunc returning the owner of the application or schedule of requests to routes with a
+// gatewayId or scheduleId. scheduleService may be nil when schedules aren't enabled.
+func Owner(appService service.GatewayApplicationReader, scheduleService service.ScheduleService) middleware.OwnerFunc {
+	return func(c *gin.Context) (string, bool, error) {
+		if gatewayId := c.Param("gatewayId"); gatewayId != "" {
+			app, err := appService.Get(c, gatewayId)
+			if err != nil {
+				return "", false, err
+			}
+			return domain.ApplicationOwner(app), true, nil
+		}
+
+		if scheduleId := c.Param("scheduleId"); scheduleId != "" && scheduleService != nil {
+			schedule, err := scheduleService.Get(c, scheduleId)
+			if err != nil {
+				return "", false, err
+			}
+			return schedule.User, true, nil
+		}
+
+		return "", false, nil
+	}
+}
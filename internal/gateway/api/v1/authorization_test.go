@@ -0,0 +1,188 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/service"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+	"github.com/stretchr/testify/assert"
+)
+
+var authorizeAnonymousTests = []struct {
+	test           string
+	method         string
+	path           string
+	anonymous      bool
+	expectedStatus int
+}{
+	{test: "authenticated mutating route", method: http.MethodDelete, path: "/api/v1/applications/clust-other-uuid", expectedStatus: http.StatusOK},
+	{test: "list allowed namespace", method: http.MethodGet, path: "/api/v1/applications?namespace=dashboards", anonymous: true, expectedStatus: http.StatusOK},
+	{test: "list without namespace", method: http.MethodGet, path: "/api/v1/applications", anonymous: true, expectedStatus: http.StatusForbidden},
+	{test: "list other namespace", method: http.MethodGet, path: "/api/v1/applications?namespace=other", anonymous: true, expectedStatus: http.StatusForbidden},
+	{test: "get allowed namespace", method: http.MethodGet, path: "/api/v1/applications/clust-dashboards-uuid", anonymous: true, expectedStatus: http.StatusOK},
+	{test: "status allowed namespace", method: http.MethodGet, path: "/api/v1/applications/clust-dashboards-uuid/status", anonymous: true, expectedStatus: http.StatusOK},
+	{test: "get other namespace", method: http.MethodGet, path: "/api/v1/applications/clust-other-uuid", anonymous: true, expectedStatus: http.StatusForbidden},
+	{test: "get invalid gatewayId", method: http.MethodGet, path: "/api/v1/applications/invalid", anonymous: true, expectedStatus: http.StatusBadRequest},
+	{test: "logs", method: http.MethodGet, path: "/api/v1/applications/clust-dashboards-uuid/logs", anonymous: true, expectedStatus: http.StatusUnauthorized},
+	{test: "summary", method: http.MethodGet, path: "/api/v1/applications/summary?namespace=dashboards", anonymous: true, expectedStatus: http.StatusUnauthorized},
+	{test: "create", method: http.MethodPost, path: "/api/v1/applications", anonymous: true, expectedStatus: http.StatusUnauthorized},
+	{test: "delete", method: http.MethodDelete, path: "/api/v1/applications/clust-dashboards-uuid", anonymous: true, expectedStatus: http.StatusUnauthorized},
+}
+
+func TestAuthorizeAnonymous(t *testing.T) {
+	appService := &service.GatewayApplicationServiceMock{
+		GetClusterNamespaceFromGatewayIdFunc: func(gatewayId string) (*domain.KubeCluster, string, error) {
+			switch gatewayId {
+			case "clust-dashboards-uuid":
+				return &domain.KubeCluster{Name: "cluster"}, "dashboards", nil
+			case "clust-other-uuid":
+				return &domain.KubeCluster{Name: "cluster"}, "other", nil
+			}
+			return nil, "", gatewayerrors.NewBadRequest(errors.New("invalid gatewayId"))
+		},
+	}
+
+	for _, test := range authorizeAnonymousTests {
+		t.Run(test.test, func(t *testing.T) {
+			router, v1Group := NewV1Router()
+			v1Group.Use(func(c *gin.Context) {
+				c.Set("user", "anonymous")
+				c.Set("anonymous", test.anonymous)
+			})
+			v1Group.Use(AuthorizeAnonymous(v1Group.BasePath(), []string{"dashboards"}, appService))
+
+			ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+			v1Group.GET("/applications", ok)
+			v1Group.POST("/applications", ok)
+			v1Group.GET("/applications/summary", ok)
+			v1Group.GET("/applications/:gatewayId", ok)
+			v1Group.DELETE("/applications/:gatewayId", ok)
+			v1Group.GET("/applications/:gatewayId/status", ok)
+			v1Group.GET("/applications/:gatewayId/logs", ok)
+
+			req, _ := http.NewRequest(test.method, test.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatus, w.Code, "codes should match")
+		})
+	}
+}
+
+func TestApplicationHandlerGetAnonymousRedacted(t *testing.T) {
+	app := &domain.GatewayApplication{
+		GatewayId: "clust-dashboards-uuid",
+		SparkApplication: domain.GatewaySparkApplication{
+			Spec: v1beta2.SparkApplicationSpec{SparkConf: map[string]string{"spark.password": "hunter2"}},
+		},
+	}
+	appService := &service.GatewayApplicationServiceMock{
+		GetClusterNamespaceFromGatewayIdFunc: func(gatewayId string) (*domain.KubeCluster, string, error) {
+			return &domain.KubeCluster{Name: "cluster"}, "dashboards", nil
+		},
+		GetFunc: func(ctx context.Context, gatewayId string) (*domain.GatewayApplication, error) {
+			return app, nil
+		},
+	}
+
+	conf := &config.SparkGatewayConfig{GatewayConfig: config.GatewayConfig{
+		AnonymousReadOnly: config.AnonymousReadOnlyConfig{Enable: true, Namespaces: []string{"dashboards"}},
+	}}
+	// Authenticate every request as the anonymous user, as AnonymousFallbackMiddleware does without credentials
+	router := gin.New()
+	registry := routes.NewRegistry()
+	registry.Add(Group(conf, appService, nil))
+	err := registry.Build(router, func(rg *gin.RouterGroup, auth config.MiddlewareRouteGroup, allowAnonymous bool) error {
+		assert.Equal(t, config.APIRouteGroup, auth, "api middleware should authenticate the V1 API")
+		assert.True(t, allowAnonymous, "anonymous requests should be allowed")
+		rg.Use(func(c *gin.Context) {
+			c.Set("user", "anonymous")
+			c.Set("anonymous", true)
+		})
+		return nil
+	})
+	assert.NoError(t, err, "routes should build")
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/applications/clust-dashboards-uuid", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var gotApp domain.GatewayApplication
+	json.Unmarshal(w.Body.Bytes(), &gotApp)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, domain.RedactedValue, gotApp.SparkApplication.Spec.SparkConf["spark.password"], "sparkConf values should be redacted")
+	assert.Equal(t, "hunter2", app.SparkApplication.Spec.SparkConf["spark.password"], "service application should not be modified")
+}
+
+func TestGroupRBAC(t *testing.T) {
+	appService := &service.GatewayApplicationServiceMock{
+		GetFunc: func(ctx context.Context, gatewayId string) (*domain.GatewayApplication, error) {
+			return &domain.GatewayApplication{
+				GatewayId: gatewayId,
+				User:      "alice",
+				SparkApplication: domain.GatewaySparkApplication{
+					GatewayApplicationMeta: domain.GatewayApplicationMeta{Labels: map[string]string{domain.GATEWAY_OWNER_LABEL: "alice"}},
+				},
+			}, nil
+		},
+		DeleteFunc: func(ctx context.Context, gatewayId string) error {
+			return nil
+		},
+	}
+	conf := &config.SparkGatewayConfig{GatewayConfig: config.GatewayConfig{
+		RBAC: config.RBACConfig{Enable: true, DefaultRole: domain.RoleSubmitter},
+	}}
+
+	for _, tc := range []struct {
+		user           string
+		expectedStatus int
+	}{
+		{user: "alice", expectedStatus: http.StatusOK},
+		{user: "mallory", expectedStatus: http.StatusForbidden},
+	} {
+		t.Run(tc.user, func(t *testing.T) {
+			router := gin.New()
+			registry := routes.NewRegistry()
+			registry.Add(Group(conf, appService, nil))
+			err := registry.Build(router, func(rg *gin.RouterGroup, auth config.MiddlewareRouteGroup, allowAnonymous bool) error {
+				rg.Use(func(c *gin.Context) {
+					c.Set("user", tc.user)
+				})
+				return nil
+			})
+			assert.NoError(t, err, "routes should build")
+
+			req, _ := http.NewRequest(http.MethodDelete, "/api/v1/applications/clust-ns-uuid", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code, "only owners should delete applications")
+		})
+	}
+}
@@ -1,23 +1,94 @@
 package v1
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/slackhq/spark-gateway/internal/gateway/api/middleware"
 	"github.com/slackhq/spark-gateway/internal/gateway/service"
 	"github.com/slackhq/spark-gateway/internal/shared/config"
+	sgMiddleware "github.com/slackhq/spark-gateway/internal/shared/middleware"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
 )
 
-// RegisterApplicationRoutes registers routes handling GatewayApplication submissions
-func RegisterGatewayApplicationRoutes(rg *gin.RouterGroup, sgConf *config.SparkGatewayConfig, appService service.GatewayApplicationService) {
+// Group declares the V1 API, authenticated by the middleware configured for api routes. Anonymous users may only read
+// applications in gateway.anonymousReadOnly namespaces when it is enabled.
+func Group(sgConf *config.SparkGatewayConfig, appService service.GatewayApplicationService, scheduleService service.ScheduleService) routes.Group {
+
+	group := routes.Group{
+		Prefix:         "/api",
+		Version:        "v1",
+		Auth:           config.APIRouteGroup,
+		AllowAnonymous: sgConf.GatewayConfig.AnonymousReadOnly.Enable,
+		Routes:         ApplicationRoutes(sgConf, appService),
+	}
+	if scheduleService != nil {
+		group.Routes = append(group.Routes, ScheduleRoutes(scheduleService)...)
+	}
+	if sgConf.GatewayConfig.ReadOnly {
+		group.Routes = routes.ReadRoutes(group.Routes)
+	}
+	// Errors are rendered before responses are wrapped with their metadata
+	group.Middleware = []gin.HandlerFunc{middleware.ResponseMetadata(group.StreamingPaths()...), sgMiddleware.ApplicationErrorHandler}
+	if sgConf.GatewayConfig.LatencyBudget.Enable {
+		group.Middleware = append(group.Middleware, middleware.LatencyBudget(sgConf.GatewayConfig.LatencyBudget, group.StreamingPaths()...))
+	}
+
+	if sgConf.GatewayConfig.AnonymousReadOnly.Enable {
+		group.Authorize = []gin.HandlerFunc{AuthorizeAnonymous(group.BasePath(), sgConf.GatewayConfig.AnonymousReadOnly.Namespaces, appService)}
+	}
+	if sgConf.GatewayConfig.RBAC.Enable {
+		group.Authorize = append(group.Authorize, middleware.AuthorizeRoles(sgConf.GatewayConfig.RBAC, sgConf.GatewayConfig.AdminUsers, Owner(appService, scheduleService)))
+	}
+
+	return group
+}
+
+// ApplicationRoutes declares routes handling GatewayApplication submissions
+func ApplicationRoutes(sgConf *config.SparkGatewayConfig, appService service.GatewayApplicationService) []routes.Route {
+
+	h := NewGatewayApplicationHandler(appService, sgConf.GatewayConfig.FieldValidation, sgConf.GatewayConfig.AsyncSubmission.Enable, sgConf.GatewayConfig.AdminUsers)
+
+	return []routes.Route{
+		{Method: http.MethodGet, Path: "/applications", Handler: h.List},
+		{Method: http.MethodPost, Path: "/applications", Handler: h.Create, Writes: true},
+		{Method: http.MethodPost, Path: "/applications/render", Handler: h.RenderPods},
+
+		{Method: http.MethodGet, Path: "/applications/summary", Handler: h.Summary},
+		{Method: http.MethodGet, Path: "/applications/watch", Handler: h.Watch, Streaming: true},
+
+		{Method: http.MethodGet, Path: "/applications/:gatewayId", Handler: h.Get},
+		{Method: http.MethodPatch, Path: "/applications/:gatewayId", Handler: h.Update, Writes: true},
+		{Method: http.MethodDelete, Path: "/applications/:gatewayId", Handler: h.Delete, Writes: true},
+
+		{Method: http.MethodPost, Path: "/applications/:gatewayId/scale", Handler: h.Scale, Writes: true},
+		{Method: http.MethodPost, Path: "/applications/:gatewayId/resubmit", Handler: h.Resubmit, Writes: true},
+
+		{Method: http.MethodGet, Path: "/applications/:gatewayId/status", Handler: h.Status},
+		{Method: http.MethodGet, Path: "/applications/:gatewayId/status/stream", Handler: h.StreamStatus, Streaming: true},
+		{Method: http.MethodGet, Path: "/applications/:gatewayId/wait", Handler: h.WaitStatus},
+		{Method: http.MethodGet, Path: "/applications/:gatewayId/logs", Handler: h.Logs, StreamingQuery: "follow"},
+		{Method: http.MethodGet, Path: "/applications/:gatewayId/logs/download", Handler: h.DownloadLogs, Streaming: true},
+		{Method: http.MethodGet, Path: "/applications/:gatewayId/pods", Handler: h.Pods},
+		{Method: http.MethodGet, Path: "/applications/:gatewayId/metrics", Handler: h.DriverMetrics},
+		{Method: http.MethodGet, Path: "/applications/:gatewayId/timeline", Handler: h.Timeline},
+	}
+}
 
-	h := NewGatewayApplicationHandler(appService, sgConf.DefaultLogLines)
+// ScheduleRoutes declares routes managing the schedules submitting GatewayApplications with cron expressions
+func ScheduleRoutes(scheduleService service.ScheduleService) []routes.Route {
 
-	rg.GET("/applications", h.List)
-	rg.POST("/applications", h.Create)
+	h := NewScheduleHandler(scheduleService)
 
-	rg.GET("/applications/:gatewayId", h.Get)
-	rg.DELETE("/applications/:gatewayId", h.Delete)
+	return []routes.Route{
+		{Method: http.MethodGet, Path: "/schedules", Handler: h.List},
+		{Method: http.MethodPost, Path: "/schedules", Handler: h.Create, Writes: true},
 
-	rg.GET("/applications/:gatewayId/status", h.Status)
-	rg.GET("/applications/:gatewayId/logs", h.Logs)
+		{Method: http.MethodGet, Path: "/schedules/:scheduleId", Handler: h.Get},
+		{Method: http.MethodDelete, Path: "/schedules/:scheduleId", Handler: h.Delete, Writes: true},
 
+		{Method: http.MethodGet, Path: "/schedules/:scheduleId/runs", Handler: h.Runs},
+		{Method: http.MethodPost, Path: "/schedules/:scheduleId/pause", Handler: h.Pause, Writes: true},
+		{Method: http.MethodPost, Path: "/schedules/:scheduleId/resume", Handler: h.Resume, Writes: true},
+	}
 }
@@ -0,0 +1,187 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/service"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+type ScheduleHandler struct {
+	service service.ScheduleService
+}
+
+func NewScheduleHandler(service service.ScheduleService) *ScheduleHandler {
+	return &ScheduleHandler{service: service}
+}
+
+// CreateSchedule godoc
+// @Summary Schedule a SparkApplication
+// @Description Creates a schedule submitting the SparkApplication as a new GatewayApplication, on behalf of the requesting user, every time the standard 5 field cron expression matches in UTC. Each run goes through routing, kill switches and validation like any submission and is annotated with spark-gateway/schedule.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param ScheduleRequest body domain.ScheduleRequest true "Cron expression and SparkApplication to submit"
+// @Success 201 {object} domain.ScheduledApplication "Created schedule"
+// @Router /v1/schedules [post]
+func (h *ScheduleHandler) Create(c *gin.Context) {
+
+	var request domain.ScheduleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	user, ok := bindSubmitter(c, &request.Application)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.service.Create(c, request, user)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, schedule)
+}
+
+// ListSchedules godoc
+// @Summary List schedules
+// @Description Lists schedules, oldest first. Optionally filter by namespace.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param namespace query string false "Namespace (optional)"
+// @Success 200 {array} domain.ScheduledApplication "List of schedules"
+// @Router /v1/schedules [get]
+func (h *ScheduleHandler) List(c *gin.Context) {
+
+	schedules, err := h.service.List(c, c.Query("namespace"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedules)
+}
+
+// GetSchedule godoc
+// @Summary Get a schedule
+// @Description Returns the schedule with its cron expression, SparkApplication and next run time
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param scheduleId path string true "Schedule id"
+// @Success 200 {object} domain.ScheduledApplication "Schedule"
+// @Router /v1/schedules/{scheduleId} [get]
+func (h *ScheduleHandler) Get(c *gin.Context) {
+
+	schedule, err := h.service.Get(c, c.Param("scheduleId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// GetScheduleRuns godoc
+// @Summary Get the runs of a schedule
+// @Description Returns the latest runs of the schedule, latest first, with the GatewayId submitted for each or the error that prevented it from being submitted
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param scheduleId path string true "Schedule id"
+// @Success 200 {array} domain.ScheduledRun "Runs of the schedule"
+// @Router /v1/schedules/{scheduleId}/runs [get]
+func (h *ScheduleHandler) Runs(c *gin.Context) {
+
+	runs, err := h.service.Runs(c, c.Param("scheduleId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, runs)
+}
+
+// PauseSchedule godoc
+// @Summary Pause a schedule
+// @Description Stops the schedule from submitting runs until it is resumed
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param scheduleId path string true "Schedule id"
+// @Success 200 {object} domain.ScheduledApplication "Paused schedule"
+// @Router /v1/schedules/{scheduleId}/pause [post]
+func (h *ScheduleHandler) Pause(c *gin.Context) {
+	h.setPaused(c, true)
+}
+
+// ResumeSchedule godoc
+// @Summary Resume a paused schedule
+// @Description Resumes the schedule from the next time its cron expression matches. Runs missed while it was paused are not submitted.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param scheduleId path string true "Schedule id"
+// @Success 200 {object} domain.ScheduledApplication "Resumed schedule"
+// @Router /v1/schedules/{scheduleId}/resume [post]
+func (h *ScheduleHandler) Resume(c *gin.Context) {
+	h.setPaused(c, false)
+}
+
+func (h *ScheduleHandler) setPaused(c *gin.Context, paused bool) {
+
+	schedule, err := h.service.SetPaused(c, c.Param("scheduleId"), paused)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// DeleteSchedule godoc
+// @Summary Delete a schedule
+// @Description Deletes the schedule and the history of its runs. GatewayApplications it submitted are not deleted.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BasicAuth
+// @Param scheduleId path string true "Schedule id"
+// @Success 200 {object} map[string]string "Schedule deleted: {'status': 'success'}"
+// @Router /v1/schedules/{scheduleId} [delete]
+func (h *ScheduleHandler) Delete(c *gin.Context) {
+
+	if err := h.service.Delete(c, c.Param("scheduleId")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
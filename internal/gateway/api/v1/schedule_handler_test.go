@@ -0,0 +1,127 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/service"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleHandlerCreate(t *testing.T) {
+	router, v1Group := NewV1Router()
+
+	v1Group.Use(func(ctx *gin.Context) {
+		ctx.Set("user", "user")
+		ctx.Set("team", "data")
+		ctx.Next()
+	})
+
+	var gotRequest domain.ScheduleRequest
+	scheduleService := &service.ScheduleServiceMock{
+		CreateFunc: func(ctx context.Context, request domain.ScheduleRequest, user string) (*domain.ScheduledApplication, error) {
+			assert.Equal(t, "user", user)
+			gotRequest = request
+			return &domain.ScheduledApplication{ScheduleId: "schedule", Cron: request.Cron, User: user}, nil
+		},
+	}
+
+	routes.Register(v1Group, ScheduleRoutes(scheduleService))
+
+	body := `{"cron": "0 2 * * *", "application": {"metadata": {"name": "nightly", "namespace": "ns", "labels": {"spark-gateway/team": "other"}}}}`
+	req, _ := http.NewRequest("POST", "/api/v1/schedules", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var gotSchedule domain.ScheduledApplication
+	json.Unmarshal(w.Body.Bytes(), &gotSchedule)
+
+	assert.Equal(t, http.StatusCreated, w.Code, "codes should match")
+	assert.Equal(t, "schedule", gotSchedule.ScheduleId)
+	assert.Equal(t, "0 2 * * *", gotRequest.Cron)
+	assert.Equal(t, "data", gotRequest.Application.Labels[domain.GATEWAY_TEAM_LABEL], "the team should be set from the authenticated principal")
+}
+
+func TestScheduleHandlerRoutes(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		err        error
+		wantCode   int
+		wantPaused *bool
+	}{
+		{name: "list", method: "GET", path: "/api/v1/schedules?namespace=ns", wantCode: http.StatusOK},
+		{name: "get", method: "GET", path: "/api/v1/schedules/schedule", wantCode: http.StatusOK},
+		{name: "get not found", method: "GET", path: "/api/v1/schedules/schedule", err: gatewayerrors.NewNotFound(errors.New("schedule 'schedule' not found")), wantCode: http.StatusNotFound},
+		{name: "runs", method: "GET", path: "/api/v1/schedules/schedule/runs", wantCode: http.StatusOK},
+		{name: "pause", method: "POST", path: "/api/v1/schedules/schedule/pause", wantCode: http.StatusOK, wantPaused: util.Ptr(true)},
+		{name: "resume", method: "POST", path: "/api/v1/schedules/schedule/resume", wantCode: http.StatusOK, wantPaused: util.Ptr(false)},
+		{name: "delete", method: "DELETE", path: "/api/v1/schedules/schedule", wantCode: http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, v1Group := NewV1Router()
+
+			var gotPaused *bool
+			scheduleService := &service.ScheduleServiceMock{
+				ListFunc: func(ctx context.Context, namespace string) ([]*domain.ScheduledApplication, error) {
+					assert.Equal(t, "ns", namespace)
+					return []*domain.ScheduledApplication{{ScheduleId: "schedule"}}, test.err
+				},
+				GetFunc: func(ctx context.Context, scheduleId string) (*domain.ScheduledApplication, error) {
+					assert.Equal(t, "schedule", scheduleId)
+					return &domain.ScheduledApplication{ScheduleId: scheduleId}, test.err
+				},
+				RunsFunc: func(ctx context.Context, scheduleId string) ([]*domain.ScheduledRun, error) {
+					assert.Equal(t, "schedule", scheduleId)
+					return []*domain.ScheduledRun{{GatewayId: "gateway-id"}}, test.err
+				},
+				SetPausedFunc: func(ctx context.Context, scheduleId string, paused bool) (*domain.ScheduledApplication, error) {
+					assert.Equal(t, "schedule", scheduleId)
+					gotPaused = &paused
+					return &domain.ScheduledApplication{ScheduleId: scheduleId, Paused: paused}, test.err
+				},
+				DeleteFunc: func(ctx context.Context, scheduleId string) error {
+					assert.Equal(t, "schedule", scheduleId)
+					return test.err
+				},
+			}
+
+			routes.Register(v1Group, ScheduleRoutes(scheduleService))
+
+			req, _ := http.NewRequest(test.method, test.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.wantCode, w.Code, "codes should match")
+			assert.Equal(t, test.wantPaused, gotPaused)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterrouter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+	cfgPkg "github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+// ApplicationCountMetric is the SparkManager gauge counting non-terminal SparkApplications. The series without a
+// namespace counts the whole cluster.
+const ApplicationCountMetric = "spark_application_count"
+
+// CapacityChecker reports whether a cluster has reached its MaxActiveApplications
+type CapacityChecker interface {
+	AtCapacity(ctx context.Context, cluster domain.KubeCluster) bool
+}
+
+// ConcurrencyCapRouter removes clusters at their MaxActiveApplications from the candidates of the wrapped router.
+// When every cluster the namespace exists in is at its cap, submissions wait up to the configured QueueTimeout for one
+// to have room, and are then rejected with 429.
+type ConcurrencyCapRouter struct {
+	clusterRepository repository.ClusterRepository
+	capacityChecker   CapacityChecker
+	concurrencyCap    cfgPkg.ConcurrencyCap
+	newRouter         func(repository.ClusterRepository) ClusterRouter
+}
+
+// NewConcurrencyCapRouter creates a ConcurrencyCapRouter. newRouter builds the wrapped router on top of a
+// ClusterRepository only returning the clusters below their cap, and is called for every submission.
+func NewConcurrencyCapRouter(
+	clusterRepository repository.ClusterRepository,
+	capacityChecker CapacityChecker,
+	concurrencyCap cfgPkg.ConcurrencyCap,
+	newRouter func(repository.ClusterRepository) ClusterRouter,
+) ClusterRouter {
+	return &ConcurrencyCapRouter{
+		clusterRepository: clusterRepository,
+		capacityChecker:   capacityChecker,
+		concurrencyCap:    concurrencyCap,
+		newRouter:         newRouter,
+	}
+}
+
+func (r *ConcurrencyCapRouter) GetCluster(ctx context.Context, namespace string) (*domain.KubeCluster, error) {
+	clusters := r.clusterRepository.GetAllWithNamespace(namespace)
+
+	deadline := time.Now()
+	if queueing(ctx) {
+		deadline = deadline.Add(r.concurrencyCap.QueueTimeout)
+	}
+	eligibleClusters := r.eligible(ctx, clusters)
+	for len(clusters) > 0 && len(eligibleClusters) == 0 {
+		if !time.Now().Before(deadline) {
+			return nil, gatewayerrors.NewTooManyRequests(fmt.Errorf("every cluster of namespace %s is at its maximum number of active applications, try again later", namespace))
+		}
+
+		klog.Infof("every cluster of namespace %s is at its maximum number of active applications, waiting for room", namespace)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(min(r.concurrencyCap.PollInterval, time.Until(deadline))):
+		}
+		eligibleClusters = r.eligible(ctx, clusters)
+	}
+
+	return r.newRouter(&eligibleClusterRepository{
+		ClusterRepository: r.clusterRepository,
+		namespace:         namespace,
+		clusters:          eligibleClusters,
+	}).GetCluster(ctx, namespace)
+}
+
+// eligible returns the clusters without a cap or below it
+func (r *ConcurrencyCapRouter) eligible(ctx context.Context, clusters []domain.KubeCluster) []domain.KubeCluster {
+	eligibleClusters := []domain.KubeCluster{}
+	for _, c := range clusters {
+		if c.MaxActiveApplications > 0 && r.capacityChecker.AtCapacity(ctx, c) {
+			klog.Warningf("excluding cluster %s from routing: it is at its maximum of %d active applications", c.ClusterId, c.MaxActiveApplications)
+			continue
+		}
+		eligibleClusters = append(eligibleClusters, c)
+	}
+	return eligibleClusters
+}
+
+type noQueueKey struct{}
+
+// WithoutQueueing returns a context whose submissions are rejected without waiting when every cluster is at its cap
+func WithoutQueueing(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noQueueKey{}, true)
+}
+
+func queueing(ctx context.Context) bool {
+	noQueue, _ := ctx.Value(noQueueKey{}).(bool)
+	return !noQueue
+}
+
+// MetricsCapacityChecker counts the non-terminal SparkApplications of each cluster from the ApplicationCountMetric
+// served by its SparkManager. Scrapes are cached per cluster for the configured CacheTTL. Clusters whose metrics can't
+// be read are treated as having room, leaving it to the routers to exclude unhealthy clusters.
+type MetricsCapacityChecker struct {
+	metrics *metricFamilyCache
+}
+
+func NewMetricsCapacityChecker(
+	concurrencyCap cfgPkg.ConcurrencyCap,
+	sparkManagerHostnameTemplate string,
+	metricsServerConfig cfgPkg.MetricsServer,
+	debugPorts map[string]cfgPkg.DebugPort,
+) *MetricsCapacityChecker {
+	return &MetricsCapacityChecker{
+		metrics: newMetricFamilyCache(ApplicationCountMetric, concurrencyCap.CacheTTL, sparkManagerHostnameTemplate, metricsServerConfig, debugPorts),
+	}
+}
+
+func (m *MetricsCapacityChecker) AtCapacity(ctx context.Context, cluster domain.KubeCluster) bool {
+	count, err := m.metrics.gauge(ctx, cluster, "")
+	if err != nil {
+		klog.Warningf("unable to count active applications of cluster %s: %v", cluster.ClusterId, err)
+		return false
+	}
+
+	return count != nil && *count >= float64(cluster.MaxActiveApplications)
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterrouter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+	cfgPkg "github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+// testCapacityChecker reports the clusters in full at capacity, for only the first checks calls if checks is set
+type testCapacityChecker struct {
+	full   map[string]bool
+	checks int
+	calls  atomic.Int32
+}
+
+func (c *testCapacityChecker) AtCapacity(ctx context.Context, cluster domain.KubeCluster) bool {
+	calls := int(c.calls.Add(1))
+	return c.full[cluster.ClusterId] && (c.checks == 0 || calls <= c.checks)
+}
+
+func TestConcurrencyCapRouter(t *testing.T) {
+	clusterRepo, err := repository.NewLocalClusterRepo([]domain.KubeCluster{
+		{Name: "cluster-a", ClusterId: "a", MaxActiveApplications: 10, Namespaces: []domain.KubeNamespace{{Name: "ns"}}},
+		{Name: "cluster-b", ClusterId: "b", MaxActiveApplications: 10, Namespaces: []domain.KubeNamespace{{Name: "ns"}}},
+		{Name: "cluster-c", ClusterId: "c", Namespaces: []domain.KubeNamespace{{Name: "uncapped"}}},
+	})
+	assert.NoError(t, err, "creating cluster repository should not error")
+
+	tests := []struct {
+		name           string
+		checker        *testCapacityChecker
+		queueTimeout   time.Duration
+		noQueue        bool
+		namespace      string
+		expectedIds    []string
+		expectedStatus int
+	}{
+		{
+			name:        "clusters below their cap stay eligible",
+			checker:     &testCapacityChecker{},
+			namespace:   "ns",
+			expectedIds: []string{"a", "b"},
+		},
+		{
+			name:        "cluster at its cap is excluded",
+			checker:     &testCapacityChecker{full: map[string]bool{"a": true}},
+			namespace:   "ns",
+			expectedIds: []string{"b"},
+		},
+		{
+			name:        "clusters without a cap are never checked",
+			checker:     &testCapacityChecker{full: map[string]bool{"c": true}},
+			namespace:   "uncapped",
+			expectedIds: []string{"c"},
+		},
+		{
+			name:           "every cluster at its cap is rejected",
+			checker:        &testCapacityChecker{full: map[string]bool{"a": true, "b": true}},
+			namespace:      "ns",
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:         "submission waits for a cluster to have room",
+			checker:      &testCapacityChecker{full: map[string]bool{"a": true, "b": true}, checks: 2},
+			queueTimeout: time.Second,
+			namespace:    "ns",
+			expectedIds:  []string{"a", "b"},
+		},
+		{
+			name:           "submission is rejected after the queue timeout",
+			checker:        &testCapacityChecker{full: map[string]bool{"a": true, "b": true}},
+			queueTimeout:   30 * time.Millisecond,
+			namespace:      "ns",
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:           "lower ranked clusters are not waited for",
+			checker:        &testCapacityChecker{full: map[string]bool{"a": true, "b": true}, checks: 2},
+			queueTimeout:   time.Second,
+			noQueue:        true,
+			namespace:      "ns",
+			expectedStatus: http.StatusTooManyRequests,
+		},
+	}
+
+	for _, test := range tests {
+		var candidateIds []string
+		concurrencyCap := cfgPkg.ConcurrencyCap{Enable: true, QueueTimeout: test.queueTimeout, PollInterval: 10 * time.Millisecond}
+		router := NewConcurrencyCapRouter(clusterRepo, test.checker, concurrencyCap, func(repo repository.ClusterRepository) ClusterRouter {
+			for _, c := range repo.GetAllWithNamespace(test.namespace) {
+				candidateIds = append(candidateIds, c.ClusterId)
+			}
+			return NewRandomClusterRouter(repo)
+		})
+
+		ctx := context.Background()
+		if test.noQueue {
+			ctx = WithoutQueueing(ctx)
+		}
+
+		cluster, err := router.GetCluster(ctx, test.namespace)
+		if test.expectedStatus != 0 {
+			var gatewayErr gatewayerrors.GatewayError
+			assert.True(t, errors.As(err, &gatewayErr), "%s: expected a GatewayError", test.name)
+			assert.Equal(t, test.expectedStatus, gatewayErr.Status, "%s: status should match", test.name)
+			continue
+		}
+
+		assert.NoError(t, err, "%s: routing should not error", test.name)
+		assert.ElementsMatch(t, test.expectedIds, candidateIds, "%s: only eligible clusters should be candidates", test.name)
+		assert.Contains(t, test.expectedIds, cluster.ClusterId, "%s: an eligible cluster should be chosen", test.name)
+	}
+}
+
+func TestMetricsCapacityChecker(t *testing.T) {
+	var scrapes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scrapes.Add(1)
+		fmt.Fprint(w, `# TYPE spark_application_count gauge
+spark_application_count{cluster="cluster-a",namespace=""} 10
+spark_application_count{cluster="cluster-a",namespace="ns"} 4
+`)
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL)
+	assert.NoError(t, err, "parsing test server url should not error")
+
+	checker := NewMetricsCapacityChecker(
+		cfgPkg.ConcurrencyCap{Enable: true, CacheTTL: time.Minute},
+		serverUrl.Hostname(),
+		cfgPkg.MetricsServer{Endpoint: "/metrics", Port: serverUrl.Port()},
+		nil,
+	)
+
+	assert.True(t, checker.AtCapacity(context.Background(), domain.KubeCluster{Name: "cluster-a", ClusterId: "a", MaxActiveApplications: 10}), "cluster at its cap should be at capacity")
+	assert.False(t, checker.AtCapacity(context.Background(), domain.KubeCluster{Name: "cluster-a", ClusterId: "a", MaxActiveApplications: 11}), "cluster below its cap should have room")
+	assert.Equal(t, int32(1), scrapes.Load(), "SparkManager metrics should be scraped once per cacheTTL")
+
+	unreachable := domain.KubeCluster{Name: "cluster-b", ClusterId: "b", MaxActiveApplications: 1}
+	checker.metrics.debugPorts = map[string]cfgPkg.DebugPort{"cluster-b": {MetricsPort: "1"}}
+	assert.False(t, checker.AtCapacity(context.Background(), unreachable), "unreachable clusters should be treated as having room")
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterrouter
+
+import (
+	"context"
+
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+)
+
+// LocalityRouter narrows the candidates of the wrapped router to the clusters matching the domain.LocalityPreference
+// of the submission being routed. Submissions are only routed to other clusters when none of the candidates match.
+type LocalityRouter struct {
+	clusterRepository repository.ClusterRepository
+	newRouter         func(repository.ClusterRepository) ClusterRouter
+}
+
+// NewLocalityRouter creates a LocalityRouter. newRouter builds the wrapped router on top of a ClusterRepository only
+// returning the matching clusters, and is called for every submission.
+func NewLocalityRouter(clusterRepository repository.ClusterRepository, newRouter func(repository.ClusterRepository) ClusterRouter) ClusterRouter {
+	return &LocalityRouter{
+		clusterRepository: clusterRepository,
+		newRouter:         newRouter,
+	}
+}
+
+func (r *LocalityRouter) GetCluster(ctx context.Context, namespace string) (*domain.KubeCluster, error) {
+	locality := localityPreference(ctx)
+	if locality.IsZero() {
+		return r.newRouter(r.clusterRepository).GetCluster(ctx, namespace)
+	}
+
+	matchingClusters := []domain.KubeCluster{}
+	for _, c := range r.clusterRepository.GetAllWithNamespace(namespace) {
+		if locality.Matches(c) {
+			matchingClusters = append(matchingClusters, c)
+		}
+	}
+
+	if len(matchingClusters) == 0 {
+		klog.Warningf("no cluster of namespace %s in %s can be routed to, routing to the other clusters", namespace, locality)
+		return r.newRouter(r.clusterRepository).GetCluster(ctx, namespace)
+	}
+
+	return r.newRouter(&eligibleClusterRepository{
+		ClusterRepository: r.clusterRepository,
+		namespace:         namespace,
+		clusters:          matchingClusters,
+	}).GetCluster(ctx, namespace)
+}
+
+type localityKey struct{}
+
+// WithLocalityPreference returns a context whose submission is routed to the clusters matching locality if it can be
+func WithLocalityPreference(ctx context.Context, locality domain.LocalityPreference) context.Context {
+	return context.WithValue(ctx, localityKey{}, locality)
+}
+
+func localityPreference(ctx context.Context) domain.LocalityPreference {
+	locality, _ := ctx.Value(localityKey{}).(domain.LocalityPreference)
+	return locality
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterrouter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+)
+
+func TestLocalityRouter(t *testing.T) {
+	clusterRepo, err := repository.NewLocalClusterRepo([]domain.KubeCluster{
+		{Name: "cluster-a", ClusterId: "a", Labels: map[string]string{"topology.kubernetes.io/region": "us-east-1", "topology.kubernetes.io/zone": "us-east-1a"}, Namespaces: []domain.KubeNamespace{{Name: "ns"}}},
+		{Name: "cluster-b", ClusterId: "b", Labels: map[string]string{"topology.kubernetes.io/region": "us-east-1", "topology.kubernetes.io/zone": "us-east-1b"}, Namespaces: []domain.KubeNamespace{{Name: "ns"}}},
+		{Name: "cluster-c", ClusterId: "c", Labels: map[string]string{"topology.kubernetes.io/region": "us-west-2"}, Namespaces: []domain.KubeNamespace{{Name: "ns"}}},
+	})
+	assert.NoError(t, err, "creating cluster repository should not error")
+
+	tests := []struct {
+		name        string
+		locality    domain.LocalityPreference
+		expectedIds []string
+	}{
+		{
+			name:        "no preference routes to every cluster",
+			expectedIds: []string{"a", "b", "c"},
+		},
+		{
+			name:        "clusters in the preferred region are candidates",
+			locality:    domain.LocalityPreference{Region: "us-east-1"},
+			expectedIds: []string{"a", "b"},
+		},
+		{
+			name:        "region and zone must both match",
+			locality:    domain.LocalityPreference{Region: "us-east-1", Zone: "us-east-1b"},
+			expectedIds: []string{"b"},
+		},
+		{
+			name:        "no cluster in the preferred region falls back to every cluster",
+			locality:    domain.LocalityPreference{Region: "eu-west-1"},
+			expectedIds: []string{"a", "b", "c"},
+		},
+	}
+
+	for _, test := range tests {
+		var candidateIds []string
+		router := NewLocalityRouter(clusterRepo, func(repo repository.ClusterRepository) ClusterRouter {
+			for _, c := range repo.GetAllWithNamespace("ns") {
+				candidateIds = append(candidateIds, c.ClusterId)
+			}
+			return NewRandomClusterRouter(repo)
+		})
+
+		cluster, err := router.GetCluster(WithLocalityPreference(context.Background(), test.locality), "ns")
+
+		assert.NoError(t, err, "%s: routing should not error", test.name)
+		assert.ElementsMatch(t, test.expectedIds, candidateIds, "%s: only matching clusters should be candidates", test.name)
+		assert.Contains(t, test.expectedIds, cluster.ClusterId, "%s: a candidate cluster should be chosen", test.name)
+	}
+}
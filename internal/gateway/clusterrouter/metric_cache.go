@@ -0,0 +1,117 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterrouter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	io_prometheus_client "github.com/prometheus/client_model/go"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	cfgPkg "github.com/slackhq/spark-gateway/internal/shared/config"
+)
+
+type metricCacheEntry struct {
+	metricFamily *io_prometheus_client.MetricFamily
+	expiresAt    time.Time
+}
+
+// metricFamilyCache scrapes a single metric family from each cluster's SparkManager, caching it per cluster for ttl
+type metricFamilyCache struct {
+	metricName                   string
+	ttl                          time.Duration
+	sparkManagerHostnameTemplate string
+	metricsServerConfig          cfgPkg.MetricsServer
+	debugPorts                   map[string]cfgPkg.DebugPort
+
+	mu    sync.Mutex
+	cache map[string]metricCacheEntry
+}
+
+func newMetricFamilyCache(
+	metricName string,
+	ttl time.Duration,
+	sparkManagerHostnameTemplate string,
+	metricsServerConfig cfgPkg.MetricsServer,
+	debugPorts map[string]cfgPkg.DebugPort,
+) *metricFamilyCache {
+	return &metricFamilyCache{
+		metricName:                   metricName,
+		ttl:                          ttl,
+		sparkManagerHostnameTemplate: sparkManagerHostnameTemplate,
+		metricsServerConfig:          metricsServerConfig,
+		debugPorts:                   debugPorts,
+		cache:                        map[string]metricCacheEntry{},
+	}
+}
+
+// gauge returns the value of the metric's gauge for namespace in cluster, an empty namespace meaning the whole
+// cluster, or nil if SparkManager doesn't serve it
+func (m *metricFamilyCache) gauge(ctx context.Context, cluster domain.KubeCluster, namespace string) (*float64, error) {
+	metricFamily, err := m.get(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	targetMetrics := GetTargetMetrics(metricFamily.GetMetric(), map[string]string{
+		clusterLabelKey:   cluster.Name,
+		namespaceLabelKey: namespace,
+	})
+	if len(targetMetrics) != 1 || targetMetrics[0].Gauge == nil {
+		return nil, nil
+	}
+
+	value := targetMetrics[0].Gauge.GetValue()
+	return &value, nil
+}
+
+func (m *metricFamilyCache) get(ctx context.Context, cluster domain.KubeCluster) (*io_prometheus_client.MetricFamily, error) {
+	m.mu.Lock()
+	entry, ok := m.cache[cluster.ClusterId]
+	m.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.metricFamily, nil
+	}
+
+	// set metrics server port
+	metricsPort := m.metricsServerConfig.Port
+	if port, ok := m.debugPorts[cluster.Name]; ok {
+		metricsPort = port.MetricsPort
+	}
+
+	metricFamilies, err := GetClusterMetricFamilies(ctx, cluster, m.sparkManagerHostnameTemplate, metricsPort, m.metricsServerConfig.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error getting metrics from SparkManager: %w", err)
+	}
+
+	// SparkManager doesn't serve a gauge until it has recorded a value
+	metricFamily, ok := metricFamilies[m.metricName]
+	if !ok {
+		metricFamily = &io_prometheus_client.MetricFamily{}
+	}
+
+	m.mu.Lock()
+	m.cache[cluster.ClusterId] = metricCacheEntry{
+		metricFamily: metricFamily,
+		expiresAt:    time.Now().Add(m.ttl),
+	}
+	m.mu.Unlock()
+
+	return metricFamily, nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterrouter
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	sgHttp "github.com/slackhq/spark-gateway/internal/shared/http"
+)
+
+// metricsClients caches the HTTP clients scraping SparkManager metrics over TLS by their domain.MetricsTLSConfig, so
+// connections are reused across scrapes
+var metricsClients sync.Map
+
+// metricsUrl returns the URL the metrics of cluster c are scraped from
+func metricsUrl(c domain.KubeCluster, hostname, metricsServerPort, metricsServerEndpoint string) string {
+	scheme := domain.MetricsScrapeSchemeHTTP
+	if c.MetricsScrape.Scheme != "" {
+		scheme = c.MetricsScrape.Scheme
+	}
+	if c.MetricsScrape.Port != "" {
+		metricsServerPort = c.MetricsScrape.Port
+	}
+	if c.MetricsScrape.Path != "" {
+		metricsServerEndpoint = c.MetricsScrape.Path
+	}
+
+	return fmt.Sprintf("%s://%s:%s%s", scheme, hostname, metricsServerPort, metricsServerEndpoint)
+}
+
+// metricsClient returns the HTTP client scraping the metrics of cluster c
+func metricsClient(c domain.KubeCluster) (*http.Client, error) {
+	tlsConfig := c.MetricsScrape.TLS
+	if c.MetricsScrape.Scheme != domain.MetricsScrapeSchemeHTTPS || tlsConfig == (domain.MetricsTLSConfig{}) {
+		return sgHttp.DefaultClient, nil
+	}
+
+	if client, ok := metricsClients.Load(tlsConfig); ok {
+		return client.(*http.Client), nil
+	}
+
+	clientTLSConfig := &tls.Config{
+		ServerName:         tlsConfig.ServerName,
+		InsecureSkipVerify: tlsConfig.InsecureSkipVerify,
+	}
+
+	if tlsConfig.CAFile != "" {
+		caPEM, err := os.ReadFile(tlsConfig.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading metrics CA file: %w", err)
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("metrics CA file '%s' has no PEM certificates", tlsConfig.CAFile)
+		}
+		clientTLSConfig.RootCAs = rootCAs
+	}
+
+	if tlsConfig.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(tlsConfig.CertFile, tlsConfig.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading metrics client certificate: %w", err)
+		}
+		clientTLSConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := sgHttp.DefaultClient.Transport.(*http.Transport).Clone()
+	transport.TLSClientConfig = clientTLSConfig
+	client, _ := metricsClients.LoadOrStore(tlsConfig, &http.Client{
+		Timeout:   sgHttp.DefaultClient.Timeout,
+		Transport: transport,
+	})
+	return client.(*http.Client), nil
+}
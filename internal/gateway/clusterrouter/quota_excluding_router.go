@@ -0,0 +1,136 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterrouter
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+	cfgPkg "github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+// QuotaUtilizationMetric is the SparkManager gauge reporting the highest used/hard ratio across a namespace's
+// ResourceQuotas
+const QuotaUtilizationMetric = "namespace_quota_utilization"
+
+// QuotaChecker reports whether a namespace's ResourceQuota is nearly exhausted in a cluster
+type QuotaChecker interface {
+	QuotaExhausted(ctx context.Context, cluster domain.KubeCluster, namespace string) bool
+}
+
+// QuotaExcludingRouter removes clusters where the namespace is nearly out of ResourceQuota from the candidates of the
+// wrapped router. Submissions are rejected with 429 when the namespace is out of quota in every cluster it exists in.
+type QuotaExcludingRouter struct {
+	clusterRepository repository.ClusterRepository
+	quotaChecker      QuotaChecker
+	newRouter         func(repository.ClusterRepository) ClusterRouter
+}
+
+// NewQuotaExcludingRouter creates a QuotaExcludingRouter. newRouter builds the wrapped router on top of a
+// ClusterRepository only returning the eligible clusters, and is called for every submission.
+func NewQuotaExcludingRouter(
+	clusterRepository repository.ClusterRepository,
+	quotaChecker QuotaChecker,
+	newRouter func(repository.ClusterRepository) ClusterRouter,
+) ClusterRouter {
+	return &QuotaExcludingRouter{
+		clusterRepository: clusterRepository,
+		quotaChecker:      quotaChecker,
+		newRouter:         newRouter,
+	}
+}
+
+func (r *QuotaExcludingRouter) GetCluster(ctx context.Context, namespace string) (*domain.KubeCluster, error) {
+	clusters := r.clusterRepository.GetAllWithNamespace(namespace)
+
+	eligibleClusters := []domain.KubeCluster{}
+	for _, c := range clusters {
+		if r.quotaChecker.QuotaExhausted(ctx, c, namespace) {
+			klog.Warningf("excluding cluster %s from routing: namespace %s is nearly out of ResourceQuota", c.ClusterId, namespace)
+			continue
+		}
+		eligibleClusters = append(eligibleClusters, c)
+	}
+
+	if len(clusters) > 0 && len(eligibleClusters) == 0 {
+		return nil, gatewayerrors.NewTooManyRequests(fmt.Errorf("namespace %s is out of ResourceQuota in every cluster, try again later", namespace))
+	}
+
+	return r.newRouter(&eligibleClusterRepository{
+		ClusterRepository: r.clusterRepository,
+		namespace:         namespace,
+		clusters:          eligibleClusters,
+	}).GetCluster(ctx, namespace)
+}
+
+// eligibleClusterRepository narrows GetAllWithNamespace to the clusters eligible for a single submission
+type eligibleClusterRepository struct {
+	repository.ClusterRepository
+	namespace string
+	clusters  []domain.KubeCluster
+}
+
+func (r *eligibleClusterRepository) GetAllWithNamespace(namespace string) []domain.KubeCluster {
+	if namespace != r.namespace {
+		return r.ClusterRepository.GetAllWithNamespace(namespace)
+	}
+	return r.clusters
+}
+
+// MetricsQuotaChecker reads namespace quota utilization from the QuotaUtilizationMetric served by each cluster's
+// SparkManager. Scrapes are cached per cluster for the configured CacheTTL. Clusters whose metrics can't be read are
+// treated as having headroom, leaving it to the routers to exclude unhealthy clusters.
+type MetricsQuotaChecker struct {
+	quotaExclusion cfgPkg.QuotaExclusion
+	metrics        *metricFamilyCache
+}
+
+func NewMetricsQuotaChecker(
+	quotaExclusion cfgPkg.QuotaExclusion,
+	sparkManagerHostnameTemplate string,
+	metricsServerConfig cfgPkg.MetricsServer,
+	debugPorts map[string]cfgPkg.DebugPort,
+) *MetricsQuotaChecker {
+	return &MetricsQuotaChecker{
+		quotaExclusion: quotaExclusion,
+		metrics:        newMetricFamilyCache(QuotaUtilizationMetric, quotaExclusion.CacheTTL, sparkManagerHostnameTemplate, metricsServerConfig, debugPorts),
+	}
+}
+
+func (q *MetricsQuotaChecker) QuotaExhausted(ctx context.Context, cluster domain.KubeCluster, namespace string) bool {
+	utilization, ok := q.QuotaUtilization(ctx, cluster, namespace)
+	return ok && utilization >= q.quotaExclusion.Threshold
+}
+
+// QuotaUtilization returns the highest used/hard ratio across the namespace's ResourceQuotas in cluster. ok is false
+// if the cluster's metrics can't be read or SparkManager hasn't recorded the namespace's utilization.
+func (q *MetricsQuotaChecker) QuotaUtilization(ctx context.Context, cluster domain.KubeCluster, namespace string) (utilization float64, ok bool) {
+	value, err := q.metrics.gauge(ctx, cluster, namespace)
+	if err != nil {
+		klog.Warningf("unable to check ResourceQuota of namespace %s in cluster %s: %v", namespace, cluster.ClusterId, err)
+		return 0, false
+	}
+	if value == nil {
+		return 0, false
+	}
+
+	return *value, true
+}
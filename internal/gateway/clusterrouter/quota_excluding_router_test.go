@@ -0,0 +1,130 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterrouter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+	cfgPkg "github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+type testQuotaChecker map[string]bool
+
+func (q testQuotaChecker) QuotaExhausted(ctx context.Context, cluster domain.KubeCluster, namespace string) bool {
+	return q[cluster.ClusterId+"/"+namespace]
+}
+
+func TestQuotaExcludingRouter(t *testing.T) {
+	clusterRepo, err := repository.NewLocalClusterRepo([]domain.KubeCluster{
+		{Name: "cluster-a", ClusterId: "a", Namespaces: []domain.KubeNamespace{{Name: "ns"}}},
+		{Name: "cluster-b", ClusterId: "b", Namespaces: []domain.KubeNamespace{{Name: "ns"}}},
+	})
+	assert.NoError(t, err, "creating cluster repository should not error")
+
+	tests := []struct {
+		name           string
+		quotaChecker   testQuotaChecker
+		namespace      string
+		expectedIds    []string
+		expectedStatus int
+	}{
+		{
+			name:         "clusters with headroom stay eligible",
+			quotaChecker: testQuotaChecker{},
+			namespace:    "ns",
+			expectedIds:  []string{"a", "b"},
+		},
+		{
+			name:         "exhausted cluster is excluded",
+			quotaChecker: testQuotaChecker{"a/ns": true},
+			namespace:    "ns",
+			expectedIds:  []string{"b"},
+		},
+		{
+			name:           "exhausted in every cluster is rejected",
+			quotaChecker:   testQuotaChecker{"a/ns": true, "b/ns": true},
+			namespace:      "ns",
+			expectedStatus: http.StatusTooManyRequests,
+		},
+	}
+
+	for _, test := range tests {
+		var candidateIds []string
+		router := NewQuotaExcludingRouter(clusterRepo, test.quotaChecker, func(repo repository.ClusterRepository) ClusterRouter {
+			for _, c := range repo.GetAllWithNamespace(test.namespace) {
+				candidateIds = append(candidateIds, c.ClusterId)
+			}
+			return NewRandomClusterRouter(repo)
+		})
+
+		cluster, err := router.GetCluster(context.Background(), test.namespace)
+		if test.expectedStatus != 0 {
+			var gatewayErr gatewayerrors.GatewayError
+			assert.True(t, errors.As(err, &gatewayErr), "%s: expected a GatewayError", test.name)
+			assert.Equal(t, test.expectedStatus, gatewayErr.Status, "%s: status should match", test.name)
+			continue
+		}
+
+		assert.NoError(t, err, "%s: routing should not error", test.name)
+		assert.ElementsMatch(t, test.expectedIds, candidateIds, "%s: only eligible clusters should be candidates", test.name)
+		assert.Contains(t, test.expectedIds, cluster.ClusterId, "%s: an eligible cluster should be chosen", test.name)
+	}
+}
+
+func TestMetricsQuotaChecker(t *testing.T) {
+	var scrapes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scrapes.Add(1)
+		fmt.Fprint(w, `# TYPE namespace_quota_utilization gauge
+namespace_quota_utilization{cluster="cluster-a",namespace="busy"} 0.95
+namespace_quota_utilization{cluster="cluster-a",namespace="idle"} 0.2
+`)
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL)
+	assert.NoError(t, err, "parsing test server url should not error")
+
+	checker := NewMetricsQuotaChecker(
+		cfgPkg.QuotaExclusion{Enable: true, Threshold: 0.9, CacheTTL: time.Minute},
+		serverUrl.Hostname(),
+		cfgPkg.MetricsServer{Endpoint: "/metrics", Port: serverUrl.Port()},
+		nil,
+	)
+	cluster := domain.KubeCluster{Name: "cluster-a", ClusterId: "a"}
+
+	assert.True(t, checker.QuotaExhausted(context.Background(), cluster, "busy"), "namespace above the threshold should be exhausted")
+	assert.False(t, checker.QuotaExhausted(context.Background(), cluster, "idle"), "namespace below the threshold should have headroom")
+	assert.False(t, checker.QuotaExhausted(context.Background(), cluster, "unreported"), "namespace without the metric should have headroom")
+	assert.Equal(t, int32(1), scrapes.Load(), "SparkManager metrics should be scraped once per cacheTTL")
+
+	unreachable := domain.KubeCluster{Name: "cluster-b", ClusterId: "b"}
+	checker.metrics.debugPorts = map[string]cfgPkg.DebugPort{"cluster-b": {MetricsPort: "1"}}
+	assert.False(t, checker.QuotaExhausted(context.Background(), unreachable, "busy"), "unreachable clusters should be treated as having headroom")
+}
@@ -19,6 +19,8 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/klog/v2"
+
 	"github.com/slackhq/spark-gateway/internal/domain"
 	"github.com/slackhq/spark-gateway/internal/gateway/repository"
 	cfg "github.com/slackhq/spark-gateway/internal/shared/config"
@@ -29,6 +31,16 @@ type ClusterRouter interface {
 	GetCluster(ctx context.Context, namespace string) (*domain.KubeCluster, error)
 }
 
+// MultiClusterRouter returns up to count distinct clusters for a namespace, in the order the router prefers them.
+// Fewer clusters are returned when the namespace doesn't exist in enough routable clusters.
+type MultiClusterRouter interface {
+	GetClusters(ctx context.Context, namespace string, count int) ([]domain.KubeCluster, error)
+}
+
+// GetClusterRouter returns the router for routerType over localClusterRepo, wrapped in a LocalityRouter, in a
+// QuotaExcludingRouter when clusterRouter.quotaExclusion is enabled and in a ConcurrencyCapRouter when
+// clusterRouter.concurrencyCap is enabled.
+// The returned router also implements MultiClusterRouter.
 func GetClusterRouter(
 	routerType cfg.ClusterRouterType,
 	localClusterRepo repository.ClusterRepository,
@@ -37,25 +49,132 @@ func GetClusterRouter(
 	metricsServerConfig cfg.MetricsServer,
 	debugPorts map[string]cfg.DebugPort) (ClusterRouter, error) {
 
-	var clusterRouter ClusterRouter
+	var newRouter func(repository.ClusterRepository) ClusterRouter
 	switch routerType {
 	case cfg.RandomRouter:
-		clusterRouter = NewRandomClusterRouter(localClusterRepo)
+		newRouter = NewRandomClusterRouter
 	case cfg.WeightBasedRouter:
-		clusterRouter = NewWeightBasedRouter(
-			localClusterRepo,
-			clusterRouterConfig,
+		newRouter = func(repo repository.ClusterRepository) ClusterRouter {
+			return NewWeightBasedRouter(
+				repo,
+				clusterRouterConfig,
+				sparkManagerHostnameTemplate,
+				metricsServerConfig,
+				debugPorts,
+			)
+		}
+	case cfg.WeightBasedRandomRouter:
+		newRouter = func(repo repository.ClusterRepository) ClusterRouter {
+			return NewWeightBasedRandomRouter(
+				repo,
+				clusterRouterConfig,
+			)
+		}
+	default:
+		return nil, fmt.Errorf("unknown cluster router type: %s", routerType)
+	}
+
+	// Prefer the clusters in the region and zone of the submission among those the exclusions wrapping it leave eligible
+	newLocalRouter := newRouter
+	newRouter = func(repo repository.ClusterRepository) ClusterRouter {
+		return NewLocalityRouter(repo, newLocalRouter)
+	}
+
+	if clusterRouterConfig.QuotaExclusion.Enable {
+		quotaChecker := NewMetricsQuotaChecker(
+			clusterRouterConfig.QuotaExclusion,
 			sparkManagerHostnameTemplate,
 			metricsServerConfig,
 			debugPorts,
 		)
-	case cfg.WeightBasedRandomRouter:
-		clusterRouter = NewWeightBasedRandomRouter(
-			localClusterRepo,
-			clusterRouterConfig,
+		newBaseRouter := newRouter
+		newRouter = func(repo repository.ClusterRepository) ClusterRouter {
+			return NewQuotaExcludingRouter(repo, quotaChecker, newBaseRouter)
+		}
+	}
+
+	if clusterRouterConfig.ConcurrencyCap.Enable {
+		capacityChecker := NewMetricsCapacityChecker(
+			clusterRouterConfig.ConcurrencyCap,
+			sparkManagerHostnameTemplate,
+			metricsServerConfig,
+			debugPorts,
 		)
-	default:
-		return nil, fmt.Errorf("unknown cluster router type: %s", routerType)
+		newCappedRouter := newRouter
+		newRouter = func(repo repository.ClusterRepository) ClusterRouter {
+			return NewConcurrencyCapRouter(repo, capacityChecker, clusterRouterConfig.ConcurrencyCap, newCappedRouter)
+		}
+	}
+
+	return NewRankingRouter(localClusterRepo, newRouter), nil
+}
+
+// RankingRouter ranks clusters by routing repeatedly, excluding the clusters already chosen each time
+type RankingRouter struct {
+	clusterRepository repository.ClusterRepository
+	newRouter         func(repository.ClusterRepository) ClusterRouter
+}
+
+// NewRankingRouter creates a RankingRouter. newRouter builds the router used for every ranking step on top of a
+// ClusterRepository without the clusters already chosen.
+func NewRankingRouter(clusterRepository repository.ClusterRepository, newRouter func(repository.ClusterRepository) ClusterRouter) *RankingRouter {
+	return &RankingRouter{
+		clusterRepository: clusterRepository,
+		newRouter:         newRouter,
+	}
+}
+
+func (r *RankingRouter) GetCluster(ctx context.Context, namespace string) (*domain.KubeCluster, error) {
+	return r.newRouter(r.clusterRepository).GetCluster(ctx, namespace)
+}
+
+// GetClusters returns the error of routing to the first cluster. Errors routing to lower ranked clusters end the
+// ranking instead, since they only mean no other cluster is routable.
+func (r *RankingRouter) GetClusters(ctx context.Context, namespace string, count int) ([]domain.KubeCluster, error) {
+	excludedRepo := &excludingClusterRepository{
+		ClusterRepository: r.clusterRepository,
+		excluded:          map[string]bool{},
+	}
+
+	clusters := []domain.KubeCluster{}
+	for len(clusters) < count {
+		if len(clusters) > 0 && len(excludedRepo.GetAllWithNamespace(namespace)) == 0 {
+			break
+		}
+
+		// Only the first ranked cluster is worth waiting for
+		rankCtx := ctx
+		if len(clusters) > 0 {
+			rankCtx = WithoutQueueing(ctx)
+		}
+
+		cluster, err := r.newRouter(excludedRepo).GetCluster(rankCtx, namespace)
+		if err != nil {
+			if len(clusters) == 0 {
+				return nil, err
+			}
+			klog.Warningf("could not route namespace %s to more than %d cluster(s): %v", namespace, len(clusters), err)
+			break
+		}
+
+		clusters = append(clusters, *cluster)
+		excludedRepo.excluded[cluster.ClusterId] = true
+	}
+	return clusters, nil
+}
+
+// excludingClusterRepository leaves the excluded cluster IDs out of GetAllWithNamespace
+type excludingClusterRepository struct {
+	repository.ClusterRepository
+	excluded map[string]bool
+}
+
+func (r *excludingClusterRepository) GetAllWithNamespace(namespace string) []domain.KubeCluster {
+	clusters := []domain.KubeCluster{}
+	for _, c := range r.ClusterRepository.GetAllWithNamespace(namespace) {
+		if !r.excluded[c.ClusterId] {
+			clusters = append(clusters, c)
+		}
 	}
-	return clusterRouter, nil
+	return clusters
 }
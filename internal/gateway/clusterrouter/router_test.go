@@ -0,0 +1,49 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterrouter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+)
+
+func TestRankingRouterGetClusters(t *testing.T) {
+	clusterRepo, err := repository.NewLocalClusterRepo([]domain.KubeCluster{
+		{Name: "cluster-a", ClusterId: "a", Namespaces: []domain.KubeNamespace{{Name: "ns"}, {Name: "only-a"}}},
+		{Name: "cluster-b", ClusterId: "b", Namespaces: []domain.KubeNamespace{{Name: "ns"}}},
+		{Name: "cluster-c", ClusterId: "c", Namespaces: []domain.KubeNamespace{{Name: "ns"}}},
+	})
+	assert.NoError(t, err, "creating cluster repository should not error")
+
+	router := NewRankingRouter(clusterRepo, NewRandomClusterRouter)
+
+	clusters, err := router.GetClusters(context.Background(), "ns", 2)
+	assert.NoError(t, err, "ranking should not error")
+	assert.Len(t, clusters, 2, "the requested number of clusters should be returned")
+	assert.NotEqual(t, clusters[0].ClusterId, clusters[1].ClusterId, "ranked clusters should be distinct")
+
+	clusters, err = router.GetClusters(context.Background(), "only-a", 2)
+	assert.NoError(t, err, "ranking should not error")
+	assert.Len(t, clusters, 1, "only clusters with the namespace should be returned")
+
+	_, err = router.GetClusters(context.Background(), "missing", 2)
+	assert.Error(t, err, "routing the first cluster should fail for a namespace in no cluster")
+}
@@ -282,14 +282,17 @@ func GetClusterMetricFamilies(
 		return nil, gatewayerrors.NewFrom(err)
 	}
 
-	metricsUrl := fmt.Sprintf("http://%s:%s%s", *hostname, metricsServerPort, metricsServerEndpoint)
+	client, err := metricsClient(c)
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(err)
+	}
 
-	request, err := http.NewRequest(http.MethodGet, metricsUrl, nil)
+	request, err := http.NewRequest(http.MethodGet, metricsUrl(c, *hostname, metricsServerPort, metricsServerEndpoint), nil)
 	if err != nil {
 		return nil, gatewayerrors.NewFrom(fmt.Errorf("error creating %s request: %w", "GET", err))
 	}
 
-	resp, respBody, err := sgHttp.HttpRequest(ctx, sgHttp.DefaultClient, request)
+	resp, respBody, err := sgHttp.HttpRequest(ctx, client, request)
 	if err != nil {
 		return nil, gatewayerrors.NewFrom(err)
 	}
@@ -16,6 +16,14 @@
 package clusterrouter
 
 import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/slackhq/spark-gateway/internal/domain"
@@ -607,3 +615,70 @@ func TestWeightedChooseClusterID(t *testing.T) {
 		assert.Equal(t, test.expected, c, "failed test: %s", test.name)
 	}
 }
+
+func TestGetClusterMetricFamilies(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/custom/metrics" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `# TYPE spark_application_count gauge
+spark_application_count{cluster="cluster-a"} 3
+`)
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL)
+	assert.NoError(t, err, "parsing test server url should not error")
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	assert.NoError(t, os.WriteFile(caFile, caPEM, 0o600), "writing the CA file should not error")
+
+	var tests = []struct {
+		test          string
+		metricsScrape domain.MetricsScrapeConfig
+		err           bool
+	}{
+		{
+			test: "https with the server CA",
+			metricsScrape: domain.MetricsScrapeConfig{
+				Scheme: domain.MetricsScrapeSchemeHTTPS,
+				Port:   serverUrl.Port(),
+				Path:   "/custom/metrics",
+				TLS:    domain.MetricsTLSConfig{CAFile: caFile},
+			},
+		},
+		{
+			test: "https without trusting the server CA",
+			metricsScrape: domain.MetricsScrapeConfig{
+				Scheme: domain.MetricsScrapeSchemeHTTPS,
+				Port:   serverUrl.Port(),
+				Path:   "/custom/metrics",
+			},
+			err: true,
+		},
+		{
+			test: "http to an https server",
+			metricsScrape: domain.MetricsScrapeConfig{
+				Port: serverUrl.Port(),
+				Path: "/custom/metrics",
+			},
+			err: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.test, func(t *testing.T) {
+			cluster := domain.KubeCluster{Name: "cluster-a", MetricsScrape: test.metricsScrape}
+			metricFamilies, err := GetClusterMetricFamilies(context.Background(), cluster, serverUrl.Hostname(), "1", "/metrics")
+
+			if test.err {
+				assert.Error(t, err, "scraping should error")
+				return
+			}
+			assert.NoError(t, err, "scraping should not error")
+			assert.Contains(t, metricFamilies, "spark_application_count", "metrics should be parsed")
+		})
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+)
+
+// Registry holds all Gateway metrics and is served on the Gateway's /metrics route
+var Registry = prometheus.NewRegistry()
+
+var (
+	// LivyCompensationTotal counts deletes of GatewayApplications whose Livy batch could not be recorded, labeled by
+	// result: "success" or "failure"
+	LivyCompensationTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_livy_compensation_total",
+			Help: "Number of cleanup deletes for Livy creates whose database insert failed",
+		},
+		[]string{"result"},
+	)
+
+	// LivyOrphansTotal counts Livy GatewayApplications found without a Livy batch row by the reconciler, labeled by
+	// result: "deleted" or "failure"
+	LivyOrphansTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_livy_orphans_total",
+			Help: "Number of orphaned Livy GatewayApplications found by the reconciler",
+		},
+		[]string{"result"},
+	)
+
+	// LivyRequestsTotal counts Livy API requests, labeled by operation, e.g. "create" or "state", and status code
+	LivyRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_livy_requests_total",
+			Help: "Number of Livy API requests",
+		},
+		[]string{"operation", "code"},
+	)
+
+	// LivyRequestDuration is the latency of Livy API requests, labeled by operation
+	LivyRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "gateway_livy_request_duration_seconds",
+			Help:    "Latency of Livy API requests",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"operation"},
+	)
+
+	// LivyBatchCreationFailuresTotal counts Livy batches that could not be created, labeled by reason: "submission" when
+	// the GatewayApplication wasn't created or "database" when its Livy batch could not be recorded
+	LivyBatchCreationFailuresTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_livy_batch_creation_failures_total",
+			Help: "Number of Livy batches that could not be created",
+		},
+		[]string{"reason"},
+	)
+
+	// LivyDatabaseDuration is the latency of Livy batch database queries, labeled by operation and result: "success" or
+	// "failure"
+	LivyDatabaseDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "gateway_livy_database_duration_seconds",
+			Help:    "Latency of Livy batch database queries",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"operation", "result"},
+	)
+
+	// SpeculativeSubmissionsTotal counts decided speculative submissions, labeled by the rank of the kept copy's
+	// cluster, winner: "primary" or "secondary", and by reason: "scheduled", "failed", "timeout" or "createFailed"
+	SpeculativeSubmissionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_speculative_submissions_total",
+			Help: "Number of speculative submissions by the copy kept and why",
+		},
+		[]string{"winner", "reason"},
+	)
+
+	// SpeculativeDeletesTotal counts deletes of the copies of speculative submissions that weren't kept, labeled by
+	// result: "success" or "failure"
+	SpeculativeDeletesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_speculative_deletes_total",
+			Help: "Number of deletes of speculative submission copies that weren't kept",
+		},
+		[]string{"result"},
+	)
+
+	// StuckApplications is the number of GatewayApplications the stuck application detector last found, labeled by
+	// cluster and state
+	StuckApplications = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "gateway_stuck_applications",
+			Help: "Number of applications stuck in SUBMITTED or PENDING_RERUN beyond the threshold",
+		},
+		[]string{"cluster", "state"},
+	)
+
+	// SparkManagerAPIVersion is the Gateway to SparkManager API version the SparkManager of each cluster last reported,
+	// labeled by cluster
+	SparkManagerAPIVersion = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "gateway_sparkmanager_api_version",
+			Help: "API version last reported by the SparkManager of each cluster",
+		},
+		[]string{"cluster"},
+	)
+
+	// DeprecatedRequestsTotal counts requests to routes declared deprecated with middleware.Deprecated, labeled by method
+	// and route, so their remaining traffic can be measured before they are removed
+	DeprecatedRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_deprecated_requests_total",
+			Help: "Number of requests to deprecated routes",
+		},
+		[]string{"method", "route"},
+	)
+	// SoftQuotaWarningsTotal counts submissions admitted with a soft quota warning, labeled by the cluster and namespace
+	// they were submitted to
+	SoftQuotaWarningsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_soft_quota_warnings_total",
+			Help: "Number of submissions admitted to namespaces above the soft quota threshold",
+		},
+		[]string{"cluster", "namespace"},
+	)
+	// QueuedSubmissionAttemptsTotal counts attempts to submit queued asynchronous submissions, labeled by cluster and
+	// result: "submitted", "retried" or "failed"
+	QueuedSubmissionAttemptsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_queued_submission_attempts_total",
+			Help: "Number of attempts to submit queued asynchronous submissions",
+		},
+		[]string{"cluster", "result"},
+	)
+	// ScheduledRunsTotal counts runs of ScheduledApplications, labeled by namespace and result: "submitted" or "failed"
+	ScheduledRunsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_scheduled_runs_total",
+			Help: "Number of runs of scheduled submissions",
+		},
+		[]string{"namespace", "result"},
+	)
+	// DeduplicatedRequestsTotal counts SparkManager reads served by sharing the response of an identical read in flight,
+	// labeled by operation: "get", "status" or "logs"
+	DeduplicatedRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_deduplicated_requests_total",
+			Help: "Number of SparkManager reads served by an identical read in flight",
+		},
+		[]string{"operation"},
+	)
+)
+
+func init() {
+	Registry.MustRegister(LivyCompensationTotal, LivyOrphansTotal, LivyRequestsTotal, LivyRequestDuration, LivyBatchCreationFailuresTotal, LivyDatabaseDuration, SpeculativeSubmissionsTotal, SpeculativeDeletesTotal, StuckApplications, SparkManagerAPIVersion, DeprecatedRequestsTotal, SoftQuotaWarningsTotal, QueuedSubmissionAttemptsTotal, ScheduledRunsTotal, DeduplicatedRequestsTotal)
+}
+
+// Routes declares the route serving the Gateway metrics Registry on /metrics
+func Routes() []routes.Route {
+	return []routes.Route{
+		{Method: http.MethodGet, Path: "/metrics", Handler: gin.WrapH(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry}))},
+	}
+}
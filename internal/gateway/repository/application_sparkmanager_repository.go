@@ -16,13 +16,19 @@
 package repository
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"net"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
-	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/klog/v2"
 
 	"github.com/slackhq/spark-gateway/internal/domain"
@@ -33,19 +39,19 @@ import (
 )
 
 // DoHTTP runs a request, checks for errors from making the request or the request body, and returns the Response body bytes
-// if the request succeeds
-func DoHTTP(ctx context.Context, request *http.Request) (*[]byte, error) {
+// if the request succeeds. The Response is returned whenever one was received, with its body already read.
+func DoHTTP(ctx context.Context, request *http.Request) (*http.Response, *[]byte, error) {
 	resp, respBody, err := sgHttp.HttpRequest(ctx, sgHttp.DefaultClient, request)
 	if err != nil {
-		return nil, gatewayerrors.NewFrom(err)
+		return nil, nil, gatewayerrors.NewFrom(err)
 	}
 
 	err = sgHttp.CheckJsonResponse(resp, respBody)
 	if err != nil {
-		return nil, gatewayerrors.NewFrom(err)
+		return resp, nil, gatewayerrors.NewFrom(err)
 	}
 
-	return respBody, nil
+	return resp, respBody, nil
 
 }
 
@@ -88,73 +94,101 @@ func NewSparkManagerRepository(clusters []domain.KubeCluster, sparkManagerHostna
 	}, nil
 }
 
-func (r *SparkManagerRepository) Get(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplication, error) {
+// CheckEndpoints resolves and dials the SparkManager endpoint of every cluster, returning an error naming each cluster
+// whose endpoint can't be reached within timeout, e.g. because of a typo in the hostname template.
+func (r *SparkManagerRepository) CheckEndpoints(ctx context.Context, timeout time.Duration) error {
+	var errs []error
+	for _, clusterName := range slices.Sorted(maps.Keys(r.ClusterEndpoints)) {
+		endpoint, err := url.Parse(r.ClusterEndpoints[clusterName])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cluster '%s' SparkManager endpoint '%s' is invalid: %w", clusterName, r.ClusterEndpoints[clusterName], err))
+			continue
+		}
 
-	clusterEndpoint := r.ClusterEndpoints[cluster.Name]
-	// Url: http://host:port/api/v1/namespace/name
-	url := fmt.Sprintf("%s/%s/%s", clusterEndpoint, namespace, name)
+		if err := checkEndpoint(ctx, endpoint, timeout); err != nil {
+			errs = append(errs, fmt.Errorf("cluster '%s' SparkManager endpoint '%s' is not reachable: %w", clusterName, endpoint.Host, err))
+		}
+	}
 
-	request, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, gatewayerrors.NewFrom(fmt.Errorf("error creating %s request: %w", http.MethodGet, err))
+	return errors.Join(errs...)
+}
+
+// ClusterOf returns the cluster whose SparkManager req is sent to, or "" when req isn't sent to a SparkManager
+func (r *SparkManagerRepository) ClusterOf(req *http.Request) string {
+	for clusterName, clusterEndpoint := range r.ClusterEndpoints {
+		endpoint, err := url.Parse(clusterEndpoint)
+		if err == nil && endpoint.Host == req.URL.Host {
+			return clusterName
+		}
 	}
+	return ""
+}
+
+func checkEndpoint(ctx context.Context, endpoint *url.URL, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	respBody, err := DoHTTP(ctx, request)
+	if _, err := net.DefaultResolver.LookupHost(ctx, endpoint.Hostname()); err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", endpoint.Host)
 	if err != nil {
-		return nil, gatewayerrors.NewFrom(err)
+		return err
 	}
+	return conn.Close()
+}
+
+// Client returns the SparkManagerClient of cluster's SparkManager
+func (r *SparkManagerRepository) Client(cluster domain.KubeCluster) *SparkManagerClient {
+	return NewSparkManagerClient(cluster.Name, r.ClusterEndpoints[cluster.Name])
+}
+
+func (r *SparkManagerRepository) Get(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplication, error) {
 
+	// Url: http://host:port/api/v1/namespace/name
 	var sparkApp v1beta2.SparkApplication
-	if err := json.Unmarshal(*respBody, &sparkApp); err != nil {
-		return nil, fmt.Errorf("failed to Unmarshal JSON response: %w", err)
+	if err := r.Client(cluster).Do(ctx, http.MethodGet, nil, nil, &sparkApp, namespace, name); err != nil {
+		return nil, err
 	}
 
 	return &sparkApp, nil
 }
 
-func (r *SparkManagerRepository) List(ctx context.Context, cluster domain.KubeCluster, namespace string) ([]*domain.SparkManagerSparkApplicationSummary, error) {
-
-	clusterEndpoint := r.ClusterEndpoints[cluster.Name]
-	// Url: http://host:port/api/v1/namespace
-	url := fmt.Sprintf("%s/%s", clusterEndpoint, namespace)
+func (r *SparkManagerRepository) List(ctx context.Context, cluster domain.KubeCluster, namespace string, view domain.SummaryView) ([]*domain.SparkManagerSparkApplicationSummary, error) {
 
-	request, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, gatewayerrors.NewFrom(fmt.Errorf("error creating %s request: %w", http.MethodGet, err))
-	}
-
-	respBody, err := DoHTTP(ctx, request)
-	if err != nil {
-		return nil, gatewayerrors.NewFrom(err)
-	}
+	// Url: http://host:port/api/v1/namespace?resourceVersion=0&view=view
+	// The Gateway accepts a list from a stale cache over failing the aggregate it is part of
+	query := url.Values{"view": {string(view)}, "resourceVersion": {"0"}}
 
 	var summaryList []*domain.SparkManagerSparkApplicationSummary
-	if err := json.Unmarshal(*respBody, &summaryList); err != nil {
-		return nil, fmt.Errorf("failed to Unmarshal JSON response: %w", err)
+	if err := r.Client(cluster).Do(ctx, http.MethodGet, query, nil, &summaryList, namespace); err != nil {
+		return nil, err
 	}
 
 	return summaryList, nil
 }
 
-func (r *SparkManagerRepository) Status(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplicationStatus, error) {
+func (r *SparkManagerRepository) Counts(ctx context.Context, cluster domain.KubeCluster, namespace string) (*domain.SparkManagerApplicationCounts, error) {
 
-	clusterEndpoint := r.ClusterEndpoints[cluster.Name]
-	// Url: http://host:port/api/v1/namespace/name/status
-	url := fmt.Sprintf("%s/%s/%s/status", clusterEndpoint, namespace, name)
+	// Url: http://host:port/api/v1/namespace/counts?resourceVersion=0
+	query := url.Values{"resourceVersion": {"0"}}
 
-	request, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, gatewayerrors.NewFrom(fmt.Errorf("error creating %s request: %w", http.MethodGet, err))
+	var counts domain.SparkManagerApplicationCounts
+	if err := r.Client(cluster).Do(ctx, http.MethodGet, query, nil, &counts, namespace, "counts"); err != nil {
+		return nil, err
 	}
 
-	respBody, err := DoHTTP(ctx, request)
-	if err != nil {
-		return nil, gatewayerrors.NewFrom(err)
-	}
+	return &counts, nil
+}
+
+func (r *SparkManagerRepository) Status(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*domain.ApplicationStatus, error) {
 
-	var appStatus v1beta2.SparkApplicationStatus
-	if err := json.Unmarshal(*respBody, &appStatus); err != nil {
-		return nil, fmt.Errorf("failed to Unmarshal JSON response: %w", err)
+	// Url: http://host:port/api/v1/namespace/name/status
+	var appStatus domain.ApplicationStatus
+	if err := r.Client(cluster).Do(ctx, http.MethodGet, nil, nil, &appStatus, namespace, name, "status"); err != nil {
+		return nil, err
 	}
 
 	return &appStatus, nil
@@ -162,52 +196,122 @@ func (r *SparkManagerRepository) Status(ctx context.Context, cluster domain.Kube
 
 func (r *SparkManagerRepository) Logs(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, tailLines int) (*string, error) {
 
-	clusterEndpoint := r.ClusterEndpoints[cluster.Name]
 	// Url: http://host:port/api/v1/namespace/name/logs?lines=lineCount
-	url := fmt.Sprintf("%s/%s/%s/logs?lines=%d", clusterEndpoint, namespace, name, tailLines)
+	query := url.Values{"lines": {strconv.Itoa(tailLines)}}
 
-	request, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, gatewayerrors.NewFrom(fmt.Errorf("error creating %s request: %w", http.MethodGet, err))
+	var logString string
+	if err := r.Client(cluster).Do(ctx, http.MethodGet, query, nil, &logString, namespace, name, "logs"); err != nil {
+		return nil, err
 	}
 
-	respBody, err := DoHTTP(ctx, request)
-	if err != nil {
-		return nil, gatewayerrors.NewFrom(err)
-	}
+	return &logString, nil
+}
+
+// ExecutorLogs returns the last tailLines lines of the logs of the executor with executorId from SparkManager
+func (r *SparkManagerRepository) ExecutorLogs(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, executorId string, tailLines int) (*string, error) {
+
+	// Url: http://host:port/api/v1/namespace/name/logs?pod=executor&executorId=id&lines=lineCount
+	query := url.Values{"pod": {"executor"}, "executorId": {executorId}, "lines": {strconv.Itoa(tailLines)}}
 
 	var logString string
-	if err := json.Unmarshal(*respBody, &logString); err != nil {
-		return nil, fmt.Errorf("failed to Unmarshal JSON response: %w", err)
+	if err := r.Client(cluster).Do(ctx, http.MethodGet, query, nil, &logString, namespace, name, "logs"); err != nil {
+		return nil, err
 	}
 
 	return &logString, nil
 }
 
-func (r *SparkManagerRepository) Create(ctx context.Context, cluster domain.KubeCluster, sparkApp *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
+// Pods returns the driver and executor pods of a SparkApplication from SparkManager
+func (r *SparkManagerRepository) Pods(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) ([]*domain.SparkApplicationPod, error) {
 
-	clusterEndpoint := r.ClusterEndpoints[cluster.Name]
-	// Url: http://host:port/api/v1/namespace/name
-	url := fmt.Sprintf("%s/%s/%s", clusterEndpoint, sparkApp.Namespace, sparkApp.Name)
+	// Url: http://host:port/api/v1/namespace/name/pods
+	var pods []*domain.SparkApplicationPod
+	if err := r.Client(cluster).Do(ctx, http.MethodGet, nil, nil, &pods, namespace, name, "pods"); err != nil {
+		return nil, err
+	}
 
-	body, err := json.Marshal(sparkApp)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal SparkApplication: %w", err)
+	return pods, nil
+}
+
+// Cost returns the estimated cost of a SparkApplication from SparkManager, nil if it hasn't been submitted yet
+func (r *SparkManagerRepository) Cost(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*domain.ApplicationCost, error) {
+
+	// Url: http://host:port/api/v1/namespace/name/cost
+	var cost *domain.ApplicationCost
+	if err := r.Client(cluster).Do(ctx, http.MethodGet, nil, nil, &cost, namespace, name, "cost"); err != nil {
+		return nil, err
 	}
-	request, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
-	if err != nil {
-		return nil, gatewayerrors.NewFrom(fmt.Errorf("error creating %s request: %w", http.MethodPost, err))
+
+	return cost, nil
+}
+
+// FollowLogs returns a stream of the driver logs from SparkManager, starting from the last tailLines lines, that tails
+// them live. The caller is responsible for closing the stream.
+func (r *SparkManagerRepository) FollowLogs(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, tailLines int) (io.ReadCloser, error) {
+
+	// Url: http://host:port/api/v1/namespace/name/logs?lines=lineCount&follow=true
+	query := url.Values{"lines": {strconv.Itoa(tailLines)}, "follow": {"true"}}
+
+	return r.Client(cluster).Stream(ctx, sgHttp.StreamingClient, query, namespace, name, "logs")
+}
+
+// StreamLogs returns a stream of the complete driver logs from SparkManager. The caller is responsible for closing the stream.
+func (r *SparkManagerRepository) StreamLogs(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (io.ReadCloser, error) {
+
+	// Url: http://host:port/api/v1/namespace/name/logs/download
+	return r.Client(cluster).Stream(ctx, sgHttp.StreamingClient, nil, namespace, name, "logs", "download")
+}
+
+// StreamStatus returns the newline delimited stream of domain.ApplicationStatus SparkManager sends each time the state
+// of the SparkApplication changes. The caller is responsible for closing the stream.
+func (r *SparkManagerRepository) StreamStatus(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (io.ReadCloser, error) {
+
+	// Url: http://host:port/api/v1/namespace/name/status/stream
+	return r.Client(cluster).Stream(ctx, sgHttp.StreamingClient, nil, namespace, name, "status", "stream")
+}
+
+// DriverMetrics returns the Prometheus metrics of the driver from SparkManager. The caller is responsible for closing the
+// stream.
+func (r *SparkManagerRepository) DriverMetrics(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (io.ReadCloser, error) {
+
+	// Url: http://host:port/api/v1/namespace/name/metrics
+	return r.Client(cluster).Stream(ctx, sgHttp.DefaultClient, nil, namespace, name, "metrics")
+}
+
+// Timeline returns the lifecycle events SparkManager recorded for a SparkApplication, oldest first
+func (r *SparkManagerRepository) Timeline(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) ([]domain.TimelineEvent, error) {
+
+	// Url: http://host:port/api/v1/namespace/name/timeline
+	var events []domain.TimelineEvent
+	if err := r.Client(cluster).Do(ctx, http.MethodGet, nil, nil, &events, namespace, name, "timeline"); err != nil {
+		return nil, err
 	}
-	request.Header.Set("Content-Type", "application/json")
 
-	respBody, err := DoHTTP(ctx, request)
-	if err != nil {
-		return nil, gatewayerrors.NewFrom(err)
+	return events, nil
+}
+
+// Watch returns the newline delimited stream of domain.SparkManagerWatchEvent for namespace from SparkManager, starting
+// after resourceVersion if set. The caller is responsible for closing the stream.
+func (r *SparkManagerRepository) Watch(ctx context.Context, cluster domain.KubeCluster, namespace string, labelSelector string, resourceVersion string) (io.ReadCloser, error) {
+
+	// Url: http://host:port/api/v1/namespace/watch?labelSelector=...&resourceVersion=...
+	query := url.Values{}
+	if labelSelector != "" {
+		query.Set("labelSelector", labelSelector)
 	}
+	if resourceVersion != "" {
+		query.Set("resourceVersion", resourceVersion)
+	}
+
+	return r.Client(cluster).Stream(ctx, sgHttp.StreamingClient, query, namespace, "watch")
+}
 
+func (r *SparkManagerRepository) Create(ctx context.Context, cluster domain.KubeCluster, sparkApp *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
+
+	// Url: http://host:port/api/v1/namespace/name
 	var respApp v1beta2.SparkApplication
-	if err := json.Unmarshal(*respBody, &respApp); err != nil {
-		return nil, fmt.Errorf("failed to Unmarshal JSON response: %w", err)
+	if err := r.Client(cluster).Do(ctx, http.MethodPost, nil, sparkApp, &respApp, sparkApp.Namespace, sparkApp.Name); err != nil {
+		return nil, err
 	}
 
 	return &respApp, nil
@@ -215,19 +319,53 @@ func (r *SparkManagerRepository) Create(ctx context.Context, cluster domain.Kube
 
 func (r *SparkManagerRepository) Delete(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) error {
 
-	clusterEndpoint := r.ClusterEndpoints[cluster.Name]
 	// Url: http://host:port/api/v1/namespace/name
-	url := fmt.Sprintf("%s/%s/%s", clusterEndpoint, namespace, name)
+	return r.Client(cluster).Do(ctx, http.MethodDelete, nil, nil, nil, namespace, name)
+}
 
-	request, err := http.NewRequest(http.MethodDelete, url, nil)
-	if err != nil {
-		return gatewayerrors.NewFrom(fmt.Errorf("error creating %s request: %w", http.MethodDelete, err))
+// Scale asks the cluster's SparkManager to change the executor count of a running SparkApplication, returning the
+// patched SparkApplication
+func (r *SparkManagerRepository) Scale(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, scale domain.ExecutorScale) (*v1beta2.SparkApplication, error) {
+
+	// Url: http://host:port/api/v1/namespace/name/scale
+	var respApp v1beta2.SparkApplication
+	if err := r.Client(cluster).Do(ctx, http.MethodPost, nil, scale, &respApp, namespace, name, "scale"); err != nil {
+		return nil, err
 	}
 
-	_, err = DoHTTP(ctx, request)
-	if err != nil {
-		return gatewayerrors.NewFrom(err)
+	return &respApp, nil
+}
+
+// Update asks the cluster's SparkManager to change the labels, TTL or maxExecutors of a SparkApplication that hasn't
+// started running, returning the updated SparkApplication
+func (r *SparkManagerRepository) Update(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, update domain.ApplicationUpdate) (*v1beta2.SparkApplication, error) {
+
+	// Url: http://host:port/api/v1/namespace/name
+	var respApp v1beta2.SparkApplication
+	if err := r.Client(cluster).Do(ctx, http.MethodPatch, nil, update, &respApp, namespace, name); err != nil {
+		return nil, err
+	}
+
+	return &respApp, nil
+}
+
+// RenderPods asks the cluster's SparkManager to render the driver and executor pods sparkApp would run, without
+// creating it
+func (r *SparkManagerRepository) RenderPods(ctx context.Context, cluster domain.KubeCluster, sparkApp *v1beta2.SparkApplication) (*domain.RenderedPods, error) {
+
+	// Url: http://host:port/api/v1/namespace/name/render
+	var pods domain.RenderedPods
+	if err := r.Client(cluster).Do(ctx, http.MethodPost, nil, sparkApp, &pods, sparkApp.Namespace, sparkApp.Name, "render"); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &pods, nil
+}
+
+// ProvisionNamespace asks the cluster's SparkManager to create namespace with the RBAC Spark drivers need. Resources
+// that already exist are left as they are.
+func (r *SparkManagerRepository) ProvisionNamespace(ctx context.Context, cluster domain.KubeCluster, namespace string, provisioning domain.NamespaceProvisioning) error {
+
+	// Url: http://host:port/api/v1/namespace
+	return r.Client(cluster).Do(ctx, http.MethodPut, nil, provisioning, nil, namespace)
 }
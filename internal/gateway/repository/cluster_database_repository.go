@@ -0,0 +1,196 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+// DatabaseClusterRepo persists the namespaces registered and the namespace routing weights set at runtime on top of the
+// wrapped ClusterRepository, so they survive restarts. Every Gateway instance applies the persisted namespaces and
+// weights on Refresh.
+type DatabaseClusterRepo struct {
+	ClusterRepository
+	weights    database.NamespaceRoutingWeightDatabase
+	namespaces database.RegisteredNamespaceDatabase
+}
+
+// NewDatabaseClusterRepo returns a DatabaseClusterRepo over clusterRepository with the persisted namespaces and weights
+// applied
+func NewDatabaseClusterRepo(ctx context.Context, clusterRepository ClusterRepository, weights database.NamespaceRoutingWeightDatabase, namespaces database.RegisteredNamespaceDatabase) (*DatabaseClusterRepo, error) {
+	repo := &DatabaseClusterRepo{ClusterRepository: clusterRepository, weights: weights, namespaces: namespaces}
+	if err := repo.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// AddNamespace persists the namespace before calling prepare and adding it, so it isn't lost if the Gateway restarts
+// and other Gateway instances add it on Refresh. Namespaces registered by another Gateway instance are rejected before
+// prepare is called, and the persisted namespace is deleted again if it isn't added.
+func (r *DatabaseClusterRepo) AddNamespace(ctx context.Context, cluster string, namespace domain.KubeNamespace, prepare func() error) (*domain.KubeCluster, error) {
+	kubeCluster, err := r.GetByName(cluster)
+	if err != nil {
+		return nil, gatewayerrors.NewNotFound(err)
+	}
+	if _, err := withNamespace(*kubeCluster, namespace); err != nil {
+		return nil, err
+	}
+
+	inserted, err := r.namespaces.InsertRegisteredNamespace(ctx, cluster, namespace, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error persisting namespace: %w", err)
+	}
+	if !inserted {
+		return nil, gatewayerrors.NewConflict(fmt.Errorf("namespace '%s' or namespace id '%s' is already registered in cluster '%s' by another Gateway instance", namespace.Name, namespace.NamespaceId, cluster))
+	}
+
+	kubeCluster, err = r.add(ctx, cluster, namespace, prepare)
+	if err != nil {
+		if deleteErr := r.namespaces.DeleteRegisteredNamespace(ctx, cluster, namespace.Name); deleteErr != nil {
+			klog.Errorf("error deleting persisted namespace '%s' of cluster '%s' that wasn't added: %v", namespace.Name, cluster, deleteErr)
+		}
+		return nil, err
+	}
+
+	return kubeCluster, nil
+}
+
+// add calls prepare and adds namespace to the wrapped ClusterRepository. A concurrent Refresh may already have added
+// the namespace from its persisted row, which isn't an error as long as it was added unchanged.
+func (r *DatabaseClusterRepo) add(ctx context.Context, cluster string, namespace domain.KubeNamespace, prepare func() error) (*domain.KubeCluster, error) {
+	if prepare != nil {
+		if err := prepare(); err != nil {
+			return nil, err
+		}
+	}
+
+	added, err := r.ClusterRepository.AddNamespace(ctx, cluster, namespace, nil)
+	if err == nil {
+		return added, nil
+	}
+
+	kubeCluster, getErr := r.GetByName(cluster)
+	if getErr != nil {
+		return nil, err
+	}
+	if ns, _ := kubeCluster.GetNamespaceByName(namespace.Name); ns != nil && reflect.DeepEqual(*ns, namespace) {
+		return kubeCluster, nil
+	}
+
+	return nil, err
+}
+
+// SetNamespaceWeight persists the routing weight before setting it, so it isn't lost if the Gateway restarts
+func (r *DatabaseClusterRepo) SetNamespaceWeight(ctx context.Context, weight domain.NamespaceWeight) (*domain.NamespaceWeight, error) {
+	kubeCluster, err := r.GetByName(weight.Cluster)
+	if err != nil {
+		return nil, gatewayerrors.NewNotFound(err)
+	}
+	if ns, _ := kubeCluster.GetNamespaceByName(weight.Namespace); ns == nil {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("namespace '%s' is not registered in cluster '%s'", weight.Namespace, weight.Cluster))
+	}
+
+	if err := r.weights.UpsertNamespaceRoutingWeight(ctx, database.NamespaceRoutingWeight{
+		Cluster:   weight.Cluster,
+		Namespace: weight.Namespace,
+		Weight:    weight.RoutingWeight,
+		UpdatedBy: weight.UpdatedBy,
+		UpdatedAt: weight.UpdatedAt,
+	}); err != nil {
+		return nil, fmt.Errorf("error persisting routing weight: %w", err)
+	}
+
+	set, err := r.ClusterRepository.SetNamespaceWeight(ctx, weight)
+	if err != nil {
+		return nil, err
+	}
+	set.Persisted = true
+
+	return set, nil
+}
+
+// Refresh adds the persisted namespaces and applies the persisted routing weights, including those registered and set
+// by other Gateway instances. Namespaces already configured are left as they are and weights of namespaces no longer
+// registered are skipped.
+func (r *DatabaseClusterRepo) Refresh(ctx context.Context) error {
+	namespaces, err := r.namespaces.ListRegisteredNamespaces(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, namespace := range namespaces {
+		kubeCluster, err := r.GetByName(namespace.Cluster)
+		if err != nil {
+			klog.Warningf("skipping persisted namespace '%s' of cluster '%s': %v", namespace.Namespace, namespace.Cluster, err)
+			continue
+		}
+		if ns, _ := kubeCluster.GetNamespaceByName(namespace.Namespace); ns != nil {
+			continue
+		}
+
+		if _, err := r.ClusterRepository.AddNamespace(ctx, namespace.Cluster, *namespace.Spec, nil); err != nil {
+			klog.Warningf("skipping persisted namespace '%s' of cluster '%s': %v", namespace.Namespace, namespace.Cluster, err)
+		}
+	}
+
+	weights, err := r.weights.ListNamespaceRoutingWeights(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, weight := range weights {
+		_, err := r.ClusterRepository.SetNamespaceWeight(ctx, domain.NamespaceWeight{
+			Cluster:       weight.Cluster,
+			Namespace:     weight.Namespace,
+			RoutingWeight: weight.Weight,
+			UpdatedBy:     weight.UpdatedBy,
+			UpdatedAt:     weight.UpdatedAt,
+		})
+		if err != nil {
+			klog.Warningf("skipping persisted routing weight of namespace '%s' in cluster '%s': %v", weight.Namespace, weight.Cluster, err)
+		}
+	}
+
+	return nil
+}
+
+// Run calls Refresh every interval until ctx is done
+func (r *DatabaseClusterRepo) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Refresh(ctx); err != nil {
+				klog.Errorf("error refreshing namespaces and routing weights: %v", err)
+			}
+		}
+	}
+}
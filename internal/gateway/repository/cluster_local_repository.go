@@ -16,9 +16,15 @@
 package repository
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
 	"k8s.io/klog/v2"
 )
 
@@ -29,9 +35,14 @@ type ClusterRepository interface {
 	GetById(clusterId string) (*domain.KubeCluster, error)
 	GetAll() []domain.KubeCluster
 	GetAllWithNamespace(namespace string) []domain.KubeCluster
+	AddNamespace(ctx context.Context, cluster string, namespace domain.KubeNamespace, prepare func() error) (*domain.KubeCluster, error)
+	SetNamespaceWeight(ctx context.Context, weight domain.NamespaceWeight) (*domain.NamespaceWeight, error)
 }
 
+// LocalClusterRepo serves the clusters from config. Namespaces added and weights set at runtime are held in memory only,
+// wrap it in a DatabaseClusterRepo to persist them and share them between Gateway instances.
 type LocalClusterRepo struct {
+	mu           sync.RWMutex
 	KubeClusters map[string]domain.KubeCluster
 }
 
@@ -51,6 +62,9 @@ func NewLocalClusterRepo(clusters []domain.KubeCluster) (*LocalClusterRepo, erro
 }
 
 func (r *LocalClusterRepo) GetByName(cluster string) (*domain.KubeCluster, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	for _, kubeCluster := range r.KubeClusters {
 		if kubeCluster.Name == cluster {
 			return &kubeCluster, nil
@@ -61,6 +75,8 @@ func (r *LocalClusterRepo) GetByName(cluster string) (*domain.KubeCluster, error
 }
 
 func (r *LocalClusterRepo) GetById(clusterId string) (*domain.KubeCluster, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
 	cluster, ok := r.KubeClusters[clusterId]
 
@@ -72,6 +88,9 @@ func (r *LocalClusterRepo) GetById(clusterId string) (*domain.KubeCluster, error
 }
 
 func (r *LocalClusterRepo) GetAll() []domain.KubeCluster {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	var clusters []domain.KubeCluster
 
 	for _, cluster := range r.KubeClusters {
@@ -106,3 +125,84 @@ func (r *LocalClusterRepo) GetAllWithNamespace(namespace string) []domain.KubeCl
 
 	return clusters
 }
+
+// AddNamespace adds namespace to cluster, returning the updated cluster. The namespace name must not already be
+// configured in cluster and the cluster must remain valid with it. prepare, if not nil, is called once the namespace has
+// been checked and before it is added, which it isn't if prepare fails.
+func (r *LocalClusterRepo) AddNamespace(ctx context.Context, cluster string, namespace domain.KubeNamespace, prepare func() error) (*domain.KubeCluster, error) {
+	if prepare != nil {
+		kubeCluster, err := r.GetByName(cluster)
+		if err != nil {
+			return nil, gatewayerrors.NewNotFound(err)
+		}
+		if _, err := withNamespace(*kubeCluster, namespace); err != nil {
+			return nil, err
+		}
+		if err := prepare(); err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for clusterId, kubeCluster := range r.KubeClusters {
+		if kubeCluster.Name != cluster {
+			continue
+		}
+
+		kubeCluster, err := withNamespace(kubeCluster, namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		r.KubeClusters[clusterId] = kubeCluster
+		klog.Infof("Registered namespace '%s' with id '%s' in cluster '%s'", namespace.Name, namespace.NamespaceId, cluster)
+
+		return &kubeCluster, nil
+	}
+
+	return nil, gatewayerrors.NewNotFound(fmt.Errorf("cluster does not exist: %s", cluster))
+}
+
+// withNamespace returns a copy of kubeCluster with namespace added, erroring if its name is already configured in
+// kubeCluster or the cluster would be invalid with it
+func withNamespace(kubeCluster domain.KubeCluster, namespace domain.KubeNamespace) (domain.KubeCluster, error) {
+	if ns, _ := kubeCluster.GetNamespaceByName(namespace.Name); ns != nil {
+		return domain.KubeCluster{}, gatewayerrors.NewConflict(fmt.Errorf("namespace '%s' is already registered in cluster '%s'", namespace.Name, kubeCluster.Name))
+	}
+
+	// Copy Namespaces so readers holding the previous cluster are unaffected
+	kubeCluster.Namespaces = append(slices.Clone(kubeCluster.Namespaces), namespace)
+	if errMessages := domain.ValidateCluster(kubeCluster); len(errMessages) > 0 {
+		return domain.KubeCluster{}, gatewayerrors.NewInvalid(errors.New(strings.Join(errMessages, ", ")))
+	}
+
+	return kubeCluster, nil
+}
+
+// SetNamespaceWeight sets the routing weight of a namespace configured in a cluster
+func (r *LocalClusterRepo) SetNamespaceWeight(ctx context.Context, weight domain.NamespaceWeight) (*domain.NamespaceWeight, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for clusterId, kubeCluster := range r.KubeClusters {
+		if kubeCluster.Name != weight.Cluster {
+			continue
+		}
+
+		idx := slices.IndexFunc(kubeCluster.Namespaces, func(ns domain.KubeNamespace) bool { return ns.Name == weight.Namespace })
+		if idx < 0 {
+			return nil, gatewayerrors.NewNotFound(fmt.Errorf("namespace '%s' is not registered in cluster '%s'", weight.Namespace, weight.Cluster))
+		}
+
+		// Copy Namespaces so readers holding the previous cluster are unaffected
+		kubeCluster.Namespaces = slices.Clone(kubeCluster.Namespaces)
+		kubeCluster.Namespaces[idx].RoutingWeight = weight.RoutingWeight
+		r.KubeClusters[clusterId] = kubeCluster
+
+		return &weight, nil
+	}
+
+	return nil, gatewayerrors.NewNotFound(fmt.Errorf("cluster does not exist: %s", weight.Cluster))
+}
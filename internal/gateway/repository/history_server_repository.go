@@ -0,0 +1,218 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	sgHttp "github.com/slackhq/spark-gateway/internal/shared/http"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+)
+
+const (
+	// historyServerTimeLayout is the format of times in Spark History Server responses
+	historyServerTimeLayout = "2006-01-02T15:04:05.000GMT"
+	// maxSummaryStages bounds the failed and longest stages listed in a HistoryServerSummary
+	maxSummaryStages = 5
+)
+
+type historyServerApplication struct {
+	ID       string                            `json:"id"`
+	Attempts []historyServerApplicationAttempt `json:"attempts"`
+}
+
+type historyServerApplicationAttempt struct {
+	AttemptID string `json:"attemptId"`
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+	Duration  int64  `json:"duration"`
+}
+
+type historyServerJob struct {
+	Status string `json:"status"`
+}
+
+type historyServerStage struct {
+	StageID        int    `json:"stageId"`
+	AttemptID      int    `json:"attemptId"`
+	Name           string `json:"name"`
+	Status         string `json:"status"`
+	SubmissionTime string `json:"submissionTime"`
+	CompletionTime string `json:"completionTime"`
+	FailureReason  string `json:"failureReason"`
+}
+
+// HistoryServerRepository reads completed Spark applications from the Spark History Server REST API of each cluster
+type HistoryServerRepository struct {
+	ClusterEndpoints map[string]string
+	client           *http.Client
+}
+
+// NewHistoryServerRepository returns a HistoryServerRepository reaching the Spark History Server of each cluster at
+// urlTemplate, rendered with the cluster's name as clusterName. Each request is bounded by timeout.
+func NewHistoryServerRepository(clusters []domain.KubeCluster, urlTemplate string, timeout time.Duration) (*HistoryServerRepository, error) {
+
+	clusterEndpoints := map[string]string{}
+	for _, kubeCluster := range clusters {
+		endpoint, err := util.RenderTemplate(urlTemplate, map[string]string{"clusterName": kubeCluster.Name})
+		if err != nil {
+			return nil, fmt.Errorf("error while formatting Spark History Server URL: %w", err)
+		}
+
+		clusterEndpoints[kubeCluster.Name] = strings.TrimSuffix(*endpoint, "/") + "/api/v1"
+		klog.Infof("Cluster %s configured with Spark History Server: %s", kubeCluster.Name, *endpoint)
+	}
+
+	return &HistoryServerRepository{
+		ClusterEndpoints: clusterEndpoints,
+		client:           &http.Client{Timeout: timeout, Transport: sgHttp.DefaultClient.Transport},
+	}, nil
+}
+
+// Summary returns a summary of the latest attempt of the Spark application sparkApplicationID in cluster: its timings,
+// job and stage counts, failed stages with their failure reasons and longest stages
+func (r *HistoryServerRepository) Summary(ctx context.Context, cluster domain.KubeCluster, sparkApplicationID string) (*domain.HistoryServerSummary, error) {
+
+	// Url: http://host:port/api/v1/applications/appId
+	appURL := fmt.Sprintf("%s/applications/%s", r.ClusterEndpoints[cluster.Name], url.PathEscape(sparkApplicationID))
+
+	var application historyServerApplication
+	if err := r.get(ctx, appURL, &application); err != nil {
+		return nil, err
+	}
+	if len(application.Attempts) == 0 {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("Spark History Server has no attempts of application '%s'", sparkApplicationID))
+	}
+
+	// Attempts are listed latest first, applications with a single attempt have no attemptId
+	attempt := application.Attempts[0]
+	attemptURL := appURL
+	if attempt.AttemptID != "" {
+		attemptURL = fmt.Sprintf("%s/%s", appURL, url.PathEscape(attempt.AttemptID))
+	}
+
+	var jobs []historyServerJob
+	if err := r.get(ctx, attemptURL+"/jobs", &jobs); err != nil {
+		return nil, err
+	}
+
+	var stages []historyServerStage
+	if err := r.get(ctx, attemptURL+"/stages", &stages); err != nil {
+		return nil, err
+	}
+
+	summary := &domain.HistoryServerSummary{
+		SparkApplicationID: sparkApplicationID,
+		StartTime:          parseHistoryServerTime(attempt.StartTime),
+		EndTime:            parseHistoryServerTime(attempt.EndTime),
+		DurationSeconds:    time.Duration(attempt.Duration * int64(time.Millisecond)).Seconds(),
+		Attempts:           len(application.Attempts),
+	}
+
+	for _, job := range jobs {
+		switch job.Status {
+		case "SUCCEEDED":
+			summary.Jobs.Succeeded++
+		case "FAILED":
+			summary.Jobs.Failed++
+		default:
+			summary.Jobs.Other++
+		}
+	}
+
+	var completed []domain.HistoryServerStage
+	for _, stage := range stages {
+		summaryStage := domain.HistoryServerStage{
+			StageID:   stage.StageID,
+			AttemptID: stage.AttemptID,
+			Name:      stage.Name,
+			Status:    stage.Status,
+		}
+		if submitted, completedAt := parseHistoryServerTime(stage.SubmissionTime), parseHistoryServerTime(stage.CompletionTime); submitted != nil && completedAt != nil {
+			summaryStage.DurationSeconds = completedAt.Sub(*submitted).Seconds()
+		}
+
+		switch stage.Status {
+		case "COMPLETE":
+			summary.Stages.Complete++
+			completed = append(completed, summaryStage)
+		case "FAILED":
+			summary.Stages.Failed++
+			summaryStage.FailureReason = stage.FailureReason
+			if len(summary.FailedStages) < maxSummaryStages {
+				summary.FailedStages = append(summary.FailedStages, summaryStage)
+			}
+		case "SKIPPED":
+			summary.Stages.Skipped++
+		default:
+			summary.Stages.Other++
+		}
+	}
+
+	slices.SortStableFunc(completed, func(a, b domain.HistoryServerStage) int {
+		return cmp.Compare(b.DurationSeconds, a.DurationSeconds)
+	})
+	summary.LongestStages = completed[:min(len(completed), maxSummaryStages)]
+
+	return summary, nil
+}
+
+// get unmarshals the JSON response to a GET of endpoint into out
+func (r *HistoryServerRepository) get(ctx context.Context, endpoint string, out any) error {
+	request, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return gatewayerrors.NewFrom(fmt.Errorf("error creating %s request: %w", http.MethodGet, err))
+	}
+
+	resp, respBody, err := sgHttp.HttpRequest(ctx, r.client, request)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return gatewayerrors.New(resp.StatusCode, fmt.Errorf("Spark History Server returned %d for %s: %s", resp.StatusCode, endpoint, strings.TrimSpace(string(*respBody))))
+	}
+
+	if err := json.Unmarshal(*respBody, out); err != nil {
+		return fmt.Errorf("failed to Unmarshal Spark History Server response: %w", err)
+	}
+
+	return nil
+}
+
+// parseHistoryServerTime returns the time in a Spark History Server response, or nil if it is unset or malformed
+func parseHistoryServerTime(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+
+	parsed, err := time.Parse(historyServerTimeLayout, value)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
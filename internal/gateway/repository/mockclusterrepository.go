@@ -4,6 +4,7 @@
 package repository
 
 import (
+	"context"
 	"github.com/slackhq/spark-gateway/internal/domain"
 	"sync"
 )
@@ -18,6 +19,9 @@ var _ ClusterRepository = &ClusterRepositoryMock{}
 //
 //		// make and configure a mocked ClusterRepository
 //		mockedClusterRepository := &ClusterRepositoryMock{
+//			AddNamespaceFunc: func(ctx context.Context, cluster string, namespace domain.KubeNamespace, prepare func() error) (*domain.KubeCluster, error) {
+//				panic("mock out the AddNamespace method")
+//			},
 //			GetAllFunc: func() []domain.KubeCluster {
 //				panic("mock out the GetAll method")
 //			},
@@ -30,6 +34,9 @@ var _ ClusterRepository = &ClusterRepositoryMock{}
 //			GetByNameFunc: func(cluster string) (*domain.KubeCluster, error) {
 //				panic("mock out the GetByName method")
 //			},
+//			SetNamespaceWeightFunc: func(ctx context.Context, weight domain.NamespaceWeight) (*domain.NamespaceWeight, error) {
+//				panic("mock out the SetNamespaceWeight method")
+//			},
 //		}
 //
 //		// use mockedClusterRepository in code that requires ClusterRepository
@@ -37,6 +44,9 @@ var _ ClusterRepository = &ClusterRepositoryMock{}
 //
 //	}
 type ClusterRepositoryMock struct {
+	// AddNamespaceFunc mocks the AddNamespace method.
+	AddNamespaceFunc func(ctx context.Context, cluster string, namespace domain.KubeNamespace, prepare func() error) (*domain.KubeCluster, error)
+
 	// GetAllFunc mocks the GetAll method.
 	GetAllFunc func() []domain.KubeCluster
 
@@ -49,8 +59,22 @@ type ClusterRepositoryMock struct {
 	// GetByNameFunc mocks the GetByName method.
 	GetByNameFunc func(cluster string) (*domain.KubeCluster, error)
 
+	// SetNamespaceWeightFunc mocks the SetNamespaceWeight method.
+	SetNamespaceWeightFunc func(ctx context.Context, weight domain.NamespaceWeight) (*domain.NamespaceWeight, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// AddNamespace holds details about calls to the AddNamespace method.
+		AddNamespace []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cluster is the cluster argument value.
+			Cluster string
+			// Namespace is the namespace argument value.
+			Namespace domain.KubeNamespace
+			// Prepare is the prepare argument value.
+			Prepare func() error
+		}
 		// GetAll holds details about calls to the GetAll method.
 		GetAll []struct {
 		}
@@ -69,11 +93,64 @@ type ClusterRepositoryMock struct {
 			// Cluster is the cluster argument value.
 			Cluster string
 		}
+		// SetNamespaceWeight holds details about calls to the SetNamespaceWeight method.
+		SetNamespaceWeight []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Weight is the weight argument value.
+			Weight domain.NamespaceWeight
+		}
 	}
+	lockAddNamespace        sync.RWMutex
 	lockGetAll              sync.RWMutex
 	lockGetAllWithNamespace sync.RWMutex
 	lockGetById             sync.RWMutex
 	lockGetByName           sync.RWMutex
+	lockSetNamespaceWeight  sync.RWMutex
+}
+
+// AddNamespace calls AddNamespaceFunc.
+func (mock *ClusterRepositoryMock) AddNamespace(ctx context.Context, cluster string, namespace domain.KubeNamespace, prepare func() error) (*domain.KubeCluster, error) {
+	if mock.AddNamespaceFunc == nil {
+		panic("ClusterRepositoryMock.AddNamespaceFunc: method is nil but ClusterRepository.AddNamespace was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Cluster   string
+		Namespace domain.KubeNamespace
+		Prepare   func() error
+	}{
+		Ctx:       ctx,
+		Cluster:   cluster,
+		Namespace: namespace,
+		Prepare:   prepare,
+	}
+	mock.lockAddNamespace.Lock()
+	mock.calls.AddNamespace = append(mock.calls.AddNamespace, callInfo)
+	mock.lockAddNamespace.Unlock()
+	return mock.AddNamespaceFunc(ctx, cluster, namespace, prepare)
+}
+
+// AddNamespaceCalls gets all the calls that were made to AddNamespace.
+// Check the length with:
+//
+//	len(mockedClusterRepository.AddNamespaceCalls())
+func (mock *ClusterRepositoryMock) AddNamespaceCalls() []struct {
+	Ctx       context.Context
+	Cluster   string
+	Namespace domain.KubeNamespace
+	Prepare   func() error
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Cluster   string
+		Namespace domain.KubeNamespace
+		Prepare   func() error
+	}
+	mock.lockAddNamespace.RLock()
+	calls = mock.calls.AddNamespace
+	mock.lockAddNamespace.RUnlock()
+	return calls
 }
 
 // GetAll calls GetAllFunc.
@@ -198,3 +275,39 @@ func (mock *ClusterRepositoryMock) GetByNameCalls() []struct {
 	mock.lockGetByName.RUnlock()
 	return calls
 }
+
+// SetNamespaceWeight calls SetNamespaceWeightFunc.
+func (mock *ClusterRepositoryMock) SetNamespaceWeight(ctx context.Context, weight domain.NamespaceWeight) (*domain.NamespaceWeight, error) {
+	if mock.SetNamespaceWeightFunc == nil {
+		panic("ClusterRepositoryMock.SetNamespaceWeightFunc: method is nil but ClusterRepository.SetNamespaceWeight was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Weight domain.NamespaceWeight
+	}{
+		Ctx:    ctx,
+		Weight: weight,
+	}
+	mock.lockSetNamespaceWeight.Lock()
+	mock.calls.SetNamespaceWeight = append(mock.calls.SetNamespaceWeight, callInfo)
+	mock.lockSetNamespaceWeight.Unlock()
+	return mock.SetNamespaceWeightFunc(ctx, weight)
+}
+
+// SetNamespaceWeightCalls gets all the calls that were made to SetNamespaceWeight.
+// Check the length with:
+//
+//	len(mockedClusterRepository.SetNamespaceWeightCalls())
+func (mock *ClusterRepositoryMock) SetNamespaceWeightCalls() []struct {
+	Ctx    context.Context
+	Weight domain.NamespaceWeight
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Weight domain.NamespaceWeight
+	}
+	mock.lockSetNamespaceWeight.RLock()
+	calls = mock.calls.SetNamespaceWeight
+	mock.lockSetNamespaceWeight.RUnlock()
+	return calls
+}
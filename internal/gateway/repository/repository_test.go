@@ -16,10 +16,27 @@
 package repository
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
 	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/metrics"
 	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	sgHttp "github.com/slackhq/spark-gateway/internal/shared/http"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -149,3 +166,367 @@ func TestNewRepository(t *testing.T) {
 		assert.Equal(t, test.expected, newRepo.ClusterEndpoints, "ClusterEndpoints map should match")
 	}
 }
+
+func TestSparkManagerRepositoryCheckEndpoints(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	// A listener that is closed right away leaves a port nothing accepts connections on
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err, "listening should not error")
+	closedAddr := listener.Addr().String()
+	listener.Close()
+
+	var tests = []struct {
+		test      string
+		endpoints map[string]string
+		err       []string
+	}{
+		{
+			test:      "reachable endpoints",
+			endpoints: map[string]string{"a": server.URL + "/api/v1"},
+		},
+		{
+			test: "unreachable endpoint",
+			endpoints: map[string]string{
+				"a": server.URL + "/api/v1",
+				"b": "http://" + closedAddr + "/api/v1",
+			},
+			err: []string{"cluster 'b' SparkManager endpoint '" + closedAddr + "' is not reachable"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.test, func(t *testing.T) {
+			repo := &SparkManagerRepository{ClusterEndpoints: test.endpoints}
+			err := repo.CheckEndpoints(context.Background(), time.Second)
+
+			if len(test.err) == 0 {
+				assert.Nil(t, err, "check should not error")
+				return
+			}
+			for _, msg := range test.err {
+				assert.ErrorContains(t, err, msg, "error should name the unreachable cluster")
+			}
+			assert.NotContains(t, err.Error(), "cluster 'a'", "reachable clusters should not error")
+		})
+	}
+}
+
+func TestLocalClusterRepoAddNamespace(t *testing.T) {
+	repo, err := NewLocalClusterRepo([]domain.KubeCluster{
+		{
+			Name:       "cluster",
+			ClusterId:  "clus",
+			MasterURL:  "masterURL",
+			Namespaces: []domain.KubeNamespace{{Name: "existing", NamespaceId: "exist"}},
+		},
+	})
+	assert.Nil(t, err, "err should be nil")
+
+	previous, _ := repo.GetByName("cluster")
+
+	cluster, err := repo.AddNamespace(context.Background(), "cluster", domain.KubeNamespace{Name: "new", NamespaceId: "new"}, nil)
+	assert.Nil(t, err, "err should be nil")
+	assert.Len(t, cluster.Namespaces, 2, "namespace should be added")
+	assert.Len(t, repo.GetAllWithNamespace("new"), 1, "namespace should be routable")
+	assert.Len(t, previous.Namespaces, 1, "previously read clusters should be unchanged")
+
+	_, err = repo.AddNamespace(context.Background(), "cluster", domain.KubeNamespace{Name: "new", NamespaceId: "other"}, nil)
+	assert.EqualError(t, err, "namespace 'new' is already registered in cluster 'cluster'", "duplicate name should conflict")
+
+	_, err = repo.AddNamespace(context.Background(), "cluster", domain.KubeNamespace{Name: "dupe", NamespaceId: "new"}, nil)
+	assert.EqualError(t, err, "duplicate namespace id found in namespaces configuration: 'new'", "duplicate id should be invalid")
+
+	_, err = repo.AddNamespace(context.Background(), "missing", domain.KubeNamespace{Name: "new", NamespaceId: "new"}, nil)
+	assert.EqualError(t, err, "cluster does not exist: missing", "unknown cluster should not be found")
+
+	var prepared []string
+	prepare := func(err error) func() error {
+		return func() error {
+			prepared = append(prepared, "called")
+			return err
+		}
+	}
+	_, err = repo.AddNamespace(context.Background(), "cluster", domain.KubeNamespace{Name: "new", NamespaceId: "other"}, prepare(nil))
+	assert.Equal(t, http.StatusConflict, gatewayerrors.NewFrom(err).Status, "duplicate name should conflict")
+	assert.Empty(t, prepared, "rejected namespaces should not be prepared")
+
+	_, err = repo.AddNamespace(context.Background(), "cluster", domain.KubeNamespace{Name: "unprepared", NamespaceId: "unprepared"}, prepare(errors.New("prepare failed")))
+	assert.EqualError(t, err, "prepare failed", "prepare error should be returned")
+	assert.Empty(t, repo.GetAllWithNamespace("unprepared"), "namespace should not be added if prepare fails")
+
+	cluster, err = repo.AddNamespace(context.Background(), "cluster", domain.KubeNamespace{Name: "prepared", NamespaceId: "prepared"}, prepare(nil))
+	assert.Nil(t, err, "err should be nil")
+	assert.Len(t, cluster.Namespaces, 3, "prepared namespace should be added")
+	assert.Len(t, prepared, 2, "accepted namespaces should be prepared")
+}
+
+func TestDatabaseClusterRepoSetNamespaceWeight(t *testing.T) {
+	localRepo, err := NewLocalClusterRepo([]domain.KubeCluster{
+		{
+			Name:       "cluster",
+			ClusterId:  "clus",
+			MasterURL:  "masterURL",
+			Namespaces: []domain.KubeNamespace{{Name: "a", NamespaceId: "a", RoutingWeight: 1}, {Name: "b", NamespaceId: "b", RoutingWeight: 1}},
+		},
+	})
+	assert.Nil(t, err, "err should be nil")
+
+	var persisted []database.NamespaceRoutingWeight
+	db := &database.NamespaceRoutingWeightDatabaseMock{
+		ListNamespaceRoutingWeightsFunc: func(ctx context.Context) ([]database.NamespaceRoutingWeight, error) {
+			return persisted, nil
+		},
+		UpsertNamespaceRoutingWeightFunc: func(ctx context.Context, weight database.NamespaceRoutingWeight) error {
+			persisted = append(persisted, weight)
+			return nil
+		},
+	}
+	// Weights persisted by other Gateway instances, one for a namespace no longer configured
+	persisted = []database.NamespaceRoutingWeight{
+		{Cluster: "cluster", Namespace: "a", Weight: 3},
+		{Cluster: "cluster", Namespace: "removed", Weight: 2},
+	}
+
+	repo, err := NewDatabaseClusterRepo(context.Background(), localRepo, db, &database.RegisteredNamespaceDatabaseMock{
+		ListRegisteredNamespacesFunc: func(ctx context.Context) ([]database.RegisteredNamespace, error) {
+			return nil, nil
+		},
+	})
+	assert.Nil(t, err, "err should be nil")
+
+	cluster, _ := localRepo.GetByName("cluster")
+	namespace, _ := cluster.GetNamespaceByName("a")
+	assert.Equal(t, 3.0, namespace.RoutingWeight, "persisted weight should be applied")
+
+	weight, err := repo.SetNamespaceWeight(context.Background(), domain.NamespaceWeight{Cluster: "cluster", Namespace: "b", RoutingWeight: 0, UpdatedBy: "admin"})
+	assert.Nil(t, err, "err should be nil")
+	assert.True(t, weight.Persisted, "weight should be persisted")
+	assert.Equal(t, database.NamespaceRoutingWeight{Cluster: "cluster", Namespace: "b", Weight: 0, UpdatedBy: "admin"}, persisted[len(persisted)-1], "weight should be upserted")
+
+	cluster, _ = localRepo.GetByName("cluster")
+	namespace, _ = cluster.GetNamespaceByName("b")
+	assert.Equal(t, 0.0, namespace.RoutingWeight, "weight should be applied")
+
+	_, err = repo.SetNamespaceWeight(context.Background(), domain.NamespaceWeight{Cluster: "cluster", Namespace: "missing", RoutingWeight: 1})
+	assert.Equal(t, http.StatusNotFound, gatewayerrors.NewFrom(err).Status, "unknown namespace should not be found")
+	_, err = repo.SetNamespaceWeight(context.Background(), domain.NamespaceWeight{Cluster: "missing", Namespace: "a", RoutingWeight: 1})
+	assert.Equal(t, http.StatusNotFound, gatewayerrors.NewFrom(err).Status, "unknown cluster should not be found")
+	assert.Len(t, db.UpsertNamespaceRoutingWeightCalls(), 1, "unknown namespaces should not be persisted")
+}
+
+func TestDatabaseClusterRepoAddNamespace(t *testing.T) {
+	localRepo, err := NewLocalClusterRepo([]domain.KubeCluster{
+		{
+			Name:       "cluster",
+			ClusterId:  "clus",
+			MasterURL:  "masterURL",
+			Namespaces: []domain.KubeNamespace{{Name: "a", NamespaceId: "a", RoutingWeight: 1}},
+		},
+	})
+	assert.Nil(t, err, "err should be nil")
+
+	// Namespaces registered by other Gateway instances, one also configured and one for a cluster no longer configured
+	persisted := []database.RegisteredNamespace{
+		{Cluster: "cluster", Namespace: "b", NamespaceID: "b", Spec: &domain.KubeNamespace{Name: "b", NamespaceId: "b", RoutingWeight: 1}},
+		{Cluster: "cluster", Namespace: "a", NamespaceID: "a", Spec: &domain.KubeNamespace{Name: "a", NamespaceId: "a", RoutingWeight: 2}},
+		{Cluster: "removed", Namespace: "c", NamespaceID: "c", Spec: &domain.KubeNamespace{Name: "c", NamespaceId: "c"}},
+	}
+	db := &database.RegisteredNamespaceDatabaseMock{
+		InsertRegisteredNamespaceFunc: func(ctx context.Context, cluster string, namespace domain.KubeNamespace, now time.Time) (bool, error) {
+			for _, registered := range persisted {
+				if registered.Cluster == cluster && (registered.Namespace == namespace.Name || registered.NamespaceID == namespace.NamespaceId) {
+					return false, nil
+				}
+			}
+			persisted = append(persisted, database.RegisteredNamespace{Cluster: cluster, Namespace: namespace.Name, NamespaceID: namespace.NamespaceId, Spec: &namespace})
+			return true, nil
+		},
+		ListRegisteredNamespacesFunc: func(ctx context.Context) ([]database.RegisteredNamespace, error) {
+			return persisted, nil
+		},
+		DeleteRegisteredNamespaceFunc: func(ctx context.Context, cluster string, namespace string) error {
+			for i, registered := range persisted {
+				if registered.Cluster == cluster && registered.Namespace == namespace {
+					persisted = append(persisted[:i], persisted[i+1:]...)
+					break
+				}
+			}
+			return nil
+		},
+	}
+	weights := &database.NamespaceRoutingWeightDatabaseMock{
+		ListNamespaceRoutingWeightsFunc: func(ctx context.Context) ([]database.NamespaceRoutingWeight, error) {
+			return []database.NamespaceRoutingWeight{{Cluster: "cluster", Namespace: "b", Weight: 5}}, nil
+		},
+	}
+
+	repo, err := NewDatabaseClusterRepo(context.Background(), localRepo, weights, db)
+	assert.Nil(t, err, "err should be nil")
+
+	cluster, _ := localRepo.GetByName("cluster")
+	assert.Len(t, cluster.Namespaces, 2, "persisted namespace should be added")
+	namespace, _ := cluster.GetNamespaceByName("a")
+	assert.Equal(t, 1.0, namespace.RoutingWeight, "configured namespace should be unchanged")
+	namespace, _ = cluster.GetNamespaceByName("b")
+	assert.Equal(t, 5.0, namespace.RoutingWeight, "persisted weight of persisted namespace should be applied")
+
+	cluster, err = repo.AddNamespace(context.Background(), "cluster", domain.KubeNamespace{Name: "d", NamespaceId: "d"}, nil)
+	assert.Nil(t, err, "err should be nil")
+	assert.Len(t, cluster.Namespaces, 3, "namespace should be added")
+	assert.Equal(t, "d", persisted[len(persisted)-1].Namespace, "namespace should be persisted")
+
+	// Registered by another Gateway instance since the last Refresh
+	persisted = append(persisted, database.RegisteredNamespace{Cluster: "cluster", Namespace: "e", NamespaceID: "e", Spec: &domain.KubeNamespace{Name: "e", NamespaceId: "e"}})
+	_, err = repo.AddNamespace(context.Background(), "cluster", domain.KubeNamespace{Name: "e", NamespaceId: "other"}, nil)
+	assert.Equal(t, http.StatusConflict, gatewayerrors.NewFrom(err).Status, "namespace registered elsewhere should conflict")
+
+	_, err = repo.AddNamespace(context.Background(), "cluster", domain.KubeNamespace{Name: "a", NamespaceId: "other"}, nil)
+	assert.Equal(t, http.StatusConflict, gatewayerrors.NewFrom(err).Status, "configured namespace should conflict")
+	_, err = repo.AddNamespace(context.Background(), "cluster", domain.KubeNamespace{Name: "f", NamespaceId: "a"}, nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, gatewayerrors.NewFrom(err).Status, "duplicate id should be invalid")
+	_, err = repo.AddNamespace(context.Background(), "missing", domain.KubeNamespace{Name: "f", NamespaceId: "f"}, nil)
+	assert.Equal(t, http.StatusNotFound, gatewayerrors.NewFrom(err).Status, "unknown cluster should not be found")
+	assert.Len(t, db.InsertRegisteredNamespaceCalls(), 2, "rejected namespaces should not be persisted")
+
+	var prepared []string
+	_, err = repo.AddNamespace(context.Background(), "cluster", domain.KubeNamespace{Name: "e", NamespaceId: "other"}, func() error {
+		prepared = append(prepared, "e")
+		return nil
+	})
+	assert.Equal(t, http.StatusConflict, gatewayerrors.NewFrom(err).Status, "namespace registered elsewhere should conflict")
+	assert.Empty(t, prepared, "namespace registered elsewhere should not be prepared")
+
+	_, err = repo.AddNamespace(context.Background(), "cluster", domain.KubeNamespace{Name: "g", NamespaceId: "g"}, func() error {
+		return errors.New("prepare failed")
+	})
+	assert.EqualError(t, err, "prepare failed", "prepare error should be returned")
+	assert.Empty(t, localRepo.GetAllWithNamespace("g"), "namespace should not be added if prepare fails")
+	assert.NotEqual(t, "g", persisted[len(persisted)-1].Namespace, "namespace should not stay persisted if prepare fails")
+	assert.Len(t, db.DeleteRegisteredNamespaceCalls(), 1, "persisted namespace should be deleted")
+
+	// Added by a Refresh while the namespace is prepared
+	cluster, err = repo.AddNamespace(context.Background(), "cluster", domain.KubeNamespace{Name: "h", NamespaceId: "h"}, func() error {
+		return repo.Refresh(context.Background())
+	})
+	assert.Nil(t, err, "namespace added unchanged by a concurrent Refresh should not error")
+	assert.Len(t, cluster.Namespaces, 5, "namespace should be added once")
+	assert.Equal(t, "h", persisted[len(persisted)-1].Namespace, "namespace should stay persisted")
+	assert.Len(t, db.DeleteRegisteredNamespaceCalls(), 1, "added namespace should not be deleted")
+
+	assert.Nil(t, repo.Refresh(context.Background()), "err should be nil")
+	cluster, _ = localRepo.GetByName("cluster")
+	assert.Len(t, cluster.Namespaces, 5, "Refresh should not add namespaces twice")
+}
+
+func TestHistoryServerRepositorySummary(t *testing.T) {
+	responses := map[string]string{
+		"/history/cluster-a/api/v1/applications/spark-123": `{"id": "spark-123", "attempts": [
+			{"attemptId": "2", "startTime": "2025-01-01T10:00:00.000GMT", "endTime": "2025-01-01T10:10:00.000GMT", "duration": 600000},
+			{"attemptId": "1", "startTime": "2025-01-01T09:00:00.000GMT", "endTime": "2025-01-01T09:05:00.000GMT", "duration": 300000}]}`,
+		"/history/cluster-a/api/v1/applications/spark-123/2/jobs": `[{"status": "SUCCEEDED"}, {"status": "SUCCEEDED"}, {"status": "FAILED"}]`,
+		"/history/cluster-a/api/v1/applications/spark-123/2/stages": `[
+			{"stageId": 0, "attemptId": 0, "name": "short", "status": "COMPLETE", "submissionTime": "2025-01-01T10:00:00.000GMT", "completionTime": "2025-01-01T10:00:30.000GMT"},
+			{"stageId": 1, "attemptId": 0, "name": "long", "status": "COMPLETE", "submissionTime": "2025-01-01T10:01:00.000GMT", "completionTime": "2025-01-01T10:05:00.000GMT"},
+			{"stageId": 2, "attemptId": 0, "name": "broken", "status": "FAILED", "failureReason": "Job aborted due to stage failure"},
+			{"stageId": 3, "attemptId": 0, "name": "unused", "status": "SKIPPED"}]`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	cluster := domain.KubeCluster{Name: "cluster-a"}
+	repo, err := NewHistoryServerRepository([]domain.KubeCluster{cluster}, server.URL+"/history/{{.clusterName}}/", time.Second)
+	assert.NoError(t, err, "creating the repository should not error")
+	assert.Equal(t, server.URL+"/history/cluster-a/api/v1", repo.ClusterEndpoints["cluster-a"], "endpoint should be rendered from the template")
+
+	summary, err := repo.Summary(context.Background(), cluster, "spark-123")
+	assert.NoError(t, err, "summary should not error")
+	assert.Equal(t, 2, summary.Attempts, "attempts should be counted")
+	assert.Equal(t, 600.0, summary.DurationSeconds, "duration should be of the latest attempt")
+	assert.Equal(t, time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC), *summary.StartTime, "start time should be of the latest attempt")
+	assert.Equal(t, domain.HistoryServerJobCounts{Succeeded: 2, Failed: 1}, summary.Jobs, "jobs should be counted by status")
+	assert.Equal(t, domain.HistoryServerStageCounts{Complete: 2, Failed: 1, Skipped: 1}, summary.Stages, "stages should be counted by status")
+	assert.Len(t, summary.FailedStages, 1, "failed stage should be listed")
+	assert.Equal(t, "Job aborted due to stage failure", summary.FailedStages[0].FailureReason, "failed stage should carry its failure reason")
+	assert.Equal(t, []string{"long", "short"}, []string{summary.LongestStages[0].Name, summary.LongestStages[1].Name}, "completed stages should be listed longest first")
+	assert.Equal(t, 240.0, summary.LongestStages[0].DurationSeconds, "stage duration should span submission to completion")
+
+	_, err = repo.Summary(context.Background(), cluster, "spark-unknown")
+	assert.Equal(t, http.StatusNotFound, gatewayerrors.NewFrom(err).Status, "unknown application should not be found")
+}
+
+func TestSparkManagerClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v1/ns/app%2Fname/scale":
+			assert.Equal(t, http.MethodPost, r.Method, "methods should match")
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"), "bodies should be sent as JSON")
+			var scale domain.ExecutorScale
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&scale), "body should decode")
+			assert.Equal(t, int32(4), *scale.Instances, "bodies should match")
+			w.Write([]byte(`"scaled"`))
+		case "/api/v1/ns/counts":
+			assert.Equal(t, "0", r.URL.Query().Get("resourceVersion"), "queries should be sent")
+			w.Write([]byte(`"counted"`))
+		case "/api/v1/ns/app/logs/download":
+			w.Write([]byte("driver logs"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewSparkManagerClient("cluster-a", server.URL+"/api/v1")
+
+	metadata := &domain.ResponseMetadata{}
+	var out string
+	err := client.Do(domain.WithResponseMetadata(context.Background(), metadata), http.MethodPost, nil, domain.ExecutorScale{Instances: util.Ptr(int32(4))}, &out, "ns", "app/name", "scale")
+	assert.NoError(t, err, "scale should not error")
+	assert.Equal(t, "scaled", out, "responses should be decoded")
+	assert.Equal(t, []string{"cluster-a"}, metadata.Clusters, "called clusters should be recorded")
+	assert.Len(t, metadata.SparkManagerCalls, 1, "calls should be timed")
+	assert.Equal(t, "/api/v1/ns/app%2Fname/scale", metadata.SparkManagerCalls[0].Path, "called paths should be recorded")
+
+	err = client.Do(context.Background(), http.MethodGet, url.Values{"resourceVersion": {"0"}}, nil, &out, "ns", "counts")
+	assert.NoError(t, err, "counts should not error")
+	assert.Equal(t, "counted", out, "responses should be decoded")
+
+	stream, err := client.Stream(context.Background(), http.DefaultClient, nil, "ns", "app", "logs", "download")
+	assert.NoError(t, err, "stream should not error")
+	logs, _ := io.ReadAll(stream)
+	stream.Close()
+	assert.Equal(t, "driver logs", string(logs), "streams should be returned unread")
+
+	err = client.Do(context.Background(), http.MethodDelete, nil, nil, nil, "ns", "missing")
+	assert.Equal(t, http.StatusNotFound, gatewayerrors.NewFrom(err).Status, "error statuses should be mapped")
+	assert.ErrorContains(t, err, "not found", "error messages should be mapped")
+
+	_, err = client.Stream(context.Background(), http.DefaultClient, nil, "ns", "missing", "logs", "download")
+	assert.Equal(t, http.StatusNotFound, gatewayerrors.NewFrom(err).Status, "stream error statuses should be mapped")
+}
+
+func TestSparkManagerClientAPIVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, strconv.Itoa(sgHttp.APIVersion), r.Header.Get(sgHttp.APIVersionHeader), "the Gateway API version should be sent")
+		assert.Equal(t, strconv.Itoa(sgHttp.MinCompatibleAPIVersion), r.Header.Get(sgHttp.MinAPIVersionHeader), "the minimum compatible API version should be sent")
+		w.Header().Set(sgHttp.APIVersionHeader, "7")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error": "incompatible API versions"}`))
+	}))
+	defer server.Close()
+
+	client := NewSparkManagerClient("skewed", server.URL+"/api/v1")
+
+	err := client.Do(context.Background(), http.MethodGet, nil, nil, nil, "ns", "app")
+	assert.Equal(t, http.StatusServiceUnavailable, gatewayerrors.NewFrom(err).Status, "rejections should be mapped")
+	assert.Equal(t, float64(7), testutil.ToFloat64(metrics.SparkManagerAPIVersion.WithLabelValues("skewed")), "the SparkManager API version should be recorded from error responses too")
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/metrics"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	sgHttp "github.com/slackhq/spark-gateway/internal/shared/http"
+)
+
+// SparkManagerClient sends requests to the SparkManager API of one cluster. Request bodies are sent as JSON, successful
+// responses are decoded into the given types and error responses are mapped to GatewayErrors carrying the SparkManager
+// status code, so new SparkManager endpoints only need a path and their request and response types.
+type SparkManagerClient struct {
+	// Cluster is the cluster the SparkManager runs in
+	Cluster string
+	// Endpoint is the base URL of the SparkManager API, e.g. http://host:port/api/v1
+	Endpoint string
+}
+
+func NewSparkManagerClient(cluster string, endpoint string) *SparkManagerClient {
+	return &SparkManagerClient{Cluster: cluster, Endpoint: endpoint}
+}
+
+// URL returns the URL of the SparkManager API path made of segments, each of them escaped, with query if it is set
+func (c *SparkManagerClient) URL(query url.Values, segments ...string) string {
+	escaped := make([]string, 0, len(segments)+1)
+	escaped = append(escaped, c.Endpoint)
+	for _, segment := range segments {
+		escaped = append(escaped, url.PathEscape(segment))
+	}
+
+	u := strings.Join(escaped, "/")
+	if len(query) > 0 {
+		u = fmt.Sprintf("%s?%s", u, query.Encode())
+	}
+	return u
+}
+
+// Do sends a method request to the SparkManager API path made of segments, with in as its JSON body if it is not nil,
+// and decodes the JSON response into out if it is not nil
+func (c *SparkManagerClient) Do(ctx context.Context, method string, query url.Values, in any, out any, segments ...string) error {
+	request, err := c.newRequest(method, query, in, segments...)
+	if err != nil {
+		return err
+	}
+	sgHttp.SetSelectorOverride(request.Header, domain.IsSelectorOverride(ctx))
+
+	callCtx, done := domain.LatencyBudgetFrom(ctx).Phase(ctx, domain.LatencyBudgetSparkManager, c.Cluster)
+	start := time.Now()
+	resp, respBody, err := DoHTTP(callCtx, request)
+	c.recordCall(ctx, request, time.Since(start))
+	c.recordAPIVersion(resp)
+	done(err)
+	if err != nil {
+		return gatewayerrors.NewFrom(err)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(*respBody, out); err != nil {
+		return fmt.Errorf("failed to Unmarshal JSON response: %w", err)
+	}
+
+	return nil
+}
+
+// Stream sends a GET request to the SparkManager API path made of segments with client and returns the response body
+// unread. The caller is responsible for closing the stream.
+func (c *SparkManagerClient) Stream(ctx context.Context, client *http.Client, query url.Values, segments ...string) (io.ReadCloser, error) {
+	request, err := c.newRequest(http.MethodGet, query, nil, segments...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Streams are only timed until SparkManager starts responding
+	start := time.Now()
+	stream, err := sgHttp.HttpStreamRequest(ctx, client, request)
+	c.recordCall(ctx, request, time.Since(start))
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(err)
+	}
+
+	return stream, nil
+}
+
+func (c *SparkManagerClient) newRequest(method string, query url.Values, in any, segments ...string) (*http.Request, error) {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %T: %w", in, err)
+		}
+		body = bytes.NewBuffer(data)
+	}
+
+	request, err := http.NewRequest(method, c.URL(query, segments...), body)
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(fmt.Errorf("error creating %s request: %w", method, err))
+	}
+	if in != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	// SparkManager rejects calls from Gateways it isn't compatible with, e.g. during a rolling upgrade
+	sgHttp.SetAPIVersionHeaders(request.Header)
+
+	return request, nil
+}
+
+// recordCall records the duration of request to the ResponseMetadata of ctx, if the request asked for it
+func (c *SparkManagerClient) recordCall(ctx context.Context, request *http.Request, duration time.Duration) {
+	domain.ResponseMetadataFrom(ctx).RecordSparkManagerCall(c.Cluster, request.Method, request.URL.EscapedPath(), duration)
+}
+
+// recordAPIVersion records the API version resp reports the SparkManager implements, showing version skew between
+// clusters during rolling upgrades
+func (c *SparkManagerClient) recordAPIVersion(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	version, ok, err := sgHttp.PeerAPIVersion(resp.Header)
+	if err != nil {
+		klog.Warningf("SparkManager of cluster '%s' returned an invalid API version: %v", c.Cluster, err)
+		return
+	}
+	if ok {
+		metrics.SparkManagerAPIVersion.WithLabelValues(c.Cluster).Set(float64(version))
+	}
+}
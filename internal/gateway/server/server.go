@@ -23,35 +23,72 @@ import (
 	"reflect"
 	"time"
 
-	"github.com/slackhq/spark-gateway/internal/domain"
 	"github.com/slackhq/spark-gateway/internal/gateway/api"
 	"github.com/slackhq/spark-gateway/internal/gateway/clusterrouter"
 	"github.com/slackhq/spark-gateway/internal/gateway/repository"
 	"github.com/slackhq/spark-gateway/internal/gateway/service"
 	"github.com/slackhq/spark-gateway/internal/shared/config"
 	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/shared/faults"
+	sgHttp "github.com/slackhq/spark-gateway/internal/shared/http"
 	"k8s.io/klog/v2"
 )
 
 type GatewayServer struct {
-	httpServer *http.Server
-	ctx        context.Context
+	httpServer  *http.Server
+	adminServer *http.Server
+	ctx         context.Context
 }
 
-func NewGateway(ctx context.Context, sgConfig *config.SparkGatewayConfig, sparkManagerHostnameTemplate string) (*GatewayServer, error) {
+// sparkManagerCheckTimeout is how long each SparkManager endpoint has to be resolved and dialed at startup
+const sparkManagerCheckTimeout = 5 * time.Second
+
+func NewGateway(ctx context.Context, sgConfig *config.SparkGatewayConfig, sparkManagerHostnameTemplate string, checkSparkManagers bool) (*GatewayServer, error) {
 
 	//Repos
 	sparkManagerRepo, err := repository.NewSparkManagerRepository(sgConfig.KubeClusters, sparkManagerHostnameTemplate, sgConfig.SparkManagerPort, sgConfig.DebugPorts)
 	if err != nil {
 		return nil, fmt.Errorf("could not create SparkManagerRespository: %w", err)
 	}
+
+	if checkSparkManagers {
+		if err := sparkManagerRepo.CheckEndpoints(ctx, sparkManagerCheckTimeout); err != nil {
+			return nil, fmt.Errorf("SparkManager endpoints rendered from the hostname template are not reachable, check the template or skip the check with --skip-spark-manager-check:\n%w", err)
+		}
+	}
+	// Inject faults into SparkManager calls, targeting them by cluster
+	var faultInjector *faults.Injector
+	if sgConfig.GatewayConfig.FaultInjection.Enable {
+		klog.Warning("Fault injection is enabled, admins can make calls to SparkManagers fail")
+		faultInjector = faults.NewInjector()
+		sgHttp.DefaultClient.Transport = faultInjector.Transport(sgHttp.DefaultClient.Transport, sparkManagerRepo.ClusterOf)
+		sgHttp.StreamingClient.Transport = faultInjector.Transport(sgHttp.StreamingClient.Transport, sparkManagerRepo.ClusterOf)
+	}
+
 	klog.Infof("Spark Gateway configured with SparkManagerRespository: %s", reflect.TypeOf(sparkManagerRepo).String())
 
 	localClusterRepo, err := repository.NewLocalClusterRepo(sgConfig.KubeClusters)
 	if err != nil {
 		return nil, fmt.Errorf("could not create LocalClusterRepo: %w", err)
 	}
-	klog.Infof("Spark Gateway configured with ClusterRepository: %s", reflect.TypeOf(sparkManagerRepo).String())
+
+	// Persist namespaces registered and routing weights set through the admin API and share them between Gateway instances
+	var clusterRepo repository.ClusterRepository = localClusterRepo
+	var gatewayDB *database.Database
+	if sgConfig.Database.Enable {
+		gatewayDB, err = database.NewDatabase(ctx, sgConfig.Database)
+		if err != nil {
+			return nil, fmt.Errorf("error creating database: %w", err)
+		}
+
+		databaseClusterRepo, err := repository.NewDatabaseClusterRepo(ctx, localClusterRepo, gatewayDB, gatewayDB)
+		if err != nil {
+			return nil, fmt.Errorf("could not create DatabaseClusterRepo: %w", err)
+		}
+		go databaseClusterRepo.Run(ctx, sgConfig.GatewayConfig.RoutingWeights.RefreshInterval)
+		clusterRepo = databaseClusterRepo
+	}
+	klog.Infof("Spark Gateway configured with ClusterRepository: %s", reflect.TypeOf(clusterRepo).String())
 
 	clusterRouter, err := clusterrouter.GetClusterRouter(
 		sgConfig.ClusterRouter.Type,
@@ -75,29 +112,137 @@ func NewGateway(ctx context.Context, sgConfig *config.SparkGatewayConfig, sparkM
 		return nil, err
 	}
 
+	// Collapse identical reads in flight, then cache hot reads if configured
+	gatewayAppRepo := service.NewDeduplicatingGatewayApplicationRepository(sparkManagerRepo, sgConfig.GatewayConfig.Deduplication)
+	gatewayAppRepo = service.NewCachingGatewayApplicationRepository(gatewayAppRepo, sgConfig.GatewayConfig.ResponseCache)
+
+	gatewayIdGen, err := service.NewGatewayIdGenerator(sgConfig.GatewayConfig.GatewayIdGenerator, sgConfig.KubeClusters)
+	if err != nil {
+		return nil, err
+	}
+
+	// Queue submissions made with async=true in the database, so they are accepted while their SparkManager is down
+	if sgConfig.GatewayConfig.AsyncSubmission.Enable {
+		// Config validation requires the database to be enabled with asynchronous submissions
+		queueingAppRepo := service.NewQueueingGatewayApplicationRepository(gatewayAppRepo, localClusterRepo, gatewayDB, sgConfig.GatewayConfig.AsyncSubmission)
+		go queueingAppRepo.Run(ctx)
+		gatewayAppRepo = queueingAppRepo
+	}
+
+	// Dead-lettered asynchronous submissions are recovered through the admin API
+	var failedSubmissions service.FailedSubmissionService
+	if sgConfig.GatewayConfig.AsyncSubmission.Enable {
+		failedSubmissions = service.NewFailedSubmissionService(
+			gatewayDB,
+			localClusterRepo,
+			sgConfig.GatewayConfig,
+			sgConfig.SelectorKey,
+			sgConfig.SelectorValue,
+			gatewayIdGen,
+		)
+	}
+
+	// Namespace kill switches reject submissions through both the V1 and Livy APIs, shared between Gateway instances
+	// through the database if enabled
+	var killSwitchDB database.NamespaceKillSwitchDatabase
+	if sgConfig.Database.Enable {
+		killSwitchDB = gatewayDB
+	}
+	killSwitchService := service.NewKillSwitchService(gatewayAppRepo, localClusterRepo, killSwitchDB)
+	if killSwitchDB != nil {
+		if err := killSwitchService.Refresh(ctx); err != nil {
+			return nil, fmt.Errorf("could not load kill switches: %w", err)
+		}
+		go killSwitchService.Run(ctx, sgConfig.GatewayConfig.KillSwitches.RefreshInterval)
+	}
+
 	// Services
 	appService := service.NewApplicationService(
-		sparkManagerRepo,
+		gatewayAppRepo,
 		localClusterRepo,
 		clusterRouter,
 		fallbackClusterRouter,
 		sgConfig.GatewayConfig,
 		sgConfig.SelectorKey,
 		sgConfig.SelectorValue,
-		domain.NewId,
+		gatewayIdGen,
 	)
+	appService = service.NewKillSwitchApplicationService(appService, killSwitchService)
+
+	// Warn submitters of namespaces nearly out of ResourceQuota before the quota starts rejecting them
+	if sgConfig.GatewayConfig.SoftQuota.Enable {
+		quotaReader := clusterrouter.NewMetricsQuotaChecker(
+			config.QuotaExclusion{CacheTTL: sgConfig.GatewayConfig.SoftQuota.CacheTTL},
+			sparkManagerHostnameTemplate,
+			sgConfig.SparkManagerConfig.MetricsServer,
+			sgConfig.DebugPorts)
+		appService = service.NewSoftQuotaApplicationService(appService, quotaReader, sgConfig.GatewayConfig.SoftQuota)
+	}
+
+	// Summarize terminal GatewayApplications from the Spark History Server if configured
+	if sgConfig.GatewayConfig.HistoryServer.Enable {
+		historyServerRepo, err := repository.NewHistoryServerRepository(sgConfig.KubeClusters, sgConfig.GatewayConfig.HistoryServer.URLTemplate, sgConfig.GatewayConfig.HistoryServer.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("could not create HistoryServerRepository: %w", err)
+		}
+		appService = service.NewHistoryServerApplicationService(appService, historyServerRepo, sgConfig.GatewayConfig.HistoryServer)
+	}
+	if sgConfig.GatewayConfig.ReadOnly {
+		// Write routes aren't registered, this guards writes from anything else holding the service
+		appService = service.NewReadOnlyApplicationService(appService)
+	}
 
 	// Livy Setup
 	var livyService service.LivyApplicationService
 	if sgConfig.LivyConfig.Enable {
-		livyDB, err := database.NewDatabase(ctx, sgConfig.Database)
-		if err != nil {
-			return nil, fmt.Errorf("error creating database: %w", err)
-		}
+		// Config validation requires the database to be enabled with Livy
+		livyDB := service.NewMetricsLivyDatabase(gatewayDB)
 		livyService = service.NewLivyService(appService, livyDB, sgConfig.LivyConfig.DefaultNamespace, sgConfig.GatewayConfig.StatusUrlTemplates)
+
+		// Sweep Livy GatewayApplications left behind by failed Create compensation
+		if sgConfig.LivyConfig.Reconciler.Interval > 0 {
+			livyReconciler := service.NewLivyReconciler(appService, livyDB, localClusterRepo, sgConfig.LivyConfig.Reconciler)
+			go livyReconciler.Run(ctx)
+		}
 	}
 
-	router, err := api.NewRouter(sgConfig, appService, livyService)
+	// Submit GatewayApplications on cron schedules stored in the database
+	var scheduleService service.ScheduleService
+	if sgConfig.GatewayConfig.Schedules.Enable {
+		// Config validation requires the database to be enabled with schedules
+		scheduler := service.NewScheduler(appService, gatewayDB, sgConfig.GatewayConfig.Schedules)
+		go scheduler.Run(ctx)
+		scheduleService = scheduler
+	}
+
+	namespaceService := service.NewNamespaceService(clusterRepo, sparkManagerRepo, sgConfig.NamespaceDefaulter)
+
+	routingSimulator := service.NewRoutingSimulator(localClusterRepo, clusterRouter, fallbackClusterRouter)
+
+	// Migrated applications that can't be resubmitted are recorded as failed submissions if the database is enabled
+	var migrationDB database.QueuedSubmissionDatabase
+	if sgConfig.Database.Enable {
+		migrationDB = gatewayDB
+	}
+	migrationService := service.NewMigrationService(
+		gatewayAppRepo,
+		localClusterRepo,
+		sgConfig.GatewayConfig,
+		sgConfig.SelectorKey,
+		sgConfig.SelectorValue,
+		gatewayIdGen,
+		migrationDB,
+	)
+
+	// Flag applications stuck before their driver runs
+	var stuckDetector service.StuckApplicationDetector
+	if sgConfig.GatewayConfig.StuckApplications.Enable {
+		detector := service.NewStuckApplicationDetector(appService, localClusterRepo, sgConfig.GatewayConfig.StuckApplications)
+		go detector.Run(ctx)
+		stuckDetector = detector
+	}
+
+	router, err := api.NewRouter(sgConfig, appService, livyService, scheduleService, namespaceService, migrationService, killSwitchService, routingSimulator, stuckDetector, failedSubmissions, faultInjector)
 	if err != nil {
 		return nil, err
 	}
@@ -114,10 +259,30 @@ func NewGateway(ctx context.Context, sgConfig *config.SparkGatewayConfig, sparkM
 		Handler: router,
 	}
 
-	return &GatewayServer{
+	gatewayServer := &GatewayServer{
 		httpServer: &server,
 		ctx:        ctx,
-	}, nil
+	}
+
+	// Serve admin and debug routes apart from user traffic
+	if sgConfig.GatewayConfig.AdminPort != "" {
+		adminRouter, err := api.NewAdminRouter(sgConfig, namespaceService, migrationService, killSwitchService, routingSimulator, stuckDetector, failedSubmissions, faultInjector)
+		if err != nil {
+			return nil, err
+		}
+
+		klog.Infof("Registered Admin Routes on port %s:", sgConfig.GatewayConfig.AdminPort)
+		for _, route := range adminRouter.Routes() {
+			klog.Infof("%s %s\n", route.Method, route.Path)
+		}
+
+		gatewayServer.adminServer = &http.Server{
+			Addr:    fmt.Sprintf(":%s", sgConfig.GatewayConfig.AdminPort),
+			Handler: adminRouter,
+		}
+	}
+
+	return gatewayServer, nil
 }
 
 func (s *GatewayServer) Run() {
@@ -130,6 +295,14 @@ func (s *GatewayServer) Run() {
 		}
 	}()
 
+	if s.adminServer != nil {
+		go func() {
+			if err := s.adminServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				klog.Infof("admin listen: %s\n", err)
+			}
+		}()
+	}
+
 	<-s.ctx.Done()
 
 	klog.Infof("Shutting down server...")
@@ -139,6 +312,12 @@ func (s *GatewayServer) Run() {
 	timeoutCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	if s.adminServer != nil {
+		if err := s.adminServer.Shutdown(timeoutCtx); err != nil {
+			klog.Errorf("admin server forced to shutdown: %v", err)
+		}
+	}
+
 	if err := s.httpServer.Shutdown(timeoutCtx); err != nil {
 		klog.Fatal("server forced to shutdown:", err)
 	}
@@ -0,0 +1,195 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+)
+
+type cacheEntry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+// ttlCache is a minimal concurrency safe cache where entries expire after a fixed TTL. Expired entries are evicted
+// when they are read, and the entries never read again are swept at most once per TTL on writes.
+type ttlCache[T any] struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	entries   map[string]cacheEntry[T]
+	nextSweep time.Time
+}
+
+func newTTLCache[T any](ttl time.Duration) *ttlCache[T] {
+	return &ttlCache[T]{ttl: ttl, now: time.Now, entries: map[string]cacheEntry[T]{}}
+}
+
+func (c *ttlCache[T]) get(key string) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if ok && !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		ok = false
+	}
+	if !ok {
+		var zero T
+		return zero, false
+	}
+
+	return entry.value, true
+}
+
+func (c *ttlCache[T]) set(key string, value T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if !now.Before(c.nextSweep) {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+		c.nextSweep = now.Add(c.ttl)
+	}
+
+	c.entries[key] = cacheEntry[T]{value: value, expires: now.Add(c.ttl)}
+}
+
+func (c *ttlCache[T]) delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// CachingGatewayApplicationRepository caches Get and Status responses of the wrapped GatewayApplicationRepository so
+// many clients polling the same GatewayApplication only result in one request to SparkManager per TTL. Create,
+// Delete, Scale and Update invalidate cached entries for the application.
+type CachingGatewayApplicationRepository struct {
+	GatewayApplicationRepository
+	getCache    *ttlCache[*v1beta2.SparkApplication]
+	statusCache *ttlCache[*domain.ApplicationStatus]
+}
+
+// NewCachingGatewayApplicationRepository wraps repo with a response cache if any route has a TTL configured,
+// otherwise repo is returned unchanged.
+func NewCachingGatewayApplicationRepository(repo GatewayApplicationRepository, conf config.ResponseCacheConfig) GatewayApplicationRepository {
+	if !conf.Enabled() {
+		return repo
+	}
+
+	cachingRepo := &CachingGatewayApplicationRepository{GatewayApplicationRepository: repo}
+	if conf.GetTTL > 0 {
+		cachingRepo.getCache = newTTLCache[*v1beta2.SparkApplication](conf.GetTTL)
+	}
+	if conf.StatusTTL > 0 {
+		cachingRepo.statusCache = newTTLCache[*domain.ApplicationStatus](conf.StatusTTL)
+	}
+
+	return cachingRepo
+}
+
+func cacheKey(cluster domain.KubeCluster, namespace string, name string) string {
+	return fmt.Sprintf("%s/%s/%s", cluster.Name, namespace, name)
+}
+
+func (r *CachingGatewayApplicationRepository) Get(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplication, error) {
+	if r.getCache == nil {
+		return r.GatewayApplicationRepository.Get(ctx, cluster, namespace, name)
+	}
+
+	key := cacheKey(cluster, namespace, name)
+	if sparkApp, ok := r.getCache.get(key); ok {
+		domain.ResponseMetadataFrom(ctx).RecordCacheHit(cluster.Name)
+		return sparkApp.DeepCopy(), nil
+	}
+
+	sparkApp, err := r.GatewayApplicationRepository.Get(ctx, cluster, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	r.getCache.set(key, sparkApp.DeepCopy())
+
+	return sparkApp, nil
+}
+
+func (r *CachingGatewayApplicationRepository) Status(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*domain.ApplicationStatus, error) {
+	if r.statusCache == nil {
+		return r.GatewayApplicationRepository.Status(ctx, cluster, namespace, name)
+	}
+
+	key := cacheKey(cluster, namespace, name)
+	if status, ok := r.statusCache.get(key); ok {
+		domain.ResponseMetadataFrom(ctx).RecordCacheHit(cluster.Name)
+		return status.DeepCopy(), nil
+	}
+
+	status, err := r.GatewayApplicationRepository.Status(ctx, cluster, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	r.statusCache.set(key, status.DeepCopy())
+
+	return status, nil
+}
+
+func (r *CachingGatewayApplicationRepository) Create(ctx context.Context, cluster domain.KubeCluster, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
+	r.invalidate(cluster, application.Namespace, application.Name)
+	return r.GatewayApplicationRepository.Create(ctx, cluster, application)
+}
+
+func (r *CachingGatewayApplicationRepository) Delete(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) error {
+	err := r.GatewayApplicationRepository.Delete(ctx, cluster, namespace, name)
+	r.invalidate(cluster, namespace, name)
+	return err
+}
+
+func (r *CachingGatewayApplicationRepository) Scale(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, scale domain.ExecutorScale) (*v1beta2.SparkApplication, error) {
+	sparkApp, err := r.GatewayApplicationRepository.Scale(ctx, cluster, namespace, name, scale)
+	r.invalidate(cluster, namespace, name)
+	return sparkApp, err
+}
+
+func (r *CachingGatewayApplicationRepository) Update(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, update domain.ApplicationUpdate) (*v1beta2.SparkApplication, error) {
+	sparkApp, err := r.GatewayApplicationRepository.Update(ctx, cluster, namespace, name, update)
+	r.invalidate(cluster, namespace, name)
+	return sparkApp, err
+}
+
+func (r *CachingGatewayApplicationRepository) invalidate(cluster domain.KubeCluster, namespace string, name string) {
+	key := cacheKey(cluster, namespace, name)
+	if r.getCache != nil {
+		r.getCache.delete(key)
+	}
+	if r.statusCache != nil {
+		r.statusCache.delete(key)
+	}
+}
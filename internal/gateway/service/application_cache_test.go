@@ -0,0 +1,129 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+)
+
+func newCountingRepo() *GatewayApplicationRepositoryMock {
+	return &GatewayApplicationRepositoryMock{
+		GetFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplication, error) {
+			if name == "error" {
+				return nil, errors.New("error getting SparkApplication")
+			}
+			return &v1beta2.SparkApplication{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}, nil
+		},
+		StatusFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*domain.ApplicationStatus, error) {
+			return &domain.ApplicationStatus{SparkApplicationStatus: v1beta2.SparkApplicationStatus{SubmissionID: name}}, nil
+		},
+		DeleteFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) error {
+			return nil
+		},
+	}
+}
+
+func TestTTLCacheEviction(t *testing.T) {
+	start := time.Now()
+	now := start
+	cache := newTTLCache[string](time.Minute)
+	cache.now = func() time.Time { return now }
+
+	cache.set("read", "value")
+	cache.set("unread", "value")
+	now = start.Add(40 * time.Second)
+	cache.set("later", "value")
+
+	now = start.Add(70 * time.Second)
+	_, ok := cache.get("read")
+	assert.False(t, ok, "expired entries should not be returned")
+	assert.NotContains(t, cache.entries, "read", "expired entries should be evicted when read")
+
+	cache.set("sweep", "value")
+	assert.NotContains(t, cache.entries, "unread", "expired entries never read should be swept")
+	assert.Contains(t, cache.entries, "later", "unexpired entries should be kept")
+
+	now = start.Add(110 * time.Second)
+	cache.set("no sweep", "value")
+	assert.Contains(t, cache.entries, "later", "entries should not be swept more than once per TTL")
+}
+
+func TestNewCachingGatewayApplicationRepositoryDisabled(t *testing.T) {
+	repo := newCountingRepo()
+
+	assert.Same(t, repo, NewCachingGatewayApplicationRepository(repo, config.ResponseCacheConfig{}), "repo should not be wrapped when caching is off")
+}
+
+func TestCachingGatewayApplicationRepositoryGet(t *testing.T) {
+	repo := newCountingRepo()
+	cachingRepo := NewCachingGatewayApplicationRepository(repo, config.ResponseCacheConfig{GetTTL: time.Minute}).(*CachingGatewayApplicationRepository)
+
+	now := time.Now()
+	cachingRepo.getCache.now = func() time.Time { return now }
+
+	first, err := cachingRepo.Get(context.Background(), testCluster, "ns", "app")
+	assert.Nil(t, err, "err should be nil")
+
+	// Mutating a returned app must not affect the cache
+	first.Name = "mutated"
+
+	second, _ := cachingRepo.Get(context.Background(), testCluster, "ns", "app")
+	assert.Equal(t, "app", second.Name, "cached app should match")
+	assert.Len(t, repo.GetCalls(), 1, "second Get should be cached")
+
+	now = now.Add(2 * time.Minute)
+	cachingRepo.Get(context.Background(), testCluster, "ns", "app")
+	assert.Len(t, repo.GetCalls(), 2, "expired entries should be refreshed")
+
+	cachingRepo.Get(context.Background(), testCluster, "ns", "error")
+	cachingRepo.Get(context.Background(), testCluster, "ns", "error")
+	assert.Len(t, repo.GetCalls(), 4, "errors should not be cached")
+
+	// Status is not cached when only GetTTL is set
+	cachingRepo.Status(context.Background(), testCluster, "ns", "app")
+	cachingRepo.Status(context.Background(), testCluster, "ns", "app")
+	assert.Len(t, repo.StatusCalls(), 2, "Status should not be cached")
+}
+
+func TestCachingGatewayApplicationRepositoryStatusInvalidate(t *testing.T) {
+	repo := newCountingRepo()
+	cachingRepo := NewCachingGatewayApplicationRepository(repo, config.ResponseCacheConfig{GetTTL: time.Minute, StatusTTL: time.Minute})
+
+	status, err := cachingRepo.Status(context.Background(), testCluster, "ns", "app")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "app", status.SubmissionID, "status should match")
+
+	cachingRepo.Status(context.Background(), testCluster, "ns", "app")
+	cachingRepo.Get(context.Background(), testCluster, "ns", "app")
+	assert.Len(t, repo.StatusCalls(), 1, "second Status should be cached")
+
+	cachingRepo.Delete(context.Background(), testCluster, "ns", "app")
+
+	cachingRepo.Status(context.Background(), testCluster, "ns", "app")
+	cachingRepo.Get(context.Background(), testCluster, "ns", "app")
+	assert.Len(t, repo.StatusCalls(), 2, "Delete should invalidate Status")
+	assert.Len(t, repo.GetCalls(), 2, "Delete should invalidate Get")
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/metrics"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+)
+
+// flight is a call in progress whose result is shared by every caller making the same call
+type flight[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+	// metadata collects the SparkManager calls made by the call, for every caller to record as its own
+	metadata *domain.ResponseMetadata
+}
+
+// flightGroup collapses concurrent calls with the same key into one
+type flightGroup[T any] struct {
+	mu      sync.Mutex
+	flights map[string]*flight[T]
+	timeout time.Duration
+}
+
+func newFlightGroup[T any](timeout time.Duration) *flightGroup[T] {
+	return &flightGroup[T]{flights: map[string]*flight[T]{}, timeout: timeout}
+}
+
+// do calls fn once for all concurrent callers with key, returning shared for the callers that waited on another's call.
+// fn runs on its own context bounded by the group's timeout rather than on the context of the caller that started it,
+// so neither that caller disconnecting nor its latency budget fails the others. Each caller waits within its own
+// latency budget and records the call to its own ResponseMetadata.
+func (g *flightGroup[T]) do(ctx context.Context, cluster domain.KubeCluster, key string, fn func(ctx context.Context) (T, error)) (value T, shared bool, err error) {
+	g.mu.Lock()
+	f, shared := g.flights[key]
+	if !shared {
+		f = &flight[T]{done: make(chan struct{}), metadata: &domain.ResponseMetadata{}}
+		g.flights[key] = f
+
+		go func() {
+			callCtx, cancel := context.WithTimeout(domain.WithResponseMetadata(context.Background(), f.metadata), g.timeout)
+			defer cancel()
+			f.value, f.err = fn(callCtx)
+
+			g.mu.Lock()
+			delete(g.flights, key)
+			g.mu.Unlock()
+			close(f.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	waitCtx, phaseDone := domain.LatencyBudgetFrom(ctx).Phase(ctx, domain.LatencyBudgetSparkManager, cluster.Name)
+	select {
+	case <-f.done:
+		phaseDone(f.err)
+		metadata := domain.ResponseMetadataFrom(ctx)
+		for _, call := range f.metadata.SparkManagerCalls {
+			metadata.RecordSparkManagerCall(call.Cluster, call.Method, call.Path, time.Duration(call.DurationSeconds*float64(time.Second)))
+		}
+		return f.value, shared, f.err
+	case <-waitCtx.Done():
+		phaseDone(waitCtx.Err())
+		var zero T
+		return zero, shared, waitCtx.Err()
+	}
+}
+
+// DeduplicatingGatewayApplicationRepository collapses concurrent identical Get, Status and Logs calls to the wrapped
+// GatewayApplicationRepository into one, so dashboards fanning out the same query result in a single request to
+// SparkManager. Every caller, including the one that started the call, gets its own copy of the response.
+type DeduplicatingGatewayApplicationRepository struct {
+	GatewayApplicationRepository
+	gets     *flightGroup[*v1beta2.SparkApplication]
+	statuses *flightGroup[*domain.ApplicationStatus]
+	logs     *flightGroup[*string]
+}
+
+// NewDeduplicatingGatewayApplicationRepository wraps repo with request deduplication if it is enabled, otherwise repo
+// is returned unchanged.
+func NewDeduplicatingGatewayApplicationRepository(repo GatewayApplicationRepository, conf config.DeduplicationConfig) GatewayApplicationRepository {
+	if !conf.Enable {
+		return repo
+	}
+
+	return &DeduplicatingGatewayApplicationRepository{
+		GatewayApplicationRepository: repo,
+		gets:                         newFlightGroup[*v1beta2.SparkApplication](conf.Timeout),
+		statuses:                     newFlightGroup[*domain.ApplicationStatus](conf.Timeout),
+		logs:                         newFlightGroup[*string](conf.Timeout),
+	}
+}
+
+func (r *DeduplicatingGatewayApplicationRepository) Get(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplication, error) {
+	sparkApp, shared, err := r.gets.do(ctx, cluster, cacheKey(cluster, namespace, name), func(ctx context.Context) (*v1beta2.SparkApplication, error) {
+		return r.GatewayApplicationRepository.Get(ctx, cluster, namespace, name)
+	})
+	if shared {
+		recordDeduplicated(ctx, cluster, "get")
+	}
+	if sparkApp != nil {
+		sparkApp = sparkApp.DeepCopy()
+	}
+
+	return sparkApp, err
+}
+
+func (r *DeduplicatingGatewayApplicationRepository) Status(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*domain.ApplicationStatus, error) {
+	status, shared, err := r.statuses.do(ctx, cluster, cacheKey(cluster, namespace, name), func(ctx context.Context) (*domain.ApplicationStatus, error) {
+		return r.GatewayApplicationRepository.Status(ctx, cluster, namespace, name)
+	})
+	if shared {
+		recordDeduplicated(ctx, cluster, "status")
+	}
+	if status != nil {
+		status = status.DeepCopy()
+	}
+
+	return status, err
+}
+
+func (r *DeduplicatingGatewayApplicationRepository) Logs(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, tailLines int) (*string, error) {
+	key := fmt.Sprintf("%s/%d", cacheKey(cluster, namespace, name), tailLines)
+	logs, shared, err := r.logs.do(ctx, cluster, key, func(ctx context.Context) (*string, error) {
+		return r.GatewayApplicationRepository.Logs(ctx, cluster, namespace, name, tailLines)
+	})
+	if shared {
+		recordDeduplicated(ctx, cluster, "logs")
+	}
+	if logs != nil {
+		logsCopy := *logs
+		logs = &logsCopy
+	}
+
+	return logs, err
+}
+
+func recordDeduplicated(ctx context.Context, cluster domain.KubeCluster, operation string) {
+	domain.ResponseMetadataFrom(ctx).RecordDeduplicated(cluster.Name)
+	metrics.DeduplicatedRequestsTotal.WithLabelValues(operation).Inc()
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+)
+
+var testDeduplication = config.DeduplicationConfig{Enable: true, Timeout: time.Second}
+
+// waitingContext is ctx reporting to waiting when a caller starts waiting on it, which callers only do once they have
+// joined the call in flight
+type waitingContext struct {
+	context.Context
+	waiting chan<- struct{}
+	once    sync.Once
+}
+
+func newWaitingContext(ctx context.Context, waiting chan<- struct{}) context.Context {
+	return &waitingContext{Context: ctx, waiting: waiting}
+}
+
+func (c *waitingContext) Done() <-chan struct{} {
+	c.once.Do(func() { c.waiting <- struct{}{} })
+	return c.Context.Done()
+}
+
+// waitFor blocks until n callers are waiting
+func waitFor(t *testing.T, waiting <-chan struct{}, n int) {
+	for range n {
+		select {
+		case <-waiting:
+		case <-time.After(time.Second):
+			assert.FailNow(t, "callers should wait on the call in flight")
+		}
+	}
+}
+
+func TestNewDeduplicatingGatewayApplicationRepositoryDisabled(t *testing.T) {
+	repo := &GatewayApplicationRepositoryMock{}
+
+	assert.Same(t, repo, NewDeduplicatingGatewayApplicationRepository(repo, config.DeduplicationConfig{}), "repo should not be wrapped when deduplication is off")
+}
+
+func TestDeduplicatingGatewayApplicationRepositoryGet(t *testing.T) {
+	release := make(chan struct{})
+	repo := &GatewayApplicationRepositoryMock{
+		GetFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplication, error) {
+			<-release
+			return &v1beta2.SparkApplication{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}, nil
+		},
+	}
+	dedupRepo := NewDeduplicatingGatewayApplicationRepository(repo, testDeduplication).(*DeduplicatingGatewayApplicationRepository)
+
+	const callers = 5
+	results := make([]*v1beta2.SparkApplication, callers)
+	waiting := make(chan struct{}, callers)
+	var wg sync.WaitGroup
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = dedupRepo.Get(newWaitingContext(context.Background(), waiting), testCluster, "ns", "app")
+		}()
+	}
+
+	waitFor(t, waiting, callers)
+	close(release)
+	wg.Wait()
+
+	assert.Len(t, repo.GetCalls(), 1, "identical Gets in flight should make one call")
+	for _, result := range results {
+		assert.Equal(t, "app", result.Name, "every caller should get the response")
+	}
+
+	for i := range results {
+		results[i].Name = "mutated"
+		assert.Equal(t, i+1, countName(results, "mutated"), "every caller, including the first, should get its own copy of the response")
+	}
+
+	dedupRepo.Get(context.Background(), testCluster, "ns", "app")
+	assert.Len(t, repo.GetCalls(), 2, "responses should not be reused once the call finishes")
+}
+
+func countName(sparkApps []*v1beta2.SparkApplication, name string) int {
+	count := 0
+	for _, sparkApp := range sparkApps {
+		if sparkApp.Name == name {
+			count++
+		}
+	}
+	return count
+}
+
+func TestDeduplicatingGatewayApplicationRepositoryStatusError(t *testing.T) {
+	release := make(chan struct{})
+	repo := &GatewayApplicationRepositoryMock{
+		StatusFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*domain.ApplicationStatus, error) {
+			<-release
+			return nil, errors.New("SparkManager unavailable")
+		},
+	}
+	dedupRepo := NewDeduplicatingGatewayApplicationRepository(repo, testDeduplication).(*DeduplicatingGatewayApplicationRepository)
+
+	errs := make(chan error, 2)
+	waiting := make(chan struct{}, 2)
+	for range 2 {
+		go func() {
+			_, err := dedupRepo.Status(newWaitingContext(context.Background(), waiting), testCluster, "ns", "app")
+			errs <- err
+		}()
+	}
+
+	waitFor(t, waiting, 2)
+	close(release)
+
+	assert.EqualError(t, <-errs, "SparkManager unavailable", "errors should be shared")
+	assert.EqualError(t, <-errs, "SparkManager unavailable", "errors should be shared")
+	assert.Len(t, repo.StatusCalls(), 1, "identical Status calls in flight should make one call")
+}
+
+func TestDeduplicatingGatewayApplicationRepositoryLogsCancel(t *testing.T) {
+	release := make(chan struct{})
+	logs := "logs"
+	repo := &GatewayApplicationRepositoryMock{
+		LogsFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, tailLines int) (*string, error) {
+			<-release
+			return &logs, ctx.Err()
+		},
+	}
+	dedupRepo := NewDeduplicatingGatewayApplicationRepository(repo, testDeduplication).(*DeduplicatingGatewayApplicationRepository)
+
+	// The caller that started the call disconnects
+	ctx, cancel := context.WithCancel(context.Background())
+	waiting := make(chan struct{}, 2)
+	first := make(chan error, 1)
+	go func() {
+		_, err := dedupRepo.Logs(newWaitingContext(ctx, waiting), testCluster, "ns", "app", 100)
+		first <- err
+	}()
+
+	second := make(chan *string, 1)
+	go func() {
+		got, _ := dedupRepo.Logs(newWaitingContext(context.Background(), waiting), testCluster, "ns", "app", 100)
+		second <- got
+	}()
+
+	waitFor(t, waiting, 2)
+	cancel()
+	assert.ErrorIs(t, <-first, context.Canceled, "a cancelled caller should stop waiting")
+
+	close(release)
+	assert.Equal(t, &logs, <-second, "other callers should still get the response")
+
+	// Different tail lengths are different calls
+	dedupRepo.Logs(context.Background(), testCluster, "ns", "app", 10)
+	assert.Len(t, repo.LogsCalls(), 2, "logs with different tailLines should not be deduplicated")
+}
+
+func TestDeduplicatingGatewayApplicationRepositoryCallerBudget(t *testing.T) {
+	release := make(chan struct{})
+	repo := &GatewayApplicationRepositoryMock{
+		GetFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplication, error) {
+			<-release
+			if _, ok := ctx.Deadline(); !ok {
+				return nil, errors.New("shared call should have a deadline")
+			}
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			domain.ResponseMetadataFrom(ctx).RecordSparkManagerCall(cluster.Name, "GET", "/api/v1/ns/app", time.Millisecond)
+			return &v1beta2.SparkApplication{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}, nil
+		},
+	}
+	dedupRepo := NewDeduplicatingGatewayApplicationRepository(repo, testDeduplication).(*DeduplicatingGatewayApplicationRepository)
+
+	// The caller that starts the call has almost no budget left
+	budget := domain.NewLatencyBudget(10*time.Millisecond, nil)
+	waiting := make(chan struct{}, 2)
+	first := make(chan error, 1)
+	go func() {
+		_, err := dedupRepo.Get(newWaitingContext(domain.WithLatencyBudget(context.Background(), budget), waiting), testCluster, "ns", "app")
+		first <- err
+	}()
+	waitFor(t, waiting, 1)
+
+	metadata := &domain.ResponseMetadata{}
+	second := make(chan *v1beta2.SparkApplication, 1)
+	go func() {
+		got, _ := dedupRepo.Get(newWaitingContext(domain.WithResponseMetadata(context.Background(), metadata), waiting), testCluster, "ns", "app")
+		second <- got
+	}()
+	waitFor(t, waiting, 1)
+
+	assert.ErrorIs(t, <-first, context.DeadlineExceeded, "a caller out of budget should stop waiting")
+	assert.True(t, budget.Exhausted(), "the budget of the caller should record its SparkManager phase")
+
+	close(release)
+	got := <-second
+	if assert.NotNil(t, got, "other callers should not be bound by the first caller's budget") {
+		assert.Equal(t, "app", got.Name)
+	}
+	assert.Len(t, metadata.SparkManagerCalls, 1, "waiting callers should record the shared call to their own metadata")
+	assert.True(t, metadata.Deduplicated)
+}
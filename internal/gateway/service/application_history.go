@@ -0,0 +1,88 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+)
+
+//go:generate moq -rm  -out mockhistoryserverrepository.go . HistoryServerRepository
+
+type HistoryServerRepository interface {
+	Summary(ctx context.Context, cluster domain.KubeCluster, sparkApplicationID string) (*domain.HistoryServerSummary, error)
+}
+
+type historyServerApplicationService struct {
+	GatewayApplicationService
+	historyServerRepo HistoryServerRepository
+	config            config.HistoryServerConfig
+	summaries         *ttlCache[*domain.HistoryServerSummary]
+}
+
+// NewHistoryServerApplicationService wraps appService so Get adds the Spark History Server summary of terminal
+// GatewayApplications, read through historyServerRepo
+func NewHistoryServerApplicationService(appService GatewayApplicationService, historyServerRepo HistoryServerRepository, historyServerConfig config.HistoryServerConfig) GatewayApplicationService {
+	return &historyServerApplicationService{
+		GatewayApplicationService: appService,
+		historyServerRepo:         historyServerRepo,
+		config:                    historyServerConfig,
+		summaries:                 newTTLCache[*domain.HistoryServerSummary](historyServerConfig.CacheTTL),
+	}
+}
+
+// Get returns the GatewayApplication with its Spark History Server summary if it is terminal. The GatewayApplication
+// is returned without a summary if the Spark History Server fails or doesn't answer within the configured timeout.
+func (s *historyServerApplicationService) Get(ctx context.Context, gatewayId string) (*domain.GatewayApplication, error) {
+	gatewayApp, err := s.GatewayApplicationService.Get(ctx, gatewayId)
+	if err != nil {
+		return nil, err
+	}
+
+	status := gatewayApp.SparkApplication.Status
+	if !domain.IsTerminalApplicationState(status.AppState.State) || status.SparkApplicationID == "" {
+		return gatewayApp, nil
+	}
+
+	if summary, ok := s.summaries.get(status.SparkApplicationID); ok {
+		gatewayApp.HistoryServer = summary
+		return gatewayApp, nil
+	}
+
+	cluster, _, err := s.GetClusterNamespaceFromGatewayId(gatewayId)
+	if err != nil {
+		klog.Warningf("error getting cluster of GatewayApplication '%s' for its Spark History Server summary: %v", gatewayId, err)
+		return gatewayApp, nil
+	}
+
+	historyCtx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	summary, err := s.historyServerRepo.Summary(historyCtx, *cluster, status.SparkApplicationID)
+	if err != nil {
+		klog.Warningf("error getting Spark History Server summary of GatewayApplication '%s': %v", gatewayId, err)
+		return gatewayApp, nil
+	}
+
+	s.summaries.set(status.SparkApplicationID, summary)
+	gatewayApp.HistoryServer = summary
+
+	return gatewayApp, nil
+}
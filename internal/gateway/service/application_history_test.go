@@ -0,0 +1,91 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+)
+
+func TestHistoryServerApplicationServiceGet(t *testing.T) {
+	gatewayApp := func(state v1beta2.ApplicationStateType, sparkApplicationID string) *domain.GatewayApplication {
+		return &domain.GatewayApplication{
+			GatewayId: "clusterid-nsid-uuid",
+			Cluster:   "cluster",
+			SparkApplication: domain.GatewaySparkApplication{Status: v1beta2.SparkApplicationStatus{
+				SparkApplicationID: sparkApplicationID,
+				AppState:           v1beta2.ApplicationState{State: state},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		state       v1beta2.ApplicationStateType
+		appID       string
+		summaryErr  error
+		wantSummary bool
+		wantCalls   int
+	}{
+		{name: "completed application is summarized and cached", state: v1beta2.ApplicationStateCompleted, appID: "spark-123", wantSummary: true, wantCalls: 1},
+		{name: "failed application is summarized and cached", state: v1beta2.ApplicationStateFailed, appID: "spark-123", wantSummary: true, wantCalls: 1},
+		{name: "running application is not summarized", state: v1beta2.ApplicationStateRunning, appID: "spark-123"},
+		{name: "application without a SparkApplicationID is not summarized", state: v1beta2.ApplicationStateFailed},
+		{name: "Spark History Server error omits the summary", state: v1beta2.ApplicationStateCompleted, appID: "spark-123", summaryErr: errors.New("unavailable"), wantCalls: 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			appService := &GatewayApplicationServiceMock{
+				GetFunc: func(ctx context.Context, gatewayId string) (*domain.GatewayApplication, error) {
+					return gatewayApp(tc.state, tc.appID), nil
+				},
+				GetClusterNamespaceFromGatewayIdFunc: func(gatewayId string) (*domain.KubeCluster, string, error) {
+					return &domain.KubeCluster{Name: "cluster"}, "ns", nil
+				},
+			}
+			historyServerRepo := &HistoryServerRepositoryMock{
+				SummaryFunc: func(ctx context.Context, cluster domain.KubeCluster, sparkApplicationID string) (*domain.HistoryServerSummary, error) {
+					assert.Equal(t, "cluster", cluster.Name, "cluster of the GatewayApplication should be queried")
+					if tc.summaryErr != nil {
+						return nil, tc.summaryErr
+					}
+					return &domain.HistoryServerSummary{SparkApplicationID: sparkApplicationID}, nil
+				},
+			}
+			historyService := NewHistoryServerApplicationService(appService, historyServerRepo, config.HistoryServerConfig{Enable: true, Timeout: time.Second, CacheTTL: time.Hour})
+
+			for range 2 {
+				got, err := historyService.Get(context.Background(), "clusterid-nsid-uuid")
+				assert.NoError(t, err, "Get should not error")
+				if tc.wantSummary {
+					assert.Equal(t, &domain.HistoryServerSummary{SparkApplicationID: tc.appID}, got.HistoryServer, "summary should be added")
+				} else {
+					assert.Nil(t, got.HistoryServer, "summary should be omitted")
+				}
+			}
+			assert.Len(t, historyServerRepo.SummaryCalls(), tc.wantCalls, "Spark History Server should be queried until a summary is cached")
+		})
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/metrics"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+// submissionLease is how long a claimed submission is reserved for the instance submitting it. Any instance retries it
+// once the lease runs out, e.g. because the instance submitting it died.
+const submissionLease = 2 * time.Minute
+
+// submissionTimeout bounds each attempt to create a queued application, well within its lease
+const submissionTimeout = time.Minute
+
+// QueueingGatewayApplicationRepository queues applications created with domain.WithAsyncSubmission in the database
+// instead of creating them, so they are accepted while their cluster's SparkManager is unreachable. Drain creates them
+// in their cluster through the wrapped GatewayApplicationRepository. Get, Status and Delete fall back to the queue for
+// applications that haven't been created yet, so they can be followed and cancelled by GatewayId.
+type QueueingGatewayApplicationRepository struct {
+	GatewayApplicationRepository
+	clusterRepository repository.ClusterRepository
+	db                database.QueuedSubmissionDatabase
+	config            config.AsyncSubmissionConfig
+	now               func() time.Time
+}
+
+func NewQueueingGatewayApplicationRepository(repo GatewayApplicationRepository, clusterRepository repository.ClusterRepository, db database.QueuedSubmissionDatabase, config config.AsyncSubmissionConfig) *QueueingGatewayApplicationRepository {
+	return &QueueingGatewayApplicationRepository{
+		GatewayApplicationRepository: repo,
+		clusterRepository:            clusterRepository,
+		db:                           db,
+		config:                       config,
+		now:                          time.Now,
+	}
+}
+
+// Create queues application if ctx is an asynchronous submission, returning it as it will be created
+func (r *QueueingGatewayApplicationRepository) Create(ctx context.Context, cluster domain.KubeCluster, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
+	if !domain.IsAsyncSubmission(ctx) {
+		return r.GatewayApplicationRepository.Create(ctx, cluster, application)
+	}
+
+	submission := database.QueuedSubmission{
+		GatewayID:   application.Name,
+		Cluster:     cluster.Name,
+		Namespace:   application.Namespace,
+		Username:    application.Labels[domain.GATEWAY_USER_LABEL],
+		Application: application,
+		State:       string(domain.SubmissionStateQueued),
+		CreatedAt:   r.now().UTC(),
+	}
+	if err := r.db.InsertQueuedSubmission(ctx, submission); err != nil {
+		return nil, fmt.Errorf("error queueing SparkApplication '%s/%s': %w", application.Namespace, application.Name, err)
+	}
+
+	return queuedSparkApplication(&submission), nil
+}
+
+func (r *QueueingGatewayApplicationRepository) Get(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplication, error) {
+	sparkApp, err := r.GatewayApplicationRepository.Get(ctx, cluster, namespace, name)
+	if err == nil {
+		return sparkApp, nil
+	}
+
+	submission := r.unsubmitted(ctx, name)
+	if submission == nil {
+		return nil, err
+	}
+
+	return queuedSparkApplication(submission), nil
+}
+
+func (r *QueueingGatewayApplicationRepository) Status(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*domain.ApplicationStatus, error) {
+	status, err := r.GatewayApplicationRepository.Status(ctx, cluster, namespace, name)
+	if err == nil {
+		return status, nil
+	}
+
+	submission := r.unsubmitted(ctx, name)
+	if submission == nil {
+		return nil, err
+	}
+
+	queuedTime := submission.CreatedAt
+	queued := &domain.QueuedSubmission{
+		State:      domain.SubmissionState(submission.State),
+		Attempts:   int(submission.Attempts),
+		QueuedTime: queuedTime,
+	}
+	if submission.LastError != nil {
+		queued.LastError = *submission.LastError
+	}
+	if queued.State == domain.SubmissionStateQueued {
+		nextAttemptTime := submission.NextAttemptAt
+		queued.NextAttemptTime = &nextAttemptTime
+	}
+
+	return &domain.ApplicationStatus{
+		SparkApplicationStatus: queuedSparkApplicationStatus(submission),
+		CreationTimestamp:      metav1.NewTime(queuedTime),
+		Submission:             queued,
+	}, nil
+}
+
+// Delete removes the application from the queue if it hasn't been submitted, otherwise deletes it from its cluster
+func (r *QueueingGatewayApplicationRepository) Delete(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) error {
+	deleted, err := r.db.DeleteUnsubmittedQueuedSubmission(ctx, name)
+	if err != nil {
+		return fmt.Errorf("error deleting queued SparkApplication '%s/%s': %w", namespace, name, err)
+	}
+	if deleted {
+		return nil
+	}
+
+	return r.GatewayApplicationRepository.Delete(ctx, cluster, namespace, name)
+}
+
+// unsubmitted returns the queued submission of name if it hasn't been created in its cluster. Errors reading the
+// queue are logged so callers return the error of the wrapped repository.
+func (r *QueueingGatewayApplicationRepository) unsubmitted(ctx context.Context, name string) *database.QueuedSubmission {
+	submission, err := r.db.GetQueuedSubmission(ctx, name)
+	if err != nil {
+		klog.Warningf("error checking the submission queue for SparkApplication '%s': %v", name, err)
+		return nil
+	}
+	if submission == nil || submission.State == string(domain.SubmissionStateSubmitted) {
+		return nil
+	}
+
+	return submission
+}
+
+// Run calls Drain every PollInterval until ctx is done
+func (r *QueueingGatewayApplicationRepository) Run(ctx context.Context) {
+	klog.Infof("Starting submission queue with poll interval %s", r.config.PollInterval)
+
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		r.Drain(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Drain prunes finished submissions older than Retention and submits up to BatchSize queued applications that are due
+func (r *QueueingGatewayApplicationRepository) Drain(ctx context.Context) {
+	now := r.now().UTC()
+
+	pruned, err := r.db.DeleteFinishedQueuedSubmissionsBefore(ctx, now.Add(-r.config.Retention))
+	if err != nil {
+		klog.Errorf("error pruning the submission queue: %v", err)
+	} else if pruned > 0 {
+		klog.Infof("Pruned %d finished submissions from the submission queue", pruned)
+	}
+
+	submissions, err := r.db.ClaimQueuedSubmissions(ctx, now, now.Add(submissionLease), r.config.BatchSize)
+	if err != nil {
+		klog.Errorf("error claiming queued submissions: %v", err)
+		return
+	}
+
+	for _, submission := range submissions {
+		r.submit(ctx, submission)
+	}
+}
+
+// submit creates a claimed submission in its cluster. SparkManager returns the existing SparkApplication for
+// identical submissions, so resubmitting an application whose previous attempt succeeded without being recorded is
+// safe.
+func (r *QueueingGatewayApplicationRepository) submit(ctx context.Context, submission database.QueuedSubmission) {
+	cluster, err := r.clusterRepository.GetByName(submission.Cluster)
+	if err != nil {
+		r.attemptFailed(ctx, submission, fmt.Errorf("error getting cluster: %w", err), false)
+		return
+	}
+
+	submitCtx, cancel := context.WithTimeout(ctx, submissionTimeout)
+	_, err = r.GatewayApplicationRepository.Create(submitCtx, *cluster, submission.Application)
+	cancel()
+	if err != nil {
+		r.attemptFailed(ctx, submission, err, retryableSubmissionError(err) && int(submission.Attempts) < r.config.MaxAttempts)
+		return
+	}
+
+	now := r.now().UTC()
+	submitted, err := r.db.UpdateQueuedSubmission(ctx, submission.GatewayID, string(domain.SubmissionStateSubmitted), nil, now, now)
+	if err != nil {
+		// The lease runs out and the submission is retried, which returns the created SparkApplication
+		klog.Errorf("error recording submission of queued SparkApplication '%s': %v", submission.GatewayID, err)
+		return
+	}
+	metrics.QueuedSubmissionAttemptsTotal.WithLabelValues(submission.Cluster, "submitted").Inc()
+
+	// The submission was deleted while it was being submitted
+	if !submitted {
+		klog.Infof("Queued SparkApplication '%s' was deleted while being submitted, deleting it from cluster '%s'", submission.GatewayID, cluster.Name)
+		if err := r.GatewayApplicationRepository.Delete(ctx, *cluster, submission.Namespace, submission.GatewayID); err != nil {
+			klog.Errorf("error deleting SparkApplication '%s' deleted while queued: %v", submission.GatewayID, err)
+		}
+		return
+	}
+
+	klog.Infof("Submitted queued SparkApplication '%s' to cluster '%s' after %d attempts", submission.GatewayID, cluster.Name, submission.Attempts)
+}
+
+// attemptFailed records a failed attempt, scheduling the next attempt with backoff if retry is set, otherwise failing
+// the submission
+func (r *QueueingGatewayApplicationRepository) attemptFailed(ctx context.Context, submission database.QueuedSubmission, attemptErr error, retry bool) {
+	now := r.now().UTC()
+	lastError := attemptErr.Error()
+
+	state, nextAttempt, result := domain.SubmissionStateFailed, now, "failed"
+	if retry {
+		state, nextAttempt, result = domain.SubmissionStateQueued, now.Add(r.backoff(int(submission.Attempts))), "retried"
+	}
+
+	if _, err := r.db.UpdateQueuedSubmission(ctx, submission.GatewayID, string(state), &lastError, nextAttempt, now); err != nil {
+		klog.Errorf("error recording failed attempt to submit queued SparkApplication '%s': %v", submission.GatewayID, err)
+		return
+	}
+	metrics.QueuedSubmissionAttemptsTotal.WithLabelValues(submission.Cluster, result).Inc()
+
+	klog.Warningf("Attempt %d to submit queued SparkApplication '%s' failed, %s: %v", submission.Attempts, submission.GatewayID, result, attemptErr)
+}
+
+// backoff returns the delay after the attempts-th failed attempt, doubling from InitialBackoff up to MaxBackoff
+func (r *QueueingGatewayApplicationRepository) backoff(attempts int) time.Duration {
+	delay := r.config.InitialBackoff
+	for i := 1; i < attempts && delay < r.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, r.config.MaxBackoff)
+}
+
+// retryableSubmissionError returns whether a failed attempt may succeed later. Errors reaching SparkManager and
+// server errors are retried, while SparkManager rejecting the application fails the submission.
+func retryableSubmissionError(err error) bool {
+	var gatewayErr gatewayerrors.GatewayError
+	if !errors.As(err, &gatewayErr) {
+		return true
+	}
+
+	return gatewayErr.Status >= http.StatusInternalServerError || gatewayErr.Status == http.StatusTooManyRequests || gatewayErr.Status == http.StatusRequestTimeout
+}
+
+// queuedSparkApplication returns the SparkApplication of a submission that hasn't been created in its cluster
+func queuedSparkApplication(submission *database.QueuedSubmission) *v1beta2.SparkApplication {
+	sparkApp := submission.Application.DeepCopy()
+	sparkApp.CreationTimestamp = metav1.NewTime(submission.CreatedAt)
+	sparkApp.Status = queuedSparkApplicationStatus(submission)
+
+	return sparkApp
+}
+
+// queuedSparkApplicationStatus is empty for QUEUED submissions, which GatewayState reports as PENDING, and
+// FAILED_SUBMISSION with the last error for FAILED submissions
+func queuedSparkApplicationStatus(submission *database.QueuedSubmission) v1beta2.SparkApplicationStatus {
+	if submission.State != string(domain.SubmissionStateFailed) {
+		return v1beta2.SparkApplicationStatus{}
+	}
+
+	status := v1beta2.SparkApplicationStatus{AppState: v1beta2.ApplicationState{State: v1beta2.ApplicationStateFailedSubmission}}
+	if submission.LastError != nil {
+		status.AppState.ErrorMessage = *submission.LastError
+	}
+
+	return status
+}
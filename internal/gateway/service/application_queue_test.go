@@ -0,0 +1,228 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+var testAsyncSubmissionConfig = config.AsyncSubmissionConfig{
+	Enable:         true,
+	PollInterval:   time.Second,
+	BatchSize:      10,
+	MaxAttempts:    3,
+	InitialBackoff: 10 * time.Second,
+	MaxBackoff:     time.Minute,
+	Retention:      time.Hour,
+}
+
+func newQueuedSubmission(state domain.SubmissionState, attempts int32) database.QueuedSubmission {
+	return database.QueuedSubmission{
+		GatewayID:   "clusterid-nsid-uuid",
+		Cluster:     "test-cluster",
+		Namespace:   "testNamespace",
+		Username:    TEST_USER,
+		Application: &v1beta2.SparkApplication{ObjectMeta: metav1.ObjectMeta{Name: "clusterid-nsid-uuid", Namespace: "testNamespace"}},
+		State:       string(state),
+		Attempts:    attempts,
+		CreatedAt:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestQueueingGatewayApplicationRepositoryCreate(t *testing.T) {
+	repo := &GatewayApplicationRepositoryMock{
+		CreateFunc: func(ctx context.Context, cluster domain.KubeCluster, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
+			return application, nil
+		},
+	}
+	db := &database.QueuedSubmissionDatabaseMock{
+		InsertQueuedSubmissionFunc: func(ctx context.Context, submission database.QueuedSubmission) error {
+			return nil
+		},
+	}
+	queueingRepo := NewQueueingGatewayApplicationRepository(repo, mockClusterRepo_Success, db, testAsyncSubmissionConfig)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	queueingRepo.now = func() time.Time { return now }
+
+	application := &v1beta2.SparkApplication{ObjectMeta: metav1.ObjectMeta{
+		Name:      "clusterid-nsid-uuid",
+		Namespace: "testNamespace",
+		Labels:    map[string]string{domain.GATEWAY_USER_LABEL: TEST_USER},
+	}}
+
+	_, err := queueingRepo.Create(context.Background(), testCluster, application)
+	assert.NoError(t, err, "synchronous create should succeed")
+	assert.Len(t, repo.CreateCalls(), 1, "synchronous submissions should be created in the cluster")
+	assert.Empty(t, db.InsertQueuedSubmissionCalls(), "synchronous submissions should not be queued")
+
+	queued, err := queueingRepo.Create(domain.WithAsyncSubmission(context.Background()), testCluster, application)
+	assert.NoError(t, err, "asynchronous create should succeed")
+	assert.Len(t, repo.CreateCalls(), 1, "asynchronous submissions should not be created in the cluster")
+	assert.Len(t, db.InsertQueuedSubmissionCalls(), 1, "asynchronous submissions should be queued")
+
+	inserted := db.InsertQueuedSubmissionCalls()[0].Submission
+	assert.Equal(t, "clusterid-nsid-uuid", inserted.GatewayID, "the GatewayId should be queued")
+	assert.Equal(t, testCluster.Name, inserted.Cluster, "the routed cluster should be queued")
+	assert.Equal(t, TEST_USER, inserted.Username, "the submitting user should be queued")
+	assert.Equal(t, now, queued.CreationTimestamp.Time, "queued applications should be created when queued")
+	assert.Equal(t, domain.GatewayStatePending, domain.NewGatewayState(queued.Status.AppState.State), "queued applications should be pending")
+}
+
+func TestQueueingGatewayApplicationRepositoryStatus(t *testing.T) {
+	unreachable := gatewayerrors.NewUnavailable(errors.New("SparkManager unreachable"))
+	lastError := "SparkManager unreachable"
+
+	tests := []struct {
+		name       string
+		submission *database.QueuedSubmission
+		wantErr    bool
+		wantState  v1beta2.ApplicationStateType
+	}{
+		{name: "not queued", wantErr: true},
+		{name: "submitted", submission: &database.QueuedSubmission{State: string(domain.SubmissionStateSubmitted)}, wantErr: true},
+		{name: "queued", submission: &database.QueuedSubmission{State: string(domain.SubmissionStateQueued), Attempts: 1, LastError: &lastError}, wantState: v1beta2.ApplicationStateNew},
+		{name: "failed", submission: &database.QueuedSubmission{State: string(domain.SubmissionStateFailed), Attempts: 3, LastError: &lastError}, wantState: v1beta2.ApplicationStateFailedSubmission},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repo := &GatewayApplicationRepositoryMock{
+				StatusFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*domain.ApplicationStatus, error) {
+					return nil, unreachable
+				},
+			}
+			db := &database.QueuedSubmissionDatabaseMock{
+				GetQueuedSubmissionFunc: func(ctx context.Context, gatewayId string) (*database.QueuedSubmission, error) {
+					return test.submission, nil
+				},
+			}
+			queueingRepo := NewQueueingGatewayApplicationRepository(repo, mockClusterRepo_Success, db, testAsyncSubmissionConfig)
+
+			status, err := queueingRepo.Status(context.Background(), testCluster, "testNamespace", "clusterid-nsid-uuid")
+			if test.wantErr {
+				assert.ErrorIs(t, err, unreachable, "the SparkManager error should be returned")
+				return
+			}
+
+			assert.NoError(t, err, "queued submissions should have a status")
+			assert.Equal(t, test.wantState, status.AppState.State, "state should match the submission")
+			assert.Equal(t, domain.SubmissionState(test.submission.State), status.Submission.State, "submission state should be returned")
+			assert.Equal(t, lastError, status.Submission.LastError, "the last error should be returned")
+		})
+	}
+}
+
+func TestQueueingGatewayApplicationRepositoryDelete(t *testing.T) {
+	repo := &GatewayApplicationRepositoryMock{
+		DeleteFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) error {
+			return nil
+		},
+	}
+	queued := true
+	db := &database.QueuedSubmissionDatabaseMock{
+		DeleteUnsubmittedQueuedSubmissionFunc: func(ctx context.Context, gatewayId string) (bool, error) {
+			return queued, nil
+		},
+	}
+	queueingRepo := NewQueueingGatewayApplicationRepository(repo, mockClusterRepo_Success, db, testAsyncSubmissionConfig)
+
+	assert.NoError(t, queueingRepo.Delete(context.Background(), testCluster, "testNamespace", "clusterid-nsid-uuid"), "deleting a queued submission should succeed")
+	assert.Empty(t, repo.DeleteCalls(), "queued submissions should not be deleted from the cluster")
+
+	queued = false
+	assert.NoError(t, queueingRepo.Delete(context.Background(), testCluster, "testNamespace", "clusterid-nsid-uuid"), "deleting a submitted application should succeed")
+	assert.Len(t, repo.DeleteCalls(), 1, "submitted applications should be deleted from the cluster")
+}
+
+func TestQueueingGatewayApplicationRepositoryDrain(t *testing.T) {
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		attempts    int32
+		createErr   error
+		updated     bool
+		wantState   domain.SubmissionState
+		wantNext    time.Time
+		wantDeleted bool
+	}{
+		{name: "submitted", attempts: 1, updated: true, wantState: domain.SubmissionStateSubmitted, wantNext: now},
+		{name: "deleted while submitting", attempts: 1, wantState: domain.SubmissionStateSubmitted, wantNext: now, wantDeleted: true},
+		{name: "unreachable", attempts: 1, createErr: errors.New("connection refused"), updated: true, wantState: domain.SubmissionStateQueued, wantNext: now.Add(10 * time.Second)},
+		{name: "unavailable backs off", attempts: 2, createErr: gatewayerrors.NewUnavailable(errors.New("unavailable")), updated: true, wantState: domain.SubmissionStateQueued, wantNext: now.Add(20 * time.Second)},
+		{name: "out of attempts", attempts: 3, createErr: errors.New("connection refused"), updated: true, wantState: domain.SubmissionStateFailed, wantNext: now},
+		{name: "rejected", attempts: 1, createErr: gatewayerrors.NewInvalid(errors.New("invalid")), updated: true, wantState: domain.SubmissionStateFailed, wantNext: now},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repo := &GatewayApplicationRepositoryMock{
+				CreateFunc: func(ctx context.Context, cluster domain.KubeCluster, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
+					return application, test.createErr
+				},
+				DeleteFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) error {
+					return nil
+				},
+			}
+			db := &database.QueuedSubmissionDatabaseMock{
+				DeleteFinishedQueuedSubmissionsBeforeFunc: func(ctx context.Context, before time.Time) (int64, error) {
+					return 0, nil
+				},
+				ClaimQueuedSubmissionsFunc: func(ctx context.Context, now time.Time, leaseUntil time.Time, size int) ([]database.QueuedSubmission, error) {
+					return []database.QueuedSubmission{newQueuedSubmission(domain.SubmissionStateQueued, test.attempts)}, nil
+				},
+				UpdateQueuedSubmissionFunc: func(ctx context.Context, gatewayId string, state string, lastError *string, nextAttemptAt time.Time, now time.Time) (bool, error) {
+					return test.updated, nil
+				},
+			}
+			queueingRepo := NewQueueingGatewayApplicationRepository(repo, mockClusterRepo_Success, db, testAsyncSubmissionConfig)
+			queueingRepo.now = func() time.Time { return now }
+
+			queueingRepo.Drain(context.Background())
+
+			assert.Equal(t, now.Add(-time.Hour), db.DeleteFinishedQueuedSubmissionsBeforeCalls()[0].Before, "entries older than the retention should be pruned")
+			assert.Equal(t, now.Add(submissionLease), db.ClaimQueuedSubmissionsCalls()[0].LeaseUntil, "claimed submissions should be leased")
+			assert.Len(t, repo.CreateCalls(), 1, "the claimed submission should be submitted")
+
+			assert.Len(t, db.UpdateQueuedSubmissionCalls(), 1, "the attempt should be recorded")
+			update := db.UpdateQueuedSubmissionCalls()[0]
+			assert.Equal(t, string(test.wantState), update.State, "state should match")
+			assert.Equal(t, test.wantNext, update.NextAttemptAt, "next attempt should match")
+			assert.Equal(t, test.createErr != nil, update.LastError != nil, "failed attempts should record their error")
+			assert.Equal(t, test.wantDeleted, len(repo.DeleteCalls()) == 1, "applications deleted while submitting should be deleted from the cluster")
+		})
+	}
+}
+
+func TestQueueingGatewayApplicationRepositoryBackoff(t *testing.T) {
+	queueingRepo := NewQueueingGatewayApplicationRepository(nil, nil, nil, testAsyncSubmissionConfig)
+
+	assert.Equal(t, 10*time.Second, queueingRepo.backoff(1), "the first retry should wait initialBackoff")
+	assert.Equal(t, 40*time.Second, queueingRepo.backoff(3), "backoff should double")
+	assert.Equal(t, time.Minute, queueingRepo.backoff(30), "backoff should be capped at maxBackoff")
+}
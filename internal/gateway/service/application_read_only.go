@@ -0,0 +1,58 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+// errReadOnly is returned by the write side of read-only Gateways
+var errReadOnly = gatewayerrors.New(http.StatusNotImplemented, errors.New("this Gateway is read-only, submit changes to a Gateway serving writes"))
+
+// readOnlyApplicationService serves the reads of a GatewayApplicationReader and rejects every write, so a read-only
+// Gateway can't change GatewayApplications even through services that write on their own, e.g. Livy or migrations
+type readOnlyApplicationService struct {
+	GatewayApplicationReader
+}
+
+// NewReadOnlyApplicationService returns a GatewayApplicationService serving the reads of reader and rejecting writes
+// with a 501
+func NewReadOnlyApplicationService(reader GatewayApplicationReader) GatewayApplicationService {
+	return &readOnlyApplicationService{GatewayApplicationReader: reader}
+}
+
+func (s *readOnlyApplicationService) Create(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error) {
+	return nil, errReadOnly
+}
+
+func (s *readOnlyApplicationService) Delete(ctx context.Context, gatewayId string) error {
+	return errReadOnly
+}
+
+func (s *readOnlyApplicationService) Scale(ctx context.Context, gatewayId string, scale domain.ExecutorScale) (*domain.GatewayApplication, error) {
+	return nil, errReadOnly
+}
+
+func (s *readOnlyApplicationService) Update(ctx context.Context, gatewayId string, update domain.ApplicationUpdate) (*domain.GatewayApplication, error) {
+	return nil, errReadOnly
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+func TestReadOnlyApplicationService(t *testing.T) {
+	appService := &GatewayApplicationServiceMock{
+		GetFunc: func(ctx context.Context, gatewayId string) (*domain.GatewayApplication, error) {
+			return &domain.GatewayApplication{GatewayId: gatewayId}, nil
+		},
+	}
+	readOnlyService := NewReadOnlyApplicationService(appService)
+
+	got, err := readOnlyService.Get(context.Background(), "clusterid-nsid-uuid")
+	assert.NoError(t, err, "reads should be served")
+	assert.Equal(t, "clusterid-nsid-uuid", got.GatewayId, "reads should be passed through")
+
+	writes := map[string]func() error{
+		"create": func() error {
+			_, err := readOnlyService.Create(context.Background(), &v1beta2.SparkApplication{}, "user")
+			return err
+		},
+		"delete": func() error {
+			return readOnlyService.Delete(context.Background(), "clusterid-nsid-uuid")
+		},
+		"scale": func() error {
+			_, err := readOnlyService.Scale(context.Background(), "clusterid-nsid-uuid", domain.ExecutorScale{})
+			return err
+		},
+		"update": func() error {
+			_, err := readOnlyService.Update(context.Background(), "clusterid-nsid-uuid", domain.ApplicationUpdate{})
+			return err
+		},
+	}
+	for name, write := range writes {
+		t.Run(name, func(t *testing.T) {
+			var gatewayErr gatewayerrors.GatewayError
+			if assert.ErrorAs(t, write(), &gatewayErr, "writes should be rejected") {
+				assert.Equal(t, http.StatusNotImplemented, gatewayErr.Status, "codes should match")
+			}
+			assert.Empty(t, appService.CreateCalls(), "writes shouldn't reach the service")
+			assert.Empty(t, appService.DeleteCalls(), "writes shouldn't reach the service")
+			assert.Empty(t, appService.ScaleCalls(), "writes shouldn't reach the service")
+			assert.Empty(t, appService.UpdateCalls(), "writes shouldn't reach the service")
+		})
+	}
+}
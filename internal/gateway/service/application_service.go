@@ -17,8 +17,12 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
+	"io"
+	"net/http"
+	"slices"
+	"time"
 
 	"k8s.io/klog/v2"
 
@@ -26,34 +30,78 @@ import (
 	"github.com/slackhq/spark-gateway/internal/domain"
 	"github.com/slackhq/spark-gateway/internal/gateway/repository"
 	"github.com/slackhq/spark-gateway/internal/shared/config"
-	"github.com/slackhq/spark-gateway/internal/shared/util"
 
 	"github.com/slackhq/spark-gateway/internal/gateway/clusterrouter"
 	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
 )
 
+// maxCreateAttempts is the number of GatewayIds tried when creating a GatewayApplication before giving up on collisions
+const maxCreateAttempts = 3
+
+// GatewayIdGenerator generates the GatewayId of an application submitted to namespace in cluster, see
+// RegisterGatewayIdGenerator
 type GatewayIdGenerator func(cluster domain.KubeCluster, namespace string) (string, error)
 
 //go:generate moq -rm  -out mocksparkapplicationrepository.go . GatewayApplicationRepository
 
 type GatewayApplicationRepository interface {
 	Get(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplication, error)
-	List(ctx context.Context, cluster domain.KubeCluster, namespace string) ([]*domain.SparkManagerSparkApplicationSummary, error)
-	Status(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplicationStatus, error)
+	List(ctx context.Context, cluster domain.KubeCluster, namespace string, view domain.SummaryView) ([]*domain.SparkManagerSparkApplicationSummary, error)
+	Counts(ctx context.Context, cluster domain.KubeCluster, namespace string) (*domain.SparkManagerApplicationCounts, error)
+	Status(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*domain.ApplicationStatus, error)
+	StreamStatus(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (io.ReadCloser, error)
 	Logs(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, tailLines int) (*string, error)
+	FollowLogs(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, tailLines int) (io.ReadCloser, error)
+	ExecutorLogs(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, executorId string, tailLines int) (*string, error)
+	StreamLogs(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (io.ReadCloser, error)
+	Pods(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) ([]*domain.SparkApplicationPod, error)
+	Cost(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*domain.ApplicationCost, error)
+	DriverMetrics(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (io.ReadCloser, error)
+	Timeline(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) ([]domain.TimelineEvent, error)
 	Create(ctx context.Context, cluster domain.KubeCluster, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error)
 	Delete(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) error
+	Scale(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, scale domain.ExecutorScale) (*v1beta2.SparkApplication, error)
+	Update(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, update domain.ApplicationUpdate) (*v1beta2.SparkApplication, error)
+	RenderPods(ctx context.Context, cluster domain.KubeCluster, application *v1beta2.SparkApplication) (*domain.RenderedPods, error)
+	Watch(ctx context.Context, cluster domain.KubeCluster, namespace string, labelSelector string, resourceVersion string) (io.ReadCloser, error)
 }
 
 //go:generate moq -rm  -out mockgatewayapplicationservice.go . GatewayApplicationService
 
+// GatewayApplicationService is both the read and the write side of the GatewayApplication API
 type GatewayApplicationService interface {
+	GatewayApplicationReader
+	GatewayApplicationWriter
+}
+
+// GatewayApplicationReader reads GatewayApplications, their status, logs and metrics without changing them. Rendering
+// pods is a dry run, so it is a read.
+type GatewayApplicationReader interface {
 	Get(ctx context.Context, gatewayId string) (*domain.GatewayApplication, error)
-	List(ctx context.Context, cluster string, namespace string) ([]*domain.GatewayApplicationSummary, error)
-	Create(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error)
-	Status(ctx context.Context, gatewayId string) (*v1beta2.SparkApplicationStatus, error)
+	List(ctx context.Context, cluster string, namespace string, view domain.SummaryView, listSort domain.ListSort) ([]*domain.GatewayApplicationSummary, error)
+	Counts(ctx context.Context, cluster string, namespace string, groupBy []domain.CountGroupBy) ([]*domain.ApplicationCount, error)
+	Resubmission(ctx context.Context, gatewayId string) (*v1beta2.SparkApplication, error)
+	RenderPods(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.RenderedPods, error)
+	Status(ctx context.Context, gatewayId string) (*domain.ApplicationStatus, error)
+	WaitStatus(ctx context.Context, gatewayId string, lastState v1beta2.ApplicationStateType, timeout time.Duration) (*domain.GatewayApplicationWaitStatus, error)
+	StreamStatus(ctx context.Context, gatewayId string) (<-chan *domain.ApplicationStatus, error)
 	Logs(ctx context.Context, gatewayId string, tailLines int) (*string, error)
+	FollowLogs(ctx context.Context, gatewayId string, tailLines int) (io.ReadCloser, error)
+	ExecutorLogs(ctx context.Context, gatewayId string, executorId string, tailLines int) (*string, error)
+	StreamLogs(ctx context.Context, gatewayId string) (io.ReadCloser, error)
+	Pods(ctx context.Context, gatewayId string) (*domain.ApplicationPods, error)
+	DriverMetrics(ctx context.Context, gatewayId string) (io.ReadCloser, error)
+	Timeline(ctx context.Context, gatewayId string) (*domain.ApplicationTimeline, error)
+	Watch(ctx context.Context, namespace string, labelSelector string, bookmark domain.WatchBookmark) (<-chan *domain.GatewayWatchEvent, error)
+	GetClusterNamespaceFromGatewayId(gatewayId string) (*domain.KubeCluster, string, error)
+}
+
+// GatewayApplicationWriter creates and changes GatewayApplications
+type GatewayApplicationWriter interface {
+	Create(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error)
 	Delete(ctx context.Context, gatewayId string) error
+	Scale(ctx context.Context, gatewayId string, scale domain.ExecutorScale) (*domain.GatewayApplication, error)
+	Update(ctx context.Context, gatewayId string, update domain.ApplicationUpdate) (*domain.GatewayApplication, error)
 }
 
 type service struct {
@@ -91,19 +139,17 @@ func NewApplicationService(
 
 func (s *service) GetClusterNamespaceFromGatewayId(gatewayId string) (*domain.KubeCluster, string, error) {
 	// gatewayId format is 'clusterId-namespaceId-uuid'.
-	parts := strings.SplitN(gatewayId, "-", 3)
-	if len(parts) < 3 {
-		return nil, "", gatewayerrors.NewBadRequest(fmt.Errorf("invalid gatewayId '%s', format must be 'cluster-namespace-uuid'", gatewayId))
+	clusterId, namespaceId, err := domain.ParseGatewayId(gatewayId)
+	if err != nil {
+		return nil, "", gatewayerrors.NewBadRequest(err)
 	}
 
-	clusterId := parts[0]
 	kubeCluster, err := s.clusterRepository.GetById(clusterId)
 
 	if err != nil {
 		return nil, "", gatewayerrors.NewInternal(fmt.Errorf("error getting cluster parsed from gatewayId: %w", err))
 	}
 
-	namespaceId := parts[1]
 	namespace, err := kubeCluster.GetNamespaceById(namespaceId)
 
 	if err != nil {
@@ -128,13 +174,27 @@ func (s *service) Get(ctx context.Context, gatewayId string) (*domain.GatewayApp
 	gatewayApp := domain.GatewayApplicationFromV1Beta2SparkApplication(sparkApp)
 
 	// Set log URLs
-	gatewayApp.SparkLogURLs = GetRenderedURLs(s.config.StatusUrlTemplates, &gatewayApp.SparkApplication)
+	var urlWarnings []domain.SubmissionWarning
+	gatewayApp.SparkLogURLs, urlWarnings = GetRenderedURLs(s.config.StatusUrlTemplates, &gatewayApp.SparkApplication)
+	gatewayApp.Warnings = append(gatewayApp.Warnings, urlWarnings...)
+
+	// Estimate the cost of the requested resources if the cluster has cost rates
+	if cluster.Cost.Enabled() {
+		cost, err := s.gatewayAppRepo.Cost(ctx, *cluster, namespace, gatewayId)
+		if err != nil {
+			// The estimate is informational, so the GatewayApplication is returned without it
+			klog.Warningf("error estimating the cost of GatewayApplication '%s': %v", gatewayId, err)
+		}
+		gatewayApp.Cost = cost
+	}
 
 	return gatewayApp, nil
 }
 
-// List retrieves `num` number of GatewayApplications from specified namespace `namespace` in cluster `cluster`
-func (s *service) List(ctx context.Context, cluster string, namespace string) ([]*domain.GatewayApplicationSummary, error) {
+// List retrieves `num` number of GatewayApplications from specified namespace `namespace` in cluster `cluster`. The `view`
+// is passed to SparkManager to limit how much of each SparkApplicationStatus is returned. Results aggregated across
+// namespaces are ordered according to `listSort`.
+func (s *service) List(ctx context.Context, cluster string, namespace string, view domain.SummaryView, listSort domain.ListSort) ([]*domain.GatewayApplicationSummary, error) {
 
 	kubeCluster, err := s.clusterRepository.GetByName(cluster)
 
@@ -157,7 +217,7 @@ func (s *service) List(ctx context.Context, cluster string, namespace string) ([
 
 	appSummaryList := []*domain.GatewayApplicationSummary{}
 	for _, ns := range namespaces {
-		nsAppSummaries, err := s.gatewayAppRepo.List(ctx, *kubeCluster, ns)
+		nsAppSummaries, err := s.gatewayAppRepo.List(ctx, *kubeCluster, ns, view)
 		if err != nil {
 			return nil, fmt.Errorf("error getting applications: %w", err)
 		}
@@ -170,52 +230,285 @@ func (s *service) List(ctx context.Context, cluster string, namespace string) ([
 
 	}
 
+	domain.SortGatewayApplicationSummaries(appSummaryList, listSort)
+
 	return appSummaryList, nil
 
 }
 
+// Counts returns the number of applications grouped by `groupBy`. If `cluster` is blank, all clusters are counted and if
+// `namespace` is blank, all namespaces in each cluster are counted. Counts are computed by each SparkManager from its
+// informer cache so no application objects are transferred.
+func (s *service) Counts(ctx context.Context, cluster string, namespace string, groupBy []domain.CountGroupBy) ([]*domain.ApplicationCount, error) {
+
+	kubeClusters := []domain.KubeCluster{}
+	if cluster != "" {
+		kubeCluster, err := s.clusterRepository.GetByName(cluster)
+		if err != nil {
+			return nil, fmt.Errorf("error getting cluster: %w", err)
+		}
+		if namespace != "" {
+			if _, err := kubeCluster.GetNamespaceByName(namespace); err != nil {
+				return nil, fmt.Errorf("error getting namespace: %w", err)
+			}
+		}
+		kubeClusters = append(kubeClusters, *kubeCluster)
+	} else if namespace != "" {
+		kubeClusters = s.clusterRepository.GetAllWithNamespace(namespace)
+	} else {
+		kubeClusters = s.clusterRepository.GetAll()
+	}
+
+	counts := map[domain.ApplicationCount]int{}
+	for _, kubeCluster := range kubeClusters {
+		namespaces := []string{namespace}
+		if namespace == "" {
+			namespaces = []string{}
+			for _, kubeNamespace := range kubeCluster.Namespaces {
+				namespaces = append(namespaces, kubeNamespace.Name)
+			}
+		}
+
+		for _, ns := range namespaces {
+			nsCounts, err := s.gatewayAppRepo.Counts(ctx, kubeCluster, ns)
+			if err != nil {
+				return nil, fmt.Errorf("error getting application counts: %w", err)
+			}
+
+			domain.AggregateApplicationCounts(counts, kubeCluster.Name, *nsCounts, groupBy)
+		}
+	}
+
+	return domain.ApplicationCountList(counts), nil
+}
+
 func (s *service) Create(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error) {
 
-	cluster, err := s.clusterRouter.GetCluster(ctx, application.Namespace)
+	// Reject names that would fail deep inside the operator with an explanation of the accepted adjustment
+	if err := domain.NewValidationError(domain.ValidateApplicationNames(application)); err != nil {
+		return nil, gatewayerrors.NewInvalid(fmt.Errorf("invalid GatewayApplication names: %w", err))
+	}
+
+	// Route to the clusters in the region and zone the submission prefers if any of them can be routed to
+	locality := domain.NewLocalityPreference(application.Annotations)
+	gatewayApp, err := s.routeAndCreate(clusterrouter.WithLocalityPreference(ctx, locality), application, user)
+	if err != nil {
+		return nil, err
+	}
+	gatewayApp.Warnings = append(gatewayApp.Warnings, s.localityWarnings(gatewayApp, locality)...)
+
+	return gatewayApp, nil
+}
+
+// routeAndCreate creates application in the cluster it is routed to, or races it in the top 2 clusters
+func (s *service) routeAndCreate(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error) {
+
+	// Speculative submissions race copies in the top 2 routed clusters when the namespace exists in more than one.
+	// Asynchronous submissions aren't raced since their copies wouldn't be created until the queue is drained.
+	if s.config.Speculative.Enable && !domain.IsAsyncSubmission(ctx) && application.Annotations[domain.GATEWAY_SPECULATIVE_ANNOTATION] == "true" {
+		if rankingRouter, ok := s.clusterRouter.(clusterrouter.MultiClusterRouter); ok {
+			clusters, err := rankingRouter.GetClusters(ctx, application.Namespace, 2)
+			switch {
+			case isRoutingRejection(err):
+				// Routing again would only wait for room a second time before being rejected
+				return nil, fmt.Errorf("error getting routing cluster: %w", err)
+			case err == nil && len(clusters) == 2:
+				return s.createSpeculative(ctx, application, user, clusters)
+			case err == nil && len(clusters) == 1:
+				klog.Warningf("submitting application '%s' to a single cluster, namespace %s can only be routed to cluster %s", application.Name, application.Namespace, clusters[0].Name)
+				return s.createInCluster(ctx, application, user, clusters[0])
+			}
+			klog.Warningf("submitting application '%s' to a single cluster, unable to route it to 2 clusters: %v", application.Name, err)
+		}
+	}
+
+	cluster, err := s.routeCluster(ctx, application)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createInCluster(ctx, application, user, *cluster)
+}
+
+// isRoutingRejection is true for errors of routers refusing a submission until clusters have room, e.g. every cluster
+// being at its concurrency cap after the queue timeout
+func isRoutingRejection(err error) bool {
+	var gatewayErr gatewayerrors.GatewayError
+	return errors.As(err, &gatewayErr) && gatewayErr.Status == http.StatusTooManyRequests
+}
+
+// localityWarnings returns a domain.LocalityWarning if gatewayApp was created in a cluster outside the locality its
+// submission prefers
+func (s *service) localityWarnings(gatewayApp *domain.GatewayApplication, locality domain.LocalityPreference) []domain.SubmissionWarning {
+	if locality.IsZero() {
+		return nil
+	}
+
+	cluster, namespace, err := s.GetClusterNamespaceFromGatewayId(gatewayApp.GatewayId)
+	if err != nil || locality.Matches(*cluster) {
+		return nil
+	}
+
+	return []domain.SubmissionWarning{{
+		Code:      domain.LocalityWarning,
+		Message:   fmt.Sprintf("no cluster of namespace %s in %s could be routed to, the application was submitted to cluster %s outside of it", namespace, locality, cluster.Name),
+		Cluster:   cluster.Name,
+		Namespace: namespace,
+	}}
+}
+
+// Resubmission returns the SparkApplication to Create to rerun the GatewayApplication under a new GatewayId, e.g. to
+// retry a failed batch job without the client keeping its spec
+func (s *service) Resubmission(ctx context.Context, gatewayId string) (*v1beta2.SparkApplication, error) {
+
+	cluster, namespace, err := s.GetClusterNamespaceFromGatewayId(gatewayId)
+	if err != nil {
+		return nil, err
+	}
+
+	sparkApp, err := s.gatewayAppRepo.Get(ctx, *cluster, namespace, gatewayId)
+	if err != nil {
+		return nil, fmt.Errorf("error getting GatewayApplication '%s' to resubmit: %w", gatewayId, err)
+	}
+
+	return domain.NewResubmissionSparkApplication(sparkApp, gatewayId), nil
+}
+
+// RenderPods returns the driver and executor pods application would run in the cluster it is routed to, with the
+// same Gateway options as Create, without creating it.
+func (s *service) RenderPods(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.RenderedPods, error) {
+
+	if err := domain.NewValidationError(domain.ValidateApplicationNames(application)); err != nil {
+		return nil, gatewayerrors.NewInvalid(fmt.Errorf("invalid GatewayApplication names: %w", err))
+	}
+
+	cluster, err := s.routeCluster(clusterrouter.WithLocalityPreference(ctx, domain.NewLocalityPreference(application.Annotations)), application)
+	if err != nil {
+		return nil, err
+	}
+
+	gatewayId, err := s.gatewayIdGen(*cluster, application.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("error generating GatewayId for GatewayApplication: %w", err)
+	}
+
+	gaOpts, err := s.gatewayOptions(application, user, *cluster)
+	if err != nil {
+		return nil, err
+	}
+	gaSparkApp := domain.NewGatewaySparkApplication(application, append(gaOpts, domain.WithId(gatewayId), domain.WithAuxiliaryConfigMapReferences())...)
+
+	pods, err := s.gatewayAppRepo.RenderPods(ctx, *cluster, gaSparkApp.ToV1Beta2SparkApplication())
+	if err != nil {
+		return nil, fmt.Errorf("error rendering pods of GatewayApplication '%s/%s': %w", gaSparkApp.Namespace, gaSparkApp.Name, err)
+	}
+
+	return pods, nil
+}
+
+// routeCluster returns the cluster application is routed to, falling back to the fallback cluster router
+func (s *service) routeCluster(ctx context.Context, application *v1beta2.SparkApplication) (*domain.KubeCluster, error) {
+	budget := domain.LatencyBudgetFrom(ctx)
+
+	routingCtx, done := budget.Phase(ctx, domain.LatencyBudgetRouting, "")
+	cluster, err := s.clusterRouter.GetCluster(routingCtx, application.Namespace)
+	done(err)
 	if cluster == nil || err != nil {
 		klog.Warningf("error getting cluster for application '%s': %v", application.Name, err)
 		klog.Warning("Trying fallback cluster router")
 		// Try fallback cluster router
-		cluster, err = s.fallbackClusterRouter.GetCluster(ctx, application.Namespace)
+		retryCtx, done := budget.Phase(ctx, domain.LatencyBudgetRoutingRetry, "")
+		cluster, err = s.fallbackClusterRouter.GetCluster(retryCtx, application.Namespace)
+		done(err)
 		if cluster == nil || err != nil {
 			return nil, fmt.Errorf("error getting routing cluster: %w", err)
 		}
 	}
 
+	return cluster, nil
+}
+
+// createInCluster creates the GatewayApplication in cluster, applying opts after the Gateway's own options
+func (s *service) createInCluster(ctx context.Context, application *v1beta2.SparkApplication, user string, cluster domain.KubeCluster, opts ...func(*domain.GatewaySparkApplication)) (*domain.GatewayApplication, error) {
 	// Generate GatewayId from clusterId and UUID
-	gatewayId, err := s.gatewayIdGen(*cluster, application.Namespace)
+	gatewayId, err := s.gatewayIdGen(cluster, application.Namespace)
 	if err != nil {
 		return nil, fmt.Errorf("error generating GatewayId for GatewayApplication: %w", err)
 	}
 
+	baseOpts, err := s.gatewayOptions(application, user, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdApp *v1beta2.SparkApplication
+	for attempt := 1; ; attempt++ {
+		gaOpts := append(slices.Clone(baseOpts), domain.WithId(gatewayId), domain.WithAuxiliaryConfigMapReferences())
+		gaOpts = append(gaOpts, opts...)
+		gaSparkApp := domain.NewGatewaySparkApplication(application, append(gaOpts, domain.WithSpecHash())...)
+
+		// Create SparkApp
+		createdApp, err = s.gatewayAppRepo.Create(ctx, cluster, gaSparkApp.ToV1Beta2SparkApplication())
+		if err == nil {
+			break
+		}
+
+		// GatewayIds are generated by us, so a conflict means the ID collided with a different SparkApplication rather
+		// than the user resubmitting. SparkManager returns the existing SparkApplication for identical submissions, so
+		// regenerate the ID and try again.
+		var gatewayErr gatewayerrors.GatewayError
+		if !errors.As(err, &gatewayErr) || gatewayErr.Status != http.StatusConflict || attempt >= maxCreateAttempts {
+			return nil, fmt.Errorf("error creating GatewayApplication '%s/%s': %w", gaSparkApp.Namespace, gaSparkApp.Name, err)
+		}
+
+		klog.Warningf("GatewayId '%s' collided with an existing SparkApplication, regenerating (attempt %d/%d)", gatewayId, attempt, maxCreateAttempts)
+		gatewayId, err = s.gatewayIdGen(cluster, application.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("error generating GatewayId for GatewayApplication: %w", err)
+		}
+	}
+
+	gatewayApp := domain.GatewayApplicationFromV1Beta2SparkApplication(createdApp)
+
+	// Set log URLs
+	var urlWarnings []domain.SubmissionWarning
+	gatewayApp.SparkLogURLs, urlWarnings = GetRenderedURLs(s.config.StatusUrlTemplates, &gatewayApp.SparkApplication)
+	gatewayApp.Warnings = append(gatewayApp.Warnings, urlWarnings...)
+
+	return gatewayApp, nil
+}
+
+// gatewayOptions returns the options the Gateway applies to application submitted by user to cluster, before its
+// GatewayId: its metadata policy, cluster, user, selector labels and the namespace's proxyUser, restartPolicy and
+// timeToLive policies
+func (s *service) gatewayOptions(application *v1beta2.SparkApplication, user string, cluster domain.KubeCluster) ([]func(*domain.GatewaySparkApplication), error) {
 	// Set selector labels
 	selectorMap := map[string]string{}
 	if s.selectorKey != "" && s.selectorValue != "" {
 		selectorMap[s.selectorKey] = s.selectorValue
 	}
 
-	gaSparkApp := domain.NewGatewaySparkApplication(application, domain.WithCluster(cluster.Name), domain.WithUser(user), domain.WithSelector(selectorMap), domain.WithId(gatewayId))
-
-	// Create SparkApp
-	createdApp, err := s.gatewayAppRepo.Create(ctx, *cluster, gaSparkApp.ToV1Beta2SparkApplication())
+	// Resolve proxyUser from the namespace's proxyUser policy
+	kubeNamespace, err := cluster.GetNamespaceByName(application.Namespace)
 	if err != nil {
-		return nil, fmt.Errorf("error creating GatewayApplication '%s/%s': %w", gaSparkApp.Namespace, gaSparkApp.Name, err)
+		return nil, gatewayerrors.NewBadRequest(fmt.Errorf("error getting namespace for GatewayApplication: %w", err))
 	}
 
-	gatewayApp := domain.GatewayApplicationFromV1Beta2SparkApplication(createdApp)
+	proxyUser, err := kubeNamespace.ProxyUser.ResolveProxyUser(user, application.Spec.ProxyUser)
+	if err != nil {
+		return nil, gatewayerrors.NewForbidden(fmt.Errorf("error resolving proxyUser for GatewayApplication: %w", err))
+	}
 
-	// Set log URLs
-	gatewayApp.SparkLogURLs = GetRenderedURLs(s.config.StatusUrlTemplates, &gatewayApp.SparkApplication)
+	restartPolicy, err := kubeNamespace.RestartPolicy.ResolveRestartPolicy(application.Spec.RestartPolicy)
+	if err != nil {
+		return nil, gatewayerrors.NewInvalid(fmt.Errorf("error resolving restartPolicy for GatewayApplication: %w", err))
+	}
 
-	return gatewayApp, nil
+	return []func(*domain.GatewaySparkApplication){domain.WithMetadataPolicy(cluster.Metadata), domain.WithCluster(cluster.Name), domain.WithUser(user), domain.WithProxyUser(proxyUser), domain.WithRestartPolicy(restartPolicy), domain.WithDefaultTimeToLive(kubeNamespace.TimeToLiveSeconds), domain.WithSelector(selectorMap)}, nil
 }
 
-func (s *service) Status(ctx context.Context, gatewayId string) (*v1beta2.SparkApplicationStatus, error) {
+// Status returns the status of a GatewayApplication with its timings measured until now.
+func (s *service) Status(ctx context.Context, gatewayId string) (*domain.ApplicationStatus, error) {
 	cluster, namespace, err := s.GetClusterNamespaceFromGatewayId(gatewayId)
 	if err != nil {
 		return nil, err
@@ -226,15 +519,71 @@ func (s *service) Status(ctx context.Context, gatewayId string) (*v1beta2.SparkA
 		return nil, fmt.Errorf("error getting status for GatewayApplication '%s': %w", gatewayId, err)
 	}
 
-	return domain.NewGatewayApplicationStatus(*sparkAppStatus), nil
+	return newGatewayApplicationStatus(sparkAppStatus), nil
 }
 
+// newGatewayApplicationStatus converts the status of a SparkApplication returned by SparkManager to the status of its
+// GatewayApplication
+func newGatewayApplicationStatus(sparkAppStatus *domain.ApplicationStatus) *domain.ApplicationStatus {
+	return &domain.ApplicationStatus{
+		SparkApplicationStatus: *domain.NewGatewayApplicationStatus(sparkAppStatus.SparkApplicationStatus),
+		GatewayState:           domain.NewGatewayState(sparkAppStatus.AppState.State),
+		CreationTimestamp:      sparkAppStatus.CreationTimestamp,
+		Timings:                domain.NewApplicationTimings(sparkAppStatus.CreationTimestamp, sparkAppStatus.SparkApplicationStatus, time.Now()),
+		Submission:             sparkAppStatus.Submission,
+	}
+}
+
+// WaitStatus long-polls the status of a GatewayApplication. It returns as soon as the state differs from lastState or
+// is terminal, re-reading the status every WaitStatus.PollInterval. If neither happens within timeout, capped at
+// WaitStatus.MaxTimeout, the current status is returned so the client can poll again. An empty lastState returns
+// the current status immediately.
+func (s *service) WaitStatus(ctx context.Context, gatewayId string, lastState v1beta2.ApplicationStateType, timeout time.Duration) (*domain.GatewayApplicationWaitStatus, error) {
+	if timeout <= 0 || timeout > s.config.WaitStatus.MaxTimeout {
+		timeout = s.config.WaitStatus.MaxTimeout
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		status, err := s.Status(ctx, gatewayId)
+		if err != nil {
+			return nil, err
+		}
+
+		waitStatus := domain.NewGatewayApplicationWaitStatus(status.SparkApplicationStatus)
+		if lastState == "" || waitStatus.State != lastState || waitStatus.Terminal {
+			return waitStatus, nil
+		}
+
+		poll := time.NewTimer(s.config.WaitStatus.PollInterval)
+		select {
+		case <-ctx.Done():
+			poll.Stop()
+			return nil, ctx.Err()
+		case <-deadline.C:
+			poll.Stop()
+			return waitStatus, nil
+		case <-poll.C:
+		}
+	}
+}
+
+// Logs returns the last tailLines lines of driver logs. A tailLines of 0 uses the namespace's defaultLogLines, and
+// requests for more than the namespace's maxLogLines are capped.
 func (s *service) Logs(ctx context.Context, gatewayId string, tailLines int) (*string, error) {
 	cluster, namespace, err := s.GetClusterNamespaceFromGatewayId(gatewayId)
 	if err != nil {
 		return nil, err
 	}
 
+	kubeNamespace, err := cluster.GetNamespaceByName(namespace)
+	if err != nil {
+		return nil, gatewayerrors.NewInternal(fmt.Errorf("error getting namespace for GatewayApplication '%s': %w", gatewayId, err))
+	}
+	tailLines = kubeNamespace.ResolveLogLines(tailLines)
+
 	logString, err := s.gatewayAppRepo.Logs(ctx, *cluster, namespace, gatewayId, tailLines)
 	if err != nil {
 		return nil, fmt.Errorf("error getting logs for GatewayApplication '%s': %w", gatewayId, err)
@@ -243,6 +592,109 @@ func (s *service) Logs(ctx context.Context, gatewayId string, tailLines int) (*s
 	return logString, nil
 }
 
+// FollowLogs returns a stream of driver logs that tails them live, starting from the last tailLines lines, resolved
+// like Logs. The caller is responsible for closing the stream.
+func (s *service) FollowLogs(ctx context.Context, gatewayId string, tailLines int) (io.ReadCloser, error) {
+	cluster, namespace, err := s.GetClusterNamespaceFromGatewayId(gatewayId)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeNamespace, err := cluster.GetNamespaceByName(namespace)
+	if err != nil {
+		return nil, gatewayerrors.NewInternal(fmt.Errorf("error getting namespace for GatewayApplication '%s': %w", gatewayId, err))
+	}
+
+	logStream, err := s.gatewayAppRepo.FollowLogs(ctx, *cluster, namespace, gatewayId, kubeNamespace.ResolveLogLines(tailLines))
+	if err != nil {
+		return nil, fmt.Errorf("error following logs for GatewayApplication '%s': %w", gatewayId, err)
+	}
+
+	return logStream, nil
+}
+
+// ExecutorLogs returns the last tailLines lines of the logs of the executor with executorId, resolved like Logs
+func (s *service) ExecutorLogs(ctx context.Context, gatewayId string, executorId string, tailLines int) (*string, error) {
+	cluster, namespace, err := s.GetClusterNamespaceFromGatewayId(gatewayId)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeNamespace, err := cluster.GetNamespaceByName(namespace)
+	if err != nil {
+		return nil, gatewayerrors.NewInternal(fmt.Errorf("error getting namespace for GatewayApplication '%s': %w", gatewayId, err))
+	}
+
+	logString, err := s.gatewayAppRepo.ExecutorLogs(ctx, *cluster, namespace, gatewayId, executorId, kubeNamespace.ResolveLogLines(tailLines))
+	if err != nil {
+		return nil, fmt.Errorf("error getting logs of executor '%s' for GatewayApplication '%s': %w", executorId, gatewayId, err)
+	}
+
+	return logString, nil
+}
+
+// Pods returns the driver and executor pods of a GatewayApplication with their phases, so clients can find the ids of
+// its executors
+func (s *service) Pods(ctx context.Context, gatewayId string) (*domain.ApplicationPods, error) {
+	cluster, namespace, err := s.GetClusterNamespaceFromGatewayId(gatewayId)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := s.gatewayAppRepo.Pods(ctx, *cluster, namespace, gatewayId)
+	if err != nil {
+		return nil, fmt.Errorf("error getting pods for GatewayApplication '%s': %w", gatewayId, err)
+	}
+
+	return &domain.ApplicationPods{GatewayId: gatewayId, Pods: pods}, nil
+}
+
+// StreamLogs returns a stream of the complete driver logs. The caller is responsible for closing the stream.
+func (s *service) StreamLogs(ctx context.Context, gatewayId string) (io.ReadCloser, error) {
+	cluster, namespace, err := s.GetClusterNamespaceFromGatewayId(gatewayId)
+	if err != nil {
+		return nil, err
+	}
+
+	logStream, err := s.gatewayAppRepo.StreamLogs(ctx, *cluster, namespace, gatewayId)
+	if err != nil {
+		return nil, fmt.Errorf("error streaming logs for GatewayApplication '%s': %w", gatewayId, err)
+	}
+
+	return logStream, nil
+}
+
+// DriverMetrics returns the Prometheus metrics served by the driver of a running GatewayApplication. The caller is
+// responsible for closing the stream.
+func (s *service) DriverMetrics(ctx context.Context, gatewayId string) (io.ReadCloser, error) {
+	cluster, namespace, err := s.GetClusterNamespaceFromGatewayId(gatewayId)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics, err := s.gatewayAppRepo.DriverMetrics(ctx, *cluster, namespace, gatewayId)
+	if err != nil {
+		return nil, fmt.Errorf("error getting driver metrics for GatewayApplication '%s': %w", gatewayId, err)
+	}
+
+	return metrics, nil
+}
+
+// Timeline returns the lifecycle events recorded for a GatewayApplication, which outlive its pods and Kubernetes events
+func (s *service) Timeline(ctx context.Context, gatewayId string) (*domain.ApplicationTimeline, error) {
+	cluster, namespace, err := s.GetClusterNamespaceFromGatewayId(gatewayId)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.gatewayAppRepo.Timeline(ctx, *cluster, namespace, gatewayId)
+	if err != nil {
+		return nil, fmt.Errorf("error getting timeline for GatewayApplication '%s': %w", gatewayId, err)
+	}
+
+	return &domain.ApplicationTimeline{GatewayId: gatewayId, Events: events}, nil
+}
+
 func (s *service) Delete(ctx context.Context, gatewayId string) error {
 	cluster, namespace, err := s.GetClusterNamespaceFromGatewayId(gatewayId)
 	if err != nil {
@@ -256,29 +708,63 @@ func (s *service) Delete(ctx context.Context, gatewayId string) error {
 	return nil
 }
 
-func GetRenderedURLs(templates domain.StatusUrlTemplates, gaSparkApp *domain.GatewaySparkApplication) domain.SparkLogURLs {
-	// Render URLs
-	sparkUI, err := util.RenderTemplate(templates.SparkUITemplate, gaSparkApp)
+// Scale changes the executor count of a running GatewayApplication through its cluster's SparkManager, which checks the
+// request against the namespace's quota
+func (s *service) Scale(ctx context.Context, gatewayId string, scale domain.ExecutorScale) (*domain.GatewayApplication, error) {
+	if err := scale.Validate(); err != nil {
+		return nil, gatewayerrors.NewBadRequest(err)
+	}
+
+	cluster, namespace, err := s.GetClusterNamespaceFromGatewayId(gatewayId)
 	if err != nil {
-		klog.Errorf("unable to render SparkUI template: %v", err)
-		sparkUI = new(string)
+		return nil, err
+	}
+
+	sparkApp, err := s.gatewayAppRepo.Scale(ctx, *cluster, namespace, gatewayId, scale)
+	if err != nil {
+		return nil, fmt.Errorf("error scaling GatewayApplication '%s': %w", gatewayId, err)
+	}
+
+	gatewayApp := domain.GatewayApplicationFromV1Beta2SparkApplication(sparkApp)
+	var urlWarnings []domain.SubmissionWarning
+	gatewayApp.SparkLogURLs, urlWarnings = GetRenderedURLs(s.config.StatusUrlTemplates, &gatewayApp.SparkApplication)
+	gatewayApp.Warnings = append(gatewayApp.Warnings, urlWarnings...)
+
+	return gatewayApp, nil
+}
+
+// Update changes the labels, TTL or maxExecutors of a GatewayApplication that hasn't started running yet through its
+// cluster's SparkManager, which rejects applications that are already running
+func (s *service) Update(ctx context.Context, gatewayId string, update domain.ApplicationUpdate) (*domain.GatewayApplication, error) {
+	if err := update.Validate(); err != nil {
+		return nil, gatewayerrors.NewBadRequest(err)
 	}
 
-	logsUI, err := util.RenderTemplate(templates.LogsUITemplate, gaSparkApp)
+	cluster, namespace, err := s.GetClusterNamespaceFromGatewayId(gatewayId)
 	if err != nil {
-		klog.Errorf("unable to render LogsUI template: %v", err)
-		logsUI = new(string)
+		return nil, err
 	}
 
-	sparkHistoryUI, err := util.RenderTemplate(templates.SparkHistoryUITemplate, gaSparkApp)
+	sparkApp, err := s.gatewayAppRepo.Update(ctx, *cluster, namespace, gatewayId, update)
 	if err != nil {
-		klog.Errorf("unable to render SparkHistoryUI template: %v", err)
-		sparkHistoryUI = new(string)
+		return nil, fmt.Errorf("error updating GatewayApplication '%s': %w", gatewayId, err)
 	}
 
-	return domain.SparkLogURLs{
-		SparkUI:        *sparkUI,
-		LogsUI:         *logsUI,
-		SparkHistoryUI: *sparkHistoryUI,
+	gatewayApp := domain.GatewayApplicationFromV1Beta2SparkApplication(sparkApp)
+	var urlWarnings []domain.SubmissionWarning
+	gatewayApp.SparkLogURLs, urlWarnings = GetRenderedURLs(s.config.StatusUrlTemplates, &gatewayApp.SparkApplication)
+	gatewayApp.Warnings = append(gatewayApp.Warnings, urlWarnings...)
+
+	return gatewayApp, nil
+}
+
+// GetRenderedURLs renders the status URLs of gaSparkApp, returning a warning with the reason for each URL that failed to
+// render, so it is left empty
+func GetRenderedURLs(templates domain.StatusUrlTemplates, gaSparkApp *domain.GatewaySparkApplication) (domain.SparkLogURLs, []domain.SubmissionWarning) {
+	urls, warnings := templates.Render(gaSparkApp)
+	for _, warning := range warnings {
+		klog.Errorf("unable to render status URL of GatewayApplication '%s': %s", gaSparkApp.Name, warning.Message)
 	}
+
+	return urls, warnings
 }
@@ -19,11 +19,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/clusterrouter"
 	"github.com/slackhq/spark-gateway/internal/gateway/repository"
 	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	// "github.com/slackhq/spark-gateway/internal/gateway/clusterrouter"
@@ -96,6 +102,7 @@ var expectedSparkApp *v1beta2.SparkApplication = &v1beta2.SparkApplication{
 		Labels: map[string]string{
 			domain.GATEWAY_CLUSTER_LABEL: "test-cluster",
 			domain.GATEWAY_USER_LABEL:    "user",
+			domain.GATEWAY_OWNER_LABEL:   "user",
 		},
 		Annotations: map[string]string{
 			domain.GATEWAY_APPLICATION_NAME_ANNOTATION: "appName",
@@ -118,6 +125,7 @@ var expectedGatewayApplication domain.GatewayApplication = domain.GatewayApplica
 			Labels: map[string]string{
 				domain.GATEWAY_CLUSTER_LABEL: "test-cluster",
 				domain.GATEWAY_USER_LABEL:    "user",
+				domain.GATEWAY_OWNER_LABEL:   "user",
 			},
 			Annotations: map[string]string{
 				domain.GATEWAY_APPLICATION_NAME_ANNOTATION: "appName",
@@ -131,9 +139,10 @@ var expectedGatewayApplication domain.GatewayApplication = domain.GatewayApplica
 			SparkApplicationID: "sparkAppID",
 		},
 	},
-	GatewayId: "clusterid-nsid-uuid",
-	Cluster:   "test-cluster",
-	User:      TEST_USER,
+	GatewayId:    "clusterid-nsid-uuid",
+	GatewayState: domain.GatewayStatePending,
+	Cluster:      "test-cluster",
+	User:         TEST_USER,
 	SparkLogURLs: domain.SparkLogURLs{
 		SparkUI:        "",
 		SparkHistoryUI: "https://spark-history-testNamespace.test.com/history/sparkAppID/jobs",
@@ -197,9 +206,10 @@ var expectedGatewayApplicationSummaries []*domain.GatewayApplicationSummary = []
 				SubmissionID: "test123",
 			},
 		},
-		GatewayId: "clusterid-nsid-uuid",
-		Cluster:   "test-cluster",
-		User:      TEST_USER,
+		GatewayId:    "clusterid-nsid-uuid",
+		GatewayState: domain.GatewayStatePending,
+		Cluster:      "test-cluster",
+		User:         TEST_USER,
 	},
 	{
 		SparkManagerSparkApplicationSummary: domain.SparkManagerSparkApplicationSummary{
@@ -219,9 +229,10 @@ var expectedGatewayApplicationSummaries []*domain.GatewayApplicationSummary = []
 				SubmissionID: "test124",
 			},
 		},
-		GatewayId: "clusterid-nsid-uuid2",
-		Cluster:   "test-cluster",
-		User:      TEST_USER,
+		GatewayId:    "clusterid-nsid-uuid2",
+		GatewayState: domain.GatewayStatePending,
+		Cluster:      "test-cluster",
+		User:         TEST_USER,
 	},
 }
 
@@ -291,14 +302,17 @@ var mockGatewayAppRepository_Success GatewayApplicationRepositoryMock = GatewayA
 	GetFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace, name string) (*v1beta2.SparkApplication, error) {
 		return expectedSparkApp, nil
 	},
-	ListFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string) ([]*domain.SparkManagerSparkApplicationSummary, error) {
+	ListFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, view domain.SummaryView) ([]*domain.SparkManagerSparkApplicationSummary, error) {
 		return expectedSparkManagerSparkApplicationSummaries, nil
 	},
 	LogsFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace, name string, tailLines int) (*string, error) {
 		return &logString, nil
 	},
-	StatusFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace, name string) (*v1beta2.SparkApplicationStatus, error) {
-		return &expectedSparkApp.Status, nil
+	StreamLogsFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace, name string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(logString)), nil
+	},
+	StatusFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace, name string) (*domain.ApplicationStatus, error) {
+		return &domain.ApplicationStatus{SparkApplicationStatus: expectedSparkApp.Status, CreationTimestamp: expectedSparkApp.CreationTimestamp}, nil
 	},
 }
 
@@ -312,13 +326,16 @@ var mockGatewayAppRepository_Failure GatewayApplicationRepositoryMock = GatewayA
 	GetFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace, name string) (*v1beta2.SparkApplication, error) {
 		return nil, gatewayerrors.NewNotFound(fmt.Errorf("error getting GatewayApplication '%s/%s'", namespace, name))
 	},
-	ListFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string) ([]*domain.SparkManagerSparkApplicationSummary, error) {
+	ListFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, view domain.SummaryView) ([]*domain.SparkManagerSparkApplicationSummary, error) {
 		return nil, errors.New("error getting application summaries:")
 	},
 	LogsFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace, name string, tailLines int) (*string, error) {
 		return nil, errors.New("error getting logs")
 	},
-	StatusFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace, name string) (*v1beta2.SparkApplicationStatus, error) {
+	StreamLogsFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace, name string) (io.ReadCloser, error) {
+		return nil, errors.New("error streaming logs")
+	},
+	StatusFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace, name string) (*domain.ApplicationStatus, error) {
 		return nil, errors.New("error getting application status:")
 	},
 }
@@ -338,6 +355,85 @@ func TestServiceGet(t *testing.T) {
 	assert.Equal(t, &expectedGatewayApplication, gatewayApp, "returned GatewayApplication should match")
 }
 
+func TestServiceGetURLTemplateWarning(t *testing.T) {
+	gatewayConfig := testGatewayConfig
+	gatewayConfig.StatusUrlTemplates.SparkUITemplate = "host.com/ui/{{.Cluster}}"
+
+	appService := NewApplicationService(
+		&mockGatewayAppRepository_Success,
+		mockClusterRepo_Success,
+		&SuccessClusterRouter{},
+		&SuccessClusterRouter{},
+		gatewayConfig,
+		"",
+		"",
+		GatewayIdGenerator_Success,
+	)
+	gatewayApp, err := appService.Get(context.Background(), "clusterid-nsid-uuid")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "", gatewayApp.SparkLogURLs.SparkUI, "the URL that failed to render should be empty")
+	if assert.Len(t, gatewayApp.Warnings, 1, "the response should warn of the URL that failed to render") {
+		assert.Equal(t, domain.URLTemplateWarning, gatewayApp.Warnings[0].Code)
+	}
+}
+
+// costingGatewayAppRepository estimates costs on top of a GatewayApplicationRepositoryMock
+type costingGatewayAppRepository struct {
+	*GatewayApplicationRepositoryMock
+	cost *domain.ApplicationCost
+	err  error
+}
+
+func (r *costingGatewayAppRepository) Cost(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*domain.ApplicationCost, error) {
+	return r.cost, r.err
+}
+
+func TestServiceGetCost(t *testing.T) {
+	costedCluster := testCluster
+	costedCluster.Cost = domain.CostRates{CoreHour: 0.05, Currency: "USD"}
+	clusterRepo := &repository.ClusterRepositoryMock{
+		GetByIdFunc: func(clusterId string) (*domain.KubeCluster, error) {
+			return &costedCluster, nil
+		},
+	}
+	cost := &domain.ApplicationCost{Currency: "USD", Estimate: 1.25, CoreHours: 25, Final: true}
+
+	tests := []struct {
+		name     string
+		repo     *costingGatewayAppRepository
+		wantCost *domain.ApplicationCost
+	}{
+		{
+			name:     "cost estimated",
+			repo:     &costingGatewayAppRepository{GatewayApplicationRepositoryMock: &mockGatewayAppRepository_Success, cost: cost},
+			wantCost: cost,
+		},
+		{
+			name:     "cost estimate failed",
+			repo:     &costingGatewayAppRepository{GatewayApplicationRepositoryMock: &mockGatewayAppRepository_Success, err: errors.New("error estimating cost")},
+			wantCost: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			appService := NewApplicationService(
+				test.repo,
+				clusterRepo,
+				&SuccessClusterRouter{},
+				&SuccessClusterRouter{},
+				testGatewayConfig,
+				"",
+				"",
+				GatewayIdGenerator_Success,
+			)
+			gatewayApp, err := appService.Get(context.Background(), "clusterid-nsid-uuid")
+			assert.Nil(t, err, "failing to estimate the cost should not fail Get")
+			assert.Equal(t, test.wantCost, gatewayApp.Cost, "costs should match")
+		})
+	}
+}
+
 func TestServiceGetNotFound(t *testing.T) {
 
 	appService := NewApplicationService(
@@ -375,6 +471,52 @@ func TestServiceGetMalformedGatewayId(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid gatewayId", "error should report invalid gatewayId")
 }
 
+func TestServiceResubmission(t *testing.T) {
+	appService := NewApplicationService(
+		&mockGatewayAppRepository_Success,
+		mockClusterRepo_Success,
+		&SuccessClusterRouter{},
+		&SuccessClusterRouter{},
+		testGatewayConfig,
+		"",
+		"",
+		GatewayIdGenerator_Success,
+	)
+
+	resubmission, err := appService.Resubmission(context.Background(), "clusterid-nsid-uuid")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, &v1beta2.SparkApplication{
+		TypeMeta: expectedSparkApp.TypeMeta,
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "appName",
+			Namespace:   "testNamespace",
+			Labels:      map[string]string{},
+			Annotations: map[string]string{domain.GATEWAY_RESUBMITTED_FROM_ANNOTATION: "clusterid-nsid-uuid"},
+		},
+		Spec: expectedSparkApp.Spec,
+	}, resubmission, "the submitted name and spec should be resubmitted")
+
+	gatewayApp, err := appService.Create(context.Background(), resubmission, "user")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "clusterid-nsid-uuid", gatewayApp.SparkApplication.Annotations[domain.GATEWAY_RESUBMITTED_FROM_ANNOTATION], "the resubmission should record the GatewayId it was resubmitted from")
+}
+
+func TestServiceResubmissionNotFound(t *testing.T) {
+	appService := NewApplicationService(
+		&mockGatewayAppRepository_Failure,
+		mockClusterRepo_Success,
+		&SuccessClusterRouter{},
+		&SuccessClusterRouter{},
+		testGatewayConfig,
+		"",
+		"",
+		GatewayIdGenerator_Success,
+	)
+
+	_, err := appService.Resubmission(context.Background(), "clusterid-nsid-uuid")
+	assert.Error(t, err, "missing GatewayApplications can't be resubmitted")
+}
+
 func TestList(t *testing.T) {
 	appService := NewApplicationService(
 		&mockGatewayAppRepository_Success,
@@ -387,7 +529,7 @@ func TestList(t *testing.T) {
 		GatewayIdGenerator_Success,
 	)
 
-	summaries, err := appService.List(context.Background(), "test-cluster", "testNamespace")
+	summaries, err := appService.List(context.Background(), "test-cluster", "testNamespace", domain.SummaryViewFull, domain.ListSort{})
 
 	assert.Equal(t, expectedGatewayApplicationSummaries, summaries, "returned GatewayApplication should match")
 	assert.Equal(t, nil, err, "err should be nil")
@@ -406,7 +548,7 @@ func TestListClusterFail(t *testing.T) {
 		GatewayIdGenerator_Success,
 	)
 
-	summaries, err := appService.List(context.Background(), "test-cluster", "testNamespace")
+	summaries, err := appService.List(context.Background(), "test-cluster", "testNamespace", domain.SummaryViewFull, domain.ListSort{})
 
 	assert.Equal(t, []*domain.GatewayApplicationSummary(nil), summaries, "returned GatewayApplication should be nil")
 	assert.Contains(t, err.Error(), "error getting cluster:", "err should match")
@@ -425,13 +567,64 @@ func TestListAppRepoFail(t *testing.T) {
 		GatewayIdGenerator_Success,
 	)
 
-	summaries, err := appService.List(context.Background(), "test-cluster", "testNamespace")
+	summaries, err := appService.List(context.Background(), "test-cluster", "testNamespace", domain.SummaryViewFull, domain.ListSort{})
 
 	assert.Nil(t, summaries, "returned GatewayApplication should be nil")
 	assert.Contains(t, err.Error(), "error getting applications:", "err should match")
 
 }
 
+func TestCounts(t *testing.T) {
+	otherCluster := domain.KubeCluster{
+		Name:       "other-cluster",
+		ClusterId:  "other",
+		Namespaces: []domain.KubeNamespace{{Name: "testNamespace"}, {Name: "otherNamespace"}},
+	}
+	clusterRepo := &repository.ClusterRepositoryMock{
+		GetAllFunc: func() []domain.KubeCluster {
+			return []domain.KubeCluster{testCluster, otherCluster}
+		},
+	}
+	appRepo := GatewayApplicationRepositoryMock{
+		CountsFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string) (*domain.SparkManagerApplicationCounts, error) {
+			return &domain.SparkManagerApplicationCounts{Namespace: namespace, States: map[string]int{"RUNNING": 2, "FAILED": 1}}, nil
+		},
+	}
+	appService := NewApplicationService(&appRepo, clusterRepo, &SuccessClusterRouter{}, &SuccessClusterRouter{}, testGatewayConfig, "", "", GatewayIdGenerator_Success)
+
+	counts, err := appService.Counts(context.Background(), "", "", []domain.CountGroupBy{})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []*domain.ApplicationCount{{Count: 9}}, counts, "total count should match")
+	assert.Equal(t, 3, len(appRepo.CountsCalls()), "every namespace in every cluster should be counted")
+
+	counts, err = appService.Counts(context.Background(), "", "", []domain.CountGroupBy{domain.CountGroupByNamespace, domain.CountGroupByState})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []*domain.ApplicationCount{
+		{Namespace: "otherNamespace", State: "FAILED", Count: 1},
+		{Namespace: "otherNamespace", State: "RUNNING", Count: 2},
+		{Namespace: "testNamespace", State: "FAILED", Count: 2},
+		{Namespace: "testNamespace", State: "RUNNING", Count: 4},
+	}, counts, "grouped counts should match")
+}
+
+func TestCountsClusterFail(t *testing.T) {
+	appService := NewApplicationService(
+		&mockGatewayAppRepository_Failure,
+		mockClusterRepo_Failure,
+		&SuccessClusterRouter{},
+		&SuccessClusterRouter{},
+		testGatewayConfig,
+		"",
+		"",
+		GatewayIdGenerator_Success,
+	)
+
+	counts, err := appService.Counts(context.Background(), "test-cluster", "", []domain.CountGroupBy{})
+
+	assert.Nil(t, counts, "returned counts should be nil")
+	assert.Contains(t, err.Error(), "error getting cluster:", "err should match")
+}
+
 func TestServiceCreateClusterFail(t *testing.T) {
 
 	appService := NewApplicationService(
@@ -504,8 +697,317 @@ func TestServiceCreateRepoSuccess(t *testing.T) {
 
 	gatewayApp, err := appService.Create(context.Background(), inputSparkApp, TEST_USER)
 
-	assert.Equal(t, &expectedGatewayApplication, gatewayApp, "returned GatewayApplication should match")
+	specHash, _ := domain.SparkApplicationSpecHash(expectedSparkApp)
+	expected := expectedGatewayApplication
+	expected.SparkApplication.Annotations = map[string]string{
+		domain.GATEWAY_APPLICATION_NAME_ANNOTATION: "appName",
+		domain.GATEWAY_SPEC_HASH_ANNOTATION:        specHash,
+	}
+
+	assert.Equal(t, &expected, gatewayApp, "returned GatewayApplication should match")
+	assert.Nil(t, err, "err should be nil")
+}
+
+func TestServiceCreateLocalityWarning(t *testing.T) {
+	regionalCluster := testCluster
+	regionalCluster.Labels = map[string]string{"topology.kubernetes.io/region": "us-east-1"}
+	regionalClusterRepo := &repository.ClusterRepositoryMock{
+		GetByIdFunc: func(clusterId string) (*domain.KubeCluster, error) {
+			return &regionalCluster, nil
+		},
+	}
+
+	tests := []struct {
+		name         string
+		clusterRepo  repository.ClusterRepository
+		region       string
+		wantWarnings int
+	}{
+		{
+			name:         "no preference",
+			clusterRepo:  mockClusterRepo_Success,
+			wantWarnings: 0,
+		},
+		{
+			name:         "routed to the preferred region",
+			clusterRepo:  regionalClusterRepo,
+			region:       "us-east-1",
+			wantWarnings: 0,
+		},
+		{
+			name:         "routed outside the preferred region",
+			clusterRepo:  mockClusterRepo_Success,
+			region:       "us-east-1",
+			wantWarnings: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			appService := NewApplicationService(
+				&mockGatewayAppRepository_Success,
+				test.clusterRepo,
+				&SuccessClusterRouter{},
+				&SuccessClusterRouter{},
+				testGatewayConfig,
+				"",
+				"",
+				GatewayIdGenerator_Success,
+			)
+
+			application := inputSparkApp.DeepCopy()
+			if test.region != "" {
+				application.Annotations[domain.GATEWAY_PREFERRED_REGION_ANNOTATION] = test.region
+			}
+
+			gatewayApp, err := appService.Create(context.Background(), application, TEST_USER)
+			assert.Nil(t, err, "err should be nil")
+			if assert.Len(t, gatewayApp.Warnings, test.wantWarnings, "warnings should match") && test.wantWarnings > 0 {
+				assert.Equal(t, domain.LocalityWarning, gatewayApp.Warnings[0].Code)
+				assert.Equal(t, testCluster.Name, gatewayApp.Warnings[0].Cluster)
+			}
+		})
+	}
+}
+
+func TestServiceRenderPods(t *testing.T) {
+
+	var rendered *v1beta2.SparkApplication
+	appRepo := &GatewayApplicationRepositoryMock{
+		RenderPodsFunc: func(ctx context.Context, cluster domain.KubeCluster, application *v1beta2.SparkApplication) (*domain.RenderedPods, error) {
+			rendered = application
+			return &domain.RenderedPods{Cluster: cluster.Name}, nil
+		},
+	}
+	appService := NewApplicationService(
+		appRepo,
+		mockClusterRepo_Success,
+		&SuccessClusterRouter{},
+		&SuccessClusterRouter{},
+		testGatewayConfig,
+		"",
+		"",
+		GatewayIdGenerator_Success,
+	)
+
+	pods, err := appService.RenderPods(context.Background(), inputSparkApp, TEST_USER)
+
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, &domain.RenderedPods{Cluster: "test-cluster"}, pods, "rendered pods should match")
+	assert.Empty(t, appRepo.CreateCalls(), "rendering should not create the SparkApplication")
+	assert.Equal(t, expectedSparkApp.Name, rendered.Name, "rendered SparkApplication should be named by its GatewayId")
+	assert.Equal(t, expectedSparkApp.Labels, rendered.Labels, "rendered SparkApplication should have the Gateway labels")
+	assert.Equal(t, expectedSparkApp.Spec.ProxyUser, rendered.Spec.ProxyUser, "rendered SparkApplication should have the resolved proxyUser")
+}
+
+func TestServiceCreateRegeneratesCollidingId(t *testing.T) {
+
+	gatewayIds := []string{}
+	gatewayIdGen := func(cluster domain.KubeCluster, namespace string) (string, error) {
+		gatewayIds = append(gatewayIds, fmt.Sprintf("clusterid-nsid-uuid%d", len(gatewayIds)))
+		return gatewayIds[len(gatewayIds)-1], nil
+	}
+
+	appRepo := GatewayApplicationRepositoryMock{
+		CreateFunc: func(ctx context.Context, cluster domain.KubeCluster, sparkApp *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
+			if sparkApp.Name == "clusterid-nsid-uuid0" {
+				return nil, gatewayerrors.NewAlreadyExists(errors.New("sparkapplications \"clusterid-nsid-uuid0\" already exists"))
+			}
+			return sparkApp, nil
+		},
+	}
+
+	appService := NewApplicationService(&appRepo, mockClusterRepo_Success, &SuccessClusterRouter{}, &SuccessClusterRouter{}, testGatewayConfig, "", "", gatewayIdGen)
+
+	gatewayApp, err := appService.Create(context.Background(), inputSparkApp.DeepCopy(), TEST_USER)
+
 	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "clusterid-nsid-uuid1", gatewayApp.GatewayId, "colliding GatewayId should be regenerated")
+	assert.Equal(t, 2, len(appRepo.CreateCalls()), "create should be retried once")
+}
+
+func TestServiceCreateCollisionAttemptsExhausted(t *testing.T) {
+
+	appRepo := GatewayApplicationRepositoryMock{
+		CreateFunc: func(ctx context.Context, cluster domain.KubeCluster, sparkApp *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
+			return nil, gatewayerrors.NewAlreadyExists(errors.New("already exists"))
+		},
+	}
+
+	appService := NewApplicationService(&appRepo, mockClusterRepo_Success, &SuccessClusterRouter{}, &SuccessClusterRouter{}, testGatewayConfig, "", "", GatewayIdGenerator_Success)
+
+	gatewayApp, err := appService.Create(context.Background(), inputSparkApp.DeepCopy(), TEST_USER)
+
+	assert.Nil(t, gatewayApp, "returned GatewayApplication should be nil")
+	var gatewayErr gatewayerrors.GatewayError
+	assert.True(t, errors.As(err, &gatewayErr), "err should be a GatewayError")
+	assert.Equal(t, http.StatusConflict, gatewayErr.Status, "conflict should be returned")
+	assert.Equal(t, maxCreateAttempts, len(appRepo.CreateCalls()), "create should be attempted maxCreateAttempts times")
+}
+
+func TestServiceCreateProxyUserForbidden(t *testing.T) {
+
+	preserveCluster := testCluster
+	preserveCluster.Namespaces = []domain.KubeNamespace{
+		{
+			Name:        "testNamespace",
+			NamespaceId: "nsid",
+			ProxyUser: domain.ProxyUserPolicy{
+				Mode:          domain.ProxyUserModePreserve,
+				AllowOverride: []string{"^airflow$"},
+			},
+		},
+	}
+
+	preserveRouter := &clusterrouter.ClusterRouterMock{
+		GetClusterFunc: func(ctx context.Context, namespace string) (*domain.KubeCluster, error) {
+			return &preserveCluster, nil
+		},
+	}
+
+	appService := NewApplicationService(
+		&mockGatewayAppRepository_Success,
+		mockClusterRepo_Success,
+		preserveRouter,
+		preserveRouter,
+		testGatewayConfig,
+		"",
+		"",
+		GatewayIdGenerator_Success,
+	)
+
+	submittedApp := inputSparkApp.DeepCopy()
+	submittedApp.Spec.ProxyUser = util.Ptr("hdfs-svc")
+
+	gatewayApp, err := appService.Create(context.Background(), submittedApp, TEST_USER)
+
+	var gatewayErr gatewayerrors.GatewayError
+	assert.Nil(t, gatewayApp, "returned GatewayApplication should be nil")
+	assert.ErrorAs(t, err, &gatewayErr, "err should be a GatewayError")
+	assert.Equal(t, http.StatusForbidden, gatewayErr.Status, "status should be forbidden")
+
+	// Allowed users keep the submitted proxyUser
+	gatewayApp, err = appService.Create(context.Background(), submittedApp, "airflow")
+
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "hdfs-svc", *gatewayApp.SparkApplication.Spec.ProxyUser, "proxyUser should be preserved")
+	assert.Equal(t, "airflow", gatewayApp.User, "user should be the authenticated user")
+}
+
+func TestServiceCreateDefaultTimeToLive(t *testing.T) {
+
+	ttlCluster := testCluster
+	ttlCluster.Namespaces = []domain.KubeNamespace{
+		{
+			Name:              "testNamespace",
+			NamespaceId:       "nsid",
+			TimeToLiveSeconds: 3600,
+		},
+	}
+
+	ttlRouter := &clusterrouter.ClusterRouterMock{
+		GetClusterFunc: func(ctx context.Context, namespace string) (*domain.KubeCluster, error) {
+			return &ttlCluster, nil
+		},
+	}
+
+	appService := NewApplicationService(
+		&mockGatewayAppRepository_Success,
+		mockClusterRepo_Success,
+		ttlRouter,
+		ttlRouter,
+		testGatewayConfig,
+		"",
+		"",
+		GatewayIdGenerator_Success,
+	)
+
+	gatewayApp, err := appService.Create(context.Background(), inputSparkApp.DeepCopy(), TEST_USER)
+
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, int64(3600), *gatewayApp.SparkApplication.Spec.TimeToLiveSeconds, "namespace TTL should be applied")
+
+	// A submitted TTL is kept
+	submittedApp := inputSparkApp.DeepCopy()
+	submittedApp.Spec.TimeToLiveSeconds = util.Ptr(int64(60))
+
+	gatewayApp, err = appService.Create(context.Background(), submittedApp, TEST_USER)
+
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, int64(60), *gatewayApp.SparkApplication.Spec.TimeToLiveSeconds, "submitted TTL should be preserved")
+}
+
+func TestServiceCreateRestartPolicyInvalid(t *testing.T) {
+
+	batchCluster := testCluster
+	batchCluster.Namespaces = []domain.KubeNamespace{
+		{
+			Name:        "testNamespace",
+			NamespaceId: "nsid",
+			RestartPolicy: domain.RestartPolicyLimits{
+				AllowedTypes:        []string{"Never", "OnFailure"},
+				MaxOnFailureRetries: 3,
+			},
+		},
+	}
+
+	batchRouter := &clusterrouter.ClusterRouterMock{
+		GetClusterFunc: func(ctx context.Context, namespace string) (*domain.KubeCluster, error) {
+			return &batchCluster, nil
+		},
+	}
+
+	appService := NewApplicationService(
+		&mockGatewayAppRepository_Success,
+		mockClusterRepo_Success,
+		batchRouter,
+		batchRouter,
+		testGatewayConfig,
+		"",
+		"",
+		GatewayIdGenerator_Success,
+	)
+
+	submittedApp := inputSparkApp.DeepCopy()
+	submittedApp.Spec.RestartPolicy = v1beta2.RestartPolicy{Type: v1beta2.RestartPolicyAlways}
+
+	gatewayApp, err := appService.Create(context.Background(), submittedApp, TEST_USER)
+
+	var gatewayErr gatewayerrors.GatewayError
+	assert.Nil(t, gatewayApp, "returned GatewayApplication should be nil")
+	assert.ErrorAs(t, err, &gatewayErr, "err should be a GatewayError")
+	assert.Equal(t, http.StatusUnprocessableEntity, gatewayErr.Status, "status should be unprocessable entity")
+
+	// Allowed types have their retries capped
+	submittedApp.Spec.RestartPolicy = v1beta2.RestartPolicy{Type: v1beta2.RestartPolicyOnFailure, OnFailureRetries: util.Ptr(int32(50))}
+	gatewayApp, err = appService.Create(context.Background(), submittedApp, TEST_USER)
+
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, int32(3), *gatewayApp.SparkApplication.Spec.RestartPolicy.OnFailureRetries, "onFailureRetries should be capped")
+}
+
+func TestServiceCreateInvalidNames(t *testing.T) {
+	appService := NewApplicationService(
+		&mockGatewayAppRepository_Success,
+		mockClusterRepo_Success,
+		&SuccessClusterRouter{},
+		&SuccessClusterRouter{},
+		testGatewayConfig,
+		"",
+		"",
+		GatewayIdGenerator_Success,
+	)
+
+	submittedApp := inputSparkApp.DeepCopy()
+	submittedApp.Spec.Driver.PodName = util.Ptr("My_Driver")
+
+	gatewayApp, err := appService.Create(context.Background(), submittedApp, TEST_USER)
+
+	var gatewayErr gatewayerrors.GatewayError
+	assert.Nil(t, gatewayApp, "returned GatewayApplication should be nil")
+	assert.ErrorAs(t, err, &gatewayErr, "err should be a GatewayError")
+	assert.Equal(t, http.StatusUnprocessableEntity, gatewayErr.Status, "status should be unprocessable entity")
+	assert.Contains(t, err.Error(), "use 'my-driver' instead", "err should explain the adjustment")
 }
 
 func TestServiceCreateRoutingError(t *testing.T) {
@@ -562,7 +1064,7 @@ func TestServiceStatus(t *testing.T) {
 
 	gotStatus, _ := appService.Status(context.Background(), "clusterid-nsid-uuid")
 
-	assert.Equal(t, &expectedGatewayApplication.SparkApplication.Status, gotStatus, "returned response should match")
+	assert.Equal(t, expectedGatewayApplication.SparkApplication.Status, gotStatus.SparkApplicationStatus, "returned response should match")
 }
 
 func TestServiceBadStatus(t *testing.T) {
@@ -579,7 +1081,101 @@ func TestServiceBadStatus(t *testing.T) {
 
 	gatewayApp, err := appService.Status(context.Background(), "clusterid-nsid-uuid")
 
-	assert.Equal(t, (*v1beta2.SparkApplicationStatus)(nil), gatewayApp, "returned GatewayApplication should be nil")
+	assert.Equal(t, (*domain.ApplicationStatus)(nil), gatewayApp, "returned GatewayApplication should be nil")
+	assert.Contains(t, err.Error(), "error getting status for GatewayApplication", "err should match")
+}
+
+func TestServiceWaitStatus(t *testing.T) {
+	waitConfig := testGatewayConfig
+	waitConfig.WaitStatus = config.WaitStatusConfig{PollInterval: time.Millisecond, MaxTimeout: 50 * time.Millisecond}
+
+	failed := v1beta2.SparkApplicationStatus{AppState: v1beta2.ApplicationState{State: v1beta2.ApplicationStateFailed, ErrorMessage: "driver OOMKilled"}}
+	running := v1beta2.SparkApplicationStatus{AppState: v1beta2.ApplicationState{State: v1beta2.ApplicationStateRunning}}
+
+	tests := []struct {
+		name          string
+		statuses      []v1beta2.SparkApplicationStatus
+		lastState     v1beta2.ApplicationStateType
+		expected      *domain.GatewayApplicationWaitStatus
+		expectedCalls int
+	}{
+		{
+			name:          "no last state returns immediately",
+			statuses:      []v1beta2.SparkApplicationStatus{running},
+			expected:      &domain.GatewayApplicationWaitStatus{State: v1beta2.ApplicationStateRunning, GatewayState: domain.GatewayStateRunning},
+			expectedCalls: 1,
+		},
+		{
+			name:          "returns on state change",
+			statuses:      []v1beta2.SparkApplicationStatus{running, running, failed},
+			lastState:     v1beta2.ApplicationStateRunning,
+			expected:      &domain.GatewayApplicationWaitStatus{State: v1beta2.ApplicationStateFailed, GatewayState: domain.GatewayStateFailed, Terminal: true, FailureMessage: "driver OOMKilled"},
+			expectedCalls: 3,
+		},
+		{
+			name:          "terminal state returns immediately",
+			statuses:      []v1beta2.SparkApplicationStatus{failed},
+			lastState:     v1beta2.ApplicationStateFailed,
+			expected:      &domain.GatewayApplicationWaitStatus{State: v1beta2.ApplicationStateFailed, GatewayState: domain.GatewayStateFailed, Terminal: true, FailureMessage: "driver OOMKilled"},
+			expectedCalls: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			repo := &GatewayApplicationRepositoryMock{
+				StatusFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*domain.ApplicationStatus, error) {
+					status := test.statuses[min(calls, len(test.statuses)-1)]
+					calls++
+					return &domain.ApplicationStatus{SparkApplicationStatus: status}, nil
+				},
+			}
+			appService := NewApplicationService(repo, mockClusterRepo_Success, &SuccessClusterRouter{}, &SuccessClusterRouter{}, waitConfig, "", "", GatewayIdGenerator_Failure)
+
+			got, err := appService.WaitStatus(context.Background(), "clusterid-nsid-uuid", test.lastState, 0)
+
+			assert.NoError(t, err, "WaitStatus should not error")
+			assert.Equal(t, test.expected, got, "returned wait status should match")
+			assert.Equal(t, test.expectedCalls, calls, "status should be polled until it changes")
+		})
+	}
+}
+
+func TestServiceWaitStatusTimeout(t *testing.T) {
+	waitConfig := testGatewayConfig
+	waitConfig.WaitStatus = config.WaitStatusConfig{PollInterval: time.Millisecond, MaxTimeout: time.Minute}
+
+	repo := &GatewayApplicationRepositoryMock{
+		StatusFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*domain.ApplicationStatus, error) {
+			return &domain.ApplicationStatus{SparkApplicationStatus: v1beta2.SparkApplicationStatus{AppState: v1beta2.ApplicationState{State: v1beta2.ApplicationStateRunning}}}, nil
+		},
+	}
+	appService := NewApplicationService(repo, mockClusterRepo_Success, &SuccessClusterRouter{}, &SuccessClusterRouter{}, waitConfig, "", "", GatewayIdGenerator_Failure)
+
+	start := time.Now()
+	got, err := appService.WaitStatus(context.Background(), "clusterid-nsid-uuid", v1beta2.ApplicationStateRunning, 20*time.Millisecond)
+
+	assert.NoError(t, err, "WaitStatus should not error on timeout")
+	assert.Equal(t, &domain.GatewayApplicationWaitStatus{State: v1beta2.ApplicationStateRunning, GatewayState: domain.GatewayStateRunning}, got, "current status should be returned on timeout")
+	assert.Less(t, time.Since(start), time.Second, "requested timeout should be honoured")
+}
+
+func TestServiceBadWaitStatus(t *testing.T) {
+	appService := NewApplicationService(
+		&mockGatewayAppRepository_Failure,
+		mockClusterRepo_Success,
+		&SuccessClusterRouter{},
+		&SuccessClusterRouter{},
+		testGatewayConfig,
+		"",
+		"",
+		GatewayIdGenerator_Failure,
+	)
+
+	got, err := appService.WaitStatus(context.Background(), "clusterid-nsid-uuid", "", 0)
+
+	assert.Nil(t, got, "returned wait status should be nil")
 	assert.Contains(t, err.Error(), "error getting status for GatewayApplication", "err should match")
 }
 
@@ -600,6 +1196,136 @@ func TestServiceLogs(t *testing.T) {
 	assert.Equal(t, &logString, gatewayLogs, "returned Gateway logs should be same")
 }
 
+func TestServiceLogsNamespaceLogLines(t *testing.T) {
+
+	logsCluster := testCluster
+	logsCluster.Namespaces = []domain.KubeNamespace{
+		{
+			Name:            "testNamespace",
+			NamespaceId:     "nsid",
+			DefaultLogLines: 50,
+			MaxLogLines:     500,
+		},
+	}
+
+	logsClusterRepo := &repository.ClusterRepositoryMock{
+		GetByIdFunc: func(clusterId string) (*domain.KubeCluster, error) {
+			return &logsCluster, nil
+		},
+	}
+
+	tests := []struct {
+		name      string
+		tailLines int
+		expected  int
+	}{
+		{name: "unset uses namespace default", tailLines: 0, expected: 50},
+		{name: "requested lines are kept", tailLines: 200, expected: 200},
+		{name: "requested lines are capped", tailLines: 1000, expected: 500},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var gotTailLines int
+			repo := &GatewayApplicationRepositoryMock{
+				LogsFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, tailLines int) (*string, error) {
+					gotTailLines = tailLines
+					return &logString, nil
+				},
+			}
+			appService := NewApplicationService(repo, logsClusterRepo, &SuccessClusterRouter{}, &SuccessClusterRouter{}, testGatewayConfig, "", "", GatewayIdGenerator_Failure)
+
+			_, err := appService.Logs(context.Background(), "clusterid-nsid-uuid", test.tailLines)
+
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, test.expected, gotTailLines, "tail lines should be resolved from the namespace")
+		})
+	}
+}
+
+func TestServiceFollowLogs(t *testing.T) {
+
+	var gotTailLines int
+	repo := &GatewayApplicationRepositoryMock{
+		FollowLogsFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, tailLines int) (io.ReadCloser, error) {
+			gotTailLines = tailLines
+			return io.NopCloser(strings.NewReader(logString)), nil
+		},
+	}
+	appService := NewApplicationService(repo, mockClusterRepo_Success, &SuccessClusterRouter{}, &SuccessClusterRouter{}, testGatewayConfig, "", "", GatewayIdGenerator_Failure)
+
+	logStream, err := appService.FollowLogs(context.Background(), "clusterid-nsid-uuid", 200)
+	assert.Nil(t, err, "err should be nil")
+
+	gotLogs, _ := io.ReadAll(logStream)
+	assert.Equal(t, logString, string(gotLogs), "followed logs should be same")
+	assert.Equal(t, 200, gotTailLines, "tail lines should be passed to SparkManager")
+}
+
+func TestServiceExecutorLogs(t *testing.T) {
+
+	repo := &GatewayApplicationRepositoryMock{
+		ExecutorLogsFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, executorId string, tailLines int) (*string, error) {
+			return &logString, nil
+		},
+	}
+	appService := NewApplicationService(repo, mockClusterRepo_Success, &SuccessClusterRouter{}, &SuccessClusterRouter{}, testGatewayConfig, "", "", GatewayIdGenerator_Failure)
+
+	gotLogs, err := appService.ExecutorLogs(context.Background(), "clusterid-nsid-uuid", "3", 200)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, logString, *gotLogs, "executor logs should be same")
+	assert.Equal(t, "3", repo.ExecutorLogsCalls()[0].ExecutorId, "executorId should be passed to SparkManager")
+	assert.Equal(t, 200, repo.ExecutorLogsCalls()[0].TailLines, "tail lines should be passed to SparkManager")
+}
+
+func TestServicePods(t *testing.T) {
+
+	pods := []*domain.SparkApplicationPod{{Name: "clusterid-nsid-uuid-driver", Role: "driver", Phase: "Running"}}
+	repo := &GatewayApplicationRepositoryMock{
+		PodsFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) ([]*domain.SparkApplicationPod, error) {
+			return pods, nil
+		},
+	}
+	appService := NewApplicationService(repo, mockClusterRepo_Success, &SuccessClusterRouter{}, &SuccessClusterRouter{}, testGatewayConfig, "", "", GatewayIdGenerator_Failure)
+
+	appPods, err := appService.Pods(context.Background(), "clusterid-nsid-uuid")
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, &domain.ApplicationPods{GatewayId: "clusterid-nsid-uuid", Pods: pods}, appPods, "pods should be returned with the gatewayId")
+}
+
+func TestServiceStreamLogs(t *testing.T) {
+	appService := NewApplicationService(
+		&mockGatewayAppRepository_Success,
+		mockClusterRepo_Success,
+		&SuccessClusterRouter{},
+		&SuccessClusterRouter{},
+		testGatewayConfig,
+		"",
+		"",
+		GatewayIdGenerator_Failure,
+	)
+
+	logStream, err := appService.StreamLogs(context.Background(), "clusterid-nsid-uuid")
+	assert.Nil(t, err, "err should be nil")
+
+	gotLogs, _ := io.ReadAll(logStream)
+	assert.Equal(t, logString, string(gotLogs), "streamed Gateway logs should be same")
+
+	failService := NewApplicationService(
+		&mockGatewayAppRepository_Failure,
+		mockClusterRepo_Success,
+		&SuccessClusterRouter{},
+		&SuccessClusterRouter{},
+		testGatewayConfig,
+		"",
+		"",
+		GatewayIdGenerator_Failure,
+	)
+
+	_, err = failService.StreamLogs(context.Background(), "clusterid-nsid-uuid")
+	assert.EqualError(t, err, "error streaming logs for GatewayApplication 'clusterid-nsid-uuid': error streaming logs", "errors should match")
+}
+
 func TestServiceBadLogs(t *testing.T) {
 	appService := NewApplicationService(
 		&mockGatewayAppRepository_Failure,
@@ -633,6 +1359,111 @@ func TestServiceDeleteError(t *testing.T) {
 
 }
 
+func TestServiceScale(t *testing.T) {
+	var gotScale domain.ExecutorScale
+	repo := &GatewayApplicationRepositoryMock{
+		ScaleFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, scale domain.ExecutorScale) (*v1beta2.SparkApplication, error) {
+			gotScale = scale
+			return expectedSparkApp, nil
+		},
+	}
+	appService := NewApplicationService(
+		repo,
+		mockClusterRepo_Success,
+		&SuccessClusterRouter{},
+		&SuccessClusterRouter{},
+		testGatewayConfig,
+		"",
+		"",
+		GatewayIdGenerator_Failure,
+	)
+
+	gatewayApp, err := appService.Scale(context.Background(), "clusterid-nsid-uuid", domain.ExecutorScale{Instances: util.Ptr(int32(3))})
+	assert.NoError(t, err, "scaling should not error")
+	assert.Equal(t, int32(3), *gotScale.Instances, "scale should be passed to the repository")
+	assert.Equal(t, expectedSparkApp.Name, gatewayApp.GatewayId, "scaled GatewayApplication should be returned")
+
+	_, err = appService.Scale(context.Background(), "clusterid-nsid-uuid", domain.ExecutorScale{})
+	assert.Equal(t, http.StatusBadRequest, gatewayerrors.NewFrom(err).Status, "invalid scale should be a bad request")
+	assert.Len(t, repo.ScaleCalls(), 1, "invalid scale should not reach the repository")
+}
+
+func TestServiceUpdate(t *testing.T) {
+	var gotUpdate domain.ApplicationUpdate
+	repo := &GatewayApplicationRepositoryMock{
+		UpdateFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, update domain.ApplicationUpdate) (*v1beta2.SparkApplication, error) {
+			gotUpdate = update
+			return expectedSparkApp, nil
+		},
+	}
+	appService := NewApplicationService(
+		repo,
+		mockClusterRepo_Success,
+		&SuccessClusterRouter{},
+		&SuccessClusterRouter{},
+		testGatewayConfig,
+		"",
+		"",
+		GatewayIdGenerator_Failure,
+	)
+
+	gatewayApp, err := appService.Update(context.Background(), "clusterid-nsid-uuid", domain.ApplicationUpdate{TimeToLiveSeconds: util.Ptr(int64(60))})
+	assert.NoError(t, err, "updating should not error")
+	assert.Equal(t, int64(60), *gotUpdate.TimeToLiveSeconds, "update should be passed to the repository")
+	assert.Equal(t, expectedSparkApp.Name, gatewayApp.GatewayId, "updated GatewayApplication should be returned")
+
+	_, err = appService.Update(context.Background(), "clusterid-nsid-uuid", domain.ApplicationUpdate{Labels: map[string]string{domain.GATEWAY_USER_LABEL: "mallory"}})
+	assert.Equal(t, http.StatusBadRequest, gatewayerrors.NewFrom(err).Status, "updating Gateway labels should be a bad request")
+	assert.Len(t, repo.UpdateCalls(), 1, "invalid updates should not reach the repository")
+}
+
+func TestServiceSelectorOverride(t *testing.T) {
+	for _, override := range []bool{false, true} {
+		t.Run(fmt.Sprintf("override %t", override), func(t *testing.T) {
+			var overrides []bool
+			repo := &GatewayApplicationRepositoryMock{
+				DeleteFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) error {
+					overrides = append(overrides, domain.IsSelectorOverride(ctx))
+					return nil
+				},
+				ScaleFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, scale domain.ExecutorScale) (*v1beta2.SparkApplication, error) {
+					overrides = append(overrides, domain.IsSelectorOverride(ctx))
+					return expectedSparkApp, nil
+				},
+				UpdateFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, update domain.ApplicationUpdate) (*v1beta2.SparkApplication, error) {
+					overrides = append(overrides, domain.IsSelectorOverride(ctx))
+					return expectedSparkApp, nil
+				},
+			}
+			appService := NewApplicationService(
+				repo,
+				mockClusterRepo_Success,
+				&SuccessClusterRouter{},
+				&SuccessClusterRouter{},
+				testGatewayConfig,
+				"spark-gateway/owned",
+				"true",
+				GatewayIdGenerator_Failure,
+			)
+
+			ctx := context.Background()
+			if override {
+				ctx = domain.WithSelectorOverride(ctx)
+			}
+
+			assert.NoError(t, appService.Delete(ctx, "clusterid-nsid-uuid"), "delete should not error")
+			_, err := appService.Scale(ctx, "clusterid-nsid-uuid", domain.ExecutorScale{Instances: util.Ptr(int32(3))})
+			assert.NoError(t, err, "scale should not error")
+			_, err = appService.Update(ctx, "clusterid-nsid-uuid", domain.ApplicationUpdate{TimeToLiveSeconds: util.Ptr(int64(60))})
+			assert.NoError(t, err, "update should not error")
+
+			// SparkManager checks the selector labels, so the override is passed on rather than checked with a Get
+			assert.Equal(t, []bool{override, override, override}, overrides, "override should be passed to SparkManager")
+			assert.Len(t, repo.GetCalls(), 0, "the application should not be read first")
+		})
+	}
+}
+
 func TestRenderURLs(t *testing.T) {
 	urlTemplates := domain.StatusUrlTemplates{
 		SparkUITemplate:        "host.com/ui/{{.Namespace}}/{{.Name}}",
@@ -653,5 +1484,16 @@ func TestRenderURLs(t *testing.T) {
 		LogsUI:         "host.com/logs/ui/namespace/clusterid-nsid-uuid",
 	}
 
-	assert.Equal(t, expected, GetRenderedURLs(urlTemplates, &gaSparkApp))
+	urls, warnings := GetRenderedURLs(urlTemplates, &gaSparkApp)
+	assert.Equal(t, expected, urls)
+	assert.Empty(t, warnings, "no warnings should be returned when every URL renders")
+
+	urlTemplates.LogsUITemplate = "host.com/logs/ui/{{.Cluster}}"
+	urls, warnings = GetRenderedURLs(urlTemplates, &gaSparkApp)
+	assert.Equal(t, "", urls.LogsUI, "the URL that failed to render should be empty")
+	assert.Equal(t, expected.SparkUI, urls.SparkUI, "the other URLs should still render")
+	if assert.Len(t, warnings, 1, "a warning should be returned for the URL that failed to render") {
+		assert.Equal(t, domain.URLTemplateWarning, warnings[0].Code)
+		assert.Contains(t, warnings[0].Message, "logsUI", "the warning should name the URL")
+	}
 }
@@ -0,0 +1,85 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/metrics"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+)
+
+//go:generate moq -rm  -out mockquotautilizationreader.go . QuotaUtilizationReader
+
+// QuotaUtilizationReader reads the highest used/hard ratio across a namespace's ResourceQuotas in a cluster. ok is
+// false if it is unknown.
+type QuotaUtilizationReader interface {
+	QuotaUtilization(ctx context.Context, cluster domain.KubeCluster, namespace string) (utilization float64, ok bool)
+}
+
+type softQuotaApplicationService struct {
+	GatewayApplicationService
+	quotaReader QuotaUtilizationReader
+	threshold   float64
+}
+
+// NewSoftQuotaApplicationService wraps appService so Create warns about submissions admitted to a namespace above the
+// soft quota threshold of its cluster
+func NewSoftQuotaApplicationService(appService GatewayApplicationService, quotaReader QuotaUtilizationReader, softQuotaConfig config.SoftQuotaConfig) GatewayApplicationService {
+	return &softQuotaApplicationService{
+		GatewayApplicationService: appService,
+		quotaReader:               quotaReader,
+		threshold:                 softQuotaConfig.Threshold,
+	}
+}
+
+// Create submits the application, adding a domain.SoftQuotaWarning to the created GatewayApplication if its namespace's
+// ResourceQuota utilization in the cluster it was submitted to is at least the threshold. Submissions are never
+// rejected by the soft quota, and are returned without a warning if the utilization is unknown.
+func (s *softQuotaApplicationService) Create(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error) {
+	gatewayApp, err := s.GatewayApplicationService.Create(ctx, application, user)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, namespace, err := s.GetClusterNamespaceFromGatewayId(gatewayApp.GatewayId)
+	if err != nil {
+		klog.Warningf("error getting cluster of GatewayApplication '%s' to check its soft quota: %v", gatewayApp.GatewayId, err)
+		return gatewayApp, nil
+	}
+
+	utilization, ok := s.quotaReader.QuotaUtilization(ctx, *cluster, namespace)
+	if !ok || utilization < s.threshold {
+		return gatewayApp, nil
+	}
+
+	metrics.SoftQuotaWarningsTotal.WithLabelValues(cluster.Name, namespace).Inc()
+	gatewayApp.Warnings = append(gatewayApp.Warnings, domain.SubmissionWarning{
+		Code:        domain.SoftQuotaWarning,
+		Message:     fmt.Sprintf("namespace %s has used %.0f%% of its ResourceQuota in cluster %s, submissions will be rejected once it is exhausted", namespace, utilization*100, cluster.Name),
+		Cluster:     cluster.Name,
+		Namespace:   namespace,
+		Utilization: utilization,
+		Threshold:   s.threshold,
+	})
+
+	return gatewayApp, nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+)
+
+func TestSoftQuotaApplicationServiceCreate(t *testing.T) {
+	tests := []struct {
+		name        string
+		utilization float64
+		known       bool
+		wantWarning bool
+	}{
+		{name: "below threshold", utilization: 0.5, known: true},
+		{name: "at threshold", utilization: 0.8, known: true, wantWarning: true},
+		{name: "above threshold", utilization: 0.95, known: true, wantWarning: true},
+		{name: "unknown utilization", utilization: 0.95},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			appService := &GatewayApplicationServiceMock{
+				CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error) {
+					return &domain.GatewayApplication{GatewayId: "clusterid-nsid-uuid", Cluster: "cluster"}, nil
+				},
+				GetClusterNamespaceFromGatewayIdFunc: func(gatewayId string) (*domain.KubeCluster, string, error) {
+					return &domain.KubeCluster{Name: "cluster"}, "ns", nil
+				},
+			}
+			quotaReader := &QuotaUtilizationReaderMock{
+				QuotaUtilizationFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string) (float64, bool) {
+					assert.Equal(t, "cluster", cluster.Name, "cluster the application was submitted to should be checked")
+					assert.Equal(t, "ns", namespace, "namespace of the application should be checked")
+					return tc.utilization, tc.known
+				},
+			}
+			softQuotaService := NewSoftQuotaApplicationService(appService, quotaReader, config.SoftQuotaConfig{Enable: true, Threshold: 0.8})
+
+			got, err := softQuotaService.Create(context.Background(), &v1beta2.SparkApplication{}, "user")
+			assert.NoError(t, err)
+
+			if !tc.wantWarning {
+				assert.Empty(t, got.Warnings, "no warning expected")
+				return
+			}
+			if assert.Len(t, got.Warnings, 1) {
+				assert.Equal(t, domain.SoftQuotaWarning, got.Warnings[0].Code)
+				assert.Equal(t, "ns", got.Warnings[0].Namespace)
+				assert.Equal(t, tc.utilization, got.Warnings[0].Utilization)
+				assert.Equal(t, 0.8, got.Warnings[0].Threshold)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/metrics"
+)
+
+// speculativeCandidate is one copy of a speculative submission
+type speculativeCandidate struct {
+	rank    string
+	cluster domain.KubeCluster
+	app     *domain.GatewayApplication
+	failed  bool
+}
+
+var speculativeRanks = []string{"primary", "secondary"}
+
+// createSpeculative creates a copy of application in each of clusters, then keeps the copy whose driver runs first and
+// deletes the others. The race is decided even if ctx is cancelled so the copies not kept are always cleaned up.
+func (s *service) createSpeculative(ctx context.Context, application *v1beta2.SparkApplication, user string, clusters []domain.KubeCluster) (*domain.GatewayApplication, error) {
+	group := uuid.NewString()
+
+	var candidates []*speculativeCandidate
+	var createErr error
+	for i, cluster := range clusters {
+		app, err := s.createInCluster(ctx, application, user, cluster, domain.WithSpeculativeGroup(group))
+		if err != nil {
+			klog.Warningf("error creating speculative copy of application '%s' in cluster %s: %v", application.Name, cluster.Name, err)
+			createErr = err
+			continue
+		}
+		candidates = append(candidates, &speculativeCandidate{rank: speculativeRanks[i], cluster: cluster, app: app})
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("error creating speculative GatewayApplication: %w", createErr)
+	case 1:
+		metrics.SpeculativeSubmissionsTotal.WithLabelValues(candidates[0].rank, "createFailed").Inc()
+		return candidates[0].app, nil
+	}
+
+	raceCtx := context.WithoutCancel(ctx)
+	winner, reason := s.raceSpeculative(raceCtx, candidates)
+	klog.Infof("speculative group %s: keeping GatewayApplication '%s' in cluster %s (%s)", group, winner.app.GatewayId, winner.cluster.Name, reason)
+	metrics.SpeculativeSubmissionsTotal.WithLabelValues(winner.rank, reason).Inc()
+
+	for _, candidate := range candidates {
+		if candidate == winner {
+			continue
+		}
+		if err := s.gatewayAppRepo.Delete(raceCtx, candidate.cluster, application.Namespace, candidate.app.GatewayId); err != nil {
+			klog.Errorf("speculative group %s: error deleting GatewayApplication '%s' in cluster %s: %v", group, candidate.app.GatewayId, candidate.cluster.Name, err)
+			metrics.SpeculativeDeletesTotal.WithLabelValues("failure").Inc()
+			continue
+		}
+		metrics.SpeculativeDeletesTotal.WithLabelValues("success").Inc()
+	}
+
+	return winner.app, nil
+}
+
+// raceSpeculative polls the candidates' status until a driver is running, returning the candidate to keep and why.
+// A candidate that fails drops out of the race, and the remaining candidate is kept. When no driver runs within the
+// schedule timeout, or every candidate failed, the highest ranked remaining candidate is kept.
+func (s *service) raceSpeculative(ctx context.Context, candidates []*speculativeCandidate) (*speculativeCandidate, string) {
+	ticker := time.NewTicker(s.config.Speculative.PollInterval)
+	defer ticker.Stop()
+	timeout := time.After(s.config.Speculative.ScheduleTimeout)
+
+	for {
+		var remaining []*speculativeCandidate
+		for _, candidate := range candidates {
+			if candidate.failed {
+				continue
+			}
+
+			status, err := s.gatewayAppRepo.Status(ctx, candidate.cluster, candidate.app.SparkApplication.Namespace, candidate.app.GatewayId)
+			if err != nil {
+				klog.Warningf("error getting status of speculative GatewayApplication '%s': %v", candidate.app.GatewayId, err)
+				remaining = append(remaining, candidate)
+				continue
+			}
+
+			switch status.AppState.State {
+			case v1beta2.ApplicationStateRunning, v1beta2.ApplicationStateSucceeding, v1beta2.ApplicationStateCompleted:
+				return candidate, "scheduled"
+			case v1beta2.ApplicationStateFailed, v1beta2.ApplicationStateFailedSubmission, v1beta2.ApplicationStateFailing:
+				candidate.failed = true
+				continue
+			}
+			remaining = append(remaining, candidate)
+		}
+
+		switch {
+		case len(remaining) == 0:
+			return candidates[0], "failed"
+		case len(remaining) < len(candidates):
+			return remaining[0], "failed"
+		}
+
+		select {
+		case <-ticker.C:
+		case <-timeout:
+			return remaining[0], "timeout"
+		}
+	}
+}
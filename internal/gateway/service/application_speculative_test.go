@@ -0,0 +1,234 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+// rankedClusterRouter routes to its clusters in order
+type rankedClusterRouter []domain.KubeCluster
+
+func (r rankedClusterRouter) GetCluster(ctx context.Context, namespace string) (*domain.KubeCluster, error) {
+	return &r[0], nil
+}
+
+func (r rankedClusterRouter) GetClusters(ctx context.Context, namespace string, count int) ([]domain.KubeCluster, error) {
+	return r[:min(count, len(r))], nil
+}
+
+func TestServiceCreateSpeculative(t *testing.T) {
+	primaryCluster := testCluster
+	primaryCluster.Name, primaryCluster.ClusterId = "primary", "p"
+	secondaryCluster := testCluster
+	secondaryCluster.Name, secondaryCluster.ClusterId = "secondary", "s"
+
+	speculativeConfig := testGatewayConfig
+	speculativeConfig.Speculative = config.SpeculativeConfig{Enable: true, PollInterval: time.Millisecond, ScheduleTimeout: 20 * time.Millisecond}
+
+	tests := []struct {
+		name            string
+		config          config.GatewayConfig
+		annotation      string
+		states          map[string]v1beta2.ApplicationStateType
+		expectedCluster string
+		expectedDeleted []string
+	}{
+		{
+			name:            "secondary driver runs first",
+			config:          speculativeConfig,
+			annotation:      "true",
+			states:          map[string]v1beta2.ApplicationStateType{"primary": v1beta2.ApplicationStateSubmitted, "secondary": v1beta2.ApplicationStateRunning},
+			expectedCluster: "secondary",
+			expectedDeleted: []string{"primary"},
+		},
+		{
+			name:            "primary fails",
+			config:          speculativeConfig,
+			annotation:      "true",
+			states:          map[string]v1beta2.ApplicationStateType{"primary": v1beta2.ApplicationStateFailedSubmission, "secondary": v1beta2.ApplicationStateSubmitted},
+			expectedCluster: "secondary",
+			expectedDeleted: []string{"primary"},
+		},
+		{
+			name:            "no driver runs before the timeout",
+			config:          speculativeConfig,
+			annotation:      "true",
+			states:          map[string]v1beta2.ApplicationStateType{"primary": v1beta2.ApplicationStateSubmitted, "secondary": v1beta2.ApplicationStateSubmitted},
+			expectedCluster: "primary",
+			expectedDeleted: []string{"secondary"},
+		},
+		{
+			name:            "not opted in",
+			config:          speculativeConfig,
+			annotation:      "",
+			expectedCluster: "primary",
+		},
+		{
+			name:            "speculative submissions disabled",
+			config:          testGatewayConfig,
+			annotation:      "true",
+			expectedCluster: "primary",
+		},
+	}
+
+	for _, test := range tests {
+		var mu sync.Mutex
+		gatewayIdClusters := map[string]string{}
+		appRepo := GatewayApplicationRepositoryMock{
+			CreateFunc: func(ctx context.Context, cluster domain.KubeCluster, sparkApp *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				gatewayIdClusters[sparkApp.Name] = cluster.Name
+				return sparkApp, nil
+			},
+			StatusFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace, name string) (*domain.ApplicationStatus, error) {
+				return &domain.ApplicationStatus{SparkApplicationStatus: v1beta2.SparkApplicationStatus{AppState: v1beta2.ApplicationState{State: test.states[cluster.Name]}}}, nil
+			},
+			DeleteFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace, name string) error {
+				return nil
+			},
+		}
+
+		var gatewayIdCount int
+		gatewayIdGen := func(cluster domain.KubeCluster, namespace string) (string, error) {
+			gatewayIdCount++
+			return fmt.Sprintf("%s-nsid-uuid%d", cluster.ClusterId, gatewayIdCount), nil
+		}
+
+		router := rankedClusterRouter{primaryCluster, secondaryCluster}
+		appService := NewApplicationService(&appRepo, mockClusterRepo_Success, router, router, test.config, "", "", gatewayIdGen)
+
+		submitted := inputSparkApp.DeepCopy()
+		if test.annotation != "" {
+			submitted.Annotations[domain.GATEWAY_SPECULATIVE_ANNOTATION] = test.annotation
+		}
+
+		gatewayApp, err := appService.Create(context.Background(), submitted, TEST_USER)
+		assert.Nil(t, err, "%s: err should be nil", test.name)
+		assert.Equal(t, test.expectedCluster, gatewayIdClusters[gatewayApp.GatewayId], "%s: kept copy's cluster should match", test.name)
+
+		var deleted []string
+		for _, call := range appRepo.DeleteCalls() {
+			deleted = append(deleted, call.Cluster.Name)
+		}
+		assert.Equal(t, test.expectedDeleted, deleted, "%s: copies not kept should be deleted", test.name)
+
+		if len(appRepo.CreateCalls()) == 2 {
+			groups := map[string]bool{}
+			for _, call := range appRepo.CreateCalls() {
+				groups[call.Application.Labels[domain.GATEWAY_SPECULATIVE_GROUP_LABEL]] = true
+			}
+			assert.Len(t, groups, 1, "%s: both copies should share a speculative group", test.name)
+			assert.NotContains(t, groups, "", "%s: copies should be labeled with their speculative group", test.name)
+		} else {
+			assert.Len(t, appRepo.CreateCalls(), 1, "%s: a non speculative submission should be created once", test.name)
+		}
+	}
+}
+
+// countingClusterRouter ranks clusters, or fails with err, counting how often it routes to a single cluster
+type countingClusterRouter struct {
+	clusters   []domain.KubeCluster
+	err        error
+	getCluster int
+}
+
+func (r *countingClusterRouter) GetCluster(ctx context.Context, namespace string) (*domain.KubeCluster, error) {
+	r.getCluster++
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &r.clusters[0], nil
+}
+
+func (r *countingClusterRouter) GetClusters(ctx context.Context, namespace string, count int) ([]domain.KubeCluster, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.clusters[:min(count, len(r.clusters))], nil
+}
+
+func TestServiceCreateSpeculativeRouting(t *testing.T) {
+	speculativeConfig := testGatewayConfig
+	speculativeConfig.Speculative = config.SpeculativeConfig{Enable: true, PollInterval: time.Millisecond, ScheduleTimeout: 20 * time.Millisecond}
+
+	tests := []struct {
+		name               string
+		router             *countingClusterRouter
+		expectedErr        bool
+		expectedStatus     int
+		expectedCreates    int
+		expectedGetCluster int
+	}{
+		{
+			name:               "routing rejected",
+			router:             &countingClusterRouter{err: gatewayerrors.NewTooManyRequests(errors.New("every cluster is at its cap"))},
+			expectedErr:        true,
+			expectedStatus:     http.StatusTooManyRequests,
+			expectedGetCluster: 0,
+		},
+		{
+			name:               "single routable cluster",
+			router:             &countingClusterRouter{clusters: []domain.KubeCluster{testCluster}},
+			expectedCreates:    1,
+			expectedGetCluster: 0,
+		},
+		{
+			name:               "ranking error",
+			router:             &countingClusterRouter{clusters: []domain.KubeCluster{testCluster}, err: errors.New("no ranking")},
+			expectedErr:        true,
+			expectedGetCluster: 1,
+		},
+	}
+
+	for _, test := range tests {
+		appRepo := GatewayApplicationRepositoryMock{
+			CreateFunc: func(ctx context.Context, cluster domain.KubeCluster, sparkApp *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
+				return sparkApp, nil
+			},
+		}
+		fallback := &countingClusterRouter{err: errors.New("no fallback")}
+		appService := NewApplicationService(&appRepo, mockClusterRepo_Success, test.router, fallback, speculativeConfig, "", "", GatewayIdGenerator_Success)
+
+		submitted := inputSparkApp.DeepCopy()
+		submitted.Annotations[domain.GATEWAY_SPECULATIVE_ANNOTATION] = "true"
+
+		_, err := appService.Create(context.Background(), submitted, TEST_USER)
+		assert.Equal(t, test.expectedErr, err != nil, "%s: error should match, got %v", test.name, err)
+		if test.expectedStatus != 0 {
+			var gatewayErr gatewayerrors.GatewayError
+			if assert.ErrorAs(t, err, &gatewayErr, "%s: error should be a GatewayError", test.name) {
+				assert.Equal(t, test.expectedStatus, gatewayErr.Status, "%s: status should match", test.name)
+			}
+		}
+		assert.Len(t, appRepo.CreateCalls(), test.expectedCreates, "%s: creates should match", test.name)
+		assert.Equal(t, test.expectedGetCluster, test.router.getCluster, "%s: the submission should only be routed again when ranking failed", test.name)
+	}
+}
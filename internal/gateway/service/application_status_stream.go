@@ -0,0 +1,108 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+)
+
+// StreamStatus relays the status of a GatewayApplication each time its state changes, starting with its current status,
+// until a terminal state has been sent or ctx is done. SparkManager pushes the changes its informer observes. A
+// SparkManager stream that ends early, such as when SparkManager restarts, is reopened, and the relay ends once it
+// can't be. The channel is closed when the relay ends.
+func (s *service) StreamStatus(ctx context.Context, gatewayId string) (<-chan *domain.ApplicationStatus, error) {
+	cluster, namespace, err := s.GetClusterNamespaceFromGatewayId(gatewayId)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := s.gatewayAppRepo.StreamStatus(ctx, *cluster, namespace, gatewayId)
+	if err != nil {
+		return nil, fmt.Errorf("error streaming status for GatewayApplication '%s': %w", gatewayId, err)
+	}
+
+	statuses := make(chan *domain.ApplicationStatus)
+	go func() {
+		defer close(statuses)
+
+		var last *domain.ApplicationStatus
+		for {
+			var done bool
+			last, done = relayStatuses(ctx, statuses, body, last)
+			body.Close()
+			if done {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(watchReconnectDelay):
+			}
+
+			body, err = s.gatewayAppRepo.StreamStatus(ctx, *cluster, namespace, gatewayId)
+			if err != nil {
+				klog.Errorf("error reopening status stream for GatewayApplication '%s': %v", gatewayId, err)
+				return
+			}
+		}
+	}()
+
+	return statuses, nil
+}
+
+// relayStatuses sends the statuses read from body whose state differs from the last one sent, so a reopened stream
+// doesn't repeat its current status. It returns the last status sent and whether the relay is done.
+func relayStatuses(ctx context.Context, statuses chan<- *domain.ApplicationStatus, body io.Reader, last *domain.ApplicationStatus) (*domain.ApplicationStatus, bool) {
+	decoder := json.NewDecoder(body)
+	for {
+		var sparkAppStatus domain.ApplicationStatus
+		if err := decoder.Decode(&sparkAppStatus); err != nil {
+			if ctx.Err() != nil {
+				return last, true
+			}
+			if !errors.Is(err, io.EOF) {
+				klog.Warningf("error reading status stream, reopening: %v", err)
+			}
+			return last, false
+		}
+
+		if last != nil && sparkAppStatus.AppState.State == last.AppState.State {
+			continue
+		}
+
+		status := newGatewayApplicationStatus(&sparkAppStatus)
+		select {
+		case <-ctx.Done():
+			return last, true
+		case statuses <- status:
+		}
+		last = status
+
+		if domain.IsTerminalApplicationState(status.AppState.State) {
+			return last, true
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+)
+
+func statusStream(t *testing.T, states ...v1beta2.ApplicationStateType) io.ReadCloser {
+	var lines []string
+	for _, state := range states {
+		line, err := json.Marshal(domain.ApplicationStatus{SparkApplicationStatus: v1beta2.SparkApplicationStatus{AppState: v1beta2.ApplicationState{State: state}}})
+		assert.NoError(t, err, "status should marshal")
+		lines = append(lines, string(line))
+	}
+	return io.NopCloser(strings.NewReader(strings.Join(lines, "\n")))
+}
+
+func collectStatusStates(t *testing.T, statuses <-chan *domain.ApplicationStatus) []domain.GatewayState {
+	var got []domain.GatewayState
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case status, ok := <-statuses:
+			if !ok {
+				return got
+			}
+			got = append(got, status.GatewayState)
+		case <-timeout:
+			t.Fatal("status channel was not closed")
+		}
+	}
+}
+
+func newStatusStreamTestService(repo GatewayApplicationRepository) GatewayApplicationService {
+	return NewApplicationService(repo, mockClusterRepo_Success, &SuccessClusterRouter{}, &SuccessClusterRouter{}, testGatewayConfig, "", "", GatewayIdGenerator_Success)
+}
+
+func TestServiceStreamStatusReopens(t *testing.T) {
+	watchReconnectDelay = time.Millisecond
+
+	streams := []io.ReadCloser{
+		statusStream(t, v1beta2.ApplicationStateSubmitted, v1beta2.ApplicationStateRunning),
+		// SparkManager restarted, and starts again with the current status
+		statusStream(t, v1beta2.ApplicationStateRunning, v1beta2.ApplicationStateCompleted),
+	}
+	repo := &GatewayApplicationRepositoryMock{
+		StreamStatusFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (io.ReadCloser, error) {
+			stream := streams[0]
+			streams = streams[1:]
+			return stream, nil
+		},
+	}
+	service := newStatusStreamTestService(repo)
+
+	statuses, err := service.StreamStatus(context.Background(), "clusterid-nsid-uuid")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []domain.GatewayState{domain.GatewayStatePending, domain.GatewayStateRunning, domain.GatewayStateSucceeded}, collectStatusStates(t, statuses), "states should be relayed once each until terminal")
+	assert.Len(t, repo.StreamStatusCalls(), 2, "an early end of the stream should reopen it")
+}
+
+func TestServiceStreamStatusReopenFails(t *testing.T) {
+	watchReconnectDelay = time.Millisecond
+
+	repo := &GatewayApplicationRepositoryMock{}
+	repo.StreamStatusFunc = func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (io.ReadCloser, error) {
+		// The SparkApplication was deleted after the first stream was opened
+		if len(repo.StreamStatusCalls()) > 1 {
+			return nil, errors.New("not found")
+		}
+		return statusStream(t, v1beta2.ApplicationStateRunning), nil
+	}
+	service := newStatusStreamTestService(repo)
+
+	statuses, err := service.StreamStatus(context.Background(), "clusterid-nsid-uuid")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []domain.GatewayState{domain.GatewayStateRunning}, collectStatusStates(t, statuses), "the relay should end once the stream can't be reopened")
+}
+
+func TestServiceStreamStatusError(t *testing.T) {
+	repo := &GatewayApplicationRepositoryMock{
+		StreamStatusFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (io.ReadCloser, error) {
+			return nil, errors.New("not found")
+		},
+	}
+	service := newStatusStreamTestService(repo)
+
+	_, err := service.StreamStatus(context.Background(), "clusterid-nsid-uuid")
+	assert.EqualError(t, err, "error streaming status for GatewayApplication 'clusterid-nsid-uuid': not found")
+}
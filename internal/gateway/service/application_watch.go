@@ -0,0 +1,225 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+)
+
+// watchReconnectDelay is how long to wait before reopening a SparkManager watch stream that ended
+var watchReconnectDelay = time.Second
+
+type watchStream struct {
+	cluster   domain.KubeCluster
+	namespace string
+	key       string
+}
+
+// watchMultiplexer serializes events from every SparkManager watch stream onto a single channel. The bookmark is
+// updated and sent with each event under the same lock, so a bookmark never covers an event that hasn't been sent.
+type watchMultiplexer struct {
+	mu       sync.Mutex
+	bookmark domain.WatchBookmark
+	events   chan *domain.GatewayWatchEvent
+}
+
+func (m *watchMultiplexer) resourceVersion(key string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.bookmark[key]
+}
+
+// forget drops the resourceVersion of stream key from the bookmark so a resumed watch starts that stream afresh
+func (m *watchMultiplexer) forget(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.bookmark, key)
+}
+
+// publish records resourceVersion for stream key, if set, and sends event with the resulting bookmark. It returns
+// false if ctx is done before the event is sent.
+func (m *watchMultiplexer) publish(ctx context.Context, key string, resourceVersion string, event *domain.GatewayWatchEvent) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if resourceVersion != "" {
+		m.bookmark[key] = resourceVersion
+	}
+	event.Bookmark = m.bookmark.Encode()
+
+	select {
+	case m.events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Watch multiplexes the SparkManager watch streams of namespace, or of every namespace when blank, across all clusters
+// into a single channel. Each stream resumes from its resourceVersion in bookmark and is reopened from the last
+// resourceVersion seen if SparkManager closes it. A stream stops when its resourceVersion has expired or it can't be
+// reopened, and the channel is closed once ctx is done or every stream has stopped.
+func (s *service) Watch(ctx context.Context, namespace string, labelSelector string, bookmark domain.WatchBookmark) (<-chan *domain.GatewayWatchEvent, error) {
+
+	kubeClusters := s.clusterRepository.GetAll()
+	if namespace != "" {
+		kubeClusters = s.clusterRepository.GetAllWithNamespace(namespace)
+	}
+
+	streams := []watchStream{}
+	for _, kubeCluster := range kubeClusters {
+		namespaces := []string{namespace}
+		if namespace == "" {
+			namespaces = []string{}
+			for _, kubeNamespace := range kubeCluster.Namespaces {
+				namespaces = append(namespaces, kubeNamespace.Name)
+			}
+		}
+
+		for _, ns := range namespaces {
+			streams = append(streams, watchStream{cluster: kubeCluster, namespace: ns, key: domain.WatchStreamKey(kubeCluster.Name, ns)})
+		}
+	}
+
+	if len(streams) == 0 {
+		return nil, fmt.Errorf("no clusters found with namespace '%s'", namespace)
+	}
+
+	// Open every stream before responding so unreachable SparkManagers are reported as request errors
+	bodies := make([]io.ReadCloser, len(streams))
+	for i, stream := range streams {
+		body, err := s.gatewayAppRepo.Watch(ctx, stream.cluster, stream.namespace, labelSelector, bookmark[stream.key])
+		if err != nil {
+			for _, opened := range bodies[:i] {
+				opened.Close()
+			}
+			return nil, fmt.Errorf("error watching applications in cluster '%s' namespace '%s': %w", stream.cluster.Name, stream.namespace, err)
+		}
+		bodies[i] = body
+	}
+
+	mux := &watchMultiplexer{
+		bookmark: maps.Clone(bookmark),
+		events:   make(chan *domain.GatewayWatchEvent),
+	}
+	if mux.bookmark == nil {
+		mux.bookmark = domain.WatchBookmark{}
+	}
+
+	var wg sync.WaitGroup
+	for i, stream := range streams {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.runWatchStream(ctx, mux, stream, labelSelector, bodies[i])
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(mux.events)
+	}()
+
+	return mux.events, nil
+}
+
+// runWatchStream relays events from body, reopening the stream whenever SparkManager ends it cleanly
+func (s *service) runWatchStream(ctx context.Context, mux *watchMultiplexer, stream watchStream, labelSelector string, body io.ReadCloser) {
+	for {
+		reopen := relayWatchEvents(ctx, mux, stream, body)
+		body.Close()
+		if !reopen {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchReconnectDelay):
+		}
+
+		var err error
+		body, err = s.gatewayAppRepo.Watch(ctx, stream.cluster, stream.namespace, labelSelector, mux.resourceVersion(stream.key))
+		if err != nil {
+			klog.Errorf("error reopening watch for cluster '%s' namespace '%s': %v", stream.cluster.Name, stream.namespace, err)
+			mux.publish(ctx, stream.key, "", &domain.GatewayWatchEvent{
+				Type:      domain.WatchEventError,
+				Cluster:   stream.cluster.Name,
+				Namespace: stream.namespace,
+				Error:     err.Error(),
+			})
+			return
+		}
+	}
+}
+
+// relayWatchEvents publishes the events read from body and returns whether the stream should be reopened
+func relayWatchEvents(ctx context.Context, mux *watchMultiplexer, stream watchStream, body io.Reader) bool {
+	decoder := json.NewDecoder(body)
+	for {
+		var event domain.SparkManagerWatchEvent
+		if err := decoder.Decode(&event); err != nil {
+			if ctx.Err() != nil {
+				return false
+			}
+			if !errors.Is(err, io.EOF) {
+				klog.Warningf("error reading watch for cluster '%s' namespace '%s', reopening: %v", stream.cluster.Name, stream.namespace, err)
+			}
+			return true
+		}
+
+		gatewayEvent := &domain.GatewayWatchEvent{
+			Type:      event.Type,
+			Cluster:   stream.cluster.Name,
+			Namespace: stream.namespace,
+			Code:      event.Code,
+			Error:     event.Error,
+		}
+		if event.Object != nil {
+			gatewayEvent.Object = domain.NewGatewayApplicationSummary(*event.Object)
+		}
+
+		// A 410 Gone means the resourceVersion is too old to resume from, so reopening would fail the same way. The
+		// stream is dropped from the bookmark so the client can relist and watch it again from the current state.
+		expired := event.Type == domain.WatchEventError && event.Code == http.StatusGone
+		if expired {
+			mux.forget(stream.key)
+		}
+
+		if !mux.publish(ctx, stream.key, event.ResourceVersion, gatewayEvent) {
+			return false
+		}
+
+		// The API server ends a watch after an error
+		if event.Type == domain.WatchEventError {
+			return !expired
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+)
+
+func watchEventStream(t *testing.T, events ...domain.SparkManagerWatchEvent) io.ReadCloser {
+	var lines []string
+	for _, event := range events {
+		line, err := json.Marshal(event)
+		assert.NoError(t, err, "watch event should marshal")
+		lines = append(lines, string(line))
+	}
+	return io.NopCloser(strings.NewReader(strings.Join(lines, "\n")))
+}
+
+func newWatchTestService(repo GatewayApplicationRepository) GatewayApplicationService {
+	clusterRepo := &repository.ClusterRepositoryMock{
+		GetAllFunc: func() []domain.KubeCluster {
+			return []domain.KubeCluster{testCluster}
+		},
+	}
+	return NewApplicationService(repo, clusterRepo, &SuccessClusterRouter{}, &SuccessClusterRouter{}, testGatewayConfig, "", "", GatewayIdGenerator_Success)
+}
+
+func collectWatchEvents(t *testing.T, events <-chan *domain.GatewayWatchEvent) []*domain.GatewayWatchEvent {
+	var got []*domain.GatewayWatchEvent
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return got
+			}
+			got = append(got, event)
+		case <-timeout:
+			t.Fatal("watch channel was not closed")
+		}
+	}
+}
+
+func TestServiceWatchExpiredResourceVersion(t *testing.T) {
+	streamKey := domain.WatchStreamKey(testCluster.Name, "testNamespace")
+	appSummary := domain.SparkManagerSparkApplicationSummary{GatewayApplicationMeta: domain.GatewayApplicationMeta{Name: "clusterid-nsid-uuid"}}
+
+	repo := &GatewayApplicationRepositoryMock{
+		WatchFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, labelSelector string, resourceVersion string) (io.ReadCloser, error) {
+			return watchEventStream(t,
+				domain.SparkManagerWatchEvent{Type: domain.WatchEventAdded, ResourceVersion: "11", Object: &appSummary},
+				domain.SparkManagerWatchEvent{Type: domain.WatchEventBookmark, ResourceVersion: "12"},
+				domain.SparkManagerWatchEvent{Type: domain.WatchEventError, Code: 410, Error: "too old resource version"},
+			), nil
+		},
+	}
+
+	events, err := newWatchTestService(repo).Watch(context.Background(), "", "app=test", domain.WatchBookmark{streamKey: "10"})
+	assert.NoError(t, err, "Watch should not return error")
+
+	got := collectWatchEvents(t, events)
+	assert.Len(t, got, 3, "every event should be relayed")
+
+	assert.Equal(t, domain.WatchEventAdded, got[0].Type, "event types should match")
+	assert.Equal(t, testCluster.Name, got[0].Cluster, "events should be labeled with cluster")
+	assert.Equal(t, "clusterid-nsid-uuid", got[0].Object.GatewayId, "objects should be GatewayApplicationSummaries")
+	bookmark, _ := domain.ParseWatchBookmark(got[0].Bookmark)
+	assert.Equal(t, domain.WatchBookmark{streamKey: "11"}, bookmark, "bookmark should advance with events")
+
+	bookmark, _ = domain.ParseWatchBookmark(got[1].Bookmark)
+	assert.Equal(t, domain.WatchBookmark{streamKey: "12"}, bookmark, "bookmark should advance with bookmark events")
+
+	assert.Equal(t, int32(410), got[2].Code, "error codes should be relayed")
+	bookmark, _ = domain.ParseWatchBookmark(got[2].Bookmark)
+	assert.Empty(t, bookmark, "expired stream should be dropped from bookmark")
+
+	watchCalls := repo.WatchCalls()
+	assert.Len(t, watchCalls, 1, "expired stream should not be reopened")
+	assert.Equal(t, "10", watchCalls[0].ResourceVersion, "stream should resume from bookmark")
+	assert.Equal(t, "app=test", watchCalls[0].LabelSelector, "labelSelector should be passed")
+}
+
+func TestServiceWatchReopensClosedStream(t *testing.T) {
+	watchReconnectDelay = time.Millisecond
+
+	repo := &GatewayApplicationRepositoryMock{}
+	repo.WatchFunc = func(ctx context.Context, cluster domain.KubeCluster, namespace string, labelSelector string, resourceVersion string) (io.ReadCloser, error) {
+		if len(repo.WatchCalls()) == 1 {
+			return watchEventStream(t, domain.SparkManagerWatchEvent{Type: domain.WatchEventBookmark, ResourceVersion: "20"}), nil
+		}
+		return nil, errors.New("SparkManager unavailable")
+	}
+
+	events, err := newWatchTestService(repo).Watch(context.Background(), "", "", domain.WatchBookmark{})
+	assert.NoError(t, err, "Watch should not return error")
+
+	got := collectWatchEvents(t, events)
+	assert.Len(t, got, 2, "bookmark and reopen error should be relayed")
+	assert.Equal(t, domain.WatchEventError, got[1].Type, "failing to reopen should be reported")
+
+	watchCalls := repo.WatchCalls()
+	assert.Len(t, watchCalls, 2, "closed stream should be reopened")
+	assert.Equal(t, "20", watchCalls[1].ResourceVersion, "stream should be reopened from last resourceVersion")
+}
+
+func TestServiceWatchOpenError(t *testing.T) {
+	repo := &GatewayApplicationRepositoryMock{
+		WatchFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, labelSelector string, resourceVersion string) (io.ReadCloser, error) {
+			return nil, errors.New("SparkManager unavailable")
+		},
+	}
+
+	events, err := newWatchTestService(repo).Watch(context.Background(), "", "", domain.WatchBookmark{})
+
+	assert.Nil(t, events, "no events should be returned")
+	assert.ErrorContains(t, err, "error watching applications in cluster 'test-cluster' namespace 'testNamespace'", "open error should be returned")
+}
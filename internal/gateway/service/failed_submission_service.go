@@ -0,0 +1,239 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+//go:generate moq -rm -out mockfailedsubmissionservice.go . FailedSubmissionService
+
+// FailedSubmissionService lets operators recover dead-lettered asynchronous submissions, the FAILED submissions kept
+// in the submission queue, without editing the database
+type FailedSubmissionService interface {
+	List(ctx context.Context, namespace string) ([]domain.FailedSubmission, error)
+	Get(ctx context.Context, gatewayId string) (*domain.FailedSubmission, error)
+	Edit(ctx context.Context, gatewayId string, edit domain.FailedSubmissionEdit) (*domain.FailedSubmission, error)
+	Retry(ctx context.Context, gatewayId string) error
+	Delete(ctx context.Context, gatewayId string) error
+}
+
+type failedSubmissionService struct {
+	*service
+	db  database.QueuedSubmissionDatabase
+	now func() time.Time
+}
+
+// NewFailedSubmissionService returns a FailedSubmissionService over the submission queue in db. Edited submissions are
+// built the same way as through a GatewayApplicationService created with the same arguments.
+func NewFailedSubmissionService(
+	db database.QueuedSubmissionDatabase,
+	clusterRepository repository.ClusterRepository,
+	config config.GatewayConfig,
+	selectorKey string,
+	selectorValue string,
+	gatewayIdGen GatewayIdGenerator,
+) FailedSubmissionService {
+	return &failedSubmissionService{
+		service: &service{
+			clusterRepository: clusterRepository,
+			config:            config,
+			selectorKey:       selectorKey,
+			selectorValue:     selectorValue,
+			gatewayIdGen:      gatewayIdGen,
+		},
+		db:  db,
+		now: time.Now,
+	}
+}
+
+// List returns the FAILED submissions, most recently failed first, only those of namespace if it is set
+func (s *failedSubmissionService) List(ctx context.Context, namespace string) ([]domain.FailedSubmission, error) {
+	submissions, err := s.db.ListQueuedSubmissions(ctx, string(domain.SubmissionStateFailed), namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	failed := []domain.FailedSubmission{}
+	for _, submission := range submissions {
+		failed = append(failed, failedSubmission(submission))
+	}
+	return failed, nil
+}
+
+// Get returns the FAILED submission of gatewayId with its SparkApplication
+func (s *failedSubmissionService) Get(ctx context.Context, gatewayId string) (*domain.FailedSubmission, error) {
+	submission, err := s.db.GetQueuedSubmission(ctx, gatewayId)
+	if err != nil {
+		return nil, err
+	}
+	if submission == nil || submission.State != string(domain.SubmissionStateFailed) {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("no failed submission with GatewayId '%s'", gatewayId))
+	}
+
+	failed := failedSubmission(*submission)
+	failed.Application = submission.Application
+	return &failed, nil
+}
+
+// Edit replaces the SparkApplication of the FAILED submission of gatewayId, e.g. the one returned by Get with its spec
+// fixed, validated and built for the submission's user the same way as by Create. The submission is moved to
+// edit.Cluster if it is set, under a new GatewayId. It stays FAILED until it is retried.
+func (s *failedSubmissionService) Edit(ctx context.Context, gatewayId string, edit domain.FailedSubmissionEdit) (*domain.FailedSubmission, error) {
+	submission, err := s.db.GetQueuedSubmission(ctx, gatewayId)
+	if err != nil {
+		return nil, err
+	}
+	if submission == nil || submission.State != string(domain.SubmissionStateFailed) {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("no failed submission with GatewayId '%s'", gatewayId))
+	}
+	if edit.Application == nil {
+		return nil, gatewayerrors.NewBadRequest(errors.New("failed submission edit must have an 'application'"))
+	}
+
+	// Strip the metadata the Gateway set when building the submission, restoring its submitted name
+	application := domain.NewMigrationSparkApplication(edit.Application)
+	if application.Namespace == "" {
+		application.Namespace = submission.Namespace
+	}
+	if application.Namespace != submission.Namespace {
+		return nil, gatewayerrors.NewBadRequest(fmt.Errorf("the namespace of failed submission '%s' is '%s' and can't be changed", gatewayId, submission.Namespace))
+	}
+	keepGatewayMetadata(application, submission.Application)
+
+	if err := domain.NewValidationError(domain.ValidateApplicationNames(application)); err != nil {
+		return nil, gatewayerrors.NewInvalid(fmt.Errorf("invalid GatewayApplication names: %w", err))
+	}
+
+	clusterName := submission.Cluster
+	if edit.Cluster != "" {
+		clusterName = edit.Cluster
+	}
+	cluster, err := s.clusterRepository.GetByName(clusterName)
+	if err != nil {
+		return nil, gatewayerrors.NewBadRequest(fmt.Errorf("error getting cluster of failed submission: %w", err))
+	}
+
+	newGatewayId := gatewayId
+	if cluster.Name != submission.Cluster {
+		newGatewayId, err = s.gatewayIdGen(*cluster, application.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("error generating GatewayId for GatewayApplication: %w", err)
+		}
+	}
+
+	gaOpts, err := s.gatewayOptions(application, submission.Username, *cluster)
+	if err != nil {
+		return nil, err
+	}
+	gaSparkApp := domain.NewGatewaySparkApplication(application, append(gaOpts, domain.WithId(newGatewayId), domain.WithAuxiliaryConfigMapReferences(), domain.WithSpecHash())...)
+
+	replacement := *submission
+	replacement.GatewayID = newGatewayId
+	replacement.Cluster = cluster.Name
+	replacement.Application = gaSparkApp.ToV1Beta2SparkApplication()
+	replacement.UpdatedAt = s.now().UTC()
+
+	replaced, err := s.db.ReplaceFailedQueuedSubmission(ctx, gatewayId, replacement, replacement.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if !replaced {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("no failed submission with GatewayId '%s'", gatewayId))
+	}
+
+	klog.Infof("Edited failed submission of SparkApplication '%s' as '%s' in cluster '%s'", gatewayId, newGatewayId, cluster.Name)
+	failed := failedSubmission(replacement)
+	failed.Application = replacement.Application
+	return &failed, nil
+}
+
+// Retry queues the FAILED submission of gatewayId again with its attempts reset, to be submitted to the same cluster
+// by the next drain of the queue
+func (s *failedSubmissionService) Retry(ctx context.Context, gatewayId string) error {
+	requeued, err := s.db.RequeueFailedQueuedSubmission(ctx, gatewayId, s.now().UTC())
+	if err != nil {
+		return err
+	}
+	if !requeued {
+		return gatewayerrors.NewNotFound(fmt.Errorf("no failed submission with GatewayId '%s'", gatewayId))
+	}
+
+	klog.Infof("Requeued failed submission of SparkApplication '%s'", gatewayId)
+	return nil
+}
+
+// Delete rejects the FAILED submission of gatewayId for good, removing it from the queue
+func (s *failedSubmissionService) Delete(ctx context.Context, gatewayId string) error {
+	deleted, err := s.db.DeleteFailedQueuedSubmission(ctx, gatewayId)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return gatewayerrors.NewNotFound(fmt.Errorf("no failed submission with GatewayId '%s'", gatewayId))
+	}
+
+	klog.Infof("Deleted failed submission of SparkApplication '%s'", gatewayId)
+	return nil
+}
+
+// keepGatewayMetadata copies the labels and annotations the Gateway set on the submitted application to its edited
+// application, so editing a submission can't change its team, acting user or bundled ConfigMaps
+func keepGatewayMetadata(application *v1beta2.SparkApplication, submitted *v1beta2.SparkApplication) {
+	delete(application.Labels, domain.GATEWAY_TEAM_LABEL)
+	delete(application.Annotations, domain.GATEWAY_ACTING_USER_ANNOTATION)
+	delete(application.Annotations, domain.GATEWAY_CONFIGMAPS_ANNOTATION)
+	if submitted == nil {
+		return
+	}
+
+	if team, ok := submitted.Labels[domain.GATEWAY_TEAM_LABEL]; ok {
+		application.Labels[domain.GATEWAY_TEAM_LABEL] = team
+	}
+	for _, key := range []string{domain.GATEWAY_ACTING_USER_ANNOTATION, domain.GATEWAY_CONFIGMAPS_ANNOTATION} {
+		if value, ok := submitted.Annotations[key]; ok {
+			application.Annotations[key] = value
+		}
+	}
+}
+
+func failedSubmission(submission database.QueuedSubmission) domain.FailedSubmission {
+	failed := domain.FailedSubmission{
+		GatewayId:  submission.GatewayID,
+		Cluster:    submission.Cluster,
+		Namespace:  submission.Namespace,
+		User:       submission.Username,
+		Attempts:   int(submission.Attempts),
+		QueuedTime: submission.CreatedAt,
+		FailedTime: submission.UpdatedAt,
+	}
+	if submission.LastError != nil {
+		failed.LastError = *submission.LastError
+	}
+	return failed
+}
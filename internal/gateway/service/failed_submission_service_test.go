@@ -0,0 +1,253 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+)
+
+func TestFailedSubmissionServiceList(t *testing.T) {
+	lastError := "admission webhook denied the request"
+	queuedTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	db := &database.QueuedSubmissionDatabaseMock{
+		ListQueuedSubmissionsFunc: func(ctx context.Context, state string, namespace string) ([]database.QueuedSubmission, error) {
+			return []database.QueuedSubmission{{
+				GatewayID:   "c1-app",
+				Cluster:     "c1",
+				Namespace:   namespace,
+				Username:    "alice",
+				Application: &v1beta2.SparkApplication{ObjectMeta: metav1.ObjectMeta{Name: "c1-app"}},
+				State:       state,
+				Attempts:    3,
+				LastError:   &lastError,
+				CreatedAt:   queuedTime,
+				UpdatedAt:   queuedTime.Add(time.Minute),
+			}}, nil
+		},
+	}
+
+	failed, err := NewFailedSubmissionService(db, newMigrationTestClusterRepo(t), testGatewayConfig, "", "", GatewayIdGenerator_Success).List(context.Background(), "ns")
+
+	assert.Nil(t, err, "should be no error")
+	assert.Equal(t, []domain.FailedSubmission{{
+		GatewayId:  "c1-app",
+		Cluster:    "c1",
+		Namespace:  "ns",
+		User:       "alice",
+		Attempts:   3,
+		LastError:  lastError,
+		QueuedTime: queuedTime,
+		FailedTime: queuedTime.Add(time.Minute),
+	}}, failed, "failed submissions should be listed without their application")
+	assert.Equal(t, string(domain.SubmissionStateFailed), db.ListQueuedSubmissionsCalls()[0].State, "only FAILED submissions should be listed")
+}
+
+func TestFailedSubmissionServiceGet(t *testing.T) {
+	tests := []struct {
+		name       string
+		submission *database.QueuedSubmission
+		wantFound  bool
+	}{
+		{
+			name:       "failed submission",
+			submission: &database.QueuedSubmission{GatewayID: "c1-app", State: string(domain.SubmissionStateFailed), Application: &v1beta2.SparkApplication{}},
+			wantFound:  true,
+		},
+		{
+			name:       "queued submission",
+			submission: &database.QueuedSubmission{GatewayID: "c1-app", State: string(domain.SubmissionStateQueued)},
+		},
+		{
+			name: "not queued",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db := &database.QueuedSubmissionDatabaseMock{
+				GetQueuedSubmissionFunc: func(ctx context.Context, gatewayId string) (*database.QueuedSubmission, error) {
+					return test.submission, nil
+				},
+			}
+
+			failed, err := NewFailedSubmissionService(db, newMigrationTestClusterRepo(t), testGatewayConfig, "", "", GatewayIdGenerator_Success).Get(context.Background(), "c1-app")
+
+			if test.wantFound {
+				assert.Nil(t, err, "should be no error")
+				assert.Same(t, test.submission.Application, failed.Application, "the application should be returned")
+				return
+			}
+			var gatewayErr gatewayerrors.GatewayError
+			if assert.ErrorAs(t, err, &gatewayErr, "error should be a GatewayError") {
+				assert.Equal(t, http.StatusNotFound, gatewayErr.Status, "submissions that aren't FAILED should not be found")
+			}
+		})
+	}
+}
+
+func TestFailedSubmissionServiceEdit(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	submitted := migrationTestSparkApp("a-ns-old")
+	submitted.Labels[domain.GATEWAY_TEAM_LABEL] = "data-eng"
+	submitted.Annotations[domain.GATEWAY_ACTING_USER_ANNOTATION] = "bob"
+
+	tests := []struct {
+		name           string
+		edit           func(*v1beta2.SparkApplication)
+		cluster        string
+		state          domain.SubmissionState
+		expectedStatus int
+		gatewayId      string
+	}{
+		{
+			name:      "spec edited in the same cluster",
+			edit:      func(app *v1beta2.SparkApplication) { app.Spec.MainApplicationFile = util.Ptr("local:///fixed.jar") },
+			gatewayId: "a-ns-old",
+		},
+		{
+			name:      "moved to another cluster",
+			cluster:   "cluster-b",
+			gatewayId: "b-ns-new",
+		},
+		{
+			name:      "gateway metadata can't be changed",
+			edit:      func(app *v1beta2.SparkApplication) { app.Labels[domain.GATEWAY_TEAM_LABEL] = "other" },
+			gatewayId: "a-ns-old",
+		},
+		{
+			name:           "namespace changed",
+			edit:           func(app *v1beta2.SparkApplication) { app.Namespace = "other" },
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid names",
+			edit:           func(app *v1beta2.SparkApplication) { app.Spec.Driver.PodName = util.Ptr("Not_A_Label") },
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "cluster without the namespace",
+			cluster:        "cluster-c",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "submission not failed",
+			state:          domain.SubmissionStateQueued,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := domain.SubmissionStateFailed
+			if test.state != "" {
+				state = test.state
+			}
+			db := &database.QueuedSubmissionDatabaseMock{
+				GetQueuedSubmissionFunc: func(ctx context.Context, gatewayId string) (*database.QueuedSubmission, error) {
+					return &database.QueuedSubmission{
+						GatewayID:   gatewayId,
+						Cluster:     "cluster-a",
+						Namespace:   "ns",
+						Username:    "alice",
+						Application: submitted,
+						State:       string(state),
+						Attempts:    3,
+					}, nil
+				},
+				ReplaceFailedQueuedSubmissionFunc: func(ctx context.Context, gatewayId string, replacement database.QueuedSubmission, now time.Time) (bool, error) {
+					return true, nil
+				},
+			}
+			gatewayIdGen := func(cluster domain.KubeCluster, namespace string) (string, error) {
+				return cluster.ClusterId + "-ns-new", nil
+			}
+			failedService := NewFailedSubmissionService(db, newMigrationTestClusterRepo(t), testGatewayConfig, "", "", gatewayIdGen).(*failedSubmissionService)
+			failedService.now = func() time.Time { return now }
+
+			edited := submitted.DeepCopy()
+			if test.edit != nil {
+				test.edit(edited)
+			}
+			failed, err := failedService.Edit(context.Background(), "a-ns-old", domain.FailedSubmissionEdit{Application: edited, Cluster: test.cluster})
+
+			if test.expectedStatus != 0 {
+				var gatewayErr gatewayerrors.GatewayError
+				if assert.ErrorAs(t, err, &gatewayErr, "error should be a GatewayError") {
+					assert.Equal(t, test.expectedStatus, gatewayErr.Status, "status should match")
+				}
+				assert.Empty(t, db.ReplaceFailedQueuedSubmissionCalls(), "the submission should not be replaced")
+				return
+			}
+
+			assert.NoError(t, err, "edit should not error")
+			assert.Equal(t, test.gatewayId, failed.GatewayId, "edited submission should be returned with its GatewayId")
+			if !assert.Len(t, db.ReplaceFailedQueuedSubmissionCalls(), 1, "the submission should be replaced") {
+				return
+			}
+			call := db.ReplaceFailedQueuedSubmissionCalls()[0]
+			replaced := call.Replacement.Application
+			assert.Equal(t, "a-ns-old", call.GatewayId, "the edited submission should be replaced")
+			assert.Equal(t, test.gatewayId, call.Replacement.GatewayID, "the replacement should have the GatewayId of its cluster")
+			assert.Equal(t, now, call.Now, "the replacement should be updated now")
+			assert.Equal(t, test.gatewayId, replaced.Name, "the application should be named after its GatewayId")
+			assert.Equal(t, call.Replacement.Cluster, replaced.Labels[domain.GATEWAY_CLUSTER_LABEL], "the application should be labelled with its cluster")
+			assert.Equal(t, "etl", replaced.Annotations[domain.GATEWAY_APPLICATION_NAME_ANNOTATION], "submitted name should be preserved")
+			assert.Equal(t, "alice", replaced.Labels[domain.GATEWAY_USER_LABEL], "submitting user should be preserved")
+			assert.Equal(t, "data-eng", replaced.Labels[domain.GATEWAY_TEAM_LABEL], "team should be preserved")
+			assert.Equal(t, "bob", replaced.Annotations[domain.GATEWAY_ACTING_USER_ANNOTATION], "acting user should be preserved")
+			assert.NotEqual(t, "hash", replaced.Annotations[domain.GATEWAY_SPEC_HASH_ANNOTATION], "spec hash should be recomputed")
+			assert.Equal(t, *edited.Spec.MainApplicationFile, *replaced.Spec.MainApplicationFile, "edited spec should be stored")
+			assert.Equal(t, 3, failed.Attempts, "the submission should stay FAILED with its attempts until retried")
+		})
+	}
+}
+
+func TestFailedSubmissionServiceRetryAndDelete(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	db := &database.QueuedSubmissionDatabaseMock{
+		RequeueFailedQueuedSubmissionFunc: func(ctx context.Context, gatewayId string, now time.Time) (bool, error) {
+			return gatewayId == "c1-failed", nil
+		},
+		DeleteFailedQueuedSubmissionFunc: func(ctx context.Context, gatewayId string) (bool, error) {
+			return gatewayId == "c1-failed", nil
+		},
+	}
+	failedService := &failedSubmissionService{db: db, now: func() time.Time { return now }}
+
+	assert.Nil(t, failedService.Retry(context.Background(), "c1-failed"), "failed submissions should be retried")
+	assert.Equal(t, now, db.RequeueFailedQueuedSubmissionCalls()[0].Now, "retried submissions should be due now")
+	assert.Nil(t, failedService.Delete(context.Background(), "c1-failed"), "failed submissions should be deleted")
+
+	var gatewayErr gatewayerrors.GatewayError
+	if assert.ErrorAs(t, failedService.Retry(context.Background(), "c1-queued"), &gatewayErr) {
+		assert.Equal(t, http.StatusNotFound, gatewayErr.Status, "submissions that aren't FAILED should not be retried")
+	}
+	if assert.ErrorAs(t, failedService.Delete(context.Background(), "c1-queued"), &gatewayErr) {
+		assert.Equal(t, http.StatusNotFound, gatewayErr.Status, "submissions that aren't FAILED should not be deleted")
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+)
+
+// Built in GatewayId generators, see the domain functions they name
+const (
+	GatewayIdGeneratorUUIDv7    = "uuidv7"
+	GatewayIdGeneratorUUIDv4    = "uuidv4"
+	GatewayIdGeneratorSnowflake = "snowflake"
+)
+
+var (
+	gatewayIdGeneratorsLock sync.RWMutex
+	gatewayIdGenerators     = map[string]GatewayIdGenerator{
+		GatewayIdGeneratorUUIDv7:    domain.NewId,
+		GatewayIdGeneratorUUIDv4:    domain.NewUUIDv4Id,
+		GatewayIdGeneratorSnowflake: domain.NewSnowflakeId,
+	}
+)
+
+// RegisterGatewayIdGenerator makes a GatewayIdGenerator available as `gateway.gatewayIdGenerator` in config, for
+// org-specific GatewayId schemes. GatewayIds must keep the 'cluster-namespace-id' format the cluster and namespace of
+// applications are parsed from. It is intended to be called from init functions and panics if name is already
+// registered.
+func RegisterGatewayIdGenerator(name string, generator GatewayIdGenerator) {
+	gatewayIdGeneratorsLock.Lock()
+	defer gatewayIdGeneratorsLock.Unlock()
+
+	if _, ok := gatewayIdGenerators[name]; ok {
+		panic(fmt.Sprintf("GatewayId generator '%s' is already registered", name))
+	}
+	gatewayIdGenerators[name] = generator
+}
+
+// GatewayIdGeneratorNames returns the registered GatewayId generator names, sorted.
+func GatewayIdGeneratorNames() []string {
+	gatewayIdGeneratorsLock.RLock()
+	defer gatewayIdGeneratorsLock.RUnlock()
+
+	names := make([]string, 0, len(gatewayIdGenerators))
+	for name := range gatewayIdGenerators {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// NewGatewayIdGenerator returns the GatewayIdGenerator registered as name, checking every GatewayId it generates with
+// domain.ValidateGatewayId. A GatewayId is generated for every namespace of clusters first, so generators producing
+// GatewayIds the Gateway can't parse fail at startup rather than on submission.
+func NewGatewayIdGenerator(name string, clusters []domain.KubeCluster) (GatewayIdGenerator, error) {
+	gatewayIdGeneratorsLock.RLock()
+	generator, ok := gatewayIdGenerators[name]
+	gatewayIdGeneratorsLock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown GatewayId generator '%s', registered generators: %v", name, GatewayIdGeneratorNames())
+	}
+
+	validatingGenerator := ValidatingGatewayIdGenerator(generator)
+	for _, cluster := range clusters {
+		for _, namespace := range cluster.Namespaces {
+			if _, err := validatingGenerator(cluster, namespace.Name); err != nil {
+				return nil, fmt.Errorf("GatewayId generator '%s' is unusable: %w", name, err)
+			}
+		}
+	}
+
+	return validatingGenerator, nil
+}
+
+// ValidatingGatewayIdGenerator wraps generator so GatewayIds failing domain.ValidateGatewayId are returned as errors
+// instead of being submitted
+func ValidatingGatewayIdGenerator(generator GatewayIdGenerator) GatewayIdGenerator {
+	return func(cluster domain.KubeCluster, namespace string) (string, error) {
+		gatewayId, err := generator(cluster, namespace)
+		if err != nil {
+			return "", err
+		}
+
+		if err := domain.ValidateGatewayId(gatewayId, cluster, namespace); err != nil {
+			return "", fmt.Errorf("generated invalid GatewayId: %w", err)
+		}
+
+		return gatewayId, nil
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+)
+
+func TestNewGatewayIdGenerator(t *testing.T) {
+	clusters := []domain.KubeCluster{testCluster}
+
+	generator, err := NewGatewayIdGenerator(GatewayIdGeneratorSnowflake, clusters)
+	assert.NoError(t, err, "built in generators should be usable")
+	gatewayId, err := generator(testCluster, "testNamespace")
+	assert.NoError(t, err)
+	assert.Regexp(t, `^id-nsid-[0-9]+$`, gatewayId, "snowflake GatewayIds should end with a number")
+
+	_, err = NewGatewayIdGenerator("ksuid", clusters)
+	assert.ErrorContains(t, err, "unknown GatewayId generator 'ksuid'", "unregistered generators should error")
+
+	RegisterGatewayIdGenerator("test-unparseable", func(cluster domain.KubeCluster, namespace string) (string, error) {
+		return "unparseable", nil
+	})
+	assert.Contains(t, GatewayIdGeneratorNames(), "test-unparseable", "registered generators should be listed")
+	_, err = NewGatewayIdGenerator("test-unparseable", clusters)
+	assert.ErrorContains(t, err, "is unusable", "generators of GatewayIds the Gateway can't parse should be rejected at startup")
+
+	assert.Panics(t, func() {
+		RegisterGatewayIdGenerator(GatewayIdGeneratorUUIDv7, domain.NewId)
+	}, "registering a name twice should panic")
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+//go:generate moq -rm  -out mockkillswitchservice.go . KillSwitchService
+
+type KillSwitchService interface {
+	Engage(ctx context.Context, namespace string, user string, request domain.KillSwitchRequest) (*domain.NamespaceKillSwitch, error)
+	Release(ctx context.Context, namespace string) error
+	List() []domain.NamespaceKillSwitch
+	Check(namespace string) error
+}
+
+type killSwitchService struct {
+	*service
+	db       database.NamespaceKillSwitchDatabase
+	mu       sync.RWMutex
+	switches map[string]domain.NamespaceKillSwitch
+}
+
+// NewKillSwitchService returns a KillSwitchService checking namespace kill switches held in memory. With a db, kill
+// switches are persisted in it and Refresh reloads those engaged by every Gateway instance; with a nil db they are only
+// held by this instance. Running GatewayApplications are suspended through gatewayAppRepo.
+func NewKillSwitchService(gatewayAppRepo GatewayApplicationRepository, clusterRepository repository.ClusterRepository, db database.NamespaceKillSwitchDatabase) *killSwitchService {
+	return &killSwitchService{
+		service: &service{
+			gatewayAppRepo:    gatewayAppRepo,
+			clusterRepository: clusterRepository,
+		},
+		db:       db,
+		switches: map[string]domain.NamespaceKillSwitch{},
+	}
+}
+
+// Engage rejects new GatewayApplications submitted to namespace until the kill switch is released. Engaging an engaged
+// kill switch replaces its message. With SuspendRunning, the executors of every running GatewayApplication in the
+// namespace are scaled to zero; their drivers keep running and are not scaled back up when the kill switch is released.
+func (s *killSwitchService) Engage(ctx context.Context, namespace string, user string, request domain.KillSwitchRequest) (*domain.NamespaceKillSwitch, error) {
+	clusters := s.clusterRepository.GetAllWithNamespace(namespace)
+	if len(clusters) == 0 {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("namespace '%s' is not configured in any cluster", namespace))
+	}
+
+	message := request.Message
+	if message == "" {
+		message = "submissions have been disabled by a Spark Gateway admin"
+	}
+	killSwitch := domain.NamespaceKillSwitch{
+		Namespace: namespace,
+		Message:   message,
+		EngagedBy: user,
+		EngagedAt: time.Now().UTC(),
+	}
+
+	if s.db != nil {
+		if err := s.db.UpsertNamespaceKillSwitch(ctx, database.NamespaceKillSwitch{
+			Namespace: killSwitch.Namespace,
+			Message:   killSwitch.Message,
+			EngagedBy: killSwitch.EngagedBy,
+			EngagedAt: killSwitch.EngagedAt,
+		}); err != nil {
+			return nil, fmt.Errorf("error persisting kill switch: %w", err)
+		}
+	}
+
+	s.mu.Lock()
+	s.switches[namespace] = killSwitch
+	s.mu.Unlock()
+	klog.Warningf("Kill switch engaged for namespace '%s' by '%s': %s", namespace, user, message)
+
+	if request.SuspendRunning {
+		killSwitch.Suspended = s.suspendRunning(ctx, clusters, namespace)
+	}
+
+	return &killSwitch, nil
+}
+
+// suspendRunning scales the executors of the running GatewayApplications in namespace to zero, returning the outcome
+// for each application
+func (s *killSwitchService) suspendRunning(ctx context.Context, clusters []domain.KubeCluster, namespace string) []domain.SuspendResult {
+	results := []domain.SuspendResult{}
+	for _, cluster := range clusters {
+		summaries, err := s.gatewayAppRepo.List(ctx, cluster, namespace, domain.SummaryViewSlim)
+		if err != nil {
+			results = append(results, domain.SuspendResult{Cluster: cluster.Name, Error: fmt.Sprintf("error listing GatewayApplications to suspend: %v", err)})
+			continue
+		}
+
+		for _, summary := range summaries {
+			if summary.Status.AppState.State != v1beta2.ApplicationStateRunning {
+				continue
+			}
+
+			result := domain.SuspendResult{GatewayId: summary.Name, Cluster: cluster.Name}
+			if err := s.suspend(ctx, cluster, namespace, summary.Name); err != nil {
+				result.Error = err.Error()
+				klog.Errorf("error suspending GatewayApplication '%s' in cluster %s: %v", summary.Name, cluster.Name, err)
+			}
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
+func (s *killSwitchService) suspend(ctx context.Context, cluster domain.KubeCluster, namespace string, gatewayId string) error {
+	sparkApp, err := s.gatewayAppRepo.Get(ctx, cluster, namespace, gatewayId)
+	if err != nil {
+		return fmt.Errorf("error getting GatewayApplication '%s' to suspend: %w", gatewayId, err)
+	}
+
+	if _, err := s.gatewayAppRepo.Scale(ctx, cluster, namespace, gatewayId, domain.NewExecutorScale(sparkApp.Spec, 0)); err != nil {
+		return fmt.Errorf("error scaling GatewayApplication '%s' to zero executors: %w", gatewayId, err)
+	}
+
+	return nil
+}
+
+// Release accepts submissions to namespace again
+func (s *killSwitchService) Release(ctx context.Context, namespace string) error {
+	// The kill switch may have been engaged by another Gateway instance since the last Refresh
+	var deleted bool
+	if s.db != nil {
+		var err error
+		if deleted, err = s.db.DeleteNamespaceKillSwitch(ctx, namespace); err != nil {
+			return fmt.Errorf("error releasing persisted kill switch: %w", err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.switches[namespace]; !ok && !deleted {
+		return gatewayerrors.NewNotFound(fmt.Errorf("no kill switch is engaged for namespace '%s'", namespace))
+	}
+	delete(s.switches, namespace)
+	klog.Infof("Kill switch released for namespace '%s'", namespace)
+
+	return nil
+}
+
+// Refresh replaces the kill switches held in memory with the persisted ones, including those engaged and released by
+// other Gateway instances
+func (s *killSwitchService) Refresh(ctx context.Context) error {
+	if s.db == nil {
+		return nil
+	}
+
+	persisted, err := s.db.ListNamespaceKillSwitches(ctx)
+	if err != nil {
+		return err
+	}
+
+	switches := make(map[string]domain.NamespaceKillSwitch, len(persisted))
+	for _, killSwitch := range persisted {
+		switches[killSwitch.Namespace] = domain.NamespaceKillSwitch{
+			Namespace: killSwitch.Namespace,
+			Message:   killSwitch.Message,
+			EngagedBy: killSwitch.EngagedBy,
+			EngagedAt: killSwitch.EngagedAt,
+		}
+	}
+
+	s.mu.Lock()
+	s.switches = switches
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Run calls Refresh every interval until ctx is done
+func (s *killSwitchService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil {
+				klog.Errorf("error refreshing kill switches: %v", err)
+			}
+		}
+	}
+}
+
+// List returns the engaged kill switches ordered by namespace
+func (s *killSwitchService) List() []domain.NamespaceKillSwitch {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	killSwitches := make([]domain.NamespaceKillSwitch, 0, len(s.switches))
+	for _, killSwitch := range s.switches {
+		killSwitches = append(killSwitches, killSwitch)
+	}
+	slices.SortFunc(killSwitches, func(a, b domain.NamespaceKillSwitch) int {
+		return strings.Compare(a.Namespace, b.Namespace)
+	})
+
+	return killSwitches
+}
+
+// Check returns a Forbidden GatewayError with the kill switch message if a kill switch is engaged for namespace
+func (s *killSwitchService) Check(namespace string) error {
+	s.mu.RLock()
+	killSwitch, ok := s.switches[namespace]
+	s.mu.RUnlock()
+
+	if ok {
+		return gatewayerrors.NewForbidden(fmt.Errorf("namespace '%s' is not accepting new applications: %s", namespace, killSwitch.Message))
+	}
+
+	return nil
+}
+
+// killSwitchApplicationService rejects GatewayApplications submitted to namespaces with an engaged kill switch before
+// they reach the wrapped GatewayApplicationService
+type killSwitchApplicationService struct {
+	GatewayApplicationService
+	killSwitches KillSwitchService
+}
+
+// NewKillSwitchApplicationService wraps appService so Create checks killSwitches first
+func NewKillSwitchApplicationService(appService GatewayApplicationService, killSwitches KillSwitchService) GatewayApplicationService {
+	return &killSwitchApplicationService{GatewayApplicationService: appService, killSwitches: killSwitches}
+}
+
+func (s *killSwitchApplicationService) Create(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error) {
+	if err := s.killSwitches.Check(application.Namespace); err != nil {
+		return nil, err
+	}
+
+	return s.GatewayApplicationService.Create(ctx, application, user)
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+func TestKillSwitchServiceEngage(t *testing.T) {
+	clusterRepo := newMigrationTestClusterRepo(t)
+
+	summary := func(gatewayId string, state v1beta2.ApplicationStateType) *domain.SparkManagerSparkApplicationSummary {
+		return &domain.SparkManagerSparkApplicationSummary{
+			GatewayApplicationMeta: domain.GatewayApplicationMeta{Name: gatewayId, Namespace: "ns"},
+			Status:                 v1beta2.SparkApplicationStatus{AppState: v1beta2.ApplicationState{State: state}},
+		}
+	}
+	dynamicApp := migrationTestSparkApp("a-ns-running")
+	dynamicApp.Spec.DynamicAllocation = &v1beta2.DynamicAllocation{Enabled: true}
+
+	var scales []domain.ExecutorScale
+	repo := &GatewayApplicationRepositoryMock{
+		ListFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, view domain.SummaryView) ([]*domain.SparkManagerSparkApplicationSummary, error) {
+			if cluster.Name != "cluster-a" {
+				return []*domain.SparkManagerSparkApplicationSummary{}, nil
+			}
+			return []*domain.SparkManagerSparkApplicationSummary{
+				summary("a-ns-running", v1beta2.ApplicationStateRunning),
+				summary("a-ns-completed", v1beta2.ApplicationStateCompleted),
+			}, nil
+		},
+		GetFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplication, error) {
+			return dynamicApp, nil
+		},
+		ScaleFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, scale domain.ExecutorScale) (*v1beta2.SparkApplication, error) {
+			scales = append(scales, scale)
+			return dynamicApp, nil
+		},
+	}
+	killSwitches := NewKillSwitchService(repo, clusterRepo, nil)
+
+	assert.NoError(t, killSwitches.Check("ns"), "namespace should accept submissions before engaging")
+
+	killSwitch, err := killSwitches.Engage(context.Background(), "ns", "admin", domain.KillSwitchRequest{Message: "incident 123", SuspendRunning: true})
+	assert.NoError(t, err, "engaging should not error")
+	assert.Equal(t, "admin", killSwitch.EngagedBy, "engaging user should be recorded")
+	assert.Equal(t, []domain.SuspendResult{{GatewayId: "a-ns-running", Cluster: "cluster-a"}}, killSwitch.Suspended, "only the running application should be suspended")
+	assert.Len(t, scales, 1, "one application should be scaled")
+	assert.Equal(t, int32(0), *scales[0].MaxExecutors, "dynamic allocation application should have maxExecutors scaled to zero")
+
+	err = killSwitches.Check("ns")
+	assert.Equal(t, http.StatusForbidden, gatewayerrors.NewFrom(err).Status, "submissions should be forbidden")
+	assert.Contains(t, err.Error(), "incident 123", "rejection should carry the message")
+	assert.Len(t, killSwitches.List(), 1, "kill switch should be listed")
+	assert.Empty(t, killSwitches.List()[0].Suspended, "listed kill switch should not hold suspend results")
+
+	assert.NoError(t, killSwitches.Release(context.Background(), "ns"), "releasing should not error")
+	assert.NoError(t, killSwitches.Check("ns"), "namespace should accept submissions after release")
+	assert.Equal(t, http.StatusNotFound, gatewayerrors.NewFrom(killSwitches.Release(context.Background(), "ns")).Status, "releasing twice should not be found")
+
+	_, err = killSwitches.Engage(context.Background(), "missing", "admin", domain.KillSwitchRequest{})
+	assert.Equal(t, http.StatusNotFound, gatewayerrors.NewFrom(err).Status, "unconfigured namespace should not be found")
+}
+
+func TestKillSwitchServicePersisted(t *testing.T) {
+	persisted := map[string]database.NamespaceKillSwitch{}
+	db := &database.NamespaceKillSwitchDatabaseMock{
+		UpsertNamespaceKillSwitchFunc: func(ctx context.Context, killSwitch database.NamespaceKillSwitch) error {
+			persisted[killSwitch.Namespace] = killSwitch
+			return nil
+		},
+		ListNamespaceKillSwitchesFunc: func(ctx context.Context) ([]database.NamespaceKillSwitch, error) {
+			var killSwitches []database.NamespaceKillSwitch
+			for _, killSwitch := range persisted {
+				killSwitches = append(killSwitches, killSwitch)
+			}
+			return killSwitches, nil
+		},
+		DeleteNamespaceKillSwitchFunc: func(ctx context.Context, namespace string) (bool, error) {
+			_, ok := persisted[namespace]
+			delete(persisted, namespace)
+			return ok, nil
+		},
+	}
+	// Two Gateway instances sharing the database
+	killSwitches := NewKillSwitchService(&GatewayApplicationRepositoryMock{}, newMigrationTestClusterRepo(t), db)
+	other := NewKillSwitchService(&GatewayApplicationRepositoryMock{}, newMigrationTestClusterRepo(t), db)
+
+	_, err := killSwitches.Engage(context.Background(), "ns", "admin", domain.KillSwitchRequest{Message: "incident 123"})
+	assert.NoError(t, err, "engaging should not error")
+	assert.Equal(t, "incident 123", persisted["ns"].Message, "kill switch should be persisted")
+	assert.Equal(t, http.StatusForbidden, gatewayerrors.NewFrom(killSwitches.Check("ns")).Status, "engaging instance should reject submissions")
+
+	assert.NoError(t, other.Check("ns"), "other instance should accept submissions before it refreshes")
+	assert.NoError(t, other.Refresh(context.Background()), "refreshing should not error")
+	assert.Equal(t, http.StatusForbidden, gatewayerrors.NewFrom(other.Check("ns")).Status, "other instance should reject submissions after it refreshes")
+	assert.Equal(t, "admin", other.List()[0].EngagedBy, "refreshed kill switch should be listed")
+
+	assert.NoError(t, other.Release(context.Background(), "ns"), "releasing from the other instance should not error")
+	assert.Empty(t, persisted, "persisted kill switch should be deleted")
+	assert.NoError(t, other.Check("ns"), "releasing instance should accept submissions")
+	assert.NoError(t, killSwitches.Refresh(context.Background()), "refreshing should not error")
+	assert.NoError(t, killSwitches.Check("ns"), "engaging instance should accept submissions after it refreshes")
+	assert.Equal(t, http.StatusNotFound, gatewayerrors.NewFrom(killSwitches.Release(context.Background(), "ns")).Status, "releasing twice should not be found")
+
+	// Engaged by another instance since the last Refresh
+	persisted["ns"] = database.NamespaceKillSwitch{Namespace: "ns", Message: "incident 456"}
+	assert.NoError(t, killSwitches.Release(context.Background(), "ns"), "kill switch engaged elsewhere should be released")
+	assert.Empty(t, persisted, "persisted kill switch should be deleted")
+
+	db.UpsertNamespaceKillSwitchFunc = func(ctx context.Context, killSwitch database.NamespaceKillSwitch) error {
+		return errors.New("database unavailable")
+	}
+	_, err = killSwitches.Engage(context.Background(), "ns", "admin", domain.KillSwitchRequest{})
+	assert.ErrorContains(t, err, "database unavailable", "persisting error should be returned")
+	assert.NoError(t, killSwitches.Check("ns"), "kill switch that wasn't persisted should not be engaged")
+}
+
+func TestKillSwitchApplicationServiceCreate(t *testing.T) {
+	killSwitches := NewKillSwitchService(&GatewayApplicationRepositoryMock{}, newMigrationTestClusterRepo(t), nil)
+	appService := &GatewayApplicationServiceMock{
+		CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error) {
+			return &domain.GatewayApplication{}, nil
+		},
+	}
+	guarded := NewKillSwitchApplicationService(appService, killSwitches)
+
+	_, err := killSwitches.Engage(context.Background(), "ns", "admin", domain.KillSwitchRequest{})
+	assert.NoError(t, err, "engaging should not error")
+
+	_, err = guarded.Create(context.Background(), migrationTestSparkApp("a-ns-new"), "alice")
+	assert.Equal(t, http.StatusForbidden, gatewayerrors.NewFrom(err).Status, "submission to a killed namespace should be forbidden")
+
+	other := migrationTestSparkApp("c-other-new")
+	other.Namespace = "other"
+	_, err = guarded.Create(context.Background(), other, "alice")
+	assert.NoError(t, err, "submission to another namespace should be created")
+	assert.Len(t, appService.CreateCalls(), 1, "only the other namespace submission should reach the service")
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/metrics"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+)
+
+// metricsLivyDatabase records the latency and result of every query of a LivyApplicationDatabase, so the batch id
+// database Livy clients poll through can be told apart from the rest of the request
+type metricsLivyDatabase struct {
+	database database.LivyApplicationDatabase
+}
+
+// NewMetricsLivyDatabase returns a LivyApplicationDatabase recording the queries of db in metrics.LivyDatabaseDuration
+func NewMetricsLivyDatabase(db database.LivyApplicationDatabase) database.LivyApplicationDatabase {
+	return &metricsLivyDatabase{database: db}
+}
+
+// observeLivyQuery records a query of operation started at start that returned err
+func observeLivyQuery(operation string, start time.Time, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	metrics.LivyDatabaseDuration.WithLabelValues(operation, result).Observe(time.Since(start).Seconds())
+}
+
+func (m *metricsLivyDatabase) GetByBatchId(ctx context.Context, batchId int) (database.LivyApplication, error) {
+	start := time.Now()
+	livyApp, err := m.database.GetByBatchId(ctx, batchId)
+	observeLivyQuery("getByBatchId", start, err)
+	return livyApp, err
+}
+
+func (m *metricsLivyDatabase) ListFrom(ctx context.Context, fromId int, size int) ([]database.LivyApplication, error) {
+	start := time.Now()
+	livyApps, err := m.database.ListFrom(ctx, fromId, size)
+	observeLivyQuery("listFrom", start, err)
+	return livyApps, err
+}
+
+func (m *metricsLivyDatabase) InsertLivyApplication(ctx context.Context, gatewayId string) (database.LivyApplication, error) {
+	start := time.Now()
+	livyApp, err := m.database.InsertLivyApplication(ctx, gatewayId)
+	observeLivyQuery("insert", start, err)
+	return livyApp, err
+}
+
+func (m *metricsLivyDatabase) LivyApplicationExists(ctx context.Context, gatewayId string) (bool, error) {
+	start := time.Now()
+	exists, err := m.database.LivyApplicationExists(ctx, gatewayId)
+	observeLivyQuery("exists", start, err)
+	return exists, err
+}
+
+func (m *metricsLivyDatabase) SetLivyApplicationTerminalBatch(ctx context.Context, batchId int, batch *domain.LivyBatch) error {
+	start := time.Now()
+	err := m.database.SetLivyApplicationTerminalBatch(ctx, batchId, batch)
+	observeLivyQuery("setTerminalBatch", start, err)
+	return err
+}
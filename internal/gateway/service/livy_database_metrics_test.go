@@ -0,0 +1,52 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/gateway/metrics"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+)
+
+func TestMetricsLivyDatabase(t *testing.T) {
+	db := &database.LivyApplicationDatabaseMock{
+		GetByBatchIdFunc: func(ctx context.Context, batchId int) (database.LivyApplication, error) {
+			if batchId == 0 {
+				return database.LivyApplication{}, errors.New("database error")
+			}
+			return database.LivyApplication{BatchID: int64(batchId)}, nil
+		},
+	}
+	metricsDB := NewMetricsLivyDatabase(db)
+
+	before := testutil.CollectAndCount(metrics.LivyDatabaseDuration)
+
+	livyApp, err := metricsDB.GetByBatchId(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), livyApp.BatchID, "results should be passed through")
+
+	_, err = metricsDB.GetByBatchId(context.Background(), 0)
+	assert.EqualError(t, err, "database error", "errors should be passed through")
+
+	assert.Len(t, db.GetByBatchIdCalls(), 2, "queries should reach the database")
+	assert.Equal(t, before+2, testutil.CollectAndCount(metrics.LivyDatabaseDuration), "queries should be observed by result")
+}
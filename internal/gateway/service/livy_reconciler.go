@@ -0,0 +1,111 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/metrics"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+)
+
+// LivyReconciler periodically removes Livy GatewayApplications that have no matching livy_applications row. These
+// are left behind when the database insert in Create fails and the compensating delete could not be completed.
+type LivyReconciler struct {
+	appService        GatewayApplicationService
+	database          database.LivyApplicationDatabase
+	clusterRepository repository.ClusterRepository
+	config            config.LivyReconcilerConfig
+	now               func() time.Time
+}
+
+func NewLivyReconciler(appService GatewayApplicationService, database database.LivyApplicationDatabase, clusterRepository repository.ClusterRepository, config config.LivyReconcilerConfig) *LivyReconciler {
+	return &LivyReconciler{
+		appService:        appService,
+		database:          database,
+		clusterRepository: clusterRepository,
+		config:            config,
+		now:               time.Now,
+	}
+}
+
+// Run calls Reconcile every config.Interval until ctx is done
+func (r *LivyReconciler) Run(ctx context.Context) {
+	klog.Infof("Starting Livy reconciler with interval %s and grace period %s", r.config.Interval, r.config.GracePeriod)
+
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			klog.Infof("Stopping Livy reconciler")
+			return
+		case <-ticker.C:
+			if err := r.Reconcile(ctx); err != nil {
+				klog.Errorf("error reconciling Livy applications: %v", err)
+			}
+		}
+	}
+}
+
+// Reconcile deletes Livy GatewayApplications older than config.GracePeriod that aren't tracked in the database. The
+// grace period avoids racing a Create that has submitted the SparkApplication but not yet inserted its row.
+func (r *LivyReconciler) Reconcile(ctx context.Context) error {
+	var errs []error
+	cutoff := r.now().Add(-r.config.GracePeriod)
+
+	for _, cluster := range r.clusterRepository.GetAll() {
+		appSummaries, err := r.appService.List(ctx, cluster.Name, "", domain.SummaryViewSlim, domain.ListSort{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error listing applications in cluster '%s': %w", cluster.Name, err))
+			continue
+		}
+
+		for _, appSummary := range appSummaries {
+			if appSummary.Labels[domain.LIVY_APPLICATION_LABEL] != "true" || !appSummary.CreationTimestamp.Time.Before(cutoff) {
+				continue
+			}
+
+			exists, err := r.database.LivyApplicationExists(ctx, appSummary.GatewayId)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("error checking Livy application '%s': %w", appSummary.GatewayId, err))
+				continue
+			}
+			if exists {
+				continue
+			}
+
+			klog.Infof("Deleting orphaned Livy GatewayApplication '%s'", appSummary.GatewayId)
+			if err := r.appService.Delete(ctx, appSummary.GatewayId); err != nil {
+				metrics.LivyOrphansTotal.WithLabelValues("failure").Inc()
+				errs = append(errs, fmt.Errorf("error deleting orphaned Livy application '%s': %w", appSummary.GatewayId, err))
+				continue
+			}
+			metrics.LivyOrphansTotal.WithLabelValues("deleted").Inc()
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+)
+
+func livySummary(gatewayId string, livy bool, created time.Time) *domain.GatewayApplicationSummary {
+	labels := map[string]string{}
+	if livy {
+		labels[domain.LIVY_APPLICATION_LABEL] = "true"
+	}
+	return &domain.GatewayApplicationSummary{
+		SparkManagerSparkApplicationSummary: domain.SparkManagerSparkApplicationSummary{
+			GatewayApplicationMeta: domain.GatewayApplicationMeta{
+				Name:              gatewayId,
+				Labels:            labels,
+				CreationTimestamp: metav1.NewTime(created),
+			},
+		},
+		GatewayId: gatewayId,
+	}
+}
+
+func TestLivyReconcilerReconcile(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-time.Hour)
+
+	testCases := []struct {
+		name            string
+		summaries       []*domain.GatewayApplicationSummary
+		tracked         map[string]bool
+		deleteErr       error
+		expectedDeletes []string
+		expectErr       bool
+	}{
+		{
+			name:            "deletes untracked Livy application",
+			summaries:       []*domain.GatewayApplicationSummary{livySummary("orphan", true, old)},
+			tracked:         map[string]bool{},
+			expectedDeletes: []string{"orphan"},
+		},
+		{
+			name:            "keeps tracked Livy application",
+			summaries:       []*domain.GatewayApplicationSummary{livySummary("tracked", true, old)},
+			tracked:         map[string]bool{"tracked": true},
+			expectedDeletes: nil,
+		},
+		{
+			name:            "ignores non Livy application",
+			summaries:       []*domain.GatewayApplicationSummary{livySummary("gateway", false, old)},
+			tracked:         map[string]bool{},
+			expectedDeletes: nil,
+		},
+		{
+			name:            "ignores application within grace period",
+			summaries:       []*domain.GatewayApplicationSummary{livySummary("new", true, now.Add(-time.Minute))},
+			tracked:         map[string]bool{},
+			expectedDeletes: nil,
+		},
+		{
+			name:            "returns delete errors",
+			summaries:       []*domain.GatewayApplicationSummary{livySummary("orphan", true, old)},
+			tracked:         map[string]bool{},
+			deleteErr:       errors.New("unavailable"),
+			expectedDeletes: []string{"orphan"},
+			expectErr:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var deleted []string
+			mockAppService := &GatewayApplicationServiceMock{
+				ListFunc: func(ctx context.Context, cluster string, namespace string, view domain.SummaryView, listSort domain.ListSort) ([]*domain.GatewayApplicationSummary, error) {
+					assert.Equal(t, "cluster", cluster, "cluster should be passed to List")
+					assert.Equal(t, "", namespace, "all namespaces should be listed")
+					return tc.summaries, nil
+				},
+				DeleteFunc: func(ctx context.Context, gatewayId string) error {
+					deleted = append(deleted, gatewayId)
+					return tc.deleteErr
+				},
+			}
+			mockDatabase := &database.LivyApplicationDatabaseMock{
+				LivyApplicationExistsFunc: func(ctx context.Context, gatewayId string) (bool, error) {
+					return tc.tracked[gatewayId], nil
+				},
+			}
+			mockClusterRepo := &repository.ClusterRepositoryMock{
+				GetAllFunc: func() []domain.KubeCluster {
+					return []domain.KubeCluster{{Name: "cluster"}}
+				},
+			}
+
+			reconciler := NewLivyReconciler(mockAppService, mockDatabase, mockClusterRepo, config.LivyReconcilerConfig{
+				Interval:    time.Minute,
+				GracePeriod: 10 * time.Minute,
+			})
+			reconciler.now = func() time.Time { return now }
+
+			err := reconciler.Reconcile(context.Background())
+
+			if tc.expectErr {
+				assert.Error(t, err, "Reconcile should return error")
+			} else {
+				assert.NoError(t, err, "Reconcile should not return error")
+			}
+			assert.Equal(t, tc.expectedDeletes, deleted, "unexpected deleted applications")
+		})
+	}
+}
+
+func TestLivyReconcilerReconcileListError(t *testing.T) {
+	mockAppService := &GatewayApplicationServiceMock{
+		ListFunc: func(ctx context.Context, cluster string, namespace string, view domain.SummaryView, listSort domain.ListSort) ([]*domain.GatewayApplicationSummary, error) {
+			return nil, errors.New("unavailable")
+		},
+	}
+	mockClusterRepo := &repository.ClusterRepositoryMock{
+		GetAllFunc: func() []domain.KubeCluster {
+			return []domain.KubeCluster{{Name: "cluster"}}
+		},
+	}
+
+	reconciler := NewLivyReconciler(mockAppService, &database.LivyApplicationDatabaseMock{}, mockClusterRepo, config.LivyReconcilerConfig{})
+
+	err := reconciler.Reconcile(context.Background())
+
+	assert.ErrorContains(t, err, "error listing applications in cluster 'cluster'", "List error should be returned")
+}
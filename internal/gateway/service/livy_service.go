@@ -2,10 +2,17 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
 
 	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/metrics"
 	"github.com/slackhq/spark-gateway/internal/shared/database"
 	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
 )
@@ -17,19 +24,43 @@ func wrapLivyError(err error, message string) error {
 
 //go:generate moq -rm  -out mocklivyapplicationservice.go . LivyApplicationService
 
+// LivySubmitter is the authenticated identity submitting a Livy batch. The batch's proxyUser is resolved against User by
+// the namespace's proxyUser policy, it is never trusted as the submitting user.
+type LivySubmitter struct {
+	User       string
+	ActingUser string
+	Team       string
+}
+
 type LivyApplicationService interface {
 	Get(ctx context.Context, batchId int) (*domain.LivyBatch, error)
 	List(ctx context.Context, from int, size int) ([]*domain.LivyBatch, error)
-	Create(ctx context.Context, createReq domain.LivyCreateBatchRequest, namespace string) (*domain.LivyBatch, error)
+	Create(ctx context.Context, createReq domain.LivyCreateBatchRequest, namespace string, submitter LivySubmitter) (*domain.LivyBatch, error)
 	Delete(ctx context.Context, batchId int) error
 	Logs(ctx context.Context, batchId int, size int) ([]string, error)
 }
 
+// livyCompensationBackoff is the backoff used when retrying the cleanup delete of a GatewayApplication whose Livy batch
+// could not be recorded
+var livyCompensationBackoff = wait.Backoff{
+	Steps:    4,
+	Duration: 200 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
+// legacyLivySuccessState is the state successful batches were reported with before it matched Apache Livy's success
+const legacyLivySuccessState = "finished"
+
+// livyCompensationTimeout bounds the total time spent on cleanup deletes, which run detached from the request context
+const livyCompensationTimeout = 30 * time.Second
+
 type livyService struct {
-	appService   GatewayApplicationService
-	database     database.LivyApplicationDatabase
-	namespace    string
-	urlTemplates domain.StatusUrlTemplates
+	appService          GatewayApplicationService
+	database            database.LivyApplicationDatabase
+	namespace           string
+	urlTemplates        domain.StatusUrlTemplates
+	compensationBackoff wait.Backoff
 }
 
 // getLivyAppByBatchId retrieves a LivyApplication from the database by batchId
@@ -43,10 +74,11 @@ func (l *livyService) getLivyAppByBatchId(ctx context.Context, batchId int) (*da
 
 func NewLivyService(appService GatewayApplicationService, database database.LivyApplicationDatabase, namespace string, urlTemplates domain.StatusUrlTemplates) *livyService {
 	return &livyService{
-		appService: appService,
-		database:   database,
-		namespace:  namespace,
-		urlTemplates: urlTemplates,
+		appService:          appService,
+		database:            database,
+		namespace:           namespace,
+		urlTemplates:        urlTemplates,
+		compensationBackoff: livyCompensationBackoff,
 	}
 }
 
@@ -57,13 +89,41 @@ func (l *livyService) Get(ctx context.Context, batchId int) (*domain.LivyBatch,
 		return nil, err
 	}
 
-	gotApp, err := l.appService.Get(ctx, livyApp.GatewayID)
+	livyBatch, err := l.batch(ctx, *livyApp)
 	if err != nil {
 		return nil, wrapLivyError(err, "error getting GatewayApplication")
 	}
+	return livyBatch, nil
+}
+
+// batch returns the LivyBatch of livyApp. Notebooks keep polling batches long after they finish, so the batch of a
+// terminal application is persisted the first time it is read and served from the database from then on.
+func (l *livyService) batch(ctx context.Context, livyApp database.LivyApplication) (*domain.LivyBatch, error) {
+	if livyApp.TerminalBatch != nil {
+		// Batches persisted before states matched Apache Livy's report successful batches as finished
+		if livyApp.TerminalBatch.State == legacyLivySuccessState {
+			livyApp.TerminalBatch.State = domain.LivySessionStateSuccess.String()
+		}
+		return livyApp.TerminalBatch, nil
+	}
+
+	gotApp, err := l.appService.Get(ctx, livyApp.GatewayID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Set log URLs
-	urls := GetRenderedURLs(l.urlTemplates, &gotApp.SparkApplication)
-	return gotApp.ToLivyBatch(int32(livyApp.BatchID), urls), nil
+	// Livy batches have no field for warnings, GetRenderedURLs logs them
+	urls, _ := GetRenderedURLs(l.urlTemplates, &gotApp.SparkApplication)
+	livyBatch := gotApp.ToLivyBatch(int32(livyApp.BatchID), urls)
+
+	if domain.IsTerminalApplicationState(gotApp.SparkApplication.Status.AppState.State) {
+		if err := l.database.SetLivyApplicationTerminalBatch(ctx, int(livyApp.BatchID), livyBatch); err != nil {
+			klog.Warningf("error persisting terminal Livy batch %d, it will be read from SparkManager again: %v", livyApp.BatchID, err)
+		}
+	}
+
+	return livyBatch, nil
 }
 
 func (l *livyService) List(ctx context.Context, from int, size int) ([]*domain.LivyBatch, error) {
@@ -75,14 +135,10 @@ func (l *livyService) List(ctx context.Context, from int, size int) ([]*domain.L
 
 	var retApps []*domain.LivyBatch
 	for _, livyApp := range livyApps {
-		gotApp, err := l.appService.Get(ctx, livyApp.GatewayID)
+		livyBatch, err := l.batch(ctx, livyApp)
 		if err != nil {
 			return nil, wrapLivyError(err, "error listing Livy GatewayApplications")
 		}
-
-		// Set log URLs
-		urls := GetRenderedURLs(l.urlTemplates, &gotApp.SparkApplication)
-		livyBatch := gotApp.ToLivyBatch(int32(livyApp.BatchID), urls)
 		retApps = append(retApps, livyBatch)
 	}
 
@@ -90,7 +146,7 @@ func (l *livyService) List(ctx context.Context, from int, size int) ([]*domain.L
 
 }
 
-func (l *livyService) Create(ctx context.Context, createReq domain.LivyCreateBatchRequest, namespace string) (*domain.LivyBatch, error) {
+func (l *livyService) Create(ctx context.Context, createReq domain.LivyCreateBatchRequest, namespace string, submitter LivySubmitter) (*domain.LivyBatch, error) {
 	// Determine the target namespace
 	ns := namespace
 	if ns == "" {
@@ -100,28 +156,74 @@ func (l *livyService) Create(ctx context.Context, createReq domain.LivyCreateBat
 	// Convert Livy request to SparkApplication
 	application := createReq.ToV1Beta2SparkApplication(ns)
 
+	// The acting user annotation and team label are only set from the authenticated submitter
+	if submitter.ActingUser != "" {
+		if application.Annotations == nil {
+			application.Annotations = map[string]string{}
+		}
+		application.Annotations[domain.GATEWAY_ACTING_USER_ANNOTATION] = submitter.ActingUser
+	}
+	if submitter.Team != "" {
+		if application.Labels == nil {
+			application.Labels = map[string]string{}
+		}
+		application.Labels[domain.GATEWAY_TEAM_LABEL] = submitter.Team
+	}
+
 	// Create the SparkApplication in Kubernetes
-	gatewayApp, err := l.appService.Create(ctx, application, *application.Spec.ProxyUser)
+	gatewayApp, err := l.appService.Create(ctx, application, submitter.User)
 	if err != nil {
+		metrics.LivyBatchCreationFailuresTotal.WithLabelValues("submission").Inc()
 		return nil, wrapLivyError(err, "error creating Livy GatewayApplication")
 	}
 
 	// Track the application in the database
 	livyApp, err := l.database.InsertLivyApplication(ctx, gatewayApp.GatewayId)
 	if err != nil {
+		metrics.LivyBatchCreationFailuresTotal.WithLabelValues("database").Inc()
 		// Cleanup the K8s resource on database failure
-		if deleteErr := l.appService.Delete(ctx, gatewayApp.GatewayId); deleteErr != nil {
+		if deleteErr := l.compensateCreate(ctx, gatewayApp.GatewayId); deleteErr != nil {
+			klog.Errorf("failed cleanup of Livy GatewayApplication '%s', it will be removed by the Livy reconciler: %v", gatewayApp.GatewayId, deleteErr)
 			return nil, wrapLivyError(err, fmt.Sprintf("error tracking Livy application '%s' and failed cleanup", gatewayApp.GatewayId))
 		}
 		return nil, wrapLivyError(err, fmt.Sprintf("error tracking Livy application '%s' in database", gatewayApp.GatewayId))
 	}
 
 	// Set log URLs
-	urls := GetRenderedURLs(l.urlTemplates, &gatewayApp.SparkApplication)
+	urls, _ := GetRenderedURLs(l.urlTemplates, &gatewayApp.SparkApplication)
 
 	return gatewayApp.ToLivyBatch(int32(livyApp.BatchID), urls), nil
 }
 
+// compensateCreate deletes a GatewayApplication whose Livy batch could not be recorded, retrying with
+// compensationBackoff. The delete runs detached from ctx so a client disconnecting doesn't abandon the cleanup.
+func (l *livyService) compensateCreate(ctx context.Context, gatewayId string) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), livyCompensationTimeout)
+	defer cancel()
+
+	var deleteErr error
+	err := wait.ExponentialBackoffWithContext(ctx, l.compensationBackoff, func(ctx context.Context) (bool, error) {
+		deleteErr = l.appService.Delete(ctx, gatewayId)
+		var gatewayErr gatewayerrors.GatewayError
+		if deleteErr == nil || (errors.As(deleteErr, &gatewayErr) && gatewayErr.Status == http.StatusNotFound) {
+			return true, nil
+		}
+		klog.Warningf("error deleting Livy GatewayApplication '%s' during cleanup, retrying: %v", gatewayId, deleteErr)
+		return false, nil
+	})
+
+	if err != nil {
+		metrics.LivyCompensationTotal.WithLabelValues("failure").Inc()
+		if deleteErr != nil {
+			return deleteErr
+		}
+		return err
+	}
+
+	metrics.LivyCompensationTotal.WithLabelValues("success").Inc()
+	return nil
+}
+
 func (l *livyService) Delete(ctx context.Context, batchId int) error {
 
 	livyApp, err := l.getLivyAppByBatchId(ctx, batchId)
@@ -133,6 +235,13 @@ func (l *livyService) Delete(ctx context.Context, batchId int) error {
 		return wrapLivyError(err, "error deleting Livy GatewayApplication")
 	}
 
+	// Deleted batches are read from SparkManager again so they are no longer found
+	if livyApp.TerminalBatch != nil {
+		if err := l.database.SetLivyApplicationTerminalBatch(ctx, batchId, nil); err != nil {
+			return wrapLivyError(err, "error clearing the persisted state of deleted Livy GatewayApplication")
+		}
+	}
+
 	return nil
 }
 
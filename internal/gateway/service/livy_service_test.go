@@ -4,13 +4,17 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/metrics"
 	"github.com/slackhq/spark-gateway/internal/shared/database"
 	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
 	"github.com/slackhq/spark-gateway/internal/shared/util"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 func TestLivyService_Get_Success(t *testing.T) {
@@ -62,6 +66,85 @@ func TestLivyService_Get_Success(t *testing.T) {
 	assert.Equal(t, int32(batchId), result.Id)
 }
 
+func TestLivyService_Get_PersistsTerminalBatch(t *testing.T) {
+	ctx := context.Background()
+	batchId := 123
+
+	livyApp := database.LivyApplication{
+		BatchID:   int64(batchId),
+		GatewayID: "clusterid-nsid-uuid",
+	}
+
+	state := v1beta2.ApplicationStateRunning
+	mockDatabase := &database.LivyApplicationDatabaseMock{
+		GetByBatchIdFunc: func(ctx context.Context, batchId int) (database.LivyApplication, error) {
+			return livyApp, nil
+		},
+		SetLivyApplicationTerminalBatchFunc: func(ctx context.Context, batchId int, batch *domain.LivyBatch) error {
+			livyApp.TerminalBatch = batch
+			return nil
+		},
+	}
+
+	mockAppService := &GatewayApplicationServiceMock{
+		GetFunc: func(ctx context.Context, gatewayId string) (*domain.GatewayApplication, error) {
+			return &domain.GatewayApplication{
+				GatewayId: gatewayId,
+				SparkApplication: domain.GatewaySparkApplication{
+					Status: v1beta2.SparkApplicationStatus{AppState: v1beta2.ApplicationState{State: state}},
+				},
+			}, nil
+		},
+	}
+
+	service := NewLivyService(mockAppService, mockDatabase, "default", domain.StatusUrlTemplates{})
+
+	// Running batches are read from SparkManager every time
+	result, err := service.Get(ctx, batchId)
+	assert.NoError(t, err)
+	assert.Equal(t, "running", result.State)
+	assert.Empty(t, mockDatabase.SetLivyApplicationTerminalBatchCalls(), "running batches should not be persisted")
+
+	// The batch is persisted the first time it is read as terminal
+	state = v1beta2.ApplicationStateCompleted
+	result, err = service.Get(ctx, batchId)
+	assert.NoError(t, err)
+	assert.Equal(t, "success", result.State)
+	assert.Len(t, mockDatabase.SetLivyApplicationTerminalBatchCalls(), 1, "terminal batches should be persisted")
+
+	// Then served from the database
+	result, err = service.Get(ctx, batchId)
+	assert.NoError(t, err)
+	assert.Equal(t, "success", result.State)
+	assert.Len(t, mockAppService.GetCalls(), 2, "persisted batches should not be read from SparkManager")
+
+	// Deleting the batch clears its persisted state
+	mockAppService.DeleteFunc = func(ctx context.Context, gatewayId string) error {
+		return nil
+	}
+	assert.NoError(t, service.Delete(ctx, batchId))
+	assert.Len(t, mockDatabase.SetLivyApplicationTerminalBatchCalls(), 2, "deleting should clear the persisted batch")
+	assert.Nil(t, livyApp.TerminalBatch, "the persisted batch should be cleared")
+}
+
+func TestLivyService_Get_LegacyTerminalBatch(t *testing.T) {
+	mockDatabase := &database.LivyApplicationDatabaseMock{
+		GetByBatchIdFunc: func(ctx context.Context, batchId int) (database.LivyApplication, error) {
+			return database.LivyApplication{
+				BatchID:       int64(batchId),
+				GatewayID:     "clusterid-nsid-uuid",
+				TerminalBatch: &domain.LivyBatch{Id: int32(batchId), State: "finished"},
+			}, nil
+		},
+	}
+
+	service := NewLivyService(&GatewayApplicationServiceMock{}, mockDatabase, "default", domain.StatusUrlTemplates{})
+
+	result, err := service.Get(context.Background(), 123)
+	assert.NoError(t, err)
+	assert.Equal(t, "success", result.State, "batches persisted as finished should be reported as success")
+}
+
 func TestLivyService_Get_DatabaseError(t *testing.T) {
 	ctx := context.Background()
 	batchId := 123
@@ -123,11 +206,14 @@ func TestLivyService_Create_Success(t *testing.T) {
 	}
 
 	mockAppService := &GatewayApplicationServiceMock{
-		CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication, proxyUser string) (*domain.GatewayApplication, error) {
+		CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error) {
 			// Verify the application was created correctly
 			assert.Equal(t, "test-job", application.Name)
 			assert.Equal(t, "default", application.Namespace)
-			assert.Equal(t, "testuser", *application.Spec.ProxyUser)
+			assert.Equal(t, "submitter", user, "the authenticated user should submit the batch")
+			assert.Equal(t, "testuser", *application.Spec.ProxyUser, "the requested proxyUser should be left for the proxyUser policy")
+			assert.Equal(t, "principal", application.Annotations[domain.GATEWAY_ACTING_USER_ANNOTATION])
+			assert.Equal(t, "team", application.Labels[domain.GATEWAY_TEAM_LABEL])
 			assert.Equal(t, domain.DEFAULT_SPARK_MODE, application.Spec.Mode)
 			assert.Equal(t, domain.DEFAULT_SPARK_VERSION, application.Spec.SparkVersion)
 			return gatewayApp, nil
@@ -138,7 +224,7 @@ func TestLivyService_Create_Success(t *testing.T) {
 	service := NewLivyService(mockAppService, mockDatabase, "default", domain.StatusUrlTemplates{})
 
 	// Test
-	result, err := service.Create(ctx, createReq, "")
+	result, err := service.Create(ctx, createReq, "", LivySubmitter{User: "submitter", ActingUser: "principal", Team: "team"})
 
 	// Assertions
 	assert.NoError(t, err)
@@ -190,17 +276,115 @@ func TestLivyService_Create_DatabaseError_WithCleanup(t *testing.T) {
 
 	// Create service
 	service := NewLivyService(mockAppService, mockDatabase, "default", domain.StatusUrlTemplates{})
+	failuresBefore := testutil.ToFloat64(metrics.LivyBatchCreationFailuresTotal.WithLabelValues("database"))
 
 	// Test
-	result, err := service.Create(ctx, createReq, "")
+	result, err := service.Create(ctx, createReq, "", LivySubmitter{User: "testuser"})
 
 	// Assertions
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.True(t, cleanupCalled, "cleanup should have been called")
+	assert.Equal(t, failuresBefore+1, testutil.ToFloat64(metrics.LivyBatchCreationFailuresTotal.WithLabelValues("database")), "database failures should be counted")
 	assert.Contains(t, err.Error(), "error tracking Livy application 'clusterid-nsid-uuid' in database")
 }
 
+func TestLivyService_CompensateCreate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		deleteErrs    []error
+		expectErr     bool
+		expectedCalls int
+	}{
+		{
+			name:          "succeeds first attempt",
+			deleteErrs:    []error{nil},
+			expectErr:     false,
+			expectedCalls: 1,
+		},
+		{
+			name:          "succeeds after transient failures",
+			deleteErrs:    []error{errors.New("unavailable"), errors.New("unavailable"), nil},
+			expectErr:     false,
+			expectedCalls: 3,
+		},
+		{
+			name:          "not found treated as deleted",
+			deleteErrs:    []error{gatewayerrors.NewNotFound(errors.New("not found"))},
+			expectErr:     false,
+			expectedCalls: 1,
+		},
+		{
+			name:          "fails after exhausting attempts",
+			deleteErrs:    []error{errors.New("unavailable"), errors.New("unavailable"), errors.New("unavailable")},
+			expectErr:     true,
+			expectedCalls: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			mockAppService := &GatewayApplicationServiceMock{
+				DeleteFunc: func(ctx context.Context, gatewayId string) error {
+					err := tc.deleteErrs[calls]
+					calls++
+					return err
+				},
+			}
+
+			service := NewLivyService(mockAppService, &database.LivyApplicationDatabaseMock{}, "default", domain.StatusUrlTemplates{})
+			service.compensationBackoff = wait.Backoff{Steps: 3, Duration: time.Millisecond}
+
+			// A cancelled request context must not abandon the cleanup
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			err := service.compensateCreate(ctx, "clusterid-nsid-uuid")
+
+			if tc.expectErr {
+				assert.Error(t, err, "compensateCreate should return error")
+			} else {
+				assert.NoError(t, err, "compensateCreate should not return error")
+			}
+			assert.Equal(t, tc.expectedCalls, calls, "unexpected number of delete attempts")
+		})
+	}
+}
+
+func TestLivyService_Create_DatabaseError_FailedCleanup(t *testing.T) {
+	createReq := domain.LivyCreateBatchRequest{
+		File:      "test.jar",
+		ProxyUser: "testuser",
+		Name:      "test-job",
+	}
+
+	mockDatabase := &database.LivyApplicationDatabaseMock{
+		InsertLivyApplicationFunc: func(ctx context.Context, gatewayId string) (database.LivyApplication, error) {
+			return database.LivyApplication{}, errors.New("database error")
+		},
+	}
+
+	mockAppService := &GatewayApplicationServiceMock{
+		CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication, proxyUser string) (*domain.GatewayApplication, error) {
+			return &domain.GatewayApplication{GatewayId: "clusterid-nsid-uuid"}, nil
+		},
+		DeleteFunc: func(ctx context.Context, gatewayId string) error {
+			return errors.New("unavailable")
+		},
+	}
+
+	service := NewLivyService(mockAppService, mockDatabase, "default", domain.StatusUrlTemplates{})
+	service.compensationBackoff = wait.Backoff{Steps: 2, Duration: time.Millisecond}
+
+	result, err := service.Create(context.Background(), createReq, "", LivySubmitter{User: "testuser"})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Len(t, mockAppService.DeleteCalls(), 2, "cleanup should be retried")
+	assert.Contains(t, err.Error(), "error tracking Livy application 'clusterid-nsid-uuid' and failed cleanup")
+}
+
 func TestLivyService_Delete_Success(t *testing.T) {
 	ctx := context.Background()
 	batchId := 123
@@ -388,7 +572,7 @@ func TestLivyService_NamespaceResolution(t *testing.T) {
 			service := NewLivyService(mockAppService, mockDatabase, tt.serviceNamespace, domain.StatusUrlTemplates{})
 
 			// Test
-			_, err := service.Create(ctx, createReq, tt.requestNamespace)
+			_, err := service.Create(ctx, createReq, tt.requestNamespace, LivySubmitter{User: "testuser"})
 
 			// Assertions
 			assert.NoError(t, err)
@@ -454,11 +638,11 @@ func TestLivyService_Create_FullMapping(t *testing.T) {
 	}
 
 	mockAppService := &GatewayApplicationServiceMock{
-		CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication, proxyUser string) (*domain.GatewayApplication, error) {
+		CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error) {
 			// Verify complete mapping of all fields
 			assert.Equal(t, "complex-spark-job", application.Name)
 			assert.Equal(t, "custom-namespace", application.Namespace)
-			assert.Equal(t, "dataeng-user", proxyUser)
+			assert.Equal(t, "dataeng-user", user)
 			assert.Equal(t, "dataeng-user", *application.Spec.ProxyUser)
 
 			// Verify application type detection (Java for .jar file)
@@ -520,7 +704,7 @@ func TestLivyService_Create_FullMapping(t *testing.T) {
 	service := NewLivyService(mockAppService, mockDatabase, "default", domain.StatusUrlTemplates{})
 
 	// Test
-	result, err := service.Create(ctx, createReq, "custom-namespace")
+	result, err := service.Create(ctx, createReq, "custom-namespace", LivySubmitter{User: "dataeng-user"})
 
 	// Assertions
 	assert.NoError(t, err)
@@ -0,0 +1,240 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+//go:generate moq -rm  -out mockmigrationservice.go . MigrationService
+
+type MigrationService interface {
+	Migrate(ctx context.Context, gatewayId string, targetCluster string) (*domain.GatewayApplication, error)
+	MigrateNamespace(ctx context.Context, sourceCluster string, namespace string, targetCluster string) ([]domain.MigrationResult, error)
+}
+
+type migrationService struct {
+	*service
+	db  database.QueuedSubmissionDatabase
+	now func() time.Time
+}
+
+// NewMigrationService returns a MigrationService moving GatewayApplications between clusters. Resubmitted applications
+// are created the same way as through a GatewayApplicationService created with the same arguments. Resubmissions that
+// fail are recorded as FAILED submissions in db, unless it is nil.
+func NewMigrationService(
+	gatewayAppRepo GatewayApplicationRepository,
+	clusterRepository repository.ClusterRepository,
+	config config.GatewayConfig,
+	selectorKey string,
+	selectorValue string,
+	gatewayIdGen GatewayIdGenerator,
+	db database.QueuedSubmissionDatabase,
+) MigrationService {
+	return &migrationService{
+		service: &service{
+			gatewayAppRepo:    gatewayAppRepo,
+			clusterRepository: clusterRepository,
+			config:            config,
+			selectorKey:       selectorKey,
+			selectorValue:     selectorValue,
+			gatewayIdGen:      gatewayIdGen,
+		},
+		db:  db,
+		now: time.Now,
+	}
+}
+
+// Migrate deletes the GatewayApplication from its cluster and resubmits its spec to targetCluster under a new
+// GatewayId, annotated with the GatewayIds it was migrated from. The application is deleted before it is resubmitted
+// so the two never run at the same time, once its pods have been rendered in targetCluster to check it accepts them.
+func (s *migrationService) Migrate(ctx context.Context, gatewayId string, targetCluster string) (*domain.GatewayApplication, error) {
+	cluster, namespace, err := s.GetClusterNamespaceFromGatewayId(gatewayId)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.getTargetCluster(*cluster, namespace, targetCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.migrate(ctx, *cluster, namespace, gatewayId, *target)
+}
+
+// MigrateNamespace migrates every GatewayApplication in namespace on sourceCluster that hasn't completed or failed to
+// targetCluster, for evacuating a cluster. Applications are migrated one at a time and a failure doesn't stop the
+// others, so the outcome of each is returned.
+func (s *migrationService) MigrateNamespace(ctx context.Context, sourceCluster string, namespace string, targetCluster string) ([]domain.MigrationResult, error) {
+	cluster, err := s.clusterRepository.GetByName(sourceCluster)
+	if err != nil {
+		return nil, gatewayerrors.NewNotFound(err)
+	}
+	if _, err := cluster.GetNamespaceByName(namespace); err != nil {
+		return nil, gatewayerrors.NewNotFound(err)
+	}
+
+	target, err := s.getTargetCluster(*cluster, namespace, targetCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := s.gatewayAppRepo.List(ctx, *cluster, namespace, domain.SummaryViewSlim)
+	if err != nil {
+		return nil, fmt.Errorf("error listing GatewayApplications to migrate: %w", err)
+	}
+
+	results := []domain.MigrationResult{}
+	for _, summary := range summaries {
+		switch summary.Status.AppState.State {
+		case v1beta2.ApplicationStateCompleted, v1beta2.ApplicationStateFailed:
+			continue
+		}
+
+		result := domain.MigrationResult{GatewayId: summary.Name, Cluster: target.Name}
+		migrated, err := s.migrate(ctx, *cluster, namespace, summary.Name, *target)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.NewGatewayId = migrated.GatewayId
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (s *migrationService) getTargetCluster(source domain.KubeCluster, namespace string, targetCluster string) (*domain.KubeCluster, error) {
+	target, err := s.clusterRepository.GetByName(targetCluster)
+	if err != nil {
+		return nil, gatewayerrors.NewBadRequest(fmt.Errorf("error getting migration target cluster: %w", err))
+	}
+	if target.ClusterId == source.ClusterId {
+		return nil, gatewayerrors.NewBadRequest(fmt.Errorf("migration target cluster '%s' is the application's cluster", targetCluster))
+	}
+	if _, err := target.GetNamespaceByName(namespace); err != nil {
+		return nil, gatewayerrors.NewBadRequest(fmt.Errorf("migration target cluster '%s' does not have namespace '%s'", targetCluster, namespace))
+	}
+	return target, nil
+}
+
+func (s *migrationService) migrate(ctx context.Context, source domain.KubeCluster, namespace string, gatewayId string, target domain.KubeCluster) (*domain.GatewayApplication, error) {
+	sparkApp, err := s.gatewayAppRepo.Get(ctx, source, namespace, gatewayId)
+	if err != nil {
+		return nil, fmt.Errorf("error getting GatewayApplication '%s' to migrate: %w", gatewayId, err)
+	}
+
+	resubmission := domain.NewMigrationSparkApplication(sparkApp)
+	user := sparkApp.Labels[domain.GATEWAY_USER_LABEL]
+
+	// Check the target accepts the resubmission before deleting the application, so a spec it rejects isn't lost
+	if err := s.checkResubmission(ctx, resubmission, user, target, gatewayId); err != nil {
+		return nil, gatewayerrors.New(gatewayerrors.NewFrom(err).Status, fmt.Errorf("GatewayApplication '%s' was not migrated, cluster '%s' would not accept it: %w", gatewayId, target.Name, err))
+	}
+
+	if err := s.gatewayAppRepo.Delete(ctx, source, namespace, gatewayId); err != nil {
+		return nil, fmt.Errorf("error deleting GatewayApplication '%s' to migrate: %w", gatewayId, err)
+	}
+
+	migrated, err := s.createInCluster(ctx, resubmission, user, target, domain.WithMigratedFrom(gatewayId))
+	if err != nil {
+		migrateErr := fmt.Errorf("GatewayApplication '%s' was deleted from cluster '%s' but could not be resubmitted to cluster '%s': %w", gatewayId, source.Name, target.Name, err)
+		if failedId := s.recordFailedResubmission(ctx, resubmission, user, target, gatewayId, migrateErr); failedId != "" {
+			migrateErr = fmt.Errorf("%w, it was recorded as failed submission '%s'", migrateErr, failedId)
+		}
+		return nil, gatewayerrors.New(gatewayerrors.NewFrom(err).Status, migrateErr)
+	}
+
+	klog.Infof("Migrated GatewayApplication '%s' from cluster '%s' to '%s' as '%s'", gatewayId, source.Name, target.Name, migrated.GatewayId)
+	return migrated, nil
+}
+
+// checkResubmission validates resubmission as Create would and renders its pods in target without creating it
+func (s *migrationService) checkResubmission(ctx context.Context, resubmission *v1beta2.SparkApplication, user string, target domain.KubeCluster, gatewayId string) error {
+	if err := domain.NewValidationError(domain.ValidateApplicationNames(resubmission)); err != nil {
+		return gatewayerrors.NewInvalid(fmt.Errorf("invalid GatewayApplication names: %w", err))
+	}
+
+	sparkApp, err := s.buildResubmission(resubmission, user, target, gatewayId)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.gatewayAppRepo.RenderPods(ctx, target, sparkApp); err != nil {
+		return fmt.Errorf("error rendering pods of GatewayApplication '%s/%s': %w", sparkApp.Namespace, sparkApp.Name, err)
+	}
+
+	return nil
+}
+
+// recordFailedResubmission records resubmission as a FAILED submission to target failed with err, so it can be
+// edited and retried through the failed submissions admin API. Returns its GatewayId, or "" if it wasn't recorded.
+func (s *migrationService) recordFailedResubmission(ctx context.Context, resubmission *v1beta2.SparkApplication, user string, target domain.KubeCluster, gatewayId string, err error) string {
+	if s.db == nil {
+		return ""
+	}
+
+	sparkApp, buildErr := s.buildResubmission(resubmission, user, target, gatewayId)
+	if buildErr != nil {
+		klog.Errorf("error recording failed resubmission of migrated GatewayApplication '%s': %v", gatewayId, buildErr)
+		return ""
+	}
+
+	lastError := err.Error()
+	if insertErr := s.db.InsertFailedQueuedSubmission(ctx, database.QueuedSubmission{
+		GatewayID:   sparkApp.Name,
+		Cluster:     target.Name,
+		Namespace:   sparkApp.Namespace,
+		Username:    user,
+		Application: sparkApp,
+		LastError:   &lastError,
+		CreatedAt:   s.now().UTC(),
+	}); insertErr != nil {
+		klog.Errorf("error recording failed resubmission of migrated GatewayApplication '%s': %v", gatewayId, insertErr)
+		return ""
+	}
+
+	klog.Warningf("Recorded failed resubmission of migrated GatewayApplication '%s' as '%s'", gatewayId, sparkApp.Name)
+	return sparkApp.Name
+}
+
+// buildResubmission returns resubmission as it is created in target under a new GatewayId by createInCluster
+func (s *migrationService) buildResubmission(resubmission *v1beta2.SparkApplication, user string, target domain.KubeCluster, gatewayId string) (*v1beta2.SparkApplication, error) {
+	newGatewayId, err := s.gatewayIdGen(target, resubmission.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("error generating GatewayId for GatewayApplication: %w", err)
+	}
+
+	gaOpts, err := s.gatewayOptions(resubmission, user, target)
+	if err != nil {
+		return nil, err
+	}
+	gaOpts = append(gaOpts, domain.WithId(newGatewayId), domain.WithAuxiliaryConfigMapReferences(), domain.WithMigratedFrom(gatewayId), domain.WithSpecHash())
+
+	// Build from a copy since the options set the labels and annotations of the application they are applied to
+	return domain.NewGatewaySparkApplication(resubmission.DeepCopy(), gaOpts...).ToV1Beta2SparkApplication(), nil
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+)
+
+func newMigrationTestClusterRepo(t *testing.T) repository.ClusterRepository {
+	clusterRepo, err := repository.NewLocalClusterRepo([]domain.KubeCluster{
+		{Name: "cluster-a", ClusterId: "a", Namespaces: []domain.KubeNamespace{{Name: "ns", NamespaceId: "ns"}}},
+		{Name: "cluster-b", ClusterId: "b", Namespaces: []domain.KubeNamespace{{Name: "ns", NamespaceId: "ns"}}},
+		{Name: "cluster-c", ClusterId: "c", Namespaces: []domain.KubeNamespace{{Name: "other", NamespaceId: "other"}}},
+	})
+	assert.NoError(t, err, "creating cluster repository should not error")
+	return clusterRepo
+}
+
+func migrationTestSparkApp(gatewayId string) *v1beta2.SparkApplication {
+	return &v1beta2.SparkApplication{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      gatewayId,
+			Namespace: "ns",
+			Labels: map[string]string{
+				domain.GATEWAY_USER_LABEL:    "alice",
+				domain.GATEWAY_CLUSTER_LABEL: "cluster-a",
+				"team":                       "data",
+			},
+			Annotations: map[string]string{
+				domain.GATEWAY_APPLICATION_NAME_ANNOTATION: "etl",
+				domain.GATEWAY_SPEC_HASH_ANNOTATION:        "hash",
+			},
+		},
+		Spec:   v1beta2.SparkApplicationSpec{MainApplicationFile: util.Ptr("local:///app.jar")},
+		Status: v1beta2.SparkApplicationStatus{AppState: v1beta2.ApplicationState{State: v1beta2.ApplicationStateRunning}},
+	}
+}
+
+func TestMigrationServiceMigrate(t *testing.T) {
+	appRepo := &GatewayApplicationRepositoryMock{
+		GetFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplication, error) {
+			return migrationTestSparkApp(name), nil
+		},
+		DeleteFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) error {
+			return nil
+		},
+		CreateFunc: func(ctx context.Context, cluster domain.KubeCluster, sparkApp *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
+			return sparkApp, nil
+		},
+		RenderPodsFunc: func(ctx context.Context, cluster domain.KubeCluster, sparkApp *v1beta2.SparkApplication) (*domain.RenderedPods, error) {
+			return &domain.RenderedPods{Cluster: cluster.Name}, nil
+		},
+	}
+	gatewayIdGen := func(cluster domain.KubeCluster, namespace string) (string, error) {
+		return cluster.ClusterId + "-ns-new", nil
+	}
+	migrationService := NewMigrationService(appRepo, newMigrationTestClusterRepo(t), testGatewayConfig, "", "", gatewayIdGen, nil)
+
+	migrated, err := migrationService.Migrate(context.Background(), "a-ns-old", "cluster-b")
+	assert.NoError(t, err, "migration should not error")
+	assert.Equal(t, "b-ns-new", migrated.GatewayId, "migrated application should get a GatewayId in the target cluster")
+
+	assert.Len(t, appRepo.RenderPodsCalls(), 1, "resubmission should be checked before the application is deleted")
+	assert.Equal(t, "cluster-b", appRepo.RenderPodsCalls()[0].Cluster.Name, "resubmission should be checked in the target cluster")
+	assert.Len(t, appRepo.DeleteCalls(), 1, "source application should be deleted")
+	assert.Equal(t, "cluster-a", appRepo.DeleteCalls()[0].Cluster.Name, "application should be deleted from its cluster")
+
+	assert.Len(t, appRepo.CreateCalls(), 1, "application should be resubmitted")
+	created := appRepo.CreateCalls()[0]
+	assert.Equal(t, "cluster-b", created.Cluster.Name, "application should be resubmitted to the target cluster")
+	assert.Equal(t, "etl", created.Application.Annotations[domain.GATEWAY_APPLICATION_NAME_ANNOTATION], "submitted name should be preserved")
+	assert.Equal(t, "a-ns-old", created.Application.Annotations[domain.GATEWAY_MIGRATED_FROM_ANNOTATION], "previous GatewayId should be recorded")
+	assert.Equal(t, "a-ns-old", created.Application.Annotations[domain.GATEWAY_ORIGINAL_GATEWAY_ID_ANNOTATION], "original GatewayId should be recorded")
+	assert.Equal(t, "alice", created.Application.Labels[domain.GATEWAY_USER_LABEL], "submitting user should be preserved")
+	assert.Equal(t, "cluster-b", created.Application.Labels[domain.GATEWAY_CLUSTER_LABEL], "cluster label should be the target cluster")
+	assert.Equal(t, "data", created.Application.Labels["team"], "user labels should be preserved")
+	assert.Equal(t, "local:///app.jar", *created.Application.Spec.MainApplicationFile, "spec should be preserved")
+}
+
+func TestMigrationServiceMigrateErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		gatewayId      string
+		targetCluster  string
+		renderErr      error
+		createErr      error
+		expectedStatus int
+		expectDelete   bool
+	}{
+		{name: "same cluster", gatewayId: "a-ns-old", targetCluster: "cluster-a", expectedStatus: http.StatusBadRequest},
+		{name: "unknown target", gatewayId: "a-ns-old", targetCluster: "missing", expectedStatus: http.StatusBadRequest},
+		{name: "target without namespace", gatewayId: "a-ns-old", targetCluster: "cluster-c", expectedStatus: http.StatusBadRequest},
+		{name: "target rejects the spec", gatewayId: "a-ns-old", targetCluster: "cluster-b", renderErr: gatewayerrors.NewBadRequest(errors.New("invalid volume")), expectedStatus: http.StatusBadRequest},
+		{name: "resubmission fails", gatewayId: "a-ns-old", targetCluster: "cluster-b", createErr: gatewayerrors.NewForbidden(errors.New("forbidden")), expectedStatus: http.StatusForbidden, expectDelete: true},
+	}
+
+	for _, test := range tests {
+		appRepo := &GatewayApplicationRepositoryMock{
+			GetFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplication, error) {
+				return migrationTestSparkApp(name), nil
+			},
+			DeleteFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) error {
+				return nil
+			},
+			CreateFunc: func(ctx context.Context, cluster domain.KubeCluster, sparkApp *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
+				return nil, test.createErr
+			},
+			RenderPodsFunc: func(ctx context.Context, cluster domain.KubeCluster, sparkApp *v1beta2.SparkApplication) (*domain.RenderedPods, error) {
+				return &domain.RenderedPods{Cluster: cluster.Name}, test.renderErr
+			},
+		}
+		migrationService := NewMigrationService(appRepo, newMigrationTestClusterRepo(t), testGatewayConfig, "", "", GatewayIdGenerator_Success, nil)
+
+		_, err := migrationService.Migrate(context.Background(), test.gatewayId, test.targetCluster)
+		assert.Equal(t, test.expectedStatus, gatewayerrors.NewFrom(err).Status, "%s: status should match", test.name)
+		assert.Equal(t, test.expectDelete, len(appRepo.DeleteCalls()) == 1, "%s: deletes should match", test.name)
+	}
+}
+
+func TestMigrationServiceMigrateRecordsFailedResubmission(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	appRepo := &GatewayApplicationRepositoryMock{
+		GetFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplication, error) {
+			return migrationTestSparkApp(name), nil
+		},
+		DeleteFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) error {
+			return nil
+		},
+		CreateFunc: func(ctx context.Context, cluster domain.KubeCluster, sparkApp *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
+			return nil, gatewayerrors.NewForbidden(errors.New("forbidden"))
+		},
+		RenderPodsFunc: func(ctx context.Context, cluster domain.KubeCluster, sparkApp *v1beta2.SparkApplication) (*domain.RenderedPods, error) {
+			return &domain.RenderedPods{Cluster: cluster.Name}, nil
+		},
+	}
+	db := &database.QueuedSubmissionDatabaseMock{
+		InsertFailedQueuedSubmissionFunc: func(ctx context.Context, submission database.QueuedSubmission) error {
+			return nil
+		},
+	}
+	gatewayIdGen := func(cluster domain.KubeCluster, namespace string) (string, error) {
+		return cluster.ClusterId + "-ns-new", nil
+	}
+	migrationService := NewMigrationService(appRepo, newMigrationTestClusterRepo(t), testGatewayConfig, "", "", gatewayIdGen, db).(*migrationService)
+	migrationService.now = func() time.Time { return now }
+
+	_, err := migrationService.Migrate(context.Background(), "a-ns-old", "cluster-b")
+	assert.Equal(t, http.StatusForbidden, gatewayerrors.NewFrom(err).Status, "status should be the resubmission's")
+	assert.ErrorContains(t, err, "recorded as failed submission 'b-ns-new'", "error should name the recorded submission")
+
+	if !assert.Len(t, db.InsertFailedQueuedSubmissionCalls(), 1, "failed resubmission should be recorded") {
+		return
+	}
+	recorded := db.InsertFailedQueuedSubmissionCalls()[0].Submission
+	assert.Equal(t, "b-ns-new", recorded.GatewayID, "recorded submission should have a GatewayId in the target cluster")
+	assert.Equal(t, "cluster-b", recorded.Cluster, "recorded submission should be to the target cluster")
+	assert.Equal(t, "ns", recorded.Namespace, "recorded submission should be to the application's namespace")
+	assert.Equal(t, "alice", recorded.Username, "recorded submission should be the submitting user's")
+	assert.Equal(t, now, recorded.CreatedAt, "recorded submission should be created now")
+	assert.Contains(t, *recorded.LastError, "could not be resubmitted", "recorded submission should have the resubmission error")
+	assert.Equal(t, "b-ns-new", recorded.Application.Name, "recorded application should be named after its GatewayId")
+	assert.Equal(t, "etl", recorded.Application.Annotations[domain.GATEWAY_APPLICATION_NAME_ANNOTATION], "submitted name should be preserved")
+	assert.Equal(t, "a-ns-old", recorded.Application.Annotations[domain.GATEWAY_MIGRATED_FROM_ANNOTATION], "previous GatewayId should be recorded")
+	assert.Equal(t, "cluster-b", recorded.Application.Labels[domain.GATEWAY_CLUSTER_LABEL], "cluster label should be the target cluster")
+}
+
+func TestMigrationServiceMigrateNamespace(t *testing.T) {
+	appRepo := &GatewayApplicationRepositoryMock{
+		ListFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, view domain.SummaryView) ([]*domain.SparkManagerSparkApplicationSummary, error) {
+			running := domain.NewSparkManagerSparkApplicationSummary(migrationTestSparkApp("a-ns-running"))
+			failing := domain.NewSparkManagerSparkApplicationSummary(migrationTestSparkApp("a-ns-failing"))
+			completed := domain.NewSparkManagerSparkApplicationSummary(migrationTestSparkApp("a-ns-completed"))
+			completed.Status.AppState.State = v1beta2.ApplicationStateCompleted
+			return []*domain.SparkManagerSparkApplicationSummary{running, failing, completed}, nil
+		},
+		GetFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplication, error) {
+			if name == "a-ns-failing" {
+				return nil, gatewayerrors.NewNotFound(errors.New("not found"))
+			}
+			return migrationTestSparkApp(name), nil
+		},
+		DeleteFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) error {
+			return nil
+		},
+		CreateFunc: func(ctx context.Context, cluster domain.KubeCluster, sparkApp *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
+			return sparkApp, nil
+		},
+		RenderPodsFunc: func(ctx context.Context, cluster domain.KubeCluster, sparkApp *v1beta2.SparkApplication) (*domain.RenderedPods, error) {
+			return &domain.RenderedPods{Cluster: cluster.Name}, nil
+		},
+	}
+	gatewayIdGen := func(cluster domain.KubeCluster, namespace string) (string, error) {
+		return cluster.ClusterId + "-ns-new", nil
+	}
+	migrationService := NewMigrationService(appRepo, newMigrationTestClusterRepo(t), testGatewayConfig, "", "", gatewayIdGen, nil)
+
+	results, err := migrationService.MigrateNamespace(context.Background(), "cluster-a", "ns", "cluster-b")
+	assert.NoError(t, err, "namespace migration should not error")
+	assert.Len(t, results, 2, "completed applications should not be migrated")
+	assert.Equal(t, domain.MigrationResult{GatewayId: "a-ns-running", NewGatewayId: "b-ns-new", Cluster: "cluster-b"}, results[0], "running application should be migrated")
+	assert.Equal(t, "a-ns-failing", results[1].GatewayId, "failed migration should be reported")
+	assert.NotEmpty(t, results[1].Error, "failed migration should have an error")
+
+	_, err = migrationService.MigrateNamespace(context.Background(), "cluster-c", "ns", "cluster-b")
+	assert.Equal(t, http.StatusNotFound, gatewayerrors.NewFrom(err).Status, "namespace missing from the source cluster should not be found")
+}
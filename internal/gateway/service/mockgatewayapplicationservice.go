@@ -7,7 +7,9 @@ import (
 	"context"
 	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
 	"github.com/slackhq/spark-gateway/internal/domain"
+	"io"
 	"sync"
+	"time"
 )
 
 // Ensure, that GatewayApplicationServiceMock does implement GatewayApplicationService.
@@ -20,24 +22,69 @@ var _ GatewayApplicationService = &GatewayApplicationServiceMock{}
 //
 //		// make and configure a mocked GatewayApplicationService
 //		mockedGatewayApplicationService := &GatewayApplicationServiceMock{
+//			CountsFunc: func(ctx context.Context, cluster string, namespace string, groupBy []domain.CountGroupBy) ([]*domain.ApplicationCount, error) {
+//				panic("mock out the Counts method")
+//			},
 //			CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error) {
 //				panic("mock out the Create method")
 //			},
 //			DeleteFunc: func(ctx context.Context, gatewayId string) error {
 //				panic("mock out the Delete method")
 //			},
+//			DriverMetricsFunc: func(ctx context.Context, gatewayId string) (io.ReadCloser, error) {
+//				panic("mock out the DriverMetrics method")
+//			},
+//			ExecutorLogsFunc: func(ctx context.Context, gatewayId string, executorId string, tailLines int) (*string, error) {
+//				panic("mock out the ExecutorLogs method")
+//			},
+//			FollowLogsFunc: func(ctx context.Context, gatewayId string, tailLines int) (io.ReadCloser, error) {
+//				panic("mock out the FollowLogs method")
+//			},
 //			GetFunc: func(ctx context.Context, gatewayId string) (*domain.GatewayApplication, error) {
 //				panic("mock out the Get method")
 //			},
-//			ListFunc: func(ctx context.Context, cluster string, namespace string) ([]*domain.GatewayApplicationSummary, error) {
+//			GetClusterNamespaceFromGatewayIdFunc: func(gatewayId string) (*domain.KubeCluster, string, error) {
+//				panic("mock out the GetClusterNamespaceFromGatewayId method")
+//			},
+//			ListFunc: func(ctx context.Context, cluster string, namespace string, view domain.SummaryView, listSort domain.ListSort) ([]*domain.GatewayApplicationSummary, error) {
 //				panic("mock out the List method")
 //			},
 //			LogsFunc: func(ctx context.Context, gatewayId string, tailLines int) (*string, error) {
 //				panic("mock out the Logs method")
 //			},
-//			StatusFunc: func(ctx context.Context, gatewayId string) (*v1beta2.SparkApplicationStatus, error) {
+//			PodsFunc: func(ctx context.Context, gatewayId string) (*domain.ApplicationPods, error) {
+//				panic("mock out the Pods method")
+//			},
+//			RenderPodsFunc: func(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.RenderedPods, error) {
+//				panic("mock out the RenderPods method")
+//			},
+//			ResubmissionFunc: func(ctx context.Context, gatewayId string) (*v1beta2.SparkApplication, error) {
+//				panic("mock out the Resubmission method")
+//			},
+//			ScaleFunc: func(ctx context.Context, gatewayId string, scale domain.ExecutorScale) (*domain.GatewayApplication, error) {
+//				panic("mock out the Scale method")
+//			},
+//			StatusFunc: func(ctx context.Context, gatewayId string) (*domain.ApplicationStatus, error) {
 //				panic("mock out the Status method")
 //			},
+//			StreamLogsFunc: func(ctx context.Context, gatewayId string) (io.ReadCloser, error) {
+//				panic("mock out the StreamLogs method")
+//			},
+//			StreamStatusFunc: func(ctx context.Context, gatewayId string) (<-chan *domain.ApplicationStatus, error) {
+//				panic("mock out the StreamStatus method")
+//			},
+//			TimelineFunc: func(ctx context.Context, gatewayId string) (*domain.ApplicationTimeline, error) {
+//				panic("mock out the Timeline method")
+//			},
+//			UpdateFunc: func(ctx context.Context, gatewayId string, update domain.ApplicationUpdate) (*domain.GatewayApplication, error) {
+//				panic("mock out the Update method")
+//			},
+//			WaitStatusFunc: func(ctx context.Context, gatewayId string, lastState v1beta2.ApplicationStateType, timeout time.Duration) (*domain.GatewayApplicationWaitStatus, error) {
+//				panic("mock out the WaitStatus method")
+//			},
+//			WatchFunc: func(ctx context.Context, namespace string, labelSelector string, bookmark domain.WatchBookmark) (<-chan *domain.GatewayWatchEvent, error) {
+//				panic("mock out the Watch method")
+//			},
 //		}
 //
 //		// use mockedGatewayApplicationService in code that requires GatewayApplicationService
@@ -45,26 +92,82 @@ var _ GatewayApplicationService = &GatewayApplicationServiceMock{}
 //
 //	}
 type GatewayApplicationServiceMock struct {
+	// CountsFunc mocks the Counts method.
+	CountsFunc func(ctx context.Context, cluster string, namespace string, groupBy []domain.CountGroupBy) ([]*domain.ApplicationCount, error)
+
 	// CreateFunc mocks the Create method.
 	CreateFunc func(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error)
 
 	// DeleteFunc mocks the Delete method.
 	DeleteFunc func(ctx context.Context, gatewayId string) error
 
+	// DriverMetricsFunc mocks the DriverMetrics method.
+	DriverMetricsFunc func(ctx context.Context, gatewayId string) (io.ReadCloser, error)
+
+	// ExecutorLogsFunc mocks the ExecutorLogs method.
+	ExecutorLogsFunc func(ctx context.Context, gatewayId string, executorId string, tailLines int) (*string, error)
+
+	// FollowLogsFunc mocks the FollowLogs method.
+	FollowLogsFunc func(ctx context.Context, gatewayId string, tailLines int) (io.ReadCloser, error)
+
 	// GetFunc mocks the Get method.
 	GetFunc func(ctx context.Context, gatewayId string) (*domain.GatewayApplication, error)
 
+	// GetClusterNamespaceFromGatewayIdFunc mocks the GetClusterNamespaceFromGatewayId method.
+	GetClusterNamespaceFromGatewayIdFunc func(gatewayId string) (*domain.KubeCluster, string, error)
+
 	// ListFunc mocks the List method.
-	ListFunc func(ctx context.Context, cluster string, namespace string) ([]*domain.GatewayApplicationSummary, error)
+	ListFunc func(ctx context.Context, cluster string, namespace string, view domain.SummaryView, listSort domain.ListSort) ([]*domain.GatewayApplicationSummary, error)
 
 	// LogsFunc mocks the Logs method.
 	LogsFunc func(ctx context.Context, gatewayId string, tailLines int) (*string, error)
 
+	// PodsFunc mocks the Pods method.
+	PodsFunc func(ctx context.Context, gatewayId string) (*domain.ApplicationPods, error)
+
+	// RenderPodsFunc mocks the RenderPods method.
+	RenderPodsFunc func(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.RenderedPods, error)
+
+	// ResubmissionFunc mocks the Resubmission method.
+	ResubmissionFunc func(ctx context.Context, gatewayId string) (*v1beta2.SparkApplication, error)
+
+	// ScaleFunc mocks the Scale method.
+	ScaleFunc func(ctx context.Context, gatewayId string, scale domain.ExecutorScale) (*domain.GatewayApplication, error)
+
 	// StatusFunc mocks the Status method.
-	StatusFunc func(ctx context.Context, gatewayId string) (*v1beta2.SparkApplicationStatus, error)
+	StatusFunc func(ctx context.Context, gatewayId string) (*domain.ApplicationStatus, error)
+
+	// StreamLogsFunc mocks the StreamLogs method.
+	StreamLogsFunc func(ctx context.Context, gatewayId string) (io.ReadCloser, error)
+
+	// StreamStatusFunc mocks the StreamStatus method.
+	StreamStatusFunc func(ctx context.Context, gatewayId string) (<-chan *domain.ApplicationStatus, error)
+
+	// TimelineFunc mocks the Timeline method.
+	TimelineFunc func(ctx context.Context, gatewayId string) (*domain.ApplicationTimeline, error)
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, gatewayId string, update domain.ApplicationUpdate) (*domain.GatewayApplication, error)
+
+	// WaitStatusFunc mocks the WaitStatus method.
+	WaitStatusFunc func(ctx context.Context, gatewayId string, lastState v1beta2.ApplicationStateType, timeout time.Duration) (*domain.GatewayApplicationWaitStatus, error)
+
+	// WatchFunc mocks the Watch method.
+	WatchFunc func(ctx context.Context, namespace string, labelSelector string, bookmark domain.WatchBookmark) (<-chan *domain.GatewayWatchEvent, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// Counts holds details about calls to the Counts method.
+		Counts []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cluster is the cluster argument value.
+			Cluster string
+			// Namespace is the namespace argument value.
+			Namespace string
+			// GroupBy is the groupBy argument value.
+			GroupBy []domain.CountGroupBy
+		}
 		// Create holds details about calls to the Create method.
 		Create []struct {
 			// Ctx is the ctx argument value.
@@ -81,6 +184,33 @@ type GatewayApplicationServiceMock struct {
 			// GatewayId is the gatewayId argument value.
 			GatewayId string
 		}
+		// DriverMetrics holds details about calls to the DriverMetrics method.
+		DriverMetrics []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GatewayId is the gatewayId argument value.
+			GatewayId string
+		}
+		// ExecutorLogs holds details about calls to the ExecutorLogs method.
+		ExecutorLogs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GatewayId is the gatewayId argument value.
+			GatewayId string
+			// ExecutorId is the executorId argument value.
+			ExecutorId string
+			// TailLines is the tailLines argument value.
+			TailLines int
+		}
+		// FollowLogs holds details about calls to the FollowLogs method.
+		FollowLogs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GatewayId is the gatewayId argument value.
+			GatewayId string
+			// TailLines is the tailLines argument value.
+			TailLines int
+		}
 		// Get holds details about calls to the Get method.
 		Get []struct {
 			// Ctx is the ctx argument value.
@@ -88,6 +218,11 @@ type GatewayApplicationServiceMock struct {
 			// GatewayId is the gatewayId argument value.
 			GatewayId string
 		}
+		// GetClusterNamespaceFromGatewayId holds details about calls to the GetClusterNamespaceFromGatewayId method.
+		GetClusterNamespaceFromGatewayId []struct {
+			// GatewayId is the gatewayId argument value.
+			GatewayId string
+		}
 		// List holds details about calls to the List method.
 		List []struct {
 			// Ctx is the ctx argument value.
@@ -96,6 +231,10 @@ type GatewayApplicationServiceMock struct {
 			Cluster string
 			// Namespace is the namespace argument value.
 			Namespace string
+			// View is the view argument value.
+			View domain.SummaryView
+			// ListSort is the listSort argument value.
+			ListSort domain.ListSort
 		}
 		// Logs holds details about calls to the Logs method.
 		Logs []struct {
@@ -106,6 +245,38 @@ type GatewayApplicationServiceMock struct {
 			// TailLines is the tailLines argument value.
 			TailLines int
 		}
+		// Pods holds details about calls to the Pods method.
+		Pods []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GatewayId is the gatewayId argument value.
+			GatewayId string
+		}
+		// RenderPods holds details about calls to the RenderPods method.
+		RenderPods []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Application is the application argument value.
+			Application *v1beta2.SparkApplication
+			// User is the user argument value.
+			User string
+		}
+		// Resubmission holds details about calls to the Resubmission method.
+		Resubmission []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GatewayId is the gatewayId argument value.
+			GatewayId string
+		}
+		// Scale holds details about calls to the Scale method.
+		Scale []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GatewayId is the gatewayId argument value.
+			GatewayId string
+			// Scale is the scale argument value.
+			Scale domain.ExecutorScale
+		}
 		// Status holds details about calls to the Status method.
 		Status []struct {
 			// Ctx is the ctx argument value.
@@ -113,13 +284,124 @@ type GatewayApplicationServiceMock struct {
 			// GatewayId is the gatewayId argument value.
 			GatewayId string
 		}
+		// StreamLogs holds details about calls to the StreamLogs method.
+		StreamLogs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GatewayId is the gatewayId argument value.
+			GatewayId string
+		}
+		// StreamStatus holds details about calls to the StreamStatus method.
+		StreamStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GatewayId is the gatewayId argument value.
+			GatewayId string
+		}
+		// Timeline holds details about calls to the Timeline method.
+		Timeline []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GatewayId is the gatewayId argument value.
+			GatewayId string
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GatewayId is the gatewayId argument value.
+			GatewayId string
+			// Update is the update argument value.
+			Update domain.ApplicationUpdate
+		}
+		// WaitStatus holds details about calls to the WaitStatus method.
+		WaitStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GatewayId is the gatewayId argument value.
+			GatewayId string
+			// LastState is the lastState argument value.
+			LastState v1beta2.ApplicationStateType
+			// Timeout is the timeout argument value.
+			Timeout time.Duration
+		}
+		// Watch holds details about calls to the Watch method.
+		Watch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Namespace is the namespace argument value.
+			Namespace string
+			// LabelSelector is the labelSelector argument value.
+			LabelSelector string
+			// Bookmark is the bookmark argument value.
+			Bookmark domain.WatchBookmark
+		}
+	}
+	lockCounts                           sync.RWMutex
+	lockCreate                           sync.RWMutex
+	lockDelete                           sync.RWMutex
+	lockDriverMetrics                    sync.RWMutex
+	lockExecutorLogs                     sync.RWMutex
+	lockFollowLogs                       sync.RWMutex
+	lockGet                              sync.RWMutex
+	lockGetClusterNamespaceFromGatewayId sync.RWMutex
+	lockList                             sync.RWMutex
+	lockLogs                             sync.RWMutex
+	lockPods                             sync.RWMutex
+	lockRenderPods                       sync.RWMutex
+	lockResubmission                     sync.RWMutex
+	lockScale                            sync.RWMutex
+	lockStatus                           sync.RWMutex
+	lockStreamLogs                       sync.RWMutex
+	lockStreamStatus                     sync.RWMutex
+	lockTimeline                         sync.RWMutex
+	lockUpdate                           sync.RWMutex
+	lockWaitStatus                       sync.RWMutex
+	lockWatch                            sync.RWMutex
+}
+
+// Counts calls CountsFunc.
+func (mock *GatewayApplicationServiceMock) Counts(ctx context.Context, cluster string, namespace string, groupBy []domain.CountGroupBy) ([]*domain.ApplicationCount, error) {
+	if mock.CountsFunc == nil {
+		panic("GatewayApplicationServiceMock.CountsFunc: method is nil but GatewayApplicationService.Counts was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Cluster   string
+		Namespace string
+		GroupBy   []domain.CountGroupBy
+	}{
+		Ctx:       ctx,
+		Cluster:   cluster,
+		Namespace: namespace,
+		GroupBy:   groupBy,
+	}
+	mock.lockCounts.Lock()
+	mock.calls.Counts = append(mock.calls.Counts, callInfo)
+	mock.lockCounts.Unlock()
+	return mock.CountsFunc(ctx, cluster, namespace, groupBy)
+}
+
+// CountsCalls gets all the calls that were made to Counts.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationService.CountsCalls())
+func (mock *GatewayApplicationServiceMock) CountsCalls() []struct {
+	Ctx       context.Context
+	Cluster   string
+	Namespace string
+	GroupBy   []domain.CountGroupBy
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Cluster   string
+		Namespace string
+		GroupBy   []domain.CountGroupBy
 	}
-	lockCreate sync.RWMutex
-	lockDelete sync.RWMutex
-	lockGet    sync.RWMutex
-	lockList   sync.RWMutex
-	lockLogs   sync.RWMutex
-	lockStatus sync.RWMutex
+	mock.lockCounts.RLock()
+	calls = mock.calls.Counts
+	mock.lockCounts.RUnlock()
+	return calls
 }
 
 // Create calls CreateFunc.
@@ -198,6 +480,126 @@ func (mock *GatewayApplicationServiceMock) DeleteCalls() []struct {
 	return calls
 }
 
+// DriverMetrics calls DriverMetricsFunc.
+func (mock *GatewayApplicationServiceMock) DriverMetrics(ctx context.Context, gatewayId string) (io.ReadCloser, error) {
+	if mock.DriverMetricsFunc == nil {
+		panic("GatewayApplicationServiceMock.DriverMetricsFunc: method is nil but GatewayApplicationService.DriverMetrics was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		GatewayId string
+	}{
+		Ctx:       ctx,
+		GatewayId: gatewayId,
+	}
+	mock.lockDriverMetrics.Lock()
+	mock.calls.DriverMetrics = append(mock.calls.DriverMetrics, callInfo)
+	mock.lockDriverMetrics.Unlock()
+	return mock.DriverMetricsFunc(ctx, gatewayId)
+}
+
+// DriverMetricsCalls gets all the calls that were made to DriverMetrics.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationService.DriverMetricsCalls())
+func (mock *GatewayApplicationServiceMock) DriverMetricsCalls() []struct {
+	Ctx       context.Context
+	GatewayId string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		GatewayId string
+	}
+	mock.lockDriverMetrics.RLock()
+	calls = mock.calls.DriverMetrics
+	mock.lockDriverMetrics.RUnlock()
+	return calls
+}
+
+// ExecutorLogs calls ExecutorLogsFunc.
+func (mock *GatewayApplicationServiceMock) ExecutorLogs(ctx context.Context, gatewayId string, executorId string, tailLines int) (*string, error) {
+	if mock.ExecutorLogsFunc == nil {
+		panic("GatewayApplicationServiceMock.ExecutorLogsFunc: method is nil but GatewayApplicationService.ExecutorLogs was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		GatewayId  string
+		ExecutorId string
+		TailLines  int
+	}{
+		Ctx:        ctx,
+		GatewayId:  gatewayId,
+		ExecutorId: executorId,
+		TailLines:  tailLines,
+	}
+	mock.lockExecutorLogs.Lock()
+	mock.calls.ExecutorLogs = append(mock.calls.ExecutorLogs, callInfo)
+	mock.lockExecutorLogs.Unlock()
+	return mock.ExecutorLogsFunc(ctx, gatewayId, executorId, tailLines)
+}
+
+// ExecutorLogsCalls gets all the calls that were made to ExecutorLogs.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationService.ExecutorLogsCalls())
+func (mock *GatewayApplicationServiceMock) ExecutorLogsCalls() []struct {
+	Ctx        context.Context
+	GatewayId  string
+	ExecutorId string
+	TailLines  int
+} {
+	var calls []struct {
+		Ctx        context.Context
+		GatewayId  string
+		ExecutorId string
+		TailLines  int
+	}
+	mock.lockExecutorLogs.RLock()
+	calls = mock.calls.ExecutorLogs
+	mock.lockExecutorLogs.RUnlock()
+	return calls
+}
+
+// FollowLogs calls FollowLogsFunc.
+func (mock *GatewayApplicationServiceMock) FollowLogs(ctx context.Context, gatewayId string, tailLines int) (io.ReadCloser, error) {
+	if mock.FollowLogsFunc == nil {
+		panic("GatewayApplicationServiceMock.FollowLogsFunc: method is nil but GatewayApplicationService.FollowLogs was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		GatewayId string
+		TailLines int
+	}{
+		Ctx:       ctx,
+		GatewayId: gatewayId,
+		TailLines: tailLines,
+	}
+	mock.lockFollowLogs.Lock()
+	mock.calls.FollowLogs = append(mock.calls.FollowLogs, callInfo)
+	mock.lockFollowLogs.Unlock()
+	return mock.FollowLogsFunc(ctx, gatewayId, tailLines)
+}
+
+// FollowLogsCalls gets all the calls that were made to FollowLogs.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationService.FollowLogsCalls())
+func (mock *GatewayApplicationServiceMock) FollowLogsCalls() []struct {
+	Ctx       context.Context
+	GatewayId string
+	TailLines int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		GatewayId string
+		TailLines int
+	}
+	mock.lockFollowLogs.RLock()
+	calls = mock.calls.FollowLogs
+	mock.lockFollowLogs.RUnlock()
+	return calls
+}
+
 // Get calls GetFunc.
 func (mock *GatewayApplicationServiceMock) Get(ctx context.Context, gatewayId string) (*domain.GatewayApplication, error) {
 	if mock.GetFunc == nil {
@@ -234,8 +636,40 @@ func (mock *GatewayApplicationServiceMock) GetCalls() []struct {
 	return calls
 }
 
+// GetClusterNamespaceFromGatewayId calls GetClusterNamespaceFromGatewayIdFunc.
+func (mock *GatewayApplicationServiceMock) GetClusterNamespaceFromGatewayId(gatewayId string) (*domain.KubeCluster, string, error) {
+	if mock.GetClusterNamespaceFromGatewayIdFunc == nil {
+		panic("GatewayApplicationServiceMock.GetClusterNamespaceFromGatewayIdFunc: method is nil but GatewayApplicationService.GetClusterNamespaceFromGatewayId was just called")
+	}
+	callInfo := struct {
+		GatewayId string
+	}{
+		GatewayId: gatewayId,
+	}
+	mock.lockGetClusterNamespaceFromGatewayId.Lock()
+	mock.calls.GetClusterNamespaceFromGatewayId = append(mock.calls.GetClusterNamespaceFromGatewayId, callInfo)
+	mock.lockGetClusterNamespaceFromGatewayId.Unlock()
+	return mock.GetClusterNamespaceFromGatewayIdFunc(gatewayId)
+}
+
+// GetClusterNamespaceFromGatewayIdCalls gets all the calls that were made to GetClusterNamespaceFromGatewayId.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationService.GetClusterNamespaceFromGatewayIdCalls())
+func (mock *GatewayApplicationServiceMock) GetClusterNamespaceFromGatewayIdCalls() []struct {
+	GatewayId string
+} {
+	var calls []struct {
+		GatewayId string
+	}
+	mock.lockGetClusterNamespaceFromGatewayId.RLock()
+	calls = mock.calls.GetClusterNamespaceFromGatewayId
+	mock.lockGetClusterNamespaceFromGatewayId.RUnlock()
+	return calls
+}
+
 // List calls ListFunc.
-func (mock *GatewayApplicationServiceMock) List(ctx context.Context, cluster string, namespace string) ([]*domain.GatewayApplicationSummary, error) {
+func (mock *GatewayApplicationServiceMock) List(ctx context.Context, cluster string, namespace string, view domain.SummaryView, listSort domain.ListSort) ([]*domain.GatewayApplicationSummary, error) {
 	if mock.ListFunc == nil {
 		panic("GatewayApplicationServiceMock.ListFunc: method is nil but GatewayApplicationService.List was just called")
 	}
@@ -243,15 +677,19 @@ func (mock *GatewayApplicationServiceMock) List(ctx context.Context, cluster str
 		Ctx       context.Context
 		Cluster   string
 		Namespace string
+		View      domain.SummaryView
+		ListSort  domain.ListSort
 	}{
 		Ctx:       ctx,
 		Cluster:   cluster,
 		Namespace: namespace,
+		View:      view,
+		ListSort:  listSort,
 	}
 	mock.lockList.Lock()
 	mock.calls.List = append(mock.calls.List, callInfo)
 	mock.lockList.Unlock()
-	return mock.ListFunc(ctx, cluster, namespace)
+	return mock.ListFunc(ctx, cluster, namespace, view, listSort)
 }
 
 // ListCalls gets all the calls that were made to List.
@@ -262,11 +700,15 @@ func (mock *GatewayApplicationServiceMock) ListCalls() []struct {
 	Ctx       context.Context
 	Cluster   string
 	Namespace string
+	View      domain.SummaryView
+	ListSort  domain.ListSort
 } {
 	var calls []struct {
 		Ctx       context.Context
 		Cluster   string
 		Namespace string
+		View      domain.SummaryView
+		ListSort  domain.ListSort
 	}
 	mock.lockList.RLock()
 	calls = mock.calls.List
@@ -314,8 +756,160 @@ func (mock *GatewayApplicationServiceMock) LogsCalls() []struct {
 	return calls
 }
 
+// Pods calls PodsFunc.
+func (mock *GatewayApplicationServiceMock) Pods(ctx context.Context, gatewayId string) (*domain.ApplicationPods, error) {
+	if mock.PodsFunc == nil {
+		panic("GatewayApplicationServiceMock.PodsFunc: method is nil but GatewayApplicationService.Pods was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		GatewayId string
+	}{
+		Ctx:       ctx,
+		GatewayId: gatewayId,
+	}
+	mock.lockPods.Lock()
+	mock.calls.Pods = append(mock.calls.Pods, callInfo)
+	mock.lockPods.Unlock()
+	return mock.PodsFunc(ctx, gatewayId)
+}
+
+// PodsCalls gets all the calls that were made to Pods.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationService.PodsCalls())
+func (mock *GatewayApplicationServiceMock) PodsCalls() []struct {
+	Ctx       context.Context
+	GatewayId string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		GatewayId string
+	}
+	mock.lockPods.RLock()
+	calls = mock.calls.Pods
+	mock.lockPods.RUnlock()
+	return calls
+}
+
+// RenderPods calls RenderPodsFunc.
+func (mock *GatewayApplicationServiceMock) RenderPods(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.RenderedPods, error) {
+	if mock.RenderPodsFunc == nil {
+		panic("GatewayApplicationServiceMock.RenderPodsFunc: method is nil but GatewayApplicationService.RenderPods was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		Application *v1beta2.SparkApplication
+		User        string
+	}{
+		Ctx:         ctx,
+		Application: application,
+		User:        user,
+	}
+	mock.lockRenderPods.Lock()
+	mock.calls.RenderPods = append(mock.calls.RenderPods, callInfo)
+	mock.lockRenderPods.Unlock()
+	return mock.RenderPodsFunc(ctx, application, user)
+}
+
+// RenderPodsCalls gets all the calls that were made to RenderPods.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationService.RenderPodsCalls())
+func (mock *GatewayApplicationServiceMock) RenderPodsCalls() []struct {
+	Ctx         context.Context
+	Application *v1beta2.SparkApplication
+	User        string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Application *v1beta2.SparkApplication
+		User        string
+	}
+	mock.lockRenderPods.RLock()
+	calls = mock.calls.RenderPods
+	mock.lockRenderPods.RUnlock()
+	return calls
+}
+
+// Resubmission calls ResubmissionFunc.
+func (mock *GatewayApplicationServiceMock) Resubmission(ctx context.Context, gatewayId string) (*v1beta2.SparkApplication, error) {
+	if mock.ResubmissionFunc == nil {
+		panic("GatewayApplicationServiceMock.ResubmissionFunc: method is nil but GatewayApplicationService.Resubmission was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		GatewayId string
+	}{
+		Ctx:       ctx,
+		GatewayId: gatewayId,
+	}
+	mock.lockResubmission.Lock()
+	mock.calls.Resubmission = append(mock.calls.Resubmission, callInfo)
+	mock.lockResubmission.Unlock()
+	return mock.ResubmissionFunc(ctx, gatewayId)
+}
+
+// ResubmissionCalls gets all the calls that were made to Resubmission.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationService.ResubmissionCalls())
+func (mock *GatewayApplicationServiceMock) ResubmissionCalls() []struct {
+	Ctx       context.Context
+	GatewayId string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		GatewayId string
+	}
+	mock.lockResubmission.RLock()
+	calls = mock.calls.Resubmission
+	mock.lockResubmission.RUnlock()
+	return calls
+}
+
+// Scale calls ScaleFunc.
+func (mock *GatewayApplicationServiceMock) Scale(ctx context.Context, gatewayId string, scale domain.ExecutorScale) (*domain.GatewayApplication, error) {
+	if mock.ScaleFunc == nil {
+		panic("GatewayApplicationServiceMock.ScaleFunc: method is nil but GatewayApplicationService.Scale was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		GatewayId string
+		Scale     domain.ExecutorScale
+	}{
+		Ctx:       ctx,
+		GatewayId: gatewayId,
+		Scale:     scale,
+	}
+	mock.lockScale.Lock()
+	mock.calls.Scale = append(mock.calls.Scale, callInfo)
+	mock.lockScale.Unlock()
+	return mock.ScaleFunc(ctx, gatewayId, scale)
+}
+
+// ScaleCalls gets all the calls that were made to Scale.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationService.ScaleCalls())
+func (mock *GatewayApplicationServiceMock) ScaleCalls() []struct {
+	Ctx       context.Context
+	GatewayId string
+	Scale     domain.ExecutorScale
+} {
+	var calls []struct {
+		Ctx       context.Context
+		GatewayId string
+		Scale     domain.ExecutorScale
+	}
+	mock.lockScale.RLock()
+	calls = mock.calls.Scale
+	mock.lockScale.RUnlock()
+	return calls
+}
+
 // Status calls StatusFunc.
-func (mock *GatewayApplicationServiceMock) Status(ctx context.Context, gatewayId string) (*v1beta2.SparkApplicationStatus, error) {
+func (mock *GatewayApplicationServiceMock) Status(ctx context.Context, gatewayId string) (*domain.ApplicationStatus, error) {
 	if mock.StatusFunc == nil {
 		panic("GatewayApplicationServiceMock.StatusFunc: method is nil but GatewayApplicationService.Status was just called")
 	}
@@ -349,3 +943,239 @@ func (mock *GatewayApplicationServiceMock) StatusCalls() []struct {
 	mock.lockStatus.RUnlock()
 	return calls
 }
+
+// StreamLogs calls StreamLogsFunc.
+func (mock *GatewayApplicationServiceMock) StreamLogs(ctx context.Context, gatewayId string) (io.ReadCloser, error) {
+	if mock.StreamLogsFunc == nil {
+		panic("GatewayApplicationServiceMock.StreamLogsFunc: method is nil but GatewayApplicationService.StreamLogs was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		GatewayId string
+	}{
+		Ctx:       ctx,
+		GatewayId: gatewayId,
+	}
+	mock.lockStreamLogs.Lock()
+	mock.calls.StreamLogs = append(mock.calls.StreamLogs, callInfo)
+	mock.lockStreamLogs.Unlock()
+	return mock.StreamLogsFunc(ctx, gatewayId)
+}
+
+// StreamLogsCalls gets all the calls that were made to StreamLogs.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationService.StreamLogsCalls())
+func (mock *GatewayApplicationServiceMock) StreamLogsCalls() []struct {
+	Ctx       context.Context
+	GatewayId string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		GatewayId string
+	}
+	mock.lockStreamLogs.RLock()
+	calls = mock.calls.StreamLogs
+	mock.lockStreamLogs.RUnlock()
+	return calls
+}
+
+// StreamStatus calls StreamStatusFunc.
+func (mock *GatewayApplicationServiceMock) StreamStatus(ctx context.Context, gatewayId string) (<-chan *domain.ApplicationStatus, error) {
+	if mock.StreamStatusFunc == nil {
+		panic("GatewayApplicationServiceMock.StreamStatusFunc: method is nil but GatewayApplicationService.StreamStatus was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		GatewayId string
+	}{
+		Ctx:       ctx,
+		GatewayId: gatewayId,
+	}
+	mock.lockStreamStatus.Lock()
+	mock.calls.StreamStatus = append(mock.calls.StreamStatus, callInfo)
+	mock.lockStreamStatus.Unlock()
+	return mock.StreamStatusFunc(ctx, gatewayId)
+}
+
+// StreamStatusCalls gets all the calls that were made to StreamStatus.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationService.StreamStatusCalls())
+func (mock *GatewayApplicationServiceMock) StreamStatusCalls() []struct {
+	Ctx       context.Context
+	GatewayId string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		GatewayId string
+	}
+	mock.lockStreamStatus.RLock()
+	calls = mock.calls.StreamStatus
+	mock.lockStreamStatus.RUnlock()
+	return calls
+}
+
+// Timeline calls TimelineFunc.
+func (mock *GatewayApplicationServiceMock) Timeline(ctx context.Context, gatewayId string) (*domain.ApplicationTimeline, error) {
+	if mock.TimelineFunc == nil {
+		panic("GatewayApplicationServiceMock.TimelineFunc: method is nil but GatewayApplicationService.Timeline was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		GatewayId string
+	}{
+		Ctx:       ctx,
+		GatewayId: gatewayId,
+	}
+	mock.lockTimeline.Lock()
+	mock.calls.Timeline = append(mock.calls.Timeline, callInfo)
+	mock.lockTimeline.Unlock()
+	return mock.TimelineFunc(ctx, gatewayId)
+}
+
+// TimelineCalls gets all the calls that were made to Timeline.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationService.TimelineCalls())
+func (mock *GatewayApplicationServiceMock) TimelineCalls() []struct {
+	Ctx       context.Context
+	GatewayId string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		GatewayId string
+	}
+	mock.lockTimeline.RLock()
+	calls = mock.calls.Timeline
+	mock.lockTimeline.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *GatewayApplicationServiceMock) Update(ctx context.Context, gatewayId string, update domain.ApplicationUpdate) (*domain.GatewayApplication, error) {
+	if mock.UpdateFunc == nil {
+		panic("GatewayApplicationServiceMock.UpdateFunc: method is nil but GatewayApplicationService.Update was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		GatewayId string
+		Update    domain.ApplicationUpdate
+	}{
+		Ctx:       ctx,
+		GatewayId: gatewayId,
+		Update:    update,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(ctx, gatewayId, update)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationService.UpdateCalls())
+func (mock *GatewayApplicationServiceMock) UpdateCalls() []struct {
+	Ctx       context.Context
+	GatewayId string
+	Update    domain.ApplicationUpdate
+} {
+	var calls []struct {
+		Ctx       context.Context
+		GatewayId string
+		Update    domain.ApplicationUpdate
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
+
+// WaitStatus calls WaitStatusFunc.
+func (mock *GatewayApplicationServiceMock) WaitStatus(ctx context.Context, gatewayId string, lastState v1beta2.ApplicationStateType, timeout time.Duration) (*domain.GatewayApplicationWaitStatus, error) {
+	if mock.WaitStatusFunc == nil {
+		panic("GatewayApplicationServiceMock.WaitStatusFunc: method is nil but GatewayApplicationService.WaitStatus was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		GatewayId string
+		LastState v1beta2.ApplicationStateType
+		Timeout   time.Duration
+	}{
+		Ctx:       ctx,
+		GatewayId: gatewayId,
+		LastState: lastState,
+		Timeout:   timeout,
+	}
+	mock.lockWaitStatus.Lock()
+	mock.calls.WaitStatus = append(mock.calls.WaitStatus, callInfo)
+	mock.lockWaitStatus.Unlock()
+	return mock.WaitStatusFunc(ctx, gatewayId, lastState, timeout)
+}
+
+// WaitStatusCalls gets all the calls that were made to WaitStatus.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationService.WaitStatusCalls())
+func (mock *GatewayApplicationServiceMock) WaitStatusCalls() []struct {
+	Ctx       context.Context
+	GatewayId string
+	LastState v1beta2.ApplicationStateType
+	Timeout   time.Duration
+} {
+	var calls []struct {
+		Ctx       context.Context
+		GatewayId string
+		LastState v1beta2.ApplicationStateType
+		Timeout   time.Duration
+	}
+	mock.lockWaitStatus.RLock()
+	calls = mock.calls.WaitStatus
+	mock.lockWaitStatus.RUnlock()
+	return calls
+}
+
+// Watch calls WatchFunc.
+func (mock *GatewayApplicationServiceMock) Watch(ctx context.Context, namespace string, labelSelector string, bookmark domain.WatchBookmark) (<-chan *domain.GatewayWatchEvent, error) {
+	if mock.WatchFunc == nil {
+		panic("GatewayApplicationServiceMock.WatchFunc: method is nil but GatewayApplicationService.Watch was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		Namespace     string
+		LabelSelector string
+		Bookmark      domain.WatchBookmark
+	}{
+		Ctx:           ctx,
+		Namespace:     namespace,
+		LabelSelector: labelSelector,
+		Bookmark:      bookmark,
+	}
+	mock.lockWatch.Lock()
+	mock.calls.Watch = append(mock.calls.Watch, callInfo)
+	mock.lockWatch.Unlock()
+	return mock.WatchFunc(ctx, namespace, labelSelector, bookmark)
+}
+
+// WatchCalls gets all the calls that were made to Watch.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationService.WatchCalls())
+func (mock *GatewayApplicationServiceMock) WatchCalls() []struct {
+	Ctx           context.Context
+	Namespace     string
+	LabelSelector string
+	Bookmark      domain.WatchBookmark
+} {
+	var calls []struct {
+		Ctx           context.Context
+		Namespace     string
+		LabelSelector string
+		Bookmark      domain.WatchBookmark
+	}
+	mock.lockWatch.RLock()
+	calls = mock.calls.Watch
+	mock.lockWatch.RUnlock()
+	return calls
+}
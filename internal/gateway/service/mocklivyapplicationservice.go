@@ -19,7 +19,7 @@ var _ LivyApplicationService = &LivyApplicationServiceMock{}
 //
 //		// make and configure a mocked LivyApplicationService
 //		mockedLivyApplicationService := &LivyApplicationServiceMock{
-//			CreateFunc: func(ctx context.Context, createReq domain.LivyCreateBatchRequest, namespace string) (*domain.LivyBatch, error) {
+//			CreateFunc: func(ctx context.Context, createReq domain.LivyCreateBatchRequest, namespace string, submitter LivySubmitter) (*domain.LivyBatch, error) {
 //				panic("mock out the Create method")
 //			},
 //			DeleteFunc: func(ctx context.Context, batchId int) error {
@@ -42,7 +42,7 @@ var _ LivyApplicationService = &LivyApplicationServiceMock{}
 //	}
 type LivyApplicationServiceMock struct {
 	// CreateFunc mocks the Create method.
-	CreateFunc func(ctx context.Context, createReq domain.LivyCreateBatchRequest, namespace string) (*domain.LivyBatch, error)
+	CreateFunc func(ctx context.Context, createReq domain.LivyCreateBatchRequest, namespace string, submitter LivySubmitter) (*domain.LivyBatch, error)
 
 	// DeleteFunc mocks the Delete method.
 	DeleteFunc func(ctx context.Context, batchId int) error
@@ -66,6 +66,8 @@ type LivyApplicationServiceMock struct {
 			CreateReq domain.LivyCreateBatchRequest
 			// Namespace is the namespace argument value.
 			Namespace string
+			// Submitter is the submitter argument value.
+			Submitter LivySubmitter
 		}
 		// Delete holds details about calls to the Delete method.
 		Delete []struct {
@@ -108,7 +110,7 @@ type LivyApplicationServiceMock struct {
 }
 
 // Create calls CreateFunc.
-func (mock *LivyApplicationServiceMock) Create(ctx context.Context, createReq domain.LivyCreateBatchRequest, namespace string) (*domain.LivyBatch, error) {
+func (mock *LivyApplicationServiceMock) Create(ctx context.Context, createReq domain.LivyCreateBatchRequest, namespace string, submitter LivySubmitter) (*domain.LivyBatch, error) {
 	if mock.CreateFunc == nil {
 		panic("LivyApplicationServiceMock.CreateFunc: method is nil but LivyApplicationService.Create was just called")
 	}
@@ -116,15 +118,17 @@ func (mock *LivyApplicationServiceMock) Create(ctx context.Context, createReq do
 		Ctx       context.Context
 		CreateReq domain.LivyCreateBatchRequest
 		Namespace string
+		Submitter LivySubmitter
 	}{
 		Ctx:       ctx,
 		CreateReq: createReq,
 		Namespace: namespace,
+		Submitter: submitter,
 	}
 	mock.lockCreate.Lock()
 	mock.calls.Create = append(mock.calls.Create, callInfo)
 	mock.lockCreate.Unlock()
-	return mock.CreateFunc(ctx, createReq, namespace)
+	return mock.CreateFunc(ctx, createReq, namespace, submitter)
 }
 
 // CreateCalls gets all the calls that were made to Create.
@@ -135,11 +139,13 @@ func (mock *LivyApplicationServiceMock) CreateCalls() []struct {
 	Ctx       context.Context
 	CreateReq domain.LivyCreateBatchRequest
 	Namespace string
+	Submitter LivySubmitter
 } {
 	var calls []struct {
 		Ctx       context.Context
 		CreateReq domain.LivyCreateBatchRequest
 		Namespace string
+		Submitter LivySubmitter
 	}
 	mock.lockCreate.RLock()
 	calls = mock.calls.Create
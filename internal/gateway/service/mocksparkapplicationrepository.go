@@ -7,6 +7,7 @@ import (
 	"context"
 	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
 	"github.com/slackhq/spark-gateway/internal/domain"
+	"io"
 	"sync"
 )
 
@@ -20,24 +21,63 @@ var _ GatewayApplicationRepository = &GatewayApplicationRepositoryMock{}
 //
 //		// make and configure a mocked GatewayApplicationRepository
 //		mockedGatewayApplicationRepository := &GatewayApplicationRepositoryMock{
+//			CostFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*domain.ApplicationCost, error) {
+//				panic("mock out the Cost method")
+//			},
+//			CountsFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string) (*domain.SparkManagerApplicationCounts, error) {
+//				panic("mock out the Counts method")
+//			},
 //			CreateFunc: func(ctx context.Context, cluster domain.KubeCluster, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
 //				panic("mock out the Create method")
 //			},
 //			DeleteFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) error {
 //				panic("mock out the Delete method")
 //			},
+//			DriverMetricsFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (io.ReadCloser, error) {
+//				panic("mock out the DriverMetrics method")
+//			},
+//			ExecutorLogsFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, executorId string, tailLines int) (*string, error) {
+//				panic("mock out the ExecutorLogs method")
+//			},
+//			FollowLogsFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, tailLines int) (io.ReadCloser, error) {
+//				panic("mock out the FollowLogs method")
+//			},
 //			GetFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplication, error) {
 //				panic("mock out the Get method")
 //			},
-//			ListFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string) ([]*domain.SparkManagerSparkApplicationSummary, error) {
+//			ListFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, view domain.SummaryView) ([]*domain.SparkManagerSparkApplicationSummary, error) {
 //				panic("mock out the List method")
 //			},
 //			LogsFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, tailLines int) (*string, error) {
 //				panic("mock out the Logs method")
 //			},
-//			StatusFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplicationStatus, error) {
+//			PodsFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) ([]*domain.SparkApplicationPod, error) {
+//				panic("mock out the Pods method")
+//			},
+//			RenderPodsFunc: func(ctx context.Context, cluster domain.KubeCluster, application *v1beta2.SparkApplication) (*domain.RenderedPods, error) {
+//				panic("mock out the RenderPods method")
+//			},
+//			ScaleFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, scale domain.ExecutorScale) (*v1beta2.SparkApplication, error) {
+//				panic("mock out the Scale method")
+//			},
+//			StatusFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*domain.ApplicationStatus, error) {
 //				panic("mock out the Status method")
 //			},
+//			StreamLogsFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (io.ReadCloser, error) {
+//				panic("mock out the StreamLogs method")
+//			},
+//			StreamStatusFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (io.ReadCloser, error) {
+//				panic("mock out the StreamStatus method")
+//			},
+//			TimelineFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) ([]domain.TimelineEvent, error) {
+//				panic("mock out the Timeline method")
+//			},
+//			UpdateFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, update domain.ApplicationUpdate) (*v1beta2.SparkApplication, error) {
+//				panic("mock out the Update method")
+//			},
+//			WatchFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, labelSelector string, resourceVersion string) (io.ReadCloser, error) {
+//				panic("mock out the Watch method")
+//			},
 //		}
 //
 //		// use mockedGatewayApplicationRepository in code that requires GatewayApplicationRepository
@@ -45,26 +85,85 @@ var _ GatewayApplicationRepository = &GatewayApplicationRepositoryMock{}
 //
 //	}
 type GatewayApplicationRepositoryMock struct {
+	// CostFunc mocks the Cost method.
+	CostFunc func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*domain.ApplicationCost, error)
+
+	// CountsFunc mocks the Counts method.
+	CountsFunc func(ctx context.Context, cluster domain.KubeCluster, namespace string) (*domain.SparkManagerApplicationCounts, error)
+
 	// CreateFunc mocks the Create method.
 	CreateFunc func(ctx context.Context, cluster domain.KubeCluster, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error)
 
 	// DeleteFunc mocks the Delete method.
 	DeleteFunc func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) error
 
+	// DriverMetricsFunc mocks the DriverMetrics method.
+	DriverMetricsFunc func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (io.ReadCloser, error)
+
+	// ExecutorLogsFunc mocks the ExecutorLogs method.
+	ExecutorLogsFunc func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, executorId string, tailLines int) (*string, error)
+
+	// FollowLogsFunc mocks the FollowLogs method.
+	FollowLogsFunc func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, tailLines int) (io.ReadCloser, error)
+
 	// GetFunc mocks the Get method.
 	GetFunc func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplication, error)
 
 	// ListFunc mocks the List method.
-	ListFunc func(ctx context.Context, cluster domain.KubeCluster, namespace string) ([]*domain.SparkManagerSparkApplicationSummary, error)
+	ListFunc func(ctx context.Context, cluster domain.KubeCluster, namespace string, view domain.SummaryView) ([]*domain.SparkManagerSparkApplicationSummary, error)
 
 	// LogsFunc mocks the Logs method.
 	LogsFunc func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, tailLines int) (*string, error)
 
+	// PodsFunc mocks the Pods method.
+	PodsFunc func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) ([]*domain.SparkApplicationPod, error)
+
+	// RenderPodsFunc mocks the RenderPods method.
+	RenderPodsFunc func(ctx context.Context, cluster domain.KubeCluster, application *v1beta2.SparkApplication) (*domain.RenderedPods, error)
+
+	// ScaleFunc mocks the Scale method.
+	ScaleFunc func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, scale domain.ExecutorScale) (*v1beta2.SparkApplication, error)
+
 	// StatusFunc mocks the Status method.
-	StatusFunc func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplicationStatus, error)
+	StatusFunc func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*domain.ApplicationStatus, error)
+
+	// StreamLogsFunc mocks the StreamLogs method.
+	StreamLogsFunc func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (io.ReadCloser, error)
+
+	// StreamStatusFunc mocks the StreamStatus method.
+	StreamStatusFunc func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (io.ReadCloser, error)
+
+	// TimelineFunc mocks the Timeline method.
+	TimelineFunc func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) ([]domain.TimelineEvent, error)
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, update domain.ApplicationUpdate) (*v1beta2.SparkApplication, error)
+
+	// WatchFunc mocks the Watch method.
+	WatchFunc func(ctx context.Context, cluster domain.KubeCluster, namespace string, labelSelector string, resourceVersion string) (io.ReadCloser, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// Cost holds details about calls to the Cost method.
+		Cost []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cluster is the cluster argument value.
+			Cluster domain.KubeCluster
+			// Namespace is the namespace argument value.
+			Namespace string
+			// Name is the name argument value.
+			Name string
+		}
+		// Counts holds details about calls to the Counts method.
+		Counts []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cluster is the cluster argument value.
+			Cluster domain.KubeCluster
+			// Namespace is the namespace argument value.
+			Namespace string
+		}
 		// Create holds details about calls to the Create method.
 		Create []struct {
 			// Ctx is the ctx argument value.
@@ -85,6 +184,45 @@ type GatewayApplicationRepositoryMock struct {
 			// Name is the name argument value.
 			Name string
 		}
+		// DriverMetrics holds details about calls to the DriverMetrics method.
+		DriverMetrics []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cluster is the cluster argument value.
+			Cluster domain.KubeCluster
+			// Namespace is the namespace argument value.
+			Namespace string
+			// Name is the name argument value.
+			Name string
+		}
+		// ExecutorLogs holds details about calls to the ExecutorLogs method.
+		ExecutorLogs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cluster is the cluster argument value.
+			Cluster domain.KubeCluster
+			// Namespace is the namespace argument value.
+			Namespace string
+			// Name is the name argument value.
+			Name string
+			// ExecutorId is the executorId argument value.
+			ExecutorId string
+			// TailLines is the tailLines argument value.
+			TailLines int
+		}
+		// FollowLogs holds details about calls to the FollowLogs method.
+		FollowLogs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cluster is the cluster argument value.
+			Cluster domain.KubeCluster
+			// Namespace is the namespace argument value.
+			Namespace string
+			// Name is the name argument value.
+			Name string
+			// TailLines is the tailLines argument value.
+			TailLines int
+		}
 		// Get holds details about calls to the Get method.
 		Get []struct {
 			// Ctx is the ctx argument value.
@@ -104,6 +242,8 @@ type GatewayApplicationRepositoryMock struct {
 			Cluster domain.KubeCluster
 			// Namespace is the namespace argument value.
 			Namespace string
+			// View is the view argument value.
+			View domain.SummaryView
 		}
 		// Logs holds details about calls to the Logs method.
 		Logs []struct {
@@ -118,6 +258,39 @@ type GatewayApplicationRepositoryMock struct {
 			// TailLines is the tailLines argument value.
 			TailLines int
 		}
+		// Pods holds details about calls to the Pods method.
+		Pods []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cluster is the cluster argument value.
+			Cluster domain.KubeCluster
+			// Namespace is the namespace argument value.
+			Namespace string
+			// Name is the name argument value.
+			Name string
+		}
+		// RenderPods holds details about calls to the RenderPods method.
+		RenderPods []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cluster is the cluster argument value.
+			Cluster domain.KubeCluster
+			// Application is the application argument value.
+			Application *v1beta2.SparkApplication
+		}
+		// Scale holds details about calls to the Scale method.
+		Scale []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cluster is the cluster argument value.
+			Cluster domain.KubeCluster
+			// Namespace is the namespace argument value.
+			Namespace string
+			// Name is the name argument value.
+			Name string
+			// Scale is the scale argument value.
+			Scale domain.ExecutorScale
+		}
 		// Status holds details about calls to the Status method.
 		Status []struct {
 			// Ctx is the ctx argument value.
@@ -129,13 +302,169 @@ type GatewayApplicationRepositoryMock struct {
 			// Name is the name argument value.
 			Name string
 		}
+		// StreamLogs holds details about calls to the StreamLogs method.
+		StreamLogs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cluster is the cluster argument value.
+			Cluster domain.KubeCluster
+			// Namespace is the namespace argument value.
+			Namespace string
+			// Name is the name argument value.
+			Name string
+		}
+		// StreamStatus holds details about calls to the StreamStatus method.
+		StreamStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cluster is the cluster argument value.
+			Cluster domain.KubeCluster
+			// Namespace is the namespace argument value.
+			Namespace string
+			// Name is the name argument value.
+			Name string
+		}
+		// Timeline holds details about calls to the Timeline method.
+		Timeline []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cluster is the cluster argument value.
+			Cluster domain.KubeCluster
+			// Namespace is the namespace argument value.
+			Namespace string
+			// Name is the name argument value.
+			Name string
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cluster is the cluster argument value.
+			Cluster domain.KubeCluster
+			// Namespace is the namespace argument value.
+			Namespace string
+			// Name is the name argument value.
+			Name string
+			// Update is the update argument value.
+			Update domain.ApplicationUpdate
+		}
+		// Watch holds details about calls to the Watch method.
+		Watch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cluster is the cluster argument value.
+			Cluster domain.KubeCluster
+			// Namespace is the namespace argument value.
+			Namespace string
+			// LabelSelector is the labelSelector argument value.
+			LabelSelector string
+			// ResourceVersion is the resourceVersion argument value.
+			ResourceVersion string
+		}
+	}
+	lockCost          sync.RWMutex
+	lockCounts        sync.RWMutex
+	lockCreate        sync.RWMutex
+	lockDelete        sync.RWMutex
+	lockDriverMetrics sync.RWMutex
+	lockExecutorLogs  sync.RWMutex
+	lockFollowLogs    sync.RWMutex
+	lockGet           sync.RWMutex
+	lockList          sync.RWMutex
+	lockLogs          sync.RWMutex
+	lockPods          sync.RWMutex
+	lockRenderPods    sync.RWMutex
+	lockScale         sync.RWMutex
+	lockStatus        sync.RWMutex
+	lockStreamLogs    sync.RWMutex
+	lockStreamStatus  sync.RWMutex
+	lockTimeline      sync.RWMutex
+	lockUpdate        sync.RWMutex
+	lockWatch         sync.RWMutex
+}
+
+// Cost calls CostFunc.
+func (mock *GatewayApplicationRepositoryMock) Cost(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*domain.ApplicationCost, error) {
+	if mock.CostFunc == nil {
+		panic("GatewayApplicationRepositoryMock.CostFunc: method is nil but GatewayApplicationRepository.Cost was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Cluster   domain.KubeCluster
+		Namespace string
+		Name      string
+	}{
+		Ctx:       ctx,
+		Cluster:   cluster,
+		Namespace: namespace,
+		Name:      name,
+	}
+	mock.lockCost.Lock()
+	mock.calls.Cost = append(mock.calls.Cost, callInfo)
+	mock.lockCost.Unlock()
+	return mock.CostFunc(ctx, cluster, namespace, name)
+}
+
+// CostCalls gets all the calls that were made to Cost.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationRepository.CostCalls())
+func (mock *GatewayApplicationRepositoryMock) CostCalls() []struct {
+	Ctx       context.Context
+	Cluster   domain.KubeCluster
+	Namespace string
+	Name      string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Cluster   domain.KubeCluster
+		Namespace string
+		Name      string
+	}
+	mock.lockCost.RLock()
+	calls = mock.calls.Cost
+	mock.lockCost.RUnlock()
+	return calls
+}
+
+// Counts calls CountsFunc.
+func (mock *GatewayApplicationRepositoryMock) Counts(ctx context.Context, cluster domain.KubeCluster, namespace string) (*domain.SparkManagerApplicationCounts, error) {
+	if mock.CountsFunc == nil {
+		panic("GatewayApplicationRepositoryMock.CountsFunc: method is nil but GatewayApplicationRepository.Counts was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Cluster   domain.KubeCluster
+		Namespace string
+	}{
+		Ctx:       ctx,
+		Cluster:   cluster,
+		Namespace: namespace,
+	}
+	mock.lockCounts.Lock()
+	mock.calls.Counts = append(mock.calls.Counts, callInfo)
+	mock.lockCounts.Unlock()
+	return mock.CountsFunc(ctx, cluster, namespace)
+}
+
+// CountsCalls gets all the calls that were made to Counts.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationRepository.CountsCalls())
+func (mock *GatewayApplicationRepositoryMock) CountsCalls() []struct {
+	Ctx       context.Context
+	Cluster   domain.KubeCluster
+	Namespace string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Cluster   domain.KubeCluster
+		Namespace string
 	}
-	lockCreate sync.RWMutex
-	lockDelete sync.RWMutex
-	lockGet    sync.RWMutex
-	lockList   sync.RWMutex
-	lockLogs   sync.RWMutex
-	lockStatus sync.RWMutex
+	mock.lockCounts.RLock()
+	calls = mock.calls.Counts
+	mock.lockCounts.RUnlock()
+	return calls
 }
 
 // Create calls CreateFunc.
@@ -222,6 +551,150 @@ func (mock *GatewayApplicationRepositoryMock) DeleteCalls() []struct {
 	return calls
 }
 
+// DriverMetrics calls DriverMetricsFunc.
+func (mock *GatewayApplicationRepositoryMock) DriverMetrics(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (io.ReadCloser, error) {
+	if mock.DriverMetricsFunc == nil {
+		panic("GatewayApplicationRepositoryMock.DriverMetricsFunc: method is nil but GatewayApplicationRepository.DriverMetrics was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Cluster   domain.KubeCluster
+		Namespace string
+		Name      string
+	}{
+		Ctx:       ctx,
+		Cluster:   cluster,
+		Namespace: namespace,
+		Name:      name,
+	}
+	mock.lockDriverMetrics.Lock()
+	mock.calls.DriverMetrics = append(mock.calls.DriverMetrics, callInfo)
+	mock.lockDriverMetrics.Unlock()
+	return mock.DriverMetricsFunc(ctx, cluster, namespace, name)
+}
+
+// DriverMetricsCalls gets all the calls that were made to DriverMetrics.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationRepository.DriverMetricsCalls())
+func (mock *GatewayApplicationRepositoryMock) DriverMetricsCalls() []struct {
+	Ctx       context.Context
+	Cluster   domain.KubeCluster
+	Namespace string
+	Name      string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Cluster   domain.KubeCluster
+		Namespace string
+		Name      string
+	}
+	mock.lockDriverMetrics.RLock()
+	calls = mock.calls.DriverMetrics
+	mock.lockDriverMetrics.RUnlock()
+	return calls
+}
+
+// ExecutorLogs calls ExecutorLogsFunc.
+func (mock *GatewayApplicationRepositoryMock) ExecutorLogs(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, executorId string, tailLines int) (*string, error) {
+	if mock.ExecutorLogsFunc == nil {
+		panic("GatewayApplicationRepositoryMock.ExecutorLogsFunc: method is nil but GatewayApplicationRepository.ExecutorLogs was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Cluster    domain.KubeCluster
+		Namespace  string
+		Name       string
+		ExecutorId string
+		TailLines  int
+	}{
+		Ctx:        ctx,
+		Cluster:    cluster,
+		Namespace:  namespace,
+		Name:       name,
+		ExecutorId: executorId,
+		TailLines:  tailLines,
+	}
+	mock.lockExecutorLogs.Lock()
+	mock.calls.ExecutorLogs = append(mock.calls.ExecutorLogs, callInfo)
+	mock.lockExecutorLogs.Unlock()
+	return mock.ExecutorLogsFunc(ctx, cluster, namespace, name, executorId, tailLines)
+}
+
+// ExecutorLogsCalls gets all the calls that were made to ExecutorLogs.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationRepository.ExecutorLogsCalls())
+func (mock *GatewayApplicationRepositoryMock) ExecutorLogsCalls() []struct {
+	Ctx        context.Context
+	Cluster    domain.KubeCluster
+	Namespace  string
+	Name       string
+	ExecutorId string
+	TailLines  int
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Cluster    domain.KubeCluster
+		Namespace  string
+		Name       string
+		ExecutorId string
+		TailLines  int
+	}
+	mock.lockExecutorLogs.RLock()
+	calls = mock.calls.ExecutorLogs
+	mock.lockExecutorLogs.RUnlock()
+	return calls
+}
+
+// FollowLogs calls FollowLogsFunc.
+func (mock *GatewayApplicationRepositoryMock) FollowLogs(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, tailLines int) (io.ReadCloser, error) {
+	if mock.FollowLogsFunc == nil {
+		panic("GatewayApplicationRepositoryMock.FollowLogsFunc: method is nil but GatewayApplicationRepository.FollowLogs was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Cluster   domain.KubeCluster
+		Namespace string
+		Name      string
+		TailLines int
+	}{
+		Ctx:       ctx,
+		Cluster:   cluster,
+		Namespace: namespace,
+		Name:      name,
+		TailLines: tailLines,
+	}
+	mock.lockFollowLogs.Lock()
+	mock.calls.FollowLogs = append(mock.calls.FollowLogs, callInfo)
+	mock.lockFollowLogs.Unlock()
+	return mock.FollowLogsFunc(ctx, cluster, namespace, name, tailLines)
+}
+
+// FollowLogsCalls gets all the calls that were made to FollowLogs.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationRepository.FollowLogsCalls())
+func (mock *GatewayApplicationRepositoryMock) FollowLogsCalls() []struct {
+	Ctx       context.Context
+	Cluster   domain.KubeCluster
+	Namespace string
+	Name      string
+	TailLines int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Cluster   domain.KubeCluster
+		Namespace string
+		Name      string
+		TailLines int
+	}
+	mock.lockFollowLogs.RLock()
+	calls = mock.calls.FollowLogs
+	mock.lockFollowLogs.RUnlock()
+	return calls
+}
+
 // Get calls GetFunc.
 func (mock *GatewayApplicationRepositoryMock) Get(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplication, error) {
 	if mock.GetFunc == nil {
@@ -267,7 +740,7 @@ func (mock *GatewayApplicationRepositoryMock) GetCalls() []struct {
 }
 
 // List calls ListFunc.
-func (mock *GatewayApplicationRepositoryMock) List(ctx context.Context, cluster domain.KubeCluster, namespace string) ([]*domain.SparkManagerSparkApplicationSummary, error) {
+func (mock *GatewayApplicationRepositoryMock) List(ctx context.Context, cluster domain.KubeCluster, namespace string, view domain.SummaryView) ([]*domain.SparkManagerSparkApplicationSummary, error) {
 	if mock.ListFunc == nil {
 		panic("GatewayApplicationRepositoryMock.ListFunc: method is nil but GatewayApplicationRepository.List was just called")
 	}
@@ -275,15 +748,17 @@ func (mock *GatewayApplicationRepositoryMock) List(ctx context.Context, cluster
 		Ctx       context.Context
 		Cluster   domain.KubeCluster
 		Namespace string
+		View      domain.SummaryView
 	}{
 		Ctx:       ctx,
 		Cluster:   cluster,
 		Namespace: namespace,
+		View:      view,
 	}
 	mock.lockList.Lock()
 	mock.calls.List = append(mock.calls.List, callInfo)
 	mock.lockList.Unlock()
-	return mock.ListFunc(ctx, cluster, namespace)
+	return mock.ListFunc(ctx, cluster, namespace, view)
 }
 
 // ListCalls gets all the calls that were made to List.
@@ -294,11 +769,13 @@ func (mock *GatewayApplicationRepositoryMock) ListCalls() []struct {
 	Ctx       context.Context
 	Cluster   domain.KubeCluster
 	Namespace string
+	View      domain.SummaryView
 } {
 	var calls []struct {
 		Ctx       context.Context
 		Cluster   domain.KubeCluster
 		Namespace string
+		View      domain.SummaryView
 	}
 	mock.lockList.RLock()
 	calls = mock.calls.List
@@ -354,8 +831,140 @@ func (mock *GatewayApplicationRepositoryMock) LogsCalls() []struct {
 	return calls
 }
 
+// Pods calls PodsFunc.
+func (mock *GatewayApplicationRepositoryMock) Pods(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) ([]*domain.SparkApplicationPod, error) {
+	if mock.PodsFunc == nil {
+		panic("GatewayApplicationRepositoryMock.PodsFunc: method is nil but GatewayApplicationRepository.Pods was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Cluster   domain.KubeCluster
+		Namespace string
+		Name      string
+	}{
+		Ctx:       ctx,
+		Cluster:   cluster,
+		Namespace: namespace,
+		Name:      name,
+	}
+	mock.lockPods.Lock()
+	mock.calls.Pods = append(mock.calls.Pods, callInfo)
+	mock.lockPods.Unlock()
+	return mock.PodsFunc(ctx, cluster, namespace, name)
+}
+
+// PodsCalls gets all the calls that were made to Pods.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationRepository.PodsCalls())
+func (mock *GatewayApplicationRepositoryMock) PodsCalls() []struct {
+	Ctx       context.Context
+	Cluster   domain.KubeCluster
+	Namespace string
+	Name      string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Cluster   domain.KubeCluster
+		Namespace string
+		Name      string
+	}
+	mock.lockPods.RLock()
+	calls = mock.calls.Pods
+	mock.lockPods.RUnlock()
+	return calls
+}
+
+// RenderPods calls RenderPodsFunc.
+func (mock *GatewayApplicationRepositoryMock) RenderPods(ctx context.Context, cluster domain.KubeCluster, application *v1beta2.SparkApplication) (*domain.RenderedPods, error) {
+	if mock.RenderPodsFunc == nil {
+		panic("GatewayApplicationRepositoryMock.RenderPodsFunc: method is nil but GatewayApplicationRepository.RenderPods was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		Cluster     domain.KubeCluster
+		Application *v1beta2.SparkApplication
+	}{
+		Ctx:         ctx,
+		Cluster:     cluster,
+		Application: application,
+	}
+	mock.lockRenderPods.Lock()
+	mock.calls.RenderPods = append(mock.calls.RenderPods, callInfo)
+	mock.lockRenderPods.Unlock()
+	return mock.RenderPodsFunc(ctx, cluster, application)
+}
+
+// RenderPodsCalls gets all the calls that were made to RenderPods.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationRepository.RenderPodsCalls())
+func (mock *GatewayApplicationRepositoryMock) RenderPodsCalls() []struct {
+	Ctx         context.Context
+	Cluster     domain.KubeCluster
+	Application *v1beta2.SparkApplication
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Cluster     domain.KubeCluster
+		Application *v1beta2.SparkApplication
+	}
+	mock.lockRenderPods.RLock()
+	calls = mock.calls.RenderPods
+	mock.lockRenderPods.RUnlock()
+	return calls
+}
+
+// Scale calls ScaleFunc.
+func (mock *GatewayApplicationRepositoryMock) Scale(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, scale domain.ExecutorScale) (*v1beta2.SparkApplication, error) {
+	if mock.ScaleFunc == nil {
+		panic("GatewayApplicationRepositoryMock.ScaleFunc: method is nil but GatewayApplicationRepository.Scale was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Cluster   domain.KubeCluster
+		Namespace string
+		Name      string
+		Scale     domain.ExecutorScale
+	}{
+		Ctx:       ctx,
+		Cluster:   cluster,
+		Namespace: namespace,
+		Name:      name,
+		Scale:     scale,
+	}
+	mock.lockScale.Lock()
+	mock.calls.Scale = append(mock.calls.Scale, callInfo)
+	mock.lockScale.Unlock()
+	return mock.ScaleFunc(ctx, cluster, namespace, name, scale)
+}
+
+// ScaleCalls gets all the calls that were made to Scale.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationRepository.ScaleCalls())
+func (mock *GatewayApplicationRepositoryMock) ScaleCalls() []struct {
+	Ctx       context.Context
+	Cluster   domain.KubeCluster
+	Namespace string
+	Name      string
+	Scale     domain.ExecutorScale
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Cluster   domain.KubeCluster
+		Namespace string
+		Name      string
+		Scale     domain.ExecutorScale
+	}
+	mock.lockScale.RLock()
+	calls = mock.calls.Scale
+	mock.lockScale.RUnlock()
+	return calls
+}
+
 // Status calls StatusFunc.
-func (mock *GatewayApplicationRepositoryMock) Status(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*v1beta2.SparkApplicationStatus, error) {
+func (mock *GatewayApplicationRepositoryMock) Status(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (*domain.ApplicationStatus, error) {
 	if mock.StatusFunc == nil {
 		panic("GatewayApplicationRepositoryMock.StatusFunc: method is nil but GatewayApplicationRepository.Status was just called")
 	}
@@ -397,3 +1006,231 @@ func (mock *GatewayApplicationRepositoryMock) StatusCalls() []struct {
 	mock.lockStatus.RUnlock()
 	return calls
 }
+
+// StreamLogs calls StreamLogsFunc.
+func (mock *GatewayApplicationRepositoryMock) StreamLogs(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (io.ReadCloser, error) {
+	if mock.StreamLogsFunc == nil {
+		panic("GatewayApplicationRepositoryMock.StreamLogsFunc: method is nil but GatewayApplicationRepository.StreamLogs was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Cluster   domain.KubeCluster
+		Namespace string
+		Name      string
+	}{
+		Ctx:       ctx,
+		Cluster:   cluster,
+		Namespace: namespace,
+		Name:      name,
+	}
+	mock.lockStreamLogs.Lock()
+	mock.calls.StreamLogs = append(mock.calls.StreamLogs, callInfo)
+	mock.lockStreamLogs.Unlock()
+	return mock.StreamLogsFunc(ctx, cluster, namespace, name)
+}
+
+// StreamLogsCalls gets all the calls that were made to StreamLogs.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationRepository.StreamLogsCalls())
+func (mock *GatewayApplicationRepositoryMock) StreamLogsCalls() []struct {
+	Ctx       context.Context
+	Cluster   domain.KubeCluster
+	Namespace string
+	Name      string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Cluster   domain.KubeCluster
+		Namespace string
+		Name      string
+	}
+	mock.lockStreamLogs.RLock()
+	calls = mock.calls.StreamLogs
+	mock.lockStreamLogs.RUnlock()
+	return calls
+}
+
+// StreamStatus calls StreamStatusFunc.
+func (mock *GatewayApplicationRepositoryMock) StreamStatus(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) (io.ReadCloser, error) {
+	if mock.StreamStatusFunc == nil {
+		panic("GatewayApplicationRepositoryMock.StreamStatusFunc: method is nil but GatewayApplicationRepository.StreamStatus was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Cluster   domain.KubeCluster
+		Namespace string
+		Name      string
+	}{
+		Ctx:       ctx,
+		Cluster:   cluster,
+		Namespace: namespace,
+		Name:      name,
+	}
+	mock.lockStreamStatus.Lock()
+	mock.calls.StreamStatus = append(mock.calls.StreamStatus, callInfo)
+	mock.lockStreamStatus.Unlock()
+	return mock.StreamStatusFunc(ctx, cluster, namespace, name)
+}
+
+// StreamStatusCalls gets all the calls that were made to StreamStatus.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationRepository.StreamStatusCalls())
+func (mock *GatewayApplicationRepositoryMock) StreamStatusCalls() []struct {
+	Ctx       context.Context
+	Cluster   domain.KubeCluster
+	Namespace string
+	Name      string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Cluster   domain.KubeCluster
+		Namespace string
+		Name      string
+	}
+	mock.lockStreamStatus.RLock()
+	calls = mock.calls.StreamStatus
+	mock.lockStreamStatus.RUnlock()
+	return calls
+}
+
+// Timeline calls TimelineFunc.
+func (mock *GatewayApplicationRepositoryMock) Timeline(ctx context.Context, cluster domain.KubeCluster, namespace string, name string) ([]domain.TimelineEvent, error) {
+	if mock.TimelineFunc == nil {
+		panic("GatewayApplicationRepositoryMock.TimelineFunc: method is nil but GatewayApplicationRepository.Timeline was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Cluster   domain.KubeCluster
+		Namespace string
+		Name      string
+	}{
+		Ctx:       ctx,
+		Cluster:   cluster,
+		Namespace: namespace,
+		Name:      name,
+	}
+	mock.lockTimeline.Lock()
+	mock.calls.Timeline = append(mock.calls.Timeline, callInfo)
+	mock.lockTimeline.Unlock()
+	return mock.TimelineFunc(ctx, cluster, namespace, name)
+}
+
+// TimelineCalls gets all the calls that were made to Timeline.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationRepository.TimelineCalls())
+func (mock *GatewayApplicationRepositoryMock) TimelineCalls() []struct {
+	Ctx       context.Context
+	Cluster   domain.KubeCluster
+	Namespace string
+	Name      string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Cluster   domain.KubeCluster
+		Namespace string
+		Name      string
+	}
+	mock.lockTimeline.RLock()
+	calls = mock.calls.Timeline
+	mock.lockTimeline.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *GatewayApplicationRepositoryMock) Update(ctx context.Context, cluster domain.KubeCluster, namespace string, name string, update domain.ApplicationUpdate) (*v1beta2.SparkApplication, error) {
+	if mock.UpdateFunc == nil {
+		panic("GatewayApplicationRepositoryMock.UpdateFunc: method is nil but GatewayApplicationRepository.Update was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Cluster   domain.KubeCluster
+		Namespace string
+		Name      string
+		Update    domain.ApplicationUpdate
+	}{
+		Ctx:       ctx,
+		Cluster:   cluster,
+		Namespace: namespace,
+		Name:      name,
+		Update:    update,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(ctx, cluster, namespace, name, update)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationRepository.UpdateCalls())
+func (mock *GatewayApplicationRepositoryMock) UpdateCalls() []struct {
+	Ctx       context.Context
+	Cluster   domain.KubeCluster
+	Namespace string
+	Name      string
+	Update    domain.ApplicationUpdate
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Cluster   domain.KubeCluster
+		Namespace string
+		Name      string
+		Update    domain.ApplicationUpdate
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
+
+// Watch calls WatchFunc.
+func (mock *GatewayApplicationRepositoryMock) Watch(ctx context.Context, cluster domain.KubeCluster, namespace string, labelSelector string, resourceVersion string) (io.ReadCloser, error) {
+	if mock.WatchFunc == nil {
+		panic("GatewayApplicationRepositoryMock.WatchFunc: method is nil but GatewayApplicationRepository.Watch was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		Cluster         domain.KubeCluster
+		Namespace       string
+		LabelSelector   string
+		ResourceVersion string
+	}{
+		Ctx:             ctx,
+		Cluster:         cluster,
+		Namespace:       namespace,
+		LabelSelector:   labelSelector,
+		ResourceVersion: resourceVersion,
+	}
+	mock.lockWatch.Lock()
+	mock.calls.Watch = append(mock.calls.Watch, callInfo)
+	mock.lockWatch.Unlock()
+	return mock.WatchFunc(ctx, cluster, namespace, labelSelector, resourceVersion)
+}
+
+// WatchCalls gets all the calls that were made to Watch.
+// Check the length with:
+//
+//	len(mockedGatewayApplicationRepository.WatchCalls())
+func (mock *GatewayApplicationRepositoryMock) WatchCalls() []struct {
+	Ctx             context.Context
+	Cluster         domain.KubeCluster
+	Namespace       string
+	LabelSelector   string
+	ResourceVersion string
+} {
+	var calls []struct {
+		Ctx             context.Context
+		Cluster         domain.KubeCluster
+		Namespace       string
+		LabelSelector   string
+		ResourceVersion string
+	}
+	mock.lockWatch.RLock()
+	calls = mock.calls.Watch
+	mock.lockWatch.RUnlock()
+	return calls
+}
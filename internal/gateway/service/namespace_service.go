@@ -0,0 +1,146 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+//go:generate moq -rm  -out mocknamespaceprovisioner.go . NamespaceProvisioner
+
+type NamespaceProvisioner interface {
+	ProvisionNamespace(ctx context.Context, cluster domain.KubeCluster, namespace string, provisioning domain.NamespaceProvisioning) error
+}
+
+//go:generate moq -rm  -out mocknamespaceservice.go . NamespaceService
+
+type NamespaceService interface {
+	Register(ctx context.Context, cluster string, registration domain.NamespaceRegistration) (*domain.RegisteredNamespace, error)
+	SetWeight(ctx context.Context, cluster string, namespace string, user string, request domain.NamespaceWeightRequest) (*domain.NamespaceWeight, error)
+}
+
+type namespaceService struct {
+	clusterRepository repository.ClusterRepository
+	provisioner       NamespaceProvisioner
+	defaulter         func(namespace *domain.KubeNamespace)
+}
+
+// NewNamespaceService returns a NamespaceService registering namespaces in clusterRepository. defaulter sets the
+// defaults configured namespaces get on registered namespaces.
+func NewNamespaceService(clusterRepository repository.ClusterRepository, provisioner NamespaceProvisioner, defaulter func(namespace *domain.KubeNamespace)) NamespaceService {
+	return &namespaceService{
+		clusterRepository: clusterRepository,
+		provisioner:       provisioner,
+		defaulter:         defaulter,
+	}
+}
+
+// Register adds a namespace to cluster so GatewayApplications can be routed to it, provisioning it through the
+// cluster's SparkManager before it's added if requested. The registration is rejected before provisioning if the
+// namespace could not be added to the cluster's configuration or was already registered by another Gateway instance.
+func (s *namespaceService) Register(ctx context.Context, cluster string, registration domain.NamespaceRegistration) (*domain.RegisteredNamespace, error) {
+	if errMessages := validation.IsDNS1123Label(registration.Name); len(errMessages) > 0 {
+		return nil, gatewayerrors.NewBadRequest(fmt.Errorf("invalid namespace name '%s': %s", registration.Name, strings.Join(errMessages, ", ")))
+	}
+	if registration.RoutingWeight < 0 {
+		return nil, gatewayerrors.NewBadRequest(errors.New("routingWeight must not be negative"))
+	}
+
+	kubeCluster, err := s.clusterRepository.GetByName(cluster)
+	if err != nil {
+		return nil, gatewayerrors.NewNotFound(err)
+	}
+
+	namespace := domain.KubeNamespace{
+		Name:          registration.Name,
+		NamespaceId:   registration.NamespaceId,
+		RoutingWeight: registration.RoutingWeight,
+	}
+	s.defaulter(&namespace)
+
+	if ns, _ := kubeCluster.GetNamespaceByName(namespace.Name); ns != nil {
+		return nil, gatewayerrors.NewConflict(fmt.Errorf("namespace '%s' is already registered in cluster '%s'", namespace.Name, cluster))
+	}
+	candidate := *kubeCluster
+	candidate.Namespaces = append(append([]domain.KubeNamespace{}, kubeCluster.Namespaces...), namespace)
+	if errMessages := domain.ValidateCluster(candidate); len(errMessages) > 0 {
+		return nil, gatewayerrors.NewInvalid(errors.New(strings.Join(errMessages, ", ")))
+	}
+
+	var provision func() error
+	if registration.Provision {
+		serviceAccount := registration.ServiceAccount
+		if serviceAccount == "" {
+			serviceAccount = domain.DefaultDriverServiceAccount
+		}
+
+		provision = func() error {
+			if err := s.provisioner.ProvisionNamespace(ctx, *kubeCluster, namespace.Name, domain.NamespaceProvisioning{ServiceAccount: serviceAccount}); err != nil {
+				return gatewayerrors.New(gatewayerrors.NewFrom(err).Status, fmt.Errorf("error provisioning namespace '%s' in cluster '%s': %w", namespace.Name, cluster, err))
+			}
+			klog.Infof("Provisioned namespace '%s' in cluster '%s' with ServiceAccount '%s'", namespace.Name, cluster, serviceAccount)
+			return nil
+		}
+	}
+
+	if _, err := s.clusterRepository.AddNamespace(ctx, cluster, namespace, provision); err != nil {
+		return nil, gatewayerrors.NewFrom(err)
+	}
+
+	return &domain.RegisteredNamespace{
+		Cluster:       cluster,
+		Name:          namespace.Name,
+		NamespaceId:   namespace.NamespaceId,
+		RoutingWeight: namespace.RoutingWeight,
+		Provisioned:   registration.Provision,
+	}, nil
+}
+
+// SetWeight overrides the routing weight of a namespace registered in cluster, recording user as the one who set it
+func (s *namespaceService) SetWeight(ctx context.Context, cluster string, namespace string, user string, request domain.NamespaceWeightRequest) (*domain.NamespaceWeight, error) {
+	if request.RoutingWeight == nil {
+		return nil, gatewayerrors.NewBadRequest(errors.New("routingWeight is required"))
+	}
+	if *request.RoutingWeight < 0 {
+		return nil, gatewayerrors.NewBadRequest(errors.New("routingWeight must not be negative"))
+	}
+
+	weight, err := s.clusterRepository.SetNamespaceWeight(ctx, domain.NamespaceWeight{
+		Cluster:       cluster,
+		Namespace:     namespace,
+		RoutingWeight: *request.RoutingWeight,
+		UpdatedBy:     user,
+		UpdatedAt:     time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(err)
+	}
+
+	klog.Infof("User '%s' set the routing weight of namespace '%s' in cluster '%s' to %v", user, namespace, cluster, weight.RoutingWeight)
+
+	return weight, nil
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+func TestNamespaceServiceRegister(t *testing.T) {
+	testCases := []struct {
+		name                string
+		registration        domain.NamespaceRegistration
+		provisionErr        error
+		expectedStatus      int
+		expectedProvisioned []string
+		expected            *domain.RegisteredNamespace
+	}{
+		{
+			name:         "registers namespace with defaults",
+			registration: domain.NamespaceRegistration{Name: "team-a", NamespaceId: "teama"},
+			expected:     &domain.RegisteredNamespace{Cluster: "cluster", Name: "team-a", NamespaceId: "teama", RoutingWeight: 1.0},
+		},
+		{
+			name:                "provisions namespace with default ServiceAccount",
+			registration:        domain.NamespaceRegistration{Name: "team-a", NamespaceId: "teama", RoutingWeight: 2.0, Provision: true},
+			expectedProvisioned: []string{"team-a/spark"},
+			expected:            &domain.RegisteredNamespace{Cluster: "cluster", Name: "team-a", NamespaceId: "teama", RoutingWeight: 2.0, Provisioned: true},
+		},
+		{
+			name:                "provisioning failure does not register",
+			registration:        domain.NamespaceRegistration{Name: "team-a", NamespaceId: "teama", Provision: true, ServiceAccount: "driver"},
+			provisionErr:        gatewayerrors.NewForbidden(errors.New("forbidden")),
+			expectedProvisioned: []string{"team-a/driver"},
+			expectedStatus:      http.StatusForbidden,
+		},
+		{
+			name:           "already registered namespace conflicts",
+			registration:   domain.NamespaceRegistration{Name: "existing", NamespaceId: "other", Provision: true},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "invalid namespace id is rejected before provisioning",
+			registration:   domain.NamespaceRegistration{Name: "team-a", NamespaceId: "Team-A", Provision: true},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "invalid namespace name",
+			registration:   domain.NamespaceRegistration{Name: "Team_A", NamespaceId: "teama"},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clusterRepo, _ := repository.NewLocalClusterRepo([]domain.KubeCluster{
+				{
+					Name:       "cluster",
+					ClusterId:  "clus",
+					MasterURL:  "masterURL",
+					Namespaces: []domain.KubeNamespace{{Name: "existing", NamespaceId: "exist"}},
+				},
+			})
+
+			var provisioned []string
+			provisioner := &NamespaceProvisionerMock{
+				ProvisionNamespaceFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, provisioning domain.NamespaceProvisioning) error {
+					provisioned = append(provisioned, namespace+"/"+provisioning.ServiceAccount)
+					return tc.provisionErr
+				},
+			}
+
+			sgConfig := &config.SparkGatewayConfig{DefaultLogLines: 100}
+			namespaceService := NewNamespaceService(clusterRepo, provisioner, sgConfig.NamespaceDefaulter)
+
+			registered, err := namespaceService.Register(context.Background(), "cluster", tc.registration)
+
+			assert.Equal(t, tc.expectedProvisioned, provisioned, "provisioned namespaces should match")
+			if tc.expectedStatus != 0 {
+				assert.Equal(t, tc.expectedStatus, gatewayerrors.NewFrom(err).Status, "status should match")
+				if tc.registration.Name != "existing" {
+					assert.Empty(t, clusterRepo.GetAllWithNamespace(tc.registration.Name), "namespace should not be registered")
+				}
+				return
+			}
+
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, tc.expected, registered, "registered namespace should match")
+
+			cluster, _ := clusterRepo.GetByName("cluster")
+			namespace, _ := cluster.GetNamespaceByName(tc.registration.Name)
+			assert.Equal(t, 100, namespace.DefaultLogLines, "configured defaults should be applied")
+		})
+	}
+}
+
+func TestNamespaceServiceRegisterPersisted(t *testing.T) {
+	localRepo, _ := repository.NewLocalClusterRepo([]domain.KubeCluster{
+		{Name: "cluster", ClusterId: "clus", MasterURL: "masterURL"},
+	})
+	var persisted []string
+	db := &database.RegisteredNamespaceDatabaseMock{
+		InsertRegisteredNamespaceFunc: func(ctx context.Context, cluster string, namespace domain.KubeNamespace, now time.Time) (bool, error) {
+			// Registered by another Gateway instance since the last Refresh
+			if namespace.Name == "elsewhere" {
+				return false, nil
+			}
+			persisted = append(persisted, namespace.Name)
+			return true, nil
+		},
+		ListRegisteredNamespacesFunc: func(ctx context.Context) ([]database.RegisteredNamespace, error) {
+			return nil, nil
+		},
+		DeleteRegisteredNamespaceFunc: func(ctx context.Context, cluster string, namespace string) error {
+			persisted = persisted[:len(persisted)-1]
+			return nil
+		},
+	}
+	clusterRepo, _ := repository.NewDatabaseClusterRepo(context.Background(), localRepo, &database.NamespaceRoutingWeightDatabaseMock{
+		ListNamespaceRoutingWeightsFunc: func(ctx context.Context) ([]database.NamespaceRoutingWeight, error) {
+			return nil, nil
+		},
+	}, db)
+
+	var provisionErr error
+	provisioner := &NamespaceProvisionerMock{
+		ProvisionNamespaceFunc: func(ctx context.Context, cluster domain.KubeCluster, namespace string, provisioning domain.NamespaceProvisioning) error {
+			assert.Equal(t, []string{namespace}, persisted, "namespace should be persisted before it's provisioned")
+			return provisionErr
+		},
+	}
+	namespaceService := NewNamespaceService(clusterRepo, provisioner, func(*domain.KubeNamespace) {})
+
+	_, err := namespaceService.Register(context.Background(), "cluster", domain.NamespaceRegistration{Name: "elsewhere", NamespaceId: "elsewhere", Provision: true})
+	assert.Equal(t, http.StatusConflict, gatewayerrors.NewFrom(err).Status, "namespace registered elsewhere should conflict")
+	assert.Empty(t, provisioner.ProvisionNamespaceCalls(), "namespace registered elsewhere should not be provisioned")
+
+	provisionErr = gatewayerrors.NewForbidden(errors.New("forbidden"))
+	_, err = namespaceService.Register(context.Background(), "cluster", domain.NamespaceRegistration{Name: "team-a", NamespaceId: "teama", Provision: true})
+	assert.Equal(t, http.StatusForbidden, gatewayerrors.NewFrom(err).Status, "provisioning error should be returned")
+	assert.Empty(t, persisted, "namespace should not stay persisted if provisioning fails")
+
+	provisionErr = nil
+	_, err = namespaceService.Register(context.Background(), "cluster", domain.NamespaceRegistration{Name: "team-a", NamespaceId: "teama", Provision: true})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []string{"team-a"}, persisted, "namespace should be persisted")
+	assert.Len(t, localRepo.GetAllWithNamespace("team-a"), 1, "namespace should be registered")
+}
+
+func TestNamespaceServiceSetWeight(t *testing.T) {
+	zero, negative := 0.0, -1.0
+
+	testCases := []struct {
+		name           string
+		namespace      string
+		request        domain.NamespaceWeightRequest
+		expectedStatus int
+	}{
+		{
+			name:      "sets weight",
+			namespace: "existing",
+			request:   domain.NamespaceWeightRequest{RoutingWeight: &zero},
+		},
+		{
+			name:           "missing weight",
+			namespace:      "existing",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "negative weight",
+			namespace:      "existing",
+			request:        domain.NamespaceWeightRequest{RoutingWeight: &negative},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown namespace",
+			namespace:      "missing",
+			request:        domain.NamespaceWeightRequest{RoutingWeight: &zero},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clusterRepo, _ := repository.NewLocalClusterRepo([]domain.KubeCluster{
+				{
+					Name:       "cluster",
+					ClusterId:  "clus",
+					MasterURL:  "masterURL",
+					Namespaces: []domain.KubeNamespace{{Name: "existing", NamespaceId: "exist", RoutingWeight: 1}},
+				},
+			})
+			namespaceService := NewNamespaceService(clusterRepo, &NamespaceProvisionerMock{}, func(*domain.KubeNamespace) {})
+
+			weight, err := namespaceService.SetWeight(context.Background(), "cluster", tc.namespace, "admin", tc.request)
+
+			cluster, _ := clusterRepo.GetByName("cluster")
+			namespace, _ := cluster.GetNamespaceByName("existing")
+			if tc.expectedStatus != 0 {
+				assert.Equal(t, tc.expectedStatus, gatewayerrors.NewFrom(err).Status, "status should match")
+				assert.Equal(t, 1.0, namespace.RoutingWeight, "weight should be unchanged")
+				return
+			}
+
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, "admin", weight.UpdatedBy, "user should be recorded")
+			assert.False(t, weight.Persisted, "in memory weight should not be persisted")
+			assert.Equal(t, 0.0, namespace.RoutingWeight, "weight should be applied")
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/clusterrouter"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+//go:generate moq -rm  -out mockroutingsimulator.go . RoutingSimulator
+
+type RoutingSimulator interface {
+	Simulate(ctx context.Context, request domain.RoutingSimulationRequest) (*domain.RoutingSimulation, error)
+}
+
+type routingSimulator struct {
+	*service
+}
+
+// NewRoutingSimulator returns a RoutingSimulator routing with the same cluster routers as a GatewayApplicationService
+// created with the same arguments, without submitting anything
+func NewRoutingSimulator(clusterRepository repository.ClusterRepository, clusterRouter clusterrouter.ClusterRouter, fallbackClusterRouter clusterrouter.ClusterRouter) RoutingSimulator {
+	return &routingSimulator{
+		service: &service{
+			clusterRepository:     clusterRepository,
+			clusterRouter:         clusterRouter,
+			fallbackClusterRouter: fallbackClusterRouter,
+		},
+	}
+}
+
+// Simulate routes request.Count submissions to request.Namespace, falling back like submissions do, and counts the
+// clusters chosen. Submissions are never queued by concurrency caps, so full clusters show up as unroutable. Routers
+// reading cluster metrics see the same metrics for every simulated submission, since nothing is submitted.
+func (s *routingSimulator) Simulate(ctx context.Context, request domain.RoutingSimulationRequest) (*domain.RoutingSimulation, error) {
+	if request.Namespace == "" {
+		return nil, gatewayerrors.NewBadRequest(fmt.Errorf("namespace is required"))
+	}
+	if request.Count < 1 || request.Count > domain.MaxRoutingSimulationCount {
+		return nil, gatewayerrors.NewBadRequest(fmt.Errorf("count must be between 1 and %d, got %d", domain.MaxRoutingSimulationCount, request.Count))
+	}
+	if len(s.clusterRepository.GetAllWithNamespace(request.Namespace)) == 0 {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("namespace '%s' is not registered in any cluster", request.Namespace))
+	}
+
+	application := &v1beta2.SparkApplication{ObjectMeta: metav1.ObjectMeta{Name: "routing-simulation", Namespace: request.Namespace}}
+	simulation := &domain.RoutingSimulation{
+		Namespace: request.Namespace,
+		Count:     request.Count,
+		Clusters:  []domain.SimulatedClusterPick{},
+	}
+
+	picks := map[string]int{}
+	ctx = clusterrouter.WithoutQueueing(ctx)
+	for range request.Count {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		cluster, err := s.routeCluster(ctx, application)
+		if err != nil {
+			simulation.Unroutable++
+			simulation.Error = err.Error()
+			continue
+		}
+		picks[cluster.Name]++
+	}
+
+	for cluster, count := range picks {
+		simulation.Clusters = append(simulation.Clusters, domain.SimulatedClusterPick{
+			Cluster: cluster,
+			Count:   count,
+			Share:   float64(count) / float64(request.Count),
+		})
+	}
+	slices.SortFunc(simulation.Clusters, func(a, b domain.SimulatedClusterPick) int {
+		return cmp.Or(b.Count-a.Count, cmp.Compare(a.Cluster, b.Cluster))
+	})
+
+	return simulation, nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/clusterrouter"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+func TestRoutingSimulatorSimulate(t *testing.T) {
+	testCases := []struct {
+		name           string
+		request        domain.RoutingSimulationRequest
+		expectedStatus int
+		expected       *domain.RoutingSimulation
+	}{
+		{
+			name:    "counts clusters chosen and unroutable submissions",
+			request: domain.RoutingSimulationRequest{Namespace: "ns", Count: 4},
+			expected: &domain.RoutingSimulation{
+				Namespace: "ns",
+				Count:     4,
+				Clusters: []domain.SimulatedClusterPick{
+					{Cluster: "a", Count: 2, Share: 0.5},
+					{Cluster: "b", Count: 1, Share: 0.25},
+				},
+				Unroutable: 1,
+				Error:      "error getting routing cluster: no cluster",
+			},
+		},
+		{
+			name:           "count out of range",
+			request:        domain.RoutingSimulationRequest{Namespace: "ns", Count: domain.MaxRoutingSimulationCount + 1},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing namespace",
+			request:        domain.RoutingSimulationRequest{Count: 1},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown namespace",
+			request:        domain.RoutingSimulationRequest{Namespace: "missing", Count: 1},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clusterRepo, _ := repository.NewLocalClusterRepo([]domain.KubeCluster{
+				{Name: "a", ClusterId: "a", MasterURL: "masterURL", Namespaces: []domain.KubeNamespace{{Name: "ns", NamespaceId: "ns"}}},
+				{Name: "b", ClusterId: "b", MasterURL: "masterURL", Namespaces: []domain.KubeNamespace{{Name: "ns", NamespaceId: "ns"}}},
+			})
+
+			// The primary router alternates between routing to cluster a and failing, the fallback routes to cluster b once
+			picks := 0
+			clusterRouter := &clusterrouter.ClusterRouterMock{
+				GetClusterFunc: func(ctx context.Context, namespace string) (*domain.KubeCluster, error) {
+					picks++
+					if picks%2 == 0 {
+						return nil, errors.New("cluster a is full")
+					}
+					return clusterRepo.GetByName("a")
+				},
+			}
+			fallbacks := 0
+			fallbackRouter := &clusterrouter.ClusterRouterMock{
+				GetClusterFunc: func(ctx context.Context, namespace string) (*domain.KubeCluster, error) {
+					fallbacks++
+					if fallbacks > 1 {
+						return nil, errors.New("no cluster")
+					}
+					return clusterRepo.GetByName("b")
+				},
+			}
+
+			simulator := NewRoutingSimulator(clusterRepo, clusterRouter, fallbackRouter)
+			simulation, err := simulator.Simulate(context.Background(), tc.request)
+
+			if tc.expectedStatus != 0 {
+				assert.Equal(t, tc.expectedStatus, gatewayerrors.NewFrom(err).Status, "status should match")
+				assert.Empty(t, clusterRouter.GetClusterCalls(), "nothing should be routed")
+				return
+			}
+
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, tc.expected, simulation, "simulation should match")
+		})
+	}
+}
@@ -0,0 +1,330 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/metrics"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+// scheduleLease is how long a due schedule is reserved for the instance submitting its run. Any instance submits it
+// once the lease runs out, e.g. because the instance submitting it died.
+const scheduleLease = 2 * time.Minute
+
+//go:generate moq -rm -out mockscheduleservice.go . ScheduleService
+
+// ScheduleService manages ScheduledApplications, which submit a SparkApplication as a new GatewayApplication every time
+// their cron expression matches
+type ScheduleService interface {
+	Create(ctx context.Context, request domain.ScheduleRequest, user string) (*domain.ScheduledApplication, error)
+	Get(ctx context.Context, scheduleId string) (*domain.ScheduledApplication, error)
+	List(ctx context.Context, namespace string) ([]*domain.ScheduledApplication, error)
+	SetPaused(ctx context.Context, scheduleId string, paused bool) (*domain.ScheduledApplication, error)
+	Delete(ctx context.Context, scheduleId string) error
+	Runs(ctx context.Context, scheduleId string) ([]*domain.ScheduledRun, error)
+}
+
+// Scheduler stores ScheduledApplications in the database and submits their runs through appService, so they get the
+// same routing, kill switches and validation as any submission. Runs missed while no instance was running, or while a
+// schedule was paused, are skipped rather than submitted late, except for the latest one.
+type Scheduler struct {
+	appService GatewayApplicationService
+	db         database.ScheduledApplicationDatabase
+	config     config.SchedulesConfig
+	now        func() time.Time
+}
+
+func NewScheduler(appService GatewayApplicationService, db database.ScheduledApplicationDatabase, config config.SchedulesConfig) *Scheduler {
+	return &Scheduler{
+		appService: appService,
+		db:         db,
+		config:     config,
+		now:        time.Now,
+	}
+}
+
+// Create stores a schedule submitting request.Application on behalf of user, starting from the next time its cron
+// expression matches
+func (s *Scheduler) Create(ctx context.Context, request domain.ScheduleRequest, user string) (*domain.ScheduledApplication, error) {
+	cron, err := domain.ParseCronSchedule(request.Cron)
+	if err != nil {
+		return nil, gatewayerrors.NewBadRequest(err)
+	}
+
+	application := request.Application.DeepCopy()
+	if application.Namespace == "" {
+		return nil, gatewayerrors.NewBadRequest(errors.New("the scheduled SparkApplication must set its namespace"))
+	}
+	if err := domain.NewValidationError(domain.ValidateApplicationNames(application)); err != nil {
+		return nil, gatewayerrors.NewInvalid(fmt.Errorf("invalid scheduled SparkApplication names: %w", err))
+	}
+	// Every run is a new submission, so nothing read back from a previous one is kept
+	application.ObjectMeta = metav1.ObjectMeta{
+		Name:        application.Name,
+		Namespace:   application.Namespace,
+		Labels:      application.Labels,
+		Annotations: application.Annotations,
+	}
+	application.Status = v1beta2.SparkApplicationStatus{}
+
+	scheduleId, err := uuid.NewV7()
+	if err != nil {
+		return nil, gatewayerrors.NewInternal(fmt.Errorf("error generating schedule id: %w", err))
+	}
+
+	now := s.now().UTC()
+	schedule := database.ScheduledApplication{
+		ScheduleID:  scheduleId.String(),
+		Cron:        request.Cron,
+		Namespace:   application.Namespace,
+		Username:    user,
+		Application: application,
+		NextRunAt:   cron.Next(now),
+		CreatedAt:   now,
+	}
+	if err := s.db.InsertScheduledApplication(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("error creating schedule: %w", err)
+	}
+
+	return newScheduledApplication(schedule), nil
+}
+
+func (s *Scheduler) Get(ctx context.Context, scheduleId string) (*domain.ScheduledApplication, error) {
+	schedule, err := s.get(ctx, scheduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	return newScheduledApplication(*schedule), nil
+}
+
+// List returns the schedules in namespace, or every schedule if it is empty
+func (s *Scheduler) List(ctx context.Context, namespace string) ([]*domain.ScheduledApplication, error) {
+	schedules, err := s.db.ListScheduledApplications(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduledApps := []*domain.ScheduledApplication{}
+	for _, schedule := range schedules {
+		scheduledApps = append(scheduledApps, newScheduledApplication(schedule))
+	}
+
+	return scheduledApps, nil
+}
+
+// SetPaused pauses or resumes a schedule. Resumed schedules next run the next time their cron expression matches.
+func (s *Scheduler) SetPaused(ctx context.Context, scheduleId string, paused bool) (*domain.ScheduledApplication, error) {
+	schedule, err := s.get(ctx, scheduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	nextRunAt := schedule.NextRunAt
+	if !paused && schedule.Paused {
+		cron, err := domain.ParseCronSchedule(schedule.Cron)
+		if err != nil {
+			return nil, gatewayerrors.NewInternal(fmt.Errorf("schedule '%s' has an invalid cron expression: %w", scheduleId, err))
+		}
+		nextRunAt = cron.Next(now)
+	}
+
+	updated, err := s.db.SetScheduledApplicationPaused(ctx, scheduleId, paused, nextRunAt, now)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("schedule '%s' not found", scheduleId))
+	}
+
+	schedule.Paused, schedule.NextRunAt, schedule.UpdatedAt = paused, nextRunAt, now
+	return newScheduledApplication(*schedule), nil
+}
+
+// Delete deletes a schedule and the history of its runs. GatewayApplications it submitted are left as is.
+func (s *Scheduler) Delete(ctx context.Context, scheduleId string) error {
+	deleted, err := s.db.DeleteScheduledApplication(ctx, scheduleId)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return gatewayerrors.NewNotFound(fmt.Errorf("schedule '%s' not found", scheduleId))
+	}
+
+	return nil
+}
+
+// Runs returns the latest runs of a schedule, latest first
+func (s *Scheduler) Runs(ctx context.Context, scheduleId string) ([]*domain.ScheduledRun, error) {
+	if _, err := s.get(ctx, scheduleId); err != nil {
+		return nil, err
+	}
+
+	runs, err := s.db.ListScheduledApplicationRuns(ctx, scheduleId, s.config.History)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduledRuns := []*domain.ScheduledRun{}
+	for _, run := range runs {
+		scheduledRun := &domain.ScheduledRun{ScheduledTime: run.ScheduledAt, CreationTime: run.CreatedAt}
+		if run.GatewayID != nil {
+			scheduledRun.GatewayId = *run.GatewayID
+		}
+		if run.Error != nil {
+			scheduledRun.Error = *run.Error
+		}
+		scheduledRuns = append(scheduledRuns, scheduledRun)
+	}
+
+	return scheduledRuns, nil
+}
+
+func (s *Scheduler) get(ctx context.Context, scheduleId string) (*database.ScheduledApplication, error) {
+	schedule, err := s.db.GetScheduledApplication(ctx, scheduleId)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("schedule '%s' not found", scheduleId))
+	}
+
+	return schedule, nil
+}
+
+// Run calls Tick every PollInterval until ctx is done
+func (s *Scheduler) Run(ctx context.Context) {
+	klog.Infof("Starting scheduler with poll interval %s", s.config.PollInterval)
+
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.Tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick submits the runs of up to BatchSize schedules that are due
+func (s *Scheduler) Tick(ctx context.Context) {
+	now := s.now().UTC()
+
+	schedules, err := s.db.ClaimDueScheduledApplications(ctx, now, now.Add(scheduleLease), s.config.BatchSize)
+	if err != nil {
+		klog.Errorf("error claiming due schedules: %v", err)
+		return
+	}
+
+	for _, schedule := range schedules {
+		s.submit(ctx, schedule)
+	}
+}
+
+// submit submits the due run of a claimed schedule and records it, moving the schedule on to the next time its cron
+// expression matches. Failed runs are recorded with their error and not retried.
+func (s *Scheduler) submit(ctx context.Context, schedule database.ScheduledApplication) {
+	run := database.ScheduledApplicationRun{ScheduleID: schedule.ScheduleID, ScheduledAt: schedule.NextRunAt}
+
+	cron, cronErr := domain.ParseCronSchedule(schedule.Cron)
+	if cronErr != nil {
+		// Cron expressions are checked when schedules are created, so this is only reached if the database was edited
+		klog.Errorf("pausing schedule '%s', it has an invalid cron expression: %v", schedule.ScheduleID, cronErr)
+		s.pauseInvalid(ctx, schedule, run, cronErr)
+		return
+	}
+
+	application := schedule.Application.DeepCopy()
+	if application.Annotations == nil {
+		application.Annotations = map[string]string{}
+	}
+	application.Annotations[domain.GATEWAY_SCHEDULE_ANNOTATION] = schedule.ScheduleID
+
+	submitCtx, cancel := context.WithTimeout(ctx, submissionTimeout)
+	gatewayApp, err := s.appService.Create(submitCtx, application, schedule.Username)
+	cancel()
+
+	result := "submitted"
+	if err != nil {
+		result = "failed"
+		runErr := err.Error()
+		run.Error = &runErr
+		klog.Warningf("Run of schedule '%s' due at %s failed: %v", schedule.ScheduleID, schedule.NextRunAt, err)
+	} else {
+		run.GatewayID = &gatewayApp.GatewayId
+		klog.Infof("Submitted GatewayApplication '%s' for the run of schedule '%s' due at %s", gatewayApp.GatewayId, schedule.ScheduleID, schedule.NextRunAt)
+	}
+	metrics.ScheduledRunsTotal.WithLabelValues(schedule.Namespace, result).Inc()
+
+	now := s.now().UTC()
+	run.CreatedAt = now
+	if err := s.db.CompleteScheduledApplicationRun(ctx, run, cron.Next(now), s.config.History); err != nil {
+		// The lease runs out and the run is submitted again
+		klog.Errorf("error recording the run of schedule '%s' due at %s: %v", schedule.ScheduleID, schedule.NextRunAt, err)
+	}
+}
+
+// pauseInvalid pauses schedule, whose cron expression can't be parsed, and records its due run as failed with err so it
+// isn't claimed again every lease until the schedule is fixed. The schedule's next run is left as it is.
+func (s *Scheduler) pauseInvalid(ctx context.Context, schedule database.ScheduledApplication, run database.ScheduledApplicationRun, err error) {
+	now := s.now().UTC()
+	if _, pauseErr := s.db.SetScheduledApplicationPaused(ctx, schedule.ScheduleID, true, schedule.NextRunAt, now); pauseErr != nil {
+		// The lease runs out and pausing is attempted again
+		klog.Errorf("error pausing schedule '%s': %v", schedule.ScheduleID, pauseErr)
+		return
+	}
+	metrics.ScheduledRunsTotal.WithLabelValues(schedule.Namespace, "failed").Inc()
+
+	runErr := fmt.Sprintf("invalid cron expression, the schedule was paused: %v", err)
+	run.Error = &runErr
+	run.CreatedAt = now
+	if err := s.db.CompleteScheduledApplicationRun(ctx, run, schedule.NextRunAt, s.config.History); err != nil {
+		klog.Errorf("error recording the run of schedule '%s' due at %s: %v", schedule.ScheduleID, schedule.NextRunAt, err)
+	}
+}
+
+// newScheduledApplication returns the ScheduledApplication stored as schedule
+func newScheduledApplication(schedule database.ScheduledApplication) *domain.ScheduledApplication {
+	return &domain.ScheduledApplication{
+		ScheduleId:   schedule.ScheduleID,
+		Cron:         schedule.Cron,
+		Namespace:    schedule.Namespace,
+		User:         schedule.Username,
+		Paused:       schedule.Paused,
+		NextRunTime:  schedule.NextRunAt,
+		LastRunTime:  schedule.LastRunAt,
+		CreationTime: schedule.CreatedAt,
+		Application:  schedule.Application,
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+var scheduleNow = time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+
+func newTestScheduler(appService GatewayApplicationService, db database.ScheduledApplicationDatabase) *Scheduler {
+	scheduler := NewScheduler(appService, db, config.SchedulesConfig{Enable: true, PollInterval: time.Second, BatchSize: 10, History: 5})
+	scheduler.now = func() time.Time { return scheduleNow }
+	return scheduler
+}
+
+func scheduledSparkApplication() v1beta2.SparkApplication {
+	return v1beta2.SparkApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "nightly",
+			Namespace:       "ns",
+			Labels:          map[string]string{"team": "data"},
+			ResourceVersion: "42",
+		},
+		Status: v1beta2.SparkApplicationStatus{SparkApplicationID: "spark-1"},
+	}
+}
+
+func TestSchedulerCreate(t *testing.T) {
+	var inserted database.ScheduledApplication
+	mockDatabase := &database.ScheduledApplicationDatabaseMock{
+		InsertScheduledApplicationFunc: func(ctx context.Context, schedule database.ScheduledApplication) error {
+			inserted = schedule
+			return nil
+		},
+	}
+
+	schedule, err := newTestScheduler(&GatewayApplicationServiceMock{}, mockDatabase).Create(context.Background(), domain.ScheduleRequest{Cron: "0 2 * * *", Application: scheduledSparkApplication()}, "alice")
+	assert.NoError(t, err)
+
+	assert.NotEmpty(t, schedule.ScheduleId)
+	assert.Equal(t, inserted.ScheduleID, schedule.ScheduleId)
+	assert.Equal(t, "ns", schedule.Namespace)
+	assert.Equal(t, "alice", schedule.User)
+	assert.Equal(t, time.Date(2025, 1, 2, 2, 0, 0, 0, time.UTC), schedule.NextRunTime)
+	assert.Equal(t, map[string]string{"team": "data"}, inserted.Application.Labels)
+	assert.Empty(t, inserted.Application.ResourceVersion, "metadata set by Kubernetes should not be stored")
+	assert.Empty(t, inserted.Application.Status.SparkApplicationID, "status should not be stored")
+}
+
+func TestSchedulerCreateErrors(t *testing.T) {
+	noNamespace := scheduledSparkApplication()
+	noNamespace.Namespace = ""
+	invalidName := scheduledSparkApplication()
+	invalidName.Name = strings.Repeat("a", 300)
+
+	tests := []struct {
+		name    string
+		request domain.ScheduleRequest
+	}{
+		{name: "invalid cron", request: domain.ScheduleRequest{Cron: "every day", Application: scheduledSparkApplication()}},
+		{name: "no namespace", request: domain.ScheduleRequest{Cron: "@daily", Application: noNamespace}},
+		{name: "invalid name", request: domain.ScheduleRequest{Cron: "@daily", Application: invalidName}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := newTestScheduler(&GatewayApplicationServiceMock{}, &database.ScheduledApplicationDatabaseMock{}).Create(context.Background(), test.request, "alice")
+
+			var gatewayErr gatewayerrors.GatewayError
+			assert.ErrorAs(t, err, &gatewayErr)
+			assert.Less(t, gatewayErr.Status, http.StatusInternalServerError)
+		})
+	}
+}
+
+func TestSchedulerSetPaused(t *testing.T) {
+	stale := time.Date(2024, 6, 1, 2, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		paused      bool
+		wantNextRun time.Time
+	}{
+		{name: "pause keeps the next run", paused: true, wantNextRun: stale},
+		{name: "resume skips missed runs", paused: false, wantNextRun: time.Date(2025, 1, 2, 2, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var setNextRunAt time.Time
+			mockDatabase := &database.ScheduledApplicationDatabaseMock{
+				GetScheduledApplicationFunc: func(ctx context.Context, scheduleId string) (*database.ScheduledApplication, error) {
+					return &database.ScheduledApplication{ScheduleID: scheduleId, Cron: "0 2 * * *", Paused: !test.paused, NextRunAt: stale}, nil
+				},
+				SetScheduledApplicationPausedFunc: func(ctx context.Context, scheduleId string, paused bool, nextRunAt time.Time, now time.Time) (bool, error) {
+					assert.Equal(t, test.paused, paused)
+					setNextRunAt = nextRunAt
+					return true, nil
+				},
+			}
+
+			schedule, err := newTestScheduler(&GatewayApplicationServiceMock{}, mockDatabase).SetPaused(context.Background(), "schedule", test.paused)
+			assert.NoError(t, err)
+			assert.Equal(t, test.paused, schedule.Paused)
+			assert.Equal(t, test.wantNextRun, setNextRunAt)
+			assert.Equal(t, test.wantNextRun, schedule.NextRunTime)
+		})
+	}
+}
+
+func TestSchedulerNotFound(t *testing.T) {
+	mockDatabase := &database.ScheduledApplicationDatabaseMock{
+		GetScheduledApplicationFunc: func(ctx context.Context, scheduleId string) (*database.ScheduledApplication, error) {
+			return nil, nil
+		},
+		DeleteScheduledApplicationFunc: func(ctx context.Context, scheduleId string) (bool, error) {
+			return false, nil
+		},
+	}
+	scheduler := newTestScheduler(&GatewayApplicationServiceMock{}, mockDatabase)
+
+	var gatewayErr gatewayerrors.GatewayError
+
+	_, err := scheduler.Get(context.Background(), "missing")
+	assert.ErrorAs(t, err, &gatewayErr)
+	assert.Equal(t, http.StatusNotFound, gatewayErr.Status)
+
+	_, err = scheduler.Runs(context.Background(), "missing")
+	assert.ErrorAs(t, err, &gatewayErr)
+	assert.Equal(t, http.StatusNotFound, gatewayErr.Status)
+
+	err = scheduler.Delete(context.Background(), "missing")
+	assert.ErrorAs(t, err, &gatewayErr)
+	assert.Equal(t, http.StatusNotFound, gatewayErr.Status)
+}
+
+func TestSchedulerTickInvalidCron(t *testing.T) {
+	due := time.Date(2025, 1, 1, 2, 0, 0, 0, time.UTC)
+	app := scheduledSparkApplication()
+
+	var paused bool
+	var pausedNextRunAt time.Time
+	var completed database.ScheduledApplicationRun
+	var completedNextRunAt time.Time
+	mockDatabase := &database.ScheduledApplicationDatabaseMock{
+		ClaimDueScheduledApplicationsFunc: func(ctx context.Context, now time.Time, leaseUntil time.Time, size int) ([]database.ScheduledApplication, error) {
+			return []database.ScheduledApplication{{ScheduleID: "schedule", Cron: "not a cron", Namespace: "ns", Username: "alice", Application: &app, NextRunAt: due}}, nil
+		},
+		SetScheduledApplicationPausedFunc: func(ctx context.Context, scheduleId string, p bool, nextRunAt time.Time, now time.Time) (bool, error) {
+			assert.Equal(t, "schedule", scheduleId)
+			paused, pausedNextRunAt = p, nextRunAt
+			return true, nil
+		},
+		CompleteScheduledApplicationRunFunc: func(ctx context.Context, run database.ScheduledApplicationRun, nextRunAt time.Time, keepRuns int) error {
+			completed, completedNextRunAt = run, nextRunAt
+			return nil
+		},
+	}
+
+	newTestScheduler(&GatewayApplicationServiceMock{}, mockDatabase).Tick(context.Background())
+
+	assert.True(t, paused, "schedules with an invalid cron expression should be paused")
+	assert.Equal(t, due, pausedNextRunAt, "the next run should be kept")
+	assert.Equal(t, due, completed.ScheduledAt, "the due run should be recorded")
+	assert.Equal(t, due, completedNextRunAt, "the next run should be kept")
+	assert.Nil(t, completed.GatewayID)
+	if assert.NotNil(t, completed.Error, "the run should be recorded as failed") {
+		assert.Contains(t, *completed.Error, "invalid cron expression, the schedule was paused")
+	}
+}
+
+func TestSchedulerTick(t *testing.T) {
+	due := time.Date(2025, 1, 1, 2, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		createErr     error
+		wantGatewayId string
+		wantError     string
+	}{
+		{name: "records submitted GatewayId", wantGatewayId: "gateway-id"},
+		{name: "records failed runs", createErr: errors.New("namespace 'ns' is disabled"), wantError: "namespace 'ns' is disabled"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			app := scheduledSparkApplication()
+
+			var submitted *v1beta2.SparkApplication
+			mockAppService := &GatewayApplicationServiceMock{
+				CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication, user string) (*domain.GatewayApplication, error) {
+					assert.Equal(t, "alice", user, "runs should be submitted on behalf of the schedule's user")
+					submitted = application
+					if test.createErr != nil {
+						return nil, test.createErr
+					}
+					return &domain.GatewayApplication{GatewayId: "gateway-id"}, nil
+				},
+			}
+
+			var completed database.ScheduledApplicationRun
+			var completedNextRunAt time.Time
+			mockDatabase := &database.ScheduledApplicationDatabaseMock{
+				ClaimDueScheduledApplicationsFunc: func(ctx context.Context, now time.Time, leaseUntil time.Time, size int) ([]database.ScheduledApplication, error) {
+					assert.Equal(t, scheduleNow, now)
+					assert.Equal(t, scheduleNow.Add(scheduleLease), leaseUntil)
+					assert.Equal(t, 10, size)
+					return []database.ScheduledApplication{{ScheduleID: "schedule", Cron: "0 2 * * *", Namespace: "ns", Username: "alice", Application: &app, NextRunAt: due}}, nil
+				},
+				CompleteScheduledApplicationRunFunc: func(ctx context.Context, run database.ScheduledApplicationRun, nextRunAt time.Time, keepRuns int) error {
+					completed, completedNextRunAt = run, nextRunAt
+					assert.Equal(t, 5, keepRuns)
+					return nil
+				},
+			}
+
+			newTestScheduler(mockAppService, mockDatabase).Tick(context.Background())
+
+			assert.Equal(t, "schedule", submitted.Annotations[domain.GATEWAY_SCHEDULE_ANNOTATION])
+			assert.Empty(t, app.Annotations, "the stored SparkApplication should not be modified")
+
+			assert.Equal(t, due, completed.ScheduledAt)
+			assert.Equal(t, time.Date(2025, 1, 2, 2, 0, 0, 0, time.UTC), completedNextRunAt)
+			if test.wantGatewayId != "" {
+				assert.Equal(t, test.wantGatewayId, *completed.GatewayID)
+				assert.Nil(t, completed.Error)
+			} else {
+				assert.Nil(t, completed.GatewayID)
+				assert.Equal(t, test.wantError, *completed.Error)
+			}
+		})
+	}
+}
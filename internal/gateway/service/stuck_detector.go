@@ -0,0 +1,225 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/metrics"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+)
+
+//go:generate moq -rm -out mockstuckapplicationdetector.go . StuckApplicationDetector
+
+// StuckApplicationDetector lists the GatewayApplications found stuck by the latest detection
+type StuckApplicationDetector interface {
+	List() []domain.StuckApplication
+}
+
+// stuckNotifyTimeout bounds each webhook notification so a slow receiver doesn't hold up detection
+const stuckNotifyTimeout = 10 * time.Second
+
+type stuckApplicationDetector struct {
+	appService        GatewayApplicationReader
+	clusterRepository repository.ClusterRepository
+	config            config.StuckApplicationsConfig
+	httpClient        *http.Client
+	now               func() time.Time
+
+	mu       sync.RWMutex
+	stuck    map[string][]domain.StuckApplication
+	notified map[string]bool
+}
+
+func NewStuckApplicationDetector(appService GatewayApplicationReader, clusterRepository repository.ClusterRepository, config config.StuckApplicationsConfig) *stuckApplicationDetector {
+	return &stuckApplicationDetector{
+		appService:        appService,
+		clusterRepository: clusterRepository,
+		config:            config,
+		httpClient:        &http.Client{Timeout: stuckNotifyTimeout},
+		now:               time.Now,
+		stuck:             map[string][]domain.StuckApplication{},
+		notified:          map[string]bool{},
+	}
+}
+
+// List returns the stuck applications of every cluster, longest stuck first
+func (d *stuckApplicationDetector) List() []domain.StuckApplication {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	stuck := []domain.StuckApplication{}
+	for _, clusterStuck := range d.stuck {
+		stuck = append(stuck, clusterStuck...)
+	}
+	sort.Slice(stuck, func(i, j int) bool {
+		if stuck[i].Since.Equal(stuck[j].Since) {
+			return stuck[i].GatewayId < stuck[j].GatewayId
+		}
+		return stuck[i].Since.Before(stuck[j].Since)
+	})
+
+	return stuck
+}
+
+// Run calls Detect every config.Interval until ctx is done
+func (d *stuckApplicationDetector) Run(ctx context.Context) {
+	klog.Infof("Starting stuck application detector with interval %s and threshold %s", d.config.Interval, d.config.Threshold)
+
+	ticker := time.NewTicker(d.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			klog.Infof("Stopping stuck application detector")
+			return
+		case <-ticker.C:
+			if err := d.Detect(ctx); err != nil {
+				klog.Errorf("error detecting stuck applications: %v", err)
+			}
+		}
+	}
+}
+
+// Detect lists the applications of every cluster and records those in domain.StuckStates since before
+// config.Threshold. Clusters that can't be listed keep the stuck applications of the previous detection.
+func (d *stuckApplicationDetector) Detect(ctx context.Context) error {
+	var errs []error
+	cutoff := d.now().Add(-d.config.Threshold)
+
+	d.mu.RLock()
+	stuckByCluster := make(map[string][]domain.StuckApplication, len(d.stuck))
+	for cluster, clusterStuck := range d.stuck {
+		stuckByCluster[cluster] = clusterStuck
+	}
+	d.mu.RUnlock()
+
+	for _, cluster := range d.clusterRepository.GetAll() {
+		appSummaries, err := d.appService.List(ctx, cluster.Name, "", domain.SummaryViewSlim, domain.ListSort{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error listing applications in cluster '%s': %w", cluster.Name, err))
+			continue
+		}
+
+		clusterStuck := []domain.StuckApplication{}
+		counts := map[string]float64{}
+		for _, appSummary := range appSummaries {
+			state := appSummary.Status.AppState.State
+			if !slices.Contains(domain.StuckStates, state) {
+				continue
+			}
+
+			since := appSummary.Status.LastSubmissionAttemptTime.Time
+			if since.IsZero() {
+				since = appSummary.CreationTimestamp.Time
+			}
+			if !since.Before(cutoff) {
+				continue
+			}
+
+			clusterStuck = append(clusterStuck, domain.StuckApplication{
+				GatewayId: appSummary.GatewayId,
+				Cluster:   cluster.Name,
+				Namespace: appSummary.Namespace,
+				User:      appSummary.User,
+				State:     state,
+				Since:     since,
+			})
+			counts[string(state)]++
+		}
+
+		stuckByCluster[cluster.Name] = clusterStuck
+		for _, state := range domain.StuckStates {
+			metrics.StuckApplications.WithLabelValues(cluster.Name, string(state)).Set(counts[string(state)])
+		}
+	}
+
+	d.mu.Lock()
+	d.stuck = stuckByCluster
+	newlyStuck := d.newlyStuck()
+	d.mu.Unlock()
+
+	for _, app := range newlyStuck {
+		klog.Warningf("GatewayApplication '%s' of user '%s' in cluster '%s' has been %s since %s", app.GatewayId, app.User, app.Cluster, app.State, app.Since.Format(time.RFC3339))
+		if err := d.notify(ctx, app); err != nil {
+			errs = append(errs, fmt.Errorf("error notifying stuck application '%s': %w", app.GatewayId, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// newlyStuck returns the stuck applications that weren't stuck in the previous detection, and forgets applications that
+// are no longer stuck so they are notified again if they get stuck again. d.mu must be held.
+func (d *stuckApplicationDetector) newlyStuck() []domain.StuckApplication {
+	var newlyStuck []domain.StuckApplication
+	notified := map[string]bool{}
+	for _, clusterStuck := range d.stuck {
+		for _, app := range clusterStuck {
+			if !d.notified[app.GatewayId] {
+				newlyStuck = append(newlyStuck, app)
+			}
+			notified[app.GatewayId] = true
+		}
+	}
+	d.notified = notified
+
+	return newlyStuck
+}
+
+// notify posts app as JSON to config.WebhookURL, if set
+func (d *stuckApplicationDetector) notify(ctx context.Context, app domain.StuckApplication) error {
+	if d.config.WebhookURL == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(app)
+	if err != nil {
+		return fmt.Errorf("error marshaling stuck application: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.config.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error calling webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+)
+
+func stuckSummary(gatewayId string, state v1beta2.ApplicationStateType, created time.Time, submitted time.Time) *domain.GatewayApplicationSummary {
+	return &domain.GatewayApplicationSummary{
+		SparkManagerSparkApplicationSummary: domain.SparkManagerSparkApplicationSummary{
+			GatewayApplicationMeta: domain.GatewayApplicationMeta{
+				Name:              gatewayId,
+				Namespace:         "ns",
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Status: v1beta2.SparkApplicationStatus{
+				AppState:                  v1beta2.ApplicationState{State: state},
+				LastSubmissionAttemptTime: metav1.NewTime(submitted),
+			},
+		},
+		GatewayId: gatewayId,
+		User:      "alice",
+	}
+}
+
+func TestStuckApplicationDetectorDetect(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-time.Hour)
+	recent := now.Add(-time.Minute)
+
+	testCases := []struct {
+		name          string
+		summaries     []*domain.GatewayApplicationSummary
+		expectedStuck []string
+	}{
+		{
+			name:          "flags submitted application past threshold",
+			summaries:     []*domain.GatewayApplicationSummary{stuckSummary("submitted", v1beta2.ApplicationStateSubmitted, old, old)},
+			expectedStuck: []string{"submitted"},
+		},
+		{
+			name:          "flags pending rerun application past threshold",
+			summaries:     []*domain.GatewayApplicationSummary{stuckSummary("rerun", v1beta2.ApplicationStatePendingRerun, old, old)},
+			expectedStuck: []string{"rerun"},
+		},
+		{
+			name:          "skips recently submitted application",
+			summaries:     []*domain.GatewayApplicationSummary{stuckSummary("resubmitted", v1beta2.ApplicationStateSubmitted, old, recent)},
+			expectedStuck: []string{},
+		},
+		{
+			name:          "uses creation time before first submission",
+			summaries:     []*domain.GatewayApplicationSummary{stuckSummary("unsubmitted", v1beta2.ApplicationStateSubmitted, old, time.Time{})},
+			expectedStuck: []string{"unsubmitted"},
+		},
+		{
+			name:          "skips running application",
+			summaries:     []*domain.GatewayApplicationSummary{stuckSummary("running", v1beta2.ApplicationStateRunning, old, old)},
+			expectedStuck: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAppService := &GatewayApplicationServiceMock{
+				ListFunc: func(ctx context.Context, cluster string, namespace string, view domain.SummaryView, listSort domain.ListSort) ([]*domain.GatewayApplicationSummary, error) {
+					assert.Equal(t, "", namespace, "all namespaces should be listed")
+					return tc.summaries, nil
+				},
+			}
+			mockClusterRepo := &repository.ClusterRepositoryMock{
+				GetAllFunc: func() []domain.KubeCluster {
+					return []domain.KubeCluster{{Name: "cluster"}}
+				},
+			}
+
+			detector := NewStuckApplicationDetector(mockAppService, mockClusterRepo, config.StuckApplicationsConfig{Enable: true, Interval: time.Minute, Threshold: 30 * time.Minute})
+			detector.now = func() time.Time { return now }
+
+			err := detector.Detect(context.Background())
+			assert.NoError(t, err, "Detect should not return error")
+
+			stuck := []string{}
+			for _, app := range detector.List() {
+				assert.Equal(t, "cluster", app.Cluster, "cluster should be set")
+				assert.Equal(t, "alice", app.User, "user should be set")
+				stuck = append(stuck, app.GatewayId)
+			}
+			assert.Equal(t, tc.expectedStuck, stuck, "stuck applications should match")
+		})
+	}
+}
+
+func TestStuckApplicationDetectorNotify(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-time.Hour)
+
+	var notified []domain.StuckApplication
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var app domain.StuckApplication
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&app), "webhook body should be a StuckApplication")
+		notified = append(notified, app)
+	}))
+	defer webhook.Close()
+
+	summaries := []*domain.GatewayApplicationSummary{stuckSummary("submitted", v1beta2.ApplicationStateSubmitted, old, old)}
+	mockAppService := &GatewayApplicationServiceMock{
+		ListFunc: func(ctx context.Context, cluster string, namespace string, view domain.SummaryView, listSort domain.ListSort) ([]*domain.GatewayApplicationSummary, error) {
+			return summaries, nil
+		},
+	}
+	mockClusterRepo := &repository.ClusterRepositoryMock{
+		GetAllFunc: func() []domain.KubeCluster {
+			return []domain.KubeCluster{{Name: "cluster"}}
+		},
+	}
+
+	detector := NewStuckApplicationDetector(mockAppService, mockClusterRepo, config.StuckApplicationsConfig{Enable: true, Threshold: 30 * time.Minute, WebhookURL: webhook.URL})
+	detector.now = func() time.Time { return now }
+
+	assert.NoError(t, detector.Detect(context.Background()), "first Detect should not return error")
+	assert.NoError(t, detector.Detect(context.Background()), "second Detect should not return error")
+	assert.Len(t, notified, 1, "a stuck application should only be notified once")
+	assert.Equal(t, "submitted", notified[0].GatewayId, "notified gatewayId should match")
+	assert.Equal(t, "alice", notified[0].User, "notified user should match")
+
+	// Once it is no longer stuck, getting stuck again notifies again
+	summaries = nil
+	assert.NoError(t, detector.Detect(context.Background()), "Detect should not return error")
+	summaries = []*domain.GatewayApplicationSummary{stuckSummary("submitted", v1beta2.ApplicationStatePendingRerun, old, old)}
+	assert.NoError(t, detector.Detect(context.Background()), "Detect should not return error")
+	assert.Len(t, notified, 2, "an application stuck again should be notified again")
+}
+
+func TestStuckApplicationDetectorListError(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	listErr := error(nil)
+
+	mockAppService := &GatewayApplicationServiceMock{
+		ListFunc: func(ctx context.Context, cluster string, namespace string, view domain.SummaryView, listSort domain.ListSort) ([]*domain.GatewayApplicationSummary, error) {
+			if listErr != nil {
+				return nil, listErr
+			}
+			return []*domain.GatewayApplicationSummary{stuckSummary("submitted", v1beta2.ApplicationStateSubmitted, now.Add(-time.Hour), now.Add(-time.Hour))}, nil
+		},
+	}
+	mockClusterRepo := &repository.ClusterRepositoryMock{
+		GetAllFunc: func() []domain.KubeCluster {
+			return []domain.KubeCluster{{Name: "cluster"}}
+		},
+	}
+
+	detector := NewStuckApplicationDetector(mockAppService, mockClusterRepo, config.StuckApplicationsConfig{Enable: true, Threshold: 30 * time.Minute})
+	detector.now = func() time.Time { return now }
+
+	assert.NoError(t, detector.Detect(context.Background()), "Detect should not return error")
+
+	listErr = errors.New("unavailable")
+	err := detector.Detect(context.Background())
+
+	assert.ErrorContains(t, err, "error listing applications in cluster 'cluster'", "List error should be returned")
+	assert.Len(t, detector.List(), 1, "stuck applications of an unlisted cluster should be kept")
+}
@@ -17,8 +17,11 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/file"
@@ -33,8 +36,27 @@ const (
 	ServiceAccountAuthType = "serviceaccount"
 )
 
+// SpeculativeMaxScheduleTimeout is the longest speculativeSubmission.scheduleTimeout allowed. A speculative create
+// request is held until a driver runs, and must be answered within the 30s timeout of the Gateway's HTTP clients,
+// including the CLI, or the client would see an error for an application that was created and kept.
+const SpeculativeMaxScheduleTimeout = 20 * time.Second
+
+// ModeLocal runs every cluster's SparkManager on an in-memory backend instead of a Kubernetes cluster
+const ModeLocal = "local"
+
+// Field validation modes for submitted SparkApplications, named like the fieldValidation of the Kubernetes API
+const (
+	// FieldValidationIgnore drops unknown fields silently
+	FieldValidationIgnore = "Ignore"
+	// FieldValidationWarn accepts submissions with unknown fields, returning a Warning header for each
+	FieldValidationWarn = "Warn"
+	// FieldValidationStrict rejects submissions with unknown fields
+	FieldValidationStrict = "Strict"
+)
+
 // Valid values
 var validClusterAuthTypes []string = []string{KubeConfigAuthType, ServiceAccountAuthType}
+var ValidFieldValidations []string = []string{FieldValidationIgnore, FieldValidationWarn, FieldValidationStrict}
 
 type ClusterRouterType string
 
@@ -59,15 +81,37 @@ var validClusterRouterDimensionTypes = []ClusterRouterDimensionType{
 }
 
 type PrometheusQuery struct {
-	Metric           string            `koanf:"metric"`
-	AdditionalLabels map[string]string `koanf:"additionalLabels"`
+	Metric           string            `koanf:"metric" desc:"Prometheus metric weightBased routing queries for cluster load"`
+	AdditionalLabels map[string]string `koanf:"additionalLabels" desc:"Label matchers added to the metric query"`
 }
 
 type ClusterRouter struct {
-	Type            ClusterRouterType          `koanf:"type"`
-	FallbackType    ClusterRouterType          `koanf:"fallbackType"`
-	Dimension       ClusterRouterDimensionType `koanf:"dimension"`
-	PrometheusQuery PrometheusQuery            `koanf:"prometheusQuery"`
+	Type            ClusterRouterType          `koanf:"type" default:"weightBasedRandom" desc:"Router picking the cluster of new applications: random, weightBased or weightBasedRandom"`
+	FallbackType    ClusterRouterType          `koanf:"fallbackType" default:"weightBasedRandom" desc:"Router used when type fails, e.g. when Prometheus can't be queried"`
+	Dimension       ClusterRouterDimensionType `koanf:"dimension" desc:"Whether routing weights and metrics are per namespace or per cluster"`
+	PrometheusQuery PrometheusQuery            `koanf:"prometheusQuery" desc:"Query of the weightBased router"`
+	QuotaExclusion  QuotaExclusion             `koanf:"quotaExclusion" desc:"Excludes clusters where the namespace's ResourceQuota is nearly exhausted"`
+	ConcurrencyCap  ConcurrencyCap             `koanf:"concurrencyCap" desc:"Excludes clusters at their maxActiveApplications"`
+}
+
+// ConcurrencyCap stops routing to clusters whose non-terminal SparkApplications, as counted by SparkManager's
+// spark_application_count metric, have reached the cluster's MaxActiveApplications. Counts are scraped from SparkManager
+// and cached for CacheTTL. When every cluster of a namespace is at its cap, submissions wait up to QueueTimeout for one
+// to drop below it, checking every PollInterval, and are then rejected with 429.
+type ConcurrencyCap struct {
+	Enable       bool          `koanf:"enable" desc:"Enables the concurrency cap"`
+	CacheTTL     time.Duration `koanf:"cacheTTL" default:"10s" desc:"How long application counts scraped from SparkManager are cached"`
+	QueueTimeout time.Duration `koanf:"queueTimeout" desc:"How long submissions wait when every cluster is at its cap, 0 rejects them immediately"`
+	PollInterval time.Duration `koanf:"pollInterval" default:"5s" desc:"How often waiting submissions check the clusters again"`
+}
+
+// QuotaExclusion stops routing a namespace to clusters where its ResourceQuota is nearly exhausted. A cluster is
+// ineligible while the namespace's utilization, the highest used/hard ratio across its quotas as reported by
+// SparkManager, is at or above Threshold. Utilization is scraped from SparkManager and cached for CacheTTL.
+type QuotaExclusion struct {
+	Enable    bool          `koanf:"enable" desc:"Enables quota exclusion"`
+	Threshold float64       `koanf:"threshold" default:"0.9" desc:"Utilization, greater than 0 and at most 1, at which a cluster is excluded"`
+	CacheTTL  time.Duration `koanf:"cacheTTL" default:"30s" desc:"How long quota utilization scraped from SparkManager is cached"`
 }
 
 type UnmarshalableConfig interface {
@@ -95,24 +139,269 @@ type BasicAllowAuthServiceConfig struct {
 }
 
 type Database struct {
-	Enable       bool   `koanf:"enable"`
-	DatabaseName string `koanf:"databaseName"`
-	Hostname     string `koanf:"hostname"`
-	Port         string `koanf:"port"`
-	Username     string `koanf:"username"`
-	Password     string `koanf:"password"`
+	Enable       bool                     `koanf:"enable" desc:"Enables storing SparkApplications in a Postgres database"`
+	DatabaseName string                   `koanf:"databaseName" desc:"Name of the database"`
+	Hostname     string                   `koanf:"hostname" required:"true" desc:"Database hostname"`
+	Port         string                   `koanf:"port" required:"true" desc:"Database port"`
+	Username     string                   `koanf:"username" required:"true" desc:"Database username"`
+	Password     string                   `koanf:"password" secret:"true" desc:"Database password, read from the DB_PASSWORD environment variable if unset"`
+	Reconciler   DatabaseReconcilerConfig `koanf:"reconciler" desc:"Sweep comparing database rows with the cluster's SparkApplications"`
+	Events       DatabaseEventsConfig     `koanf:"events" desc:"Retention of SparkApplication lifecycle events"`
 }
 
+// DatabaseEventsConfig configures how long SparkApplication lifecycle events are kept. Namespaces can override
+// Retention with their eventRetention. Each SparkManager deletes its cluster's expired events every SweepInterval.
+type DatabaseEventsConfig struct {
+	Retention     time.Duration `koanf:"retention" desc:"How long events are kept, 0 keeps them forever"`
+	SweepInterval time.Duration `koanf:"sweepInterval" desc:"How often expired events are deleted, 1h when unset"`
+}
+
+// DatabaseReconcilerConfig configures the SparkManager sweep comparing spark_applications rows with the cluster's
+// SparkApplications. An Interval of 0 disables the sweep. Rows younger than GracePeriod are skipped so applications
+// that haven't reached the informer cache yet aren't marked lost.
+type DatabaseReconcilerConfig struct {
+	Interval    time.Duration `koanf:"interval" desc:"How often the sweep runs, 0 disables it"`
+	GracePeriod time.Duration `koanf:"gracePeriod" desc:"Age below which rows are skipped, 10m when the sweep is enabled"`
+}
+
+// MiddlewareRouteGroup is a group of Gateway routes middleware can be scoped to
+type MiddlewareRouteGroup string
+
+var APIRouteGroup MiddlewareRouteGroup = "api"
+var LivyRouteGroup MiddlewareRouteGroup = "livy"
+var AdminRouteGroup MiddlewareRouteGroup = "admin"
+var UIRouteGroup MiddlewareRouteGroup = "ui"
+var MetricsRouteGroup MiddlewareRouteGroup = "metrics"
+
+var validMiddlewareRouteGroups = []MiddlewareRouteGroup{
+	APIRouteGroup,
+	LivyRouteGroup,
+	AdminRouteGroup,
+	UIRouteGroup,
+	MetricsRouteGroup,
+}
+
+// MiddlewareDefinition configures a middleware. Middleware run in ascending Order, those with the same Order in the
+// order they are listed. A middleware applies to its Routes, or to every route group except metrics when Routes is
+// unset, so the metrics route stays unauthenticated unless a middleware is scoped to it.
 type MiddlewareDefinition struct {
-	Type string         `koanf:"type"`
-	Conf map[string]any `koanf:"conf"`
+	Type   string                 `koanf:"type" required:"true" desc:"Middleware type"`
+	Conf   map[string]any         `koanf:"conf" desc:"Configuration of the middleware type"`
+	Order  int                    `koanf:"order" desc:"Middleware run in ascending order"`
+	Routes []MiddlewareRouteGroup `koanf:"routes" desc:"Route groups the middleware applies to: api, livy, admin, ui or metrics. Unset applies to all but metrics"`
+}
+
+// AppliesTo returns whether the middleware applies to routeGroup
+func (m MiddlewareDefinition) AppliesTo(routeGroup MiddlewareRouteGroup) bool {
+	if len(m.Routes) == 0 {
+		return routeGroup != MetricsRouteGroup
+	}
+	return util.ValueExists(routeGroup, m.Routes)
 }
 
 type GatewayConfig struct {
-	GatewayPort        string                    `koanf:"gatewayPort"`
-	Middleware         []MiddlewareDefinition    `koanf:"middleware"`
-	StatusUrlTemplates domain.StatusUrlTemplates `koanf:"statusUrlTemplates"`
-	EnableSwaggerUI    bool                      `koanf:"enableSwaggerUI"`
+	GatewayPort        string                    `koanf:"gatewayPort" desc:"Port the Gateway listens on"`
+	AdminPort          string                    `koanf:"adminPort" desc:"Port serving the admin API and pprof separately from gatewayPort"`
+	Middleware         []MiddlewareDefinition    `koanf:"middleware" desc:"Authentication and authorization middleware"`
+	StatusUrlTemplates domain.StatusUrlTemplates `koanf:"statusUrlTemplates" desc:"Templates of the UI links returned with application status"`
+	EnableSwaggerUI    bool                      `koanf:"enableSwaggerUI" desc:"Serves the Swagger UI on /swagger"`
+	ResponseCache      ResponseCacheConfig       `koanf:"responseCache" desc:"Per gatewayId response caching"`
+	Deduplication      DeduplicationConfig       `koanf:"requestDeduplication" desc:"Collapsing of identical SparkManager reads in flight"`
+	WebUI              WebUIConfig               `koanf:"webUI" desc:"Dashboard served on /ui"`
+	WaitStatus         WaitStatusConfig          `koanf:"waitStatus" desc:"Long-poll status endpoint"`
+	AdminUsers         []string                  `koanf:"adminUsers" desc:"Users allowed to call the admin API"`
+	Speculative        SpeculativeConfig         `koanf:"speculativeSubmission" desc:"Speculative submission to the top 2 routed clusters"`
+	AnonymousReadOnly  AnonymousReadOnlyConfig   `koanf:"anonymousReadOnly" desc:"Unauthenticated read only access to applications"`
+	StuckApplications  StuckApplicationsConfig   `koanf:"stuckApplications" desc:"Detection of applications stuck before their driver runs"`
+	FaultInjection     FaultInjectionConfig      `koanf:"faultInjection" desc:"Fault injection into calls to SparkManagers, for resilience testing"`
+	FieldValidation    string                    `koanf:"fieldValidation" default:"Ignore" desc:"How unknown and duplicate fields of submitted SparkApplications are handled: Ignore, Warn or Strict"`
+	HistoryServer      HistoryServerConfig       `koanf:"historyServer" desc:"Spark History Server summaries of completed applications"`
+	ClientIP           ClientIPConfig            `koanf:"clientIP" desc:"How the client IP of requests is found behind proxies"`
+	SoftQuota          SoftQuotaConfig           `koanf:"softQuota" desc:"Warnings in submission responses for namespaces nearly out of ResourceQuota"`
+	RoutingWeights     RoutingWeightsConfig      `koanf:"routingWeights" desc:"Namespace routing weights set at runtime through the admin API"`
+	KillSwitches       KillSwitchesConfig        `koanf:"killSwitches" desc:"Namespace kill switches engaged through the admin API"`
+	LatencyBudget      LatencyBudgetConfig       `koanf:"latencyBudget" desc:"Per request latency budgets for the v1 API"`
+	AsyncSubmission    AsyncSubmissionConfig     `koanf:"asyncSubmission" desc:"Submissions queued in the database with async=true"`
+	Schedules          SchedulesConfig           `koanf:"schedules" desc:"Scheduled submissions created from cron expressions"`
+	GatewayIdGenerator string                    `koanf:"gatewayIdGenerator" default:"uuidv7" desc:"Scheme GatewayIds are generated with: uuidv7, uuidv4, snowflake or a generator registered with service.RegisterGatewayIdGenerator"`
+	ReadOnly           bool                      `koanf:"readOnly" desc:"Only serves routes reading applications, for replicas serving dashboards and reporting"`
+	RBAC               RBACConfig                `koanf:"rbac" desc:"Roles of users enforced on the V1 and Livy APIs"`
+}
+
+// AsyncSubmissionConfig configures submissions made with async=true, which are queued in the database and return their
+// GatewayId before SparkManager is called. Every Gateway instance drains the queue every PollInterval, submitting up to
+// BatchSize applications at a time. Failed attempts are retried with exponential backoff from InitialBackoff up to
+// MaxBackoff until MaxAttempts have been made. Submitted and failed entries are kept for Retention.
+type AsyncSubmissionConfig struct {
+	Enable         bool          `koanf:"enable" desc:"Enables asynchronous submissions, requires the database"`
+	PollInterval   time.Duration `koanf:"pollInterval" default:"5s" desc:"How often the queue is drained"`
+	BatchSize      int           `koanf:"batchSize" default:"10" desc:"How many queued applications an instance submits per poll"`
+	MaxAttempts    int           `koanf:"maxAttempts" default:"20" desc:"How many times submitting an application is attempted before it fails"`
+	InitialBackoff time.Duration `koanf:"initialBackoff" default:"10s" desc:"Delay before the second attempt, doubled after each failed attempt"`
+	MaxBackoff     time.Duration `koanf:"maxBackoff" default:"5m" desc:"Cap on the delay between attempts"`
+	Retention      time.Duration `koanf:"retention" default:"24h" desc:"How long submitted and failed entries are kept so their submission state can be read"`
+}
+
+// SchedulesConfig configures ScheduledApplications, which are stored in the database and submit their SparkApplication
+// every time their cron expression matches. Every Gateway instance checks for due schedules every PollInterval,
+// submitting up to BatchSize at a time, and keeps the History latest runs of each schedule.
+type SchedulesConfig struct {
+	Enable       bool          `koanf:"enable" desc:"Enables scheduled submissions, requires the database"`
+	PollInterval time.Duration `koanf:"pollInterval" default:"15s" desc:"How often due schedules are submitted"`
+	BatchSize    int           `koanf:"batchSize" default:"10" desc:"How many due schedules an instance submits per poll"`
+	History      int           `koanf:"history" default:"100" desc:"How many of the latest runs of each schedule are kept"`
+}
+
+// LatencyBudgetConfig bounds how long v1 API requests take, except streaming ones. The budget of a request is the
+// duration in its X-Spark-Gateway-Latency-Budget header, capped at Max, or Default. Routing may use RoutingShare of it
+// and retrying routing with the fallback router RoutingRetryShare, leaving the rest to the SparkManager call. Requests
+// running out of budget fail with a 504 describing how it was spent.
+type LatencyBudgetConfig struct {
+	Enable            bool          `koanf:"enable" desc:"Enables latency budgets"`
+	Default           time.Duration `koanf:"default" desc:"Budget of requests without a latency budget header, 0 leaves them unbounded"`
+	Max               time.Duration `koanf:"max" desc:"Cap on the budget requested in the header, 0 for no cap"`
+	RoutingShare      float64       `koanf:"routingShare" default:"0.25" desc:"Share of the budget routing may use, between 0 and 1"`
+	RoutingRetryShare float64       `koanf:"routingRetryShare" default:"0.25" desc:"Share of the budget routing with the fallback router may use, between 0 and 1"`
+}
+
+// RoutingWeightsConfig configures how namespaces registered and routing weights set through the admin API are shared.
+// When the database is enabled, they are persisted and every Gateway instance reloads them every RefreshInterval,
+// otherwise they are held in memory by the instance that received the request until it restarts.
+type RoutingWeightsConfig struct {
+	RefreshInterval time.Duration `koanf:"refreshInterval" default:"30s" desc:"How often persisted namespaces and routing weights are reloaded from the database"`
+}
+
+// KillSwitchesConfig configures how namespace kill switches engaged through the admin API are shared. When the database
+// is enabled, they are persisted and every Gateway instance reloads them every RefreshInterval, otherwise they are held
+// in memory by the instance that received the request until it restarts.
+type KillSwitchesConfig struct {
+	RefreshInterval time.Duration `koanf:"refreshInterval" default:"10s" desc:"How often persisted kill switches are reloaded from the database"`
+}
+
+// SoftQuotaConfig adds a warning to the response of submissions admitted to a cluster where the namespace's
+// ResourceQuota utilization, as reported by SparkManager's namespace_quota_utilization metric, is at least Threshold.
+// Utilization is cached per cluster for CacheTTL.
+type SoftQuotaConfig struct {
+	Enable    bool          `koanf:"enable" desc:"Enables soft quota warnings"`
+	Threshold float64       `koanf:"threshold" default:"0.8" desc:"ResourceQuota utilization, greater than 0 and at most 1, from which submissions are warned"`
+	CacheTTL  time.Duration `koanf:"cacheTTL" default:"30s" desc:"How long quota utilization scraped from SparkManager is cached"`
+}
+
+// ClientIPConfig configures the client IP recorded in access and audit logs. Headers are only read for requests whose
+// direct peer is one of TrustedProxies, and are then walked from the right, skipping trusted hops, so clients can't spoof
+// their IP. Without TrustedProxies the peer's address is always used.
+type ClientIPConfig struct {
+	TrustedProxies []string `koanf:"trustedProxies" desc:"IPs and CIDRs of the proxies whose forwarded headers are trusted"`
+	Headers        []string `koanf:"headers" desc:"Headers holding the client IP, in order of precedence, X-Forwarded-For and X-Real-IP when unset"`
+}
+
+// HistoryServerConfig configures summaries of terminal GatewayApplications read from the Spark History Server REST API
+// at URLTemplate, rendered with the cluster's name as clusterName. Summaries are cached by SparkApplicationID for
+// CacheTTL since completed applications don't change.
+type HistoryServerConfig struct {
+	Enable      bool          `koanf:"enable" desc:"Adds Spark History Server summaries to terminal GatewayApplications"`
+	URLTemplate string        `koanf:"urlTemplate" required:"true" desc:"Template of the Spark History Server URL of a cluster, e.g. http://spark-history.{{.clusterName}}:18080"`
+	Timeout     time.Duration `koanf:"timeout" default:"5s" desc:"How long a Get waits for the Spark History Server before returning without a summary"`
+	CacheTTL    time.Duration `koanf:"cacheTTL" default:"1h" desc:"How long summaries are cached"`
+}
+
+// FaultInjectionConfig allows admins to inject latency, errors and connection resets into the calls made to
+// SparkManagers by the Gateway, or to the Kubernetes API by SparkManager, at runtime. No faults are injected until a
+// rule is set. Never enable it in production.
+type FaultInjectionConfig struct {
+	Enable bool `koanf:"enable" desc:"Enables the fault injection endpoints"`
+}
+
+// StuckApplicationsConfig configures the detector listing the GatewayApplications of every cluster every Interval and
+// flagging those in domain.StuckStates for longer than Threshold. When WebhookURL is set, each newly stuck application
+// is posted to it once, without retries, so the owner can be notified.
+type StuckApplicationsConfig struct {
+	Enable     bool          `koanf:"enable" desc:"Enables the stuck application detector"`
+	Interval   time.Duration `koanf:"interval" default:"1m" desc:"How often applications are checked"`
+	Threshold  time.Duration `koanf:"threshold" default:"30m" desc:"How long an application can stay SUBMITTED or PENDING_RERUN before it is stuck"`
+	WebhookURL string        `koanf:"webhookUrl" secret:"true" desc:"URL newly stuck applications are posted to as JSON"`
+}
+
+// AnonymousReadOnlyConfig allows requests to /api/v1 that no middleware authenticated to get, list and read the status
+// of applications in Namespaces, with spec values that may hold secrets redacted. Every other route still requires
+// authentication.
+type AnonymousReadOnlyConfig struct {
+	Enable     bool     `koanf:"enable" desc:"Enables anonymous read only access"`
+	Namespaces []string `koanf:"namespaces" required:"true" desc:"Namespaces whose applications can be read anonymously"`
+}
+
+// RBACConfig enforces the roles of users on the V1 and Livy APIs. Users get the most privileged Role of the Bindings
+// listing them or one of their `groups`, as set by e.g. LDAPGroupMiddleware, and DefaultRole if none do. AdminUsers are
+// always admins. Viewers can only get and list applications and submitters can only change and delete the applications
+// and schedules they own.
+type RBACConfig struct {
+	Enable      bool          `koanf:"enable" desc:"Enforces roles"`
+	DefaultRole domain.Role   `koanf:"defaultRole" default:"submitter" desc:"Role of users no binding lists: admin, submitter or viewer"`
+	Bindings    []RoleBinding `koanf:"bindings" desc:"Roles of users and groups"`
+}
+
+// RoleBinding gives Role to Users and to the members of Groups
+type RoleBinding struct {
+	Role   domain.Role `koanf:"role" desc:"admin, submitter or viewer"`
+	Users  []string    `koanf:"users" desc:"Users given the role"`
+	Groups []string    `koanf:"groups" desc:"Groups whose members are given the role"`
+}
+
+// Role returns the role of user, a member of groups
+func (c RBACConfig) Role(user string, groups []string) domain.Role {
+	var roles []domain.Role
+	for _, binding := range c.Bindings {
+		if slices.Contains(binding.Users, user) || slices.ContainsFunc(groups, func(group string) bool {
+			return slices.Contains(binding.Groups, group)
+		}) {
+			roles = append(roles, binding.Role)
+		}
+	}
+	return domain.HighestRole(roles, c.DefaultRole)
+}
+
+// SpeculativeConfig configures speculative submissions, which applications opt in to with the
+// spark-gateway/speculative annotation. A speculative submission is created in the top 2 routed clusters and the
+// Gateway keeps whichever driver is running first, checking every PollInterval. The other copy is deleted. If neither
+// driver runs within ScheduleTimeout, at most SpeculativeMaxScheduleTimeout, the copy in the first ranked cluster is
+// kept.
+type SpeculativeConfig struct {
+	Enable          bool          `koanf:"enable" desc:"Enables speculative submissions"`
+	PollInterval    time.Duration `koanf:"pollInterval" default:"1s" desc:"How often the drivers of both copies are checked"`
+	ScheduleTimeout time.Duration `koanf:"scheduleTimeout" default:"15s" desc:"How long to wait for either driver to run, at most 20s"`
+}
+
+// WaitStatusConfig configures the long-poll status endpoint. A waiting request re-reads the status every PollInterval
+// and is held for at most MaxTimeout. Combine with responseCache.statusTTL so many waiters on the same application
+// share one SparkManager request per TTL.
+type WaitStatusConfig struct {
+	PollInterval time.Duration `koanf:"pollInterval" default:"2s" desc:"How often a waiting request re-reads the status"`
+	MaxTimeout   time.Duration `koanf:"maxTimeout" default:"1m" desc:"Longest a request is held"`
+}
+
+// WebUIConfig configures the dashboard served on /ui. When BasicAuthRealm is set, responses from /ui carry a Basic
+// auth challenge so browsers prompt for credentials when a basic auth middleware rejects the request.
+type WebUIConfig struct {
+	Enable         bool   `koanf:"enable" desc:"Enables the dashboard"`
+	BasicAuthRealm string `koanf:"basicAuthRealm" desc:"Realm of the Basic auth challenge sent with /ui responses"`
+}
+
+// ResponseCacheConfig sets how long responses are cached per gatewayId for each route. A TTL of 0 disables caching
+// for that route.
+type ResponseCacheConfig struct {
+	GetTTL    time.Duration `koanf:"getTTL" desc:"TTL of cached application get responses, 0 disables caching"`
+	StatusTTL time.Duration `koanf:"statusTTL" desc:"TTL of cached application status responses, 0 disables caching"`
+}
+
+func (r ResponseCacheConfig) Enabled() bool {
+	return r.GetTTL > 0 || r.StatusTTL > 0
+}
+
+// DeduplicationConfig collapses concurrent identical Get, Status and Logs calls to SparkManager, for the same cluster,
+// namespace and name, into a single call whose response is shared by every caller.
+type DeduplicationConfig struct {
+	Enable  bool          `koanf:"enable" desc:"Enables deduplication of identical SparkManager reads in flight"`
+	Timeout time.Duration `koanf:"timeout" default:"30s" desc:"How long a deduplicated SparkManager read may take, independently of the callers waiting on it"`
 }
 
 func (g *GatewayConfig) Key() string {
@@ -120,13 +409,65 @@ func (g *GatewayConfig) Key() string {
 }
 
 type MetricsServer struct {
-	Endpoint string `koanf:"endpoint"`
-	Port     string `koanf:"port"`
+	Endpoint                    string `koanf:"endpoint" desc:"Path SparkManager serves metrics on"`
+	Port                        string `koanf:"port" desc:"Port SparkManager serves metrics on"`
+	AllocationFromExecutorState bool   `koanf:"allocationFromExecutorState" desc:"Counts the active executors in status.executorState of dynamic allocation applications in cpu_allocated and memory_allocated_bytes instead of maxExecutors"`
+	ExecutorPods                bool   `koanf:"executorPods" desc:"Exports the running_executor_pods gauge, also exported when the cluster router queries it"`
 }
 
 type SparkManagerConfig struct {
-	ClusterAuthType string        `koanf:"clusterAuthType"`
-	MetricsServer   MetricsServer `koanf:"metricsServer"`
+	ClusterAuthType  string                 `koanf:"clusterAuthType" desc:"How SparkManager authenticates to its cluster: kubeconfig or serviceaccount"`
+	FaultInjection   FaultInjectionConfig   `koanf:"faultInjection" desc:"Fault injection into calls to the Kubernetes API, for resilience testing"`
+	GarbageCollector GarbageCollectorConfig `koanf:"garbageCollector" desc:"Garbage collection of terminal SparkApplications submitted through the Gateway"`
+	Informers        InformerConfig         `koanf:"informers" desc:"Informers caching SparkApplications, executor pods and ResourceQuotas"`
+	Local            LocalBackendConfig     `koanf:"local" desc:"In-memory backend SparkManager uses in local mode"`
+	MetricsServer    MetricsServer          `koanf:"metricsServer" desc:"SparkManager metrics server"`
+	OrphanSweeper    OrphanSweeperConfig    `koanf:"orphanSweeper" desc:"Sweep deleting pods, services and configmaps left behind by deleted SparkApplications"`
+	PermissionCheck  PermissionCheckConfig  `koanf:"permissionCheck" desc:"Startup check of SparkManager's Kubernetes permissions against those it needs"`
+	RequestTimeout   time.Duration          `koanf:"requestTimeout" default:"30s" desc:"Deadline of the Kubernetes calls made for an API request, except watches and log downloads"`
+}
+
+// InformerConfig configures the informers SparkManager caches cluster resources with. Namespace scoped informers only
+// cache the cluster's configured namespaces, shrinking SparkManager's memory on shared clusters where few namespaces are
+// managed by the Gateway, at the cost of a watch per namespace.
+type InformerConfig struct {
+	ResyncPeriod    time.Duration `koanf:"resyncPeriod" default:"30s" desc:"How often informers replay their cache to SparkManager's event handlers"`
+	NamespaceScoped bool          `koanf:"namespaceScoped" desc:"Runs an informer per configured namespace of the cluster instead of one for all namespaces"`
+}
+
+// LocalBackendConfig configures the in-memory backend SparkManagers use in local mode. Its SparkApplications move from
+// SUBMITTED to RUNNING after SubmittedDuration and finish RunningDuration later.
+type LocalBackendConfig struct {
+	SubmittedDuration time.Duration `koanf:"submittedDuration" default:"5s" desc:"How long SparkApplications stay SUBMITTED"`
+	RunningDuration   time.Duration `koanf:"runningDuration" default:"30s" desc:"How long SparkApplications stay RUNNING"`
+	Latency           time.Duration `koanf:"latency" desc:"Latency added to each backend call, standing in for the Kubernetes API"`
+}
+
+// OrphanSweeperConfig configures the SparkManager sweep deleting the pods, services and configmaps labeled with
+// selectorKey and selectorValue whose SparkApplication no longer exists. A resource is deleted once its SparkApplication
+// has been missing for GracePeriod, so the operator's own cleanup and informer cache lag aren't raced.
+type OrphanSweeperConfig struct {
+	Enable      bool          `koanf:"enable" desc:"Enables the orphan sweeper, requires selectorKey and selectorValue"`
+	Interval    time.Duration `koanf:"interval" default:"10m" desc:"How often the sweep runs"`
+	GracePeriod time.Duration `koanf:"gracePeriod" default:"30m" desc:"How long a resource's SparkApplication must be missing before it is deleted"`
+}
+
+// PermissionCheckConfig configures the check SparkManager makes at startup, through SelfSubjectAccessReviews, that it has
+// the Kubernetes permissions its configuration needs in the cluster's namespaces. Minimal mode also reports the
+// permissions it has without needing them, from SelfSubjectRulesReviews, so its RBAC can be kept tight.
+type PermissionCheckConfig struct {
+	Enable  bool `koanf:"enable" desc:"Enables the permission check, logging and exporting missing permissions"`
+	Minimal bool `koanf:"minimal" desc:"Also reports permissions SparkManager has in the cluster's namespaces without needing them"`
+}
+
+// GarbageCollectorConfig configures the SparkManager controller deleting SparkApplications labeled with selectorKey and
+// selectorValue once they have been terminal for the applicationRetention of their namespace, which defaults to Retention. It is
+// independent of spec.timeToLiveSeconds, so applications submitted without a TTL, or with a longer one, are still
+// cleaned up.
+type GarbageCollectorConfig struct {
+	Enable    bool          `koanf:"enable" desc:"Enables the garbage collector, requires selectorKey and selectorValue"`
+	Interval  time.Duration `koanf:"interval" default:"10m" desc:"How often terminal SparkApplications are collected"`
+	Retention time.Duration `koanf:"retention" desc:"How long SparkApplications are kept once terminal, 0 only collects namespaces setting applicationRetention"`
 }
 
 func (sm *SparkManagerConfig) Key() string {
@@ -139,32 +480,55 @@ func (c *SparkManagerConfig) Validate() (errorMessages []string) {
 		errorMessages = append(errorMessages, fmt.Sprintf("config error: invalid 'sparkManager.clusterAuthType' '%s', valid clusterAuthType values: %s", c.ClusterAuthType, strings.Join(validClusterAuthTypes, ", ")))
 	}
 
+	if c.RequestTimeout < 0 {
+		errorMessages = append(errorMessages, "config error: 'sparkManager.requestTimeout' must not be negative")
+	}
+
+	if c.Local.SubmittedDuration < 0 || c.Local.RunningDuration < 0 || c.Local.Latency < 0 {
+		errorMessages = append(errorMessages, "config error: 'sparkManager.local' durations must not be negative")
+	}
+
+	if c.Informers.ResyncPeriod < 0 {
+		errorMessages = append(errorMessages, "config error: 'sparkManager.informers.resyncPeriod' must not be negative")
+	}
+
 	return errorMessages
 }
 
 type DebugPort struct {
-	SparkManagerPort string `koanf:"sparkManagerPort"`
-	MetricsPort      string `koanf:"metricsPort"`
+	SparkManagerPort string `koanf:"sparkManagerPort" desc:"SparkManager port used for the cluster"`
+	MetricsPort      string `koanf:"metricsPort" desc:"SparkManager metrics port used for the cluster"`
 }
 
 type LivyConfig struct {
-	Enable bool `koanf:"enable"`
-	DefaultNamespace string `koanf:"defaultNamespace"`
+	Enable           bool                 `koanf:"enable" desc:"Enables the Livy compatible API, requires the database"`
+	DefaultNamespace string               `koanf:"defaultNamespace" desc:"Namespace of batches submitted without one"`
+	Reconciler       LivyReconcilerConfig `koanf:"reconciler" desc:"Sweep deleting Livy applications without a batch row"`
+}
+
+// LivyReconcilerConfig configures the sweep deleting GatewayApplications created through the Livy API that have no
+// Livy batch row, e.g. because both the database insert and its cleanup delete failed. An Interval of 0 disables the
+// sweep. Applications younger than GracePeriod are skipped so in-flight creates aren't deleted.
+type LivyReconcilerConfig struct {
+	Interval    time.Duration `koanf:"interval" desc:"How often the sweep runs, 0 disables it"`
+	GracePeriod time.Duration `koanf:"gracePeriod" desc:"Age below which applications are skipped, 10m when the sweep is enabled"`
 }
 
 type SparkGatewayConfig struct {
-	KubeClusters       []domain.KubeCluster `koanf:"clusters"`
-	ClusterRouter      ClusterRouter        `koanf:"clusterRouter"`
-	DefaultLogLines    int                  `koanf:"defaultLogLines"`
-	Mode               string               `koanf:"mode"`
-	SelectorKey        string               `koanf:"selectorKey"`
-	SelectorValue      string               `koanf:"selectorValue"`
-	SparkManagerPort   string               `koanf:"sparkManagerPort"`
-	GatewayConfig      GatewayConfig        `koanf:"gateway"`
-	SparkManagerConfig SparkManagerConfig   `koanf:"sparkManager"`
-	LivyConfig         LivyConfig           `koanf:"livy"`
-	Database           Database             `koanf:"database"`
-	DebugPorts         map[string]DebugPort `koanf:"debugPorts"`
+	KubeClusters       []domain.KubeCluster `koanf:"clusters" required:"true" desc:"Clusters SparkApplications are submitted to"`
+	ClusterRouter      ClusterRouter        `koanf:"clusterRouter" desc:"How new applications are routed to clusters"`
+	DefaultLogLines    int                  `koanf:"defaultLogLines" desc:"Driver log lines returned when a request doesn't set lines"`
+	MaxLogLines        int                  `koanf:"maxLogLines" desc:"Cap on the driver log lines a request can ask for, 0 disables it"`
+	TimeToLiveSeconds  int64                `koanf:"timeToLiveSeconds" desc:"spec.timeToLiveSeconds of applications submitted without one, 0 disables it"`
+	Mode               string               `koanf:"mode" desc:"Operating mode, debug runs gin in debug mode and local runs SparkManagers without a cluster"`
+	SelectorKey        string               `koanf:"selectorKey" desc:"Label key set on and selecting the SparkApplications managed by the Gateway"`
+	SelectorValue      string               `koanf:"selectorValue" desc:"Label value set on and selecting the SparkApplications managed by the Gateway"`
+	SparkManagerPort   string               `koanf:"sparkManagerPort" desc:"Port SparkManager listens on"`
+	GatewayConfig      GatewayConfig        `koanf:"gateway" desc:"Gateway server"`
+	SparkManagerConfig SparkManagerConfig   `koanf:"sparkManager" desc:"SparkManager server"`
+	LivyConfig         LivyConfig           `koanf:"livy" desc:"Livy compatible API"`
+	Database           Database             `koanf:"database" desc:"SparkManager database"`
+	DebugPorts         map[string]DebugPort `koanf:"debugPorts" desc:"Ports used per cluster name when running SparkManagers locally"`
 }
 
 func (c *SparkGatewayConfig) Unmarshal(k *koanf.Koanf) error {
@@ -185,10 +549,12 @@ func (c *SparkGatewayConfig) Validate() (errorMessages []string) {
 	// Set defaults
 	c.ConfigDefaulter()
 
-	if len(c.KubeClusters) == 0 {
-		errorMessages = append(errorMessages, "config error: 'kubeClusters' config must be specified")
+	if c.Database.Enable && c.Database.Password == "" {
+		c.Database.Password = os.Getenv("DB_PASSWORD")
 	}
 
+	errorMessages = append(errorMessages, ValidateRequired(c)...)
+
 	// KubeClusters
 	seenClusterIds := map[string]bool{}
 	for _, cluster := range c.KubeClusters {
@@ -216,28 +582,182 @@ func (c *SparkGatewayConfig) Validate() (errorMessages []string) {
 		errorMessages = append(errorMessages, fmt.Sprintf("config error: invalid 'clusterRouter.dimension' '%s', valid values: %v", c.ClusterRouter.Dimension, validClusterRouterDimensionTypes))
 	}
 
+	if c.ClusterRouter.ConcurrencyCap.Enable {
+		if c.ClusterRouter.ConcurrencyCap.CacheTTL < 0 || c.ClusterRouter.ConcurrencyCap.QueueTimeout < 0 {
+			errorMessages = append(errorMessages, "config error: 'clusterRouter.concurrencyCap' cacheTTL and queueTimeout must not be negative")
+		}
+		if c.ClusterRouter.ConcurrencyCap.PollInterval <= 0 {
+			errorMessages = append(errorMessages, "config error: 'clusterRouter.concurrencyCap.pollInterval' must be positive")
+		}
+	}
+
+	if c.ClusterRouter.QuotaExclusion.Enable {
+		if c.ClusterRouter.QuotaExclusion.Threshold <= 0 || c.ClusterRouter.QuotaExclusion.Threshold > 1 {
+			errorMessages = append(errorMessages, fmt.Sprintf("config error: 'clusterRouter.quotaExclusion.threshold' must be greater than 0 and at most 1, got %v", c.ClusterRouter.QuotaExclusion.Threshold))
+		}
+		if c.ClusterRouter.QuotaExclusion.CacheTTL < 0 {
+			errorMessages = append(errorMessages, "config error: 'clusterRouter.quotaExclusion.cacheTTL' must not be negative")
+		}
+	}
+
+	errorMessages = append(errorMessages, c.GatewayConfig.StatusUrlTemplates.Validate()...)
+
+	for _, mwDef := range c.GatewayConfig.Middleware {
+		for _, routeGroup := range mwDef.Routes {
+			if !util.ValueExists(routeGroup, validMiddlewareRouteGroups) {
+				errorMessages = append(errorMessages, fmt.Sprintf("config error: invalid route group '%s' in 'gateway.middleware' '%s' routes, valid values: %v", routeGroup, mwDef.Type, validMiddlewareRouteGroups))
+			}
+		}
+	}
+
+	if c.GatewayConfig.ResponseCache.GetTTL < 0 || c.GatewayConfig.ResponseCache.StatusTTL < 0 {
+		errorMessages = append(errorMessages, "config error: 'gateway.responseCache' TTLs cannot be negative")
+	}
+
+	if c.DefaultLogLines < 0 || c.MaxLogLines < 0 || c.TimeToLiveSeconds < 0 {
+		errorMessages = append(errorMessages, "config error: 'defaultLogLines', 'maxLogLines' and 'timeToLiveSeconds' must not be negative")
+	}
+
+	if c.GatewayConfig.WaitStatus.PollInterval < 0 || c.GatewayConfig.WaitStatus.MaxTimeout < 0 {
+		errorMessages = append(errorMessages, "config error: 'gateway.waitStatus' pollInterval and maxTimeout must not be negative")
+	}
+
+	if c.GatewayConfig.Speculative.PollInterval < 0 || c.GatewayConfig.Speculative.ScheduleTimeout < 0 {
+		errorMessages = append(errorMessages, "config error: 'gateway.speculativeSubmission' pollInterval and scheduleTimeout must not be negative")
+	}
+	if c.GatewayConfig.Speculative.ScheduleTimeout > SpeculativeMaxScheduleTimeout {
+		errorMessages = append(errorMessages, fmt.Sprintf("config error: 'gateway.speculativeSubmission' scheduleTimeout must be at most %s so clients get the create response before they time out", SpeculativeMaxScheduleTimeout))
+	}
+
+	if c.GatewayConfig.StuckApplications.Enable && (c.GatewayConfig.StuckApplications.Interval <= 0 || c.GatewayConfig.StuckApplications.Threshold <= 0) {
+		errorMessages = append(errorMessages, "config error: 'gateway.stuckApplications' interval and threshold must be positive")
+	}
+
+	if c.GatewayConfig.HistoryServer.Enable && (c.GatewayConfig.HistoryServer.Timeout <= 0 || c.GatewayConfig.HistoryServer.CacheTTL < 0) {
+		errorMessages = append(errorMessages, "config error: 'gateway.historyServer' timeout must be positive and cacheTTL must not be negative")
+	}
+
+	if c.GatewayConfig.SoftQuota.Enable {
+		if c.GatewayConfig.SoftQuota.Threshold <= 0 || c.GatewayConfig.SoftQuota.Threshold > 1 {
+			errorMessages = append(errorMessages, fmt.Sprintf("config error: 'gateway.softQuota.threshold' must be greater than 0 and at most 1, got %v", c.GatewayConfig.SoftQuota.Threshold))
+		}
+		if c.GatewayConfig.SoftQuota.CacheTTL < 0 {
+			errorMessages = append(errorMessages, "config error: 'gateway.softQuota.cacheTTL' must not be negative")
+		}
+	}
+
+	if budget := c.GatewayConfig.LatencyBudget; budget.Enable {
+		if budget.Default < 0 || budget.Max < 0 {
+			errorMessages = append(errorMessages, "config error: 'gateway.latencyBudget' default and max must not be negative")
+		}
+		if budget.RoutingShare <= 0 || budget.RoutingShare > 1 || budget.RoutingRetryShare <= 0 || budget.RoutingRetryShare > 1 {
+			errorMessages = append(errorMessages, "config error: 'gateway.latencyBudget' routingShare and routingRetryShare must be greater than 0 and at most 1")
+		}
+	}
+
+	if rbac := c.GatewayConfig.RBAC; rbac.Enable {
+		if !domain.ValidRole(rbac.DefaultRole) {
+			errorMessages = append(errorMessages, fmt.Sprintf("config error: invalid 'gateway.rbac.defaultRole' '%s', valid values: %v", rbac.DefaultRole, domain.Roles))
+		}
+		for _, binding := range rbac.Bindings {
+			if !domain.ValidRole(binding.Role) {
+				errorMessages = append(errorMessages, fmt.Sprintf("config error: invalid 'gateway.rbac.bindings' role '%s', valid values: %v", binding.Role, domain.Roles))
+			}
+			if len(binding.Users) == 0 && len(binding.Groups) == 0 {
+				errorMessages = append(errorMessages, fmt.Sprintf("config error: 'gateway.rbac.bindings' role '%s' must list users or groups", binding.Role))
+			}
+		}
+	}
+
+	// Read-only Gateways don't run anything submitting or deleting applications on their own
+	if c.GatewayConfig.ReadOnly && (c.GatewayConfig.AsyncSubmission.Enable || c.GatewayConfig.Schedules.Enable || c.LivyConfig.Enable) {
+		errorMessages = append(errorMessages, "config error: 'gateway.readOnly' can't be enabled with 'gateway.asyncSubmission', 'gateway.schedules' or 'livy'")
+	}
+
+	if async := c.GatewayConfig.AsyncSubmission; async.Enable {
+		if !c.Database.Enable {
+			errorMessages = append(errorMessages, "config error: 'gateway.asyncSubmission' requires 'database.enable'")
+		}
+		if async.PollInterval <= 0 || async.InitialBackoff <= 0 || async.MaxBackoff <= 0 || async.Retention <= 0 {
+			errorMessages = append(errorMessages, "config error: 'gateway.asyncSubmission' pollInterval, initialBackoff, maxBackoff and retention must be positive")
+		}
+		if async.BatchSize <= 0 || async.MaxAttempts <= 0 {
+			errorMessages = append(errorMessages, "config error: 'gateway.asyncSubmission' batchSize and maxAttempts must be positive")
+		}
+	}
+
+	if schedules := c.GatewayConfig.Schedules; schedules.Enable {
+		if !c.Database.Enable {
+			errorMessages = append(errorMessages, "config error: 'gateway.schedules' requires 'database.enable'")
+		}
+		if schedules.PollInterval <= 0 {
+			errorMessages = append(errorMessages, "config error: 'gateway.schedules.pollInterval' must be positive")
+		}
+		if schedules.BatchSize <= 0 || schedules.History <= 0 {
+			errorMessages = append(errorMessages, "config error: 'gateway.schedules' batchSize and history must be positive")
+		}
+	}
+
+	if c.Database.Enable && c.GatewayConfig.RoutingWeights.RefreshInterval <= 0 {
+		errorMessages = append(errorMessages, "config error: 'gateway.routingWeights.refreshInterval' must be positive")
+	}
+
+	if c.Database.Enable && c.GatewayConfig.KillSwitches.RefreshInterval <= 0 {
+		errorMessages = append(errorMessages, "config error: 'gateway.killSwitches.refreshInterval' must be positive")
+	}
+
+	for _, proxy := range c.GatewayConfig.ClientIP.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("config error: 'gateway.clientIP.trustedProxies' entry '%s' is not an IP or CIDR", proxy))
+		}
+	}
+
+	if !util.ValueExists(c.GatewayConfig.FieldValidation, ValidFieldValidations) {
+		errorMessages = append(errorMessages, fmt.Sprintf("config error: invalid 'gateway.fieldValidation' '%s', valid fieldValidation values: %s", c.GatewayConfig.FieldValidation, strings.Join(ValidFieldValidations, ", ")))
+	}
+
+	if c.GatewayConfig.AdminPort != "" && c.GatewayConfig.AdminPort == c.GatewayConfig.GatewayPort {
+		errorMessages = append(errorMessages, "config error: 'gateway.adminPort' must differ from 'gateway.gatewayPort'")
+	}
+
+	if c.SparkManagerConfig.GarbageCollector.Enable {
+		if c.SelectorKey == "" || c.SelectorValue == "" {
+			errorMessages = append(errorMessages, "config error: 'sparkManager.garbageCollector' requires 'selectorKey' and 'selectorValue' to find the SparkApplications it collects")
+		}
+		if c.SparkManagerConfig.GarbageCollector.Interval <= 0 || c.SparkManagerConfig.GarbageCollector.Retention < 0 {
+			errorMessages = append(errorMessages, "config error: 'sparkManager.garbageCollector' interval must be positive and retention must not be negative")
+		}
+	}
+
+	if c.SparkManagerConfig.OrphanSweeper.Enable {
+		if c.SelectorKey == "" || c.SelectorValue == "" {
+			errorMessages = append(errorMessages, "config error: 'sparkManager.orphanSweeper' requires 'selectorKey' and 'selectorValue' to find the resources it sweeps")
+		}
+		if c.SparkManagerConfig.OrphanSweeper.Interval <= 0 || c.SparkManagerConfig.OrphanSweeper.GracePeriod <= 0 {
+			errorMessages = append(errorMessages, "config error: 'sparkManager.orphanSweeper' interval and gracePeriod must be positive")
+		}
+	}
+
 	if c.LivyConfig.Enable {
 		if !c.Database.Enable {
 			errorMessages = append(errorMessages, "Database must be enabled and configured if Livy is enabled")
 		}
+		if c.LivyConfig.Reconciler.Interval < 0 || c.LivyConfig.Reconciler.GracePeriod < 0 {
+			errorMessages = append(errorMessages, "config error: 'livy.reconciler' interval and gracePeriod must not be negative")
+		}
 	}
 
 	if c.Database.Enable {
 		if c.Database.Password == "" {
-			c.Database.Password = os.Getenv("DB_PASSWORD")
-		}
-		// Database hostname and port should exist
-		if c.Database.Hostname == "" || c.Database.Port == "" {
-			errorMessages = append(errorMessages, "config error: 'sparkManager.database.hostname' and 'sparkManager.database.port' must be specified")
+			errorMessages = append(errorMessages, "config error: 'database.password' config or DB_PASSWORD environment variable must be specified")
 		}
 
-		// Database Username or Password must be set
-		if c.Database.Username == "" {
-			errorMessages = append(errorMessages, "config error: 'sparkManager.database.username' config must be specified")
+		if c.Database.Reconciler.Interval < 0 || c.Database.Reconciler.GracePeriod < 0 {
+			errorMessages = append(errorMessages, "config error: 'database.reconciler' interval and gracePeriod must not be negative")
 		}
 
-		if c.Database.Password == "" {
-			errorMessages = append(errorMessages, "config error: 'sparkManager.database.password' config or DB_PASSWORD environment variable must be specified")
+		if c.Database.Events.Retention < 0 || c.Database.Events.SweepInterval < 0 {
+			errorMessages = append(errorMessages, "config error: 'database.events' retention and sweepInterval must not be negative")
 		}
 
 	}
@@ -248,23 +768,73 @@ func (c *SparkGatewayConfig) Validate() (errorMessages []string) {
 func (c *SparkGatewayConfig) ConfigDefaulter() {
 	c.KubeClustersDefaulter()
 	c.ClusterRouterDefaulter()
+	c.LivyDefaulter()
+	c.DatabaseDefaulter()
+	c.GatewayDefaulter()
+	c.SparkManagerDefaulter()
+}
+
+func (c *SparkGatewayConfig) SparkManagerDefaulter() {
+	ApplyDefaults(&c.SparkManagerConfig)
+}
+
+func (c *SparkGatewayConfig) GatewayDefaulter() {
+	ApplyDefaults(&c.GatewayConfig)
+}
+
+func (c *SparkGatewayConfig) LivyDefaulter() {
+	if c.LivyConfig.Reconciler.Interval > 0 && c.LivyConfig.Reconciler.GracePeriod == 0 {
+		c.LivyConfig.Reconciler.GracePeriod = 10 * time.Minute
+	}
+}
+
+func (c *SparkGatewayConfig) DatabaseDefaulter() {
+	if c.Database.Reconciler.Interval > 0 && c.Database.Reconciler.GracePeriod == 0 {
+		c.Database.Reconciler.GracePeriod = 10 * time.Minute
+	}
+	if c.Database.Events.SweepInterval == 0 {
+		c.Database.Events.SweepInterval = time.Hour
+	}
 }
 
 func (c *SparkGatewayConfig) KubeClustersDefaulter() {
-	// set default routingWeight to 1
+	ApplyDefaults(&c.KubeClusters)
+
 	for i := range c.KubeClusters {
-		if c.KubeClusters[i].RoutingWeight == float64(0) {
-			c.KubeClusters[i].RoutingWeight = 1.0
+		c.KubeClusters[i].SparkApplicationCRD = c.KubeClusters[i].SparkApplicationCRD.WithDefaults()
+
+		if c.KubeClusters[i].Backend == domain.BackendEMROnEKS && c.KubeClusters[i].EMROnEKS.ListWindow == 0 {
+			c.KubeClusters[i].EMROnEKS.ListWindow = 24 * time.Hour
 		}
 
 		for j := range c.KubeClusters[i].Namespaces {
-			if c.KubeClusters[i].Namespaces[j].RoutingWeight == float64(0) {
-				c.KubeClusters[i].Namespaces[j].RoutingWeight = 1.0
-			}
+			c.NamespaceDefaulter(&c.KubeClusters[i].Namespaces[j])
 		}
 	}
 }
 
+// NamespaceDefaulter sets the defaults of a namespace, for configured namespaces and those registered at runtime
+func (c *SparkGatewayConfig) NamespaceDefaulter(namespace *domain.KubeNamespace) {
+	ApplyDefaults(namespace)
+
+	// namespaces inherit the global TTL, log, event and application retention settings unless they override them
+	if namespace.TimeToLiveSeconds == 0 {
+		namespace.TimeToLiveSeconds = c.TimeToLiveSeconds
+	}
+	if namespace.DefaultLogLines == 0 {
+		namespace.DefaultLogLines = c.DefaultLogLines
+	}
+	if namespace.MaxLogLines == 0 {
+		namespace.MaxLogLines = c.MaxLogLines
+	}
+	if namespace.EventRetention == 0 {
+		namespace.EventRetention = c.Database.Events.Retention
+	}
+	if namespace.ApplicationRetention == 0 {
+		namespace.ApplicationRetention = c.SparkManagerConfig.GarbageCollector.Retention
+	}
+}
+
 func (c *SparkGatewayConfig) GetKubeCluster(clusterName string) *domain.KubeCluster {
 	for _, cluster := range c.KubeClusters {
 		if cluster.Name == clusterName {
@@ -275,10 +845,5 @@ func (c *SparkGatewayConfig) GetKubeCluster(clusterName string) *domain.KubeClus
 }
 
 func (c *SparkGatewayConfig) ClusterRouterDefaulter() {
-	if c.ClusterRouter.Type == "" {
-		c.ClusterRouter.Type = WeightBasedRandomRouter
-	}
-	if c.ClusterRouter.FallbackType == "" {
-		c.ClusterRouter.FallbackType = WeightBasedRandomRouter
-	}
+	ApplyDefaults(&c.ClusterRouter)
 }
@@ -18,7 +18,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/knadh/koanf/v2"
 	"github.com/slackhq/spark-gateway/internal/domain"
@@ -98,7 +100,7 @@ func TestKubeClustersDefaulter(t *testing.T) {
 					{Name: "ns-set", RoutingWeight: 3.0},
 				},
 			},
-			{Name: "set-weight", RoutingWeight: 5.0},
+			{Name: "set-weight", RoutingWeight: 5.0, Backend: "custom"},
 		},
 	}
 
@@ -110,4 +112,146 @@ func TestKubeClustersDefaulter(t *testing.T) {
 	// ...while explicitly set weights are preserved.
 	assert.Equal(t, 3.0, conf.KubeClusters[0].Namespaces[1].RoutingWeight)
 	assert.Equal(t, 5.0, conf.KubeClusters[1].RoutingWeight)
+	// Unset backends default to the Spark Operator
+	assert.Equal(t, domain.BackendSparkOperator, conf.KubeClusters[0].Backend)
+	assert.Equal(t, "custom", conf.KubeClusters[1].Backend)
+}
+
+func TestKubeClustersDefaulterNamespaceSettings(t *testing.T) {
+	conf := SparkGatewayConfig{
+		DefaultLogLines:   100,
+		MaxLogLines:       1000,
+		TimeToLiveSeconds: 86400,
+		KubeClusters: []domain.KubeCluster{
+			{
+				Name: "cluster",
+				Namespaces: []domain.KubeNamespace{
+					{Name: "inherits"},
+					{Name: "streaming", TimeToLiveSeconds: 3600, DefaultLogLines: 500, MaxLogLines: 10000},
+				},
+			},
+		},
+	}
+
+	conf.KubeClustersDefaulter()
+
+	inherits := conf.KubeClusters[0].Namespaces[0]
+	assert.Equal(t, int64(86400), inherits.TimeToLiveSeconds, "unset namespace TTL should inherit the global TTL")
+	assert.Equal(t, 100, inherits.DefaultLogLines, "unset namespace defaultLogLines should inherit the global value")
+	assert.Equal(t, 1000, inherits.MaxLogLines, "unset namespace maxLogLines should inherit the global value")
+
+	streaming := conf.KubeClusters[0].Namespaces[1]
+	assert.Equal(t, int64(3600), streaming.TimeToLiveSeconds, "namespace TTL should override the global TTL")
+	assert.Equal(t, 500, streaming.DefaultLogLines, "namespace defaultLogLines should override the global value")
+	assert.Equal(t, 10000, streaming.MaxLogLines, "namespace maxLogLines should override the global value")
+}
+
+func TestClusterRouterDefaulterQuotaExclusion(t *testing.T) {
+	conf := SparkGatewayConfig{ClusterRouter: ClusterRouter{QuotaExclusion: QuotaExclusion{Enable: true}}}
+	conf.ClusterRouterDefaulter()
+
+	assert.Equal(t, 0.9, conf.ClusterRouter.QuotaExclusion.Threshold, "unset threshold should default to 0.9")
+	assert.Equal(t, 30*time.Second, conf.ClusterRouter.QuotaExclusion.CacheTTL, "unset cacheTTL should default to 30s")
+
+	disabled := SparkGatewayConfig{}
+	disabled.ClusterRouterDefaulter()
+	assert.Equal(t, QuotaExclusion{}, disabled.ClusterRouter.QuotaExclusion, "disabled quota exclusion should not be defaulted")
+}
+
+func TestSparkManagerDefaulterOrphanSweeper(t *testing.T) {
+	conf := SparkGatewayConfig{SparkManagerConfig: SparkManagerConfig{OrphanSweeper: OrphanSweeperConfig{Enable: true}}}
+	conf.SparkManagerDefaulter()
+
+	assert.Equal(t, 10*time.Minute, conf.SparkManagerConfig.OrphanSweeper.Interval, "unset interval should default to 10m")
+	assert.Equal(t, 30*time.Minute, conf.SparkManagerConfig.OrphanSweeper.GracePeriod, "unset gracePeriod should default to 30m")
+
+	errs := conf.Validate()
+	assert.Contains(t, errs, "config error: 'sparkManager.orphanSweeper' requires 'selectorKey' and 'selectorValue' to find the resources it sweeps", "orphan sweeper should require the selector")
+}
+
+func TestValidateGarbageCollectorSelector(t *testing.T) {
+	conf := SparkGatewayConfig{SparkManagerConfig: SparkManagerConfig{GarbageCollector: GarbageCollectorConfig{Enable: true, Interval: time.Minute}}}
+
+	errs := conf.Validate()
+	assert.Contains(t, errs, "config error: 'sparkManager.garbageCollector' requires 'selectorKey' and 'selectorValue' to find the SparkApplications it collects", "garbage collector should require the selector")
+
+	conf.SelectorKey = "spark-gateway/managed"
+	conf.SelectorValue = "true"
+	errs = conf.Validate()
+	assert.NotContains(t, errs, "config error: 'sparkManager.garbageCollector' requires 'selectorKey' and 'selectorValue' to find the SparkApplications it collects", "garbage collector with a selector should be valid")
+}
+
+func TestValidateTrustedProxies(t *testing.T) {
+	conf := SparkGatewayConfig{GatewayConfig: GatewayConfig{ClientIP: ClientIPConfig{TrustedProxies: []string{"10.0.0.0/8", "192.168.1.10", "::1", "proxy.example.com"}}}}
+
+	errs := conf.Validate()
+	assert.Contains(t, errs, "config error: 'gateway.clientIP.trustedProxies' entry 'proxy.example.com' is not an IP or CIDR", "hostnames should be rejected")
+	for _, err := range errs {
+		assert.NotContains(t, err, "'10.0.0.0/8'", "CIDRs should be accepted")
+		assert.NotContains(t, err, "'192.168.1.10'", "IPs should be accepted")
+		assert.NotContains(t, err, "'::1'", "IPv6 addresses should be accepted")
+	}
+}
+
+func TestValidateStatusUrlTemplates(t *testing.T) {
+	conf := SparkGatewayConfig{GatewayConfig: GatewayConfig{StatusUrlTemplates: domain.StatusUrlTemplates{
+		SparkUITemplate:        "host.com/ui/{{.Namespace}}/{{.Name}}",
+		SparkHistoryUITemplate: "host.com/history/{{.Status.SparkApplicationID}",
+		LogsUITemplate:         "host.com/logs/{{.ClusterName}}",
+	}}}
+
+	errs := strings.Join(conf.Validate(), "\n")
+	assert.NotContains(t, errs, "sparkUI URL", "templates that render should be accepted")
+	assert.Contains(t, errs, "sparkHistoryUI URL", "templates that don't parse should be rejected")
+	assert.Contains(t, errs, "logsUI URL", "templates referencing fields that don't exist should be rejected")
+}
+
+func TestRBACConfigRole(t *testing.T) {
+	rbac := RBACConfig{
+		Enable:      true,
+		DefaultRole: domain.RoleViewer,
+		Bindings: []RoleBinding{
+			{Role: domain.RoleSubmitter, Groups: []string{"data-eng"}},
+			{Role: domain.RoleAdmin, Users: []string{"alice"}},
+		},
+	}
+
+	assert.Equal(t, domain.RoleAdmin, rbac.Role("alice", []string{"data-eng"}), "the most privileged role should be given")
+	assert.Equal(t, domain.RoleSubmitter, rbac.Role("bob", []string{"data-eng"}), "members of groups should be given their role")
+	assert.Equal(t, domain.RoleViewer, rbac.Role("carol", nil), "users no binding lists should be given the default role")
+}
+
+func TestValidateRBAC(t *testing.T) {
+	conf := SparkGatewayConfig{GatewayConfig: GatewayConfig{RBAC: RBACConfig{
+		Enable:      true,
+		DefaultRole: "owner",
+		Bindings:    []RoleBinding{{Role: domain.RoleAdmin}},
+	}}}
+
+	errs := strings.Join(conf.Validate(), "\n")
+	assert.Contains(t, errs, "invalid 'gateway.rbac.defaultRole' 'owner'", "unknown roles should be rejected")
+	assert.Contains(t, errs, "'gateway.rbac.bindings' role 'admin' must list users or groups", "empty bindings should be rejected")
+}
+
+func TestValidateSpeculativeScheduleTimeout(t *testing.T) {
+	conf := SparkGatewayConfig{GatewayConfig: GatewayConfig{Speculative: SpeculativeConfig{Enable: true, ScheduleTimeout: 2 * time.Minute}}}
+	assert.Contains(t, strings.Join(conf.Validate(), "\n"), "'gateway.speculativeSubmission' scheduleTimeout must be at most 20s", "schedule timeouts longer than clients wait should be rejected")
+
+	conf.GatewayConfig.Speculative.ScheduleTimeout = SpeculativeMaxScheduleTimeout
+	assert.NotContains(t, strings.Join(conf.Validate(), "\n"), "scheduleTimeout must be at most", "the maximum schedule timeout should be accepted")
+}
+
+func TestMiddlewareDefinitionAppliesTo(t *testing.T) {
+	unscoped := MiddlewareDefinition{Type: "unscoped"}
+	assert.True(t, unscoped.AppliesTo(APIRouteGroup), "unscoped middleware should apply to api routes")
+	assert.True(t, unscoped.AppliesTo(LivyRouteGroup), "unscoped middleware should apply to livy routes")
+	assert.False(t, unscoped.AppliesTo(MetricsRouteGroup), "unscoped middleware should not apply to metrics routes")
+
+	scoped := MiddlewareDefinition{Type: "scoped", Routes: []MiddlewareRouteGroup{LivyRouteGroup, MetricsRouteGroup}}
+	assert.False(t, scoped.AppliesTo(APIRouteGroup), "scoped middleware should not apply to other routes")
+	assert.True(t, scoped.AppliesTo(LivyRouteGroup), "scoped middleware should apply to its routes")
+	assert.True(t, scoped.AppliesTo(MetricsRouteGroup), "scoped middleware should apply to metrics when listed")
+
+	conf := SparkGatewayConfig{GatewayConfig: GatewayConfig{Middleware: []MiddlewareDefinition{{Type: "typo", Routes: []MiddlewareRouteGroup{"v1"}}}}}
+	assert.Contains(t, conf.Validate(), "config error: invalid route group 'v1' in 'gateway.middleware' 'typo' routes, valid values: [api livy admin ui metrics]", "invalid route groups should fail validation")
 }
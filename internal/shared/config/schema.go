@@ -0,0 +1,325 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/knadh/koanf/parsers/yaml"
+)
+
+// The config schema is declared with struct tags on the config types, next to their koanf key:
+//
+//	default:"..."   value applied by ApplyDefaults when the field is unset
+//	required:"true" the key must be set, checked by ValidateRequired
+//	desc:"..."      description of the key, used by PrintSchema
+//	secret:"true"   the value is redacted by PrintConfig
+//
+// Defaults and required keys of a struct with an Enable field only apply while it is enabled. Defaults depending on
+// other keys, such as namespaces inheriting the global settings, are set by the defaulters in config.go.
+
+const redactedValue = "<redacted>"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// FieldSchema describes a config key
+type FieldSchema struct {
+	Key         string
+	Type        string
+	Default     string
+	Required    bool
+	Description string
+}
+
+// Schema returns the keys of the config file, in the order they are declared
+func Schema() []FieldSchema {
+	return schemaFields(reflect.TypeOf(SparkGatewayConfig{}), "")
+}
+
+func schemaFields(t reflect.Type, prefix string) (fields []FieldSchema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("koanf")
+		if !field.IsExported() || name == "" {
+			continue
+		}
+
+		key := prefix + name
+		fields = append(fields, FieldSchema{
+			Key:         key,
+			Type:        schemaTypeName(field.Type),
+			Default:     field.Tag.Get("default"),
+			Required:    field.Tag.Get("required") == "true",
+			Description: field.Tag.Get("desc"),
+		})
+
+		switch {
+		case field.Type.Kind() == reflect.Struct && field.Type != durationType:
+			fields = append(fields, schemaFields(field.Type, key+".")...)
+		case field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct:
+			fields = append(fields, schemaFields(field.Type.Elem(), key+"[].")...)
+		case field.Type.Kind() == reflect.Map && field.Type.Elem().Kind() == reflect.Struct:
+			fields = append(fields, schemaFields(field.Type.Elem(), key+".<name>.")...)
+		}
+	}
+	return fields
+}
+
+func schemaTypeName(t reflect.Type) string {
+	if t == durationType {
+		return "duration"
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return "object"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Struct {
+			return "list"
+		}
+		return "[]" + schemaTypeName(t.Elem())
+	case reflect.Map:
+		if t.Elem().Kind() == reflect.Struct {
+			return "map"
+		}
+		return "map[string]" + schemaTypeName(t.Elem())
+	case reflect.Interface:
+		return "any"
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return "int"
+	case reflect.Float64:
+		return "float"
+	default:
+		return t.Kind().String()
+	}
+}
+
+// PrintSchema writes a markdown reference of the config keys to w
+func PrintSchema(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("# Configuration Reference\n\n")
+	b.WriteString("<!-- Generated by `go run ./cmd/gateway --print-config-schema`, do not edit. -->\n\n")
+	b.WriteString("See [Configurations](Configurations.md) for details and examples.\n\n")
+	b.WriteString("| Key | Type | Default | Required | Description |\n")
+	b.WriteString("|-----|------|---------|----------|-------------|\n")
+	for _, field := range Schema() {
+		def := ""
+		if field.Default != "" {
+			def = fmt.Sprintf("`%s`", field.Default)
+		}
+		required := ""
+		if field.Required {
+			required = "yes"
+		}
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %s |\n", field.Key, field.Type, def, required, strings.ReplaceAll(field.Description, "|", "\\|"))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// PrintConfig writes conf to w as YAML, with every key listed and secrets redacted. Call ConfigDefaulter first to
+// print the effective config.
+func PrintConfig(w io.Writer, conf *SparkGatewayConfig) error {
+	out, err := yaml.Parser().Marshal(configMap(reflect.ValueOf(*conf)))
+	if err != nil {
+		return fmt.Errorf("error marshaling config: %w", err)
+	}
+
+	_, err = w.Write(out)
+	return err
+}
+
+func configMap(v reflect.Value) map[string]any {
+	out := map[string]any{}
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name := field.Tag.Get("koanf")
+		if !field.IsExported() || name == "" {
+			continue
+		}
+
+		if field.Tag.Get("secret") == "true" && !v.Field(i).IsZero() {
+			out[name] = redactedValue
+			continue
+		}
+		out[name] = configValue(v.Field(i))
+	}
+	return out
+}
+
+func configValue(v reflect.Value) any {
+	if v.Type() == durationType {
+		return v.Interface().(time.Duration).String()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return configMap(v)
+	case reflect.Slice:
+		items := make([]any, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, configValue(v.Index(i)))
+		}
+		return items
+	case reflect.Map:
+		items := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			items[iter.Key().String()] = configValue(iter.Value())
+		}
+		return items
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return v.Elem().Interface()
+	case reflect.String:
+		return v.String()
+	default:
+		return v.Interface()
+	}
+}
+
+// ApplyDefaults sets the fields of v, a pointer to a config struct or slice of config structs, that are unset to the
+// value of their default tag. It panics if a default tag can't be parsed as its field's type.
+func ApplyDefaults(v any) {
+	applyDefaults(reflect.ValueOf(v))
+}
+
+func applyDefaults(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			applyDefaults(v.Elem())
+		}
+	case reflect.Struct:
+		if !enabled(v) {
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if def, ok := field.Tag.Lookup("default"); ok && v.Field(i).IsZero() {
+				if err := setFromString(v.Field(i), def); err != nil {
+					panic(fmt.Sprintf("invalid default for config field %s.%s: %v", v.Type().Name(), field.Name, err))
+				}
+			}
+			applyDefaults(v.Field(i))
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			applyDefaults(v.Index(i))
+		}
+	case reflect.Map:
+		if v.Type().Elem().Kind() != reflect.Struct {
+			return
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			// map values aren't addressable, so default a copy and store it back
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(iter.Value())
+			applyDefaults(value)
+			v.SetMapIndex(iter.Key(), value)
+		}
+	}
+}
+
+// enabled returns false if v has an Enable field that is false
+func enabled(v reflect.Value) bool {
+	enable := v.FieldByName("Enable")
+	return !enable.IsValid() || enable.Kind() != reflect.Bool || enable.Bool()
+}
+
+func setFromString(v reflect.Value, s string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("defaults are not supported for %s fields", v.Kind())
+	}
+	return nil
+}
+
+// ValidateRequired returns an error message for each key of conf with a required tag that is unset. Items of lists
+// are checked by their own validation, e.g. domain.ValidateCluster.
+func ValidateRequired(conf *SparkGatewayConfig) []string {
+	return requiredErrors(reflect.ValueOf(*conf), "")
+}
+
+func requiredErrors(v reflect.Value, prefix string) (errorMessages []string) {
+	if !enabled(v) {
+		return nil
+	}
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name := field.Tag.Get("koanf")
+		if !field.IsExported() || name == "" {
+			continue
+		}
+
+		key := prefix + name
+		if field.Tag.Get("required") == "true" && unset(v.Field(i)) {
+			errorMessages = append(errorMessages, fmt.Sprintf("config error: '%s' must be specified", key))
+		}
+		if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+			errorMessages = append(errorMessages, requiredErrors(v.Field(i), key+".")...)
+		}
+	}
+	return errorMessages
+}
+
+// unset returns whether v is its zero value, or an empty list or map
+func unset(v reflect.Value) bool {
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Map {
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
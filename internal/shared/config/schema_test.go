@@ -0,0 +1,162 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+)
+
+type defaultsTestConfig struct {
+	Name     string                         `koanf:"name" default:"test"`
+	Weight   float64                        `koanf:"weight" default:"1.5"`
+	Timeout  time.Duration                  `koanf:"timeout" default:"2s"`
+	Feature  defaultsTestFeature            `koanf:"feature"`
+	Items    []defaultsTestFeature          `koanf:"items"`
+	ItemsMap map[string]defaultsTestFeature `koanf:"itemsMap"`
+}
+
+type defaultsTestFeature struct {
+	Enable bool  `koanf:"enable"`
+	Limit  int64 `koanf:"limit" default:"10"`
+}
+
+func TestApplyDefaults(t *testing.T) {
+	var tests = []struct {
+		test     string
+		conf     defaultsTestConfig
+		expected defaultsTestConfig
+	}{
+		{
+			test:     "unset fields are defaulted",
+			conf:     defaultsTestConfig{},
+			expected: defaultsTestConfig{Name: "test", Weight: 1.5, Timeout: 2 * time.Second},
+		},
+		{
+			test:     "set fields are kept",
+			conf:     defaultsTestConfig{Name: "set", Weight: 3, Timeout: time.Minute},
+			expected: defaultsTestConfig{Name: "set", Weight: 3, Timeout: time.Minute},
+		},
+		{
+			test: "enabled structs are defaulted in fields, lists and maps",
+			conf: defaultsTestConfig{
+				Feature:  defaultsTestFeature{Enable: true},
+				Items:    []defaultsTestFeature{{Enable: true}, {Enable: false}},
+				ItemsMap: map[string]defaultsTestFeature{"a": {Enable: true, Limit: 5}, "b": {Enable: true}},
+			},
+			expected: defaultsTestConfig{
+				Name: "test", Weight: 1.5, Timeout: 2 * time.Second,
+				Feature:  defaultsTestFeature{Enable: true, Limit: 10},
+				Items:    []defaultsTestFeature{{Enable: true, Limit: 10}, {Enable: false}},
+				ItemsMap: map[string]defaultsTestFeature{"a": {Enable: true, Limit: 5}, "b": {Enable: true, Limit: 10}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.test, func(t *testing.T) {
+			ApplyDefaults(&test.conf)
+			assert.Equal(t, test.expected, test.conf, "config should be defaulted")
+		})
+	}
+}
+
+func TestApplyDefaultsInvalidDefault(t *testing.T) {
+	conf := struct {
+		Limit int `default:"ten"`
+	}{}
+
+	assert.Panics(t, func() { ApplyDefaults(&conf) }, "an unparsable default should panic")
+}
+
+func TestSchemaDefaultsParse(t *testing.T) {
+	conf := SparkGatewayConfig{
+		KubeClusters:  []domain.KubeCluster{{Namespaces: []domain.KubeNamespace{{}}}},
+		ClusterRouter: ClusterRouter{QuotaExclusion: QuotaExclusion{Enable: true}},
+		GatewayConfig: GatewayConfig{Speculative: SpeculativeConfig{Enable: true}},
+	}
+
+	assert.NotPanics(t, func() { conf.ConfigDefaulter() }, "every default tag should parse")
+	assert.Equal(t, domain.BackendSparkOperator, conf.KubeClusters[0].Backend, "backend should be defaulted")
+	assert.Equal(t, domain.ProxyUserModeUser, conf.KubeClusters[0].Namespaces[0].ProxyUser.Mode, "proxyUser mode should be defaulted")
+	assert.Equal(t, 15*time.Second, conf.GatewayConfig.Speculative.ScheduleTimeout, "scheduleTimeout should be defaulted")
+}
+
+func TestValidateRequired(t *testing.T) {
+	var tests = []struct {
+		test     string
+		conf     SparkGatewayConfig
+		expected []string
+	}{
+		{
+			test:     "missing clusters",
+			conf:     SparkGatewayConfig{},
+			expected: []string{"config error: 'clusters' must be specified"},
+		},
+		{
+			test: "disabled structs are not checked",
+			conf: SparkGatewayConfig{KubeClusters: []domain.KubeCluster{{}}},
+		},
+		{
+			test: "enabled structs are checked",
+			conf: SparkGatewayConfig{
+				KubeClusters: []domain.KubeCluster{{}},
+				Database:     Database{Enable: true, Hostname: "localhost"},
+			},
+			expected: []string{
+				"config error: 'database.port' must be specified",
+				"config error: 'database.username' must be specified",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.test, func(t *testing.T) {
+			assert.Equal(t, test.expected, ValidateRequired(&test.conf), "required errors should match")
+		})
+	}
+}
+
+func TestPrintConfig(t *testing.T) {
+	conf := SparkGatewayConfig{
+		Database:      Database{Enable: true, Password: "hunter2"},
+		GatewayConfig: GatewayConfig{WaitStatus: WaitStatusConfig{PollInterval: 2 * time.Second}},
+	}
+
+	var out bytes.Buffer
+	err := PrintConfig(&out, &conf)
+
+	assert.Nil(t, err, "printing should not error")
+	assert.NotContains(t, out.String(), "hunter2", "secrets should be redacted")
+	assert.Contains(t, out.String(), "password: <redacted>", "secrets should be redacted")
+	assert.Contains(t, out.String(), "pollInterval: 2s", "durations should be printed as strings")
+	assert.Contains(t, out.String(), "selectorKey: \"\"", "unset keys should be printed")
+}
+
+func TestConfigReferenceUpToDate(t *testing.T) {
+	expected, err := os.ReadFile("../../../docs/ConfigReference.md")
+	assert.Nil(t, err, "docs/ConfigReference.md should exist")
+
+	var out bytes.Buffer
+	assert.Nil(t, PrintSchema(&out), "printing the schema should not error")
+	assert.Equal(t, string(expected), out.String(), "docs/ConfigReference.md is out of date, regenerate it with `go run ./cmd/gateway --print-config-schema > docs/ConfigReference.md`")
+}
@@ -18,6 +18,7 @@ package database
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 
@@ -25,16 +26,22 @@ import (
 
 	"time"
 
+	"github.com/slackhq/spark-gateway/internal/domain"
 	"github.com/slackhq/spark-gateway/internal/shared/config"
 	"github.com/slackhq/spark-gateway/internal/shared/util"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
 
 	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
 )
 
+// LostState is recorded for SparkApplications whose resource was removed from the cluster before a terminal state
+// was observed
+const LostState = "LOST"
+
 // statementTimeout bounds how long any single query may run on the server,
 // protecting pooled connections from being held indefinitely by a wedged query.
 const statementTimeout = "30s"
@@ -45,6 +52,80 @@ type SparkApplicationDatabase interface {
 	GetById(ctx context.Context, gatewayIdUid uuid.UUID) (*SparkApplication, error)
 	UpdateSparkApplication(ctx context.Context, gatewayIdUid uuid.UUID, updateSparkApp v1beta2.SparkApplication) error
 	InsertSparkApplication(ctx context.Context, gatewayIdUid uuid.UUID, creationTime time.Time, userSubmittedSparkApp *v1beta2.SparkApplication, clusterName string) error
+	ListActiveSparkApplications(ctx context.Context, clusterName string, createdBefore time.Time) ([]SparkApplication, error)
+	MarkSparkApplicationLost(ctx context.Context, gatewayIdUid uuid.UUID, terminationTime time.Time) error
+}
+
+//go:generate moq -rm -out mocksparkapplicationeventdatabase.go . SparkApplicationEventDatabase
+
+// SparkApplicationEventDatabase stores the lifecycle events of SparkApplications, so their timeline outlives their
+// pods and Kubernetes events
+type SparkApplicationEventDatabase interface {
+	InsertSparkApplicationEvent(ctx context.Context, gatewayIdUid uuid.UUID, clusterName string, namespace string, event domain.TimelineEvent) error
+	ListSparkApplicationEvents(ctx context.Context, gatewayIdUid uuid.UUID) ([]SparkApplicationEvent, error)
+	DeleteSparkApplicationEventsBefore(ctx context.Context, clusterName string, namespace string, before time.Time) (int64, error)
+}
+
+//go:generate moq -rm -out mocknamespaceroutingweightdatabase.go . NamespaceRoutingWeightDatabase
+
+// NamespaceRoutingWeightDatabase stores the routing weights admins set on namespaces at runtime, so they survive
+// restarts and are shared by every Gateway instance
+type NamespaceRoutingWeightDatabase interface {
+	UpsertNamespaceRoutingWeight(ctx context.Context, weight NamespaceRoutingWeight) error
+	ListNamespaceRoutingWeights(ctx context.Context) ([]NamespaceRoutingWeight, error)
+}
+
+//go:generate moq -rm -out mockregisterednamespacedatabase.go . RegisteredNamespaceDatabase
+
+// RegisteredNamespaceDatabase stores the namespaces admins register with clusters at runtime, so they survive restarts
+// and are shared by every Gateway instance
+type RegisteredNamespaceDatabase interface {
+	InsertRegisteredNamespace(ctx context.Context, cluster string, namespace domain.KubeNamespace, now time.Time) (bool, error)
+	ListRegisteredNamespaces(ctx context.Context) ([]RegisteredNamespace, error)
+	DeleteRegisteredNamespace(ctx context.Context, cluster string, namespace string) error
+}
+
+//go:generate moq -rm -out mocknamespacekillswitchdatabase.go . NamespaceKillSwitchDatabase
+
+// NamespaceKillSwitchDatabase stores the namespace kill switches engaged by admins, so they survive restarts and are
+// shared by every Gateway instance
+type NamespaceKillSwitchDatabase interface {
+	UpsertNamespaceKillSwitch(ctx context.Context, killSwitch NamespaceKillSwitch) error
+	ListNamespaceKillSwitches(ctx context.Context) ([]NamespaceKillSwitch, error)
+	DeleteNamespaceKillSwitch(ctx context.Context, namespace string) (bool, error)
+}
+
+//go:generate moq -rm -out mockqueuedsubmissiondatabase.go . QueuedSubmissionDatabase
+
+// QueuedSubmissionDatabase is the durable queue of asynchronous submissions shared by every Gateway instance. Claimed
+// submissions are leased until their next attempt, so a submission claimed by an instance that dies is retried.
+type QueuedSubmissionDatabase interface {
+	InsertQueuedSubmission(ctx context.Context, submission QueuedSubmission) error
+	InsertFailedQueuedSubmission(ctx context.Context, submission QueuedSubmission) error
+	GetQueuedSubmission(ctx context.Context, gatewayId string) (*QueuedSubmission, error)
+	ClaimQueuedSubmissions(ctx context.Context, now time.Time, leaseUntil time.Time, size int) ([]QueuedSubmission, error)
+	UpdateQueuedSubmission(ctx context.Context, gatewayId string, state string, lastError *string, nextAttemptAt time.Time, now time.Time) (bool, error)
+	DeleteUnsubmittedQueuedSubmission(ctx context.Context, gatewayId string) (bool, error)
+	DeleteFinishedQueuedSubmissionsBefore(ctx context.Context, before time.Time) (int64, error)
+	ListQueuedSubmissions(ctx context.Context, state string, namespace string) ([]QueuedSubmission, error)
+	RequeueFailedQueuedSubmission(ctx context.Context, gatewayId string, now time.Time) (bool, error)
+	ReplaceFailedQueuedSubmission(ctx context.Context, gatewayId string, replacement QueuedSubmission, now time.Time) (bool, error)
+	DeleteFailedQueuedSubmission(ctx context.Context, gatewayId string) (bool, error)
+}
+
+//go:generate moq -rm -out mockscheduledapplicationdatabase.go . ScheduledApplicationDatabase
+
+// ScheduledApplicationDatabase stores ScheduledApplications and the history of their runs, shared by every Gateway
+// instance. Due schedules are leased to the instance submitting their run, so a run is only submitted by one instance.
+type ScheduledApplicationDatabase interface {
+	InsertScheduledApplication(ctx context.Context, schedule ScheduledApplication) error
+	GetScheduledApplication(ctx context.Context, scheduleId string) (*ScheduledApplication, error)
+	ListScheduledApplications(ctx context.Context, namespace string) ([]ScheduledApplication, error)
+	SetScheduledApplicationPaused(ctx context.Context, scheduleId string, paused bool, nextRunAt time.Time, now time.Time) (bool, error)
+	DeleteScheduledApplication(ctx context.Context, scheduleId string) (bool, error)
+	ClaimDueScheduledApplications(ctx context.Context, now time.Time, leaseUntil time.Time, size int) ([]ScheduledApplication, error)
+	CompleteScheduledApplicationRun(ctx context.Context, run ScheduledApplicationRun, nextRunAt time.Time, keepRuns int) error
+	ListScheduledApplicationRuns(ctx context.Context, scheduleId string, size int) ([]ScheduledApplicationRun, error)
 }
 
 //go:generate moq -rm -out mocklivyapplicationdatabase.go . LivyApplicationDatabase
@@ -54,6 +135,8 @@ type LivyApplicationDatabase interface {
 	GetByBatchId(ctx context.Context, batchId int) (LivyApplication, error)
 	ListFrom(ctx context.Context, fromId int, size int) ([]LivyApplication, error)
 	InsertLivyApplication(ctx context.Context, gatewayId string) (LivyApplication, error)
+	LivyApplicationExists(ctx context.Context, gatewayId string) (bool, error)
+	SetLivyApplicationTerminalBatch(ctx context.Context, batchId int, batch *domain.LivyBatch) error
 }
 
 type Database struct {
@@ -195,6 +278,105 @@ func (db *Database) InsertSparkApplication(ctx context.Context, gatewayIdUid uui
 	return nil
 }
 
+// ListActiveSparkApplications returns the SparkApplications in clusterName created before createdBefore that have not
+// been recorded in a terminal state
+func (db *Database) ListActiveSparkApplications(ctx context.Context, clusterName string, createdBefore time.Time) ([]SparkApplication, error) {
+	queries := New(db.connectionPool)
+
+	sparkApps, err := queries.ListActiveSparkApplications(ctx, ListActiveSparkApplicationsParams{
+		Cluster:       &clusterName,
+		CreatedBefore: &createdBefore,
+	})
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(fmt.Errorf("error listing active SparkApplications in cluster '%s' from database: %w", clusterName, err))
+	}
+
+	return sparkApps, nil
+}
+
+// MarkSparkApplicationLost sets the state of a SparkApplication whose resource no longer exists to LostState, keeping
+// any termination time already recorded
+func (db *Database) MarkSparkApplicationLost(ctx context.Context, gatewayIdUid uuid.UUID, terminationTime time.Time) error {
+	queries := New(db.connectionPool)
+
+	err := queries.MarkSparkApplicationLost(ctx, MarkSparkApplicationLostParams{
+		TerminationTime: &terminationTime,
+		Uid:             gatewayIdUid,
+	})
+	if err != nil {
+		return gatewayerrors.NewFrom(fmt.Errorf("error marking SparkApplication '%s' lost in database: %w", gatewayIdUid, err))
+	}
+
+	return nil
+}
+
+// InsertSparkApplicationEvent records a lifecycle event of the SparkApplication gatewayIdUid
+func (db *Database) InsertSparkApplicationEvent(ctx context.Context, gatewayIdUid uuid.UUID, clusterName string, namespace string, event domain.TimelineEvent) error {
+	queries := New(db.connectionPool)
+
+	var message *string
+	if event.Message != "" {
+		message = &event.Message
+	}
+
+	err := queries.InsertSparkApplicationEvent(ctx, InsertSparkApplicationEventParams{
+		Uid:       gatewayIdUid,
+		Cluster:   clusterName,
+		Namespace: namespace,
+		EventTime: event.Time,
+		State:     event.State,
+		Message:   message,
+	})
+	if err != nil {
+		return gatewayerrors.NewFrom(fmt.Errorf("error inserting SparkApplication '%s' event into database: %w", gatewayIdUid, err))
+	}
+
+	return nil
+}
+
+// RecordStateEvent records the state sparkApp is in at now as a lifecycle event, if db stores events
+func RecordStateEvent(ctx context.Context, db SparkApplicationDatabase, gatewayIdUid uuid.UUID, clusterName string, sparkApp *v1beta2.SparkApplication, now time.Time) error {
+	events, ok := db.(SparkApplicationEventDatabase)
+	if !ok {
+		return nil
+	}
+
+	return events.InsertSparkApplicationEvent(ctx, gatewayIdUid, clusterName, sparkApp.Namespace, domain.TimelineEvent{
+		Time:    now.UTC(),
+		State:   string(sparkApp.Status.AppState.State),
+		Message: sparkApp.Status.AppState.ErrorMessage,
+	})
+}
+
+// ListSparkApplicationEvents returns the lifecycle events of the SparkApplication gatewayIdUid, oldest first
+func (db *Database) ListSparkApplicationEvents(ctx context.Context, gatewayIdUid uuid.UUID) ([]SparkApplicationEvent, error) {
+	queries := New(db.connectionPool)
+
+	events, err := queries.ListSparkApplicationEvents(ctx, gatewayIdUid)
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(fmt.Errorf("error listing SparkApplication '%s' events from database: %w", gatewayIdUid, err))
+	}
+
+	return events, nil
+}
+
+// DeleteSparkApplicationEventsBefore deletes the events of SparkApplications in namespace of clusterName recorded before
+// before, returning how many were deleted
+func (db *Database) DeleteSparkApplicationEventsBefore(ctx context.Context, clusterName string, namespace string, before time.Time) (int64, error) {
+	queries := New(db.connectionPool)
+
+	deleted, err := queries.DeleteSparkApplicationEventsBefore(ctx, DeleteSparkApplicationEventsBeforeParams{
+		Cluster:   clusterName,
+		Namespace: namespace,
+		Before:    before,
+	})
+	if err != nil {
+		return 0, gatewayerrors.NewFrom(fmt.Errorf("error deleting SparkApplication events of namespace '%s' from database: %w", namespace, err))
+	}
+
+	return deleted, nil
+}
+
 func SparkAppAuditLog(gatewayIdUid uuid.UUID, sparkApp SparkApplication) {
 	klog.Infof("SparkApplication Updated in DB: gatewayIdUid: %s, name: %s, namespace: %s, cluster: %s, creation_time: %s, username: %s",
 		gatewayIdUid,
@@ -247,3 +429,493 @@ func (db *Database) InsertLivyApplication(ctx context.Context, gatewayId string)
 
 	return livyBatch, nil
 }
+
+// LivyApplicationExists returns true if a Livy batch row exists for the GatewayApplication gatewayId
+func (db *Database) LivyApplicationExists(ctx context.Context, gatewayId string) (bool, error) {
+	queries := New(db.connectionPool)
+
+	exists, err := queries.LivyApplicationExists(ctx, gatewayId)
+	if err != nil {
+		return false, gatewayerrors.NewFrom(fmt.Errorf("error checking Livy SparkApplication '%s' in database: %w", gatewayId, err))
+	}
+
+	return exists, nil
+}
+
+// SetLivyApplicationTerminalBatch persists the batch of a terminal Livy application, or clears it if batch is nil
+func (db *Database) SetLivyApplicationTerminalBatch(ctx context.Context, batchId int, batch *domain.LivyBatch) error {
+	var jsonBatch []byte
+	if batch != nil {
+		var err error
+		jsonBatch, err = json.Marshal(batch)
+		if err != nil {
+			return gatewayerrors.NewFrom(fmt.Errorf("error marshaling Livy batch '%d': %w", batchId, err))
+		}
+	}
+
+	queries := New(db.connectionPool)
+
+	err := queries.SetLivyApplicationTerminalBatch(ctx, SetLivyApplicationTerminalBatchParams{
+		TerminalBatch: jsonBatch,
+		BatchID:       int64(batchId),
+	})
+	if err != nil {
+		return gatewayerrors.NewFrom(fmt.Errorf("error persisting terminal Livy batch '%d' in database: %w", batchId, err))
+	}
+
+	return nil
+}
+
+// UpsertNamespaceRoutingWeight sets the routing weight of a namespace, replacing the weight previously set
+func (db *Database) UpsertNamespaceRoutingWeight(ctx context.Context, weight NamespaceRoutingWeight) error {
+	queries := New(db.connectionPool)
+
+	err := queries.UpsertNamespaceRoutingWeight(ctx, UpsertNamespaceRoutingWeightParams(weight))
+	if err != nil {
+		return gatewayerrors.NewFrom(fmt.Errorf("error setting routing weight of namespace '%s' in cluster '%s' in database: %w", weight.Namespace, weight.Cluster, err))
+	}
+
+	return nil
+}
+
+// InsertRegisteredNamespace records namespace as registered with cluster, returning false if a namespace with the same
+// name or id is already registered with it
+func (db *Database) InsertRegisteredNamespace(ctx context.Context, cluster string, namespace domain.KubeNamespace, now time.Time) (bool, error) {
+	jsonSpec, err := json.Marshal(namespace)
+	if err != nil {
+		return false, gatewayerrors.NewFrom(fmt.Errorf("error marshaling namespace '%s': %w", namespace.Name, err))
+	}
+
+	queries := New(db.connectionPool)
+
+	inserted, err := queries.InsertRegisteredNamespace(ctx, InsertRegisteredNamespaceParams{
+		Cluster:      cluster,
+		Namespace:    namespace.Name,
+		NamespaceID:  namespace.NamespaceId,
+		Spec:         jsonSpec,
+		RegisteredAt: now,
+	})
+	if err != nil {
+		return false, gatewayerrors.NewFrom(fmt.Errorf("error inserting namespace '%s' of cluster '%s' into database: %w", namespace.Name, cluster, err))
+	}
+
+	return inserted > 0, nil
+}
+
+// ListRegisteredNamespaces returns every namespace registered at runtime, oldest first
+func (db *Database) ListRegisteredNamespaces(ctx context.Context) ([]RegisteredNamespace, error) {
+	queries := New(db.connectionPool)
+
+	namespaces, err := queries.ListRegisteredNamespaces(ctx)
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(fmt.Errorf("error listing registered namespaces from database: %w", err))
+	}
+
+	return namespaces, nil
+}
+
+// DeleteRegisteredNamespace removes namespace from the namespaces registered with cluster
+func (db *Database) DeleteRegisteredNamespace(ctx context.Context, cluster string, namespace string) error {
+	queries := New(db.connectionPool)
+
+	if err := queries.DeleteRegisteredNamespace(ctx, DeleteRegisteredNamespaceParams{Cluster: cluster, Namespace: namespace}); err != nil {
+		return gatewayerrors.NewFrom(fmt.Errorf("error deleting namespace '%s' of cluster '%s' from database: %w", namespace, cluster, err))
+	}
+
+	return nil
+}
+
+// ListNamespaceRoutingWeights returns every namespace routing weight set at runtime
+func (db *Database) ListNamespaceRoutingWeights(ctx context.Context) ([]NamespaceRoutingWeight, error) {
+	queries := New(db.connectionPool)
+
+	weights, err := queries.ListNamespaceRoutingWeights(ctx)
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(fmt.Errorf("error listing namespace routing weights from database: %w", err))
+	}
+
+	return weights, nil
+}
+
+// UpsertNamespaceKillSwitch engages the kill switch of a namespace, replacing the kill switch previously engaged
+func (db *Database) UpsertNamespaceKillSwitch(ctx context.Context, killSwitch NamespaceKillSwitch) error {
+	queries := New(db.connectionPool)
+
+	err := queries.UpsertNamespaceKillSwitch(ctx, UpsertNamespaceKillSwitchParams(killSwitch))
+	if err != nil {
+		return gatewayerrors.NewFrom(fmt.Errorf("error engaging kill switch of namespace '%s' in database: %w", killSwitch.Namespace, err))
+	}
+
+	return nil
+}
+
+// ListNamespaceKillSwitches returns every engaged namespace kill switch
+func (db *Database) ListNamespaceKillSwitches(ctx context.Context) ([]NamespaceKillSwitch, error) {
+	queries := New(db.connectionPool)
+
+	killSwitches, err := queries.ListNamespaceKillSwitches(ctx)
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(fmt.Errorf("error listing namespace kill switches from database: %w", err))
+	}
+
+	return killSwitches, nil
+}
+
+// DeleteNamespaceKillSwitch releases the kill switch of namespace, returning false if none was engaged
+func (db *Database) DeleteNamespaceKillSwitch(ctx context.Context, namespace string) (bool, error) {
+	queries := New(db.connectionPool)
+
+	deleted, err := queries.DeleteNamespaceKillSwitch(ctx, namespace)
+	if err != nil {
+		return false, gatewayerrors.NewFrom(fmt.Errorf("error releasing kill switch of namespace '%s' in database: %w", namespace, err))
+	}
+
+	return deleted > 0, nil
+}
+
+func (db *Database) InsertQueuedSubmission(ctx context.Context, submission QueuedSubmission) error {
+	jsonApplication, err := json.Marshal(submission.Application)
+	if err != nil {
+		return gatewayerrors.NewFrom(fmt.Errorf("error marshaling queued SparkApplication '%s': %w", submission.GatewayID, err))
+	}
+
+	queries := New(db.connectionPool)
+
+	err = queries.InsertQueuedSubmission(ctx, InsertQueuedSubmissionParams{
+		GatewayID:   submission.GatewayID,
+		Cluster:     submission.Cluster,
+		Namespace:   submission.Namespace,
+		Username:    submission.Username,
+		Application: jsonApplication,
+		CreatedAt:   submission.CreatedAt,
+	})
+	if err != nil {
+		return gatewayerrors.NewFrom(fmt.Errorf("error queueing SparkApplication '%s' in database: %w", submission.GatewayID, err))
+	}
+
+	return nil
+}
+
+// InsertFailedQueuedSubmission records submission as FAILED after one attempt with its LastError, so it can be
+// recovered like a dead-lettered asynchronous submission
+func (db *Database) InsertFailedQueuedSubmission(ctx context.Context, submission QueuedSubmission) error {
+	jsonApplication, err := json.Marshal(submission.Application)
+	if err != nil {
+		return gatewayerrors.NewFrom(fmt.Errorf("error marshaling queued SparkApplication '%s': %w", submission.GatewayID, err))
+	}
+
+	queries := New(db.connectionPool)
+
+	err = queries.InsertFailedQueuedSubmission(ctx, InsertFailedQueuedSubmissionParams{
+		GatewayID:   submission.GatewayID,
+		Cluster:     submission.Cluster,
+		Namespace:   submission.Namespace,
+		Username:    submission.Username,
+		Application: jsonApplication,
+		LastError:   submission.LastError,
+		CreatedAt:   submission.CreatedAt,
+	})
+	if err != nil {
+		return gatewayerrors.NewFrom(fmt.Errorf("error recording failed SparkApplication '%s' in database: %w", submission.GatewayID, err))
+	}
+
+	return nil
+}
+
+// GetQueuedSubmission returns the queued submission of gatewayId, or nil if it was never queued or has been pruned
+func (db *Database) GetQueuedSubmission(ctx context.Context, gatewayId string) (*QueuedSubmission, error) {
+	queries := New(db.connectionPool)
+
+	submission, err := queries.GetQueuedSubmission(ctx, gatewayId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(fmt.Errorf("error getting queued SparkApplication '%s' from database: %w", gatewayId, err))
+	}
+
+	return &submission, nil
+}
+
+func (db *Database) ClaimQueuedSubmissions(ctx context.Context, now time.Time, leaseUntil time.Time, size int) ([]QueuedSubmission, error) {
+	queries := New(db.connectionPool)
+
+	submissions, err := queries.ClaimQueuedSubmissions(ctx, ClaimQueuedSubmissionsParams{
+		LeaseUntil: leaseUntil,
+		Now:        now,
+		Size:       int32(size),
+	})
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(fmt.Errorf("error claiming queued SparkApplications from database: %w", err))
+	}
+
+	return submissions, nil
+}
+
+// UpdateQueuedSubmission sets the state of a QUEUED submission, returning false if it is no longer queued, e.g.
+// because it was deleted while it was being submitted
+func (db *Database) UpdateQueuedSubmission(ctx context.Context, gatewayId string, state string, lastError *string, nextAttemptAt time.Time, now time.Time) (bool, error) {
+	queries := New(db.connectionPool)
+
+	updated, err := queries.UpdateQueuedSubmission(ctx, UpdateQueuedSubmissionParams{
+		State:         state,
+		LastError:     lastError,
+		NextAttemptAt: nextAttemptAt,
+		Now:           now,
+		GatewayID:     gatewayId,
+	})
+	if err != nil {
+		return false, gatewayerrors.NewFrom(fmt.Errorf("error updating queued SparkApplication '%s' in database: %w", gatewayId, err))
+	}
+
+	return updated > 0, nil
+}
+
+// DeleteUnsubmittedQueuedSubmission deletes the submission of gatewayId unless it was submitted, returning whether
+// it was deleted
+func (db *Database) DeleteUnsubmittedQueuedSubmission(ctx context.Context, gatewayId string) (bool, error) {
+	queries := New(db.connectionPool)
+
+	deleted, err := queries.DeleteUnsubmittedQueuedSubmission(ctx, gatewayId)
+	if err != nil {
+		return false, gatewayerrors.NewFrom(fmt.Errorf("error deleting queued SparkApplication '%s' from database: %w", gatewayId, err))
+	}
+
+	return deleted > 0, nil
+}
+
+func (db *Database) DeleteFinishedQueuedSubmissionsBefore(ctx context.Context, before time.Time) (int64, error) {
+	queries := New(db.connectionPool)
+
+	deleted, err := queries.DeleteFinishedQueuedSubmissionsBefore(ctx, before)
+	if err != nil {
+		return 0, gatewayerrors.NewFrom(fmt.Errorf("error deleting finished queued SparkApplications from database: %w", err))
+	}
+
+	return deleted, nil
+}
+
+// ListQueuedSubmissions returns the submissions in state, most recently updated first, only those of namespace if it
+// is set
+func (db *Database) ListQueuedSubmissions(ctx context.Context, state string, namespace string) ([]QueuedSubmission, error) {
+	queries := New(db.connectionPool)
+
+	submissions, err := queries.ListQueuedSubmissions(ctx, ListQueuedSubmissionsParams{
+		State:     state,
+		Namespace: namespace,
+	})
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(fmt.Errorf("error listing %s queued SparkApplications from database: %w", state, err))
+	}
+
+	return submissions, nil
+}
+
+// RequeueFailedQueuedSubmission queues the FAILED submission of gatewayId again with its attempts reset, returning
+// false if it isn't FAILED
+func (db *Database) RequeueFailedQueuedSubmission(ctx context.Context, gatewayId string, now time.Time) (bool, error) {
+	queries := New(db.connectionPool)
+
+	requeued, err := queries.RequeueFailedQueuedSubmission(ctx, RequeueFailedQueuedSubmissionParams{
+		Now:       now,
+		GatewayID: gatewayId,
+	})
+	if err != nil {
+		return false, gatewayerrors.NewFrom(fmt.Errorf("error requeueing SparkApplication '%s' in database: %w", gatewayId, err))
+	}
+
+	return requeued > 0, nil
+}
+
+// ReplaceFailedQueuedSubmission replaces the GatewayId, cluster and SparkApplication of the FAILED submission of
+// gatewayId with those of replacement, leaving it FAILED. Returns false if it isn't FAILED.
+func (db *Database) ReplaceFailedQueuedSubmission(ctx context.Context, gatewayId string, replacement QueuedSubmission, now time.Time) (bool, error) {
+	jsonApplication, err := json.Marshal(replacement.Application)
+	if err != nil {
+		return false, gatewayerrors.NewFrom(fmt.Errorf("error marshaling queued SparkApplication '%s': %w", replacement.GatewayID, err))
+	}
+
+	queries := New(db.connectionPool)
+
+	replaced, err := queries.ReplaceFailedQueuedSubmission(ctx, ReplaceFailedQueuedSubmissionParams{
+		NewGatewayID: replacement.GatewayID,
+		Cluster:      replacement.Cluster,
+		Application:  jsonApplication,
+		Now:          now,
+		GatewayID:    gatewayId,
+	})
+	if err != nil {
+		return false, gatewayerrors.NewFrom(fmt.Errorf("error replacing queued SparkApplication '%s' in database: %w", gatewayId, err))
+	}
+
+	return replaced > 0, nil
+}
+
+// DeleteFailedQueuedSubmission deletes the FAILED submission of gatewayId, returning false if it isn't FAILED
+func (db *Database) DeleteFailedQueuedSubmission(ctx context.Context, gatewayId string) (bool, error) {
+	queries := New(db.connectionPool)
+
+	deleted, err := queries.DeleteFailedQueuedSubmission(ctx, gatewayId)
+	if err != nil {
+		return false, gatewayerrors.NewFrom(fmt.Errorf("error deleting failed queued SparkApplication '%s' from database: %w", gatewayId, err))
+	}
+
+	return deleted > 0, nil
+}
+
+func (db *Database) InsertScheduledApplication(ctx context.Context, schedule ScheduledApplication) error {
+	jsonApplication, err := json.Marshal(schedule.Application)
+	if err != nil {
+		return gatewayerrors.NewFrom(fmt.Errorf("error marshaling SparkApplication of schedule '%s': %w", schedule.ScheduleID, err))
+	}
+
+	queries := New(db.connectionPool)
+
+	err = queries.InsertScheduledApplication(ctx, InsertScheduledApplicationParams{
+		ScheduleID:  schedule.ScheduleID,
+		Cron:        schedule.Cron,
+		Namespace:   schedule.Namespace,
+		Username:    schedule.Username,
+		Application: jsonApplication,
+		NextRunAt:   schedule.NextRunAt,
+		CreatedAt:   schedule.CreatedAt,
+	})
+	if err != nil {
+		return gatewayerrors.NewFrom(fmt.Errorf("error inserting schedule '%s' into database: %w", schedule.ScheduleID, err))
+	}
+
+	return nil
+}
+
+// GetScheduledApplication returns the schedule scheduleId, or nil if there is none
+func (db *Database) GetScheduledApplication(ctx context.Context, scheduleId string) (*ScheduledApplication, error) {
+	queries := New(db.connectionPool)
+
+	schedule, err := queries.GetScheduledApplication(ctx, scheduleId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(fmt.Errorf("error getting schedule '%s' from database: %w", scheduleId, err))
+	}
+
+	return &schedule, nil
+}
+
+// ListScheduledApplications returns the schedules in namespace, or in every namespace if it is empty, oldest first
+func (db *Database) ListScheduledApplications(ctx context.Context, namespace string) ([]ScheduledApplication, error) {
+	queries := New(db.connectionPool)
+
+	schedules, err := queries.ListScheduledApplications(ctx, namespace)
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(fmt.Errorf("error listing schedules from database: %w", err))
+	}
+
+	return schedules, nil
+}
+
+// SetScheduledApplicationPaused pauses or resumes a schedule, returning false if it doesn't exist
+func (db *Database) SetScheduledApplicationPaused(ctx context.Context, scheduleId string, paused bool, nextRunAt time.Time, now time.Time) (bool, error) {
+	queries := New(db.connectionPool)
+
+	updated, err := queries.SetScheduledApplicationPaused(ctx, SetScheduledApplicationPausedParams{
+		Paused:     paused,
+		NextRunAt:  nextRunAt,
+		Now:        now,
+		ScheduleID: scheduleId,
+	})
+	if err != nil {
+		return false, gatewayerrors.NewFrom(fmt.Errorf("error updating schedule '%s' in database: %w", scheduleId, err))
+	}
+
+	return updated > 0, nil
+}
+
+// DeleteScheduledApplication deletes a schedule and the history of its runs, returning false if it doesn't exist
+func (db *Database) DeleteScheduledApplication(ctx context.Context, scheduleId string) (bool, error) {
+	queries := New(db.connectionPool)
+
+	deleted, err := queries.DeleteScheduledApplication(ctx, scheduleId)
+	if err != nil {
+		return false, gatewayerrors.NewFrom(fmt.Errorf("error deleting schedule '%s' from database: %w", scheduleId, err))
+	}
+
+	return deleted > 0, nil
+}
+
+func (db *Database) ClaimDueScheduledApplications(ctx context.Context, now time.Time, leaseUntil time.Time, size int) ([]ScheduledApplication, error) {
+	queries := New(db.connectionPool)
+
+	schedules, err := queries.ClaimDueScheduledApplications(ctx, ClaimDueScheduledApplicationsParams{
+		LeaseUntil: &leaseUntil,
+		Now:        now,
+		Size:       int32(size),
+	})
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(fmt.Errorf("error claiming due schedules from database: %w", err))
+	}
+
+	return schedules, nil
+}
+
+// CompleteScheduledApplicationRun records run in the history of its schedule, keeping its keepRuns latest runs, and
+// moves the schedule on to nextRunAt unless it was resumed with another next run in the meantime
+func (db *Database) CompleteScheduledApplicationRun(ctx context.Context, run ScheduledApplicationRun, nextRunAt time.Time, keepRuns int) error {
+	tx, err := db.connectionPool.Begin(ctx)
+	if err != nil {
+		return gatewayerrors.NewFrom(fmt.Errorf("error starting transaction: %w", err))
+	}
+	defer tx.Rollback(ctx)
+
+	queries := New(db.connectionPool).WithTx(tx)
+
+	err = queries.InsertScheduledApplicationRun(ctx, InsertScheduledApplicationRunParams{
+		ScheduleID:  run.ScheduleID,
+		ScheduledAt: run.ScheduledAt,
+		GatewayID:   run.GatewayID,
+		Error:       run.Error,
+		CreatedAt:   run.CreatedAt,
+	})
+	if err != nil {
+		return gatewayerrors.NewFrom(fmt.Errorf("error inserting run of schedule '%s' into database: %w", run.ScheduleID, err))
+	}
+
+	_, err = queries.CompleteScheduledApplicationRun(ctx, CompleteScheduledApplicationRunParams{
+		NextRunAt:   nextRunAt,
+		ScheduledAt: &run.ScheduledAt,
+		Now:         run.CreatedAt,
+		ScheduleID:  run.ScheduleID,
+	})
+	if err != nil {
+		return gatewayerrors.NewFrom(fmt.Errorf("error updating schedule '%s' in database: %w", run.ScheduleID, err))
+	}
+
+	_, err = queries.DeleteScheduledApplicationRunsBeyond(ctx, DeleteScheduledApplicationRunsBeyondParams{
+		ScheduleID: run.ScheduleID,
+		Keep:       int32(keepRuns),
+	})
+	if err != nil {
+		return gatewayerrors.NewFrom(fmt.Errorf("error pruning runs of schedule '%s' from database: %w", run.ScheduleID, err))
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return gatewayerrors.NewFrom(fmt.Errorf("error committing run of schedule '%s': %w", run.ScheduleID, err))
+	}
+
+	return nil
+}
+
+// ListScheduledApplicationRuns returns up to size of the latest runs of a schedule, latest first
+func (db *Database) ListScheduledApplicationRuns(ctx context.Context, scheduleId string, size int) ([]ScheduledApplicationRun, error) {
+	queries := New(db.connectionPool)
+
+	runs, err := queries.ListScheduledApplicationRuns(ctx, ListScheduledApplicationRunsParams{
+		ScheduleID: scheduleId,
+		Size:       int32(size),
+	})
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(fmt.Errorf("error listing runs of schedule '%s' from database: %w", scheduleId, err))
+	}
+
+	return runs, nil
+}
@@ -5,6 +5,7 @@ package database
 
 import (
 	"context"
+	domain "github.com/slackhq/spark-gateway/internal/domain"
 	"sync"
 )
 
@@ -27,6 +28,12 @@ var _ LivyApplicationDatabase = &LivyApplicationDatabaseMock{}
 //			ListFromFunc: func(ctx context.Context, fromId int, size int) ([]LivyApplication, error) {
 //				panic("mock out the ListFrom method")
 //			},
+//			LivyApplicationExistsFunc: func(ctx context.Context, gatewayId string) (bool, error) {
+//				panic("mock out the LivyApplicationExists method")
+//			},
+//			SetLivyApplicationTerminalBatchFunc: func(ctx context.Context, batchId int, batch *domain.LivyBatch) error {
+//				panic("mock out the SetLivyApplicationTerminalBatch method")
+//			},
 //		}
 //
 //		// use mockedLivyApplicationDatabase in code that requires LivyApplicationDatabase
@@ -43,6 +50,12 @@ type LivyApplicationDatabaseMock struct {
 	// ListFromFunc mocks the ListFrom method.
 	ListFromFunc func(ctx context.Context, fromId int, size int) ([]LivyApplication, error)
 
+	// LivyApplicationExistsFunc mocks the LivyApplicationExists method.
+	LivyApplicationExistsFunc func(ctx context.Context, gatewayId string) (bool, error)
+
+	// SetLivyApplicationTerminalBatchFunc mocks the SetLivyApplicationTerminalBatch method.
+	SetLivyApplicationTerminalBatchFunc func(ctx context.Context, batchId int, batch *domain.LivyBatch) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// GetByBatchId holds details about calls to the GetByBatchId method.
@@ -68,10 +81,28 @@ type LivyApplicationDatabaseMock struct {
 			// Size is the size argument value.
 			Size int
 		}
+		// LivyApplicationExists holds details about calls to the LivyApplicationExists method.
+		LivyApplicationExists []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GatewayId is the gatewayId argument value.
+			GatewayId string
+		}
+		// SetLivyApplicationTerminalBatch holds details about calls to the SetLivyApplicationTerminalBatch method.
+		SetLivyApplicationTerminalBatch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BatchId is the batchId argument value.
+			BatchId int
+			// Batch is the batch argument value.
+			Batch *domain.LivyBatch
+		}
 	}
-	lockGetByBatchId          sync.RWMutex
-	lockInsertLivyApplication sync.RWMutex
-	lockListFrom              sync.RWMutex
+	lockGetByBatchId                    sync.RWMutex
+	lockInsertLivyApplication           sync.RWMutex
+	lockListFrom                        sync.RWMutex
+	lockLivyApplicationExists           sync.RWMutex
+	lockSetLivyApplicationTerminalBatch sync.RWMutex
 }
 
 // GetByBatchId calls GetByBatchIdFunc.
@@ -185,3 +216,79 @@ func (mock *LivyApplicationDatabaseMock) ListFromCalls() []struct {
 	mock.lockListFrom.RUnlock()
 	return calls
 }
+
+// LivyApplicationExists calls LivyApplicationExistsFunc.
+func (mock *LivyApplicationDatabaseMock) LivyApplicationExists(ctx context.Context, gatewayId string) (bool, error) {
+	if mock.LivyApplicationExistsFunc == nil {
+		panic("LivyApplicationDatabaseMock.LivyApplicationExistsFunc: method is nil but LivyApplicationDatabase.LivyApplicationExists was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		GatewayId string
+	}{
+		Ctx:       ctx,
+		GatewayId: gatewayId,
+	}
+	mock.lockLivyApplicationExists.Lock()
+	mock.calls.LivyApplicationExists = append(mock.calls.LivyApplicationExists, callInfo)
+	mock.lockLivyApplicationExists.Unlock()
+	return mock.LivyApplicationExistsFunc(ctx, gatewayId)
+}
+
+// LivyApplicationExistsCalls gets all the calls that were made to LivyApplicationExists.
+// Check the length with:
+//
+//	len(mockedLivyApplicationDatabase.LivyApplicationExistsCalls())
+func (mock *LivyApplicationDatabaseMock) LivyApplicationExistsCalls() []struct {
+	Ctx       context.Context
+	GatewayId string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		GatewayId string
+	}
+	mock.lockLivyApplicationExists.RLock()
+	calls = mock.calls.LivyApplicationExists
+	mock.lockLivyApplicationExists.RUnlock()
+	return calls
+}
+
+// SetLivyApplicationTerminalBatch calls SetLivyApplicationTerminalBatchFunc.
+func (mock *LivyApplicationDatabaseMock) SetLivyApplicationTerminalBatch(ctx context.Context, batchId int, batch *domain.LivyBatch) error {
+	if mock.SetLivyApplicationTerminalBatchFunc == nil {
+		panic("LivyApplicationDatabaseMock.SetLivyApplicationTerminalBatchFunc: method is nil but LivyApplicationDatabase.SetLivyApplicationTerminalBatch was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		BatchId int
+		Batch   *domain.LivyBatch
+	}{
+		Ctx:     ctx,
+		BatchId: batchId,
+		Batch:   batch,
+	}
+	mock.lockSetLivyApplicationTerminalBatch.Lock()
+	mock.calls.SetLivyApplicationTerminalBatch = append(mock.calls.SetLivyApplicationTerminalBatch, callInfo)
+	mock.lockSetLivyApplicationTerminalBatch.Unlock()
+	return mock.SetLivyApplicationTerminalBatchFunc(ctx, batchId, batch)
+}
+
+// SetLivyApplicationTerminalBatchCalls gets all the calls that were made to SetLivyApplicationTerminalBatch.
+// Check the length with:
+//
+//	len(mockedLivyApplicationDatabase.SetLivyApplicationTerminalBatchCalls())
+func (mock *LivyApplicationDatabaseMock) SetLivyApplicationTerminalBatchCalls() []struct {
+	Ctx     context.Context
+	BatchId int
+	Batch   *domain.LivyBatch
+} {
+	var calls []struct {
+		Ctx     context.Context
+		BatchId int
+		Batch   *domain.LivyBatch
+	}
+	mock.lockSetLivyApplicationTerminalBatch.RLock()
+	calls = mock.calls.SetLivyApplicationTerminalBatch
+	mock.lockSetLivyApplicationTerminalBatch.RUnlock()
+	return calls
+}
@@ -27,6 +27,12 @@ var _ SparkApplicationDatabase = &SparkApplicationDatabaseMock{}
 //			InsertSparkApplicationFunc: func(ctx context.Context, gatewayIdUid uuid.UUID, creationTime time.Time, userSubmittedSparkApp *v1beta2.SparkApplication, clusterName string) error {
 //				panic("mock out the InsertSparkApplication method")
 //			},
+//			ListActiveSparkApplicationsFunc: func(ctx context.Context, clusterName string, createdBefore time.Time) ([]SparkApplication, error) {
+//				panic("mock out the ListActiveSparkApplications method")
+//			},
+//			MarkSparkApplicationLostFunc: func(ctx context.Context, gatewayIdUid uuid.UUID, terminationTime time.Time) error {
+//				panic("mock out the MarkSparkApplicationLost method")
+//			},
 //			UpdateSparkApplicationFunc: func(ctx context.Context, gatewayIdUid uuid.UUID, updateSparkApp v1beta2.SparkApplication) error {
 //				panic("mock out the UpdateSparkApplication method")
 //			},
@@ -43,6 +49,12 @@ type SparkApplicationDatabaseMock struct {
 	// InsertSparkApplicationFunc mocks the InsertSparkApplication method.
 	InsertSparkApplicationFunc func(ctx context.Context, gatewayIdUid uuid.UUID, creationTime time.Time, userSubmittedSparkApp *v1beta2.SparkApplication, clusterName string) error
 
+	// ListActiveSparkApplicationsFunc mocks the ListActiveSparkApplications method.
+	ListActiveSparkApplicationsFunc func(ctx context.Context, clusterName string, createdBefore time.Time) ([]SparkApplication, error)
+
+	// MarkSparkApplicationLostFunc mocks the MarkSparkApplicationLost method.
+	MarkSparkApplicationLostFunc func(ctx context.Context, gatewayIdUid uuid.UUID, terminationTime time.Time) error
+
 	// UpdateSparkApplicationFunc mocks the UpdateSparkApplication method.
 	UpdateSparkApplicationFunc func(ctx context.Context, gatewayIdUid uuid.UUID, updateSparkApp v1beta2.SparkApplication) error
 
@@ -68,6 +80,24 @@ type SparkApplicationDatabaseMock struct {
 			// ClusterName is the clusterName argument value.
 			ClusterName string
 		}
+		// ListActiveSparkApplications holds details about calls to the ListActiveSparkApplications method.
+		ListActiveSparkApplications []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ClusterName is the clusterName argument value.
+			ClusterName string
+			// CreatedBefore is the createdBefore argument value.
+			CreatedBefore time.Time
+		}
+		// MarkSparkApplicationLost holds details about calls to the MarkSparkApplicationLost method.
+		MarkSparkApplicationLost []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GatewayIdUid is the gatewayIdUid argument value.
+			GatewayIdUid uuid.UUID
+			// TerminationTime is the terminationTime argument value.
+			TerminationTime time.Time
+		}
 		// UpdateSparkApplication holds details about calls to the UpdateSparkApplication method.
 		UpdateSparkApplication []struct {
 			// Ctx is the ctx argument value.
@@ -78,9 +108,11 @@ type SparkApplicationDatabaseMock struct {
 			UpdateSparkApp v1beta2.SparkApplication
 		}
 	}
-	lockGetById                sync.RWMutex
-	lockInsertSparkApplication sync.RWMutex
-	lockUpdateSparkApplication sync.RWMutex
+	lockGetById                     sync.RWMutex
+	lockInsertSparkApplication      sync.RWMutex
+	lockListActiveSparkApplications sync.RWMutex
+	lockMarkSparkApplicationLost    sync.RWMutex
+	lockUpdateSparkApplication      sync.RWMutex
 }
 
 // GetById calls GetByIdFunc.
@@ -167,6 +199,86 @@ func (mock *SparkApplicationDatabaseMock) InsertSparkApplicationCalls() []struct
 	return calls
 }
 
+// ListActiveSparkApplications calls ListActiveSparkApplicationsFunc.
+func (mock *SparkApplicationDatabaseMock) ListActiveSparkApplications(ctx context.Context, clusterName string, createdBefore time.Time) ([]SparkApplication, error) {
+	if mock.ListActiveSparkApplicationsFunc == nil {
+		panic("SparkApplicationDatabaseMock.ListActiveSparkApplicationsFunc: method is nil but SparkApplicationDatabase.ListActiveSparkApplications was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		ClusterName   string
+		CreatedBefore time.Time
+	}{
+		Ctx:           ctx,
+		ClusterName:   clusterName,
+		CreatedBefore: createdBefore,
+	}
+	mock.lockListActiveSparkApplications.Lock()
+	mock.calls.ListActiveSparkApplications = append(mock.calls.ListActiveSparkApplications, callInfo)
+	mock.lockListActiveSparkApplications.Unlock()
+	return mock.ListActiveSparkApplicationsFunc(ctx, clusterName, createdBefore)
+}
+
+// ListActiveSparkApplicationsCalls gets all the calls that were made to ListActiveSparkApplications.
+// Check the length with:
+//
+//	len(mockedSparkApplicationDatabase.ListActiveSparkApplicationsCalls())
+func (mock *SparkApplicationDatabaseMock) ListActiveSparkApplicationsCalls() []struct {
+	Ctx           context.Context
+	ClusterName   string
+	CreatedBefore time.Time
+} {
+	var calls []struct {
+		Ctx           context.Context
+		ClusterName   string
+		CreatedBefore time.Time
+	}
+	mock.lockListActiveSparkApplications.RLock()
+	calls = mock.calls.ListActiveSparkApplications
+	mock.lockListActiveSparkApplications.RUnlock()
+	return calls
+}
+
+// MarkSparkApplicationLost calls MarkSparkApplicationLostFunc.
+func (mock *SparkApplicationDatabaseMock) MarkSparkApplicationLost(ctx context.Context, gatewayIdUid uuid.UUID, terminationTime time.Time) error {
+	if mock.MarkSparkApplicationLostFunc == nil {
+		panic("SparkApplicationDatabaseMock.MarkSparkApplicationLostFunc: method is nil but SparkApplicationDatabase.MarkSparkApplicationLost was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		GatewayIdUid    uuid.UUID
+		TerminationTime time.Time
+	}{
+		Ctx:             ctx,
+		GatewayIdUid:    gatewayIdUid,
+		TerminationTime: terminationTime,
+	}
+	mock.lockMarkSparkApplicationLost.Lock()
+	mock.calls.MarkSparkApplicationLost = append(mock.calls.MarkSparkApplicationLost, callInfo)
+	mock.lockMarkSparkApplicationLost.Unlock()
+	return mock.MarkSparkApplicationLostFunc(ctx, gatewayIdUid, terminationTime)
+}
+
+// MarkSparkApplicationLostCalls gets all the calls that were made to MarkSparkApplicationLost.
+// Check the length with:
+//
+//	len(mockedSparkApplicationDatabase.MarkSparkApplicationLostCalls())
+func (mock *SparkApplicationDatabaseMock) MarkSparkApplicationLostCalls() []struct {
+	Ctx             context.Context
+	GatewayIdUid    uuid.UUID
+	TerminationTime time.Time
+} {
+	var calls []struct {
+		Ctx             context.Context
+		GatewayIdUid    uuid.UUID
+		TerminationTime time.Time
+	}
+	mock.lockMarkSparkApplicationLost.RLock()
+	calls = mock.calls.MarkSparkApplicationLost
+	mock.lockMarkSparkApplicationLost.RUnlock()
+	return calls
+}
+
 // UpdateSparkApplication calls UpdateSparkApplicationFunc.
 func (mock *SparkApplicationDatabaseMock) UpdateSparkApplication(ctx context.Context, gatewayIdUid uuid.UUID, updateSparkApp v1beta2.SparkApplication) error {
 	if mock.UpdateSparkApplicationFunc == nil {
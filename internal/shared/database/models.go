@@ -9,11 +9,72 @@ import (
 
 	"github.com/google/uuid"
 	v1beta2 "github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	domain "github.com/slackhq/spark-gateway/internal/domain"
 )
 
 type LivyApplication struct {
-	BatchID   int64  `json:"batch_id"`
-	GatewayID string `json:"gateway_id"`
+	BatchID       int64             `json:"batch_id"`
+	GatewayID     string            `json:"gateway_id"`
+	TerminalBatch *domain.LivyBatch `json:"terminal_batch"`
+}
+
+type NamespaceKillSwitch struct {
+	Namespace string    `json:"namespace"`
+	Message   string    `json:"message"`
+	EngagedBy string    `json:"engaged_by"`
+	EngagedAt time.Time `json:"engaged_at"`
+}
+
+type NamespaceRoutingWeight struct {
+	Cluster   string    `json:"cluster"`
+	Namespace string    `json:"namespace"`
+	Weight    float64   `json:"weight"`
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type QueuedSubmission struct {
+	GatewayID     string                    `json:"gateway_id"`
+	Cluster       string                    `json:"cluster"`
+	Namespace     string                    `json:"namespace"`
+	Username      string                    `json:"username"`
+	Application   *v1beta2.SparkApplication `json:"application"`
+	State         string                    `json:"state"`
+	Attempts      int32                     `json:"attempts"`
+	LastError     *string                   `json:"last_error"`
+	NextAttemptAt time.Time                 `json:"next_attempt_at"`
+	CreatedAt     time.Time                 `json:"created_at"`
+	UpdatedAt     time.Time                 `json:"updated_at"`
+}
+
+type RegisteredNamespace struct {
+	Cluster      string                `json:"cluster"`
+	Namespace    string                `json:"namespace"`
+	NamespaceID  string                `json:"namespace_id"`
+	Spec         *domain.KubeNamespace `json:"spec"`
+	RegisteredAt time.Time             `json:"registered_at"`
+}
+
+type ScheduledApplication struct {
+	ScheduleID  string                    `json:"schedule_id"`
+	Cron        string                    `json:"cron"`
+	Namespace   string                    `json:"namespace"`
+	Username    string                    `json:"username"`
+	Application *v1beta2.SparkApplication `json:"application"`
+	Paused      bool                      `json:"paused"`
+	NextRunAt   time.Time                 `json:"next_run_at"`
+	LeasedUntil *time.Time                `json:"leased_until"`
+	LastRunAt   *time.Time                `json:"last_run_at"`
+	CreatedAt   time.Time                 `json:"created_at"`
+	UpdatedAt   time.Time                 `json:"updated_at"`
+}
+
+type ScheduledApplicationRun struct {
+	ScheduleID  string    `json:"schedule_id"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+	GatewayID   *string   `json:"gateway_id"`
+	Error       *string   `json:"error"`
+	CreatedAt   time.Time `json:"created_at"`
 }
 
 type SparkApplication struct {
@@ -29,3 +90,13 @@ type SparkApplication struct {
 	State           *string                         `json:"state"`
 	Status          *v1beta2.SparkApplicationStatus `json:"status"`
 }
+
+type SparkApplicationEvent struct {
+	ID        int64     `json:"id"`
+	Uid       uuid.UUID `json:"uid"`
+	Cluster   string    `json:"cluster"`
+	Namespace string    `json:"namespace"`
+	EventTime time.Time `json:"event_time"`
+	State     string    `json:"state"`
+	Message   *string   `json:"message"`
+}
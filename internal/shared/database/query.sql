@@ -46,6 +46,41 @@ DO UPDATE SET
     status = EXCLUDED.status
 RETURNING *;
 
+-- name: ListActiveSparkApplications :many
+SELECT * FROM spark_applications
+WHERE cluster = @cluster
+AND (state IS NULL OR state NOT IN ('COMPLETED', 'FAILED', 'LOST'))
+AND creation_time < @created_before;
+
+-- name: MarkSparkApplicationLost :exec
+UPDATE spark_applications
+SET state = 'LOST',
+    termination_time = COALESCE(termination_time, @termination_time)
+WHERE uid = @uid;
+
+-- name: InsertSparkApplicationEvent :exec
+INSERT INTO spark_application_events (
+    uid,
+    cluster,
+    namespace,
+    event_time,
+    state,
+    message
+) VALUES (
+    @uid, @cluster, @namespace, @event_time, @state, @message
+);
+
+-- name: ListSparkApplicationEvents :many
+SELECT * FROM spark_application_events
+WHERE uid = @uid
+ORDER BY event_time ASC, id ASC;
+
+-- name: DeleteSparkApplicationEventsBefore :execrows
+DELETE FROM spark_application_events
+WHERE cluster = @cluster
+AND namespace = @namespace
+AND event_time < @before;
+
 -- name: InsertLivyApplication :one
 INSERT INTO livy_applications (
     gateway_id
@@ -62,4 +97,264 @@ WHERE "batch_id" = @batch_id;
 SELECT * FROM livy_applications
 WHERE "batch_id" >= @batch_id
 ORDER BY batch_id ASC
-LIMIT @size;
\ No newline at end of file
+LIMIT @size;
+
+-- name: LivyApplicationExists :one
+SELECT EXISTS (
+    SELECT 1 FROM livy_applications
+    WHERE "gateway_id" = @gateway_id
+);
+
+-- name: SetLivyApplicationTerminalBatch :exec
+UPDATE livy_applications
+SET terminal_batch = @terminal_batch::jsonb
+WHERE "batch_id" = @batch_id;
+
+-- name: UpsertNamespaceRoutingWeight :exec
+INSERT INTO namespace_routing_weights (
+    cluster,
+    namespace,
+    weight,
+    updated_by,
+    updated_at
+) VALUES (
+    @cluster, @namespace, @weight, @updated_by, @updated_at
+)
+ON CONFLICT (cluster, namespace) DO UPDATE
+SET weight = EXCLUDED.weight,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: ListNamespaceRoutingWeights :many
+SELECT * FROM namespace_routing_weights
+ORDER BY cluster, namespace;
+
+-- name: InsertRegisteredNamespace :execrows
+INSERT INTO registered_namespaces (
+    cluster,
+    namespace,
+    namespace_id,
+    spec,
+    registered_at
+) VALUES (
+    @cluster, @namespace, @namespace_id, @spec::jsonb, @registered_at
+)
+ON CONFLICT DO NOTHING;
+
+-- name: ListRegisteredNamespaces :many
+SELECT * FROM registered_namespaces
+ORDER BY registered_at;
+
+-- name: DeleteRegisteredNamespace :exec
+DELETE FROM registered_namespaces
+WHERE cluster = @cluster
+AND namespace = @namespace;
+
+-- name: UpsertNamespaceKillSwitch :exec
+INSERT INTO namespace_kill_switches (
+    namespace,
+    message,
+    engaged_by,
+    engaged_at
+) VALUES (
+    @namespace, @message, @engaged_by, @engaged_at
+)
+ON CONFLICT (namespace) DO UPDATE
+SET message = EXCLUDED.message,
+    engaged_by = EXCLUDED.engaged_by,
+    engaged_at = EXCLUDED.engaged_at;
+
+-- name: ListNamespaceKillSwitches :many
+SELECT * FROM namespace_kill_switches
+ORDER BY namespace;
+
+-- name: DeleteNamespaceKillSwitch :execrows
+DELETE FROM namespace_kill_switches
+WHERE namespace = @namespace;
+
+-- name: InsertQueuedSubmission :exec
+INSERT INTO queued_submissions (
+    gateway_id,
+    cluster,
+    namespace,
+    username,
+    application,
+    state,
+    attempts,
+    next_attempt_at,
+    created_at,
+    updated_at
+) VALUES (
+    @gateway_id, @cluster, @namespace, @username, @application::jsonb, 'QUEUED', 0, @created_at, @created_at, @created_at
+);
+
+-- name: InsertFailedQueuedSubmission :exec
+INSERT INTO queued_submissions (
+    gateway_id,
+    cluster,
+    namespace,
+    username,
+    application,
+    state,
+    attempts,
+    last_error,
+    next_attempt_at,
+    created_at,
+    updated_at
+) VALUES (
+    @gateway_id, @cluster, @namespace, @username, @application::jsonb, 'FAILED', 1, @last_error, @created_at, @created_at, @created_at
+);
+
+-- name: GetQueuedSubmission :one
+SELECT * FROM queued_submissions
+WHERE gateway_id = @gateway_id;
+
+-- name: ClaimQueuedSubmissions :many
+UPDATE queued_submissions
+SET attempts = attempts + 1,
+    next_attempt_at = @lease_until,
+    updated_at = @now
+WHERE gateway_id IN (
+    SELECT gateway_id FROM queued_submissions
+    WHERE state = 'QUEUED'
+    AND next_attempt_at <= @now
+    ORDER BY next_attempt_at
+    LIMIT @size
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: UpdateQueuedSubmission :execrows
+UPDATE queued_submissions
+SET state = @state,
+    last_error = @last_error,
+    next_attempt_at = @next_attempt_at,
+    updated_at = @now
+WHERE gateway_id = @gateway_id
+AND state = 'QUEUED';
+
+-- name: DeleteUnsubmittedQueuedSubmission :execrows
+DELETE FROM queued_submissions
+WHERE gateway_id = @gateway_id
+AND state <> 'SUBMITTED';
+
+-- name: ListQueuedSubmissions :many
+SELECT * FROM queued_submissions
+WHERE state = @state
+AND (@namespace::text = '' OR namespace = @namespace::text)
+ORDER BY updated_at DESC;
+
+-- name: RequeueFailedQueuedSubmission :execrows
+UPDATE queued_submissions
+SET state = 'QUEUED',
+    attempts = 0,
+    next_attempt_at = @now,
+    updated_at = @now
+WHERE gateway_id = @gateway_id
+AND state = 'FAILED';
+
+-- name: ReplaceFailedQueuedSubmission :execrows
+UPDATE queued_submissions
+SET gateway_id = @new_gateway_id,
+    cluster = @cluster,
+    application = @application::jsonb,
+    updated_at = @now
+WHERE gateway_id = @gateway_id
+AND state = 'FAILED';
+
+-- name: DeleteFailedQueuedSubmission :execrows
+DELETE FROM queued_submissions
+WHERE gateway_id = @gateway_id
+AND state = 'FAILED';
+
+-- name: DeleteFinishedQueuedSubmissionsBefore :execrows
+DELETE FROM queued_submissions
+WHERE state <> 'QUEUED'
+AND updated_at < @before;
+
+-- name: InsertScheduledApplication :exec
+INSERT INTO scheduled_applications (
+    schedule_id,
+    cron,
+    namespace,
+    username,
+    application,
+    paused,
+    next_run_at,
+    created_at,
+    updated_at
+) VALUES (
+    @schedule_id, @cron, @namespace, @username, @application::jsonb, false, @next_run_at, @created_at, @created_at
+);
+
+-- name: GetScheduledApplication :one
+SELECT * FROM scheduled_applications
+WHERE schedule_id = @schedule_id;
+
+-- name: ListScheduledApplications :many
+SELECT * FROM scheduled_applications
+WHERE (@namespace::text = '' OR namespace = @namespace::text)
+ORDER BY created_at;
+
+-- name: SetScheduledApplicationPaused :execrows
+UPDATE scheduled_applications
+SET paused = @paused,
+    next_run_at = @next_run_at,
+    updated_at = @now
+WHERE schedule_id = @schedule_id;
+
+-- name: DeleteScheduledApplication :execrows
+DELETE FROM scheduled_applications
+WHERE schedule_id = @schedule_id;
+
+-- name: ClaimDueScheduledApplications :many
+UPDATE scheduled_applications
+SET leased_until = @lease_until,
+    updated_at = @now
+WHERE schedule_id IN (
+    SELECT schedule_id FROM scheduled_applications
+    WHERE NOT paused
+    AND next_run_at <= @now
+    AND (leased_until IS NULL OR leased_until <= @now)
+    ORDER BY next_run_at
+    LIMIT @size
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CompleteScheduledApplicationRun :execrows
+UPDATE scheduled_applications
+SET next_run_at = @next_run_at,
+    last_run_at = @scheduled_at,
+    leased_until = NULL,
+    updated_at = @now
+WHERE schedule_id = @schedule_id
+AND next_run_at = @scheduled_at;
+
+-- name: InsertScheduledApplicationRun :exec
+INSERT INTO scheduled_application_runs (
+    schedule_id,
+    scheduled_at,
+    gateway_id,
+    error,
+    created_at
+) VALUES (
+    @schedule_id, @scheduled_at, @gateway_id, @error, @created_at
+)
+ON CONFLICT (schedule_id, scheduled_at) DO NOTHING;
+
+-- name: ListScheduledApplicationRuns :many
+SELECT * FROM scheduled_application_runs
+WHERE schedule_id = @schedule_id
+ORDER BY scheduled_at DESC
+LIMIT @size;
+
+-- name: DeleteScheduledApplicationRunsBeyond :execrows
+DELETE FROM scheduled_application_runs
+WHERE schedule_id = @schedule_id
+AND scheduled_at NOT IN (
+    SELECT scheduled_at FROM scheduled_application_runs
+    WHERE schedule_id = @schedule_id
+    ORDER BY scheduled_at DESC
+    LIMIT @keep
+);
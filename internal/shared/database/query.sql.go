@@ -12,15 +12,282 @@ import (
 	"github.com/google/uuid"
 )
 
+const claimDueScheduledApplications = `-- name: ClaimDueScheduledApplications :many
+UPDATE scheduled_applications
+SET leased_until = $1,
+    updated_at = $2
+WHERE schedule_id IN (
+    SELECT schedule_id FROM scheduled_applications
+    WHERE NOT paused
+    AND next_run_at <= $2
+    AND (leased_until IS NULL OR leased_until <= $2)
+    ORDER BY next_run_at
+    LIMIT $3
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING schedule_id, cron, namespace, username, application, paused, next_run_at, leased_until, last_run_at, created_at, updated_at
+`
+
+type ClaimDueScheduledApplicationsParams struct {
+	LeaseUntil *time.Time `json:"lease_until"`
+	Now        time.Time  `json:"now"`
+	Size       int32      `json:"size"`
+}
+
+func (q *Queries) ClaimDueScheduledApplications(ctx context.Context, arg ClaimDueScheduledApplicationsParams) ([]ScheduledApplication, error) {
+	rows, err := q.db.Query(ctx, claimDueScheduledApplications, arg.LeaseUntil, arg.Now, arg.Size)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ScheduledApplication
+	for rows.Next() {
+		var i ScheduledApplication
+		if err := rows.Scan(
+			&i.ScheduleID,
+			&i.Cron,
+			&i.Namespace,
+			&i.Username,
+			&i.Application,
+			&i.Paused,
+			&i.NextRunAt,
+			&i.LeasedUntil,
+			&i.LastRunAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const claimQueuedSubmissions = `-- name: ClaimQueuedSubmissions :many
+UPDATE queued_submissions
+SET attempts = attempts + 1,
+    next_attempt_at = $1,
+    updated_at = $2
+WHERE gateway_id IN (
+    SELECT gateway_id FROM queued_submissions
+    WHERE state = 'QUEUED'
+    AND next_attempt_at <= $2
+    ORDER BY next_attempt_at
+    LIMIT $3
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING gateway_id, cluster, namespace, username, application, state, attempts, last_error, next_attempt_at, created_at, updated_at
+`
+
+type ClaimQueuedSubmissionsParams struct {
+	LeaseUntil time.Time `json:"lease_until"`
+	Now        time.Time `json:"now"`
+	Size       int32     `json:"size"`
+}
+
+func (q *Queries) ClaimQueuedSubmissions(ctx context.Context, arg ClaimQueuedSubmissionsParams) ([]QueuedSubmission, error) {
+	rows, err := q.db.Query(ctx, claimQueuedSubmissions, arg.LeaseUntil, arg.Now, arg.Size)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []QueuedSubmission
+	for rows.Next() {
+		var i QueuedSubmission
+		if err := rows.Scan(
+			&i.GatewayID,
+			&i.Cluster,
+			&i.Namespace,
+			&i.Username,
+			&i.Application,
+			&i.State,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const completeScheduledApplicationRun = `-- name: CompleteScheduledApplicationRun :execrows
+UPDATE scheduled_applications
+SET next_run_at = $1,
+    last_run_at = $2,
+    leased_until = NULL,
+    updated_at = $3
+WHERE schedule_id = $4
+AND next_run_at = $2
+`
+
+type CompleteScheduledApplicationRunParams struct {
+	NextRunAt   time.Time  `json:"next_run_at"`
+	ScheduledAt *time.Time `json:"scheduled_at"`
+	Now         time.Time  `json:"now"`
+	ScheduleID  string     `json:"schedule_id"`
+}
+
+func (q *Queries) CompleteScheduledApplicationRun(ctx context.Context, arg CompleteScheduledApplicationRunParams) (int64, error) {
+	result, err := q.db.Exec(ctx, completeScheduledApplicationRun,
+		arg.NextRunAt,
+		arg.ScheduledAt,
+		arg.Now,
+		arg.ScheduleID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteFailedQueuedSubmission = `-- name: DeleteFailedQueuedSubmission :execrows
+DELETE FROM queued_submissions
+WHERE gateway_id = $1
+AND state = 'FAILED'
+`
+
+func (q *Queries) DeleteFailedQueuedSubmission(ctx context.Context, gatewayID string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteFailedQueuedSubmission, gatewayID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteFinishedQueuedSubmissionsBefore = `-- name: DeleteFinishedQueuedSubmissionsBefore :execrows
+DELETE FROM queued_submissions
+WHERE state <> 'QUEUED'
+AND updated_at < $1
+`
+
+func (q *Queries) DeleteFinishedQueuedSubmissionsBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteFinishedQueuedSubmissionsBefore, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteNamespaceKillSwitch = `-- name: DeleteNamespaceKillSwitch :execrows
+DELETE FROM namespace_kill_switches
+WHERE namespace = $1
+`
+
+func (q *Queries) DeleteNamespaceKillSwitch(ctx context.Context, namespace string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteNamespaceKillSwitch, namespace)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteRegisteredNamespace = `-- name: DeleteRegisteredNamespace :exec
+DELETE FROM registered_namespaces
+WHERE cluster = $1
+AND namespace = $2
+`
+
+type DeleteRegisteredNamespaceParams struct {
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+}
+
+func (q *Queries) DeleteRegisteredNamespace(ctx context.Context, arg DeleteRegisteredNamespaceParams) error {
+	_, err := q.db.Exec(ctx, deleteRegisteredNamespace, arg.Cluster, arg.Namespace)
+	return err
+}
+
+const deleteScheduledApplication = `-- name: DeleteScheduledApplication :execrows
+DELETE FROM scheduled_applications
+WHERE schedule_id = $1
+`
+
+func (q *Queries) DeleteScheduledApplication(ctx context.Context, scheduleID string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteScheduledApplication, scheduleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteScheduledApplicationRunsBeyond = `-- name: DeleteScheduledApplicationRunsBeyond :execrows
+DELETE FROM scheduled_application_runs
+WHERE schedule_id = $1
+AND scheduled_at NOT IN (
+    SELECT scheduled_at FROM scheduled_application_runs
+    WHERE schedule_id = $1
+    ORDER BY scheduled_at DESC
+    LIMIT $2
+)
+`
+
+type DeleteScheduledApplicationRunsBeyondParams struct {
+	ScheduleID string `json:"schedule_id"`
+	Keep       int32  `json:"keep"`
+}
+
+func (q *Queries) DeleteScheduledApplicationRunsBeyond(ctx context.Context, arg DeleteScheduledApplicationRunsBeyondParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteScheduledApplicationRunsBeyond, arg.ScheduleID, arg.Keep)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteSparkApplicationEventsBefore = `-- name: DeleteSparkApplicationEventsBefore :execrows
+DELETE FROM spark_application_events
+WHERE cluster = $1
+AND namespace = $2
+AND event_time < $3
+`
+
+type DeleteSparkApplicationEventsBeforeParams struct {
+	Cluster   string    `json:"cluster"`
+	Namespace string    `json:"namespace"`
+	Before    time.Time `json:"before"`
+}
+
+func (q *Queries) DeleteSparkApplicationEventsBefore(ctx context.Context, arg DeleteSparkApplicationEventsBeforeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteSparkApplicationEventsBefore, arg.Cluster, arg.Namespace, arg.Before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteUnsubmittedQueuedSubmission = `-- name: DeleteUnsubmittedQueuedSubmission :execrows
+DELETE FROM queued_submissions
+WHERE gateway_id = $1
+AND state <> 'SUBMITTED'
+`
+
+func (q *Queries) DeleteUnsubmittedQueuedSubmission(ctx context.Context, gatewayID string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUnsubmittedQueuedSubmission, gatewayID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getByBatchId = `-- name: GetByBatchId :one
-SELECT batch_id, gateway_id FROM livy_applications
+SELECT batch_id, gateway_id, terminal_batch FROM livy_applications
 WHERE "batch_id" = $1
 `
 
 func (q *Queries) GetByBatchId(ctx context.Context, batchID int64) (LivyApplication, error) {
 	row := q.db.QueryRow(ctx, getByBatchId, batchID)
 	var i LivyApplication
-	err := row.Scan(&i.BatchID, &i.GatewayID)
+	err := row.Scan(&i.BatchID, &i.GatewayID, &i.TerminalBatch)
 	return i, err
 }
 
@@ -50,22 +317,255 @@ func (q *Queries) GetById(ctx context.Context, uid uuid.UUID) (SparkApplication,
 	return i, err
 }
 
+const getQueuedSubmission = `-- name: GetQueuedSubmission :one
+SELECT gateway_id, cluster, namespace, username, application, state, attempts, last_error, next_attempt_at, created_at, updated_at FROM queued_submissions
+WHERE gateway_id = $1
+`
+
+func (q *Queries) GetQueuedSubmission(ctx context.Context, gatewayID string) (QueuedSubmission, error) {
+	row := q.db.QueryRow(ctx, getQueuedSubmission, gatewayID)
+	var i QueuedSubmission
+	err := row.Scan(
+		&i.GatewayID,
+		&i.Cluster,
+		&i.Namespace,
+		&i.Username,
+		&i.Application,
+		&i.State,
+		&i.Attempts,
+		&i.LastError,
+		&i.NextAttemptAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getScheduledApplication = `-- name: GetScheduledApplication :one
+SELECT schedule_id, cron, namespace, username, application, paused, next_run_at, leased_until, last_run_at, created_at, updated_at FROM scheduled_applications
+WHERE schedule_id = $1
+`
+
+func (q *Queries) GetScheduledApplication(ctx context.Context, scheduleID string) (ScheduledApplication, error) {
+	row := q.db.QueryRow(ctx, getScheduledApplication, scheduleID)
+	var i ScheduledApplication
+	err := row.Scan(
+		&i.ScheduleID,
+		&i.Cron,
+		&i.Namespace,
+		&i.Username,
+		&i.Application,
+		&i.Paused,
+		&i.NextRunAt,
+		&i.LeasedUntil,
+		&i.LastRunAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const insertFailedQueuedSubmission = `-- name: InsertFailedQueuedSubmission :exec
+INSERT INTO queued_submissions (
+    gateway_id,
+    cluster,
+    namespace,
+    username,
+    application,
+    state,
+    attempts,
+    last_error,
+    next_attempt_at,
+    created_at,
+    updated_at
+) VALUES (
+    $1, $2, $3, $4, $5::jsonb, 'FAILED', 1, $6, $7, $7, $7
+)
+`
+
+type InsertFailedQueuedSubmissionParams struct {
+	GatewayID   string    `json:"gateway_id"`
+	Cluster     string    `json:"cluster"`
+	Namespace   string    `json:"namespace"`
+	Username    string    `json:"username"`
+	Application []byte    `json:"application"`
+	LastError   *string   `json:"last_error"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (q *Queries) InsertFailedQueuedSubmission(ctx context.Context, arg InsertFailedQueuedSubmissionParams) error {
+	_, err := q.db.Exec(ctx, insertFailedQueuedSubmission,
+		arg.GatewayID,
+		arg.Cluster,
+		arg.Namespace,
+		arg.Username,
+		arg.Application,
+		arg.LastError,
+		arg.CreatedAt,
+	)
+	return err
+}
+
 const insertLivyApplication = `-- name: InsertLivyApplication :one
 INSERT INTO livy_applications (
     gateway_id
 ) VALUES (
     $1
 )
-RETURNING batch_id, gateway_id
+RETURNING batch_id, gateway_id, terminal_batch
 `
 
 func (q *Queries) InsertLivyApplication(ctx context.Context, gatewayID string) (LivyApplication, error) {
 	row := q.db.QueryRow(ctx, insertLivyApplication, gatewayID)
 	var i LivyApplication
-	err := row.Scan(&i.BatchID, &i.GatewayID)
+	err := row.Scan(&i.BatchID, &i.GatewayID, &i.TerminalBatch)
 	return i, err
 }
 
+const insertQueuedSubmission = `-- name: InsertQueuedSubmission :exec
+INSERT INTO queued_submissions (
+    gateway_id,
+    cluster,
+    namespace,
+    username,
+    application,
+    state,
+    attempts,
+    next_attempt_at,
+    created_at,
+    updated_at
+) VALUES (
+    $1, $2, $3, $4, $5::jsonb, 'QUEUED', 0, $6, $6, $6
+)
+`
+
+type InsertQueuedSubmissionParams struct {
+	GatewayID   string    `json:"gateway_id"`
+	Cluster     string    `json:"cluster"`
+	Namespace   string    `json:"namespace"`
+	Username    string    `json:"username"`
+	Application []byte    `json:"application"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (q *Queries) InsertQueuedSubmission(ctx context.Context, arg InsertQueuedSubmissionParams) error {
+	_, err := q.db.Exec(ctx, insertQueuedSubmission,
+		arg.GatewayID,
+		arg.Cluster,
+		arg.Namespace,
+		arg.Username,
+		arg.Application,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const insertRegisteredNamespace = `-- name: InsertRegisteredNamespace :execrows
+INSERT INTO registered_namespaces (
+    cluster,
+    namespace,
+    namespace_id,
+    spec,
+    registered_at
+) VALUES (
+    $1, $2, $3, $4::jsonb, $5
+)
+ON CONFLICT DO NOTHING
+`
+
+type InsertRegisteredNamespaceParams struct {
+	Cluster      string    `json:"cluster"`
+	Namespace    string    `json:"namespace"`
+	NamespaceID  string    `json:"namespace_id"`
+	Spec         []byte    `json:"spec"`
+	RegisteredAt time.Time `json:"registered_at"`
+}
+
+func (q *Queries) InsertRegisteredNamespace(ctx context.Context, arg InsertRegisteredNamespaceParams) (int64, error) {
+	result, err := q.db.Exec(ctx, insertRegisteredNamespace,
+		arg.Cluster,
+		arg.Namespace,
+		arg.NamespaceID,
+		arg.Spec,
+		arg.RegisteredAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const insertScheduledApplication = `-- name: InsertScheduledApplication :exec
+INSERT INTO scheduled_applications (
+    schedule_id,
+    cron,
+    namespace,
+    username,
+    application,
+    paused,
+    next_run_at,
+    created_at,
+    updated_at
+) VALUES (
+    $1, $2, $3, $4, $5::jsonb, false, $6, $7, $7
+)
+`
+
+type InsertScheduledApplicationParams struct {
+	ScheduleID  string    `json:"schedule_id"`
+	Cron        string    `json:"cron"`
+	Namespace   string    `json:"namespace"`
+	Username    string    `json:"username"`
+	Application []byte    `json:"application"`
+	NextRunAt   time.Time `json:"next_run_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (q *Queries) InsertScheduledApplication(ctx context.Context, arg InsertScheduledApplicationParams) error {
+	_, err := q.db.Exec(ctx, insertScheduledApplication,
+		arg.ScheduleID,
+		arg.Cron,
+		arg.Namespace,
+		arg.Username,
+		arg.Application,
+		arg.NextRunAt,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const insertScheduledApplicationRun = `-- name: InsertScheduledApplicationRun :exec
+INSERT INTO scheduled_application_runs (
+    schedule_id,
+    scheduled_at,
+    gateway_id,
+    error,
+    created_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (schedule_id, scheduled_at) DO NOTHING
+`
+
+type InsertScheduledApplicationRunParams struct {
+	ScheduleID  string    `json:"schedule_id"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+	GatewayID   *string   `json:"gateway_id"`
+	Error       *string   `json:"error"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (q *Queries) InsertScheduledApplicationRun(ctx context.Context, arg InsertScheduledApplicationRunParams) error {
+	_, err := q.db.Exec(ctx, insertScheduledApplicationRun,
+		arg.ScheduleID,
+		arg.ScheduledAt,
+		arg.GatewayID,
+		arg.Error,
+		arg.CreatedAt,
+	)
+	return err
+}
+
 const insertSparkApplication = `-- name: InsertSparkApplication :one
 INSERT INTO spark_applications (
     uid,
@@ -127,8 +627,86 @@ func (q *Queries) InsertSparkApplication(ctx context.Context, arg InsertSparkApp
 	return i, err
 }
 
+const insertSparkApplicationEvent = `-- name: InsertSparkApplicationEvent :exec
+INSERT INTO spark_application_events (
+    uid,
+    cluster,
+    namespace,
+    event_time,
+    state,
+    message
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+`
+
+type InsertSparkApplicationEventParams struct {
+	Uid       uuid.UUID `json:"uid"`
+	Cluster   string    `json:"cluster"`
+	Namespace string    `json:"namespace"`
+	EventTime time.Time `json:"event_time"`
+	State     string    `json:"state"`
+	Message   *string   `json:"message"`
+}
+
+func (q *Queries) InsertSparkApplicationEvent(ctx context.Context, arg InsertSparkApplicationEventParams) error {
+	_, err := q.db.Exec(ctx, insertSparkApplicationEvent,
+		arg.Uid,
+		arg.Cluster,
+		arg.Namespace,
+		arg.EventTime,
+		arg.State,
+		arg.Message,
+	)
+	return err
+}
+
+const listActiveSparkApplications = `-- name: ListActiveSparkApplications :many
+SELECT uid, name, creation_time, termination_time, username, namespace, cluster, submitted, updated, state, status FROM spark_applications
+WHERE cluster = $1
+AND (state IS NULL OR state NOT IN ('COMPLETED', 'FAILED', 'LOST'))
+AND creation_time < $2
+`
+
+type ListActiveSparkApplicationsParams struct {
+	Cluster       *string    `json:"cluster"`
+	CreatedBefore *time.Time `json:"created_before"`
+}
+
+func (q *Queries) ListActiveSparkApplications(ctx context.Context, arg ListActiveSparkApplicationsParams) ([]SparkApplication, error) {
+	rows, err := q.db.Query(ctx, listActiveSparkApplications, arg.Cluster, arg.CreatedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SparkApplication
+	for rows.Next() {
+		var i SparkApplication
+		if err := rows.Scan(
+			&i.Uid,
+			&i.Name,
+			&i.CreationTime,
+			&i.TerminationTime,
+			&i.Username,
+			&i.Namespace,
+			&i.Cluster,
+			&i.Submitted,
+			&i.Updated,
+			&i.State,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listFrom = `-- name: ListFrom :many
-SELECT batch_id, gateway_id FROM livy_applications
+SELECT batch_id, gateway_id, terminal_batch FROM livy_applications
 WHERE "batch_id" >= $1
 ORDER BY batch_id ASC
 LIMIT $2
@@ -148,7 +726,143 @@ func (q *Queries) ListFrom(ctx context.Context, arg ListFromParams) ([]LivyAppli
 	var items []LivyApplication
 	for rows.Next() {
 		var i LivyApplication
-		if err := rows.Scan(&i.BatchID, &i.GatewayID); err != nil {
+		if err := rows.Scan(&i.BatchID, &i.GatewayID, &i.TerminalBatch); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNamespaceKillSwitches = `-- name: ListNamespaceKillSwitches :many
+SELECT namespace, message, engaged_by, engaged_at FROM namespace_kill_switches
+ORDER BY namespace
+`
+
+func (q *Queries) ListNamespaceKillSwitches(ctx context.Context) ([]NamespaceKillSwitch, error) {
+	rows, err := q.db.Query(ctx, listNamespaceKillSwitches)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NamespaceKillSwitch
+	for rows.Next() {
+		var i NamespaceKillSwitch
+		if err := rows.Scan(
+			&i.Namespace,
+			&i.Message,
+			&i.EngagedBy,
+			&i.EngagedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNamespaceRoutingWeights = `-- name: ListNamespaceRoutingWeights :many
+SELECT cluster, namespace, weight, updated_by, updated_at FROM namespace_routing_weights
+ORDER BY cluster, namespace
+`
+
+func (q *Queries) ListNamespaceRoutingWeights(ctx context.Context) ([]NamespaceRoutingWeight, error) {
+	rows, err := q.db.Query(ctx, listNamespaceRoutingWeights)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NamespaceRoutingWeight
+	for rows.Next() {
+		var i NamespaceRoutingWeight
+		if err := rows.Scan(
+			&i.Cluster,
+			&i.Namespace,
+			&i.Weight,
+			&i.UpdatedBy,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listQueuedSubmissions = `-- name: ListQueuedSubmissions :many
+SELECT gateway_id, cluster, namespace, username, application, state, attempts, last_error, next_attempt_at, created_at, updated_at FROM queued_submissions
+WHERE state = $1
+AND ($2::text = '' OR namespace = $2::text)
+ORDER BY updated_at DESC
+`
+
+type ListQueuedSubmissionsParams struct {
+	State     string `json:"state"`
+	Namespace string `json:"namespace"`
+}
+
+func (q *Queries) ListQueuedSubmissions(ctx context.Context, arg ListQueuedSubmissionsParams) ([]QueuedSubmission, error) {
+	rows, err := q.db.Query(ctx, listQueuedSubmissions, arg.State, arg.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []QueuedSubmission
+	for rows.Next() {
+		var i QueuedSubmission
+		if err := rows.Scan(
+			&i.GatewayID,
+			&i.Cluster,
+			&i.Namespace,
+			&i.Username,
+			&i.Application,
+			&i.State,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRegisteredNamespaces = `-- name: ListRegisteredNamespaces :many
+SELECT cluster, namespace, namespace_id, spec, registered_at FROM registered_namespaces
+ORDER BY registered_at
+`
+
+func (q *Queries) ListRegisteredNamespaces(ctx context.Context) ([]RegisteredNamespace, error) {
+	rows, err := q.db.Query(ctx, listRegisteredNamespaces)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RegisteredNamespace
+	for rows.Next() {
+		var i RegisteredNamespace
+		if err := rows.Scan(
+			&i.Cluster,
+			&i.Namespace,
+			&i.NamespaceID,
+			&i.Spec,
+			&i.RegisteredAt,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -159,6 +873,278 @@ func (q *Queries) ListFrom(ctx context.Context, arg ListFromParams) ([]LivyAppli
 	return items, nil
 }
 
+const listScheduledApplicationRuns = `-- name: ListScheduledApplicationRuns :many
+SELECT schedule_id, scheduled_at, gateway_id, error, created_at FROM scheduled_application_runs
+WHERE schedule_id = $1
+ORDER BY scheduled_at DESC
+LIMIT $2
+`
+
+type ListScheduledApplicationRunsParams struct {
+	ScheduleID string `json:"schedule_id"`
+	Size       int32  `json:"size"`
+}
+
+func (q *Queries) ListScheduledApplicationRuns(ctx context.Context, arg ListScheduledApplicationRunsParams) ([]ScheduledApplicationRun, error) {
+	rows, err := q.db.Query(ctx, listScheduledApplicationRuns, arg.ScheduleID, arg.Size)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ScheduledApplicationRun
+	for rows.Next() {
+		var i ScheduledApplicationRun
+		if err := rows.Scan(
+			&i.ScheduleID,
+			&i.ScheduledAt,
+			&i.GatewayID,
+			&i.Error,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listScheduledApplications = `-- name: ListScheduledApplications :many
+SELECT schedule_id, cron, namespace, username, application, paused, next_run_at, leased_until, last_run_at, created_at, updated_at FROM scheduled_applications
+WHERE ($1::text = '' OR namespace = $1::text)
+ORDER BY created_at
+`
+
+func (q *Queries) ListScheduledApplications(ctx context.Context, namespace string) ([]ScheduledApplication, error) {
+	rows, err := q.db.Query(ctx, listScheduledApplications, namespace)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ScheduledApplication
+	for rows.Next() {
+		var i ScheduledApplication
+		if err := rows.Scan(
+			&i.ScheduleID,
+			&i.Cron,
+			&i.Namespace,
+			&i.Username,
+			&i.Application,
+			&i.Paused,
+			&i.NextRunAt,
+			&i.LeasedUntil,
+			&i.LastRunAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSparkApplicationEvents = `-- name: ListSparkApplicationEvents :many
+SELECT id, uid, cluster, namespace, event_time, state, message FROM spark_application_events
+WHERE uid = $1
+ORDER BY event_time ASC, id ASC
+`
+
+func (q *Queries) ListSparkApplicationEvents(ctx context.Context, uid uuid.UUID) ([]SparkApplicationEvent, error) {
+	rows, err := q.db.Query(ctx, listSparkApplicationEvents, uid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SparkApplicationEvent
+	for rows.Next() {
+		var i SparkApplicationEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.Uid,
+			&i.Cluster,
+			&i.Namespace,
+			&i.EventTime,
+			&i.State,
+			&i.Message,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const livyApplicationExists = `-- name: LivyApplicationExists :one
+SELECT EXISTS (
+    SELECT 1 FROM livy_applications
+    WHERE "gateway_id" = $1
+)
+`
+
+func (q *Queries) LivyApplicationExists(ctx context.Context, gatewayID string) (bool, error) {
+	row := q.db.QueryRow(ctx, livyApplicationExists, gatewayID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const markSparkApplicationLost = `-- name: MarkSparkApplicationLost :exec
+UPDATE spark_applications
+SET state = 'LOST',
+    termination_time = COALESCE(termination_time, $1)
+WHERE uid = $2
+`
+
+type MarkSparkApplicationLostParams struct {
+	TerminationTime *time.Time `json:"termination_time"`
+	Uid             uuid.UUID  `json:"uid"`
+}
+
+func (q *Queries) MarkSparkApplicationLost(ctx context.Context, arg MarkSparkApplicationLostParams) error {
+	_, err := q.db.Exec(ctx, markSparkApplicationLost, arg.TerminationTime, arg.Uid)
+	return err
+}
+
+const replaceFailedQueuedSubmission = `-- name: ReplaceFailedQueuedSubmission :execrows
+UPDATE queued_submissions
+SET gateway_id = $1,
+    cluster = $2,
+    application = $3::jsonb,
+    updated_at = $4
+WHERE gateway_id = $5
+AND state = 'FAILED'
+`
+
+type ReplaceFailedQueuedSubmissionParams struct {
+	NewGatewayID string    `json:"new_gateway_id"`
+	Cluster      string    `json:"cluster"`
+	Application  []byte    `json:"application"`
+	Now          time.Time `json:"now"`
+	GatewayID    string    `json:"gateway_id"`
+}
+
+func (q *Queries) ReplaceFailedQueuedSubmission(ctx context.Context, arg ReplaceFailedQueuedSubmissionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, replaceFailedQueuedSubmission,
+		arg.NewGatewayID,
+		arg.Cluster,
+		arg.Application,
+		arg.Now,
+		arg.GatewayID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const requeueFailedQueuedSubmission = `-- name: RequeueFailedQueuedSubmission :execrows
+UPDATE queued_submissions
+SET state = 'QUEUED',
+    attempts = 0,
+    next_attempt_at = $1,
+    updated_at = $1
+WHERE gateway_id = $2
+AND state = 'FAILED'
+`
+
+type RequeueFailedQueuedSubmissionParams struct {
+	Now       time.Time `json:"now"`
+	GatewayID string    `json:"gateway_id"`
+}
+
+func (q *Queries) RequeueFailedQueuedSubmission(ctx context.Context, arg RequeueFailedQueuedSubmissionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, requeueFailedQueuedSubmission, arg.Now, arg.GatewayID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setLivyApplicationTerminalBatch = `-- name: SetLivyApplicationTerminalBatch :exec
+UPDATE livy_applications
+SET terminal_batch = $1::jsonb
+WHERE "batch_id" = $2
+`
+
+type SetLivyApplicationTerminalBatchParams struct {
+	TerminalBatch []byte `json:"terminal_batch"`
+	BatchID       int64  `json:"batch_id"`
+}
+
+func (q *Queries) SetLivyApplicationTerminalBatch(ctx context.Context, arg SetLivyApplicationTerminalBatchParams) error {
+	_, err := q.db.Exec(ctx, setLivyApplicationTerminalBatch, arg.TerminalBatch, arg.BatchID)
+	return err
+}
+
+const setScheduledApplicationPaused = `-- name: SetScheduledApplicationPaused :execrows
+UPDATE scheduled_applications
+SET paused = $1,
+    next_run_at = $2,
+    updated_at = $3
+WHERE schedule_id = $4
+`
+
+type SetScheduledApplicationPausedParams struct {
+	Paused     bool      `json:"paused"`
+	NextRunAt  time.Time `json:"next_run_at"`
+	Now        time.Time `json:"now"`
+	ScheduleID string    `json:"schedule_id"`
+}
+
+func (q *Queries) SetScheduledApplicationPaused(ctx context.Context, arg SetScheduledApplicationPausedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setScheduledApplicationPaused,
+		arg.Paused,
+		arg.NextRunAt,
+		arg.Now,
+		arg.ScheduleID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateQueuedSubmission = `-- name: UpdateQueuedSubmission :execrows
+UPDATE queued_submissions
+SET state = $1,
+    last_error = $2,
+    next_attempt_at = $3,
+    updated_at = $4
+WHERE gateway_id = $5
+AND state = 'QUEUED'
+`
+
+type UpdateQueuedSubmissionParams struct {
+	State         string    `json:"state"`
+	LastError     *string   `json:"last_error"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	Now           time.Time `json:"now"`
+	GatewayID     string    `json:"gateway_id"`
+}
+
+func (q *Queries) UpdateQueuedSubmission(ctx context.Context, arg UpdateQueuedSubmissionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateQueuedSubmission,
+		arg.State,
+		arg.LastError,
+		arg.NextAttemptAt,
+		arg.Now,
+		arg.GatewayID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateSparkApplication = `-- name: UpdateSparkApplication :one
 INSERT INTO spark_applications (
     uid,
@@ -211,3 +1197,70 @@ func (q *Queries) UpdateSparkApplication(ctx context.Context, arg UpdateSparkApp
 	)
 	return i, err
 }
+
+const upsertNamespaceKillSwitch = `-- name: UpsertNamespaceKillSwitch :exec
+INSERT INTO namespace_kill_switches (
+    namespace,
+    message,
+    engaged_by,
+    engaged_at
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (namespace) DO UPDATE
+SET message = EXCLUDED.message,
+    engaged_by = EXCLUDED.engaged_by,
+    engaged_at = EXCLUDED.engaged_at
+`
+
+type UpsertNamespaceKillSwitchParams struct {
+	Namespace string    `json:"namespace"`
+	Message   string    `json:"message"`
+	EngagedBy string    `json:"engaged_by"`
+	EngagedAt time.Time `json:"engaged_at"`
+}
+
+func (q *Queries) UpsertNamespaceKillSwitch(ctx context.Context, arg UpsertNamespaceKillSwitchParams) error {
+	_, err := q.db.Exec(ctx, upsertNamespaceKillSwitch,
+		arg.Namespace,
+		arg.Message,
+		arg.EngagedBy,
+		arg.EngagedAt,
+	)
+	return err
+}
+
+const upsertNamespaceRoutingWeight = `-- name: UpsertNamespaceRoutingWeight :exec
+INSERT INTO namespace_routing_weights (
+    cluster,
+    namespace,
+    weight,
+    updated_by,
+    updated_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (cluster, namespace) DO UPDATE
+SET weight = EXCLUDED.weight,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at
+`
+
+type UpsertNamespaceRoutingWeightParams struct {
+	Cluster   string    `json:"cluster"`
+	Namespace string    `json:"namespace"`
+	Weight    float64   `json:"weight"`
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (q *Queries) UpsertNamespaceRoutingWeight(ctx context.Context, arg UpsertNamespaceRoutingWeightParams) error {
+	_, err := q.db.Exec(ctx, upsertNamespaceRoutingWeight,
+		arg.Cluster,
+		arg.Namespace,
+		arg.Weight,
+		arg.UpdatedBy,
+		arg.UpdatedAt,
+	)
+	return err
+}
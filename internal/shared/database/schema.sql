@@ -14,7 +14,89 @@ CREATE TABLE spark_applications (
     status JSONB                            -- Updated by SparkManager Controller
 );
 
+CREATE TABLE spark_application_events (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    uid UUID NOT NULL,                      -- GatewayId UUID of the SparkApplication
+    cluster TEXT NOT NULL,
+    namespace TEXT NOT NULL,
+    event_time TIMESTAMPTZ NOT NULL,
+    state TEXT NOT NULL,                    -- State the SparkApplication transitioned to
+    message TEXT                            -- Error message of the state, if any
+);
+
+CREATE INDEX spark_application_events_uid_idx ON spark_application_events (uid, event_time);
+CREATE INDEX spark_application_events_retention_idx ON spark_application_events (cluster, namespace, event_time);
+
 CREATE TABLE livy_applications (
     batch_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
-    gateway_id TEXT NOT NULL
-);
\ No newline at end of file
+    gateway_id TEXT NOT NULL,
+    terminal_batch JSONB                    -- Livy batch persisted once its application is terminal
+);
+
+CREATE TABLE namespace_routing_weights (
+    cluster TEXT NOT NULL,
+    namespace TEXT NOT NULL,
+    weight DOUBLE PRECISION NOT NULL,       -- Overrides the namespace's configured routingWeight
+    updated_by TEXT NOT NULL,               -- Admin who set the weight
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (cluster, namespace)
+);
+
+CREATE TABLE registered_namespaces (
+    cluster TEXT NOT NULL,
+    namespace TEXT NOT NULL,
+    namespace_id TEXT NOT NULL,             -- Id used in GatewayIds, unique in the cluster
+    spec JSONB NOT NULL,                    -- KubeNamespace registered, with defaults applied
+    registered_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (cluster, namespace),
+    UNIQUE (cluster, namespace_id)
+);
+
+CREATE TABLE namespace_kill_switches (
+    namespace TEXT PRIMARY KEY,
+    message TEXT NOT NULL,                  -- Returned with the 403 rejecting submissions to the namespace
+    engaged_by TEXT NOT NULL,               -- Admin who engaged the kill switch
+    engaged_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE queued_submissions (
+    gateway_id TEXT PRIMARY KEY,
+    cluster TEXT NOT NULL,
+    namespace TEXT NOT NULL,
+    username TEXT NOT NULL,
+    application JSONB NOT NULL,             -- SparkApplication to create, named after its GatewayId
+    state TEXT NOT NULL,                    -- QUEUED, SUBMITTED or FAILED
+    attempts INTEGER NOT NULL,
+    last_error TEXT,                        -- Error of the last failed attempt
+    next_attempt_at TIMESTAMPTZ NOT NULL,   -- Leased until then while being submitted
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX queued_submissions_next_attempt_idx ON queued_submissions (state, next_attempt_at);
+CREATE INDEX queued_submissions_retention_idx ON queued_submissions (state, updated_at);
+
+CREATE TABLE scheduled_applications (
+    schedule_id TEXT PRIMARY KEY,
+    cron TEXT NOT NULL,
+    namespace TEXT NOT NULL,
+    username TEXT NOT NULL,
+    application JSONB NOT NULL,             -- SparkApplication submitted on every run
+    paused BOOLEAN NOT NULL,
+    next_run_at TIMESTAMPTZ NOT NULL,       -- Scheduled time of the next run
+    leased_until TIMESTAMPTZ,               -- Set while an instance submits the next run
+    last_run_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX scheduled_applications_next_run_idx ON scheduled_applications (paused, next_run_at);
+
+CREATE TABLE scheduled_application_runs (
+    schedule_id TEXT NOT NULL REFERENCES scheduled_applications (schedule_id) ON DELETE CASCADE,
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    gateway_id TEXT,                        -- GatewayId of the submitted application, NULL if submitting it failed
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (schedule_id, scheduled_at)
+);
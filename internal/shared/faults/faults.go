@@ -0,0 +1,181 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package faults injects latency, errors and connection resets into outgoing HTTP calls, to check retries, timeouts
+// and circuit breakers behave under controlled failures.
+package faults
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+// InjectedFaultMessage is the message of injected errors
+const InjectedFaultMessage = "injected fault"
+
+// Injector holds the FaultRules applied to calls made through its transports, keyed by target
+type Injector struct {
+	lock  sync.RWMutex
+	rules map[string]domain.FaultRule
+	// random returns a number in [0, 1) to decide which fault to inject
+	random func() float64
+}
+
+func NewInjector() *Injector {
+	return &Injector{
+		rules:  map[string]domain.FaultRule{},
+		random: rand.Float64,
+	}
+}
+
+// Rules returns the rules by target
+func (i *Injector) Rules() map[string]domain.FaultRule {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	return maps.Clone(i.rules)
+}
+
+// Set applies rule to calls to target, replacing any rule it had
+func (i *Injector) Set(target string, rule domain.FaultRule) error {
+	if err := rule.Validate(); err != nil {
+		return gatewayerrors.NewBadRequest(fmt.Errorf("invalid fault rule for '%s': %w", target, err))
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.rules[target] = rule
+	klog.Warningf("Injecting faults into calls to '%s': %+v", target, rule)
+	return nil
+}
+
+// Clear stops injecting faults into calls to target
+func (i *Injector) Clear(target string) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if _, ok := i.rules[target]; !ok {
+		return gatewayerrors.NewNotFound(fmt.Errorf("no fault rule for '%s'", target))
+	}
+	delete(i.rules, target)
+	klog.Infof("Stopped injecting faults into calls to '%s'", target)
+	return nil
+}
+
+// rule returns the rule applied to calls to target, falling back to the domain.AllFaultTargets rule
+func (i *Injector) rule(target string) (domain.FaultRule, bool) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	if rule, ok := i.rules[target]; ok {
+		return rule, true
+	}
+	rule, ok := i.rules[domain.AllFaultTargets]
+	return rule, ok
+}
+
+// Transport returns a RoundTripper injecting faults into calls made through next. targetOf names the target of each
+// request to look its rule up.
+func (i *Injector) Transport(next http.RoundTripper, targetOf func(*http.Request) string) http.RoundTripper {
+	return &faultTransport{injector: i, next: next, targetOf: targetOf}
+}
+
+type faultTransport struct {
+	injector *Injector
+	next     http.RoundTripper
+	targetOf func(*http.Request) string
+}
+
+func (t *faultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rule, ok := t.injector.rule(t.targetOf(req))
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+
+	if latency := rule.LatencyDuration(); latency > 0 {
+		timer := time.NewTimer(latency)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	roll := t.injector.random()
+	switch {
+	case roll < rule.ResetRate:
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+	case roll < rule.ResetRate+rule.ErrorRate:
+		return errorResponse(req, rule.ErrorStatus), nil
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// errorResponse returns the response of an injected error. Its body is both a Kubernetes Status and a Spark Gateway
+// error, so it is decoded as a failure by Kubernetes clients and by the Gateway alike.
+func errorResponse(req *http.Request, status int) *http.Response {
+	if status == 0 {
+		status = http.StatusServiceUnavailable
+	}
+	body := fmt.Sprintf(`{"kind":"Status","apiVersion":"v1","status":"Failure","code":%d,"message":"%s","error":"%s"}`, status, InjectedFaultMessage, InjectedFaultMessage)
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewBufferString(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// KubernetesVerb names the Kubernetes API verb of a request as a fault injection target: get, list, watch, create,
+// update, patch or delete. Requests for single objects and collections can't be told apart from the URL alone, so
+// GETs are named get unless they watch.
+func KubernetesVerb(req *http.Request) string {
+	switch req.Method {
+	case http.MethodGet:
+		if req.URL.Query().Get("watch") == "true" {
+			return "watch"
+		}
+		return "get"
+	case http.MethodPost:
+		return "create"
+	case http.MethodPut:
+		return "update"
+	case http.MethodPatch:
+		return "patch"
+	case http.MethodDelete:
+		return "delete"
+	default:
+		return req.Method
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faults
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+func TestInjector_Transport(t *testing.T) {
+	testCases := []struct {
+		name           string
+		rules          map[string]domain.FaultRule
+		roll           float64
+		expectedStatus int
+		expectedReset  bool
+		expectedSent   bool
+	}{
+		{
+			name:           "no rule",
+			roll:           0,
+			expectedStatus: http.StatusOK,
+			expectedSent:   true,
+		},
+		{
+			name:           "rule for another target",
+			rules:          map[string]domain.FaultRule{"other": {ErrorRate: 1}},
+			roll:           0,
+			expectedStatus: http.StatusOK,
+			expectedSent:   true,
+		},
+		{
+			name:          "reset",
+			rules:         map[string]domain.FaultRule{"cluster": {ResetRate: 0.5, ErrorRate: 0.5}},
+			roll:          0.4,
+			expectedReset: true,
+		},
+		{
+			name:           "error with default status",
+			rules:          map[string]domain.FaultRule{"cluster": {ResetRate: 0.5, ErrorRate: 0.5}},
+			roll:           0.6,
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "error with status from all targets rule",
+			rules:          map[string]domain.FaultRule{domain.AllFaultTargets: {ErrorRate: 1, ErrorStatus: http.StatusInternalServerError}},
+			roll:           0.9,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "target rule takes precedence over all targets rule",
+			rules:          map[string]domain.FaultRule{domain.AllFaultTargets: {ErrorRate: 1}, "cluster": {Latency: "1ms"}},
+			roll:           0,
+			expectedStatus: http.StatusOK,
+			expectedSent:   true,
+		},
+		{
+			name:           "roll above rates is sent",
+			rules:          map[string]domain.FaultRule{"cluster": {ErrorRate: 0.1, ResetRate: 0.1}},
+			roll:           0.5,
+			expectedStatus: http.StatusOK,
+			expectedSent:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sent := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				sent = true
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			injector := NewInjector()
+			injector.random = func() float64 { return tc.roll }
+			for target, rule := range tc.rules {
+				require.NoError(t, injector.Set(target, rule), "rule should be valid")
+			}
+
+			client := &http.Client{Transport: injector.Transport(http.DefaultTransport, func(*http.Request) string { return "cluster" })}
+			resp, err := client.Get(server.URL)
+
+			assert.Equal(t, tc.expectedSent, sent, "whether the request was sent should match")
+			if tc.expectedReset {
+				assert.True(t, errors.Is(err, syscall.ECONNRESET), "error should be a connection reset")
+				return
+			}
+			require.NoError(t, err, "request should not fail")
+			defer resp.Body.Close()
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode, "status should match")
+			if !tc.expectedSent {
+				body, _ := io.ReadAll(resp.Body)
+				assert.Contains(t, string(body), InjectedFaultMessage, "body should carry the injected fault message")
+			}
+		})
+	}
+}
+
+func TestInjector_TransportLatencyHonorsContext(t *testing.T) {
+	injector := NewInjector()
+	require.NoError(t, injector.Set(domain.AllFaultTargets, domain.FaultRule{Latency: "1h"}), "rule should be valid")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://sparkmanager", nil)
+
+	_, err := injector.Transport(http.DefaultTransport, KubernetesVerb).RoundTrip(req)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "latency should stop at the request deadline")
+}
+
+func TestInjector_SetAndClear(t *testing.T) {
+	injector := NewInjector()
+
+	err := injector.Set("cluster", domain.FaultRule{ErrorRate: 0.8, ResetRate: 0.5})
+	assert.Equal(t, http.StatusBadRequest, gatewayerrors.NewFrom(err).Status, "rates adding up to more than 1 should be rejected")
+
+	err = injector.Set("cluster", domain.FaultRule{Latency: "soon"})
+	assert.Equal(t, http.StatusBadRequest, gatewayerrors.NewFrom(err).Status, "invalid latency should be rejected")
+
+	require.NoError(t, injector.Set("cluster", domain.FaultRule{Latency: "100ms"}), "rule should be valid")
+	assert.Equal(t, map[string]domain.FaultRule{"cluster": {Latency: "100ms"}}, injector.Rules(), "rules should match")
+
+	require.NoError(t, injector.Clear("cluster"), "rule should be cleared")
+	assert.Empty(t, injector.Rules(), "no rules should remain")
+
+	err = injector.Clear("cluster")
+	assert.Equal(t, http.StatusNotFound, gatewayerrors.NewFrom(err).Status, "clearing a missing rule should be not found")
+}
+
+func TestKubernetesVerb(t *testing.T) {
+	testCases := []struct {
+		method   string
+		url      string
+		expected string
+	}{
+		{method: http.MethodGet, url: "https://k8s/apis/sparkoperator.k8s.io/v1beta2/namespaces/ns/sparkapplications", expected: "get"},
+		{method: http.MethodGet, url: "https://k8s/apis/sparkoperator.k8s.io/v1beta2/sparkapplications?watch=true", expected: "watch"},
+		{method: http.MethodPost, url: "https://k8s/api/v1/namespaces", expected: "create"},
+		{method: http.MethodPut, url: "https://k8s/api/v1/namespaces/ns", expected: "update"},
+		{method: http.MethodPatch, url: "https://k8s/api/v1/namespaces/ns", expected: "patch"},
+		{method: http.MethodDelete, url: "https://k8s/api/v1/namespaces/ns", expected: "delete"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			req, _ := http.NewRequest(tc.method, tc.url, nil)
+			assert.Equal(t, tc.expected, KubernetesVerb(req), "verb should match")
+		})
+	}
+}
@@ -16,6 +16,7 @@
 package gatewayerrors
 
 import (
+	"context"
 	"errors"
 	"net/http"
 
@@ -73,6 +74,13 @@ func NewBadRequest(err error) GatewayError {
 	}
 }
 
+func NewForbidden(err error) GatewayError {
+	return GatewayError{
+		Status: http.StatusForbidden,
+		Err:    err,
+	}
+}
+
 func NewNotFound(err error) GatewayError {
 	return GatewayError{
 		Status: http.StatusNotFound,
@@ -87,6 +95,41 @@ func NewAlreadyExists(err error) GatewayError {
 	}
 }
 
+func NewConflict(err error) GatewayError {
+	return GatewayError{
+		Status: http.StatusConflict,
+		Err:    err,
+	}
+}
+
+func NewUnauthorized(err error) GatewayError {
+	return GatewayError{
+		Status: http.StatusUnauthorized,
+		Err:    err,
+	}
+}
+
+func NewTooManyRequests(err error) GatewayError {
+	return GatewayError{
+		Status: http.StatusTooManyRequests,
+		Err:    err,
+	}
+}
+
+func NewUnavailable(err error) GatewayError {
+	return GatewayError{
+		Status: http.StatusServiceUnavailable,
+		Err:    err,
+	}
+}
+
+func NewTimeout(err error) GatewayError {
+	return GatewayError{
+		Status: http.StatusGatewayTimeout,
+		Err:    err,
+	}
+}
+
 func NewInvalid(err error) GatewayError {
 	return GatewayError{
 		Status: http.StatusUnprocessableEntity,
@@ -96,6 +139,8 @@ func NewInvalid(err error) GatewayError {
 
 func MapK8sErrorToGatewayError(err error) GatewayError {
 	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return NewTimeout(err)
 	case errors2.IsAlreadyExists(err):
 		return NewAlreadyExists(err)
 	case errors2.IsNotFound(err):
@@ -104,7 +149,28 @@ func MapK8sErrorToGatewayError(err error) GatewayError {
 		return NewBadRequest(err)
 	case errors2.IsInvalid(err):
 		return NewInvalid(err)
+	case errors2.IsConflict(err):
+		return NewConflict(err)
+	case errors2.IsUnauthorized(err):
+		return NewUnauthorized(err)
+	case errors2.IsForbidden(err):
+		return NewForbidden(err)
+	case errors2.IsTooManyRequests(err):
+		return NewTooManyRequests(err)
+	case errors2.IsServerTimeout(err), errors2.IsTimeout(err):
+		return NewTimeout(err)
+	case errors2.IsServiceUnavailable(err):
+		return NewUnavailable(err)
 	default:
 		return NewInternal(err)
 	}
 }
+
+// IsRetryableK8sError returns true for Kubernetes API errors that are transient and safe to retry: client side
+// throttling by the API server, server or request timeouts and the API server being unavailable
+func IsRetryableK8sError(err error) bool {
+	return errors2.IsTooManyRequests(err) ||
+		errors2.IsServerTimeout(err) ||
+		errors2.IsTimeout(err) ||
+		errors2.IsServiceUnavailable(err)
+}
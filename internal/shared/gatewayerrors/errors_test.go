@@ -0,0 +1,59 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gatewayerrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var testResource = schema.GroupResource{Group: "sparkoperator.k8s.io", Resource: "sparkapplications"}
+
+func TestMapK8sErrorToGatewayError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		status    int
+		retryable bool
+	}{
+		{"already exists", k8sErrors.NewAlreadyExists(testResource, "app"), http.StatusConflict, false},
+		{"not found", k8sErrors.NewNotFound(testResource, "app"), http.StatusNotFound, false},
+		{"conflict", k8sErrors.NewConflict(testResource, "app", errors.New("object was modified")), http.StatusConflict, false},
+		{"unauthorized", k8sErrors.NewUnauthorized("bad token"), http.StatusUnauthorized, false},
+		{"forbidden", k8sErrors.NewForbidden(testResource, "app", errors.New("rbac")), http.StatusForbidden, false},
+		{"throttled", k8sErrors.NewTooManyRequests("slow down", 1), http.StatusTooManyRequests, true},
+		{"server timeout", k8sErrors.NewServerTimeout(testResource, "create", 1), http.StatusGatewayTimeout, true},
+		{"timeout", k8sErrors.NewTimeoutError("timed out", 1), http.StatusGatewayTimeout, true},
+		{"unavailable", k8sErrors.NewServiceUnavailable("down"), http.StatusServiceUnavailable, true},
+		{"wrapped throttled", fmt.Errorf("error creating SparkApplication: %w", k8sErrors.NewTooManyRequests("slow down", 1)), http.StatusTooManyRequests, true},
+		{"request deadline", fmt.Errorf("error getting SparkApplication: %w", context.DeadlineExceeded), http.StatusGatewayTimeout, false},
+		{"unknown", errors.New("something else"), http.StatusInternalServerError, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.status, MapK8sErrorToGatewayError(test.err).Status, "statuses should match")
+			assert.Equal(t, test.retryable, IsRetryableK8sError(test.err), "retryable should match")
+		})
+	}
+}
@@ -29,6 +29,34 @@ import (
 	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
 )
 
+// RequestDeadlineHeader carries the deadline of a Gateway request to SparkManager as an RFC 3339 timestamp, so
+// SparkManager stops its Kubernetes calls once the Gateway has given up on the response
+const RequestDeadlineHeader = "X-Spark-Gateway-Deadline"
+
+// SelectorOverrideHeader is set to "true" on Gateway requests made for admins that override the selector label check,
+// so SparkManager also deletes and changes SparkApplications that weren't submitted through Spark Gateway
+const SelectorOverrideHeader = "X-Spark-Gateway-Selector-Override"
+
+// SetSelectorOverride sets SelectorOverrideHeader if override is set
+func SetSelectorOverride(header http.Header, override bool) {
+	if override {
+		header.Set(SelectorOverrideHeader, "true")
+	}
+}
+
+// IsSelectorOverride returns whether header carries SelectorOverrideHeader
+func IsSelectorOverride(header http.Header) bool {
+	return header.Get(SelectorOverrideHeader) == "true"
+}
+
+const (
+	// ResourceVersionHeader carries the resource version of the informer cache a SparkManager list was served from
+	ResourceVersionHeader = "X-Spark-Gateway-Resource-Version"
+	// CacheStaleSinceHeader carries when the informer cache a SparkManager list was served from went stale, as an
+	// RFC 3339 timestamp. It is only set on lists served from a stale cache.
+	CacheStaleSinceHeader = "X-Spark-Gateway-Cache-Stale-Since"
+)
+
 type HttpError struct {
 	Error string `json:"error"`
 }
@@ -47,9 +75,47 @@ var DefaultClient = &http.Client{
 	},
 }
 
+// StreamingClient is a shared HTTP client for internal SparkManager calls that stream large response bodies. It has
+// no overall request timeout since reading the body may take a long time, but still bounds waiting for a peer to
+// start responding.
+var StreamingClient = &http.Client{
+	Transport: &http.Transport{
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       90 * time.Second,
+		ResponseHeaderTimeout: 30 * time.Second,
+	},
+}
+
+// HttpStreamRequest runs a request and returns the response body unread when the response is successful. Otherwise the
+// body is read and mapped to an error with CheckJsonResponse. The caller is responsible for closing the returned body.
+func HttpStreamRequest(ctx context.Context, client *http.Client, req *http.Request) (io.ReadCloser, error) {
+
+	req = req.WithContext(ctx)
+	setDeadlineHeader(ctx, client, req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(fmt.Errorf("failed to make %s request to %s: %w", req.Method, req.URL, err))
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		return resp.Body, nil
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(fmt.Errorf("failed to read response body: %w", err))
+	}
+
+	return nil, CheckJsonResponse(resp, &responseBody)
+}
+
 func HttpRequest(ctx context.Context, client *http.Client, req *http.Request) (*http.Response, *[]byte, error) {
 
 	req = req.WithContext(ctx)
+	setDeadlineHeader(ctx, client, req)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -86,3 +152,33 @@ func CheckJsonResponse(resp *http.Response, respBody *[]byte) error {
 
 	return nil
 }
+
+// setDeadlineHeader sets RequestDeadlineHeader to the earlier of ctx's deadline and client's timeout, if either is set
+func setDeadlineHeader(ctx context.Context, client *http.Client, req *http.Request) {
+	deadline, ok := ctx.Deadline()
+	if client.Timeout > 0 {
+		if clientDeadline := time.Now().Add(client.Timeout); !ok || clientDeadline.Before(deadline) {
+			deadline, ok = clientDeadline, true
+		}
+	}
+	if !ok {
+		return
+	}
+
+	req.Header.Set(RequestDeadlineHeader, deadline.UTC().Format(time.RFC3339Nano))
+}
+
+// RequestDeadline returns the deadline set by the Gateway in req's RequestDeadlineHeader, if any
+func RequestDeadline(req *http.Request) (time.Time, bool, error) {
+	value := req.Header.Get(RequestDeadlineHeader)
+	if value == "" {
+		return time.Time{}, false, nil
+	}
+
+	deadline, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s header '%s': %w", RequestDeadlineHeader, value, err)
+	}
+
+	return deadline, true, nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// APIVersionHeader carries the version of the Gateway to SparkManager API implemented by the sender
+	APIVersionHeader = "X-Spark-Gateway-Api-Version"
+	// MinAPIVersionHeader carries the oldest version of the Gateway to SparkManager API the sender is compatible with
+	MinAPIVersionHeader = "X-Spark-Gateway-Min-Api-Version"
+)
+
+const (
+	// APIVersion is the version of the Gateway to SparkManager API implemented by this build. Bump it with every change
+	// to the requests or responses exchanged between them.
+	APIVersion = 2
+	// MinCompatibleAPIVersion is the oldest APIVersion this build is compatible with. Raise it when a change can't be
+	// handled by older builds, so calls between incompatible Gateways and SparkManagers fail during a rolling upgrade
+	// instead of being mis-handled.
+	MinCompatibleAPIVersion = 1
+)
+
+// SetAPIVersionHeaders sets APIVersionHeader and MinAPIVersionHeader to the versions of this build
+func SetAPIVersionHeaders(header http.Header) {
+	header.Set(APIVersionHeader, strconv.Itoa(APIVersion))
+	header.Set(MinAPIVersionHeader, strconv.Itoa(MinCompatibleAPIVersion))
+}
+
+// PeerAPIVersion returns the APIVersion the peer set in header, if any
+func PeerAPIVersion(header http.Header) (int, bool, error) {
+	return headerVersion(header, APIVersionHeader)
+}
+
+// CheckAPIVersion returns an error if the peer that sent header and this build aren't compatible, i.e. either one
+// implements an APIVersion older than the other's MinCompatibleAPIVersion. Peers without the headers predate versioning
+// and are accepted.
+func CheckAPIVersion(header http.Header) error {
+	return checkAPIVersion(header, APIVersion, MinCompatibleAPIVersion)
+}
+
+func checkAPIVersion(header http.Header, version int, minVersion int) error {
+	peerVersion, ok, err := headerVersion(header, APIVersionHeader)
+	if err != nil || !ok {
+		return err
+	}
+	peerMinVersion, ok, err := headerVersion(header, MinAPIVersionHeader)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		peerMinVersion = peerVersion
+	}
+
+	if peerVersion < minVersion {
+		return fmt.Errorf("peer API version %d is older than the minimum version %d supported", peerVersion, minVersion)
+	}
+	if version < peerMinVersion {
+		return fmt.Errorf("API version %d is older than the minimum version %d supported by the peer", version, peerMinVersion)
+	}
+
+	return nil
+}
+
+func headerVersion(header http.Header, name string) (int, bool, error) {
+	value := header.Get(name)
+	if value == "" {
+		return 0, false, nil
+	}
+
+	version, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid %s header '%s': %w", name, value, err)
+	}
+
+	return version, true, nil
+}
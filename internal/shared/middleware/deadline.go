@@ -0,0 +1,59 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	sgHttp "github.com/slackhq/spark-gateway/internal/shared/http"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+)
+
+// RequestDeadline bounds the context of each request by timeout and by the deadline the Gateway propagated in
+// sgHttp.RequestDeadlineHeader, whichever is earlier, so Kubernetes calls made for requests the client has given up on
+// are cancelled. Requests to streamingRoutes, as returned by routes.Group.StreamingPaths, are only bounded by the
+// propagated deadline. A timeout of 0 only applies propagated deadlines.
+func RequestDeadline(timeout time.Duration, streamingRoutes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		deadline, ok, err := sgHttp.RequestDeadline(c.Request)
+		if err != nil {
+			c.Error(gatewayerrors.NewBadRequest(err))
+			c.Abort()
+			return
+		}
+
+		if timeout > 0 && !routes.IsStreaming(c, streamingRoutes) {
+			if timeoutDeadline := time.Now().Add(timeout); !ok || timeoutDeadline.Before(deadline) {
+				deadline, ok = timeoutDeadline, true
+			}
+		}
+
+		if !ok {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithDeadline(c.Request.Context(), deadline)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	sgHttp "github.com/slackhq/spark-gateway/internal/shared/http"
+)
+
+func TestRequestDeadline(t *testing.T) {
+	propagated := time.Now().Add(5 * time.Second).UTC()
+
+	tests := []struct {
+		name           string
+		path           string
+		header         string
+		expectStatus   int
+		expectDeadline bool
+		maxRemaining   time.Duration
+	}{
+		{"timeout applied", "/apps/ns", "", http.StatusOK, true, time.Minute},
+		{"earlier propagated deadline wins", "/apps/ns", propagated.Format(time.RFC3339Nano), http.StatusOK, true, 5 * time.Second},
+		{"streaming route unbounded", "/apps/ns/watch", "", http.StatusOK, false, 0},
+		{"streaming route keeps propagated deadline", "/apps/ns/watch", propagated.Format(time.RFC3339Nano), http.StatusOK, true, 5 * time.Second},
+		{"invalid header", "/apps/ns", "tomorrow", http.StatusBadRequest, false, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var ctx context.Context
+
+			router := gin.New()
+			router.Use(ApplicationErrorHandler, RequestDeadline(time.Minute, "/apps/:namespace/watch"))
+			handler := func(c *gin.Context) {
+				ctx = c.Request.Context()
+				c.Status(http.StatusOK)
+			}
+			router.GET("/apps/:namespace", handler)
+			router.GET("/apps/:namespace/watch", handler)
+
+			req := httptest.NewRequest(http.MethodGet, test.path, nil)
+			if test.header != "" {
+				req.Header.Set(sgHttp.RequestDeadlineHeader, test.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectStatus, w.Code, "statuses should match")
+			if test.expectStatus != http.StatusOK {
+				return
+			}
+
+			deadline, ok := ctx.Deadline()
+			assert.Equal(t, test.expectDeadline, ok, "deadline presence should match")
+			if ok {
+				assert.LessOrEqual(t, time.Until(deadline), test.maxRemaining, "deadline should not exceed the expected bound")
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/slackhq/spark-gateway/internal/domain"
 	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
 )
 
@@ -35,7 +36,18 @@ func ApplicationErrorHandler(c *gin.Context) {
 
 		var gatewayError gatewayerrors.GatewayError
 		if errors.As(lastErr, &gatewayError) {
-			c.AbortWithStatusJSON(gatewayError.Status, gin.H{"error": gatewayError.Error()})
+			body := gin.H{"error": gatewayError.Error()}
+			// Return the structured results of failed validations so clients can point at the fields to fix
+			var validationErr *domain.ValidationError
+			if errors.As(gatewayError, &validationErr) {
+				body["validation"] = validationErr.Results
+			}
+			// Describe how the latency budget of requests that ran out of it was spent
+			var budgetErr *domain.LatencyBudgetError
+			if errors.As(gatewayError, &budgetErr) {
+				body["latencyBudget"] = budgetErr
+			}
+			c.AbortWithStatusJSON(gatewayError.Status, body)
 			return
 		}
 
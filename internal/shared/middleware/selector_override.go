@@ -0,0 +1,35 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	sgHttp "github.com/slackhq/spark-gateway/internal/shared/http"
+)
+
+// SelectorOverride carries the override the Gateway propagated in sgHttp.SelectorOverrideHeader into the context of the
+// request, see domain.WithSelectorOverride. The Gateway only sets the header for admins.
+func SelectorOverride() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if sgHttp.IsSelectorOverride(c.Request.Header) {
+			c.Request = c.Request.WithContext(domain.WithSelectorOverride(c.Request.Context()))
+		}
+
+		c.Next()
+	}
+}
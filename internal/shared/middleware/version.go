@@ -0,0 +1,45 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	sgHttp "github.com/slackhq/spark-gateway/internal/shared/http"
+)
+
+// APIVersion sets the API version headers of this build on every response and rejects requests from peers it isn't
+// compatible with, as checked by sgHttp.CheckAPIVersion, before they are handled. Rejected requests get a 503 since the
+// skew only lasts until the rolling upgrade completes, so callers retrying server errors retry them on their own.
+func APIVersion() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sgHttp.SetAPIVersionHeaders(c.Writer.Header())
+
+		if err := sgHttp.CheckAPIVersion(c.Request.Header); err != nil {
+			klog.Warningf("Rejecting %s %s from an incompatible peer: %v", c.Request.Method, c.Request.URL.Path, err)
+			c.Error(gatewayerrors.New(http.StatusServiceUnavailable, fmt.Errorf("incompatible API versions, retry once the rolling upgrade completes: %w", err)))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	sgHttp "github.com/slackhq/spark-gateway/internal/shared/http"
+)
+
+func TestAPIVersion(t *testing.T) {
+	tests := []struct {
+		name         string
+		version      string
+		minVersion   string
+		expectStatus int
+	}{
+		{"unversioned peer", "", "", http.StatusOK},
+		{"same version", strconv.Itoa(sgHttp.APIVersion), strconv.Itoa(sgHttp.MinCompatibleAPIVersion), http.StatusOK},
+		{"newer compatible peer", strconv.Itoa(sgHttp.APIVersion + 1), strconv.Itoa(sgHttp.MinCompatibleAPIVersion), http.StatusOK},
+		{"peer older than supported", strconv.Itoa(sgHttp.MinCompatibleAPIVersion - 1), "", http.StatusServiceUnavailable},
+		{"peer requires a newer version", strconv.Itoa(sgHttp.APIVersion + 1), strconv.Itoa(sgHttp.APIVersion + 1), http.StatusServiceUnavailable},
+		{"invalid header", "latest", "", http.StatusServiceUnavailable},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handled := false
+
+			router := gin.New()
+			router.Use(ApplicationErrorHandler, APIVersion())
+			router.GET("/apps", func(c *gin.Context) {
+				handled = true
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/apps", nil)
+			if test.version != "" {
+				req.Header.Set(sgHttp.APIVersionHeader, test.version)
+			}
+			if test.minVersion != "" {
+				req.Header.Set(sgHttp.MinAPIVersionHeader, test.minVersion)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectStatus, w.Code, "statuses should match")
+			assert.Equal(t, test.expectStatus == http.StatusOK, handled, "only compatible requests should be handled")
+			assert.Equal(t, strconv.Itoa(sgHttp.APIVersion), w.Header().Get(sgHttp.APIVersionHeader), "responses should carry the API version")
+		})
+	}
+}
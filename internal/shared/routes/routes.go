@@ -0,0 +1,168 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+)
+
+// Route is a route served by a handler
+type Route struct {
+	Method string
+	// Path is relative to the BasePath of the Group serving the Route
+	Path string
+	// Middleware run on this Route only, after the Group's, e.g. to declare it deprecated
+	Middleware []gin.HandlerFunc
+	Handler    gin.HandlerFunc
+	// Streaming Routes hold their response open until the client disconnects, so request timeouts don't apply to them
+	Streaming bool
+	// StreamingQuery names a query parameter that makes requests setting it to true Streaming, for Routes that only
+	// stream on request
+	StreamingQuery string
+	// Writes Routes change state, so read-only servers don't serve them. It isn't implied by Method since some reads,
+	// e.g. dry runs, are POSTs.
+	Writes bool
+}
+
+// Group is a set of Routes served under the same base path, API version, authentication and middleware
+type Group struct {
+	// Prefix is the path the Group is served under, before its Version
+	Prefix string
+	// Version is the API version of the Group's Routes, e.g. v1. It is empty for unversioned routes such as /health
+	Version string
+	// Auth is the route group whose configured middleware authenticate requests to the Group. Requests aren't
+	// authenticated if it is empty
+	Auth config.MiddlewareRouteGroup
+	// AllowAnonymous lets requests no configured middleware authenticated through as the anonymous user
+	AllowAnonymous bool
+	// Middleware run on every Route of the Group, before authentication
+	Middleware []gin.HandlerFunc
+	// Authorize run on every Route of the Group after authentication, to restrict what authenticated users can do
+	Authorize []gin.HandlerFunc
+	Routes    []Route
+}
+
+// BasePath returns the path the Group's Routes are relative to
+func (g Group) BasePath() string {
+	if g.Version == "" {
+		return g.Prefix
+	}
+	return g.Prefix + "/" + g.Version
+}
+
+// StreamingPaths returns the full paths of the Group's Streaming Routes, as matched by gin's FullPath. Routes with a
+// StreamingQuery are returned as path?query, see IsStreaming.
+func (g Group) StreamingPaths() []string {
+	var paths []string
+	for _, route := range g.Routes {
+		if route.Streaming {
+			paths = append(paths, g.BasePath()+route.Path)
+		} else if route.StreamingQuery != "" {
+			paths = append(paths, g.BasePath()+route.Path+"?"+route.StreamingQuery)
+		}
+	}
+	return paths
+}
+
+// ReadRoutes returns the Routes of routes that don't write
+func ReadRoutes(routes []Route) []Route {
+	var reads []Route
+	for _, route := range routes {
+		if !route.Writes {
+			reads = append(reads, route)
+		}
+	}
+	return reads
+}
+
+// IsStreaming returns whether the request c is to one of streamingPaths, as returned by Group.StreamingPaths
+func IsStreaming(c *gin.Context, streamingPaths []string) bool {
+	for _, streamingPath := range streamingPaths {
+		path, query, conditional := strings.Cut(streamingPath, "?")
+		if path == c.FullPath() && (!conditional || c.Query(query) == "true") {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticator adds the middleware configured for auth to rg
+type Authenticator func(rg *gin.RouterGroup, auth config.MiddlewareRouteGroup, allowAnonymous bool) error
+
+// Registry collects the Groups a server serves, so the server's gin tree is built from the Groups' declarations
+type Registry struct {
+	groups []Group
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Add adds groups to the Registry
+func (r *Registry) Add(groups ...Group) {
+	r.groups = append(r.groups, groups...)
+}
+
+// Groups returns the Groups added to the Registry
+func (r *Registry) Groups() []Group {
+	return r.groups
+}
+
+// Build registers the Routes of every Group on router, authenticating Groups with Auth set with authenticate. It fails
+// if two Routes share a method and full path, or if a Group needs authentication and authenticate is nil.
+func (r *Registry) Build(router *gin.Engine, authenticate Authenticator) error {
+	seen := map[string]bool{}
+	for _, group := range r.groups {
+		for _, route := range group.Routes {
+			key := route.Method + " " + group.BasePath() + route.Path
+			if seen[key] {
+				return fmt.Errorf("route %s is declared more than once", key)
+			}
+			seen[key] = true
+		}
+		if group.Auth != "" && authenticate == nil {
+			return fmt.Errorf("routes under '%s' need %s authentication but no Authenticator was given", group.BasePath(), group.Auth)
+		}
+	}
+
+	for _, group := range r.groups {
+		rg := router.Group(group.BasePath())
+		rg.Use(group.Middleware...)
+		if group.Auth != "" {
+			if err := authenticate(rg, group.Auth, group.AllowAnonymous); err != nil {
+				return fmt.Errorf("error adding middlewares to routes: %w", err)
+			}
+		}
+		rg.Use(group.Authorize...)
+
+		Register(rg, group.Routes)
+	}
+
+	return nil
+}
+
+// Register registers routes on rg, running each Route's Middleware before its Handler
+func Register(rg *gin.RouterGroup, routes []Route) {
+	for _, route := range routes {
+		handlers := append(append([]gin.HandlerFunc{}, route.Middleware...), route.Handler)
+		rg.Handle(route.Method, route.Path, handlers...)
+	}
+}
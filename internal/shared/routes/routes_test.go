@@ -0,0 +1,160 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func TestGroupBasePath(t *testing.T) {
+	assert.Equal(t, "/api/v1", Group{Prefix: "/api", Version: "v1"}.BasePath(), "versioned base paths should match")
+	assert.Equal(t, "/health", Group{Prefix: "/health"}.BasePath(), "unversioned base paths should match")
+}
+
+func TestGroupStreamingPaths(t *testing.T) {
+	group := Group{
+		Prefix:  "/api",
+		Version: "v1",
+		Routes: []Route{
+			{Method: http.MethodGet, Path: "/applications/:gatewayId"},
+			{Method: http.MethodGet, Path: "/applications/:gatewayId/watch", Streaming: true},
+			{Method: http.MethodGet, Path: "/applications/:gatewayId/logs", StreamingQuery: "follow"},
+		},
+	}
+
+	assert.Equal(t, []string{"/api/v1/applications/:gatewayId/watch", "/api/v1/applications/:gatewayId/logs?follow"}, group.StreamingPaths(), "streaming paths should match")
+}
+
+func TestReadRoutes(t *testing.T) {
+	reads := ReadRoutes([]Route{
+		{Method: http.MethodGet, Path: "/applications"},
+		{Method: http.MethodPost, Path: "/applications", Writes: true},
+		{Method: http.MethodPost, Path: "/applications/render"},
+		{Method: http.MethodDelete, Path: "/applications/:gatewayId", Writes: true},
+	})
+
+	var paths []string
+	for _, route := range reads {
+		paths = append(paths, route.Method+" "+route.Path)
+	}
+	assert.Equal(t, []string{"GET /applications", "POST /applications/render"}, paths, "only reads should be kept")
+}
+
+func TestIsStreaming(t *testing.T) {
+	streamingPaths := []string{"/api/v1/applications/:gatewayId/watch", "/api/v1/applications/:gatewayId/logs?follow"}
+
+	testCases := []struct {
+		name      string
+		path      string
+		streaming bool
+	}{
+		{name: "streaming route", path: "/api/v1/applications/id/watch", streaming: true},
+		{name: "streaming query set", path: "/api/v1/applications/id/logs?follow=true", streaming: true},
+		{name: "streaming query unset", path: "/api/v1/applications/id/logs", streaming: false},
+		{name: "streaming query false", path: "/api/v1/applications/id/logs?follow=false", streaming: false},
+		{name: "other route", path: "/api/v1/applications/id?follow=true", streaming: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var streaming bool
+			router := gin.New()
+			for _, path := range []string{"/api/v1/applications/:gatewayId", "/api/v1/applications/:gatewayId/watch", "/api/v1/applications/:gatewayId/logs"} {
+				router.GET(path, func(c *gin.Context) {
+					streaming = IsStreaming(c, streamingPaths)
+				})
+			}
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tc.path, nil))
+			assert.Equal(t, tc.streaming, streaming, "streaming should match")
+		})
+	}
+}
+
+func marker(name string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("X-Order", name)
+	}
+}
+
+func TestRegistryBuild(t *testing.T) {
+	registry := NewRegistry()
+	registry.Add(Group{
+		Prefix:         "/api",
+		Version:        "v1",
+		Auth:           config.APIRouteGroup,
+		AllowAnonymous: true,
+		Middleware:     []gin.HandlerFunc{marker("group")},
+		Authorize:      []gin.HandlerFunc{marker("authorize")},
+		Routes: []Route{
+			{
+				Method:     http.MethodGet,
+				Path:       "/ping",
+				Middleware: []gin.HandlerFunc{marker("route")},
+				Handler: func(c *gin.Context) {
+					c.Status(http.StatusOK)
+				},
+			},
+		},
+	})
+
+	router := gin.New()
+	err := registry.Build(router, func(rg *gin.RouterGroup, auth config.MiddlewareRouteGroup, allowAnonymous bool) error {
+		assert.Equal(t, config.APIRouteGroup, auth, "route groups should match")
+		assert.True(t, allowAnonymous, "anonymous access should be passed through")
+		rg.Use(marker("auth"))
+		return nil
+	})
+	assert.NoError(t, err, "registry should build")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/ping", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, []string{"group", "auth", "authorize", "route"}, w.Header().Values("X-Order"), "middleware should run in order")
+}
+
+func TestRegistryBuildDuplicateRoute(t *testing.T) {
+	handler := func(c *gin.Context) {}
+	registry := NewRegistry()
+	registry.Add(
+		Group{Prefix: "/api", Version: "v1", Routes: []Route{{Method: http.MethodGet, Path: "/applications", Handler: handler}}},
+		Group{Prefix: "/api/v1", Routes: []Route{{Method: http.MethodGet, Path: "/applications", Handler: handler}}},
+	)
+
+	err := registry.Build(gin.New(), nil)
+	assert.EqualError(t, err, "route GET /api/v1/applications is declared more than once", "errors should match")
+}
+
+func TestRegistryBuildMissingAuthenticator(t *testing.T) {
+	registry := NewRegistry()
+	registry.Add(Group{Prefix: "/api", Version: "v1", Auth: config.APIRouteGroup})
+
+	err := registry.Build(gin.New(), nil)
+	assert.ErrorContains(t, err, "no Authenticator was given", "errors should match")
+}
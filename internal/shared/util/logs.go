@@ -16,11 +16,14 @@
 package util
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"strings"
+	"unicode/utf8"
 
 	"k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
@@ -45,78 +48,165 @@ type LogLine struct {
 	UnmarshalledFailedString string
 }
 
-func GetLogs(podName string, podNamespace string, tailLines int64, k8sClient *kubernetes.Clientset) (*string, error) {
+// StreamLogs returns a stream of the pod's logs, limited to the last tailLines lines if tailLines is not nil. The caller
+// is responsible for closing the stream.
+func StreamLogs(ctx context.Context, podName string, podNamespace string, tailLines *int64, k8sClient *kubernetes.Clientset) (io.ReadCloser, error) {
 	podLogOpts := &v1.PodLogOptions{
-		TailLines: &tailLines,
+		TailLines: tailLines,
 	}
 
 	req := k8sClient.CoreV1().Pods(podNamespace).GetLogs(podName, podLogOpts)
 
-	logStream, err := req.Stream(context.Background())
-	if err != nil {
-		return nil, err
-	}
-
-	defer logStream.Close()
+	return req.Stream(ctx)
+}
 
-	var sb strings.Builder
-	if _, err := io.Copy(&sb, logStream); err != nil {
-		return nil, err
+// FollowLogs returns a stream of the pod's logs that stays open, sending new lines as the pod writes them, until the
+// container exits or ctx is done. It starts from the last tailLines lines if tailLines is not nil. The caller is
+// responsible for closing the stream.
+func FollowLogs(ctx context.Context, podName string, podNamespace string, tailLines *int64, k8sClient *kubernetes.Clientset) (io.ReadCloser, error) {
+	podLogOpts := &v1.PodLogOptions{
+		TailLines: tailLines,
+		Follow:    true,
 	}
 
-	str := sb.String()
+	req := k8sClient.CoreV1().Pods(podNamespace).GetLogs(podName, podLogOpts)
 
-	return &str, nil
+	return req.Stream(ctx)
 }
 
-func UnmarshalLogLines(logString string) *[]LogLine {
-	//logString = strings.ReplaceAll(logString, `\"`, `"`)
-	randomString := "sp1N2L3Str4!^"
-	// Replace "\n" in "}\n{", "}\n", and "\n{" with a special string which can be used as delimiter for splitting log lines later
-	logString = strings.ReplaceAll(logString, "}\n{", fmt.Sprintf("}%s{", randomString))
-	// Replace '\n' in '}\n' with special string which can be used as delimiter for splitting log lines later
-	logString = strings.ReplaceAll(logString, "}\n", fmt.Sprintf("}%s", randomString))
-	// Replace '\n' in '\n{' with special string which can be used as delimiter for splitting log lines later
-	logString = strings.ReplaceAll(logString, "\n{", fmt.Sprintf("%s{", randomString))
+// FormatLogStream reads JSON formatted log lines from src and writes them to dst in a human readable format, one line
+// at a time. Each line is prefixed with a newline. Lines that are not valid JSON are written unchanged.
+func FormatLogStream(dst io.Writer, src io.Reader) error {
+	reader := bufio.NewReader(src)
 
-	// Split by the special string
-	logStrings := strings.Split(logString, randomString)
-
-	var logLines []LogLine
+	for {
+		line, readErr := reader.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			return readErr
+		}
 
-	for _, jsonString := range logStrings {
-		if jsonString == "" {
-			continue
+		line = strings.TrimSuffix(line, "\n")
+		if line != "" {
+			if _, err := io.WriteString(dst, "\n"+FormatLogLine(line)); err != nil {
+				return err
+			}
 		}
 
-		var logLine LogLine
-		err := json.Unmarshal([]byte(jsonString), &logLine)
-		if err != nil {
-			logLine.UnmarshalledFailedString = jsonString
+		if readErr == io.EOF {
+			return nil
 		}
+	}
+}
+
+// FormatLogLine formats a single JSON log line. If the line cannot be unmarshaled to a LogLine, it is returned unchanged.
+func FormatLogLine(line string) string {
+	var logLine LogLine
+	if err := json.Unmarshal([]byte(line), &logLine); err != nil {
+		return line
+	}
 
-		logLines = append(logLines, logLine)
+	// If not an exception logLine
+	if logLine.Exception.Exception_class == "" && logLine.Exception.Exception_message == "" && logLine.Exception.Stacktrace == "" {
+		return fmt.Sprintf("%s %s %s %s %s %s", logLine.Timestamp, logLine.Level, logLine.Source_host, logLine.Thread_name, logLine.Logger_name, logLine.Msg)
 	}
-	return &logLines
+
+	return fmt.Sprintf("%s %s %s %s %s %s\nException: %s - %s\n%s", logLine.Timestamp, logLine.Level, logLine.Source_host, logLine.Thread_name, logLine.Logger_name, logLine.Msg, logLine.Exception.Exception_class, logLine.Exception.Exception_message, logLine.Exception.Stacktrace)
+}
+
+// JSONStringWriter writes everything written to it to the underlying writer as a single JSON string, escaping as it
+// goes so the full value never needs to be held in memory. Close must be called to terminate the string.
+type JSONStringWriter struct {
+	w       io.Writer
+	started bool
+	// pending holds a trailing partial UTF-8 sequence until the rest of it is written
+	pending []byte
+}
+
+func NewJSONStringWriter(w io.Writer) *JSONStringWriter {
+	return &JSONStringWriter{w: w}
 }
 
-func FormatLogLines(logLines *[]LogLine) *string {
-	var logStrings string
-
-	for _, logLine := range *logLines {
-		var logStr string
-		if logLine.UnmarshalledFailedString == "" {
-			// If not an exception logLine
-			if logLine.Exception.Exception_class == "" && logLine.Exception.Exception_message == "" && logLine.Exception.Stacktrace == "" {
-				logStr = fmt.Sprintf("%s %s %s %s %s %s", logLine.Timestamp, logLine.Level, logLine.Source_host, logLine.Thread_name, logLine.Logger_name, logLine.Msg)
-			} else {
-				logStr = fmt.Sprintf("%s %s %s %s %s %s\nException: %s - %s\n%s", logLine.Timestamp, logLine.Level, logLine.Source_host, logLine.Thread_name, logLine.Logger_name, logLine.Msg, logLine.Exception.Exception_class, logLine.Exception.Exception_message, logLine.Exception.Stacktrace)
+func (j *JSONStringWriter) Write(p []byte) (int, error) {
+	if err := j.start(); err != nil {
+		return 0, err
+	}
+
+	data := append(j.pending, p...)
+
+	// Hold back an incomplete rune at the end of data
+	cut := len(data)
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				cut = i
 			}
-		} else {
-			logStr = fmt.Sprintf("%s", logLine.UnmarshalledFailedString)
+			break
 		}
-		logStrings = fmt.Sprintf("%s\n%s", logStrings, logStr)
 	}
+	j.pending = append([]byte(nil), data[cut:]...)
 
-	return &logStrings
+	if err := j.writeEscaped(data[:cut]); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Close writes any pending bytes and the closing quote. It does not close the underlying writer.
+func (j *JSONStringWriter) Close() error {
+	if err := j.start(); err != nil {
+		return err
+	}
+
+	if err := j.writeEscaped(j.pending); err != nil {
+		return err
+	}
+	j.pending = nil
+
+	_, err := io.WriteString(j.w, `"`)
+	return err
+}
+
+func (j *JSONStringWriter) start() error {
+	if j.started {
+		return nil
+	}
+	j.started = true
+
+	_, err := io.WriteString(j.w, `"`)
+	return err
+}
+
+func (j *JSONStringWriter) writeEscaped(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	escaped, err := json.Marshal(string(data))
+	if err != nil {
+		return err
+	}
+
+	// Strip the surrounding quotes added by Marshal
+	_, err = j.w.Write(escaped[1 : len(escaped)-1])
+	return err
+}
+
+// FlushWriter flushes after every write, so followed logs are sent to the client as soon as they are read
+type FlushWriter struct {
+	w       io.Writer
+	flusher http.Flusher
+}
+
+func NewFlushWriter(w http.ResponseWriter) *FlushWriter {
+	flusher, _ := w.(http.Flusher)
+	return &FlushWriter{w: w, flusher: flusher}
+}
+
+func (f *FlushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if f.flusher != nil {
+		f.flusher.Flush()
+	}
+	return n, err
 }
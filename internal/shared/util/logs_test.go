@@ -0,0 +1,64 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatLogStream(t *testing.T) {
+	logs := `{"@timestamp":"2025-01-01T00:00:00Z","level":"INFO","source_host":"host","thread_name":"main","logger_name":"Logger","msg":"started"}
+plain line
+{"@timestamp":"2025-01-01T00:00:01Z","level":"ERROR","source_host":"host","thread_name":"main","logger_name":"Logger","msg":"failed","exception":{"exception_class":"Ex","exception_message":"boom","stacktrace":"at x"}}
+`
+
+	var out bytes.Buffer
+	err := FormatLogStream(&out, strings.NewReader(logs))
+
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "\n2025-01-01T00:00:00Z INFO host main Logger started"+
+		"\nplain line"+
+		"\n2025-01-01T00:00:01Z ERROR host main Logger failed\nException: Ex - boom\nat x", out.String(), "formatted logs should match")
+}
+
+func TestJSONStringWriter(t *testing.T) {
+	value := "quote \" newline \n tab \t unicode é 日本"
+
+	var out bytes.Buffer
+	writer := NewJSONStringWriter(&out)
+
+	// Write one byte at a time to split multi-byte runes across writes
+	for i := 0; i < len(value); i++ {
+		n, err := writer.Write([]byte{value[i]})
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 1, n, "bytes written should match")
+	}
+	assert.Nil(t, writer.Close(), "err should be nil")
+
+	var got string
+	assert.Nil(t, json.Unmarshal(out.Bytes(), &got), "output should be a valid JSON string")
+	assert.Equal(t, value, got, "decoded value should match")
+
+	out.Reset()
+	empty := NewJSONStringWriter(&out)
+	empty.Close()
+	assert.Equal(t, `""`, out.String(), "empty writer should produce an empty JSON string")
+}
@@ -19,15 +19,28 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
 )
 
 type HealthResponse struct {
-	Status string `json:"status"`
+	Status   string                 `json:"status"`
+	Operator *domain.OperatorHealth `json:"operator,omitempty"`
 }
 
-type HealthHandler struct{}
+type HealthHandler struct {
+	operatorHealth service.OperatorHealthChecker
+}
 
 func (h *HealthHandler) Health(c *gin.Context) {
 
-	c.JSON(http.StatusOK, HealthResponse{Status: "OK"})
+	response := HealthResponse{Status: "OK"}
+	// SparkManager itself stays healthy while the operator is down, so it isn't restarted and keeps serving reads
+	if h.operatorHealth != nil {
+		operator := h.operatorHealth.Health()
+		response.Operator = &operator
+	}
+
+	c.JSON(http.StatusOK, response)
 }
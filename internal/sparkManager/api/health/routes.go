@@ -1,11 +1,18 @@
 package health
 
-import "github.com/gin-gonic/gin"
+import (
+	"net/http"
 
-func RegisterHealthRoutes(rg *gin.RouterGroup) {
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
+)
 
-	h := &HealthHandler{}
+// Routes declares /health. operatorHealth is nil when the cluster doesn't check the Spark Operator.
+func Routes(operatorHealth service.OperatorHealthChecker) []routes.Route {
 
-	rg.GET("/health", h.Health)
+	h := &HealthHandler{operatorHealth: operatorHealth}
 
+	return []routes.Route{
+		{Method: http.MethodGet, Path: "/health", Handler: h.Health},
+	}
 }
@@ -3,26 +3,47 @@ package api
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/faults"
 	sgMiddleware "github.com/slackhq/spark-gateway/internal/shared/middleware"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
 	"github.com/slackhq/spark-gateway/internal/sparkManager/api/health"
 	"github.com/slackhq/spark-gateway/internal/sparkManager/api/v1"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/metrics"
 	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
 )
 
-func NewRouter(sgConf *config.SparkGatewayConfig, appService service.SparkApplicationService) (*gin.Engine, error) {
+func NewRouter(sgConf *config.SparkGatewayConfig, namespaces *service.ManagedNamespaces, appService service.SparkApplicationService, namespaceProvisioner service.NamespaceProvisioner, scaleService service.SparkApplicationScaleService, podRenderService service.SparkApplicationPodRenderService, driverMetricsService service.SparkApplicationDriverMetricsService, timelineService service.SparkApplicationTimelineService, operatorHealth service.OperatorHealthChecker, faultInjector *faults.Injector, cluster string) (*gin.Engine, error) {
 
 	router := gin.Default()
-	router.Use(sgMiddleware.ApplicationErrorHandler)
+	// Reject calls from Gateways this SparkManager isn't compatible with before they are handled, e.g. during a rolling
+	// upgrade
+	router.Use(sgMiddleware.ApplicationErrorHandler, sgMiddleware.APIVersion())
 
-	// Root group for unversioned routes
-	rootGroup := router.Group("")
+	registry := routes.NewRegistry()
 
-	health.RegisterHealthRoutes(rootGroup)
+	// Unversioned routes
+	registry.Add(routes.Group{Routes: health.Routes(operatorHealth)})
 
-	// Versioned routes
-	v1Group := router.Group("/api/v1")
+	// faultInjector is nil unless sparkManager.faultInjection is enabled
+	if faultInjector != nil {
+		registry.Add(routes.Group{Prefix: "/faults", Routes: v1.FaultRoutes(faultInjector)})
+	}
 
-	v1.RegisterKubeflowApplicationRoutes(v1Group, sgConf, appService)
+	// Versioned routes
+	v1Group := v1.Group(sgConf, namespaces, appService, namespaceProvisioner, scaleService, podRenderService, driverMetricsService, timelineService)
+	v1Group.Middleware = []gin.HandlerFunc{
+		metrics.RequestMetrics(cluster, v1Group.BasePath(), metrics.Definition),
+		// Bound Kubernetes calls by sparkManager.requestTimeout and the Gateway's deadline, except for long-lived streams
+		sgMiddleware.RequestDeadline(sgConf.SparkManagerConfig.RequestTimeout, v1Group.StreamingPaths()...),
+		// Let admins delete and change SparkApplications without the selector labels
+		sgMiddleware.SelectorOverride(),
+	}
+	registry.Add(v1Group)
+
+	// SparkManager is only called by the Gateway, so its routes aren't authenticated
+	if err := registry.Build(router, nil); err != nil {
+		return nil, err
+	}
 
 	return router, nil
 
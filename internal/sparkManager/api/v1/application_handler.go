@@ -16,13 +16,21 @@
 package v1
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"k8s.io/klog/v2"
 
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	sgHttp "github.com/slackhq/spark-gateway/internal/shared/http"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
 	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
 )
 
@@ -50,7 +58,18 @@ func (h *SparkApplicationHandler) Get(c *gin.Context) {
 }
 
 func (h *SparkApplicationHandler) List(c *gin.Context) {
-	appMetaList, err := h.sparkApplicationService.List(c.Param("namespace"))
+	view, err := domain.ParseSummaryView(c.Query("view"))
+	if err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	if err := h.checkCache(c); err != nil {
+		c.Error(err)
+		return
+	}
+
+	appMetaList, err := h.sparkApplicationService.List(c.Param("namespace"), view)
 
 	if err != nil {
 		c.Error(err)
@@ -60,6 +79,52 @@ func (h *SparkApplicationHandler) List(c *gin.Context) {
 	c.JSON(http.StatusOK, appMetaList)
 }
 
+func (h *SparkApplicationHandler) Counts(c *gin.Context) {
+	if err := h.checkCache(c); err != nil {
+		c.Error(err)
+		return
+	}
+
+	counts, err := h.sparkApplicationService.Counts(c.Param("namespace"))
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, counts)
+}
+
+// checkCache sets the cache headers of a list response and returns an error if it can't be served from the cache.
+// Lists are only ever served from the cache: like Kubernetes, `resourceVersion=0` accepts whatever the cache holds,
+// while an unset resourceVersion asks for the latest state, so a cache that has not synced or whose watch is failing
+// is rejected as unavailable.
+func (h *SparkApplicationHandler) checkCache(c *gin.Context) error {
+	resourceVersion := c.Query("resourceVersion")
+	if resourceVersion != "" && resourceVersion != "0" {
+		return gatewayerrors.NewBadRequest(fmt.Errorf("invalid resourceVersion '%s', lists are served from the informer cache and only support resourceVersion=0", resourceVersion))
+	}
+
+	status := h.sparkApplicationService.CacheStatus(c.Param("namespace"))
+	if status.ResourceVersion != "" {
+		c.Header(sgHttp.ResourceVersionHeader, status.ResourceVersion)
+	}
+	if !status.StaleSince.IsZero() {
+		c.Header(sgHttp.CacheStaleSinceHeader, status.StaleSince.UTC().Format(time.RFC3339))
+	}
+
+	if resourceVersion == "" {
+		if !status.Synced {
+			return gatewayerrors.NewUnavailable(fmt.Errorf("the SparkApplication cache has not synced yet, retry or pass resourceVersion=0 to accept a partial list"))
+		}
+		if status.Stale() {
+			return gatewayerrors.NewUnavailable(fmt.Errorf("the SparkApplication cache is stale since %s, retry or pass resourceVersion=0 to accept a stale list", status.StaleSince.UTC().Format(time.RFC3339)))
+		}
+	}
+
+	return nil
+}
+
 func (h *SparkApplicationHandler) Status(c *gin.Context) {
 
 	appStatus, err := h.sparkApplicationService.Status(c.Param("namespace"), c.Param("name"))
@@ -81,14 +146,171 @@ func (h *SparkApplicationHandler) Logs(c *gin.Context) {
 		return
 	}
 
-	logs, err := h.sparkApplicationService.Logs(c.Param("namespace"), c.Param("name"), tailLines)
+	logPod, err := domain.ParseLogPod(c.Query("pod"), c.Query("executorId"))
+	if err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	if c.Query("follow") == "true" {
+		if logPod.IsExecutor() {
+			c.Error(gatewayerrors.NewBadRequest(fmt.Errorf("only driver logs can be followed")))
+			return
+		}
+		h.followLogs(c, tailLines)
+		return
+	}
+
+	var logStream io.ReadCloser
+	if logPod.IsExecutor() {
+		logStream, err = h.sparkApplicationService.ExecutorLogs(c.Request.Context(), c.Param("namespace"), c.Param("name"), logPod.ExecutorId, tailLines)
+	} else {
+		logStream, err = h.sparkApplicationService.Logs(c.Request.Context(), c.Param("namespace"), c.Param("name"), tailLines)
+	}
 	if err != nil {
 		c.Error(fmt.Errorf("cannot get logs: %w", err))
 		return
 	}
+	defer logStream.Close()
+
+	// Logs are streamed as a single JSON string rather than buffered in memory
+	c.Header("Content-Type", "application/json; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	jsonWriter := util.NewJSONStringWriter(c.Writer)
+	if _, err := io.Copy(jsonWriter, logStream); err != nil {
+		// Headers have already been sent, so we can only log the error
+		klog.Errorf("error streaming logs for SparkApplication '%s/%s': %v", c.Param("namespace"), c.Param("name"), err)
+	}
+
+	if err := jsonWriter.Close(); err != nil {
+		klog.Errorf("error streaming logs for SparkApplication '%s/%s': %v", c.Param("namespace"), c.Param("name"), err)
+	}
+
+}
+
+// followLogs streams the driver logs as plain text, starting from the last tailLines lines, flushing each read so lines
+// reach the Gateway as the driver writes them
+func (h *SparkApplicationHandler) followLogs(c *gin.Context, tailLines int64) {
+
+	logStream, err := h.sparkApplicationService.FollowLogs(c.Request.Context(), c.Param("namespace"), c.Param("name"), tailLines)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer logStream.Close()
+
+	c.Header("Content-Type", "text/plain; charset=utf-8")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	if _, err := io.Copy(util.NewFlushWriter(c.Writer), logStream); err != nil {
+		// Headers have already been sent, so we can only log the error
+		klog.Errorf("error following logs for SparkApplication '%s/%s': %v", c.Param("namespace"), c.Param("name"), err)
+	}
+}
+
+// DownloadLogs streams the complete driver logs as plain text
+func (h *SparkApplicationHandler) DownloadLogs(c *gin.Context) {
+
+	logStream, err := h.sparkApplicationService.StreamLogs(c.Request.Context(), c.Param("namespace"), c.Param("name"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer logStream.Close()
+
+	c.Header("Content-Type", "text/plain; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	if _, err := io.Copy(c.Writer, logStream); err != nil {
+		// Headers have already been sent, so we can only log the error
+		klog.Errorf("error streaming logs for SparkApplication '%s/%s': %v", c.Param("namespace"), c.Param("name"), err)
+	}
+}
+
+// Pods lists the driver and executor Pods of a SparkApplication with their phases
+func (h *SparkApplicationHandler) Pods(c *gin.Context) {
+
+	pods, err := h.sparkApplicationService.Pods(c.Request.Context(), c.Param("namespace"), c.Param("name"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pods)
+}
+
+// Cost returns the estimated cost of a SparkApplication at the cluster's cost rates
+func (h *SparkApplicationHandler) Cost(c *gin.Context) {
+
+	cost, err := h.sparkApplicationService.Cost(c.Param("namespace"), c.Param("name"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
-	c.JSON(http.StatusOK, logs)
+	c.JSON(http.StatusOK, cost)
+}
+
+// Watch streams SparkApplication watch events in namespace as newline delimited JSON until the client disconnects or
+// the watch is closed by the API server
+func (h *SparkApplicationHandler) Watch(c *gin.Context) {
+
+	watcher, err := h.sparkApplicationService.Watch(c.Request.Context(), c.Param("namespace"), c.Query("labelSelector"), c.Query("resourceVersion"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer watcher.Stop()
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Status(http.StatusOK)
+	// Send headers immediately so the Gateway isn't left waiting for the first event
+	c.Writer.Flush()
+
+	encoder := json.NewEncoder(c.Writer)
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return
+			}
+			if err := encoder.Encode(domain.NewSparkManagerWatchEvent(event)); err != nil {
+				// Headers have already been sent, so we can only log the error
+				klog.Errorf("error streaming watch events for namespace '%s': %v", c.Param("namespace"), err)
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+// StreamStatus streams the status of the SparkApplication as newline delimited JSON each time its state changes, until
+// it reaches a terminal state, is deleted or the client disconnects
+func (h *SparkApplicationHandler) StreamStatus(c *gin.Context) {
+
+	statuses, err := h.sparkApplicationService.StreamStatus(c.Request.Context(), c.Param("namespace"), c.Param("name"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	encoder := json.NewEncoder(c.Writer)
+	for status := range statuses {
+		if err := encoder.Encode(status); err != nil {
+			// Headers have already been sent, so we can only log the error
+			klog.Errorf("error streaming status of SparkApplication '%s/%s': %v", c.Param("namespace"), c.Param("name"), err)
+			return
+		}
+		c.Writer.Flush()
+	}
 }
 
 func (h *SparkApplicationHandler) Create(c *gin.Context) {
@@ -99,7 +321,7 @@ func (h *SparkApplicationHandler) Create(c *gin.Context) {
 		return
 	}
 
-	sparkApplication, err := h.sparkApplicationService.Create(c, &application)
+	sparkApplication, err := h.sparkApplicationService.Create(c.Request.Context(), &application)
 
 	if err != nil {
 		c.Error(err)
@@ -109,9 +331,26 @@ func (h *SparkApplicationHandler) Create(c *gin.Context) {
 	c.JSON(http.StatusCreated, sparkApplication)
 }
 
+// Update changes the labels, TTL or maxExecutors of a SparkApplication that hasn't started running yet
+func (h *SparkApplicationHandler) Update(c *gin.Context) {
+	var update domain.ApplicationUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	sparkApp, err := h.sparkApplicationService.Update(c.Request.Context(), c.Param("namespace"), c.Param("name"), update)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sparkApp)
+}
+
 func (h *SparkApplicationHandler) Delete(c *gin.Context) {
 
-	err := h.sparkApplicationService.Delete(c, c.Param("namespace"), c.Param("name"))
+	err := h.sparkApplicationService.Delete(c.Request.Context(), c.Param("namespace"), c.Param("name"))
 
 	if err != nil {
 		c.Error(err)
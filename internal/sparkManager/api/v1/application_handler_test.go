@@ -24,16 +24,22 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 
+	"github.com/slackhq/spark-gateway/internal/domain"
 	"github.com/slackhq/spark-gateway/internal/shared/config"
 	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	sgHttp "github.com/slackhq/spark-gateway/internal/shared/http"
 	sgMiddleware "github.com/slackhq/spark-gateway/internal/shared/middleware"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
 	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
 )
 
@@ -52,11 +58,23 @@ var mockSparkAppService_SuccessTests service.SparkApplicationServiceMock = servi
 	GetFunc: func(namespace string, name string) (*v1beta2.SparkApplication, error) {
 		return &expectedSparkApplication, nil
 	},
-	StatusFunc: func(namespace string, name string) (*v1beta2.SparkApplicationStatus, error) {
-		return &expectedSparkApplication.Status, nil
+	ListFunc: func(namespace string, view domain.SummaryView) ([]*domain.SparkManagerSparkApplicationSummary, error) {
+		return []*domain.SparkManagerSparkApplicationSummary{domain.NewSparkManagerSparkApplicationSummaryView(&expectedSparkApplication, view)}, nil
 	},
-	LogsFunc: func(namespace string, name string, tailLines int64) (*string, error) {
-		return &logString, nil
+	CountsFunc: func(namespace string) (*domain.SparkManagerApplicationCounts, error) {
+		return &domain.SparkManagerApplicationCounts{Namespace: namespace, States: map[string]int{"RUNNING": 1}}, nil
+	},
+	CacheStatusFunc: func(namespace string) domain.CacheStatus {
+		return domain.CacheStatus{Synced: true, ResourceVersion: "42"}
+	},
+	StatusFunc: func(namespace string, name string) (*domain.ApplicationStatus, error) {
+		return &domain.ApplicationStatus{SparkApplicationStatus: expectedSparkApplication.Status}, nil
+	},
+	LogsFunc: func(ctx context.Context, namespace string, name string, tailLines int64) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(logString)), nil
+	},
+	StreamLogsFunc: func(ctx context.Context, namespace string, name string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(logString)), nil
 	},
 	CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
 		return &expectedSparkApplication, nil
@@ -70,12 +88,15 @@ var mockSparkAppService_FailureTests service.SparkApplicationServiceMock = servi
 	GetFunc: func(namespace string, name string) (*v1beta2.SparkApplication, error) {
 		return nil, gatewayerrors.NewNotFound(fmt.Errorf("error getting SparkApplication '%s'", expectedSparkApplication.Name))
 	},
-	StatusFunc: func(namespace string, name string) (*v1beta2.SparkApplicationStatus, error) {
+	StatusFunc: func(namespace string, name string) (*domain.ApplicationStatus, error) {
 		return nil, gatewayerrors.NewNotFound(fmt.Errorf("error getting SparkApplication '%s'", expectedSparkApplication.Name))
 	},
-	LogsFunc: func(namespace string, name string, tailLines int64) (*string, error) {
+	LogsFunc: func(ctx context.Context, namespace string, name string, tailLines int64) (io.ReadCloser, error) {
 		return nil, gatewayerrors.NewNotFound(fmt.Errorf("error getting SparkApplication '%s' to get Spark Driver Pod name for logs", expectedSparkApplication.Name))
 	},
+	StreamLogsFunc: func(ctx context.Context, namespace string, name string) (io.ReadCloser, error) {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("error getting SparkApplication '%s'", expectedSparkApplication.Name))
+	},
 	CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
 		return nil, gatewayerrors.NewAlreadyExists(errors.New("resource.group \"test\" already exists"))
 	},
@@ -95,7 +116,7 @@ func NewV1Router(mockService *service.SparkApplicationServiceMock) *gin.Engine {
 	v1Group := router.Group("/api/v1")
 	v1Group.Use(sgMiddleware.ApplicationErrorHandler)
 
-	RegisterKubeflowApplicationRoutes(v1Group, testConfig, mockService)
+	routes.Register(v1Group, KubeflowApplicationRoutes(testConfig, mockService))
 
 	return router
 }
@@ -132,6 +153,86 @@ func Test_SparkApplicationHandler_Get_Error(t *testing.T) {
 
 }
 
+func Test_SparkApplicationHandler_List_View(t *testing.T) {
+
+	ginRouter := NewV1Router(&mockSparkAppService_SuccessTests)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/namespace?view=summary", nil)
+	ginRouter.ServeHTTP(w, req)
+
+	var respBody []*domain.SparkManagerSparkApplicationSummary
+	json.Unmarshal(w.Body.Bytes(), &respBody)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, domain.SummaryViewSlim, mockSparkAppService_SuccessTests.ListCalls()[0].View, "view should be passed to service")
+	assert.Equal(t, "test123", respBody[0].Status.SubmissionID, "submissionID should be returned")
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/namespace?view=bad", nil)
+	ginRouter.ServeHTTP(w, req)
+
+	responseData, _ := io.ReadAll(w.Body)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "codes should match")
+	assert.Equal(t, `{"error":"invalid view 'bad', valid values: [full summary]"}`, string(responseData), "errors should match")
+}
+
+func Test_SparkApplicationHandler_Counts_Success(t *testing.T) {
+	ginRouter := NewV1Router(&mockSparkAppService_SuccessTests)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/namespace/counts", nil)
+	ginRouter.ServeHTTP(w, req)
+
+	var respBody domain.SparkManagerApplicationCounts
+	json.Unmarshal(w.Body.Bytes(), &respBody)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, domain.SparkManagerApplicationCounts{Namespace: "namespace", States: map[string]int{"RUNNING": 1}}, respBody, "returned JSON should match")
+}
+
+func Test_SparkApplicationHandler_List_Cache(t *testing.T) {
+	staleSince := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		url               string
+		cacheStatus       domain.CacheStatus
+		expectCode        int
+		expectStaleHeader string
+	}{
+		{"fresh cache", "/api/v1/namespace", domain.CacheStatus{Synced: true, ResourceVersion: "42"}, http.StatusOK, ""},
+		{"stale cache rejected", "/api/v1/namespace", domain.CacheStatus{Synced: true, ResourceVersion: "42", StaleSince: staleSince}, http.StatusServiceUnavailable, "2025-01-01T12:00:00Z"},
+		{"stale cache accepted", "/api/v1/namespace?resourceVersion=0", domain.CacheStatus{Synced: true, ResourceVersion: "42", StaleSince: staleSince}, http.StatusOK, "2025-01-01T12:00:00Z"},
+		{"unsynced cache rejected", "/api/v1/namespace/counts", domain.CacheStatus{ResourceVersion: "42"}, http.StatusServiceUnavailable, ""},
+		{"unsynced cache accepted", "/api/v1/namespace/counts?resourceVersion=0", domain.CacheStatus{ResourceVersion: "42"}, http.StatusOK, ""},
+		{"unsupported resourceVersion", "/api/v1/namespace?resourceVersion=41", domain.CacheStatus{Synced: true, ResourceVersion: "42"}, http.StatusBadRequest, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockService := service.SparkApplicationServiceMock{
+				ListFunc:   mockSparkAppService_SuccessTests.ListFunc,
+				CountsFunc: mockSparkAppService_SuccessTests.CountsFunc,
+				CacheStatusFunc: func(namespace string) domain.CacheStatus {
+					return test.cacheStatus
+				},
+			}
+			ginRouter := NewV1Router(&mockService)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, test.url, nil)
+			ginRouter.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectCode, w.Code, "codes should match")
+			assert.Equal(t, test.expectStaleHeader, w.Header().Get(sgHttp.CacheStaleSinceHeader), "stale headers should match")
+			if test.expectCode == http.StatusOK {
+				assert.Equal(t, "42", w.Header().Get(sgHttp.ResourceVersionHeader), "resource versions should match")
+			}
+		})
+	}
+}
+
 func TestSparkApplicationHandler_Status_Success(t *testing.T) {
 	ginRouter := NewV1Router(&mockSparkAppService_SuccessTests)
 
@@ -195,6 +296,251 @@ func Test_SparkApplicationHandler_Logs_Error(t *testing.T) {
 
 }
 
+func Test_SparkApplicationHandler_Logs_Follow(t *testing.T) {
+
+	mockService := &service.SparkApplicationServiceMock{
+		FollowLogsFunc: func(ctx context.Context, namespace string, name string, tailLines int64) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(logString)), nil
+		},
+	}
+	ginRouter := NewV1Router(mockService)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/namespace/appName/logs?follow=true&lines=10", nil)
+	ginRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"), "followed logs should be plain text")
+	assert.Equal(t, logString, w.Body.String(), "logs should match")
+	assert.Equal(t, int64(10), mockService.FollowLogsCalls()[0].TailLines, "lines should be passed to the service")
+}
+
+func Test_SparkApplicationHandler_Logs_Executor(t *testing.T) {
+
+	mockService := &service.SparkApplicationServiceMock{
+		ExecutorLogsFunc: func(ctx context.Context, namespace string, name string, executorId string, tailLines int64) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(logString)), nil
+		},
+	}
+	ginRouter := NewV1Router(mockService)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/namespace/appName/logs?pod=executor&executorId=3&lines=10", nil)
+	ginRouter.ServeHTTP(w, req)
+
+	var respBody string
+	json.Unmarshal(w.Body.Bytes(), &respBody)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, logString, respBody, "returned JSON should match")
+	assert.Equal(t, "3", mockService.ExecutorLogsCalls()[0].ExecutorId, "executorId should be passed to the service")
+	assert.Equal(t, int64(10), mockService.ExecutorLogsCalls()[0].TailLines, "lines should be passed to the service")
+}
+
+func Test_SparkApplicationHandler_Logs_InvalidPod(t *testing.T) {
+
+	mockService := &service.SparkApplicationServiceMock{}
+	ginRouter := NewV1Router(mockService)
+
+	for _, query := range []string{"pod=executor", "pod=executor&executorId=abc", "pod=shuffle", "pod=executor&executorId=3&follow=true"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/namespace/appName/logs?"+query, nil)
+		ginRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, "'%s' should be rejected", query)
+	}
+	assert.Empty(t, mockService.ExecutorLogsCalls(), "executor logs should not be read")
+}
+
+func Test_SparkApplicationHandler_Pods(t *testing.T) {
+
+	pods := []*domain.SparkApplicationPod{
+		{Name: "appName-driver", Role: "driver", Phase: "Running"},
+		{Name: "appName-exec-1", Role: "executor", ExecutorId: "1", Phase: "Pending"},
+	}
+	mockService := &service.SparkApplicationServiceMock{
+		PodsFunc: func(ctx context.Context, namespace string, name string) ([]*domain.SparkApplicationPod, error) {
+			return pods, nil
+		},
+	}
+	ginRouter := NewV1Router(mockService)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/namespace/appName/pods", nil)
+	ginRouter.ServeHTTP(w, req)
+
+	var respBody []*domain.SparkApplicationPod
+	json.Unmarshal(w.Body.Bytes(), &respBody)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, pods, respBody, "returned JSON should match")
+}
+
+func Test_SparkApplicationHandler_Cost(t *testing.T) {
+
+	cost := &domain.ApplicationCost{Currency: "USD", Estimate: 1.25, CoreHours: 20, GBHours: 25, Final: true}
+	mockService := &service.SparkApplicationServiceMock{
+		CostFunc: func(namespace string, name string) (*domain.ApplicationCost, error) {
+			return cost, nil
+		},
+	}
+	ginRouter := NewV1Router(mockService)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/namespace/appName/cost", nil)
+	ginRouter.ServeHTTP(w, req)
+
+	var respBody *domain.ApplicationCost
+	json.Unmarshal(w.Body.Bytes(), &respBody)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, cost, respBody, "returned JSON should match")
+}
+
+func Test_SparkApplicationHandler_Update(t *testing.T) {
+
+	var gotUpdate domain.ApplicationUpdate
+	mockService := &service.SparkApplicationServiceMock{
+		UpdateFunc: func(ctx context.Context, namespace string, name string, update domain.ApplicationUpdate) (*v1beta2.SparkApplication, error) {
+			gotUpdate = update
+			return &v1beta2.SparkApplication{ObjectMeta: v1.ObjectMeta{Name: name, Namespace: namespace, Labels: update.Labels}}, nil
+		},
+	}
+	ginRouter := NewV1Router(mockService)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPatch, "/api/v1/namespace/appName", bytes.NewBufferString(`{"labels":{"team":"data"},"timeToLiveSeconds":60}`))
+	ginRouter.ServeHTTP(w, req)
+
+	var respBody v1beta2.SparkApplication
+	json.Unmarshal(w.Body.Bytes(), &respBody)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, int64(60), *gotUpdate.TimeToLiveSeconds, "update should be passed to the service")
+	assert.Equal(t, "data", respBody.Labels["team"], "updated application should be returned")
+}
+
+func Test_SparkApplicationHandler_DownloadLogs_Success(t *testing.T) {
+
+	ginRouter := NewV1Router(&mockSparkAppService_SuccessTests)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/namespace/appName/logs/download", nil)
+	ginRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"), "content type should match")
+	assert.Equal(t, logString, w.Body.String(), "logs should match")
+}
+
+func Test_SparkApplicationHandler_DownloadLogs_Error(t *testing.T) {
+
+	ginRouter := NewV1Router(&mockSparkAppService_FailureTests)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/namespace/appName/logs/download", nil)
+	ginRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code, "codes should match")
+	assert.Equal(t, `{"error":"error getting SparkApplication 'appName'"}`, w.Body.String(), "errors should match")
+}
+
+func Test_SparkApplicationHandler_Watch_Success(t *testing.T) {
+
+	fakeWatcher := watch.NewFakeWithChanSize(2, false)
+	fakeWatcher.Add(&expectedSparkApplication)
+	fakeWatcher.Error(&v1.Status{Code: http.StatusGone, Message: "too old resource version"})
+	fakeWatcher.Stop()
+
+	mockService := &service.SparkApplicationServiceMock{
+		WatchFunc: func(ctx context.Context, namespace string, labelSelector string, resourceVersion string) (watch.Interface, error) {
+			assert.Equal(t, "app=test", labelSelector, "labelSelector should be passed")
+			assert.Equal(t, "42", resourceVersion, "resourceVersion should be passed")
+			return fakeWatcher, nil
+		},
+	}
+	ginRouter := NewV1Router(mockService)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/namespace/watch?labelSelector=app%3Dtest&resourceVersion=42", nil)
+	ginRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"), "content type should match")
+
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	assert.Len(t, lines, 2, "each event should be a line")
+
+	var added domain.SparkManagerWatchEvent
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &added), "event should be json")
+	assert.Equal(t, domain.WatchEventAdded, added.Type, "event types should match")
+	assert.Equal(t, expectedSparkApplication.Name, added.Object.Name, "event objects should match")
+
+	var watchErr domain.SparkManagerWatchEvent
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &watchErr), "event should be json")
+	assert.Equal(t, domain.SparkManagerWatchEvent{Type: domain.WatchEventError, Code: http.StatusGone, Error: "too old resource version"}, watchErr, "error events should match")
+}
+
+func Test_SparkApplicationHandler_Watch_Error(t *testing.T) {
+
+	mockService := &service.SparkApplicationServiceMock{
+		WatchFunc: func(ctx context.Context, namespace string, labelSelector string, resourceVersion string) (watch.Interface, error) {
+			return nil, gatewayerrors.NewForbidden(errors.New("forbidden"))
+		},
+	}
+	ginRouter := NewV1Router(mockService)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/namespace/watch", nil)
+	ginRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code, "codes should match")
+}
+
+func Test_SparkApplicationHandler_StreamStatus_Success(t *testing.T) {
+
+	mockService := &service.SparkApplicationServiceMock{
+		StreamStatusFunc: func(ctx context.Context, namespace string, name string) (<-chan *domain.ApplicationStatus, error) {
+			statuses := make(chan *domain.ApplicationStatus, 2)
+			statuses <- &domain.ApplicationStatus{GatewayState: domain.GatewayStateRunning}
+			statuses <- &domain.ApplicationStatus{GatewayState: domain.GatewayStateSucceeded}
+			close(statuses)
+			return statuses, nil
+		},
+	}
+	ginRouter := NewV1Router(mockService)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/namespace/name/status/stream", nil)
+	ginRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "codes should match")
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"), "content type should match")
+
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	assert.Len(t, lines, 2, "each status should be a line")
+
+	var last domain.ApplicationStatus
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &last), "status should be json")
+	assert.Equal(t, domain.GatewayStateSucceeded, last.GatewayState, "statuses should be streamed in order")
+}
+
+func Test_SparkApplicationHandler_StreamStatus_Error(t *testing.T) {
+
+	mockService := &service.SparkApplicationServiceMock{
+		StreamStatusFunc: func(ctx context.Context, namespace string, name string) (<-chan *domain.ApplicationStatus, error) {
+			return nil, gatewayerrors.New(http.StatusNotImplemented, errors.New("not supported"))
+		},
+	}
+	ginRouter := NewV1Router(mockService)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/namespace/name/status/stream", nil)
+	ginRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotImplemented, w.Code, "codes should match")
+}
+
 func TestSparkApplicationHandler_Create_Success(t *testing.T) {
 	ginRouter := NewV1Router(&mockSparkAppService_SuccessTests)
 
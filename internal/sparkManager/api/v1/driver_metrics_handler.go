@@ -0,0 +1,64 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
+)
+
+// prometheusTextContentType is the content type of the Prometheus text exposition format served by drivers
+const prometheusTextContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type DriverMetricsHandler struct {
+	driverMetricsService service.SparkApplicationDriverMetricsService
+}
+
+// NewDriverMetricsHandler returns a DriverMetricsHandler reading driver metrics with driverMetricsService, which is nil
+// if the cluster's backend cannot reach driver Pods.
+func NewDriverMetricsHandler(driverMetricsService service.SparkApplicationDriverMetricsService) *DriverMetricsHandler {
+	return &DriverMetricsHandler{driverMetricsService: driverMetricsService}
+}
+
+// DriverMetrics streams the Prometheus metrics of a running SparkApplication's driver
+func (h *DriverMetricsHandler) DriverMetrics(c *gin.Context) {
+	if h.driverMetricsService == nil {
+		c.Error(gatewayerrors.New(http.StatusNotImplemented, fmt.Errorf("this cluster's backend does not serve driver metrics")))
+		return
+	}
+
+	metrics, err := h.driverMetricsService.DriverMetrics(c.Request.Context(), c.Param("namespace"), c.Param("name"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer metrics.Close()
+
+	c.Header("Content-Type", prometheusTextContentType)
+	c.Status(http.StatusOK)
+
+	if _, err := io.Copy(c.Writer, metrics); err != nil {
+		// Headers have already been sent, so we can only log the error
+		klog.Errorf("error streaming driver metrics for SparkApplication '%s/%s': %v", c.Param("namespace"), c.Param("name"), err)
+	}
+}
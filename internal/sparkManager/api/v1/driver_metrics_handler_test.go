@@ -0,0 +1,79 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	sgMiddleware "github.com/slackhq/spark-gateway/internal/shared/middleware"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
+)
+
+func TestDriverMetricsHandlerDriverMetrics(t *testing.T) {
+	testCases := []struct {
+		name           string
+		noProxy        bool
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "streams driver metrics",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "up 1\n",
+		},
+		{
+			name:           "backend cannot reach drivers",
+			noProxy:        true,
+			expectedStatus: http.StatusNotImplemented,
+			expectedBody:   `{"error":"this cluster's backend does not serve driver metrics"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			driverMetricsService := &service.SparkApplicationDriverMetricsServiceMock{
+				DriverMetricsFunc: func(ctx context.Context, namespace string, name string) (io.ReadCloser, error) {
+					return io.NopCloser(strings.NewReader("up 1\n")), nil
+				},
+			}
+
+			router := gin.New()
+			router.Use(sgMiddleware.ApplicationErrorHandler)
+			v1Group := router.Group("/api/v1")
+			if tc.noProxy {
+				routes.Register(v1Group, DriverMetricsRoutes(nil))
+			} else {
+				routes.Register(v1Group, DriverMetricsRoutes(driverMetricsService))
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/api/v1/team-a/app/metrics", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code, "status should match")
+			assert.Equal(t, tc.expectedBody, w.Body.String(), "body should match")
+		})
+	}
+}
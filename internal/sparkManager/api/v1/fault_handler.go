@@ -0,0 +1,63 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/faults"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+// FaultHandler manages the faults injected into SparkManager's Kubernetes API calls, targeted by verb
+type FaultHandler struct {
+	injector *faults.Injector
+}
+
+func NewFaultHandler(injector *faults.Injector) *FaultHandler {
+	return &FaultHandler{injector: injector}
+}
+
+func (h *FaultHandler) List(c *gin.Context) {
+	c.JSON(http.StatusOK, h.injector.Rules())
+}
+
+func (h *FaultHandler) Set(c *gin.Context) {
+	var rule domain.FaultRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	if err := h.injector.Set(c.Param("target"), rule); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *FaultHandler) Clear(c *gin.Context) {
+	if err := h.injector.Clear(c.Param("target")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
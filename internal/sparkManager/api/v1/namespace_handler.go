@@ -0,0 +1,90 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
+)
+
+type NamespaceHandler struct {
+	provisioner service.NamespaceProvisioner
+	namespaces  *service.ManagedNamespaces
+}
+
+// NewNamespaceHandler returns a NamespaceHandler provisioning namespaces with provisioner, which is nil if the
+// cluster's backend cannot provision namespaces. Provisioned namespaces are added to namespaces.
+func NewNamespaceHandler(provisioner service.NamespaceProvisioner, namespaces *service.ManagedNamespaces) *NamespaceHandler {
+	return &NamespaceHandler{provisioner: provisioner, namespaces: namespaces}
+}
+
+func (h *NamespaceHandler) Provision(c *gin.Context) {
+	if h.provisioner == nil {
+		c.Error(gatewayerrors.New(http.StatusNotImplemented, fmt.Errorf("this cluster's backend does not support provisioning namespaces")))
+		return
+	}
+
+	var provisioning domain.NamespaceProvisioning
+	if err := c.ShouldBindJSON(&provisioning); err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	namespace := c.Param("namespace")
+	for field, value := range map[string]string{"namespace": namespace, "serviceAccount": provisioning.ServiceAccount} {
+		if errMessages := validation.IsDNS1123Label(value); len(errMessages) > 0 {
+			c.Error(gatewayerrors.NewBadRequest(fmt.Errorf("invalid %s '%s': %s", field, value, strings.Join(errMessages, ", "))))
+			return
+		}
+	}
+
+	if err := h.provisioner.ProvisionNamespace(c.Request.Context(), namespace, provisioning); err != nil {
+		c.Error(err)
+		return
+	}
+	h.namespaces.Add(namespace)
+
+	c.Status(http.StatusNoContent)
+}
+
+// ManagedNamespace rejects requests to namespaces that aren't in namespaces with a 403, so SparkManager can't be used
+// to read or change resources outside of the namespaces it manages
+func ManagedNamespace(namespaces *service.ManagedNamespaces) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		namespace := c.Param("namespace")
+		managed, err := namespaces.Manages(c.Request.Context(), namespace)
+		if err != nil {
+			c.Error(gatewayerrors.NewFrom(err))
+			c.Abort()
+			return
+		}
+		if !managed {
+			c.Error(gatewayerrors.NewForbidden(fmt.Errorf("namespace '%s' is not managed by this SparkManager", namespace)))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	sgMiddleware "github.com/slackhq/spark-gateway/internal/shared/middleware"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
+)
+
+func TestNamespaceHandlerProvision(t *testing.T) {
+	testCases := []struct {
+		name           string
+		noProvisioner  bool
+		namespace      string
+		body           string
+		expectedStatus int
+		expectedCalls  int
+	}{
+		{
+			name:           "provisions namespace",
+			namespace:      "team-a",
+			body:           `{"serviceAccount":"spark"}`,
+			expectedStatus: http.StatusNoContent,
+			expectedCalls:  1,
+		},
+		{
+			name:           "invalid serviceAccount",
+			namespace:      "team-a",
+			body:           `{"serviceAccount":"Spark_Driver"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "backend cannot provision",
+			noProvisioner:  true,
+			namespace:      "team-a",
+			body:           `{"serviceAccount":"spark"}`,
+			expectedStatus: http.StatusNotImplemented,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provisioner := &service.NamespaceProvisionerMock{
+				ProvisionNamespaceFunc: func(ctx context.Context, namespace string, provisioning domain.NamespaceProvisioning) error {
+					return nil
+				},
+			}
+
+			namespaces := service.NewManagedNamespaces(domain.KubeCluster{}, nil)
+
+			router := gin.New()
+			router.Use(sgMiddleware.ApplicationErrorHandler)
+			v1Group := router.Group("/api/v1")
+			if tc.noProvisioner {
+				routes.Register(v1Group, NamespaceRoutes(nil, namespaces))
+			} else {
+				routes.Register(v1Group, NamespaceRoutes(provisioner, namespaces))
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/api/v1/"+tc.namespace, bytes.NewBufferString(tc.body))
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code, "status should match")
+			assert.Len(t, provisioner.ProvisionNamespaceCalls(), tc.expectedCalls, "provisioner calls should match")
+			managed, _ := namespaces.Manages(context.Background(), tc.namespace)
+			assert.Equal(t, tc.expectedStatus == http.StatusNoContent, managed, "only provisioned namespaces should be managed")
+		})
+	}
+}
+
+func TestManagedNamespace(t *testing.T) {
+	provisioner := &service.NamespaceProvisionerMock{
+		IsProvisionedFunc: func(ctx context.Context, namespace string) (bool, error) {
+			if namespace == "unreachable" {
+				return false, gatewayerrors.NewUnavailable(errors.New("connection refused"))
+			}
+			return namespace == "provisioned", nil
+		},
+	}
+	namespaces := service.NewManagedNamespaces(domain.KubeCluster{Namespaces: []domain.KubeNamespace{{Name: "team-a"}}}, provisioner)
+
+	router := gin.New()
+	router.Use(sgMiddleware.ApplicationErrorHandler)
+	routes.Register(router.Group("/api/v1"), ManagedRoutes(namespaces, []routes.Route{
+		{Method: http.MethodGet, Path: "/:namespace/:name", Handler: func(c *gin.Context) { c.Status(http.StatusOK) }},
+	}))
+
+	testCases := []struct {
+		namespace      string
+		expectedStatus int
+	}{
+		{"team-a", http.StatusOK},
+		{"provisioned", http.StatusOK},
+		{"kube-system", http.StatusForbidden},
+		{"unreachable", http.StatusServiceUnavailable},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.namespace, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/api/v1/"+tc.namespace+"/app", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code, "status should match")
+		})
+	}
+}
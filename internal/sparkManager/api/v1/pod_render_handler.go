@@ -0,0 +1,58 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
+)
+
+type PodRenderHandler struct {
+	renderService service.SparkApplicationPodRenderService
+}
+
+// NewPodRenderHandler returns a PodRenderHandler rendering the pods of SparkApplications with renderService, which is
+// nil if the cluster's backend doesn't run SparkApplications through the Spark Operator.
+func NewPodRenderHandler(renderService service.SparkApplicationPodRenderService) *PodRenderHandler {
+	return &PodRenderHandler{renderService: renderService}
+}
+
+func (h *PodRenderHandler) Render(c *gin.Context) {
+	if h.renderService == nil {
+		c.Error(gatewayerrors.New(http.StatusNotImplemented, fmt.Errorf("this cluster's backend does not render SparkApplication pods")))
+		return
+	}
+
+	var application v1beta2.SparkApplication
+	if err := c.ShouldBindJSON(&application); err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	pods, err := h.renderService.RenderPods(c.Request.Context(), &application)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pods)
+}
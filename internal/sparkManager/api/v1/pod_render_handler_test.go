@@ -0,0 +1,87 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	sgMiddleware "github.com/slackhq/spark-gateway/internal/shared/middleware"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
+)
+
+func TestPodRenderHandlerRender(t *testing.T) {
+	testCases := []struct {
+		name           string
+		noRenderer     bool
+		body           string
+		expectedStatus int
+		expectedCalls  int
+	}{
+		{
+			name:           "renders pods",
+			body:           `{"metadata":{"name":"app","namespace":"team-a"}}`,
+			expectedStatus: http.StatusOK,
+			expectedCalls:  1,
+		},
+		{
+			name:           "invalid body",
+			body:           `{"metadata":"app"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "backend cannot render pods",
+			noRenderer:     true,
+			body:           `{"metadata":{"name":"app","namespace":"team-a"}}`,
+			expectedStatus: http.StatusNotImplemented,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			renderService := &service.SparkApplicationPodRenderServiceMock{
+				RenderPodsFunc: func(ctx context.Context, application *v1beta2.SparkApplication) (*domain.RenderedPods, error) {
+					return &domain.RenderedPods{}, nil
+				},
+			}
+
+			router := gin.New()
+			router.Use(sgMiddleware.ApplicationErrorHandler)
+			v1Group := router.Group("/api/v1")
+			if tc.noRenderer {
+				routes.Register(v1Group, PodRenderRoutes(nil))
+			} else {
+				routes.Register(v1Group, PodRenderRoutes(renderService))
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/api/v1/team-a/app/render", bytes.NewBufferString(tc.body))
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code, "status should match")
+			assert.Len(t, renderService.RenderPodsCalls(), tc.expectedCalls, "render service calls should match")
+		})
+	}
+}
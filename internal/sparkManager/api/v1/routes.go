@@ -1,23 +1,130 @@
 package v1
 
 import (
+	"net/http"
+	"slices"
+
 	"github.com/gin-gonic/gin"
+
 	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/faults"
+	"github.com/slackhq/spark-gateway/internal/shared/routes"
 	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
 )
 
-// RegisterKubeflowApplicationRoutes registers routes handling Kubeflow SparkOperator SparkApplication submissions
-func RegisterKubeflowApplicationRoutes(rg *gin.RouterGroup, sgConf *config.SparkGatewayConfig, appService service.SparkApplicationService) {
+// Group declares the V1 SparkManager API called by the Gateway. Services that are nil because the cluster's backend
+// doesn't support them answer their routes with a 501. Routes other than provisioning only serve the namespaces
+// SparkManager manages.
+func Group(sgConf *config.SparkGatewayConfig, namespaces *service.ManagedNamespaces, appService service.SparkApplicationService, provisioner service.NamespaceProvisioner, scaleService service.SparkApplicationScaleService, renderService service.SparkApplicationPodRenderService, driverMetricsService service.SparkApplicationDriverMetricsService, timelineService service.SparkApplicationTimelineService) routes.Group {
+	return routes.Group{
+		Prefix:  "/api",
+		Version: "v1",
+		Routes: slices.Concat(
+			ManagedRoutes(namespaces, slices.Concat(
+				KubeflowApplicationRoutes(sgConf, appService),
+				ScaleRoutes(scaleService),
+				PodRenderRoutes(renderService),
+				DriverMetricsRoutes(driverMetricsService),
+				TimelineRoutes(timelineService),
+			)),
+			// Namespaces being provisioned aren't managed until they have been
+			NamespaceRoutes(provisioner, namespaces),
+		),
+	}
+}
+
+// ManagedRoutes returns routes restricted to the namespaces SparkManager manages, see ManagedNamespace
+func ManagedRoutes(namespaces *service.ManagedNamespaces, managed []routes.Route) []routes.Route {
+	guard := ManagedNamespace(namespaces)
+	for i := range managed {
+		managed[i].Middleware = append([]gin.HandlerFunc{guard}, managed[i].Middleware...)
+	}
+	return managed
+}
+
+// KubeflowApplicationRoutes declares routes handling Kubeflow SparkOperator SparkApplication submissions
+func KubeflowApplicationRoutes(sgConf *config.SparkGatewayConfig, appService service.SparkApplicationService) []routes.Route {
 
 	h := NewSparkApplicationHandler(appService, sgConf.DefaultLogLines)
 
-	rg.GET("/:namespace", h.List)
+	return []routes.Route{
+		{Method: http.MethodGet, Path: "/:namespace", Handler: h.List},
+		{Method: http.MethodGet, Path: "/:namespace/counts", Handler: h.Counts},
+		{Method: http.MethodGet, Path: "/:namespace/watch", Handler: h.Watch, Streaming: true},
+
+		{Method: http.MethodPost, Path: "/:namespace/:name", Handler: h.Create},
+		{Method: http.MethodGet, Path: "/:namespace/:name", Handler: h.Get},
+		{Method: http.MethodGet, Path: "/:namespace/:name/status", Handler: h.Status},
+		{Method: http.MethodGet, Path: "/:namespace/:name/status/stream", Handler: h.StreamStatus, Streaming: true},
+		{Method: http.MethodGet, Path: "/:namespace/:name/logs", Handler: h.Logs, StreamingQuery: "follow"},
+		{Method: http.MethodGet, Path: "/:namespace/:name/logs/download", Handler: h.DownloadLogs, Streaming: true},
+		{Method: http.MethodGet, Path: "/:namespace/:name/pods", Handler: h.Pods},
+		{Method: http.MethodGet, Path: "/:namespace/:name/cost", Handler: h.Cost},
+
+		{Method: http.MethodPatch, Path: "/:namespace/:name", Handler: h.Update},
+
+		{Method: http.MethodDelete, Path: "/:namespace/:name", Handler: h.Delete},
+	}
+}
+
+// NamespaceRoutes declares routes provisioning namespaces for SparkApplications
+func NamespaceRoutes(provisioner service.NamespaceProvisioner, namespaces *service.ManagedNamespaces) []routes.Route {
+
+	h := NewNamespaceHandler(provisioner, namespaces)
+
+	return []routes.Route{
+		{Method: http.MethodPut, Path: "/:namespace", Handler: h.Provision},
+	}
+}
+
+// ScaleRoutes declares routes scaling the executors of running SparkApplications
+func ScaleRoutes(scaleService service.SparkApplicationScaleService) []routes.Route {
+
+	h := NewScaleHandler(scaleService)
+
+	return []routes.Route{
+		{Method: http.MethodPost, Path: "/:namespace/:name/scale", Handler: h.Scale},
+	}
+}
+
+// PodRenderRoutes declares routes rendering the pods SparkApplications would run
+func PodRenderRoutes(renderService service.SparkApplicationPodRenderService) []routes.Route {
+
+	h := NewPodRenderHandler(renderService)
+
+	return []routes.Route{
+		{Method: http.MethodPost, Path: "/:namespace/:name/render", Handler: h.Render},
+	}
+}
+
+// DriverMetricsRoutes declares routes reading the Prometheus metrics of running SparkApplication drivers
+func DriverMetricsRoutes(driverMetricsService service.SparkApplicationDriverMetricsService) []routes.Route {
+
+	h := NewDriverMetricsHandler(driverMetricsService)
+
+	return []routes.Route{
+		{Method: http.MethodGet, Path: "/:namespace/:name/metrics", Handler: h.DriverMetrics},
+	}
+}
+
+// TimelineRoutes declares routes reading the lifecycle events recorded for SparkApplications
+func TimelineRoutes(timelineService service.SparkApplicationTimelineService) []routes.Route {
+
+	h := NewTimelineHandler(timelineService)
+
+	return []routes.Route{
+		{Method: http.MethodGet, Path: "/:namespace/:name/timeline", Handler: h.Timeline},
+	}
+}
 
-	rg.POST("/:namespace/:name", h.Create)
-	rg.GET("/:namespace/:name", h.Get)
-	rg.GET("/:namespace/:name/status", h.Status)
-	rg.GET("/:namespace/:name/logs", h.Logs)
+// FaultRoutes declares routes managing the faults injected into SparkManager's calls to the Kubernetes API
+func FaultRoutes(injector *faults.Injector) []routes.Route {
 
-	rg.DELETE("/:namespace/:name", h.Delete)
+	h := NewFaultHandler(injector)
 
+	return []routes.Route{
+		{Method: http.MethodGet, Path: "", Handler: h.List},
+		{Method: http.MethodPut, Path: "/:target", Handler: h.Set},
+		{Method: http.MethodDelete, Path: "/:target", Handler: h.Clear},
+	}
 }
@@ -0,0 +1,58 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
+)
+
+type ScaleHandler struct {
+	scaleService service.SparkApplicationScaleService
+}
+
+// NewScaleHandler returns a ScaleHandler scaling SparkApplications with scaleService, which is nil if the cluster's
+// backend cannot scale SparkApplications.
+func NewScaleHandler(scaleService service.SparkApplicationScaleService) *ScaleHandler {
+	return &ScaleHandler{scaleService: scaleService}
+}
+
+func (h *ScaleHandler) Scale(c *gin.Context) {
+	if h.scaleService == nil {
+		c.Error(gatewayerrors.New(http.StatusNotImplemented, fmt.Errorf("this cluster's backend does not support scaling SparkApplications")))
+		return
+	}
+
+	var scale domain.ExecutorScale
+	if err := c.ShouldBindJSON(&scale); err != nil {
+		c.Error(gatewayerrors.NewBadRequest(err))
+		return
+	}
+
+	sparkApp, err := h.scaleService.Scale(c.Request.Context(), c.Param("namespace"), c.Param("name"), scale)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sparkApp)
+}
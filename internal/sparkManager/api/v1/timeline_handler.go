@@ -0,0 +1,52 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
+)
+
+type TimelineHandler struct {
+	timelineService service.SparkApplicationTimelineService
+}
+
+// NewTimelineHandler returns a TimelineHandler reading lifecycle events with timelineService, which is nil if the
+// database is disabled.
+func NewTimelineHandler(timelineService service.SparkApplicationTimelineService) *TimelineHandler {
+	return &TimelineHandler{timelineService: timelineService}
+}
+
+// Timeline returns the lifecycle events recorded for a SparkApplication
+func (h *TimelineHandler) Timeline(c *gin.Context) {
+	if h.timelineService == nil {
+		c.Error(gatewayerrors.New(http.StatusNotImplemented, fmt.Errorf("lifecycle events are only recorded when the database is enabled")))
+		return
+	}
+
+	events, err := h.timelineService.Timeline(c.Request.Context(), c.Param("namespace"), c.Param("name"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
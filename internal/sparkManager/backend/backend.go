@@ -0,0 +1,105 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
+)
+
+// Backend runs SparkApplications on a cluster. SparkManager's API, services, reconciler and metrics only reach a
+// cluster's SparkApplications through its Backend, so alternative execution backends can be served behind the same
+// Gateway API. Errors should be GatewayErrors so they map to the right HTTP status.
+type Backend = service.SparkApplicationRepository
+
+// Params are the dependencies available to a Factory.
+type Params struct {
+	Config     *config.SparkGatewayConfig
+	Cluster    domain.KubeCluster
+	KubeConfig *rest.Config
+	Database   database.SparkApplicationDatabase
+}
+
+// Factory creates the Backend for a cluster. ctx is cancelled when SparkManager shuts down and should stop any
+// background work the Backend starts.
+type Factory func(ctx context.Context, params Params) (Backend, error)
+
+var (
+	factoriesLock sync.RWMutex
+	factories     = map[string]Factory{
+		domain.BackendSparkOperator: newSparkOperatorBackend,
+		domain.BackendEMROnEKS:      newEMROnEKSBackend,
+	}
+)
+
+// Register makes a Factory available as the `backend` of clusters in config. It is intended to be called from init
+// functions and panics if name is already registered.
+func Register(name string, factory Factory) {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+
+	if _, ok := factories[name]; ok {
+		panic(fmt.Sprintf("backend '%s' is already registered", name))
+	}
+	factories[name] = factory
+}
+
+// Names returns the registered backend names, sorted.
+func Names() []string {
+	factoriesLock.RLock()
+	defer factoriesLock.RUnlock()
+
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// New creates the Backend configured for params.Cluster, or the in-memory local backend in local mode.
+func New(ctx context.Context, params Params) (Backend, error) {
+	if params.Config != nil && params.Config.Mode == config.ModeLocal {
+		klog.Infof("Running cluster '%s' on the in-memory local backend", params.Cluster.Name)
+		return newLocalBackend(ctx, params)
+	}
+
+	factoriesLock.RLock()
+	factory, ok := factories[params.Cluster.Backend]
+	factoriesLock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown backend '%s' for cluster '%s', registered backends: %v", params.Cluster.Backend, params.Cluster.Name, Names())
+	}
+
+	backend, err := factory(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error creating '%s' backend for cluster '%s': %w", params.Cluster.Backend, params.Cluster.Name, err)
+	}
+
+	return backend, nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
+)
+
+func TestNew(t *testing.T) {
+	Register("test", func(ctx context.Context, params Params) (Backend, error) {
+		return &service.SparkApplicationRepositoryMock{}, nil
+	})
+	Register("testFailing", func(ctx context.Context, params Params) (Backend, error) {
+		return nil, errors.New("boom")
+	})
+
+	var tests = []struct {
+		test     string
+		backend  string
+		expected string
+	}{
+		{
+			test:    "registered backend",
+			backend: "test",
+		},
+		{
+			test:     "factory error",
+			backend:  "testFailing",
+			expected: "error creating 'testFailing' backend for cluster 'cluster': boom",
+		},
+		{
+			test:     "unknown backend",
+			backend:  "unknown",
+			expected: "unknown backend 'unknown' for cluster 'cluster', registered backends: [emrOnEks sparkOperator test testFailing]",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.test, func(t *testing.T) {
+			backend, err := New(context.Background(), Params{Cluster: domain.KubeCluster{Name: "cluster", Backend: test.backend}})
+
+			if test.expected == "" {
+				assert.Nil(t, err, "err should be nil")
+				assert.NotNil(t, backend, "backend should be created")
+			} else {
+				assert.EqualError(t, err, test.expected, "err should match")
+				assert.Nil(t, backend, "backend should be nil")
+			}
+		})
+	}
+}
+
+func TestRegisterDuplicate(t *testing.T) {
+	assert.Panics(t, func() {
+		Register(domain.BackendSparkOperator, newSparkOperatorBackend)
+	}, "registering an existing backend should panic")
+}
@@ -0,0 +1,651 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/emrcontainers"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+const (
+	// EMRJobRunIdAnnotation is set on SparkApplications from the emrOnEks backend to the id of their EMR job run
+	EMRJobRunIdAnnotation = "spark-gateway/emr-job-run-id"
+
+	// SparkApplication labels and annotations are stored as job run tags with these key prefixes
+	emrLabelTagPrefix      = "label:"
+	emrAnnotationTagPrefix = "annotation:"
+	emrMaxTagKeyLength     = 128
+	emrMaxTagValueLength   = 256
+
+	// EMR on EKS writes container logs to <prefix>/<virtualClusterId>/jobs/<jobRunId>/containers/<sparkAppId>/<pod>/
+	emrDriverStdoutSuffix = "-driver/stdout"
+	// GetLogEvents returns at most this many events per call
+	cloudWatchMaxLogEvents = 10000
+)
+
+// emrJobRunStates maps EMR job run states to the SparkApplication states the rest of Spark Gateway understands
+var emrJobRunStates = map[string]v1beta2.ApplicationStateType{
+	emrcontainers.JobRunStatePending:       v1beta2.ApplicationStateSubmitted,
+	emrcontainers.JobRunStateSubmitted:     v1beta2.ApplicationStateSubmitted,
+	emrcontainers.JobRunStateRunning:       v1beta2.ApplicationStateRunning,
+	emrcontainers.JobRunStateCancelPending: v1beta2.ApplicationStateFailing,
+	emrcontainers.JobRunStateCancelled:     v1beta2.ApplicationStateFailed,
+	emrcontainers.JobRunStateFailed:        v1beta2.ApplicationStateFailed,
+	emrcontainers.JobRunStateCompleted:     v1beta2.ApplicationStateCompleted,
+}
+
+type emrContainersClient interface {
+	StartJobRunWithContext(ctx aws.Context, input *emrcontainers.StartJobRunInput, opts ...request.Option) (*emrcontainers.StartJobRunOutput, error)
+	CancelJobRunWithContext(ctx aws.Context, input *emrcontainers.CancelJobRunInput, opts ...request.Option) (*emrcontainers.CancelJobRunOutput, error)
+	ListJobRunsPagesWithContext(ctx aws.Context, input *emrcontainers.ListJobRunsInput, fn func(*emrcontainers.ListJobRunsOutput, bool) bool, opts ...request.Option) error
+}
+
+type cloudWatchLogsClient interface {
+	DescribeLogStreamsPagesWithContext(ctx aws.Context, input *cloudwatchlogs.DescribeLogStreamsInput, fn func(*cloudwatchlogs.DescribeLogStreamsOutput, bool) bool, opts ...request.Option) error
+	GetLogEventsWithContext(ctx aws.Context, input *cloudwatchlogs.GetLogEventsInput, opts ...request.Option) (*cloudwatchlogs.GetLogEventsOutput, error)
+}
+
+type s3Client interface {
+	ListObjectsV2PagesWithContext(ctx aws.Context, input *s3.ListObjectsV2Input, fn func(*s3.ListObjectsV2Output, bool) bool, opts ...request.Option) error
+	GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput, opts ...request.Option) (*s3.GetObjectOutput, error)
+}
+
+// emrOnEKSBackend runs SparkApplications as EMR on EKS job runs. Each configured namespace maps to the virtual
+// cluster registered for it, and SparkApplications are identified by job run name.
+type emrOnEKSBackend struct {
+	emr           emrContainersClient
+	logs          cloudWatchLogsClient
+	s3            s3Client
+	config        domain.EMROnEKSConfig
+	clusterName   string
+	selectorKey   string
+	selectorValue string
+	now           func() time.Time
+}
+
+func newEMROnEKSBackend(ctx context.Context, params Params) (Backend, error) {
+	sess, err := session.NewSession(aws.NewConfig().WithRegion(params.Cluster.EMROnEKS.Region))
+	if err != nil {
+		return nil, fmt.Errorf("error creating AWS session: %w", err)
+	}
+
+	return &emrOnEKSBackend{
+		emr:           emrcontainers.New(sess),
+		logs:          cloudwatchlogs.New(sess),
+		s3:            s3.New(sess),
+		config:        params.Cluster.EMROnEKS,
+		clusterName:   params.Cluster.Name,
+		selectorKey:   params.Config.SelectorKey,
+		selectorValue: params.Config.SelectorValue,
+		now:           time.Now,
+	}, nil
+}
+
+func (b *emrOnEKSBackend) Get(namespace string, name string) (*v1beta2.SparkApplication, error) {
+	jobRun, err := b.getJobRun(context.Background(), namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.toSparkApplication(namespace, jobRun), nil
+}
+
+// List returns the SparkApplications for job runs created within the configured listWindow, in all namespaces if
+// namespace is empty.
+func (b *emrOnEKSBackend) List(namespace string) ([]*v1beta2.SparkApplication, error) {
+	namespaces := []string{namespace}
+	if namespace == metav1.NamespaceAll {
+		namespaces = slices.Sorted(maps.Keys(b.config.VirtualClusters))
+	}
+
+	sparkApps := []*v1beta2.SparkApplication{}
+	for _, ns := range namespaces {
+		virtualClusterId, err := b.virtualCluster(ns)
+		if err != nil {
+			return nil, err
+		}
+
+		input := &emrcontainers.ListJobRunsInput{
+			VirtualClusterId: aws.String(virtualClusterId),
+			CreatedAfter:     aws.Time(b.now().Add(-b.config.ListWindow)),
+		}
+		err = b.emr.ListJobRunsPagesWithContext(context.Background(), input, func(page *emrcontainers.ListJobRunsOutput, _ bool) bool {
+			for _, jobRun := range page.JobRuns {
+				if b.isGatewayJobRun(jobRun) {
+					sparkApps = append(sparkApps, b.toSparkApplication(ns, jobRun))
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, mapEMRError(fmt.Errorf("error listing EMR job runs in virtual cluster '%s': %w", virtualClusterId, err))
+		}
+	}
+
+	return sparkApps, nil
+}
+
+// StreamLogs returns the driver's stdout, from CloudWatch Logs if a log group is configured and otherwise from S3,
+// limited to the last tailLines lines if tailLines is not nil. The caller is responsible for closing the stream.
+func (b *emrOnEKSBackend) StreamLogs(ctx context.Context, namespace string, name string, tailLines *int64) (io.ReadCloser, error) {
+	jobRun, err := b.getJobRun(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	containersPrefix := path.Join(aws.StringValue(jobRun.VirtualClusterId), "jobs", aws.StringValue(jobRun.Id), "containers") + "/"
+
+	switch {
+	case b.config.LogGroupName != "":
+		return b.cloudWatchDriverLogs(ctx, containersPrefix, tailLines)
+	case b.config.S3LogUri != "":
+		return b.s3DriverLogs(ctx, containersPrefix, tailLines)
+	default:
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("cluster '%s' has no `emrOnEks.logGroupName` or `emrOnEks.s3LogUri` to read driver logs from", b.clusterName))
+	}
+}
+
+func (b *emrOnEKSBackend) Create(ctx context.Context, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
+	// Job runs can't mount ConfigMaps created alongside them
+	if _, ok := application.Annotations[domain.GATEWAY_CONFIGMAPS_ANNOTATION]; ok {
+		return nil, gatewayerrors.New(http.StatusNotImplemented, fmt.Errorf("the '%s' backend does not support submissions bundling ConfigMaps", domain.BackendEMROnEKS))
+	}
+
+	virtualClusterId, err := b.virtualCluster(application.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := b.startJobRunInput(virtualClusterId, application)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := b.emr.StartJobRunWithContext(ctx, input)
+	if err != nil {
+		return nil, mapEMRError(fmt.Errorf("error starting EMR job run: %w", err))
+	}
+
+	sparkApp := application.DeepCopy()
+	if sparkApp.Annotations == nil {
+		sparkApp.Annotations = map[string]string{}
+	}
+	sparkApp.Annotations[EMRJobRunIdAnnotation] = aws.StringValue(output.Id)
+	sparkApp.UID = types.UID(aws.StringValue(output.Id))
+	sparkApp.CreationTimestamp = metav1.NewTime(b.now())
+	sparkApp.Status = v1beta2.SparkApplicationStatus{
+		SparkApplicationID: aws.StringValue(output.Id),
+		SubmissionID:       aws.StringValue(output.Id),
+		AppState:           v1beta2.ApplicationState{State: v1beta2.ApplicationStateSubmitted},
+	}
+
+	return sparkApp, nil
+}
+
+// Delete cancels the SparkApplication's job run. Job runs which have already finished are left as they are, EMR
+// keeps their history.
+func (b *emrOnEKSBackend) Delete(ctx context.Context, namespace string, name string) error {
+	jobRun, err := b.getJobRun(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+
+	switch aws.StringValue(jobRun.State) {
+	case emrcontainers.JobRunStateCompleted, emrcontainers.JobRunStateFailed, emrcontainers.JobRunStateCancelled, emrcontainers.JobRunStateCancelPending:
+		return nil
+	}
+
+	_, err = b.emr.CancelJobRunWithContext(ctx, &emrcontainers.CancelJobRunInput{
+		Id:               jobRun.Id,
+		VirtualClusterId: jobRun.VirtualClusterId,
+	})
+	if err != nil {
+		return mapEMRError(fmt.Errorf("error cancelling EMR job run '%s': %w", aws.StringValue(jobRun.Id), err))
+	}
+
+	return nil
+}
+
+func (b *emrOnEKSBackend) Watch(ctx context.Context, namespace string, labelSelector string, resourceVersion string) (watch.Interface, error) {
+	return nil, gatewayerrors.New(http.StatusNotImplemented, fmt.Errorf("watch is not supported by the '%s' backend of cluster '%s'", domain.BackendEMROnEKS, b.clusterName))
+}
+
+func (b *emrOnEKSBackend) virtualCluster(namespace string) (string, error) {
+	virtualClusterId, ok := b.config.VirtualClusters[namespace]
+	if !ok {
+		return "", gatewayerrors.NewNotFound(fmt.Errorf("namespace '%s' has no EMR virtual cluster in cluster '%s'", namespace, b.clusterName))
+	}
+	return virtualClusterId, nil
+}
+
+// getJobRun returns the most recently created Spark Gateway job run named name in namespace's virtual cluster.
+func (b *emrOnEKSBackend) getJobRun(ctx context.Context, namespace string, name string) (*emrcontainers.JobRun, error) {
+	virtualClusterId, err := b.virtualCluster(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *emrcontainers.JobRun
+	input := &emrcontainers.ListJobRunsInput{
+		VirtualClusterId: aws.String(virtualClusterId),
+		Name:             aws.String(name),
+	}
+	err = b.emr.ListJobRunsPagesWithContext(ctx, input, func(page *emrcontainers.ListJobRunsOutput, _ bool) bool {
+		for _, jobRun := range page.JobRuns {
+			if b.isGatewayJobRun(jobRun) && (latest == nil || aws.TimeValue(jobRun.CreatedAt).After(aws.TimeValue(latest.CreatedAt))) {
+				latest = jobRun
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, mapEMRError(fmt.Errorf("error listing EMR job runs named '%s' in virtual cluster '%s': %w", name, virtualClusterId, err))
+	}
+
+	if latest == nil {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("SparkApplication '%s/%s' not found", namespace, name))
+	}
+
+	return latest, nil
+}
+
+// isGatewayJobRun returns whether jobRun was submitted with this SparkManager's selector label.
+func (b *emrOnEKSBackend) isGatewayJobRun(jobRun *emrcontainers.JobRun) bool {
+	if b.selectorKey == "" {
+		return true
+	}
+	return aws.StringValue(jobRun.Tags[emrLabelTagPrefix+b.selectorKey]) == b.selectorValue
+}
+
+func (b *emrOnEKSBackend) startJobRunInput(virtualClusterId string, application *v1beta2.SparkApplication) (*emrcontainers.StartJobRunInput, error) {
+	if application.Spec.MainApplicationFile == nil || *application.Spec.MainApplicationFile == "" {
+		return nil, gatewayerrors.NewInvalid(fmt.Errorf("spec.mainApplicationFile is required to submit to the '%s' backend", domain.BackendEMROnEKS))
+	}
+
+	jobDriver := &emrcontainers.SparkSubmitJobDriver{
+		EntryPoint: application.Spec.MainApplicationFile,
+	}
+	if len(application.Spec.Arguments) > 0 {
+		jobDriver.EntryPointArguments = aws.StringSlice(application.Spec.Arguments)
+	}
+	if params := SparkSubmitParameters(application.Spec); params != "" {
+		jobDriver.SparkSubmitParameters = aws.String(params)
+	}
+
+	monitoring := &emrcontainers.MonitoringConfiguration{}
+	if b.config.LogGroupName != "" {
+		monitoring.CloudWatchMonitoringConfiguration = &emrcontainers.CloudWatchMonitoringConfiguration{LogGroupName: aws.String(b.config.LogGroupName)}
+		if b.config.LogStreamPrefix != "" {
+			monitoring.CloudWatchMonitoringConfiguration.LogStreamNamePrefix = aws.String(b.config.LogStreamPrefix)
+		}
+	}
+	if b.config.S3LogUri != "" {
+		monitoring.S3MonitoringConfiguration = &emrcontainers.S3MonitoringConfiguration{LogUri: aws.String(b.config.S3LogUri)}
+	}
+
+	return &emrcontainers.StartJobRunInput{
+		VirtualClusterId: aws.String(virtualClusterId),
+		Name:             aws.String(application.Name),
+		// GatewayIds are unique per submission, so retried submissions start the same job run
+		ClientToken:            aws.String(application.Name),
+		ExecutionRoleArn:       aws.String(b.config.ExecutionRoleArn),
+		ReleaseLabel:           aws.String(b.config.ReleaseLabel),
+		JobDriver:              &emrcontainers.JobDriver{SparkSubmitJobDriver: jobDriver},
+		ConfigurationOverrides: &emrcontainers.ConfigurationOverrides{MonitoringConfiguration: monitoring},
+		Tags:                   jobRunTags(application),
+	}, nil
+}
+
+// SparkSubmitParameters renders the spark-submit parameters for spec: the main class, dependencies, and sparkConf,
+// hadoopConf, image and driver/executor resources as `--conf` entries sorted by key.
+func SparkSubmitParameters(spec v1beta2.SparkApplicationSpec) string {
+	var params []string
+	if spec.MainClass != nil {
+		params = append(params, "--class", *spec.MainClass)
+	}
+
+	for _, dep := range []struct {
+		flag   string
+		values []string
+	}{
+		{"--jars", spec.Deps.Jars},
+		{"--files", spec.Deps.Files},
+		{"--py-files", spec.Deps.PyFiles},
+		{"--packages", spec.Deps.Packages},
+		{"--exclude-packages", spec.Deps.ExcludePackages},
+		{"--repositories", spec.Deps.Repositories},
+	} {
+		if len(dep.values) > 0 {
+			params = append(params, dep.flag, strings.Join(dep.values, ","))
+		}
+	}
+
+	conf := maps.Clone(spec.SparkConf)
+	if conf == nil {
+		conf = map[string]string{}
+	}
+	for key, value := range spec.HadoopConf {
+		conf["spark.hadoop."+key] = value
+	}
+	if spec.Image != nil {
+		conf["spark.kubernetes.container.image"] = *spec.Image
+	}
+	if spec.Driver.Cores != nil {
+		conf["spark.driver.cores"] = strconv.Itoa(int(*spec.Driver.Cores))
+	}
+	if spec.Driver.Memory != nil {
+		conf["spark.driver.memory"] = *spec.Driver.Memory
+	}
+	if spec.Executor.Cores != nil {
+		conf["spark.executor.cores"] = strconv.Itoa(int(*spec.Executor.Cores))
+	}
+	if spec.Executor.Memory != nil {
+		conf["spark.executor.memory"] = *spec.Executor.Memory
+	}
+	if spec.Executor.Instances != nil {
+		conf["spark.executor.instances"] = strconv.Itoa(int(*spec.Executor.Instances))
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(conf)) {
+		params = append(params, "--conf", key+"="+conf[key])
+	}
+
+	return strings.Join(params, " ")
+}
+
+// jobRunTags stores application's labels and annotations as job run tags. Entries exceeding EMR's tag limits are
+// dropped with a warning.
+func jobRunTags(application *v1beta2.SparkApplication) map[string]*string {
+	tags := map[string]*string{}
+	add := func(prefix string, entries map[string]string) {
+		for key, value := range entries {
+			if len(prefix+key) > emrMaxTagKeyLength || len(value) > emrMaxTagValueLength {
+				klog.Warningf("not tagging EMR job run '%s' with '%s%s', it exceeds EMR tag limits", application.Name, prefix, key)
+				continue
+			}
+			tags[prefix+key] = aws.String(value)
+		}
+	}
+	add(emrLabelTagPrefix, application.Labels)
+	add(emrAnnotationTagPrefix, application.Annotations)
+
+	return tags
+}
+
+// toSparkApplication converts jobRun to the SparkApplication it was submitted from. Only the entry point and
+// arguments of the spec are kept by EMR.
+func (b *emrOnEKSBackend) toSparkApplication(namespace string, jobRun *emrcontainers.JobRun) *v1beta2.SparkApplication {
+	jobRunId := aws.StringValue(jobRun.Id)
+
+	sparkApp := &v1beta2.SparkApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              aws.StringValue(jobRun.Name),
+			Namespace:         namespace,
+			UID:               types.UID(jobRunId),
+			CreationTimestamp: metav1.NewTime(aws.TimeValue(jobRun.CreatedAt)),
+			Labels:            map[string]string{},
+			Annotations:       map[string]string{EMRJobRunIdAnnotation: jobRunId},
+		},
+		Spec: v1beta2.SparkApplicationSpec{
+			Mode: v1beta2.DeployModeCluster,
+		},
+		Status: v1beta2.SparkApplicationStatus{
+			SparkApplicationID:        jobRunId,
+			SubmissionID:              jobRunId,
+			LastSubmissionAttemptTime: metav1.NewTime(aws.TimeValue(jobRun.CreatedAt)),
+			AppState: v1beta2.ApplicationState{
+				State:        emrApplicationState(aws.StringValue(jobRun.State)),
+				ErrorMessage: emrFailureMessage(jobRun),
+			},
+		},
+	}
+
+	for key, value := range jobRun.Tags {
+		if label, ok := strings.CutPrefix(key, emrLabelTagPrefix); ok {
+			sparkApp.Labels[label] = aws.StringValue(value)
+		} else if annotation, ok := strings.CutPrefix(key, emrAnnotationTagPrefix); ok {
+			sparkApp.Annotations[annotation] = aws.StringValue(value)
+		}
+	}
+
+	if jobRun.FinishedAt != nil {
+		sparkApp.Status.TerminationTime = metav1.NewTime(*jobRun.FinishedAt)
+	}
+
+	if jobRun.JobDriver != nil && jobRun.JobDriver.SparkSubmitJobDriver != nil {
+		sparkApp.Spec.MainApplicationFile = jobRun.JobDriver.SparkSubmitJobDriver.EntryPoint
+		sparkApp.Spec.Arguments = aws.StringValueSlice(jobRun.JobDriver.SparkSubmitJobDriver.EntryPointArguments)
+	}
+
+	return sparkApp
+}
+
+func emrApplicationState(jobRunState string) v1beta2.ApplicationStateType {
+	if state, ok := emrJobRunStates[jobRunState]; ok {
+		return state
+	}
+	return v1beta2.ApplicationStateUnknown
+}
+
+func emrFailureMessage(jobRun *emrcontainers.JobRun) string {
+	var parts []string
+	if state := aws.StringValue(jobRun.State); state == emrcontainers.JobRunStateCancelled || state == emrcontainers.JobRunStateCancelPending {
+		parts = append(parts, "job run cancelled")
+	}
+	if jobRun.FailureReason != nil {
+		parts = append(parts, *jobRun.FailureReason)
+	}
+	if jobRun.StateDetails != nil {
+		parts = append(parts, *jobRun.StateDetails)
+	}
+	return strings.Join(parts, ": ")
+}
+
+func (b *emrOnEKSBackend) cloudWatchDriverLogs(ctx context.Context, containersPrefix string, tailLines *int64) (io.ReadCloser, error) {
+	streamPrefix := containersPrefix
+	if b.config.LogStreamPrefix != "" {
+		streamPrefix = b.config.LogStreamPrefix + "/" + containersPrefix
+	}
+
+	var streamName string
+	input := &cloudwatchlogs.DescribeLogStreamsInput{
+		LogGroupName:        aws.String(b.config.LogGroupName),
+		LogStreamNamePrefix: aws.String(streamPrefix),
+	}
+	err := b.logs.DescribeLogStreamsPagesWithContext(ctx, input, func(page *cloudwatchlogs.DescribeLogStreamsOutput, _ bool) bool {
+		for _, stream := range page.LogStreams {
+			if strings.HasSuffix(aws.StringValue(stream.LogStreamName), emrDriverStdoutSuffix) {
+				streamName = aws.StringValue(stream.LogStreamName)
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, mapEMRError(fmt.Errorf("error finding driver log stream in log group '%s': %w", b.config.LogGroupName, err))
+	}
+	if streamName == "" {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("driver log stream '%s*%s' not found in log group '%s'", streamPrefix, emrDriverStdoutSuffix, b.config.LogGroupName))
+	}
+
+	if tailLines != nil {
+		output, err := b.logs.GetLogEventsWithContext(ctx, &cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(b.config.LogGroupName),
+			LogStreamName: aws.String(streamName),
+			Limit:         aws.Int64(min(*tailLines, cloudWatchMaxLogEvents)),
+			StartFromHead: aws.Bool(false),
+		})
+		if err != nil {
+			return nil, mapEMRError(fmt.Errorf("error reading driver log stream '%s': %w", streamName, err))
+		}
+
+		var logs strings.Builder
+		for _, event := range output.Events {
+			logs.WriteString(aws.StringValue(event.Message) + "\n")
+		}
+		return io.NopCloser(strings.NewReader(logs.String())), nil
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		var nextToken *string
+		for {
+			output, err := b.logs.GetLogEventsWithContext(ctx, &cloudwatchlogs.GetLogEventsInput{
+				LogGroupName:  aws.String(b.config.LogGroupName),
+				LogStreamName: aws.String(streamName),
+				StartFromHead: aws.Bool(true),
+				NextToken:     nextToken,
+			})
+			if err != nil {
+				pipeWriter.CloseWithError(fmt.Errorf("error reading driver log stream '%s': %w", streamName, err))
+				return
+			}
+			for _, event := range output.Events {
+				if _, err := io.WriteString(pipeWriter, aws.StringValue(event.Message)+"\n"); err != nil {
+					return
+				}
+			}
+			// GetLogEvents returns the token it was given once the end of the stream is reached
+			if output.NextForwardToken == nil || aws.StringValue(output.NextForwardToken) == aws.StringValue(nextToken) {
+				pipeWriter.Close()
+				return
+			}
+			nextToken = output.NextForwardToken
+		}
+	}()
+
+	return pipeReader, nil
+}
+
+func (b *emrOnEKSBackend) s3DriverLogs(ctx context.Context, containersPrefix string, tailLines *int64) (io.ReadCloser, error) {
+	logUri, err := url.Parse(b.config.S3LogUri)
+	if err != nil || logUri.Scheme != "s3" {
+		return nil, gatewayerrors.NewInternal(fmt.Errorf("`emrOnEks.s3LogUri` '%s' of cluster '%s' must be an s3:// URI", b.config.S3LogUri, b.clusterName))
+	}
+	bucket := logUri.Host
+	prefix := strings.TrimPrefix(path.Join(logUri.Path, containersPrefix), "/") + "/"
+
+	var key string
+	err = b.s3.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{Bucket: aws.String(bucket), Prefix: aws.String(prefix)}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, object := range page.Contents {
+			if strings.HasSuffix(aws.StringValue(object.Key), emrDriverStdoutSuffix+".gz") {
+				key = aws.StringValue(object.Key)
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, mapEMRError(fmt.Errorf("error finding driver logs in 's3://%s/%s': %w", bucket, prefix, err))
+	}
+	if key == "" {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("driver logs not found in 's3://%s/%s', EMR uploads them periodically while the job runs", bucket, prefix))
+	}
+
+	object, err := b.s3.GetObjectWithContext(ctx, &s3.GetObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+	if err != nil {
+		return nil, mapEMRError(fmt.Errorf("error reading driver logs 's3://%s/%s': %w", bucket, key, err))
+	}
+
+	logs, err := gzip.NewReader(object.Body)
+	if err != nil {
+		object.Body.Close()
+		return nil, gatewayerrors.NewInternal(fmt.Errorf("error decompressing driver logs 's3://%s/%s': %w", bucket, key, err))
+	}
+
+	if tailLines == nil {
+		return &gzipObjectReader{Reader: logs, body: object.Body}, nil
+	}
+
+	defer object.Body.Close()
+	lines, err := tail(logs, int(*tailLines))
+	if err != nil {
+		return nil, gatewayerrors.NewInternal(fmt.Errorf("error reading driver logs 's3://%s/%s': %w", bucket, key, err))
+	}
+	return io.NopCloser(strings.NewReader(lines)), nil
+}
+
+// gzipObjectReader closes the S3 object body along with the gzip reader reading it.
+type gzipObjectReader struct {
+	*gzip.Reader
+	body io.Closer
+}
+
+func (r *gzipObjectReader) Close() error {
+	return errors.Join(r.Reader.Close(), r.body.Close())
+}
+
+// tail returns the last n lines of reader.
+func tail(reader io.Reader, n int) (string, error) {
+	if n <= 0 {
+		return "", nil
+	}
+
+	lines := make([]string, 0, n)
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(lines) == n {
+			lines = lines[1:]
+		}
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	if len(lines) == 0 {
+		return "", nil
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// mapEMRError returns err as a GatewayError with the status of the AWS request that failed, if any.
+func mapEMRError(err error) error {
+	var requestFailure awserr.RequestFailure
+	if errors.As(err, &requestFailure) && requestFailure.StatusCode() >= 400 {
+		return gatewayerrors.New(requestFailure.StatusCode(), err)
+	}
+	return gatewayerrors.NewInternal(err)
+}
@@ -0,0 +1,278 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/emrcontainers"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+)
+
+type fakeEMRContainers struct {
+	jobRuns   []*emrcontainers.JobRun
+	started   *emrcontainers.StartJobRunInput
+	cancelled *emrcontainers.CancelJobRunInput
+}
+
+func (f *fakeEMRContainers) StartJobRunWithContext(ctx aws.Context, input *emrcontainers.StartJobRunInput, opts ...request.Option) (*emrcontainers.StartJobRunOutput, error) {
+	f.started = input
+	return &emrcontainers.StartJobRunOutput{Id: aws.String("jobrun1"), Name: input.Name, VirtualClusterId: input.VirtualClusterId}, nil
+}
+
+func (f *fakeEMRContainers) CancelJobRunWithContext(ctx aws.Context, input *emrcontainers.CancelJobRunInput, opts ...request.Option) (*emrcontainers.CancelJobRunOutput, error) {
+	f.cancelled = input
+	return &emrcontainers.CancelJobRunOutput{Id: input.Id}, nil
+}
+
+func (f *fakeEMRContainers) ListJobRunsPagesWithContext(ctx aws.Context, input *emrcontainers.ListJobRunsInput, fn func(*emrcontainers.ListJobRunsOutput, bool) bool, opts ...request.Option) error {
+	var jobRuns []*emrcontainers.JobRun
+	for _, jobRun := range f.jobRuns {
+		if aws.StringValue(jobRun.VirtualClusterId) != aws.StringValue(input.VirtualClusterId) {
+			continue
+		}
+		if input.Name != nil && aws.StringValue(jobRun.Name) != *input.Name {
+			continue
+		}
+		jobRuns = append(jobRuns, jobRun)
+	}
+	fn(&emrcontainers.ListJobRunsOutput{JobRuns: jobRuns}, true)
+	return nil
+}
+
+type fakeS3 struct {
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ListObjectsV2PagesWithContext(ctx aws.Context, input *s3.ListObjectsV2Input, fn func(*s3.ListObjectsV2Output, bool) bool, opts ...request.Option) error {
+	var contents []*s3.Object
+	for key := range f.objects {
+		contents = append(contents, &s3.Object{Key: aws.String(key)})
+	}
+	fn(&s3.ListObjectsV2Output{Contents: contents}, true)
+	return nil
+}
+
+func (f *fakeS3) GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput, opts ...request.Option) (*s3.GetObjectOutput, error) {
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(f.objects[*input.Key]))}, nil
+}
+
+func newTestEMRBackend(emr *fakeEMRContainers) *emrOnEKSBackend {
+	return &emrOnEKSBackend{
+		emr: emr,
+		config: domain.EMROnEKSConfig{
+			ExecutionRoleArn: "arn:aws:iam::123456789012:role/emr",
+			ReleaseLabel:     "emr-7.1.0-latest",
+			VirtualClusters:  map[string]string{"ns": "vc1"},
+			ListWindow:       time.Hour,
+			LogGroupName:     "emr-logs",
+		},
+		clusterName:   "cluster",
+		selectorKey:   "spark-gateway/owned",
+		selectorValue: "true",
+		now:           func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) },
+	}
+}
+
+func TestSparkSubmitParameters(t *testing.T) {
+	spec := v1beta2.SparkApplicationSpec{
+		MainClass:  util.Ptr("org.example.Main"),
+		Image:      util.Ptr("spark:3.5"),
+		SparkConf:  map[string]string{"spark.eventLog.enabled": "true"},
+		HadoopConf: map[string]string{"fs.s3a.fast.upload": "true"},
+		Deps:       v1beta2.Dependencies{Jars: []string{"s3://bucket/a.jar", "s3://bucket/b.jar"}},
+		Driver:     v1beta2.DriverSpec{SparkPodSpec: v1beta2.SparkPodSpec{Cores: util.Ptr(int32(2)), Memory: util.Ptr("4g")}},
+		Executor:   v1beta2.ExecutorSpec{Instances: util.Ptr(int32(3))},
+	}
+
+	assert.Equal(t,
+		"--class org.example.Main --jars s3://bucket/a.jar,s3://bucket/b.jar "+
+			"--conf spark.driver.cores=2 --conf spark.driver.memory=4g --conf spark.eventLog.enabled=true "+
+			"--conf spark.executor.instances=3 --conf spark.hadoop.fs.s3a.fast.upload=true --conf spark.kubernetes.container.image=spark:3.5",
+		SparkSubmitParameters(spec),
+		"parameters should include class, deps and sorted conf",
+	)
+	assert.Equal(t, "", SparkSubmitParameters(v1beta2.SparkApplicationSpec{}), "empty spec should have no parameters")
+}
+
+func TestEMROnEKSCreate(t *testing.T) {
+	emr := &fakeEMRContainers{}
+	backend := newTestEMRBackend(emr)
+
+	app := &v1beta2.SparkApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "clus-ns-1234",
+			Namespace:   "ns",
+			Labels:      map[string]string{"spark-gateway/owned": "true"},
+			Annotations: map[string]string{"spark-gateway/spec-hash": "abc"},
+		},
+		Spec: v1beta2.SparkApplicationSpec{
+			MainApplicationFile: util.Ptr("s3://bucket/main.py"),
+			Arguments:           []string{"--date", "2025-01-01"},
+		},
+	}
+
+	created, err := backend.Create(context.Background(), app)
+	assert.Nil(t, err, "err should be nil")
+
+	assert.Equal(t, "vc1", *emr.started.VirtualClusterId, "job run should start in the namespace's virtual cluster")
+	assert.Equal(t, "clus-ns-1234", *emr.started.Name, "job run should be named after the SparkApplication")
+	assert.Equal(t, "clus-ns-1234", *emr.started.ClientToken, "client token should make retries idempotent")
+	assert.Equal(t, "s3://bucket/main.py", *emr.started.JobDriver.SparkSubmitJobDriver.EntryPoint, "entry point should be the main application file")
+	assert.Equal(t, []string{"--date", "2025-01-01"}, aws.StringValueSlice(emr.started.JobDriver.SparkSubmitJobDriver.EntryPointArguments), "arguments should be passed")
+	assert.Equal(t, "emr-logs", *emr.started.ConfigurationOverrides.MonitoringConfiguration.CloudWatchMonitoringConfiguration.LogGroupName, "logs should go to the log group")
+	assert.Equal(t, map[string]string{"label:spark-gateway/owned": "true", "annotation:spark-gateway/spec-hash": "abc"}, aws.StringValueMap(emr.started.Tags), "labels and annotations should be tags")
+
+	assert.Equal(t, "jobrun1", created.Annotations[EMRJobRunIdAnnotation], "job run id should be annotated")
+	assert.Equal(t, "jobrun1", string(created.UID), "UID should be the job run id")
+	assert.Equal(t, v1beta2.ApplicationStateSubmitted, created.Status.AppState.State, "created SparkApplication should be submitted")
+
+	_, err = backend.Create(context.Background(), &v1beta2.SparkApplication{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"}})
+	assert.Equal(t, http.StatusUnprocessableEntity, gatewayerrors.NewFrom(err).Status, "missing main application file should be invalid")
+
+	_, err = backend.Create(context.Background(), &v1beta2.SparkApplication{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "other"}})
+	assert.Equal(t, http.StatusNotFound, gatewayerrors.NewFrom(err).Status, "namespace without a virtual cluster should not be found")
+}
+
+func TestEMROnEKSGet(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	gatewayTags := map[string]*string{"label:spark-gateway/owned": aws.String("true"), "label:spark-gateway/user": aws.String("alice")}
+
+	var tests = []struct {
+		test          string
+		jobRuns       []*emrcontainers.JobRun
+		expectedState v1beta2.ApplicationStateType
+		expectedError string
+		expectedId    string
+	}{
+		{
+			test: "running job run",
+			jobRuns: []*emrcontainers.JobRun{
+				{Id: aws.String("run1"), Name: aws.String("app"), VirtualClusterId: aws.String("vc1"), State: aws.String(emrcontainers.JobRunStateRunning), CreatedAt: aws.Time(created), Tags: gatewayTags},
+			},
+			expectedState: v1beta2.ApplicationStateRunning,
+			expectedId:    "run1",
+		},
+		{
+			test: "latest job run is used",
+			jobRuns: []*emrcontainers.JobRun{
+				{Id: aws.String("run1"), Name: aws.String("app"), VirtualClusterId: aws.String("vc1"), State: aws.String(emrcontainers.JobRunStateFailed), CreatedAt: aws.Time(created), Tags: gatewayTags},
+				{Id: aws.String("run2"), Name: aws.String("app"), VirtualClusterId: aws.String("vc1"), State: aws.String(emrcontainers.JobRunStateCompleted), CreatedAt: aws.Time(created.Add(time.Minute)), Tags: gatewayTags},
+			},
+			expectedState: v1beta2.ApplicationStateCompleted,
+			expectedId:    "run2",
+		},
+		{
+			test: "cancelled job run is failed",
+			jobRuns: []*emrcontainers.JobRun{
+				{Id: aws.String("run1"), Name: aws.String("app"), VirtualClusterId: aws.String("vc1"), State: aws.String(emrcontainers.JobRunStateCancelled), StateDetails: aws.String("by user"), CreatedAt: aws.Time(created), Tags: gatewayTags},
+			},
+			expectedState: v1beta2.ApplicationStateFailed,
+			expectedError: "job run cancelled: by user",
+			expectedId:    "run1",
+		},
+		{
+			test: "job runs not submitted by gateway are ignored",
+			jobRuns: []*emrcontainers.JobRun{
+				{Id: aws.String("run1"), Name: aws.String("app"), VirtualClusterId: aws.String("vc1"), State: aws.String(emrcontainers.JobRunStateRunning), CreatedAt: aws.Time(created)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.test, func(t *testing.T) {
+			backend := newTestEMRBackend(&fakeEMRContainers{jobRuns: test.jobRuns})
+
+			sparkApp, err := backend.Get("ns", "app")
+			if test.expectedId == "" {
+				assert.Equal(t, http.StatusNotFound, gatewayerrors.NewFrom(err).Status, "SparkApplication should not be found")
+				return
+			}
+
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, "app", sparkApp.Name, "name should be the job run name")
+			assert.Equal(t, "ns", sparkApp.Namespace, "namespace should be the virtual cluster's namespace")
+			assert.Equal(t, "alice", sparkApp.Labels["spark-gateway/user"], "labels should be restored from tags")
+			assert.Equal(t, test.expectedId, sparkApp.Annotations[EMRJobRunIdAnnotation], "job run id should be annotated")
+			assert.Equal(t, test.expectedState, sparkApp.Status.AppState.State, "state should be mapped")
+			assert.Equal(t, test.expectedError, sparkApp.Status.AppState.ErrorMessage, "error message should be mapped")
+		})
+	}
+}
+
+func TestEMROnEKSDelete(t *testing.T) {
+	tags := map[string]*string{"label:spark-gateway/owned": aws.String("true")}
+	emr := &fakeEMRContainers{jobRuns: []*emrcontainers.JobRun{
+		{Id: aws.String("run1"), Name: aws.String("running"), VirtualClusterId: aws.String("vc1"), State: aws.String(emrcontainers.JobRunStateRunning), Tags: tags},
+		{Id: aws.String("run2"), Name: aws.String("done"), VirtualClusterId: aws.String("vc1"), State: aws.String(emrcontainers.JobRunStateCompleted), Tags: tags},
+	}}
+	backend := newTestEMRBackend(emr)
+
+	assert.Nil(t, backend.Delete(context.Background(), "ns", "done"), "deleting a finished job run should succeed")
+	assert.Nil(t, emr.cancelled, "finished job runs should not be cancelled")
+
+	assert.Nil(t, backend.Delete(context.Background(), "ns", "running"), "deleting a running job run should succeed")
+	assert.Equal(t, "run1", aws.StringValue(emr.cancelled.Id), "running job run should be cancelled")
+}
+
+func TestEMROnEKSStreamLogsS3(t *testing.T) {
+	var logs bytes.Buffer
+	gzipWriter := gzip.NewWriter(&logs)
+	gzipWriter.Write([]byte("line1\nline2\nline3\n"))
+	gzipWriter.Close()
+
+	emr := &fakeEMRContainers{jobRuns: []*emrcontainers.JobRun{
+		{Id: aws.String("run1"), Name: aws.String("app"), VirtualClusterId: aws.String("vc1"), Tags: map[string]*string{"label:spark-gateway/owned": aws.String("true")}},
+	}}
+	backend := newTestEMRBackend(emr)
+	backend.config.LogGroupName = ""
+	backend.config.S3LogUri = "s3://bucket/logs"
+	backend.s3 = &fakeS3{objects: map[string][]byte{
+		"logs/vc1/jobs/run1/containers/spark-abc/spark-run1-exec-1/stdout.gz": {},
+		"logs/vc1/jobs/run1/containers/spark-abc/spark-run1-driver/stdout.gz": logs.Bytes(),
+	}}
+
+	stream, err := backend.StreamLogs(context.Background(), "ns", "app", util.Ptr(int64(2)))
+	assert.Nil(t, err, "err should be nil")
+	tailed, _ := io.ReadAll(stream)
+	assert.Equal(t, "line2\nline3\n", string(tailed), "should return the last lines of the driver logs")
+
+	stream, err = backend.StreamLogs(context.Background(), "ns", "app", nil)
+	assert.Nil(t, err, "err should be nil")
+	all, _ := io.ReadAll(stream)
+	assert.Nil(t, stream.Close(), "close should succeed")
+	assert.Equal(t, "line1\nline2\nline3\n", string(all), "should return the complete driver logs")
+}
+
+func TestEMROnEKSWatch(t *testing.T) {
+	_, err := newTestEMRBackend(&fakeEMRContainers{}).Watch(context.Background(), "ns", "", "")
+	assert.Equal(t, http.StatusNotImplemented, gatewayerrors.NewFrom(err).Status, "watch should not be implemented")
+}
@@ -0,0 +1,378 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
+)
+
+// LocalFinalStateAnnotation sets the state a local backend SparkApplication finishes in, COMPLETED when unset, so
+// failures can be exercised without a cluster
+const LocalFinalStateAnnotation = "spark-gateway/local-final-state"
+
+// localTickInterval is how often the local backend advances the states of its SparkApplications
+const localTickInterval = time.Second
+
+// localBackend keeps SparkApplications in memory and moves them through SUBMITTED, RUNNING and a final state on a
+// timer, as the Spark Operator would, so the API, routing and Livy paths can run without a cluster. Status changes are
+// written to the database like the Spark Operator backend's informer does.
+type localBackend struct {
+	config      config.LocalBackendConfig
+	clusterName string
+	database    database.SparkApplicationDatabase
+	broadcaster *watch.Broadcaster
+	now         func() time.Time
+
+	lock            sync.RWMutex
+	apps            map[string]*v1beta2.SparkApplication
+	resourceVersion int
+}
+
+var (
+	_ service.ExecutorScaler     = (*localBackend)(nil)
+	_ service.ApplicationUpdater = (*localBackend)(nil)
+	_ service.ApplicationWatcher = (*localBackend)(nil)
+)
+
+// newLocalBackend returns the in-memory backend every cluster uses in local mode. Its SparkApplications are lost when
+// SparkManager stops.
+func newLocalBackend(ctx context.Context, params Params) (Backend, error) {
+	b := &localBackend{
+		config:      params.Config.SparkManagerConfig.Local,
+		clusterName: params.Cluster.Name,
+		database:    params.Database,
+		broadcaster: watch.NewBroadcaster(100, watch.DropIfChannelFull),
+		now:         time.Now,
+		apps:        map[string]*v1beta2.SparkApplication{},
+	}
+
+	go b.run(ctx)
+
+	return b, nil
+}
+
+func localKey(namespace string, name string) string {
+	return namespace + "/" + name
+}
+
+// wait stands in for the latency of the Kubernetes API
+func (b *localBackend) wait() {
+	if b.config.Latency > 0 {
+		time.Sleep(b.config.Latency)
+	}
+}
+
+func (b *localBackend) Get(namespace string, name string) (*v1beta2.SparkApplication, error) {
+	b.wait()
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	sparkApp, ok := b.apps[localKey(namespace, name)]
+	if !ok {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("error getting SparkApplication '%s/%s': not found", namespace, name))
+	}
+
+	return sparkApp.DeepCopy(), nil
+}
+
+func (b *localBackend) List(namespace string) ([]*v1beta2.SparkApplication, error) {
+	b.wait()
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	sparkApps := []*v1beta2.SparkApplication{}
+	for _, sparkApp := range b.apps {
+		if sparkApp.Namespace == namespace {
+			sparkApps = append(sparkApps, sparkApp.DeepCopy())
+		}
+	}
+	slices.SortFunc(sparkApps, func(a, b *v1beta2.SparkApplication) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return sparkApps, nil
+}
+
+// StreamLogs returns generated driver log lines for SparkApplications that have a driver
+func (b *localBackend) StreamLogs(ctx context.Context, namespace string, name string, tailLines *int64) (io.ReadCloser, error) {
+	sparkApp, err := b.Get(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if sparkApp.Status.DriverInfo.PodName == "" {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("spark driver does not exist, cannot fetch logs"))
+	}
+
+	lines := []string{
+		fmt.Sprintf("INFO SparkContext: Running Spark version local for %s", sparkApp.Name),
+		fmt.Sprintf("INFO SparkContext: Submitted application: %s", sparkApp.Status.SparkApplicationID),
+	}
+	if state := sparkApp.Status.AppState.State; state != v1beta2.ApplicationStateRunning {
+		lines = append(lines, fmt.Sprintf("INFO SparkContext: Application finished in state %s", state))
+	}
+	if tailLines != nil && int(*tailLines) < len(lines) {
+		lines = lines[len(lines)-int(*tailLines):]
+	}
+
+	return io.NopCloser(strings.NewReader(strings.Join(lines, "\n") + "\n")), nil
+}
+
+func (b *localBackend) Create(ctx context.Context, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
+	b.wait()
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	key := localKey(application.Namespace, application.Name)
+	if existing, ok := b.apps[key]; ok {
+		if domain.IsSameSubmission(existing, application) {
+			return existing.DeepCopy(), nil
+		}
+		return nil, gatewayerrors.NewAlreadyExists(fmt.Errorf("error creating SparkApplication: '%s' already exists", key))
+	}
+
+	now := v1.NewTime(b.now())
+	sparkApp := application.DeepCopy()
+	// There is no cluster to create bundled ConfigMaps in, and nothing runs that would read them
+	delete(sparkApp.Annotations, domain.GATEWAY_CONFIGMAPS_ANNOTATION)
+	sparkApp.UID = types.UID(uuid.NewString())
+	sparkApp.CreationTimestamp = now
+	sparkApp.Status = v1beta2.SparkApplicationStatus{
+		AppState:                  v1beta2.ApplicationState{State: v1beta2.ApplicationStateSubmitted},
+		SubmissionID:              uuid.NewString(),
+		SubmissionAttempts:        1,
+		LastSubmissionAttemptTime: now,
+	}
+	b.store(watch.Added, key, sparkApp)
+
+	return sparkApp.DeepCopy(), nil
+}
+
+func (b *localBackend) Delete(ctx context.Context, namespace string, name string) error {
+	b.wait()
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	key := localKey(namespace, name)
+	sparkApp, ok := b.apps[key]
+	if !ok {
+		return gatewayerrors.NewNotFound(fmt.Errorf("error deleting SparkApplication: '%s' not found", key))
+	}
+	delete(b.apps, key)
+	b.broadcast(watch.Deleted, sparkApp)
+
+	return nil
+}
+
+// Watch streams changes to the SparkApplications in namespace matching labelSelector. Past changes aren't kept, so
+// resourceVersion is ignored.
+func (b *localBackend) Watch(ctx context.Context, namespace string, labelSelector string, resourceVersion string) (watch.Interface, error) {
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, gatewayerrors.NewBadRequest(fmt.Errorf("invalid label selector '%s': %w", labelSelector, err))
+	}
+
+	watcher, err := b.broadcaster.Watch()
+	if err != nil {
+		return nil, gatewayerrors.NewUnavailable(fmt.Errorf("error watching SparkApplications in namespace '%s': %w", namespace, err))
+	}
+
+	return watch.Filter(watcher, func(event watch.Event) (watch.Event, bool) {
+		sparkApp, ok := event.Object.(*v1beta2.SparkApplication)
+		return event, ok && sparkApp.Namespace == namespace && selector.Matches(labels.Set(sparkApp.Labels))
+	}), nil
+}
+
+// WatchApplication streams changes to the SparkApplication namespace/name
+func (b *localBackend) WatchApplication(ctx context.Context, namespace string, name string) (watch.Interface, error) {
+	watcher, err := b.broadcaster.Watch()
+	if err != nil {
+		return nil, gatewayerrors.NewUnavailable(fmt.Errorf("error watching SparkApplication '%s/%s': %w", namespace, name, err))
+	}
+
+	return watch.Filter(watcher, func(event watch.Event) (watch.Event, bool) {
+		sparkApp, ok := event.Object.(*v1beta2.SparkApplication)
+		return event, ok && sparkApp.Namespace == namespace && sparkApp.Name == name
+	}), nil
+}
+
+func (b *localBackend) ScaleExecutors(ctx context.Context, namespace string, name string, scale domain.ExecutorScale) (*v1beta2.SparkApplication, error) {
+	b.wait()
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	key := localKey(namespace, name)
+	existing, ok := b.apps[key]
+	if !ok {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("error scaling executors of SparkApplication '%s': not found", key))
+	}
+
+	sparkApp := existing.DeepCopy()
+	if scale.Instances != nil {
+		sparkApp.Spec.Executor.Instances = scale.Instances
+	}
+	if scale.MaxExecutors != nil {
+		if sparkApp.Spec.DynamicAllocation == nil {
+			sparkApp.Spec.DynamicAllocation = &v1beta2.DynamicAllocation{}
+		}
+		sparkApp.Spec.DynamicAllocation.MaxExecutors = scale.MaxExecutors
+	}
+	b.store(watch.Modified, key, sparkApp)
+
+	return sparkApp.DeepCopy(), nil
+}
+
+func (b *localBackend) Update(ctx context.Context, namespace string, name string, update domain.ApplicationUpdate) (*v1beta2.SparkApplication, error) {
+	b.wait()
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	key := localKey(namespace, name)
+	existing, ok := b.apps[key]
+	if !ok {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("error updating SparkApplication '%s': not found", key))
+	}
+	if !domain.IsUpdatable(existing.Status.AppState.State) {
+		return nil, gatewayerrors.NewConflict(fmt.Errorf("SparkApplication '%s' is in state '%s', only SparkApplications that haven't started running can be updated", key, existing.Status.AppState.State))
+	}
+
+	sparkApp := existing.DeepCopy()
+	update.Apply(sparkApp)
+	b.store(watch.Modified, key, sparkApp)
+
+	return sparkApp.DeepCopy(), nil
+}
+
+// store saves sparkApp under key with the next resource version and broadcasts the change. b.lock must be held.
+func (b *localBackend) store(eventType watch.EventType, key string, sparkApp *v1beta2.SparkApplication) {
+	b.resourceVersion++
+	sparkApp.ResourceVersion = strconv.Itoa(b.resourceVersion)
+	b.apps[key] = sparkApp
+	b.broadcast(eventType, sparkApp)
+}
+
+// broadcast sends a copy of sparkApp to watchers, dropping it for watchers that have fallen behind. b.lock must be held
+// so watchers see changes in order.
+func (b *localBackend) broadcast(eventType watch.EventType, sparkApp *v1beta2.SparkApplication) {
+	if err := b.broadcaster.Action(eventType, sparkApp.DeepCopy()); err != nil {
+		klog.Errorf("error broadcasting %s of SparkApplication '%s/%s': %v", eventType, sparkApp.Namespace, sparkApp.Name, err)
+	}
+}
+
+// run advances SparkApplication states every localTickInterval until ctx is cancelled
+func (b *localBackend) run(ctx context.Context) {
+	ticker := time.NewTicker(localTickInterval)
+	defer ticker.Stop()
+	defer b.broadcaster.Shutdown()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.advance(ctx)
+		}
+	}
+}
+
+// advance moves the SparkApplications whose current state has lasted long enough to their next state, and writes the
+// changes to the database
+func (b *localBackend) advance(ctx context.Context) {
+	now := b.now()
+
+	var updated []*v1beta2.SparkApplication
+	b.lock.Lock()
+	for key, existing := range b.apps {
+		sparkApp := existing.DeepCopy()
+		if !b.transition(sparkApp, now) {
+			continue
+		}
+		b.store(watch.Modified, key, sparkApp)
+		updated = append(updated, sparkApp.DeepCopy())
+	}
+	b.lock.Unlock()
+
+	if b.database == nil {
+		return
+	}
+	for _, sparkApp := range updated {
+		gatewayIdUid, err := domain.ParseGatewayIdUUID(sparkApp.Name)
+		if err != nil {
+			klog.ErrorS(err, "Failed to parse the gateway UUID, skipping database update", "gatewayId", sparkApp.Name)
+			continue
+		}
+		if err := b.database.UpdateSparkApplication(ctx, *gatewayIdUid, *sparkApp); err != nil {
+			klog.Errorf("error updating SparkApplication '%s/%s' in the database: %v", sparkApp.Namespace, sparkApp.Name, err)
+		}
+		if err := database.RecordStateEvent(ctx, b.database, *gatewayIdUid, b.clusterName, sparkApp, now); err != nil {
+			klog.Errorf("error recording SparkApplication '%s/%s' state event in the database: %v", sparkApp.Namespace, sparkApp.Name, err)
+		}
+	}
+}
+
+// transition moves sparkApp to its next state if its current state has lasted long enough, returning whether it did
+func (b *localBackend) transition(sparkApp *v1beta2.SparkApplication, now time.Time) bool {
+	switch sparkApp.Status.AppState.State {
+	case v1beta2.ApplicationStateSubmitted:
+		if now.Sub(sparkApp.Status.LastSubmissionAttemptTime.Time) < b.config.SubmittedDuration {
+			return false
+		}
+		sparkApp.Status.AppState.State = v1beta2.ApplicationStateRunning
+		sparkApp.Status.SparkApplicationID = "spark-" + strings.ReplaceAll(string(sparkApp.UID), "-", "")
+		sparkApp.Status.DriverInfo = v1beta2.DriverInfo{PodName: sparkApp.Name + "-driver"}
+		sparkApp.Status.ExecutionAttempts = 1
+		return true
+	case v1beta2.ApplicationStateRunning:
+		if now.Sub(sparkApp.Status.LastSubmissionAttemptTime.Time) < b.config.SubmittedDuration+b.config.RunningDuration {
+			return false
+		}
+		sparkApp.Status.AppState.State = v1beta2.ApplicationStateCompleted
+		if finalState := sparkApp.Annotations[LocalFinalStateAnnotation]; finalState == string(v1beta2.ApplicationStateFailed) {
+			sparkApp.Status.AppState.State = v1beta2.ApplicationStateFailed
+			sparkApp.Status.AppState.ErrorMessage = fmt.Sprintf("failed by the %s annotation", LocalFinalStateAnnotation)
+		}
+		sparkApp.Status.TerminationTime = v1.NewTime(now)
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+const localTestName = "clus-nspc-01982d11-c2c1-7c3d-8b2f-944ae7248434"
+
+func newTestLocalBackend(now *time.Time) *localBackend {
+	return &localBackend{
+		config:      config.LocalBackendConfig{SubmittedDuration: 5 * time.Second, RunningDuration: 30 * time.Second},
+		broadcaster: watch.NewBroadcaster(100, watch.DropIfChannelFull),
+		now:         func() time.Time { return *now },
+		apps:        map[string]*v1beta2.SparkApplication{},
+	}
+}
+
+func TestNewLocalMode(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	backend, err := New(ctx, Params{
+		Config:  &config.SparkGatewayConfig{Mode: config.ModeLocal},
+		Cluster: domain.KubeCluster{Name: "cluster", Backend: domain.BackendSparkOperator},
+	})
+	require.NoError(t, err)
+	assert.IsType(t, &localBackend{}, backend, "local mode should use the local backend regardless of the cluster's backend")
+}
+
+func TestLocalBackendTransitions(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := newTestLocalBackend(&now)
+	ctx := context.Background()
+
+	completed := &v1beta2.SparkApplication{ObjectMeta: v1.ObjectMeta{Name: localTestName, Namespace: "default"}}
+	failed := &v1beta2.SparkApplication{ObjectMeta: v1.ObjectMeta{Name: localTestName, Namespace: "other", Annotations: map[string]string{LocalFinalStateAnnotation: "FAILED"}}}
+	for _, sparkApp := range []*v1beta2.SparkApplication{completed, failed} {
+		created, err := b.Create(ctx, sparkApp)
+		require.NoError(t, err)
+		assert.NotEmpty(t, created.UID, "created SparkApplications should have a UID")
+		assert.Equal(t, v1beta2.ApplicationStateSubmitted, created.Status.AppState.State, "created SparkApplications should be submitted")
+	}
+
+	_, err := b.StreamLogs(ctx, "default", localTestName, nil)
+	assert.Equal(t, 404, gatewayerrors.NewFrom(err).Status, "submitted SparkApplications should have no driver logs")
+
+	var tests = []struct {
+		test          string
+		elapsed       time.Duration
+		expected      v1beta2.ApplicationStateType
+		expectedOther v1beta2.ApplicationStateType
+	}{
+		{"still submitted", 4 * time.Second, v1beta2.ApplicationStateSubmitted, v1beta2.ApplicationStateSubmitted},
+		{"running", 5 * time.Second, v1beta2.ApplicationStateRunning, v1beta2.ApplicationStateRunning},
+		{"still running", 34 * time.Second, v1beta2.ApplicationStateRunning, v1beta2.ApplicationStateRunning},
+		{"finished", 35 * time.Second, v1beta2.ApplicationStateCompleted, v1beta2.ApplicationStateFailed},
+	}
+
+	start := now
+	for _, test := range tests {
+		t.Run(test.test, func(t *testing.T) {
+			now = start.Add(test.elapsed)
+			b.advance(ctx)
+
+			sparkApp, err := b.Get("default", localTestName)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, sparkApp.Status.AppState.State, "states should match")
+
+			other, err := b.Get("other", localTestName)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedOther, other.Status.AppState.State, "annotated final states should match")
+		})
+	}
+
+	logs, err := b.StreamLogs(ctx, "default", localTestName, nil)
+	require.NoError(t, err)
+	content, _ := io.ReadAll(logs)
+	assert.Contains(t, string(content), "finished in state COMPLETED", "logs should report the final state")
+}
+
+func TestLocalBackendCRUD(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := newTestLocalBackend(&now)
+	ctx := context.Background()
+
+	watcher, err := b.Watch(ctx, "default", "", "")
+	require.NoError(t, err)
+	defer watcher.Stop()
+
+	sparkApp := &v1beta2.SparkApplication{ObjectMeta: v1.ObjectMeta{Name: localTestName, Namespace: "default"}}
+	_, err = b.Create(ctx, sparkApp)
+	require.NoError(t, err)
+
+	_, err = b.Create(ctx, sparkApp)
+	assert.Equal(t, 409, gatewayerrors.NewFrom(err).Status, "a SparkApplication without a matching spec hash should conflict")
+
+	list, err := b.List("default")
+	require.NoError(t, err)
+	assert.Len(t, list, 1, "list should return the created SparkApplication")
+
+	list, err = b.List("other")
+	require.NoError(t, err)
+	assert.Empty(t, list, "list should only return the namespace's SparkApplications")
+
+	require.NoError(t, b.Delete(ctx, "default", localTestName))
+	assert.Equal(t, 404, gatewayerrors.NewFrom(b.Delete(ctx, "default", localTestName)).Status, "deleting twice should not find the SparkApplication")
+
+	for _, expected := range []watch.EventType{watch.Added, watch.Deleted} {
+		select {
+		case event := <-watcher.ResultChan():
+			assert.Equal(t, expected, event.Type, "watch events should match")
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for %s event", expected)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/kube"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/repository"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
+)
+
+// sparkOperatorBackend also provisions namespaces for SparkApplications, scales the executors of running
+// SparkApplications and proxies requests to their drivers
+type sparkOperatorBackend struct {
+	*repository.SparkApplicationRepository
+	*repository.NamespaceRepository
+}
+
+var (
+	_ service.NamespaceProvisioner = (*sparkOperatorBackend)(nil)
+	_ service.ExecutorScaler       = (*sparkOperatorBackend)(nil)
+	_ service.DriverProxy          = (*sparkOperatorBackend)(nil)
+	_ service.ApplicationWatcher   = (*sparkOperatorBackend)(nil)
+	_ service.LogFollower          = (*sparkOperatorBackend)(nil)
+	_ service.PodReader            = (*sparkOperatorBackend)(nil)
+	_ service.ApplicationUpdater   = (*sparkOperatorBackend)(nil)
+	_ service.LatestReader         = (*sparkOperatorBackend)(nil)
+)
+
+// newSparkOperatorBackend runs SparkApplications through the Kubeflow Spark Operator, creating SparkApplication
+// resources and reading them back from an informer cache.
+func newSparkOperatorBackend(ctx context.Context, params Params) (Backend, error) {
+	k8sClient, err := kubernetes.NewForConfig(params.KubeConfig)
+	if err != nil {
+		return nil, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error creating k8s client: %w", err))
+	}
+	sparkClient, err := kube.NewSparkClient(params.KubeConfig, params.Cluster.SparkApplicationCRD)
+	if err != nil {
+		return nil, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error creating spark client: %w", err))
+	}
+
+	// Initialize Kube SparkApp Controller
+	controller, err := kube.NewSparkController(
+		ctx,
+		sparkClient,
+		params.Config.SelectorKey,
+		params.Config.SelectorValue,
+		params.Cluster.Name,
+		params.Database,
+		kube.NewInformerOptions(params.Config.SparkManagerConfig.Informers, params.Cluster),
+	)
+	if err != nil {
+		return nil, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("unable to initialize SparkApplication Controller: %w", err))
+	}
+
+	sparkAppRepo, err := repository.NewSparkApplicationRepository(controller, sparkClient, k8sClient, params.Cluster.SparkApplicationCRD)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create NewSparkApplicationRepository: %w", err)
+	}
+
+	return &sparkOperatorBackend{
+		SparkApplicationRepository: sparkAppRepo,
+		NamespaceRepository:        repository.NewNamespaceRepository(k8sClient),
+	}, nil
+}
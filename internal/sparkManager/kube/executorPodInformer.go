@@ -0,0 +1,65 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubeflow/spark-operator/v2/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corev1Lister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// NewRunningExecutorPodLister starts informers watching running Spark executor pods in the namespaces of options and
+// returns their lister once the caches have synced. Only pods with the spark-role=executor label in the Running phase
+// are cached, so SparkManager can report the executors actually running rather than those a spec allows.
+func NewRunningExecutorPodLister(ctx context.Context, k8sClient kubernetes.Interface, options InformerOptions) (corev1Lister.PodLister, error) {
+	listers := namespacedListers[corev1Lister.PodLister]{}
+	var hasSynced []cache.InformerSynced
+	for _, namespace := range options.namespaces() {
+		informerFactory := informers.NewSharedInformerFactoryWithOptions(k8sClient, options.resyncPeriod(),
+			informers.WithNamespace(namespace),
+			informers.WithTweakListOptions(func(listOptions *metav1.ListOptions) {
+				listOptions.LabelSelector = labels.SelectorFromSet(labels.Set{common.LabelSparkRole: common.SparkRoleExecutor}).String()
+				listOptions.FieldSelector = fields.OneTermEqualSelector("status.phase", string(corev1.PodRunning)).String()
+			}),
+		)
+		podInformer := informerFactory.Core().V1().Pods()
+		listers[namespace] = podInformer.Lister()
+		hasSynced = append(hasSynced, podInformer.Informer().HasSynced)
+
+		// Start method is non-blocking and runs all registered informers in a dedicated goroutine.
+		informerFactory.Start(ctx.Done())
+	}
+
+	klog.Info("Syncing executor Pod Cache")
+	if ok := cache.WaitForNamedCacheSync("ExecutorPodInformer", ctx.Done(), hasSynced...); !ok {
+		return nil, fmt.Errorf("failed to wait for executor Pod cache to sync")
+	}
+
+	if len(listers) == 1 {
+		return listers.forNamespace(metav1.NamespaceAll), nil
+	}
+	return namespacedPodLister{listers}, nil
+}
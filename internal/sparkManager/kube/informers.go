@@ -0,0 +1,138 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	v1beta2Lister "github.com/kubeflow/spark-operator/v2/pkg/client/listers/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1Lister "k8s.io/client-go/listers/core/v1"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+)
+
+// defaultResyncPeriod is how often informers replay their cache to event handlers when InformerOptions doesn't set it
+const defaultResyncPeriod = 30 * time.Second
+
+// InformerOptions configures the informers SparkManager caches SparkApplications, executor pods and ResourceQuotas with
+type InformerOptions struct {
+	// ResyncPeriod is how often informers replay their cache to event handlers, defaultResyncPeriod when 0
+	ResyncPeriod time.Duration
+	// Namespaces restricts informers to these namespaces, each watched by its own informer. All namespaces are watched
+	// by a single informer when empty.
+	Namespaces []string
+}
+
+// NewInformerOptions returns the InformerOptions of cluster's informers, restricted to its configured namespaces when
+// informerConfig is namespace scoped
+func NewInformerOptions(informerConfig config.InformerConfig, cluster domain.KubeCluster) InformerOptions {
+	options := InformerOptions{ResyncPeriod: informerConfig.ResyncPeriod}
+	if informerConfig.NamespaceScoped {
+		for _, namespace := range cluster.Namespaces {
+			options.Namespaces = append(options.Namespaces, namespace.Name)
+		}
+	}
+	return options
+}
+
+func (o InformerOptions) resyncPeriod() time.Duration {
+	if o.ResyncPeriod == 0 {
+		return defaultResyncPeriod
+	}
+	return o.ResyncPeriod
+}
+
+// namespaces returns the namespaces to start an informer for, metav1.NamespaceAll for a single cluster-wide informer
+func (o InformerOptions) namespaces() []string {
+	if len(o.Namespaces) == 0 {
+		return []string{metav1.NamespaceAll}
+	}
+	return slices.Compact(slices.Sorted(slices.Values(o.Namespaces)))
+}
+
+// namespacedListers are the listers of informers that each watch one namespace
+type namespacedListers[L any] map[string]L
+
+// forNamespace returns the lister of namespace's informer. Listers filter by namespace, so the lister of any other
+// informer lists nothing for a namespace that isn't watched.
+func (n namespacedListers[L]) forNamespace(namespace string) L {
+	if lister, ok := n[namespace]; ok {
+		return lister
+	}
+	return n[slices.Min(slices.Collect(maps.Keys(n)))]
+}
+
+// listAll lists across every namespace's informer
+func listAll[L any, T any](n namespacedListers[L], list func(L) ([]T, error)) ([]T, error) {
+	var all []T
+	for _, namespace := range slices.Sorted(maps.Keys(n)) {
+		items, err := list(n[namespace])
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+	}
+	return all, nil
+}
+
+type namespacedSparkApplicationLister struct {
+	namespacedListers[v1beta2Lister.SparkApplicationLister]
+}
+
+func (l namespacedSparkApplicationLister) List(selector labels.Selector) ([]*v1beta2.SparkApplication, error) {
+	return listAll(l.namespacedListers, func(lister v1beta2Lister.SparkApplicationLister) ([]*v1beta2.SparkApplication, error) {
+		return lister.List(selector)
+	})
+}
+
+func (l namespacedSparkApplicationLister) SparkApplications(namespace string) v1beta2Lister.SparkApplicationNamespaceLister {
+	return l.forNamespace(namespace).SparkApplications(namespace)
+}
+
+type namespacedPodLister struct {
+	namespacedListers[corev1Lister.PodLister]
+}
+
+func (l namespacedPodLister) List(selector labels.Selector) ([]*corev1.Pod, error) {
+	return listAll(l.namespacedListers, func(lister corev1Lister.PodLister) ([]*corev1.Pod, error) {
+		return lister.List(selector)
+	})
+}
+
+func (l namespacedPodLister) Pods(namespace string) corev1Lister.PodNamespaceLister {
+	return l.forNamespace(namespace).Pods(namespace)
+}
+
+type namespacedResourceQuotaLister struct {
+	namespacedListers[corev1Lister.ResourceQuotaLister]
+}
+
+func (l namespacedResourceQuotaLister) List(selector labels.Selector) ([]*corev1.ResourceQuota, error) {
+	return listAll(l.namespacedListers, func(lister corev1Lister.ResourceQuotaLister) ([]*corev1.ResourceQuota, error) {
+		return lister.List(selector)
+	})
+}
+
+func (l namespacedResourceQuotaLister) ResourceQuotas(namespace string) corev1Lister.ResourceQuotaNamespaceLister {
+	return l.forNamespace(namespace).ResourceQuotas(namespace)
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	sparkFake "github.com/kubeflow/spark-operator/v2/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+)
+
+func TestNewInformerOptions(t *testing.T) {
+	cluster := domain.KubeCluster{Namespaces: []domain.KubeNamespace{{Name: "a"}, {Name: "b"}}}
+
+	assert.Equal(t, InformerOptions{}, NewInformerOptions(config.InformerConfig{}, cluster), "cluster-wide options should have no namespaces")
+	assert.Equal(t, InformerOptions{Namespaces: []string{"a", "b"}}, NewInformerOptions(config.InformerConfig{NamespaceScoped: true}, cluster), "namespace scoped options should list the cluster's namespaces")
+}
+
+func TestNewResourceQuotaListerNamespaces(t *testing.T) {
+	k8sClient := fake.NewClientset(
+		&corev1.ResourceQuota{ObjectMeta: v1.ObjectMeta{Name: "quota", Namespace: "a"}},
+		&corev1.ResourceQuota{ObjectMeta: v1.ObjectMeta{Name: "quota", Namespace: "b"}},
+		&corev1.ResourceQuota{ObjectMeta: v1.ObjectMeta{Name: "quota", Namespace: "unmanaged"}},
+	)
+
+	tests := []struct {
+		name         string
+		namespaces   []string
+		expectAll    int
+		expectInA    int
+		expectInMiss int
+	}{
+		{"cluster-wide", nil, 3, 1, 1},
+		{"single namespace", []string{"a"}, 1, 1, 0},
+		{"namespace scoped", []string{"a", "b"}, 2, 1, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			lister, err := NewResourceQuotaLister(ctx, k8sClient, InformerOptions{Namespaces: test.namespaces})
+			require.NoError(t, err)
+
+			all, err := lister.List(labels.Everything())
+			require.NoError(t, err)
+			assert.Len(t, all, test.expectAll, "quotas across namespaces should match")
+
+			inA, err := lister.ResourceQuotas("a").List(labels.Everything())
+			require.NoError(t, err)
+			assert.Len(t, inA, test.expectInA, "quotas in a watched namespace should match")
+
+			inMiss, err := lister.ResourceQuotas("unmanaged").List(labels.Everything())
+			require.NoError(t, err)
+			assert.Len(t, inMiss, test.expectInMiss, "quotas in an unwatched namespace should match")
+		})
+	}
+}
+
+func TestNewSparkControllerNamespaces(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sparkClient := sparkFake.NewSimpleClientset(
+		&v1beta2.SparkApplication{ObjectMeta: v1.ObjectMeta{Name: "app", Namespace: "a"}},
+		&v1beta2.SparkApplication{ObjectMeta: v1.ObjectMeta{Name: "app", Namespace: "b"}},
+		&v1beta2.SparkApplication{ObjectMeta: v1.ObjectMeta{Name: "app", Namespace: "unmanaged"}},
+	)
+
+	controller, err := NewSparkController(ctx, sparkClient, "", "", "cluster", nil, InformerOptions{Namespaces: []string{"b", "a"}})
+	require.NoError(t, err)
+
+	all, err := controller.SparkLister.List(labels.Everything())
+	require.NoError(t, err)
+	assert.Len(t, all, 2, "only watched namespaces should be cached")
+
+	_, err = controller.SparkLister.SparkApplications("a").Get("app")
+	assert.NoError(t, err, "SparkApplications in watched namespaces should be found")
+
+	_, err = controller.SparkLister.SparkApplications("unmanaged").Get("app")
+	assert.Error(t, err, "SparkApplications in unwatched namespaces should not be found")
+
+	assert.True(t, controller.CacheStatus("a").Synced, "watched namespace caches should be synced")
+	assert.True(t, controller.CacheStatus("unmanaged").Synced, "unwatched namespaces should report synced")
+}
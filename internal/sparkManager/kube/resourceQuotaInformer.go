@@ -0,0 +1,55 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corev1Lister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// NewResourceQuotaLister starts informers watching ResourceQuotas in the namespaces of options and returns their lister
+// once the caches have synced. SparkManager reports namespace quota utilization from it so the Gateway can route
+// namespaces away from clusters where their quota is nearly exhausted.
+func NewResourceQuotaLister(ctx context.Context, k8sClient kubernetes.Interface, options InformerOptions) (corev1Lister.ResourceQuotaLister, error) {
+	listers := namespacedListers[corev1Lister.ResourceQuotaLister]{}
+	var hasSynced []cache.InformerSynced
+	for _, namespace := range options.namespaces() {
+		informerFactory := informers.NewSharedInformerFactoryWithOptions(k8sClient, options.resyncPeriod(), informers.WithNamespace(namespace))
+		quotaInformer := informerFactory.Core().V1().ResourceQuotas()
+		listers[namespace] = quotaInformer.Lister()
+		hasSynced = append(hasSynced, quotaInformer.Informer().HasSynced)
+
+		// Start method is non-blocking and runs all registered informers in a dedicated goroutine.
+		informerFactory.Start(ctx.Done())
+	}
+
+	klog.Info("Syncing ResourceQuota Cache")
+	if ok := cache.WaitForNamedCacheSync("ResourceQuotaInformer", ctx.Done(), hasSynced...); !ok {
+		return nil, fmt.Errorf("failed to wait for ResourceQuota cache to sync")
+	}
+
+	if len(listers) == 1 {
+		return listers.forNamespace(metav1.NamespaceAll), nil
+	}
+	return namespacedResourceQuotaLister{listers}, nil
+}
@@ -18,6 +18,7 @@ package kube
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
@@ -25,13 +26,12 @@ import (
 	sparkOpInformer "github.com/kubeflow/spark-operator/v2/pkg/client/informers/externalversions"
 	v1beta2Lister "github.com/kubeflow/spark-operator/v2/pkg/client/listers/api/v1beta2"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/rest"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
 	"github.com/slackhq/spark-gateway/internal/domain"
 	"github.com/slackhq/spark-gateway/internal/shared/database"
-	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
 )
 
 // SparkController is a type of Kubernetes controller. The difference between SparkController and a typical
@@ -42,66 +42,104 @@ import (
 // Reference: https://github.com/kubernetes/sample-controller/blob/master/controller.go
 
 type SparkController struct {
-	SparkInformer cache.SharedIndexInformer
-	SparkLister   v1beta2Lister.SparkApplicationLister
-	ctx           context.Context
-	clusterName   string
-	database      database.SparkApplicationDatabase
+	SparkLister v1beta2Lister.SparkApplicationLister
+	// informers are keyed by the namespace they watch, metav1.NamespaceAll for a cluster-wide informer
+	informers   map[string]*sparkInformer
+	ctx         context.Context
+	clusterName string
+	database    database.SparkApplicationDatabase
+	// LabelSelector is the selector the informer is filtered by, empty when all SparkApplications are monitored
+	LabelSelector string
+	// broadcaster relays the changes the informers observe to WatchApplication watchers
+	broadcaster *watch.Broadcaster
+}
+
+// sparkInformer tracks whether the watch of a SparkApplication informer is failing
+type sparkInformer struct {
+	cache.SharedIndexInformer
+
+	// staleLock guards staleSince and staleVersion, set when the watch fails and cleared once the informer observes a
+	// newer resource version
+	staleLock    sync.Mutex
+	staleSince   time.Time
+	staleVersion string
+	now          func() time.Time
 }
 
 func NewSparkController(
 	ctx context.Context,
-	kubeConfig *rest.Config,
+	sparkClient sparkClientSet.Interface,
 	selectorKey string,
 	selectorValue string,
 	clusterName string,
 	database database.SparkApplicationDatabase,
+	options InformerOptions,
 ) (*SparkController, error) {
 
-	sparkClient, err := sparkClientSet.NewForConfig(kubeConfig)
-	if err != nil {
-		return nil, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error creating spark client: %w", err))
-	}
-
 	// Filter SparkApps by selector label if set
 	sharedInformerOption := sparkOpInformer.WithTweakListOptions(func(options *v1.ListOptions) {})
+	labelSelector := ""
 	if selectorKey != "" && selectorValue != "" {
-		labelSelector := fmt.Sprintf("%s=%s", selectorKey, selectorValue)
+		labelSelector = fmt.Sprintf("%s=%s", selectorKey, selectorValue)
 		klog.Infof("Spark Gateway Indexer monitoring LabelSelector: %s", labelSelector)
 		sharedInformerOption = sparkOpInformer.WithTweakListOptions(func(options *v1.ListOptions) {
 			options.LabelSelector = labelSelector
 		})
 	}
 
-	// Create an instance of SharedInformerFactory with additional options
-	// Refresh every 30 seconds
-	informerFactory := sparkOpInformer.NewSharedInformerFactoryWithOptions(
-		sparkClient,
-		30*time.Second,
-		sharedInformerOption,
-	)
-
 	controller := &SparkController{
-		SparkInformer: informerFactory.Sparkoperator().V1beta2().SparkApplications().Informer(),
-		SparkLister:   informerFactory.Sparkoperator().V1beta2().SparkApplications().Lister(),
+		informers:     map[string]*sparkInformer{},
 		ctx:           ctx,
 		clusterName:   clusterName,
 		database:      database,
+		LabelSelector: labelSelector,
+		broadcaster:   watch.NewBroadcaster(100, watch.DropIfChannelFull),
 	}
+	go func() {
+		<-ctx.Done()
+		controller.broadcaster.Shutdown()
+	}()
+
+	listers := namespacedListers[v1beta2Lister.SparkApplicationLister]{}
+	for _, namespace := range options.namespaces() {
+		// Create an instance of SharedInformerFactory with additional options
+		informerFactory := sparkOpInformer.NewSharedInformerFactoryWithOptions(
+			sparkClient,
+			options.resyncPeriod(),
+			sparkOpInformer.WithNamespace(namespace),
+			sharedInformerOption,
+		)
+
+		informer := &sparkInformer{
+			SharedIndexInformer: informerFactory.Sparkoperator().V1beta2().SparkApplications().Informer(),
+			now:                 time.Now,
+		}
+		listers[namespace] = informerFactory.Sparkoperator().V1beta2().SparkApplications().Lister()
+		controller.informers[namespace] = informer
 
-	_, err = controller.SparkInformer.AddEventHandler(
-		cache.ResourceEventHandlerFuncs{
-			AddFunc:    controller.onAdd,
-			UpdateFunc: controller.onUpdate,
-			DeleteFunc: controller.onDelete,
-		})
+		if err := informer.SetWatchErrorHandlerWithContext(informer.onWatchError); err != nil {
+			return nil, err
+		}
 
-	if err != nil {
-		return nil, err
+		_, err := informer.AddEventHandler(
+			cache.ResourceEventHandlerFuncs{
+				AddFunc:    controller.onAdd,
+				UpdateFunc: controller.onUpdate,
+				DeleteFunc: controller.onDelete,
+			})
+
+		if err != nil {
+			return nil, err
+		}
+
+		// Start method is non-blocking and runs all registered informers in a dedicated goroutine.
+		informerFactory.Start(ctx.Done())
 	}
 
-	// Start method is non-blocking and runs all registered informers in a dedicated goroutine.
-	informerFactory.Start(ctx.Done())
+	controller.SparkLister = namespacedSparkApplicationLister{listers}
+	if len(listers) == 1 {
+		controller.SparkLister = listers.forNamespace(v1.NamespaceAll)
+	}
 
 	controller.Run()
 
@@ -118,6 +156,7 @@ func (c *SparkController) onAdd(obj interface{}) {
 		logger.Info("SparkApp added",
 			"namespace", sparkApp.Namespace,
 			"name", sparkApp.Name)
+		c.broadcast(watch.Added, sparkApp)
 	}
 }
 
@@ -147,6 +186,12 @@ func (c *SparkController) onUpdate(oldObj interface{}, newObj interface{}) {
 			if err != nil {
 				logger.Error(err, "Failed to update db: %s", err)
 			}
+			// Keep state transitions as the application's timeline
+			if oldSparkApp.Status.AppState.State != newSparkApp.Status.AppState.State {
+				if err := database.RecordStateEvent(c.ctx, c.database, *gatewayIdUid, c.clusterName, newSparkApp, time.Now()); err != nil {
+					logger.Error(err, "Failed to record state event")
+				}
+			}
 		}
 	}
 
@@ -154,6 +199,8 @@ func (c *SparkController) onUpdate(oldObj interface{}, newObj interface{}) {
 		"namespace", newSparkApp.Namespace,
 		"name", newSparkApp.Name)
 
+	// Resyncs are relayed too, so a watcher that missed a change catches up on the next one
+	c.broadcast(watch.Modified, newSparkApp)
 }
 
 func (c *SparkController) onDelete(obj interface{}) {
@@ -162,15 +209,88 @@ func (c *SparkController) onDelete(obj interface{}) {
 		logger.Info("SparkApp deleted",
 			"namespace", sparkApp.Namespace,
 			"name", sparkApp.Name)
+		c.broadcast(watch.Deleted, sparkApp)
+	}
+}
+
+// broadcast relays a change observed by the informers to WatchApplication watchers, dropping it for watchers that have
+// fallen behind so a slow watcher never holds up the informers
+func (c *SparkController) broadcast(eventType watch.EventType, sparkApp *v1beta2.SparkApplication) {
+	if err := c.broadcaster.Action(eventType, sparkApp); err != nil {
+		klog.Errorf("error broadcasting %s of SparkApplication '%s/%s': %v", eventType, sparkApp.Namespace, sparkApp.Name, err)
+	}
+}
+
+// WatchApplication watches a single SparkApplication through the informers rather than the API server. Events carry the
+// informer's cached object, which must not be modified. The caller is responsible for stopping the watch.
+func (c *SparkController) WatchApplication(namespace string, name string) (watch.Interface, error) {
+	watcher, err := c.broadcaster.Watch()
+	if err != nil {
+		return nil, fmt.Errorf("error watching SparkApplication '%s/%s': %w", namespace, name, err)
 	}
+
+	return watch.Filter(watcher, func(event watch.Event) (watch.Event, bool) {
+		sparkApp, ok := event.Object.(*v1beta2.SparkApplication)
+		return event, ok && sparkApp.Namespace == namespace && sparkApp.Name == name
+	}), nil
+}
+
+// onWatchError marks the cache stale from the first of consecutive watch failures
+func (i *sparkInformer) onWatchError(ctx context.Context, r *cache.Reflector, err error) {
+	cache.DefaultWatchErrorHandler(ctx, r, err)
+
+	i.staleLock.Lock()
+	defer i.staleLock.Unlock()
+
+	if i.staleSince.IsZero() {
+		i.staleSince = i.now()
+		i.staleVersion = i.LastSyncResourceVersion()
+	}
+}
+
+// cacheStatus returns how fresh the informer's cache is. The informer requests watch bookmarks, so its resource version
+// advances even while no SparkApplications change, and a cache marked stale by a watch failure is fresh again once the
+// resource version moves past the one it failed at.
+func (i *sparkInformer) cacheStatus() domain.CacheStatus {
+	status := domain.CacheStatus{
+		Synced:          i.HasSynced(),
+		ResourceVersion: i.LastSyncResourceVersion(),
+	}
+
+	i.staleLock.Lock()
+	defer i.staleLock.Unlock()
+
+	if !i.staleSince.IsZero() && status.ResourceVersion != i.staleVersion {
+		i.staleSince = time.Time{}
+	}
+	status.StaleSince = i.staleSince
+
+	return status
+}
+
+// CacheStatus returns how fresh the informer cache SparkApplications in namespace are served from is
+func (c *SparkController) CacheStatus(namespace string) domain.CacheStatus {
+	if informer, ok := c.informers[namespace]; ok {
+		return informer.cacheStatus()
+	}
+	if informer, ok := c.informers[v1.NamespaceAll]; ok {
+		return informer.cacheStatus()
+	}
+	// Namespaces without an informer have no SparkApplications to be stale about
+	return domain.CacheStatus{Synced: true}
 }
 
 func (c *SparkController) Run() {
 	logger := klog.FromContext(c.ctx)
 	logger.Info("Starting Spark controller")
 
+	var hasSynced []cache.InformerSynced
+	for _, informer := range c.informers {
+		hasSynced = append(hasSynced, informer.HasSynced)
+	}
+
 	logger.Info("Syncing Cache")
-	if ok := cache.WaitForNamedCacheSync("SparkInformer", c.ctx.Done(), c.SparkInformer.HasSynced); !ok {
+	if ok := cache.WaitForNamedCacheSync("SparkInformer", c.ctx.Done(), hasSynced...); !ok {
 		logger.Error(fmt.Errorf("failed to wait for caches to sync"), "")
 		return
 	}
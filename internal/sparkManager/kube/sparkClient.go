@@ -0,0 +1,133 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	sparkClientSet "github.com/kubeflow/spark-operator/v2/pkg/client/clientset/versioned"
+	"github.com/kubeflow/spark-operator/v2/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+)
+
+// schemeLock guards registering custom CRD kinds in the shared spark clientset scheme
+var schemeLock sync.Mutex
+
+// NewSparkClient returns a clientset for the SparkApplication CRD crd. The Spark Operator's CRD uses the generated
+// clientset unchanged. For other CRDs the generated typed client is reused over a REST client pointed at crd's group
+// and version, with crd's kind registered in the clientset scheme so objects encode and decode as
+// v1beta2.SparkApplications, and crd's resource name rewritten into request paths.
+func NewSparkClient(kubeConfig *rest.Config, crd domain.SparkApplicationCRD) (*sparkClientSet.Clientset, error) {
+	crd = crd.WithDefaults()
+	if crd == domain.DefaultSparkApplicationCRD {
+		return sparkClientSet.NewForConfig(kubeConfig)
+	}
+
+	gv := schema.GroupVersion{Group: crd.Group, Version: crd.Version}
+	registerSparkApplicationKind(gv.WithKind(crd.Kind))
+
+	crdConfig := rest.CopyConfig(kubeConfig)
+	crdConfig.GroupVersion = &gv
+	crdConfig.APIPath = "/apis"
+	crdConfig.NegotiatedSerializer = rest.CodecFactoryForGeneratedClient(scheme.Scheme, scheme.Codecs).WithoutConversion()
+	if crdConfig.UserAgent == "" {
+		crdConfig.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+
+	if crd.Resource != domain.DefaultSparkApplicationCRD.Resource {
+		prefix := fmt.Sprintf("/apis/%s/%s/", crd.Group, crd.Version)
+		crdConfig.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+			return &resourceRewriter{
+				rt:     rt,
+				prefix: prefix,
+				from:   domain.DefaultSparkApplicationCRD.Resource,
+				to:     crd.Resource,
+			}
+		})
+	}
+
+	restClient, err := rest.RESTClientFor(crdConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating REST client for SparkApplication CRD %s: %w", gv.WithKind(crd.Kind), err)
+	}
+
+	return sparkClientSet.New(restClient), nil
+}
+
+func registerSparkApplicationKind(gvk schema.GroupVersionKind) {
+	schemeLock.Lock()
+	defer schemeLock.Unlock()
+
+	if scheme.Scheme.Recognizes(gvk) {
+		return
+	}
+
+	scheme.Scheme.AddKnownTypeWithName(gvk, &v1beta2.SparkApplication{})
+	scheme.Scheme.AddKnownTypeWithName(gvk.GroupVersion().WithKind(gvk.Kind+"List"), &v1beta2.SparkApplicationList{})
+	// Registers ListOptions, WatchEvent etc. so request parameters and watch events convert for the group version
+	v1.AddToGroupVersion(scheme.Scheme, gvk.GroupVersion())
+}
+
+// resourceRewriter replaces the resource name the generated typed client puts in request paths under prefix.
+type resourceRewriter struct {
+	rt     http.RoundTripper
+	prefix string
+	from   string
+	to     string
+}
+
+func (r *resourceRewriter) RoundTrip(req *http.Request) (*http.Response, error) {
+	path, ok := r.rewritePath(req.URL.Path)
+	if !ok {
+		return r.rt.RoundTrip(req)
+	}
+
+	// RoundTrippers must not modify the request they are given
+	req = req.Clone(req.Context())
+	req.URL.Path = path
+	req.URL.RawPath = ""
+
+	return r.rt.RoundTrip(req)
+}
+
+// rewritePath rewrites '<prefix>[namespaces/<namespace>/]<from>[/...]' paths, returning false for other paths.
+func (r *resourceRewriter) rewritePath(path string) (string, bool) {
+	remainder, ok := strings.CutPrefix(path, r.prefix)
+	if !ok {
+		return path, false
+	}
+
+	parts := strings.Split(remainder, "/")
+	resourceIndex := 0
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		resourceIndex = 2
+	}
+
+	if parts[resourceIndex] != r.from {
+		return path, false
+	}
+	parts[resourceIndex] = r.to
+
+	return r.prefix + strings.Join(parts, "/"), true
+}
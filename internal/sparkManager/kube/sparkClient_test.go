@@ -0,0 +1,91 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+)
+
+func TestNewSparkClientCustomCRD(t *testing.T) {
+	var gotPaths []string
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPaths = append(gotPaths, r.URL.Path)
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &gotBody)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"apiVersion":"spark.example.com/v1","kind":"PatchedSparkApplication","metadata":{"name":"app","namespace":"ns"},"status":{"applicationState":{"state":"RUNNING"}}}`))
+	}))
+	defer server.Close()
+
+	crd := domain.SparkApplicationCRD{Group: "spark.example.com", Version: "v1", Kind: "PatchedSparkApplication", Resource: "patchedsparkapplications"}
+	client, err := NewSparkClient(&rest.Config{Host: server.URL}, crd)
+	assert.Nil(t, err, "err should be nil")
+
+	sparkApp, err := client.SparkoperatorV1beta2().SparkApplications("ns").Get(context.Background(), "app", v1.GetOptions{})
+	assert.Nil(t, err, "get should succeed")
+	assert.Equal(t, "app", sparkApp.Name, "name should be decoded")
+	assert.Equal(t, v1beta2.ApplicationStateRunning, sparkApp.Status.AppState.State, "status should be decoded")
+
+	_, err = client.SparkoperatorV1beta2().SparkApplications("ns").Create(context.Background(), &v1beta2.SparkApplication{ObjectMeta: v1.ObjectMeta{Name: "app", Namespace: "ns"}}, v1.CreateOptions{})
+	assert.Nil(t, err, "create should succeed")
+
+	assert.Equal(t, []string{
+		"/apis/spark.example.com/v1/namespaces/ns/patchedsparkapplications/app",
+		"/apis/spark.example.com/v1/namespaces/ns/patchedsparkapplications",
+	}, gotPaths, "requests should use the custom group, version and resource")
+	assert.Equal(t, "spark.example.com/v1", gotBody["apiVersion"], "created object should use the custom group version")
+	assert.Equal(t, "PatchedSparkApplication", gotBody["kind"], "created object should use the custom kind")
+}
+
+func TestResourceRewriterRewritePath(t *testing.T) {
+	rewriter := &resourceRewriter{prefix: "/apis/spark.example.com/v1/", from: "sparkapplications", to: "sparkjobs"}
+
+	tests := []struct {
+		path     string
+		expected string
+		ok       bool
+	}{
+		{path: "/apis/spark.example.com/v1/namespaces/ns/sparkapplications/app", expected: "/apis/spark.example.com/v1/namespaces/ns/sparkjobs/app", ok: true},
+		{path: "/apis/spark.example.com/v1/namespaces/ns/sparkapplications", expected: "/apis/spark.example.com/v1/namespaces/ns/sparkjobs", ok: true},
+		{path: "/apis/spark.example.com/v1/sparkapplications", expected: "/apis/spark.example.com/v1/sparkjobs", ok: true},
+		{path: "/apis/spark.example.com/v1/namespaces/sparkapplications", expected: "/apis/spark.example.com/v1/namespaces/sparkapplications", ok: false},
+		{path: "/api/v1/namespaces/ns/pods", expected: "/api/v1/namespaces/ns/pods", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			path, ok := rewriter.rewritePath(test.path)
+			assert.Equal(t, test.expected, path, "path should match")
+			assert.Equal(t, test.ok, ok, "rewritten should match")
+		})
+	}
+}
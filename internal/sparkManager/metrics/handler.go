@@ -41,7 +41,7 @@ type Handler struct {
 func NewHandler(service Service, serverConfig config.MetricsServer) *Handler {
 	reg := prometheus.NewRegistry()
 
-	reg.MustRegister(Definition.sparkApplicationCount, Definition.cpuAllocated)
+	reg.MustRegister(Definition.sparkApplicationCount, Definition.cpuAllocated, Definition.memoryAllocated, Definition.requestCount, Definition.requestLatency, Definition.reconcileDrift, Definition.quotaUtilization, Definition.runningExecutorPods, Definition.operatorUp, Definition.orphans, Definition.garbageCollected, Definition.permissionProblems)
 
 	http.Handle(serverConfig.Endpoint, promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg}))
 	metricsServer := http.Server{
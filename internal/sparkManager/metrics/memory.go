@@ -0,0 +1,231 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// defaultSparkMemory is the default value for spark.driver.memory and spark.executor.memory
+	defaultSparkMemory = float64(1 << 30)
+	// minMemoryOverhead is the smallest memory overhead Spark on Kubernetes adds to a pod
+	minMemoryOverhead = float64(384 << 20)
+	// defaultMemoryOverheadFactor and nonJVMMemoryOverheadFactor are the default spark.kubernetes.memoryOverheadFactor
+	// for JVM and non-JVM (Python and R) applications
+	defaultMemoryOverheadFactor = 0.1
+	nonJVMMemoryOverheadFactor  = 0.4
+)
+
+var sparkMemoryRegex = regexp.MustCompile(`^([0-9]+)([a-z]*)$`)
+
+var sparkMemoryUnits = map[string]float64{
+	"b":  1,
+	"k":  1 << 10,
+	"kb": 1 << 10,
+	"m":  1 << 20,
+	"mb": 1 << 20,
+	"g":  1 << 30,
+	"gb": 1 << 30,
+	"t":  1 << 40,
+	"tb": 1 << 40,
+	"p":  1 << 50,
+	"pb": 1 << 50,
+}
+
+/*
+GetTotalMemoryAllocation returns the combined memory allocation, in bytes, for all SparkApplications in the
+sparkApplicationList arg.
+*/
+func (r *Repository) GetTotalMemoryAllocation(sparkApplicationList []*v1beta2.SparkApplication) float64 {
+	memoryAllocation := 0.0
+	for _, sparkApp := range sparkApplicationList {
+		if r.allocationFromExecutorState {
+			memoryAllocation += GetSparkAppActiveMemoryAllocation(sparkApp, defaultMaxExecutorCount)
+		} else {
+			memoryAllocation += GetSparkAppMemoryAllocation(sparkApp, defaultMaxExecutorCount)
+		}
+	}
+	return memoryAllocation
+}
+
+/*
+GetSparkAppMemoryAllocation returns the max memory, in bytes, allocated to the driver and executors combined based on
+the SparkApplication spec. The executor count follows GetSparkAppCpuAllocation.
+
+Each pod is allocated its memory plus memory overhead, as Spark on Kubernetes requests for the pod:
+- Memory config precedence, high to low, defaulting to 1g:
+  - .Spec.Driver.Memory / .Spec.Executor.Memory
+  - --conf spark.driver.memory / spark.executor.memory
+
+- Memory overhead config precedence, high to low:
+  - .Spec.Driver.MemoryOverhead / .Spec.Executor.MemoryOverhead
+  - --conf spark.driver.memoryOverhead / spark.executor.memoryOverhead
+  - memory * GetMemoryOverheadFactor, at least 384MiB
+*/
+func GetSparkAppMemoryAllocation(sparkApp *v1beta2.SparkApplication, defaultMaxExecutorCount float64) float64 {
+	return GetDriverMemory(sparkApp) + (GetExecutorMemory(sparkApp) * GetExecutorCount(sparkApp, defaultMaxExecutorCount))
+}
+
+/*
+GetSparkAppActiveMemoryAllocation returns the memory, in bytes, allocated to the driver and the executors that are
+pending or running according to status.executorState, falling back to GetSparkAppMemoryAllocation like
+GetSparkAppActiveCpuAllocation.
+*/
+func GetSparkAppActiveMemoryAllocation(sparkApp *v1beta2.SparkApplication, defaultMaxExecutorCount float64) float64 {
+	activeExecutors, ok := GetActiveExecutorCount(sparkApp)
+	if !ok {
+		return GetSparkAppMemoryAllocation(sparkApp, defaultMaxExecutorCount)
+	}
+
+	return GetDriverMemory(sparkApp) + (GetExecutorMemory(sparkApp) * activeExecutors)
+}
+
+/*
+GetDriverMemory returns the memory, in bytes, requested for the driver pod including memory overhead.
+*/
+func GetDriverMemory(sparkApp *v1beta2.SparkApplication) float64 {
+	return getPodMemory(sparkApp, sparkApp.Spec.Driver.SparkPodSpec, "driver")
+}
+
+/*
+GetExecutorMemory returns the memory, in bytes, requested for each executor pod including memory overhead.
+*/
+func GetExecutorMemory(sparkApp *v1beta2.SparkApplication) float64 {
+	return getPodMemory(sparkApp, sparkApp.Spec.Executor.SparkPodSpec, "executor")
+}
+
+func getPodMemory(sparkApp *v1beta2.SparkApplication, podSpec v1beta2.SparkPodSpec, role string) float64 {
+	memory := ParseMemoryValue(podSpec.Memory, sparkApp.Spec.SparkConf, fmt.Sprintf("spark.%s.memory", role))
+	if memory == 0 {
+		memory = defaultSparkMemory
+	}
+
+	overhead := ParseMemoryValue(podSpec.MemoryOverhead, sparkApp.Spec.SparkConf, fmt.Sprintf("spark.%s.memoryOverhead", role))
+	if overhead == 0 {
+		overhead = max(memory*GetMemoryOverheadFactor(sparkApp, role), minMemoryOverhead)
+	}
+
+	return memory + overhead
+}
+
+/*
+GetMemoryOverheadFactor returns the fraction of a pod's memory added as memory overhead for role, driver or executor.
+
+Memory overhead factor config precedence, high to low:
+  - --conf spark.driver.memoryOverheadFactor / spark.executor.memoryOverheadFactor
+  - .Spec.MemoryOverheadFactor (spark.kubernetes.memoryOverheadFactor)
+  - --conf spark.kubernetes.memoryOverheadFactor
+  - 0.4 for Python and R applications, 0.1 otherwise
+*/
+func GetMemoryOverheadFactor(sparkApp *v1beta2.SparkApplication, role string) float64 {
+	factorStr, ok := sparkApp.Spec.SparkConf[fmt.Sprintf("spark.%s.memoryOverheadFactor", role)]
+	if !ok && sparkApp.Spec.MemoryOverheadFactor != nil {
+		factorStr, ok = *sparkApp.Spec.MemoryOverheadFactor, true
+	}
+	if !ok {
+		factorStr, ok = sparkApp.Spec.SparkConf["spark.kubernetes.memoryOverheadFactor"]
+	}
+
+	if ok {
+		factor, err := strconv.ParseFloat(factorStr, 64)
+		if err == nil {
+			return factor
+		}
+		klog.Error(err)
+	}
+
+	if sparkApp.Spec.Type == v1beta2.SparkApplicationTypePython || sparkApp.Spec.Type == v1beta2.SparkApplicationTypeR {
+		return nonJVMMemoryOverheadFactor
+	}
+	return defaultMemoryOverheadFactor
+}
+
+/*
+ParseMemoryValue returns the memory value in bytes. SparkApplication spec value (specValue arg), if set, will take
+precedence over the SparkConf value (sparkConf[sparkConfKey]), if set. Both are in Spark's memory format, see
+ParseSparkMemory. ParseMemoryValue will return float64(0) if spec value and sparkConf values are not set or if there is
+a parsing error.
+*/
+func ParseMemoryValue(specValue *string, sparkConf map[string]string, sparkConfKey string) float64 {
+	memory := float64(0)
+	var err error = nil
+	if specValue != nil {
+		memory, err = ParseSparkMemory(*specValue)
+		if err != nil {
+			klog.Error(err)
+		}
+	} else if val, ok := sparkConf[sparkConfKey]; ok {
+		memory, err = ParseSparkMemory(val)
+		if err != nil {
+			klog.Error(err)
+		}
+	}
+	return memory
+}
+
+/*
+ParseSparkMemory takes a memory value in Spark's format, e.g. 4g, 512m or 1024kb, and returns it in bytes. Units are
+case insensitive and a value without a unit is in MiB, as for spark.driver.memory and spark.executor.memory.
+*/
+func ParseSparkMemory(memStr string) (float64, error) {
+	if memStr == "" {
+		return 0, nil
+	}
+
+	match := sparkMemoryRegex.FindStringSubmatch(strings.ToLower(strings.TrimSpace(memStr)))
+	if match == nil {
+		return 0, fmt.Errorf("invalid Spark memory value '%s'", memStr)
+	}
+
+	unit := match[2]
+	if unit == "" {
+		unit = "m"
+	}
+	multiplier, ok := sparkMemoryUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid Spark memory unit '%s' in '%s'", match[2], memStr)
+	}
+
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, err
+	}
+	return value * multiplier, nil
+}
+
+/*
+ParseKubeMemory takes a memory value in Kubernetes quantity units, e.g. 512Mi, 4Gi or 1G, and returns it in bytes.
+Kubernetes memory resource units: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/#meaning-of-memory
+*/
+func ParseKubeMemory(memStr string) (float64, error) {
+	if memStr == "" {
+		return 0, nil
+	}
+
+	quantity, err := resource.ParseQuantity(memStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Kubernetes memory value '%s': %w", memStr, err)
+	}
+	return quantity.AsApproximateFloat64(), nil
+}
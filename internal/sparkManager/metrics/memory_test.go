@@ -0,0 +1,151 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSparkMemory(t *testing.T) {
+	tests := []struct {
+		name        string
+		memStr      string
+		expected    float64
+		expectError bool
+	}{
+		{name: "Empty string returns zero", memStr: "", expected: 0},
+		{name: "Gibibytes", memStr: "4g", expected: 4 << 30},
+		{name: "Mebibytes", memStr: "512m", expected: 512 << 20},
+		{name: "Two letter unit", memStr: "1024kb", expected: 1 << 20},
+		{name: "Upper case unit", memStr: "2G", expected: 2 << 30},
+		{name: "No unit is MiB", memStr: "1024", expected: 1 << 30},
+		{name: "Fraction is invalid", memStr: "1.5g", expectError: true},
+		{name: "Unknown unit is invalid", memStr: "4x", expectError: true},
+		{name: "Kubernetes unit is invalid", memStr: "4Gi", expectError: true},
+	}
+	for _, test := range tests {
+		got, err := ParseSparkMemory(test.memStr)
+		if test.expectError {
+			assert.Error(t, err, "%s: parsing should error", test.name)
+			continue
+		}
+		assert.NoError(t, err, "%s: parsing should not error", test.name)
+		assert.Equal(t, test.expected, got, "%s: bytes should match", test.name)
+	}
+}
+
+func TestParseKubeMemory(t *testing.T) {
+	tests := []struct {
+		name        string
+		memStr      string
+		expected    float64
+		expectError bool
+	}{
+		{name: "Empty string returns zero", memStr: "", expected: 0},
+		{name: "Mebibytes", memStr: "512Mi", expected: 512 << 20},
+		{name: "Gibibytes", memStr: "4Gi", expected: 4 << 30},
+		{name: "Decimal gigabytes", memStr: "1G", expected: 1e9},
+		{name: "Plain bytes", memStr: "1024", expected: 1024},
+		{name: "Spark unit is invalid", memStr: "4g", expectError: true},
+	}
+	for _, test := range tests {
+		got, err := ParseKubeMemory(test.memStr)
+		if test.expectError {
+			assert.Error(t, err, "%s: parsing should error", test.name)
+			continue
+		}
+		assert.NoError(t, err, "%s: parsing should not error", test.name)
+		assert.Equal(t, test.expected, got, "%s: bytes should match", test.name)
+	}
+}
+
+func TestGetSparkAppMemoryAllocation(t *testing.T) {
+	const mib = float64(1 << 20)
+
+	tests := []struct {
+		name     string
+		spec     v1beta2.SparkApplicationSpec
+		expected float64
+	}{
+		{
+			name: "defaults to 1g with the minimum overhead",
+			spec: v1beta2.SparkApplicationSpec{
+				Executor: v1beta2.ExecutorSpec{Instances: int32Ptr(2)},
+			},
+			expected: 3 * (1024 + 384) * mib,
+		},
+		{
+			name: "overhead factor of the memory",
+			spec: v1beta2.SparkApplicationSpec{
+				Driver:   v1beta2.DriverSpec{SparkPodSpec: v1beta2.SparkPodSpec{Memory: strPtr("8g")}},
+				Executor: v1beta2.ExecutorSpec{Instances: int32Ptr(1), SparkPodSpec: v1beta2.SparkPodSpec{Memory: strPtr("4g")}},
+			},
+			expected: 8192*1.1*mib + 4096*1.1*mib,
+		},
+		{
+			name: "explicit overhead and sparkConf memory",
+			spec: v1beta2.SparkApplicationSpec{
+				SparkConf: map[string]string{"spark.driver.memory": "2048", "spark.executor.memory": "2g"},
+				Driver:    v1beta2.DriverSpec{SparkPodSpec: v1beta2.SparkPodSpec{MemoryOverhead: strPtr("512m")}},
+				Executor:  v1beta2.ExecutorSpec{Instances: int32Ptr(2), SparkPodSpec: v1beta2.SparkPodSpec{MemoryOverhead: strPtr("1g")}},
+			},
+			expected: (2048+512)*mib + 2*(2048+1024)*mib,
+		},
+		{
+			name: "non-JVM overhead factor",
+			spec: v1beta2.SparkApplicationSpec{
+				Type:     v1beta2.SparkApplicationTypePython,
+				Driver:   v1beta2.DriverSpec{SparkPodSpec: v1beta2.SparkPodSpec{Memory: strPtr("10g")}},
+				Executor: v1beta2.ExecutorSpec{Instances: int32Ptr(0)},
+			},
+			expected: 10240 * 1.4 * mib,
+		},
+		{
+			name: "role overhead factor takes precedence",
+			spec: v1beta2.SparkApplicationSpec{
+				MemoryOverheadFactor: strPtr("0.2"),
+				SparkConf:            map[string]string{"spark.executor.memoryOverheadFactor": "0.5"},
+				Driver:               v1beta2.DriverSpec{SparkPodSpec: v1beta2.SparkPodSpec{Memory: strPtr("10g")}},
+				Executor:             v1beta2.ExecutorSpec{Instances: int32Ptr(1), SparkPodSpec: v1beta2.SparkPodSpec{Memory: strPtr("10g")}},
+			},
+			expected: 10240*1.2*mib + 10240*1.5*mib,
+		},
+	}
+	for _, test := range tests {
+		sparkApp := &v1beta2.SparkApplication{Spec: test.spec}
+		assert.InDelta(t, test.expected, GetSparkAppMemoryAllocation(sparkApp, 1000), 1, "%s: memory allocation should match", test.name)
+	}
+}
+
+func TestGetSparkAppActiveMemoryAllocation(t *testing.T) {
+	sparkApp := &v1beta2.SparkApplication{
+		Spec: v1beta2.SparkApplicationSpec{
+			DynamicAllocation: &v1beta2.DynamicAllocation{Enabled: true, MaxExecutors: int32Ptr(10)},
+			Executor:          v1beta2.ExecutorSpec{SparkPodSpec: v1beta2.SparkPodSpec{MemoryOverhead: strPtr("1g")}},
+			Driver:            v1beta2.DriverSpec{SparkPodSpec: v1beta2.SparkPodSpec{MemoryOverhead: strPtr("1g")}},
+		},
+		Status: v1beta2.SparkApplicationStatus{ExecutorState: map[string]v1beta2.ExecutorState{
+			"exec-1": v1beta2.ExecutorStateRunning,
+			"exec-2": v1beta2.ExecutorStateCompleted,
+		}},
+	}
+
+	assert.Equal(t, float64(2*(2<<30)), GetSparkAppActiveMemoryAllocation(sparkApp, 1000), "only active executors should be counted")
+	assert.Equal(t, float64(11*(2<<30)), GetSparkAppMemoryAllocation(sparkApp, 1000), "maxExecutors should be counted")
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// routeVerbs maps SparkManager API routes, relative to the versioned group, to the verb used in request metrics
+var routeVerbs = map[string]string{
+	http.MethodGet + " /:namespace":                     "list",
+	http.MethodGet + " /:namespace/counts":              "counts",
+	http.MethodGet + " /:namespace/watch":               "watch",
+	http.MethodPost + " /:namespace/:name":              "create",
+	http.MethodGet + " /:namespace/:name":               "get",
+	http.MethodGet + " /:namespace/:name/status":        "status",
+	http.MethodGet + " /:namespace/:name/status/stream": "streamStatus",
+	http.MethodGet + " /:namespace/:name/logs":          "logs",
+	http.MethodGet + " /:namespace/:name/logs/download": "downloadLogs",
+	http.MethodGet + " /:namespace/:name/pods":          "pods",
+	http.MethodGet + " /:namespace/:name/cost":          "cost",
+	http.MethodPatch + " /:namespace/:name":             "update",
+	http.MethodDelete + " /:namespace/:name":            "delete",
+}
+
+// RequestVerb returns the verb for a request method and its matched route relative to groupPath. Routes that are not
+// known are reported as "other" so unmatched paths cannot grow label cardinality.
+func RequestVerb(method string, groupPath string, fullPath string) string {
+	if verb, ok := routeVerbs[method+" "+strings.TrimPrefix(fullPath, groupPath)]; ok {
+		return verb
+	}
+	return "other"
+}
+
+// RequestMetrics returns a middleware recording request counts and latencies labeled by verb and namespace. It should
+// be added to the router group with path groupPath that the application routes are registered on.
+func RequestMetrics(cluster string, groupPath string, metrics Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		// Skip requests which didn't match a route, such as 404s
+		if c.FullPath() == "" {
+			return
+		}
+
+		verb := RequestVerb(c.Request.Method, groupPath, c.FullPath())
+		namespace := c.Param("namespace")
+
+		metrics.requestCount.With(prometheus.Labels{
+			"cluster":   cluster,
+			"namespace": namespace,
+			"verb":      verb,
+			"code":      strconv.Itoa(c.Writer.Status()),
+		}).Inc()
+		metrics.requestLatency.With(prometheus.Labels{
+			"cluster":   cluster,
+			"namespace": namespace,
+			"verb":      verb,
+		}).Observe(time.Since(start).Seconds())
+	}
+}
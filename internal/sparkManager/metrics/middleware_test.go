@@ -0,0 +1,75 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestVerb(t *testing.T) {
+	tests := []struct {
+		method   string
+		fullPath string
+		expected string
+	}{
+		{http.MethodGet, "/api/v1/:namespace", "list"},
+		{http.MethodPost, "/api/v1/:namespace/:name", "create"},
+		{http.MethodGet, "/api/v1/:namespace/:name", "get"},
+		{http.MethodDelete, "/api/v1/:namespace/:name", "delete"},
+		{http.MethodGet, "/api/v1/:namespace/:name/logs/download", "downloadLogs"},
+		{http.MethodPut, "/api/v1/:namespace/:name", "other"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, RequestVerb(test.method, "/api/v1", test.fullPath), "verbs should match for %s %s", test.method, test.fullPath)
+	}
+}
+
+func TestRequestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testMetrics := Metrics{
+		requestCount:   prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_requests_total"}, []string{"cluster", "namespace", "verb", "code"}),
+		requestLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "test_request_duration_seconds"}, []string{"cluster", "namespace", "verb"}),
+	}
+
+	router := gin.New()
+	v1Group := router.Group("/api/v1")
+	v1Group.Use(RequestMetrics("cluster", v1Group.BasePath(), testMetrics))
+	v1Group.GET("/:namespace", func(c *gin.Context) { c.Status(http.StatusOK) })
+	v1Group.POST("/:namespace/:name", func(c *gin.Context) { c.Status(http.StatusConflict) })
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/api/v1/ns", nil),
+		httptest.NewRequest(http.MethodGet, "/api/v1/ns", nil),
+		httptest.NewRequest(http.MethodPost, "/api/v1/ns/app", nil),
+		httptest.NewRequest(http.MethodGet, "/api/v1/ns/app/missing/route", nil),
+	} {
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(testMetrics.requestCount.WithLabelValues("cluster", "ns", "list", "200")), "list requests should be counted")
+	assert.Equal(t, float64(1), testutil.ToFloat64(testMetrics.requestCount.WithLabelValues("cluster", "ns", "create", "409")), "create requests should be counted")
+	assert.Equal(t, 2, testutil.CollectAndCount(testMetrics.requestCount), "unmatched routes should not be counted")
+	assert.Equal(t, 2, testutil.CollectAndCount(testMetrics.requestLatency), "latency should be observed per verb")
+}
@@ -22,8 +22,21 @@ import (
 type Metrics struct {
 	sparkApplicationCount *prometheus.GaugeVec
 	cpuAllocated          *prometheus.GaugeVec
+	memoryAllocated       *prometheus.GaugeVec
+	requestCount          *prometheus.CounterVec
+	requestLatency        *prometheus.HistogramVec
+	reconcileDrift        *prometheus.CounterVec
+	quotaUtilization      *prometheus.GaugeVec
+	runningExecutorPods   *prometheus.GaugeVec
+	operatorUp            *prometheus.GaugeVec
+	orphans               *prometheus.CounterVec
+	garbageCollected      *prometheus.CounterVec
+	permissionProblems    *prometheus.GaugeVec
 }
 
+// RunningExecutorPodsMetric is the gauge of running executor pods, which the cluster router can weigh clusters by
+const RunningExecutorPodsMetric = "running_executor_pods"
+
 var Definition = Metrics{
 	sparkApplicationCount: prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -39,4 +52,108 @@ var Definition = Metrics{
 		},
 		[]string{"cluster", "namespace"},
 	),
+	memoryAllocated: prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "memory_allocated_bytes",
+			Help: "Bytes of memory allocated, including memory overhead",
+		},
+		[]string{"cluster", "namespace"},
+	),
+	requestCount: prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sparkmanager_requests_total",
+			Help: "Number of SparkManager API requests",
+		},
+		[]string{"cluster", "namespace", "verb", "code"},
+	),
+	requestLatency: prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "sparkmanager_request_duration_seconds",
+			Help:    "Latency of SparkManager API requests",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"cluster", "namespace", "verb"},
+	),
+	reconcileDrift: prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sparkmanager_reconcile_drift_total",
+			Help: "Number of database records found out of sync with the cluster by the reconciler",
+		},
+		[]string{"cluster", "kind"},
+	),
+	quotaUtilization: prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "namespace_quota_utilization",
+			Help: "Highest used/hard ratio across the namespace's ResourceQuotas",
+		},
+		[]string{"cluster", "namespace"},
+	),
+	runningExecutorPods: prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: RunningExecutorPodsMetric,
+			Help: "Number of running Spark executor pods",
+		},
+		[]string{"cluster", "namespace"},
+	),
+	operatorUp: prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "spark_operator_up",
+			Help: "Whether every Spark Operator deployment has an available replica (1) or not (0)",
+		},
+		[]string{"cluster"},
+	),
+	orphans: prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sparkmanager_orphans_total",
+			Help: "Number of resources left behind by deleted SparkApplications found by the orphan sweeper",
+		},
+		[]string{"cluster", "kind", "result"},
+	),
+	garbageCollected: prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sparkmanager_garbage_collected_total",
+			Help: "Number of terminal SparkApplications deleted by the garbage collector once past their retention",
+		},
+		[]string{"cluster", "namespace", "result"},
+	),
+	permissionProblems: prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "sparkmanager_permission_problems",
+			Help: "Permissions the startup permission check found SparkManager missing, or having without needing them (1)",
+		},
+		[]string{"cluster", "namespace", "group", "resource", "verb", "problem"},
+	),
+}
+
+// RecordReconcileDrift counts a database record the reconciler found out of sync with cluster. kind describes the
+// drift, e.g. lost or terminal_state.
+func (m Metrics) RecordReconcileDrift(cluster string, kind string) {
+	m.reconcileDrift.WithLabelValues(cluster, kind).Inc()
+}
+
+// RecordOrphan counts a resource of kind, e.g. pod, whose SparkApplication no longer exists. result is deleted or
+// failure.
+func (m Metrics) RecordOrphan(cluster string, kind string, result string) {
+	m.orphans.WithLabelValues(cluster, kind, result).Inc()
+}
+
+// RecordGarbageCollected counts a terminal SparkApplication of namespace the garbage collector deleted past its
+// retention. result is deleted or failure.
+func (m Metrics) RecordGarbageCollected(cluster string, namespace string, result string) {
+	m.garbageCollected.WithLabelValues(cluster, namespace, result).Inc()
+}
+
+// SetOperatorUp records whether the Spark Operator in cluster is running
+func (m Metrics) SetOperatorUp(cluster string, up bool) {
+	value := 0.0
+	if up {
+		value = 1
+	}
+	m.operatorUp.WithLabelValues(cluster).Set(value)
+}
+
+// SetPermissionProblem records a permission SparkManager is missing, or has without needing it. problem is missing or
+// excessive, and namespace is empty for permissions in all namespaces.
+func (m Metrics) SetPermissionProblem(cluster string, namespace string, group string, resource string, verb string, problem string) {
+	m.permissionProblems.WithLabelValues(cluster, namespace, group, resource, verb, problem).Set(1)
 }
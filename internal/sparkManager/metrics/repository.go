@@ -20,28 +20,110 @@ import (
 	"strings"
 
 	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	corev1Lister "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
-
-	"github.com/slackhq/spark-gateway/internal/sparkManager/kube"
 )
 
+// SparkApplicationLister lists the SparkApplications in a namespace, or in all namespaces when namespace is empty.
+// It is satisfied by the cluster's SparkManager backend.
+type SparkApplicationLister interface {
+	List(namespace string) ([]*v1beta2.SparkApplication, error)
+}
+
 type Repository struct {
-	controller *kube.SparkController
+	lister                      SparkApplicationLister
+	quotaLister                 corev1Lister.ResourceQuotaLister
+	executorPodLister           corev1Lister.PodLister
+	allocationFromExecutorState bool
 }
 
-func NewRepository(controller *kube.SparkController) *Repository {
+// NewRepository creates a metrics Repository. quotaLister may be nil, in which case namespace quota utilization isn't
+// reported, and executorPodLister may be nil, in which case running executor pods aren't reported. When
+// allocationFromExecutorState is set, the CPU and memory allocation of dynamic allocation SparkApplications counts
+// their active executors instead of maxExecutors.
+func NewRepository(lister SparkApplicationLister, quotaLister corev1Lister.ResourceQuotaLister, executorPodLister corev1Lister.PodLister, allocationFromExecutorState bool) *Repository {
 	return &Repository{
-		controller: controller,
+		lister:                      lister,
+		quotaLister:                 quotaLister,
+		executorPodLister:           executorPodLister,
+		allocationFromExecutorState: allocationFromExecutorState,
 	}
 }
 
+/*
+GetRunningExecutorPods returns the number of running executor pods in the namespace, or in all namespaces when
+namespace is empty. Unlike the CPU allocation derived from specs, this follows dynamic allocation scaling executors
+down. The bool return is false when executor pods aren't watched or can't be listed.
+*/
+func (r *Repository) GetRunningExecutorPods(namespace string) (int, bool) {
+	if r.executorPodLister == nil {
+		return 0, false
+	}
+
+	var pods []*corev1.Pod
+	var err error
+	if namespace == metav1.NamespaceAll {
+		pods, err = r.executorPodLister.List(labels.Everything())
+	} else {
+		pods, err = r.executorPodLister.Pods(namespace).List(labels.Everything())
+	}
+	if err != nil {
+		klog.Error(err)
+		return 0, false
+	}
+
+	running := 0
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodRunning {
+			running++
+		}
+	}
+	return running, true
+}
+
+/*
+GetNamespaceQuotaUtilization returns the highest used/hard ratio across all resources of all ResourceQuotas in the
+namespace, so 1.0 means at least one resource is fully consumed. A resource with a hard limit of 0 counts as fully
+consumed. Namespaces without ResourceQuotas have a utilization of 0. The bool return is false when ResourceQuotas
+aren't watched or can't be listed.
+*/
+func (r *Repository) GetNamespaceQuotaUtilization(namespace string) (float64, bool) {
+	if r.quotaLister == nil {
+		return 0, false
+	}
+
+	quotas, err := r.quotaLister.ResourceQuotas(namespace).List(labels.Everything())
+	if err != nil {
+		klog.Error(err)
+		return 0, false
+	}
+
+	utilization := 0.0
+	for _, quota := range quotas {
+		for resourceName, hard := range quota.Status.Hard {
+			used, ok := quota.Status.Used[resourceName]
+			if !ok {
+				continue
+			}
+
+			ratio := 1.0
+			if !hard.IsZero() {
+				ratio = used.AsApproximateFloat64() / hard.AsApproximateFloat64()
+			}
+			utilization = max(utilization, ratio)
+		}
+	}
+	return utilization, true
+}
+
 /*
 GetFilteredSparkApplicationsByCluster returns a filtered list of SparkApplication that exist in the cluster.
 */
 func (r *Repository) GetFilteredSparkApplicationsByCluster() []*v1beta2.SparkApplication {
-	allLabels := labels.SelectorFromSet(map[string]string{})
-	sparkApplicationList, err := r.controller.SparkLister.List(allLabels)
+	sparkApplicationList, err := r.lister.List(metav1.NamespaceAll)
 	if err != nil {
 		klog.Error(err)
 	}
@@ -53,8 +135,7 @@ GetFilteredSparkApplicationsByNamespace returns a filtered list of SparkApplicat
 in the cluster.
 */
 func (r *Repository) GetFilteredSparkApplicationsByNamespace(namespace string) []*v1beta2.SparkApplication {
-	allLabels := labels.SelectorFromSet(map[string]string{})
-	sparkApplicationList, err := r.controller.SparkLister.SparkApplications(namespace).List(allLabels)
+	sparkApplicationList, err := r.lister.List(namespace)
 	if err != nil {
 		klog.Error(err)
 	}
@@ -92,14 +173,21 @@ func (r *Repository) FilterSparkApplicationList(sparkApplicationList []*v1beta2.
 	return filteredSparkApplicationList
 }
 
+// defaultMaxExecutorCount is the executor count assumed for SparkApplications with dynamicAllocation and no
+// maxExecutors, which Spark treats as unbounded
+const defaultMaxExecutorCount = float64(1000)
+
 /*
 GetTotalCPUAllocation returns the combined CPU allocation for all SparkApplications in the sparkApplicationList arg.
 */
 func (r *Repository) GetTotalCPUAllocation(sparkApplicationList []*v1beta2.SparkApplication) float64 {
 	cpuAllocation := 0.0
-	defaultMaxExecutorCount := float64(1000)
 	for _, sparkApp := range sparkApplicationList {
-		cpuAllocation += GetSparkAppCpuAllocation(sparkApp, defaultMaxExecutorCount)
+		if r.allocationFromExecutorState {
+			cpuAllocation += GetSparkAppActiveCpuAllocation(sparkApp, defaultMaxExecutorCount)
+		} else {
+			cpuAllocation += GetSparkAppCpuAllocation(sparkApp, defaultMaxExecutorCount)
+		}
 	}
 	return cpuAllocation
 }
@@ -139,48 +227,110 @@ Assumptions:
   - if not dynamicAllocationEnabled then --conf spark.executor.instances
 */
 func GetSparkAppCpuAllocation(sparkApp *v1beta2.SparkApplication, defaultMaxExecutorCount float64) float64 {
-	defaultCpuCores := 1.0 // default value for spark.driver.cores and spark.executor.cores
-
 	// Driver
-	driverCores := defaultCpuCores
+	driverCores := GetDriverCores(sparkApp)
+
+	// Executor
+	executorCores := GetExecutorCores(sparkApp)
+
+	// Count total executor CPU allocation
+	return driverCores + (executorCores * GetExecutorCount(sparkApp, defaultMaxExecutorCount))
+}
+
+/*
+GetExecutorCount returns the max number of executors of the SparkApplication, following the executor count config
+precedence of GetSparkAppCpuAllocation. defaultMaxExecutorCount is used when dynamicAllocation has no maxExecutors.
+*/
+func GetExecutorCount(sparkApp *v1beta2.SparkApplication, defaultMaxExecutorCount float64) float64 {
+	if !IsDynamicAllocationEnabled(sparkApp.Spec.DynamicAllocation, sparkApp.Spec.SparkConf) {
+		return ParseExecutorCount(sparkApp.Spec.Executor.Instances, sparkApp.Spec.SparkConf)
+	}
+
+	dynamicAllocExecutorCount := ParseDynamicAllocExecutorCount(sparkApp.Spec.DynamicAllocation, sparkApp.Spec.SparkConf)
+	if dynamicAllocExecutorCount != 0 {
+		return dynamicAllocExecutorCount
+	}
+	return defaultMaxExecutorCount
+}
+
+/*
+GetActiveExecutorCount returns the number of executors status.executorState reports as pending or running, for
+SparkApplications with dynamicAllocation enabled. The bool return is false for SparkApplications without
+dynamicAllocation or whose status doesn't report any executors yet.
+*/
+func GetActiveExecutorCount(sparkApp *v1beta2.SparkApplication) (float64, bool) {
+	if !IsDynamicAllocationEnabled(sparkApp.Spec.DynamicAllocation, sparkApp.Spec.SparkConf) || len(sparkApp.Status.ExecutorState) == 0 {
+		return 0, false
+	}
+
+	activeExecutors := 0
+	for _, state := range sparkApp.Status.ExecutorState {
+		if state == v1beta2.ExecutorStatePending || state == v1beta2.ExecutorStateRunning {
+			activeExecutors++
+		}
+	}
+	return float64(activeExecutors), true
+}
+
+/*
+GetSparkAppActiveCpuAllocation returns the CPU allocated to the driver and the executors that are pending or running
+according to status.executorState, for SparkApplications with dynamicAllocation enabled. Dynamic allocation scales
+executors down when idle, so long-running applications rarely hold maxExecutors.
+
+SparkApplications without dynamicAllocation, or whose status doesn't report any executors yet, fall back to
+GetSparkAppCpuAllocation.
+*/
+func GetSparkAppActiveCpuAllocation(sparkApp *v1beta2.SparkApplication, defaultMaxExecutorCount float64) float64 {
+	activeExecutors, ok := GetActiveExecutorCount(sparkApp)
+	if !ok {
+		return GetSparkAppCpuAllocation(sparkApp, defaultMaxExecutorCount)
+	}
+
+	return GetDriverCores(sparkApp) + (GetExecutorCores(sparkApp) * activeExecutors)
+}
+
+/*
+GetSparkAppResourceRequests returns the CPU cores and memory, in bytes, requested by the driver and executors of the
+SparkApplication combined, following GetSparkAppCpuAllocation and GetSparkAppMemoryAllocation.
+*/
+func GetSparkAppResourceRequests(sparkApp *v1beta2.SparkApplication) (float64, float64) {
+	return GetSparkAppCpuAllocation(sparkApp, defaultMaxExecutorCount), GetSparkAppMemoryAllocation(sparkApp, defaultMaxExecutorCount)
+}
+
+/*
+GetDriverCores returns the CPU cores requested by the driver of the SparkApplication, following the driver CPU config
+precedence of GetSparkAppCpuAllocation and defaulting to 1 core.
+*/
+func GetDriverCores(sparkApp *v1beta2.SparkApplication) float64 {
 	k8sDriverCores := ParseK8sCoresValue(sparkApp.Spec.Driver.CoreRequest, sparkApp.Spec.SparkConf, "spark.kubernetes.driver.request.cores")
 	if k8sDriverCores != 0 {
-		driverCores = k8sDriverCores
-	} else {
-		sparkDriverCores := ParseCoresValue(sparkApp.Spec.Driver.Cores, sparkApp.Spec.SparkConf, "spark.driver.cores")
-		if sparkDriverCores != 0 {
-			driverCores = sparkDriverCores
-		}
+		return k8sDriverCores
 	}
 
-	// Executor
-	executorCores := defaultCpuCores
+	sparkDriverCores := ParseCoresValue(sparkApp.Spec.Driver.Cores, sparkApp.Spec.SparkConf, "spark.driver.cores")
+	if sparkDriverCores != 0 {
+		return sparkDriverCores
+	}
+
+	return 1.0 // default value for spark.driver.cores
+}
+
+/*
+GetExecutorCores returns the CPU cores requested by each executor of the SparkApplication, following the executor CPU
+config precedence of GetSparkAppCpuAllocation and defaulting to 1 core.
+*/
+func GetExecutorCores(sparkApp *v1beta2.SparkApplication) float64 {
 	k8sExecCores := ParseK8sCoresValue(sparkApp.Spec.Executor.CoreRequest, sparkApp.Spec.SparkConf, "spark.kubernetes.executor.request.cores")
 	if k8sExecCores != 0 {
-		executorCores = k8sExecCores
-	} else {
-		sparkExecCores := ParseCoresValue(sparkApp.Spec.Executor.Cores, sparkApp.Spec.SparkConf, "spark.executor.cores")
-		if sparkExecCores != 0 {
-			executorCores = sparkExecCores
-		}
+		return k8sExecCores
 	}
 
-	// Check if DynamicAllocation enabled
-	dynamicAllocationEnabled := IsDynamicAllocationEnabled(sparkApp.Spec.DynamicAllocation, sparkApp.Spec.SparkConf)
-
-	// Executor Count
-	executorCount := defaultMaxExecutorCount
-	if dynamicAllocationEnabled {
-		dynamicAllocExecutorCount := ParseDynamicAllocExecutorCount(sparkApp.Spec.DynamicAllocation, sparkApp.Spec.SparkConf)
-		if dynamicAllocExecutorCount != 0 {
-			executorCount = dynamicAllocExecutorCount
-		}
-	} else {
-		executorCount = ParseExecutorCount(sparkApp.Spec.Executor.Instances, sparkApp.Spec.SparkConf)
+	sparkExecCores := ParseCoresValue(sparkApp.Spec.Executor.Cores, sparkApp.Spec.SparkConf, "spark.executor.cores")
+	if sparkExecCores != 0 {
+		return sparkExecCores
 	}
 
-	// Count total executor CPU allocation
-	return driverCores + (executorCores * executorCount)
+	return 1.0 // default value for spark.executor.cores
 }
 
 /*
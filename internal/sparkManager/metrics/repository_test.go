@@ -19,6 +19,12 @@ import (
 	"testing"
 
 	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1Lister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 )
 
 func int32Ptr(i int32) *int32 { return &i }
@@ -173,6 +179,55 @@ func TestParseDynamicAllocExecutorCount(t *testing.T) {
 	}
 }
 
+func TestGetSparkAppActiveCpuAllocation(t *testing.T) {
+	executorState := map[string]v1beta2.ExecutorState{
+		"exec-1": v1beta2.ExecutorStateRunning,
+		"exec-2": v1beta2.ExecutorStatePending,
+		"exec-3": v1beta2.ExecutorStateCompleted,
+		"exec-4": v1beta2.ExecutorStateFailed,
+	}
+	dynamicAllocation := &v1beta2.DynamicAllocation{Enabled: true, MaxExecutors: int32Ptr(10)}
+
+	tests := []struct {
+		name          string
+		dynamicAlloc  *v1beta2.DynamicAllocation
+		instances     *int32
+		executorState map[string]v1beta2.ExecutorState
+		expected      float64
+	}{
+		{
+			name:          "dynamic allocation counts pending and running executors",
+			dynamicAlloc:  dynamicAllocation,
+			executorState: executorState,
+			expected:      1 + 2*2,
+		},
+		{
+			name:         "dynamic allocation without executor state uses maxExecutors",
+			dynamicAlloc: dynamicAllocation,
+			expected:     1 + 2*10,
+		},
+		{
+			name:          "static allocation uses instances",
+			instances:     int32Ptr(3),
+			executorState: executorState,
+			expected:      1 + 2*3,
+		},
+	}
+	for _, test := range tests {
+		sparkApp := &v1beta2.SparkApplication{
+			Spec: v1beta2.SparkApplicationSpec{
+				DynamicAllocation: test.dynamicAlloc,
+				Executor: v1beta2.ExecutorSpec{
+					Instances:    test.instances,
+					SparkPodSpec: v1beta2.SparkPodSpec{Cores: int32Ptr(2)},
+				},
+			},
+			Status: v1beta2.SparkApplicationStatus{ExecutorState: test.executorState},
+		}
+		assert.Equal(t, test.expected, GetSparkAppActiveCpuAllocation(sparkApp, 1000), "%s: cpu allocation should match", test.name)
+	}
+}
+
 func TestParseExecutorCount(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -346,3 +401,82 @@ func TestParseKubeCPU(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNamespaceQuotaUtilization(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, quota := range []*corev1.ResourceQuota{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "busy", Name: "compute"},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("100"), corev1.ResourceRequestsMemory: resource.MustParse("100Gi")},
+				Used: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("95"), corev1.ResourceRequestsMemory: resource.MustParse("10Gi")},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "busy", Name: "objects"},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")},
+				Used: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("5")},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "blocked", Name: "none"},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("0")},
+				Used: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("0")},
+			},
+		},
+	} {
+		assert.NoError(t, indexer.Add(quota), "adding quota should not error")
+	}
+	repo := NewRepository(nil, corev1Lister.NewResourceQuotaLister(indexer), nil, false)
+
+	tests := []struct {
+		name      string
+		namespace string
+		expected  float64
+	}{
+		{name: "highest ratio across quotas", namespace: "busy", expected: 0.95},
+		{name: "zero hard limit is fully consumed", namespace: "blocked", expected: 1.0},
+		{name: "namespace without quotas", namespace: "free", expected: 0},
+	}
+	for _, test := range tests {
+		utilization, ok := repo.GetNamespaceQuotaUtilization(test.namespace)
+		assert.True(t, ok, "%s: utilization should be reported", test.name)
+		assert.InDelta(t, test.expected, utilization, 0.0001, "%s: utilization should match", test.name)
+	}
+
+	_, ok := NewRepository(nil, nil, nil, false).GetNamespaceQuotaUtilization("busy")
+	assert.False(t, ok, "utilization should not be reported without a quota lister")
+}
+
+func TestGetRunningExecutorPods(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, pod := range []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "busy", Name: "exec-1"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "busy", Name: "exec-2"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "busy", Name: "exec-3"}, Status: corev1.PodStatus{Phase: corev1.PodSucceeded}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "idle", Name: "exec-1"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+	} {
+		assert.NoError(t, indexer.Add(pod), "adding pod should not error")
+	}
+	repo := NewRepository(nil, nil, corev1Lister.NewPodLister(indexer), false)
+
+	tests := []struct {
+		name      string
+		namespace string
+		expected  int
+	}{
+		{name: "running pods in namespace", namespace: "busy", expected: 2},
+		{name: "running pods in cluster", namespace: "", expected: 3},
+		{name: "namespace without pods", namespace: "free", expected: 0},
+	}
+	for _, test := range tests {
+		running, ok := repo.GetRunningExecutorPods(test.namespace)
+		assert.True(t, ok, "%s: running executor pods should be reported", test.name)
+		assert.Equal(t, test.expected, running, "%s: running executor pods should match", test.name)
+	}
+
+	_, ok := NewRepository(nil, nil, nil, false).GetRunningExecutorPods("busy")
+	assert.False(t, ok, "running executor pods should not be reported without a pod lister")
+}
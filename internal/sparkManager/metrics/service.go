@@ -66,6 +66,13 @@ func (s *service) setClusterMetrics(metrics Metrics) {
 
 	cpuByCluster := s.repository.GetTotalCPUAllocation(sparkApplicationList)
 	metrics.cpuAllocated.With(prometheus.Labels{"cluster": s.kubeCluster.Name, "namespace": ""}).Set(cpuByCluster)
+
+	memoryByCluster := s.repository.GetTotalMemoryAllocation(sparkApplicationList)
+	metrics.memoryAllocated.With(prometheus.Labels{"cluster": s.kubeCluster.Name, "namespace": ""}).Set(memoryByCluster)
+
+	if executorPods, ok := s.repository.GetRunningExecutorPods(""); ok {
+		metrics.runningExecutorPods.With(prometheus.Labels{"cluster": s.kubeCluster.Name, "namespace": ""}).Set(float64(executorPods))
+	}
 }
 
 func (s *service) setNamespaceMetrics(metrics Metrics) {
@@ -78,5 +85,16 @@ func (s *service) setNamespaceMetrics(metrics Metrics) {
 
 		cpuByNamespace := s.repository.GetTotalCPUAllocation(sparkApplicationList)
 		metrics.cpuAllocated.With(prometheus.Labels{"cluster": s.kubeCluster.Name, "namespace": ns.Name}).Set(cpuByNamespace)
+
+		memoryByNamespace := s.repository.GetTotalMemoryAllocation(sparkApplicationList)
+		metrics.memoryAllocated.With(prometheus.Labels{"cluster": s.kubeCluster.Name, "namespace": ns.Name}).Set(memoryByNamespace)
+
+		if quotaUtilization, ok := s.repository.GetNamespaceQuotaUtilization(ns.Name); ok {
+			metrics.quotaUtilization.With(prometheus.Labels{"cluster": s.kubeCluster.Name, "namespace": ns.Name}).Set(quotaUtilization)
+		}
+
+		if executorPods, ok := s.repository.GetRunningExecutorPods(ns.Name); ok {
+			metrics.runningExecutorPods.With(prometheus.Labels{"cluster": s.kubeCluster.Name, "namespace": ns.Name}).Set(float64(executorPods))
+		}
 	}
 }
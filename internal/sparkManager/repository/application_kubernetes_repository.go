@@ -17,30 +17,46 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
 
+	"github.com/slackhq/spark-gateway/internal/domain"
 	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
 	"github.com/slackhq/spark-gateway/internal/shared/util"
 	"github.com/slackhq/spark-gateway/internal/sparkManager/kube"
 
 	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
 	sparkClientSet "github.com/kubeflow/spark-operator/v2/pkg/client/clientset/versioned"
+	"github.com/kubeflow/spark-operator/v2/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
 )
 
 type SparkApplicationRepository struct {
 	sparkClient *sparkClientSet.Clientset
 	k8sClient   *kubernetes.Clientset
-	controller  kube.SparkController
+	controller  *kube.SparkController
+	// crd is the SparkApplication CRD served by the cluster, which bundled ConfigMaps reference as their owner
+	crd domain.SparkApplicationCRD
 }
 
-func NewSparkApplicationRepository(controller *kube.SparkController, sparkClient *sparkClientSet.Clientset, k8sClient *kubernetes.Clientset) (*SparkApplicationRepository, error) {
+func NewSparkApplicationRepository(controller *kube.SparkController, sparkClient *sparkClientSet.Clientset, k8sClient *kubernetes.Clientset, crd domain.SparkApplicationCRD) (*SparkApplicationRepository, error) {
 	return &SparkApplicationRepository{
 		sparkClient: sparkClient,
 		k8sClient:   k8sClient,
-		controller:  *controller,
+		controller:  controller,
+		crd:         crd.WithDefaults(),
 	}, nil
 }
 
@@ -67,28 +83,176 @@ func (s *SparkApplicationRepository) List(namespace string) ([]*v1beta2.SparkApp
 
 }
 
-func (s *SparkApplicationRepository) GetLogs(namespace string, name string, tailLines int64) (*string, error) {
+// CacheStatus returns how fresh the informer cache Get and List of namespace are served from is
+func (s *SparkApplicationRepository) CacheStatus(namespace string) domain.CacheStatus {
+	return s.controller.CacheStatus(namespace)
+}
+
+// StreamLogs returns a stream of the Spark Driver Pod logs, limited to the last tailLines lines if tailLines is not nil.
+// The caller is responsible for closing the stream.
+func (s *SparkApplicationRepository) StreamLogs(ctx context.Context, namespace string, name string, tailLines *int64) (io.ReadCloser, error) {
 
 	sparkApp, err := s.Get(namespace, name)
 	if err != nil {
-		return nil, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error getting SparkApplication '%s/%s' to get Spark Driver Pod name for logs: %w", sparkApp.Namespace, sparkApp.Name, err))
+		return nil, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error getting SparkApplication '%s/%s' to get Spark Driver Pod name for logs: %w", namespace, name, err))
 	}
-	logString, err := util.GetLogs(sparkApp.Status.DriverInfo.PodName, sparkApp.Namespace, tailLines, s.k8sClient)
+
+	var logStream io.ReadCloser
+	err = retryKube(ctx, "log stream", kubeRetryBackoff, func() error {
+		var streamErr error
+		logStream, streamErr = util.StreamLogs(ctx, sparkApp.Status.DriverInfo.PodName, sparkApp.Namespace, tailLines, s.k8sClient)
+		return streamErr
+	})
 	if err != nil {
+		return nil, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error streaming logs for SparkApplication '%s/%s': %w", namespace, name, err))
+	}
+
+	return logStream, nil
+}
+
+// FollowLogs returns a stream of the Spark Driver Pod logs that tails them live, starting from the last tailLines lines
+// if tailLines is not nil. The caller is responsible for closing the stream.
+func (s *SparkApplicationRepository) FollowLogs(ctx context.Context, namespace string, name string, tailLines *int64) (io.ReadCloser, error) {
+
+	sparkApp, err := s.Get(namespace, name)
+	if err != nil {
+		return nil, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error getting SparkApplication '%s/%s' to get Spark Driver Pod name for logs: %w", namespace, name, err))
+	}
+
+	var logStream io.ReadCloser
+	err = retryKube(ctx, "log follow", kubeRetryBackoff, func() error {
+		var streamErr error
+		logStream, streamErr = util.FollowLogs(ctx, sparkApp.Status.DriverInfo.PodName, sparkApp.Namespace, tailLines, s.k8sClient)
+		return streamErr
+	})
+	if err != nil {
+		return nil, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error following logs for SparkApplication '%s/%s': %w", namespace, name, err))
+	}
+
+	return logStream, nil
+}
+
+// ListPods returns the driver and executor Pods of the SparkApplication namespace/name, found by the app-name label the
+// Spark Operator sets on them
+func (s *SparkApplicationRepository) ListPods(ctx context.Context, namespace string, name string) ([]corev1.Pod, error) {
+
+	if _, err := s.Get(namespace, name); err != nil {
 		return nil, err
 	}
-	logLines := util.UnmarshalLogLines(*logString)
-	formattedLogString := util.FormatLogLines(logLines)
 
-	return formattedLogString, nil
+	var podList *corev1.PodList
+	err := retryKube(ctx, "pod list", kubeRetryBackoff, func() error {
+		var listErr error
+		podList, listErr = s.k8sClient.CoreV1().Pods(namespace).List(ctx, v1.ListOptions{
+			LabelSelector: labels.Set{common.LabelSparkAppName: name}.String(),
+		})
+		return listErr
+	})
+	if err != nil {
+		return nil, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error listing Pods of SparkApplication '%s/%s': %w", namespace, name, err))
+	}
+
+	return podList.Items, nil
+}
+
+// StreamExecutorLogs returns a stream of the logs of the executor Pod with executorId, limited to the last tailLines
+// lines if tailLines is not nil. The caller is responsible for closing the stream.
+func (s *SparkApplicationRepository) StreamExecutorLogs(ctx context.Context, namespace string, name string, executorId string, tailLines *int64) (io.ReadCloser, error) {
+
+	pods, err := s.ListPods(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Executors of an earlier attempt are left until they are garbage collected, so read the newest with executorId
+	var executorPod *corev1.Pod
+	for i, pod := range pods {
+		if pod.Labels[common.LabelSparkRole] != common.SparkRoleExecutor || pod.Labels[common.LabelSparkExecutorID] != executorId {
+			continue
+		}
+		if executorPod == nil || executorPod.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			executorPod = &pods[i]
+		}
+	}
+	if executorPod == nil {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("SparkApplication '%s/%s' has no executor with id '%s'", namespace, name, executorId))
+	}
+
+	var logStream io.ReadCloser
+	err = retryKube(ctx, "executor log stream", kubeRetryBackoff, func() error {
+		var streamErr error
+		logStream, streamErr = util.StreamLogs(ctx, executorPod.Name, namespace, tailLines, s.k8sClient)
+		return streamErr
+	})
+	if err != nil {
+		return nil, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error streaming logs of executor '%s' of SparkApplication '%s/%s': %w", executorId, namespace, name, err))
+	}
+
+	return logStream, nil
 }
 
 func (s *SparkApplicationRepository) Create(ctx context.Context, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
 
+	// ConfigMaps bundled with the submission are created first and owned by the SparkApplication once it exists
+	configMaps, err := domain.AuxiliaryConfigMaps(application.Annotations)
+	if err != nil {
+		return nil, gatewayerrors.NewBadRequest(err)
+	}
+	var createdConfigMaps []string
+	if len(configMaps) > 0 {
+		application = application.DeepCopy()
+		delete(application.Annotations, domain.GATEWAY_CONFIGMAPS_ANNOTATION)
+
+		createdConfigMaps, err = createAuxiliaryConfigMaps(ctx, s.k8sClient, application, configMaps)
+		if err != nil {
+			deleteAuxiliaryConfigMaps(context.WithoutCancel(ctx), s.k8sClient, application.Namespace, createdConfigMaps)
+			return nil, err
+		}
+	}
+
+	sparkApp, err := s.create(ctx, application)
+	if err != nil {
+		deleteAuxiliaryConfigMaps(context.WithoutCancel(ctx), s.k8sClient, application.Namespace, createdConfigMaps)
+		return nil, err
+	}
+
+	if len(configMaps) > 0 {
+		ownerRef := v1.OwnerReference{
+			APIVersion: s.crd.Group + "/" + s.crd.Version,
+			Kind:       s.crd.Kind,
+			Name:       sparkApp.Name,
+			UID:        sparkApp.UID,
+		}
+		if err := ownAuxiliaryConfigMaps(ctx, s.k8sClient, sparkApp, ownerRef, configMaps); err != nil {
+			// The SparkApplication runs regardless, and the orphan sweeper deletes the ConfigMaps once it is gone
+			klog.Warningf("ConfigMaps bundled with SparkApplication '%s/%s' won't be deleted with it: %v", sparkApp.Namespace, sparkApp.Name, err)
+		}
+	}
+
+	return sparkApp, nil
+}
+
+func (s *SparkApplicationRepository) create(ctx context.Context, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
+
 	// The API server populates the server-assigned UID on the object returned
 	// by Create, so we use it directly rather than polling the (eventually
 	// consistent) informer cache, which would busy-loop while the cache caught up.
-	sparkApp, err := s.sparkClient.SparkoperatorV1beta2().SparkApplications(application.Namespace).Create(ctx, application, v1.CreateOptions{})
+	var sparkApp *v1beta2.SparkApplication
+	err := retryKube(ctx, "create", kubeRetryBackoff, func() error {
+		var createErr error
+		sparkApp, createErr = s.sparkClient.SparkoperatorV1beta2().SparkApplications(application.Namespace).Create(ctx, application, v1.CreateOptions{})
+		return createErr
+	})
+	if k8sErrors.IsAlreadyExists(err) {
+		// A previous attempt may have succeeded without us seeing the response. Read from the API server rather than
+		// the informer cache, which may not have caught up yet, and return the existing SparkApplication if it was
+		// created from the same submission.
+		existing, getErr := s.sparkClient.SparkoperatorV1beta2().SparkApplications(application.Namespace).Get(ctx, application.Name, v1.GetOptions{})
+		if getErr == nil && domain.IsSameSubmission(existing, application) {
+			klog.Infof("SparkApplication '%s/%s' already exists with a matching spec, returning existing SparkApplication", application.Namespace, application.Name)
+			return existing, nil
+		}
+	}
 	if err != nil {
 		return nil, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error creating SparkApplication: %w", err))
 	}
@@ -100,10 +264,180 @@ func (s *SparkApplicationRepository) Create(ctx context.Context, application *v1
 	return sparkApp, nil
 }
 
+// Watch starts a watch on the SparkApplications in namespace from the API server, with bookmarks enabled so clients
+// can resume from resourceVersion. labelSelector is combined with the selector the informer is filtered by. The caller
+// is responsible for stopping the watch.
+func (s *SparkApplicationRepository) Watch(ctx context.Context, namespace string, labelSelector string, resourceVersion string) (watch.Interface, error) {
+	selector := labelSelector
+	if s.controller.LabelSelector != "" {
+		selector = strings.Trim(strings.Join([]string{s.controller.LabelSelector, labelSelector}, ","), ",")
+	}
+
+	watcher, err := s.sparkClient.SparkoperatorV1beta2().SparkApplications(namespace).Watch(ctx, v1.ListOptions{
+		LabelSelector:       selector,
+		ResourceVersion:     resourceVersion,
+		AllowWatchBookmarks: true,
+	})
+	if err != nil {
+		return nil, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error watching SparkApplications in namespace '%s': %w", namespace, err))
+	}
+
+	return watcher, nil
+}
+
+// WatchApplication watches the SparkApplication namespace/name through the informer cache, so watching it doesn't add a
+// watch on the API server. The caller is responsible for stopping the watch.
+func (s *SparkApplicationRepository) WatchApplication(ctx context.Context, namespace string, name string) (watch.Interface, error) {
+	watcher, err := s.controller.WatchApplication(namespace, name)
+	if err != nil {
+		return nil, gatewayerrors.NewUnavailable(err)
+	}
+
+	return watcher, nil
+}
+
+// Delete deletes the SparkApplication namespace/name if it carries the selector the informer is filtered by, or ctx
+// overrides the selector check. The UID read is a precondition of the deletion, so a SparkApplication recreated in
+// between isn't deleted.
 func (s *SparkApplicationRepository) Delete(ctx context.Context, namespace string, name string) error {
-	if err := s.sparkClient.SparkoperatorV1beta2().SparkApplications(namespace).Delete(ctx, name, v1.DeleteOptions{}); err != nil {
+	sparkApp, err := s.gatewayApplication(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+
+	err = retryKube(ctx, "delete", kubeRetryBackoff, func() error {
+		return s.sparkClient.SparkoperatorV1beta2().SparkApplications(namespace).Delete(ctx, name, v1.DeleteOptions{
+			Preconditions: &v1.Preconditions{UID: &sparkApp.UID},
+		})
+	})
+	if err != nil {
 		return gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error deleting SparkApplication: %w", err))
 	}
 
 	return nil
 }
+
+// ScaleExecutors merge patches `spec.executor.instances` or `spec.dynamicAllocation.maxExecutors` of the
+// SparkApplication, whichever is set in scale
+func (s *SparkApplicationRepository) ScaleExecutors(ctx context.Context, namespace string, name string, scale domain.ExecutorScale) (*v1beta2.SparkApplication, error) {
+	spec := map[string]any{}
+	if scale.Instances != nil {
+		spec["executor"] = map[string]any{"instances": *scale.Instances}
+	}
+	if scale.MaxExecutors != nil {
+		spec["dynamicAllocation"] = map[string]any{"maxExecutors": *scale.MaxExecutors}
+	}
+
+	patch, err := json.Marshal(map[string]any{"spec": spec})
+	if err != nil {
+		return nil, gatewayerrors.NewInternal(fmt.Errorf("error marshaling executor scale patch: %w", err))
+	}
+
+	if _, err := s.gatewayApplication(ctx, namespace, name); err != nil {
+		return nil, err
+	}
+
+	var sparkApp *v1beta2.SparkApplication
+	err = retryKube(ctx, "scale", kubeRetryBackoff, func() error {
+		var patchErr error
+		sparkApp, patchErr = s.sparkClient.SparkoperatorV1beta2().SparkApplications(namespace).Patch(ctx, name, types.MergePatchType, patch, v1.PatchOptions{})
+		return patchErr
+	})
+	if err != nil {
+		return nil, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error scaling executors of SparkApplication '%s/%s': %w", namespace, name, err))
+	}
+
+	return sparkApp, nil
+}
+
+// Update applies update to the latest version of the SparkApplication read from the API server, retrying if it changes
+// in between. Applications that have started running in the meantime are rejected with a Conflict.
+func (s *SparkApplicationRepository) Update(ctx context.Context, namespace string, name string, update domain.ApplicationUpdate) (*v1beta2.SparkApplication, error) {
+	var sparkApp *v1beta2.SparkApplication
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := s.sparkClient.SparkoperatorV1beta2().SparkApplications(namespace).Get(ctx, name, v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if !s.changeable(ctx, current) {
+			return gatewayerrors.NewNotFound(fmt.Errorf("SparkApplication '%s/%s' not found", namespace, name))
+		}
+
+		if !domain.IsUpdatable(current.Status.AppState.State) {
+			return gatewayerrors.NewConflict(fmt.Errorf("SparkApplication '%s/%s' is in state '%s', only SparkApplications that haven't started running can be updated", namespace, name, current.Status.AppState.State))
+		}
+
+		update.Apply(current)
+		sparkApp, err = s.sparkClient.SparkoperatorV1beta2().SparkApplications(namespace).Update(ctx, current, v1.UpdateOptions{})
+		return err
+	})
+	var gatewayErr gatewayerrors.GatewayError
+	if errors.As(err, &gatewayErr) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error updating SparkApplication '%s/%s': %w", namespace, name, err))
+	}
+
+	return sparkApp, nil
+}
+
+// GetLatest reads the SparkApplication namespace/name from the API server, see gatewayApplication
+func (s *SparkApplicationRepository) GetLatest(ctx context.Context, namespace string, name string) (*v1beta2.SparkApplication, error) {
+	return s.gatewayApplication(ctx, namespace, name)
+}
+
+// gatewayApplication reads the SparkApplication namespace/name from the API server, rather than the informer cache which
+// may not have caught up with it yet. SparkApplications without the selector the informer is filtered by are not found,
+// like they are by Get, so SparkApplications that weren't submitted through Spark Gateway can't be changed unless ctx
+// overrides the selector check.
+func (s *SparkApplicationRepository) gatewayApplication(ctx context.Context, namespace string, name string) (*v1beta2.SparkApplication, error) {
+	var sparkApp *v1beta2.SparkApplication
+	err := retryKube(ctx, "get", kubeRetryBackoff, func() error {
+		var getErr error
+		sparkApp, getErr = s.sparkClient.SparkoperatorV1beta2().SparkApplications(namespace).Get(ctx, name, v1.GetOptions{})
+		return getErr
+	})
+	if err != nil {
+		return nil, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error getting SparkApplication '%s/%s': %w", namespace, name, err))
+	}
+	if !s.changeable(ctx, sparkApp) {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("SparkApplication '%s/%s' not found", namespace, name))
+	}
+
+	return sparkApp, nil
+}
+
+// changeable returns whether sparkApp may be deleted or changed with ctx, because it carries the selector the informer
+// is filtered by or an admin overrode the check, see domain.WithSelectorOverride
+func (s *SparkApplicationRepository) changeable(ctx context.Context, sparkApp *v1beta2.SparkApplication) bool {
+	return domain.IsSelectorOverride(ctx) || hasSelector(s.controller.LabelSelector, sparkApp.Labels)
+}
+
+// hasSelector returns whether objectLabels match selector. Every object matches an empty selector.
+func hasSelector(selector string, objectLabels map[string]string) bool {
+	if selector == "" {
+		return true
+	}
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return false
+	}
+	return parsed.Matches(labels.Set(objectLabels))
+}
+
+// ProxyDriver GETs path from port of the driver Pod podName through the API server's Pod proxy. The caller is
+// responsible for closing the stream.
+func (s *SparkApplicationRepository) ProxyDriver(ctx context.Context, namespace string, podName string, port int32, path string) (io.ReadCloser, error) {
+	var stream io.ReadCloser
+	err := retryKube(ctx, "driver proxy", kubeRetryBackoff, func() error {
+		var proxyErr error
+		stream, proxyErr = s.k8sClient.CoreV1().Pods(namespace).ProxyGet("http", podName, strconv.Itoa(int(port)), path, nil).Stream(ctx)
+		return proxyErr
+	})
+	if err != nil {
+		return nil, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error proxying %s of driver Pod '%s/%s' on port %d: %w", path, namespace, podName, port, err))
+	}
+
+	return stream, nil
+}
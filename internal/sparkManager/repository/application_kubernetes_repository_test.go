@@ -0,0 +1,59 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/kube"
+)
+
+func TestHasSelector(t *testing.T) {
+	testCases := []struct {
+		name     string
+		selector string
+		labels   map[string]string
+		expected bool
+	}{
+		{"empty selector", "", nil, true},
+		{"matching label", "spark-gateway/managed=true", map[string]string{"spark-gateway/managed": "true", "team": "a"}, true},
+		{"different value", "spark-gateway/managed=true", map[string]string{"spark-gateway/managed": "false"}, false},
+		{"missing label", "spark-gateway/managed=true", map[string]string{"team": "a"}, false},
+		{"invalid selector", "=", map[string]string{"team": "a"}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, hasSelector(tc.selector, tc.labels), "hasSelector should match")
+		})
+	}
+}
+
+func TestSparkApplicationRepositoryChangeable(t *testing.T) {
+	repo := &SparkApplicationRepository{controller: &kube.SparkController{LabelSelector: "spark-gateway/managed=true"}}
+	gatewayApp := &v1beta2.SparkApplication{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"spark-gateway/managed": "true"}}}
+	operatorApp := &v1beta2.SparkApplication{}
+
+	assert.True(t, repo.changeable(context.Background(), gatewayApp), "Gateway applications should be changeable")
+	assert.False(t, repo.changeable(context.Background(), operatorApp), "other applications should not be changeable")
+	assert.True(t, repo.changeable(domain.WithSelectorOverride(context.Background()), operatorApp), "admins should override the selector")
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+// appNameLabel is the label the Spark Operator names the SparkApplication of its resources with, which the orphan
+// sweeper relies on to delete bundled ConfigMaps that lost their SparkApplication before being owned by it
+const appNameLabel = "sparkoperator.k8s.io/app-name"
+
+// createAuxiliaryConfigMaps creates the ConfigMaps bundled with application before it is created, so its pods never
+// start without them. They carry application's labels. A ConfigMap left by a previous attempt of the same submission
+// is kept if its data matches. The names of the ConfigMaps created are returned.
+func createAuxiliaryConfigMaps(ctx context.Context, k8sClient kubernetes.Interface, application *v1beta2.SparkApplication, configMaps []domain.AuxiliaryConfigMap) ([]string, error) {
+	labels := maps.Clone(application.Labels)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[appNameLabel] = application.Name
+
+	var created []string
+	for _, configMap := range configMaps {
+		name := domain.AuxiliaryConfigMapName(application.Name, configMap.Name)
+		err := retryKube(ctx, "configmap create", kubeRetryBackoff, func() error {
+			_, createErr := k8sClient.CoreV1().ConfigMaps(application.Namespace).Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: v1.ObjectMeta{Name: name, Namespace: application.Namespace, Labels: labels},
+				Data:       configMap.Data,
+			}, v1.CreateOptions{})
+			return createErr
+		})
+		if k8sErrors.IsAlreadyExists(err) {
+			existing, getErr := k8sClient.CoreV1().ConfigMaps(application.Namespace).Get(ctx, name, v1.GetOptions{})
+			if getErr == nil && maps.Equal(existing.Data, configMap.Data) {
+				continue
+			}
+		}
+		if err != nil {
+			return created, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error creating ConfigMap '%s' bundled with SparkApplication '%s/%s': %w", name, application.Namespace, application.Name, err))
+		}
+		created = append(created, name)
+	}
+
+	return created, nil
+}
+
+// ownAuxiliaryConfigMaps adds owner to the owner references of the ConfigMaps bundled with it, so they are garbage
+// collected with it
+func ownAuxiliaryConfigMaps(ctx context.Context, k8sClient kubernetes.Interface, owner *v1beta2.SparkApplication, ownerRef v1.OwnerReference, configMaps []domain.AuxiliaryConfigMap) error {
+	var errs []error
+	for _, configMap := range configMaps {
+		name := domain.AuxiliaryConfigMapName(owner.Name, configMap.Name)
+		err := retryKube(ctx, "configmap update", kubeRetryBackoff, func() error {
+			existing, err := k8sClient.CoreV1().ConfigMaps(owner.Namespace).Get(ctx, name, v1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			for _, ref := range existing.OwnerReferences {
+				if ref.UID == ownerRef.UID {
+					return nil
+				}
+			}
+			existing.OwnerReferences = append(existing.OwnerReferences, ownerRef)
+			_, err = k8sClient.CoreV1().ConfigMaps(owner.Namespace).Update(ctx, existing, v1.UpdateOptions{})
+			return err
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error adding owner reference to ConfigMap '%s': %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// deleteAuxiliaryConfigMaps deletes the ConfigMaps named names created for a SparkApplication in namespace that failed
+// to be created. Failures are only logged, the orphan sweeper deletes ConfigMaps left behind.
+func deleteAuxiliaryConfigMaps(ctx context.Context, k8sClient kubernetes.Interface, namespace string, names []string) {
+	for _, name := range names {
+		if err := k8sClient.CoreV1().ConfigMaps(namespace).Delete(ctx, name, v1.DeleteOptions{}); err != nil && !k8sErrors.IsNotFound(err) {
+			klog.Warningf("error deleting ConfigMap '%s/%s' bundled with SparkApplication that failed to be created: %v", namespace, name, err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+)
+
+func TestAuxiliaryConfigMapsLifecycle(t *testing.T) {
+	leftOver := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{Name: "c1-ns-uuid-templates", Namespace: "ns"},
+		Data:       map[string]string{"driver.yaml": "kind: Pod"},
+	}
+	k8sClient := fake.NewClientset(leftOver)
+	application := &v1beta2.SparkApplication{ObjectMeta: v1.ObjectMeta{Name: "c1-ns-uuid", Namespace: "ns", Labels: map[string]string{"team": "a"}}}
+	configMaps := []domain.AuxiliaryConfigMap{
+		{Name: "log4j", Data: map[string]string{"log4j.properties": "rootLogger.level=info"}},
+		{Name: "templates", Data: map[string]string{"driver.yaml": "kind: Pod"}},
+	}
+
+	created, err := createAuxiliaryConfigMaps(context.Background(), k8sClient, application, configMaps)
+	assert.NoError(t, err, "ConfigMaps should be created")
+	assert.Equal(t, []string{"c1-ns-uuid-log4j"}, created, "a matching ConfigMap left by a previous attempt should be kept, not reported as created")
+
+	log4j, err := k8sClient.CoreV1().ConfigMaps("ns").Get(context.Background(), "c1-ns-uuid-log4j", v1.GetOptions{})
+	assert.NoError(t, err, "ConfigMap should exist")
+	assert.Equal(t, configMaps[0].Data, log4j.Data, "ConfigMap data should match")
+	assert.Equal(t, map[string]string{"team": "a", appNameLabel: "c1-ns-uuid"}, log4j.Labels, "ConfigMap should carry the SparkApplication's labels")
+
+	ownerRef := v1.OwnerReference{APIVersion: "sparkoperator.k8s.io/v1beta2", Kind: "SparkApplication", Name: "c1-ns-uuid", UID: "uid"}
+	assert.NoError(t, ownAuxiliaryConfigMaps(context.Background(), k8sClient, application, ownerRef, configMaps), "ConfigMaps should be owned")
+	assert.NoError(t, ownAuxiliaryConfigMaps(context.Background(), k8sClient, application, ownerRef, configMaps), "owning ConfigMaps again should succeed")
+	for _, name := range []string{"c1-ns-uuid-log4j", "c1-ns-uuid-templates"} {
+		configMap, err := k8sClient.CoreV1().ConfigMaps("ns").Get(context.Background(), name, v1.GetOptions{})
+		assert.NoError(t, err, "ConfigMap should exist")
+		assert.Equal(t, []v1.OwnerReference{ownerRef}, configMap.OwnerReferences, "ConfigMap should be owned by the SparkApplication once")
+	}
+
+	deleteAuxiliaryConfigMaps(context.Background(), k8sClient, "ns", append(created, "missing"))
+	_, err = k8sClient.CoreV1().ConfigMaps("ns").Get(context.Background(), "c1-ns-uuid-log4j", v1.GetOptions{})
+	assert.Error(t, err, "created ConfigMap should be deleted")
+}
+
+func TestCreateAuxiliaryConfigMapsConflict(t *testing.T) {
+	existing := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{Name: "c1-ns-uuid-log4j", Namespace: "ns"},
+		Data:       map[string]string{"log4j.properties": "rootLogger.level=debug"},
+	}
+	k8sClient := fake.NewClientset(existing)
+	application := &v1beta2.SparkApplication{ObjectMeta: v1.ObjectMeta{Name: "c1-ns-uuid", Namespace: "ns"}}
+
+	_, err := createAuxiliaryConfigMaps(context.Background(), k8sClient, application, []domain.AuxiliaryConfigMap{
+		{Name: "log4j", Data: map[string]string{"log4j.properties": "rootLogger.level=info"}},
+	})
+	assert.ErrorContains(t, err, "error creating ConfigMap 'c1-ns-uuid-log4j' bundled with SparkApplication 'ns/c1-ns-uuid'", "a different existing ConfigMap should not be reused")
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+const (
+	// driverRoleName is the Role, and RoleBinding, granting the driver ServiceAccount what Spark needs to run executors
+	driverRoleName = "spark-gateway-driver"
+
+	managedByLabel = "app.kubernetes.io/managed-by"
+	managedByValue = "spark-gateway"
+)
+
+// NamespaceRepository provisions namespaces for SparkApplications in the cluster.
+type NamespaceRepository struct {
+	k8sClient kubernetes.Interface
+}
+
+func NewNamespaceRepository(k8sClient kubernetes.Interface) *NamespaceRepository {
+	return &NamespaceRepository{k8sClient: k8sClient}
+}
+
+// ProvisionNamespace creates namespace with provisioning's ServiceAccount, bound to a Role with the permissions Spark
+// drivers need. Resources that already exist are left unchanged so provisioning can be retried.
+func (r *NamespaceRepository) ProvisionNamespace(ctx context.Context, namespace string, provisioning domain.NamespaceProvisioning) error {
+	labels := map[string]string{managedByLabel: managedByValue}
+
+	steps := []struct {
+		kind   string
+		name   string
+		create func() error
+	}{
+		{"Namespace", namespace, func() error {
+			_, err := r.k8sClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+				ObjectMeta: v1.ObjectMeta{Name: namespace, Labels: labels},
+			}, v1.CreateOptions{})
+			return err
+		}},
+		{"ServiceAccount", provisioning.ServiceAccount, func() error {
+			_, err := r.k8sClient.CoreV1().ServiceAccounts(namespace).Create(ctx, &corev1.ServiceAccount{
+				ObjectMeta: v1.ObjectMeta{Name: provisioning.ServiceAccount, Namespace: namespace, Labels: labels},
+			}, v1.CreateOptions{})
+			return err
+		}},
+		{"Role", driverRoleName, func() error {
+			_, err := r.k8sClient.RbacV1().Roles(namespace).Create(ctx, &rbacv1.Role{
+				ObjectMeta: v1.ObjectMeta{Name: driverRoleName, Namespace: namespace, Labels: labels},
+				Rules:      domain.DriverRoleRules,
+			}, v1.CreateOptions{})
+			return err
+		}},
+		{"RoleBinding", driverRoleName, func() error {
+			_, err := r.k8sClient.RbacV1().RoleBindings(namespace).Create(ctx, &rbacv1.RoleBinding{
+				ObjectMeta: v1.ObjectMeta{Name: driverRoleName, Namespace: namespace, Labels: labels},
+				Subjects: []rbacv1.Subject{
+					{Kind: rbacv1.ServiceAccountKind, Name: provisioning.ServiceAccount, Namespace: namespace},
+				},
+				RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: driverRoleName},
+			}, v1.CreateOptions{})
+			return err
+		}},
+	}
+
+	for _, step := range steps {
+		err := retryKube(ctx, "create "+step.kind, kubeRetryBackoff, step.create)
+		if k8sErrors.IsAlreadyExists(err) {
+			klog.Infof("%s '%s' already exists in namespace '%s', leaving it unchanged", step.kind, step.name, namespace)
+			continue
+		}
+		if err != nil {
+			return gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error creating %s '%s' in namespace '%s': %w", step.kind, step.name, namespace, err))
+		}
+		klog.Infof("Created %s '%s' in namespace '%s'", step.kind, step.name, namespace)
+	}
+
+	return nil
+}
+
+// IsProvisioned returns whether namespace exists and was provisioned by Spark Gateway
+func (r *NamespaceRepository) IsProvisioned(ctx context.Context, namespace string) (bool, error) {
+	var ns *corev1.Namespace
+	err := retryKube(ctx, "get Namespace", kubeRetryBackoff, func() error {
+		var getErr error
+		ns, getErr = r.k8sClient.CoreV1().Namespaces().Get(ctx, namespace, v1.GetOptions{})
+		return getErr
+	})
+	if k8sErrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error getting Namespace '%s': %w", namespace, err))
+	}
+
+	return ns.Labels[managedByLabel] == managedByValue, nil
+}
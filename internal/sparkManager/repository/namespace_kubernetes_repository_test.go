@@ -0,0 +1,69 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+)
+
+func TestNamespaceRepositoryProvisionNamespace(t *testing.T) {
+	existingServiceAccount := &corev1.ServiceAccount{ObjectMeta: v1.ObjectMeta{Name: "spark", Namespace: "team-a", Labels: map[string]string{"owner": "team-a"}}}
+	k8sClient := fake.NewClientset(existingServiceAccount)
+	repo := NewNamespaceRepository(k8sClient)
+
+	err := repo.ProvisionNamespace(context.Background(), "team-a", domain.NamespaceProvisioning{ServiceAccount: "spark"})
+	assert.Nil(t, err, "err should be nil")
+
+	namespace, err := k8sClient.CoreV1().Namespaces().Get(context.Background(), "team-a", v1.GetOptions{})
+	assert.Nil(t, err, "namespace should be created")
+	assert.Equal(t, managedByValue, namespace.Labels[managedByLabel], "namespace should be labelled as managed by Spark Gateway")
+
+	serviceAccount, _ := k8sClient.CoreV1().ServiceAccounts("team-a").Get(context.Background(), "spark", v1.GetOptions{})
+	assert.Equal(t, map[string]string{"owner": "team-a"}, serviceAccount.Labels, "existing ServiceAccount should be unchanged")
+
+	role, err := k8sClient.RbacV1().Roles("team-a").Get(context.Background(), driverRoleName, v1.GetOptions{})
+	assert.Nil(t, err, "role should be created")
+	assert.Equal(t, domain.DriverRoleRules, role.Rules, "role should grant driver permissions")
+
+	roleBinding, err := k8sClient.RbacV1().RoleBindings("team-a").Get(context.Background(), driverRoleName, v1.GetOptions{})
+	assert.Nil(t, err, "role binding should be created")
+	assert.Equal(t, "spark", roleBinding.Subjects[0].Name, "role should be bound to the ServiceAccount")
+
+	err = repo.ProvisionNamespace(context.Background(), "team-a", domain.NamespaceProvisioning{ServiceAccount: "spark"})
+	assert.Nil(t, err, "provisioning again should succeed")
+}
+
+func TestNamespaceRepositoryIsProvisioned(t *testing.T) {
+	k8sClient := fake.NewClientset(&corev1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "kube-system"}})
+	repo := NewNamespaceRepository(k8sClient)
+
+	err := repo.ProvisionNamespace(context.Background(), "team-a", domain.NamespaceProvisioning{ServiceAccount: "spark"})
+	assert.Nil(t, err, "err should be nil")
+
+	for namespace, want := range map[string]bool{"team-a": true, "kube-system": false, "missing": false} {
+		provisioned, err := repo.IsProvisioned(context.Background(), namespace)
+		assert.Nil(t, err, "err should be nil for namespace '%s'", namespace)
+		assert.Equal(t, want, provisioned, "namespace '%s' provisioned", namespace)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// kubeRetryBackoff is the backoff used when retrying retryable Kubernetes API errors. With 4 steps, requests are
+// retried after roughly 100ms, 200ms and 400ms before giving up.
+var kubeRetryBackoff = wait.Backoff{
+	Steps:    4,
+	Duration: 100 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
+// retryKube runs fn, retrying with kubeRetryBackoff while it returns an error classified as retryable by
+// gatewayerrors.IsRetryableK8sError. The last error is returned if all attempts fail or ctx is done.
+func retryKube(ctx context.Context, operation string, backoff wait.Backoff, fn func() error) error {
+	var err error
+	for {
+		err = fn()
+		if err == nil || !gatewayerrors.IsRetryableK8sError(err) {
+			return err
+		}
+
+		if backoff.Steps <= 1 {
+			return err
+		}
+
+		delay := backoff.Step()
+		klog.Warningf("retryable Kubernetes API error during %s, retrying in %s: %v", operation, delay, err)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
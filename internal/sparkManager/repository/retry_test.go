@@ -0,0 +1,82 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+var testResource = schema.GroupResource{Group: "sparkoperator.k8s.io", Resource: "sparkapplications"}
+
+var testBackoff = wait.Backoff{Steps: 3, Duration: time.Millisecond, Factor: 1.0}
+
+func TestRetryKubeRetryable(t *testing.T) {
+	attempts := 0
+	err := retryKube(context.Background(), "test", testBackoff, func() error {
+		attempts++
+		if attempts < 3 {
+			return k8sErrors.NewTooManyRequests("slow down", 1)
+		}
+		return nil
+	})
+
+	assert.Nil(t, err, "err should be nil after retries succeed")
+	assert.Equal(t, 3, attempts, "fn should be retried until it succeeds")
+}
+
+func TestRetryKubeExhausted(t *testing.T) {
+	attempts := 0
+	err := retryKube(context.Background(), "test", testBackoff, func() error {
+		attempts++
+		return k8sErrors.NewServerTimeout(testResource, "create", 1)
+	})
+
+	assert.True(t, k8sErrors.IsServerTimeout(err), "last error should be returned")
+	assert.Equal(t, testBackoff.Steps, attempts, "fn should be attempted once per backoff step")
+}
+
+func TestRetryKubeNotRetryable(t *testing.T) {
+	attempts := 0
+	err := retryKube(context.Background(), "test", testBackoff, func() error {
+		attempts++
+		return k8sErrors.NewForbidden(testResource, "app", errors.New("rbac"))
+	})
+
+	assert.True(t, k8sErrors.IsForbidden(err), "error should be returned")
+	assert.Equal(t, 1, attempts, "non retryable errors should not be retried")
+}
+
+func TestRetryKubeContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attempts := 0
+	err := retryKube(ctx, "test", wait.Backoff{Steps: 3, Duration: time.Hour}, func() error {
+		attempts++
+		return k8sErrors.NewServiceUnavailable("down")
+	})
+
+	assert.True(t, k8sErrors.IsServiceUnavailable(err), "last error should be returned")
+	assert.Equal(t, 1, attempts, "fn should not be retried once ctx is done")
+}
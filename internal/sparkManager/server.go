@@ -21,17 +21,19 @@ import (
 	"fmt"
 	"net/http"
 
-	sparkClientSet "github.com/kubeflow/spark-operator/v2/pkg/client/clientset/versioned"
 	"k8s.io/client-go/kubernetes"
+	corev1Lister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 
+	"github.com/slackhq/spark-gateway/internal/domain"
 	"github.com/slackhq/spark-gateway/internal/shared/config"
 	"github.com/slackhq/spark-gateway/internal/shared/database"
-	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/shared/faults"
 	"github.com/slackhq/spark-gateway/internal/sparkManager/api"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/backend"
 	"github.com/slackhq/spark-gateway/internal/sparkManager/kube"
 	"github.com/slackhq/spark-gateway/internal/sparkManager/metrics"
-	appRepo "github.com/slackhq/spark-gateway/internal/sparkManager/repository"
 	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
 
 	"time"
@@ -60,49 +62,152 @@ func NewSparkManager(ctx context.Context, sgConfig *config.SparkGatewayConfig, c
 		db = sparkAppDB
 	}
 
+	// Local mode runs on an in-memory backend without a cluster to connect to
+	local := sgConfig.Mode == config.ModeLocal
+
 	// Initialize Kube Clients
-	kubeConfig, err := kube.GetKubeConfig(sgConfig.SparkManagerConfig.ClusterAuthType, kubeCluster)
-	if err != nil {
-		return nil, err
-	}
-	k8sClient, err := kubernetes.NewForConfig(kubeConfig)
-	if err != nil {
-		return nil, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error creating k8s client: %w", err))
+	var kubeConfig *rest.Config
+	if !local {
+		var err error
+		kubeConfig, err = kube.GetKubeConfig(sgConfig.SparkManagerConfig.ClusterAuthType, kubeCluster)
+		if err != nil {
+			return nil, err
+		}
 	}
-	sparkClient, err := sparkClientSet.NewForConfig(kubeConfig)
-	if err != nil {
-		return nil, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("error creating spark client: %w", err))
+
+	// Inject faults into Kubernetes API calls, targeting them by verb
+	var faultInjector *faults.Injector
+	if sgConfig.SparkManagerConfig.FaultInjection.Enable {
+		klog.Warning("Fault injection is enabled, calls to /faults can make Kubernetes API calls fail")
+		faultInjector = faults.NewInjector()
+		if kubeConfig != nil {
+			kubeConfig.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+				return faultInjector.Transport(rt, faults.KubernetesVerb)
+			})
+		}
 	}
 
-	// Initialize Kube SparkApp Controller
-	controller, err := kube.NewSparkController(
-		ctx,
-		kubeConfig,
-		sgConfig.SelectorKey,
-		sgConfig.SelectorValue,
-		kubeCluster.Name,
-		db,
-	)
+	// Initialize the cluster's execution backend
+	sparkAppRepo, err := backend.New(ctx, backend.Params{
+		Config:     sgConfig,
+		Cluster:    *kubeCluster,
+		KubeConfig: kubeConfig,
+		Database:   db,
+	})
 	if err != nil {
-		return nil, gatewayerrors.MapK8sErrorToGatewayError(fmt.Errorf("unable to initialize SparkApplication Controller: %w", err))
+		return nil, err
 	}
 
-	// Init repos
-	sparkAppRepo, err := appRepo.NewSparkApplicationRepository(controller, sparkClient, k8sClient)
-	if err != nil {
-		return nil, fmt.Errorf("unable to create NewSparkApplicationRepository: %w", err)
+	// Executors can only be scaled if the backend supports it
+	executorScaler, _ := sparkAppRepo.(service.ExecutorScaler)
+	// Driver metrics can only be read if the backend can reach driver Pods
+	driverProxy, _ := sparkAppRepo.(service.DriverProxy)
+	// Namespaces can only be provisioned if the backend supports it
+	namespaceProvisioner, _ := sparkAppRepo.(service.NamespaceProvisioner)
+	// Only serve requests for the cluster's configured namespaces and those provisioned since
+	managedNamespaces := service.NewManagedNamespaces(*kubeCluster, namespaceProvisioner)
+
+	// Watch ResourceQuotas so the Gateway can route namespaces away from clusters where their quota is nearly exhausted,
+	// and so scaling up executors can be checked against the namespace's quota
+	watchQuotas := !local && (sgConfig.ClusterRouter.QuotaExclusion.Enable || executorScaler != nil)
+	// Watch running executor pods when they are exported, or when the Gateway routes by them
+	watchExecutorPods := !local && (sgConfig.SparkManagerConfig.MetricsServer.ExecutorPods || sgConfig.ClusterRouter.PrometheusQuery.Metric == metrics.RunningExecutorPodsMetric)
+
+	// Only the Spark Operator backend depends on the spark-operator running in the cluster
+	checkOperator := !local && kubeCluster.OperatorHealth.Enable && kubeCluster.Backend == domain.BackendSparkOperator
+	// Operator created pods, services and configmaps only exist with the Spark Operator backend
+	sweepOrphans := !local && sgConfig.SparkManagerConfig.OrphanSweeper.Enable && kubeCluster.Backend == domain.BackendSparkOperator
+	// The permissions checked are those the Spark Operator backend needs
+	checkPermissions := !local && sgConfig.SparkManagerConfig.PermissionCheck.Enable && kubeCluster.Backend == domain.BackendSparkOperator
+
+	informerOptions := kube.NewInformerOptions(sgConfig.SparkManagerConfig.Informers, *kubeCluster)
+	var quotaLister corev1Lister.ResourceQuotaLister
+	var executorPodLister corev1Lister.PodLister
+	var operatorHealth service.OperatorHealthChecker
+	if watchQuotas || watchExecutorPods || checkOperator || sweepOrphans || checkPermissions {
+		k8sClient, err := kubernetes.NewForConfig(kubeConfig)
+		if err != nil {
+			return nil, fmt.Errorf("error creating k8s client: %w", err)
+		}
+		if watchQuotas {
+			quotaLister, err = kube.NewResourceQuotaLister(ctx, k8sClient, informerOptions)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if watchExecutorPods {
+			executorPodLister, err = kube.NewRunningExecutorPodLister(ctx, k8sClient, informerOptions)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if checkOperator {
+			operatorHealthChecker := service.NewDeploymentOperatorHealthChecker(k8sClient, *kubeCluster)
+			// Check once before serving so submissions aren't rejected until the first interval elapses
+			operatorHealthChecker.Check(ctx)
+			go operatorHealthChecker.Run(ctx)
+			operatorHealth = operatorHealthChecker
+		}
+		if sweepOrphans {
+			orphanSweeper := service.NewOrphanSweeper(k8sClient, sparkAppRepo, *kubeCluster, managedNamespaces, sgConfig.SparkManagerConfig.OrphanSweeper, sgConfig.SelectorKey, sgConfig.SelectorValue)
+			go orphanSweeper.Run(ctx)
+		}
+		if checkPermissions {
+			_, provisioning := sparkAppRepo.(service.NamespaceProvisioner)
+			permissionChecker := service.NewPermissionChecker(k8sClient, *kubeCluster, service.PermissionNeeds{
+				NamespaceScoped: sgConfig.SparkManagerConfig.Informers.NamespaceScoped,
+				ExecutorPods:    watchExecutorPods,
+				Quotas:          watchQuotas,
+				OrphanSweeper:   sweepOrphans,
+				Provisioning:    provisioning,
+				OperatorHealth:  checkOperator,
+			}, sgConfig.SparkManagerConfig.PermissionCheck)
+			// Missing permissions are reported rather than failing startup, since not every permission is used by
+			// every request
+			go func() {
+				if _, err := permissionChecker.Check(ctx); err != nil {
+					klog.Errorf("error checking the permissions of SparkManager: %v", err)
+				}
+			}()
+		}
 	}
-	metricsRepo := metrics.NewRepository(controller)
+	metricsRepo := metrics.NewRepository(sparkAppRepo, quotaLister, executorPodLister, sgConfig.SparkManagerConfig.MetricsServer.AllocationFromExecutorState)
 
 	// Initialize services
 	sparkApplicationService := service.NewSparkApplicationService(sparkAppRepo, db, *kubeCluster)
 	metricsService := metrics.NewService(metricsRepo, kubeCluster)
+	scaleService := service.NewScaleService(sparkAppRepo, executorScaler, quotaLister)
+	podRenderService := service.NewPodRenderService(*kubeCluster)
+	driverMetricsService := service.NewDriverMetricsService(sparkAppRepo, driverProxy)
+	timelineService := service.NewTimelineService(db)
+	if operatorHealth != nil {
+		sparkApplicationService = service.NewOperatorHealthApplicationService(sparkApplicationService, *kubeCluster, operatorHealth)
+	}
+
+	// Keep database records in sync with the cluster
+	if db != nil && sgConfig.Database.Reconciler.Interval > 0 {
+		reconciler := service.NewReconciler(sparkAppRepo, db, *kubeCluster, sgConfig.Database.Reconciler)
+		go reconciler.Run(ctx)
+	}
+
+	// Delete terminal SparkApplications past their namespace's retention. EMR on EKS job runs aren't deleted since EMR
+	// keeps their history itself.
+	if sgConfig.SparkManagerConfig.GarbageCollector.Enable && (local || kubeCluster.Backend == domain.BackendSparkOperator) {
+		if garbageCollector := service.NewGarbageCollector(sparkAppRepo, *kubeCluster, sgConfig.SparkManagerConfig.GarbageCollector, sgConfig.SelectorKey, sgConfig.SelectorValue); garbageCollector != nil {
+			go garbageCollector.Run(ctx)
+		}
+	}
+
+	// Delete lifecycle events past their namespace's retention
+	if sweeper := service.NewEventRetentionSweeper(db, *kubeCluster, sgConfig.Database.Events.SweepInterval); sweeper != nil {
+		go sweeper.Run(ctx)
+	}
 
 	// Init metrics
 	metricsServer := metrics.NewHandler(metricsService, sgConfig.SparkManagerConfig.MetricsServer)
 
 	// Register routes
-	router, err := api.NewRouter(sgConfig, sparkApplicationService)
+	router, err := api.NewRouter(sgConfig, managedNamespaces, sparkApplicationService, namespaceProvisioner, scaleService, podRenderService, driverMetricsService, timelineService, operatorHealth, faultInjector, kubeCluster.Name)
 	if err != nil {
 		return nil, err
 	}
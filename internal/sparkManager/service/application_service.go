@@ -17,14 +17,21 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"net/http"
 	"time"
 
 	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/klog/v2"
 
 	"github.com/slackhq/spark-gateway/internal/domain"
 	"github.com/slackhq/spark-gateway/internal/shared/database"
 	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/metrics"
 )
 
 //go:generate moq -rm -out mocksparkapplicationrepository.go . SparkApplicationRepository
@@ -32,20 +39,61 @@ import (
 type SparkApplicationRepository interface {
 	Get(namespace string, name string) (*v1beta2.SparkApplication, error)
 	List(namespace string) ([]*v1beta2.SparkApplication, error)
-	GetLogs(namespace string, name string, tailLines int64) (*string, error)
+	StreamLogs(ctx context.Context, namespace string, name string, tailLines *int64) (io.ReadCloser, error)
 	Create(ctx context.Context, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error)
 	Delete(ctx context.Context, namespace string, name string) error
+	Watch(ctx context.Context, namespace string, labelSelector string, resourceVersion string) (watch.Interface, error)
+}
+
+// CachedSparkApplicationRepository is implemented by SparkApplicationRepositories that serve Get and List from a cache
+// rather than reading through to the cluster
+type CachedSparkApplicationRepository interface {
+	CacheStatus(namespace string) domain.CacheStatus
+}
+
+// ApplicationWatcher is implemented by SparkApplicationRepositories that can watch a single SparkApplication from the
+// changes they already observe, without a watch on the API server
+type ApplicationWatcher interface {
+	WatchApplication(ctx context.Context, namespace string, name string) (watch.Interface, error)
+}
+
+// LogFollower is implemented by SparkApplicationRepositories that can tail driver logs live
+type LogFollower interface {
+	FollowLogs(ctx context.Context, namespace string, name string, tailLines *int64) (io.ReadCloser, error)
+}
+
+// PodReader is implemented by SparkApplicationRepositories that can list the driver and executor Pods of a
+// SparkApplication and read the logs of its executors
+type PodReader interface {
+	ListPods(ctx context.Context, namespace string, name string) ([]corev1.Pod, error)
+	StreamExecutorLogs(ctx context.Context, namespace string, name string, executorId string, tailLines *int64) (io.ReadCloser, error)
+}
+
+// ApplicationUpdater is implemented by SparkApplicationRepositories that can change the mutable fields of
+// SparkApplications that haven't started running yet
+type ApplicationUpdater interface {
+	Update(ctx context.Context, namespace string, name string, update domain.ApplicationUpdate) (*v1beta2.SparkApplication, error)
 }
 
 //go:generate moq -rm -out mocksparkapplicationservice.go . SparkApplicationService
 
 type SparkApplicationService interface {
 	Get(namespace string, name string) (*v1beta2.SparkApplication, error)
-	List(namespace string) ([]*domain.SparkManagerSparkApplicationSummary, error)
-	Status(namespace string, name string) (*v1beta2.SparkApplicationStatus, error)
-	Logs(namespace string, name string, tailLines int64) (*string, error)
+	List(namespace string, view domain.SummaryView) ([]*domain.SparkManagerSparkApplicationSummary, error)
+	Counts(namespace string) (*domain.SparkManagerApplicationCounts, error)
+	CacheStatus(namespace string) domain.CacheStatus
+	Status(namespace string, name string) (*domain.ApplicationStatus, error)
+	Logs(ctx context.Context, namespace string, name string, tailLines int64) (io.ReadCloser, error)
+	FollowLogs(ctx context.Context, namespace string, name string, tailLines int64) (io.ReadCloser, error)
+	ExecutorLogs(ctx context.Context, namespace string, name string, executorId string, tailLines int64) (io.ReadCloser, error)
+	StreamLogs(ctx context.Context, namespace string, name string) (io.ReadCloser, error)
+	Pods(ctx context.Context, namespace string, name string) ([]*domain.SparkApplicationPod, error)
+	Cost(namespace string, name string) (*domain.ApplicationCost, error)
 	Create(ctx context.Context, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error)
+	Update(ctx context.Context, namespace string, name string, update domain.ApplicationUpdate) (*v1beta2.SparkApplication, error)
 	Delete(ctx context.Context, namespace string, name string) error
+	Watch(ctx context.Context, namespace string, labelSelector string, resourceVersion string) (watch.Interface, error)
+	StreamStatus(ctx context.Context, namespace string, name string) (<-chan *domain.ApplicationStatus, error)
 }
 
 type ApplicationService struct {
@@ -69,7 +117,7 @@ func (s *ApplicationService) Get(namespace string, name string) (*v1beta2.SparkA
 	return sparkApp, nil
 }
 
-func (s *ApplicationService) List(namespace string) ([]*domain.SparkManagerSparkApplicationSummary, error) {
+func (s *ApplicationService) List(namespace string, view domain.SummaryView) ([]*domain.SparkManagerSparkApplicationSummary, error) {
 
 	sparkApps, err := s.sparkApplicationRepository.List(namespace)
 
@@ -79,25 +127,260 @@ func (s *ApplicationService) List(namespace string) ([]*domain.SparkManagerSpark
 
 	appSummaries := []*domain.SparkManagerSparkApplicationSummary{}
 	for _, sparkApp := range sparkApps {
-		appSummary := domain.NewSparkManagerSparkApplicationSummary(sparkApp)
+		appSummary := domain.NewSparkManagerSparkApplicationSummaryView(sparkApp, view)
+		appSummary.Cost = s.estimateCost(sparkApp)
 		appSummaries = append(appSummaries, appSummary)
 	}
 
 	return appSummaries, nil
 }
 
-func (s *ApplicationService) Status(namespace string, name string) (*v1beta2.SparkApplicationStatus, error) {
+// Counts returns the number of SparkApplications in each state in namespace, computed from the informer cache
+func (s *ApplicationService) Counts(namespace string) (*domain.SparkManagerApplicationCounts, error) {
+
+	sparkApps, err := s.sparkApplicationRepository.List(namespace)
+
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(err)
+	}
+
+	counts := &domain.SparkManagerApplicationCounts{Namespace: namespace, States: map[string]int{}}
+	for _, sparkApp := range sparkApps {
+		counts.States[domain.CountState(sparkApp.Status.AppState.State)]++
+	}
+
+	return counts, nil
+}
+
+// CacheStatus returns how fresh the cache List and Counts of namespace are computed from is. Repositories without a cache read
+// through to the cluster, so they are always reported fresh.
+func (s *ApplicationService) CacheStatus(namespace string) domain.CacheStatus {
+	if cached, ok := s.sparkApplicationRepository.(CachedSparkApplicationRepository); ok {
+		return cached.CacheStatus(namespace)
+	}
+
+	return domain.CacheStatus{Synced: true}
+}
+
+// Status returns the status of the SparkApplication along with its creation time, so the Gateway can derive its
+// timings
+func (s *ApplicationService) Status(namespace string, name string) (*domain.ApplicationStatus, error) {
 
 	sparkApp, err := s.Get(namespace, name)
 	if err != nil {
 		return nil, gatewayerrors.NewFrom(err)
 	}
 
-	return &sparkApp.Status, nil
+	return newApplicationStatus(sparkApp), nil
+}
+
+func newApplicationStatus(sparkApp *v1beta2.SparkApplication) *domain.ApplicationStatus {
+	return &domain.ApplicationStatus{
+		SparkApplicationStatus: *sparkApp.Status.DeepCopy(),
+		GatewayState:           domain.NewGatewayState(sparkApp.Status.AppState.State),
+		CreationTimestamp:      sparkApp.CreationTimestamp,
+	}
+}
+
+// StreamStatus sends the current status of the SparkApplication, then its status each time its state changes, until
+// a terminal state has been sent, the SparkApplication is deleted or ctx is done. The channel is closed when the
+// stream ends.
+func (s *ApplicationService) StreamStatus(ctx context.Context, namespace string, name string) (<-chan *domain.ApplicationStatus, error) {
+	appWatcher, ok := s.sparkApplicationRepository.(ApplicationWatcher)
+	if !ok {
+		return nil, gatewayerrors.New(http.StatusNotImplemented, fmt.Errorf("cluster '%s' does not support streaming SparkApplication status", s.cluster.Name))
+	}
+
+	// Watch before reading the current status so a change in between isn't missed
+	watcher, err := appWatcher.WatchApplication(ctx, namespace, name)
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(err)
+	}
+
+	status, err := s.Status(namespace, name)
+	if err != nil {
+		watcher.Stop()
+		return nil, err
+	}
+
+	statuses := make(chan *domain.ApplicationStatus)
+	go func() {
+		defer close(statuses)
+		defer watcher.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case statuses <- status:
+			}
+
+			if domain.IsTerminalApplicationState(status.AppState.State) {
+				return
+			}
+
+			next, ok := nextStateChange(ctx, watcher, status.AppState.State)
+			if !ok {
+				return
+			}
+			status = newApplicationStatus(next)
+		}
+	}()
+
+	return statuses, nil
+}
+
+// nextStateChange waits for the watched SparkApplication to leave state. It returns false if the SparkApplication is
+// deleted, the watch ends or ctx is done first.
+func nextStateChange(ctx context.Context, watcher watch.Interface, state v1beta2.ApplicationStateType) (*v1beta2.SparkApplication, bool) {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case event, ok := <-watcher.ResultChan():
+			if !ok || event.Type == watch.Deleted {
+				return nil, false
+			}
+			if sparkApp, ok := event.Object.(*v1beta2.SparkApplication); ok && sparkApp.Status.AppState.State != state {
+				return sparkApp, true
+			}
+		}
+	}
+}
+
+// Logs returns a stream of the last tailLines lines of driver logs, formatted as they are read. The caller is
+// responsible for closing the stream.
+func (s *ApplicationService) Logs(ctx context.Context, namespace string, name string, tailLines int64) (io.ReadCloser, error) {
+
+	logStream, err := s.sparkApplicationRepository.StreamLogs(ctx, namespace, name, &tailLines)
+	if err != nil {
+		return nil, err
+	}
+
+	return formatLogs(logStream), nil
+}
+
+// FollowLogs returns a stream of driver logs, starting from the last tailLines lines, that tails them live until the
+// driver exits or ctx is done. Lines are formatted as they are read, like Logs. The caller is responsible for closing
+// the stream.
+func (s *ApplicationService) FollowLogs(ctx context.Context, namespace string, name string, tailLines int64) (io.ReadCloser, error) {
+	follower, ok := s.sparkApplicationRepository.(LogFollower)
+	if !ok {
+		return nil, gatewayerrors.New(http.StatusNotImplemented, fmt.Errorf("cluster '%s' does not support following driver logs", s.cluster.Name))
+	}
+
+	logStream, err := follower.FollowLogs(ctx, namespace, name, &tailLines)
+	if err != nil {
+		return nil, err
+	}
+
+	return formatLogs(logStream), nil
+}
+
+// ExecutorLogs returns a stream of the last tailLines lines of the logs of the executor with executorId, formatted as
+// they are read like Logs. The caller is responsible for closing the stream.
+func (s *ApplicationService) ExecutorLogs(ctx context.Context, namespace string, name string, executorId string, tailLines int64) (io.ReadCloser, error) {
+	podReader, ok := s.sparkApplicationRepository.(PodReader)
+	if !ok {
+		return nil, gatewayerrors.New(http.StatusNotImplemented, fmt.Errorf("cluster '%s' does not support reading executor logs", s.cluster.Name))
+	}
+
+	logStream, err := podReader.StreamExecutorLogs(ctx, namespace, name, executorId, &tailLines)
+	if err != nil {
+		return nil, err
+	}
+
+	return formatLogs(logStream), nil
+}
+
+// formatLogs formats the lines of logStream as they are read, closing logStream once it is read or the returned stream
+// is closed
+func formatLogs(logStream io.ReadCloser) io.ReadCloser {
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		defer logStream.Close()
+		pipeWriter.CloseWithError(util.FormatLogStream(pipeWriter, logStream))
+	}()
+
+	return pipeReader
+}
+
+// StreamLogs returns a stream of the complete, unformatted driver logs. The caller is responsible for closing the stream.
+func (s *ApplicationService) StreamLogs(ctx context.Context, namespace string, name string) (io.ReadCloser, error) {
+	return s.sparkApplicationRepository.StreamLogs(ctx, namespace, name, nil)
+}
+
+// Pods returns the driver and executor Pods of a SparkApplication with their phases, the driver first and executors in
+// order of their id
+func (s *ApplicationService) Pods(ctx context.Context, namespace string, name string) ([]*domain.SparkApplicationPod, error) {
+	podReader, ok := s.sparkApplicationRepository.(PodReader)
+	if !ok {
+		return nil, gatewayerrors.New(http.StatusNotImplemented, fmt.Errorf("cluster '%s' does not support listing the pods of SparkApplications", s.cluster.Name))
+	}
+
+	pods, err := podReader.ListPods(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewSparkApplicationPods(pods), nil
+}
+
+// Cost returns the estimated cost of the resources a SparkApplication requests at the cluster's cost rates. It is nil
+// if the application hasn't been submitted yet.
+func (s *ApplicationService) Cost(namespace string, name string) (*domain.ApplicationCost, error) {
+	if !s.cluster.Cost.Enabled() {
+		return nil, gatewayerrors.New(http.StatusNotImplemented, fmt.Errorf("cluster '%s' has no cost rates to estimate costs with", s.cluster.Name))
+	}
+
+	sparkApp, err := s.sparkApplicationRepository.Get(namespace, name)
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(err)
+	}
+
+	return s.estimateCost(sparkApp), nil
 }
 
-func (s *ApplicationService) Logs(namespace string, name string, tailLines int64) (*string, error) {
-	return s.sparkApplicationRepository.GetLogs(namespace, name, tailLines)
+// Update changes the labels, TTL or maxExecutors of a SparkApplication that hasn't started running yet. Updating
+// maxExecutors is only allowed for applications with dynamic allocation enabled.
+func (s *ApplicationService) Update(ctx context.Context, namespace string, name string, update domain.ApplicationUpdate) (*v1beta2.SparkApplication, error) {
+	if err := update.Validate(); err != nil {
+		return nil, gatewayerrors.NewBadRequest(err)
+	}
+
+	updater, ok := s.sparkApplicationRepository.(ApplicationUpdater)
+	if !ok {
+		return nil, gatewayerrors.New(http.StatusNotImplemented, fmt.Errorf("cluster '%s' does not support updating SparkApplications", s.cluster.Name))
+	}
+
+	sparkApp, err := s.sparkApplicationRepository.Get(namespace, name)
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(err)
+	}
+
+	if !domain.IsUpdatable(sparkApp.Status.AppState.State) {
+		return nil, gatewayerrors.NewConflict(fmt.Errorf("SparkApplication '%s/%s' is in state '%s', only SparkApplications that haven't started running can be updated", namespace, name, sparkApp.Status.AppState.State))
+	}
+	if update.MaxExecutors != nil && !metrics.IsDynamicAllocationEnabled(sparkApp.Spec.DynamicAllocation, sparkApp.Spec.SparkConf) {
+		return nil, gatewayerrors.NewBadRequest(fmt.Errorf("SparkApplication '%s/%s' doesn't have dynamic allocation enabled, 'maxExecutors' can't be set", namespace, name))
+	}
+
+	updatedApp, err := updater.Update(ctx, namespace, name, update)
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(err)
+	}
+
+	return updatedApp, nil
+}
+
+// estimateCost returns the estimated cost of sparkApp, nil if the cluster has no cost rates
+func (s *ApplicationService) estimateCost(sparkApp *v1beta2.SparkApplication) *domain.ApplicationCost {
+	if !s.cluster.Cost.Enabled() {
+		return nil
+	}
+
+	cores, memoryBytes := metrics.GetSparkAppResourceRequests(sparkApp)
+	return domain.NewApplicationCost(s.cluster.Cost, sparkApp.Status, cores, memoryBytes, time.Now())
 }
 
 func (s *ApplicationService) Create(ctx context.Context, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
@@ -130,3 +413,15 @@ func (s *ApplicationService) Delete(ctx context.Context, namespace string, name
 
 	return nil
 }
+
+// Watch returns a watch on SparkApplications in namespace. The caller is responsible for stopping the watch.
+func (s *ApplicationService) Watch(ctx context.Context, namespace string, labelSelector string, resourceVersion string) (watch.Interface, error) {
+
+	watcher, err := s.sparkApplicationRepository.Watch(ctx, namespace, labelSelector, resourceVersion)
+
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(err)
+	}
+
+	return watcher, nil
+}
@@ -1,4 +1,3 @@
-
 // Copyright (c) 2025, Salesforce, Inc.
 // SPDX-License-Identifier: Apache-2
 //
@@ -20,14 +19,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 
 	"github.com/slackhq/spark-gateway/internal/domain"
 	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
 )
 
 var expectedSparkApplication v1beta2.SparkApplication = v1beta2.SparkApplication{
@@ -57,8 +63,8 @@ var mockSparkAppRepository_SuccessTests SparkApplicationRepositoryMock = SparkAp
 	GetFunc: func(namespace string, name string) (*v1beta2.SparkApplication, error) {
 		return &expectedSparkApplication, nil
 	},
-	GetLogsFunc: func(namespace string, name string, tailLine int64) (*string, error) {
-		return &logString, nil
+	StreamLogsFunc: func(ctx context.Context, namespace string, name string, tailLines *int64) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(logString)), nil
 	},
 	CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
 		return &expectedSparkApplication, nil
@@ -72,7 +78,7 @@ var mockSparkAppRepository_FailureTests SparkApplicationRepositoryMock = SparkAp
 	GetFunc: func(namespace string, name string) (*v1beta2.SparkApplication, error) {
 		return nil, gatewayerrors.NewNotFound(fmt.Errorf("error getting SparkApplication '%s/%s'", expectedSparkApplication.Namespace, expectedSparkApplication.Name))
 	},
-	GetLogsFunc: func(namespace string, name string, tailLine int64) (*string, error) {
+	StreamLogsFunc: func(ctx context.Context, namespace string, name string, tailLines *int64) (io.ReadCloser, error) {
 		return nil, errors.New("error getting logs")
 	},
 	CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
@@ -99,12 +105,40 @@ func TestSparkApplicationService_Get_Error(t *testing.T) {
 	assert.Equal(t, gatewayerrors.NewNotFound(fmt.Errorf("error getting SparkApplication '%s/%s'", expectedSparkApplication.Namespace, expectedSparkApplication.Name)), err)
 }
 
+func TestSparkApplicationService_Counts(t *testing.T) {
+	newApp := func(state v1beta2.ApplicationStateType) *v1beta2.SparkApplication {
+		return &v1beta2.SparkApplication{Status: v1beta2.SparkApplicationStatus{AppState: v1beta2.ApplicationState{State: state}}}
+	}
+	repo := SparkApplicationRepositoryMock{
+		ListFunc: func(namespace string) ([]*v1beta2.SparkApplication, error) {
+			return []*v1beta2.SparkApplication{
+				newApp(v1beta2.ApplicationStateRunning),
+				newApp(v1beta2.ApplicationStateRunning),
+				newApp(v1beta2.ApplicationStateCompleted),
+				newApp(v1beta2.ApplicationStateNew),
+			}, nil
+		},
+	}
+	service := NewSparkApplicationService(&repo, nil, testCluster)
+
+	result, err := service.Counts("testNamespace")
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.SparkManagerApplicationCounts{
+		Namespace: "testNamespace",
+		States:    map[string]int{"RUNNING": 2, "COMPLETED": 1, "NEW": 1},
+	}, result, "counts should match")
+}
+
 func TestSparkApplicationService_Status(t *testing.T) {
 	service := NewSparkApplicationService(&mockSparkAppRepository_SuccessTests, nil, testCluster)
 
-	result, err := service.Get("testNamespace", "clusterid-nsid-testid")
+	result, err := service.Status("testNamespace", "clusterid-nsid-testid")
 	assert.NoError(t, err)
-	assert.Equal(t, &expectedSparkApplication, result)
+	assert.Equal(t, &domain.ApplicationStatus{
+		SparkApplicationStatus: expectedSparkApplication.Status,
+		GatewayState:           domain.NewGatewayState(expectedSparkApplication.Status.AppState.State),
+		CreationTimestamp:      expectedSparkApplication.CreationTimestamp,
+	}, result)
 }
 
 func TestSparkApplicationService_Status_Error(t *testing.T) {
@@ -118,15 +152,201 @@ func TestSparkApplicationService_Status_Error(t *testing.T) {
 func TestSparkApplicationService_GetLogs(t *testing.T) {
 	service := NewSparkApplicationService(&mockSparkAppRepository_SuccessTests, nil, testCluster)
 
-	result, err := service.Logs("testNamespace", "clusterid-nsid-testid", 100)
+	result, err := service.Logs(context.Background(), "testNamespace", "clusterid-nsid-testid", 100)
+	assert.NoError(t, err)
+
+	gotLogs, err := io.ReadAll(result)
+	assert.NoError(t, err)
+	assert.Equal(t, "\n"+logString, string(gotLogs))
+	assert.Equal(t, int64(100), *mockSparkAppRepository_SuccessTests.StreamLogsCalls()[0].TailLines)
+}
+
+// followingSparkAppRepository is a SparkApplicationRepository that can follow driver logs
+type followingSparkAppRepository struct {
+	*SparkApplicationRepositoryMock
+	logs string
+}
+
+func (r *followingSparkAppRepository) FollowLogs(ctx context.Context, namespace string, name string, tailLines *int64) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(r.logs)), nil
+}
+
+func TestSparkApplicationService_FollowLogs(t *testing.T) {
+	repo := &followingSparkAppRepository{SparkApplicationRepositoryMock: &mockSparkAppRepository_SuccessTests, logs: "line 1\nline 2\n"}
+	service := NewSparkApplicationService(repo, nil, testCluster)
+
+	result, err := service.FollowLogs(context.Background(), "testNamespace", "clusterid-nsid-testid", 100)
+	assert.NoError(t, err)
+
+	gotLogs, err := io.ReadAll(result)
+	assert.NoError(t, err)
+	assert.Equal(t, "\nline 1\nline 2", string(gotLogs), "followed logs should be formatted like Logs")
+
+	_, err = NewSparkApplicationService(&mockSparkAppRepository_SuccessTests, nil, testCluster).FollowLogs(context.Background(), "testNamespace", "clusterid-nsid-testid", 100)
+	assert.Equal(t, http.StatusNotImplemented, gatewayerrors.NewFrom(err).Status, "repositories that can't follow logs should not follow them")
+}
+
+type podReadingSparkAppRepository struct {
+	*SparkApplicationRepositoryMock
+	pods []corev1.Pod
+	logs string
+}
+
+func (r *podReadingSparkAppRepository) ListPods(ctx context.Context, namespace string, name string) ([]corev1.Pod, error) {
+	return r.pods, nil
+}
+
+func (r *podReadingSparkAppRepository) StreamExecutorLogs(ctx context.Context, namespace string, name string, executorId string, tailLines *int64) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(r.logs)), nil
+}
+
+func TestSparkApplicationService_ExecutorLogs(t *testing.T) {
+	repo := &podReadingSparkAppRepository{SparkApplicationRepositoryMock: &mockSparkAppRepository_SuccessTests, logs: "line 1\nline 2\n"}
+	service := NewSparkApplicationService(repo, nil, testCluster)
+
+	result, err := service.ExecutorLogs(context.Background(), "testNamespace", "clusterid-nsid-testid", "3", 100)
+	assert.NoError(t, err)
+
+	gotLogs, err := io.ReadAll(result)
+	assert.NoError(t, err)
+	assert.Equal(t, "\nline 1\nline 2", string(gotLogs), "executor logs should be formatted like Logs")
+
+	_, err = NewSparkApplicationService(&mockSparkAppRepository_SuccessTests, nil, testCluster).ExecutorLogs(context.Background(), "testNamespace", "clusterid-nsid-testid", "3", 100)
+	assert.Equal(t, http.StatusNotImplemented, gatewayerrors.NewFrom(err).Status, "repositories that can't read pods should not read executor logs")
+}
+
+func TestSparkApplicationService_Pods(t *testing.T) {
+	repo := &podReadingSparkAppRepository{
+		SparkApplicationRepositoryMock: &mockSparkAppRepository_SuccessTests,
+		pods: []corev1.Pod{
+			{ObjectMeta: v1.ObjectMeta{Name: "exec-1", Labels: map[string]string{"spark-role": "executor", "spark-exec-id": "1"}}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+			{ObjectMeta: v1.ObjectMeta{Name: "driver", Labels: map[string]string{"spark-role": "driver"}}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+		},
+	}
+	service := NewSparkApplicationService(repo, nil, testCluster)
+
+	pods, err := service.Pods(context.Background(), "testNamespace", "clusterid-nsid-testid")
 	assert.NoError(t, err)
-	assert.Equal(t, &logString, result)
+	assert.Equal(t, []*domain.SparkApplicationPod{
+		{Name: "driver", Role: "driver", Phase: corev1.PodRunning},
+		{Name: "exec-1", Role: "executor", ExecutorId: "1", Phase: corev1.PodRunning},
+	}, pods, "pods should be listed driver first")
+
+	_, err = NewSparkApplicationService(&mockSparkAppRepository_SuccessTests, nil, testCluster).Pods(context.Background(), "testNamespace", "clusterid-nsid-testid")
+	assert.Equal(t, http.StatusNotImplemented, gatewayerrors.NewFrom(err).Status, "repositories that can't read pods should not list them")
+}
+
+func TestSparkApplicationService_Cost(t *testing.T) {
+	submitted := time.Now().Add(-2 * time.Hour)
+	costedApp := &v1beta2.SparkApplication{
+		ObjectMeta: v1.ObjectMeta{Name: "clusterid-nsid-testid", Namespace: "testNamespace"},
+		Spec: v1beta2.SparkApplicationSpec{
+			Driver:   v1beta2.DriverSpec{SparkPodSpec: v1beta2.SparkPodSpec{Cores: util.Ptr[int32](1)}},
+			Executor: v1beta2.ExecutorSpec{SparkPodSpec: v1beta2.SparkPodSpec{Cores: util.Ptr[int32](1)}, Instances: util.Ptr[int32](3)},
+		},
+		Status: v1beta2.SparkApplicationStatus{
+			AppState:                  v1beta2.ApplicationState{State: v1beta2.ApplicationStateCompleted},
+			LastSubmissionAttemptTime: v1.NewTime(submitted),
+			TerminationTime:           v1.NewTime(submitted.Add(time.Hour)),
+		},
+	}
+	repo := SparkApplicationRepositoryMock{
+		GetFunc: func(namespace string, name string) (*v1beta2.SparkApplication, error) {
+			return costedApp, nil
+		},
+		ListFunc: func(namespace string) ([]*v1beta2.SparkApplication, error) {
+			return []*v1beta2.SparkApplication{costedApp}, nil
+		},
+	}
+	costedCluster := testCluster
+	costedCluster.Cost = domain.CostRates{CoreHour: 0.5, Currency: "USD"}
+	service := NewSparkApplicationService(&repo, nil, costedCluster)
+
+	cost, err := service.Cost("testNamespace", "clusterid-nsid-testid")
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.ApplicationCost{Currency: "USD", Estimate: 2, CoreHours: 4, GBHours: cost.GBHours, Final: true}, cost, "cost should cover the driver and executor cores until termination")
+
+	summaries, err := service.List("testNamespace", domain.SummaryViewFull)
+	assert.NoError(t, err)
+	assert.Equal(t, cost, summaries[0].Cost, "summaries should carry the cost")
+
+	_, err = NewSparkApplicationService(&repo, nil, testCluster).Cost("testNamespace", "clusterid-nsid-testid")
+	assert.Equal(t, http.StatusNotImplemented, gatewayerrors.NewFrom(err).Status, "clusters without cost rates should not estimate costs")
+}
+
+// updatingSparkAppRepository is a SparkApplicationRepository that can update SparkApplications
+type updatingSparkAppRepository struct {
+	*SparkApplicationRepositoryMock
+	updates []domain.ApplicationUpdate
+}
+
+func (r *updatingSparkAppRepository) Update(ctx context.Context, namespace string, name string, update domain.ApplicationUpdate) (*v1beta2.SparkApplication, error) {
+	r.updates = append(r.updates, update)
+	sparkApp, _ := r.Get(namespace, name)
+	update.Apply(sparkApp)
+	return sparkApp, nil
+}
+
+func TestSparkApplicationService_Update(t *testing.T) {
+	state := v1beta2.ApplicationStateSubmitted
+	newApp := func() *v1beta2.SparkApplication {
+		return &v1beta2.SparkApplication{
+			ObjectMeta: v1.ObjectMeta{Name: "clusterid-nsid-testid", Namespace: "testNamespace"},
+			Spec:       v1beta2.SparkApplicationSpec{DynamicAllocation: &v1beta2.DynamicAllocation{Enabled: true}},
+			Status:     v1beta2.SparkApplicationStatus{AppState: v1beta2.ApplicationState{State: state}},
+		}
+	}
+	repo := &updatingSparkAppRepository{SparkApplicationRepositoryMock: &SparkApplicationRepositoryMock{
+		GetFunc: func(namespace string, name string) (*v1beta2.SparkApplication, error) {
+			return newApp(), nil
+		},
+	}}
+	service := NewSparkApplicationService(repo, nil, testCluster)
+
+	updatedApp, err := service.Update(context.Background(), "testNamespace", "clusterid-nsid-testid", domain.ApplicationUpdate{MaxExecutors: util.Ptr(int32(4))})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), *updatedApp.Spec.DynamicAllocation.MaxExecutors, "updated application should be returned")
+
+	_, err = service.Update(context.Background(), "testNamespace", "clusterid-nsid-testid", domain.ApplicationUpdate{})
+	assert.Equal(t, http.StatusBadRequest, gatewayerrors.NewFrom(err).Status, "empty updates should be bad requests")
+
+	state = v1beta2.ApplicationStateRunning
+	_, err = service.Update(context.Background(), "testNamespace", "clusterid-nsid-testid", domain.ApplicationUpdate{TimeToLiveSeconds: util.Ptr(int64(60))})
+	assert.Equal(t, http.StatusConflict, gatewayerrors.NewFrom(err).Status, "running applications should not be updated")
+	assert.Len(t, repo.updates, 1, "rejected updates should not reach the repository")
+
+	_, err = NewSparkApplicationService(repo.SparkApplicationRepositoryMock, nil, testCluster).Update(context.Background(), "testNamespace", "clusterid-nsid-testid", domain.ApplicationUpdate{TimeToLiveSeconds: util.Ptr(int64(60))})
+	assert.Equal(t, http.StatusNotImplemented, gatewayerrors.NewFrom(err).Status, "repositories that can't update should not update")
+}
+
+func TestSparkApplicationService_UpdateStaticExecutors(t *testing.T) {
+	repo := &updatingSparkAppRepository{SparkApplicationRepositoryMock: &SparkApplicationRepositoryMock{
+		GetFunc: func(namespace string, name string) (*v1beta2.SparkApplication, error) {
+			return &v1beta2.SparkApplication{Status: v1beta2.SparkApplicationStatus{AppState: v1beta2.ApplicationState{State: v1beta2.ApplicationStateNew}}}, nil
+		},
+	}}
+	service := NewSparkApplicationService(repo, nil, testCluster)
+
+	_, err := service.Update(context.Background(), "testNamespace", "clusterid-nsid-testid", domain.ApplicationUpdate{MaxExecutors: util.Ptr(int32(4))})
+	assert.Equal(t, http.StatusBadRequest, gatewayerrors.NewFrom(err).Status, "maxExecutors should need dynamic allocation")
+	assert.Empty(t, repo.updates)
+}
+
+func TestSparkApplicationService_StreamLogs(t *testing.T) {
+	service := NewSparkApplicationService(&mockSparkAppRepository_SuccessTests, nil, testCluster)
+
+	result, err := service.StreamLogs(context.Background(), "testNamespace", "clusterid-nsid-testid")
+	assert.NoError(t, err)
+
+	gotLogs, err := io.ReadAll(result)
+	assert.NoError(t, err)
+	assert.Equal(t, logString, string(gotLogs))
 }
 
 func TestSparkApplicationService_GetLogs_Error(t *testing.T) {
 	service := NewSparkApplicationService(&mockSparkAppRepository_FailureTests, nil, testCluster)
 
-	_, err := service.Logs("testNamespace", "clusterid-nsid-testid", 100)
+	_, err := service.Logs(context.Background(), "testNamespace", "clusterid-nsid-testid", 100)
 	assert.Error(t, err)
 	assert.Equal(t, errors.New("error getting logs"), err)
 }
@@ -163,3 +383,67 @@ func TestSparkApplicationService_Delete_Error(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, gatewayerrors.NewInternal(errors.New("error deleting SparkApp")), err)
 }
+
+// watchingSparkAppRepository is a SparkApplicationRepository that can watch single SparkApplications
+type watchingSparkAppRepository struct {
+	*SparkApplicationRepositoryMock
+	watcher *watch.FakeWatcher
+}
+
+func (r *watchingSparkAppRepository) WatchApplication(ctx context.Context, namespace string, name string) (watch.Interface, error) {
+	return r.watcher, nil
+}
+
+func TestSparkApplicationService_StreamStatus(t *testing.T) {
+	newApp := func(state v1beta2.ApplicationStateType) *v1beta2.SparkApplication {
+		return &v1beta2.SparkApplication{Status: v1beta2.SparkApplicationStatus{AppState: v1beta2.ApplicationState{State: state}}}
+	}
+	repo := &watchingSparkAppRepository{
+		SparkApplicationRepositoryMock: &SparkApplicationRepositoryMock{
+			GetFunc: func(namespace string, name string) (*v1beta2.SparkApplication, error) {
+				return newApp(v1beta2.ApplicationStateSubmitted), nil
+			},
+		},
+		watcher: watch.NewFakeWithChanSize(4, false),
+	}
+	repo.watcher.Modify(newApp(v1beta2.ApplicationStateSubmitted))
+	repo.watcher.Modify(newApp(v1beta2.ApplicationStateRunning))
+	repo.watcher.Modify(newApp(v1beta2.ApplicationStateCompleted))
+	repo.watcher.Modify(newApp(v1beta2.ApplicationStateCompleted))
+	service := NewSparkApplicationService(repo, nil, testCluster)
+
+	statuses, err := service.StreamStatus(context.Background(), "testNamespace", "clusterid-nsid-testid")
+	assert.NoError(t, err)
+
+	states := []domain.GatewayState{}
+	for status := range statuses {
+		states = append(states, status.GatewayState)
+	}
+	assert.Equal(t, []domain.GatewayState{domain.GatewayStatePending, domain.GatewayStateRunning, domain.GatewayStateSucceeded}, states, "only state changes should be streamed, ending at the terminal state")
+	assert.True(t, repo.watcher.IsStopped(), "the watch should be stopped when the stream ends")
+}
+
+func TestSparkApplicationService_StreamStatus_Deleted(t *testing.T) {
+	repo := &watchingSparkAppRepository{
+		SparkApplicationRepositoryMock: &mockSparkAppRepository_SuccessTests,
+		watcher:                        watch.NewFakeWithChanSize(1, false),
+	}
+	repo.watcher.Delete(&expectedSparkApplication)
+	service := NewSparkApplicationService(repo, nil, testCluster)
+
+	statuses, err := service.StreamStatus(context.Background(), "testNamespace", "clusterid-nsid-testid")
+	assert.NoError(t, err)
+
+	count := 0
+	for range statuses {
+		count++
+	}
+	assert.Equal(t, 1, count, "the stream should end when the SparkApplication is deleted")
+}
+
+func TestSparkApplicationService_StreamStatus_NotImplemented(t *testing.T) {
+	service := NewSparkApplicationService(&mockSparkAppRepository_SuccessTests, nil, testCluster)
+
+	_, err := service.StreamStatus(context.Background(), "testNamespace", "clusterid-nsid-testid")
+	assert.Equal(t, http.StatusNotImplemented, gatewayerrors.NewFrom(err).Status, "repositories that can't watch applications should not stream status")
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/kubeflow/spark-operator/v2/pkg/common"
+
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+const (
+	// defaultSparkUIPort is the driver port serving Spark's PrometheusServlet unless spark.ui.port is set
+	defaultSparkUIPort = 4040
+	// defaultPrometheusServletPath is the path of Spark's PrometheusServlet unless its sink sets one
+	defaultPrometheusServletPath = "/metrics/prometheus"
+	// jmxExporterPath is the path of the metrics served by the Prometheus JMX exporter
+	jmxExporterPath = "/metrics"
+)
+
+// prometheusServletConfPrefixes are the metrics system instances whose PrometheusServlet sink serves driver metrics,
+// most specific first
+var prometheusServletConfPrefixes = []string{
+	"spark.metrics.conf.driver.sink.prometheusServlet.",
+	"spark.metrics.conf.*.sink.prometheusServlet.",
+}
+
+//go:generate moq -rm -out mockdriverproxy.go . DriverProxy
+
+// DriverProxy GETs path from a port of a running driver Pod. It is implemented by backends whose drivers run as Pods
+// reachable through the Kubernetes API server.
+type DriverProxy interface {
+	ProxyDriver(ctx context.Context, namespace string, podName string, port int32, path string) (io.ReadCloser, error)
+}
+
+//go:generate moq -rm -out mocksparkapplicationdrivermetricsservice.go . SparkApplicationDriverMetricsService
+
+type SparkApplicationDriverMetricsService interface {
+	DriverMetrics(ctx context.Context, namespace string, name string) (io.ReadCloser, error)
+}
+
+type DriverMetricsService struct {
+	sparkApplicationRepository SparkApplicationRepository
+	proxy                      DriverProxy
+}
+
+// NewDriverMetricsService returns a SparkApplicationDriverMetricsService reading driver metrics through proxy, or nil if
+// proxy is nil because the cluster's backend cannot reach driver Pods.
+func NewDriverMetricsService(sparkAppRepo SparkApplicationRepository, proxy DriverProxy) SparkApplicationDriverMetricsService {
+	if proxy == nil {
+		return nil
+	}
+
+	return &DriverMetricsService{sparkApplicationRepository: sparkAppRepo, proxy: proxy}
+}
+
+// DriverMetrics returns the Prometheus metrics served by the driver of a running SparkApplication, from the Prometheus
+// JMX exporter if spec.monitoring exposes driver metrics, otherwise from Spark's PrometheusServlet if a metrics sink
+// configures one. The caller is responsible for closing the stream.
+func (s *DriverMetricsService) DriverMetrics(ctx context.Context, namespace string, name string) (io.ReadCloser, error) {
+
+	sparkApp, err := s.sparkApplicationRepository.Get(namespace, name)
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(err)
+	}
+
+	if sparkApp.Status.AppState.State != v1beta2.ApplicationStateRunning || sparkApp.Status.DriverInfo.PodName == "" {
+		return nil, gatewayerrors.NewConflict(fmt.Errorf("SparkApplication '%s/%s' is in state '%s', driver metrics are only served by running SparkApplications", namespace, name, sparkApp.Status.AppState.State))
+	}
+
+	port, path, ok := driverMetricsEndpoint(sparkApp)
+	if !ok {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("SparkApplication '%s/%s' doesn't expose driver metrics, set spec.monitoring.exposeDriverMetrics with spec.monitoring.prometheus or configure a PrometheusServlet sink", namespace, name))
+	}
+
+	metrics, err := s.proxy.ProxyDriver(ctx, namespace, sparkApp.Status.DriverInfo.PodName, port, path)
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(err)
+	}
+
+	return metrics, nil
+}
+
+// driverMetricsEndpoint returns the port and path of the driver's Prometheus metrics, or false if the SparkApplication
+// doesn't expose any
+func driverMetricsEndpoint(sparkApp *v1beta2.SparkApplication) (int32, string, bool) {
+	if monitoring := sparkApp.Spec.Monitoring; monitoring != nil && monitoring.ExposeDriverMetrics && monitoring.Prometheus != nil {
+		port := common.DefaultPrometheusJavaAgentPort
+		if monitoring.Prometheus.Port != nil {
+			port = *monitoring.Prometheus.Port
+		}
+		return port, jmxExporterPath, true
+	}
+
+	for _, prefix := range prometheusServletConfPrefixes {
+		if _, ok := sparkApp.Spec.SparkConf[prefix+"class"]; !ok {
+			continue
+		}
+
+		port := int32(defaultSparkUIPort)
+		if uiPort, err := strconv.ParseInt(sparkApp.Spec.SparkConf["spark.ui.port"], 10, 32); err == nil && uiPort > 0 {
+			port = int32(uiPort)
+		}
+		path := defaultPrometheusServletPath
+		if confPath := sparkApp.Spec.SparkConf[prefix+"path"]; confPath != "" {
+			path = confPath
+		}
+		return port, path, true
+	}
+
+	return 0, "", false
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+)
+
+func TestDriverMetricsServiceDriverMetrics(t *testing.T) {
+	runningApp := func(spec v1beta2.SparkApplicationSpec) *v1beta2.SparkApplication {
+		return &v1beta2.SparkApplication{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "app"},
+			Spec:       spec,
+			Status: v1beta2.SparkApplicationStatus{
+				AppState:   v1beta2.ApplicationState{State: v1beta2.ApplicationStateRunning},
+				DriverInfo: v1beta2.DriverInfo{PodName: "app-driver"},
+			},
+		}
+	}
+	completedApp := runningApp(v1beta2.SparkApplicationSpec{Monitoring: &v1beta2.MonitoringSpec{ExposeDriverMetrics: true, Prometheus: &v1beta2.PrometheusSpec{}}})
+	completedApp.Status.AppState.State = v1beta2.ApplicationStateCompleted
+
+	tests := []struct {
+		name           string
+		app            *v1beta2.SparkApplication
+		expectedPort   int32
+		expectedPath   string
+		expectedStatus int
+	}{
+		{
+			name:         "JMX exporter on its default port",
+			app:          runningApp(v1beta2.SparkApplicationSpec{Monitoring: &v1beta2.MonitoringSpec{ExposeDriverMetrics: true, Prometheus: &v1beta2.PrometheusSpec{}}}),
+			expectedPort: 8090,
+			expectedPath: "/metrics",
+		},
+		{
+			name:         "JMX exporter on a configured port",
+			app:          runningApp(v1beta2.SparkApplicationSpec{Monitoring: &v1beta2.MonitoringSpec{ExposeDriverMetrics: true, Prometheus: &v1beta2.PrometheusSpec{Port: util.Ptr(int32(9100))}}}),
+			expectedPort: 9100,
+			expectedPath: "/metrics",
+		},
+		{
+			name: "PrometheusServlet on the Spark UI",
+			app: runningApp(v1beta2.SparkApplicationSpec{SparkConf: map[string]string{
+				"spark.metrics.conf.*.sink.prometheusServlet.class": "org.apache.spark.metrics.sink.PrometheusServlet",
+				"spark.ui.port": "4041",
+			}}),
+			expectedPort: 4041,
+			expectedPath: "/metrics/prometheus",
+		},
+		{
+			name: "PrometheusServlet of the driver on a configured path",
+			app: runningApp(v1beta2.SparkApplicationSpec{SparkConf: map[string]string{
+				"spark.metrics.conf.*.sink.prometheusServlet.class":      "org.apache.spark.metrics.sink.PrometheusServlet",
+				"spark.metrics.conf.driver.sink.prometheusServlet.class": "org.apache.spark.metrics.sink.PrometheusServlet",
+				"spark.metrics.conf.driver.sink.prometheusServlet.path":  "/metrics/driver/prometheus",
+			}}),
+			expectedPort: 4040,
+			expectedPath: "/metrics/driver/prometheus",
+		},
+		{
+			name:           "metrics not exposed",
+			app:            runningApp(v1beta2.SparkApplicationSpec{Monitoring: &v1beta2.MonitoringSpec{ExposeExecutorMetrics: true, Prometheus: &v1beta2.PrometheusSpec{}}}),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "application not running",
+			app:            completedApp,
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &SparkApplicationRepositoryMock{
+				GetFunc: func(namespace string, name string) (*v1beta2.SparkApplication, error) {
+					return tc.app, nil
+				},
+			}
+			proxy := &DriverProxyMock{
+				ProxyDriverFunc: func(ctx context.Context, namespace string, podName string, port int32, path string) (io.ReadCloser, error) {
+					return io.NopCloser(strings.NewReader("up 1\n")), nil
+				},
+			}
+
+			metrics, err := NewDriverMetricsService(repo, proxy).DriverMetrics(context.Background(), "team-a", "app")
+			if tc.expectedStatus != 0 {
+				assert.Equal(t, tc.expectedStatus, gatewayerrors.NewFrom(err).Status, "status should match")
+				assert.Empty(t, proxy.ProxyDriverCalls(), "driver should not be proxied")
+				return
+			}
+
+			assert.NoError(t, err, "driver metrics should not error")
+			assert.Len(t, proxy.ProxyDriverCalls(), 1, "driver should be proxied once")
+			call := proxy.ProxyDriverCalls()[0]
+			assert.Equal(t, "app-driver", call.PodName, "driver pod should be proxied")
+			assert.Equal(t, tc.expectedPort, call.Port, "port should match")
+			assert.Equal(t, tc.expectedPath, call.Path, "path should match")
+			body, _ := io.ReadAll(metrics)
+			assert.Equal(t, "up 1\n", string(body), "metrics should be returned")
+		})
+	}
+}
+
+func TestNewDriverMetricsServiceWithoutProxy(t *testing.T) {
+	assert.Nil(t, NewDriverMetricsService(&SparkApplicationRepositoryMock{}, nil), "service should be nil without a driver proxy")
+}
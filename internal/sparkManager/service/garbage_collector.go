@@ -0,0 +1,166 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/metrics"
+)
+
+const (
+	GarbageCollectionDeleted = "deleted"
+	GarbageCollectionFailure = "failure"
+)
+
+// GarbageCollector periodically deletes the SparkApplications submitted through the Gateway, i.e. labeled with the
+// Gateway's selector, that have been terminal for longer than the applicationRetention of their namespace. Namespaces
+// with no retention keep their applications until they are deleted, or until the operator deletes them after
+// spec.timeToLiveSeconds.
+type GarbageCollector struct {
+	sparkApplicationRepository SparkApplicationRepository
+	cluster                    domain.KubeCluster
+	interval                   time.Duration
+	selectorKey                string
+	selectorValue              string
+	metrics                    metrics.Metrics
+	now                        func() time.Time
+}
+
+// NewGarbageCollector returns a GarbageCollector deleting expired SparkApplications labeled selectorKey: selectorValue
+// every config.Interval, or nil if no namespace of cluster has a retention
+func NewGarbageCollector(sparkAppRepo SparkApplicationRepository, cluster domain.KubeCluster, config config.GarbageCollectorConfig, selectorKey string, selectorValue string) *GarbageCollector {
+	for _, namespace := range cluster.Namespaces {
+		if namespace.ApplicationRetention > 0 {
+			return &GarbageCollector{
+				sparkApplicationRepository: sparkAppRepo,
+				cluster:                    cluster,
+				interval:                   config.Interval,
+				selectorKey:                selectorKey,
+				selectorValue:              selectorValue,
+				metrics:                    metrics.Definition,
+				now:                        time.Now,
+			}
+		}
+	}
+
+	return nil
+}
+
+// Run calls Collect every interval until ctx is done
+func (c *GarbageCollector) Run(ctx context.Context) {
+	klog.Infof("Starting garbage collector for cluster '%s' with interval %s", c.cluster.Name, c.interval)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			klog.Infof("Stopping garbage collector")
+			return
+		case <-ticker.C:
+			if err := c.Collect(ctx); err != nil {
+				klog.Errorf("error garbage collecting SparkApplications of cluster '%s': %v", c.cluster.Name, err)
+			}
+		}
+	}
+}
+
+// Collect deletes the SparkApplications of each namespace with a retention that were submitted through the Gateway and
+// terminated before it
+func (c *GarbageCollector) Collect(ctx context.Context) error {
+	now := c.now()
+
+	var errs []error
+	for _, namespace := range c.cluster.Namespaces {
+		if namespace.ApplicationRetention <= 0 {
+			continue
+		}
+
+		sparkApps, err := c.sparkApplicationRepository.List(namespace.Name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error listing SparkApplications in namespace '%s': %w", namespace.Name, err))
+			continue
+		}
+
+		for _, sparkApp := range sparkApps {
+			if !c.selected(sparkApp) {
+				continue
+			}
+
+			terminated, ok := terminationTime(sparkApp)
+			if !ok || now.Sub(terminated) < namespace.ApplicationRetention {
+				continue
+			}
+
+			if err := c.delete(ctx, sparkApp, namespace.ApplicationRetention); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *GarbageCollector) delete(ctx context.Context, sparkApp *v1beta2.SparkApplication, retention time.Duration) error {
+	klog.Infof("Deleting SparkApplication '%s/%s' in cluster '%s', terminal in state %s for more than %s", sparkApp.Namespace, sparkApp.Name, c.cluster.Name, sparkApp.Status.AppState.State, retention)
+
+	err := c.sparkApplicationRepository.Delete(ctx, sparkApp.Namespace, sparkApp.Name)
+	var gatewayErr gatewayerrors.GatewayError
+	if errors.As(err, &gatewayErr) && gatewayErr.Status == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		c.metrics.RecordGarbageCollected(c.cluster.Name, sparkApp.Namespace, GarbageCollectionFailure)
+		return fmt.Errorf("error deleting SparkApplication '%s/%s': %w", sparkApp.Namespace, sparkApp.Name, err)
+	}
+	c.metrics.RecordGarbageCollected(c.cluster.Name, sparkApp.Namespace, GarbageCollectionDeleted)
+
+	return nil
+}
+
+// selected is true if sparkApp is labeled with the Gateway's selector. Applications without it were not submitted
+// through this Gateway, e.g. on a cluster shared with another Gateway, and are never collected.
+func (c *GarbageCollector) selected(sparkApp *v1beta2.SparkApplication) bool {
+	return c.selectorKey != "" && c.selectorValue != "" && sparkApp.Labels[c.selectorKey] == c.selectorValue
+}
+
+// terminationTime returns when sparkApp terminated, if it is terminal. Applications without a termination time, e.g.
+// those that failed to start, are timed from their last submission attempt, or from their creation.
+func terminationTime(sparkApp *v1beta2.SparkApplication) (time.Time, bool) {
+	if !domain.IsTerminalApplicationState(sparkApp.Status.AppState.State) {
+		return time.Time{}, false
+	}
+
+	for _, t := range []time.Time{sparkApp.Status.TerminationTime.Time, sparkApp.Status.LastSubmissionAttemptTime.Time, sparkApp.CreationTimestamp.Time} {
+		if !t.IsZero() {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
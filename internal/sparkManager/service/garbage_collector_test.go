@@ -0,0 +1,123 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+func collectableApp(name string, state v1beta2.ApplicationStateType, terminated time.Time, gatewayLabeled bool) *v1beta2.SparkApplication {
+	labels := map[string]string{domain.GATEWAY_USER_LABEL: "user"}
+	if gatewayLabeled {
+		labels["spark-gateway/managed"] = "true"
+	}
+	return &v1beta2.SparkApplication{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", Labels: labels, CreationTimestamp: metav1.NewTime(terminated.Add(-time.Hour))},
+		Status: v1beta2.SparkApplicationStatus{
+			AppState:        v1beta2.ApplicationState{State: state},
+			TerminationTime: metav1.NewTime(terminated),
+		},
+	}
+}
+
+func TestGarbageCollectorCollect(t *testing.T) {
+	now := time.Date(2025, 1, 8, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-48 * time.Hour)
+	recent := now.Add(-time.Hour)
+
+	noTerminationTime := collectableApp("failed-without-termination-time", v1beta2.ApplicationStateFailed, time.Time{}, true)
+	noTerminationTime.CreationTimestamp = metav1.NewTime(old)
+
+	otherGateway := collectableApp("submitted-through-other-gateway", v1beta2.ApplicationStateCompleted, old, false)
+	otherGateway.Labels["spark-gateway/managed"] = "other"
+
+	sparkApps := map[string][]*v1beta2.SparkApplication{
+		"ns": {
+			collectableApp("old-completed", v1beta2.ApplicationStateCompleted, old, true),
+			collectableApp("old-failed", v1beta2.ApplicationStateFailed, old, true),
+			noTerminationTime,
+			collectableApp("recent-completed", v1beta2.ApplicationStateCompleted, recent, true),
+			collectableApp("running", v1beta2.ApplicationStateRunning, time.Time{}, true),
+			collectableApp("not-submitted-through-gateway", v1beta2.ApplicationStateCompleted, old, false),
+			otherGateway,
+		},
+		"kept": {
+			collectableApp("old-completed-kept", v1beta2.ApplicationStateCompleted, old, true),
+		},
+	}
+
+	var deleted []string
+	sparkAppRepo := &SparkApplicationRepositoryMock{
+		ListFunc: func(namespace string) ([]*v1beta2.SparkApplication, error) {
+			return sparkApps[namespace], nil
+		},
+		DeleteFunc: func(ctx context.Context, namespace string, name string) error {
+			deleted = append(deleted, name)
+			return nil
+		},
+	}
+
+	cluster := domain.KubeCluster{Name: "cluster", Namespaces: []domain.KubeNamespace{{Name: "ns", ApplicationRetention: 24 * time.Hour}, {Name: "kept"}}}
+	collector := NewGarbageCollector(sparkAppRepo, cluster, config.GarbageCollectorConfig{Enable: true, Interval: time.Minute}, "spark-gateway/managed", "true")
+	collector.now = func() time.Time { return now }
+
+	assert.NoError(t, collector.Collect(context.Background()), "Collect should not return error")
+	assert.ElementsMatch(t, []string{"old-completed", "old-failed", "failed-without-termination-time"}, deleted, "only Gateway applications terminal past their namespace's retention should be deleted")
+	assert.Len(t, sparkAppRepo.ListCalls(), 1, "namespaces without a retention should not be listed")
+}
+
+func TestGarbageCollectorCollectErrors(t *testing.T) {
+	now := time.Date(2025, 1, 8, 12, 0, 0, 0, time.UTC)
+
+	sparkAppRepo := &SparkApplicationRepositoryMock{
+		ListFunc: func(namespace string) ([]*v1beta2.SparkApplication, error) {
+			return []*v1beta2.SparkApplication{
+				collectableApp("already-deleted", v1beta2.ApplicationStateCompleted, now.Add(-48*time.Hour), true),
+				collectableApp("forbidden", v1beta2.ApplicationStateCompleted, now.Add(-48*time.Hour), true),
+			}, nil
+		},
+		DeleteFunc: func(ctx context.Context, namespace string, name string) error {
+			if name == "already-deleted" {
+				return gatewayerrors.NewNotFound(errors.New("not found"))
+			}
+			return gatewayerrors.NewForbidden(errors.New("forbidden"))
+		},
+	}
+
+	cluster := domain.KubeCluster{Name: "cluster", Namespaces: []domain.KubeNamespace{{Name: "ns", ApplicationRetention: 24 * time.Hour}}}
+	collector := NewGarbageCollector(sparkAppRepo, cluster, config.GarbageCollectorConfig{Enable: true, Interval: time.Minute}, "spark-gateway/managed", "true")
+	collector.now = func() time.Time { return now }
+
+	err := collector.Collect(context.Background())
+	assert.ErrorContains(t, err, "error deleting SparkApplication 'ns/forbidden'", "delete errors should be returned")
+	assert.NotContains(t, err.Error(), "already-deleted", "applications already deleted should not be errors")
+}
+
+func TestNewGarbageCollectorWithoutRetention(t *testing.T) {
+	cluster := domain.KubeCluster{Name: "cluster", Namespaces: []domain.KubeNamespace{{Name: "forever"}}}
+	assert.Nil(t, NewGarbageCollector(&SparkApplicationRepositoryMock{}, cluster, config.GarbageCollectorConfig{Enable: true, Interval: time.Minute}, "spark-gateway/managed", "true"), "clusters keeping applications forever should not be collected")
+}
@@ -6,6 +6,8 @@ package service
 import (
 	"context"
 	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"io"
+	"k8s.io/apimachinery/pkg/watch"
 	"sync"
 )
 
@@ -28,12 +30,15 @@ var _ SparkApplicationRepository = &SparkApplicationRepositoryMock{}
 //			GetFunc: func(namespace string, name string) (*v1beta2.SparkApplication, error) {
 //				panic("mock out the Get method")
 //			},
-//			GetLogsFunc: func(namespace string, name string, tailLines int64) (*string, error) {
-//				panic("mock out the GetLogs method")
-//			},
 //			ListFunc: func(namespace string) ([]*v1beta2.SparkApplication, error) {
 //				panic("mock out the List method")
 //			},
+//			StreamLogsFunc: func(ctx context.Context, namespace string, name string, tailLines *int64) (io.ReadCloser, error) {
+//				panic("mock out the StreamLogs method")
+//			},
+//			WatchFunc: func(ctx context.Context, namespace string, labelSelector string, resourceVersion string) (watch.Interface, error) {
+//				panic("mock out the Watch method")
+//			},
 //		}
 //
 //		// use mockedSparkApplicationRepository in code that requires SparkApplicationRepository
@@ -50,12 +55,15 @@ type SparkApplicationRepositoryMock struct {
 	// GetFunc mocks the Get method.
 	GetFunc func(namespace string, name string) (*v1beta2.SparkApplication, error)
 
-	// GetLogsFunc mocks the GetLogs method.
-	GetLogsFunc func(namespace string, name string, tailLines int64) (*string, error)
-
 	// ListFunc mocks the List method.
 	ListFunc func(namespace string) ([]*v1beta2.SparkApplication, error)
 
+	// StreamLogsFunc mocks the StreamLogs method.
+	StreamLogsFunc func(ctx context.Context, namespace string, name string, tailLines *int64) (io.ReadCloser, error)
+
+	// WatchFunc mocks the Watch method.
+	WatchFunc func(ctx context.Context, namespace string, labelSelector string, resourceVersion string) (watch.Interface, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// Create holds details about calls to the Create method.
@@ -81,26 +89,40 @@ type SparkApplicationRepositoryMock struct {
 			// Name is the name argument value.
 			Name string
 		}
-		// GetLogs holds details about calls to the GetLogs method.
-		GetLogs []struct {
+		// List holds details about calls to the List method.
+		List []struct {
+			// Namespace is the namespace argument value.
+			Namespace string
+		}
+		// StreamLogs holds details about calls to the StreamLogs method.
+		StreamLogs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// Namespace is the namespace argument value.
 			Namespace string
 			// Name is the name argument value.
 			Name string
 			// TailLines is the tailLines argument value.
-			TailLines int64
+			TailLines *int64
 		}
-		// List holds details about calls to the List method.
-		List []struct {
+		// Watch holds details about calls to the Watch method.
+		Watch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// Namespace is the namespace argument value.
 			Namespace string
+			// LabelSelector is the labelSelector argument value.
+			LabelSelector string
+			// ResourceVersion is the resourceVersion argument value.
+			ResourceVersion string
 		}
 	}
-	lockCreate  sync.RWMutex
-	lockDelete  sync.RWMutex
-	lockGet     sync.RWMutex
-	lockGetLogs sync.RWMutex
-	lockList    sync.RWMutex
+	lockCreate     sync.RWMutex
+	lockDelete     sync.RWMutex
+	lockGet        sync.RWMutex
+	lockList       sync.RWMutex
+	lockStreamLogs sync.RWMutex
+	lockWatch      sync.RWMutex
 }
 
 // Create calls CreateFunc.
@@ -215,74 +237,122 @@ func (mock *SparkApplicationRepositoryMock) GetCalls() []struct {
 	return calls
 }
 
-// GetLogs calls GetLogsFunc.
-func (mock *SparkApplicationRepositoryMock) GetLogs(namespace string, name string, tailLines int64) (*string, error) {
-	if mock.GetLogsFunc == nil {
-		panic("SparkApplicationRepositoryMock.GetLogsFunc: method is nil but SparkApplicationRepository.GetLogs was just called")
+// List calls ListFunc.
+func (mock *SparkApplicationRepositoryMock) List(namespace string) ([]*v1beta2.SparkApplication, error) {
+	if mock.ListFunc == nil {
+		panic("SparkApplicationRepositoryMock.ListFunc: method is nil but SparkApplicationRepository.List was just called")
 	}
 	callInfo := struct {
 		Namespace string
+	}{
+		Namespace: namespace,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(namespace)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedSparkApplicationRepository.ListCalls())
+func (mock *SparkApplicationRepositoryMock) ListCalls() []struct {
+	Namespace string
+} {
+	var calls []struct {
+		Namespace string
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// StreamLogs calls StreamLogsFunc.
+func (mock *SparkApplicationRepositoryMock) StreamLogs(ctx context.Context, namespace string, name string, tailLines *int64) (io.ReadCloser, error) {
+	if mock.StreamLogsFunc == nil {
+		panic("SparkApplicationRepositoryMock.StreamLogsFunc: method is nil but SparkApplicationRepository.StreamLogs was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Namespace string
 		Name      string
-		TailLines int64
+		TailLines *int64
 	}{
+		Ctx:       ctx,
 		Namespace: namespace,
 		Name:      name,
 		TailLines: tailLines,
 	}
-	mock.lockGetLogs.Lock()
-	mock.calls.GetLogs = append(mock.calls.GetLogs, callInfo)
-	mock.lockGetLogs.Unlock()
-	return mock.GetLogsFunc(namespace, name, tailLines)
+	mock.lockStreamLogs.Lock()
+	mock.calls.StreamLogs = append(mock.calls.StreamLogs, callInfo)
+	mock.lockStreamLogs.Unlock()
+	return mock.StreamLogsFunc(ctx, namespace, name, tailLines)
 }
 
-// GetLogsCalls gets all the calls that were made to GetLogs.
+// StreamLogsCalls gets all the calls that were made to StreamLogs.
 // Check the length with:
 //
-//	len(mockedSparkApplicationRepository.GetLogsCalls())
-func (mock *SparkApplicationRepositoryMock) GetLogsCalls() []struct {
+//	len(mockedSparkApplicationRepository.StreamLogsCalls())
+func (mock *SparkApplicationRepositoryMock) StreamLogsCalls() []struct {
+	Ctx       context.Context
 	Namespace string
 	Name      string
-	TailLines int64
+	TailLines *int64
 } {
 	var calls []struct {
+		Ctx       context.Context
 		Namespace string
 		Name      string
-		TailLines int64
+		TailLines *int64
 	}
-	mock.lockGetLogs.RLock()
-	calls = mock.calls.GetLogs
-	mock.lockGetLogs.RUnlock()
+	mock.lockStreamLogs.RLock()
+	calls = mock.calls.StreamLogs
+	mock.lockStreamLogs.RUnlock()
 	return calls
 }
 
-// List calls ListFunc.
-func (mock *SparkApplicationRepositoryMock) List(namespace string) ([]*v1beta2.SparkApplication, error) {
-	if mock.ListFunc == nil {
-		panic("SparkApplicationRepositoryMock.ListFunc: method is nil but SparkApplicationRepository.List was just called")
+// Watch calls WatchFunc.
+func (mock *SparkApplicationRepositoryMock) Watch(ctx context.Context, namespace string, labelSelector string, resourceVersion string) (watch.Interface, error) {
+	if mock.WatchFunc == nil {
+		panic("SparkApplicationRepositoryMock.WatchFunc: method is nil but SparkApplicationRepository.Watch was just called")
 	}
 	callInfo := struct {
-		Namespace string
+		Ctx             context.Context
+		Namespace       string
+		LabelSelector   string
+		ResourceVersion string
 	}{
-		Namespace: namespace,
+		Ctx:             ctx,
+		Namespace:       namespace,
+		LabelSelector:   labelSelector,
+		ResourceVersion: resourceVersion,
 	}
-	mock.lockList.Lock()
-	mock.calls.List = append(mock.calls.List, callInfo)
-	mock.lockList.Unlock()
-	return mock.ListFunc(namespace)
+	mock.lockWatch.Lock()
+	mock.calls.Watch = append(mock.calls.Watch, callInfo)
+	mock.lockWatch.Unlock()
+	return mock.WatchFunc(ctx, namespace, labelSelector, resourceVersion)
 }
 
-// ListCalls gets all the calls that were made to List.
+// WatchCalls gets all the calls that were made to Watch.
 // Check the length with:
 //
-//	len(mockedSparkApplicationRepository.ListCalls())
-func (mock *SparkApplicationRepositoryMock) ListCalls() []struct {
-	Namespace string
+//	len(mockedSparkApplicationRepository.WatchCalls())
+func (mock *SparkApplicationRepositoryMock) WatchCalls() []struct {
+	Ctx             context.Context
+	Namespace       string
+	LabelSelector   string
+	ResourceVersion string
 } {
 	var calls []struct {
-		Namespace string
+		Ctx             context.Context
+		Namespace       string
+		LabelSelector   string
+		ResourceVersion string
 	}
-	mock.lockList.RLock()
-	calls = mock.calls.List
-	mock.lockList.RUnlock()
+	mock.lockWatch.RLock()
+	calls = mock.calls.Watch
+	mock.lockWatch.RUnlock()
 	return calls
 }
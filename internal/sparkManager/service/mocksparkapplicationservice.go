@@ -7,6 +7,8 @@ import (
 	"context"
 	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
 	"github.com/slackhq/spark-gateway/internal/domain"
+	"io"
+	"k8s.io/apimachinery/pkg/watch"
 	"sync"
 )
 
@@ -20,24 +22,54 @@ var _ SparkApplicationService = &SparkApplicationServiceMock{}
 //
 //		// make and configure a mocked SparkApplicationService
 //		mockedSparkApplicationService := &SparkApplicationServiceMock{
+//			CacheStatusFunc: func(namespace string) domain.CacheStatus {
+//				panic("mock out the CacheStatus method")
+//			},
+//			CostFunc: func(namespace string, name string) (*domain.ApplicationCost, error) {
+//				panic("mock out the Cost method")
+//			},
+//			CountsFunc: func(namespace string) (*domain.SparkManagerApplicationCounts, error) {
+//				panic("mock out the Counts method")
+//			},
 //			CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
 //				panic("mock out the Create method")
 //			},
 //			DeleteFunc: func(ctx context.Context, namespace string, name string) error {
 //				panic("mock out the Delete method")
 //			},
+//			ExecutorLogsFunc: func(ctx context.Context, namespace string, name string, executorId string, tailLines int64) (io.ReadCloser, error) {
+//				panic("mock out the ExecutorLogs method")
+//			},
+//			FollowLogsFunc: func(ctx context.Context, namespace string, name string, tailLines int64) (io.ReadCloser, error) {
+//				panic("mock out the FollowLogs method")
+//			},
 //			GetFunc: func(namespace string, name string) (*v1beta2.SparkApplication, error) {
 //				panic("mock out the Get method")
 //			},
-//			ListFunc: func(namespace string) ([]*domain.SparkManagerSparkApplicationSummary, error) {
+//			ListFunc: func(namespace string, view domain.SummaryView) ([]*domain.SparkManagerSparkApplicationSummary, error) {
 //				panic("mock out the List method")
 //			},
-//			LogsFunc: func(namespace string, name string, tailLines int64) (*string, error) {
+//			LogsFunc: func(ctx context.Context, namespace string, name string, tailLines int64) (io.ReadCloser, error) {
 //				panic("mock out the Logs method")
 //			},
-//			StatusFunc: func(namespace string, name string) (*v1beta2.SparkApplicationStatus, error) {
+//			PodsFunc: func(ctx context.Context, namespace string, name string) ([]*domain.SparkApplicationPod, error) {
+//				panic("mock out the Pods method")
+//			},
+//			StatusFunc: func(namespace string, name string) (*domain.ApplicationStatus, error) {
 //				panic("mock out the Status method")
 //			},
+//			StreamLogsFunc: func(ctx context.Context, namespace string, name string) (io.ReadCloser, error) {
+//				panic("mock out the StreamLogs method")
+//			},
+//			StreamStatusFunc: func(ctx context.Context, namespace string, name string) (<-chan *domain.ApplicationStatus, error) {
+//				panic("mock out the StreamStatus method")
+//			},
+//			UpdateFunc: func(ctx context.Context, namespace string, name string, update domain.ApplicationUpdate) (*v1beta2.SparkApplication, error) {
+//				panic("mock out the Update method")
+//			},
+//			WatchFunc: func(ctx context.Context, namespace string, labelSelector string, resourceVersion string) (watch.Interface, error) {
+//				panic("mock out the Watch method")
+//			},
 //		}
 //
 //		// use mockedSparkApplicationService in code that requires SparkApplicationService
@@ -45,26 +77,73 @@ var _ SparkApplicationService = &SparkApplicationServiceMock{}
 //
 //	}
 type SparkApplicationServiceMock struct {
+	// CacheStatusFunc mocks the CacheStatus method.
+	CacheStatusFunc func(namespace string) domain.CacheStatus
+
+	// CostFunc mocks the Cost method.
+	CostFunc func(namespace string, name string) (*domain.ApplicationCost, error)
+
+	// CountsFunc mocks the Counts method.
+	CountsFunc func(namespace string) (*domain.SparkManagerApplicationCounts, error)
+
 	// CreateFunc mocks the Create method.
 	CreateFunc func(ctx context.Context, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error)
 
 	// DeleteFunc mocks the Delete method.
 	DeleteFunc func(ctx context.Context, namespace string, name string) error
 
+	// ExecutorLogsFunc mocks the ExecutorLogs method.
+	ExecutorLogsFunc func(ctx context.Context, namespace string, name string, executorId string, tailLines int64) (io.ReadCloser, error)
+
+	// FollowLogsFunc mocks the FollowLogs method.
+	FollowLogsFunc func(ctx context.Context, namespace string, name string, tailLines int64) (io.ReadCloser, error)
+
 	// GetFunc mocks the Get method.
 	GetFunc func(namespace string, name string) (*v1beta2.SparkApplication, error)
 
 	// ListFunc mocks the List method.
-	ListFunc func(namespace string) ([]*domain.SparkManagerSparkApplicationSummary, error)
+	ListFunc func(namespace string, view domain.SummaryView) ([]*domain.SparkManagerSparkApplicationSummary, error)
 
 	// LogsFunc mocks the Logs method.
-	LogsFunc func(namespace string, name string, tailLines int64) (*string, error)
+	LogsFunc func(ctx context.Context, namespace string, name string, tailLines int64) (io.ReadCloser, error)
+
+	// PodsFunc mocks the Pods method.
+	PodsFunc func(ctx context.Context, namespace string, name string) ([]*domain.SparkApplicationPod, error)
 
 	// StatusFunc mocks the Status method.
-	StatusFunc func(namespace string, name string) (*v1beta2.SparkApplicationStatus, error)
+	StatusFunc func(namespace string, name string) (*domain.ApplicationStatus, error)
+
+	// StreamLogsFunc mocks the StreamLogs method.
+	StreamLogsFunc func(ctx context.Context, namespace string, name string) (io.ReadCloser, error)
+
+	// StreamStatusFunc mocks the StreamStatus method.
+	StreamStatusFunc func(ctx context.Context, namespace string, name string) (<-chan *domain.ApplicationStatus, error)
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, namespace string, name string, update domain.ApplicationUpdate) (*v1beta2.SparkApplication, error)
+
+	// WatchFunc mocks the Watch method.
+	WatchFunc func(ctx context.Context, namespace string, labelSelector string, resourceVersion string) (watch.Interface, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// CacheStatus holds details about calls to the CacheStatus method.
+		CacheStatus []struct {
+			// Namespace is the namespace argument value.
+			Namespace string
+		}
+		// Cost holds details about calls to the Cost method.
+		Cost []struct {
+			// Namespace is the namespace argument value.
+			Namespace string
+			// Name is the name argument value.
+			Name string
+		}
+		// Counts holds details about calls to the Counts method.
+		Counts []struct {
+			// Namespace is the namespace argument value.
+			Namespace string
+		}
 		// Create holds details about calls to the Create method.
 		Create []struct {
 			// Ctx is the ctx argument value.
@@ -81,6 +160,30 @@ type SparkApplicationServiceMock struct {
 			// Name is the name argument value.
 			Name string
 		}
+		// ExecutorLogs holds details about calls to the ExecutorLogs method.
+		ExecutorLogs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Namespace is the namespace argument value.
+			Namespace string
+			// Name is the name argument value.
+			Name string
+			// ExecutorId is the executorId argument value.
+			ExecutorId string
+			// TailLines is the tailLines argument value.
+			TailLines int64
+		}
+		// FollowLogs holds details about calls to the FollowLogs method.
+		FollowLogs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Namespace is the namespace argument value.
+			Namespace string
+			// Name is the name argument value.
+			Name string
+			// TailLines is the tailLines argument value.
+			TailLines int64
+		}
 		// Get holds details about calls to the Get method.
 		Get []struct {
 			// Namespace is the namespace argument value.
@@ -92,9 +195,13 @@ type SparkApplicationServiceMock struct {
 		List []struct {
 			// Namespace is the namespace argument value.
 			Namespace string
+			// View is the view argument value.
+			View domain.SummaryView
 		}
 		// Logs holds details about calls to the Logs method.
 		Logs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// Namespace is the namespace argument value.
 			Namespace string
 			// Name is the name argument value.
@@ -102,6 +209,15 @@ type SparkApplicationServiceMock struct {
 			// TailLines is the tailLines argument value.
 			TailLines int64
 		}
+		// Pods holds details about calls to the Pods method.
+		Pods []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Namespace is the namespace argument value.
+			Namespace string
+			// Name is the name argument value.
+			Name string
+		}
 		// Status holds details about calls to the Status method.
 		Status []struct {
 			// Namespace is the namespace argument value.
@@ -109,13 +225,163 @@ type SparkApplicationServiceMock struct {
 			// Name is the name argument value.
 			Name string
 		}
+		// StreamLogs holds details about calls to the StreamLogs method.
+		StreamLogs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Namespace is the namespace argument value.
+			Namespace string
+			// Name is the name argument value.
+			Name string
+		}
+		// StreamStatus holds details about calls to the StreamStatus method.
+		StreamStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Namespace is the namespace argument value.
+			Namespace string
+			// Name is the name argument value.
+			Name string
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Namespace is the namespace argument value.
+			Namespace string
+			// Name is the name argument value.
+			Name string
+			// Update is the update argument value.
+			Update domain.ApplicationUpdate
+		}
+		// Watch holds details about calls to the Watch method.
+		Watch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Namespace is the namespace argument value.
+			Namespace string
+			// LabelSelector is the labelSelector argument value.
+			LabelSelector string
+			// ResourceVersion is the resourceVersion argument value.
+			ResourceVersion string
+		}
+	}
+	lockCacheStatus  sync.RWMutex
+	lockCost         sync.RWMutex
+	lockCounts       sync.RWMutex
+	lockCreate       sync.RWMutex
+	lockDelete       sync.RWMutex
+	lockExecutorLogs sync.RWMutex
+	lockFollowLogs   sync.RWMutex
+	lockGet          sync.RWMutex
+	lockList         sync.RWMutex
+	lockLogs         sync.RWMutex
+	lockPods         sync.RWMutex
+	lockStatus       sync.RWMutex
+	lockStreamLogs   sync.RWMutex
+	lockStreamStatus sync.RWMutex
+	lockUpdate       sync.RWMutex
+	lockWatch        sync.RWMutex
+}
+
+// CacheStatus calls CacheStatusFunc.
+func (mock *SparkApplicationServiceMock) CacheStatus(namespace string) domain.CacheStatus {
+	if mock.CacheStatusFunc == nil {
+		panic("SparkApplicationServiceMock.CacheStatusFunc: method is nil but SparkApplicationService.CacheStatus was just called")
 	}
-	lockCreate sync.RWMutex
-	lockDelete sync.RWMutex
-	lockGet    sync.RWMutex
-	lockList   sync.RWMutex
-	lockLogs   sync.RWMutex
-	lockStatus sync.RWMutex
+	callInfo := struct {
+		Namespace string
+	}{
+		Namespace: namespace,
+	}
+	mock.lockCacheStatus.Lock()
+	mock.calls.CacheStatus = append(mock.calls.CacheStatus, callInfo)
+	mock.lockCacheStatus.Unlock()
+	return mock.CacheStatusFunc(namespace)
+}
+
+// CacheStatusCalls gets all the calls that were made to CacheStatus.
+// Check the length with:
+//
+//	len(mockedSparkApplicationService.CacheStatusCalls())
+func (mock *SparkApplicationServiceMock) CacheStatusCalls() []struct {
+	Namespace string
+} {
+	var calls []struct {
+		Namespace string
+	}
+	mock.lockCacheStatus.RLock()
+	calls = mock.calls.CacheStatus
+	mock.lockCacheStatus.RUnlock()
+	return calls
+}
+
+// Cost calls CostFunc.
+func (mock *SparkApplicationServiceMock) Cost(namespace string, name string) (*domain.ApplicationCost, error) {
+	if mock.CostFunc == nil {
+		panic("SparkApplicationServiceMock.CostFunc: method is nil but SparkApplicationService.Cost was just called")
+	}
+	callInfo := struct {
+		Namespace string
+		Name      string
+	}{
+		Namespace: namespace,
+		Name:      name,
+	}
+	mock.lockCost.Lock()
+	mock.calls.Cost = append(mock.calls.Cost, callInfo)
+	mock.lockCost.Unlock()
+	return mock.CostFunc(namespace, name)
+}
+
+// CostCalls gets all the calls that were made to Cost.
+// Check the length with:
+//
+//	len(mockedSparkApplicationService.CostCalls())
+func (mock *SparkApplicationServiceMock) CostCalls() []struct {
+	Namespace string
+	Name      string
+} {
+	var calls []struct {
+		Namespace string
+		Name      string
+	}
+	mock.lockCost.RLock()
+	calls = mock.calls.Cost
+	mock.lockCost.RUnlock()
+	return calls
+}
+
+// Counts calls CountsFunc.
+func (mock *SparkApplicationServiceMock) Counts(namespace string) (*domain.SparkManagerApplicationCounts, error) {
+	if mock.CountsFunc == nil {
+		panic("SparkApplicationServiceMock.CountsFunc: method is nil but SparkApplicationService.Counts was just called")
+	}
+	callInfo := struct {
+		Namespace string
+	}{
+		Namespace: namespace,
+	}
+	mock.lockCounts.Lock()
+	mock.calls.Counts = append(mock.calls.Counts, callInfo)
+	mock.lockCounts.Unlock()
+	return mock.CountsFunc(namespace)
+}
+
+// CountsCalls gets all the calls that were made to Counts.
+// Check the length with:
+//
+//	len(mockedSparkApplicationService.CountsCalls())
+func (mock *SparkApplicationServiceMock) CountsCalls() []struct {
+	Namespace string
+} {
+	var calls []struct {
+		Namespace string
+	}
+	mock.lockCounts.RLock()
+	calls = mock.calls.Counts
+	mock.lockCounts.RUnlock()
+	return calls
 }
 
 // Create calls CreateFunc.
@@ -194,6 +460,98 @@ func (mock *SparkApplicationServiceMock) DeleteCalls() []struct {
 	return calls
 }
 
+// ExecutorLogs calls ExecutorLogsFunc.
+func (mock *SparkApplicationServiceMock) ExecutorLogs(ctx context.Context, namespace string, name string, executorId string, tailLines int64) (io.ReadCloser, error) {
+	if mock.ExecutorLogsFunc == nil {
+		panic("SparkApplicationServiceMock.ExecutorLogsFunc: method is nil but SparkApplicationService.ExecutorLogs was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Namespace  string
+		Name       string
+		ExecutorId string
+		TailLines  int64
+	}{
+		Ctx:        ctx,
+		Namespace:  namespace,
+		Name:       name,
+		ExecutorId: executorId,
+		TailLines:  tailLines,
+	}
+	mock.lockExecutorLogs.Lock()
+	mock.calls.ExecutorLogs = append(mock.calls.ExecutorLogs, callInfo)
+	mock.lockExecutorLogs.Unlock()
+	return mock.ExecutorLogsFunc(ctx, namespace, name, executorId, tailLines)
+}
+
+// ExecutorLogsCalls gets all the calls that were made to ExecutorLogs.
+// Check the length with:
+//
+//	len(mockedSparkApplicationService.ExecutorLogsCalls())
+func (mock *SparkApplicationServiceMock) ExecutorLogsCalls() []struct {
+	Ctx        context.Context
+	Namespace  string
+	Name       string
+	ExecutorId string
+	TailLines  int64
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Namespace  string
+		Name       string
+		ExecutorId string
+		TailLines  int64
+	}
+	mock.lockExecutorLogs.RLock()
+	calls = mock.calls.ExecutorLogs
+	mock.lockExecutorLogs.RUnlock()
+	return calls
+}
+
+// FollowLogs calls FollowLogsFunc.
+func (mock *SparkApplicationServiceMock) FollowLogs(ctx context.Context, namespace string, name string, tailLines int64) (io.ReadCloser, error) {
+	if mock.FollowLogsFunc == nil {
+		panic("SparkApplicationServiceMock.FollowLogsFunc: method is nil but SparkApplicationService.FollowLogs was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Namespace string
+		Name      string
+		TailLines int64
+	}{
+		Ctx:       ctx,
+		Namespace: namespace,
+		Name:      name,
+		TailLines: tailLines,
+	}
+	mock.lockFollowLogs.Lock()
+	mock.calls.FollowLogs = append(mock.calls.FollowLogs, callInfo)
+	mock.lockFollowLogs.Unlock()
+	return mock.FollowLogsFunc(ctx, namespace, name, tailLines)
+}
+
+// FollowLogsCalls gets all the calls that were made to FollowLogs.
+// Check the length with:
+//
+//	len(mockedSparkApplicationService.FollowLogsCalls())
+func (mock *SparkApplicationServiceMock) FollowLogsCalls() []struct {
+	Ctx       context.Context
+	Namespace string
+	Name      string
+	TailLines int64
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Namespace string
+		Name      string
+		TailLines int64
+	}
+	mock.lockFollowLogs.RLock()
+	calls = mock.calls.FollowLogs
+	mock.lockFollowLogs.RUnlock()
+	return calls
+}
+
 // Get calls GetFunc.
 func (mock *SparkApplicationServiceMock) Get(namespace string, name string) (*v1beta2.SparkApplication, error) {
 	if mock.GetFunc == nil {
@@ -231,19 +589,21 @@ func (mock *SparkApplicationServiceMock) GetCalls() []struct {
 }
 
 // List calls ListFunc.
-func (mock *SparkApplicationServiceMock) List(namespace string) ([]*domain.SparkManagerSparkApplicationSummary, error) {
+func (mock *SparkApplicationServiceMock) List(namespace string, view domain.SummaryView) ([]*domain.SparkManagerSparkApplicationSummary, error) {
 	if mock.ListFunc == nil {
 		panic("SparkApplicationServiceMock.ListFunc: method is nil but SparkApplicationService.List was just called")
 	}
 	callInfo := struct {
 		Namespace string
+		View      domain.SummaryView
 	}{
 		Namespace: namespace,
+		View:      view,
 	}
 	mock.lockList.Lock()
 	mock.calls.List = append(mock.calls.List, callInfo)
 	mock.lockList.Unlock()
-	return mock.ListFunc(namespace)
+	return mock.ListFunc(namespace, view)
 }
 
 // ListCalls gets all the calls that were made to List.
@@ -252,9 +612,11 @@ func (mock *SparkApplicationServiceMock) List(namespace string) ([]*domain.Spark
 //	len(mockedSparkApplicationService.ListCalls())
 func (mock *SparkApplicationServiceMock) ListCalls() []struct {
 	Namespace string
+	View      domain.SummaryView
 } {
 	var calls []struct {
 		Namespace string
+		View      domain.SummaryView
 	}
 	mock.lockList.RLock()
 	calls = mock.calls.List
@@ -263,15 +625,17 @@ func (mock *SparkApplicationServiceMock) ListCalls() []struct {
 }
 
 // Logs calls LogsFunc.
-func (mock *SparkApplicationServiceMock) Logs(namespace string, name string, tailLines int64) (*string, error) {
+func (mock *SparkApplicationServiceMock) Logs(ctx context.Context, namespace string, name string, tailLines int64) (io.ReadCloser, error) {
 	if mock.LogsFunc == nil {
 		panic("SparkApplicationServiceMock.LogsFunc: method is nil but SparkApplicationService.Logs was just called")
 	}
 	callInfo := struct {
+		Ctx       context.Context
 		Namespace string
 		Name      string
 		TailLines int64
 	}{
+		Ctx:       ctx,
 		Namespace: namespace,
 		Name:      name,
 		TailLines: tailLines,
@@ -279,7 +643,7 @@ func (mock *SparkApplicationServiceMock) Logs(namespace string, name string, tai
 	mock.lockLogs.Lock()
 	mock.calls.Logs = append(mock.calls.Logs, callInfo)
 	mock.lockLogs.Unlock()
-	return mock.LogsFunc(namespace, name, tailLines)
+	return mock.LogsFunc(ctx, namespace, name, tailLines)
 }
 
 // LogsCalls gets all the calls that were made to Logs.
@@ -287,11 +651,13 @@ func (mock *SparkApplicationServiceMock) Logs(namespace string, name string, tai
 //
 //	len(mockedSparkApplicationService.LogsCalls())
 func (mock *SparkApplicationServiceMock) LogsCalls() []struct {
+	Ctx       context.Context
 	Namespace string
 	Name      string
 	TailLines int64
 } {
 	var calls []struct {
+		Ctx       context.Context
 		Namespace string
 		Name      string
 		TailLines int64
@@ -302,8 +668,48 @@ func (mock *SparkApplicationServiceMock) LogsCalls() []struct {
 	return calls
 }
 
+// Pods calls PodsFunc.
+func (mock *SparkApplicationServiceMock) Pods(ctx context.Context, namespace string, name string) ([]*domain.SparkApplicationPod, error) {
+	if mock.PodsFunc == nil {
+		panic("SparkApplicationServiceMock.PodsFunc: method is nil but SparkApplicationService.Pods was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Namespace string
+		Name      string
+	}{
+		Ctx:       ctx,
+		Namespace: namespace,
+		Name:      name,
+	}
+	mock.lockPods.Lock()
+	mock.calls.Pods = append(mock.calls.Pods, callInfo)
+	mock.lockPods.Unlock()
+	return mock.PodsFunc(ctx, namespace, name)
+}
+
+// PodsCalls gets all the calls that were made to Pods.
+// Check the length with:
+//
+//	len(mockedSparkApplicationService.PodsCalls())
+func (mock *SparkApplicationServiceMock) PodsCalls() []struct {
+	Ctx       context.Context
+	Namespace string
+	Name      string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Namespace string
+		Name      string
+	}
+	mock.lockPods.RLock()
+	calls = mock.calls.Pods
+	mock.lockPods.RUnlock()
+	return calls
+}
+
 // Status calls StatusFunc.
-func (mock *SparkApplicationServiceMock) Status(namespace string, name string) (*v1beta2.SparkApplicationStatus, error) {
+func (mock *SparkApplicationServiceMock) Status(namespace string, name string) (*domain.ApplicationStatus, error) {
 	if mock.StatusFunc == nil {
 		panic("SparkApplicationServiceMock.StatusFunc: method is nil but SparkApplicationService.Status was just called")
 	}
@@ -337,3 +743,171 @@ func (mock *SparkApplicationServiceMock) StatusCalls() []struct {
 	mock.lockStatus.RUnlock()
 	return calls
 }
+
+// StreamLogs calls StreamLogsFunc.
+func (mock *SparkApplicationServiceMock) StreamLogs(ctx context.Context, namespace string, name string) (io.ReadCloser, error) {
+	if mock.StreamLogsFunc == nil {
+		panic("SparkApplicationServiceMock.StreamLogsFunc: method is nil but SparkApplicationService.StreamLogs was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Namespace string
+		Name      string
+	}{
+		Ctx:       ctx,
+		Namespace: namespace,
+		Name:      name,
+	}
+	mock.lockStreamLogs.Lock()
+	mock.calls.StreamLogs = append(mock.calls.StreamLogs, callInfo)
+	mock.lockStreamLogs.Unlock()
+	return mock.StreamLogsFunc(ctx, namespace, name)
+}
+
+// StreamLogsCalls gets all the calls that were made to StreamLogs.
+// Check the length with:
+//
+//	len(mockedSparkApplicationService.StreamLogsCalls())
+func (mock *SparkApplicationServiceMock) StreamLogsCalls() []struct {
+	Ctx       context.Context
+	Namespace string
+	Name      string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Namespace string
+		Name      string
+	}
+	mock.lockStreamLogs.RLock()
+	calls = mock.calls.StreamLogs
+	mock.lockStreamLogs.RUnlock()
+	return calls
+}
+
+// StreamStatus calls StreamStatusFunc.
+func (mock *SparkApplicationServiceMock) StreamStatus(ctx context.Context, namespace string, name string) (<-chan *domain.ApplicationStatus, error) {
+	if mock.StreamStatusFunc == nil {
+		panic("SparkApplicationServiceMock.StreamStatusFunc: method is nil but SparkApplicationService.StreamStatus was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Namespace string
+		Name      string
+	}{
+		Ctx:       ctx,
+		Namespace: namespace,
+		Name:      name,
+	}
+	mock.lockStreamStatus.Lock()
+	mock.calls.StreamStatus = append(mock.calls.StreamStatus, callInfo)
+	mock.lockStreamStatus.Unlock()
+	return mock.StreamStatusFunc(ctx, namespace, name)
+}
+
+// StreamStatusCalls gets all the calls that were made to StreamStatus.
+// Check the length with:
+//
+//	len(mockedSparkApplicationService.StreamStatusCalls())
+func (mock *SparkApplicationServiceMock) StreamStatusCalls() []struct {
+	Ctx       context.Context
+	Namespace string
+	Name      string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Namespace string
+		Name      string
+	}
+	mock.lockStreamStatus.RLock()
+	calls = mock.calls.StreamStatus
+	mock.lockStreamStatus.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *SparkApplicationServiceMock) Update(ctx context.Context, namespace string, name string, update domain.ApplicationUpdate) (*v1beta2.SparkApplication, error) {
+	if mock.UpdateFunc == nil {
+		panic("SparkApplicationServiceMock.UpdateFunc: method is nil but SparkApplicationService.Update was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Namespace string
+		Name      string
+		Update    domain.ApplicationUpdate
+	}{
+		Ctx:       ctx,
+		Namespace: namespace,
+		Name:      name,
+		Update:    update,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(ctx, namespace, name, update)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedSparkApplicationService.UpdateCalls())
+func (mock *SparkApplicationServiceMock) UpdateCalls() []struct {
+	Ctx       context.Context
+	Namespace string
+	Name      string
+	Update    domain.ApplicationUpdate
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Namespace string
+		Name      string
+		Update    domain.ApplicationUpdate
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
+
+// Watch calls WatchFunc.
+func (mock *SparkApplicationServiceMock) Watch(ctx context.Context, namespace string, labelSelector string, resourceVersion string) (watch.Interface, error) {
+	if mock.WatchFunc == nil {
+		panic("SparkApplicationServiceMock.WatchFunc: method is nil but SparkApplicationService.Watch was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		Namespace       string
+		LabelSelector   string
+		ResourceVersion string
+	}{
+		Ctx:             ctx,
+		Namespace:       namespace,
+		LabelSelector:   labelSelector,
+		ResourceVersion: resourceVersion,
+	}
+	mock.lockWatch.Lock()
+	mock.calls.Watch = append(mock.calls.Watch, callInfo)
+	mock.lockWatch.Unlock()
+	return mock.WatchFunc(ctx, namespace, labelSelector, resourceVersion)
+}
+
+// WatchCalls gets all the calls that were made to Watch.
+// Check the length with:
+//
+//	len(mockedSparkApplicationService.WatchCalls())
+func (mock *SparkApplicationServiceMock) WatchCalls() []struct {
+	Ctx             context.Context
+	Namespace       string
+	LabelSelector   string
+	ResourceVersion string
+} {
+	var calls []struct {
+		Ctx             context.Context
+		Namespace       string
+		LabelSelector   string
+		ResourceVersion string
+	}
+	mock.lockWatch.RLock()
+	calls = mock.calls.Watch
+	mock.lockWatch.RUnlock()
+	return calls
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+)
+
+//go:generate moq -rm -out mocknamespaceprovisioner.go . NamespaceProvisioner
+
+// NamespaceProvisioner creates namespaces ready for SparkApplications. It is implemented by backends that can
+// provision the cluster they run SparkApplications on.
+type NamespaceProvisioner interface {
+	ProvisionNamespace(ctx context.Context, namespace string, provisioning domain.NamespaceProvisioning) error
+	// IsProvisioned returns whether namespace was provisioned by ProvisionNamespace
+	IsProvisioned(ctx context.Context, namespace string) (bool, error)
+}
+
+// ManagedNamespaces are the namespaces SparkManager serves requests for: those configured for its cluster and those
+// provisioned since, so it can't be used to read or change resources in any other namespace of the cluster.
+type ManagedNamespaces struct {
+	// provisioner is nil if the cluster's backend cannot provision namespaces
+	provisioner NamespaceProvisioner
+	// all is set when every namespace is managed
+	all bool
+
+	mu         sync.RWMutex
+	namespaces map[string]bool
+}
+
+func NewManagedNamespaces(cluster domain.KubeCluster, provisioner NamespaceProvisioner) *ManagedNamespaces {
+	namespaces := map[string]bool{}
+	for _, namespace := range cluster.Namespaces {
+		namespaces[namespace.Name] = true
+	}
+	return &ManagedNamespaces{provisioner: provisioner, namespaces: namespaces}
+}
+
+// AllNamespaces returns ManagedNamespaces managing every namespace, for SparkManagers that don't run SparkApplications
+// in a real cluster
+func AllNamespaces() *ManagedNamespaces {
+	return &ManagedNamespaces{all: true, namespaces: map[string]bool{}}
+}
+
+// Manages returns whether namespace is managed. Namespaces that aren't configured are looked up through the provisioner,
+// since they may have been provisioned through another SparkManager replica or before a restart, and remembered once
+// found.
+func (m *ManagedNamespaces) Manages(ctx context.Context, namespace string) (bool, error) {
+	m.mu.RLock()
+	managed := m.all || m.namespaces[namespace]
+	m.mu.RUnlock()
+	if managed || m.provisioner == nil {
+		return managed, nil
+	}
+
+	provisioned, err := m.provisioner.IsProvisioned(ctx, namespace)
+	if err != nil {
+		return false, err
+	}
+	if provisioned {
+		m.Add(namespace)
+	}
+
+	return provisioned, nil
+}
+
+// Namespaces returns the known managed namespaces, sorted: the configured ones and those provisioned or found
+// provisioned since SparkManager started
+func (m *ManagedNamespaces) Namespaces() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	namespaces := make([]string, 0, len(m.namespaces))
+	for namespace := range m.namespaces {
+		namespaces = append(namespaces, namespace)
+	}
+	slices.Sort(namespaces)
+	return namespaces
+}
+
+// Add adds namespace to the managed namespaces, once it has been provisioned
+func (m *ManagedNamespaces) Add(namespace string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.namespaces[namespace] = true
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+)
+
+func TestManagedNamespacesManages(t *testing.T) {
+	cluster := domain.KubeCluster{Name: "cluster", Namespaces: []domain.KubeNamespace{{Name: "team-a"}}}
+	provisioner := &NamespaceProvisionerMock{
+		IsProvisionedFunc: func(ctx context.Context, namespace string) (bool, error) {
+			switch namespace {
+			case "team-b":
+				return true, nil
+			case "unreachable":
+				return false, errors.New("connection refused")
+			}
+			return false, nil
+		},
+	}
+	namespaces := NewManagedNamespaces(cluster, provisioner)
+
+	tests := []struct {
+		namespace   string
+		wantManaged bool
+		wantErr     bool
+	}{
+		{"team-a", true, false},
+		{"team-b", true, false},
+		{"kube-system", false, false},
+		{"unreachable", false, true},
+	}
+	for _, test := range tests {
+		t.Run(test.namespace, func(t *testing.T) {
+			managed, err := namespaces.Manages(context.Background(), test.namespace)
+			assert.Equal(t, test.wantErr, err != nil, "unexpected error: %v", err)
+			assert.Equal(t, test.wantManaged, managed, "managed")
+		})
+	}
+
+	// Configured and already provisioned namespaces aren't looked up again
+	calls := len(provisioner.IsProvisionedCalls())
+	for _, namespace := range []string{"team-a", "team-b"} {
+		managed, _ := namespaces.Manages(context.Background(), namespace)
+		assert.True(t, managed, "namespace '%s' should be managed", namespace)
+	}
+	assert.Len(t, provisioner.IsProvisionedCalls(), calls, "managed namespaces should be remembered")
+}
+
+func TestManagedNamespacesWithoutProvisioner(t *testing.T) {
+	namespaces := NewManagedNamespaces(domain.KubeCluster{Namespaces: []domain.KubeNamespace{{Name: "team-a"}}}, nil)
+
+	managed, err := namespaces.Manages(context.Background(), "team-b")
+	assert.Nil(t, err, "err should be nil")
+	assert.False(t, managed, "namespaces that aren't configured should not be managed")
+
+	namespaces.Add("team-b")
+	managed, _ = namespaces.Manages(context.Background(), "team-b")
+	assert.True(t, managed, "added namespaces should be managed")
+}
+
+func TestAllNamespaces(t *testing.T) {
+	managed, err := AllNamespaces().Manages(context.Background(), "any")
+	assert.Nil(t, err, "err should be nil")
+	assert.True(t, managed, "every namespace should be managed")
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/metrics"
+)
+
+//go:generate moq -rm -out mockoperatorhealthchecker.go . OperatorHealthChecker
+
+// OperatorHealthChecker reports whether the cluster's Spark Operator is running
+type OperatorHealthChecker interface {
+	Health() domain.OperatorHealth
+}
+
+// DeploymentOperatorHealthChecker checks the Spark Operator is running from the available replicas of its Deployments
+type DeploymentOperatorHealthChecker struct {
+	k8sClient   kubernetes.Interface
+	cluster     domain.KubeCluster
+	config      domain.OperatorHealthConfig
+	deployments []string
+	metrics     metrics.Metrics
+	now         func() time.Time
+
+	mu     sync.RWMutex
+	health domain.OperatorHealth
+}
+
+func NewDeploymentOperatorHealthChecker(k8sClient kubernetes.Interface, cluster domain.KubeCluster) *DeploymentOperatorHealthChecker {
+	deployments := cluster.OperatorHealth.Deployments
+	if len(deployments) == 0 {
+		deployments = domain.DefaultOperatorDeployments
+	}
+
+	return &DeploymentOperatorHealthChecker{
+		k8sClient:   k8sClient,
+		cluster:     cluster,
+		config:      cluster.OperatorHealth,
+		deployments: deployments,
+		metrics:     metrics.Definition,
+		now:         time.Now,
+	}
+}
+
+// Health returns the result of the latest Check
+func (c *DeploymentOperatorHealthChecker) Health() domain.OperatorHealth {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.health
+}
+
+// Run calls Check every config.Interval until ctx is done
+func (c *DeploymentOperatorHealthChecker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Check(ctx)
+		}
+	}
+}
+
+// Check reads the Spark Operator Deployments and records whether all of them have an available replica
+func (c *DeploymentOperatorHealthChecker) Check(ctx context.Context) {
+	var problems []string
+	for _, name := range c.deployments {
+		deployment, err := c.k8sClient.AppsV1().Deployments(c.config.Namespace).Get(ctx, name, metav1.GetOptions{})
+		switch {
+		case errors.IsNotFound(err):
+			problems = append(problems, fmt.Sprintf("deployment '%s/%s' not found", c.config.Namespace, name))
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("error getting deployment '%s/%s': %v", c.config.Namespace, name, err))
+		case deployment.Status.AvailableReplicas == 0:
+			problems = append(problems, fmt.Sprintf("deployment '%s/%s' has no available replicas", c.config.Namespace, name))
+		}
+	}
+
+	health := domain.OperatorHealth{
+		Healthy:   len(problems) == 0,
+		Message:   strings.Join(problems, "; "),
+		CheckedAt: c.now().UTC(),
+	}
+
+	c.mu.Lock()
+	wasHealthy := c.health.Healthy || c.health.CheckedAt.IsZero()
+	c.health = health
+	c.mu.Unlock()
+
+	c.metrics.SetOperatorUp(c.cluster.Name, health.Healthy)
+	if wasHealthy && !health.Healthy {
+		klog.Errorf("Spark Operator in cluster '%s' is down: %s", c.cluster.Name, health.Message)
+	} else if !wasHealthy && health.Healthy {
+		klog.Infof("Spark Operator in cluster '%s' has recovered", c.cluster.Name)
+	}
+}
+
+type operatorHealthApplicationService struct {
+	SparkApplicationService
+	cluster        domain.KubeCluster
+	operatorHealth OperatorHealthChecker
+}
+
+// NewOperatorHealthApplicationService returns a SparkApplicationService rejecting submissions while operatorHealth
+// reports the Spark Operator is down, since the operator wouldn't pick the SparkApplications up
+func NewOperatorHealthApplicationService(appService SparkApplicationService, cluster domain.KubeCluster, operatorHealth OperatorHealthChecker) SparkApplicationService {
+	return &operatorHealthApplicationService{
+		SparkApplicationService: appService,
+		cluster:                 cluster,
+		operatorHealth:          operatorHealth,
+	}
+}
+
+func (s *operatorHealthApplicationService) Create(ctx context.Context, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
+	if health := s.operatorHealth.Health(); !health.Healthy {
+		return nil, gatewayerrors.NewUnavailable(fmt.Errorf("the Spark Operator in cluster '%s' is not running, submissions are rejected until it recovers: %s", s.cluster.Name, health.Message))
+	}
+
+	return s.SparkApplicationService.Create(ctx, application)
+}
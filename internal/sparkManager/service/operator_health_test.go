@@ -0,0 +1,143 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+func operatorDeployment(name string, availableReplicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "spark-operator"},
+		Status:     appsv1.DeploymentStatus{AvailableReplicas: availableReplicas},
+	}
+}
+
+func TestDeploymentOperatorHealthCheckerCheck(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		deployments   []string
+		objects       []*appsv1.Deployment
+		expectHealthy bool
+		expectMessage string
+	}{
+		{
+			name:          "healthy when default deployments are available",
+			objects:       []*appsv1.Deployment{operatorDeployment("spark-operator-controller", 1), operatorDeployment("spark-operator-webhook", 2)},
+			expectHealthy: true,
+		},
+		{
+			name:          "unhealthy when a deployment has no available replicas",
+			objects:       []*appsv1.Deployment{operatorDeployment("spark-operator-controller", 0), operatorDeployment("spark-operator-webhook", 1)},
+			expectMessage: "deployment 'spark-operator/spark-operator-controller' has no available replicas",
+		},
+		{
+			name:          "unhealthy when a deployment is missing",
+			objects:       []*appsv1.Deployment{operatorDeployment("spark-operator-controller", 1)},
+			expectMessage: "deployment 'spark-operator/spark-operator-webhook' not found",
+		},
+		{
+			name:          "checks configured deployments",
+			deployments:   []string{"operator"},
+			objects:       []*appsv1.Deployment{operatorDeployment("operator", 1)},
+			expectHealthy: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sClient := fake.NewClientset()
+			for _, deployment := range tc.objects {
+				_, err := k8sClient.AppsV1().Deployments(deployment.Namespace).Create(context.Background(), deployment, metav1.CreateOptions{})
+				assert.NoError(t, err, "creating deployment should not error")
+			}
+
+			cluster := testCluster
+			cluster.OperatorHealth = domain.OperatorHealthConfig{Enable: true, Namespace: "spark-operator", Deployments: tc.deployments, Interval: time.Minute}
+			checker := NewDeploymentOperatorHealthChecker(k8sClient, cluster)
+			checker.now = func() time.Time { return now }
+
+			checker.Check(context.Background())
+
+			health := checker.Health()
+			assert.Equal(t, tc.expectHealthy, health.Healthy, "healthy should match")
+			assert.Equal(t, tc.expectMessage, health.Message, "message should match")
+			assert.Equal(t, now, health.CheckedAt, "checkedAt should be the check time")
+		})
+	}
+}
+
+func TestOperatorHealthApplicationServiceCreate(t *testing.T) {
+	testCases := []struct {
+		name         string
+		health       domain.OperatorHealth
+		expectCreate bool
+	}{
+		{
+			name:         "creates when operator is healthy",
+			health:       domain.OperatorHealth{Healthy: true},
+			expectCreate: true,
+		},
+		{
+			name:   "rejects when operator is down",
+			health: domain.OperatorHealth{Message: "deployment 'spark-operator/spark-operator-controller' has no available replicas"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			created := false
+			appService := &SparkApplicationServiceMock{
+				CreateFunc: func(ctx context.Context, application *v1beta2.SparkApplication) (*v1beta2.SparkApplication, error) {
+					created = true
+					return application, nil
+				},
+			}
+			checker := &OperatorHealthCheckerMock{
+				HealthFunc: func() domain.OperatorHealth { return tc.health },
+			}
+
+			service := NewOperatorHealthApplicationService(appService, testCluster, checker)
+			_, err := service.Create(context.Background(), &v1beta2.SparkApplication{})
+
+			assert.Equal(t, tc.expectCreate, created, "create should only be called when the operator is healthy")
+			if tc.expectCreate {
+				assert.NoError(t, err, "create should not error")
+				return
+			}
+
+			var gatewayErr gatewayerrors.GatewayError
+			assert.True(t, errors.As(err, &gatewayErr), "error should be a GatewayError")
+			assert.Equal(t, http.StatusServiceUnavailable, gatewayErr.Status, "status should be 503")
+			assert.Contains(t, err.Error(), tc.health.Message, "error should explain why the operator is down")
+		})
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/pkg/common"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/metrics"
+)
+
+const (
+	OrphanDeleted = "deleted"
+	OrphanFailure = "failure"
+)
+
+// orphanKind lists and deletes one kind of resource the Spark Operator creates for SparkApplications
+type orphanKind struct {
+	name   string
+	list   func(ctx context.Context, namespace string, opts metav1.ListOptions) ([]metav1.Object, error)
+	delete func(ctx context.Context, namespace string, name string, opts metav1.DeleteOptions) error
+}
+
+// OrphanSweeper periodically deletes the driver and executor pods, services and configmaps labeled with the Gateway
+// selector whose SparkApplication no longer exists. Kubernetes garbage collection normally removes them with their
+// SparkApplication, but resources created without an owner reference, e.g. when the operator restarts mid submission,
+// are otherwise left behind.
+type OrphanSweeper struct {
+	sparkApplicationRepository SparkApplicationRepository
+	cluster                    domain.KubeCluster
+	namespaces                 *ManagedNamespaces
+	config                     config.OrphanSweeperConfig
+	selector                   string
+	kinds                      []orphanKind
+	metrics                    metrics.Metrics
+	now                        func() time.Time
+
+	// orphans are the orphaned resources found in previous sweeps that haven't been deleted yet, by UID
+	orphans map[types.UID]orphan
+}
+
+// orphan is when a resource was first found orphaned
+type orphan struct {
+	kind      string
+	namespace string
+	since     time.Time
+}
+
+func NewOrphanSweeper(k8sClient kubernetes.Interface, sparkAppRepo SparkApplicationRepository, cluster domain.KubeCluster, namespaces *ManagedNamespaces, config config.OrphanSweeperConfig, selectorKey string, selectorValue string) *OrphanSweeper {
+	return &OrphanSweeper{
+		sparkApplicationRepository: sparkAppRepo,
+		cluster:                    cluster,
+		namespaces:                 namespaces,
+		config:                     config,
+		selector:                   labels.Set{selectorKey: selectorValue}.String(),
+		kinds:                      orphanKinds(k8sClient),
+		metrics:                    metrics.Definition,
+		now:                        time.Now,
+		orphans:                    map[types.UID]orphan{},
+	}
+}
+
+func orphanKinds(k8sClient kubernetes.Interface) []orphanKind {
+	return []orphanKind{
+		{
+			name: "pod",
+			list: func(ctx context.Context, namespace string, opts metav1.ListOptions) ([]metav1.Object, error) {
+				list, err := k8sClient.CoreV1().Pods(namespace).List(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				objects := make([]metav1.Object, len(list.Items))
+				for i := range list.Items {
+					objects[i] = &list.Items[i]
+				}
+				return objects, nil
+			},
+			delete: func(ctx context.Context, namespace string, name string, opts metav1.DeleteOptions) error {
+				return k8sClient.CoreV1().Pods(namespace).Delete(ctx, name, opts)
+			},
+		},
+		{
+			name: "service",
+			list: func(ctx context.Context, namespace string, opts metav1.ListOptions) ([]metav1.Object, error) {
+				list, err := k8sClient.CoreV1().Services(namespace).List(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				objects := make([]metav1.Object, len(list.Items))
+				for i := range list.Items {
+					objects[i] = &list.Items[i]
+				}
+				return objects, nil
+			},
+			delete: func(ctx context.Context, namespace string, name string, opts metav1.DeleteOptions) error {
+				return k8sClient.CoreV1().Services(namespace).Delete(ctx, name, opts)
+			},
+		},
+		{
+			name: "configmap",
+			list: func(ctx context.Context, namespace string, opts metav1.ListOptions) ([]metav1.Object, error) {
+				list, err := k8sClient.CoreV1().ConfigMaps(namespace).List(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				objects := make([]metav1.Object, len(list.Items))
+				for i := range list.Items {
+					objects[i] = &list.Items[i]
+				}
+				return objects, nil
+			},
+			delete: func(ctx context.Context, namespace string, name string, opts metav1.DeleteOptions) error {
+				return k8sClient.CoreV1().ConfigMaps(namespace).Delete(ctx, name, opts)
+			},
+		},
+	}
+}
+
+// Run calls Sweep every config.Interval until ctx is done
+func (s *OrphanSweeper) Run(ctx context.Context) {
+	klog.Infof("Starting orphan sweeper for cluster '%s' with interval %s and grace period %s", s.cluster.Name, s.config.Interval, s.config.GracePeriod)
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			klog.Infof("Stopping orphan sweeper")
+			return
+		case <-ticker.C:
+			if err := s.Sweep(ctx); err != nil {
+				klog.Errorf("error sweeping orphaned resources: %v", err)
+			}
+		}
+	}
+}
+
+// Sweep finds the selector labeled resources of the managed namespaces whose SparkApplication, named by their
+// sparkoperator.k8s.io/app-name label, doesn't exist, and deletes those first found more than config.GracePeriod ago.
+// Resources without the label are left alone.
+func (s *OrphanSweeper) Sweep(ctx context.Context) error {
+	now := s.now()
+	orphans := map[types.UID]orphan{}
+
+	var errs []error
+	for _, namespace := range s.namespaces.Namespaces() {
+		for _, kind := range s.kinds {
+			objects, err := kind.list(ctx, namespace, metav1.ListOptions{LabelSelector: s.selector})
+			if err != nil {
+				errs = append(errs, fmt.Errorf("error listing %ss in namespace '%s': %w", kind.name, namespace, err))
+				// Keep tracking the orphans that couldn't be listed so a failed list doesn't restart their grace period
+				for uid, o := range s.orphans {
+					if o.kind == kind.name && o.namespace == namespace {
+						orphans[uid] = o
+					}
+				}
+				continue
+			}
+
+			for _, object := range objects {
+				orphaned, err := s.isOrphaned(object)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				if !orphaned {
+					continue
+				}
+
+				o, seen := s.orphans[object.GetUID()]
+				if !seen {
+					o = orphan{kind: kind.name, namespace: namespace, since: now}
+				}
+				if now.Sub(o.since) < s.config.GracePeriod {
+					orphans[object.GetUID()] = o
+					continue
+				}
+
+				if err := s.deleteOrphan(ctx, kind, object); err != nil {
+					errs = append(errs, err)
+					orphans[object.GetUID()] = o
+				}
+			}
+		}
+	}
+	s.orphans = orphans
+
+	return errors.Join(errs...)
+}
+
+func (s *OrphanSweeper) isOrphaned(object metav1.Object) (bool, error) {
+	appName, ok := object.GetLabels()[common.LabelSparkAppName]
+	if !ok || appName == "" {
+		return false, nil
+	}
+
+	if _, err := s.sparkApplicationRepository.Get(object.GetNamespace(), appName); err != nil {
+		var gatewayErr gatewayerrors.GatewayError
+		if errors.As(err, &gatewayErr) && gatewayErr.Status == http.StatusNotFound {
+			return true, nil
+		}
+		return false, fmt.Errorf("error getting SparkApplication '%s/%s': %w", object.GetNamespace(), appName, err)
+	}
+
+	return false, nil
+}
+
+func (s *OrphanSweeper) deleteOrphan(ctx context.Context, kind orphanKind, object metav1.Object) error {
+	klog.Infof("Deleting %s '%s/%s' in cluster '%s', its SparkApplication '%s' no longer exists", kind.name, object.GetNamespace(), object.GetName(), s.cluster.Name, object.GetLabels()[common.LabelSparkAppName])
+
+	// The UID precondition keeps a resource recreated under the same name from being deleted
+	uid := object.GetUID()
+	err := kind.delete(ctx, object.GetNamespace(), object.GetName(), metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}})
+	if k8sErrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		s.metrics.RecordOrphan(s.cluster.Name, kind.name, OrphanFailure)
+		return fmt.Errorf("error deleting orphaned %s '%s/%s': %w", kind.name, object.GetNamespace(), object.GetName(), err)
+	}
+	s.metrics.RecordOrphan(s.cluster.Name, kind.name, OrphanDeleted)
+
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/kubeflow/spark-operator/v2/pkg/common"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+func orphanMeta(name string, appName string, gatewayLabeled bool) metav1.ObjectMeta {
+	labels := map[string]string{}
+	if appName != "" {
+		labels[common.LabelSparkAppName] = appName
+	}
+	if gatewayLabeled {
+		labels["spark-gateway/managed"] = "true"
+	}
+	return metav1.ObjectMeta{Name: name, Namespace: "ns", UID: types.UID(name), Labels: labels}
+}
+
+func TestOrphanSweeperSweep(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	objects := []runtime.Object{
+		&corev1.Pod{ObjectMeta: orphanMeta("gone-driver", "gone", true)},
+		&corev1.Pod{ObjectMeta: orphanMeta("gone-exec-1", "gone", true)},
+		&corev1.Service{ObjectMeta: orphanMeta("gone-ui-svc", "gone", true)},
+		&corev1.ConfigMap{ObjectMeta: orphanMeta("gone-conf", "gone", true)},
+		&corev1.Pod{ObjectMeta: orphanMeta("alive-driver", "alive", true)},
+		&corev1.Pod{ObjectMeta: orphanMeta("unlabeled-driver", "gone", false)},
+		&corev1.Pod{ObjectMeta: orphanMeta("no-app-pod", "", true)},
+	}
+	k8sClient := fake.NewClientset(objects...)
+
+	sparkAppRepo := &SparkApplicationRepositoryMock{
+		GetFunc: func(namespace string, name string) (*v1beta2.SparkApplication, error) {
+			if name == "alive" {
+				return &v1beta2.SparkApplication{}, nil
+			}
+			return nil, gatewayerrors.NewNotFound(errors.New("not found"))
+		},
+	}
+
+	cluster := domain.KubeCluster{Name: "cluster", Namespaces: []domain.KubeNamespace{{Name: "ns"}}}
+	sweeper := NewOrphanSweeper(k8sClient, sparkAppRepo, cluster, NewManagedNamespaces(cluster, nil), config.OrphanSweeperConfig{Enable: true, Interval: time.Minute, GracePeriod: 10 * time.Minute}, "spark-gateway/managed", "true")
+	sweeper.now = func() time.Time { return now }
+
+	remaining := func() []string {
+		var names []string
+		pods, _ := k8sClient.CoreV1().Pods("ns").List(context.Background(), metav1.ListOptions{})
+		for _, pod := range pods.Items {
+			names = append(names, pod.Name)
+		}
+		services, _ := k8sClient.CoreV1().Services("ns").List(context.Background(), metav1.ListOptions{})
+		for _, service := range services.Items {
+			names = append(names, service.Name)
+		}
+		configMaps, _ := k8sClient.CoreV1().ConfigMaps("ns").List(context.Background(), metav1.ListOptions{})
+		for _, configMap := range configMaps.Items {
+			names = append(names, configMap.Name)
+		}
+		return names
+	}
+
+	assert.NoError(t, sweeper.Sweep(context.Background()), "first Sweep should not return error")
+	assert.Len(t, remaining(), len(objects), "orphans should not be deleted within the grace period")
+	assert.Len(t, sweeper.orphans, 4, "orphans should be tracked")
+
+	now = now.Add(11 * time.Minute)
+	assert.NoError(t, sweeper.Sweep(context.Background()), "second Sweep should not return error")
+	assert.ElementsMatch(t, []string{"alive-driver", "unlabeled-driver", "no-app-pod"}, remaining(), "orphans past the grace period should be deleted")
+	assert.Empty(t, sweeper.orphans, "deleted orphans should no longer be tracked")
+}
+
+func TestOrphanSweeperSweepGetError(t *testing.T) {
+	k8sClient := fake.NewClientset(&corev1.Pod{ObjectMeta: orphanMeta("driver", "app", true)})
+
+	sparkAppRepo := &SparkApplicationRepositoryMock{
+		GetFunc: func(namespace string, name string) (*v1beta2.SparkApplication, error) {
+			return nil, gatewayerrors.NewUnavailable(errors.New("unavailable"))
+		},
+	}
+
+	cluster := domain.KubeCluster{Name: "cluster", Namespaces: []domain.KubeNamespace{{Name: "ns"}}}
+	sweeper := NewOrphanSweeper(k8sClient, sparkAppRepo, cluster, NewManagedNamespaces(cluster, nil), config.OrphanSweeperConfig{Enable: true, GracePeriod: time.Nanosecond}, "spark-gateway/managed", "true")
+
+	err := sweeper.Sweep(context.Background())
+
+	assert.ErrorContains(t, err, "error getting SparkApplication 'ns/app'", "Get error should be returned")
+	assert.Empty(t, sweeper.orphans, "resources whose SparkApplication can't be read should not be tracked")
+}
+
+func TestOrphanSweeperSweepProvisionedNamespace(t *testing.T) {
+	provisionedMeta := orphanMeta("gone-driver", "gone", true)
+	provisionedMeta.Namespace = "provisioned"
+	k8sClient := fake.NewClientset(&corev1.Pod{ObjectMeta: provisionedMeta})
+
+	sparkAppRepo := &SparkApplicationRepositoryMock{
+		GetFunc: func(namespace string, name string) (*v1beta2.SparkApplication, error) {
+			return nil, gatewayerrors.NewNotFound(errors.New("not found"))
+		},
+	}
+
+	cluster := domain.KubeCluster{Name: "cluster", Namespaces: []domain.KubeNamespace{{Name: "ns"}}}
+	namespaces := NewManagedNamespaces(cluster, nil)
+	sweeper := NewOrphanSweeper(k8sClient, sparkAppRepo, cluster, namespaces, config.OrphanSweeperConfig{Enable: true, GracePeriod: time.Minute}, "spark-gateway/managed", "true")
+
+	assert.NoError(t, sweeper.Sweep(context.Background()), "Sweep should not return error")
+	assert.Empty(t, sweeper.orphans, "namespaces that aren't managed should not be swept")
+
+	namespaces.Add("provisioned")
+
+	assert.NoError(t, sweeper.Sweep(context.Background()), "Sweep should not return error")
+	assert.Contains(t, sweeper.orphans, types.UID("gone-driver"), "orphans in provisioned namespaces should be tracked")
+	assert.Equal(t, "provisioned", sweeper.orphans["gone-driver"].namespace, "orphan should be tracked in its namespace")
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/metrics"
+)
+
+// PermissionNeeds are the features SparkManager runs with that need Kubernetes permissions beyond managing
+// SparkApplications, their pods and their bundled ConfigMaps
+type PermissionNeeds struct {
+	// NamespaceScoped informers only list and watch the cluster's namespaces instead of all namespaces
+	NamespaceScoped bool
+	ExecutorPods    bool
+	Quotas          bool
+	OrphanSweeper   bool
+	Provisioning    bool
+	OperatorHealth  bool
+}
+
+// basicUserRules are granted to every authenticated user by the default system:basic-user ClusterRole, so they aren't
+// reported as excessive
+var basicUserRules = []authorizationv1.ResourceAttributes{
+	{Verb: "create", Group: "authorization.k8s.io", Resource: "selfsubjectaccessreviews"},
+	{Verb: "create", Group: "authorization.k8s.io", Resource: "selfsubjectrulesreviews"},
+	{Verb: "create", Group: "authentication.k8s.io", Resource: "selfsubjectreviews"},
+}
+
+// RequiredPermissions returns the permissions SparkManager needs in cluster to run with needs, once per verb.
+// Permissions with an empty Namespace are needed in every namespace.
+func RequiredPermissions(cluster domain.KubeCluster, needs PermissionNeeds) []authorizationv1.ResourceAttributes {
+	var namespaces []string
+	for _, namespace := range cluster.Namespaces {
+		namespaces = append(namespaces, namespace.Name)
+	}
+	// Informers watching all namespaces list and watch them cluster wide
+	informerNamespaces := []string{metav1.NamespaceAll}
+	if needs.NamespaceScoped {
+		informerNamespaces = namespaces
+	}
+
+	var required []authorizationv1.ResourceAttributes
+	add := func(namespaces []string, group string, resource string, verbs ...string) {
+		resource, subresource, _ := strings.Cut(resource, "/")
+		for _, namespace := range namespaces {
+			for _, verb := range verbs {
+				attributes := authorizationv1.ResourceAttributes{Namespace: namespace, Verb: verb, Group: group, Resource: resource, Subresource: subresource}
+				if !slices.Contains(required, attributes) {
+					required = append(required, attributes)
+				}
+			}
+		}
+	}
+
+	crd := cluster.SparkApplicationCRD.WithDefaults()
+	add(informerNamespaces, crd.Group, crd.Resource, "list", "watch")
+	add(namespaces, crd.Group, crd.Resource, "get", "create", "update", "patch", "delete")
+	add(namespaces, "", "pods", "list")
+	add(namespaces, "", "pods/log", "get")
+	add(namespaces, "", "pods/proxy", "get")
+	add(namespaces, "", "configmaps", "get", "create", "update", "delete")
+
+	if needs.ExecutorPods {
+		add(informerNamespaces, "", "pods", "list", "watch")
+	}
+	if needs.Quotas {
+		add(informerNamespaces, "", "resourcequotas", "list", "watch")
+	}
+	if needs.OrphanSweeper {
+		for _, resource := range []string{"pods", "services", "configmaps"} {
+			add(namespaces, "", resource, "list", "delete")
+		}
+	}
+	if needs.Provisioning {
+		// Provisioned namespaces don't exist yet, so what is created in them is needed in every namespace
+		add([]string{metav1.NamespaceAll}, "", "namespaces", "get", "create")
+		add([]string{metav1.NamespaceAll}, "", "serviceaccounts", "create")
+		add([]string{metav1.NamespaceAll}, "rbac.authorization.k8s.io", "roles", "create")
+		add([]string{metav1.NamespaceAll}, "rbac.authorization.k8s.io", "rolebindings", "create")
+		// Granting the driver Role takes holding its permissions
+		for _, rule := range domain.DriverRoleRules {
+			for _, group := range rule.APIGroups {
+				for _, resource := range rule.Resources {
+					add([]string{metav1.NamespaceAll}, group, resource, rule.Verbs...)
+				}
+			}
+		}
+	}
+	if needs.OperatorHealth {
+		add([]string{cluster.OperatorHealth.Namespace}, "apps", "deployments", "get")
+	}
+
+	return required
+}
+
+// PermissionReport lists the permissions a PermissionChecker found SparkManager missing, and those it has without
+// needing them
+type PermissionReport struct {
+	Missing   []authorizationv1.ResourceAttributes
+	Excessive []authorizationv1.ResourceAttributes
+}
+
+// PermissionChecker checks the permissions SparkManager has in its cluster against those it needs, so operators can
+// keep its RBAC as tight as its configuration allows
+type PermissionChecker struct {
+	k8sClient kubernetes.Interface
+	cluster   domain.KubeCluster
+	required  []authorizationv1.ResourceAttributes
+	// minimal also reports permissions that aren't required
+	minimal bool
+	metrics metrics.Metrics
+}
+
+func NewPermissionChecker(k8sClient kubernetes.Interface, cluster domain.KubeCluster, needs PermissionNeeds, checkConfig config.PermissionCheckConfig) *PermissionChecker {
+	return &PermissionChecker{
+		k8sClient: k8sClient,
+		cluster:   cluster,
+		required:  RequiredPermissions(cluster, needs),
+		minimal:   checkConfig.Minimal,
+		metrics:   metrics.Definition,
+	}
+}
+
+// Check reviews every required permission with a SelfSubjectAccessReview and, in minimal mode, lists the permissions
+// granted in each of the cluster's namespaces with a SelfSubjectRulesReview to find those that aren't required. Problems
+// are logged and exported as metrics.
+func (c *PermissionChecker) Check(ctx context.Context) (PermissionReport, error) {
+	var report PermissionReport
+
+	for _, attributes := range c.required {
+		review, err := c.k8sClient.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &attributes},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return report, fmt.Errorf("error reviewing permission to %s: %w", describePermission(attributes), err)
+		}
+		if !review.Status.Allowed {
+			report.Missing = append(report.Missing, attributes)
+		}
+	}
+
+	if c.minimal {
+		for _, namespace := range c.cluster.Namespaces {
+			excessive, err := c.excessive(ctx, namespace.Name)
+			if err != nil {
+				return report, err
+			}
+			report.Excessive = append(report.Excessive, excessive...)
+		}
+	}
+
+	for _, attributes := range report.Missing {
+		klog.Warningf("SparkManager of cluster '%s' is missing the permission to %s", c.cluster.Name, describePermission(attributes))
+		c.metrics.SetPermissionProblem(c.cluster.Name, attributes.Namespace, attributes.Group, resourceName(attributes), attributes.Verb, "missing")
+	}
+	for _, attributes := range report.Excessive {
+		klog.Warningf("SparkManager of cluster '%s' has the permission to %s without needing it", c.cluster.Name, describePermission(attributes))
+		c.metrics.SetPermissionProblem(c.cluster.Name, attributes.Namespace, attributes.Group, resourceName(attributes), attributes.Verb, "excessive")
+	}
+	if len(report.Missing) == 0 && len(report.Excessive) == 0 {
+		klog.Infof("SparkManager of cluster '%s' has the permissions it needs", c.cluster.Name)
+	}
+
+	return report, nil
+}
+
+// excessive returns the permissions granted in namespace that aren't required there. Wildcard verbs, groups and
+// resources are reported as granted, since no required permission is a wildcard.
+func (c *PermissionChecker) excessive(ctx context.Context, namespace string) ([]authorizationv1.ResourceAttributes, error) {
+	review, err := c.k8sClient.AuthorizationV1().SelfSubjectRulesReviews().Create(ctx, &authorizationv1.SelfSubjectRulesReview{
+		Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: namespace},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error reviewing permissions in namespace '%s': %w", namespace, err)
+	}
+	if review.Status.Incomplete {
+		klog.Warningf("Permissions of SparkManager of cluster '%s' in namespace '%s' are only partly known: %s", c.cluster.Name, namespace, review.Status.EvaluationError)
+	}
+
+	needed := map[authorizationv1.ResourceAttributes]bool{}
+	for _, attributes := range slices.Concat(c.required, basicUserRules) {
+		if attributes.Namespace == namespace || attributes.Namespace == metav1.NamespaceAll {
+			attributes.Namespace = namespace
+			needed[attributes] = true
+		}
+	}
+
+	var excessive []authorizationv1.ResourceAttributes
+	for _, rule := range review.Status.ResourceRules {
+		for _, group := range rule.APIGroups {
+			for _, resource := range rule.Resources {
+				resource, subresource, _ := strings.Cut(resource, "/")
+				for _, verb := range rule.Verbs {
+					attributes := authorizationv1.ResourceAttributes{Namespace: namespace, Verb: verb, Group: group, Resource: resource, Subresource: subresource}
+					if !needed[attributes] && !slices.Contains(excessive, attributes) {
+						excessive = append(excessive, attributes)
+					}
+				}
+			}
+		}
+	}
+
+	return excessive, nil
+}
+
+// resourceName returns the resource of attributes, with its subresource if it has one, e.g. pods/log
+func resourceName(attributes authorizationv1.ResourceAttributes) string {
+	if attributes.Subresource == "" {
+		return attributes.Resource
+	}
+	return attributes.Resource + "/" + attributes.Subresource
+}
+
+func describePermission(attributes authorizationv1.ResourceAttributes) string {
+	resource := resourceName(attributes)
+	if attributes.Group != "" {
+		resource += "." + attributes.Group
+	}
+	if attributes.Namespace == metav1.NamespaceAll {
+		return fmt.Sprintf("%s %s in all namespaces", attributes.Verb, resource)
+	}
+	return fmt.Sprintf("%s %s in namespace '%s'", attributes.Verb, resource, attributes.Namespace)
+}
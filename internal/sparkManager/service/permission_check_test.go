@@ -0,0 +1,86 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+)
+
+func TestRequiredPermissions(t *testing.T) {
+	cluster := domain.KubeCluster{
+		Namespaces:     []domain.KubeNamespace{{Name: "team-a"}, {Name: "team-b"}},
+		OperatorHealth: domain.OperatorHealthConfig{Namespace: "spark-operator"},
+	}
+
+	required := RequiredPermissions(cluster, PermissionNeeds{NamespaceScoped: true})
+	assert.Contains(t, required, authorizationv1.ResourceAttributes{Namespace: "team-b", Verb: "watch", Group: "sparkoperator.k8s.io", Resource: "sparkapplications"}, "namespace scoped informers should watch each namespace")
+	assert.Contains(t, required, authorizationv1.ResourceAttributes{Namespace: "team-a", Verb: "get", Resource: "pods", Subresource: "log"}, "driver logs should be read")
+	assert.NotContains(t, required, authorizationv1.ResourceAttributes{Namespace: "team-a", Verb: "list", Resource: "resourcequotas"}, "quotas should only be listed when watched")
+	assert.NotContains(t, required, authorizationv1.ResourceAttributes{Verb: "create", Resource: "namespaces"}, "namespaces should only be created when provisioning")
+
+	required = RequiredPermissions(cluster, PermissionNeeds{Quotas: true, Provisioning: true, OperatorHealth: true, OrphanSweeper: true})
+	assert.Contains(t, required, authorizationv1.ResourceAttributes{Verb: "watch", Group: "sparkoperator.k8s.io", Resource: "sparkapplications"}, "cluster wide informers should watch all namespaces")
+	assert.Contains(t, required, authorizationv1.ResourceAttributes{Verb: "list", Resource: "resourcequotas"}, "watched quotas should be listed")
+	assert.Contains(t, required, authorizationv1.ResourceAttributes{Verb: "create", Resource: "namespaces"}, "provisioning should create namespaces")
+	assert.Contains(t, required, authorizationv1.ResourceAttributes{Namespace: "spark-operator", Verb: "get", Group: "apps", Resource: "deployments"}, "operator deployments should be read")
+	assert.Contains(t, required, authorizationv1.ResourceAttributes{Namespace: "team-a", Verb: "delete", Resource: "services"}, "orphaned services should be deleted")
+
+	seen := map[authorizationv1.ResourceAttributes]bool{}
+	for _, attributes := range required {
+		assert.False(t, seen[attributes], "permission %v should be required once", attributes)
+		seen[attributes] = true
+	}
+}
+
+func TestPermissionCheckerCheck(t *testing.T) {
+	cluster := domain.KubeCluster{Name: "cluster", Namespaces: []domain.KubeNamespace{{Name: "team-a"}}}
+	k8sClient := fake.NewClientset()
+	k8sClient.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		// Everything but proxying to driver pods is allowed
+		review.Status.Allowed = review.Spec.ResourceAttributes.Subresource != "proxy"
+		return true, review, nil
+	})
+	k8sClient.PrependReactor("create", "selfsubjectrulesreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectRulesReview)
+		review.Status.ResourceRules = []authorizationv1.ResourceRule{
+			{Verbs: []string{"get", "list", "watch"}, APIGroups: []string{"sparkoperator.k8s.io"}, Resources: []string{"sparkapplications"}},
+			{Verbs: []string{"create"}, APIGroups: []string{"authorization.k8s.io"}, Resources: []string{"selfsubjectaccessreviews"}},
+			{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"secrets", "pods/log"}},
+		}
+		return true, review, nil
+	})
+
+	report, err := NewPermissionChecker(k8sClient, cluster, PermissionNeeds{}, config.PermissionCheckConfig{Enable: true}).Check(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []authorizationv1.ResourceAttributes{{Namespace: "team-a", Verb: "get", Resource: "pods", Subresource: "proxy"}}, report.Missing, "missing permissions should be reported")
+	assert.Empty(t, report.Excessive, "excessive permissions should only be reported in minimal mode")
+
+	report, err = NewPermissionChecker(k8sClient, cluster, PermissionNeeds{}, config.PermissionCheckConfig{Enable: true, Minimal: true}).Check(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []authorizationv1.ResourceAttributes{{Namespace: "team-a", Verb: "get", Resource: "secrets"}}, report.Excessive, "only permissions that aren't needed should be excessive")
+}
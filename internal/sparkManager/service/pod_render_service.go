@@ -0,0 +1,389 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/kubeflow/spark-operator/v2/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/metrics"
+)
+
+//go:generate moq -rm -out mocksparkapplicationpodrenderservice.go . SparkApplicationPodRenderService
+
+type SparkApplicationPodRenderService interface {
+	RenderPods(ctx context.Context, application *v1beta2.SparkApplication) (*domain.RenderedPods, error)
+}
+
+// maxConfigMapVolumeNameLength is the length the Spark Operator truncates spec.driver/executor.configMaps volume
+// names to
+const maxConfigMapVolumeNameLength = 63
+
+type PodRenderService struct {
+	cluster string
+}
+
+// NewPodRenderService returns a SparkApplicationPodRenderService rendering the pods of SparkApplications run by the
+// Spark Operator in cluster, or nil if the cluster's backend doesn't run them through the Spark Operator.
+func NewPodRenderService(cluster domain.KubeCluster) SparkApplicationPodRenderService {
+	if cluster.Backend != domain.BackendSparkOperator {
+		return nil
+	}
+
+	return &PodRenderService{cluster: cluster.Name}
+}
+
+// sparkPodRole holds the fields of a SparkApplication that shape the pods of one Spark role, driver or executor
+type sparkPodRole struct {
+	role              string
+	name              string
+	containerName     string
+	envConfPrefix     string
+	spec              v1beta2.SparkPodSpec
+	coreRequest       *string
+	lifecycle         *corev1.Lifecycle
+	ports             []v1beta2.Port
+	priorityClassName *string
+	memoryBytes       float64
+}
+
+// RenderPods returns the driver and first executor pods of application as they would be created by spark-submit and
+// mutated by the Spark Operator's webhook. Cluster admission, e.g. LimitRange defaults or policy webhooks, and the
+// Prometheus JMX exporter the operator adds for spec.monitoring are not applied.
+func (s *PodRenderService) RenderPods(ctx context.Context, application *v1beta2.SparkApplication) (*domain.RenderedPods, error) {
+	driverName := fmt.Sprintf("%s-driver", application.Name)
+	if application.Spec.Driver.PodName != nil && *application.Spec.Driver.PodName != "" {
+		driverName = *application.Spec.Driver.PodName
+	} else if name := application.Spec.SparkConf[common.SparkKubernetesDriverPodName]; name != "" {
+		driverName = name
+	}
+
+	executorPrefix := application.Name
+	if prefix := application.Spec.SparkConf["spark.kubernetes.executor.podNamePrefix"]; prefix != "" {
+		executorPrefix = prefix
+	}
+
+	driver, err := renderPod(application, sparkPodRole{
+		role:              common.SparkRoleDriver,
+		name:              driverName,
+		containerName:     common.SparkDriverContainerName,
+		envConfPrefix:     "spark.kubernetes.driverEnv.",
+		spec:              application.Spec.Driver.SparkPodSpec,
+		coreRequest:       application.Spec.Driver.CoreRequest,
+		lifecycle:         application.Spec.Driver.Lifecycle,
+		ports:             application.Spec.Driver.Ports,
+		priorityClassName: application.Spec.Driver.PriorityClassName,
+		memoryBytes:       metrics.GetDriverMemory(application),
+	})
+	if err != nil {
+		return nil, gatewayerrors.NewBadRequest(fmt.Errorf("error rendering driver pod: %w", err))
+	}
+
+	executor, err := renderPod(application, sparkPodRole{
+		role:              common.SparkRoleExecutor,
+		name:              fmt.Sprintf("%s-exec-1", executorPrefix),
+		containerName:     common.Spark3DefaultExecutorContainerName,
+		envConfPrefix:     "spark.executorEnv.",
+		spec:              application.Spec.Executor.SparkPodSpec,
+		coreRequest:       application.Spec.Executor.CoreRequest,
+		lifecycle:         application.Spec.Executor.Lifecycle,
+		ports:             application.Spec.Executor.Ports,
+		priorityClassName: application.Spec.Executor.PriorityClassName,
+		memoryBytes:       metrics.GetExecutorMemory(application),
+	})
+	if err != nil {
+		return nil, gatewayerrors.NewBadRequest(fmt.Errorf("error rendering executor pod: %w", err))
+	}
+	// Spark defaults the executor service account to the driver's
+	if executor.Spec.ServiceAccountName == "" {
+		executor.Spec.ServiceAccountName = driver.Spec.ServiceAccountName
+	}
+
+	return &domain.RenderedPods{Cluster: s.cluster, Driver: *driver, Executor: *executor}, nil
+}
+
+// renderPod builds the pod spark-submit creates for role, starting from its pod template, then applies the mutations
+// of the Spark Operator's webhook in the order it applies them
+func renderPod(application *v1beta2.SparkApplication, role sparkPodRole) (*corev1.Pod, error) {
+	pod := &corev1.Pod{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"}}
+	if role.spec.Template != nil {
+		pod.ObjectMeta = *role.spec.Template.ObjectMeta.DeepCopy()
+		pod.Spec = *role.spec.Template.Spec.DeepCopy()
+	}
+	pod.Name = role.name
+	pod.Namespace = application.Namespace
+
+	// spark-submit
+	pod.Labels = mergeMaps(pod.Labels, sparkConfWithPrefix(application.Spec.SparkConf, fmt.Sprintf("spark.kubernetes.%s.label.", role.role)), role.spec.Labels, map[string]string{
+		common.LabelSparkAppName:            application.Name,
+		common.LabelLaunchedBySparkOperator: "true",
+		common.LabelSparkRole:               role.role,
+	})
+	pod.Annotations = mergeMaps(pod.Annotations, sparkConfWithPrefix(application.Spec.SparkConf, fmt.Sprintf("spark.kubernetes.%s.annotation.", role.role)), role.spec.Annotations)
+	pod.Spec.RestartPolicy = corev1.RestartPolicyNever
+	if role.spec.ServiceAccount != nil {
+		pod.Spec.ServiceAccountName = *role.spec.ServiceAccount
+	}
+	for _, secret := range application.Spec.ImagePullSecrets {
+		pod.Spec.ImagePullSecrets = append(pod.Spec.ImagePullSecrets, corev1.LocalObjectReference{Name: secret})
+	}
+	pod.Spec.NodeSelector = mergeMaps(pod.Spec.NodeSelector, sparkConfWithPrefix(application.Spec.SparkConf, "spark.kubernetes.node.selector."), application.Spec.NodeSelector, sparkConfWithPrefix(application.Spec.SparkConf, fmt.Sprintf("spark.kubernetes.%s.node.selector.", role.role)))
+
+	container := sparkContainer(pod, role.containerName)
+	if role.spec.Image != nil {
+		container.Image = *role.spec.Image
+	} else if application.Spec.Image != nil {
+		container.Image = *application.Spec.Image
+	}
+	if application.Spec.ImagePullPolicy != nil {
+		container.ImagePullPolicy = corev1.PullPolicy(*application.Spec.ImagePullPolicy)
+	}
+
+	resources, err := renderResources(application, role)
+	if err != nil {
+		return nil, err
+	}
+	container.Resources = resources
+
+	envVars := mergeMaps(sparkConfWithPrefix(application.Spec.SparkConf, role.envConfPrefix), role.spec.EnvVars)
+	for _, name := range slices.Sorted(maps.Keys(envVars)) {
+		container.Env = append(container.Env, corev1.EnvVar{Name: name, Value: envVars[name]})
+	}
+	for _, name := range slices.Sorted(maps.Keys(role.spec.EnvSecretKeyRefs)) {
+		ref := role.spec.EnvSecretKeyRefs[name]
+		container.Env = append(container.Env, corev1.EnvVar{Name: name, ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: ref.Name}, Key: ref.Key},
+		}})
+	}
+	for _, secret := range role.spec.Secrets {
+		volumeName := secret.Name + "-volume"
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{Name: volumeName, VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: secret.Name}}})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: volumeName, MountPath: secret.Path})
+	}
+
+	// Spark Operator webhook
+	container.Env = append(container.Env, role.spec.Env...)
+	container.EnvFrom = append(container.EnvFrom, role.spec.EnvFrom...)
+	if application.Spec.HadoopConfigMap != nil {
+		addConfigMap(pod, container, *application.Spec.HadoopConfigMap, common.HadoopConfigMapVolumeName, common.DefaultHadoopConfDir)
+		container.Env = append(container.Env, corev1.EnvVar{Name: common.EnvHadoopConfDir, Value: common.DefaultHadoopConfDir})
+	}
+	if application.Spec.SparkConfigMap != nil {
+		addConfigMap(pod, container, *application.Spec.SparkConfigMap, common.SparkConfigMapVolumeName, common.DefaultSparkConfDir)
+		container.Env = append(container.Env, corev1.EnvVar{Name: common.EnvSparkConfDir, Value: common.DefaultSparkConfDir})
+	}
+	for _, configMap := range role.spec.ConfigMaps {
+		volumeName := configMap.Name + "-vol"
+		if len(volumeName) > maxConfigMapVolumeNameLength {
+			volumeName = volumeName[:maxConfigMapVolumeNameLength]
+		}
+		addConfigMap(pod, container, configMap.Name, volumeName, configMap.Path)
+	}
+
+	volumes := map[string]corev1.Volume{}
+	for _, volume := range application.Spec.Volumes {
+		volumes[volume.Name] = volume
+	}
+	added := map[string]bool{}
+	for _, mount := range role.spec.VolumeMounts {
+		volume, ok := volumes[mount.Name]
+		if !ok {
+			continue
+		}
+		if !added[mount.Name] {
+			pod.Spec.Volumes = append(pod.Spec.Volumes, volume)
+			added[mount.Name] = true
+		}
+		container.VolumeMounts = append(container.VolumeMounts, mount)
+	}
+
+	for _, port := range role.ports {
+		container.Ports = append(container.Ports, corev1.ContainerPort{Name: port.Name, ContainerPort: port.ContainerPort, Protocol: corev1.Protocol(port.Protocol)})
+	}
+	if role.spec.HostNetwork != nil && *role.spec.HostNetwork {
+		pod.Spec.HostNetwork = true
+		pod.Spec.DNSPolicy = corev1.DNSClusterFirstWithHostNet
+	}
+	pod.Spec.HostAliases = append(pod.Spec.HostAliases, role.spec.HostAliases...)
+	for _, initContainer := range role.spec.InitContainers {
+		if !hasContainer(pod.Spec.InitContainers, initContainer) {
+			pod.Spec.InitContainers = append(pod.Spec.InitContainers, *initContainer.DeepCopy())
+		}
+	}
+	containerName := container.Name
+	for _, sidecar := range role.spec.Sidecars {
+		if !hasContainer(pod.Spec.Containers, sidecar) {
+			pod.Spec.Containers = append(pod.Spec.Containers, *sidecar.DeepCopy())
+		}
+	}
+	// Appending sidecars may have moved the Spark container
+	container = sparkContainer(pod, containerName)
+
+	if role.spec.DNSConfig != nil {
+		pod.Spec.DNSConfig = role.spec.DNSConfig.DeepCopy()
+	}
+	if role.priorityClassName != nil && *role.priorityClassName != "" {
+		pod.Spec.PriorityClassName = *role.priorityClassName
+		pod.Spec.Priority = nil
+		pod.Spec.PreemptionPolicy = nil
+	}
+	if application.Spec.BatchScheduler != nil && *application.Spec.BatchScheduler != "" {
+		pod.Spec.SchedulerName = *application.Spec.BatchScheduler
+	} else if role.spec.SchedulerName != nil && *role.spec.SchedulerName != "" {
+		pod.Spec.SchedulerName = *role.spec.SchedulerName
+	}
+	if len(role.spec.NodeSelector) > 0 {
+		pod.Spec.NodeSelector = mergeMaps(pod.Spec.NodeSelector, role.spec.NodeSelector)
+	}
+	if role.spec.Affinity != nil {
+		pod.Spec.Affinity = role.spec.Affinity.DeepCopy()
+	}
+	pod.Spec.Tolerations = append(pod.Spec.Tolerations, role.spec.Tolerations...)
+	if role.spec.MemoryLimit != nil {
+		limitBytes, err := metrics.ParseSparkMemory(*role.spec.MemoryLimit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid memoryLimit: %w", err)
+		}
+		container.Resources.Limits[corev1.ResourceMemory] = *resource.NewQuantity(int64(limitBytes), resource.BinarySI)
+	}
+	if gpu := role.spec.GPU; gpu != nil && gpu.Name != "" && gpu.Quantity > 0 {
+		container.Resources.Limits[corev1.ResourceName(gpu.Name)] = *resource.NewQuantity(gpu.Quantity, resource.DecimalSI)
+	}
+	if role.spec.SecurityContext != nil {
+		container.SecurityContext = role.spec.SecurityContext.DeepCopy()
+	}
+	if role.spec.PodSecurityContext != nil {
+		pod.Spec.SecurityContext = role.spec.PodSecurityContext.DeepCopy()
+	}
+	if role.spec.TerminationGracePeriodSeconds != nil {
+		pod.Spec.TerminationGracePeriodSeconds = role.spec.TerminationGracePeriodSeconds
+	}
+	if role.lifecycle != nil {
+		container.Lifecycle = role.lifecycle.DeepCopy()
+	}
+	if role.spec.ShareProcessNamespace != nil && *role.spec.ShareProcessNamespace {
+		pod.Spec.ShareProcessNamespace = role.spec.ShareProcessNamespace
+	}
+
+	return pod, nil
+}
+
+// renderResources returns the requests and limits spark-submit sets on the Spark container of role: the core request,
+// defaulting to cores, the core limit and memory including overhead as both request and limit
+func renderResources(application *v1beta2.SparkApplication, role sparkPodRole) (corev1.ResourceRequirements, error) {
+	cores := "1"
+	if role.coreRequest != nil {
+		cores = *role.coreRequest
+	} else if request := application.Spec.SparkConf[fmt.Sprintf("spark.kubernetes.%s.request.cores", role.role)]; request != "" {
+		cores = request
+	} else if role.spec.Cores != nil {
+		cores = fmt.Sprintf("%d", *role.spec.Cores)
+	} else if conf := application.Spec.SparkConf[fmt.Sprintf("spark.%s.cores", role.role)]; conf != "" {
+		cores = conf
+	}
+	cpuRequest, err := resource.ParseQuantity(cores)
+	if err != nil {
+		return corev1.ResourceRequirements{}, fmt.Errorf("invalid core request '%s': %w", cores, err)
+	}
+
+	memory := *resource.NewQuantity(int64(role.memoryBytes), resource.BinarySI)
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: cpuRequest, corev1.ResourceMemory: memory},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: memory},
+	}
+
+	coreLimit := application.Spec.SparkConf[fmt.Sprintf("spark.kubernetes.%s.limit.cores", role.role)]
+	if role.spec.CoreLimit != nil {
+		coreLimit = *role.spec.CoreLimit
+	}
+	if coreLimit != "" {
+		cpuLimit, err := resource.ParseQuantity(coreLimit)
+		if err != nil {
+			return corev1.ResourceRequirements{}, fmt.Errorf("invalid coreLimit '%s': %w", coreLimit, err)
+		}
+		resources.Limits[corev1.ResourceCPU] = cpuLimit
+	}
+
+	return resources, nil
+}
+
+// sparkContainer returns the Spark container of pod, named name or else the first container as Spark picks it from
+// pod templates, adding it if pod has no containers
+func sparkContainer(pod *corev1.Pod, name string) *corev1.Container {
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == name {
+			return &pod.Spec.Containers[i]
+		}
+	}
+	if len(pod.Spec.Containers) == 0 {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{})
+	}
+	pod.Spec.Containers[0].Name = name
+
+	return &pod.Spec.Containers[0]
+}
+
+// addConfigMap mounts configMap read only at mountPath in container as the Spark Operator does
+func addConfigMap(pod *corev1.Pod, container *corev1.Container, configMap string, volumeName string, mountPath string) {
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{Name: volumeName, VolumeSource: corev1.VolumeSource{
+		ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: configMap}},
+	}})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: volumeName, ReadOnly: true, MountPath: mountPath})
+}
+
+// hasContainer reports whether containers has a container with the name and image of container, which the Spark
+// Operator doesn't add again
+func hasContainer(containers []corev1.Container, container corev1.Container) bool {
+	return slices.ContainsFunc(containers, func(c corev1.Container) bool {
+		return c.Name == container.Name && c.Image == container.Image
+	})
+}
+
+// sparkConfWithPrefix returns the sparkConf entries with keys starting with prefix, keyed by the rest of the key
+func sparkConfWithPrefix(sparkConf map[string]string, prefix string) map[string]string {
+	values := map[string]string{}
+	for key, value := range sparkConf {
+		if name, ok := strings.CutPrefix(key, prefix); ok && name != "" {
+			values[name] = value
+		}
+	}
+	return values
+}
+
+// mergeMaps returns the union of maps, later maps taking precedence, or nil if it's empty
+func mergeMaps(sources ...map[string]string) map[string]string {
+	merged := map[string]string{}
+	for _, m := range sources {
+		for key, value := range m {
+			merged[key] = value
+		}
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	return merged
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+)
+
+func TestNewPodRenderService(t *testing.T) {
+	assert.NotNil(t, NewPodRenderService(domain.KubeCluster{Name: "c1", Backend: domain.BackendSparkOperator}), "Spark Operator clusters should render pods")
+	assert.Nil(t, NewPodRenderService(domain.KubeCluster{Name: "c1", Backend: domain.BackendEMROnEKS}), "other backends should not render pods")
+}
+
+func TestPodRenderServiceRenderPods(t *testing.T) {
+	toleration := corev1.Toleration{Key: "spark", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}
+	affinity := &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+		NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"spark"}}}}},
+	}}}
+	application := &v1beta2.SparkApplication{
+		ObjectMeta: metav1.ObjectMeta{Name: "c1-ns-uuid", Namespace: "ns"},
+		Spec: v1beta2.SparkApplicationSpec{
+			Image:          util.Ptr("spark:3.5"),
+			SparkConfigMap: util.Ptr("c1-ns-uuid-log4j"),
+			NodeSelector:   map[string]string{"pool": "spark"},
+			SparkConf:      map[string]string{"spark.kubernetes.executor.podNamePrefix": "job", "spark.executorEnv.FROM_CONF": "conf"},
+			Volumes:        []corev1.Volume{{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+			Driver: v1beta2.DriverSpec{
+				CoreRequest: util.Ptr("500m"),
+				SparkPodSpec: v1beta2.SparkPodSpec{
+					Memory:         util.Ptr("2g"),
+					CoreLimit:      util.Ptr("1"),
+					ServiceAccount: util.Ptr("spark"),
+					Labels:         map[string]string{"team": "a"},
+					Env:            []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}},
+					Tolerations:    []corev1.Toleration{toleration},
+					Affinity:       affinity,
+					VolumeMounts:   []corev1.VolumeMount{{Name: "scratch", MountPath: "/scratch"}},
+					Sidecars:       []corev1.Container{{Name: "proxy", Image: "proxy:1"}},
+				},
+			},
+			Executor: v1beta2.ExecutorSpec{
+				SparkPodSpec: v1beta2.SparkPodSpec{
+					Cores:        util.Ptr(int32(4)),
+					Memory:       util.Ptr("8g"),
+					MemoryLimit:  util.Ptr("10g"),
+					Image:        util.Ptr("spark-executor:3.5"),
+					GPU:          &v1beta2.GPUSpec{Name: "nvidia.com/gpu", Quantity: 1},
+					NodeSelector: map[string]string{"gpu": "true"},
+					Template: &corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"from": "template"}},
+						Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "spark", WorkingDir: "/work"}}},
+					},
+				},
+			},
+		},
+	}
+
+	pods, err := NewPodRenderService(domain.KubeCluster{Name: "c1", Backend: domain.BackendSparkOperator}).RenderPods(context.Background(), application)
+	assert.NoError(t, err, "pods should render")
+	assert.Equal(t, "c1", pods.Cluster, "cluster should be set")
+
+	driver := pods.Driver
+	assert.Equal(t, "c1-ns-uuid-driver", driver.Name, "driver name should match")
+	assert.Equal(t, "ns", driver.Namespace, "driver namespace should match")
+	assert.Equal(t, map[string]string{"team": "a", "sparkoperator.k8s.io/app-name": "c1-ns-uuid", "sparkoperator.k8s.io/launched-by-spark-operator": "true", "spark-role": "driver"}, driver.Labels, "driver labels should match")
+	assert.Equal(t, "spark", driver.Spec.ServiceAccountName, "driver service account should match")
+	assert.Equal(t, []corev1.Toleration{toleration}, driver.Spec.Tolerations, "driver tolerations should match")
+	assert.Equal(t, affinity, driver.Spec.Affinity, "driver affinity should match")
+	assert.Equal(t, map[string]string{"pool": "spark"}, driver.Spec.NodeSelector, "driver node selector should match")
+	assert.Len(t, driver.Spec.Containers, 2, "driver should run the Spark container and sidecar")
+	assert.Equal(t, "proxy", driver.Spec.Containers[1].Name, "sidecar should be added")
+
+	container := driver.Spec.Containers[0]
+	assert.Equal(t, "spark-kubernetes-driver", container.Name, "driver container name should match")
+	assert.Equal(t, "spark:3.5", container.Image, "driver image should default to spec.image")
+	assert.Equal(t, resource.MustParse("500m"), container.Resources.Requests[corev1.ResourceCPU], "driver CPU request should be coreRequest")
+	assert.Equal(t, resource.MustParse("1"), container.Resources.Limits[corev1.ResourceCPU], "driver CPU limit should be coreLimit")
+	assert.Equal(t, int64(2432<<20), container.Resources.Requests.Memory().Value(), "driver memory should include the minimum overhead")
+	assert.Equal(t, []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}, {Name: "SPARK_CONF_DIR", Value: "/etc/spark/conf"}}, container.Env, "driver env should match")
+	assert.ElementsMatch(t, []corev1.VolumeMount{
+		{Name: "spark-configmap-volume", ReadOnly: true, MountPath: "/etc/spark/conf"},
+		{Name: "scratch", MountPath: "/scratch"},
+	}, container.VolumeMounts, "driver volume mounts should match")
+	assert.Len(t, driver.Spec.Volumes, 2, "driver should have the ConfigMap and mounted volumes")
+
+	executor := pods.Executor
+	assert.Equal(t, "job-exec-1", executor.Name, "executor name should use the pod name prefix")
+	assert.Equal(t, "template", executor.Labels["from"], "executor should start from its pod template")
+	assert.Equal(t, "spark", executor.Spec.ServiceAccountName, "executor service account should default to the driver's")
+	assert.Equal(t, map[string]string{"pool": "spark", "gpu": "true"}, executor.Spec.NodeSelector, "executor node selector should merge application and executor selectors")
+	assert.Len(t, executor.Spec.Containers, 1, "template container should be the Spark container")
+
+	container = executor.Spec.Containers[0]
+	assert.Equal(t, "spark-kubernetes-executor", container.Name, "executor container name should match")
+	assert.Equal(t, "/work", container.WorkingDir, "template container fields should be kept")
+	assert.Equal(t, "spark-executor:3.5", container.Image, "executor image should match")
+	assert.Equal(t, resource.MustParse("4"), container.Resources.Requests[corev1.ResourceCPU], "executor CPU request should be cores")
+	assert.Equal(t, int64(8<<30+(8<<30)/10), container.Resources.Requests.Memory().Value(), "executor memory should include 10% overhead")
+	assert.Equal(t, int64(10<<30), container.Resources.Limits.Memory().Value(), "executor memory limit should be memoryLimit")
+	assert.Equal(t, int64(1), container.Resources.Limits.Name("nvidia.com/gpu", resource.DecimalSI).Value(), "executor GPU limit should match")
+	assert.Equal(t, []corev1.EnvVar{{Name: "FROM_CONF", Value: "conf"}, {Name: "SPARK_CONF_DIR", Value: "/etc/spark/conf"}}, container.Env, "executor env should match")
+}
+
+func TestPodRenderServiceRenderPodsInvalid(t *testing.T) {
+	application := &v1beta2.SparkApplication{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"},
+		Spec:       v1beta2.SparkApplicationSpec{Driver: v1beta2.DriverSpec{SparkPodSpec: v1beta2.SparkPodSpec{CoreLimit: util.Ptr("one")}}},
+	}
+
+	_, err := NewPodRenderService(domain.KubeCluster{Name: "c1", Backend: domain.BackendSparkOperator}).RenderPods(context.Background(), application)
+	assert.ErrorContains(t, err, "error rendering driver pod: invalid coreLimit 'one'", "invalid coreLimit should be rejected")
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/metrics"
+)
+
+const (
+	// DriftLost counts records whose SparkApplication no longer exists in the cluster
+	DriftLost = "lost"
+	// DriftTerminalState counts records missing the terminal state their SparkApplication reached
+	DriftTerminalState = "terminal_state"
+)
+
+// Reconciler periodically compares the cluster's active spark_applications rows with the SparkApplications in the
+// informer cache. Rows whose SparkApplication is gone are marked database.LostState, and rows that missed the
+// update to a terminal state are brought up to date, so the history kept in the database can be trusted.
+type Reconciler struct {
+	sparkApplicationRepository SparkApplicationRepository
+	database                   database.SparkApplicationDatabase
+	cluster                    domain.KubeCluster
+	config                     config.DatabaseReconcilerConfig
+	metrics                    metrics.Metrics
+	now                        func() time.Time
+}
+
+func NewReconciler(sparkAppRepo SparkApplicationRepository, database database.SparkApplicationDatabase, cluster domain.KubeCluster, config config.DatabaseReconcilerConfig) *Reconciler {
+	return &Reconciler{
+		sparkApplicationRepository: sparkAppRepo,
+		database:                   database,
+		cluster:                    cluster,
+		config:                     config,
+		metrics:                    metrics.Definition,
+		now:                        time.Now,
+	}
+}
+
+// Run calls Reconcile every config.Interval until ctx is done
+func (r *Reconciler) Run(ctx context.Context) {
+	klog.Infof("Starting database reconciler with interval %s and grace period %s", r.config.Interval, r.config.GracePeriod)
+
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			klog.Infof("Stopping database reconciler")
+			return
+		case <-ticker.C:
+			if err := r.Reconcile(ctx); err != nil {
+				klog.Errorf("error reconciling database with cluster '%s': %v", r.cluster.Name, err)
+			}
+		}
+	}
+}
+
+// Reconcile checks every active row created more than config.GracePeriod ago against the cluster
+func (r *Reconciler) Reconcile(ctx context.Context) error {
+	now := r.now()
+
+	records, err := r.database.ListActiveSparkApplications(ctx, r.cluster.Name, now.Add(-r.config.GracePeriod))
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, record := range records {
+		if err := r.reconcileRecord(ctx, record, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *Reconciler) reconcileRecord(ctx context.Context, record database.SparkApplication, now time.Time) error {
+	// Rows only written by the controller have no name or namespace to look up
+	if record.Name == nil || record.Namespace == nil {
+		return nil
+	}
+
+	sparkApp, err := r.sparkApplicationRepository.Get(*record.Namespace, *record.Name)
+	if err != nil {
+		var gatewayErr gatewayerrors.GatewayError
+		if !errors.As(err, &gatewayErr) || gatewayErr.Status != http.StatusNotFound {
+			return fmt.Errorf("error getting SparkApplication '%s/%s': %w", *record.Namespace, *record.Name, err)
+		}
+
+		klog.Infof("Marking SparkApplication '%s/%s' lost, it no longer exists in cluster '%s'", *record.Namespace, *record.Name, r.cluster.Name)
+		if err := r.database.MarkSparkApplicationLost(ctx, record.Uid, now); err != nil {
+			return err
+		}
+		lost := &v1beta2.SparkApplication{}
+		lost.Namespace = *record.Namespace
+		lost.Status.AppState.State = database.LostState
+		if err := database.RecordStateEvent(ctx, r.database, record.Uid, r.cluster.Name, lost, now); err != nil {
+			return err
+		}
+		r.metrics.RecordReconcileDrift(r.cluster.Name, DriftLost)
+		return nil
+	}
+
+	state := sparkApp.Status.AppState.State
+	if !isTerminalState(state) || util.SafeString(record.State) == string(state) {
+		return nil
+	}
+
+	klog.Infof("Updating SparkApplication '%s/%s' to missed terminal state '%s'", *record.Namespace, *record.Name, state)
+	if err := r.database.UpdateSparkApplication(ctx, record.Uid, *sparkApp); err != nil {
+		return err
+	}
+	if err := database.RecordStateEvent(ctx, r.database, record.Uid, r.cluster.Name, sparkApp, now); err != nil {
+		return err
+	}
+	r.metrics.RecordReconcileDrift(r.cluster.Name, DriftTerminalState)
+
+	return nil
+}
+
+func isTerminalState(state v1beta2.ApplicationStateType) bool {
+	return state == v1beta2.ApplicationStateCompleted || state == v1beta2.ApplicationStateFailed
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+)
+
+func TestReconcilerReconcile(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	recordUid := uuid.New()
+
+	testCases := []struct {
+		name         string
+		record       database.SparkApplication
+		sparkApp     *v1beta2.SparkApplication
+		getErr       error
+		expectLost   bool
+		expectUpdate bool
+		expectErr    bool
+	}{
+		{
+			name:       "marks missing application lost",
+			record:     database.SparkApplication{Uid: recordUid, Name: util.Ptr("app"), Namespace: util.Ptr("ns"), State: util.Ptr("RUNNING")},
+			getErr:     gatewayerrors.NewNotFound(errors.New("not found")),
+			expectLost: true,
+		},
+		{
+			name:         "updates missed terminal state",
+			record:       database.SparkApplication{Uid: recordUid, Name: util.Ptr("app"), Namespace: util.Ptr("ns"), State: util.Ptr("RUNNING")},
+			sparkApp:     &v1beta2.SparkApplication{Status: v1beta2.SparkApplicationStatus{AppState: v1beta2.ApplicationState{State: v1beta2.ApplicationStateCompleted}}},
+			expectUpdate: true,
+		},
+		{
+			name:     "leaves running application",
+			record:   database.SparkApplication{Uid: recordUid, Name: util.Ptr("app"), Namespace: util.Ptr("ns"), State: util.Ptr("RUNNING")},
+			sparkApp: &v1beta2.SparkApplication{Status: v1beta2.SparkApplicationStatus{AppState: v1beta2.ApplicationState{State: v1beta2.ApplicationStateRunning}}},
+		},
+		{
+			name:   "skips record without name",
+			record: database.SparkApplication{Uid: recordUid},
+		},
+		{
+			name:      "returns get errors",
+			record:    database.SparkApplication{Uid: recordUid, Name: util.Ptr("app"), Namespace: util.Ptr("ns")},
+			getErr:    gatewayerrors.NewUnavailable(errors.New("unavailable")),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDatabase := &database.SparkApplicationDatabaseMock{
+				ListActiveSparkApplicationsFunc: func(ctx context.Context, clusterName string, createdBefore time.Time) ([]database.SparkApplication, error) {
+					assert.Equal(t, testCluster.Name, clusterName, "cluster name should be passed to the database")
+					assert.Equal(t, now.Add(-10*time.Minute), createdBefore, "grace period should be applied")
+					return []database.SparkApplication{tc.record}, nil
+				},
+				MarkSparkApplicationLostFunc: func(ctx context.Context, gatewayIdUid uuid.UUID, terminationTime time.Time) error {
+					assert.Equal(t, recordUid, gatewayIdUid, "record uid should be marked lost")
+					return nil
+				},
+				UpdateSparkApplicationFunc: func(ctx context.Context, gatewayIdUid uuid.UUID, updateSparkApp v1beta2.SparkApplication) error {
+					assert.Equal(t, recordUid, gatewayIdUid, "record uid should be updated")
+					return nil
+				},
+			}
+			mockRepo := &SparkApplicationRepositoryMock{
+				GetFunc: func(namespace string, name string) (*v1beta2.SparkApplication, error) {
+					return tc.sparkApp, tc.getErr
+				},
+			}
+
+			reconciler := NewReconciler(mockRepo, mockDatabase, testCluster, config.DatabaseReconcilerConfig{
+				Interval:    time.Minute,
+				GracePeriod: 10 * time.Minute,
+			})
+			reconciler.now = func() time.Time { return now }
+
+			err := reconciler.Reconcile(context.Background())
+
+			if tc.expectErr {
+				assert.Error(t, err, "Reconcile should return error")
+			} else {
+				assert.NoError(t, err, "Reconcile should not return error")
+			}
+			assert.Equal(t, tc.expectLost, len(mockDatabase.MarkSparkApplicationLostCalls()) == 1, "unexpected MarkSparkApplicationLost calls")
+			assert.Equal(t, tc.expectUpdate, len(mockDatabase.UpdateSparkApplicationCalls()) == 1, "unexpected UpdateSparkApplication calls")
+		})
+	}
+}
+
+func TestReconcilerReconcileListError(t *testing.T) {
+	mockDatabase := &database.SparkApplicationDatabaseMock{
+		ListActiveSparkApplicationsFunc: func(ctx context.Context, clusterName string, createdBefore time.Time) ([]database.SparkApplication, error) {
+			return nil, errors.New("database error")
+		},
+	}
+
+	reconciler := NewReconciler(&SparkApplicationRepositoryMock{}, mockDatabase, testCluster, config.DatabaseReconcilerConfig{})
+
+	err := reconciler.Reconcile(context.Background())
+
+	assert.ErrorContains(t, err, "database error", "list error should be returned")
+}
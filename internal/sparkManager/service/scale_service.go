@@ -0,0 +1,174 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1Lister "k8s.io/client-go/listers/core/v1"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/metrics"
+)
+
+//go:generate moq -rm -out mockexecutorscaler.go . ExecutorScaler
+
+// ExecutorScaler patches the executor count of SparkApplications. It is implemented by backends that can resize
+// running SparkApplications.
+type ExecutorScaler interface {
+	ScaleExecutors(ctx context.Context, namespace string, name string, scale domain.ExecutorScale) (*v1beta2.SparkApplication, error)
+}
+
+// LatestReader is implemented by SparkApplicationRepositories that serve Get from a cache and can also read
+// SparkApplications from the cluster, including those the cache doesn't hold if ctx overrides the selector check
+type LatestReader interface {
+	GetLatest(ctx context.Context, namespace string, name string) (*v1beta2.SparkApplication, error)
+}
+
+//go:generate moq -rm -out mocksparkapplicationscaleservice.go . SparkApplicationScaleService
+
+type SparkApplicationScaleService interface {
+	Scale(ctx context.Context, namespace string, name string, scale domain.ExecutorScale) (*v1beta2.SparkApplication, error)
+}
+
+// executorQuotaResources are the ResourceQuota resources that additional executors consume
+var executorQuotaResources = []corev1.ResourceName{
+	corev1.ResourcePods,
+	corev1.ResourceName("count/pods"),
+	corev1.ResourceCPU,
+	corev1.ResourceRequestsCPU,
+	corev1.ResourceLimitsCPU,
+}
+
+type ScaleService struct {
+	sparkApplicationRepository SparkApplicationRepository
+	scaler                     ExecutorScaler
+	quotaLister                corev1Lister.ResourceQuotaLister
+}
+
+// NewScaleService returns a SparkApplicationScaleService scaling executors through scaler, or nil if scaler is nil
+// because the cluster's backend cannot scale SparkApplications. quotaLister may be nil, in which case scaling up isn't
+// checked against the namespace's ResourceQuotas.
+func NewScaleService(sparkAppRepo SparkApplicationRepository, scaler ExecutorScaler, quotaLister corev1Lister.ResourceQuotaLister) SparkApplicationScaleService {
+	if scaler == nil {
+		return nil
+	}
+
+	return &ScaleService{sparkApplicationRepository: sparkAppRepo, scaler: scaler, quotaLister: quotaLister}
+}
+
+// Scale sets the executor count of a running SparkApplication. Applications with dynamic allocation enabled can only
+// have their maxExecutors changed, others only their instances. Scaling up is rejected if the additional executors
+// don't fit in the remaining capacity of the namespace's ResourceQuotas, scaling down is always allowed.
+func (s *ScaleService) Scale(ctx context.Context, namespace string, name string, scale domain.ExecutorScale) (*v1beta2.SparkApplication, error) {
+
+	if err := scale.Validate(); err != nil {
+		return nil, gatewayerrors.NewBadRequest(err)
+	}
+
+	sparkApp, err := s.get(ctx, namespace, name)
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(err)
+	}
+
+	if sparkApp.Status.AppState.State != v1beta2.ApplicationStateRunning {
+		return nil, gatewayerrors.NewConflict(fmt.Errorf("SparkApplication '%s/%s' is in state '%s', only running SparkApplications can be scaled", namespace, name, sparkApp.Status.AppState.State))
+	}
+
+	var currentCount float64
+	if metrics.IsDynamicAllocationEnabled(sparkApp.Spec.DynamicAllocation, sparkApp.Spec.SparkConf) {
+		if scale.MaxExecutors == nil {
+			return nil, gatewayerrors.NewBadRequest(fmt.Errorf("SparkApplication '%s/%s' has dynamic allocation enabled, set 'maxExecutors' instead of 'instances'", namespace, name))
+		}
+		currentCount = metrics.ParseDynamicAllocExecutorCount(sparkApp.Spec.DynamicAllocation, sparkApp.Spec.SparkConf)
+		// Without maxExecutors dynamic allocation is unbounded, so any limit scales the application down
+		if currentCount == 0 {
+			currentCount = math.Inf(1)
+		}
+	} else {
+		if scale.Instances == nil {
+			return nil, gatewayerrors.NewBadRequest(fmt.Errorf("SparkApplication '%s/%s' doesn't have dynamic allocation enabled, set 'instances' instead of 'maxExecutors'", namespace, name))
+		}
+		currentCount = metrics.ParseExecutorCount(sparkApp.Spec.Executor.Instances, sparkApp.Spec.SparkConf)
+	}
+
+	if additional := float64(scale.Count()) - currentCount; additional > 0 {
+		if err := s.checkQuota(sparkApp, int64(additional)); err != nil {
+			return nil, err
+		}
+	}
+
+	scaledApp, err := s.scaler.ScaleExecutors(ctx, namespace, name, scale)
+	if err != nil {
+		return nil, gatewayerrors.NewFrom(err)
+	}
+
+	return scaledApp, nil
+}
+
+// get returns the SparkApplication namespace/name. SparkApplications without the selector labels aren't cached, so they
+// are read from the cluster when an admin overrides the selector check.
+func (s *ScaleService) get(ctx context.Context, namespace string, name string) (*v1beta2.SparkApplication, error) {
+	if reader, ok := s.sparkApplicationRepository.(LatestReader); ok && domain.IsSelectorOverride(ctx) {
+		return reader.GetLatest(ctx, namespace, name)
+	}
+
+	return s.sparkApplicationRepository.Get(namespace, name)
+}
+
+// checkQuota returns a Forbidden GatewayError if any ResourceQuota in the SparkApplication's namespace doesn't have
+// enough pods or CPU left for additional executors
+func (s *ScaleService) checkQuota(sparkApp *v1beta2.SparkApplication, additional int64) error {
+	if s.quotaLister == nil {
+		return nil
+	}
+
+	quotas, err := s.quotaLister.ResourceQuotas(sparkApp.Namespace).List(labels.Everything())
+	if err != nil {
+		return gatewayerrors.NewInternal(fmt.Errorf("error listing ResourceQuotas in namespace '%s': %w", sparkApp.Namespace, err))
+	}
+
+	additionalCPU := float64(additional) * metrics.GetExecutorCores(sparkApp)
+	for _, quota := range quotas {
+		for _, resourceName := range executorQuotaResources {
+			hard, ok := quota.Status.Hard[resourceName]
+			if !ok {
+				continue
+			}
+
+			needed := *resource.NewQuantity(additional, resource.DecimalSI)
+			if resourceName != corev1.ResourcePods && resourceName != "count/pods" {
+				needed = *resource.NewMilliQuantity(int64(math.Ceil(additionalCPU*1000)), resource.DecimalSI)
+			}
+
+			remaining := hard.DeepCopy()
+			used := quota.Status.Used[resourceName]
+			remaining.Sub(used)
+			if remaining.Cmp(needed) < 0 {
+				return gatewayerrors.NewForbidden(fmt.Errorf("scaling SparkApplication '%s/%s' by %d executors needs %s more '%s' but ResourceQuota '%s' only has %s left", sparkApp.Namespace, sparkApp.Name, additional, needed.String(), resourceName, quota.Name, remaining.String()))
+			}
+		}
+	}
+
+	return nil
+}
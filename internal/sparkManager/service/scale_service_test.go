@@ -0,0 +1,146 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1Lister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+)
+
+func TestScaleServiceScale(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "compute"},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("20"), corev1.ResourcePods: resource.MustParse("100")},
+			Used: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("12"), corev1.ResourcePods: resource.MustParse("5")},
+		},
+	}
+	assert.NoError(t, indexer.Add(quota), "adding quota should not error")
+	quotaLister := corev1Lister.NewResourceQuotaLister(indexer)
+
+	staticApp := func(state v1beta2.ApplicationStateType) *v1beta2.SparkApplication {
+		return &v1beta2.SparkApplication{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "app"},
+			Spec: v1beta2.SparkApplicationSpec{
+				Executor: v1beta2.ExecutorSpec{Instances: util.Ptr(int32(4)), SparkPodSpec: v1beta2.SparkPodSpec{Cores: util.Ptr(int32(2))}},
+			},
+			Status: v1beta2.SparkApplicationStatus{AppState: v1beta2.ApplicationState{State: state}},
+		}
+	}
+	dynamicApp := staticApp(v1beta2.ApplicationStateRunning)
+	dynamicApp.Spec.DynamicAllocation = &v1beta2.DynamicAllocation{Enabled: true, MaxExecutors: util.Ptr(int32(10))}
+
+	tests := []struct {
+		name           string
+		app            *v1beta2.SparkApplication
+		scale          domain.ExecutorScale
+		expectedStatus int
+		expectedCalls  int
+	}{
+		{name: "scale up within quota", app: staticApp(v1beta2.ApplicationStateRunning), scale: domain.ExecutorScale{Instances: util.Ptr(int32(8))}, expectedCalls: 1},
+		{name: "scale up beyond quota", app: staticApp(v1beta2.ApplicationStateRunning), scale: domain.ExecutorScale{Instances: util.Ptr(int32(9))}, expectedStatus: http.StatusForbidden},
+		{name: "scale down ignores quota", app: staticApp(v1beta2.ApplicationStateRunning), scale: domain.ExecutorScale{Instances: util.Ptr(int32(1))}, expectedCalls: 1},
+		{name: "dynamic allocation scale down", app: dynamicApp, scale: domain.ExecutorScale{MaxExecutors: util.Ptr(int32(2))}, expectedCalls: 1},
+		{name: "dynamic allocation requires maxExecutors", app: dynamicApp, scale: domain.ExecutorScale{Instances: util.Ptr(int32(2))}, expectedStatus: http.StatusBadRequest},
+		{name: "static allocation requires instances", app: staticApp(v1beta2.ApplicationStateRunning), scale: domain.ExecutorScale{MaxExecutors: util.Ptr(int32(2))}, expectedStatus: http.StatusBadRequest},
+		{name: "not running", app: staticApp(v1beta2.ApplicationStateSubmitted), scale: domain.ExecutorScale{Instances: util.Ptr(int32(1))}, expectedStatus: http.StatusConflict},
+		{name: "invalid scale", app: staticApp(v1beta2.ApplicationStateRunning), scale: domain.ExecutorScale{}, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repo := &SparkApplicationRepositoryMock{
+				GetFunc: func(namespace string, name string) (*v1beta2.SparkApplication, error) {
+					return test.app, nil
+				},
+			}
+			scaler := &ExecutorScalerMock{
+				ScaleExecutorsFunc: func(ctx context.Context, namespace string, name string, scale domain.ExecutorScale) (*v1beta2.SparkApplication, error) {
+					return test.app, nil
+				},
+			}
+
+			_, err := NewScaleService(repo, scaler, quotaLister).Scale(context.Background(), "team-a", "app", test.scale)
+
+			if test.expectedStatus != 0 {
+				assert.Error(t, err, "scaling should error")
+				assert.Equal(t, test.expectedStatus, gatewayerrors.NewFrom(err).Status, "status should match")
+			} else {
+				assert.NoError(t, err, "scaling should not error")
+			}
+			assert.Len(t, scaler.ScaleExecutorsCalls(), test.expectedCalls, "scaler calls should match")
+		})
+	}
+}
+
+// latestReaderRepository is a SparkApplicationRepository whose cache doesn't hold SparkApplications without the selector
+type latestReaderRepository struct {
+	*SparkApplicationRepositoryMock
+	latest *v1beta2.SparkApplication
+}
+
+func (r *latestReaderRepository) GetLatest(ctx context.Context, namespace string, name string) (*v1beta2.SparkApplication, error) {
+	return r.latest, nil
+}
+
+func TestScaleServiceSelectorOverride(t *testing.T) {
+	running := &v1beta2.SparkApplication{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "app"},
+		Spec:       v1beta2.SparkApplicationSpec{Executor: v1beta2.ExecutorSpec{Instances: util.Ptr(int32(4))}},
+		Status:     v1beta2.SparkApplicationStatus{AppState: v1beta2.ApplicationState{State: v1beta2.ApplicationStateRunning}},
+	}
+	repo := &latestReaderRepository{
+		SparkApplicationRepositoryMock: &SparkApplicationRepositoryMock{
+			GetFunc: func(namespace string, name string) (*v1beta2.SparkApplication, error) {
+				return nil, gatewayerrors.NewNotFound(fmt.Errorf("SparkApplication '%s/%s' not found", namespace, name))
+			},
+		},
+		latest: running,
+	}
+	scaler := &ExecutorScalerMock{
+		ScaleExecutorsFunc: func(ctx context.Context, namespace string, name string, scale domain.ExecutorScale) (*v1beta2.SparkApplication, error) {
+			return running, nil
+		},
+	}
+	scaleService := NewScaleService(repo, scaler, nil)
+	scale := domain.ExecutorScale{Instances: util.Ptr(int32(2))}
+
+	_, err := scaleService.Scale(context.Background(), "team-a", "app", scale)
+	assert.Equal(t, http.StatusNotFound, gatewayerrors.NewFrom(err).Status, "uncached applications should not be found")
+
+	_, err = scaleService.Scale(domain.WithSelectorOverride(context.Background()), "team-a", "app", scale)
+	assert.NoError(t, err, "overriding the selector should read the application from the cluster")
+	assert.Len(t, scaler.ScaleExecutorsCalls(), 1, "only the overridden scale should reach the scaler")
+}
+
+func TestNewScaleServiceWithoutScaler(t *testing.T) {
+	assert.Nil(t, NewScaleService(&SparkApplicationRepositoryMock{}, nil, nil), "scale service should be nil without a scaler")
+}
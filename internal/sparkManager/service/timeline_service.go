@@ -0,0 +1,151 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+)
+
+//go:generate moq -rm -out mocksparkapplicationtimelineservice.go . SparkApplicationTimelineService
+
+type SparkApplicationTimelineService interface {
+	Timeline(ctx context.Context, namespace string, name string) ([]domain.TimelineEvent, error)
+}
+
+type TimelineService struct {
+	events database.SparkApplicationEventDatabase
+}
+
+// NewTimelineService returns a SparkApplicationTimelineService reading lifecycle events from db, or nil if db is nil
+// or doesn't store events.
+func NewTimelineService(db database.SparkApplicationDatabase) SparkApplicationTimelineService {
+	events, ok := db.(database.SparkApplicationEventDatabase)
+	if !ok {
+		return nil
+	}
+
+	return &TimelineService{events: events}
+}
+
+// Timeline returns the lifecycle events recorded for SparkApplication name in namespace, oldest first. It is served
+// from the database, so it is available after the SparkApplication has been deleted.
+func (s *TimelineService) Timeline(ctx context.Context, namespace string, name string) ([]domain.TimelineEvent, error) {
+	uid, err := domain.ParseGatewayIdUUID(name)
+	if err != nil {
+		return nil, gatewayerrors.NewBadRequest(err)
+	}
+
+	records, err := s.events.ListSparkApplicationEvents(ctx, *uid)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []domain.TimelineEvent{}
+	for _, record := range records {
+		if record.Namespace != namespace {
+			continue
+		}
+		event := domain.TimelineEvent{Time: record.EventTime, State: record.State}
+		if record.Message != nil {
+			event.Message = *record.Message
+		}
+		events = append(events, event)
+	}
+
+	if len(events) == 0 {
+		return nil, gatewayerrors.NewNotFound(fmt.Errorf("no events recorded for SparkApplication '%s/%s'", namespace, name))
+	}
+
+	return events, nil
+}
+
+// EventRetentionSweeper periodically deletes the lifecycle events of the cluster's SparkApplications that are older
+// than the eventRetention of their namespace. Namespaces with no retention keep their events forever.
+type EventRetentionSweeper struct {
+	events   database.SparkApplicationEventDatabase
+	cluster  domain.KubeCluster
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewEventRetentionSweeper returns an EventRetentionSweeper deleting expired events from db every interval, or nil if
+// db doesn't store events or no namespace of cluster has a retention.
+func NewEventRetentionSweeper(db database.SparkApplicationDatabase, cluster domain.KubeCluster, interval time.Duration) *EventRetentionSweeper {
+	events, ok := db.(database.SparkApplicationEventDatabase)
+	if !ok {
+		return nil
+	}
+
+	for _, namespace := range cluster.Namespaces {
+		if namespace.EventRetention > 0 {
+			return &EventRetentionSweeper{events: events, cluster: cluster, interval: interval, now: time.Now}
+		}
+	}
+
+	return nil
+}
+
+// Run calls Sweep every interval until ctx is done
+func (s *EventRetentionSweeper) Run(ctx context.Context) {
+	klog.Infof("Starting event retention sweeper with interval %s", s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			klog.Infof("Stopping event retention sweeper")
+			return
+		case <-ticker.C:
+			if err := s.Sweep(ctx); err != nil {
+				klog.Errorf("error sweeping expired events of cluster '%s': %v", s.cluster.Name, err)
+			}
+		}
+	}
+}
+
+// Sweep deletes the events of each namespace with a retention that were recorded before it
+func (s *EventRetentionSweeper) Sweep(ctx context.Context) error {
+	now := s.now()
+
+	var errs []error
+	for _, namespace := range s.cluster.Namespaces {
+		if namespace.EventRetention <= 0 {
+			continue
+		}
+
+		deleted, err := s.events.DeleteSparkApplicationEventsBefore(ctx, s.cluster.Name, namespace.Name, now.Add(-namespace.EventRetention))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if deleted > 0 {
+			klog.Infof("Deleted %d events older than %s in namespace '%s'", deleted, namespace.EventRetention, namespace.Name)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/database"
+	"github.com/slackhq/spark-gateway/internal/shared/gatewayerrors"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+)
+
+// eventDatabase stores SparkApplications and their events, like database.Database
+type eventDatabase struct {
+	database.SparkApplicationDatabaseMock
+	database.SparkApplicationEventDatabaseMock
+}
+
+func TestNewTimelineService(t *testing.T) {
+	assert.Nil(t, NewTimelineService(nil), "timelines should not be served without a database")
+	assert.Nil(t, NewTimelineService(&database.SparkApplicationDatabaseMock{}), "timelines should not be served by databases without events")
+	assert.NotNil(t, NewTimelineService(&eventDatabase{}), "timelines should be served by databases with events")
+}
+
+func TestTimelineServiceTimeline(t *testing.T) {
+	gatewayId := "clusterid-nsid-01982d11-c2c1-7c3d-8b2f-944ae7248434"
+	submitted := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	db := &eventDatabase{SparkApplicationEventDatabaseMock: database.SparkApplicationEventDatabaseMock{
+		ListSparkApplicationEventsFunc: func(ctx context.Context, gatewayIdUid uuid.UUID) ([]database.SparkApplicationEvent, error) {
+			assert.Equal(t, "01982d11-c2c1-7c3d-8b2f-944ae7248434", gatewayIdUid.String(), "events should be listed by GatewayId UUID")
+			return []database.SparkApplicationEvent{
+				{Namespace: "ns", EventTime: submitted, State: "SUBMITTED"},
+				{Namespace: "ns", EventTime: submitted.Add(time.Minute), State: "FAILED", Message: util.Ptr("driver OOMKilled")},
+			}, nil
+		},
+	}}
+	service := NewTimelineService(db)
+
+	events, err := service.Timeline(context.Background(), "ns", gatewayId)
+	assert.NoError(t, err, "timeline should not error")
+	assert.Equal(t, []domain.TimelineEvent{
+		{Time: submitted, State: "SUBMITTED"},
+		{Time: submitted.Add(time.Minute), State: "FAILED", Message: "driver OOMKilled"},
+	}, events, "events should match")
+
+	_, err = service.Timeline(context.Background(), "other", gatewayId)
+	assert.Equal(t, http.StatusNotFound, gatewayerrors.NewFrom(err).Status, "events of other namespaces should not be returned")
+
+	_, err = service.Timeline(context.Background(), "ns", "spark-pi")
+	assert.Equal(t, http.StatusBadRequest, gatewayerrors.NewFrom(err).Status, "names that aren't GatewayIds should be rejected")
+}
+
+func TestEventRetentionSweeperSweep(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cluster := domain.KubeCluster{
+		Name: "cluster",
+		Namespaces: []domain.KubeNamespace{
+			{Name: "short", EventRetention: time.Hour},
+			{Name: "forever"},
+			{Name: "long", EventRetention: 24 * time.Hour},
+		},
+	}
+
+	deletedBefore := map[string]time.Time{}
+	db := &eventDatabase{SparkApplicationEventDatabaseMock: database.SparkApplicationEventDatabaseMock{
+		DeleteSparkApplicationEventsBeforeFunc: func(ctx context.Context, clusterName string, namespace string, before time.Time) (int64, error) {
+			assert.Equal(t, "cluster", clusterName, "only the cluster's events should be deleted")
+			deletedBefore[namespace] = before
+			return 1, nil
+		},
+	}}
+
+	assert.Nil(t, NewEventRetentionSweeper(db, domain.KubeCluster{Namespaces: []domain.KubeNamespace{{Name: "forever"}}}, time.Hour), "clusters keeping events forever should not be swept")
+
+	sweeper := NewEventRetentionSweeper(db, cluster, time.Hour)
+	sweeper.now = func() time.Time { return now }
+
+	assert.NoError(t, sweeper.Sweep(context.Background()), "sweep should not error")
+	assert.Equal(t, map[string]time.Time{
+		"short": now.Add(-time.Hour),
+		"long":  now.Add(-24 * time.Hour),
+	}, deletedBefore, "events should be deleted past their namespace's retention")
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/api"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/backend"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
+)
+
+// NewFakeSparkManager returns an HTTP server listening on addr with SparkManager's API for cluster, backed by the
+// local mode in-memory backend instead of a Kubernetes cluster, so a Gateway can be load tested without the cost of
+// running SparkApplications. Every backend call waits latency. ctx stops the backend's state transitions.
+func NewFakeSparkManager(ctx context.Context, addr string, cluster string, latency time.Duration) (*http.Server, error) {
+	sgConfig := &config.SparkGatewayConfig{Mode: config.ModeLocal}
+	config.ApplyDefaults(&sgConfig.SparkManagerConfig)
+	sgConfig.SparkManagerConfig.Local.Latency = latency
+
+	kubeCluster := domain.KubeCluster{Name: cluster}
+	repo, err := backend.New(ctx, backend.Params{Config: sgConfig, Cluster: kubeCluster})
+	if err != nil {
+		return nil, err
+	}
+	appService := service.NewSparkApplicationService(repo, nil, kubeCluster)
+
+	router, err := api.NewRouter(sgConfig, service.AllNamespaces(), appService, nil, nil, nil, nil, nil, nil, nil, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Server{Addr: addr, Handler: router}, nil
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+)
+
+// Operations exercised for every fixture, in order
+const (
+	FixtureCreate = "create"
+	FixtureGet    = "get"
+	FixtureLogs   = "logs"
+	FixtureDelete = "delete"
+)
+
+var fixtureOperations = []string{FixtureCreate, FixtureGet, FixtureLogs, FixtureDelete}
+
+// Fixture is a representative SparkApplication exercised against a Gateway
+type Fixture struct {
+	Name             string
+	SparkApplication *v1beta2.SparkApplication
+}
+
+// Fixtures returns the representative SparkApplications submitted in fixtures mode
+func Fixtures() []Fixture {
+	java := getTestSparkApp()
+	java.Spec.Type = v1beta2.SparkApplicationTypeJava
+	java.Spec.DynamicAllocation = nil
+
+	python := getTestSparkApp()
+	pythonFile := "local:///opt/spark/job.py"
+	python.Spec.Type = v1beta2.SparkApplicationTypePython
+	python.Spec.MainApplicationFile = &pythonFile
+	python.Spec.MainClass = nil
+	python.Spec.Deps = v1beta2.Dependencies{PyFiles: []string{"local:///opt/spark/deps.zip"}}
+	python.Spec.DynamicAllocation = nil
+
+	dynamicAllocation := getTestSparkApp()
+	dynamicAllocation.Spec.SparkConf["spark.dynamicAllocation.shuffleTracking.enabled"] = "true"
+
+	gpu := getTestSparkApp()
+	gpu.Spec.DynamicAllocation = nil
+	gpu.Spec.Driver.GPU = &v1beta2.GPUSpec{Name: "nvidia.com/gpu", Quantity: 1}
+	gpu.Spec.Executor.GPU = &v1beta2.GPUSpec{Name: "nvidia.com/gpu", Quantity: 1}
+	gpu.Spec.SparkConf["spark.executor.resource.gpu.amount"] = "1"
+	gpu.Spec.SparkConf["spark.task.resource.gpu.amount"] = "1"
+
+	// Thousands of conf entries, as generated by job templating, to catch size limits along the way
+	largeConf := getTestSparkApp()
+	largeConf.Spec.HadoopConf = map[string]string{}
+	for i := range 2000 {
+		largeConf.Spec.SparkConf[fmt.Sprintf("spark.fixture.conf.key%04d", i)] = fmt.Sprintf("value-%04d-%s", i, "abcdefghijklmnopqrstuvwxyz")
+		largeConf.Spec.HadoopConf[fmt.Sprintf("fs.fixture.key%04d", i)] = fmt.Sprintf("value-%04d", i)
+	}
+
+	return []Fixture{
+		{Name: "java", SparkApplication: java},
+		{Name: "python", SparkApplication: python},
+		{Name: "dynamic-allocation", SparkApplication: dynamicAllocation},
+		{Name: "gpu", SparkApplication: gpu},
+		{Name: "large-conf", SparkApplication: largeConf},
+	}
+}
+
+// FixturesConfig configures a fixtures run against a Gateway
+type FixturesConfig struct {
+	GatewayURL string
+	User       string
+	// Namespace the fixtures are submitted to, the test application's namespace when unset
+	Namespace string
+	// Fixtures limits the run to the named fixtures, all of them when empty
+	Fixtures []string
+	// Timeout bounds each request
+	Timeout time.Duration
+}
+
+// FixtureResult is the outcome of one operation on a fixture. Skipped operations weren't sent because the fixture
+// couldn't be created.
+type FixtureResult struct {
+	Passed     bool
+	Skipped    bool
+	StatusCode int
+	Error      string
+}
+
+// FixtureReport is the compatibility matrix of a fixtures run, the result of every operation on every fixture
+type FixtureReport struct {
+	Fixtures []string
+	Results  map[string]map[string]FixtureResult
+}
+
+// Passed is true when every operation on every fixture passed
+func (r FixtureReport) Passed() bool {
+	for _, results := range r.Results {
+		for _, result := range results {
+			if !result.Passed {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Write writes r as a table with a row per fixture and a column per operation to w, followed by the errors
+func (r FixtureReport) Write(w io.Writer) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(table, "FIXTURE\tCREATE\tGET\tLOGS\tDELETE\n")
+	var failures []string
+	for _, fixture := range r.Fixtures {
+		fmt.Fprintf(table, "%s", fixture)
+		for _, operation := range fixtureOperations {
+			result := r.Results[fixture][operation]
+			cell := "ok"
+			switch {
+			case result.Skipped:
+				cell = "skipped"
+			case !result.Passed:
+				cell = "FAIL"
+				failures = append(failures, fmt.Sprintf("%s %s: %s", fixture, operation, result.Error))
+			}
+			if result.StatusCode != 0 {
+				cell = fmt.Sprintf("%s (%d)", cell, result.StatusCode)
+			}
+			fmt.Fprintf(table, "\t%s", cell)
+		}
+		fmt.Fprintf(table, "\n")
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	for _, failure := range failures {
+		fmt.Fprintln(w, failure)
+	}
+	return nil
+}
+
+// RunFixtures creates, gets, reads the logs of and deletes every fixture through the Gateway, one fixture at a time,
+// and reports the outcome of each operation. Logs pass with a 404 since the driver may not have started yet. A fixture
+// that was created is always deleted, even if getting it failed.
+func RunFixtures(ctx context.Context, config FixturesConfig) (FixtureReport, error) {
+	fixtures := Fixtures()
+	for _, name := range config.Fixtures {
+		if !slices.ContainsFunc(fixtures, func(f Fixture) bool { return f.Name == name }) {
+			return FixtureReport{}, fmt.Errorf("unknown fixture '%s'", name)
+		}
+	}
+
+	client := &http.Client{Timeout: config.Timeout}
+	report := FixtureReport{Results: map[string]map[string]FixtureResult{}}
+	for _, fixture := range fixtures {
+		if len(config.Fixtures) > 0 && !slices.Contains(config.Fixtures, fixture.Name) {
+			continue
+		}
+		if config.Namespace != "" {
+			fixture.SparkApplication.Namespace = config.Namespace
+		}
+
+		report.Fixtures = append(report.Fixtures, fixture.Name)
+		report.Results[fixture.Name] = runFixture(ctx, client, config, fixture)
+	}
+
+	return report, nil
+}
+
+func runFixture(ctx context.Context, client *http.Client, config FixturesConfig, fixture Fixture) map[string]FixtureResult {
+	results := map[string]FixtureResult{}
+
+	body, err := json.Marshal(fixture.SparkApplication)
+	if err != nil {
+		results[FixtureCreate] = FixtureResult{Error: err.Error()}
+	} else {
+		var created domain.GatewayApplication
+		results[FixtureCreate] = fixtureRequest(ctx, client, config, http.MethodPost, "/api/v1/applications", body, &created, func(result FixtureResult) bool {
+			return result.StatusCode == http.StatusCreated && created.GatewayId != ""
+		})
+		if results[FixtureCreate].Passed {
+			applicationPath := "/api/v1/applications/" + created.GatewayId
+
+			var got domain.GatewayApplication
+			results[FixtureGet] = fixtureRequest(ctx, client, config, http.MethodGet, applicationPath, nil, &got, func(result FixtureResult) bool {
+				return result.StatusCode == http.StatusOK && got.SparkApplication.Spec.Type == fixture.SparkApplication.Spec.Type
+			})
+			results[FixtureLogs] = fixtureRequest(ctx, client, config, http.MethodGet, applicationPath+"/logs", nil, nil, func(result FixtureResult) bool {
+				return result.StatusCode == http.StatusOK || result.StatusCode == http.StatusNotFound
+			})
+			results[FixtureDelete] = fixtureRequest(ctx, client, config, http.MethodDelete, applicationPath, nil, nil, func(result FixtureResult) bool {
+				return result.StatusCode == http.StatusOK
+			})
+			return results
+		}
+	}
+
+	for _, operation := range fixtureOperations[1:] {
+		results[operation] = FixtureResult{Skipped: true}
+	}
+	return results
+}
+
+// fixtureRequest sends a request to the Gateway, decoding the response into out if set, and passes it if passed returns
+// true for the response
+func fixtureRequest(ctx context.Context, client *http.Client, config FixturesConfig, method string, path string, body []byte, out any, passed func(FixtureResult) bool) FixtureResult {
+	request, err := http.NewRequestWithContext(ctx, method, config.GatewayURL+path, bytes.NewReader(body))
+	if err != nil {
+		return FixtureResult{Error: err.Error()}
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(AddBasicAuth(request, config.User))
+	if err != nil {
+		return FixtureResult{Error: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	result := FixtureResult{StatusCode: resp.StatusCode}
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if out != nil && resp.StatusCode < 300 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			result.Error = fmt.Sprintf("error decoding response: %v", err)
+			return result
+		}
+	}
+
+	result.Passed = passed(result)
+	if !result.Passed {
+		result.Error = string(bytes.TrimSpace(respBody))
+		if result.Error == "" {
+			result.Error = "unexpected response"
+		}
+	}
+	return result
+}
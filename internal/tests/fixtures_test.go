@@ -0,0 +1,81 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunFixtures(t *testing.T) {
+	// The Gateway rejects GPU applications and has no drivers to read logs from
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			var sparkApp v1beta2.SparkApplication
+			json.NewDecoder(r.Body).Decode(&sparkApp)
+			if sparkApp.Spec.Driver.GPU != nil {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				w.Write([]byte(`{"error":"gpu not allowed"}`))
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"gatewayId":"clus-nspc-0198","sparkApplication":{"spec":{"type":"` + string(sparkApp.Spec.Type) + `"}}}`))
+		case strings.HasSuffix(r.URL.Path, "/logs"):
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{"gatewayId":"clus-nspc-0198","sparkApplication":{"spec":{"type":"Python"}}}`))
+		case r.Method == http.MethodDelete:
+			w.Write([]byte(`{"status":"success"}`))
+		}
+	}))
+	defer gateway.Close()
+
+	report, err := RunFixtures(context.Background(), FixturesConfig{
+		GatewayURL: gateway.URL,
+		User:       "admin",
+		Fixtures:   []string{"python", "gpu", "java"},
+		Timeout:    time.Second,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"java", "python", "gpu"}, report.Fixtures, "requested fixtures should run in order")
+	assert.False(t, report.Passed(), "report should fail")
+	assert.True(t, report.Results["python"][FixtureGet].Passed, "python get should pass")
+	assert.True(t, report.Results["python"][FixtureLogs].Passed, "logs without a driver should pass")
+	assert.False(t, report.Results["java"][FixtureGet].Passed, "get returning another type should fail")
+	assert.True(t, report.Results["java"][FixtureDelete].Passed, "created fixtures should be deleted")
+	assert.Equal(t, FixtureResult{StatusCode: http.StatusUnprocessableEntity, Error: `{"error":"gpu not allowed"}`}, report.Results["gpu"][FixtureCreate], "gpu create should fail")
+	assert.True(t, report.Results["gpu"][FixtureDelete].Skipped, "fixtures not created should be skipped")
+
+	var out bytes.Buffer
+	require.NoError(t, report.Write(&out))
+	assert.Contains(t, out.String(), "FAIL (422)", "matrix should show the failed create")
+	assert.Contains(t, out.String(), `gpu create: {"error":"gpu not allowed"}`, "errors should be listed")
+
+	_, err = RunFixtures(context.Background(), FixturesConfig{Fixtures: []string{"missing"}})
+	assert.Error(t, err, "unknown fixtures should be rejected")
+}
@@ -0,0 +1,323 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"k8s.io/klog/v2"
+)
+
+// Load generated operations
+const (
+	OperationSubmit = "submit"
+	OperationStatus = "status"
+	OperationList   = "list"
+)
+
+// LoadConfig configures a load run against a Gateway. Each operation is sent at its own rate, and operations with a
+// rate of 0 aren't sent.
+type LoadConfig struct {
+	GatewayURL string
+	User       string
+	Cluster    string
+	Namespace  string
+	SubmitRPS  float64
+	StatusRPS  float64
+	ListRPS    float64
+	Duration   time.Duration
+	// Timeout bounds each request
+	Timeout time.Duration
+	// Cleanup deletes the applications submitted during the run once it ends
+	Cleanup bool
+}
+
+// OperationReport summarizes the requests sent for an operation during a load run
+type OperationReport struct {
+	Operation string
+	Requests  int
+	// Errors counts requests that failed or returned a non 2xx status
+	Errors      int
+	StatusCodes map[int]int
+	RPS         float64
+	P50         time.Duration
+	P90         time.Duration
+	P99         time.Duration
+	Max         time.Duration
+}
+
+// LoadReport summarizes a load run
+type LoadReport struct {
+	Duration   time.Duration
+	Operations []OperationReport
+}
+
+// Write writes r as a table to w
+func (r LoadReport) Write(w io.Writer) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(table, "OPERATION\tREQUESTS\tERRORS\tRPS\tP50\tP90\tP99\tMAX\tSTATUS CODES\n")
+	for _, op := range r.Operations {
+		codes := ""
+		for _, code := range slices.Sorted(maps.Keys(op.StatusCodes)) {
+			codes += fmt.Sprintf("%d=%d ", code, op.StatusCodes[code])
+		}
+		fmt.Fprintf(table, "%s\t%d\t%d\t%.1f\t%s\t%s\t%s\t%s\t%s\n", op.Operation, op.Requests, op.Errors, op.RPS,
+			op.P50.Round(time.Millisecond), op.P90.Round(time.Millisecond), op.P99.Round(time.Millisecond), op.Max.Round(time.Millisecond), codes)
+	}
+	fmt.Fprintf(table, "\nDuration: %s\n", r.Duration.Round(time.Millisecond))
+	return table.Flush()
+}
+
+// loadRun holds the state shared by the operations of a load run
+type loadRun struct {
+	config LoadConfig
+	client *http.Client
+
+	lock       sync.Mutex
+	latencies  map[string][]time.Duration
+	errors     map[string]int
+	codes      map[string]map[int]int
+	gatewayIds []string
+}
+
+// RunLoad sends submit, status and list requests to the Gateway at the rates of config until config.Duration elapses
+// or ctx is cancelled, and reports their latencies and errors. Status requests ask for the applications submitted
+// during the run, so they only start once a submit succeeded.
+func RunLoad(ctx context.Context, config LoadConfig) (LoadReport, error) {
+	if config.SubmitRPS < 0 || config.StatusRPS < 0 || config.ListRPS < 0 {
+		return LoadReport{}, fmt.Errorf("request rates must not be negative")
+	}
+	if config.StatusRPS > 0 && config.SubmitRPS == 0 {
+		return LoadReport{}, fmt.Errorf("status requests need a submit rate to have applications to ask for")
+	}
+	if config.ListRPS > 0 && config.Cluster == "" {
+		return LoadReport{}, fmt.Errorf("list requests need a cluster")
+	}
+
+	run := &loadRun{
+		config:    config,
+		client:    &http.Client{Timeout: config.Timeout},
+		latencies: map[string][]time.Duration{},
+		errors:    map[string]int{},
+		codes:     map[string]map[int]int{},
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, config.Duration)
+	defer cancel()
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for operation, rps := range map[string]float64{OperationSubmit: config.SubmitRPS, OperationStatus: config.StatusRPS, OperationList: config.ListRPS} {
+		if rps == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			run.generate(runCtx, &wg, operation, rps)
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	if config.Cleanup {
+		run.cleanup(ctx)
+	}
+
+	return run.report(elapsed), nil
+}
+
+// generate sends operation rps times a second until ctx is done, without waiting for responses so slow responses
+// don't lower the rate
+func (r *loadRun) generate(ctx context.Context, wg *sync.WaitGroup, operation string, rps float64) {
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / rps))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			request, err := r.newRequest(ctx, operation)
+			if err != nil {
+				klog.Errorf("error creating %s request: %v", operation, err)
+				continue
+			}
+			if request == nil {
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				r.send(operation, request)
+			}()
+		}
+	}
+}
+
+// newRequest returns the next request of operation, or nil if there's nothing to send yet
+func (r *loadRun) newRequest(ctx context.Context, operation string) (*http.Request, error) {
+	var request *http.Request
+	var err error
+	switch operation {
+	case OperationSubmit:
+		sparkApp := getTestSparkApp()
+		if r.config.Namespace != "" {
+			sparkApp.Namespace = r.config.Namespace
+		}
+		body, marshalErr := json.Marshal(sparkApp)
+		if marshalErr != nil {
+			return nil, marshalErr
+		}
+		request, err = http.NewRequestWithContext(ctx, http.MethodPost, r.config.GatewayURL+"/api/v1/applications", bytes.NewReader(body))
+	case OperationStatus:
+		gatewayId, ok := r.randomGatewayId()
+		if !ok {
+			return nil, nil
+		}
+		request, err = http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/api/v1/applications/%s/status", r.config.GatewayURL, gatewayId), nil)
+	case OperationList:
+		query := url.Values{"cluster": {r.config.Cluster}, "view": {"summary"}}
+		if r.config.Namespace != "" {
+			query.Set("namespace", r.config.Namespace)
+		}
+		request, err = http.NewRequestWithContext(ctx, http.MethodGet, r.config.GatewayURL+"/api/v1/applications?"+query.Encode(), nil)
+	default:
+		return nil, fmt.Errorf("unknown operation '%s'", operation)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	return AddBasicAuth(request, r.config.User), nil
+}
+
+// send sends request and records its latency and status under operation. Requests cancelled because the run ended
+// aren't recorded.
+func (r *loadRun) send(operation string, request *http.Request) {
+	start := time.Now()
+	resp, err := r.client.Do(request)
+	latency := time.Since(start)
+	if err != nil && request.Context().Err() != nil {
+		return
+	}
+
+	var body []byte
+	if err == nil {
+		body, _ = io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.latencies[operation] = append(r.latencies[operation], latency)
+	if err != nil {
+		r.errors[operation]++
+		return
+	}
+	if r.codes[operation] == nil {
+		r.codes[operation] = map[int]int{}
+	}
+	r.codes[operation][resp.StatusCode]++
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		r.errors[operation]++
+		return
+	}
+
+	if operation == OperationSubmit {
+		var sparkApp v1beta2.SparkApplication
+		if err := json.Unmarshal(body, &sparkApp); err == nil && sparkApp.Name != "" {
+			r.gatewayIds = append(r.gatewayIds, sparkApp.Name)
+		}
+	}
+}
+
+func (r *loadRun) randomGatewayId() (string, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.gatewayIds) == 0 {
+		return "", false
+	}
+	return r.gatewayIds[rand.IntN(len(r.gatewayIds))], true
+}
+
+// cleanup deletes the applications submitted during the run, logging the ones that couldn't be deleted
+func (r *loadRun) cleanup(ctx context.Context) {
+	for _, gatewayId := range r.gatewayIds {
+		request, err := http.NewRequestWithContext(ctx, http.MethodDelete, fmt.Sprintf("%s/api/v1/applications/%s", r.config.GatewayURL, gatewayId), nil)
+		if err != nil {
+			klog.Errorf("error creating delete request for '%s': %v", gatewayId, err)
+			continue
+		}
+		resp, err := r.client.Do(AddBasicAuth(request, r.config.User))
+		if err != nil {
+			klog.Errorf("error deleting '%s': %v", gatewayId, err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			klog.Errorf("error deleting '%s': status %d", gatewayId, resp.StatusCode)
+		}
+	}
+}
+
+func (r *loadRun) report(elapsed time.Duration) LoadReport {
+	report := LoadReport{Duration: elapsed}
+	for _, operation := range []string{OperationSubmit, OperationStatus, OperationList} {
+		latencies, ok := r.latencies[operation]
+		if !ok {
+			continue
+		}
+		slices.Sort(latencies)
+		report.Operations = append(report.Operations, OperationReport{
+			Operation:   operation,
+			Requests:    len(latencies),
+			Errors:      r.errors[operation],
+			StatusCodes: r.codes[operation],
+			RPS:         float64(len(latencies)) / elapsed.Seconds(),
+			P50:         percentile(latencies, 0.5),
+			P90:         percentile(latencies, 0.9),
+			P99:         percentile(latencies, 0.99),
+			Max:         latencies[len(latencies)-1],
+		})
+	}
+	return report
+}
+
+// percentile returns the p percentile of sorted latencies with the nearest rank method
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p*float64(len(sorted))+0.5) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentile(t *testing.T) {
+	sorted := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	assert.Equal(t, time.Duration(5), percentile(sorted, 0.5), "p50 should match")
+	assert.Equal(t, time.Duration(9), percentile(sorted, 0.9), "p90 should match")
+	assert.Equal(t, time.Duration(10), percentile(sorted, 0.99), "p99 should match")
+	assert.Equal(t, time.Duration(0), percentile(nil, 0.5), "empty latencies should have no percentile")
+}
+
+func TestRunLoad(t *testing.T) {
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			w.Write([]byte(`{"metadata":{"name":"clus-nspc-0198"}}`))
+		case strings.HasSuffix(r.URL.Path, "/status"):
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodDelete:
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer gateway.Close()
+
+	report, err := RunLoad(context.Background(), LoadConfig{
+		GatewayURL: gateway.URL,
+		User:       "admin",
+		Cluster:    "cluster",
+		SubmitRPS:  50,
+		StatusRPS:  50,
+		ListRPS:    50,
+		Duration:   300 * time.Millisecond,
+		Timeout:    time.Second,
+		Cleanup:    true,
+	})
+	require.NoError(t, err)
+
+	operations := map[string]OperationReport{}
+	for _, op := range report.Operations {
+		operations[op.Operation] = op
+	}
+
+	assert.Positive(t, operations[OperationSubmit].Requests, "submits should be sent")
+	assert.Zero(t, operations[OperationSubmit].Errors, "submits should succeed")
+	assert.Positive(t, operations[OperationStatus].Requests, "statuses of submitted applications should be sent")
+	assert.Equal(t, operations[OperationList].Requests, operations[OperationList].Errors, "failed lists should be counted as errors")
+	assert.Equal(t, operations[OperationList].Requests, operations[OperationList].StatusCodes[http.StatusServiceUnavailable], "list status codes should be counted")
+
+	var out bytes.Buffer
+	require.NoError(t, report.Write(&out))
+	assert.Contains(t, out.String(), "503=", "report should list status codes")
+}
+
+func TestRunLoadConfig(t *testing.T) {
+	_, err := RunLoad(context.Background(), LoadConfig{StatusRPS: 1})
+	assert.Error(t, err, "status requests without submits should be rejected")
+
+	_, err = RunLoad(context.Background(), LoadConfig{ListRPS: 1})
+	assert.Error(t, err, "list requests without a cluster should be rejected")
+}
+
+func TestFakeSparkManager(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server, err := NewFakeSparkManager(ctx, "", "cluster", 0)
+	require.NoError(t, err)
+
+	body := `{"metadata":{"name":"clus-nspc-01982d11-c2c1-7c3d-8b2f-944ae7248434","namespace":"default"}}`
+	w := httptest.NewRecorder()
+	server.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/default/clus-nspc-01982d11-c2c1-7c3d-8b2f-944ae7248434", strings.NewReader(body)))
+	assert.Equal(t, http.StatusCreated, w.Code, "create should succeed: %s", w.Body.String())
+
+	w = httptest.NewRecorder()
+	server.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/default/clus-nspc-01982d11-c2c1-7c3d-8b2f-944ae7248434/status", nil))
+	assert.Equal(t, http.StatusOK, w.Code, "status should succeed")
+	assert.Contains(t, w.Body.String(), `"SUBMITTED"`, "fake SparkApplications should be submitted")
+
+	w = httptest.NewRecorder()
+	server.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/default", nil))
+	assert.Equal(t, http.StatusOK, w.Code, "list should succeed")
+	assert.Contains(t, w.Body.String(), "clus-nspc-01982d11-c2c1-7c3d-8b2f-944ae7248434", "list should include the created SparkApplication")
+}
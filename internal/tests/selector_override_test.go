@@ -0,0 +1,81 @@
+// Copyright (c) 2025, Salesforce, Inc.
+// SPDX-License-Identifier: Apache-2
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubeflow/spark-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slackhq/spark-gateway/internal/domain"
+	"github.com/slackhq/spark-gateway/internal/gateway/repository"
+	"github.com/slackhq/spark-gateway/internal/shared/config"
+	"github.com/slackhq/spark-gateway/internal/shared/util"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/api"
+	"github.com/slackhq/spark-gateway/internal/sparkManager/service"
+)
+
+// TestSelectorOverride checks the selector override set for admins by the Gateway reaches SparkManager's services
+func TestSelectorOverride(t *testing.T) {
+	var overrides []bool
+	sparkApp := &v1beta2.SparkApplication{}
+	appService := &service.SparkApplicationServiceMock{
+		DeleteFunc: func(ctx context.Context, namespace string, name string) error {
+			overrides = append(overrides, domain.IsSelectorOverride(ctx))
+			return nil
+		},
+		UpdateFunc: func(ctx context.Context, namespace string, name string, update domain.ApplicationUpdate) (*v1beta2.SparkApplication, error) {
+			overrides = append(overrides, domain.IsSelectorOverride(ctx))
+			return sparkApp, nil
+		},
+	}
+	scaleService := &service.SparkApplicationScaleServiceMock{
+		ScaleFunc: func(ctx context.Context, namespace string, name string, scale domain.ExecutorScale) (*v1beta2.SparkApplication, error) {
+			overrides = append(overrides, domain.IsSelectorOverride(ctx))
+			return sparkApp, nil
+		},
+	}
+
+	sgConfig := &config.SparkGatewayConfig{}
+	config.ApplyDefaults(&sgConfig.SparkManagerConfig)
+	router, err := api.NewRouter(sgConfig, service.AllNamespaces(), appService, nil, scaleService, nil, nil, nil, nil, nil, "cluster")
+	require.NoError(t, err)
+	sparkManager := httptest.NewServer(router)
+	defer sparkManager.Close()
+
+	cluster := domain.KubeCluster{Name: "cluster"}
+	sparkManagerRepo := &repository.SparkManagerRepository{ClusterEndpoints: map[string]string{cluster.Name: sparkManager.URL + "/api/v1"}}
+
+	for _, override := range []bool{false, true} {
+		overrides = nil
+		ctx := context.Background()
+		if override {
+			ctx = domain.WithSelectorOverride(ctx)
+		}
+
+		assert.NoError(t, sparkManagerRepo.Delete(ctx, cluster, "team-a", "app"), "delete should not error")
+		_, err := sparkManagerRepo.Update(ctx, cluster, "team-a", "app", domain.ApplicationUpdate{TimeToLiveSeconds: util.Ptr(int64(60))})
+		assert.NoError(t, err, "update should not error")
+		_, err = sparkManagerRepo.Scale(ctx, cluster, "team-a", "app", domain.ExecutorScale{Instances: util.Ptr(int32(2))})
+		assert.NoError(t, err, "scale should not error")
+
+		assert.Equal(t, []bool{override, override, override}, overrides, "SparkManager should receive the override %t", override)
+	}
+}